@@ -130,6 +130,7 @@ func (m *ApplyRequest) GetCaCerts() map[string]string {
 type ApplyResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	OperationId          string   `protobuf:"bytes,3,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -174,6 +175,13 @@ func (m *ApplyResponse) GetStatus() string {
 	return ""
 }
 
+func (m *ApplyResponse) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("kubekit.v1.ApplyAction", ApplyAction_name, ApplyAction_value)
 	proto.RegisterType((*ApplyRequest)(nil), "kubekit.v1.ApplyRequest")
@@ -184,26 +192,27 @@ func init() {
 func init() { proto.RegisterFile("apply.proto", fileDescriptor_993661bab0ce9d1e) }
 
 var fileDescriptor_993661bab0ce9d1e = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0xcf, 0x4b, 0xc3, 0x30,
-	0x14, 0xb6, 0xed, 0xd6, 0x6d, 0xaf, 0xad, 0x94, 0x20, 0x5a, 0x76, 0xb1, 0x4e, 0x84, 0xe2, 0xa1,
-	0xb2, 0xe9, 0x41, 0x77, 0x72, 0x8e, 0x29, 0x83, 0xb1, 0x8d, 0xca, 0x3c, 0x78, 0x29, 0x59, 0x88,
-	0x32, 0xda, 0xb5, 0x35, 0x49, 0x07, 0xfd, 0xcf, 0xfc, 0xf3, 0xa4, 0x6d, 0x86, 0x03, 0xbd, 0xe5,
-	0xfb, 0xf1, 0xde, 0xc7, 0xf7, 0x02, 0x06, 0xce, 0xb2, 0xb8, 0xf0, 0x33, 0x96, 0x8a, 0x14, 0x41,
-	0x94, 0xaf, 0x69, 0xb4, 0x11, 0xfe, 0xae, 0xdf, 0xb5, 0x48, 0x9c, 0x73, 0x41, 0x59, 0x2d, 0xf5,
-	0xbe, 0x55, 0x30, 0x47, 0xa5, 0x35, 0xa0, 0x5f, 0x39, 0xe5, 0x02, 0xd9, 0xa0, 0xe1, 0x6c, 0xe3,
-	0x28, 0xae, 0xe2, 0x75, 0x82, 0xf2, 0x89, 0x2e, 0xc0, 0x94, 0x33, 0x61, 0x82, 0xb7, 0xd4, 0x51,
-	0x2b, 0xc9, 0x90, 0xdc, 0x1c, 0x6f, 0x29, 0xba, 0x01, 0x1d, 0x13, 0xb1, 0x49, 0x13, 0x47, 0x73,
-	0x15, 0xef, 0x78, 0x70, 0xe6, 0xff, 0x26, 0xfa, 0xd5, 0xfa, 0x51, 0x25, 0x07, 0xd2, 0x86, 0xce,
-	0xc1, 0xc8, 0x30, 0x89, 0xf0, 0x27, 0x0d, 0x73, 0x16, 0x3b, 0x8d, 0x6a, 0x25, 0x48, 0x6a, 0xc5,
-	0x62, 0x74, 0x09, 0xd6, 0x47, 0xca, 0x08, 0x0d, 0x25, 0xe7, 0x34, 0x5d, 0xc5, 0x6b, 0x07, 0x66,
-	0x45, 0x2e, 0x6b, 0x0e, 0x3d, 0x42, 0x9b, 0xe0, 0x90, 0x50, 0x26, 0xb8, 0xa3, 0xbb, 0x9a, 0x67,
-	0x0c, 0xae, 0xfe, 0x04, 0xcb, 0x5e, 0xfe, 0x18, 0x8f, 0x4b, 0xdf, 0x24, 0x11, 0xac, 0x08, 0x5a,
-	0xa4, 0x46, 0xdd, 0x21, 0x98, 0x87, 0x42, 0xd9, 0x3e, 0xa2, 0xc5, 0xbe, 0x7d, 0x44, 0x0b, 0x74,
-	0x02, 0xcd, 0x1d, 0x8e, 0xf3, 0x7d, 0xed, 0x1a, 0x0c, 0xd5, 0x7b, 0xa5, 0xf7, 0x00, 0x96, 0x4c,
-	0xe0, 0x59, 0x9a, 0x70, 0xfa, 0xcf, 0xe9, 0x4e, 0x41, 0xe7, 0x02, 0x8b, 0x9c, 0xcb, 0x69, 0x89,
-	0xae, 0xef, 0xc0, 0x38, 0xb8, 0x0a, 0x6a, 0x81, 0x36, 0x9a, 0xcd, 0xec, 0x23, 0x64, 0x41, 0x67,
-	0x19, 0x2c, 0xde, 0xa6, 0xaf, 0xd3, 0xc5, 0xdc, 0x56, 0x4a, 0x38, 0x5e, 0xcc, 0x9f, 0xa7, 0x2f,
-	0xab, 0x60, 0x62, 0xab, 0x4f, 0x8d, 0x77, 0x75, 0xd7, 0x5f, 0xeb, 0xd5, 0xc7, 0xdd, 0xfe, 0x04,
-	0x00, 0x00, 0xff, 0xff, 0xab, 0x3a, 0xe6, 0x27, 0xe2, 0x01, 0x00, 0x00,
+	// 351 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0x41, 0x6b, 0xa3, 0x40,
+	0x18, 0x5d, 0x35, 0x31, 0xc9, 0xa7, 0x2e, 0x32, 0x2c, 0xbb, 0x92, 0xcb, 0xda, 0x94, 0x82, 0xf4,
+	0x60, 0x49, 0xda, 0x43, 0xc9, 0xa9, 0x69, 0x48, 0x8b, 0x10, 0x92, 0x30, 0x25, 0x3d, 0x94, 0x82,
+	0x4c, 0xcc, 0xb4, 0x88, 0x46, 0xed, 0x38, 0x06, 0xfc, 0x67, 0xfd, 0x79, 0x45, 0x9d, 0xb4, 0x81,
+	0xf6, 0x36, 0xef, 0xbd, 0xef, 0xfb, 0x1e, 0xef, 0x0d, 0x68, 0x24, 0xcb, 0xe2, 0xd2, 0xcd, 0x58,
+	0xca, 0x53, 0x04, 0x51, 0xb1, 0xa1, 0x51, 0xc8, 0xdd, 0xfd, 0xb0, 0x6f, 0x04, 0x71, 0x91, 0x73,
+	0xca, 0x1a, 0x69, 0xf0, 0x2e, 0x83, 0x3e, 0xa9, 0x46, 0x31, 0x7d, 0x2b, 0x68, 0xce, 0x91, 0x09,
+	0x0a, 0xc9, 0x42, 0x4b, 0xb2, 0x25, 0xa7, 0x87, 0xab, 0x27, 0x3a, 0x01, 0x5d, 0xec, 0xf8, 0x09,
+	0xd9, 0x51, 0x4b, 0xae, 0x25, 0x4d, 0x70, 0x0b, 0xb2, 0xa3, 0xe8, 0x02, 0x54, 0x12, 0xf0, 0x30,
+	0x4d, 0x2c, 0xc5, 0x96, 0x9c, 0xdf, 0xa3, 0x7f, 0xee, 0x97, 0xa3, 0x5b, 0x9f, 0x9f, 0xd4, 0x32,
+	0x16, 0x63, 0xe8, 0x3f, 0x68, 0x19, 0x09, 0x22, 0xf2, 0x4a, 0xfd, 0x82, 0xc5, 0x56, 0xab, 0x3e,
+	0x09, 0x82, 0x5a, 0xb3, 0x18, 0x9d, 0x82, 0xf1, 0x92, 0xb2, 0x80, 0xfa, 0x82, 0xb3, 0xda, 0xb6,
+	0xe4, 0x74, 0xb1, 0x5e, 0x93, 0xab, 0x86, 0x43, 0x37, 0xd0, 0x0d, 0x88, 0x1f, 0x50, 0xc6, 0x73,
+	0x4b, 0xb5, 0x15, 0x47, 0x1b, 0x9d, 0x7d, 0x33, 0x16, 0xb9, 0xdc, 0x29, 0x99, 0x56, 0x73, 0xb3,
+	0x84, 0xb3, 0x12, 0x77, 0x82, 0x06, 0xf5, 0xc7, 0xa0, 0x1f, 0x0b, 0x55, 0xfa, 0x88, 0x96, 0x87,
+	0xf4, 0x11, 0x2d, 0xd1, 0x1f, 0x68, 0xef, 0x49, 0x5c, 0x1c, 0x62, 0x37, 0x60, 0x2c, 0x5f, 0x4b,
+	0x83, 0x67, 0x30, 0x84, 0x43, 0x9e, 0xa5, 0x49, 0x4e, 0x7f, 0xa8, 0xee, 0x2f, 0xa8, 0x39, 0x27,
+	0xbc, 0xc8, 0xc5, 0xb6, 0x40, 0x55, 0xa5, 0x69, 0x46, 0x19, 0xa9, 0xba, 0xf0, 0xc3, 0x6d, 0xdd,
+	0x5a, 0x0f, 0x6b, 0x9f, 0x9c, 0xb7, 0x3d, 0xbf, 0x02, 0xed, 0xa8, 0x38, 0xd4, 0x01, 0x65, 0x32,
+	0x9f, 0x9b, 0xbf, 0x90, 0x01, 0xbd, 0x15, 0x5e, 0x3e, 0x7a, 0x0f, 0xde, 0x72, 0x61, 0x4a, 0x15,
+	0x9c, 0x2e, 0x17, 0x77, 0xde, 0xfd, 0x1a, 0xcf, 0x4c, 0xf9, 0xb6, 0xf5, 0x24, 0xef, 0x87, 0x1b,
+	0xb5, 0xfe, 0xdb, 0xcb, 0x8f, 0x01, 0x00, 0x19, 0x85, 0x36, 0xc7, 0x05, 0x02, 0x00, 0x00,
 }
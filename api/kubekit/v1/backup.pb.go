@@ -0,0 +1,354 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: backup.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Backup struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Node                 string   `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	Release              string   `protobuf:"bytes,4,opt,name=release,proto3" json:"release,omitempty"`
+	EtcdVersion          string   `protobuf:"bytes,5,opt,name=etcd_version,json=etcdVersion,proto3" json:"etcd_version,omitempty"`
+	Size                 int64    `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt            int64    `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Path                 string   `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Backup) Reset()         { *m = Backup{} }
+func (m *Backup) String() string { return proto.CompactTextString(m) }
+func (*Backup) ProtoMessage()    {}
+func (*Backup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{0}
+}
+
+func (m *Backup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Backup.Unmarshal(m, b)
+}
+func (m *Backup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Backup.Marshal(b, m, deterministic)
+}
+func (m *Backup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Backup.Merge(m, src)
+}
+func (m *Backup) XXX_Size() int {
+	return xxx_messageInfo_Backup.Size(m)
+}
+func (m *Backup) XXX_DiscardUnknown() {
+	xxx_messageInfo_Backup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Backup proto.InternalMessageInfo
+
+func (m *Backup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Backup) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *Backup) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Backup) GetRelease() string {
+	if m != nil {
+		return m.Release
+	}
+	return ""
+}
+
+func (m *Backup) GetEtcdVersion() string {
+	if m != nil {
+		return m.EtcdVersion
+	}
+	return ""
+}
+
+func (m *Backup) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Backup) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *Backup) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type BackupRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{1}
+}
+
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupRequest.Unmarshal(m, b)
+}
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
+}
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
+}
+func (m *BackupRequest) XXX_Size() int {
+	return xxx_messageInfo_BackupRequest.Size(m)
+}
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
+
+func (m *BackupRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BackupRequest) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+type BackupResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Backup               *Backup  `protobuf:"bytes,2,opt,name=backup,proto3" json:"backup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupResponse) Reset()         { *m = BackupResponse{} }
+func (m *BackupResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupResponse) ProtoMessage()    {}
+func (*BackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{2}
+}
+
+func (m *BackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupResponse.Unmarshal(m, b)
+}
+func (m *BackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupResponse.Merge(m, src)
+}
+func (m *BackupResponse) XXX_Size() int {
+	return xxx_messageInfo_BackupResponse.Size(m)
+}
+func (m *BackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupResponse proto.InternalMessageInfo
+
+func (m *BackupResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *BackupResponse) GetBackup() *Backup {
+	if m != nil {
+		return m.Backup
+	}
+	return nil
+}
+
+type RestoreRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	From                 string   `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{3}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *RestoreRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+type RestoreResponse struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	OperationId          string   `protobuf:"bytes,3,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{4}
+}
+
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreResponse.Size(m)
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
+
+func (m *RestoreResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *RestoreResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RestoreResponse) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Backup)(nil), "kubekit.v1.Backup")
+	proto.RegisterType((*BackupRequest)(nil), "kubekit.v1.BackupRequest")
+	proto.RegisterType((*BackupResponse)(nil), "kubekit.v1.BackupResponse")
+	proto.RegisterType((*RestoreRequest)(nil), "kubekit.v1.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "kubekit.v1.RestoreResponse")
+}
+
+func init() { proto.RegisterFile("backup.proto", fileDescriptor_65240d19de191688) }
+
+var fileDescriptor_65240d19de191688 = []byte{
+	// 313 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0xb1, 0x4e, 0xfb, 0x30,
+	0x10, 0xc6, 0x95, 0xb6, 0xff, 0xf4, 0xdf, 0x6b, 0x29, 0xc8, 0x03, 0xf2, 0x82, 0x54, 0x32, 0x55,
+	0x0c, 0x91, 0x0a, 0x4f, 0x40, 0xc5, 0xc2, 0xd2, 0x21, 0x03, 0x12, 0x0c, 0x44, 0x6e, 0x7c, 0x08,
+	0xab, 0x6d, 0x6c, 0xec, 0x4b, 0x06, 0x5e, 0x94, 0xd7, 0x41, 0x76, 0x9c, 0xb2, 0x80, 0x84, 0xc4,
+	0xf6, 0xf9, 0xbb, 0xbb, 0x5f, 0xee, 0x3b, 0x05, 0x66, 0x5b, 0x51, 0xed, 0x1a, 0x93, 0x1b, 0xab,
+	0x49, 0x33, 0xd8, 0x35, 0x5b, 0xdc, 0x29, 0xca, 0xdb, 0x55, 0xf6, 0x91, 0x40, 0xba, 0x0e, 0x45,
+	0xc6, 0x60, 0x54, 0x8b, 0x03, 0xf2, 0x64, 0x91, 0x2c, 0x27, 0x45, 0xd0, 0xec, 0x12, 0x66, 0xd5,
+	0xbe, 0x71, 0x84, 0xb6, 0x0c, 0xb5, 0x41, 0xa8, 0x4d, 0xa3, 0xb7, 0xf1, 0x2d, 0x7e, 0x4c, 0x4b,
+	0xe4, 0xc3, 0x38, 0xa6, 0x25, 0x32, 0x0e, 0x63, 0x8b, 0x7b, 0x14, 0x0e, 0xf9, 0x28, 0xd8, 0xfd,
+	0xd3, 0x03, 0x91, 0x2a, 0x59, 0xb6, 0x68, 0x9d, 0xd2, 0x35, 0xff, 0xd7, 0x01, 0xbd, 0xf7, 0xd0,
+	0x59, 0x1e, 0xe8, 0xd4, 0x3b, 0xf2, 0x74, 0x91, 0x2c, 0x87, 0x45, 0xd0, 0xec, 0x02, 0xa0, 0xb2,
+	0x28, 0x08, 0x65, 0x29, 0x88, 0x8f, 0x43, 0x65, 0x12, 0x9d, 0x5b, 0xf2, 0x23, 0x46, 0xd0, 0x2b,
+	0xff, 0xdf, 0xed, 0xe0, 0x75, 0x76, 0x07, 0x27, 0x5d, 0xb0, 0x02, 0xdf, 0x1a, 0x74, 0xc4, 0xce,
+	0x60, 0x28, 0x8c, 0x8a, 0xf1, 0xbc, 0xfc, 0x45, 0xba, 0x6c, 0x03, 0xf3, 0x9e, 0xe2, 0x8c, 0xae,
+	0x1d, 0x7e, 0x83, 0xb9, 0x82, 0xb4, 0xbb, 0x6f, 0x00, 0x4c, 0xaf, 0x59, 0xfe, 0x75, 0xe0, 0x3c,
+	0x4e, 0xc7, 0x8e, 0xec, 0x11, 0xe6, 0x05, 0x3a, 0xd2, 0x16, 0xff, 0xb2, 0x96, 0x0f, 0xfc, 0x62,
+	0xf5, 0xa1, 0x3f, 0xba, 0xd7, 0xd9, 0x33, 0x9c, 0x1e, 0xd1, 0x3f, 0xee, 0x7a, 0x0e, 0xa9, 0x23,
+	0x41, 0x8d, 0x8b, 0xd4, 0xf8, 0xf2, 0xdf, 0xd4, 0x06, 0xad, 0x20, 0xa5, 0xeb, 0x52, 0xc9, 0x08,
+	0x9e, 0x1e, 0xbd, 0x7b, 0xb9, 0x1e, 0x3d, 0x0d, 0xda, 0xd5, 0x36, 0x0d, 0xff, 0xd0, 0xcd, 0xe7,
+	0x00, 0x32, 0xb6, 0x75, 0x6f, 0x53, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: check.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type CheckClusterRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckClusterRequest) Reset()         { *m = CheckClusterRequest{} }
+func (m *CheckClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckClusterRequest) ProtoMessage()    {}
+func (*CheckClusterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d8d3c606fb107336, []int{0}
+}
+
+func (m *CheckClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckClusterRequest.Unmarshal(m, b)
+}
+func (m *CheckClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckClusterRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckClusterRequest.Merge(m, src)
+}
+func (m *CheckClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckClusterRequest.Size(m)
+}
+func (m *CheckClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckClusterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckClusterRequest proto.InternalMessageInfo
+
+func (m *CheckClusterRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CheckClusterRequest) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+type CheckClusterResponse struct {
+	Api                  string         `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string         `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Status               string         `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Pass                 int32          `protobuf:"varint,4,opt,name=pass,proto3" json:"pass,omitempty"`
+	Warn                 int32          `protobuf:"varint,5,opt,name=warn,proto3" json:"warn,omitempty"`
+	Fail                 int32          `protobuf:"varint,6,opt,name=fail,proto3" json:"fail,omitempty"`
+	Results              []*CheckResult `protobuf:"bytes,7,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CheckClusterResponse) Reset()         { *m = CheckClusterResponse{} }
+func (m *CheckClusterResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckClusterResponse) ProtoMessage()    {}
+func (*CheckClusterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d8d3c606fb107336, []int{1}
+}
+
+func (m *CheckClusterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckClusterResponse.Unmarshal(m, b)
+}
+func (m *CheckClusterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckClusterResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckClusterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckClusterResponse.Merge(m, src)
+}
+func (m *CheckClusterResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckClusterResponse.Size(m)
+}
+func (m *CheckClusterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckClusterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckClusterResponse proto.InternalMessageInfo
+
+func (m *CheckClusterResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CheckClusterResponse) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *CheckClusterResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CheckClusterResponse) GetPass() int32 {
+	if m != nil {
+		return m.Pass
+	}
+	return 0
+}
+
+func (m *CheckClusterResponse) GetWarn() int32 {
+	if m != nil {
+		return m.Warn
+	}
+	return 0
+}
+
+func (m *CheckClusterResponse) GetFail() int32 {
+	if m != nil {
+		return m.Fail
+	}
+	return 0
+}
+
+func (m *CheckClusterResponse) GetResults() []*CheckResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type CheckResult struct {
+	Check                string   `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	Node                 string   `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckResult) Reset()         { *m = CheckResult{} }
+func (m *CheckResult) String() string { return proto.CompactTextString(m) }
+func (*CheckResult) ProtoMessage()    {}
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d8d3c606fb107336, []int{2}
+}
+
+func (m *CheckResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResult.Unmarshal(m, b)
+}
+func (m *CheckResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResult.Marshal(b, m, deterministic)
+}
+func (m *CheckResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResult.Merge(m, src)
+}
+func (m *CheckResult) XXX_Size() int {
+	return xxx_messageInfo_CheckResult.Size(m)
+}
+func (m *CheckResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResult proto.InternalMessageInfo
+
+func (m *CheckResult) GetCheck() string {
+	if m != nil {
+		return m.Check
+	}
+	return ""
+}
+
+func (m *CheckResult) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *CheckResult) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CheckResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*CheckClusterRequest)(nil), "kubekit.v1.CheckClusterRequest")
+	proto.RegisterType((*CheckClusterResponse)(nil), "kubekit.v1.CheckClusterResponse")
+	proto.RegisterType((*CheckResult)(nil), "kubekit.v1.CheckResult")
+}
+
+func init() { proto.RegisterFile("check.proto", fileDescriptor_d8d3c606fb107336) }
+
+var fileDescriptor_d8d3c606fb107336 = []byte{
+	// 251 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x91, 0xbd, 0x4e, 0xc5, 0x30,
+	0x0c, 0x85, 0xd5, 0xdb, 0x3f, 0x5d, 0x97, 0x01, 0x85, 0x2b, 0xc8, 0x58, 0x3a, 0x75, 0xaa, 0x54,
+	0x78, 0x03, 0xee, 0xc6, 0xc0, 0x90, 0x91, 0x05, 0xe5, 0x16, 0x03, 0x55, 0x7f, 0xa9, 0x93, 0xf2,
+	0x9e, 0x3c, 0x11, 0x8a, 0xdb, 0x0a, 0x18, 0x58, 0xd8, 0x8e, 0xbf, 0x63, 0x39, 0x3e, 0x0e, 0x24,
+	0xd5, 0x1b, 0x56, 0x4d, 0x31, 0x4e, 0x83, 0x19, 0x04, 0x34, 0xf6, 0x84, 0x4d, 0x6d, 0x8a, 0xb9,
+	0xcc, 0xee, 0xe1, 0xe2, 0xe8, 0xac, 0x63, 0x6b, 0xc9, 0xe0, 0xa4, 0xf0, 0xdd, 0x22, 0x19, 0x71,
+	0x0e, 0xbe, 0x1e, 0x6b, 0xe9, 0xa5, 0x5e, 0xbe, 0x57, 0x4e, 0x8a, 0x6b, 0x38, 0xab, 0x96, 0x9e,
+	0xa7, 0x5e, 0x77, 0x28, 0x77, 0x6c, 0x25, 0x2b, 0x7b, 0xd0, 0x1d, 0x66, 0x9f, 0x1e, 0x1c, 0x7e,
+	0x0f, 0xa3, 0x71, 0xe8, 0x09, 0xff, 0x35, 0x4d, 0x5c, 0x42, 0x44, 0x46, 0x1b, 0x4b, 0xd2, 0x67,
+	0x73, 0xad, 0x84, 0x80, 0x60, 0xd4, 0x44, 0x32, 0x48, 0xbd, 0x3c, 0x54, 0xac, 0x1d, 0xfb, 0xd0,
+	0x53, 0x2f, 0xc3, 0x85, 0x39, 0xed, 0xd8, 0x8b, 0xae, 0x5b, 0x19, 0x2d, 0xcc, 0x69, 0x51, 0x42,
+	0x3c, 0x21, 0xd9, 0xd6, 0x90, 0x8c, 0x53, 0x3f, 0x4f, 0x6e, 0xae, 0x8a, 0xef, 0x5b, 0x14, 0xbc,
+	0xbb, 0x62, 0x5f, 0x6d, 0x7d, 0x59, 0x0d, 0xc9, 0x0f, 0x2e, 0x0e, 0x10, 0xf2, 0x29, 0xd7, 0x30,
+	0x4b, 0xe1, 0xde, 0xea, 0x87, 0xe7, 0x2d, 0x06, 0xeb, 0x3f, 0xf7, 0x97, 0x10, 0x77, 0x48, 0xa4,
+	0x5f, 0x91, 0x23, 0xec, 0xd5, 0x56, 0xde, 0x05, 0x8f, 0xbb, 0xb9, 0x3c, 0x45, 0xfc, 0x49, 0xb7,
+	0x5f, 0x03, 0x00, 0x77, 0xa3, 0x25, 0x73, 0xb3, 0x01, 0x00, 0x00,
+}
@@ -103,6 +103,7 @@ func (m *DeleteRequest) GetDestroyAll() bool {
 type DeleteResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	OperationId          string   `protobuf:"bytes,3,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -147,6 +148,13 @@ func (m *DeleteResponse) GetStatus() string {
 	return ""
 }
 
+func (m *DeleteResponse) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
 type DeleteClusterConfigRequest struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
 	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
@@ -260,7 +268,7 @@ func init() {
 func init() { proto.RegisterFile("delete.proto", fileDescriptor_600d681a62b3a9a7) }
 
 var fileDescriptor_600d681a62b3a9a7 = []byte{
-	// 258 bytes of a gzipped FileDescriptorProto
+	// 279 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0x49, 0xcd, 0x49,
 	0x2d, 0x49, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0xca, 0x2e, 0x4d, 0x4a, 0xcd, 0xce,
 	0x2c, 0xd1, 0x2b, 0x33, 0x54, 0x4a, 0xe5, 0xe2, 0x75, 0x01, 0xcb, 0x05, 0xa5, 0x16, 0x96, 0xa6,
@@ -268,14 +276,15 @@ var fileDescriptor_600d681a62b3a9a7 = []byte{
 	0x81, 0x98, 0x42, 0x8a, 0x5c, 0x3c, 0xc9, 0x39, 0xa5, 0xc5, 0x25, 0xa9, 0x45, 0xf1, 0x79, 0x89,
 	0xb9, 0xa9, 0x12, 0x4c, 0x60, 0x29, 0x6e, 0xa8, 0x98, 0x5f, 0x62, 0x6e, 0xaa, 0x90, 0x3c, 0x17,
 	0x77, 0x4a, 0x6a, 0x71, 0x49, 0x51, 0x7e, 0x65, 0x7c, 0x62, 0x4e, 0x8e, 0x04, 0xb3, 0x02, 0xa3,
-	0x06, 0x47, 0x10, 0x17, 0x54, 0xc8, 0x31, 0x27, 0x47, 0xc9, 0x8a, 0x8b, 0x0f, 0x66, 0x4d, 0x71,
+	0x06, 0x47, 0x10, 0x17, 0x54, 0xc8, 0x31, 0x27, 0x47, 0x29, 0x96, 0x8b, 0x0f, 0x66, 0x4d, 0x71,
 	0x41, 0x7e, 0x5e, 0x71, 0x2a, 0x16, 0x7b, 0xc4, 0xb8, 0xd8, 0x8a, 0x4b, 0x12, 0x4b, 0x4a, 0x8b,
-	0xa1, 0x36, 0x40, 0x79, 0x4a, 0x81, 0x5c, 0x52, 0x10, 0xbd, 0xce, 0x10, 0x1b, 0x9d, 0xf3, 0xf3,
-	0xd2, 0x32, 0xd3, 0x29, 0x71, 0xaf, 0xd2, 0x44, 0x46, 0x2e, 0x69, 0xac, 0x66, 0xe2, 0x74, 0x1c,
-	0x11, 0x81, 0x60, 0x0b, 0x77, 0x3f, 0xc8, 0xff, 0x7c, 0x46, 0xaa, 0x7a, 0x88, 0x70, 0xd6, 0xc3,
-	0x62, 0x5b, 0x30, 0x58, 0x31, 0xcc, 0x9b, 0x5a, 0xe6, 0x5c, 0x92, 0x38, 0x15, 0x09, 0x71, 0x73,
-	0xb1, 0xbb, 0xb8, 0xfa, 0xb8, 0x86, 0xb8, 0xba, 0x08, 0x30, 0x08, 0xf1, 0x72, 0x71, 0xfa, 0xf9,
-	0x87, 0xc4, 0xbb, 0xf9, 0x87, 0xfa, 0xb9, 0x08, 0x30, 0x3a, 0xb1, 0x44, 0x31, 0x95, 0x19, 0x26,
-	0xb1, 0x81, 0xe3, 0xd6, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0xba, 0x46, 0x2f, 0xf5, 0xeb, 0x01,
-	0x00, 0x00,
+	0xa1, 0x36, 0x40, 0x79, 0x20, 0xfb, 0xf3, 0x0b, 0x52, 0x8b, 0x12, 0x4b, 0x32, 0xf3, 0xf3, 0xe2,
+	0x33, 0x53, 0xc0, 0xa6, 0x73, 0x06, 0x71, 0xc3, 0xc5, 0x3c, 0x53, 0x94, 0x02, 0xb9, 0xa4, 0x20,
+	0xc6, 0x3b, 0x43, 0x1c, 0xe5, 0x9c, 0x9f, 0x97, 0x96, 0x99, 0x4e, 0x89, 0x97, 0x94, 0x26, 0x32,
+	0x72, 0x49, 0x63, 0x35, 0x13, 0xa7, 0xfb, 0x89, 0x08, 0x27, 0x5b, 0xb8, 0x17, 0x41, 0x9e, 0xe0,
+	0x33, 0x52, 0xd5, 0x43, 0x44, 0x85, 0x1e, 0x16, 0xdb, 0x82, 0xc1, 0x8a, 0x61, 0x21, 0xa1, 0x65,
+	0xce, 0x25, 0x89, 0x53, 0x91, 0x10, 0x37, 0x17, 0xbb, 0x8b, 0xab, 0x8f, 0x6b, 0x88, 0xab, 0x8b,
+	0x00, 0x83, 0x10, 0x2f, 0x17, 0xa7, 0x9f, 0x7f, 0x48, 0xbc, 0x9b, 0x7f, 0xa8, 0x9f, 0x8b, 0x00,
+	0xa3, 0x13, 0x4b, 0x14, 0x53, 0x99, 0x61, 0x12, 0x1b, 0x38, 0xfa, 0x8d, 0x01, 0x03, 0x00, 0xb3,
+	0x16, 0xd4, 0xd7, 0x0e, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,206 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: event.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type EventType int32
+
+const (
+	EventType_STEP_STARTED  EventType = 0
+	EventType_STEP_FINISHED EventType = 1
+	EventType_RESOURCE      EventType = 2
+	EventType_TASK          EventType = 3
+	EventType_STATUS        EventType = 4
+)
+
+var EventType_name = map[int32]string{
+	0: "STEP_STARTED",
+	1: "STEP_FINISHED",
+	2: "RESOURCE",
+	3: "TASK",
+	4: "STATUS",
+}
+
+var EventType_value = map[string]int32{
+	"STEP_STARTED":  0,
+	"STEP_FINISHED": 1,
+	"RESOURCE":      2,
+	"TASK":          3,
+	"STATUS":        4,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2d17a9d3f0ddf27e, []int{0}
+}
+
+type Event struct {
+	Api                  string    `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string    `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Type                 EventType `protobuf:"varint,3,opt,name=type,proto3,enum=kubekit.v1.EventType" json:"type,omitempty"`
+	Step                 string    `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+	Location             string    `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Resource             string    `protobuf:"bytes,6,opt,name=resource,proto3" json:"resource,omitempty"`
+	Message              string    `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Status               string    `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Error                string    `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	Timestamp            int64     `protobuf:"varint,10,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OperationId          string    `protobuf:"bytes,11,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d17a9d3f0ddf27e, []int{0}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *Event) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *Event) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_STEP_STARTED
+}
+
+func (m *Event) GetStep() string {
+	if m != nil {
+		return m.Step
+	}
+	return ""
+}
+
+func (m *Event) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Event) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *Event) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Event) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Event) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Event) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *Event) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("kubekit.v1.EventType", EventType_name, EventType_value)
+	proto.RegisterType((*Event)(nil), "kubekit.v1.Event")
+}
+
+func init() { proto.RegisterFile("event.proto", fileDescriptor_2d17a9d3f0ddf27e) }
+
+var fileDescriptor_2d17a9d3f0ddf27e = []byte{
+	// 321 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x91, 0x4d, 0x4b, 0xeb, 0x40,
+	0x14, 0x86, 0x6f, 0x3e, 0x9a, 0x26, 0xa7, 0xbd, 0x97, 0xdc, 0x83, 0xca, 0x20, 0x2e, 0xaa, 0xab,
+	0xea, 0x22, 0x50, 0xfd, 0x05, 0xd5, 0x46, 0x2c, 0x42, 0x95, 0x64, 0xba, 0x71, 0x53, 0xd2, 0xf6,
+	0x20, 0xa1, 0x4d, 0x67, 0x98, 0x99, 0x14, 0xfa, 0xdf, 0xfc, 0x71, 0x92, 0xe9, 0xd7, 0xee, 0xbc,
+	0xcf, 0x13, 0x5e, 0xf2, 0x32, 0xd0, 0xa1, 0x2d, 0x6d, 0x4c, 0x22, 0x95, 0x30, 0x02, 0x61, 0x55,
+	0xcf, 0x69, 0x55, 0x9a, 0x64, 0x3b, 0xb8, 0xfb, 0x71, 0xa1, 0x95, 0x36, 0x0e, 0x63, 0xf0, 0x0a,
+	0x59, 0x32, 0xa7, 0xe7, 0xf4, 0xa3, 0xac, 0x39, 0xf1, 0x16, 0xba, 0x8b, 0x75, 0xad, 0x0d, 0xa9,
+	0xd9, 0xa6, 0xa8, 0x88, 0xb9, 0x56, 0x75, 0x0e, 0x6c, 0x52, 0x54, 0x84, 0xf7, 0xe0, 0x9b, 0x9d,
+	0x24, 0xe6, 0xf5, 0x9c, 0xfe, 0xbf, 0xc7, 0xcb, 0xe4, 0xdc, 0x9c, 0xd8, 0x56, 0xbe, 0x93, 0x94,
+	0xd9, 0x4f, 0x10, 0xc1, 0xd7, 0x86, 0x24, 0xf3, 0x6d, 0x8b, 0xbd, 0xf1, 0x1a, 0xc2, 0xb5, 0x58,
+	0x14, 0xa6, 0x14, 0x1b, 0xd6, 0xb2, 0xfc, 0x94, 0x1b, 0xa7, 0x48, 0x8b, 0x5a, 0x2d, 0x88, 0x05,
+	0x7b, 0x77, 0xcc, 0xc8, 0xa0, 0x5d, 0x91, 0xd6, 0xc5, 0x37, 0xb1, 0xb6, 0x55, 0xc7, 0x88, 0x57,
+	0x10, 0x68, 0x53, 0x98, 0x5a, 0xb3, 0xd0, 0x8a, 0x43, 0xc2, 0x0b, 0x68, 0x91, 0x52, 0x42, 0xb1,
+	0xc8, 0xe2, 0x7d, 0xc0, 0x1b, 0x88, 0x4c, 0x59, 0x91, 0x36, 0x45, 0x25, 0x19, 0xf4, 0x9c, 0xbe,
+	0x97, 0x9d, 0x41, 0xb3, 0x5f, 0x48, 0x52, 0xf6, 0x77, 0x66, 0xe5, 0x92, 0x75, 0xf6, 0xfb, 0x4f,
+	0x6c, 0xbc, 0x7c, 0xe0, 0x10, 0x9d, 0x76, 0x62, 0x0c, 0xdd, 0x9c, 0xa7, 0x9f, 0xb3, 0x9c, 0x0f,
+	0x33, 0x9e, 0x8e, 0xe2, 0x3f, 0xf8, 0x1f, 0xfe, 0x5a, 0xf2, 0x3a, 0x9e, 0x8c, 0xf3, 0xb7, 0x74,
+	0x14, 0x3b, 0xd8, 0x85, 0x30, 0x4b, 0xf3, 0x8f, 0x69, 0xf6, 0x92, 0xc6, 0x2e, 0x86, 0xe0, 0xf3,
+	0x61, 0xfe, 0x1e, 0x7b, 0x08, 0x10, 0xe4, 0x7c, 0xc8, 0xa7, 0x79, 0xec, 0x3f, 0xfb, 0x5f, 0xee,
+	0x76, 0x30, 0x0f, 0xec, 0x6b, 0x3d, 0xfd, 0x0e, 0x00, 0x58, 0x49, 0xe6, 0x04, 0xbc, 0x01, 0x00,
+	0x00,
+}
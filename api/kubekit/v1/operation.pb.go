@@ -0,0 +1,546 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: operation.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type OperationState int32
+
+const (
+	OperationState_OPERATION_RUNNING   OperationState = 0
+	OperationState_OPERATION_SUCCEEDED OperationState = 1
+	OperationState_OPERATION_FAILED    OperationState = 2
+	OperationState_OPERATION_CANCELED  OperationState = 3
+)
+
+var OperationState_name = map[int32]string{
+	0: "OPERATION_RUNNING",
+	1: "OPERATION_SUCCEEDED",
+	2: "OPERATION_FAILED",
+	3: "OPERATION_CANCELED",
+}
+
+var OperationState_value = map[string]int32{
+	"OPERATION_RUNNING":   0,
+	"OPERATION_SUCCEEDED": 1,
+	"OPERATION_FAILED":    2,
+	"OPERATION_CANCELED":  3,
+}
+
+func (x OperationState) String() string {
+	return proto.EnumName(OperationState_name, int32(x))
+}
+
+func (OperationState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{0}
+}
+
+type OperationStep struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StartTime            int64    `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              int64    `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OperationStep) Reset()         { *m = OperationStep{} }
+func (m *OperationStep) String() string { return proto.CompactTextString(m) }
+func (*OperationStep) ProtoMessage()    {}
+func (*OperationStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{0}
+}
+
+func (m *OperationStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OperationStep.Unmarshal(m, b)
+}
+func (m *OperationStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OperationStep.Marshal(b, m, deterministic)
+}
+func (m *OperationStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OperationStep.Merge(m, src)
+}
+func (m *OperationStep) XXX_Size() int {
+	return xxx_messageInfo_OperationStep.Size(m)
+}
+func (m *OperationStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_OperationStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OperationStep proto.InternalMessageInfo
+
+func (m *OperationStep) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *OperationStep) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *OperationStep) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *OperationStep) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Operation struct {
+	Id                   string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClusterName          string           `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Action               string           `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	State                OperationState   `protobuf:"varint,4,opt,name=state,proto3,enum=kubekit.v1.OperationState" json:"state,omitempty"`
+	StartTime            int64            `protobuf:"varint,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              int64            `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Steps                []*OperationStep `protobuf:"bytes,7,rep,name=steps,proto3" json:"steps,omitempty"`
+	Status               string           `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Error                string           `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Operation) Reset()         { *m = Operation{} }
+func (m *Operation) String() string { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()    {}
+func (*Operation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{1}
+}
+
+func (m *Operation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Operation.Unmarshal(m, b)
+}
+func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Operation.Marshal(b, m, deterministic)
+}
+func (m *Operation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Operation.Merge(m, src)
+}
+func (m *Operation) XXX_Size() int {
+	return xxx_messageInfo_Operation.Size(m)
+}
+func (m *Operation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Operation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Operation proto.InternalMessageInfo
+
+func (m *Operation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Operation) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *Operation) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *Operation) GetState() OperationState {
+	if m != nil {
+		return m.State
+	}
+	return OperationState_OPERATION_RUNNING
+}
+
+func (m *Operation) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Operation) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *Operation) GetSteps() []*OperationStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+func (m *Operation) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Operation) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type ListOperationsRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOperationsRequest) Reset()         { *m = ListOperationsRequest{} }
+func (m *ListOperationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsRequest) ProtoMessage()    {}
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{2}
+}
+
+func (m *ListOperationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsRequest.Unmarshal(m, b)
+}
+func (m *ListOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsRequest.Merge(m, src)
+}
+func (m *ListOperationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsRequest.Size(m)
+}
+func (m *ListOperationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsRequest proto.InternalMessageInfo
+
+func (m *ListOperationsRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListOperationsRequest) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+type ListOperationsResponse struct {
+	Api                  string       `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Operations           []*Operation `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListOperationsResponse) Reset()         { *m = ListOperationsResponse{} }
+func (m *ListOperationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsResponse) ProtoMessage()    {}
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{3}
+}
+
+func (m *ListOperationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsResponse.Unmarshal(m, b)
+}
+func (m *ListOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsResponse.Merge(m, src)
+}
+func (m *ListOperationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsResponse.Size(m)
+}
+func (m *ListOperationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsResponse proto.InternalMessageInfo
+
+func (m *ListOperationsResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *ListOperationsResponse) GetOperations() []*Operation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+type GetOperationRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOperationRequest) Reset()         { *m = GetOperationRequest{} }
+func (m *GetOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOperationRequest) ProtoMessage()    {}
+func (*GetOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{4}
+}
+
+func (m *GetOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOperationRequest.Unmarshal(m, b)
+}
+func (m *GetOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationRequest.Merge(m, src)
+}
+func (m *GetOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOperationRequest.Size(m)
+}
+func (m *GetOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperationRequest proto.InternalMessageInfo
+
+func (m *GetOperationRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetOperationResponse struct {
+	Api                  string     `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Operation            *Operation `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GetOperationResponse) Reset()         { *m = GetOperationResponse{} }
+func (m *GetOperationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetOperationResponse) ProtoMessage()    {}
+func (*GetOperationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{5}
+}
+
+func (m *GetOperationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOperationResponse.Unmarshal(m, b)
+}
+func (m *GetOperationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOperationResponse.Marshal(b, m, deterministic)
+}
+func (m *GetOperationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationResponse.Merge(m, src)
+}
+func (m *GetOperationResponse) XXX_Size() int {
+	return xxx_messageInfo_GetOperationResponse.Size(m)
+}
+func (m *GetOperationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperationResponse proto.InternalMessageInfo
+
+func (m *GetOperationResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *GetOperationResponse) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+type CancelOperationRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOperationRequest) Reset()         { *m = CancelOperationRequest{} }
+func (m *CancelOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationRequest) ProtoMessage()    {}
+func (*CancelOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{6}
+}
+
+func (m *CancelOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationRequest.Unmarshal(m, b)
+}
+func (m *CancelOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationRequest.Merge(m, src)
+}
+func (m *CancelOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationRequest.Size(m)
+}
+func (m *CancelOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationRequest proto.InternalMessageInfo
+
+func (m *CancelOperationRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CancelOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelOperationResponse struct {
+	Api                  string     `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	Operation            *Operation `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CancelOperationResponse) Reset()         { *m = CancelOperationResponse{} }
+func (m *CancelOperationResponse) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationResponse) ProtoMessage()    {}
+func (*CancelOperationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{7}
+}
+
+func (m *CancelOperationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationResponse.Unmarshal(m, b)
+}
+func (m *CancelOperationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationResponse.Marshal(b, m, deterministic)
+}
+func (m *CancelOperationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationResponse.Merge(m, src)
+}
+func (m *CancelOperationResponse) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationResponse.Size(m)
+}
+func (m *CancelOperationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationResponse proto.InternalMessageInfo
+
+func (m *CancelOperationResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CancelOperationResponse) GetOperation() *Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("kubekit.v1.OperationState", OperationState_name, OperationState_value)
+	proto.RegisterType((*OperationStep)(nil), "kubekit.v1.OperationStep")
+	proto.RegisterType((*Operation)(nil), "kubekit.v1.Operation")
+	proto.RegisterType((*ListOperationsRequest)(nil), "kubekit.v1.ListOperationsRequest")
+	proto.RegisterType((*ListOperationsResponse)(nil), "kubekit.v1.ListOperationsResponse")
+	proto.RegisterType((*GetOperationRequest)(nil), "kubekit.v1.GetOperationRequest")
+	proto.RegisterType((*GetOperationResponse)(nil), "kubekit.v1.GetOperationResponse")
+	proto.RegisterType((*CancelOperationRequest)(nil), "kubekit.v1.CancelOperationRequest")
+	proto.RegisterType((*CancelOperationResponse)(nil), "kubekit.v1.CancelOperationResponse")
+}
+
+func init() { proto.RegisterFile("operation.proto", fileDescriptor_619dee0fded31cb3) }
+
+var fileDescriptor_619dee0fded31cb3 = []byte{
+	// 445 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4f, 0x6f, 0xd3, 0x30,
+	0x14, 0xa7, 0x4e, 0xdb, 0x2d, 0x6f, 0x50, 0x82, 0xd7, 0x76, 0x19, 0x12, 0x52, 0xc9, 0xa9, 0xe2,
+	0x50, 0xd8, 0x26, 0x84, 0xc4, 0xad, 0xa4, 0x61, 0xaa, 0x54, 0xa5, 0xc8, 0xdb, 0x2e, 0x48, 0xa8,
+	0x78, 0xcd, 0x3b, 0x58, 0x5b, 0x9d, 0x10, 0x3b, 0xfb, 0x3e, 0x7c, 0x53, 0x14, 0x27, 0x4b, 0xda,
+	0xb1, 0x20, 0x84, 0xb8, 0xf9, 0xfd, 0xfb, 0xfd, 0x79, 0xb6, 0x0c, 0xcf, 0xe3, 0x04, 0x53, 0xae,
+	0x45, 0x2c, 0x27, 0x49, 0x1a, 0xeb, 0x98, 0xc2, 0x4d, 0x76, 0x8d, 0x37, 0x42, 0x4f, 0xee, 0x4e,
+	0x3c, 0x05, 0xcf, 0x96, 0xf7, 0xe5, 0x0b, 0x8d, 0x09, 0xa5, 0xd0, 0x96, 0x7c, 0x83, 0x6e, 0x6b,
+	0xd4, 0x1a, 0xdb, 0xcc, 0x9c, 0xe9, 0x2b, 0x00, 0xa5, 0x79, 0xaa, 0x57, 0x5a, 0x6c, 0xd0, 0x25,
+	0xa3, 0xd6, 0xd8, 0x62, 0xb6, 0xc9, 0x5c, 0x8a, 0x0d, 0xd2, 0x63, 0xd8, 0x47, 0x19, 0x15, 0x45,
+	0xcb, 0x14, 0xf7, 0x50, 0x46, 0xa6, 0xd4, 0x87, 0x0e, 0xa6, 0x69, 0x9c, 0xba, 0x6d, 0x03, 0x57,
+	0x04, 0xde, 0x4f, 0x02, 0x76, 0xc5, 0x4a, 0x7b, 0x40, 0x44, 0x54, 0xf2, 0x11, 0x11, 0xd1, 0xd7,
+	0xf0, 0x74, 0x7d, 0x9b, 0x29, 0x8d, 0xe9, 0x4a, 0xf2, 0x92, 0xcf, 0x66, 0x07, 0x65, 0x2e, 0xcc,
+	0x05, 0x0d, 0xa1, 0xcb, 0xd7, 0xf9, 0xb0, 0xe1, 0xb3, 0x59, 0x19, 0xd1, 0x77, 0xd0, 0x51, 0x9a,
+	0x6b, 0x34, 0x74, 0xbd, 0xd3, 0x97, 0x93, 0xda, 0xe9, 0x64, 0xcb, 0x26, 0xd7, 0xc8, 0x8a, 0xc6,
+	0x07, 0xd6, 0x3a, 0x7f, 0xb2, 0xd6, 0xdd, 0xb5, 0xf6, 0x36, 0xe7, 0xc2, 0x44, 0xb9, 0x7b, 0x23,
+	0x6b, 0x7c, 0x70, 0x7a, 0xdc, 0xc0, 0x85, 0x09, 0x2b, 0xfa, 0x72, 0xd1, 0x39, 0x67, 0xa6, 0xdc,
+	0xfd, 0x42, 0x74, 0x11, 0xd5, 0x3b, 0xb2, 0xb7, 0x77, 0xb4, 0x80, 0xc1, 0x42, 0x28, 0x5d, 0x21,
+	0x29, 0x86, 0x3f, 0x32, 0x54, 0x9a, 0x3a, 0x60, 0xf1, 0x44, 0x94, 0xfb, 0xca, 0x8f, 0x7f, 0xb1,
+	0x30, 0x8f, 0xc3, 0xf0, 0x21, 0x9a, 0x4a, 0x62, 0xa9, 0xf0, 0x11, 0xb8, 0xf7, 0x00, 0xd5, 0x8b,
+	0x51, 0x2e, 0x31, 0xee, 0x06, 0x8f, 0xba, 0x63, 0x5b, 0x8d, 0xde, 0x07, 0x38, 0x3c, 0xc7, 0x9a,
+	0xa1, 0x59, 0x6e, 0x71, 0xdf, 0xe4, 0xfe, 0xbe, 0xbd, 0x6f, 0xd0, 0xdf, 0x1d, 0x6c, 0x54, 0x76,
+	0x06, 0x76, 0x45, 0x68, 0x00, 0x1a, 0x85, 0xd5, 0x7d, 0xde, 0x47, 0x18, 0xfa, 0x5c, 0xae, 0xf1,
+	0xf6, 0x1f, 0xa4, 0x7d, 0x87, 0xa3, 0xdf, 0x66, 0xff, 0xab, 0xba, 0x37, 0x12, 0x7a, 0xbb, 0x0f,
+	0x93, 0x0e, 0xe0, 0xc5, 0xf2, 0x4b, 0xc0, 0xa6, 0x97, 0xf3, 0x65, 0xb8, 0x62, 0x57, 0x61, 0x38,
+	0x0f, 0xcf, 0x9d, 0x27, 0xf4, 0x08, 0x0e, 0xeb, 0xf4, 0xc5, 0x95, 0xef, 0x07, 0xc1, 0x2c, 0x98,
+	0x39, 0x2d, 0xda, 0x07, 0xa7, 0x2e, 0x7c, 0x9e, 0xce, 0x17, 0xc1, 0xcc, 0x21, 0x74, 0x08, 0xb4,
+	0xce, 0xfa, 0xd3, 0xd0, 0x0f, 0xf2, 0xbc, 0xf5, 0xa9, 0xfd, 0x95, 0xdc, 0x9d, 0x5c, 0x77, 0xcd,
+	0x47, 0x70, 0xf6, 0x6b, 0x00, 0x54, 0x45, 0xcd, 0x94, 0x1b, 0x04, 0x00, 0x00,
+}
@@ -0,0 +1,428 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: plan.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type PlanClusterRequest struct {
+	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string   `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Destroy              bool     `protobuf:"varint,3,opt,name=destroy,proto3" json:"destroy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlanClusterRequest) Reset()         { *m = PlanClusterRequest{} }
+func (m *PlanClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*PlanClusterRequest) ProtoMessage()    {}
+func (*PlanClusterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d655ab2f7683c23, []int{0}
+}
+
+func (m *PlanClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanClusterRequest.Unmarshal(m, b)
+}
+func (m *PlanClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanClusterRequest.Marshal(b, m, deterministic)
+}
+func (m *PlanClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanClusterRequest.Merge(m, src)
+}
+func (m *PlanClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_PlanClusterRequest.Size(m)
+}
+func (m *PlanClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanClusterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanClusterRequest proto.InternalMessageInfo
+
+func (m *PlanClusterRequest) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *PlanClusterRequest) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *PlanClusterRequest) GetDestroy() bool {
+	if m != nil {
+		return m.Destroy
+	}
+	return false
+}
+
+type PlanClusterResponse struct {
+	Api                  string          `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	ClusterName          string          `protobuf:"bytes,2,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
+	Platform             string          `protobuf:"bytes,3,opt,name=platform,proto3" json:"platform,omitempty"`
+	Destroy              bool            `protobuf:"varint,4,opt,name=destroy,proto3" json:"destroy,omitempty"`
+	ToAdd                int32           `protobuf:"varint,5,opt,name=to_add,json=toAdd,proto3" json:"to_add,omitempty"`
+	ToChange             int32           `protobuf:"varint,6,opt,name=to_change,json=toChange,proto3" json:"to_change,omitempty"`
+	ToDestroy            int32           `protobuf:"varint,7,opt,name=to_destroy,json=toDestroy,proto3" json:"to_destroy,omitempty"`
+	Destructive          bool            `protobuf:"varint,8,opt,name=destructive,proto3" json:"destructive,omitempty"`
+	NodePools            []*PlanNodePool `protobuf:"bytes,9,rep,name=node_pools,json=nodePools,proto3" json:"node_pools,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PlanClusterResponse) Reset()         { *m = PlanClusterResponse{} }
+func (m *PlanClusterResponse) String() string { return proto.CompactTextString(m) }
+func (*PlanClusterResponse) ProtoMessage()    {}
+func (*PlanClusterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d655ab2f7683c23, []int{1}
+}
+
+func (m *PlanClusterResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanClusterResponse.Unmarshal(m, b)
+}
+func (m *PlanClusterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanClusterResponse.Marshal(b, m, deterministic)
+}
+func (m *PlanClusterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanClusterResponse.Merge(m, src)
+}
+func (m *PlanClusterResponse) XXX_Size() int {
+	return xxx_messageInfo_PlanClusterResponse.Size(m)
+}
+func (m *PlanClusterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanClusterResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanClusterResponse proto.InternalMessageInfo
+
+func (m *PlanClusterResponse) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *PlanClusterResponse) GetClusterName() string {
+	if m != nil {
+		return m.ClusterName
+	}
+	return ""
+}
+
+func (m *PlanClusterResponse) GetPlatform() string {
+	if m != nil {
+		return m.Platform
+	}
+	return ""
+}
+
+func (m *PlanClusterResponse) GetDestroy() bool {
+	if m != nil {
+		return m.Destroy
+	}
+	return false
+}
+
+func (m *PlanClusterResponse) GetToAdd() int32 {
+	if m != nil {
+		return m.ToAdd
+	}
+	return 0
+}
+
+func (m *PlanClusterResponse) GetToChange() int32 {
+	if m != nil {
+		return m.ToChange
+	}
+	return 0
+}
+
+func (m *PlanClusterResponse) GetToDestroy() int32 {
+	if m != nil {
+		return m.ToDestroy
+	}
+	return 0
+}
+
+func (m *PlanClusterResponse) GetDestructive() bool {
+	if m != nil {
+		return m.Destructive
+	}
+	return false
+}
+
+func (m *PlanClusterResponse) GetNodePools() []*PlanNodePool {
+	if m != nil {
+		return m.NodePools
+	}
+	return nil
+}
+
+type PlanNodePool struct {
+	Name                 string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Resources            []*PlanResourceChange `protobuf:"bytes,2,rep,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *PlanNodePool) Reset()         { *m = PlanNodePool{} }
+func (m *PlanNodePool) String() string { return proto.CompactTextString(m) }
+func (*PlanNodePool) ProtoMessage()    {}
+func (*PlanNodePool) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d655ab2f7683c23, []int{2}
+}
+
+func (m *PlanNodePool) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanNodePool.Unmarshal(m, b)
+}
+func (m *PlanNodePool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanNodePool.Marshal(b, m, deterministic)
+}
+func (m *PlanNodePool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanNodePool.Merge(m, src)
+}
+func (m *PlanNodePool) XXX_Size() int {
+	return xxx_messageInfo_PlanNodePool.Size(m)
+}
+func (m *PlanNodePool) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanNodePool.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanNodePool proto.InternalMessageInfo
+
+func (m *PlanNodePool) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PlanNodePool) GetResources() []*PlanResourceChange {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type PlanResourceChange struct {
+	Address              string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Mode                 string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Type                 string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Name                 string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Action               string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Attributes           []*PlanAttributeChange `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PlanResourceChange) Reset()         { *m = PlanResourceChange{} }
+func (m *PlanResourceChange) String() string { return proto.CompactTextString(m) }
+func (*PlanResourceChange) ProtoMessage()    {}
+func (*PlanResourceChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d655ab2f7683c23, []int{3}
+}
+
+func (m *PlanResourceChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanResourceChange.Unmarshal(m, b)
+}
+func (m *PlanResourceChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanResourceChange.Marshal(b, m, deterministic)
+}
+func (m *PlanResourceChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanResourceChange.Merge(m, src)
+}
+func (m *PlanResourceChange) XXX_Size() int {
+	return xxx_messageInfo_PlanResourceChange.Size(m)
+}
+func (m *PlanResourceChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanResourceChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanResourceChange proto.InternalMessageInfo
+
+func (m *PlanResourceChange) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *PlanResourceChange) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *PlanResourceChange) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PlanResourceChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PlanResourceChange) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *PlanResourceChange) GetAttributes() []*PlanAttributeChange {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type PlanAttributeChange struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Before               string   `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After                string   `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	Computed             bool     `protobuf:"varint,4,opt,name=computed,proto3" json:"computed,omitempty"`
+	Sensitive            bool     `protobuf:"varint,5,opt,name=sensitive,proto3" json:"sensitive,omitempty"`
+	ForcesReplacement    bool     `protobuf:"varint,6,opt,name=forces_replacement,json=forcesReplacement,proto3" json:"forces_replacement,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlanAttributeChange) Reset()         { *m = PlanAttributeChange{} }
+func (m *PlanAttributeChange) String() string { return proto.CompactTextString(m) }
+func (*PlanAttributeChange) ProtoMessage()    {}
+func (*PlanAttributeChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2d655ab2f7683c23, []int{4}
+}
+
+func (m *PlanAttributeChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlanAttributeChange.Unmarshal(m, b)
+}
+func (m *PlanAttributeChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlanAttributeChange.Marshal(b, m, deterministic)
+}
+func (m *PlanAttributeChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlanAttributeChange.Merge(m, src)
+}
+func (m *PlanAttributeChange) XXX_Size() int {
+	return xxx_messageInfo_PlanAttributeChange.Size(m)
+}
+func (m *PlanAttributeChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlanAttributeChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlanAttributeChange proto.InternalMessageInfo
+
+func (m *PlanAttributeChange) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PlanAttributeChange) GetBefore() string {
+	if m != nil {
+		return m.Before
+	}
+	return ""
+}
+
+func (m *PlanAttributeChange) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *PlanAttributeChange) GetComputed() bool {
+	if m != nil {
+		return m.Computed
+	}
+	return false
+}
+
+func (m *PlanAttributeChange) GetSensitive() bool {
+	if m != nil {
+		return m.Sensitive
+	}
+	return false
+}
+
+func (m *PlanAttributeChange) GetForcesReplacement() bool {
+	if m != nil {
+		return m.ForcesReplacement
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*PlanClusterRequest)(nil), "kubekit.v1.PlanClusterRequest")
+	proto.RegisterType((*PlanClusterResponse)(nil), "kubekit.v1.PlanClusterResponse")
+	proto.RegisterType((*PlanNodePool)(nil), "kubekit.v1.PlanNodePool")
+	proto.RegisterType((*PlanResourceChange)(nil), "kubekit.v1.PlanResourceChange")
+	proto.RegisterType((*PlanAttributeChange)(nil), "kubekit.v1.PlanAttributeChange")
+}
+
+func init() { proto.RegisterFile("plan.proto", fileDescriptor_2d655ab2f7683c23) }
+
+var fileDescriptor_2d655ab2f7683c23 = []byte{
+	// 469 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x95, 0xd3, 0xc4, 0x8d, 0x27, 0x3d, 0xc0, 0x16, 0xaa, 0x15, 0x9f, 0x21, 0xa7, 0x5c, 0x88,
+	0x54, 0x38, 0x70, 0x41, 0x42, 0xa5, 0x9c, 0xab, 0x6a, 0x8f, 0x5c, 0xcc, 0xc6, 0x3b, 0x01, 0xab,
+	0xf6, 0x8e, 0xd9, 0x1d, 0x47, 0xea, 0x9f, 0xe2, 0x37, 0x20, 0xf1, 0xc7, 0x90, 0x37, 0xeb, 0xc4,
+	0x2d, 0x3d, 0x71, 0x9b, 0x37, 0x6f, 0x3c, 0x33, 0xef, 0xed, 0x18, 0xa0, 0xa9, 0xb4, 0x5d, 0x35,
+	0x8e, 0x98, 0x04, 0xdc, 0xb4, 0x6b, 0xbc, 0x29, 0x79, 0xb5, 0x3d, 0x5f, 0x14, 0x20, 0xae, 0x2b,
+	0x6d, 0x2f, 0xab, 0xd6, 0x33, 0x3a, 0x85, 0x3f, 0x5b, 0xf4, 0x2c, 0x1e, 0xc1, 0x91, 0x6e, 0x4a,
+	0x99, 0xcc, 0x93, 0x65, 0xa6, 0xba, 0x50, 0xbc, 0x81, 0x93, 0x62, 0x57, 0x93, 0x5b, 0x5d, 0xa3,
+	0x1c, 0x05, 0x6a, 0x16, 0x73, 0x57, 0xba, 0x46, 0x21, 0xe1, 0xd8, 0xa0, 0x67, 0x47, 0xb7, 0xf2,
+	0x68, 0x9e, 0x2c, 0xa7, 0xaa, 0x87, 0x8b, 0x5f, 0x23, 0x38, 0xbd, 0x33, 0xc5, 0x37, 0x64, 0x3d,
+	0xfe, 0xdf, 0x98, 0x67, 0x30, 0x6d, 0x2a, 0xcd, 0x1b, 0x72, 0x75, 0x98, 0x93, 0xa9, 0x3d, 0x1e,
+	0xae, 0x30, 0xbe, 0xb3, 0x82, 0x78, 0x0a, 0x29, 0x53, 0xae, 0x8d, 0x91, 0x93, 0x79, 0xb2, 0x9c,
+	0xa8, 0x09, 0xd3, 0x85, 0x31, 0xe2, 0x39, 0x64, 0x4c, 0x79, 0xf1, 0x43, 0xdb, 0xef, 0x28, 0xd3,
+	0xc0, 0x4c, 0x99, 0x2e, 0x03, 0x16, 0x2f, 0x01, 0x98, 0xf2, 0xbe, 0xe1, 0x71, 0x60, 0x33, 0xa6,
+	0x2f, 0xb1, 0xe5, 0x1c, 0x66, 0x81, 0x6b, 0x0b, 0x2e, 0xb7, 0x28, 0xa7, 0x61, 0xe0, 0x30, 0x25,
+	0x3e, 0x00, 0x58, 0x32, 0x98, 0x37, 0x44, 0x95, 0x97, 0xd9, 0xfc, 0x68, 0x39, 0x7b, 0x27, 0x57,
+	0x07, 0xf7, 0x57, 0x9d, 0x29, 0x57, 0x64, 0xf0, 0x9a, 0xa8, 0x52, 0x99, 0x8d, 0x91, 0x5f, 0x7c,
+	0x83, 0x93, 0x21, 0x25, 0x04, 0x8c, 0x83, 0x1d, 0x3b, 0xa7, 0x42, 0x2c, 0x3e, 0x42, 0xe6, 0xd0,
+	0x53, 0xeb, 0x0a, 0xf4, 0x72, 0x14, 0x7a, 0xbf, 0xba, 0xdf, 0x5b, 0xc5, 0x82, 0x9d, 0x20, 0x75,
+	0xf8, 0x60, 0xf1, 0x27, 0x01, 0xf1, 0x6f, 0x45, 0x67, 0xa0, 0x36, 0xc6, 0xa1, 0xf7, 0x71, 0x56,
+	0x0f, 0xbb, 0x15, 0x6a, 0x32, 0xfd, 0x8b, 0x84, 0xb8, 0xcb, 0xf1, 0x6d, 0x83, 0xf1, 0x19, 0x42,
+	0xbc, 0x5f, 0x75, 0x3c, 0x58, 0xf5, 0x0c, 0x52, 0x5d, 0x70, 0x49, 0x36, 0x98, 0x9f, 0xa9, 0x88,
+	0xc4, 0x27, 0x00, 0xcd, 0xec, 0xca, 0x75, 0xcb, 0xe8, 0x65, 0x1a, 0x34, 0xbc, 0xbe, 0xaf, 0xe1,
+	0xa2, 0xaf, 0x88, 0x22, 0x06, 0x9f, 0x2c, 0x7e, 0x27, 0x70, 0xfa, 0x40, 0xcd, 0x83, 0x7e, 0x9d,
+	0x41, 0xba, 0xc6, 0x0d, 0xb9, 0x5e, 0x42, 0x44, 0xe2, 0x09, 0x4c, 0xf4, 0x86, 0xd1, 0x45, 0x15,
+	0x3b, 0xd0, 0x5d, 0x59, 0x41, 0x75, 0xd3, 0x32, 0x9a, 0x78, 0x4a, 0x7b, 0x2c, 0x5e, 0x40, 0xe6,
+	0xd1, 0xfa, 0x32, 0x3c, 0xfb, 0x24, 0x90, 0x87, 0x84, 0x78, 0x0b, 0x62, 0x43, 0x9d, 0xc7, 0xb9,
+	0xc3, 0xa6, 0xd2, 0x05, 0xd6, 0x68, 0x39, 0xdc, 0xd6, 0x54, 0x3d, 0xde, 0x31, 0xea, 0x40, 0x7c,
+	0x1e, 0x7f, 0x1d, 0x6d, 0xcf, 0xd7, 0x69, 0xf8, 0x33, 0xdf, 0xff, 0x1d, 0x00, 0xbb, 0xe2, 0x23,
+	0x4b, 0xa7, 0x03, 0x00, 0x00,
+}
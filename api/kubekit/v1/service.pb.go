@@ -29,51 +29,68 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("service.proto", fileDescriptor_a0b84a42fa06f626) }
 
 var fileDescriptor_a0b84a42fa06f626 = []byte{
-	// 690 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdd, 0x4e, 0x13, 0x41,
-	0x18, 0x4d, 0x97, 0x9f, 0x9a, 0x01, 0x04, 0x46, 0x12, 0x70, 0x21, 0x38, 0x56, 0x2d, 0xb1, 0xd2,
-	0x6e, 0x8b, 0x68, 0x4c, 0x0d, 0x51, 0xa4, 0x89, 0xc1, 0x12, 0x23, 0x05, 0x4c, 0xc4, 0x0b, 0xb2,
-	0xec, 0x7e, 0x6e, 0x87, 0x6e, 0x67, 0xd6, 0x9d, 0xe9, 0xa2, 0x31, 0xc6, 0xc4, 0x47, 0xd0, 0x78,
-	0xe3, 0xcb, 0x78, 0xe9, 0x03, 0xf8, 0x0a, 0x5e, 0xfa, 0x10, 0x66, 0x67, 0x67, 0x63, 0x57, 0x0a,
-	0xbd, 0xda, 0x9d, 0x73, 0xbe, 0x39, 0xe7, 0x3b, 0xdf, 0xce, 0x0e, 0x9a, 0x12, 0x10, 0x46, 0xd4,
-	0x81, 0x4a, 0x10, 0x72, 0xc9, 0x31, 0xea, 0xf4, 0x8e, 0xa1, 0x43, 0x65, 0x25, 0xaa, 0x99, 0x4b,
-	0x1e, 0xe7, 0x9e, 0x0f, 0x96, 0x1d, 0x50, 0xcb, 0x66, 0x8c, 0x4b, 0x5b, 0x52, 0xce, 0x44, 0x52,
-	0x69, 0xae, 0xaa, 0x87, 0x53, 0xf6, 0x80, 0x95, 0xc5, 0xa9, 0xed, 0x79, 0x10, 0x5a, 0x3c, 0x50,
-	0x15, 0x03, 0xaa, 0xa7, 0x22, 0x08, 0x05, 0xe5, 0x4c, 0x2f, 0x27, 0x24, 0xef, 0x40, 0xba, 0x40,
-	0x94, 0x51, 0x99, 0x12, 0x76, 0x10, 0xf8, 0xef, 0xf5, 0x62, 0xd2, 0x05, 0x1f, 0xa4, 0x6e, 0xcd,
-	0x9c, 0xf5, 0x40, 0x1e, 0x39, 0x7e, 0x4f, 0x48, 0x08, 0x35, 0x74, 0xd9, 0x05, 0xe1, 0x84, 0xf4,
-	0x38, 0x2d, 0x99, 0xec, 0x05, 0xae, 0x9d, 0x6e, 0x58, 0xfb, 0x93, 0x47, 0xf9, 0x66, 0x12, 0x07,
-	0xbf, 0x46, 0xf9, 0x97, 0x49, 0x07, 0xd8, 0xac, 0xfc, 0xcb, 0x58, 0xd1, 0x60, 0x0b, 0xde, 0xf6,
-	0x40, 0x48, 0x73, 0x71, 0x20, 0x27, 0x02, 0xce, 0x04, 0x14, 0xe6, 0x3f, 0xff, 0xfa, 0xfd, 0xd5,
-	0x98, 0xc5, 0xd3, 0x6a, 0x22, 0x51, 0xcd, 0xd2, 0x99, 0xf0, 0x09, 0x1a, 0xdb, 0x8f, 0xf3, 0xe0,
-	0x85, 0xfe, 0xed, 0x0a, 0x4a, 0x85, 0xaf, 0x0e, 0x60, 0xb4, 0xec, 0xaa, 0x92, 0x2d, 0xe2, 0x9b,
-	0xa9, 0xac, 0xce, 0x68, 0x7d, 0xd0, 0x2f, 0x47, 0xcc, 0xee, 0xc2, 0x47, 0x4b, 0x8d, 0x0c, 0x1f,
-	0xa0, 0xd1, 0x6d, 0x46, 0x25, 0x9e, 0xef, 0x17, 0x8c, 0x91, 0xd4, 0x69, 0xe1, 0x2c, 0xa1, 0x8d,
-	0x4c, 0x65, 0x34, 0x57, 0x98, 0xfe, 0xcf, 0xa8, 0x9e, 0x2b, 0x61, 0x0f, 0x8d, 0x6d, 0xc6, 0x93,
-	0xcf, 0x46, 0x50, 0xd0, 0xc0, 0x08, 0x9a, 0xd1, 0xca, 0xb7, 0x95, 0xf2, 0x8d, 0xc2, 0xf2, 0xc5,
-	0x11, 0x12, 0xa3, 0xf1, 0x86, 0xfa, 0xaa, 0x38, 0xa3, 0x97, 0x60, 0xa9, 0x95, 0x39, 0x88, 0xd2,
-	0x5e, 0x45, 0xe5, 0x45, 0x4a, 0x43, 0xbc, 0xb0, 0x87, 0x26, 0x9e, 0x82, 0xdc, 0x4a, 0x30, 0x81,
-	0x97, 0xfb, 0x25, 0xfb, 0x88, 0xd4, 0xf2, 0xda, 0xb9, 0xfc, 0x79, 0x5f, 0x5f, 0xdb, 0xe1, 0x2e,
-	0xba, 0xd4, 0xd0, 0xc7, 0x10, 0x2f, 0x66, 0x1b, 0x4f, 0xd0, 0xd4, 0x62, 0x69, 0x30, 0x99, 0xcd,
-	0x85, 0x87, 0xe5, 0xfa, 0x96, 0x43, 0x57, 0x92, 0x91, 0xe8, 0x16, 0xb7, 0x38, 0x7b, 0x43, 0x3d,
-	0x5c, 0x3c, 0x3b, 0xb3, 0x4c, 0x41, 0xda, 0xc5, 0xca, 0xd0, 0x3a, 0xdd, 0x50, 0x59, 0x35, 0xb4,
-	0x52, 0xba, 0x35, 0xe4, 0x5c, 0x3a, 0x89, 0xff, 0x27, 0x34, 0x75, 0xa0, 0xfe, 0x3e, 0xad, 0x86,
-	0x49, 0xbf, 0x51, 0x86, 0x4a, 0x5b, 0xb9, 0x7e, 0x41, 0x45, 0xf6, 0x64, 0x99, 0xc3, 0x4f, 0xd6,
-	0x93, 0x9f, 0xc6, 0x97, 0xcd, 0x1f, 0x06, 0xde, 0x46, 0xd3, 0xf1, 0x4f, 0xdf, 0xa4, 0x92, 0xec,
-	0x25, 0x57, 0x5b, 0xa1, 0x96, 0xdc, 0x03, 0x4d, 0x2a, 0xf1, 0x5c, 0x5b, 0xca, 0x40, 0xd4, 0x2d,
-	0x2b, 0x75, 0x76, 0x21, 0xb2, 0xcc, 0x19, 0x09, 0x76, 0xf7, 0x71, 0x1f, 0xb4, 0x36, 0x52, 0xab,
-	0x54, 0x4b, 0x46, 0xce, 0x58, 0x9b, 0x89, 0x2f, 0x24, 0xea, 0xa8, 0xcb, 0xcc, 0x3a, 0x11, 0x9c,
-	0xd5, 0xcf, 0x20, 0xad, 0x87, 0x68, 0x64, 0xbd, 0xba, 0x8e, 0xd7, 0x51, 0xa9, 0x05, 0xb2, 0x17,
-	0x32, 0x70, 0xc9, 0x69, 0x1b, 0x18, 0x91, 0x6d, 0x20, 0x21, 0x08, 0xde, 0x0b, 0x1d, 0x20, 0x2e,
-	0x07, 0x41, 0x18, 0x97, 0x04, 0xde, 0x51, 0x21, 0x2b, 0x78, 0x1c, 0x8d, 0x7e, 0x37, 0x72, 0xf9,
-	0xd6, 0x66, 0xbc, 0xb9, 0x8a, 0xeb, 0xe8, 0x41, 0x76, 0xb3, 0x4d, 0xc2, 0x64, 0x4c, 0x84, 0x0a,
-	0x42, 0x59, 0x64, 0xfb, 0xd4, 0x25, 0x3c, 0x24, 0x5d, 0x2a, 0x04, 0x65, 0x1e, 0x09, 0xec, 0xd0,
-	0xee, 0x42, 0x7c, 0x44, 0xc3, 0x67, 0x68, 0x31, 0x8d, 0xdc, 0x80, 0x08, 0x7c, 0x1e, 0x74, 0x81,
-	0x49, 0x52, 0x26, 0x7b, 0xbe, 0xed, 0x74, 0xf0, 0x1d, 0x11, 0x3f, 0xea, 0x96, 0xe5, 0xb4, 0x6d,
-	0xc6, 0xc0, 0x7f, 0x14, 0xa7, 0xdd, 0xd8, 0xdf, 0x7d, 0x5e, 0xdd, 0xb9, 0x77, 0x78, 0x50, 0x2b,
-	0x52, 0x77, 0x63, 0x6b, 0xf7, 0xd5, 0xfd, 0x17, 0xcd, 0x9d, 0x46, 0xe3, 0xd0, 0x88, 0x6a, 0xc7,
-	0xe3, 0xea, 0xfe, 0xbc, 0xfb, 0x37, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x93, 0x2e, 0x38, 0x1c, 0x06,
-	0x00, 0x00,
+	// 975 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0xff, 0x6e, 0x1b, 0x45,
+	0x10, 0xc7, 0x65, 0x87, 0x24, 0x68, 0xed, 0xd4, 0xc9, 0xb6, 0xa2, 0xa9, 0x53, 0xc2, 0x71, 0x01,
+	0xb7, 0xb8, 0x8d, 0xcf, 0x4e, 0x0b, 0x42, 0x96, 0x2a, 0x48, 0x6d, 0x54, 0x95, 0x44, 0xd0, 0x3a,
+	0x0d, 0x12, 0xe5, 0x8f, 0x6a, 0x73, 0x1e, 0xce, 0x5b, 0x9f, 0x77, 0x2f, 0xbb, 0x6b, 0x17, 0x54,
+	0x95, 0x48, 0x88, 0x27, 0x00, 0x21, 0xf1, 0x00, 0x3c, 0x07, 0x7f, 0xf2, 0x02, 0xbc, 0x02, 0x0f,
+	0x82, 0x6e, 0x6f, 0xd7, 0xb9, 0x4b, 0x2e, 0x76, 0xf8, 0xcb, 0xb9, 0xf9, 0xce, 0xcc, 0x67, 0x66,
+	0xb2, 0xbf, 0xd0, 0x8a, 0x04, 0x31, 0xa1, 0x3e, 0x34, 0x22, 0xc1, 0x15, 0xc7, 0x68, 0x38, 0x3e,
+	0x82, 0x21, 0x55, 0x8d, 0x49, 0xab, 0x7a, 0x33, 0xe0, 0x3c, 0x08, 0xc1, 0x23, 0x11, 0xf5, 0x08,
+	0x63, 0x5c, 0x11, 0x45, 0x39, 0x93, 0x89, 0x67, 0xf5, 0xae, 0xfe, 0xf1, 0xb7, 0x03, 0x60, 0xdb,
+	0xf2, 0x15, 0x09, 0x02, 0x10, 0x1e, 0x8f, 0xb4, 0x47, 0x8e, 0xf7, 0xca, 0x04, 0x84, 0xa4, 0x9c,
+	0x99, 0xcf, 0x92, 0xe2, 0x43, 0xb0, 0x1f, 0x88, 0x32, 0xaa, 0xac, 0x40, 0xa2, 0x28, 0xfc, 0xd1,
+	0x7c, 0x94, 0xfb, 0x10, 0x82, 0x32, 0xa5, 0x55, 0xd7, 0x02, 0x50, 0x2f, 0xfc, 0x70, 0x2c, 0x15,
+	0x08, 0x63, 0xba, 0xd2, 0x07, 0xe9, 0x0b, 0x7a, 0x64, 0x5d, 0xca, 0xe3, 0xa8, 0x4f, 0xa6, 0x01,
+	0x25, 0x98, 0x00, 0xb3, 0x89, 0x2b, 0x3c, 0x02, 0xa1, 0x4b, 0xb2, 0xbe, 0x47, 0xc4, 0x1f, 0x8e,
+	0x23, 0x5b, 0x43, 0x14, 0x92, 0x69, 0x71, 0xfe, 0x00, 0xfc, 0x61, 0xf2, 0xb1, 0xf3, 0xe7, 0x2a,
+	0x5a, 0xde, 0x4b, 0x66, 0x82, 0xbf, 0x43, 0xcb, 0xdf, 0x24, 0x6d, 0xe0, 0x6a, 0xe3, 0x74, 0x50,
+	0x0d, 0x63, 0xec, 0xc1, 0xf1, 0x18, 0xa4, 0xaa, 0x6e, 0xe4, 0x6a, 0x32, 0xe2, 0x4c, 0x82, 0x7b,
+	0xfd, 0xe7, 0x7f, 0xfe, 0xfd, 0xad, 0xb8, 0x86, 0x2b, 0x7a, 0xac, 0x93, 0x96, 0x67, 0x06, 0x83,
+	0x5f, 0xa2, 0xc5, 0x67, 0xf1, 0x50, 0xf0, 0x7a, 0x3a, 0x5c, 0x9b, 0x6c, 0xe2, 0x1b, 0x39, 0x8a,
+	0x49, 0x7b, 0x57, 0xa7, 0xad, 0xe1, 0x0f, 0x6c, 0x5a, 0x33, 0x28, 0xef, 0xb5, 0xf9, 0xe3, 0x05,
+	0x23, 0x23, 0x78, 0xe3, 0xe9, 0xb9, 0xe3, 0x43, 0xf4, 0xd6, 0x63, 0x46, 0x15, 0xbe, 0x9e, 0x4e,
+	0x18, 0x5b, 0x2c, 0x69, 0xfd, 0xbc, 0x60, 0x40, 0x55, 0x0d, 0xba, 0xe6, 0x56, 0xce, 0x80, 0xda,
+	0x85, 0x3a, 0x0e, 0xd0, 0xe2, 0x6e, 0xfc, 0xef, 0xcb, 0xb6, 0xa0, 0x4d, 0xb9, 0x2d, 0x18, 0xc5,
+	0x64, 0xfe, 0x48, 0x67, 0xde, 0x72, 0x37, 0x67, 0xb7, 0x10, 0x83, 0x18, 0x2a, 0xe9, 0xd8, 0x03,
+	0x25, 0x80, 0x8c, 0x66, 0xe0, 0xd6, 0xd2, 0xca, 0x17, 0xf1, 0x72, 0x70, 0x9b, 0x1a, 0x53, 0x77,
+	0x3f, 0x9c, 0x33, 0x29, 0xa9, 0x73, 0xb7, 0x0b, 0xf5, 0x66, 0x01, 0x07, 0x68, 0xa9, 0xab, 0x97,
+	0x22, 0xce, 0xd4, 0x9f, 0xd8, 0x2c, 0xab, 0x9a, 0x27, 0x99, 0xde, 0x6a, 0x1a, 0xea, 0xd4, 0xe7,
+	0xf4, 0x86, 0x47, 0xa8, 0x9c, 0x44, 0x9a, 0xce, 0x66, 0xe0, 0x72, 0x5a, 0xdb, 0xd6, 0x94, 0x5b,
+	0xf5, 0xcb, 0xb5, 0xa6, 0xfb, 0x2a, 0x3d, 0x02, 0xd5, 0x49, 0x44, 0x89, 0x37, 0xd3, 0x29, 0x53,
+	0x82, 0x45, 0xbe, 0x77, 0xa1, 0x7e, 0xd1, 0xe2, 0x36, 0x5c, 0x3c, 0x42, 0x6f, 0x77, 0xcd, 0x56,
+	0xc5, 0x1b, 0xd9, 0x9e, 0x12, 0xab, 0x45, 0xdc, 0xcc, 0x17, 0xb3, 0x63, 0xc4, 0xf3, 0xc6, 0xf8,
+	0x7b, 0x01, 0x5d, 0x4d, 0xa6, 0x65, 0x4a, 0xec, 0x70, 0xf6, 0x3d, 0x0d, 0x70, 0xed, 0xfc, 0x38,
+	0x33, 0x0e, 0xb6, 0x8a, 0x5b, 0x73, 0xfd, 0x4c, 0x41, 0x97, 0x9d, 0xb8, 0x9f, 0xf0, 0x4f, 0xd0,
+	0xca, 0xa1, 0x3e, 0xa1, 0x4c, 0x36, 0xec, 0xa4, 0x41, 0x19, 0xc9, 0x96, 0xf2, 0xfe, 0x0c, 0x8f,
+	0xec, 0xc6, 0xa9, 0x5e, 0x62, 0xe3, 0x08, 0x74, 0x65, 0x9f, 0x4a, 0xf5, 0xb5, 0x3d, 0x0b, 0x25,
+	0xce, 0xe4, 0xcf, 0x6a, 0xb6, 0x04, 0x77, 0x96, 0x8b, 0xa9, 0xe1, 0x86, 0xae, 0xe1, 0x2a, 0x5e,
+	0xb3, 0x35, 0x4c, 0x8f, 0x5b, 0x7c, 0x8c, 0xca, 0x8f, 0xe0, 0x34, 0x06, 0x9f, 0x5d, 0x45, 0x53,
+	0xc5, 0xf2, 0x9c, 0x8b, 0x1d, 0x0c, 0x6d, 0x53, 0xd3, 0xd6, 0xf1, 0x3b, 0xe7, 0x68, 0xde, 0x6b,
+	0xda, 0x7f, 0x83, 0x7f, 0x29, 0xa0, 0x4a, 0x87, 0x30, 0x1f, 0xc2, 0x53, 0x6c, 0xa6, 0x8b, 0x33,
+	0xa2, 0x25, 0x6f, 0xcd, 0xf4, 0x31, 0xf0, 0xdb, 0x1a, 0xee, 0xba, 0xef, 0xe6, 0xc3, 0x3d, 0x5f,
+	0xc7, 0xc5, 0xd3, 0x3e, 0x46, 0x4b, 0x0f, 0xf5, 0x25, 0x93, 0xdd, 0xc7, 0x89, 0x2d, 0xf7, 0xd8,
+	0xb0, 0x92, 0x41, 0x5d, 0xf6, 0xac, 0x4a, 0xae, 0xb2, 0x18, 0x39, 0x46, 0xcb, 0x3d, 0x90, 0x8a,
+	0x0b, 0xc8, 0x5e, 0x51, 0xc6, 0x98, 0x7b, 0x45, 0x4d, 0x35, 0x43, 0x6d, 0x69, 0xea, 0x1d, 0xb7,
+	0x36, 0x87, 0x2a, 0x92, 0xb8, 0x18, 0xfb, 0x13, 0x2a, 0x3d, 0x09, 0x09, 0xb3, 0xcb, 0x3a, 0x73,
+	0x90, 0xa4, 0x84, 0xdc, 0x83, 0x24, 0xa3, 0x9b, 0x12, 0x1a, 0xba, 0x84, 0xdb, 0xee, 0xd6, 0x9c,
+	0x12, 0xe2, 0x5b, 0x3b, 0xe6, 0x9f, 0xa0, 0x72, 0x27, 0xbe, 0xb4, 0x6d, 0x01, 0x19, 0x40, 0x5a,
+	0xc9, 0x5d, 0x63, 0x59, 0x87, 0xff, 0x79, 0xa3, 0xea, 0xc7, 0xc2, 0xc3, 0xbf, 0x8b, 0xbf, 0xee,
+	0xfe, 0x55, 0xc4, 0x8f, 0x51, 0x25, 0x7e, 0x2c, 0xec, 0x51, 0xe5, 0x1c, 0x24, 0xef, 0x2a, 0xb7,
+	0x95, 0xbc, 0x1f, 0xf6, 0xa8, 0xc2, 0xd7, 0x06, 0x4a, 0x45, 0xb2, 0xed, 0x79, 0x96, 0xdd, 0x87,
+	0x89, 0x57, 0x5d, 0x55, 0x40, 0x46, 0x9f, 0xa7, 0x4c, 0x3b, 0x0b, 0xad, 0x46, 0xb3, 0x5e, 0x2c,
+	0x14, 0x77, 0x56, 0xe3, 0xd7, 0x10, 0xf5, 0x93, 0xc5, 0xf5, 0x52, 0x72, 0xd6, 0x3e, 0x67, 0xe9,
+	0xb5, 0xd1, 0xc2, 0xfd, 0xe6, 0x7d, 0x7c, 0x0f, 0xd5, 0x7b, 0xa0, 0xc6, 0x82, 0x41, 0xdf, 0x79,
+	0x35, 0x00, 0xe6, 0xa8, 0x01, 0x38, 0x02, 0x24, 0x1f, 0x0b, 0x1f, 0x9c, 0x3e, 0x07, 0xe9, 0x30,
+	0xae, 0x1c, 0xf8, 0x81, 0x4a, 0xd5, 0xc0, 0x8b, 0x68, 0xe1, 0x8f, 0xe2, 0x72, 0x6f, 0x37, 0x8e,
+	0x6d, 0xe2, 0x36, 0xfa, 0x34, 0x1b, 0x4b, 0x1c, 0x91, 0xcc, 0xc9, 0xa1, 0xd2, 0xa1, 0x6c, 0x42,
+	0x42, 0xda, 0x77, 0xb8, 0x70, 0x46, 0x54, 0x4a, 0xca, 0x02, 0x27, 0x22, 0x82, 0x8c, 0x40, 0x81,
+	0x90, 0xe2, 0x4b, 0xb4, 0x61, 0x3b, 0xee, 0xc2, 0x04, 0x42, 0x1e, 0x8d, 0x80, 0x29, 0x67, 0xdb,
+	0x39, 0x08, 0x89, 0x3f, 0xc4, 0x77, 0x64, 0xfc, 0xd3, 0xf6, 0x3c, 0x7f, 0x40, 0x18, 0x83, 0xf0,
+	0xb3, 0xb8, 0xd9, 0x07, 0xcf, 0x9e, 0x7e, 0xd5, 0xdc, 0xff, 0xf8, 0xf9, 0x61, 0xab, 0x46, 0xfb,
+	0x0f, 0x3a, 0x4f, 0xbf, 0xfd, 0xe4, 0xc9, 0xde, 0x7e, 0xb7, 0xfb, 0xbc, 0x38, 0x69, 0x1d, 0x2d,
+	0xe9, 0x67, 0xd7, 0xbd, 0xff, 0x06, 0x00, 0x8c, 0x45, 0xd4, 0x47, 0x98, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -92,11 +109,20 @@ type KubekitClient interface {
 	Token(ctx context.Context, in *TokenRequest, opts ...grpc.CallOption) (*TokenResponse, error)
 	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error)
 	Apply(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (*ApplyResponse, error)
+	ApplyStream(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (Kubekit_ApplyStreamClient, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	DeleteStream(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (Kubekit_DeleteStreamClient, error)
 	GetClusters(ctx context.Context, in *GetClustersRequest, opts ...grpc.CallOption) (*GetClustersResponse, error)
 	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
 	DeleteClusterConfig(ctx context.Context, in *DeleteClusterConfigRequest, opts ...grpc.CallOption) (*DeleteClusterConfigResponse, error)
 	UpdateCluster(ctx context.Context, in *UpdateClusterRequest, opts ...grpc.CallOption) (*UpdateClusterResponse, error)
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error)
+	CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationResponse, error)
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	PlanCluster(ctx context.Context, in *PlanClusterRequest, opts ...grpc.CallOption) (*PlanClusterResponse, error)
+	CheckCluster(ctx context.Context, in *CheckClusterRequest, opts ...grpc.CallOption) (*CheckClusterResponse, error)
 }
 
 type kubekitClient struct {
@@ -143,6 +169,38 @@ func (c *kubekitClient) Apply(ctx context.Context, in *ApplyRequest, opts ...grp
 	return out, nil
 }
 
+func (c *kubekitClient) ApplyStream(ctx context.Context, in *ApplyRequest, opts ...grpc.CallOption) (Kubekit_ApplyStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Kubekit_serviceDesc.Streams[0], "/kubekit.v1.Kubekit/ApplyStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &kubekitApplyStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Kubekit_ApplyStreamClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type kubekitApplyStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *kubekitApplyStreamClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *kubekitClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	out := new(DeleteResponse)
 	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/Delete", in, out, opts...)
@@ -152,6 +210,38 @@ func (c *kubekitClient) Delete(ctx context.Context, in *DeleteRequest, opts ...g
 	return out, nil
 }
 
+func (c *kubekitClient) DeleteStream(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (Kubekit_DeleteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Kubekit_serviceDesc.Streams[1], "/kubekit.v1.Kubekit/DeleteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &kubekitDeleteStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Kubekit_DeleteStreamClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type kubekitDeleteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *kubekitDeleteStreamClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *kubekitClient) GetClusters(ctx context.Context, in *GetClustersRequest, opts ...grpc.CallOption) (*GetClustersResponse, error) {
 	out := new(GetClustersResponse)
 	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/GetClusters", in, out, opts...)
@@ -188,17 +278,89 @@ func (c *kubekitClient) UpdateCluster(ctx context.Context, in *UpdateClusterRequ
 	return out, nil
 }
 
+func (c *kubekitClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/ListOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kubekitClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error) {
+	out := new(GetOperationResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/GetOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kubekitClient) CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationResponse, error) {
+	out := new(CancelOperationResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/CancelOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kubekitClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (*BackupResponse, error) {
+	out := new(BackupResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/Backup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kubekitClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kubekitClient) PlanCluster(ctx context.Context, in *PlanClusterRequest, opts ...grpc.CallOption) (*PlanClusterResponse, error) {
+	out := new(PlanClusterResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/PlanCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kubekitClient) CheckCluster(ctx context.Context, in *CheckClusterRequest, opts ...grpc.CallOption) (*CheckClusterResponse, error) {
+	out := new(CheckClusterResponse)
+	err := c.cc.Invoke(ctx, "/kubekit.v1.Kubekit/CheckCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KubekitServer is the server API for Kubekit service.
 type KubekitServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	Token(context.Context, *TokenRequest) (*TokenResponse, error)
 	Init(context.Context, *InitRequest) (*InitResponse, error)
 	Apply(context.Context, *ApplyRequest) (*ApplyResponse, error)
+	ApplyStream(*ApplyRequest, Kubekit_ApplyStreamServer) error
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	DeleteStream(*DeleteRequest, Kubekit_DeleteStreamServer) error
 	GetClusters(context.Context, *GetClustersRequest) (*GetClustersResponse, error)
 	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
 	DeleteClusterConfig(context.Context, *DeleteClusterConfigRequest) (*DeleteClusterConfigResponse, error)
 	UpdateCluster(context.Context, *UpdateClusterRequest) (*UpdateClusterResponse, error)
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error)
+	CancelOperation(context.Context, *CancelOperationRequest) (*CancelOperationResponse, error)
+	Backup(context.Context, *BackupRequest) (*BackupResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	PlanCluster(context.Context, *PlanClusterRequest) (*PlanClusterResponse, error)
+	CheckCluster(context.Context, *CheckClusterRequest) (*CheckClusterResponse, error)
 }
 
 // UnimplementedKubekitServer can be embedded to have forward compatible implementations.
@@ -217,9 +379,15 @@ func (*UnimplementedKubekitServer) Init(ctx context.Context, req *InitRequest) (
 func (*UnimplementedKubekitServer) Apply(ctx context.Context, req *ApplyRequest) (*ApplyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Apply not implemented")
 }
+func (*UnimplementedKubekitServer) ApplyStream(req *ApplyRequest, srv Kubekit_ApplyStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ApplyStream not implemented")
+}
 func (*UnimplementedKubekitServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (*UnimplementedKubekitServer) DeleteStream(req *DeleteRequest, srv Kubekit_DeleteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DeleteStream not implemented")
+}
 func (*UnimplementedKubekitServer) GetClusters(ctx context.Context, req *GetClustersRequest) (*GetClustersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetClusters not implemented")
 }
@@ -232,6 +400,27 @@ func (*UnimplementedKubekitServer) DeleteClusterConfig(ctx context.Context, req
 func (*UnimplementedKubekitServer) UpdateCluster(ctx context.Context, req *UpdateClusterRequest) (*UpdateClusterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCluster not implemented")
 }
+func (*UnimplementedKubekitServer) ListOperations(ctx context.Context, req *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (*UnimplementedKubekitServer) GetOperation(ctx context.Context, req *GetOperationRequest) (*GetOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
+}
+func (*UnimplementedKubekitServer) CancelOperation(ctx context.Context, req *CancelOperationRequest) (*CancelOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOperation not implemented")
+}
+func (*UnimplementedKubekitServer) Backup(ctx context.Context, req *BackupRequest) (*BackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Backup not implemented")
+}
+func (*UnimplementedKubekitServer) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (*UnimplementedKubekitServer) PlanCluster(ctx context.Context, req *PlanClusterRequest) (*PlanClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanCluster not implemented")
+}
+func (*UnimplementedKubekitServer) CheckCluster(ctx context.Context, req *CheckClusterRequest) (*CheckClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCluster not implemented")
+}
 
 func RegisterKubekitServer(s *grpc.Server, srv KubekitServer) {
 	s.RegisterService(&_Kubekit_serviceDesc, srv)
@@ -309,6 +498,27 @@ func _Kubekit_Apply_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Kubekit_ApplyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ApplyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KubekitServer).ApplyStream(m, &kubekitApplyStreamServer{stream})
+}
+
+type Kubekit_ApplyStreamServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type kubekitApplyStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *kubekitApplyStreamServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Kubekit_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -327,6 +537,27 @@ func _Kubekit_Delete_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Kubekit_DeleteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeleteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KubekitServer).DeleteStream(m, &kubekitDeleteStreamServer{stream})
+}
+
+type Kubekit_DeleteStreamServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type kubekitDeleteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *kubekitDeleteStreamServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Kubekit_GetClusters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetClustersRequest)
 	if err := dec(in); err != nil {
@@ -399,6 +630,132 @@ func _Kubekit_UpdateCluster_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Kubekit_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/ListOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kubekit_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/GetOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).GetOperation(ctx, req.(*GetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kubekit_CancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).CancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/CancelOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).CancelOperation(ctx, req.(*CancelOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kubekit_Backup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).Backup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/Backup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).Backup(ctx, req.(*BackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kubekit_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kubekit_PlanCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).PlanCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/PlanCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).PlanCluster(ctx, req.(*PlanClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kubekit_CheckCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KubekitServer).CheckCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubekit.v1.Kubekit/CheckCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KubekitServer).CheckCluster(ctx, req.(*CheckClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Kubekit_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubekit.v1.Kubekit",
 	HandlerType: (*KubekitServer)(nil),
@@ -439,7 +796,46 @@ var _Kubekit_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCluster",
 			Handler:    _Kubekit_UpdateCluster_Handler,
 		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _Kubekit_ListOperations_Handler,
+		},
+		{
+			MethodName: "GetOperation",
+			Handler:    _Kubekit_GetOperation_Handler,
+		},
+		{
+			MethodName: "CancelOperation",
+			Handler:    _Kubekit_CancelOperation_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _Kubekit_Backup_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Kubekit_Restore_Handler,
+		},
+		{
+			MethodName: "PlanCluster",
+			Handler:    _Kubekit_PlanCluster_Handler,
+		},
+		{
+			MethodName: "CheckCluster",
+			Handler:    _Kubekit_CheckCluster_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ApplyStream",
+			Handler:       _Kubekit_ApplyStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DeleteStream",
+			Handler:       _Kubekit_DeleteStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
 }
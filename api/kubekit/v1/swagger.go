@@ -295,6 +295,90 @@ var Swagger = []byte(`
         ]
       }
     },
+    "/api/v1/cluster/{cluster_name}/backup": {
+      "post": {
+        "operationId": "Backup",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1BackupResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "cluster_name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1BackupRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
+    "/api/v1/cluster/{cluster_name}/check": {
+      "get": {
+        "operationId": "CheckCluster",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1CheckClusterResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "cluster_name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
     "/api/v1/cluster/{cluster_name}/config": {
       "delete": {
         "operationId": "DeleteClusterConfig",
@@ -336,6 +420,181 @@ var Swagger = []byte(`
         ]
       }
     },
+    "/api/v1/cluster/{cluster_name}/plan": {
+      "post": {
+        "operationId": "PlanCluster",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1PlanClusterResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "cluster_name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1PlanClusterRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
+    "/api/v1/cluster/{cluster_name}/restore": {
+      "post": {
+        "operationId": "Restore",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1RestoreResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "cluster_name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1RestoreRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
+    "/api/v1/cluster/{cluster_name}/stream": {
+      "delete": {
+        "operationId": "DeleteStream",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "$ref": "#/x-stream-definitions/v1Event"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "cluster_name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "destroy_all",
+            "in": "query",
+            "required": false,
+            "type": "boolean",
+            "format": "boolean"
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      },
+      "post": {
+        "operationId": "ApplyStream",
+        "responses": {
+          "200": {
+            "description": "A successful response.(streaming responses)",
+            "schema": {
+              "$ref": "#/x-stream-definitions/v1Event"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "cluster_name",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1ApplyRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
     "/api/v1/cluster/{cluster_name}/token": {
       "get": {
         "operationId": "Token",
@@ -383,6 +642,131 @@ var Swagger = []byte(`
         ]
       }
     },
+    "/api/v1/operation": {
+      "get": {
+        "operationId": "ListOperations",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1ListOperationsResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "cluster_name",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
+    "/api/v1/operation/{id}": {
+      "get": {
+        "operationId": "GetOperation",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1GetOperationResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "api",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
+    "/api/v1/operation/{id}/cancel": {
+      "post": {
+        "operationId": "CancelOperation",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/v1CancelOperationResponse"
+            }
+          },
+          "400": {
+            "description": "Returned when a request is invalid or missing parameters",
+            "schema": {}
+          },
+          "404": {
+            "description": "Returned when the resource does not exist.",
+            "schema": {
+              "type": "string",
+              "format": "string"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "id",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/v1CancelOperationRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Kubekit"
+        ]
+      }
+    },
     "/api/v1/version": {
       "get": {
         "operationId": "Version",
@@ -417,27 +801,217 @@ var Swagger = []byte(`
           "Kubekit"
         ]
       }
-    }
-  },
-  "definitions": {
-    "protobufNullValue": {
-      "type": "string",
-      "enum": [
-        "NULL_VALUE"
-      ],
-      "default": "NULL_VALUE",
-      "description": "` + "`" + `NullValue` + "`" + ` is a singleton enumeration to represent the null value for the\n` + "`" + `Value` + "`" + ` type union.\n\n The JSON representation for ` + "`" + `NullValue` + "`" + ` is JSON ` + "`" + `null` + "`" + `.\n\n - NULL_VALUE: Null value."
+    }
+  },
+  "definitions": {
+    "kubekitv1Operation": {
+      "type": "object",
+      "properties": {
+        "id": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "action": {
+          "type": "string"
+        },
+        "state": {
+          "$ref": "#/definitions/v1OperationState"
+        },
+        "start_time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "end_time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "steps": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/v1OperationStep"
+          }
+        },
+        "status": {
+          "type": "string"
+        },
+        "error": {
+          "type": "string"
+        }
+      }
+    },
+    "protobufAny": {
+      "type": "object",
+      "properties": {
+        "type_url": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "protobufNullValue": {
+      "type": "string",
+      "enum": [
+        "NULL_VALUE"
+      ],
+      "default": "NULL_VALUE",
+      "description": "` + "`" + `NullValue` + "`" + ` is a singleton enumeration to represent the null value for the\n` + "`" + `Value` + "`" + ` type union.\n\n The JSON representation for ` + "`" + `NullValue` + "`" + ` is JSON ` + "`" + `null` + "`" + `.\n\n - NULL_VALUE: Null value."
+    },
+    "runtimeStreamError": {
+      "type": "object",
+      "properties": {
+        "grpc_code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "http_code": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "message": {
+          "type": "string"
+        },
+        "http_status": {
+          "type": "string"
+        },
+        "details": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protobufAny"
+          }
+        }
+      }
+    },
+    "v1ApplyAction": {
+      "type": "string",
+      "enum": [
+        "ALL",
+        "PROVISION",
+        "CONFIGURE"
+      ],
+      "default": "ALL"
+    },
+    "v1ApplyRequest": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "action": {
+          "$ref": "#/definitions/v1ApplyAction"
+        },
+        "package_url": {
+          "type": "string"
+        },
+        "force_package": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "ca_certs": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "v1ApplyResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "operation_id": {
+          "type": "string"
+        }
+      }
+    },
+    "v1Backup": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "release": {
+          "type": "string"
+        },
+        "etcd_version": {
+          "type": "string"
+        },
+        "size": {
+          "type": "string",
+          "format": "int64"
+        },
+        "created_at": {
+          "type": "string",
+          "format": "int64"
+        },
+        "path": {
+          "type": "string"
+        }
+      }
+    },
+    "v1BackupRequest": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        }
+      }
+    },
+    "v1BackupResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "backup": {
+          "$ref": "#/definitions/v1Backup"
+        }
+      }
+    },
+    "v1CancelOperationRequest": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "id": {
+          "type": "string"
+        }
+      }
     },
-    "v1ApplyAction": {
-      "type": "string",
-      "enum": [
-        "ALL",
-        "PROVISION",
-        "CONFIGURE"
-      ],
-      "default": "ALL"
+    "v1CancelOperationResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "operation": {
+          "$ref": "#/definitions/kubekitv1Operation"
+        }
+      }
     },
-    "v1ApplyRequest": {
+    "v1CheckClusterResponse": {
       "type": "object",
       "properties": {
         "api": {
@@ -446,32 +1020,43 @@ var Swagger = []byte(`
         "cluster_name": {
           "type": "string"
         },
-        "action": {
-          "$ref": "#/definitions/v1ApplyAction"
-        },
-        "package_url": {
+        "status": {
           "type": "string"
         },
-        "force_package": {
-          "type": "boolean",
-          "format": "boolean"
+        "pass": {
+          "type": "integer",
+          "format": "int32"
         },
-        "ca_certs": {
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
+        "warn": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "fail": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/v1CheckResult"
           }
         }
       }
     },
-    "v1ApplyResponse": {
+    "v1CheckResult": {
       "type": "object",
       "properties": {
-        "api": {
+        "check": {
+          "type": "string"
+        },
+        "node": {
           "type": "string"
         },
         "status": {
           "type": "string"
+        },
+        "message": {
+          "type": "string"
         }
       }
     },
@@ -551,6 +1136,9 @@ var Swagger = []byte(`
         },
         "status": {
           "type": "string"
+        },
+        "operation_id": {
+          "type": "string"
         }
       }
     },
@@ -577,6 +1165,56 @@ var Swagger = []byte(`
         }
       }
     },
+    "v1Event": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "type": {
+          "$ref": "#/definitions/v1EventType"
+        },
+        "step": {
+          "type": "string"
+        },
+        "location": {
+          "type": "string"
+        },
+        "resource": {
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "error": {
+          "type": "string"
+        },
+        "timestamp": {
+          "type": "string",
+          "format": "int64"
+        },
+        "operation_id": {
+          "type": "string"
+        }
+      }
+    },
+    "v1EventType": {
+      "type": "string",
+      "enum": [
+        "STEP_STARTED",
+        "STEP_FINISHED",
+        "RESOURCE",
+        "TASK",
+        "STATUS"
+      ],
+      "default": "STEP_STARTED"
+    },
     "v1GetClustersResponse": {
       "type": "object",
       "properties": {
@@ -591,6 +1229,17 @@ var Swagger = []byte(`
         }
       }
     },
+    "v1GetOperationResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "operation": {
+          "$ref": "#/definitions/kubekitv1Operation"
+        }
+      }
+    },
     "v1InitRequest": {
       "type": "object",
       "properties": {
@@ -640,6 +1289,20 @@ var Swagger = []byte(`
         }
       }
     },
+    "v1ListOperationsResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "operations": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/kubekitv1Operation"
+          }
+        }
+      }
+    },
     "v1Node": {
       "type": "object",
       "properties": {
@@ -692,6 +1355,156 @@ var Swagger = []byte(`
         }
       }
     },
+    "v1OperationState": {
+      "type": "string",
+      "enum": [
+        "OPERATION_RUNNING",
+        "OPERATION_SUCCEEDED",
+        "OPERATION_FAILED",
+        "OPERATION_CANCELED"
+      ],
+      "default": "OPERATION_RUNNING"
+    },
+    "v1OperationStep": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "start_time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "end_time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "error": {
+          "type": "string"
+        }
+      }
+    },
+    "v1PlanAttributeChange": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "before": {
+          "type": "string"
+        },
+        "after": {
+          "type": "string"
+        },
+        "computed": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "sensitive": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "forces_replacement": {
+          "type": "boolean",
+          "format": "boolean"
+        }
+      }
+    },
+    "v1PlanClusterRequest": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "destroy": {
+          "type": "boolean",
+          "format": "boolean"
+        }
+      }
+    },
+    "v1PlanClusterResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "platform": {
+          "type": "string"
+        },
+        "destroy": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "to_add": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "to_change": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "to_destroy": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "destructive": {
+          "type": "boolean",
+          "format": "boolean"
+        },
+        "node_pools": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/v1PlanNodePool"
+          }
+        }
+      }
+    },
+    "v1PlanNodePool": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "resources": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/v1PlanResourceChange"
+          }
+        }
+      }
+    },
+    "v1PlanResourceChange": {
+      "type": "object",
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "mode": {
+          "type": "string"
+        },
+        "type": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "action": {
+          "type": "string"
+        },
+        "attributes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/v1PlanAttributeChange"
+          }
+        }
+      }
+    },
     "v1PlatformName": {
       "type": "string",
       "enum": [
@@ -708,6 +1521,34 @@ var Swagger = []byte(`
       ],
       "default": "UNKNOWN"
     },
+    "v1RestoreRequest": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "cluster_name": {
+          "type": "string"
+        },
+        "from": {
+          "type": "string"
+        }
+      }
+    },
+    "v1RestoreResponse": {
+      "type": "object",
+      "properties": {
+        "api": {
+          "type": "string"
+        },
+        "status": {
+          "type": "string"
+        },
+        "operation_id": {
+          "type": "string"
+        }
+      }
+    },
     "v1Status": {
       "type": "string",
       "enum": [
@@ -793,6 +1634,9 @@ var Swagger = []byte(`
       "properties": {
         "api": {
           "type": "string"
+        },
+        "operation_id": {
+          "type": "string"
         }
       }
     },
@@ -817,6 +1661,20 @@ var Swagger = []byte(`
       }
     }
   },
+  "x-stream-definitions": {
+    "v1Event": {
+      "type": "object",
+      "properties": {
+        "result": {
+          "$ref": "#/definitions/v1Event"
+        },
+        "error": {
+          "$ref": "#/definitions/runtimeStreamError"
+        }
+      },
+      "title": "Stream result of v1Event"
+    }
+  },
   "externalDocs": {
     "description": "KubeKit Development - Slack",
     "url": "slack://channel?team=TQN0L5ZU1\u0026id=CQY6PKLDD"
@@ -101,6 +101,7 @@ func (m *UpdateClusterRequest) GetResources() []string {
 
 type UpdateClusterResponse struct {
 	Api                  string   `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	OperationId          string   `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -138,6 +139,13 @@ func (m *UpdateClusterResponse) GetApi() string {
 	return ""
 }
 
+func (m *UpdateClusterResponse) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*UpdateClusterRequest)(nil), "kubekit.v1.UpdateClusterRequest")
 	proto.RegisterMapType((map[string]string)(nil), "kubekit.v1.UpdateClusterRequest.CredentialsEntry")
@@ -148,23 +156,24 @@ func init() {
 func init() { proto.RegisterFile("update.proto", fileDescriptor_3f0fa214029f1c21) }
 
 var fileDescriptor_3f0fa214029f1c21 = []byte{
-	// 280 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0x4f, 0x4b, 0xc3, 0x40,
-	0x10, 0xc5, 0xc9, 0x9f, 0x16, 0x32, 0x29, 0x52, 0x96, 0x0a, 0x4b, 0xf1, 0x10, 0x7b, 0x8a, 0x97,
-	0x48, 0xf4, 0x22, 0x22, 0x1e, 0x2c, 0x1e, 0xf5, 0x10, 0xd1, 0x83, 0x17, 0xd9, 0x24, 0x73, 0x08,
-	0x9b, 0x6e, 0xe2, 0xfe, 0x09, 0xe4, 0xd3, 0xf8, 0x55, 0xa5, 0x9b, 0x96, 0x68, 0x15, 0xc4, 0xdb,
-	0xec, 0x1b, 0xde, 0x6f, 0x66, 0xdf, 0xc0, 0xcc, 0xb4, 0x25, 0xd3, 0x98, 0xb4, 0xb2, 0xd1, 0x0d,
-	0x01, 0x6e, 0x72, 0xe4, 0x95, 0x4e, 0xba, 0x74, 0xf5, 0xe1, 0xc1, 0xe2, 0xd9, 0x36, 0xd7, 0xb5,
-	0x51, 0x1a, 0x65, 0x86, 0xef, 0x06, 0x95, 0x26, 0x73, 0xf0, 0x58, 0x5b, 0x51, 0x27, 0x72, 0xe2,
-	0x20, 0xdb, 0x96, 0x84, 0x80, 0xcf, 0x2b, 0x51, 0x52, 0xd7, 0x4a, 0xb6, 0x26, 0xa7, 0x30, 0x2b,
-	0x06, 0xdf, 0x9b, 0x60, 0x1b, 0xa4, 0x9e, 0xed, 0x85, 0x3b, 0xed, 0x91, 0x6d, 0x90, 0x3c, 0x40,
-	0xd0, 0x31, 0x59, 0xb1, 0xbc, 0x46, 0x45, 0xfd, 0xc8, 0x8b, 0xc3, 0x8b, 0xf3, 0x64, 0xdc, 0x20,
-	0xf9, 0x6d, 0x7a, 0xf2, 0xb2, 0x77, 0xdc, 0x0b, 0x2d, 0xfb, 0x6c, 0x24, 0x90, 0x27, 0x08, 0x0b,
-	0x89, 0x25, 0x0a, 0x5d, 0xb1, 0x5a, 0xd1, 0x89, 0x05, 0xa6, 0x7f, 0x02, 0xd7, 0xa3, 0x67, 0x40,
-	0x7e, 0xa5, 0x90, 0x13, 0x08, 0x24, 0xaa, 0xc6, 0xc8, 0x02, 0x15, 0x9d, 0x46, 0x5e, 0x1c, 0x64,
-	0xa3, 0xb0, 0xbc, 0x81, 0xa3, 0xef, 0xfb, 0x6c, 0xc3, 0xe1, 0xd8, 0xef, 0xc3, 0xe1, 0xd8, 0x93,
-	0x05, 0x4c, 0x3a, 0x56, 0x1b, 0xdc, 0xa5, 0x33, 0x3c, 0xae, 0xdd, 0x2b, 0x67, 0x79, 0x0b, 0xf3,
-	0xc3, 0xe1, 0xff, 0xf1, 0xaf, 0xce, 0xe0, 0xf8, 0xe0, 0x47, 0xaa, 0x6d, 0x84, 0xc2, 0x9f, 0x17,
-	0xba, 0xf3, 0x5f, 0xdd, 0x2e, 0xcd, 0xa7, 0xf6, 0xca, 0x97, 0x9f, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xf0, 0xe5, 0x7b, 0xbc, 0xf5, 0x01, 0x00, 0x00,
+	// 295 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0x4f, 0x4b, 0xc3, 0x40,
+	0x10, 0xc5, 0x69, 0xd3, 0x16, 0x32, 0x29, 0x52, 0x96, 0x0a, 0x4b, 0xf1, 0xd0, 0xf6, 0xd4, 0x53,
+	0x24, 0x7a, 0x11, 0x11, 0x0f, 0x16, 0x0f, 0x82, 0x7a, 0x88, 0xe8, 0xc1, 0x4b, 0xd9, 0x24, 0x73,
+	0x58, 0x92, 0xee, 0xc6, 0xfd, 0x13, 0xc8, 0xa7, 0xf1, 0xab, 0x4a, 0x37, 0x8d, 0xd1, 0x52, 0x10,
+	0x6f, 0x93, 0x37, 0xbc, 0xdf, 0x4c, 0xde, 0x2c, 0x8c, 0x6d, 0x99, 0x31, 0x83, 0x61, 0xa9, 0xa4,
+	0x91, 0x04, 0x72, 0x9b, 0x60, 0xce, 0x4d, 0x58, 0x45, 0xcb, 0x4f, 0x0f, 0xa6, 0xaf, 0xae, 0xb9,
+	0x2e, 0xac, 0x36, 0xa8, 0x62, 0xfc, 0xb0, 0xa8, 0x0d, 0x99, 0x80, 0xc7, 0x4a, 0x4e, 0x7b, 0xf3,
+	0xde, 0xca, 0x8f, 0x77, 0x25, 0x21, 0x30, 0xc8, 0xb9, 0xc8, 0x68, 0xdf, 0x49, 0xae, 0x26, 0x0b,
+	0x18, 0xa7, 0x8d, 0x6f, 0x23, 0xd8, 0x16, 0xa9, 0xe7, 0x7a, 0xc1, 0x5e, 0x7b, 0x66, 0x5b, 0x24,
+	0x4f, 0xe0, 0x57, 0x4c, 0x71, 0x96, 0x14, 0xa8, 0xe9, 0x60, 0xee, 0xad, 0x82, 0x8b, 0xf3, 0xb0,
+	0xdb, 0x20, 0x3c, 0x36, 0x3d, 0x7c, 0x6b, 0x1d, 0xf7, 0xc2, 0xa8, 0x3a, 0xee, 0x08, 0xe4, 0x05,
+	0x82, 0x54, 0x61, 0x86, 0xc2, 0x70, 0x56, 0x68, 0x3a, 0x74, 0xc0, 0xe8, 0x4f, 0xe0, 0xba, 0xf3,
+	0x34, 0xc8, 0x9f, 0x14, 0x72, 0x06, 0xbe, 0x42, 0x2d, 0xad, 0x4a, 0x51, 0xd3, 0xd1, 0xdc, 0x5b,
+	0xf9, 0x71, 0x27, 0xcc, 0x6e, 0xe0, 0xe4, 0xf7, 0x3e, 0xbb, 0x70, 0x72, 0xac, 0xdb, 0x70, 0x72,
+	0xac, 0xc9, 0x14, 0x86, 0x15, 0x2b, 0x2c, 0xee, 0xd3, 0x69, 0x3e, 0xae, 0xfb, 0x57, 0xbd, 0xd9,
+	0x2d, 0x4c, 0x0e, 0x87, 0xff, 0xc7, 0xbf, 0x7c, 0x84, 0xd3, 0x83, 0x3f, 0xd2, 0xa5, 0x14, 0x1a,
+	0x8f, 0x5c, 0x68, 0x01, 0x63, 0x59, 0xa2, 0x62, 0x86, 0x4b, 0xb1, 0xe1, 0xed, 0xa5, 0x82, 0x6f,
+	0xed, 0x21, 0xbb, 0x1b, 0xbc, 0xf7, 0xab, 0x28, 0x19, 0xb9, 0x87, 0x70, 0xf9, 0x35, 0x00, 0xb5,
+	0x63, 0x55, 0x67, 0x18, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// RestoreOpts encapsulate all the CLI parameters received from the `restore` command
+type RestoreOpts struct {
+	ClusterName string
+	From        string
+	Timeout     time.Duration
+}
+
+// RestoreGetOpts get the `restore` command parameters from the cobra commands and arguments
+func RestoreGetOpts(cmd *cobra.Command, args []string) (opts *RestoreOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	var from string
+	if fromFlag := cmd.Flags().Lookup("from"); fromFlag != nil {
+		from = fromFlag.Value.String()
+	}
+	if len(from) == 0 {
+		return nil, warns, UserErrorf("requires the backup name or snapshot file to restore with the flag --from")
+	}
+
+	timeout := kluster.DefaultRestoreTimeout
+	if timeoutFlag := cmd.Flags().Lookup("timeout"); timeoutFlag != nil {
+		if timeout, err = time.ParseDuration(timeoutFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("invalid timeout %q. %s", timeoutFlag.Value.String(), err)
+		}
+	}
+
+	opts = &RestoreOpts{
+		ClusterName: clusterName,
+		From:        from,
+		Timeout:     timeout,
+	}
+
+	return opts, warns, nil
+}
@@ -171,8 +171,8 @@ func GetGenericCredentials(cmd *cobra.Command) map[string]string {
 	credMap := map[string]string{}
 
 	// Only check for AWS, Azure and vSphere. Any the other platform will have the
-	// same credential parameters as these 3. Do not use "vra", "raw" or "stacki",
-	// they do not have credentials
+	// same credential parameters as these 3, "stacki" and "vra" use the same
+	// parameters as vSphere and "raw" does not have credentials
 	for _, platform := range []string{"aws", "azure", "vsphere"} {
 		cred := GetCredentials(platform, cmd)
 		for k, v := range cred {
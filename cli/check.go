@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// CheckOpts encapsulate all the CLI parameters received from the `check` command
+type CheckOpts struct {
+	ClusterName string
+	Output      string
+	Pp          bool
+}
+
+// CheckGetOpts get the `check` command parameters from the cobra commands and arguments
+func CheckGetOpts(cmd *cobra.Command, args []string) (opts *CheckOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	// Get the flags `--output` and `--pp`
+	var output string
+	if outputFlag := cmd.Flags().Lookup("output"); outputFlag != nil {
+		output = outputFlag.Value.String()
+	}
+	switch output {
+	case "", "table", "json", "yaml":
+	default:
+		return nil, warns, UserErrorf("unknown check output format %q, the available formats are: 'table', 'json' and 'yaml'", output)
+	}
+	pp := false
+	if ppFlag := cmd.Flags().Lookup("pp"); ppFlag != nil {
+		pp = ppFlag.Value.String() == "true"
+	}
+
+	opts = &CheckOpts{
+		ClusterName: clusterName,
+		Output:      output,
+		Pp:          pp,
+	}
+
+	return opts, warns, nil
+}
+
+// CheckReport is the report of the diagnostics of the cluster
+type CheckReport kluster.CheckReport
+
+// Sprintf returns a string to print in the given format. Pretty Print (`pp`)
+// applies only for JSON
+func (cr *CheckReport) Sprintf(format string, pp bool) (string, error) {
+	switch format {
+	case "", "table":
+		return cr.Table(), nil
+	case "json":
+		return cr.JSON(pp)
+	case "yaml":
+		return cr.YAML()
+	default:
+		return "", UserErrorf("unknown format %q", format)
+	}
+}
+
+// JSON returns the check report in JSON format
+func (cr *CheckReport) JSON(pp bool) (string, error) {
+	var (
+		output []byte
+		err    error
+	)
+
+	if pp {
+		output, err = json.MarshalIndent(cr, "", "  ")
+	} else {
+		output, err = json.Marshal(cr)
+	}
+
+	return string(output), err
+}
+
+// YAML returns the check report in YAML format
+func (cr *CheckReport) YAML() (string, error) {
+	output, err := yaml.Marshal(cr)
+	return string(output), err
+}
+
+// Table returns the check report as a table, with a row for every diagnostic
+// followed by the summary
+func (cr *CheckReport) Table() string {
+	b := &bytes.Buffer{}
+	w := tabwriter.NewWriter(b, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(w, "Check\tNode\tStatus\tMessage\n")
+	for _, r := range cr.Results {
+		node := r.Node
+		if len(node) == 0 {
+			node = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Check, node, r.Status, r.Message)
+	}
+	w.Flush()
+
+	fmt.Fprintf(b, "\nCluster %q: %s. %d passed, %d warnings, %d failed\n", cr.ClusterName, cr.Status, cr.Pass, cr.Warn, cr.Fail)
+
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ConsoleOpts encapsulate all the CLI parameters received from the `console`
+// command
+type ConsoleOpts struct {
+	ClusterName string
+	Nodes       []string
+	Pools       []string
+}
+
+// ConsoleGetOpts get the `console` command parameters from the cobra commands
+// and arguments
+func ConsoleGetOpts(cmd *cobra.Command, args []string) (opts *ConsoleOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	// Nodes:
+	var nodes []string
+	if nodesFlag := cmd.Flags().Lookup("nodes"); nodesFlag != nil {
+		if nodes, err = StringToArray(nodesFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("failed to parse the list of nodes")
+		}
+	}
+
+	// Pools:
+	var pools []string
+	if poolsFlag := cmd.Flags().Lookup("pools"); poolsFlag != nil {
+		if pools, err = StringToArray(poolsFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("failed to parse the list of pools")
+		}
+	}
+
+	if len(nodes) != 0 && len(pools) != 0 {
+		return nil, warns, UserErrorf("'nodes' and 'pools' flags are mutually exclusive, use --nodes or --pools but not both in the same command")
+	}
+
+	return &ConsoleOpts{
+		ClusterName: clusterName,
+		Nodes:       nodes,
+		Pools:       pools,
+	}, warns, nil
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestConsoleGetOpts(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		flags   map[string]string
+		want    *ConsoleOpts
+		wantErr bool
+	}{
+		{"all nodes", []string{"kkdemo"}, nil, &ConsoleOpts{"kkdemo", []string{}, []string{}}, false},
+		{"nodes", []string{"kkdemo"}, map[string]string{"nodes": "10.0.0.1,10.0.0.2"}, &ConsoleOpts{"kkdemo", []string{"10.0.0.1", "10.0.0.2"}, []string{}}, false},
+		{"pools", []string{"kkdemo"}, map[string]string{"pools": "master"}, &ConsoleOpts{"kkdemo", []string{}, []string{"master"}}, false},
+		{"no cluster", []string{}, nil, nil, true},
+		{"many clusters", []string{"kkdemo1", "kkdemo2"}, nil, nil, true},
+		{"nodes and pools", []string{"kkdemo"}, map[string]string{"nodes": "10.0.0.1", "pools": "worker"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringSliceP("nodes", "n", nil, "")
+			cmd.Flags().StringSliceP("pools", "p", nil, "")
+			for name, value := range tt.flags {
+				cmd.Flags().Set(name, value)
+			}
+
+			got, _, err := ConsoleGetOpts(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConsoleGetOpts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConsoleGetOpts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	"github.com/liferaft/kubekit/pkg/configurator/resources"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DiffOpts encapsulate all the CLI parameters received from the `diff` command
+type DiffOpts struct {
+	ClusterName string
+	Output      string
+	Pp          bool
+}
+
+// DiffGetOpts get the `diff` command parameters from the cobra commands and arguments
+func DiffGetOpts(cmd *cobra.Command, args []string) (opts *DiffOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	// Get the flags `--output` and `--pp`
+	var output string
+	if outputFlag := cmd.Flags().Lookup("output"); outputFlag != nil {
+		output = outputFlag.Value.String()
+	}
+	switch output {
+	case "", "table", "json", "yaml":
+	default:
+		return nil, warns, UserErrorf("unknown diff output format %q, the available formats are: 'table', 'json' and 'yaml'", output)
+	}
+	pp := false
+	if ppFlag := cmd.Flags().Lookup("pp"); ppFlag != nil {
+		pp = ppFlag.Value.String() == "true"
+	}
+
+	opts = &DiffOpts{
+		ClusterName: clusterName,
+		Output:      output,
+		Pp:          pp,
+	}
+
+	return opts, warns, nil
+}
+
+// DiffReport is the drift of the objects of every resource of the cluster
+type DiffReport []resources.ResourceDrift
+
+// InSync returns true if every object of every resource is in sync with the
+// live object
+func (dr DiffReport) InSync() bool {
+	for _, r := range dr {
+		for _, obj := range r.Objects {
+			if obj.Status != kube.DriftInSync {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Sprintf returns a string to print in the given format. Pretty Print (`pp`)
+// applies only for JSON
+func (dr DiffReport) Sprintf(format string, pp bool) (string, error) {
+	switch format {
+	case "", "table":
+		return dr.Table()
+	case "json":
+		return dr.JSON(pp)
+	case "yaml":
+		return dr.YAML()
+	default:
+		return "", UserErrorf("unknown format %q", format)
+	}
+}
+
+// JSON returns the diff report in JSON format
+func (dr DiffReport) JSON(pp bool) (string, error) {
+	var (
+		output []byte
+		err    error
+	)
+
+	if pp {
+		output, err = json.MarshalIndent(dr, "", "  ")
+	} else {
+		output, err = json.Marshal(dr)
+	}
+
+	return string(output), err
+}
+
+// YAML returns the diff report in YAML format
+func (dr DiffReport) YAML() (string, error) {
+	output, err := yaml.Marshal(dr)
+	return string(output), err
+}
+
+// Table returns the diff report as a table, with a row for every object
+// followed by the changes of the drifted objects in YAML format
+func (dr DiffReport) Table() (string, error) {
+	b := &bytes.Buffer{}
+	w := tabwriter.NewWriter(b, 0, 0, 3, ' ', 0)
+
+	drifted := []kube.Drift{}
+	fmt.Fprintf(w, "Resource\tKind\tNamespace\tName\tStatus\n")
+	for _, r := range dr {
+		for _, obj := range r.Objects {
+			namespace := obj.Namespace
+			if len(namespace) == 0 {
+				namespace = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Resource, obj.Kind, namespace, obj.Name, obj.Status)
+			if obj.Status == kube.DriftChanged {
+				drifted = append(drifted, obj)
+			}
+		}
+	}
+	w.Flush()
+
+	for _, obj := range drifted {
+		changes, err := yaml.Marshal(obj.Changes)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(b, "\n%s:\n", obj.Object)
+		for _, line := range strings.Split(strings.TrimRight(string(changes), "\n"), "\n") {
+			fmt.Fprintf(b, "  %s\n", line)
+		}
+	}
+
+	return b.String(), nil
+}
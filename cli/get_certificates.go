@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	toml "github.com/pelletier/go-toml"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// GetCertificatesOpts encapsulate all the CLI parameters received from the `get certificates` command
+type GetCertificatesOpts struct {
+	ClusterName string
+	Output      string
+	Pp          bool
+	Remote      bool
+	Nodes       []string
+	Pools       []string
+}
+
+// GetCertificatesGetOpts get the `get certificates` command parameters from the cobra commands and arguments
+func GetCertificatesGetOpts(cmd *cobra.Command, args []string) (opts *GetCertificatesOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	// Get the flags `--output` and `--pp`
+	var output string
+	if outputFlag := cmd.Flags().Lookup("output"); outputFlag != nil {
+		output = outputFlag.Value.String()
+	}
+	pp := false
+	if ppFlag := cmd.Flags().Lookup("pp"); ppFlag != nil {
+		pp = ppFlag.Value.String() == "true"
+	}
+
+	remote := false
+	if remoteFlag := cmd.Flags().Lookup("remote"); remoteFlag != nil {
+		remote = remoteFlag.Value.String() == "true"
+	}
+
+	// Nodes:
+	nodesStr := cmd.Flags().Lookup("nodes").Value.String()
+	nodes, err := StringToArray(nodesStr)
+	if err != nil {
+		return nil, warns, UserErrorf("failed to parse the list of nodes")
+	}
+
+	// Pools:
+	poolsStr := cmd.Flags().Lookup("pools").Value.String()
+	pools, err := StringToArray(poolsStr)
+	if err != nil {
+		return nil, warns, UserErrorf("failed to parse the list of pools")
+	}
+
+	if len(nodes) != 0 && len(pools) != 0 {
+		return nil, warns, UserErrorf("'nodes' and 'pools' flags are mutually exclusive, use --nodes or --pools but not both in the same command")
+	}
+	if !remote && (len(nodes) != 0 || len(pools) != 0) {
+		remote = true
+		warns = append(warns, "the certificates are read from the nodes because the flag --nodes or --pools was used")
+	}
+
+	return &GetCertificatesOpts{
+		ClusterName: clusterName,
+		Output:      output,
+		Pp:          pp,
+		Remote:      remote,
+		Nodes:       nodes,
+		Pools:       pools,
+	}, warns, nil
+}
+
+// CertificatesInfo is a list of certificates with their important information
+type CertificatesInfo []*kluster.CertificateInfo
+
+// Sprintf returns a string to print in the given format. Pretty Print (`pp`)
+// applies only for JSON
+func (ci CertificatesInfo) Sprintf(format string, pp bool) (string, error) {
+	switch format {
+	case "", "wide", "w":
+		return "", ci.Table((format == "wide") || (format == "w"))
+	case "json":
+		return ci.JSON(pp)
+	case "yaml":
+		return ci.YAML()
+	case "toml":
+		return ci.TOML()
+	case "quiet":
+		return ci.Names(), nil
+	default:
+		return "", UserErrorf("unknown format %q", format)
+	}
+}
+
+// JSON returns the certificates information in JSON format
+func (ci CertificatesInfo) JSON(pp bool) (string, error) {
+	var (
+		output []byte
+		err    error
+	)
+
+	if pp {
+		output, err = json.MarshalIndent(ci, "", "  ")
+	} else {
+		output, err = json.Marshal(ci)
+	}
+
+	return string(output), err
+}
+
+// YAML returns the certificates information in YAML format
+func (ci CertificatesInfo) YAML() (string, error) {
+	output, err := yaml.Marshal(ci)
+	return string(output), err
+}
+
+// TOML returns the certificates information in TOML format
+func (ci CertificatesInfo) TOML() (string, error) {
+	var tomlStruct struct {
+		Certificates CertificatesInfo `toml:"certificates"`
+	}
+	tomlStruct.Certificates = ci
+
+	output, err := toml.Marshal(tomlStruct)
+	return string(output), err
+}
+
+// Table returns the certificates information as a table
+func (ci CertificatesInfo) Table(wide bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+
+	header := "Name\tNode\tCN\tNot After\tExpires In"
+	if wide {
+		header = header + "\tIssuer\tSANs"
+	}
+	fmt.Fprintf(w, header+"\n")
+
+	for _, cert := range ci {
+		node := cert.Node
+		if len(node) == 0 {
+			node = "local"
+		}
+		row := fmt.Sprintf("%s\t%s\t%s\t%s\t%s", cert.Name, node, cert.CN, cert.NotAfter.Format(time.RFC3339), expiresIn(cert.ExpiresIn()))
+		if wide {
+			row = fmt.Sprintf("%s\t%s\t%s", row, cert.Issuer, strings.Join(cert.SANs, ","))
+		}
+		fmt.Fprintf(w, "%s\n", row)
+	}
+
+	w.Flush()
+	return nil
+}
+
+// Names returns only the name of the certificates
+func (ci CertificatesInfo) Names() string {
+	b := &bytes.Buffer{}
+	for _, cert := range ci {
+		fmt.Fprintln(b, cert.Name)
+	}
+	return b.String()
+}
+
+// expiresIn returns the time left to expire in days, or 'expired'
+func expiresIn(d time.Duration) string {
+	if d <= 0 {
+		return "expired"
+	}
+	days := int(d.Hours() / 24)
+	if days == 0 {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", days)
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_expiresIn(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{"expired", -time.Hour, "expired"},
+		{"expires now", 0, "expired"},
+		{"hours", 5*time.Hour + 30*time.Minute, "5h"},
+		{"one day", 24 * time.Hour, "1d"},
+		{"one year", 365 * 24 * time.Hour, "365d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expiresIn(tt.d); got != tt.want {
+				t.Errorf("expiresIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// GetInventoryOpts encapsulate all the CLI parameters received from the `get inventory` command
+type GetInventoryOpts struct {
+	ClusterName string
+	Output      string
+	To          string
+}
+
+// GetInventoryGetOpts get the `get inventory` command parameters from the cobra commands and arguments
+func GetInventoryGetOpts(cmd *cobra.Command, args []string) (opts *GetInventoryOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	// Get the flags `--output` and `--to`
+	var output string
+	if outputFlag := cmd.Flags().Lookup("output"); outputFlag != nil {
+		output = outputFlag.Value.String()
+	}
+	var to string
+	if toFlag := cmd.Flags().Lookup("to"); toFlag != nil {
+		to = toFlag.Value.String()
+	}
+
+	if len(to) != 0 {
+		if len(output) != 0 {
+			warns = append(warns, "the inventory is exported in every format to the directory given with --to, the output format is ignored")
+		}
+	} else if len(output) == 0 {
+		output = kluster.InventoryAnsible
+	} else if !kluster.ValidInventoryFormat(output) {
+		return nil, warns, UserErrorf("unknown inventory format %q, the supported formats are: %s", output, strings.Join(kluster.InventoryFormats, ", "))
+	}
+
+	return &GetInventoryOpts{
+		ClusterName: clusterName,
+		Output:      output,
+		To:          to,
+	}, warns, nil
+}
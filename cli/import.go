@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ImportOpts encapsulate all the CLI parameters received from the `import`
+// command. The parameters to create the cluster configuration are the same as
+// the `init` command
+type ImportOpts struct {
+	*InitOpts
+	InstanceIDs map[string][]string
+}
+
+// ImportGetOpts get the `import` command parameters from the cobra commands and arguments
+func ImportGetOpts(cmd *cobra.Command, args []string) (opts *ImportOpts, warns []string, err error) {
+	initOpts, warns, err := InitGetOpts(cmd, args)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	idsStr := cmd.Flags().Lookup("instance-ids").Value.String()
+	params, err := StringToArray(idsStr)
+	if err != nil {
+		return nil, warns, UserErrorf("failed to parse the instance IDs. %s", err)
+	}
+	instanceIDs, err := ParseInstanceIDs(params)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	return &ImportOpts{
+		InitOpts:    initOpts,
+		InstanceIDs: instanceIDs,
+	}, warns, nil
+}
+
+// ParseInstanceIDs parses the instance IDs parameters in the form
+// POOL-NAME=ID[,ID...], returning the IDs grouped by node pool name
+func ParseInstanceIDs(params []string) (map[string][]string, error) {
+	if len(params) == 0 {
+		return nil, UserErrorf("requires the instance IDs to import, like: --instance-ids POOL-NAME=ID[,ID...]")
+	}
+
+	instanceIDs := map[string][]string{}
+	seen := map[string]string{}
+	for _, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return nil, UserErrorf("invalid instance IDs parameter %q, use the form POOL-NAME=ID[,ID...]", param)
+		}
+
+		poolName := strings.TrimSpace(kv[0])
+		if len(poolName) == 0 {
+			return nil, UserErrorf("the node pool name cannot be empty")
+		}
+
+		for _, id := range strings.Split(kv[1], ",") {
+			id = strings.TrimSpace(id)
+			if len(id) == 0 {
+				continue
+			}
+			if pool, ok := seen[id]; ok {
+				return nil, UserErrorf("the instance %q is imported more than once, in node pools %q and %q", id, pool, poolName)
+			}
+			seen[id] = poolName
+			instanceIDs[poolName] = append(instanceIDs[poolName], id)
+		}
+
+		if len(instanceIDs[poolName]) == 0 {
+			return nil, UserErrorf("there are no instance IDs for the node pool %q", poolName)
+		}
+	}
+
+	return instanceIDs, nil
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseInstanceIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{"one pool", []string{"worker=i-01,i-02"}, map[string][]string{"worker": {"i-01", "i-02"}}, false},
+		{"two pools", []string{"master=i-01", "worker=i-02,i-03"}, map[string][]string{"master": {"i-01"}, "worker": {"i-02", "i-03"}}, false},
+		{"same pool twice", []string{"worker=i-01", "worker=i-02"}, map[string][]string{"worker": {"i-01", "i-02"}}, false},
+		{"spaces", []string{" worker = i-01 , i-02 "}, map[string][]string{"worker": {"i-01", "i-02"}}, false},
+		{"vsphere path", []string{"master=/dc1/vm/kube/master-01"}, map[string][]string{"master": {"/dc1/vm/kube/master-01"}}, false},
+		{"no params", []string{}, nil, true},
+		{"no pool", []string{"i-01,i-02"}, nil, true},
+		{"empty pool", []string{"=i-01"}, nil, true},
+		{"no IDs", []string{"worker=,"}, nil, true},
+		{"duplicated ID", []string{"master=i-01", "worker=i-01"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInstanceIDs(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseInstanceIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseInstanceIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/liferaft/kubekit/cli"
 
 	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/notifier"
 	"github.com/liferaft/kubekit/pkg/packages"
 	"github.com/spf13/cobra"
 )
@@ -68,7 +69,7 @@ or testing.`,
 }
 
 func addApplyCmd() {
-	// apply [cluster] NAME --provision --configure --certificates --generate-certs --export --plan --CERT-key-file FILE --CERT-cert-file FILE
+	// apply [cluster] NAME --provision --configure --certificates --generate-certs --export --plan --output (table|json|yaml) --CERT-key-file FILE --CERT-cert-file FILE
 	RootCmd.AddCommand(applyCmd)
 	applyCmd.Flags().BoolVarP(&doProvision, "provision", "p", false, "only apply the provisioning. If possible for the cluster platform, creates or updates the nodes of the cluster")
 	applyCmd.Flags().BoolVarP(&doConfigure, "configure", "c", false, "only apply the configuration. The cluster must exists. Generate the certificates (if doesn't exists), install and configure Kubernetes on the existing cluster")
@@ -82,6 +83,7 @@ func addApplyCmd() {
 	// Advance command, do not print in help:
 	// applyCmd.Flags().MarkHidden("export")
 	applyCmd.Flags().BoolVar(&doPlan, "plan", false, "don't apply, just print the provisioning changes")
+	applyCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
 
 	// Advance command, do not print in help:
 	// applyCmd.Flags().MarkHidden("plan")
@@ -99,6 +101,7 @@ func addApplyCmd() {
 	// Advance command, do not print in help:
 	// applyClusterCmd.Flags().MarkHidden("export")
 	applyClusterCmd.Flags().BoolVar(&doPlan, "plan", false, "don't apply, just print the provisioning changes")
+	applyClusterCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
 	applyClusterCmd.Flags().Bool("force-pkg", false, "force install of package")
 	// Advance command, do not print in help:
 	// applyClusterCmd.Flags().MarkHidden("plan")
@@ -134,7 +137,7 @@ func applyCertificatesRun(cmd *cobra.Command, args []string) error {
 	return cluster.ApplyClientCertificates(true)
 }
 
-func applyClusterRun(cmd *cobra.Command, args []string) error {
+func applyClusterRun(cmd *cobra.Command, args []string) (err error) {
 	if len(args) == 0 {
 		return cli.UserErrorf("requires a cluster name")
 	}
@@ -152,6 +155,13 @@ func applyClusterRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// no other KubeKit, local or sharing the storage, can apply it at same time
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
 	// generate (if doesn't exists) the SSH keys, required for the terraform templates and provisioner
 	if err := cluster.HandleKeys(); err != nil {
 		return err
@@ -164,7 +174,7 @@ func applyClusterRun(cmd *cobra.Command, args []string) error {
 
 	// If so, that's all, print the plan and return
 	if doPlan {
-		return cluster.Plan(false)
+		return printPlan(cmd, cluster, false)
 	}
 
 	pkgFilename := cmd.Flags().Lookup("package-file").Value.String()
@@ -174,6 +184,15 @@ func applyClusterRun(cmd *cobra.Command, args []string) error {
 	if err := packages.CheckRpmPackage(pkgFilename, forcePkg); err != nil {
 		return err
 	}
+
+	cluster.Notify(notifier.ApplyStarted, nil)
+	defer func() {
+		if errI := cluster.UpdateInventory(); errI != nil {
+			config.UI.Log.Warnf("failed to update the inventory of the cluster %q. %s", clusterName, errI)
+		}
+		cluster.Notify(notifier.ApplyFinished, err)
+	}()
+
 	// if one of these flags is set, then do not apply the entire process, just
 	// the explicit actions specified by the flags
 	explicitActions := doProvision || doConfigure // || doCerts
@@ -289,6 +308,28 @@ func loadCluster(clusterName string) (*kluster.Kluster, error) {
 	return kluster.LoadCluster(clusterName, config.ClustersDir(), config.UI)
 }
 
+// printPlan prints the report of the changes to apply to the cluster, in the
+// format requested with the flag --output
+func printPlan(cmd *cobra.Command, cluster *kluster.Kluster, destroy bool) error {
+	format, err := cli.GetPlanOutput(cmd)
+	if err != nil {
+		return err
+	}
+
+	report, err := cluster.PlanReport(destroy)
+	if err != nil {
+		return err
+	}
+
+	output, err := (*cli.PlanReport)(report).Sprintf(format, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(output)
+	return nil
+}
+
 func provision(cluster *kluster.Kluster) error {
 	errP := cluster.Create()
 	// TODO: Should it save the cluster if fail?
@@ -0,0 +1,144 @@
+package kubekit
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup [cluster] NAME",
+	Short: "Takes a backup of the cluster etcd",
+	Long: `Backup is used to take a snapshot of the etcd database of the cluster. The
+snapshot is taken on a master node and stored, with its metadata, in the
+backups directory of the cluster configuration. When it's backing up a cluster
+the noun cluster is optional, as it's the default noun.`,
+	RunE: backupClusterRun,
+}
+
+// backupClusterCmd represents the 'backup cluster' command
+var backupClusterCmd = &cobra.Command{
+	Use:     "cluster NAME",
+	Aliases: []string{"c"},
+	Short:   "Takes a backup of the cluster etcd",
+	Long: `The command backup cluster is used to take a snapshot of the etcd database of
+the cluster and store it in the backups directory of the cluster configuration.`,
+	RunE: backupClusterRun,
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore [cluster] NAME --from BACKUP",
+	Short: "Restores the cluster etcd from a backup",
+	Long: `Restore is used to restore the etcd database of the cluster from a backup
+taken with the 'backup' command, or from an etcd snapshot file. The etcd members
+and API servers are stopped during the restore, then every etcd member is
+rebuilt from the snapshot. When it's restoring a cluster the noun cluster is
+optional, as it's the default noun.`,
+	RunE: restoreClusterRun,
+}
+
+// restoreClusterCmd represents the 'restore cluster' command
+var restoreClusterCmd = &cobra.Command{
+	Use:     "cluster NAME --from BACKUP",
+	Aliases: []string{"c"},
+	Short:   "Restores the cluster etcd from a backup",
+	Long: `The command restore cluster is used to restore the etcd database of the
+cluster from a backup name, 'latest' for the newest backup, or the path to an
+etcd snapshot file.`,
+	RunE: restoreClusterRun,
+}
+
+func addBackupCmd() {
+	// backup [cluster] NAME
+	RootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupClusterCmd)
+
+	// restore [cluster] NAME --from BACKUP --timeout DURATION
+	RootCmd.AddCommand(restoreCmd)
+	addRestoreFlags(restoreCmd)
+
+	restoreCmd.AddCommand(restoreClusterCmd)
+	addRestoreFlags(restoreClusterCmd)
+}
+
+func addRestoreFlags(cmd *cobra.Command) {
+	cmd.Flags().String("from", "", "backup name, 'latest' or etcd snapshot file to restore")
+	cmd.Flags().String("timeout", kluster.DefaultRestoreTimeout.String(), "time to wait for the cluster to be ready after the restore")
+}
+
+func backupClusterRun(cmd *cobra.Command, args []string) error {
+	clusterName, err := cli.GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return err
+	}
+
+	cluster, err := loadCluster(clusterName)
+	if err != nil {
+		return err
+	}
+
+	// no other KubeKit, local or sharing the storage, can apply or restore it
+	// while it's backed up
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// the SSH keys are required to access the nodes
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	backup, err := cluster.Backup()
+	if err != nil {
+		return err
+	}
+
+	config.UI.Log.Infof("backup %s of cluster %q stored at %s", backup.Name, clusterName, backup.Path)
+	return nil
+}
+
+func restoreClusterRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.RestoreGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	// no other KubeKit, local or sharing the storage, can apply it at same time
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// the SSH keys are required to access the nodes
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	errR := cluster.Restore(opts.From, opts.Timeout)
+	// Save the cluster, even if the restore failed the status has changed
+	if err := cluster.Save(); err != nil {
+		if errR != nil {
+			return fmt.Errorf("failed to restore the cluster and to save the cluster configuration file.\n%s\n%s", errR, err)
+		}
+		return err
+	}
+
+	return errR
+}
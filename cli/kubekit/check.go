@@ -0,0 +1,90 @@
+package kubekit
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [cluster] NAME",
+	Short: "Runs diagnostics on a cluster",
+	Long: `Check is used to run diagnostics on the Kubernetes cluster, over SSH and the
+Kubernetes API. It verifies the nodes readiness and pressure, the etcd members
+health and database size, the nodes clock skew, the certificates expiration,
+the disk usage, the pods phase per namespace and the control plane containers.
+Every diagnostic passes, warns or fails, the command fails if any diagnostic
+fails. When it's checking a cluster the noun cluster is optional, as it's the
+default noun.`,
+	RunE: checkClusterRun,
+}
+
+// checkClusterCmd represents the 'check cluster' command
+var checkClusterCmd = &cobra.Command{
+	Use:     "cluster NAME",
+	Aliases: []string{"c"},
+	Short:   "Runs diagnostics on a cluster",
+	Long: `The command check cluster is used to run diagnostics on the Kubernetes
+cluster and print the results as a table, JSON or YAML.`,
+	RunE: checkClusterRun,
+}
+
+func addCheckCmd() {
+	// check [cluster] NAME --output (table|json|yaml) --pp
+	RootCmd.AddCommand(checkCmd)
+	addCheckFlags(checkCmd)
+
+	checkCmd.AddCommand(checkClusterCmd)
+	addCheckFlags(checkClusterCmd)
+}
+
+func addCheckFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", "", "output format of the results: 'table' (default), 'json' or 'yaml'")
+	cmd.Flags().Bool("pp", false, "pretty print the JSON output")
+}
+
+func checkClusterRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.CheckGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	// the SSH keys are required to access the nodes
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	report, err := cluster.Check()
+	if err != nil {
+		return err
+	}
+
+	output, err := (*cli.CheckReport)(report).Sprintf(opts.Output, opts.Pp)
+	if err != nil {
+		return err
+	}
+	fmt.Println(output)
+
+	// run 'check' periodically to get a warning before the certificates expire
+	if _, err := cluster.NotifyExpiringCertificates(); err != nil {
+		config.UI.Log.Warnf("failed to notify the certificates about to expire. %s", err)
+	}
+
+	if report.Status == kluster.CheckFail {
+		return fmt.Errorf("%d diagnostics of the cluster %q failed", report.Fail, opts.ClusterName)
+	}
+	return nil
+}
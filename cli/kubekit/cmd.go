@@ -23,7 +23,12 @@ func Execute() {
 
 	AddCommands()
 
-	if c, err := RootCmd.ExecuteC(); err != nil {
+	c, err := RootCmd.ExecuteC()
+
+	// the cluster lifecycle events are delivered before exit
+	kluster.WaitNotifications()
+
+	if err != nil {
 		if config != nil && config.UI != nil && config.UI.Log != nil {
 			config.UI.Log.Error(err.Error())
 		}
@@ -45,10 +50,10 @@ func AddCommands() {
 	// init certificates CLUSTER-NAME --CERT-key-file FILE --CERT-cert-file FILE
 	addInitCmd()
 
-	// apply [cluster] NAME --provision --configure --certificates --generate-certs --export-tf --export-k8s --plan --CERT-key-file FILE --CERT-cert-file FILE
+	// apply [cluster] NAME --provision --configure --certificates --generate-certs --export-tf --export-k8s --plan --output (table|json|yaml) --CERT-key-file FILE --CERT-cert-file FILE
 	addApplyCmd()
 
-	// delete [cluster] NAME --force --all
+	// delete [cluster] NAME --force --all --plan --output (table|json|yaml)
 	// delete clusters-config NAME --force
 	// delete templates NAME[,NAME...] --force
 	// delete files CLUSTER-NAME FILE[,FILE...] --force --nodes NODE[,NODE] --pools POOL[,POOL]
@@ -61,22 +66,26 @@ func AddCommands() {
 	// [get] nodes CLUSTER-NAME NAME[,NAME...] --output (wide|json|yaml|toml) --pp --nodes NODE[,NODE] --pools POOL[,POOL]
 	// [get] files CLUSTER-NAME FILENAME[,FILENAME...] --output (wide|json|yaml|toml) --pp --nodes NODE[,NODE] --pools POOL[,POOL] --path PATHT[,PATH]
 	// [get] templates NAME[,NAME...] --output (wide|json|yaml|toml) --pp
+	// [get] certificates CLUSTER-NAME --output (wide|json|yaml|toml) --pp --remote --nodes NODE[,NODE] --pools POOL[,POOL]
 	addGetCmd()
 
-	// copy [cluster] NAME --to NEW-NAME --provision --configure --certificates --generate-certs --export --plan --CERT-key-file FILE --CERT-cert-file FILE
+	// copy [cluster] NAME --to NEW-NAME --provision --configure --certificates --generate-certs --export --plan --output (table|json|yaml) --CERT-key-file FILE --CERT-cert-file FILE
 	// copy cluster-config CLUSTER-NAME --to NEW-NAME --platform NAME --path PATH --template NAME
 	// copy template NAME
 	// copy files
 	// copy certificates
 	addCopyCmd()
 
-	// exec [cluster] NAME
+	// exec [cluster] NAME --cmd COMMAND --file FILE --nodes NODE[,NODE] --pools POOL[,POOL] --sudo --output (json|yaml|toml) --pp --stream --parallel N --timeout DURATION --fail-fast
 	addExecCmd()
 
 	// login [cluster] NAME --platform platform --list --access_key aws_access_key_id --secret_key aws_secret_access_key --region aws_default_region --server server_ip_or_dns --username username --password password
 	// login node NAME --cluster NAME
 	addLoginCmd()
 
+	// console [cluster] NAME --nodes NODE[,NODE] --pools POOL[,POOL]
+	addConsoleCmd()
+
 	// describe [cluster] NAME[,NAME ...] --output (json|yaml|toml) --pp
 	// describe templates NAME[,NAME ...] --output (json|yaml|toml) --pp
 	// describe nodes CLUSTER-NAME --output (json|yaml|toml) --pp
@@ -87,9 +96,31 @@ func AddCommands() {
 	// restart [cluster] NAME[,NAME ...]
 	addStartStopCmd()
 
-	// scale [cluster] NAME POOL-NAME=[+|-]N
+	// scale [cluster] NAME POOL-NAME=[+|-]N --drain-timeout DURATION --force
 	addScaleCmd()
 
+	// import [cluster] NAME --platform NAME --instance-ids POOL-NAME=ID[,ID...] --path PATH --format FORMAT
+	addImportCmd()
+
+	// upgrade [cluster] NAME --to RELEASE --package-file FILE --force-pkg --drain-timeout DURATION
+	addUpgradeCmd()
+
+	// backup [cluster] NAME
+	// restore [cluster] NAME --from BACKUP --timeout DURATION
+	addBackupCmd()
+
+	// rotate certificates NAME --ca --timeout DURATION --CERT-key-file FILE --CERT-cert-file FILE
+	addRotateCmd()
+
+	// check [cluster] NAME --output (table|json|yaml) --pp
+	addCheckCmd()
+
+	// replace node CLUSTER-NAME NODE --drain-timeout DURATION --CERT-key-file FILE --CERT-cert-file FILE
+	addReplaceCmd()
+
+	// diff resources NAME --output (table|json|yaml) --pp
+	addDiffCmd()
+
 	// --version
 	// version
 	addVersionCmd()
@@ -12,6 +12,9 @@ import (
 	"github.com/johandry/log"
 	"github.com/kraken/ui"
 	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/notifier"
+	"github.com/liferaft/kubekit/pkg/storage"
 	homedir "github.com/mitchellh/go-homedir"
 	toml "github.com/pelletier/go-toml"
 	"github.com/spf13/cobra"
@@ -62,6 +65,12 @@ type Config struct {
 	ClustersPath   string `json:"clusters_path" yaml:"clusters_path" toml:"clusters_path" mapstructure:"clusters_path"`
 	TemplatesPath  string `json:"templates_path" yaml:"templates_path" toml:"templates_path" mapstructure:"templates_path"`
 	PKIPath        string `json:"pki_path" yaml:"pki_path" toml:"pki_path" mapstructure:"pki_path"`
+	InventoryPath  string `json:"inventory_path,omitempty" yaml:"inventory_path,omitempty" toml:"inventory_path,omitempty" mapstructure:"inventory_path"`
+	Storage        string `json:"storage,omitempty" yaml:"storage,omitempty" toml:"storage,omitempty" mapstructure:"storage"`
+	Credentials    string `json:"credentials_backend,omitempty" yaml:"credentials_backend,omitempty" toml:"credentials_backend,omitempty" mapstructure:"credentials_backend"`
+
+	// Webhooks to notify the cluster lifecycle events
+	Notifications *notifier.Config `json:"notifications,omitempty" yaml:"notifications,omitempty" toml:"notifications,omitempty" mapstructure:"notifications"`
 
 	// Keep viper and command just in case a parameter is missing or to compare them
 	// Remove them when no needed anymore.
@@ -167,6 +176,15 @@ func (c *Config) TemplatesDir() string {
 	return absDir(c.Dir(), c.TemplatesPath)
 }
 
+// InventoryDir returns the absolute directory path where the clusters inventory
+// is kept updated, or empty if it's not set
+func (c *Config) InventoryDir() string {
+	if len(c.InventoryPath) == 0 {
+		return ""
+	}
+	return absDir(c.Dir(), c.InventoryPath)
+}
+
 // PKIDir returns the KubeKit PKI directory. Here is where the server store the
 // certificates
 func (c *Config) PKIDir() string {
@@ -299,6 +317,37 @@ func initConfigE() error {
 
 	config.UI = ui
 
+	// the clusters path is a working copy of the shared storage, if any
+	if len(config.Storage) != 0 {
+		s, err := storage.New(config.Storage)
+		if err != nil {
+			return err
+		}
+		kluster.SetStorage(s)
+	}
+
+	// the platform credentials are kept in the cluster directory, encrypted, or
+	// in Vault
+	if len(config.Credentials) != 0 {
+		b, err := kluster.NewCredentialsBackend(config.Credentials)
+		if err != nil {
+			return err
+		}
+		kluster.SetCredentialsBackend(b)
+	}
+
+	// the inventory of the clusters is updated after every apply or delete
+	kluster.SetInventoryPath(config.InventoryDir())
+
+	// the cluster lifecycle events are sent to the webhooks, if any
+	if config.Notifications != nil {
+		n, err := notifier.New(config.Notifications, ui)
+		if err != nil {
+			return err
+		}
+		kluster.SetNotifier(n)
+	}
+
 	return nil
 }
 
@@ -385,6 +434,9 @@ func setDefaultsAndBindPFlags(v *viper.Viper) {
 	v.SetDefault("clusters_path", filepath.Join(kubekitHomeDir, defClustersDir))
 	v.SetDefault("templates_path", filepath.Join(kubekitHomeDir, defTemplatesDir))
 	v.SetDefault("pki_path", filepath.Join(kubekitHomeDir, defServerPKIDir))
+	v.SetDefault("storage", "")
+	v.SetDefault("credentials_backend", "")
+	v.SetDefault("inventory_path", "")
 }
 
 func setDefaultAndBindPFlag(v *viper.Viper, f *pflag.Flag, value interface{}) {
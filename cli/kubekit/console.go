@@ -0,0 +1,57 @@
+package kubekit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/spf13/cobra"
+)
+
+// consoleCmd represents the console command
+var consoleCmd = &cobra.Command{
+	Use:     "console [cluster] NAME",
+	Aliases: []string{"con"},
+	Short:   "Opens a shell on many cluster nodes sending them the same input",
+	Long: `Opens a shell on all the nodes of the cluster or some of them, every line typed
+is sent to all the nodes at same time and the output of every node is printed
+prefixed with the node IP address. Type 'exit' or Ctrl-D to close the console.`,
+	RunE: consoleRun,
+}
+
+func addConsoleCmd() {
+	// console [cluster] NAME --nodes NODE[,NODE] --pools POOL[,POOL]
+	RootCmd.AddCommand(consoleCmd)
+	consoleCmd.Flags().StringSliceP("nodes", "n", nil, "list of nodes where to open the console")
+	consoleCmd.Flags().StringSliceP("pools", "p", nil, "list of node pools where in such nodes open the console")
+}
+
+func consoleRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.ConsoleGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	hosts := cluster.HostsFilterBy(opts.Nodes, opts.Pools)
+	if len(hosts) == 0 {
+		return cli.UserErrorf("there are no nodes in the cluster %s matching the given nodes or pools", opts.ClusterName)
+	}
+	addresses := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		addresses = append(addresses, host.PublicIP)
+	}
+	fmt.Fprintf(os.Stderr, "sending the input to %d nodes: %s. Type 'exit' or Ctrl-D to close the console\n", len(hosts), strings.Join(addresses, ", "))
+
+	return cluster.Console(opts.Nodes, opts.Pools, os.Stdin, os.Stdout, os.Stderr)
+}
@@ -35,6 +35,7 @@ var copyCmd = &cobra.Command{
 Copy is used to duplicate a cluster, a cluster configuration file, a template
 file, copy files to/from an existing cluster or copy cluster certificates to a
 given plath or to an existing cluster.`,
+	RunE: copyClusterRun,
 }
 
 // copyClusterCmd represents the 'copy cluster' command
@@ -46,10 +47,7 @@ var copyClusterCmd = &cobra.Command{
 	Long: `Duplicates an existing cluster in the same platform with a different name. Same
 as the command 'apply cluster' after coping the cluster configuration with a new
 cluster name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'copy cluster' still not implemented")
-		return nil
-	},
+	RunE: copyClusterRun,
 }
 
 // copyClusterConfigCmd represents the 'copy cluster-config' command
@@ -112,7 +110,7 @@ file but with less parameters.`,
 }
 
 func addCopyCmd() {
-	// copy [cluster] NAME --to NEW-NAME --provision --configure --certificates --generate-certs --export --plan --CERT-key-file FILE --CERT-cert-file FILE
+	// copy [cluster] NAME --to NEW-NAME --provision --configure --certificates --generate-certs --export --plan --output (table|json|yaml) --CERT-key-file FILE --CERT-cert-file FILE
 	RootCmd.AddCommand(copyCmd)
 	copyCmd.Flags().String("to", "", "new cluster name")
 	copyCmd.Flags().BoolP("provision", "p", false, "only apply the provisioning. If possible for the cluster platform, creates or updates the nodes of the cluster")
@@ -123,6 +121,7 @@ func addCopyCmd() {
 	// Advance command, do not print in help:
 	// copyCmd.Flags().MarkHidden("export")
 	copyCmd.Flags().Bool("plan", false, "don't apply, just print the provisioning changes")
+	copyCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
 	// Advance command, do not print in help:
 	// copyCmd.Flags().MarkHidden("plan")
 	addCertFlags(copyCmd)
@@ -136,6 +135,7 @@ func addCopyCmd() {
 	// Advance command, do not print in help:
 	// copyClusterCmd.Flags().MarkHidden("export")
 	copyClusterCmd.Flags().Bool("plan", false, "don't apply, just print the provisioning changes")
+	copyClusterCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
 	// Advance command, do not print in help:
 	// copyClusterCmd.Flags().MarkHidden("plan")
 	addCertFlags(copyClusterCmd)
@@ -186,6 +186,57 @@ func addCopyCmd() {
 	copyPackageCmd.Flags().StringP("package-file", "f", "", "package file to transfer. By default will be at the cluster directory named 'kubekit.rpm' or '.deb'")
 }
 
+func copyClusterRun(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cli.UserErrorf("requires a cluster name")
+	}
+	if len(args) != 1 {
+		return cli.UserErrorf("accepts 1 cluster name, received %d. %v", len(args), args)
+	}
+	clusterName := args[0]
+	if len(clusterName) == 0 {
+		return cli.UserErrorf("cluster name cannot be empty")
+	}
+
+	newClusterName := cmd.Flags().Lookup("to").Value.String()
+	if len(newClusterName) == 0 {
+		return cli.UserErrorf("new cluster name not found. Use the '--to' flag to set the new name")
+	}
+	export := cmd.Flags().Lookup("export").Value.String() == "true"
+	plan := cmd.Flags().Lookup("plan").Value.String() == "true"
+
+	// validate the output format before copying anything
+	if _, err := cli.GetPlanOutput(cmd); err != nil {
+		return err
+	}
+
+	if _, err := copyClusterConfig(clusterName, newClusterName, "", "", "yaml", map[string]string{}, "", false, false); err != nil {
+		return err
+	}
+
+	if !export && !plan {
+		config.UI.Log.Infof("cluster %s copied to %s, to create it use the command 'kubekit apply %s'", clusterName, newClusterName, newClusterName)
+		return nil
+	}
+
+	// load the copied cluster with its provisioner, as 'apply' does
+	newCluster, err := loadCluster(newClusterName)
+	if err != nil {
+		return err
+	}
+
+	// generate the SSH keys, required for the terraform templates and provisioner
+	if err := newCluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	if export {
+		return newCluster.ExportTF()
+	}
+
+	return printPlan(cmd, newCluster, false)
+}
+
 func copyClusterConfigRun(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return cli.UserErrorf("requires a cluster name")
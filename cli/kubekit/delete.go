@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/liferaft/kubekit/cli"
 	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/notifier"
 	"github.com/spf13/cobra"
 	"os"
 	"path/filepath"
@@ -69,16 +70,18 @@ include wildcards (*,?) or ranges (i.e. [1-3])`,
 }
 
 func addDeleteCmd() {
-	// delete [cluster] NAME --force --all
+	// delete [cluster] NAME --force --all --plan --output (table|json|yaml)
 	RootCmd.AddCommand(deleteCmd)
 	deleteCmd.PersistentFlags().BoolVar(&deleteForce, "force", false, "do not confirm or ask to the user before delete the resource")
 
 	deleteCmd.Flags().Bool("all", false, "delete all the cluster resources such as configuration files, certificates and state")
 	deleteCmd.Flags().BoolVar(&doPlan, "plan", false, "don't delete the cluster, just print the changes to apply")
+	deleteCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
 
 	deleteCmd.AddCommand(deleteClusterCmd)
 	deleteClusterCmd.Flags().Bool("all", false, "delete all the cluster resources such as configuration files, certificates and state")
 	deleteClusterCmd.Flags().BoolVar(&doPlan, "plan", false, "don't delete the cluster, just print the changes to apply")
+	deleteClusterCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
 
 	// delete clusters-config NAME --force
 	deleteCmd.AddCommand(deleteClustersConfigCmd)
@@ -123,11 +126,20 @@ func deleteClusterRun(cmd *cobra.Command, args []string) error {
 
 	// If so, that's all, print the plan and return
 	if doPlan {
-		return cluster.Plan(true)
+		return printPlan(cmd, cluster, true)
 	}
 
+	// no other KubeKit, local or sharing the storage, can delete it at same time
+	lock, err := cluster.Lock("delete")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
 	var errS error
+	cluster.Notify(notifier.DeleteStarted, nil)
 	errT := cluster.Terminate()
+	cluster.Notify(notifier.DeleteFinished, errT)
 
 	updated := make(map[string]string)
 	if cluster.Platform() != "aks" {
@@ -142,6 +154,9 @@ func deleteClusterRun(cmd *cobra.Command, args []string) error {
 	if !opts.DestroyAll {
 		errS = cluster.Save()
 	}
+	if err := cluster.UpdateInventory(); err != nil {
+		config.UI.Log.Warnf("failed to update the inventory of the cluster %q. %s", opts.ClusterName, err)
+	}
 	if errT != nil && errS != nil {
 		return fmt.Errorf("failed to destroy the cluster and to save the cluster configuration file.\n%s\n%s", errT, errS)
 	}
@@ -198,8 +213,7 @@ func deleteCerts(clusterName string, cluster *kluster.Kluster) (err error) {
 			return err
 		}
 	}
-	certsDir := filepath.Join(cluster.Dir(), kluster.CertificatesDirname)
-	return os.RemoveAll(certsDir)
+	return cluster.DeleteCerts()
 }
 
 func deletetfState(clusterName string, cluster *kluster.Kluster) (err error) {
@@ -208,8 +222,7 @@ func deletetfState(clusterName string, cluster *kluster.Kluster) (err error) {
 			return err
 		}
 	}
-	tfstateDir := filepath.Join(cluster.Dir(), ".tfstate")
-	return os.RemoveAll(tfstateDir)
+	return cluster.DeleteState()
 }
 
 func deleteKluster(force bool, clustersName ...string) error {
@@ -239,7 +252,7 @@ func deleteKluster(force bool, clustersName ...string) error {
 			}
 		}
 		if deleteIt {
-			err := os.RemoveAll(baseDir)
+			err := kluster.DeleteConfig(baseDir)
 			if err != nil {
 				if !force {
 					errs = append(errs, err)
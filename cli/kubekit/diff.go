@@ -0,0 +1,74 @@
+package kubekit
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Shows the differences between the configuration and the cluster",
+	Long: `The diff command is used to show the differences between what KubeKit would
+apply with the current configuration and what is live in the cluster.`,
+}
+
+// diffResourcesCmd represents the 'diff resources' command
+var diffResourcesCmd = &cobra.Command{
+	Use:     "resources NAME",
+	Aliases: []string{"r", "res"},
+	Short:   "Shows the drift of the Kubernetes resources of a cluster",
+	Long: `The command diff resources renders the Kubernetes resources of the cluster and
+compares every object with the live object in the cluster. An object is in-sync,
+drifted if a field has a different value in the cluster, missing if it is not in
+the cluster or orphan if it was applied by KubeKit but it is no longer in the
+resources. The orphans are deleted by 'apply' when 'prune_resources' is set in
+the cluster configuration. The charts are not compared. The command fails if
+any object is not in sync.`,
+	RunE: diffResourcesRun,
+}
+
+func addDiffCmd() {
+	// diff resources NAME --output (table|json|yaml) --pp
+	RootCmd.AddCommand(diffCmd)
+
+	diffCmd.AddCommand(diffResourcesCmd)
+	diffResourcesCmd.Flags().StringP("output", "o", "", "output format of the drift: 'table' (default), 'json' or 'yaml'")
+	diffResourcesCmd.Flags().Bool("pp", false, "pretty print the JSON output")
+}
+
+func diffResourcesRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.DiffGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	drifts, err := cluster.DiffResources()
+	if err != nil {
+		return err
+	}
+
+	report := cli.DiffReport(drifts)
+	output, err := report.Sprintf(opts.Output, opts.Pp)
+	if err != nil {
+		return err
+	}
+	fmt.Println(output)
+
+	if !report.InSync() {
+		return fmt.Errorf("the resources of the cluster %q are not in sync", opts.ClusterName)
+	}
+	return nil
+}
@@ -2,11 +2,15 @@ package kubekit
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/liferaft/kubekit/cli"
 
+	"github.com/liferaft/kubekit/pkg/configurator"
 	"github.com/liferaft/kubekit/pkg/kluster"
 	"github.com/spf13/cobra"
 )
@@ -54,6 +58,10 @@ func addExecCmd() {
 	execCmd.Flags().BoolVar(&sudoExec, "sudo", false, "use sudo. The user needs to have sudo access")
 	execCmd.Flags().StringP("output", "o", "yaml", "Output format. Available formats: 'json', 'yaml' and 'toml'")
 	execCmd.Flags().Bool("pp", false, "Pretty print. Show the configuration in a human readable format. Applies only for 'json' format")
+	execCmd.Flags().Bool("stream", false, "stream the output of every node as it's received, every line prefixed with the node IP address")
+	execCmd.Flags().Int("parallel", 0, "maximum number of nodes executing the command at same time. Applies only with '--stream'. By default, all of them")
+	execCmd.Flags().String("timeout", "", "maximum time the command can run on every node, i.e. 30s or 5m. Applies only with '--stream'. By default, no timeout")
+	execCmd.Flags().Bool("fail-fast", false, "stop the execution on every node once the command fails on one of them. Applies only with '--stream'")
 
 	execCmd.AddCommand(execClusterCmd)
 	execClusterCmd.Flags().StringP("cmd", "c", "", "command to execute")
@@ -63,6 +71,10 @@ func addExecCmd() {
 	execClusterCmd.Flags().BoolVar(&sudoExec, "sudo", false, "use sudo. The user needs to have sudo access")
 	execClusterCmd.Flags().StringP("output", "o", "yaml", "Output format. Available formats: 'json', 'yaml' and 'toml'")
 	execClusterCmd.Flags().Bool("pp", false, "Pretty print. Show the configuration in a human readable format. Applies only for 'json' format")
+	execClusterCmd.Flags().Bool("stream", false, "stream the output of every node as it's received, every line prefixed with the node IP address")
+	execClusterCmd.Flags().Int("parallel", 0, "maximum number of nodes executing the command at same time. Applies only with '--stream'. By default, all of them")
+	execClusterCmd.Flags().String("timeout", "", "maximum time the command can run on every node, i.e. 30s or 5m. Applies only with '--stream'. By default, no timeout")
+	execClusterCmd.Flags().Bool("fail-fast", false, "stop the execution on every node once the command fails on one of them. Applies only with '--stream'")
 
 	// exec package CLUSTER-NAME
 	execCmd.AddCommand(execPackageCmd)
@@ -106,6 +118,21 @@ func execClusterRun(cmd *cobra.Command, args []string) error {
 	output := cmd.Flags().Lookup("output").Value.String()
 	pp := cmd.Flags().Lookup("pp").Value.String() == "true"
 
+	stream := cmd.Flags().Lookup("stream").Value.String() == "true"
+	var streamOpts configurator.StreamOptions
+	if streamOpts.Parallel, err = strconv.Atoi(cmd.Flags().Lookup("parallel").Value.String()); err != nil || streamOpts.Parallel < 0 {
+		return cli.UserErrorf("invalid number of parallel executions %q", cmd.Flags().Lookup("parallel").Value.String())
+	}
+	if timeoutStr := cmd.Flags().Lookup("timeout").Value.String(); len(timeoutStr) != 0 {
+		if streamOpts.Timeout, err = time.ParseDuration(timeoutStr); err != nil || streamOpts.Timeout < 0 {
+			return cli.UserErrorf("invalid timeout %q", timeoutStr)
+		}
+	}
+	streamOpts.FailFast = cmd.Flags().Lookup("fail-fast").Value.String() == "true"
+	if !stream && (cmd.Flags().Changed("parallel") || cmd.Flags().Changed("timeout") || cmd.Flags().Changed("fail-fast")) {
+		return cli.UserErrorf("'parallel', 'timeout' and 'fail-fast' flags require the --stream flag")
+	}
+
 	// DEBUG:
 	// var ppFlag, sudoFlag string
 	// if pp {
@@ -121,6 +148,10 @@ func execClusterRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if stream {
+		return execStream(cluster, command, script, nodes, pools, streamOpts)
+	}
+
 	result, err := cluster.Exec(command, script, nodes, pools, sudoExec)
 	if err != nil {
 		return err
@@ -140,6 +171,22 @@ func execClusterRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// execStream executes the command streaming the output of every node, then
+// prints how many nodes succeeded
+func execStream(cluster *kluster.Kluster, command, script string, nodes, pools []string, opts configurator.StreamOptions) error {
+	result, err := cluster.ExecStream(command, script, nodes, pools, sudoExec, os.Stdout, os.Stderr, opts)
+	if result != nil {
+		fmt.Fprintf(os.Stderr, "command succeeded in %d/%d nodes\n", result.Success, len(cluster.HostsFilterBy(nodes, pools)))
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failures != 0 {
+		return fmt.Errorf("the command failed in %d nodes", result.Failures)
+	}
+	return nil
+}
+
 func execPackageRun(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return cli.UserErrorf("requires a cluster name")
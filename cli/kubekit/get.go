@@ -55,6 +55,32 @@ absent, prints the nodes in the configuration.`,
 	RunE: getNodesRun,
 }
 
+// getCertificatesCmd represents the 'get certificates' command
+var getCertificatesCmd = &cobra.Command{
+	Use:     "certificates CLUSTER-NAME",
+	Aliases: []string{"certs"},
+	Short:   "Prints information about the certificates of the given cluster",
+	Long: `Prints information about the certificates of the given cluster, like: name,
+CN, SANs, issuer and expiration date. The certificates are read from the cluster
+directory or, with the flag --remote, from the cluster nodes.`,
+	RunE: getCertificatesRun,
+}
+
+// getInventoryCmd represents the 'get inventory' command
+var getInventoryCmd = &cobra.Command{
+	Use:     "inventory CLUSTER-NAME",
+	Aliases: []string{"inv"},
+	Short:   "Prints the inventory of the cluster nodes for other tools",
+	Long: `Prints the inventory of the cluster nodes in the given output format: an
+Ansible inventory with the same groups used by KubeKit (ansible), the SSH config
+entries to access the nodes (ssh-config), the Prometheus file_sd targets for
+node-exporter (prometheus-sd) or the /etc/hosts lines (hosts). With the flag --to
+the inventory is exported in every format to the directory CLUSTER-NAME in the
+given directory. To keep it updated after every apply or delete, set the
+'inventory_path' parameter in the KubeKit configuration.`,
+	RunE: getInventoryRun,
+}
+
 // getTemplatesCmd represents the 'get templates' command
 var getTemplatesCmd = &cobra.Command{
 	Hidden:  true,
@@ -103,6 +129,16 @@ func addGetCmd() {
 	// getFilesCmd.Flags().StringSliceP("pools", "p", nil, "list of node pools where in such nodes locate the files")
 	// getFilesCmd.Flags().StringSlice("path", nil, "path in the selected nodes where to find the given filenames")
 
+	// [get] certificates CLUSTER-NAME --output (wide|json|yaml|toml) --pp --remote --nodes NODE[,NODE] --pools POOL[,POOL]
+	getCmd.AddCommand(getCertificatesCmd)
+	getCertificatesCmd.Flags().Bool("remote", false, "read the certificates from the cluster nodes instead of the cluster directory")
+	getCertificatesCmd.Flags().StringSliceP("nodes", "n", nil, "list of nodes to read the certificates from")
+	getCertificatesCmd.Flags().StringSliceP("pools", "p", nil, "list of node pools to read the certificates from the nodes in there")
+
+	// [get] inventory CLUSTER-NAME --output (ansible|ssh-config|prometheus-sd|hosts) --to DIR
+	getCmd.AddCommand(getInventoryCmd)
+	getInventoryCmd.Flags().String("to", "", "directory to export the inventory in every format, in a subdirectory named as the cluster")
+
 	// [get] templates NAME[,NAME...] --output (wide|json|yaml|toml) --pp
 	// RootCmd.AddCommand(getTemplatesCmd)
 	getCmd.AddCommand(getTemplatesCmd)
@@ -195,6 +231,86 @@ func getNodesRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func getCertificatesRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.GetCertificatesGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	if config.Quiet {
+		if len(opts.Output) != 0 {
+			return cli.UserErrorf("cannot use an output format %q and quiet mode at same time", opts.Output)
+		}
+		opts.Output = "quiet"
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	var certsInfo []*kluster.CertificateInfo
+	if opts.Remote {
+		// the SSH keys are required to access the nodes
+		if err := cluster.HandleKeys(); err != nil {
+			return err
+		}
+		certsInfo, err = cluster.NodesCertificatesInfo(opts.Nodes, opts.Pools)
+	} else {
+		certsInfo, err = cluster.CertificatesInfo()
+	}
+	if err != nil {
+		return err
+	}
+
+	output, err := cli.CertificatesInfo(certsInfo).Sprintf(opts.Output, opts.Pp)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(output)
+	return nil
+}
+
+func getInventoryRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.GetInventoryGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.To) != 0 {
+		dir := filepath.Join(opts.To, opts.ClusterName)
+		if err := cluster.ExportInventory(dir); err != nil {
+			return err
+		}
+		config.UI.Log.Infof("the inventory of the cluster %q was exported to %s", opts.ClusterName, dir)
+		return nil
+	}
+
+	inventory, err := cluster.Inventory(opts.Output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(inventory))
+	return nil
+}
+
 func getEnvRun(cmd *cobra.Command, args []string) error {
 	opts, warns, err := cli.GetEnvGetOpts(cmd, args)
 	if err != nil {
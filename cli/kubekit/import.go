@@ -0,0 +1,121 @@
+package kubekit
+
+import (
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [cluster] NAME",
+	Short: "Import existing infrastructure into a cluster",
+	Long: `Import is used to bring existing instances or virtual machines under KubeKit
+management. It generates the cluster configuration, if it doesn't exists, and
+imports the given instances into the Terraform state of the cluster, so the
+following 'apply' or 'delete' manage them. The instances are grouped by node
+pool, the number of nodes of every node pool is set to the number of instances.
+When it's importing a cluster the noun cluster is optional, as it's the default
+noun.
+
+Review the changes with 'apply --plan' before applying them, the resources not
+imported are created and the imported resources that are different to the
+cluster configuration are modified or replaced.`,
+	RunE: importClusterRun,
+}
+
+// importClusterCmd represents the 'import cluster' command
+var importClusterCmd = &cobra.Command{
+	Use:     "cluster NAME",
+	Aliases: []string{"c"},
+	Short:   "Import existing infrastructure into a cluster",
+	Long: `The command import cluster is used to bring existing instances or virtual
+machines under KubeKit management. The instances of every node pool are
+imported with --instance-ids POOL-NAME=ID[,ID...]. The ID is the instance ID on
+EC2, the VM inventory path on vSphere or the instance UUID on OpenStack.`,
+	RunE: importClusterRun,
+}
+
+func addImportCmd() {
+	// import [cluster] NAME --platform NAME --instance-ids POOL-NAME=ID[,ID...] --path PATH --format FORMAT --var NAME01=VALUE01 ...
+	RootCmd.AddCommand(importCmd)
+	addImportFlags(importCmd)
+
+	importCmd.AddCommand(importClusterCmd)
+	addImportFlags(importClusterCmd)
+}
+
+func addImportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("platform", "p", "", "platform where the instances to import are (Example: ec2, vsphere, openstack)")
+	cmd.Flags().StringArray("instance-ids", []string{}, "instances to import into a node pool in the form POOL-NAME=ID[,ID...]. Can be used multiple times")
+	cmd.Flags().String("path", "", "path to store the cluster configuration file if is not the default location")
+	cmd.Flags().StringP("format", "f", "yaml", "cluster config file format. Available formats: 'json', 'yaml' and 'toml'")
+	cmd.Flags().StringArray("var", []string{}, "KubeKit variable to be used for the cluster configuration")
+	// ... [credentials]
+	cmd.Flags().String("server", "", "Provisioner Server IP or DNS. Also retrived from $KUBEKIT_<PLATFORM>_SERVER or $<PLATFORM>_SERVER, like $VSPHERE_SERVER")
+	cmd.Flags().String("username", "", "Provisioner Username. Also retrived from $KUBEKIT_<PLATFORM>_USERNAME or $<PLATFORM>_USERNAME, like $VSPHERE_USERNAME")
+	cmd.Flags().String("password", "", "Provisioner Password. Also retrived from $KUBEKIT_<PLATFORM>_PASSWORD or $<PLATFORM>_PASSWORD, like $VSPHERE_PASSWORD")
+	// ... [AWS/EC2 credentials]
+	cmd.Flags().String("access_key", "", "AWS Access Key Id. Also retrived from $KUBEKIT_AWS_ACCESS_KEY_ID or $AWS_ACCESS_KEY_ID")
+	cmd.Flags().String("secret_key", "", "AWS Secret Access Key. Also retrived from $KUBEKIT_AWS_SECRET_ACCESS_KEY or $AWS_SECRET_ACCESS_KEY")
+	cmd.Flags().String("session_token", "", "AWS Secret Session Token. Also retrived from $KUBEKIT_AWS_SESSION_TOKEN or $AWS_SESSION_TOKEN")
+	cmd.Flags().String("region", "", "AWS Default Region. Also retrived from $KUBEKIT_AWS_DEFAULT_REGION or $AWS_DEFAULT_REGION")
+	cmd.Flags().String("profile", "", "AWS Profile. Also retrived from $KUBEKIT_AWS_PROFILE or $AWS_PROFILE")
+}
+
+func importClusterRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.ImportGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	var cluster *kluster.Kluster
+
+	// Import into the existing cluster configuration, if any, otherwise create it
+	// like the `init` command does
+	if len(kluster.Path(opts.ClusterName, config.ClustersDir())) != 0 {
+		if cluster, err = loadCluster(opts.ClusterName); err != nil {
+			return err
+		}
+		if platform := cluster.Platform(); platform != opts.Platform {
+			return cli.UserErrorf("the cluster %q exists on the %s platform, cannot import instances from %s", opts.ClusterName, platform, opts.Platform)
+		}
+	} else {
+		switch opts.Platform {
+		case "ec2", "vsphere", "openstack":
+		default:
+			return cli.UserErrorf("the platform %q does not support import, the supported platforms are: ec2, vsphere and openstack", opts.Platform)
+		}
+		if _, err := initCluster(opts.InitOpts); err != nil {
+			return err
+		}
+		// load the new cluster to get the platform with the credentials
+		if cluster, err = kluster.LoadCluster(opts.ClusterName, opts.Path, config.UI); err != nil {
+			return err
+		}
+	}
+
+	// no other KubeKit, local or sharing the storage, can apply it at same time
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// the SSH keys are required for the terraform templates and provisioner
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	if err := cluster.Import(opts.InstanceIDs); err != nil {
+		return err
+	}
+
+	config.UI.Log.Infof("the instances were imported into the cluster %q, review the changes with 'kubekit apply %s --plan' before applying them", opts.ClusterName, opts.ClusterName)
+	return nil
+}
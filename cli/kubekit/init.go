@@ -175,11 +175,17 @@ func initClusterRun(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	_, err = initCluster(opts)
+	return err
+}
+
+// initCluster creates the configuration and credentials of a new cluster
+func initCluster(opts *cli.InitOpts) (cluster *kluster.Kluster, err error) {
 	if len(opts.Path) == 0 {
 		opts.Path = config.ClustersDir()
 	} else {
 		if _, err := os.Stat(opts.Path); os.IsNotExist(err) {
-			return cli.UserErrorf("path %q does not exists", opts.Path)
+			return nil, cli.UserErrorf("path %q does not exists", opts.Path)
 		}
 	}
 
@@ -197,12 +203,15 @@ func initClusterRun(cmd *cobra.Command, args []string) error {
 	// credentials. They do not have them
 	switch opts.Platform {
 	case "vra", "raw", "stacki":
-		return createClusterConfig()
+		if err := createClusterConfig(); err != nil {
+			return nil, err
+		}
+		return cluster, nil
 
 	case "aws":
 		// Special case, "aws" is no longer a concrete platform, instead use ec2
 		// Alternate option would be to accept aws but silently remap it to ec2
-		return cli.UserErrorf("'aws' is no longer a supported platform name, use 'ec2' instead")
+		return nil, cli.UserErrorf("'aws' is no longer a supported platform name, use 'ec2' instead")
 	}
 
 	config.UI.Log.Debugf("initializing cluster %q credentials", opts.ClusterName)
@@ -212,7 +221,7 @@ func initClusterRun(cmd *cobra.Command, args []string) error {
 	// variables, in that order of priority
 	credentials := kluster.NewCredentials(opts.ClusterName, opts.Platform, "")
 	if err := credentials.AssignFromMap(opts.Credentials); err != nil {
-		return err
+		return nil, err
 	}
 
 	if !credentials.Complete() {
@@ -227,18 +236,18 @@ func initClusterRun(cmd *cobra.Command, args []string) error {
 		// 3rd: ask the missing values to the user
 		config.UI.Log.Debugf("get credentials asking the user")
 		if err := credentials.Ask(); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	if err := createClusterConfig(); err != nil {
-		return err
+		return nil, err
 	}
 
 	credentialsPath := filepath.Join(filepath.Dir(cluster.Path()), ".credentials")
 	credentials.SetPath(credentialsPath)
 
-	return credentials.Write()
+	return cluster, credentials.Write()
 }
 
 func initTemplateRun(cmd *cobra.Command, args []string) error {
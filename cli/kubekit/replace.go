@@ -0,0 +1,102 @@
+package kubekit
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// replaceCmd represents the replace command
+var replaceCmd = &cobra.Command{
+	Use:   "replace",
+	Short: "Replaces an element of a cluster",
+	Long: `The replace command is used to replace an element of a cluster, such as a
+failed node, with a new one.`,
+}
+
+// replaceNodeCmd represents the 'replace node' command
+var replaceNodeCmd = &cobra.Command{
+	Use:   "node CLUSTER-NAME NODE",
+	Short: "Replaces a node of a cluster with a new one",
+	Long: `The command replace node destroys the given node, by IP, DNS or hostname, and
+provisions a new one. The node is cordoned and drained, then removed from the
+cluster, if it's a master node it's also removed from the etcd cluster. Only
+the instance of the node is provisioned again and only the new node is
+configured to join the cluster. This command is available for the ec2, vsphere
+and openstack platforms.`,
+	RunE: replaceNodeRun,
+}
+
+func addReplaceCmd() {
+	// replace node CLUSTER-NAME NODE --drain-timeout DURATION --CERT-key-file FILE --CERT-cert-file FILE
+	RootCmd.AddCommand(replaceCmd)
+
+	replaceCmd.AddCommand(replaceNodeCmd)
+	replaceNodeCmd.Flags().String("drain-timeout", kluster.DefaultDrainTimeout.String(), "time to wait for the pods of the node to be evicted before replace it")
+	addCertFlags(replaceNodeCmd)
+}
+
+func replaceNodeRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.ReplaceGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	// no other KubeKit, local or sharing the storage, can apply it at same time
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// the SSH keys are required for the terraform templates and provisioner
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	newHost, errR := cluster.Replace(opts.Node, opts.DrainTimeout)
+	// Save the cluster, even if the replacement failed the node may be destroyed
+	if err := cluster.Save(); err != nil {
+		if errR != nil {
+			return fmt.Errorf("failed to replace the node and to save the cluster configuration file.\n%s\n%s", errR, err)
+		}
+		return err
+	}
+	if errR != nil {
+		return errR
+	}
+
+	// the new node requires certificates and to join the existing Kubernetes cluster
+	userCACertsFiles, err := cli.GetCertFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if err := initCertificates(opts.ClusterName, cluster, false, userCACertsFiles); err != nil {
+		return err
+	}
+	if err := cluster.LoadState(); err != nil {
+		return err
+	}
+
+	errC := cluster.ConfigureReplacement(newHost)
+	if err := cluster.Save(); err != nil {
+		if errC != nil {
+			return fmt.Errorf("failed to configure the new node and to save the cluster configuration file.\n%s\n%s", errC, err)
+		}
+		return err
+	}
+
+	return errC
+}
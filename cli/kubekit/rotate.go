@@ -0,0 +1,84 @@
+package kubekit
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// rotateCmd represents the rotate command
+var rotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotates the credentials of a cluster",
+	Long: `The rotate command is used to replace the credentials of a cluster, such as
+the certificates, with new ones and distribute them to the cluster nodes.`,
+}
+
+// rotateCertificatesCmd represents the 'rotate certificates' command
+var rotateCertificatesCmd = &cobra.Command{
+	Use:     "certificates NAME --ca",
+	Aliases: []string{"certs"},
+	Short:   "Regenerates and distributes the certificates of a cluster",
+	Long: `The command rotate certificates regenerates the certificates of the cluster,
+signed by the current CA certificates, or regenerates also the CA certificates
+with the flag --ca. The current certificates are backed up in the cluster
+directory, the new certificates are uploaded to the nodes, the control plane is
+restarted one master node at a time, then the Kubernetes services on the rest
+of the nodes, and the kubeconfig file is regenerated.
+
+Rotating the CA certificates also regenerates the service account key, the pods
+using service accounts have to be restarted after the rotation.`,
+	RunE: rotateCertificatesRun,
+}
+
+func addRotateCmd() {
+	// rotate certificates NAME --ca --timeout DURATION --CERT-key-file FILE --CERT-cert-file FILE
+	RootCmd.AddCommand(rotateCmd)
+
+	rotateCmd.AddCommand(rotateCertificatesCmd)
+	rotateCertificatesCmd.Flags().Bool("ca", false, "regenerate also the CA certificates, or use the given CA key and certificate files")
+	rotateCertificatesCmd.Flags().String("timeout", kluster.DefaultRestoreTimeout.String(), "time to wait for the cluster to be ready after restart every node")
+	addCertFlags(rotateCertificatesCmd)
+}
+
+func rotateCertificatesRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.RotateGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	// no other KubeKit, local or sharing the storage, can apply it at same time
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// the SSH keys are required to access the nodes
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	errR := cluster.RotateCerts(opts.UserCACerts, opts.CA, opts.Timeout)
+	// Save the cluster, even if the rotation failed the status may have changed
+	if err := cluster.Save(); err != nil {
+		if errR != nil {
+			return fmt.Errorf("failed to rotate the certificates and to save the cluster configuration file.\n%s\n%s", errR, err)
+		}
+		return err
+	}
+
+	return errR
+}
@@ -3,39 +3,139 @@ package kubekit
 import (
 	"fmt"
 
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
 	"github.com/spf13/cobra"
 )
 
 // scaleCmd represents the scale command
 var scaleCmd = &cobra.Command{
-	Hidden: true,
-	Use:    "scale [cluster] NAME POOL-NAME=[+|-]NUMBER",
-	Short:  "scales up or down a cluster",
+	Use:   "scale [cluster] NAME POOL-NAME=[+|-]NUMBER [POOL-NAME=[+|-]NUMBER ...]",
+	Short: "scales up or down a cluster",
 	Long: `Scales by increasing or decreasing the number of nodes in a cluster from a given
 pool. The number could be possitive (to add), negative (to remove) or unsigned
 number (assign).`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'scale' still not implemented")
-		return nil
-	},
+	RunE: scaleClusterRun,
 }
 
 // scaleClusterCmd represents the cluster command
 var scaleClusterCmd = &cobra.Command{
 	Hidden: true,
-	Use:    "cluster NAME POOL-NAME=[+|-]NUMBER",
+	Use:    "cluster NAME POOL-NAME=[+|-]NUMBER [POOL-NAME=[+|-]NUMBER ...]",
 	Short:  "scales up or down a cluster",
 	Long: `Scales by increasing or decreasing the number of nodes in a cluster from a given
 pool. The number could be possitive (to add), negative (to remove) or unsigned
 number (assign).`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'scale cluster' still not implemented")
-		return nil
-	},
+	RunE: scaleClusterRun,
 }
 
 func addScaleCmd() {
-	// scale [cluster] NAME POOL-NAME=[+|-]N
+	// scale [cluster] NAME POOL-NAME=[+|-]N [POOL-NAME=[+|-]N ...] --plan --output (table|json|yaml) --drain-timeout DURATION --force
 	RootCmd.AddCommand(scaleCmd)
+	scaleCmd.Flags().Bool("plan", false, "don't apply, just print the provisioning changes")
+	scaleCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
+	scaleCmd.Flags().String("drain-timeout", kluster.DefaultDrainTimeout.String(), "time to wait for the pods of the nodes to remove to be evicted")
+	scaleCmd.Flags().Bool("force", false, "remove the nodes without draining them if the Kubernetes cluster is not reachable")
+	addCertFlags(scaleCmd)
+
 	scaleCmd.AddCommand(scaleClusterCmd)
+	scaleClusterCmd.Flags().Bool("plan", false, "don't apply, just print the provisioning changes")
+	scaleClusterCmd.Flags().StringP("output", "o", "", "output format of the plan: 'table' (default), 'json' or 'yaml'. Applies only with --plan")
+	scaleClusterCmd.Flags().String("drain-timeout", kluster.DefaultDrainTimeout.String(), "time to wait for the pods of the nodes to remove to be evicted")
+	scaleClusterCmd.Flags().Bool("force", false, "remove the nodes without draining them if the Kubernetes cluster is not reachable")
+	addCertFlags(scaleClusterCmd)
+}
+
+func scaleClusterRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.ScaleGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int, len(opts.Pools))
+	for _, pool := range opts.Pools {
+		current, err := cluster.NodePoolCount(pool.Name)
+		if err != nil {
+			return cli.UserErrorf("%s", err)
+		}
+		count, err := pool.Count(current)
+		if err != nil {
+			return err
+		}
+		if count == current {
+			config.UI.Log.Warnf("the node pool %q already has %d nodes", pool.Name, count)
+			continue
+		}
+		config.UI.Log.Infof("scaling node pool %q of cluster %q from %d to %d nodes", pool.Name, opts.ClusterName, current, count)
+		counts[pool.Name] = count
+	}
+	if len(counts) == 0 {
+		config.UI.Log.Warnf("nothing to scale in cluster %q", opts.ClusterName)
+		return nil
+	}
+
+	// the SSH keys are required for the terraform templates and provisioner
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	if opts.Plan {
+		for poolName, count := range counts {
+			if err := cluster.SetNodePoolCount(poolName, count); err != nil {
+				return err
+			}
+		}
+		return printPlan(cmd, cluster, false)
+	}
+
+	newHosts, errS := cluster.Scale(counts, opts.DrainTimeout, opts.Force)
+	// Save the cluster, even if the scaling failed some nodes may be created or destroyed
+	if err := cluster.Save(); err != nil {
+		if errS != nil {
+			return fmt.Errorf("failed to scale the cluster and to save the cluster configuration file.\n%s\n%s", errS, err)
+		}
+		return err
+	}
+	if errS != nil {
+		return errS
+	}
+
+	if len(newHosts) == 0 {
+		return nil
+	}
+
+	// the new hosts require certificates and to join the existing Kubernetes cluster
+	userCACertsFiles, err := cli.GetCertFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if err := initCertificates(opts.ClusterName, cluster, false, userCACertsFiles); err != nil {
+		return err
+	}
+	if err := cluster.LoadState(); err != nil {
+		return err
+	}
+	if err := cluster.CreateKubeConfigFile(); err != nil {
+		return err
+	}
+
+	errC := cluster.ConfigureNodes(newHosts)
+	if err := cluster.Save(); err != nil {
+		if errC != nil {
+			return fmt.Errorf("failed to configure the new nodes and to save the cluster configuration file.\n%s\n%s", errC, err)
+		}
+		return err
+	}
+
+	return errC
 }
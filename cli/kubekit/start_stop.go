@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/liferaft/kubekit/cli"
 	"github.com/liferaft/kubekit/pkg/server"
 	"github.com/liferaft/kubekit/pkg/service"
 	homedir "github.com/mitchellh/go-homedir"
@@ -15,41 +16,29 @@ import (
 
 // startCmd represents the start command
 var startCmd = &cobra.Command{
-	Hidden: true,
-	Use:    "start [cluster] NAME[,NAME ...]",
-	Short:  "Starts a cluster or nodes",
+	Use:   "start [cluster] NAME[,NAME ...]",
+	Short: "Starts a cluster or nodes",
 	Long: `Starts a cluster, a single or multiple nodes of a cluster filtered by node name,
 IP, DNS or by the pool name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'start' still not implemented")
-		return nil
-	},
+	RunE: startClusterRun,
 }
 
 // stopCmd represents the stop command
 var stopCmd = &cobra.Command{
-	Hidden: true,
-	Use:    "stop [cluster] NAME[,NAME ...]",
-	Short:  "Stop a cluster or nodes",
+	Use:   "stop [cluster] NAME[,NAME ...]",
+	Short: "Stop a cluster or nodes",
 	Long: `Stops a cluster, a single or multiple nodes of a cluster filtered by node name,
 IP, DNS or by the pool name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'stop' still not implemented")
-		return nil
-	},
+	RunE: stopClusterRun,
 }
 
 // restartCmd represents the restart command
 var restartCmd = &cobra.Command{
-	Hidden: true,
-	Use:    "restart [cluster] NAME[,NAME ...]",
-	Short:  "Restart a cluster or nodes",
+	Use:   "restart [cluster] NAME[,NAME ...]",
+	Short: "Restart a cluster or nodes",
 	Long: `Restarts a cluster, a single or multiple nodes of a cluster filtered by node
 name, IP, DNS or by the pool name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'restart' still not implemented")
-		return nil
-	},
+	RunE: restartClusterRun,
 }
 
 // startClusterCmd represents the start custer command
@@ -59,10 +48,7 @@ var startClusterCmd = &cobra.Command{
 	Short:  "Starts a cluster or nodes",
 	Long: `Starts a cluster, a single or multiple nodes of a cluster filtered by node name,
 IP, DNS or by the pool name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'start cluster' still not implemented")
-		return nil
-	},
+	RunE: startClusterRun,
 }
 
 // stopClusterCmd represents the stop cluster command
@@ -72,10 +58,7 @@ var stopClusterCmd = &cobra.Command{
 	Short:  "Stop a cluster or nodes",
 	Long: `Stops a cluster, a single or multiple nodes of a cluster filtered by node name,
 IP, DNS or by the pool name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'stop cluster' still not implemented")
-		return nil
-	},
+	RunE: stopClusterRun,
 }
 
 // restartCmd represents the restart cluster command
@@ -85,10 +68,7 @@ var restartClusterCmd = &cobra.Command{
 	Short:  "Restart a cluster or nodes",
 	Long: `Restarts a cluster, a single or multiple nodes of a cluster filtered by node
 name, IP, DNS or by the pool name.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("[ERROR] command 'restart cluster' still not implemented")
-		return nil
-	},
+	RunE: restartClusterRun,
 }
 
 // startServerCmd represents the start server command
@@ -153,6 +133,10 @@ func addStartStopCmd() {
 	// 							--tls-private-key-file /path/to/my/ca/certs/kubekit.key
 	// 							--ca-file /path/to/my/ca/certs/client-ca.key
 	// 							--insecure --allow-cors
+	// 							--token-auth-file /path/to/tokens.csv
+	// 							--oidc-issuer-url https://issuer --oidc-client-id kubekit --oidc-jwks https://issuer/keys
+	// 							--authorization-policy-file /path/to/policy.yaml
+	// 							--audit-log-file /path/to/audit.log
 	startCmd.AddCommand(startServerCmd)
 	startServerCmd.Flags().String("host", defServerHost, "The hostname or IP address for KubeKit Server to serve on.")
 	startServerCmd.Flags().Int("port", defServerPort, "The port for the KubeKit Server to serve on.")
@@ -166,6 +150,14 @@ func addStartStopCmd() {
 	startServerCmd.Flags().Bool("insecure", false, "Do not use TLS to provide security to the server to expose the API gRPC and HTTP/REST")
 	startServerCmd.Flags().Bool("allow-cors", false, "Make the server to allow Cross-Origin Resource Sharing (CORS). Usefull for developement & testing")
 	startServerCmd.Flags().Bool("dry-run", false, "Makes API calls inert and returns generic output for testing purposes")
+	startServerCmd.Flags().String("token-auth-file", "", "If set, the file with the static tokens to authenticate the requests with a bearer token. Every line has the format: token,user,\"group1,group2\"")
+	startServerCmd.Flags().String("oidc-issuer-url", "", "The URL of the OpenID issuer. If set, the requests with an OIDC ID Token as bearer token are authenticated. Its 'iss' claim should match this value.")
+	startServerCmd.Flags().String("oidc-client-id", "", "The client ID for the OpenID Connect client, the ID Token 'aud' claim should include it. Required if --oidc-issuer-url is set.")
+	startServerCmd.Flags().String("oidc-jwks", "", "The file or URL of the JSON Web Key Set with the keys to verify the ID Token signature. Required if --oidc-issuer-url is set.")
+	startServerCmd.Flags().String("oidc-username-claim", "sub", "The OpenID claim to use as the user name.")
+	startServerCmd.Flags().String("oidc-groups-claim", "groups", "The OpenID claim to use as the user groups. The claim value is a string or a list of strings.")
+	startServerCmd.Flags().String("authorization-policy-file", "", "If set, the file with the policy rules allowing users and groups to use the verbs get, apply or delete on clusters. Every request requires an authenticated user.")
+	startServerCmd.Flags().String("audit-log-file", "", "If set, every request modifying a cluster is recorded in this file, one JSON entry per line. Use '-' for the standard output.")
 
 	// stop server
 	stopCmd.AddCommand(stopServerCmd)
@@ -174,6 +166,61 @@ func addStartStopCmd() {
 	restartCmd.AddCommand(restartServerCmd)
 }
 
+func startClusterRun(cmd *cobra.Command, args []string) error {
+	return startStopRun(cmd, args, "start")
+}
+
+func stopClusterRun(cmd *cobra.Command, args []string) error {
+	return startStopRun(cmd, args, "stop")
+}
+
+func restartClusterRun(cmd *cobra.Command, args []string) error {
+	return startStopRun(cmd, args, "restart")
+}
+
+func startStopRun(cmd *cobra.Command, args []string, action string) error {
+	opts, warns, err := cli.StartStopGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	for _, clusterName := range opts.ClustersName {
+		cluster, err := loadCluster(clusterName)
+		if err != nil {
+			return err
+		}
+
+		var errA error
+		switch action {
+		case "start":
+			errA = cluster.Start(opts.Nodes, opts.Pools)
+		case "stop":
+			errA = cluster.Stop(opts.Nodes, opts.Pools)
+		case "restart":
+			errA = cluster.Restart(opts.Nodes, opts.Pools)
+		}
+		errS := cluster.Save()
+		if errA != nil && errS != nil {
+			return fmt.Errorf("failed to %s the cluster %q and to save the cluster configuration file.\n%s\n%s", action, clusterName, errA, errS)
+		}
+		if errA != nil {
+			return errA
+		}
+		if errS != nil {
+			return errS
+		}
+
+		config.UI.Log.Infof("%s of cluster %q completed", action, clusterName)
+	}
+
+	return nil
+}
+
 func startServerRun(cmd *cobra.Command, args []string) error {
 	host := cmd.Flags().Lookup("host").Value.String()
 	// TODO: validation using TCP IP functions
@@ -221,6 +268,15 @@ func startServerRun(cmd *cobra.Command, args []string) error {
 
 	dry := cmd.Flags().Lookup("dry-run").Value.String() == "true"
 
+	tokenAuthFile := cmd.Flags().Lookup("token-auth-file").Value.String()
+	oidcIssuerURL := cmd.Flags().Lookup("oidc-issuer-url").Value.String()
+	oidcClientID := cmd.Flags().Lookup("oidc-client-id").Value.String()
+	oidcJWKS := cmd.Flags().Lookup("oidc-jwks").Value.String()
+	oidcUsernameClaim := cmd.Flags().Lookup("oidc-username-claim").Value.String()
+	oidcGroupsClaim := cmd.Flags().Lookup("oidc-groups-claim").Value.String()
+	policyFile := cmd.Flags().Lookup("authorization-policy-file").Value.String()
+	auditLogFile := cmd.Flags().Lookup("audit-log-file").Value.String()
+
 	// DEBUG:
 	// var insecureFlag string
 	// if insecure {
@@ -246,8 +302,13 @@ func startServerRun(cmd *cobra.Command, args []string) error {
 		AddServices(v1Services).
 		WithSwagger().
 		WithHealthCheck(healthzPort).
+		WithMetrics().
 		SetCORS(allowCORS).
-		SetTLS(!insecure, certDir, tlsCertFile, tlsPrivateKeyFile, caFile)
+		SetTLS(!insecure, certDir, tlsCertFile, tlsPrivateKeyFile, caFile).
+		WithTokenAuth(tokenAuthFile).
+		WithOIDCAuth(oidcIssuerURL, oidcClientID, oidcJWKS, oidcUsernameClaim, oidcGroupsClaim).
+		WithPolicy(policyFile).
+		WithAuditLog(auditLogFile)
 
 	// if allowCORS {
 	// 	s.AllowCORS()
@@ -0,0 +1,107 @@
+package kubekit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/manifest"
+	"github.com/liferaft/kubekit/pkg/packages"
+	"github.com/spf13/cobra"
+)
+
+// upgradeCmd represents the upgrade command
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [cluster] NAME --to RELEASE",
+	Short: "Upgrades a cluster to a KubeKit release",
+	Long: `Upgrade is used to upgrade the Kubernetes cluster to a newer KubeKit release
+without downtime. The upgrade path from the current release of the cluster has
+to be allowed by the KubeKit manifest. A snapshot of etcd is taken before the
+upgrade and the package of the release is installed on every node, then the
+master nodes are upgraded one at a time and the worker nodes pool by pool. Every node is drained before its upgrade and the cluster health
+is verified before continue with the next node or pool. When it's upgrading a
+cluster the noun cluster is optional, as it's the default noun.`,
+	RunE: upgradeClusterRun,
+}
+
+// upgradeClusterCmd represents the 'upgrade cluster' command
+var upgradeClusterCmd = &cobra.Command{
+	Use:     "cluster NAME --to RELEASE",
+	Aliases: []string{"c"},
+	Short:   "Upgrades a cluster to a KubeKit release",
+	Long: `The command upgrade cluster is used to upgrade the Kubernetes cluster to a
+newer KubeKit release, by default the release of this KubeKit. The master nodes
+are upgraded one at a time and the worker nodes pool by pool, draining the nodes
+before the upgrade and verifying the cluster health after.`,
+	RunE: upgradeClusterRun,
+}
+
+func addUpgradeCmd() {
+	// upgrade [cluster] NAME --to RELEASE --package-file FILE --force-pkg --drain-timeout DURATION
+	RootCmd.AddCommand(upgradeCmd)
+	addUpgradeFlags(upgradeCmd)
+
+	upgradeCmd.AddCommand(upgradeClusterCmd)
+	addUpgradeFlags(upgradeClusterCmd)
+}
+
+func addUpgradeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("to", manifest.Version, "KubeKit release to upgrade the cluster to")
+	cmd.Flags().StringP("package-file", "f", "", "package of the release to install before the upgrade. By default will be at the cluster directory named 'kubekit.rpm' or '.deb'")
+	cmd.Flags().Bool("force-pkg", false, "force install of package")
+	cmd.Flags().String("drain-timeout", kluster.DefaultDrainTimeout.String(), "time to wait for the pods of every node to be evicted before upgrade it")
+}
+
+func upgradeClusterRun(cmd *cobra.Command, args []string) error {
+	opts, warns, err := cli.UpgradeGetOpts(cmd, args)
+	if err != nil {
+		return err
+	}
+	if len(warns) != 0 {
+		for _, w := range warns {
+			config.UI.Log.Warn(w)
+		}
+	}
+
+	cluster, err := loadCluster(opts.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	pkgFilename := opts.PackageFile
+	if len(pkgFilename) == 0 {
+		if pkgFilename = findPackage(cluster.Dir()); len(pkgFilename) == 0 {
+			return cli.UserErrorf("the package of the release %s is required to upgrade the cluster, use --package-file or save it to the cluster directory named 'kubekit.rpm' or '.deb'", opts.To)
+		}
+	}
+	// the RPM package has to have the images of the target release
+	if filepath.Ext(pkgFilename) == ".rpm" {
+		if err := packages.CheckReleaseRpmPackage(opts.To, pkgFilename, opts.ForcePackage); err != nil {
+			return err
+		}
+	}
+
+	// no other KubeKit, local or sharing the storage, can apply it at same time
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// the SSH keys are required to access the nodes
+	if err := cluster.HandleKeys(); err != nil {
+		return err
+	}
+
+	errU := cluster.Upgrade(opts.To, pkgFilename, opts.ForcePackage, opts.DrainTimeout)
+	// Save the cluster, even if the upgrade failed the status has changed
+	if err := cluster.Save(); err != nil {
+		if errU != nil {
+			return fmt.Errorf("failed to upgrade the cluster and to save the cluster configuration file.\n%s\n%s", errU, err)
+		}
+		return err
+	}
+
+	return errU
+}
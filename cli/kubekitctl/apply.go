@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
 	"github.com/liferaft/kubekit/cli"
 	"github.com/spf13/cobra"
 )
@@ -36,6 +37,7 @@ func applyAddCommands() {
 	applyCmd.Flags().BoolP("configure", "c", false, "only apply the configuration. The cluster must exists. Generate the certificates (if doesn't exists), install and configure Kubernetes on the existing cluster")
 	applyCmd.Flags().StringP("package-file-url", "f", "", "URL to get a package to install before configure. If not given will use the package located in the KubeKit server")
 	applyCmd.Flags().Bool("force-pkg", false, "force install of package")
+	applyCmd.Flags().Bool("stream", false, "wait until the cluster is applied, printing the progress of every step")
 	cli.AddCertFlags(applyCmd)
 
 	applyCmd.AddCommand(applyClusterCmd)
@@ -43,6 +45,7 @@ func applyAddCommands() {
 	applyClusterCmd.Flags().BoolP("configure", "c", false, "only apply the configuration. The cluster must exists. Generate the certificates (if doesn't exists), install and configure Kubernetes on the existing cluster")
 	applyClusterCmd.Flags().StringP("package-file-url", "u", "", "URL to get a package to install before configure. If not given will use the package located in the KubeKit server")
 	applyClusterCmd.Flags().Bool("force-pkg", false, "force install of package")
+	applyClusterCmd.Flags().Bool("stream", false, "wait until the cluster is applied, printing the progress of every step")
 	cli.AddCertFlags(applyClusterCmd)
 }
 
@@ -87,6 +90,12 @@ func applyClusterRun(cmd *cobra.Command, args []string) error {
 		defer config.client.GrpcConn.Close()
 	}
 
+	if stream := cmd.Flags().Lookup("stream").Value.String() == "true"; stream {
+		return printEvents(func(fn func(*apiv1.Event)) error {
+			return config.client.ApplyStream(ctx, opts.ClusterName, opts.Action, opts.PackageURL, opts.ForcePackage, opts.UserCACerts, fn)
+		})
+	}
+
 	output, err := config.client.Apply(ctx, opts.ClusterName, opts.Action, opts.PackageURL, opts.ForcePackage, opts.UserCACerts)
 	if err != nil {
 		return err
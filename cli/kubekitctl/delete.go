@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
 	"github.com/liferaft/kubekit/cli"
 	"github.com/spf13/cobra"
 )
@@ -45,9 +46,11 @@ func deleteAddCommands() {
 	deleteCmd.PersistentFlags().Bool("force", false, "do not confirm or ask to the user before delete the resource")
 
 	deleteCmd.Flags().Bool("all", false, "delete all the cluster resources such as configuration files, certificates and state")
+	deleteCmd.Flags().Bool("stream", false, "wait until the cluster is deleted, printing the progress of every step")
 
 	deleteCmd.AddCommand(deleteClusterCmd)
 	deleteClusterCmd.Flags().Bool("all", false, "delete all the cluster resources such as configuration files, certificates and state")
+	deleteClusterCmd.Flags().Bool("stream", false, "wait until the cluster is deleted, printing the progress of every step")
 
 	deleteCmd.AddCommand(deleteClustersConfigCmd)
 }
@@ -85,6 +88,12 @@ func deleteClusterRun(cmd *cobra.Command, args []string) error {
 		defer config.client.GrpcConn.Close()
 	}
 
+	if stream := cmd.Flags().Lookup("stream").Value.String() == "true"; stream {
+		return printEvents(func(fn func(*apiv1.Event)) error {
+			return config.client.DeleteStream(ctx, opts.ClusterName, opts.DestroyAll, fn)
+		})
+	}
+
 	output, err := config.client.Delete(ctx, opts.ClusterName, opts.DestroyAll)
 	if err != nil {
 		return err
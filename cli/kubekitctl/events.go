@@ -0,0 +1,56 @@
+package kubekitctl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+)
+
+// printEvents prints every event received from a streaming request. It returns
+// an error if the request fails or if the final status reports an error
+func printEvents(request func(fn func(*apiv1.Event)) error) error {
+	var errStatus error
+	var operationID string
+
+	err := request(func(e *apiv1.Event) {
+		// the operation ID is required to cancel the operation
+		if len(operationID) == 0 && len(e.OperationId) != 0 {
+			operationID = e.OperationId
+			fmt.Printf("operation ID: %s\n", operationID)
+		}
+		fmt.Println(eventString(e))
+		if e.Type == apiv1.EventType_STATUS && len(e.Error) != 0 {
+			errStatus = fmt.Errorf("cluster %q finished with status %q. %s", e.ClusterName, e.Status, e.Error)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return errStatus
+}
+
+// eventString returns the event as a line to print
+func eventString(e *apiv1.Event) string {
+	timestamp := time.Unix(e.Timestamp, 0).Format("15:04:05")
+
+	var msg string
+	switch e.Type {
+	case apiv1.EventType_STEP_STARTED:
+		msg = fmt.Sprintf("[%s] started", e.Step)
+	case apiv1.EventType_STEP_FINISHED:
+		msg = fmt.Sprintf("[%s] finished", e.Step)
+	case apiv1.EventType_RESOURCE, apiv1.EventType_TASK:
+		msg = fmt.Sprintf("[%s] %s: %s", e.Location, e.Resource, e.Message)
+	case apiv1.EventType_STATUS:
+		msg = fmt.Sprintf("cluster %q status: %s", e.ClusterName, strings.ToLower(e.Status))
+	}
+
+	if len(e.Error) != 0 {
+		msg = fmt.Sprintf("%s. ERROR: %s", msg, e.Error)
+	}
+
+	return fmt.Sprintf("%s %s", timestamp, msg)
+}
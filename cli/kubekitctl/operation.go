@@ -0,0 +1,130 @@
+package kubekitctl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/liferaft/kubekit/cli"
+	"github.com/spf13/cobra"
+)
+
+// operationCmd represents the `operation` command
+var operationCmd = &cobra.Command{
+	Use:     "operation",
+	Aliases: []string{"op"},
+	Short:   "Lists, gets or cancels the operations executed by the server",
+	Long: `The operations are the apply, delete or update actions executed by the
+server. Use the subcommands to list them, get the steps of an operation or
+cancel a running operation.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.HelpFunc()(cmd, args)
+	},
+}
+
+// operationListCmd represents the `operation list` command
+var operationListCmd = &cobra.Command{
+	Use:     "list [CLUSTER-NAME]",
+	Aliases: []string{"ls"},
+	Short:   "Lists the operations, all or the operations of the given cluster",
+	Long: `Lists the recent operations executed by the server in JSON format. If a
+cluster name is given, only the operations of this cluster are listed.`,
+	RunE: operationListRun,
+}
+
+// operationGetCmd represents the `operation get` command
+var operationGetCmd = &cobra.Command{
+	Use:   "get ID",
+	Short: "Prints the operation with the given ID",
+	Long: `Prints in JSON format the operation with the given ID, including the steps
+done so far and the error if it failed.`,
+	RunE: operationGetRun,
+}
+
+// operationCancelCmd represents the `operation cancel` command
+var operationCancelCmd = &cobra.Command{
+	Use:   "cancel ID",
+	Short: "Cancels the running operation with the given ID",
+	Long: `Cancels the running operation with the given ID. Terraform stops after the
+resources in progress are done and the commands in execution on the nodes are
+killed. Use 'operation get ID' to know when the operation is canceled.`,
+	RunE: operationCancelRun,
+}
+
+func operationAddCommands() {
+	// operation list [CLUSTER-NAME]
+	// operation get ID
+	// operation cancel ID
+	RootCmd.AddCommand(operationCmd)
+	operationCmd.AddCommand(operationListCmd)
+	operationCmd.AddCommand(operationGetCmd)
+	operationCmd.AddCommand(operationCancelCmd)
+}
+
+func operationListRun(cmd *cobra.Command, args []string) error {
+	var clusterName string
+	if len(args) > 1 {
+		return cli.UserErrorf("accepts at most one cluster name")
+	}
+	if len(args) == 1 {
+		clusterName = args[0]
+	}
+
+	// DEBUG:
+	config.Logger.Debugf("operation list %s", clusterName)
+
+	return runOperation(func(ctx context.Context) (string, error) {
+		return config.client.ListOperations(ctx, clusterName)
+	})
+}
+
+func operationGetRun(cmd *cobra.Command, args []string) error {
+	id, err := operationID(args)
+	if err != nil {
+		return err
+	}
+
+	// DEBUG:
+	config.Logger.Debugf("operation get %s", id)
+
+	return runOperation(func(ctx context.Context) (string, error) {
+		return config.client.GetOperation(ctx, id)
+	})
+}
+
+func operationCancelRun(cmd *cobra.Command, args []string) error {
+	id, err := operationID(args)
+	if err != nil {
+		return err
+	}
+
+	// DEBUG:
+	config.Logger.Debugf("operation cancel %s", id)
+
+	return runOperation(func(ctx context.Context) (string, error) {
+		return config.client.CancelOperation(ctx, id)
+	})
+}
+
+func operationID(args []string) (string, error) {
+	if len(args) != 1 || len(args[0]) == 0 {
+		return "", cli.UserErrorf("requires one operation ID")
+	}
+	return args[0], nil
+}
+
+func runOperation(request func(ctx context.Context) (string, error)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if config.client.GrpcConn != nil {
+		defer config.client.GrpcConn.Close()
+	}
+
+	output, err := request(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(output)
+
+	return nil
+}
@@ -173,4 +173,5 @@ func addCommands() {
 	describeAddCommands()
 	getAddCommands()
 	updateAddCommands()
+	operationAddCommands()
 }
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// GetPlanOutput returns the output format of the plan from the flag `--output`
+func GetPlanOutput(cmd *cobra.Command) (string, error) {
+	var output string
+	if outputFlag := cmd.Flags().Lookup("output"); outputFlag != nil {
+		output = outputFlag.Value.String()
+	}
+
+	switch output {
+	case "", "table", "json", "yaml":
+		return output, nil
+	default:
+		return "", UserErrorf("unknown plan output format %q, the available formats are: 'table', 'json' and 'yaml'", output)
+	}
+}
+
+// PlanReport is the report of the changes to apply to the cluster
+type PlanReport kluster.PlanReport
+
+// Sprintf returns a string to print in the given format. Pretty Print (`pp`)
+// applies only for JSON
+func (pr *PlanReport) Sprintf(format string, pp bool) (string, error) {
+	switch format {
+	case "", "table":
+		return pr.Table(), nil
+	case "json":
+		return pr.JSON(pp)
+	case "yaml":
+		return pr.YAML()
+	default:
+		return "", UserErrorf("unknown format %q", format)
+	}
+}
+
+// JSON returns the plan report in JSON format
+func (pr *PlanReport) JSON(pp bool) (string, error) {
+	var (
+		output []byte
+		err    error
+	)
+
+	if pp {
+		output, err = json.MarshalIndent(pr, "", "  ")
+	} else {
+		output, err = json.Marshal(pr)
+	}
+
+	return string(output), err
+}
+
+// YAML returns the plan report in YAML format
+func (pr *PlanReport) YAML() (string, error) {
+	output, err := yaml.Marshal(pr)
+	return string(output), err
+}
+
+// Table returns the plan report as a table, with a row for every resource to
+// change followed by a row for every attribute to change
+func (pr *PlanReport) Table() string {
+	b := &bytes.Buffer{}
+	w := tabwriter.NewWriter(b, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintf(w, "Pool\tAction\tResource\tAttribute\tBefore\tAfter\n")
+	for _, pool := range pr.NodePools {
+		for _, rc := range pool.Resources {
+			fmt.Fprintf(w, "%s\t%s\t%s\t\t\t\n", pool.Name, rc.Action, rc.Address)
+			for _, attr := range rc.Attributes {
+				name := attr.Name
+				if attr.ForcesReplacement {
+					name = name + " (forces replacement)"
+				}
+				fmt.Fprintf(w, "\t\t\t%s\t%s\t%s\n", name, attr.Before, attr.After)
+			}
+		}
+	}
+	w.Flush()
+
+	fmt.Fprintf(b, "\nPlan: %d to add, %d to change, %d to destroy\n", pr.ToAdd, pr.ToChange, pr.ToDestroy)
+	if pr.Destructive {
+		fmt.Fprintf(b, "WARNING: the plan deletes or replaces resources of the cluster %q, review the changes before apply them\n", pr.ClusterName)
+	}
+
+	return b.String()
+}
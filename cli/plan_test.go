@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kraken/terraformer"
+	"github.com/liferaft/kubekit/pkg/kluster"
+)
+
+func TestPlanReport_Table(t *testing.T) {
+	report := &PlanReport{
+		ClusterName: "kkdemo",
+		Platform:    "ec2",
+		ToAdd:       1,
+		ToDestroy:   1,
+		NodePools: []*kluster.PoolPlan{
+			{
+				Name: "worker",
+				Resources: []*terraformer.ResourceChange{
+					{
+						Address: "aws_instance.kkdemo-worker",
+						Action:  "replace",
+						Attributes: []*terraformer.AttributeChange{
+							{Name: "ami", Before: "ami-1", After: "ami-2", ForcesReplacement: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		destructive bool
+		want        string
+	}{
+		{"not destructive", false, `Pool     Action    Resource                     Attribute                  Before   After
+worker   replace   aws_instance.kkdemo-worker
+                                                ami (forces replacement)   ami-1    ami-2
+
+Plan: 1 to add, 0 to change, 1 to destroy
+`},
+		{"destructive", true, `Pool     Action    Resource                     Attribute                  Before   After
+worker   replace   aws_instance.kkdemo-worker
+                                                ami (forces replacement)   ami-1    ami-2
+
+Plan: 1 to add, 0 to change, 1 to destroy
+WARNING: the plan deletes or replaces resources of the cluster "kkdemo", review the changes before apply them
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report.Destructive = tt.destructive
+			if got := trimLines(report.Table()); got != tt.want {
+				t.Errorf("PlanReport.Table() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanReport_Sprintf(t *testing.T) {
+	report := &PlanReport{ClusterName: "kkdemo", Platform: "ec2"}
+	tests := []struct {
+		format   string
+		contains string
+		wantErr  bool
+	}{
+		{"", "Plan: 0 to add", false},
+		{"table", "Plan: 0 to add", false},
+		{"json", `"cluster_name": "kkdemo"`, false},
+		{"yaml", "cluster_name: kkdemo", false},
+		{"xml", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := report.Sprintf(tt.format, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlanReport.Sprintf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(got, tt.contains) {
+				t.Errorf("PlanReport.Sprintf() = %s, want it to contain %q", got, tt.contains)
+			}
+		})
+	}
+}
+
+// trimLines removes the trailing spaces of every line, added by the tabwriter
+// to the empty cells
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
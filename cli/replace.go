@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// ReplaceOpts encapsulate all the CLI parameters received from the `replace node` command
+type ReplaceOpts struct {
+	ClusterName  string
+	Node         string
+	DrainTimeout time.Duration
+}
+
+// ReplaceGetOpts get the `replace node` command parameters from the cobra commands and arguments
+func ReplaceGetOpts(cmd *cobra.Command, args []string) (opts *ReplaceOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	if len(args) != 2 {
+		return nil, warns, UserErrorf("requires a cluster name and a node, received %d arguments. %v", len(args), args)
+	}
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args[:1], false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	node := args[1]
+	if len(node) == 0 {
+		return nil, warns, UserErrorf("node cannot be empty")
+	}
+
+	drainTimeout := kluster.DefaultDrainTimeout
+	if drainTimeoutFlag := cmd.Flags().Lookup("drain-timeout"); drainTimeoutFlag != nil {
+		if drainTimeout, err = time.ParseDuration(drainTimeoutFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("invalid drain timeout %q. %s", drainTimeoutFlag.Value.String(), err)
+		}
+	}
+
+	opts = &ReplaceOpts{
+		ClusterName:  clusterName,
+		Node:         node,
+		DrainTimeout: drainTimeout,
+	}
+
+	return opts, warns, nil
+}
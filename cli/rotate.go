@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/crypto/tls"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// RotateOpts encapsulate all the CLI parameters received from the `rotate certificates` command
+type RotateOpts struct {
+	ClusterName string
+	CA          bool
+	Timeout     time.Duration
+	UserCACerts tls.KeyPairs
+}
+
+// RotateGetOpts get the `rotate certificates` command parameters from the cobra commands and arguments
+func RotateGetOpts(cmd *cobra.Command, args []string) (opts *RotateOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	var ca bool
+	if caFlag := cmd.Flags().Lookup("ca"); caFlag != nil {
+		ca = caFlag.Value.String() == "true"
+	}
+
+	timeout := kluster.DefaultRestoreTimeout
+	if timeoutFlag := cmd.Flags().Lookup("timeout"); timeoutFlag != nil {
+		if timeout, err = time.ParseDuration(timeoutFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("invalid timeout %q. %s", timeoutFlag.Value.String(), err)
+		}
+	}
+
+	userCACerts, err := GetCertFlags(cmd)
+	if err != nil {
+		return nil, warns, err
+	}
+	// The CA certificates from the user replace the current CA certificates
+	if !ca {
+		for _, kp := range userCACerts {
+			if len(kp.KeyFile) != 0 || len(kp.CertFile) != 0 {
+				return nil, warns, UserErrorf("the CA key and certificate files replace the CA certificates, they can only be used with the flag --ca")
+			}
+		}
+	}
+
+	opts = &RotateOpts{
+		ClusterName: clusterName,
+		CA:          ca,
+		Timeout:     timeout,
+		UserCACerts: userCACerts,
+	}
+
+	return opts, warns, nil
+}
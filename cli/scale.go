@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/spf13/cobra"
+)
+
+// ScaleOpts encapsulate all the CLI parameters received from the `scale` command
+type ScaleOpts struct {
+	ClusterName  string
+	Pools        []ScalePool
+	Plan         bool
+	DrainTimeout time.Duration
+	Force        bool
+}
+
+// ScalePool is the scale operation to apply to a node pool
+type ScalePool struct {
+	Name      string
+	Operation string
+	Number    int
+}
+
+// ScaleGetOpts get the `scale` command parameters from the cobra commands and arguments
+func ScaleGetOpts(cmd *cobra.Command, args []string) (opts *ScaleOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	if len(args) < 2 {
+		return nil, warns, UserErrorf("requires a cluster name and at least one node pool with the number of nodes, like: POOL-NAME=[+|-]NUMBER")
+	}
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args[:1], false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	pools := []ScalePool{}
+	poolNames := map[string]struct{}{}
+	for _, param := range args[1:] {
+		pool, err := ParseScale(param)
+		if err != nil {
+			return nil, warns, err
+		}
+		if _, ok := poolNames[pool.Name]; ok {
+			return nil, warns, UserErrorf("node pool %q is scaled more than once", pool.Name)
+		}
+		poolNames[pool.Name] = struct{}{}
+		pools = append(pools, pool)
+	}
+
+	plan := false
+	if planFlag := cmd.Flags().Lookup("plan"); planFlag != nil {
+		plan = planFlag.Value.String() == "true"
+	}
+
+	drainTimeout := kluster.DefaultDrainTimeout
+	if drainTimeoutFlag := cmd.Flags().Lookup("drain-timeout"); drainTimeoutFlag != nil {
+		if drainTimeout, err = time.ParseDuration(drainTimeoutFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("invalid drain timeout %q. %s", drainTimeoutFlag.Value.String(), err)
+		}
+	}
+
+	force := false
+	if forceFlag := cmd.Flags().Lookup("force"); forceFlag != nil {
+		force = forceFlag.Value.String() == "true"
+	}
+
+	opts = &ScaleOpts{
+		ClusterName:  clusterName,
+		Pools:        pools,
+		Plan:         plan,
+		DrainTimeout: drainTimeout,
+		Force:        force,
+	}
+
+	return opts, warns, nil
+}
+
+// ParseScale parses the scale parameter in the form POOL-NAME=[+|-]NUMBER. The
+// operation is "+" to add, "-" to remove or empty to assign the number of nodes
+func ParseScale(param string) (pool ScalePool, err error) {
+	kv := strings.SplitN(param, "=", 2)
+	if len(kv) != 2 {
+		return pool, UserErrorf("invalid scale parameter %q, use the form POOL-NAME=[+|-]NUMBER", param)
+	}
+
+	poolName := strings.TrimSpace(kv[0])
+	if len(poolName) == 0 {
+		return pool, UserErrorf("the node pool name cannot be empty")
+	}
+
+	var op string
+	numberStr := strings.TrimSpace(kv[1])
+	if strings.HasPrefix(numberStr, "+") || strings.HasPrefix(numberStr, "-") {
+		op = numberStr[:1]
+		numberStr = numberStr[1:]
+	}
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number < 0 {
+		return pool, UserErrorf("invalid number of nodes %q for node pool %q", kv[1], poolName)
+	}
+
+	return ScalePool{
+		Name:      poolName,
+		Operation: op,
+		Number:    number,
+	}, nil
+}
+
+// Count returns the new number of nodes from the given current number of nodes
+func (p ScalePool) Count(current int) (int, error) {
+	count := p.Number
+	switch p.Operation {
+	case "+":
+		count = current + p.Number
+	case "-":
+		count = current - p.Number
+	}
+
+	if count < 0 {
+		return 0, UserErrorf("cannot remove %d nodes from node pool %q, it has %d nodes", p.Number, p.Name, current)
+	}
+
+	return count, nil
+}
+
+// String returns the scale operation in the form POOL-NAME=[+|-]NUMBER
+func (p ScalePool) String() string {
+	return fmt.Sprintf("%s=%s%d", p.Name, p.Operation, p.Number)
+}
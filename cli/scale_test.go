@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"testing"
+)
+
+func TestParseScale(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		want    ScalePool
+		wantErr bool
+	}{
+		{"add", "worker=+2", ScalePool{"worker", "+", 2}, false},
+		{"remove", "worker=-1", ScalePool{"worker", "-", 1}, false},
+		{"assign", "master=3", ScalePool{"master", "", 3}, false},
+		{"assign zero", "worker=0", ScalePool{"worker", "", 0}, false},
+		{"spaces", " worker = +2 ", ScalePool{"worker", "+", 2}, false},
+		{"no equal", "worker+2", ScalePool{}, true},
+		{"no pool", "=2", ScalePool{}, true},
+		{"no number", "worker=", ScalePool{}, true},
+		{"not a number", "worker=two", ScalePool{}, true},
+		{"double sign", "worker=+-2", ScalePool{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScale(tt.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseScale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseScale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScalePool_Count(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		number    int
+		current   int
+		want      int
+		wantErr   bool
+	}{
+		{"add", "+", 2, 3, 5, false},
+		{"remove", "-", 1, 3, 2, false},
+		{"remove all", "-", 3, 3, 0, false},
+		{"remove too many", "-", 4, 3, 0, true},
+		{"assign", "", 7, 3, 7, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ScalePool{Name: "worker", Operation: tt.operation, Number: tt.number}
+			got, err := p.Count(tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ScalePool.Count() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ScalePool.Count() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// StartStopOpts encapsulate all the CLI parameters received from the `start`,
+// `stop` and `restart` commands
+type StartStopOpts struct {
+	ClustersName []string
+	Nodes        []string
+	Pools        []string
+}
+
+// StartStopGetOpts get the `start`, `stop` or `restart` command parameters from
+// the cobra commands and arguments
+func StartStopGetOpts(cmd *cobra.Command, args []string) (opts *StartStopOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	names, err := GetMultipleClustersName(cmd, args)
+	if err != nil {
+		return nil, warns, err
+	}
+	// the clusters name could be in the form NAME[,NAME ...]
+	clustersName := []string{}
+	for _, name := range names {
+		for _, n := range strings.Split(name, ",") {
+			if n = strings.TrimSpace(n); len(n) != 0 {
+				clustersName = append(clustersName, n)
+			}
+		}
+	}
+	if len(clustersName) == 0 {
+		return nil, warns, UserErrorf("requires a cluster name")
+	}
+
+	// Nodes:
+	var nodes []string
+	if nodesFlag := cmd.Flags().Lookup("nodes"); nodesFlag != nil {
+		if nodes, err = StringToArray(nodesFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("failed to parse the list of nodes")
+		}
+	}
+
+	// Pools:
+	var pools []string
+	if poolsFlag := cmd.Flags().Lookup("pools"); poolsFlag != nil {
+		if pools, err = StringToArray(poolsFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("failed to parse the list of pools")
+		}
+	}
+
+	if len(nodes) != 0 && len(pools) != 0 {
+		return nil, warns, UserErrorf("'nodes' and 'pools' flags are mutually exclusive, use --nodes or --pools but not both in the same command")
+	}
+
+	if len(clustersName) > 1 && (len(nodes) != 0 || len(pools) != 0) {
+		warns = append(warns, "the nodes or pools will be filtered in every cluster")
+	}
+
+	return &StartStopOpts{
+		ClustersName: clustersName,
+		Nodes:        nodes,
+		Pools:        pools,
+	}, warns, nil
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestStartStopGetOpts(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		flags   map[string]string
+		want    *StartStopOpts
+		wantErr bool
+	}{
+		{"one cluster", []string{"kkdemo"}, nil, &StartStopOpts{[]string{"kkdemo"}, []string{}, []string{}}, false},
+		{"clusters with comma", []string{"kkdemo1,kkdemo2"}, nil, &StartStopOpts{[]string{"kkdemo1", "kkdemo2"}, []string{}, []string{}}, false},
+		{"clusters as args", []string{"kkdemo1", "kkdemo2,"}, nil, &StartStopOpts{[]string{"kkdemo1", "kkdemo2"}, []string{}, []string{}}, false},
+		{"nodes", []string{"kkdemo"}, map[string]string{"nodes": "10.0.0.1,worker000"}, &StartStopOpts{[]string{"kkdemo"}, []string{"10.0.0.1", "worker000"}, []string{}}, false},
+		{"pools", []string{"kkdemo"}, map[string]string{"pools": "worker"}, &StartStopOpts{[]string{"kkdemo"}, []string{}, []string{"worker"}}, false},
+		{"no cluster", []string{}, nil, nil, true},
+		{"empty cluster", []string{","}, nil, nil, true},
+		{"nodes and pools", []string{"kkdemo"}, map[string]string{"nodes": "10.0.0.1", "pools": "worker"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringSliceP("nodes", "n", nil, "")
+			cmd.Flags().StringSliceP("pools", "p", nil, "")
+			for name, value := range tt.flags {
+				cmd.Flags().Set(name, value)
+			}
+
+			got, _, err := StartStopGetOpts(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StartStopGetOpts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StartStopGetOpts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/manifest"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeOpts encapsulate all the CLI parameters received from the `upgrade` command
+type UpgradeOpts struct {
+	ClusterName  string
+	To           string
+	PackageFile  string
+	ForcePackage bool
+	DrainTimeout time.Duration
+}
+
+// UpgradeGetOpts get the `upgrade` command parameters from the cobra commands and arguments
+func UpgradeGetOpts(cmd *cobra.Command, args []string) (opts *UpgradeOpts, warns []string, err error) {
+	warns = make([]string, 0)
+
+	// cluster_name
+	clusterName, err := GetOneClusterName(cmd, args, false)
+	if err != nil {
+		return nil, warns, err
+	}
+
+	to := manifest.Version
+	if toFlag := cmd.Flags().Lookup("to"); toFlag != nil && len(toFlag.Value.String()) != 0 {
+		to = toFlag.Value.String()
+	}
+
+	var pkgFile string
+	if pkgFileFlag := cmd.Flags().Lookup("package-file"); pkgFileFlag != nil && len(pkgFileFlag.Value.String()) != 0 {
+		if pkgFile, err = filepath.Abs(pkgFileFlag.Value.String()); err != nil {
+			return nil, warns, fmt.Errorf("failed to get the absolute path of the given file path %q. %s", pkgFileFlag.Value.String(), err)
+		}
+	}
+
+	var forcePkg bool
+	if forcePkgFlag := cmd.Flags().Lookup("force-pkg"); forcePkgFlag != nil {
+		forcePkg = forcePkgFlag.Value.String() == "true"
+	}
+
+	drainTimeout := kluster.DefaultDrainTimeout
+	if drainTimeoutFlag := cmd.Flags().Lookup("drain-timeout"); drainTimeoutFlag != nil {
+		if drainTimeout, err = time.ParseDuration(drainTimeoutFlag.Value.String()); err != nil {
+			return nil, warns, UserErrorf("invalid drain timeout %q. %s", drainTimeoutFlag.Value.String(), err)
+		}
+	}
+
+	opts = &UpgradeOpts{
+		ClusterName:  clusterName,
+		To:           to,
+		PackageFile:  pkgFile,
+		ForcePackage: forcePkg,
+		DrainTimeout: drainTimeout,
+	}
+
+	return opts, warns, nil
+}
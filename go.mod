@@ -8,10 +8,12 @@ require (
 	github.com/aws/aws-sdk-go v1.25.4
 	github.com/cavaliercoder/badio v0.0.0-20160213150051-ce5280129e9e // indirect
 	github.com/cavaliercoder/go-rpm v0.0.0-20190131055624-7a9c54e3d83e
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/evanphx/json-patch v4.5.0+incompatible
 	github.com/go-ini/ini v1.48.0
 	github.com/golang/protobuf v1.3.2
 	github.com/golang/snappy v0.0.1 // indirect
+	github.com/gophercloud/gophercloud v0.4.1-0.20190920074709-6e93a6ba3b09
 	github.com/grpc-ecosystem/grpc-gateway v1.11.3
 	github.com/hashicorp/hcl v1.0.0
 	github.com/hashicorp/terraform v0.12.20
@@ -40,6 +42,7 @@ require (
 	github.com/terraform-providers/terraform-provider-openstack v1.23.0
 	github.com/terraform-providers/terraform-provider-template v1.0.1-0.20190501175038-5333ad92003c
 	github.com/terraform-providers/terraform-provider-vsphere v1.13.0
+	github.com/vmware/govmomi v0.21.0
 	github.com/zclconf/go-cty v1.2.1
 	golang.org/x/crypto v0.0.0-20191029031824-8986dd9e96cf
 	golang.org/x/net v0.0.0-20191009170851-d66e71096ffb
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"strings"
 	"time"
 
@@ -20,11 +21,71 @@ import (
 func (c *Config) Apply(ctx context.Context, clusterName string, action string, pkgURL string, forcePkg bool, userCACerts tls.KeyPairs) (string, error) {
 	c.Logger.Debugf("Sending parameters to server to apply changes to the cluster %q", clusterName)
 
-	var (
-		applyAction int32
-		ok          bool
-	)
-	if applyAction, ok = apiv1.ApplyAction_value[strings.ToUpper(action)]; !ok {
+	applyAction, caCerts := applyParams(action, userCACerts)
+
+	return c.RunGRPCnRESTFunc("apply", true,
+		func() (string, error) {
+			return c.applyGRPC(ctx, clusterName, applyAction, pkgURL, forcePkg, caCerts)
+		},
+		func() (string, error) {
+			return c.applyHTTP(clusterName, applyAction, pkgURL, forcePkg, caCerts)
+		})
+}
+
+// ApplyStream applies the changes to the cluster like Apply, but it waits until
+// the cluster is applied calling fn with every event received from the KubeKit
+// Server. It uses gRPC or, if there is no gRPC connection, HTTP/REST
+func (c *Config) ApplyStream(ctx context.Context, clusterName string, action string, pkgURL string, forcePkg bool, userCACerts tls.KeyPairs, fn EventFunc) error {
+	c.Logger.Debugf("Sending parameters to server to apply changes to the cluster %q and receive the events", clusterName)
+
+	applyAction, caCerts := applyParams(action, userCACerts)
+
+	if c.GrpcClient != nil {
+		reqApply := apiv1.ApplyRequest{
+			Api:          c.APIVersion,
+			ClusterName:  clusterName,
+			Action:       apiv1.ApplyAction(applyAction),
+			PackageUrl:   pkgURL,
+			ForcePackage: forcePkg,
+			CaCerts:      caCerts,
+		}
+		stream, err := c.GrpcClient.ApplyStream(ctx, &reqApply)
+		if err != nil {
+			return grpc.Errorf(codes.Internal, "failed to request apply. %s", err)
+		}
+		return recvEventsGRPC(stream.Recv, fn)
+	}
+
+	if c.HTTPClient == nil {
+		return fmt.Errorf("there is no gRPC or HTTP/REST connection to the KubeKit server")
+	}
+
+	applyURL := fmt.Sprintf("%s/api/%s/cluster/%s/stream", c.HTTPBaseURL, c.APIVersion, clusterName)
+	variablesJSON, err := applyVariablesInJSON(clusterName, applyAction, pkgURL, forcePkg, caCerts)
+	if err != nil {
+		return err
+	}
+	c.Logger.Debugf(`curl request: curl -s -k -N -X POST -d '%s' "%s"`, string(variablesJSON), applyURL)
+
+	req, err := http.NewRequest(http.MethodPost, applyURL, bytes.NewBuffer(variablesJSON))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return recvEventsHTTP(resp, fn)
+}
+
+// applyParams returns the apply action and the CA certificates as they are
+// sent in the apply requests
+func applyParams(action string, userCACerts tls.KeyPairs) (int32, map[string]string) {
+	applyAction, ok := apiv1.ApplyAction_value[strings.ToUpper(action)]
+	if !ok {
 		applyAction = int32(apiv1.ApplyAction_ALL)
 	}
 
@@ -36,13 +97,7 @@ func (c *Config) Apply(ctx context.Context, clusterName string, action string, p
 		}
 	}
 
-	return c.RunGRPCnRESTFunc("apply", true,
-		func() (string, error) {
-			return c.applyGRPC(ctx, clusterName, applyAction, pkgURL, forcePkg, caCerts)
-		},
-		func() (string, error) {
-			return c.applyHTTP(clusterName, applyAction, pkgURL, forcePkg, caCerts)
-		})
+	return applyAction, caCerts
 }
 
 func (c *Config) applyGRPC(ctx context.Context, clusterName string, action int32, pkgURL string, forcePkg bool, caCerts map[string]string) (string, error) {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"time"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -27,6 +28,45 @@ func (c *Config) Delete(ctx context.Context, clusterName string, destroyAll bool
 		})
 }
 
+// DeleteStream deletes the cluster like Delete, but it waits until the cluster
+// is deleted calling fn with every event received from the KubeKit Server. It
+// uses gRPC or, if there is no gRPC connection, HTTP/REST
+func (c *Config) DeleteStream(ctx context.Context, clusterName string, destroyAll bool, fn EventFunc) error {
+	c.Logger.Debugf("Sending parameters to server to delete the cluster %q and receive the events", clusterName)
+
+	if c.GrpcClient != nil {
+		reqDelete := apiv1.DeleteRequest{
+			Api:         c.APIVersion,
+			ClusterName: clusterName,
+			DestroyAll:  destroyAll,
+		}
+		stream, err := c.GrpcClient.DeleteStream(ctx, &reqDelete)
+		if err != nil {
+			return grpc.Errorf(codes.Internal, "failed to request delete. %s", err)
+		}
+		return recvEventsGRPC(stream.Recv, fn)
+	}
+
+	if c.HTTPClient == nil {
+		return fmt.Errorf("there is no gRPC or HTTP/REST connection to the KubeKit server")
+	}
+
+	deleteURL := fmt.Sprintf("%s/api/%s/cluster/%s/stream?destroy_all=%t", c.HTTPBaseURL, c.APIVersion, clusterName, destroyAll)
+	c.Logger.Debugf(`curl request: curl -s -k -N -X DELETE "%s"`, deleteURL)
+
+	req, err := http.NewRequest(http.MethodDelete, deleteURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return recvEventsHTTP(resp, fn)
+}
+
 func (c *Config) deleteGRPC(ctx context.Context, clusterName string, destroyAll bool) (string, error) {
 	if c.GrpcClient == nil {
 		return "", nil
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+)
+
+// ListOperations returns the operations of the KubeKit Server, or only the
+// operations of the given cluster, using HTTP/REST or gRPC
+func (c *Config) ListOperations(ctx context.Context, clusterName string) (string, error) {
+	c.Logger.Debugf("Sending parameters to server to list the operations of cluster %q", clusterName)
+
+	return c.RunGRPCnRESTFunc("list operations", true,
+		func() (string, error) {
+			if c.GrpcClient == nil {
+				return "", nil
+			}
+			childCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			c.Logger.Debugf(`grpcurl request: grpcurl -insecure -d '{"cluster_name": %q}' %s:%s kubekit.%s.Kubekit/ListOperations`, clusterName, c.Host, c.GrpcPort, c.APIVersion)
+			res, err := c.GrpcClient.ListOperations(childCtx, &apiv1.ListOperationsRequest{
+				Api:         c.APIVersion,
+				ClusterName: clusterName,
+			})
+			if err != nil {
+				return "", grpc.Errorf(codes.Internal, "failed to request the list of operations. %s", err)
+			}
+			return operationJSON(res)
+		},
+		func() (string, error) {
+			listURL := fmt.Sprintf("%s/api/%s/operation", c.HTTPBaseURL, c.APIVersion)
+			if len(clusterName) != 0 {
+				listURL = listURL + "?cluster_name=" + url.QueryEscape(clusterName)
+			}
+			return c.operationHTTP(http.MethodGet, listURL)
+		})
+}
+
+// GetOperation returns the operation with the given ID using HTTP/REST or gRPC
+func (c *Config) GetOperation(ctx context.Context, id string) (string, error) {
+	c.Logger.Debugf("Sending parameters to server to get the operation %q", id)
+
+	return c.RunGRPCnRESTFunc("get operation", true,
+		func() (string, error) {
+			if c.GrpcClient == nil {
+				return "", nil
+			}
+			childCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			c.Logger.Debugf(`grpcurl request: grpcurl -insecure -d '{"id": %q}' %s:%s kubekit.%s.Kubekit/GetOperation`, id, c.Host, c.GrpcPort, c.APIVersion)
+			res, err := c.GrpcClient.GetOperation(childCtx, &apiv1.GetOperationRequest{
+				Api: c.APIVersion,
+				Id:  id,
+			})
+			if err != nil {
+				return "", grpc.Errorf(codes.Internal, "failed to request the operation %q. %s", id, err)
+			}
+			return operationJSON(res)
+		},
+		func() (string, error) {
+			getURL := fmt.Sprintf("%s/api/%s/operation/%s", c.HTTPBaseURL, c.APIVersion, id)
+			return c.operationHTTP(http.MethodGet, getURL)
+		})
+}
+
+// CancelOperation cancels the running operation with the given ID using
+// HTTP/REST or gRPC
+func (c *Config) CancelOperation(ctx context.Context, id string) (string, error) {
+	c.Logger.Debugf("Sending parameters to server to cancel the operation %q", id)
+
+	return c.RunGRPCnRESTFunc("cancel operation", true,
+		func() (string, error) {
+			if c.GrpcClient == nil {
+				return "", nil
+			}
+			childCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			c.Logger.Debugf(`grpcurl request: grpcurl -insecure -d '{"id": %q}' %s:%s kubekit.%s.Kubekit/CancelOperation`, id, c.Host, c.GrpcPort, c.APIVersion)
+			res, err := c.GrpcClient.CancelOperation(childCtx, &apiv1.CancelOperationRequest{
+				Api: c.APIVersion,
+				Id:  id,
+			})
+			if err != nil {
+				return "", grpc.Errorf(codes.Internal, "failed to cancel the operation %q. %s", id, err)
+			}
+			return operationJSON(res)
+		},
+		func() (string, error) {
+			cancelURL := fmt.Sprintf("%s/api/%s/operation/%s/cancel", c.HTTPBaseURL, c.APIVersion, id)
+			return c.operationHTTP(http.MethodPost, cancelURL)
+		})
+}
+
+func operationJSON(res proto.Message) (string, error) {
+	jsm := jsonpb.Marshaler{
+		EmitDefaults: true,
+	}
+	resJSON, err := jsm.MarshalToString(res)
+	if err != nil {
+		return "", grpc.Errorf(codes.Internal, "failed to marshall the received operation response: %+v. %s", res, err)
+	}
+	return resJSON, nil
+}
+
+func (c *Config) operationHTTP(method, reqURL string) (string, error) {
+	var body []byte
+	if method == http.MethodPost {
+		body = []byte("{}")
+		c.Logger.Debugf(`curl request: curl -s -k -X POST -d '{}' "%s"`, reqURL)
+	} else {
+		c.Logger.Debugf(`curl request: curl -s -k -X %s "%s"`, method, reqURL)
+	}
+
+	req, err := http.NewRequest(method, reqURL, bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	resJSON, err := ioutil.ReadAll(resp.Body)
+	return string(resJSON), err
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// EventFunc is called for every event received from a streaming request
+type EventFunc func(*apiv1.Event)
+
+// httpStreamChunk is every message sent by the grpc-gateway as response of a
+// streaming request, it contain either the result or an error
+type httpStreamChunk struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		GrpcCode int32  `json:"grpc_code"`
+		Message  string `json:"message"`
+	} `json:"error"`
+}
+
+// recvEventsGRPC receives the events from a gRPC stream until it's closed
+func recvEventsGRPC(recv func() (*apiv1.Event, error), fn EventFunc) error {
+	for {
+		event, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return grpc.Errorf(codes.Internal, "failed to receive the events. %s", err)
+		}
+		fn(event)
+	}
+}
+
+// recvEventsHTTP receives the events from the chunked JSON response of a
+// streaming request made to the grpc-gateway
+func recvEventsHTTP(resp *http.Response, fn EventFunc) error {
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var chunk httpStreamChunk
+		err := decoder.Decode(&chunk)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode the received events. %s", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("%s. gRPC Code: %s", chunk.Error.Message, codes.Code(chunk.Error.GrpcCode))
+		}
+
+		event := &apiv1.Event{}
+		if err := jsonpb.Unmarshal(bytes.NewReader(chunk.Result), event); err != nil {
+			return fmt.Errorf("failed to unmarshal the received event %s. %s", string(chunk.Result), err)
+		}
+		fn(event)
+	}
+}
@@ -0,0 +1,129 @@
+package configurator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/configurator/resources"
+)
+
+// encapsulatedPlatforms are the platforms where the pods traffic has to be
+// encapsulated, the cloud network drops the packets to the pods IP addresses
+var encapsulatedPlatforms = map[string]struct{}{
+	"ec2":   struct{}{},
+	"eks":   struct{}{},
+	"aks":   struct{}{},
+	"azure": struct{}{},
+}
+
+// CNI is the configuration of the network plugin of the cluster: the CNI
+// provider and the options of every provider. Only the options of the selected
+// provider are used
+type CNI struct {
+	Provider string         `json:"provider" yaml:"provider" mapstructure:"provider"`
+	Calico   CalicoOptions  `json:"calico" yaml:"calico" mapstructure:"calico"`
+	Cilium   CiliumOptions  `json:"cilium" yaml:"cilium" mapstructure:"cilium"`
+	Flannel  FlannelOptions `json:"flannel" yaml:"flannel" mapstructure:"flannel"`
+}
+
+// CalicoOptions are the options of the Calico CNI provider
+type CalicoOptions struct {
+	IPEncapsulation string `json:"ip_encapsulation,omitempty" yaml:"ip_encapsulation,omitempty" mapstructure:"ip_encapsulation"` // Always, CrossSubnet or Off. If empty, cni_ip_encapsulation is used
+}
+
+// CiliumOptions are the options of the Cilium CNI provider
+type CiliumOptions struct {
+	Tunnel            string `json:"tunnel" yaml:"tunnel" mapstructure:"tunnel"`                                     // vxlan, geneve or disabled
+	PolicyEnforcement string `json:"policy_enforcement" yaml:"policy_enforcement" mapstructure:"policy_enforcement"` // default, always or never
+}
+
+// FlannelOptions are the options of the Flannel CNI provider
+type FlannelOptions struct {
+	Backend string `json:"backend" yaml:"backend" mapstructure:"backend"` // vxlan or host-gw
+}
+
+var defaultCNI = CNI{
+	Provider: "calico",
+	Cilium: CiliumOptions{
+		Tunnel:            "vxlan",
+		PolicyEnforcement: "default",
+	},
+	Flannel: FlannelOptions{
+		Backend: "vxlan",
+	},
+}
+
+// validate sets the default value of the empty options and returns an error if
+// the CNI provider is not supported on the given platform or any option of the
+// provider is invalid on that platform
+func (c *CNI) validate(platform string) error {
+	if len(c.Provider) == 0 {
+		c.Provider = defaultCNIProvider(platform)
+	}
+	if len(c.Cilium.Tunnel) == 0 {
+		c.Cilium.Tunnel = defaultCNI.Cilium.Tunnel
+	}
+	if len(c.Cilium.PolicyEnforcement) == 0 {
+		c.Cilium.PolicyEnforcement = defaultCNI.Cilium.PolicyEnforcement
+	}
+	if len(c.Flannel.Backend) == 0 {
+		c.Flannel.Backend = defaultCNI.Flannel.Backend
+	}
+
+	providers := resources.CNIProvidersFor(platform)
+	if !inList(c.Provider, providers) {
+		return fmt.Errorf("the CNI provider %q is not supported on the %s platform, the supported CNI providers are: %s", c.Provider, platform, strings.Join(providers, ", "))
+	}
+
+	_, encapsulated := encapsulatedPlatforms[platform]
+
+	switch c.Provider {
+	case "calico":
+		if !inList(c.Calico.IPEncapsulation, []string{"", "Always", "CrossSubnet", "Off"}) {
+			return fmt.Errorf("unknown Calico IP encapsulation %q, it can be 'Always', 'CrossSubnet' or 'Off'", c.Calico.IPEncapsulation)
+		}
+	case "cilium":
+		if !inList(c.Cilium.Tunnel, []string{"vxlan", "geneve", "disabled"}) {
+			return fmt.Errorf("unknown Cilium tunnel %q, it can be 'vxlan', 'geneve' or 'disabled'", c.Cilium.Tunnel)
+		}
+		if c.Cilium.Tunnel == "disabled" && encapsulated {
+			return fmt.Errorf("the Cilium tunnel cannot be disabled on the %s platform, the pods traffic has to be encapsulated", platform)
+		}
+		if !inList(c.Cilium.PolicyEnforcement, []string{"default", "always", "never"}) {
+			return fmt.Errorf("unknown Cilium policy enforcement %q, it can be 'default', 'always' or 'never'", c.Cilium.PolicyEnforcement)
+		}
+	case "flannel":
+		if !inList(c.Flannel.Backend, []string{"vxlan", "host-gw"}) {
+			return fmt.Errorf("unknown Flannel backend %q, it can be 'vxlan' or 'host-gw'", c.Flannel.Backend)
+		}
+		if c.Flannel.Backend == "host-gw" && encapsulated {
+			return fmt.Errorf("the Flannel backend host-gw is not supported on the %s platform, the pods traffic has to be encapsulated", platform)
+		}
+	}
+
+	return nil
+}
+
+// DefaultFor replaces the default CNI provider with the default provider of the
+// given platform, if it's not supported on that platform
+func (c *CNI) DefaultFor(platform string) {
+	providers := resources.CNIProvidersFor(platform)
+	if c.Provider != defaultCNI.Provider || inList(c.Provider, providers) {
+		return
+	}
+	c.Provider = providers[0]
+}
+
+// defaultCNIProvider returns the default CNI provider of the given platform
+func defaultCNIProvider(platform string) string {
+	return resources.CNIProvidersFor(platform)[0]
+}
+
+func inList(value string, list []string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package configurator
+
+import "testing"
+
+func TestCNI_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		cni      CNI
+		platform string
+		wantErr  bool
+		want     CNI
+	}{
+		{"empty is calico", CNI{}, "vsphere", false, defaultCNI},
+		{"cilium", CNI{Provider: "cilium"}, "ec2", false, CNI{Provider: "cilium", Cilium: defaultCNI.Cilium, Flannel: defaultCNI.Flannel}},
+		{"flannel host-gw", CNI{Provider: "flannel", Flannel: FlannelOptions{Backend: "host-gw"}}, "raw", false, CNI{Provider: "flannel", Cilium: defaultCNI.Cilium, Flannel: FlannelOptions{Backend: "host-gw"}}},
+		{"unknown provider", CNI{Provider: "weave"}, "vsphere", true, CNI{}},
+		{"cilium on eks", CNI{Provider: "cilium"}, "eks", true, CNI{}},
+		{"flannel host-gw on ec2", CNI{Provider: "flannel", Flannel: FlannelOptions{Backend: "host-gw"}}, "ec2", true, CNI{}},
+		{"cilium without tunnel on ec2", CNI{Provider: "cilium", Cilium: CiliumOptions{Tunnel: "disabled"}}, "ec2", true, CNI{}},
+		{"unknown cilium policy enforcement", CNI{Provider: "cilium", Cilium: CiliumOptions{PolicyEnforcement: "strict"}}, "stacki", true, CNI{}},
+		{"unknown calico encapsulation", CNI{Calico: CalicoOptions{IPEncapsulation: "VXLAN"}}, "openstack", true, CNI{}},
+		{"empty is cilium on azure", CNI{}, "azure", false, CNI{Provider: "cilium", Cilium: defaultCNI.Cilium, Flannel: defaultCNI.Flannel}},
+		{"calico on azure", CNI{Provider: "calico"}, "azure", true, CNI{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cni.validate(tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CNI.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.cni != tt.want {
+				t.Errorf("CNI.validate() = %+v, want %+v", tt.cni, tt.want)
+			}
+		})
+	}
+}
+
+func TestCNI_DefaultFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		platform string
+		want     string
+	}{
+		{"default on vsphere", "calico", "vsphere", "calico"},
+		{"default on azure", "calico", "azure", "cilium"},
+		{"flannel on azure", "flannel", "azure", "flannel"},
+		{"default on eks", "calico", "eks", "calico"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := CNI{Provider: tt.provider}
+			c.DefaultFor(tt.platform)
+			if c.Provider != tt.want {
+				t.Errorf("CNI.DefaultFor() = %q, want %q", c.Provider, tt.want)
+			}
+		})
+	}
+}
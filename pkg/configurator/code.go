@@ -368,6 +368,15 @@ class CallbackModule(CallbackModule_default):  # pylint: disable=too-few-public-
 	Playbook = `- hosts: kube_cluster
   connection: local
   become: yes
+  vars:
+    # container_runtime is 'docker' or 'containerd'
+    container_runtime_service: "{{ container_runtime }}.service"
+    kubectl_cmd: "{% if container_runtime == 'containerd' %}/etc/kubernetes/bin/kubectl{% else %}docker exec kubelet kubectl{% endif %}"
+    ctr_cmd: "/usr/local/bin/ctr --namespace k8s.io"
+    crictl_cmd: /usr/local/bin/crictl
+    # images loaded to containerd are named 'docker.io/tdc/<src>', ctr requires the full name
+    ctr_repo_root: "docker.io/tdc/"
+    containerd_socket: /run/containerd/containerd.sock
   roles:
     - { role: manifest, tags: [manifest, setup] }
     - { role: precheck, tags: [precheck, setup]}
@@ -377,13 +386,14 @@ class CallbackModule(CallbackModule_default):  # pylint: disable=too-few-public-
     - { role: root-cert, tags: [root-cert, setup] }
     - { role: ipsec, tags: [ipsec, setup] }
     - { role: etcd, tags: [etcd, setup] }
-    - { role: docker/systemd, tags: [docker, systemd, setup] }
-    - { role: docker/registry, tags: [docker, registry, setup] }
+    - { role: docker/systemd, tags: [docker, systemd, setup], when: container_runtime == 'docker' }
+    - { role: docker/registry, tags: [docker, registry, setup], when: container_runtime == 'docker' }
+    - { role: containerd, tags: [containerd, setup], when: container_runtime == 'containerd' }
     - { role: kubernetes/ha, tags: [kubernetes, ha] }
     - { role: kubernetes/systemd, tags: [kubernetes, systemd] }
     - { role: kubernetes/control-plane, tags: [kubernetes, control] }
     - { role: kubernetes/security-policies, tags: [kubernetes, security] }
     - { role: kubernetes/core, tags: [kubernetes, core] }
 `
-	Data = "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1b\x00	\x00roles/dns/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[D\xcd\xd1	\x041\x08\x04\xd0\xffT1\x0d\\\x03i\xe6\xf0p\xbe.1\xa0\xc2\x92\xee\x17\xcc\xc2\xfe>\x9d\x99\x0fL&;\x9cc\x89BL\xe1\x8c\x14O\x18\xf3Z\xfeo@\xecHN\xed\x0d\xc0\xf3\xff\x1e\x81H\xc9\xaa\xa8\x1c\xb5\x90&\xbfA\xed\xd8\x8c\x02\x15\xcee\xdf3t\xf8\x0e\x00\x00\xff\xffPK\x07\x08\xd0@\x0f5X\x00\x00\x00}\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x18\x00	\x00roles/dns/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xcc\x94Mo\xd3@\x10\x86\xef\xf9\x15/\xbe\xb4=T\xbe\xaf(R\x15\x02D\xad\x92*\x8ezA\xb0\x9ax\xc7\xf6\x92\xcdn4\xbbN\xa9\xda\xfew\xe4\x0f\xa0B\xd0V\\\xe0\xe8\xd9w\x9e\x19?\x879\x85\xa7\x1d+\xbc6>\xbe\x99\x00\x867m\xad&\x00\xb0\x8b\xb5\xc2\x95X\x9f\x90\xa8\x1e\x12\xa8\x82\xe0\xa2\xdd\xf0\x85M\x98\x06_\xd9\xba\x15JA\xb0'\x89,\x93\xc9)6.\x94[5\x99\x00\xdf\xe1\xd3\x86\xcb-\xc894!\xa6\x08\x12FL\xd69\xd0\x81\xac\xa3\x8d\xe3~\xa2\xf5\xa5k\x0dk	\x8e\x87\x1d0\x12\xf6\xc2e\x07\x19\x8b\x89\xe26\xeaJ\xc2NuX\xddc59{\xe0a\xae\xb3\x9e\xad\xaf\xecO\xce\x9eR\xa3\x90s*\xf3x\x1b\xcb~\xf5\xdcs\xba	\xb2\xcd\x87\xcf1\x19\x13\xa5add\x9f\xc6\xa2p\xcd_\xf7\nG\x9f\x17\xb3\xf5t\xb9x7\x7f\xaf\xdf.\n]\xac\xcf\xd7\xf3\xa9.f\xe7\xab\xe9\x87\xcby\xb1>\x1a\x1b\xba\x0d\x14\x8e\x9eI\x9feww0>\xea\xc8$e\x83{\x18\xae\xa8u\xe9\xf8c\x86\xec\xd3	\xee\xf1%X\x7f\x9c!;\xc1\xc3C6\xd0o\x1a\xf6\xeaq\x9b\x8d]\x9f\xf5l\xfaw\x1f\x92\xadn\x15\x84] \x03\xf2\x06\xc21\x91$\x8c\x7f\xfc\xaf,\xad\xaeg\xab\xe2e\x8a\xfa\xe8#?r`\x89\x7f\x12\x84'\x0c\x0d\x8d\xff\xbb\xa2\xd5\xacX^^\xcfVzy\xb5\x9e/\x17OK\xfa5\xfcC\x13I\x1d\x7fg\xa1\xaf\xff\xa5\x82\x1d'R\xa8\\\x1b\x1b\xdd\x907\x8e%v/\x03\xbdt\xa15z/\xe1`\x0d\x0b^\x9d!\x8b\x89\xca\xad\xcd\xba[0\x1e\x97\xfc\xf9\xeb\x92\xbf\xe8\xbc|\x0b\x00\x00\xff\xffPK\x07\x08\x83\xeb\xc5{\xad\x01\x00\x00\xaf\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/docker/registry/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x9c\x92\xd1\x8e\x9b<\x10\x85\xef\xfd\x14\xa3\xdc\xf0\xff\x17\x04\x92f\xd5\x84\x8b\xbeH\x14Y\x13{\x00\x0bc\xa3\xb1\xb3U\xb4\x9bw\xaf\x0c$\xa2\xd9U[\xed\x15h\xce\xf17\xa33c\x82lQ*{	\x91\xb8\x82\xd5\xdb\x1b4\xec/C8f=\xa6Zv\x82w\xb0\xe4\x9a\xd8\xc2\x0f\xd8\x00:\x0d\xff9\x1fA\x9b\x80gKr\xb2\xc9\x16\xe1\x1d\xce\xde\xdb\xff\xe1v[	\xc1\xd4\x98\x10\xf9*\x91Uk^I\x0e\x18\xdb\xa9\x81\xba0\x93\x8b\xb2Ggj\n\xf1\x98i\x1a\xc8ir\xcaP\xc8N\xc7Ly\xa6\xf4\xbdC\xd2\xff\xc0t\xc6\x8e\xf2\xc4\xc9Nc\x93G\x0f\xd3cC\x15\xac\xa2V\xc5\x97\x1b\x04V\xcf\xdc;\xe0\xebs?\x851x\x8e\x15\xbc\x94e)\xd0Fb\x871e\xf3\xa4\x7f\xdb\x96\x9bR\x08E\x1c\xa56\\AAQ\x15Cg\x04{\x1f\xa5B\xa98\xca\xdaXr\xd8S\x05su\xad8N\x8f:\xba.d\xe75\xad;\xbaN\xda\xefOG-\xbd\x13\xda\xab\x8ex1\xca\xb8\xaf\xe2\x15\xb9\xb0\xe6\\Lrq\x97\xef\xf6\x119[\xd3\x90\xb3-\x95\xc3Z\x7f\x80\x06RL)N\xac\xeb\xedf\xaf)W{\xb5\xcfwe\xb9\xcb\x0fe\xfd\x92o\xf4\xf6\x80\xf8}w8\xd4\xb4\x12\xa2Fc\xa5\xa9\xa7\x05?N\xa97!\x18\xd7\xa4\xe9\x85\xf6?\x9d\xf5\xa8'KH\xe6\x87|\xa5 \x96\x83\x86J\x00\xe4\x10X-\xafqN\x19n\xb7t;\x9f%<.\x11\x00@S\x98\xaa\x15\xccq\xff\x95\xf7!\xf3Oa\xd6\x90\x8b\xeb\xe4\xfdG\xe2r\xc3\x7f \xa6\xad\xff\n\x00\x00\xff\xffPK\x07\x08\xaa\xc2\x8c\xc6\x9a\x01\x00\x00\xe3\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/docker/registry/files/etc/systemd/system/registry.service.d/registry.confUT\x05\x00\x01\xf0b\xe7[\x8a\x0e\xcd\xcb,\x89\xe5rL+I-\xb2M\xc9O\xceN-\xd2+N-*\xcbLN\xe5\nO\xcc+)F\x17\x0cH,*\xf1OC\x17\xe5\x8a\xf6\xcc+.I\xcc\xc9\x89\x05\xebJMq\xaaDW\x02\x08\x00\x00\xff\xffPK\x07\x08m\xa3$\xadC\x00\x00\x00j\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/docker/registry/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\xcdA\nB1\x0c\x84\xe1}O1\x17\xf0\x02\xbd\x8cD3\x88\xf8\x9aB\x92Mo/\xe4AW\xe2\xe6m\xffa\xf8n0\x19\xecp\x1eS\x14b\ng\xa4x\xc2\xf9zG\xfaj@\xacH\x0e\xed\x0d\xc0>\xec\x15\x88\x94\xacVOjE\x9a<\x0ej\xc7bTP\xe1\x98v?\xa93\xb7?\xbe\xce\xe7\x87\xfe[\xdf\xdb\x05\xfb\x1b\x00\x00\xff\xffPK\x07\x08:\x1cM\xc4g\x00\x00\x00\xfb\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/docker/registry/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\xd2\xd5\xd5\xe5JI-H\xcdKI\xcdK\xceL-\xb6\xe2R\xd0U(\xca\xcfI\xb5R\xc8M\xcc\xcbLK-.\xe1\x02\x04\x00\x00\xff\xffPK\x07\x08\xccd6\xd0*\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/docker/registry/tasks/certs.ymlUT\x05\x00\x01\xf0b\xe7[\x9c\x90\xcdj\xc30\x10\x84\xefz\x8a\xc1\x97\x9cJz(=\xe8UJ\x11B\xde\xc4\xc2?kv\xb7MM\xf0\xbb\x17)&i(\xed!\xc7\x1943\xfb\xe9	S\x1c\xc9\xa3\x89\xaa$\x86\xf3\x19-\xa7\x9e$\x08\x1d\xb3\x9a,\xa1c5\xac+\xe8+\xabi\xe3\x80C\x1e\xc8;\x00\x98\xa3u\x1e\xcd-\x95H,\x14\x17\xeb\xba\xff\xbb\xac\xa9i\xb5h\xe4\xd1f\xa1d,K5Gn\xc9\xe3\xf5\xe5\xb9*>M$\x1e\xc2lU\x1f\x85?f\xbf\xd5:w=_\x97q\xc8S\x8f\xb2\x9f\x0f9E#E\x9cZ\xf4\xb4\xc0\xf8\x1f\xae{ \x95t\xe1)V\xc8F\xe3\xdbN%U\xa4\xdd\xfb\xf5\xf2\x96\xd4\x1e\xe1\xde\xdf7\x97\x9aP\xf4\x8f\xea\xedS\n\x8d\x03N\xd9\xba\xfaX\x7f\xcd\xe9\x16\x19\x98\xe7\x90x2\xe1\xe1\x02Q\x9d\xcf(\xfe\xb6\xe5\x80\xef\x00\x00\x00\xff\xffPK\x07\x08\xc2u\xca\x98\xe1\x00\x00\x00\xeb\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/docker/registry/tasks/images.ymlUT\x05\x00\x01\xf0b\xe7[\x9cUOo\xfa8\x10\xbd\xf3)\xdeF\x14\xc3\xaa!\xed\xef\x18\x89jO\x95\xf6\xb2\x87\x95\xf6D\xa3\xc8u\xa6\xc4K\x88#\xdb@\xdb$\xdf}\xe58\x84?\x05\x0e\xcb\x0d\xcf\x9b7o\xe6\x0dC\x88\x92o(\x86\xc8I\xac!? 7|E\x90\x06\xbc\xd0\xc4\xb3/\x14\x8ag\x94\xc1*\x14J\xf0\x02\x9aV\xd2X\xfd5\x02LNE\x11\xa3\x19\x01@\xfd\x00C\xd6\xe7\xa7\x9a*\x95Z\xbe\xc2\xc2?,\x99\xd1\x82%sS\x15\xd2NY\xccfxh\xbb4\xb1\xd5\x05B\x83P!\xcah\x17\x95\xdb\xa2@\xf8'\xc2=\x82\x87:\xb7\xb6J\x85\xca\xa8\x0d\xf0\xd6\xe1\x810\x14\\\x90\xb6\x88\xc8\x8a(SbM:r\x0ff\x9eE\x9d\xc6\\\x19\xfb\x16\xd7\xf5AjZ)m\xdb6\x12|.\xb4\x1d\x88\x1c\xb9\x89\xa3c\xce\x95\x94\xdd\xaf\xa8k\xc0D6\x13Q]_\xf4\xb7\x8c\xc3\xe7\xa4\xf9W\xc9\xd27\xd5\xb6\xd1\x86\x97\xf2\x83\x8c5W\xd0\xe1s\x82\xd6\xf5\xed\xcb\x90\x8e!M\xeaA~\xce\xa3Q\x88\xf7B\x89u<\xeaT\xde\xf0\x87k\x91\xcb\x1d\x81>\xa5\xb1\xe6\xd1\x05Je\x91\xa9}\xe9x\x9c[6w\xe1\x8a\x84\xa5\x0c\x95\xa6w\xbe&T\xdc\xe6}\xf7\xbdy/\xfd\xd7\xc1@'\x16\x0bL\x97\xb7\x9c[>%\x8f`\x7f\xb0\xc7\x03\xa0\xd3f\xb6\x9b\xd4~U\xc4\\0\xfe\x19d	\x124\xf0\xa3b\xb3\xc1\x7f\xf7\xf1\x1e\xa2r\xd6\xd7\xb5W\xd0\xb6\x98L\x0e\x11\xb7I'\x81S+z}\x1e?0n\xd6\x99\xd4\x08+\x1ca\xfd\x08B7\x02\xe6\xa4dR\xbb\xd9^d\xf6\x15\x0d\xdf\xd1\xad:\xcd\x00\xfe\xfcF\xf8\x8d\x97\xdbU\xdaC\x93\xfb\x9c\xcax\xf0\xc7[nR\xf9\x91n\xa41\xb2\\\xf50\xaeW&\x1e\xe8\x85&n\xc9\xc4\x08\xee\x14\x08\xce\x17\xa5\xb3\xdf\xd3\xdf2\xda\xb7\xd8-\xdcb<\xed\x1b\xee\xf2By\xaf\x95\xd9\xc00\x99`\xea\xf7u1\x9e\x92\xc8\x15\x82\xf1	k\x80\x06|\xbf\x06\xab+-K\x8b\xf1_\xaf-;\xcb^.\x11\x8c=C\x80\xdf\x16\x08\xdc\xa8\x83\xdf\x91$g5\x96\x18\xe8\x0f`\xcf\x1c\xbe\xb2\x98\x1d\xf9\x9f[v\x0cD?\x02+M\x15\xd8\xdb\x9c\xcd\x90\\l\xd5\x91\xb8\x93p,\xd3\xdcA\xf9\xc8\\\xaa\x01\x7fl\xaei\xe0\x15\xdf\x19\xe4\xc5m\x0df\xe7\x16\xfeM\xae\xa6\xf7p\xb8\xbb\xee>\x9d\x1b\x1a\x9c\x08\xbc\xb1\xa9\xf7.\xdb\xf5\x94\x8bm\xfa\xa7:\xd9\xa7\xd3\xff\x80J]\x17SmM\xfe\x7f\xcb\xfa\xdf\xc8\xc5A\x9c\x1b\x9b\xa9\xad\xed\x96\xe4\xd7\xd3S0\xfa/\x00\x00\xff\xffPK\x07\x08\x92\xac\x07\xbd\x8e\x02\x00\x00\xb2\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/docker/registry/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4W\xcdr\xdb6\x10\xbe\xeb)\xb6\xceA\xc94\x96dO\xd2\xccp\x92^r\xcc\xa5\xb7\x1e<\x1e\x0e\x08,%\xd4 \xc0.\x16rU\xdb\xef\xde\xc1\x0f)\xca\x92eg\xea\x835\\\xee\xcf\xf7\xed.\x16\xcbK\xb0\xa2\xc3\n\xbe*'\xef\x90\x96\x84k\xed\x99v\xbf\xcf\x00\x146a]\xcd\x00\x00:\xbf\xae\xe0\x0f\xd2\x96\x81\xc5\xfaX\x1bZG\xf0#4\xf8C3|w\xb6\xd5\xeb@\x82\x1dA/\xc8#\xcdfC\xa0\xef\x1b\x94w \x8c\x81\x8d\xf3\xecA\x10\x82gm\x0c\x88\xad\xd0F4\x06g\x00\xdaJ\x13\x14\xd6\xe4\x0cf\x04\xd9\xba'\x94\xd1A\x12\xb1\xf0w\xben\xc9uUtX'\x87\xb50z\x8b1\x9eG\xae[!9\xdbg\xc4\xf5\x808)\xd7F{\xae5cWAR\x02\xb8\x84\x8b\x87\x87\x04m+\xc8\xdf\xc4w\xb77sa\xbdn\x0c&\xa3\xf9\xed\xa3\xc2V\x04\xc3\xef\xe7\xc6Ia\x92\xf0\x03<=]\x9c\xf5\xd1\x87\xc6hY\xeb~\xea\xe0\xea\xfa\xcbb\xb5X-\xae\xde\xe2\x80\xf4V0\x9e\xf7p\x9e\xc7\xe0BY\x7f\x86\xc6+>2\x8f\xf3.f\x00\xf7\x9a7)\xb7\xbeJ~\xd6\xe4B\xef\x17\x9d\xf0\x8cTtr5\x90\xaa3\xe5!\xf4\xc1\xf0AA\xe1|Es\xbc\xd7<.\xf2\x8f\x87G\xe8D\xff^0\x93n\x02\xe3\xb7\xb1\xda1\x96_\x9co\x9c\xf9\x07x\x84\xf84\x96ov9voj\xd0\n$\x12\xfb\xc5\xae33\x80\x98\xc9\x97\x1b2#\x8f~\x17+xz\xaa\x86\x87\xabiN-zFU\x92\x90kt\x03\x93\x02||\x99:\xdc\xc2#\xb4F0\xa3\x85G\x08V\xff\x1dpD~	7\xd1\xd9h\xd6;J\xb4>\xa6\x18\xc20\x92\x15\xac\xb7X\x1f\xa9\xdc\xce\xfe?\xed\xbb\xd0`\xbd\xd5\xc4A\x98Z\xf7\xb5\xe8\xf5$\x07\xc7]5\xe1\x7f\x84\xe7\xd4;\xd3\x9cz}\x96V\x0c\xb8A;\x84\xd2\xbe\xde\x88Z\x9a\x10{\xb6\xc8N\xa1\xd6\x1e\x14\xb6\xda\xa2:\xa3\xf4\xcb7\x98\xcfc\xda\x1a\xe3\xe4]5\x9b\xa5\x08\x87)\xec\x84\xd5-z\xae{B\xe3\x84J\xd9<\xa58\"\xd7\x9dX\xe3^-OM\xe1=\x12\xc3\x89C\xd1\x0b\xde\xc0\xd3\x13\xe0?\xda\xb3Oh[=\x8c\\\x80\xf8\xfa\xf4i*\x86\xc3\xc4\xf2,\x18+P\x9aP\xb2\xa3]\x11wNa\x05\xab/\xab\xd5\x14\x0e\xa1UH\xc0\xd8\xf5F0\x02;X\x06OK\xa3\x9b\xa5\xdfy\xc6N\x95\xdf\xf1\x82Yx\xa4\xad\x96\x98\xe7~1\x1cPz\x92\x15\xbc\xd1\xc1\xe2\xaf\xebb\xa506\xdeO\x05\x1e	\xfd\xf6\xe9S\x12\xec\xe7\xd7\x98\x98`5\xe7\x0b\xcb\xb1nw\xf1M\xac\x1c\x08\xab\x80\xd0\xb3 \x1e\x95\xa7IA\xeb\x03!\xcc\x1f\x1e\xd2\xc1\xa9\x95\x8eCr\xfeJe\x96\xc8\xf29\xf4\xfd\x81\x19I\xab\xe5+\x85r\xf76\xb1p\x8e\x8b$\x8d\xeb\x03\xc9P\xcc\xcf\x9f\x93\xe0\xf9IL\x07*\xb7\xc9\xe4\x1e\x1b\xa8^\x1c\xf4\xa3R\xa5\xa1\xa0`\x8f\xa3\x88\xc1m\x91H\xab3U>A7;\x9aP-\x02\xe9l{T\xed\x9f\xb4?]\xf53\x95\xcd\xb6\xcf\xb9\x0eI8\xc3V\xba~\xf7\x1a\xd3\xa3^V{\xd1\x04\xed\xcbT\xdf\xe8\xe0\xedt\x0f\x1b\xb9C\x16\x15\xb4&\xf8M\xbd\x11V\x19\xa4\xdc\xb8i\x86\x1e\x1e\x91\x85\xdc\x08\xbbFux\x04ZG\x12\xf7\xe9\xa2`\xad\xb6\xeb\xe4\xa3P\x19r4L\x92\x12\xbf$.7wA\x87\n\xde\x81ETq\xc0\xb4\xda*\x10\xd0 \xc7\xed\xe3^\xec\xa20\x83L\xe7\x0d2\x1e_<\xa1\x8d\x8b\xa8\xaa`\x87~\n\xf1O\xa1\x19B\x1f\x8d\xaf\xa1\xd360\xfa\xb4\xfb\x96vf\x17A4\xda\xe8\x7fs]\xef\x85\xe6\xbau4\x9e[G\xe5\xba;}g\xc5\xf2\x19\xb1\xab\xe0jU\x9eYw\xe8\x02Wpu}0E\xf3\"\x1dc\xa7\x11\xa1\xed\x1a\xd2\x0e\x00\xe9\n\xc8D\x02\xe9!p S\xc1\x86\xb9\xf7\xd5r9.\x0b\xd5\xe7\xd5j\xb5\xdc^/k)X\x18\xb7.\xda\x84\x1c\xc8\xd6\xd2YF\xcb9\x0d\xf9\xcdV\x18\xad\xe2\x0e\x99\xae\xf7\n\xac+/\xa4\xd1h9\x893\xc1\xc9\x18[\x0e\x8f\x92\xb8\x8ec,R\x98p.\xb6w\xb8{\xd9\xf4\x0ew\xc7\xa6\xd3\x01\xdc\xbb\"b\xd2\xe8+\xc8G\x7f\x9f\xce\x19\xc0\xbb\xa4\x06\xb1\x8fc\xbe\xa4\x0bFA\x83\xa0\x9cE\xd0\x16\xe2\x8fg\xec\xd3p\x03\xde \x94]p\xa8o\xcamr\xd49\x15\x0c~\x84&pR\xcc\xcf\xa0\xb0G\xab<8\x0b\xfd\xae\x189\x9b4\x08;\xc7\x08\xd6\xa9\xa1\xa7\xf67?\xa4iy\xf8\xc5\x92\xffr5kv\xe3\xdd[\x96\xc4#\xf9\xb8\x8c\xdf\xdc~\x80_\xf3\xe2\xb8\x15&`\xfe\xef\xdfO?1\x8e\xf7s\x19\x88b\x11\x86\x9dc\x91\xa9\xa0\x95\x1a\xe3\x92\xac\xb4\xe4\xeb\xa4\x9f\xdc\x8c\xa0\x9fm\"\x19WY\xfb\xf2\x9fq\xae\x7f	\xf4\x01\xa4\xa8\x98z\x8e\x9c\x99\xe6\xc0\x88\x06\xcd\xc4\xc3\xcd<6\xc1\xfc\xf6\xc0\xb8\x98o\x05\x15\x10\x19\"\x8b\xf5\xe4\x82\x8a\x95\xaf\x8fQ\xcc\x86E\x0f.\xe6\xf9\x03e\x1e\xdb!\xdd\x81u\x8c\xe5/\xf6_\xb0_\x97?\xf7\xad|\xa4\xfe\xea\xc7\xf2\x7f\x01\x00\x00\xff\xffPK\x07\x08*\x82\"\xda\x11\x05\x00\x00\x95\x0f\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00roles/docker/registry/tasks/manifest_preload.ymlUT\x05\x00\x01\xf0b\xe7[l\x901o\xdb0\x10\x85w\xfe\x8aW\x15\xb0\\\xa0\xb2;\x0b\xe8\xd0\xa1\x05:\xb4K\xb2\x05\x81\xc0\x90'\xebb\x8a\xb4\xc9\x13<H\xfe\xef\x81\x19\xc7\x91\x82\xac\xf7\xde\xbb\xbb\xf7}\x85t\x84C$\x17\xb4E\xcb\x8e\xc0	=i/\x90\x00\x1b\xcc\x9e\"\xb2\xa8\x9d\xcbfK-{\xb2\x97\xd0\x93\xdeSu\xd0\xd2%\xb0\xcf\xe2\xbf_\xff\xff\xfe\xf9}w\xaf*x\xddS\x8dH\xda\xde\xa6\xe0^\xefh\x91\\\xdcV@\xea\xc8\xb9\x1a\xc5\x03\xaa\x16\xdb\x18\x82l{\xed\xb9\xa5$\x9b\x14\x0d\x1e\xb1Z\xc1h\xf9L\x9b&\x90\xe9\x02\xca\xb2P@\xa4\x1d'\xa1X\xe3\xcd\xd4\xa4h\x94\xaa\x90H\x9aV\x1b\xa9\x15\x80w5\x17i\xae\xff\xd4(\xc6q\x91\xdc$\xb1a\x90\xc6\xb1\xa7\x84	\xbd>\xacK\x89\xdc\x97\xdf0!\xd23\x19Y\x97t\x1c\xb4\x93P~G\x99\xe7\x8e\x93`\xc2\xe0\xf98\x10\xce\xe7B\xdd\xd0\xcc\xe1\xce\xd9\xdd\x90\\9Y\xe4\xcffp\xe6\xc9\x8a1\x8e`\xa1>o\x9f\xf7~\xb55\x17\x9b\x02Z\xcd\x8els\xea\xc8/\xa4M4\xf8\xf2\x13?\x14pb\xe9\x9a\xcb\xaa\xf4\xa1\xfe\x02M\xbe\xf3\x12\x00\x00\xff\xffPK\x07\x08u@\x04\xf8-\x01\x00\x009\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00roles/docker/registry/tasks/registry_image.ymlUT\x05\x00\x01\xf0b\xe7[|\x93As\x9b0\x10\x85\xef\xfc\x8aW\xc6A\xb8\x13\x9c\xf6\xca\x0c\x99\x9er\xec\x1f\xa0\x0c\xa3\xc2\x06T\x03R%%\x8ec\xf8\xef\x1d\x04\x01\xd7I|e\xdf\xfbv\xf7i\x89\xd0\xf1\x96b\x145\x15{\x88Gh\xaa\x84\xb1\xfa\x08a\xd0H^R\xe9\x01\xa6\xa6\xa6\x89\xe1\x97\xb2\xd8\x93\x86hyE\x06\xd1_\x9cN\x8b!w_1\x0c\xbe\x87\xf9#\xe9\x18\xc2\xe4\x8bb\xe6y\x9f7\xe5\xba\xa8\xc53\x81^\x84\xb1\xe6v\xacu\xd2\xa2\x94\x87n4\xc3J\xd8z,+*,\x95P\x9a~\xf3=Aq[\xafs\xde{\x00p\xba\x81!\x0bMJ\"A\x98^L\xba3\xaa\x116d1\xdb\xa6\xdf\xb2[\xb0\x1f\xecv\x95\xb4\xbc\x13\x8fdl\xca\xdc\x90\xe6\xa9\xcd\xedQ\x11\x1b\x85\xf1u!\xcb\x90\xa1\xc7\x1f)\xba\x90\xb1\xed\x167\x83\x9bg\x0eO=5\xcd\x94\x9b\x92\x18\x06\x04\xc1[\xc5\xf2\xea\xbc\xf0Q\xb6\x08\x02\xc7j\xf7\xa5\xd0\x88\xd4\x7f\xa29\xbc|\x0c\x03=J\xa1\xc7\x98\xcf\\s\x1f\xc3\x9f\xe9cz\xefd/\xaf\x88^q\xff9{\x18\x17:\xd4\xd4\xc5\xcb\xcbL\x0c\x93\x8b\xc7\xbc\x15\xc6\x88\xae\xf2\x00\xae+\x13;d\xa1\x89[21\xfcs\xe8\x9a\xdd\xfc\x8e\xd1\x88g\x99;\xa2\xe5J\xdc\xc3\xafg9\xf6\xb9|\xeai1w_\xc9&\x9c\xd7t\xbeH\\[c\xeb\xdcA\x80p:\xcdd\x13RQK\xf8\x9b3\xa2\x8f\x1e\xfc\xb0\x07;)-:\x8b\xcd\xcf\x87\x81-\xce4\x85\xbf\x99\xdc>\xbe$\xf0mY\xdc\xf9_\x91e\x0b;\xc5\x82}\x13N\xc4\xe8\x81\xc5l\xe5~\x1f\xd8Z\xb8{W\xa84)\xb0_;\xb6Evq5+\xd8\xb5_\xdb\xf4WTSe'\xe4\xa2\x9f\x96\xea{L\xd3^	\x0e\xbc\xd1\xc4\xcb#\xce\x9c\xd3A\xbc\xff\xdfw\xc6\x96\xf2\xc9\"I\xe0\xfb\xde\xbf\x00\x00\x00\xff\xffPK\x07\x08\xba\xa3}\x8d\xdb\x01\x00\x00q\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00R\x00	\x00roles/docker/registry/templates/etc/systemd/system/docker.service.d/docker.conf.j2UT\x05\x00\x01\xf0b\xe7[t\x8f\xcdj\xc2@\x14\x85\xf7\xf3\x14\x03\xd9h\xa9\x99\xa4\xa1U\x0bY\x94bE\x8a(\x86\xd2\x82\x88L&\xb7:437\xcc\xbd\x11E}\xf7\xd2\x1f\xa1\x9b\xee>\xbe\xb38\xe7,_\xbc\xe5\x95x\xd5\x9e)\x0f\xb0\xb1\xc4\xe1\x10\x13\x84\x9d5 \xc4\xb2\xf8\xa1\x95\x88\xa4A\xcf\xdaz\x08\xd5%\x97\x95%\xdd4\xa0\x03I\xebe\x85\xe6\x03\x82L\x07qr'\"\xb9en\xe8^\xa9\x8d\xe5m[\xc6\x06\x9d2\x18\x00I\x95\xed\x86\x94%j\x81T:L\x86\xd17\x1bt\x0e<\xf7\xb2,\xbb\xe9\xa7I6\x10#\xbf\xb3\x01\xfd\x97\xcd\xc7\xb3\xe9\xc3\xdb|1{,\xf2\xe3Q.;\x1d\xed\xc9\x965\xac\x9b\x80\x06\x880\xac\x0d\xb6\x9e\xe5\x95L\xe2\xfem\xf7d=w\xafe\xba\x92'\xe9\xf4^\x9e\xcf\xffM\xba\xbc\xfa\x8be\x8d\xa5r\x9a\x18\x82\xaa\xd0\x90\xc2\x86bWEt \x06W\x89g[\xd7S\xac \xffm\x17b\x01\xc4:p\x8e\xbe\xf7\xaem\xdd\x06\xb8\xa8'\x0c\x06F{\xcb\x05kn)/&\xe3\xf9d>\x12\x9f\x01\x00\x00\xff\xffPK\x07\x08\xbc\xc0\xa9+\x16\x01\x00\x00{\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/docker/registry/templates/etc/systemd/system/registry.service.d/registry.conf.j2UT\x05\x00\x01\xf0b\xe7[\x84\x91Ao\xaa@\x14\x85\xf7\xfc\x8a\xf3\x0cq\xf4%H\xde\xf6\x19\x16mR\x93nZ\x13\xd3t\x81\x133\x1d.0\x91\x82\x9d\x99\xdaZ\xe5\xbf7\x80U\x81\xa6\xdd\x91;\xe7|\x1f37|\xc8\x95\xe5\xceUlI\x07Q!\xd7\xa4'\x86\xf4VIr\x1eEnMw8\x17\xda\xde\xc7\xdd\xa9\x13\xde\xe6\xc6\x8a,\xe3u\x8b\xa2\xeb]?\xb2h\xbe\xb8s\xf3Nra\x85\xb6sM\x81\xff\xa4r\xdf\xa4\xf0$\x06K\x07P1Bx\x1fpG\xfe\xab\xd1\xf5iC\x82z\x16	\x19x/\xd8\xef\xa1)Q\xc6\xea\xdd\xaa\x9e\xa2,\xc7\xe0S\xd8\x94rT\x14\xa0)\xad\xb2BDA\x9fU\x8d\xe1\xa9\x16Ih\x99\xaa-\xad6\xc2\xa65\xb0\x01\x01\xc3!FU\x81Z$\x92i\x01\xf7B\x83\x03N\x87\xe2m\x0d\xb6\xdfh\x95[\xb8w\xb3\x92\xb5ha\x08\xb7\x01\xe2O\x00f#\xe9\xb3\xbf\xe0\xbce\x0c\xd1\x93\x1d;\x1d\x8f7c\xff\xd9\xd9\xf6\xafd\xdf$\xfc^\"\xd1\xb4\x01[N\xd8\x18\xbc\x12v\x9f\xc8\x8a\xe4d\xac\xfe\xf0d?\xfc\x1a=\xae^\x15_\xa5\xf3\xed/\xdb\xf5\xa5\xd8\x0f+\x80\xc84\x89h\x87\x86r|\xc4XM\xb1t\x06\xceg\x00\x00\x00\xff\xffPK\x07\x08@\x8fM\xad:\x01\x00\x00\xbc\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00J\x00	\x00roles/docker/registry/templates/usr/lib/systemd/system/registry.service.j2UT\x05\x00\x01\xf0b\xe7[\x84T_o\xa2J\x14\x7f\xe7S\x9c\xdb\x98\xd2\xde\x04\xf1\xde\xa4/6<x-\xf6\x92\xedV\x03l6\xc6\x122;\x1eu\"\x02=3\xd8\xba\x96\xef\xbe\x01\xc1\xd6\xff\xe1\x893\xbf\x7fs\xe6\xcc\x8c~\xc4B\x05\xda\x03JN\"U\"\x89\xad\x01\x89%S\x08\x0f	\x9f#\x81\x8bS!\x15\xad\xc0CZ\n\x8eZg\xa2\x90\xacq\xb9\xda\x94U\xd1\xc5\xd7L\x10\xca\xfd\xba6\xaah\x81\xe6\x8b\x05&\x99\xf2\x14#\xe5!\xb7Z\x9a\x8b\xb2\xf8\xb1\x92\xd8\x980\x11e\x84u\xa9X\xbf\xd3\xecw\xe4%|@h\x99\xbfDl\xca\x19\x18\x1c\xae^4\x001\x81\x11\x18\xbf\xa1qcf\x92\xca\xd5\x8d7\x88\x05\x9b\xa2\x04\xe3\x15\xd6k\xa0*~XV!\xcfo!\xb8\x075\xc3\x18\n\x15\x80\x0d)\x8c\x126\xb6\x0e\xb5\x8a2\x18bG\x89\x11\x9f\x89%\x86)S\xb3Rp#\x04p}\x0d7\x05\x01w\x94\x90\xcf\x12h|\xb1\x81\x0f\xd8.\xb2\xb79\xe8\xeb\x94D\xac\xa0\xf1\xdc\xcb\xf5\x1d\xb5\xd1\x08\x1a\x1bA\xf8\xcb\x02]\x8d\xb9\xa9\xff\x0dA\xb0\xe38\x82\x03\xb3\x8a\xb3\xe7c\xf4\xf4\xb6\xfe\xe9\xf6O\xae\x1fA\x98\x07\x88)a\n\xfaKS\xbf\x85\xa00\xdco\x91b\xd3\xadc\x91p\xeb\xfeq\x11Z\x0d\x8bHj\xd2\xe7\xee\xbf\xb2\xcbM\xe9g\x8e\x00XD\xc8\xc6+\xd8\xa8TM\x9c\x88{x\xd1\xaev\xc7\xc8\xd8\xcf4\x17Q\xb4\x15\xbe\x80\xa5\xc5\x11\xa4u\x80\xcab0\x0cZT{1\x10\\\xfb\xd1\xf1|w\x18\xfe\xef\xfb\x83\xd0\x7f\xf2\xc2\xae\xed\xfaN\xcf\xe9v|\xdb29\x92\x92&\x8f\x04\xc6\xaa\xc9I\x9d#~\xb3\x87{\x849\xae\xce:=9\xf6\xb3\xdf\xedxakKd'\\<\xbf\xefv\x1e\xed\xb0\xe7<\xd9\xde\xd0\xf3\xed\xef\xa1\xdb\xef\xfb\x0f\x8ekw\xfd\xbe;\xb4\xd6\xeb\xfa\xbel\x8f\xa2>\x82\x13\x11<\xbb\xeb\xda\xbe\xa5\x1faJ\xe4\x84\n\xf2\\\xaf\xc9\xcb\xe2\x9e\x15\x19\xc3\xb1 \xc8s\xb3\xfe\xe5\xa4\xc2\x89\x880f\x8b\xe2\x0e\xb7\x0fZ\xd6\xa6\xe4\xa2\xc8\x1cWgD\xe6\xb8\xba BI\xa2B\xceN\x86a\xc7\x82\x9chW\xfb\xcc\x12\xbd\xd5\n)\xb4\x9a\xe5\xd7\xfe:\xfciBE\xd7\xdaw\xadV\xabF\x1ae\x98\x1aRU\x8f=\x7f\xd5\xdc&\xe9\xc1\xd8J\x95\xa4`(\xf8\xf7s\xc6\xab\xd7\xb8\x97\x10G\xfb]\x14o\xb7\xca\xa4\xe59\x8f\x03g`k\xda\xc8\x89\xa5bQ\x14h?Y\xacp\xfc\xdf\xcaZd\x91\x12F&\x91\x9a\x8a\xd1\x14\x95\xf6'\x00\x00\xff\xffPK\x07\x08\x93\xb1\xda%\x85\x02\x00\x00c\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/docker/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x9cS]o\xa3H\x10|\xf7\xaf\xa8\xc3\xb2\xecHq\x0e\"%\x91\x90\xee\xe5\xde\xef\x17X\x16\x1a\xa0!-\x0f3\xdct\xc3\xc6\xeb\xf5\x7f_\x0d6\xf9X\xed>$\x8f]Tuu5\xd3K\x98\xaa\xa2^Mi	\x83c\x154>\xa0\xf6\xd5\x81Ba}[\x08\x7f'\x98@\xd0g\x02;V6V\xf2\xc5\x12\xc0\x01\x9b\x03[_\x1e\x95\xe4\xe6\x16\x1d6\x1d\xb5f.[lZ\x9e\xcb\xc5\x12B\xaa\xec\xda\xa9\xcfh\xec@P\x8fmv\x1b?\x08Xc98\xcb\x1d+\xd5\x8bw\x13t\xe6e\x9a\"G\x92=v	\xb0DI\xaa\x14\xa2BH\xe1G\nh\xd8RIF\x11\xe9\x93)z\x13LG\x91\x183\x19\x08\xbb\xd6\x12:\x121-aSSc\x06\xab\xf0\x0d\xb2\xf4?\xfe\xf7\xe6W\xd7\xd8Sr$\x0f\x93\xa9#\xaa%z\x96\x14\x9bi`\xd7\xce\x8a\xc8\xae\xbc\xab\x86\x10\xc8iQ\xfbo\xcezSK\x8e,\xfd\x03g\xe8\xdf\x18\x8b@-\x8b\x86c\xd1\xfb\xa09\x1e\xd2\xf4M\xa6C\x8e\xe4t\xc2\xb3\x17\x1dM\x90\x1d\xbb\x91\x9c\xfap,\"\xe4LG\xfb]e\x07Q\n\x057\xa6\xa2\xe2\x82\xad;\x1d\xd6{\xfc\xc05\xe8&{H\xd3\x1b\x9c\xcf\xc9\xdc\xdb\x84Vr\x9c\xce\x8b\x19\xe0\xcbS\x90\x1c\x8d\xb1B3\\r\x1f\xb7\xfft\x7f\x97=\xdd\xa5w\xd9\xdf\xd9c\xf2\xaa\xa9\x0du\xde\xc5h\x0d\xb7\xf9\x02HJ\xee\x93\xcb\xcco\xfa\xc9\x16Hj'\xd3\xb7\x15\xb8Ae\xfdP\x17}\xf0#\xd7\x14\xf0\xd7?X\x8b\x9a\xea\xc0k\xac\xceQ\xee\xa4\x10\n#\x05y\x17c\xb7\x8f!N+\x90\x15\xba\x10w\xfb+\xe2jn\xb0z\xb5\xda\xfa^?\xe3wM\xe4\xa4\x88\xc2/z\n\x99P=\x7f\xc2uJ\x195_0\x9c\xff\xd8\xc7\x85\xcf\xe8\xbcu\xeb\xdb\xeb*\x16\x00\x90t\xe6e\x1bo\xea\xa3\xea\xfd\xb5]\x95Wn\xbc\x84\xdfs\xa7\x1b\x99mD}0-m\xeb\xc0#\x85(\x88\xb7i\xcd\xf1\xfe2\x05\x8f\xb4\x0d\x14Y\xb1\x9b\x86\x81.\x11\xb6\x9d\x91\xff\x93\xf9\xd1\xfd\x0c\x00\x00\xff\xffPK\x07\x08\"\xd5\x9aI\x11\x02\x00\x00\x95\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x001\x00	\x00roles/docker/systemd/files/etc/logrotate.d/dockerUT\x05\x00\x01\xf0b\xe7[<\xce\xc1\n\xc20\x0c\x06\xe0\xfb\x9e\"\x97\x1e\xe7&L\xdf\xc2\x07\xf0X\xda\xac\x94\xb5MI\xa3X\xc5w\x17\xe3\xf0\x12\xfe/$\xf0Ow\xcbS\xa20yr\x1b\xf2!Q\x80\xd7\x00\x00\xc0$V\x10N\nG\xb5\x0b\xdf\x8a\xb3\x82\xba\xc8\xb1\xb5X\x02m\xaaB\x12W\xccU\xfa~\x9d+ck\x8a\x16\x9f\x08\xc7y\xbe\xa8\xbc\x8d\xa9\xefI\x10\x1f\xf2\xcf+q\xb6\x02\xa3\xb9\x9al\xfch~\xdf\x8e\xf1\xdbb>/\x0b0\x91\xe8\x18\xde\xc3'\x00\x00\xff\xffPK\x07\x08<\x00\x98w\x87\x00\x00\x00\xb8\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x007\x00	\x00roles/docker/systemd/files/etc/rsyslog.d/30-docker.confUT\x05\x00\x01\xf0b\xe7[$\xcd\xc1JC1\x10\x85\xe1}\x9eb\xa0\xc5\xaer\xe7\xb6\x94\x82\xdd\n\xee\xdc\xf9\x02c2M\x06\x93L\x99D\x83.|vi\xbb=p\xbe\x7f\xf3\x9e\xa5\xc3\xa4\x0e\xc1\x98\x06G\xb8\x98\xd6\xb3\xdb\xe41\xae\xfd\x8c8\xe7\\\xba\xd4\xafR9\n-A+~\x14MxX\xf7'\\\x0f\xb8\x7f\xc6\xc0m\x18\x15\xf9\xe5\xe8\xa3\x86O6_4%i\xc9O\x19\xd9[\xff\xe9\xb7\x8b\xdb\xbeJ\xe1\x97{\xe7M#\xc3z:\x1e\x9d\\`{5MF\xb5Qe\x08\xda\x06I\xeb\xb0{X;\x18\x99\x1b\xe07\x19\xde\x94\xc7\xba\x14M\xee	\xfe\xdc\x7f\x00\x00\x00\xff\xffPK\x07\x08*\xab`\x88\xa6\x00\x00\x00\xc1\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00A\x00	\x00roles/docker/systemd/files/usr/lib/systemd/system/logrotate.timerUT\x05\x00\x01\xf0b\xe7[T\x8d\xbf\x8a\xc2@\x10\x87\xfby\x8a-\x93&p\xc5\xc1q\xb0\xc5\x9di\xac\x14T,B\x8aa3\x89\x0b\xbb32;[\xe4\xed%jc\xf9\xfb\xc3\xf7\x0d\x17\x8e6BO%h\xbc[\x14\xf67\xa9\x9aV\xa7b\xb8e'\xb3K\xb2\xb89&*\xd0K\xa8\x99\xf85\xf9\x8c\xfc\x9bdy^\xa9\xf9i\xddG\xd1\x05\xe1\xb9\xf9n\x01\x86s\xcc\xa4#\x1cx\x87\x89xB}[\xe0/\x84\xaa\x18\xd6\x13\x05\xff\x95\xe1HZb1b\xf3\xa6\x95\x00\x86=\x17\xc3\x94F\xb8\"\x1bM\xff\xab\xb7\x8dU:C]\xc8\x1e\x01\x00\x00\xff\xffPK\x07\x08t\xae\xdf)\x9d\x00\x00\x00\xc0\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/docker/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xb4\x8eM\n\xc20\x10F\xf79\xc5G\xf7\xf5\x00\xbdL\x89\x9d\xa1\x06\xf3\x033\x83RJ\xef.M\xc5\n\x16\xc1\x85\xdb7\xc9\xf7^\x8b\xec\x13w\x10\x8e\xc5\x13|&\x08\xaby1P\x19\xae,\x0e\xd0I\x8d\x13u\x0e\xc0\xf3\xf9\xeb\x06\xa8y\xab\x03\xf5\x17S\x85\x9c\xfd92u\x98X+ \xcf\xa9\xe4~\xd3l\xd8}q\x8bN\x1a\xcbx,\xdf\x8f\x7f\xb2\xc72JY\x87+\xb5\x90X\xf48\xa5\x99g\x04\xe3\x84ei\xde{~\xad\x01\xee\xc1.\xfd:\xa5\xdb~\xbbW\x9c\x94\xe5\x16\x06\xfe\xe0\xb5\xcc=\x02\x00\x00\xff\xffPK\x07\x08\x91\xcbw0\x9b\x00\x00\x00\xc3\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/docker/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94TMO\xdd0\x10\xbc\xfbW\x8c\xb8\xbf\x04\xb5\xd0J\x16\xea\x85#\x97\xfe\x83\xc8\xcf\xde$\x06\xc7~Zo@\x11\xe2\xbfW\xf9\x80\x97&\x05\xca)\x9233;;\xbb\xf6\x01\xd1t\xa4q\xe3\x92} .\xf3\x90\x85:\xf7K\x01\x8e\x8e}\xa3\x15\x00t\xb9\xd1\xf8\xcd>\n\xc44;0\xea\xc4\xb8\xeb\x8ft\xe7\x05\xb7)\xd6\xbe\xe9\xd9Hb\x9c\x0cgb\xa5^\xcb\xdc\xb6d\x1f`B@\x9b\xb2d\x18&d\xf1!\xc0<\x1a\x1f\xcc1\x90\x02|\xb4\xa1wTq\n4\x1b\x98\xd9'&;\nLGb\xf2C\xaejN\x9d\x1e\x05\xabI\xb02\xc1?\xd2\xb9\x9ee2B(Il9\x9bV@\xed_eOFZ\xbd\xf9\x0bd1B\x1a\xce3YI<\xcc\x11$G\x1a\x97?\xaf\xafW\xea\xe94 \xd2\x13\xda\xd4s\x18\x10R\xc3i$\xa3\x8f^\xa6:\n\x13j.\x97\xd9j\xf4\x99\xcb\xe0\x8f\xaf\xe1-\xdf\xf2\x8d[\x88\xef\x16#\x8e\xb2h\x94_`,.\x7f\\]) &\xf1\xf5\xa0\xc1\x14\x92q0\xd1\x81)\x8baY9\x1dO'~>\xf7E\xb1Nli\x85\x9aX\xe4\x14\xb0\x98X\x8f\xe5\xe2\xf9\x19^\xa8\xc3\xcb\xcb\xc5:\xc03	\xa08\xce\xd6i\x0c\x94\x15\xf0\xe4\xa5\xadFN\x9e\x85\x0e\xe7ZE&~\xf4\x96v\xe7s\x97\xfb\xf0\xe7\xc9\x81\xf3\x90Cj@Q\xa6\x91mb\x1fg\xbc@\nW~\xbf<\xcc\xb4\xc2\xa6X\xaf\xc3\xfe\x14\xf7\xbf\x11/\"g\xc3L\xd1\x11C\xa8;\x851SI\xeb\xcd+\x9d\xa1.\xc5\xe2>\xa7\xa8\xf0\x86\xdat\xb0\xc7\x16\xf7\xdf\xb6\xf6\xff)\xb81\xce\xd4\xf8,\xc4z\x89\xaf\x1a7\xf6\xe3\x86\x96\x1b\xa2\x0e\xe8H\x8cF\x1d\xfa\xdcV\xad\x89.\x8c\xdb\x03<\xb5\x14\xff\xd2+lkbC\xee]\xcen\xe3\x96Y~\xb8n\xfb\x9b\xfa\xee\xa2\xbd\xe9\xdf\x94_z\xdf\xb6\xe8O\x1f\xb8?\x01\x00\x00\xff\xffPK\x07\x08\x05Ds\x7f\xe9\x01\x00\x00G\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/docker/systemd/templates/etc/docker/daemon.json.j2UT\x05\x00\x01\xf0b\xe7[|\x8fA\xca\x83@\x0cF\xf7\xff)\xb2SA\xe1\xef\xb6\x97\x19\xa6\x99(\xd3:I\x19\x13*\xe8\xdc\xbd\x14l\xebB\xba\xcd{y\xf0-\x0b\x04\xc1\x1be\x17<%a\x87\xc2}\x1c`\x05\x94t\x89L\xf5\x86}\x1e\xa6fw\x86\xa5Jj\xd5\xf9\xfd\x9e\xd4\xd6\xc8\xdaB\x95\xfc\xdc\xa10Z\xce\xc4\xda\x05y\xf0(>L;\xd7\xcf\xeek\xb8\x8f\xb1\x06\xea\xbd\x8dZ\x9f\xfe[\xcdF\xcdq\xd1\xee\xbf{\x1b?\xac\x15x\x8dPq\x1c\x91\xdcu\x12\x86R\xfe\x9e\x01\x00\x00\xff\xffPK\x07\x08\x96\x1e\x11\xca\x8d\x00\x00\x00\x06\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1c\x00	\x00roles/etcd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[l\x8eAn\xb40\x0cF\xf79E\x94\xe5\xaf\xbf\x82j64\x97\xb1L\xe2\x01\xab\x99\x189fTn_\xc1\xd0\xaaUg\x99\xbc\xf7\xac\x8f,e\xc8tU\x9c \xa9T\xc3\x11fY5\xfa\xf0\x1a\xdcA\x8bL\x0d2n\x0dL\xe0\x9dh\x89\xfe\xd2\xff@_\xd9\x8d\xebj\x14}\xe8\xff_\xfa\xf0D8\xef\xfe;Y\xab\xb8\xb4Y\xacAf\xa5d\xa2[\xf4\xa1\xcbh\xd8\xed\xc2\xcb\xb7p\x06;\xf9\xed\xdeQ\xbb\xc2\xe3\xa1\x07\xe7RY\x9b\x91\x02_1\x11T\xbc\xedk\xb06\x1e\x0b\x01\xd9\xdc\x07\xe7\xa8\xe2\xe3u\xacKX`Q\xf9\xd8\xa2\xafr\xce2T\xe3:=\xfe\x01\xf3\x9d\xd4\xb8\x11,\xa2\x16\xfd0\x0c\xc3S\x91+\x1bc\xf9\x13\xbc\xed\xc1g\x00\x00\x00\xff\xffPK\x07\x08pE\xdb\xc5\xc6\x00\x00\x00g\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00roles/etcd/files/etc/rsyslog.d/30-etcd.confUT\x05\x00\x01\xf0b\xe7[l\x8e1O\xc30\x10Fw\xff\x8aOmD`H\x9cVU%\xba\"\xb1\xb11\xb2\x18\xfb\xe2X\xd8\xbe\xc8v\xb0`\xe0\xb7#gaa\xbd{\xef\xdd\x1d_\x17\x97QU\x86N\xa4\n\x19\xcc\x89\xc3M\x1c\x97R\xd6|\x93\xb2\xd6:f\x176\x1f\xc885j\x0e\xf2\xdd\xb3\x95\xe7\xe9t\x95\xd3Y\x9e\x1e\xa5\xa6X\x92\xf2\xee\x9b\xcc`X\x7fP\x1a<[\xeb\xa2\x1d\xaa+\xcb\x90\xf2Wn\x8a\xe8\x9e\x9d\xa7\xa7\xfd\xce\x0b\x1b\xc2t\xbd\\\x84\x9bq\xdf\xad\x89mR!\xaa@\xd0\x1c\x8br1\xe3@E\x9b\x83\x00\x00\x15\x0d\xba\x90\xed\xdf\xb2\x9fy\x8b\x06\x9aC\xe0\x88]lx\xff\x00\xbc\x89\xb2PD.\xbc\xb6\xfa\xff\xf1~\xa7\xb1\x93\xf2S%\xd9>l\xb3\xd1\xb3\x15w\xf8\x11\xbf\x01\x00\x00\xff\xffPK\x07\x08\xa6\xf2\x7f\x92\xcf\x00\x00\x00\x1b\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00roles/etcd/files/var/lib/etcd/.pidUT\x05\x00\x01\xf0b\xe7[\x01\x00\x00\xff\xffPK\x07\x08\x00\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1c\x00	\x00roles/etcd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[<\xcd\xc1\x0d\xc50\x08\x03\xd0{\xa6\xf0\x02\x7f\x81,\xf3E\x85\xd5KB$\xe0\x92\xed+\x11\xb5\xd7\x07\xb6\x7f0\x99\xecp\x8e%\n1\x853R<\xe1\xb1c\xac\xbb\x01\xb1#9\xb57\x00\xef\xffw\x04\"%\xab\xa2r\xd4B\x9a\\\x83\xda\xb1\x19\x05*\x9c\xcb\xfeg\xe8\xf0\x13\x00\x00\xff\xffPK\x07\x08\xe2_=\xd8W\x00\x00\x00}\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x19\x00	\x00roles/etcd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xdcXao\x1b\xb9\x11\xfd\xee_\xf1\xa0\xd4\x91\xedX\xbb+\xc9\xb1\x9b\xc59@\xea\x04\xe8\xe1z\xe8\x01\xcd\x97\xb6.\x16\xd4rVbE\x91[\x92+[\x8d\xf3\xdf\x0b\x92\xbb\xb2,[\x96{h\x0e\xd7\xfb\xa6%\x1fg\xde\x903\x8fC\x0d\xa0\xd8\x82r|G\xae\xe4\xef\x0f\x00N\x93f\x9a\x1f\x00\xc0\xc2Ns\xfcd\x84rpl\xdaBPi\x83\x1f\x9a	\xfd \x1c\xae\xb4\xaa\xc4\xb41\xcci\x83\x9a\x19K\xe6\xe0\xa03y5\xa3r\x0e&%f\xda:\x0bf\x08\xd6	)\xc1\x96LH6\x91t\x00\x08U\xca\x86Sa\xb4\xa4\xe86\xae\xae\x0d\x95\xde@\x18r\xcc\xcemQ\x19\xbd\xc8\xbd\xc1\"\x18,\x98\x14K\xf2\xfe&R\x97\xf3\xfc\xe0\x00\xe8|\x97\xba^A\xd1\x0d<g\x18\xbb\xb2ROA\xca\x99U0\xe8\xe7\xa37\xc0\x9a2\xf7\xb8\xb4\x85%<\x1dg\x03\xbf0)\xb5\xaaZ\x14'\xebr\xa4{q\x0b\xcd)Gv~v\x16\x83\xd1NT\xab\x1c\x86\xa4f\x1cLq\x18\xb2\x8e\x19\xd7\xb1\xda\xa4\xfdI\xd9\xc6\x10\xa6F75z\xdet\x0ft+\xac\xb3\xc1X\x18\xefh\xc7\x15\x1e\xd3\xc5\xe1\x98\x8b\x1bgI\xb9\x07\xbba\x889\xc2\x97/\x01^p\xe6X\xc1\x85\xa1\xd2i\xb3\xc2\xd7\xaf\xc1@%\xba\x03\x00j\xe6f9z;\x17\xf4\x1e\xba\\O=\xd8\x83\x8b,k\xbf\xf5\x8d\"\x93\xc3h\xed\xda\x91\x18Id\xbfA\xf4*\x12\x0d\x87f\x15\xab\xedL;\xbbe\xfe\x19\x9e\xeb%\xff=\xd9\xec\xfc,\xdbd\xf2\x81s\x94F+\xc7&!\xe3\x03\xa5	+\xe7M\x1d\xcf\xc2O><\x8a\xde\x1f\xc2t\x80v>_\xe1{\xe5\xc8,\x99\xf4V\x16\x8dd\x10\x16G\xe7Y\xaa\x8eq\x02\x81S\xdc\xcc\xc8\x10\xfa\xaa\xefgT\xb3\x98\x90\x81\xae\xb0`\xd6\x91\x81\xd2\x9clH\x9b\xbe\x88\x08\xcd	Bq\xba]\xbb\xf8\xb3\xc2x\x13\x8fR6\xe1\xe3H]\x8e\x8fOQ	c\xdd\x03\xc0\x91\xb8\xcc\x8eq\xe3kqB8\xcf\xd21N\x90\xe1\x12\xd9),\x95Z\xf1m\xf8\xf0\x11|\x88K\x8c\xb2S\xb8\x990\x8f\xe0\xa3G\xf0\x11.q\xd6%\xc4B\xa8\xc6\x9f\x85?\xb9\xa3\xf3\x0ciL\x08\xfb\xf7~\xb4\xd3\xff\x07\xee IM\xdd\x0cw\xf0\x02t\xd2\"\x92\x08H\xc2\x0e\x1c	\xb5$\xe5\xcf2(\x82?\x86\xe3c\xdc\xc1\xe8F\xf1v\xa1\xa8\xf6\xda~\x8f\x11H\xda\x98w\x85\xd4S[\xb4G_D\xa2\x1bI\xf4\n\x1f\xa9b\x8dt\xfe,z'\xbdS\x88\x84\x12\xd0\x92\xcc\n3\xdd\x98\x16\xe7\x7fnd\xe6\x03\xa3~n\xc3\xe4?\xf5$G\xff\xd3\xe7\xab\x8fW\x9f\xffT|\xf8\xe9\xfb\xcbq\xd4\x99y3!\xa3\xc8\x91M'B\xf9!^:\x89\xc1\x80\x14\xaf\xb5P\xce^\xce\x9c\xabm\x9e\xa6\xc3\xd1E\x92%Y2\xccG\xe3\x8bw\x18\x0cJ2\xee\xd2\xbb\xf7?|9\xe0\xeb\xd7\xb4\xfd\xb4I\x14-\xe3<\x0b\x0c\x06sZ\xed\xc1\xce)T\xd2\xba(a\xd9\xf2^P\x9e\xae\xbb\xb4\x1b\x1e\xfc\xee(m\xac	Ap_\xdf\x83\x06oz\xd7\x87\x7f\x1d\\\x1f.\x06\xd7\x87\xfc\xf3\xf5\xe1\x1f\x07\xd7\x87?\x0e\xae\x0f\xff\xf2\xb7\xdeq\xc2'x\x8f\x94\xd32U\x8d\x94\x18\xbd\x7f=\xc4\xeb\xd7\xa8\x84\xe2{|b\xb0pbq\xcf,\xec;g+[8]\xcc\x89\xea\x80\xe1$\xc9Q\x7fo\xbds\xaa\x0c\x9b\xee\xac\xf7\x8fa\xfae\xf5\x9e\xac+\xfeM\xf7\x99\x8e\x8e\x7f%\xe5\x9ft\x02\xf0\xa6\xfdH}\xb5\x0e_&\x06I'\x07\xf7\x8b/1~\x910$\x9d4l.}\xbbC$\x92o*\x13o^\xec\"\xc5h\xbd\xf8hx\x8a~%\xb56\xfd\xe3vz\x97T\x0c;\xa9\x18\xe2\xc3\x8f\xad^p\xb6zZ.b\xe2\xfd\x16\x04#F\xf2\xa0\xd4\xa6\xe4\xe0\x8bT7\x0e\xa5^,|v\xfb\x8b<Dgg$e\x8e\xde\xcdL\x94\xb3\x0e\x16\x0374\x15\xfeH\xf2n\xb8\x08\xab\xb6\xbb\xbeP\xb9I-x\xe8\x14\xe0\xf4\x9e\xbe\xe7Q+\xb8d&\x95b\x12\xb6/\xf5\x86\xda\xc9\xd8\x01\xeen\x8a\x02\xb6\xb7\xddU\x9c=M\xb0\xa9\xbd\x12\x16B+QRbg\xfb\xc9\x06\x15X\xb09\x81n\xa9l\\\xdb?\x03\x8e\x16\xb5\xf4\xad\xcd\xce \xb6\x9d\xbd4\xa0\xedu[\xc1]\xc4\xe0^a\xee+\xda'\xabOM\x7f\xe3\n\xffb\x98\x93\x85\xd4jJ\x06n\xc6\x14\xde\xb6jbw\xc8\xae\xd3\xb0\xe4Z\xe5\x15v\x8e\xda\x08m\x84[\xed\xd2\xdfO\xdbH\xf1o\xe6\x84V\xbdm\x019I\x87\xa3\x9e'j\x1a\xd5\x96\xde\xdbv\xd6>\xa8\xa9/_\x1e\xe4Vb\x1d\xd7\x8d\xbbsF,\xc2\xc5a\xf1\x0eow\x1f\xd1\xa3\x0dk\xf3\xde\x92+*V\xba\x8e\x7fX-\xfcEQ\xb1\xb2U\xb8V\xb4\x0b\xe1\x87\n\x1f\xe1\x9d\xa1Z\xb2\x92\x8e\xfaLY1\x91T\xf4O\xfb\xfd\xe3 \x04\x1b[\xb8\x8e\xbd\xed\x9bk\xf2\x1bnXU\x89\x12zI\x06\xa5\x14\xe4\xdfpql\xb3\xcc\xeeZB\xae\xc4\xbf\xb8\xb0%\x18\xf7\xb7\xder\x1d\xe2\x9a\xa4\x0f\xdf\xf7\xef\x981\xc5%a\x18\x1dc\xc2\x14\xb7\x18\xfb\xeb\xf9\xfa\xdeX%\xa4\x17\xfdg\xad\xd5\xccxV\xc1\x90v\xba\xd4\x12\xa2\x8eF\x87h\xc6#,\x98+g~\xcc\xd6\xda8\x8c\xc6\xbf\xcf\x90\xddVUU\xa1\x92\xfaFp\x0c\xf3\xe17u\xcc\x7f1\xc7\xa3\xa7#\xbex\xf7\x0b;\xe6\xcf8\x0e>\xc5TiC\x05\x19\xa3\x8d\xcd\xb1\"\x1bs\xf1\xfe	\xfe\xf33\xd3+Gc\x85\x9aB\xea\x92IO\xf6\xb6\xbb \xb7\x12\xf6\x7f\x11\xfa\x93Y\xb6n/\xfd\x13]\xa8i\x11H\x14B	'\x98,\x18_\x92q\xc2R\xe1\xd1w\xb1s\x90Z\xd7\xb1\xd3\xc8\xbc\xcbgO\xec\x1b\x10\xe7\xff7\xc4\x9f\xcc\xf2\x1d\xc4\x7f\x8d\x84\x9f\xdd\xe9\x9fE\xb8\xe5\xeaa\xf1\x1a0LM\xe9h\xbb\x07\xbd\x8b\x1d\xa8\xef1\xa5\xb0\x9bM\xe6\xd3\xf5\x08\xff\xacP9H\xf9.\xa1\xa0\xf8\x14*\x99\x8c\\\xe3\xadM\x8a\xc7\xb2=\xe8\xe0\xbd\xce!\x84\x8a}p\xb8\x88l\xef\xfeO\xbd\xefBG\xb1\xe7\x8f\xc2\x88\xd9\xfbO\xe1\x7f\x02\x00\x00\xff\xffPK\x07\x08!\xc3\xb0\x19\n\x06\x00\x00|\x14\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x002\x00	\x00roles/etcd/templates/var/lib/etcd/update_ionice.shUT\x05\x00\x01\xf0b\xe7[t\x92Ak\xdb@\x10\x85\xef\xfb+^\xe4\x1cb\xb0\xeb\xd0kq/q\n\x81@KhO\xa5\x88\xf5\xee\xc8;X\xde\x11\xbb#T\xd7\xe8\xbf\x17\xc9\x89\x1b\xa7\xceI0;\xef\xcd7o4\xb9Z\xac9.\xd66\x07c2)\xe6d\xcc\xfd\xf7\xbbU\xf9\xedaU~yx\xbc_\x16\x87\x03H\x9d/\xbdU[zN\xe4T\xd2\x1e}\xbf\xf8\xd0\xb0/\xcc\xdd\x8f\xa7\xa7\xf2E\xb3\xbc\xbei6\x89\x9aQ2\x05&\xd0\xc0\x19\x9d\xa4m\x86\xc4\xb1\x8cF|\x86\xcd\xe8\xa8\xae\x8d\xd9m='\xcc\x1b\xbc?\xa90*\xad\x0b(\xae\x0fgp}a\xcc\xd7\xc7\xd5\xeb\xe9\xce\xea\x85\xb6\xa91\\\xe1\xe7\xf0r\x86\xdb\x17X\x0e\xc5\xd7&}\x81_\x9f\xa0\x81\xa2\x19\xf8\xa3\xc0\x05\x1b74C\x16\xd0oV\xe4\xd69\xca\xb9j\xebzop\xac\xdd\x9a\x8a\x8d\x99\x80+t\x84D\xd6\x05\xf2\x08\x94h6x\xa1a\x0f\xce\x90V\xbdU\xf2C\xeb\x907KdGh\x12Kb\xddC\x05Q\"\x0dQI\xedG\xd5MG\xd8\xd9-\x8d66\xe7v\xd7(K\x84\x06{\xd2\x07\x9b\xa3bM\x14G\xd7J\x12D\x03%4I\x06T\xca\xd3c\x00W\x98\xff\xf9\x7f\xe1\x7f\xfb>\xfb\xcd\xdd\xedx\x91\xb7\x8d\xcfKv\x89\x95\xe0\xda\x94(\xea\x08\xa92~*\xae\xc9\x90\x0br)\xe9\xcf\x17\xefw\xcca\xfc/<\xe7\xedY\x14k\xca\n\xaa*I:C\xe0M8\xbd\x9a\x13\xe8G\xcc\xe3\x0b\xec\x9b\x89\xe6o\x00\x00\x00\xff\xffPK\x07\x08!\x7f\xb7\xa1\x81\x01\x00\x00\xdf\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/ipsec/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xca,(NM\x8eO\xcdKL\xcaIM\xb1R\xc8\xcb\xe7\x02\x04\x00\x00\xff\xffPK\x07\x08C\xe9 }\x18\x00\x00\x00\x12\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x00roles/ipsec/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x8c\xceA\n\xc20\x10\x85\xe1}N\xf1.P\xbb\x0f\xe2\xc6e7\xde\xa0L\xdai\x0c\xa6\x932\x93P\x04\x0f/\x8a\xee\xbb\x7f\xef\xe7\xeb \xb4\xb2\xc79m\xc6\xd3\xc5\x013\x87\x16\xbd\x03\x80\xd5\xa2\xc7M\x93TT\x8a\xff\x0d\x96\xa2\x18Z\xe0!U\\\x8b,)6\xa5Z\x14\x1b\xa9\xb1:\xd7!\xc9\x94\xdb\xccc%{\x98\x87U-\x12m'9=\xd7\xec\x80\xfd\xce\xe2\xf1\xed\x8d,\x142\xcfx!\x94\x92?\xef\x1f\xa9?b\xea\x0f\xa2\xde\x01\x00\x00\xff\xffPK\x07\x08\xe4\xf6GL\x88\x00\x00\x00\xe9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/ipsec/tasks/strongswan.ymlUT\x05\x00\x01\xf0b\xe7[\xacT\xd1n\x9c0\x10|\xe7+\xa6'\x9dh\x1f\xb8\\\xa2\xaa\x0fH\xf9\x80~@\x9f\xd0	\xf9\xec\x05\xdc3\xc6\xf2.IN\xf4\xfe\xbd2\xa4!\x89\xdaFj\xf3\x86=\xc3x\xec\xdd\x9d\x02^\xf5T\xe2\xabgQ\xce\xc1\x06&\x8d\xa3\xf5*Z\xe2\x0c\xe0\x8e\x9c+\xb1\x99\xb6`\x12\xd0\x83\xe0\x16\x1f\xf5\x18#y\xa9{\xe5mC,Un(\x907\xe4\xb5%\xce\x0fU>\x0b\xa5\x0f\x968\xf8\x96\xef\x95O\xab\x10\xe9\xa8NT\x04%]~\xf8\xc1\xc1Y\xa1\x07\xf9T]\x1f\xb0\xbdL[\xd8f9\xe3\x16\xf9.\x86>\xc7\xf6\x12C\x8f\xe2\xdb]7mA\xee9n\xe8\x98p\x13N-\n\x9b`ol\x83\xed\x05E\xd1\x0cQ\x13\xa6	\xefd\x15\x97\xcb&\x03\xee;\xf2e\x06\x14\xef\xa6k\x19\x86\x1a\xeb\xc9\xbc\xab\xec\x87[\xe4y\x96\xfd\xaa\xaf\x8e\xa4\x84\x96\xf2\xee\xf4\xe0\x9b\x0c\x10\xea\x83SB\xe9>\x00G]bC\xa2\xafV\xd2\xee\xfb\xcdf\x06\x0d\xb1\x94\xd8\\\xbd\x84\x17l\xb8\xf7\x14K\xc4a\x90y\xdd\xc6a\x0c\xcf\xd6\xfd`\xa8\xc4\xfe\xcb~\xff\x07;':\xbf\xe9\x86IG\x12\xfe\x8b\xa1G\xc6\x7f{Z_\xf5\xadwz\xc5\xfc\xad\xb7W\x9c\x7fwG~\xe9\xe8U\x10q\xf4\xde\xfa6\x0d\xe9\x99\x85z\xb3\x18\\\xf8+oq-\xe9\x02\x88\xc4\xa2\xa2\xcc\xbd\x06\x90WGG\xa6\xc4\x99x=i\x0c\xe6\xa96k\x00,\xc1\xb0`+7X\xdf\"\xe5F7\xb0<\x8f\x8b\x19(4\xae\xd3\x08&\xf0NE\xae\xacP\x7f\xa8\xb4\x1bY(\xd6\xb6Q\x9a\xea$4w\xf5\xdd\xe7\xd4\xc6\xca\x98H\xcc\xeb\xc0Y\xe9\xea\xf4\x1f\x97\xc0f\x9a\x96\xc7\xe2*?\x8dG\xaa\x1f\xa5\x9e\xd8\x91Z\x9b6\x16gu$\x1e\x9d\xcc\xfb\x92\xf2\xac\xc4M\x06\x8c^\xac{\xc1\xd8E\x9d\x12e\x9f\xa5\xe29u.q\x9d\xfd\x0c\x00\x00\xff\xffPK\x07\x08\x13j{%\xe0\x01\x00\x00\x1a\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/ipsec/templates/etc/ipsec.conf.j2UT\x05\x00\x01\xf0b\xe7[\xac\x92Ak\xdc>\x10\xc5\xef\xfa\x14\xf3OX\x9c\xc0\xb2\xff6-=\x14t\x08\xa5\xa5\x94\xd0K\x0b\xa5,\x8b\x99\x95\xc6\xdeae\xc9Hc'f\xe3\xef^$'M\n\xe9\xad\xa7\xd5\xe8i\xe6\xed\xfc\xfc\xce\xe1\xe6\xe3\xa7\xef\xc0	\xaeo~\\\xff\xfc\x06.\x18t\x87\x90d\x03\xdd\x04\xdc\xbf?\x9d \x97#\xc6\xb4e?\x92\x97\x10\xa7:_y\xech\xb75nHB\xb1\xe6\x06\x0d\xd5\xcb]\xc5\xfd\xf8\xb6\xdam+\xb46RJ\xd5\x0e\xe6Y\x9d\xc3g\x1c\xd9\xb7\xe0\xa8\x11\xbdB?\xad\x01\x9d\x0b\xb7	<\xdd\x02Z\xec\x85b\x02	\xb0' L\xec&@k\xc9n\x00\xbe\x0cI\xc0\x13\xd9,\xa3\xb5\xa5\x85\xfb\xaa<7\xc1{Hd\x84\x83W&\xf8\x86[H$C\xafT\x91V\x96\x1a\x1c\x9c(\xe0#9nH\xb8#\xfd\xeeU\xa7\xe0HS\xbe\xd0W\xb9\x88t\xa4\xa9\xc3\xd8\xb2\xd7o\x16\x91}+\x91)\xe9\xd7\n\xba\xb0\xe7#i\x1f\x8aBw\xe6\x80\xbe%\xcdG\x1a\xafT\xd9\xcaP\x14}:A\xfe\xad-G\x98\xe7\xff\x1f\xca\xb4\xe1>\x91\xd9\x98(\x19\xc6\x13\x04\x058H\xd0I0\x8a\x82\xc8\xedA\xd8\xea\xb3\x0f_ui8[\x9e6\x1c\xe9\x16\x9d\xd3\x13%\xa5\x0cB\x11\x15\x18\xfc\x8bg\xd1\xeb\x18\x82\xd4\x06k\x13\xa5n\xd8Q\xfe>\xc5\xfe\x99\xa7:\x87k?-\xce\x1aRO\x86\x1b&\x0b\x07\x8a\x04\x18	\xc8\x9b8\xf5B\xb6\x80^0\x17\xeer@)\xe9\xd8\xe4\x19\xce\xc1\x03\xe7\x0c_\xd8\xb7\xa9\xb4?M\xc4}\x18I\xa9\xd3\n\x9a\x10\x81{`\x0fm\x0cC\x9f\xb6\xd5q\xd8S\xfd\x10\xa6jw\x0f\x1d\xf6\x17\x15\xddID#\xd5\xfa1\x82\xeb\x17\xf2\xb6^\xe2\xb6~J\xdb\xe5\xbd\xe3$\x00\xab9{q\xf3O\x12\x0c\xff\xe9\xfc\x97W\xf3\x92\xa9\x82\xb8\xa0\\\xc0\xfd\xaeO+ o\xb9\x81\xd5\xbc\x1c\xf3\xae\xe5\xcc\x0d\\p\xaa\x0f\xf8\xb8'\xdc\xc3>\x04w\xf9|h\xe10r\x94\x01]\xcd}\x8d=\xff\xe9\xf2\xf2\x83g\xae\xeaW\x00\x00\x00\xff\xffPK\x07\x08\x8b\xf7\xa5\xf7\x0c\x02\x00\x00\xd5\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00roles/ipsec/templates/etc/ipsec.secrets.j2UT\x05\x00\x01\xf0b\xe7[\xb2R\x08\nvTP\xaa\xaeVHN-*\x89O\xc9,R\xa8\xad\xd5\x87r\x8b\xf52\x0b\x8aS\x93\xf5\xb2S+\x15jk\x95\xb8\x00\x01\x00\x00\xff\xffPK\x07\x08\x0bj\xe4\x15.\x00\x00\x00-\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00roles/ipsec/templates/etc/strongswan.conf.j2UT\x05\x00\x01\xf0b\xe7[,\xcd1n\xc30\x0c\x85\xe1]\xa7x@g7u\x00\x0f\x19r\x8a\x1e\xa0 h\xda\x16,\x91\x82D\xc5\x05\x8a\xde\xbdp\x93\xe5\x0d\xdf\xf0\xfe7\\\xc4\xf9\xd2\xbc\x9a\xae\xed }g\xd3\x05\x03\x9e\xf2y\x90\xe2\x94\xb8\xf6J\x1eM\xb1\xc4$!\xf0F\xd5\x14?\x01HF3\xee\xa8\xa4\xb3e\xa8)\x0bH\x1a\xdaF\xe39W\x14\xc9(;\xb7\x11\xdc\xebC\xae\xd34\xde\xb0\xe6\x82\xef\xe9\xe3vZB\x95\x87\xf13\xb0e\xe2\xff\xfc.\xd8\xa5\xaa\xa4A\xc5S\xd4\x1d\xcdx\x17\x1ffY\xa8'G/\xb3\x1d\x1a\x80\xdc\x93\xc7\x92\xe4\x8b\xbao\xa2\x1e_Ww\xa8\x85\xdf\xf0\x17\x00\x00\xff\xffPK\x07\x08\xfd\xc5\x8c/\xae\x00\x00\x00\xe5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/journald/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[d\xce\xb1n\x830\x10\xc6\xf1\xddO\xf1),\xae\x84p\xbb\xb2G\x0c\x11R\x86\xec\x91\x03\x07\xb85>\xe4\xbb\x88\xd0\xa7\xafZ5t\xe8\xf8;\xe9\xf4\xff\nL\xaa\x8b\xd4\xce\xad\xebZ\x0d\x99\xa8'\xf9P^*\xce\xa3\x13\x1et\xf5\x99\x9cl\xa24\xf7n\xf6\xc9\xbd\xf3='\x1f\xfb\xaa\xe34T\x93\xce\xd1\x14\xf0]G\x8b\xfa[$\xdcSP)\x11R\xd0\xe0\xa3\x80S\xdcJ\x0c\x9c\xa1\x13\xe1\xf9}\x8d<^%|\x12|\xa6\xda\x14\x00N\xb0\xa7\x10\xf9\xb6)\xc9K\x89\x16\xb6\xa5\xd1?\xd9\xc06\xe1\x8f\x17\xd8\x0b\xe5\x9dg\xd83\xe9\xce#\xec\xf1\xf1+\xb3'g\xff\xf8I\xd68\xbc5\x07\xf3o\xca\xf7\xfd\xb5=\x98\xaf\x00\x00\x00\xff\xffPK\x07\x08\x8c3\xa50\xbe\x00\x00\x00\x15\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/journald/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[L\x8d1\xaa\xc40\x0cD{\x9fb.\x90\xeaw\xbe\xccG\x1b\x0d\x8b\x17G\x01I.r\xfb\x05;\x81\xed\xa4\xc7\xcc\x9b\x0d&\x07+\x9c\xfd\x14\x85\x98\xc2\x19)\x9e\xf8\x9c\xc3M\xba\x16 \xaeH\x1eZ\x0b\x80\xbbp\xa3\xed'\x05DJN\xd94pA\x9a\xbc:\xb5\xe2bL\xa0\xc2\xe3\xb4\xff5\xf9`gzcT\xfc\x15@\xd9\xe5Z\xa7\xf3\xdd\"\xe9\xd3:z\x16`X\xb6\xfe\xfch\x81\x18\xfbN*\xb5|\x03\x00\x00\xff\xffPK\x07\x08(\xf1B1\x7f\x00\x00\x00\xcf\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/journald/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xbc\x96AO\xdbN\x10\xc5\xef\xfe\x14O\xfa\x1f\xf8\xf7Pr\xb7(\x17\xa4\x1e\x8a*UE\\kM\xbc\xe3\xf5\x96\xf5n4;\x86\x00\xe2\xbbW\xeb\xd8N \x94\xa6H\xe91;3of~~\xb1\xf7#\x02u\\\xe2\xecg\xec%\x907\xe7\x05`x\xd9\xdb\xb2\x00\x80.\xd9\x12\xdf\xc4\x05\x85\x92\xddIC\x13\x05\x97\xfd\x92/\x9d\xe2\"\x86\xc6\xd9^H\xa3`E\x92X\x8ab\x92\xbeh\xb9\xbe\x01y\x8f6&M a$u\xde\x83n\xc9yZz.\x00\x17j\xdf\x1b\xae$z\xde\xb4\xdeT\xaf\x84\xeb,0\x1c)\xa5\x9bT5\x12\xbb2\x0bV\x83`E\xde\xddrQ\xfc\x87\xc0l\x124b\xc9\xb9.q\xd0a\xccih\xf8h!QI]\x0c\xb8k9\xa0O.X$\x8dB\x96?Q\xaf\x11\xff\xdf\xb5\xaen\xe1\x12\xb4e\x18n\xa8\xf7\xfaa^\x87RbQ,nI\x16>\xda\xc5(\x0e^\xbb\xa4\xa9\x00\x1a7m\xb0\"m\xcb\xbd\xcc!\x94\x94\x94K\x18'\\k\x94\xfb-\xae\xeb\x95!e|\x99f\xaeg\xb6yh\x8dX\xb1$\x97\x14\x86\x94\n\xc0\xbb\xc0.\xec5e\xad\x17\xe9>)wfjlN\xb3\xd6n\xfb\x11\xd2p$ly\xbd*q\xf2\xe3j\xa4q2\x9cg\xfd\x12'\xd3\xe1\xd8\x9d\x83\xe6\xb0\xb0\xcd?\xa4\x9c\x19W}pZ\x8d@\xabmv\x01\x84\xa8\xae\xb9/!\xec#\x19P0\x10NJ\xa2s\xf5_`\xf0\xaes\n\xe9\x83\xba\x8eA\xd6\n\xdb\\\x91\x9fqr\x0f|\x144\xdf7\xed\xbe\xd2\xfa:\xbd\x00\xf4<\xf4\xf8\xb8%\xd2\xd1\xba\xca\x13\xe1\xe9\xe9-f\xe3*Cz\x9f\xf8H\xc0\xb6\xa0\xf23bs<VWC\xd9k\xa8\x9eE\xdeAj3\xd0\x91AM\xce\xfaG~\xfa\xec<_\xb9\x87\xdf\x9aj\x8e\xef\xf2\xf2\xd1\x1e\xc2k\xd7Yy\xfaj\xdc\xe7\x18\xd8FW\x1d\x95\xda\xec\x9f\xd7\xa1\xed\x87\xdf\xc1l\xc7c\xef@\xd6\xb1R\x89\xc6\xf7\xa9\xadZ\n\xc6\xb3\xe4OC\xfe\xe4\x948\x1f\x86\xfd\xd3\x0b\xf3\xb4n)X6\x88\xf2J\xfe\x8b\x97\xc5\xdb\xc9\xcf\xff/\x87\x0b\xcf\x8b\x1f,\xbfW\xb1\xf5\xcf\xd9\xe2\xc0+\xc6\xe2\xf0;\xc6\xaf\x00\x00\x00\xff\xffPK\x07\x08\xc4Kuq1\x02\x00\x00\xbe\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00roles/kubernetes/control-plane/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xdc:i\x8f\x1b7\x96\xdf\xebW\xbcQ\xaf!{\xc7\xa5\xa3\xedv:\x02\x14\xc0\xeb\xf1L\x0c\x8c\xb3\xbd\xb6\x93\xfd`4\n\x14\xebIb\xc4\"+$K\xb6\xd2\xee\xff\xbex$\xeb\x92Jng\xb2\xc0\x02;\x83\xf1\xb4\xf8\x0e\xbe\xfb\xa0\xc4ee\x1d\x9aL\xb1\x02\x170\xdaU+\xdc	7J\x12\xfa++\x8d\xfe|\xc8\n\x9d\x13L\x94\x8e\xad$\xda\x91\x87It\x99E#\x98\x14\xbfc&\n\xb6\xc1\xac\xac\xa4\xb4\x0bP:IX\x9e\x0b'\xb4b23v\xcb\x0c\xe6Y\xa1+\xe5\xb2R\x0b\xe5\xec\x02>\xde&\xc9\x050)\xf5'\x0bkm\xb8P\x1bp[\x84\xca\xb2\x0d\x82^\xc3\x9b\x1b\x0bBY\x87,\xa7\x8f\x7f\xfb\xe9=\xa0rF\xa0\x05\xb1\x06\x85\x98cN\xf7\x18\xb46\x13j\x8f\xcais\xc8\xd6\x02e\xbe\x80\xd1\xdd#B\xe3RW9\xe9\xb1\x179\x1aX.a\x8c\xfcr\x0c\x8f\xeeK#\xf6\xcca&\xca\xbbG\x80\xd2b\xe7,W\x96\x0eU.\xd6\xf0\xe8~\x94$\xe8x\x9e	%\x9c`2\xabm\xe6\xf4\x0e\xd5\x02f\xfc\xd9\x8b\xf9\x0b\xce\xd3\xe7\xcf\x9ecR\x03\xc5\x9aq\xac\xcd\xca\x94\x15+\x89\x19\xba\xedl\x94\x94\xd5J\n\x9e\xedE\x19\xb0\x16\x00\x17 \x91\xed\x11\xb0(\xdd\x01\x98\x85\x1c\xd7\xac\x92.IP\xb1@\xc9\xf3Lj\xcedp\x8a\xb7\xb2?\xb4\x8e\x19'\xd4&:\x8b\xe5{4NX\xccJm\xdc\x02\xae\xaf\xaf\xaf\x07\x11km\x8e	\xbe'\x82$\xde\xb7\xb1Y\xce\x0e6s:\xdb!\x96\x0bx6\xeb\x80\xb8\xd1\xca\xb1UV\x08U9\xd2s\xf6\xf4\xd9l4\x80\xb0\xd5\x95Y\xc0\xe8\xdfkKn\x91\x19\xb7B\xe62\xa1\x1c\x9a=\x93\x0b\x98_F\xd6(\x91S\xe8dN\x14\xa8+\xb7\x80\xf9l\x16aV\xb1\xd2n\xb5\xcb8\x05S\x80\xccfd\xbf\x9f\xaab\x85\x86\x02\x85\xeb\xa2\x10\xcea\x0e\xce0e\x99gf\xc1ipFl6h\x80\x01\xab\x9c.\x98\x13\x1cj\x8e\x04\xcf\x85\xdd\x85{\n\xf69\xfb\xc4(\x98k\xa9\xb8.\xca\xc0*3\xe8P\xd1_\x0b\xf8\xeer\x1b\xc0\xbfU\xda\xb1l\xc5\xf8\x0eU\x9e\xad\x0e\x0e\xed\x02\xbc`\x16=\xef\x8dA\xe6\xd0\x80\xdb2\x053:	\x9e\x8d\x16\xc9\x99cY.\xc8Jww\xd0;BNq\x0d_\xea\x98x<\x9e\xee\x99\x99J\xb1\x9a\x12\xde\xf8)8S\xe1\x13\xb8\xa7@\xd5{4\x9f\x8cp\x98\xd5I\xeaXH\xb85\x93\x16\x07\xe0\x92\xadP6\xf0\xe4\x02\x8a\xca:X!\x18d|K\"\xc2\xda\xe8\x02>	\xb7\x15\n\x0e\xba2\x10\x83<\xe1h\\\x90\x9aD\x99\x96;\x91\x18M\xdea\x197.[\x0b\x89!\x03\xe2\xe9\x84\x1b\x17\xf4\x1dF\xeb\x82<\xaeE\xb3\x17\x1c3\xc6\xbd\xc3\xb3\x1d\x1e:\xe8\xd6\xec	2\xd9\xe1!\xf1\xb2\xd8E\x02\xc0\xf2B(\xfa\x03`\x87\x87E<\xf0Ht\xc6)\xc8\xe3\x19]Ag%\x16\xcdY\x89E\x02\xa0\xa8\xe6ux\xd0\xe7#\x16\xfe(p\x88\xb6\xec\x12\xc4\xa3#\x9a\xfa\xb4%\x8b\xe9|D\x19N\x07\x88#\xa0C\xcf\xb5rFK\x89\xe6\x84I\x0b\x1a\xe2\xd4\x81v\xd8Y\xbe\xc5\xbc\x1a\xe2\xd6@\x86\x98\xb5\xc0\xc0\x8b<\xd9\xe5\xe0=;`\xc4\xf6\x9c\xe8\x92Z\xb0\xb5\xd8t\xfc<j\x02>\xda\xcf\xff\x7f@\x1b%\x89\xc1\x8d\xb0\xce\x1cb\x01\xbb\xa2j\xd1\x9c\xc9U{|\x95\x84\nj\xb0\xd4>\xccB\xb6m\xb5u{f\xec\xc7q\xc1(\xacg\xb3\xd9\xf8\xf6\xe3i!\xbf\xfd8\x16\xe5\xfe\xf9\xf8\xf6\xe38\xb6\x9d\xf1-\xfc\x15\xc6\x8b1\xfc\x15zB|\xb1\xceP?\xfb+\x8c\xa7.\xe7\xd3qHN\xaf\xdd\x1e\x8d\xf5\xb5\x83\xae\xe6\x951\xa8\\V0%\xd6h\xdd\xc71\xe1\x18\x85\x0em\x1a1\xc7\xb7\x9e:\x98&\n\xc5EN\xa5b\xfe\xdd\xe5\xe4\xf2\xf9d6\x99M\xe7/\"JL\x19\xdb\xc3\x99\xf7qX)2\x8b\xbc2\x98\xad\x84\xca\xb3\xa8\x0eU\xf0\x89\xffo\x17\xcf\xcah\xc1\xd1\x8b\xab\xab\xeb\xa8\x06U\x0e*\x92\xa5\xce\xed\x02\xe6\xf3Y\x92pm\xf0\xd8\xb4Aca\\\xc5d&JbH]Y\xd8l\xcbjeB\xf3}<\xec\x853\xfd\xfdv\xa0\xb7\xff\xa5\xee\xed\x9e\xdf0\xbbq\xdb\xf5\xc7\xb7T2\x17wwp\x1c+g$\xecy\x18\xee\xef\xbdgGT1\xc5\x1a\xdcVX\x106\x16\xf5\x1cX)\xc8\x11h\x9eB\xcc\xb3\xb4`\x8aQ\xf7\xd9\xa0\x03\xdf\x8b1\xf7E5\xb9\x804\xf5\xba\xa4\xb5.\xcb\x8f\xc8/\xa7{[n\xd1\xe0m\x03\x0e\x11\xbf\xfc8uEYC't\x08\xda\x84\n\xcc>Y\xfa\x9f?\xbcM.`\xcf\xa4\xc8\xe9\xdf\nm\xdbs\x80\x19\x04\xf6\xc9\x12Yd\x03Z\x81A[\x02\xaa\xbd0Z\x15\xa8\x9cM.\x82^{f\x84\xa7\x13\x16\x98\xb4\x9a:\x91P\x98\x83P\xd08\x06(_\x93\xbeO\x160\xf2\x06\xa2N\xfe\xbbVX\xb70/\xcb\xcf\x1f^\x01S9\xe4\x1a-(\xed\xfcH\x07L\x1d\xc0\x96\xc8\x05\x93\xb0e*\x97~.\xd4`*Es\xd1\xcf\x1f^\x05\x83W\x96\xba\xfd\x1e\x8d\x119\xda\xa7M\xd7bQ\xe7\xe6Jf\xa1D\x03RX\x97\xd6\x87\x16t\xe5\xca\xca%\xd17\xa5d\n\xb3\x1aJR\x03\x89M\xd1\xdb\x8a\xc78\xc7\xd2\x01\xf3\xach\xd0\xa0`\x0eN\xb65r\xe7\x88\x8cEd\xceT\xf2\x00?\xbe\x9c\xbe\xad\xa4\x13o}0B\xa5\x9c\x904\xff\x82\xd4,\x87\x15\x93Lq4\xf0\xb84\xb8Fc0\x7f\x92\\\x90{~|	7T\xf1!&\x11\xbc\xb9\x81-\xb3 \x8aR\"9	sxL\xd7t\x08;)N\x92\x84\xb1q\x01\xa3\xads\xa5]L\xa7\xfe3\xe5\xc7\xa2\xce\xd0n\x9e\xb7\xc5f\x1f\x126\xb2\xe908\x93\xd8!\x9b\xfa\xa4]\xa6'6\x82\x98\xdd\xf0i\x8b\n~ysS\xdb\xcc\xe0o\x950\xa4\xda\xaf\xe4Vrc\xc8\xe2'I\x12C(k\xd2\xfa\xdc\xac\xff\x97v\xd6?[\xe4\xcf\x96\x97\xfb\xfbv+8K\xdd\xab&\x91\xa4\xd9\x19\x8e\xbc\xd07_M\xd8\x94	Z9|\x93\xe9\xccyqO8\xc5\xa9'\xbe\x16\xf5\xc4(\xedPx\xde\xc9I\xaf\xc8\x85\x82\xbd1\xba*\x1b5\xc7\xb7\xf0\x05$\xaa\x8d\xdb\xc2\x0f0\xf7\xe9\xea\xa3-\x17\xd6\xaf%\x01-\xdb2\xf8\x02+\xad\xe5\x93N\xa3k\x17\x8b\xb6\xbdP\xcb\xeb\xf6\xd5	5\xd4I\x1d\x06'm.W\xb6\xdb\xc1\xe6\xb3Q\xe2S\x96	\xe5\xe3z\xe3{\x10\x15\x1e\xbb\x80\xab\x01\x90\x15\xbf\xe3\x02\xae\xde\x8aZ\xa6*\x17\xb4\x83J\xc1\xc3 \xb9\x80\xa95\xfbi\xdbu\xa7u+\xb6S\x8f\x1c\xfeM\x03\xc9\xe4\xc0\n\xd9\xe5D\xf7\x94\xccm;\xdd\xae\x05\xac\xb5\xa48\xbc\xbf\xf7\xecS\x0f\x98H\xbd\x19\x1ds\x08\x88\x0b\x08c\x8e\xde\x84;\xa3\xc8\x0d\x90\xea{+gm\xe3BX\x1a\x122\xae\x9c\x91\x19_o\xa2Z\x8d<\xad\x14\xa1}\xd8iC\x14\xab\xdf\x84\xafk\x99Pqs(\xfd\x96\x13\xa7\xb0#\xedN\xb9\xb5$\xb1?y#\xf9\xb2\xff\xab\xd5\x8a\x8a\x98\xc4\x0d\xe3\x87S\xadM\xc1\xdc\xc2c%\xc7@r\x1e\xdb\xa0_8\x07@\xb4hU%\xed\x7fC\xd0\xe0\xf5\xf9\xe5u\x9284\x85P\xccaN\xa3J\xb6\xe1\x99\xdb\x1a\xb4[-s\xbf=&t\x8a{q\xb4q^\x16	m2\xbb\x8c[\x91\xc5\x9e\x1e\x1e6.`\x8d\xccU\x06a\xc3\x1c5\xd5-s\xbe\x9f\xd6\x9d\x7fu\xa8\xb3\xd2\x1fS\xb6\xd0\nHM6\x89\xa4\x99'\xa5\xf1\xf8\xf5\xe7\x92\xa9\xfc\xd5\xfb7\xbfhY\x15\x14\xc5\x94\xb6\x0d\xe0\x8d\xfa\xd9\xe2\x0d\x8d\x81\x96V\xcec\xa4whue8\xfeS\x14\xc2\xd9\x1b#\xb4\x11\xee\xf0\xf7J\xf1\xb0\x9dF\xb4\x0f\x1f\xfe\xf9r\xed\xd0\xfc](a\xb7\xa4\x88\x07${\xcf.+e\xb5\x11\xca\xc6\xed\xf3d\xd4\x0eX\x11\xa9\xce\xedz\x04df\xe3\xf5Ha\x94\xa6\\	\x1f\x02i.\xcc\xd2\x87+Wb\xaa\xd0M\xf2Q\x17g%T@\xd1\xa5\xf3(+\xa1\x1a\x84\x1db\x99\xb6^KK\x9d\xa7A\x04\xbb\xf4+j\x8b\xd9\xec\x00\xcb::\x8f6\x07_P\"\xb6B\xf7I\x9b]\x1a\x14Yr%\x1a\x10]!\xd4\xda\xb0\xb4) \xa9\x7f\xd0\"\xbe\xfd\xa9\x96\x8a\xfc\xd0\xd4\x9ec\x89*G\xc5\x05Zj\x0c\xf5\xcc\xe7\x07\x0b\xdf)Xe\xfd\x1f\xd6\xf08\xd0\xc7\xdb\xc3X\x89&\xa5-hI\x9ei@A\xef(\xb27\xd9\xdd\x1d\x9cz\xad[s\x9b^\xd1\xa9\x0cA\x96\xe8\xa6\x9fX\x81\xb6d\x144k\xe4\x07.\xeb\xeb|\x10\xbdcj\x83&\x9e\xbc\x0f\x9b\xc4\xcb\xb0{\xc7\xc3:\xe6\xfe\xab\xd2\x8e\xc5\xb3\xbf\x85x\x7f\xef\xb4a\x1b|%\x99\xb5\xd4\xed\x03H\x1d^[\xce$sBm^\x7fF\x1eint\xfe\x9e6\x10\xe1\x0e7\xbe\xb6\xc6\xf3\x9ft\x8e\xef\x90\xf6'\x1f\xc4\xf1\xf45\xb5\xe7w\xcc\x85P\xafY\xc4\x80\x8f\x1f\xdfV\xce_\xf2\xb2\xd6\xfc\xbfq\xb5\xd5z\x17\xc1\xbf\xd0Xx\x06\xe1\xd8x\x86\xe6\xb3\xa2\x8e\xa7:\xc2\xcd\x8a\xf1	\xab\xdcV\x1b\xf1;#\xe9&\xbbk;\x11z\xba\x9fw\x1d\x87\x9f\x1d*\xe2o\xa7\xfb\xf9\n\x1d\x9bOiM\x8a\xda\x86N\xf2\x00>\xaf\xac\xd3\x85\x89\xb6\xf6\xe3\xb6\x7fN\x8dd\xc1\xb6HF1\xcc\xa1$\xa3L\x1a\x8f\xb7R1Yn\xd9\xbcOtr\xd9\x03\xe0i\x8e\xa5\xd4\x07\xbf\x10<\x88\xca\xb0\xd0\xca\xe2\x11\xe6\x8a9\xbe\x9d\xee/{\xe2\x9c\x04l\xb7\x94\xf8w\xe1\x94\xa6$!q\x83y?/\x9a\xc9\"\x8d\x93\xc3\xf2\xdf\x1e\xff\xf8\x9f\xef?don\x9et\x90\xa29\xd2:\x19\x837S\xaa\x0eM\xc58\xd7@\xbb\x19\xca\x94V\x87BW6%\xe7\x1fU!T\xb9\x7f\xd2N\x0d\x15#.$\x9a\xd4\x1dJ\\Jd\x1d\xb4\xee\x14q$\xc1\xf1H\xd2\xbb\xda\x93I\xbdI\xa9\x0f/Og\x0c:\x1e&\x08\xedu\x80$\x00\x86\x89\n\xf69\xd6\xbd\xe1~|\x96*4\xe43\x84\x01x\x96\x96\xda\xf5\x19J\x02\xf5\xe9:\xd9\x97\x16T1\xdf\xfd\xc7\xcbWO\xa9j\xb4=F\nT.\xe5\xac1t\xfdNIs\x0b-\xfc\x03\x8f\x8f\xdd[\xd0\xf1<\xe5\xec\x0c\xf5\xd9\xf7\xcbS\x16h\xdc\x19&\xfe\xb5rBX\x13n\xdc	\xe5\x0e\x0f\x0f\x13\xee\xf0\xd0#\xfc\\\xa2\x11\x94\xa7L\xa6\x9d\xa9\xac^\x88\xd2\xa3f9<\xebu9\n\x15\x1e\x89RZ\x19\x96\xb3N\xcb\xfa\xadB\xeb\xb6\xc8<\xdb?k\xee8L4\x8c(\xb7\xd7\x823w^\xff\xe6\xf9\xf6+|vxx\x80\xfe\xc8\x80\x0d}+@\x1dpn\x88\xd5\xb7\xea\xd5\xac\xe6u\xb9\xf2\x05\xc2.\xdf(\x87F1\xf9\xe6\xe6\xe9\x8f\xda:\xbf\xd9\xbd\xfe\\\x9f5lJ\xa3\xd7B\n\xb59\xaa<\xd1\x0bi\x1cZ\x97\xcff3\xdb\x02C\x07;\xf6\xf9\xd9\x16\x07_\xe0W-\xd4\xe3\xf1\xd3\xf1\x93\xae\x0e\xf151\x8d\x0f\xf0d\xd4\x137\x07k|\xed\xa9\xfek\x1c\xa5\xd6T9z\x05\xdeI\xeb\xbdp\xe6\xaa\xe0\xc3\xfa\xd5\xb8\xcb\x9c\x08\xe3n=(*\x9c0\xa8c\xa0yz\x8eo\xe2Y|\xae;\xedK>.\x886\xe5\"7\xb6/y\xdc\\S\xd2z\xd9nk'p\"m\xc7\xd6\xce\xabn\xd4\xe6\x02\xe2P\x05\xd7\xdf\xbd\x98mg\xc5\xcc\xc2\x12\xe6p@f\x06\x13\xbefl\xc5F	\xb5I\xf3\xca\xf8\"\xb9l\xe8{q\xf9\xed\x833e\x00*G\xe9@\xd5\xe4\x8f\x13\xb7\xf5\xfa\x8f\xd1\xcaP^\xfc\xd7\x81}#\x9f\xcb\x88\xa3\xb5a\xc3\xd3f\xd9\xa3\x0b\xbf\xb2\x0b\xf6\x07\xf2\xff\xd5\xea\xf6\xa7\x19\x9cM\x86^0\x1f\x85\xef\x9fJ\x8cc^Gu\x92\x04\xfe\x13\xfa\x1c\x17\x80\xaf\x89\xf5\xc7J\x0b9\xbd\xde\xe5\x9b\xb2xw\x07C;~\x93g\x9e\xb2\xb2x\"\x177\x98S\xe03\x19s\x1c.\xe0\x03\x16%\x18,\xf4\x1es`\x16\x84\x03N\x9b\x9d\x05\xc9\xcc\x06\x0d\xd4S>\x08k+\xb4\xb5dk\x89\x9f\xff\x85u\xae\xf9V\xad_\x85\xf6\xcb\xcb\xfe/4\xfakx\xbf\xc4\x9cT\x98\xa3\x0e\xf5\xed	\xe9\xef\nCW\x8d\xdd\xfe@\xa4\xfb\xfdt\xfdK\x91\xdeW\xd3=\xd9\xeb\x87\xf7,\xbc\xbc7onA\x89\xf0\xad\x1f]\x10\xee\xf4\xdf\x176\xaf0\x07oV\x80\xd2\xa0\x7f'\xb6\xc2a8M\x92\x0b\xa8w&\xb0\xe8h\xd9\xb3\xfe\xeb\x953\xff\xa9\x1f\xf9%\xba\xf6G-\x07\xeb\xb0\xc8\x0c\xfaF\xe9\xbf\xc4\xe4e\xb5\x80\xd1\xe5\xd5\xac 5\n,\xb49\xf8\xef\xca.\xff\xe1_\x11\xb0\xdcb\x81\x86\xc9\xcc\x86\x15x\x01\xa39\x81j\x9e\xdeV\xdf\xc01\xbcc\xd7\xbf\x16	\xc7Y\xb1\x9a\x18dr\xe2\xb4c\x12~X\xc2\xf5\xfc\xfbKxt?\xff\x87h\xdf\xa8g\x93\xab\xf0\xb1y\x7f\xfe\x06\xb1\x9a\x9c\xd82\xe3\xc5\x8a7\xb2=\x13\x92\xac\xed\x95\xbc\nJR\xc7[\xdb\x1el>{\xe4\xdf\xf9h\x1a\x8d_\xeb\x87\xdai\xb3\xa0`_\x9f\xd2h\x8e\xd6j\x13~\xbdA\xaa<#E.\xbd\x1abM\x9f~\xf8\x1a\xf6s\xd2\xba\xd5\xf9j6+z*\xf7E\xf8\x83V\x9d\xbfxvM\x17<\x8ff\x15\xebx\xf4\xc3\x83\xa4\xd1!\x97-\xa5?y\x98\xf0\xf9\xec\xfb\x17\x0f{2\xfc\x9e\xc6\xbf\xf1\xd5v\xad\xb7\x90\x8e\xb9}\x92uQ[\xfd\x8f\xb1\x03$\xd4\x99\x90\x00i3\x18\x9e<\x85\xfc\x9fz\xf4\x9c0\xffo|{\xa4\xe0\xb1\x97\xbf\xe2\x0c\xef\xbea\xf2\xae\xe7\xbfn\xc1^\x0c\xb4\x1d\xbf\xfe\xae\xfa\xecP|\x14\x15\xa1\x90=\x88\xfc/z\xedj~\xf9\xb6c\xc4\xcb\xab\x17o\x07\x8c8p\xf1\xa05\x1f\xd0\xa65\xeby\x86'\xf6}X\xe9\x9e\xa1\x9b\xb6~\xdc\xe5\xcf\x9bu\x00\xa7\x91\xc2\x1b\xe4\x04oP\xf9\xe1\xabZ\x9dO\xc8OT=+HO\xc3\xd0\xb6;#\xc2y\xcd\x8e\xe0\x83Z\x05\x9cA\x8dN\xd9\xb7\xda\xf4\xc8N4\x19\xbc\xd8\x13\xffO\x00\x00\x00\xff\xffPK\x07\x08\xf7x\xc4T\xa8\x0d\x00\x00\x15+\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00?\x00	\x00roles/kubernetes/control-plane/files/etc/logrotate.d/kubernetesUT\x05\x00\x01\xf0b\xe7[\xcc\x8fAn\x83P\x0cD\xf7>\xc5lXR\xa8\x94\xe6\x16=@\x97.8\xd4\x82\x8f\xbf\xfc\x0d\n\xadr\xf7\nRE\xea\x0d\xb2\xb1Gc[~\xd3\xac\xec\xcddC3.\x9fR\x17\xf1U;)/\x93\x0dx\x8c\xbe8\xbb]\xb7\xff\xe6(\x92y\xd2U\xfa\xdd\xa7\x1f\x02\xdc\x82C\xf0F@gy\x0b_\xe6\x8eC\x08HZ\x8a\xce\x83\x8d\x04\xcc\x16z\x91\x94c;\xf6Rv)\x85\x80\xa2\xdf\x82\xd7\xb6}'\xa0g\x9d\xb6\xa3\x87\xc85\xfe\xd4\xc5<q\xa0\xae>\xaaT\xf5u\xb5_u.\xfb\xcf\xf6|:\xc1\xcd\xe2(t#z\x80\xf2\xd2k\xdc\xe3\x1d\xf29p\xcf\xed\x1dw\xe7\xa2\x1b\xfd\x06\x00\x00\xff\xffPK\x07\x08\xfb\xe7\xc1S\xb5\x00\x00\x00\x87\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00E\x00	\x00roles/kubernetes/control-plane/files/etc/rsyslog.d/30-kubernetes.confUT\x05\x00\x01\xf0b\xe7[\x1c\x8d\xc1j\x021\x10@\xef\xf9\x8a\x01\xa5\x9e\xb2\xb3\x8a\x08\xf5Z\xe8\xad\xb7\xfe@L\xc6\xec`\x92\x91\x99hh\x0f\xfd\xf6\xb2^\x1f\xbc\xf76\xdf\x0b\x1b\x8c`\x10\x95B\xa7\x04W\x95zv\x9b\xa5\xf7\xbb\x9d\x11\xc7\x18\x93q}\x94J\x89\xc3\x14\xa5\xe2\xa5H\xc6\xc3\xbc?\xe1|\xc0\xfd;Fj]C\xe1_J>I\xbc\x91\xfa\"9s\xcb~p_\xbc\xda\x8f\xad\x8a\xdb~r\xa1\x8f\xd7\xe7K\x12\xc1|:\x1e\x1d_a{W\xc9\x1aj\x0b\x95 J\xeb\x81\x9b\xc1\xee\xf6\xb8\xd0\x0e\xfaB\x0d\xf0\x19\x14\xd7\xc6\xca\xbc\x91>9\x92ME\xb2{\x83?\xf7\x1f\x00\x00\xff\xffPK\x07\x08>\xd4	\xb7\xaa\x00\x00\x00\xc6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/control-plane/files/srv/kubernetes/manifests/audit/audit-policy.yamlUT\x05\x00\x01\xf0b\xe7[<\xcc1\n\x02A\x0c\x05\xd0>\xa7\xf8`=#v2gP\xb0\xb2\x0fN\xd0\xb0a\xa3\x93\xcc\x82\xb7\xb7\x10\xf6\x00\xef\x95R\xe8\x80\x8b?\xc1f\x18\xf2\x99\x12\x19\xe0D\xbe\x04WI\xee\x9c\x0c\x93M\xac\x12\xbf\xf5.#\xd4\xd7\x06\x9e]\xb3.\xe7\xa8\xea\xc7\xedD\x8b\xae\xbd\xe1\xe6\xa6\x8f/\x8di\x12\x8d\x80\xf2\xa7m\xaf\xe8\x17\x00\x00\xff\xffPK\x07\x08\xb3iZ\xa8f\x00\x00\x00r\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00:\x00	\x00roles/kubernetes/control-plane/files/usr/local/bin/etcdctlUT\x05\x00\x01\xf0b\xe7[RV\xd4O\xca\xcc\xd3OJ,\xce\xe0\xe2J\xad(\xc8/*Qp\x0dqvq\x0e\xf1\x89w\x0c\xf0\xb45\x06\x89\xa6&+\xe8\xa7\x96$\xebg\x97&\xa5\x16\xe5\xa5\x96\xa4\x16\x835\xa5\x96$\xa7$\x97\xe4((\xa98(q\x01\x02\x00\x00\xff\xffPK\x07\x08p\xad\x17%J\x00\x00\x00I\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00:\x00	\x00roles/kubernetes/control-plane/files/usr/local/bin/kubectlUT\x05\x00\x01\xf0b\xe7[RV\xd4O\xca\xcc\xd3OJ,\xce\xe0\xe2\xcaLS\x88V\xd0MS\xd0O-I\xd6\xcf.MJ-\xcaK-I-\x06\xab\x00q\x93Kr\x14b\xad\x15J2R\xf3\xb8\x14\x14\x14\x14R+R\x93\xf1*VRqP\xe2J\xcd\xc1gnFeAj\x11H\x8c8\x93\x11\xcaQ\xecH\xcb\xe4\x02\x04\x00\x00\xff\xffPK\x07\x08\xff\xe6\x92\x06^\x00\x00\x00\xca\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x007\x00	\x00roles/kubernetes/control-plane/filter_plugins/filter.pyUT\x05\x00\x01\xf0b\xe7[\xa4\x92Qk\xbb0\x14\xc5\xdf\xfd\x14\xf7\xcf\x7f\xa0\xa1\xade\xaf\x82\xf4i}\xdb\x18\xec\xb1\xb3%\xd5\x9b5#M$\x89\xa3\xa3\xebw\x1f&.U\xe7`\xb0<\x88\x1e\xcf=\xf7\x97\x9b\xfc\xff\xb7l\x8c^\xee\xb9\\\xd6\xef\xf6\xa0d\xc4\x8f\xb5\xd2\x164FQ)\xa81\xb0\xe6\xc2\xa2\xbeWU#0Q\xfbW,-\xc9\xa2\x08\x00\x80\x9b\x9d\xa5\\Z\xc8AcZ\xaac\xcd\x05&:\xden\xb6Y1\xcb\x92G\x8d\x0c5Y=\xa8\xe4\xa9<`\x9b\xf0qw\xc2\xb2\xb1H\xce\xb7\x97\xcdvQ\xdc\xc4d\x10\xb5k\xe4_\"\x17\xab^\xa0\xa0{\x14\x13Ay1\xcb\xdd\xb3\xf5:s\x85\x0c\x98\xdb\xa7I\x0c\nF2'\xb7K\xa3m\xb4\x84s\x10\xda\x15\xbfQ\xc1+Ol\xe2\x0c\xda\x9a\xd4i\xd4b'\xcf\xa7*\x1c\xd2\xf7\n/\x87\x82\xcb\x95j\x14\xea\xe8\xe6\xd0u\x80nX&_Sa\x10\x86\xd8\x90\xc3\xa6\x08\x02g\xc1\x9d\x0d\xc84\xbe\xe0	rO4>\x86\xe0Da\xf0\x17u\xc1\xc1\x94\xf6\x94\xc0%L\xb5\xe5\xcc'\xa4Gj\xcbC\xe2,\xb3\xf8Y\xc6d\xe8\xf3\xadlJ\xeb\x1ae\xe5}d|:\x1a\xed\xc4\xc8\xfcT\xbb\x91\xf9\x8fk\xf8x@-\xb0\xbf0\\v\xe6\x01\x08ga\xa7\xeeo\xc7\xed\xde\x7f\xe0\xeea;\x1b\xe9\xf5\xfe\xa2\xfe\x0c\x00\x00\xff\xffPK\x07\x08\xf5K9{Z\x01\x00\x00\x81\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00roles/kubernetes/control-plane/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\x8d1\x0e\x021\x0c\x04\xfb\xbcb?\xc0\x07\xf2\x19\xe4\x93W\x14$\x8ed\x9b\"\xbfG\xf2!]\x83hhg\xec\x9d\x1bL&;\x9cc\x89BL\xe1\x8c\x14O<_\x07\x07\xb3\x01\xb1#9\xb57\x00\x9f\xfbK\x02\x91\x925Q\x7f\xd4\x8249\x06\xb5c3\n\xa8p.\xbb\x9f\xa1\x13\xb7\x1fu\x8f\x1dc=\xbe\xd7/\xf9G\xfd\x1d\x00\x00\xff\xffPK\x07\x08\x14\x7f\xe8\xf6h\x00\x00\x00\xfb\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00-\x00	\x00roles/kubernetes/control-plane/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xec;]s\x1b7\x92\xcf\xa7_\xd1\xa6WE;\xa7\xe1P\x1f\x96d\xd6\xeaR.\xc7\xb9K\xc5\xb1S\xb1w\xf7a\x9d\x9d\x02g\x9a\x1c\x84\x18`\x02`(\xf3$\xeeo\xbfj`>\xf9\xcdd\x93\xdb\x87\xe8\x85\xe4\xa0\xbb\xd1\xdf\xe8n\x8c\x02\x90,\xc3\x11\xfcyV\x8cQK\xb4h\xc2XI\xab\x95\x08r\xc1$\xfe\xd7	@\x82\xe3b::\x01\x00\xc8\xcct\x04\xdfk.-X6\xdd\x81\x06\x13\xa5\xe1\xdbb\x8c\xdfr\x0b\xaf\x95\x9c\xf0i\xa1\x99U\x1ar\xa6\x0d\xea\x93\x93j\xebX#\xb3\x08BM\xb5\xb2\xf4\x8d0\x89\xee\x17'\x00\xb1\xca\x17~g\xa3\xe3\x11\xa0\x8d\xc3\x1ap\x90\x84\xcd\xf6\x0e&AcG\x10\xee\x81R\xf7\x12\xf5\x08\xb4R\xd6\xfd\x9ejU\xe4\xad\xdf\x99Jp\x04\xc3\xeb\xab\xab\x16\x93*_\x80\xc4{hh\x816\x0b#\xd4\x14PZ\xbd\xd8\xcck	2H\xc2\xcba\xd0\xa0\x0eb%'\xab\x1c\xef\x85m\xf1\x05 \x95\xe5\x93\xc5\x084\n\xc5\x12`2\x01\x8d\xc62m+\xbe\x1a\xe6\xdfHSh\xf4rB\x8fH\xf7\x00?scI\x1f^z\xb7\x81\x87\xa6u/\x05\xa9o\x04\xb9F\x83\xd2\xae\x91+\x0c\xea5j\xf4\xf0(b\xcc\x18\xd4\xd6k\xa0\xa5\xdb\x9a\xe0\x84\x0b\xf4\x04sf\xd3\x11\xf4V {m\xea	\xd7\x18[\xe5\xac\xb1\xd5\xce5K\x95>_\\\xedc\x87|{\xc2\xa7G\xb0Ua\x1c\xcf\xdeN7\xdc\xc2\xde\x98\xcb#X\x1bsy<[[\xb46\x16*\x9e\x8dNN\x00\x02\xc0\xf8\"\xca\xd0\xb2\x84Y\x16MXlM\xb9\xb0\xce;\xbb7a\xc3q\x9b\xe7.\xd7\x04\xd7+\x9fo\xe4v\x9d\xdfu\x8ek\x9eon\x86m\x86\x8a<\xa1t\xf3\xeao\x1f \x16\xaaH \xae\xd3\x14W\x12\xee\xb9M\xdb\xe1\x1e\x8b\xc2X\xd4\x94\xf7\x1cY\x8bY.\x88\xa5\x8a\xbf*\xe8\x89iv\xef\x03w\xf0\xd3E\xb9\xdc\x8a\xf46\xc0\xf1R\\_9)\xeeS\x94#\xcfx\x94k5\xe7	\xea\x08%\x1b\x0b\xf4\xe9\xa0\xbb\x04ww\xd0\xc7\xf8\xa2\xdf\xb2\x1a\xc0S\xf8\x98r\x03\xf7\xcc\x80)\xf2\\\x19L\xc0*J.0F\x90\x18\xa31L/\x80\x19\xc8It\x02\xfe\xf6\x16\xbe\xff\xc1\xe1\xa6\xd6\xe6f\x14\x86Sn\xd3b<\x88U\xd6v\xb3\xd6\xd7\xbc\x10\"|q{q9tx\xff\xf3\xfeoo\xfe\xfa\xe6\x87'O\x9e<\x81o\xac\xdb]I\xb1\x80\xbc0)&\xc0\xa5U\xf0\xdd\xab\x0f\x1f\xdf\xfc\xe0\x04!n\xce\x07\xe7CP\x9a>\xcf\x1d\x8d\x0f\xfe\xef\xfd\x86\xbfF\xae\x9cc\x8c\xa0&\x10\xab\x04!+\x8c%/Z@!-\x17^\x1an`\xaa$\xc2\x08\xfecn\x06\xf3\xec/\x7f\xf9\xe6\xab3@\xad\xe1\x0e\xfe\x1b\xed_\xbf\xa3\x07\xcf\x9e;\x9a_k\x95A\x8a\x1aG\x87\x8b>\x16j\x1cj\x14\xc8\x0c\x06\xc4}\x98\xcf\xa6\xa1\xb3Me\x9a\xb0\xfab\xc2\xb9\xc9\x89~\xf59\x98\xaa\xa7o/\xbc\xd7>\x85\xf7o\xbf\x82\xd7\xef\xbf\xfb\xee\xcd\xbb\x8f\x1f\xdc\x03.\xe1|\xf0rp\x05s\x07\x0d\xf3\xccy\xb6SZQ\xf0\x04\x98F\x90\n\x84\x92S,\x0f\xde\x04&$\x85wu\x17wg\x8e\x96\x83\xe7\x06\x98\xb8g\x0b\x03B\xa9\x19&P\xe40\xe7\x0cB\xb30a,\x981a\x92\xf1\x90'\xc4rR\xc462\xa89\x13\x8e\xc2}\xca\xe3\xd4+y\x8c\xa0\x91%\xe4\x8b0^\x00\xcb9\x18\xd4s\xd4gPV	\x025dL\xb2)\x9a3\xc8\x951|,\x16\xce\xd9\x05Z/\xae\xc6\x9f\x0b\xae1\x81{\xa5gL\xabB&\xae4 \x91?\x9f\x81\x1a\x1b%\xd0\xa2\xd3\xc2\x97_~	\xcf\x8c\xe5B\xd0O\xf2\xa7\x89s\x95\xd2nJ\x03\x97\x13\xa53\x17\xd9gN:.\xa7\xc0,\x14y\xac2\xfa\xfem\xeb`\xf7\xe6\x82\xadT\xbd\xcal\x8aP\x1a\xaaL u\xb0\xdd\x13N\xa3y\x16S \x1d\xa6F\xa5+\xc9\xc1\xa6\xca \x99\x8dehQ\x1bx6\xcf\x022\xd4\x19\xcc\xb3\x80L\xfd\x9c\xa2u\x8c\xd5\xc6\xc9qa\x19\xab,\xe36d\xd7/o\xc7\xf1\xcd%\x8e\x87\xd7\xd7\xe7\xc9\xed\xcd\xcb\xdb\xcb\x8b\x84]\xde\xdeNp\xcc\xae^N&\xb7\xb77WC\xe6\x88S\xbc\x92pS\xb4\xa4\x80\xcc\xbb\x13\xa9\xe2\x9dJ\xf0\x1b9Q\xa0\xc6?al\x8fc\xc5\x85I\xc6(\xc1\x1e\x14 R%\xe8\xfdG\xfb \xb9>o9!\x97`\x0b-\x89I\x03\xb9\xca\x0b\xca\xd3\xde\xf3\x7f_\xb6n^R0\xfe\xb6{\x96\x9fQa\xb9p\x9b\xbe\xb8\xbah\x9dr\xaer\xed\xbbs\xc7f9\x05\xbc\x19$\xe4\x89\x16\xb3$\xf0\x8e\xe7N\xa2\xfe\xeeSm7\xf2\xc6sn7\xca\xca\xa96\x1c\xee;\n\xeb'e\xed[\xae\x06U\x07\xe1k\xe0L\xcd\x11\xaa\xed*\x0e\x8e\xce(\x7f$\x94?\x12\xca\x1f	\xe5\x17$\x14\xf2\xa6_\x98Nj\xd4C\x93I\x8d\xf0{\xa7\x92\x0d\xaaXo\xfd\xea\"\xee\x00m\xec\xc1\xde\xa8\x90=8\xabM\xc3\xd5A:\xf9\x85\x8dE\xb9q\x7f\xadS\xcd\x98\xe4\x134\xb6n\xdbx\xd5\xdco\xeaT\x1f\x1e\x80[\xcc`\xb9<\xbeA]\xed\x9bo^\xbc a\xb8M#\xa2i\xfcF\x01\x84F\xcfW'BkO\xc3\x8a\xef\xbd\xeb!+\x12n\xf7B\xe5\x9a+\xcd\xed\"p\xd9z/\xb8F\xa3\n\x1dc\xf0s\xa1,k\xb4j\xd0\x169\x14\x92\xff\\ \xa0\x8c\xf5\"w\xbdj\xce\x8c\xb9W\x9a\x12\x83IQ\x88\x91\xab\xe7\xa7(\xe1\x11\xac\x86 \x81~\xd0\x87GH\x91%\x10\xc4py\x01\x8f0f\x06\xaf\xc9-4N9e\x87Q\x8bdT\x93\xec\xce\xc0r%x\xbcp\xa63tV\xecW\xcc\xcaX\xac\xb7\x1b!l|`\xb0`\x99\xf0\x8e\xe0\xdd\xbe\xb7g\xb3\xcd\xb8\x07\xfa\x8b\x8f\x90u\x7fq\x94\x03/\xf6\xfa f\xcet(\xd4\xd4\xef\xbf}\x06\xd3\x85\xdb\xeb\xdb\xb3\xaa\xe9\xdf2|\xb9\x19\x0e\xb7\xb0\xc2\xc7a\xd9D\xedc\xa6\x81\xdc\xcb\xce>\xd5Q\xa8u\xbd\xa4\xff\xf0\xe0\x98\x8e|f\x8a\x88\x05\xd7\x9a.\x97\x94	\xbby\xd0\xb9\xc5\nO\xee\xd3#W\xc9\xaf\xf4\x81\xed\x94{]s\x0e\xf7\x8aP\xf3\xdc\xfb\x81\xe2\xe2\xe1\x01b\xd46J\xb8\x86\xe5\x92\x9c\x89\xa0\xa2\x98E\xb1\xb6\xab\x1b\x95a\xd6\x8b\x99=\x1c\xb1\x0e\xba^'\xea\x08\x99Ox\xcc,F\xac\xb0\xa9\xcb\x14Q\xc2\xac\xaf\x90X\xecb<e\x86*\xc0\\\xe3\x9c\xab\xc2x\x8bQ\x08\xc6*\xcb\x99\xc6\x01\x98T\x15\"q\xe5\x1b3\x06&\x8c\x8b\x01\x05`\xca\xe4\x14\x93\xc8'v\xa9\xf6\xcbM?\xcd\x80%\x19\x97\x83\x19.\x0e\x12y\x1dg\x8b\xb4\x0e$\x9a\xe1\xbf\x8d\x84\xb1\xb6GK\xe8qvJ\xe8\xb1\x7f{\x11_\xf9\xf8\xffg8\xa0\xb8h\"x\xfb1[\x82\xee8c[y\x06\x80\x021\xc3\xc8\x8d\xf6W\xcf\xe8\xf5|YGZ\xe0`\x994|,<\xb6\xc3\xa9\xf9~\xcb\xe5\x0c\x12\x9c\xb0BXh\xc2\xbd\xc3\xb0K\x0d\xab\xf9*\xec\x00\xd7\x89\xa1\x94\xaa,\x86:\xc2	.gm\xb9\xae~K\xb9>\xb09B\xcd\xde\x01\xa9\x8e\nM\x8b\xc1\xb6\x8c\xb7&\xfe\x1a\xfc\xaeDW\x1f\x1d\x13\xa5c\x1c\xc1\xa2,y\xda\xc3\xe4\x95\x93\xa4!\x0c9\xea\x8c\x1b\xc3\x95<\xe4 Y5L\xa9}B;\x88\xc5=<\x05\xee\xf0\x1c\x08u\x18_\xd5i\x1bvq\xdb\x9cYU\xc4\xe9Q\xac]\x0f\xeb\n\xb9\xd7\xf7=U\x9f\xbaC\x07\x1f\x11\xcb\xa6\xb7r\x166\xf5J{\xeaj6\xfbFoKe\xdf\xaal~\xba\xe8\xd6D{1V\xce\xc5\xab\xbd\xe7\xe2\xaa\xfb\x07\xfe\x9aO\xa0u\x9dE\xcf?2q\x8aI!P\xb7\x1fR\xeasv)\xe7\xbc\x83x2-\x97\x9a\xda2(\xdd\xbc\xaa\xd1hq\x8e\xd2jfQ\xf0\x8c\xdbz\xa5\xc9\xa9\xad3\xdf\xec\xbe\xfe\xac\xaa\x9au+PUdP\xcfy\x8c[\xb4_\x18\xef\xd0e\xd3W~V\xfe=(\x91\xd7Lp\x18\xda\x06;t\xe5\x13h\xa3B\xba\xfa\xf00\xf9\xea{\x9cMwl{dH\xc2\xaa\xb5\x85\x89\x12\xd4\xc8\x1dp\x1f\xb7\x97\xe6\xd6\xcb\xba\xd5\xae\xb9\xb6G\xc9\x83\x9a\xa3\xd6<\xc1&[ll\x96{\x07qQ\x11\xab\x9a\xe7\x8a\xabv\xc8\xec%\xb2\xea\xf2\x9b\xacUm\xe4\x96\x0f\xb1Y\xd3^\xbb\xcb\xc4\x88O\x18m\x86\xb4'p\x03\x19\xb3q\xfa\xac7^\xc8\xc1\x17\xbd\xe7d\xe3\x0c-\x1b\xc1D\x14&\x8dR&\x13\x81\xda\xd4d\xda^3(K\x04(_\x9fh\xf3\xb7\xbaVER\xf5|\xef\x0ds\xddi\x1dq\xcf\\\xe3\xec/4\x0e\xc9J\x9d\xdb\xe6\xa7TEI\xf4\xd7\x95	\xe6B-\xdc\xcc0W\x89\x01\xc3e\x8c\x8d\x87\xd5\x05U9\xa5u\xe8\x13\xa5\xcb\x9a\xca\xb8\xe9^\x8a\xd0\xc8\xe8\x03b\xef\xa0\xa7i?\x9bd\xbbc\xd0\xb3\x9a\xdb7\xa1\xbb\xcc\xb7\xc5c\x0fA\xad\xf0\x8e\x1c\xfb\xd0\xef\xd5\x92\xc7]\xe9\xdb8\xa9\x7f\xf8s4\xe7\xfe*\xaf\xfb\xb8\xb9\xd6\x0b\xcaqew\xbd>,\xbc\xf1\xbc\x85H\xe9\x95\x95r\xd4\\%<fB, N1\x9e\x19g#\xf2\x1a\x1e{\xbb*	\\Z\xd4s&\x8c\x9f\x98S\x8cQ\xb0\x19?:\xe6\x12\x18h&\x13\x95\x81\xc1\x9f\x0b\xa4]\xc6\x85\xcb\xfa\x9c(\x9f\x01\xb7`\xd9\x0c\x0d\x18\x95!X\x9e\xf9\xf7\x8d\x1c\xba/\xc3\x11\x8a\xbce\xfa{\xc6m\xfdJRs\x8e\x93\xb4\xe0^ur\xbf\x18\x97\xa8M\x97B\xd3O$*\x9e\xa1\x86\xdc\xc0#\xb0\xfb\x19\xf4\xff\xf4\xeek\xf8'\x84\xff\x98\xdd\x9a\xe8\x19)\xf9\xb1\xab\xdc\xc7-J}\xec*\xf3y4\xf8\"\x84\x87\xdc\xbd\x8f\xf5\xa7w_/\xfb\xe5\x06\xc1\xd7\xfd\xa8\x0f\xfdj\xe5\xc2-\x18\xa5-<\xba\xe9\x91\x1f\x0b\xf5?\xc9>\xf4\xcf\xfak\xd9\xed\xd6D\x9e\xe5(\xf7\xe7\x81\xbb0\xa7\x92\x87x=\xeb\xf2z\xb6\x85\xd7\xb3.\xafg\xaeN\xea\x90\x1e\x18\x9b\xa8\xc2V\xdb[\xcd\xd1\x8c\xe0r\xe8\xee\xa3M\xe1\\\xa1\x8aqg\x066V\x85\x85K\xc8\xb8,\x9aw\xbe\x04[\x8c\xe0\xbc\xf3jGm4\xa1b&\x9c\xe9\xa0f\xb8\xbc\xe2`s\xc6\x05\x1b\x97\xa7Mi\xaa\xc7\xd2m\xe3B\x0b\x08L=dwtRe\xec\xa8\x9a?\xb0\x9cG\xc6\x88(W\xbe\x15\xfcTb\x02\x04A\xcc\xa8\xf9;b.\xd0A\xde\x8c\xba\xa1\xfblcQ\xcb}HS\xde\xd5\xf5\xb5\xd3u\x15\x83\xee\x1e\x86\xa2\xa3\x15\x1cV\xb9\x00s\xb1\xda\xc4\"t\xe7\xa3\x8d\x0d\xba\x8e\xe4\xb4\x16\xd5\x8a\x8fJ\x95s\xc1\xed\xa2\xebWj\xe6\xdcc\x17B\xc7[~}C\xbd\xa9\xa5&\xba\xef\xde\x7f|3r\xe2\x16\x9aS\x1e-\x04B\xa2\xd0\xc8\xbe\x05\xd2Ra<!\x02y\xfd\xaa=\xbcq)\x94<\xf4\xa7\"\xcb\xb9\x9c\x82M\xb5*\xa6)dJ#\xa4J\xb9h\xea\xf8\xa8\x7f\x13e\xc5?\xb99\xcc9\x1fN\x81O|\x1e7\x7f\xaf\xba\x91\x1f\x1f\x05\xca\xa9M\xe1\xee\x0e\xce]\x82\xdc\xd2\xa7\xc0\xe9\xf2`\xef~8\x05\x14|\x02\xcf\xb8\x89R\x16U\xafB=\xc2X)\xf1\x1cN\x97\x15\xde\x9c\xe7\x1e\xd7K\xd2`\xae\\\x1a<\xb9\x83>\xbb7\xfd\x16\x13\x0f\x0f@,\xcc\x99\xae\x85\x19\x0e\x87\xfd\x1f\xff\xce\x92D\xa31\x11\x97\xd4$(\xbd\x88&\x1cE\xf2#,\x97;\xf95x\x08\xf5~\xae\xf9\x9cY\x8cx\xde\xdfGR&|\x02\xa7\xcb0E&l\xfa\xbf\x7f\xc4\xfc\x86\x98?\"\xda\xff\x1f\xe3|\xb5\xdc\xddz\xb1\xd0y\xaby_\x8bt\x18\x95Cz$\x8d\x92\xc2D*\x19\x94\x16\xe8\xd6\x1c][l.7\x0f\xe3\xa6\xaa \x07\xa5_n\xab@\x7f\x055O\xaaU_\xba	Z\x89\x179\xbc\xa8)\xbe\xab\x81\x857\x97#V\xde>v\x0c\x97\xe7T\x1d\xec\xd3O;}Ve\x18~\xc6\xb8\xf6\x7f\xfa\x8c\xad\xa0h\xaa\x87Ww;\x06[\xe5\xc6\xc1\xe4P\x9f	W\xab+G \x92JF\x9e\xf3h\xb3\"\xda\x11s \xca@\xc7\x94\xf5\x87\xddx/#\xb4\x8c\xd7\x17m\xdd\xd6	\x95R\xa3\xb3\xd8\xdd\x1d\xb4\xb2c[\xe1\x16\x8d\x859\x13<\xe1v\x01j\x02\xb3\x95a\xdf\xbfR\xc9S\xb4 UR\xbd\xde\xddm\xbc\xcb\x9b\x9f\x8a\x97\xb6\xa26,\x1f\xa6\x94c\xa6z\xbdz\x80A2\x99X\xf3\xdc\xba\xabP7\x06\xa2\xa3\xb4z\xc5z\xe5\xea\xb3\xb3\x1e\x96\x04N\x9a \x0b\xb7\x03\x1c8\x9f\xbcy1\\\xe5\x93*\xf6_\xc5gI`;\x9fm\x80#\xf8<B\xe3\x06-\x8c\x99I\x81	\xce\x8c+\xfc\x05\x97\xc8\xe5\xfa\x0c\x82v\x0e\x07\x04\xacc\xef<\x049\x82\xbeC\x85*=\xf9\x83\xf1\xae\x9a\xfa\x0f\xe6L\x14\xeej\xcf\xf7\xef\xfe5\x90fZ~\xc0\xb44\xe1\xb1\xf5\xac\xccF~^\x1a\xdb2\xf3\xcd\xa6y\xf3\xc8\xf9\xb6kg\x83\x80	\xe1\xde\xba29\x8b+_\x9fM\xcd\n\xb0\x99\xc7\xdb`[[A \xeff\xae\xe3r\x03-\x0f\xc0sK\x99\xd3\x04\x92Q\x12\xaf~B`A2\x0b\x81|\xdb[}\x8b\xdf\xa0u/\xefWg	Y7B\x99\xe4\x8aK\xebR\xf7\xa9\xeb\xac(e\xd4\x16k\x95\x9f\x10\xb4\x8a.W\xa0vkF\xaaH}>\x8f\x1ci_\xf2\xe7Z}^\xc0\xe9\xf2\xfc\xe2f0\x1c\x0c\x07\xe7T\x86\xb9u7@\xe3r\xeaA\"\x96\xcc\xa9\xe06\xe8\xea\xb2Gjo\xff\x13\x84R\xf9\x80\xcb\x04?\x0f\xb7\x97\x9c\xd5\x0b\xf0\xbeZ\xad\x8bA\xfa\xb2\xa1\n\xbc\xb8\xbcy\xd9T\x91\xeb\x08\xbb\x8a\xd2\n\xb7,\x17\xbd\x16\xa4\xb2\x9eO\xc1\x8c\x85\xd3\xe5Y\x07\"\xa0\xef\xa4\xd6\xd3e\xaf\x9du\xfb\xe4\xfa\xdeu\xcb0\x0bj[83\x90\x0b\xf6k?oG\xc4\xae\x98X\x89\x8a5\xd2w\xbd7\x1f_\x7f\xf5\xfa\xe3\xdb\xe8\xd5\xf7\xdf\xdc]n\xfa\x87\x93*\xec!h\xa1UFkx\\.\xcb\xf2\xf6\xeeSo[\xadJ(e}\xfb\xa9\xe7\xeb\xda}\xd0>~?\xf5\xcax]\x8f\xd8}\x13\xaf\xdd:\xf6c\x83\x7f\xb9~\x1d\xd9_\xaa\xdb&4\xc8\xbf~\x1f\xadrI\xe51\x9b\xb8\xb3\xb7\xff\x8f-\xdeR\xd6	\xeb\n\xf2\xe8c\x9c(\x8d;\xf1;:{J\xe1\xd2x\x90/\xdf\xcf\xa8\x1b\x91\x90\xb9N\xa5\xd0\xae\xf4/\xff\x81\x87\xda\x94\x06\xbc\x1c\xe6\xaeG\x8b\xdb\xbc\xbf\xd3t\x87\x9dI\\\xc6\xa2H0\xb2\xcc\xcc\xcc\x08,\xe3\xd2\x06L&\x81`c\x14\x83EFG!\x85\xba\xafs\xab\x04\xc9\x84p\xa9\xa5W\xaeVE\x9cW\x96{2gz\x04\x8e\x8a\xab\xc8N\x8e(\xd4*g\xfe\xf3\xca]\xe0\xc1\xff\xc9\xb9\x1do\xef\xbfr\xfe_\x00\x00\x00\xff\xffPK\x07\x08\x96:\xb0IQ\x0e\x00\x00E:\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/kubernetes/control-plane/tasks/taint-and-label.ymlUT\x05\x00\x01\xf0b\xe7[\xb4\x92\xdd\x8e\x9b0\x10\x85\xef\xf3\x14GH\x11\xed\x05\xcb^#\xd1\x17YEh\x82\x87`\xadc\xb7\xf6@Z\xa5\xbc{e\x9bn\x8a\xda\xa4?R\xaf0\x9e\xf193\xf3\x0dP\xc1\xd2\x99\x1b\x14\xd3GE\xc2\xb0N1\x0c\x1d\xd9\x84b\x07\x00adc\x1a\x14\xca\xf5\xaf\xec\xc1\x9f\xb9\xc7\xebtd\xc3\x92\xbe\xbd\x18TU:9;\xe8S[\xcf\xe4k\xa3\x8f\xf5\x9aU\xdfbY8y\x04\\\xaf\x18]\x90\x99|xI\xf7]\xfc=\xbc\x94\xf1\x13\x8b*\x0fX\x96\x98\xa6\x85\xcf\xf1\x88\xeb\x1ez\x80\x9b\xd9_\xbc\x16\xeeV\x87.\xd7\x8b\xfd\x82\xaaz\x8b\xc6l\xb6J\x0f\xd8/\xb9\x95\x8b\x96\xb1\x8bb\xa1Aq\xd7~+Z\x1e\xf0\x153\x19\xad\xbe\xbb,\xab\x9a\xe7\x93\x0e\xc2\xbe\xc9]uy\x80!\xc5\x06\xd2\x86Uw\x19\xd96(6\xf1'\xdf\xe3\x03\x9eAV\xa1$\xe3\x99\xd4\x17\x8c\x14@\xd1f\xe2\x12\xd6	\xb4\xdd\xaa>\x05Q\xec}v\xeeG\xb2\xa7\x07\xf2m\x8b\xe7b\xb7\xbb\x03WH[\xf9\x1fp\x93\xf0?\xc3\xbd\xc76\x97\xfb[\xb67\x1a\xe9\xc1\x03\x1a\x9b\xf8\x86F\x1c\xfc\xe0&\xab\xde\x18ls3\x83\x95\x9c\xc00\x05\x81\xb3\xebH\xb1\x0eY\x07x\xfe4i\xcf\x7f\xa2\x13w\x12\x81\xce\x0c\x1e\x06\xee\xe5\xe1\x93_\xe2\xff\xa9\x9f\x8c\xff\xef\x17>O\xfa\x87\x85\xcf\x17\xef&\x9b\x0f\xad\xf8\x89\xdf\xa7\xfd\xff\x16\x00\x00\xff\xffPK\x07\x08\x85|\x8f+l\x01\x00\x008\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00<\x00	\x00roles/kubernetes/control-plane/templates/etc/aws/aws.conf.j2UT\x05\x00\x01\xf0b\xe7[\x8av\xcf\xc9OJ\xcc\x89\xe5\x8a\xca\xcfKU\xb0U\xa8\xaeVH\xcc+\xceL\xcaI\x8dOM6\x8a/\xc8ILN\xcdM\xcd+\x89O,K\xcc\xccIL\xca\xcc\xc9,\xa9\x8c\xaf\x02)\xae\xad\xe5\xf2.MJ-\xcaK-I-v\xce)-.I-\nIL\xb7\xcd\x86\x0b\xeae\xe6\xeb'C$\xf4\xab\xab\x15\xa0\xcc\xf8\xbc\xc4\\\xb0n@\x00\x00\x00\xff\xffPK\x07\x08U@N\xe1m\x00\x00\x00|\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/admissioncontrol.cfg.j2UT\x05\x00\x01\xf0b\xe7[|\x90\xc1N\xc30\x0c\x86\xef}\n\xab\xf7\xb6\xda\x0d\xe5\x86&\x0eH\x08\xc1\x90\xb8\x9b\xd4\xac\xd6\xd2$\xd8n\xa5\xbd=J\xb7\xb2\xa1!\xae\xf6\x97\xcf\xff\x9f\x03\xc7\xde\xc1}?\xb2*\xa7\xb8M\xf1\x93\xf7\x93\xa0q\x8a\x15f~')s\x07\x98YIf\x92\xf6p\xa7-\xa7n\xde`\xc8\x03n\xaa\x1c\xa6=GuU\x03\x11GrP?\xcc\x14m\x87FO<\xb2\xd5\x15@F\x1b\x1ctd\xbe;L\x1f$\x91\x8c\xb4\xf3\xcb5\xed\xa8\xf0\x82F\xa1\xf0\xed\x11\xc7p\x91\xedH\xd3$\x9e^\xa7dX\\\xfe:\xa3\xab\x00\x00\xae\x83\xca\x99\xff*|\x8bk\xb3\x9b\xd8\xe5\xdd\xa9\xfd\xef\xd2e\xbe\xe4\xa0~=\xad\xa7+\xcd\x8f\xdbAN\xbd.\xc3\x11\xcd\x0fo>\xe5\x0b\x952	Z\x12p\xf0x\xf2\x01h!\x9e\x97F/\xc2I\xd8\x8e\xdb\x80\xaa\xe7\xf5\x8caZ\x05EQ\xebQ\x8d\xc6&\xa6\x9e\x1a/l\xec1\xd47k\x1f&5\x92\xbf\x88\xf2\xcd\xcd\x8a\xfd\xbf\x1ex?\xd4\xd5w\x00\x00\x00\xff\xffPK\x07\x08\xff\x95\xe8Q\x0b\x01\x00\x00\n\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Y\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/encryption-config.yaml.j2UT\x05\x00\x01\xf0b\xe7[T\x8eA\xca\xc2@\x0c\x85\xf7s\x8a\\\xa0?t\x9b\xed\x8fWp+\xe3L\x94PL\x86$\xad\x94\xd2\xbbK\xb5T\xcc\xee\xfb\xf2\xe0\xbd\x81\xa5\"\x9c\xa4\xd8\xdc\x82U\xfeUn|O\xb9\xf1\x99\xccY\x05a\xea\x93\x91\xebh\x85\x1c\x13@\x07?\xb8	\xa7b\x14\xfe\xa6f:q%;\x9e\\I\x82cFX\xd6]e\xf2r-\x9f\xc0v\x03\xcd\xfe\xa5\x0e$?\x087\xdb\x1f\x12\xf6\x12\x84e\x01:\xf6^Zv\x7f\xaa\xd5?\x8f\xaac\xc0\xba\xa6W\x00\x00\x00\xff\xffPK\x07\x08\xef\xdd\xfa9\x8b\x00\x00\x00\xd4\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/eventratelimit.yaml.j2UT\x05\x00\x01\xf0b\xe7[L\xcbM\n\xc2@\x0c@\xe1\xfd\x9c\"\x17\xb0\xce\x08\x05\xc9\xd6\xbd\x1b\xd1}l\xa3\x0d\xed\xfc8I\x0bzz\xa9\xa0\xb8|<\xbeQR\x8fp\xc8\xe9&\xf7\xb9\x92IN\x8e\x8a\\\xb8\xaa\xe4\x84\xc0\x0b'\xabd<I\x14k\xa8\x8f\xa2\xebi\xc6\xbd6\x92\xb7K\xa0\xa9\x0c\x14\xdc\xe7+\xba\x0d\xd8\xb30\xc2\x91\"k\xa1\x8e\x1d\xc0\xa3(B\xeb\x1d\xc0u\xaej\x08\xc1\xaf\xd1Q7\xf0I^\x8c\xb0\xf3\xde\xff\xe8Y\xb9~U\xf8S\xadw\xef\x00\x00\x00\xff\xffPK\x07\x08\x0d\xc77:\x88\x00\x00\x00\xaf\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/kubelet.conf.j2UT\x05\x00\x01\xf0b\xe7[\x94SMo\xe36\x10\xbd\xebW\x0ctJ\x80\xc6vR\x04hu\x0b\\\xb4\x0d\x92\xb6j\xe2\xec\x1e\x8919\xb2	\xf3\xc3 G^\xdb\xb2\xff\xfb\x82\x94b\xc0\xc1&\xc8\x9e\x04q\xde{\xf38\xf3\xb8\xd2NU\xf0\xd0\xce\xc9\x10O\xbdk\xf4\xa2\x0d\xc8\xda\xbb\x02\xd7\xfa\x0b\x85\xa8\xbd\xab`\xd5\x03F2#F\xab\xdf\xe2H\xfb\xf1\xe6zN\x8c\xd7\x05\xb6\xbc$\xc7ZfbU\x00lo'\xbf\xa7/\x804\x9a\x1cO\xef\xfe\xd4\x86*(\xbb\x0e$\x05\x16J\x078\x1e\xc7]\x07\xc1{\x16\x12\x85\x0c,\x1am\xc8\xa1%8\x1e\xcb\x02\xe0\x1b\xcd\x97\xde\xafz!r87\xa4*\xe0\xd0R/\x8drI\xb3\xd9c\x057v\x12\x0b\x00t\xde\xed\xaco\xe3\x1bF\x83&Rv\xe9\x83\xde\x9fLZ\xaf\xa8\x82\xaf}\x93\xb7\xed\xb2\xf8\xdd@!\x95\xdb\xdc\xf6m\x86\xe2\x8b\xc3\xf3\xf2\xaf\x93XH\xd3F\xa6\xf0\xc7\xbf\xcfU\x01W\xd0uytb8\x16\xcaE8\x1eO(oQ\xbb\n\x86\xdf\x91\xf1\x12MA\x1br\xfcD\xd2\x07\xf5\x7f\xfd\\\xc1\xa4\x90\xde1jG\xe1\xd1/\xfe\xc1m\x1ae\xac\x92\xf6\xa9 \x8c_\x08\x8b\xdb<\xc1\xbe\xc59\xe7Y\xef\xe9\x1dJ\xd4\xfb4\xf0\xa2!\xe46\xd0_\xc8\x14\xab\xa2\xeb`8\x10\x8bt\x02\x07`/\x9c\x96$vh\x0d\x1c@;E\x8e/n~\xc9\x1b\xb9L\x12\x16\xb7\xb5W\xbd\xb7!2\xb9\xc5\xda\xab\xd8\xd7Wt_\xcf\xd2Z\xe2\x0bk3]\xa2vqXi T\xff9\xb3\xab}\xe0t\xeb\xe0\x19\x99\xa6\x14X7)Z\xe9\xce\xfd&#\x07B\xab\xddb\xea\x9d#\x99\xf6y\xaf\x0c\xcd\xb4%\xdfr\x05eZTYDF\xd6\xb2\xf6\xaaF^VP\x8e\x89\xe58\xd9\n\x8e\x98\xe2\xd8\xa2\xd3\x0dE\x8ee\xc1&\xa6F\xef\x864\xfd\xc6\xd1\xe9\x15\x04\xce	e\x13\xeb\xa07\xc8\xf4@\xbbOrW\xb4\xcb\xdc\xb8\x8bL\xf6\x89\"\x85\x0d\xa9\x949\xb9n{\xfe\xeb\xe4z\x88\x08\x03f$\xd7\xed\xf00,Y\x1fv\x1f\xa3{\xcc@\xa0\xf5\x92,\x054W\x91}\xc0\x05}\xcc=\xc1\xc5\x00\xcf2	\xfd\xa1\xe1\x1c\xf5O\xda=\xc7\xfe\x8c\xd9s\xe6\x8f\xad\xd2F\xe7X\xfc\x8d![\xed\xf5G\xb8AmR\xfa\xce\x15_\xd1b\x89\xe1\xd5\x8b8a\x07W\xce+j\xe2\xe7$z\xec\x1b\x89HA\xa3\xd1{\xba\xb7\xb8\xa0\xba5\xe6\xfc\xa1\x9c\xeaB'\x80X'\x04\x1c@Q\x83\xad\xe1\x8b2\x87\xbf\xbc\x84\x03\xcc\xbd7\xe9=}\x0f\x00\x00\xff\xffPK\x07\x08\x17\x0fGf\x86\x02\x00\x00\xbe\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Q\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/scheduler.conf.j2UT\x05\x00\x01\xf0b\xe7[\x8c\x93Ak\xdc0\x10\x85\xef\xfa\x15CN-$\x8e7\x90RtK\xb3\xa5\x87\x96daC{\\d\xe9\xd9\x9e\xae<r%9e\xb3\xc9\x7f/\xb6\xb7\x9b\x84@)>\xe9\xd3\xc3~o\xe6y\xcb\xe24}\x1d*\xacm\x0b7x\xc4\xeb 57C4\x99\x83(\xd3\xf3w\xc4\xc4A4m\x87\n\xe9\xaf\xac\xb0\x93\xae\xd8~L\x05\x87\xf3\xfb\x85\xf1}k\x16\xca\xf8&D\xcem\xb7\x0eC\xb4\xd0\x8a\xa8\x8f\xe1\x9e\x1d\xa2\xa6%j3\xf8\xbc:\x00U\xb1\xb8;\xee\x10\x86\xbc\x86\x0d\xe2\x92\xa6\x0fe\xa9\xacgH\xbe\x0e\"\xb0\xa3\x8d\xf1-\xc6Z\xf4#\xcb\x90|\xb7\xeb\x914\x9d\x9c(\xa2j\x88)kZ\x94\xa5\"\xb2\xcf\xf7\x9aL\xdf{\xb6S\x90\xf3{q\xc5\x18 \n2R\xd1\xc7\x90C5\xd4\x8a\xa6Xs\x18M\xfb\xfdt\xdc\xcc\xe7M\xcd\x1eb:\xd0\xd3\x93\"\xfa\xd5'M\x97\xa5r\x9cL\xe5\xb1\x8a@\xd7O\xf6\xa86>AAF~\xb0\xc8AV1\xd4\xecY\x9a\xd7\x827\xb86\xec\x87\x88e\xe8\x0cK\x9a\x07}\xf0\xc9\xe1\xbc\x0d)\x8f&N\x0f\xb237\xe9\x8a\n\xd9\x14\xaf\xa5\x0fA\xfeG\x16\xd1\x8c\xbb\xada\xf2\x10\xf1\xc5d$\xad\xf6{:\x80M3\x12z\xa4\x1c6\xc2\x16\x9b\x9d\xe9<=\x12\x8b\x83\xe4w\x17\xa7\x94\xe3\x80\xf7\xe3PZ\x13\xdd*\xb8\xab\xbaf\xe1\xbc[\xef\xba\x0e9\xb2\xfd\x01n\xdaq)\xaa\x85\xf1\xb9}\xf8\xc4\xe2\xae\x9c\x8bHISYL\x8f^\x94\x17\x97\x0b\xe5a\x1c\xe2g\xff\xbc\xea\x17DO\xdf\x9aY\xc2\xf2PKM\x8b\xcb4\xc2`\xb7\xb7\xd5O\xd8|c:\xcc\x83;;V\xf4\x8d \xf5\xc6\x1eU\xbb\x94\xd1)\xa2\x08\xc1\xef%\x8c\xf3,\x18k\x94&\x98\xa6\xfa~\x0bv\xab	\xe2\xfa\xc0\x92\xe7\x9b\x1cw+D\x0eN\xd3ERs\xde\xf4\x8f|=\xa2\x85d\xd3\xe0\xb6\xbe	\x0e\xe9.\xacm\x88\x98\xbat4;\x07p\xf3\x0f\xf2\"\xc3\x9f\x00\x00\x00\xff\xffPK\x07\x08\xa4o\xdc \xfd\x01\x00\x00\xa2\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/vsphere.conf.j2UT\x05\x00\x01\xf0b\xe7[\x9c\x911\x8b\xe30\x10\x85{\xfd\x8a\xc1\"\xa5\x12\x8eK{\xdd\xc1u\xd7naLP\xe4q\",k\xc4\x8c\xec%\x84\xfc\xf7E\x89\x13\xb2\xd9,\x84-\xf5\xbe\xe77\xef\xe1\xfa_\xa0\xad\x0d\x8d\x12t\x8c\xd9D; \xfc\x81jr\x8ebW\xdd\xcb\x92\xac;\xb3~\xdc\xa2\x91\x83d\x1c*\x95\x88s\x11\xd7\xeb\xdf\x95\xf2Q\xd0\x8d\x8c\xa6\x0bvW\xd4_%\x81'\xe4\xf28\x1ea\x92\xb4G\xc6\xcd,\x9eN\x95jm\xb6\x0ec\xfe\xe2\xb9\x03W\x9fdb|b\xbb\xe8\xc5\xf5N\xdc\xfb\xb83\xad?\xc7\xad\xbe\xcb[M\xc3=\xeb(\xb4\xf3\x1d\xa5\xa1~#\xee/s\x9f\x97\x86Fi\xb8\x0d\x9b\xf9R\xec\x90\x02.\x1d\x0d\xa04\xbc\xbaKC\x8b\x9d\x1dC6\xaf-\xd40\x97\xfd\xd9<\x0d\xc7\x05\xf8\xee\x86\x18\x85Fv\xb8ID\x01\xbc\x946>b\x0b\x8b\x93\xd2p\xa5\x05\x9ad\xf3\xfe\xa1\xda\xe7\xafo\xf9\x18[\xdf\x95\x04U\xff\xf5\xd27J\x9cxG13\x85\x80\x9c\x0f\xa9lLS\x91\x95\xaa\xffc.\xff\xadQi\xdc\x06\xefL\xbc\xbc\x1fnE\xcc\xe7\x0b\x1f\x01\x00\x00\xff\xffPK\x07\x08\xf16\x8d\xa6#\x01\x00\x00\xb3\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00N\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/etcd.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcX}O\xf38\x12\xff\x9fO1[`Ku\x9b\xf2\xa6\xd3\xb2YuO\x08x\xee\x90\x9e\x07*\xe0V'UUd\x9c)\xb1p\xec\xac\xed\x14\xba\xd0\xef~\xb2\xe3\xbc\xb5)\xb0<\xa7\xfb\xab\xe9x\xe67\xaf\x1e\x8fM2\xf6;*\xcd\xa4\x08a~\xb8\xf5\xc8D\x1c\xc2X\xc6[)\x1a\x12\x13C\xc2-\x00AR\x0c\x01\x0d\x8d\xfd\x1f\x9d\x11\x8a!<\xe6\xf7\x18\xe8\x856\x98n\x01\x10!\xa4!\x86I\xa1\xad\x10\x80\xa6	\xc69G5$<K\xc8\xd0\xb2+\x81\x06\xf5\x90\xc9}\xaa\x98a\x94\xf0 \x93q\x08\xbd\x9e\x13\xc9\x94L\xd1$\x98;\x16M\x15\xc90\x84\x9eQ9v1dR\x99\x10zG\xc7'\x87v\x99\x93{\xe4^9\x95i&\x05\nS\x19\x0e`\x18\xaa\x10\xa8\x14FI\x1ed\x9c\x08\xdc\xd2\x19R+\x91Hm\xae\xd0<I\xf5\x18\x82\xd5\xb7e\x951\xa9\x98Y\x9cq\xa2\xf5\x95\x0bB\xe1m@y\xae\x0d\xaa\xa0tb\x0b\x1c,a\x02\x953 h\x07\x0d\x80\xa5\xe4\x01Cxy\x01*\x15F\n3\x19))\x0d,\x97\x96\x96+\x85\xc2D)\x11l\x86\xdaL\xfa1f(b\x14\x94\xa1\xeeO'\xfd\x96\xd5\x96`\x91\xed\xafV\xb4?\x85\xe5\xb2\xd62\xce9\x1fK\xce\xe8\"\x84\xcb\xd9\x954c\x85\x1a\x85q\x1c(\xe6E\x80J\x0b\xffy\xfd\xed\xf4?\xe3\x9b\xeb\xb3[G\x06\x98\x13\x9e\xe3\x17%\xd3\xd0\x13\x00\x14j\x99+\x8a_\x18\xf2\xf8\x06g\xf5J\xbd\x16\x02g)3zH\xb3\xbc\xa5\xe0\xe2\xee\xec\xfc\xec\xeekt:\xbelj\x08\xa1w\xdc\xdbz\xd9\x056\x83\x07%\xf3LO\xfa)\xb1Q\xedO_9\x8a\x07\x93\xc0h\x04\x87\xb0\xbbl\xc1]]\x9f_D\xff\xba\xbe\xbd[\x01\xe3\x92\x12n\xb3\xe8@\x91k\xfc\x90d\xdb\xd1Y\x87\x83\x8e6&&	A\x1bbr=\xb4Z.\xc7N\x8d\x88\xd9\xcc\xea)\xfc`:JH\xe4\x8b\x03\x88\x88\x01\x05\xb9\xe7\x18\xd9dE\xce\xc2(S\xf2y\xb1j\xda\xe9\xf9\xef\x177w\x97\xb7\x17\xd1\xf8\xfaf\xd5\xb3\x97\x17WF\x916D\x19&\x1e\n\x88\x88\xc4sT\x86i\x8c\xec6xe\xc2\xc0\xdf\\\x19\xcf\x89\xd2\x13&\xe6(\x8cT\x8b\xc8\x92\xac\x96\xe9\x84\xc4\xb1B\xad\xa3z\xcd\xf96\x1d\xea\x8c3\xb3\xd7\x0f\xfa\x83Ip8uP\x01\x1c\xc2r\xd9kYyyuywy\xfa5\xfa\x8c\xb5L0\xc3\x08\xff?Y\xbd\xa1\x02\xde4\xfc\xe8\xf8\xe7_>\xe1\xef\xd1\xf1\xc9A\xafU\x0bM\x88\xb3\xaf\xff\xbe\xbd\xbb\xb8\x89\xc6\x17\x177\xb7\x7f\xa1\xdak|\x1b\xcc\xa0\x10\xa4\\\xe6\xb1\x8d\xe7\x9c\xc5\xa8,\x7f\x0f\xe9Q\x0fvm\x0bYE\x9d\x1cL\xe1\x15\x14f\x9cP\xdc+\xc9?A\xbf?\xb0=\xa7\n\xd0\xcbK\x1d\xfe\x0e\x8c\xe9\xa4_\xa6\xa2o\x01\xb9|B\xe5\x01\xbc\xc3\xa3\xc4\x98L\x87\xfb\xfb\xd5\x16\x0c\xeb\xa0\xf0\xcfl\x0b\x80m\xb804\x86{&b\x0d\xe7W\xb7\xc5\xb1\x03F\x02\x11p9\x06)\xf8\x02\x88\x01Wey\x06O	\xa3	P\x92k\xd4\xc0\xb4\xceQ\xc3\x133	\xdc}\xbd\x85\xa7\x04\xad\x90\x06\x9a\x10\xf1\x80m\x15\xf8\x9c!5\x1aL\x82\x16\xf8\xf6\xf4J\x03\x13\xee/\xb5\x95:c\x94\x18\xb4\x9aSbh\xe2\x16\xacYL<T@&a\x1aR$\xc2\xc2\x10\x03O\x08	\x99;\xa1<\x8b\x9dx\x82\x85\xba\x06f%>\x1c\x0es\xcd\xc4C\xcd\xe5b\x02EL\x1e\xd0h J\xe6\".\x149\xf7Vw\xdd.\xcc\xa4r\x99\xb4\xd6\xaf&\x12\x82\xddeQH\x8dl\xdb\x8fM\xe9\xadrzx\xf4\xf3\xf0`x0<\x0c?\xb3\xb1\xb9\x94\xd9\x90\x89\x18\x9f\x0f\x8a\x9aa3\x10\xd2\x14tN\xb4\x81\xdd\xe5O\x8dRz\xd9\x0d\xec\xb7uewY\x17\xd0\xe6\xc2\xff\xbe0\xbc\xb1A\xaa\x00tD,SlN\x0cF,s'\xaf\xab\xf6\xcf\xf9V\xb7\xa8O\xfb\xf0\xf1T\xae\x0blj\xad\xdf\xe5T\xb3\x0dR\x99\xa6D\xc4\xe5\xc4\xa1\x13\xff\x11P\xffa=\xa1\x86G\xf3b\x08\x1d\xed\xecy\n$\xc8\xb3\xc1\xaf>6+lQq\x10\x8fv\xfeQ1\xd0DBog\x85\xaf\x07\xbf\xc1\xbe\xa7\x0d=mh\xd2\xac\x94b3\x98\xc0\xaa\x90\x07\x87@ \x1c\xc0\x14^_a\x02?@0sP\xfb\xf5\x14\xbb\x7f\xcf\xc4\xfep\x05\xbe\x16\xd8\xd9\x8b\xd9\xec\x83B]F\x0e`\xfa\xab\xed\x08\xa2\x9aEJC3b\x92\xd1\xce\x9eB\x12s&\x1e\xadi;{E\x07\xf4,\x83\x01\xfc\xf8c%\xe7t\xd2\xac\x11\x1f\x8b\xd0\x83^\x97m\x9e\xa5\xd7\x04x;\xba\xefyWF{\xc6\xca\xaf\xfd\\\xab\xe2\xb4\xa8Tn\xbdw\xc4\x95\xc6\x04\x81\xad\xf0Q\xb9\x05\xd6'\x8676\xf5\xfa\xa6k\xc1\xed\xecyG_\xec\x94xu\xfa\xedb\xd9\x83W0\n\xfa\x930\xcf2T\xe1\xb4o\xbf\xdd\xd6\n\xa7\xfd\xc1Z\xc1;D{o\nb\xa6Fe\xcb\xb4\x84(f\xaa\x1c\xd3\x1d\x17r\xa4\xf6\xb6\x14\x18\x96\xa2\xccM\xc5].D~\xa1%\x95 Q\xe6\x1e\x89	\x980\xa8\xe6\x84Wr\xd5RT.\xb5$S\xf2\x1c<\x11\xae+\xfe\x94<G\x96\xd0\xe2\xd2\x82d:\x91&\xa02\x17\xb5M%9r\xe4\x96\x04\xc9\x8d\x0c\xecu\x8b\x14\xee\xa42\xc6Q\x86\x8a\xc9\x98\xd1\xcdl\n\x0d\n\xfbU\xe9\xa8\x17\xa3j\xd1'\x8d\xcd\n\x96?riHtO\xe8#\x8a8\xba_\x18\xd4\xf0\x1b\x1c\xb4\xa2\xefx\x02\xcf\x138\x9eJE\x97\xbc/\x8b\xb5,r\xa6\x0d\x8a\x80r\x86\xc2\x04\xb9\xe2\xba\xea\xa4\x07\xeeH<\x08w\xf6\xda\xa3\xe1`M:CT\x9bd\xbb\xc7\xcb\xc1g\xef\x12E\x94\xcbS\xb8\xd3\xee\xfa0\x7f\xc3r\x7f\xa07\xa0\xd6\x9dh\x02\xbd\xe1\xc6\xda^{\xdb\xbc\x9d\xbd\xea\x8a6\xf8^\x0bW\xb0\xde2r-\xf3\xde6;\xaa\xd9\xb2MF\xfe=\xc0/\x1bes\x12\x07\x94\x043\xc6\xd1\x16\x97e\xf5\x1b|\xbf\xac5{\xbb\x8f(\x89\xa82\x91\xe5s\xbd\xa9\xb9q\x1c\xfe\x06\x04\xfbW\xbbF:\xa4\xaa\xbd\xdf\x1eq\xf1\x01\xa9G\\\xb4\xa4\\\x84\xdeq\xcc\xf1\xfc\xaf\xbc+\x14~\xceE'\xfb9?\xcb\xda(_h\xeca\x8e#\x81O\x9bY\x8c|\xc4\xba\x0b\x95\xf3\xac_\x8d\xdc\xea[*F;{\xad[\xde\xa0+\x06\x84\xdb3#\x0e\xa8pG\xcdZ\x9fH\xd1(Fu]\xc3\x8dNqt|r\xd8h\xe3\x05\xe7\x08\x9f\x0d\n\xcd\xe6U\xfe\xb6\xed\xc9\x83\xf7yq-)\x9fet\xf9\x94\xa1\xf0\x8f\x1c\xb5\xd1\xf5\xd3\x06\xcdr\xf7 U\xe4\xd3/G4\xcb\x1b\xdeB\x8a\xa9T\x8b\x0e\xbeba\xa5?\x17O@\x0eC\xaa\x16\xc9\xb3W\xdb\xac \x87\x1b\x84\xeb\xdd\xd8\xb6\xb2\xc1\xb2\xd2\xb6\xd7qV5\xae\xfb\xd2f\\\x07lu\x06{\xab\xd1\xe5 [=\xf6\x8d\xdd\x0b\xe4\xd1\xf1\xcf\xbfx-v\nY!v\xb0\x9f\x1ct\xb0{b\x17\xfba\x17{A\xe4l\x8e\x02\xb5\x1e+y\x8fer\xf1\xb9x\xd5\xf4!lN\xe1\x85\n7w\xf9q\xbc\xa0\x04H\x1b\xff\x1a\x8fv\xa3\xe3r\xaa\xb4C\x8b\x883\xc9\x84\xa9\x1b\xed\xa4:\x0b\xa6\xa1u\xd966bk\xfe\x93\x9d\xa3j\x8d\x1fi\x19E;\xfc+\x1d\x02\xc0^\xa8a&\xa5\xa7\xcc\x08\xe3\xb9\xc2\xbbD\xa1N$\x8fC8\xf1+~\x9f\x9f#'\x8b[\xa4R\xc4:\x84\xc3\xbf\xfbU?\x9c\xad.\xcc%\xcfS\xfcf\xa7\xa4\xaa\xba\xfd\xdb\xad{\xbauC\xdd\x9fR \xfc0\x82^o\xf5\xbd\xc8\xfc\x19P)f\xac|[H-P\xf1\xf8\xe8Fmw\xee[\x88\x8e\x17\xa7\x06\xef\xe6\xc1\xb3~\x96vcj\xa7h#\x96\xebR.\xb2UO!\xf1\xb5\xe0\x8b\xea\xc5|\x05\xab\xe3v\xd0\x02\xac\x97\x82z\xa9\x06\x9d\x11\xae\x0b\xd4\xeduX\xdb\xe0\xf8|h\xa3U\xb04\xac\x0c\x8a\xc5\x92\xdeaf\x07\xa0\xdd[z\x1d\xaaE^C*\xd2\xed\x9f\xfc\xdd\xee\xb4\x80\xde\x93\xec\x9d\\\x98E\x86!\x9c3\x85\xd4\xde`\xae\xd5\x99\xc2\xf2]h=Oo\xe0\xaf'\xec\x83\xd0e27aoN\xe0\xfb\n\xd6\x92\xbb\xdd\xd6\xb2\xbd\xa2\xa6\x9d\xd0\xedZ\xc9\x17\xc6qkS\x82\xdf\x01-\xb3\xf7\x0e\\\xc1\xf6\xc1\xdd\xba)X=w\xad\xd5	Q\xb8oe\x98\x98I\xd7\x8b:\x01\xcb\xc7\xf5\xd5}\xdf\xdc\xd7\xff\x0d\x00\x00\xff\xffPK\x07\x08\xf9\xfcv\x0e>\x08\x00\x00\x9e\x1b\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00X\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-apiserver.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcX\xdfo\xe3\xb8\x11~\xf7_1\xf5\xd5P\x02\xac\xec\xcb\x16\xc5\xb5\x02\xfcP\xec\x8f\xeb=\xdc\xc6\xd8\x04\x87\x02A 0\xd4\xc8bC\x91,\x87r\xe0\xf5\xfa\x7f/HI\x96\xe5\xc8vv\xb3\xb8\x00\x01\x8c\xe1\xcc7\x1f\xc9\x99oh3#\xfe@KB\xab\x04VW\xa3G\xa1\xb2\x04\x16:\x1b\x95\xe8X\xc6\x1cKF\x00\x8a\x95\x98\xc0c\xf5\x8013\x82\xd0\xae\xd06f2\x8c\xb7k\xb4&\x87\xe5\x08\x80)\xa5\x1dsB+\xf2\xe1\x00\xc4\x0b\xcc*\x89v\xca\xa4)\xd8\xd4\xbb[\x85\x0ei*\xf4\x8c[\xe1\x04g26:K`<\x0e!\xc6\xea\x12]\x81Up!n\x99\xc1\x04\xc6\xceV8\xe4`\xb4u	\x8c7\x9b@%eF\xa4D2\xf5f\xd8n}\x84d\x0f(\x1b>\\\x97F+Tn`W\x00N\xa0M\x80k\xe5\xac\x96\xb1\x91L\xe1\x88\x0cr\x1f[hr\x9f\xd0=i\xfb\x98\x80'3\xf2L\x84\xb6\xc2\xad\xdfIF\xf4)\x9cU}\x141\x97\x159\xb4q\xbb\xc3\x11\x04X&\x14\xda@%>v\xb6\x00\xa2dKL`\xb3\x01\xae-\xa6\x16\x8dN\xad\xd6~;\xdeVY\x8b\xca\xa5%S\"GrwQ\x86\x06U\x86\x8a\x0b\xa4\xe8\xfe.\xea\xf1\xf7\x86~\x0eo!\xcb\xa3{\xd8n\xbb|\x8bJ\xca\x85\x96\x82\xaf\x13\xf8-\xff\xa4\xdd\xc2\"\xa1r\xc1\x03\xd5\xaa>\xbe\x96\xf5\xaf\xd7\xbf\xff\xeb?\x8b\xcf\xd7\xefn\x82\x19`\xc5d\x85\x1f\xad.\x93\xc6\x00`\x91te9~\x14(\xb3\xcf\x98w+\xddZ\x02R\x94\xc2\xd1\x94\x9bj\xb4\x99\x80\xc8aiue\xe8.*\x99?\xc0\xe8\xfe\xabD\xb5t\x05\xcc\xe7p\x05\x93m\x8f\xc6\x87\xdbw\xef\xd3\x9b\x0f\x9f\xff\xf8\xf0\xb9G$\x81q\xe1\x9c\xa1d6\x93\x9a3\xe9\xef.y\xfb\xb7_\xfe9\xf6IP\x8a\x1c\xb8\xd4U\x96\x1a\xabW\"C\x0b\x7f\x99C\x84\xfcm\xf4\x0d\x196\x13\xc8\xb5\x0du\x01B=\xe3\x0d\xf1d\xdb\x92\xd8l\x82\xdb\x8aY\xba\xf3\x1f\xee\xefX\x96Y$J\x85Z\xa1r\xda\xae\xd3\xdc\x1f\x93\xbf\x93@\xb4>\x0b\xa5\x1dH\xad\xcdT2r0\xd9\xbe\xf1\xecU&r\x98l7\x93\xd8\x7f\xf6\x14&\xdbf_\x84\x7f\x16\xff\xc8X\xb1b\x0eSa\xa2\xd7\x91nVz\xac\xff}}s\x9b\xfe\xb68^[\xf9@M\x05\xdb\x82\xb9\"\x01r\xccU4\xf5T\x1b\x14\xae\xcb\x92\xa9,i\x8a\xec\xc75\xd1\x94\x8c\x14\xee\"\x9aE\x97w\xf1\xd5\xfd\x94\x1c\xb3\x8e\x9e\x84+.\xa2bm\xd0\xfa\xb8\xe8\xb2\xdb\xe1lg\x1d\xd8\xfe\x80\x1a\xc4\x10\xc7\x0fBeqS2\xf3\x9e\xd2!\xaf,\xa6~=m\xd6\xdb\xae\xf6q\xf5r\xec\xb5p~D w\xbe\xe8x\x16\xd7yi\xfe\xd7\x8b\xfd\xc2\xb9\xdc\x03\xb4+\xc1q'o\xc2\xc4\x96\xa9%\xee\xd0\x1b\x07J\xb9\xc8\xac\x87o\xce\xbb\xd7m)*\xf6 1\x03\xa6\xb2\xc3F\x9c\xcfa\x8c\xfc\xed\xb8;\x938\x0e.q\xeb2gOt\xb0\xc4\xb5\xca\xc5r>C\xc7g\xec\x89\xfc\xff\xd4\xdb\x8et\xfb\xb9\xfc+2\x05Z<\xc5\xa1q9\xc1\xa3\x9bt\xb3\xdaL\xb3&h\x8f\xda~\xc3>\xcb1P\x1fq\\S\x8fYV\n\xf2\xc3;6\xb2Z\n\xd5+\x8b\xfa\x16\xd3\x9dO\xda\x143|\x85\xffj\xa1.\xa27\xd1es7\xbe\x13\x85\xc3\xd2+\xc0a\xb8]R\x97y\xb3\xa9\xfd\xea\xb0]\x0f\xb7\x18\x8f\xb8~\xe3\x1b\xd5\xe3\xe4\xc8\x9c\xaf\xca%\x0bc\xdeaI\x17\x97\xf0\x15\xa4\x08z\xb0\xdbJ\xe3\x17\x07\xbf\xc0\x1f\xd7\xb0\xdd\xfaO\x1e\xe90\x93\x0f\xf3EK\xed(\xda\x0d\xd4Ex\x02\x9c*\xf0z|\x9f\xf7\x93b\x85\n\x89\x16V?`+.9\x13\xb2\xb2x[X\xa4B\xcb,\x81\x7f4+?y\x81\xfc\x15]\xeb\xf9S\x9d'\x81\xab\xb7\xbfL\x7f\x9e\xfe<\xbd\xea\x16LP\xa6Y\x81L\xba\xe2\xcb\x9e\xfd,\xa9\xe0\xe6\x1fRA\x1aoo\x177;\xf3\xa7\xeb\xdb\x0f	<!T\x84\xe0\n\x04\xc7\x0d\xf0\x02\xf9#\x90P\xbc\xb6y\x92\x8d\xd1\xe2\xff*a\x91\xa0\"p\x1a\xeab\xea\x92\x84?\xa6\xb4Z\x97\xba\"`\x95+@\xdb\xce3\xc0	U\xcbJ`\xde\xc4:nn4\x7f\xecN\xe2e\xfb\x12J8\xc1\xe4{\x94l}\x83\\\xab\x8c\x12\xb8\xfa{\x0b*J\xd4\x95;\\h\xdf\x0e\xd4\xe6\xf2\x9bBr\xd4\xe5\xe6\xa6\xea\xa5n*\xbauL\xb9\xa9:\x12\x00%\x96\xda\xaeOF\xd4.\x9d\xa0\x1d\xf8\xd5\xaf\x98\x80\xeb\x9bap\xb1\x81\x98\xb4yksr\x16p\xb2=\xb7\xaf=\xe7]\xd3\xd4\xaaq\n\xfb\x90\xcf\xa9s\xe8\x87<O\xd2\x93\xa9\x95\x96U\x89\xbf\xebJu\xdbk\x14(L\xd3\xd4_\xec\x17\xad\xd0\xbf\xba\xc6\xe3\xc3\xe1\xef\xbe4:\xda\xf0*=P=\xd7\x83\xb0\x86\x17\x9d\x87\x18\xd0\xc7=_\xffTF\xeb\xd2L\xd8\xee\xae\xeb\x0c\xdeN\xbb\xe2a\xd9\xb5\x92\xeb\xdd\x9b\xfe9L}\x16U&\\j\xc2\x0b9\xcd\x85\xc4C\xd0\xe0\x10\xd7\x0e\xdf\x85-\xf52\xcd\xb5\xf4Ch\x10Z\xea%\x1d\xc7\x18\x8eD\xc7\xe3n\x10\x0d\xd3z\xfd\x84>\xbc\xa1\xde\x08\xee\x1d|o\xc0\xc5{\xeb\xcf9\xfd\x90\xb9}\xc8\xec\xdcP\xfen\xb2G\xcb0\xa4%\x92\xb3\xfd\xa2k\x12\xb0\xf8[*qV\x91\xad\x8b\x7fF\x05\xb38k\xe2E.8\xebn\xb7\xc6\xae\xc8\xc6\xc17\x0e\xbe\xf1\xb0\xef\xd9\x94\x9e\xfc\xa94\xbe\xbe\xbe\x13\xda\xef\xe6e\xfbx\xcd\x0e\xda\xf6\xa8o;4\xae\x07>\xec\x13\xef\xd3\x13\x9dg\xb8\xb5\xa85_\xdd\xc3c\xc2\xe37\xde\xe6\xb8\xe2\xb8\xb5\xc1\x04\xde\x0b\x8b\xdc\x7f\xcd\x1b\xc13\x15:\x01wNyj\xf0\x8f\xa2\x99\xe1\x83B\xf42\xf8!\xf19N\xbd'H\xe7\x12\xbc\x1c\xf6@\xad^'L\xc7h\x1dS\xa8\xc1\xb3\x1c\xd2\x80\x1f\xa1M'\xc9\xbdL\xa4\xbe\x89o'O'3\x1f\xea\xd4\xc1U]\xdbw\x16\x99\xeb%\xec4\xec(\xf2\xb9N?\x9f\xe5\x94\n\x9cL{^._\x96\xfc\xb4\x94\x9e<\xd3\xefM;,\xad\xe7\x9a\xed\xa8\xce\x0d\x96\xcb\x9e\xec\xbd\xf0\x99v,\xffx\xef\x96}\x8cP\xb9\x9e\x85_0\x87\x00\xeb\xdfe\xe1\xd9\x83o\xbfT\xff\x1f\x00\x00\xff\xffPK\x07\x08O\x00\x1b\x86#\x06\x00\x00\xa0\x16\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00a\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-controller-manager.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xb4Wm\x8b\xdbF\x10\xfe\xee_15\x1cN\xa0\xb2r\x07!E\xe0\x0f%!i?$g\x92P\n!\x88\xbd\xd5\xc8Zn\xb5\xab\xce\x8e|8\xae\xff{Y\xbdX\xb6N\x96}I\x1a\x08\x1c\xf3\xf6<\xb3;\xf3\xac,\n\xf5\x17\x92S\xd6D\xb0\xbe\x9e\xdc+\x93D\xb0\xb4\xc9$G\x16\x89`\x11M\x00\x8c\xc81\x82\xfb\xf2\x0e\x03i\x0d\x93\xd5\x1a)\xc8\x85\x11+\xa4\xc6\xef\n!\xdb \xb7q\x8c\xf9\x04@\x18cY\xb0\xb2\xc6\xf9:\x00Nf\x98\x94\x1ai.t\x91\x89\xb9\x0f'\x83\x8cn\xael(I\xb1\x92B\x07\x85M\"\x98N\xab\x94\x82l\x8e\x9caY\x85\x14\x968\x82\xe9\xf5\x8b\x9b\x977C~'I\x14\x18\xc1\x94\xa9D\x1f\xa0\xc5\x1d\xea\x06]\xda\xbc\xb0\x06\x0d\x8f5\x03\xc0\n)\x82\xc6\x19\x14Z\x18\x9c\xb8\x02\xa5/\x92Y\xc7\x1f\x90\x1f,\xddG\xe01&\x9e\x81\xb2\xa4x\xf3Z\x0b\xe7>TgU\x9f@ u\xe9\x18)h\x1b\x9b@UV(\x83Tq\n\xce\x9e-\x80\xca\xc5\n#\xd8nAZ\xc2\x98\xb0\xb01Y\xcb\xb0\xdby[I\x84\x86\xe3\\\x18\x95\xa2\xe3/\xb3\x04\x0b4	\x1a\xa9\xd0\xcd\xbe~\x99\x1d5\xe2\x0d'\xc0\xbc\xcb\x91\x9c}\x85\xdd\xae\x03^\x96Z/\xadVr\x13\xc1\x9f\xe9\x07\xcbKB\x87\x86\xab\x084\xeb\xfad\xdb>\xde\xdd\xbe\xff\xfd\xef\xe5\xc7\xdb\xd7\x9f*3\xc0Z\xe8\x12\xdf\x92\xcd\xa3\xc6\x00@\xe8lI\x12\xdf*\xd4\xc9GL;O\xe7\x8b@\xab\\\xb1\x9b\xcb\xa2l\xaf.\x17&\x89&\xdb+P\xe9\xff\xd1\xf5\xdc\x15Z\xf1\xb3Y8{\xfe%\xb8\xfe:w,\x88\xdd\x83\xe2\xec\xd9,\xdb\x14H\xbe\xc0\xec9\\\xed\x9a\x8e\xc3\xbd\xd5\x93B\x93\xa8\xb4s\x8e]h\x00Ap\xa7L\x12\x88$!tn\xb1\xddV\xf1\xb1(T\xecP\x96\x84\xb1\xf7\xc7\x8d\xdf\xdfG\xd3\xb6\xb6e\x12\x17d\xd7*A\x8a\xd1\x88;\x8d	\x08\x93\xf4\\\xb0X\xc0\x14\xe5\xcd\xb4c\x14\x04UH\xd0\x86,\xc4\x83\xeb\xb9\xa45\xa9Z-Bd\x19\x8a\x07\xe7\xff\xcf\xbd\xad\xeaO?\x19\x7f\xed\x8a\x0c	\xc784!#<:\x85\x08k\xb3\x0b\x9b\xa4\x03j\x0eG0\x8eng{\x05\xa9%P\x8c9(S\x1f{wMqsM\xb1\xa0\x95\xebJn\xb7uB}\x0fh\x12_\xa2+v\x8f\x9b_\xfd\xa0\xfb\x82)\n\xf6\xf7\xb7\x12\x95\xa61\xe6\xee\xd9s\xf8\x17\xb4r|\xc8\xb1\x89\x0b\xaa\xb8j\x00p\x03\xbb\x9d\xff\xcbW\xea#\xf94/}\xae]\xb7\xbd\x8c,+A\xf4z\xf8\xaa\xd9#/Q}\xeb`\xc2\xcd`Bm\xd5j\x8d\x06\x9d[\x92\xbd\xc3vCS\xa1tI\xf89#t\x99\xd5I\x04\xbf\xb5%\x98\x8bw\xc8\xdd*\xfb\x9a\x11\\\xdf\xbc\x9a\xbf\x98\xbf\x98_\xef\xed\x85\xe0,\x820C\xa19\xfb\xd6\x99\x1f\x91\xaa\xdf\n\xaf)\x7f|\xfe\xbcl\x8c\xca(VB\xbfA-6\x9fPZ\x93\xb8\x08\xae_6^V9\xda\x92\xfb\x8eVS\\\xcb\x8e\xf0\x9f\x12\x1d\xbb\x8e\xad,\xcaJ`O\x8dC\x9b\x11\xcb\xa2l\xd5\xd1\xff\xcb1\xb7\xb4\xb9,\xb5\x8e\xedv\xf9TB-|\x15\x92\x9f\xad\xf1\xa8\xa6\xe8UK\xa96G\x97C\\\xed.>\x84\x83\xac\xfdx\xee\x97\xea<Z\x9f\xeaE\xa7w\x9c\xfb\x18\xf6Hu\xd7V\x979\xbe\xb7\xa5\xe9\x8e\xa0\xa9Y\xbd\x02\xb1\x1f\x90o\xd6 \xfc\xb2\x80\xe9\x81,\xd5o\x17\x7fk\x84\xa7!\x98\xfbB\xcbz^\xbd\x12i+\x85\xf6%\x06\xe4\xfe \xd6\xbf\xc9H\x1c'\x8a\xbaQ\x19~\xe5}\x9c\xdb\x0f\xa5Hn\x8d\xde\xec\xbf*\x1e\x97m\x0f)U\xab8U\x1a}\xd1!\x88\xa3..\xad\x9bZ\xedu\xbbW\x0eY\x06\x9d\x02\x0f\x97\xfc\xf1\xa7\xa9\x7f\xd2Go\xcf!\x9dce\x0f\x0e\xfc\x8f9\xfd\x94\x07\xab\xcf\xec\xdck\xf4\xdddO\x8eS\x05\xeb\x9c\x0e\x0f\x87\xa5\x01\x10O\x9a\xa0\xb0tT\x0fq\xe82A\x186\xf9*URt\xb7[\xd7.\x1d\x05UlP\xc5\x06\xc3\xb1g!=\xf91\x18?_\xdfY\xdawsY\x1fO\xea\xa0\x16\x91\xe6\xe3\xbcz\x19=\\\x13]\x9c\xdep\xde\xf8\x9f\x1do\x14\xa1dK\x9b	\x9c\xdd\xfa\x91\xf2\xe3\x9b^C\xbdU\x1a{(\xfb\xc5?W\xba\xbf\xec\xa7\xc9\xf7\x04\xe0\xc7v\xfd\x14\xadSK?\xd8\xe8\xd0Z\xfd\x8cu\x1f%w\xd9\xde?\x89o\xb7\xf1\xa3\xc8\xfd\xd5\xef]\xd5-\xbd&\x14|\x04(\xce\x8e\xd8\xd9\xe59\x8f2\xb6X\xa3\xb0\xe7\x15\xe82\xf0qu\x1a=\xd3\xef\x85\x1dR\xab\x0b?3N\xf1\x99\x1e\\\x85\xcfQ&\xb5a\xf5S\x7f\xa8\xe0n7=\xa0\xd3}\xb0\x1c\xce\xd3\x7f\x01\x00\x00\xff\xffPK\x07\x08\x99\x91\xfd\x8c\xa7\x04\x00\x00\xd2\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00X\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-scheduler.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xacVMo\xdbF\x10\xbd\xf3WLe\x18J\x0e\x14\xad\x00A\x0b\x029\x14\x0e\x9c\xf4\x10[H\x8c\xb6\x80a\x08\xeb\xe5\xd0\x1cx\xb9\xbb\x9d\x1d:\x90\x0d\xfd\xf7b\xf9!\xca\xb2d\xbb@u\x9c\x8f73\xfb\xe6\x0d\xa5<\xfd\x89\x1c\xc8\xd9\x1c\xee\xe7\xc9\x1d\xd9\"\x87\x85+\x92\x1aE\x15JT\x9e\x00XUc\x0ew\xcd\x0d\xa6AWX4\x06\xb97\x07\xaf\xf4\xc6\xb7\n\x82u\x02\xa0\xacu\xa2\x84\x9c\x0d1\x1d`\x935S\xc6Wj\x16\xc3\xd9\xa2`\x98\x91\xcb4\x93\x90V&\xf5\xae\xc8a2iS<\xbb\x1a\xa5\xc2\xa6\x0d\xf1\x8e%\x87\xc9\xfc\xe4\xc3\xc7\xf9>\x7f\xd0\xac<\xe60\x11n0\x06\x18u\x83\xa6\xaf\xae]\xed\x9dE+{f\x00\x10B\xceA;+\xecL\xea\x8d\xb2\x98\x04\x8f:\xe6V.\xc89\xcaO\xc7w9D\xe8\x04\xe0\xa8\xb0a\xe1\x0c\xe9U\x0e\xa7\xa6	\x82|F\x1c\xe4/\x92\xeak\x17\x9f\xc4\xf6\xc81\xc9\xea\xd4\xa8\x10\xce\xdb\xf7\xeb\x9e'\xd5]N:L\x9d@[\\\x91En\x1bN\x0f\xbd7\x00\xd5\xea\x16sx|\x04\xed\x18\x97\x8c\xde-\xd99\x81\xf5:\xda\x1af\xb4\xb2\xac\x95\xa5\x12\x83\\M\x0b\xf4h\x0b\xb4\x9a0L\xaf\xaf\xa6O\xa6\x8c\x86\xa75\xa2%\xb0\x9e^\xc3z=\xd6[4\xc6\x0c\x03\xffQ\x9e;Y0\x06\xb4\xd2F\xa0\xbd\xef\x1ey\xe8\xfa\xcb\xc5\xb7\xdf\xff^|\xbf8\xfd\xd1\x9a\x01\xee\x95i\xf0\x8c]\x9d\xf7\x06\x00\xc6\xe0\x1a\xd6xFh\x8a\xefX\x8e\x9e\xd1\x97\x83\xa1\x9a$\xcc\xb4o\x06\x16ke\x8b<y<\x06*\xff\xc7ag\xc1\x1b\x92w\xd3l\xfa\xfe*\x9d_\xcf\x82(\x96\xf0\x93\xa4z7\xadV\x1e9\xe6M\xdf\xc3\xf1\xba\x1f4\xdbXc/h\x0b*G\xe7\x1e\xd6RHS\xedlI\xb7\x9f2\x14\x9d\x8d\xdb\x9fu\xe6\x90\x8d\x02\x89\x96\x08[:\x06\x12\xac\x81l\x8b\xb9\xdc\x84,\x15\xdf\x86\xb1\xe0\xe3c\x17\xb7^\xf7\xdd\xc4\xcc\xde{\x14u\xd3\xab\xe0(\x1d\xf7l\xd1\xca\xa9US\xe7\xebV}\xd7l\xe8\x1e-\x86\xb0`w\x83\x03I\xa5\"\xd30^V\x8c\xa1r\xa6\xc8\xe1\xb7\xdeS\x89\xf8/(#\x9b\x113\x87\xf9\x87_g'\xb3\x93\xd9|c\xf7J\xaa\x1c\xb2\n\x95\x91\xeaa4\xef\x94\x87\xfer\xc4\xb5\xfazy\xb9\xe8\x8ddIH\x99\xcfh\xd4\xea\x07jg\x8b\x90\xc3\xfcc\xef\x15\xaa\xd15\xb2\xeb\x18\xd6*\x0c\xdd1\xfe\xd3`\x900v\xab}\xd3Jk\xe7\xb5\x87\xc0\xa5\xf6\xcd\xa0\x8b\xf8\xab\xb1v\xbcz1\xa3\x0b\xe9\x99\xa1r7\xae[\xf0\x16\xd7\xf1\x01g\x0fq<\xd4\xed\xcc\xf9\xab\x80\xc7\xeb\xd7\xe6\xda\n\xde\xacN\xb7\xc8/a\xef\xf6\xf3\xd2;<My^\xe4\x89r\xee\x9dij\xfc\xe6\x1a;\x8e\xd7+\xb8\x15\xf02\x12\xfb\xe0,\xc2/\x9f`2\x19\xf7\xbf;;\xf2\xd0K\xac\xef\xab\x8e@\x8bn\xcf\xa2\xe6\x8c\xd3\xcaD\x88=\x92\xdd\x8a\x8dW\x14Y\x96\x05\xf1\xc8\xf5\xd69\xee;2\xf1~#K\xd8,\x93*.\xacYm>\x12\xcfa\xdb\xb7\xe9Z\\\x96d0\x82\xee+\xf1d\x8a\xb7\xe2\x96\xce\x14\xf8\xacc\x14\x9d\x8e\xb7\xe6\x8d\x90o\xbcP\xdbu6\xaet\xcb\xf5\xacNGp\xff\x85k\xafM\xac\xd7G\xfb\xc3\xaf/\xab\xf8a\xffL\x8cZ\x1c\xaf\x92\xd7\x19y\x01\xfee\x16\xbaRgd09@\xcak\xd0\xbbD\x1cn\xfe\x199\x87\xa0\xff\x13!{'\xd8\xe1\xe7\x8d\xda:\xd4\xcf$k\x02g\xa1R\x8cY\xcc![\xba\xac\xfdG\xb2\x0fp\xbd\x9elu2\xaat[\x85\xff\x06\x00\x00\xff\xffPK\x07\x08,c\x93Q\x93\x03\x00\x00\x84\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/control-plane/templates/etc/systemd/system/kubelet.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[\x8a\x0e\xcd\xcb,\x89\xe5rL+I-\xb2M\xaa\xccK-\xd1+N-*\xcbLN\xe5\nO\xcc+)F\x13\x0bH,*\xf1OC\x13\xe4\x8a\xf6\xcc+.I\xcc\xc9\x89\x05\xebIMq\xaaDS\x01\x08\x00\x00\xff\xffPK\x07\x08\x9a\x97F\xfeB\x00\x00\x00f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00N\x00	\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-serial.conf.j2UT\x05\x00\x01\xf0b\xe7[\xaaR\xd0/\xae,\xd6O\xceI,.\xd6O\xc9\xcd\xd4\xcfL\xd1/(\xcaO)M.\x89/N-\xcaL\xccQ0011Q\xd0\x85@.@\x00\x00\x00\xff\xffPK\x07\x08\\\xef\x8fe2\x00\x00\x000\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00L\x00	\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-uuid.conf.j2UT\x05\x00\x01\xf0b\xe7[\xaaR\xd0/\xae,\xd6O\xceI,.\xd6O\xc9\xcd\xd4\xcfL\xd1/(\xcaO)M.\x89/-\xcdLQ0011Q\xd0\x85@.@\x00\x00\x00\xff\xffPK\x07\x08\x94b\x82\x060\x00\x00\x00.\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00b\x00	\x00roles/kubernetes/control-plane/templates/srv/kubernetes/manifests/control-plane/kube-proxy.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xacV[o\x1b\xb7\x12~\xd7\xaf\x18\x18\x10\x94\x00g\xa5\xf8\xe0<\x9c.\xd0\x07\xd7q\xd2\x00\xb5l\xd8M\xd1\"\x08\x16\x14w$\xb1\xe2\x92\xecp\xa8XU\xf5\xdf\x0b\xee\xaed\xaenn\x8a\xec\x8b\xad\xb9|\xdf\xdc8\xa4p\xea\x17$\xaf\xac\xc9ay\xd9[(S\xe6\xf0\x88\xb4T\x12\xaf\xa4\xb4\xc1p\xafB\x16\xa5`\x91\xf7\x00\x8c\xa80\x87E\x98`\xe6\xc8>\xadZ\x91wBn\xe5~\xe5\x19\xab^/\xcb\xb2\x16\xefZ\x07\xcfH\x0fV\xe3\x0f\xca\x94\xca\xccz)/M\x84\x1c\x8a\xc0sK\xeaO\xc1\xca\x9a\xe1\xe2\xff~\xa8\xechyy\x84\xbc\xc1\xcf;1h1A\xed\xa3\x0d\x80(Kk*a\xc4\x0ci\x18\xad\xc8 c\x8dW\xd9\x12sx@i\x8dT\x1a{>L~G\xc9\xb5c\x06G\x93\x8f\x88G\x92>\x996Y\x8d\x0f8\x8d\x88\x07\xc9\xef\xa7`l\xd9 *\xa4\x1e\x80p\xea=\xd9\xe0\xceT\xa4\xa9jZ<\xe1\x9c\x1f\xed:\xf7V`e\xcd#\xfe\xdb\xa6u+)m\xe5\xacA\xc3\x07\xc9\xb3B\xcaAZ\xc3du\xe6\xb40\xd8\xf3\x0ee\xf4\xf3\xa8Q\xb2\xa5\x06\xa3\x12,\xe7?%\xa0\xe7`\x8f\x03\x03\x04W\n\xc6G&\xc18[58\xbcr\xb1\x97Vkef\x1fk\x83ZN\xa9dKY\x89\xa7\x8fF,\x85\xd2b\xa21\x87\xcb7\xfd\x1e\x00c\xe5\xf4\xce*\xadX=G\xc6X\xae\x8b\xbf\x8b\x1c\xc0\xcb9\x96A#\x0d\x85vs\xb17_\x92\x14+)t\xe6l\x99\xc3\xc5\xc5\xce\xcd\x91\xad\x90\xe7\x18j3g\x89s\xb8\xb8|\xf3\xdf\xff}w\xca\xc6K\x121\xc1\x0b\xa6\x80[#\xdd\xa9\xe3\xf9J\x9e\xaae\xf3}\xc5!\xa9\xd3n{\x1b\xbf\xb9\xf5<F\xfebi\x91C\x8c\xae\xb7\x8d_YR\xbc\xba\xd6\xc2\xfbq2\xe7Y=\xe7\xdb\xda\xb4\xd6\xbes\xcc\xc6\xc7N\x18\x00[\x8d\xd4mA\x06\x0b\\\xe5p\xdd\xa2]\xc5<\xfc\x9d\xd1\xcfi[\x17},\xe5p\xf3\xa4<\xfb\x9d#N\xa7(9\x87\xb1}l\xbb\xf85>7O(\x03\xbf\xe8\x12\xcb-\x94AJ\">\xba?\xe2\xa7*1\xc3\x1c\xd6k\x90\x96\xb0 t\xb6 k\x196\x9b(\x0bDh\xb8\xa8\x84QS\xf4\xfciP\xa2CS\xa2\x91\n\xfd\xe0\xf3\xa7A\xa7\xb7Q\xf0\xcc\x11\x7fy\x92\x83\xcf\xb0\xd9t\xf9\xee\x83\xd6\xf7V+\xb9\xca\xe1\xc3tl\xf9\x9e\xd0c\xbb\xea\xe2\x87f\xf9<b\xdb\xe8\xdf\xdf\xdd^\xfdz\xffpw\xfd\xb8S\x01,\x85\x0e\xf8\x8el\x95'B\x00Bo\x03I|\xa7P\x97\xed2<\xa6\xcfA\xabJ\xb1\x1fJ\x17\x0e\x08o\x7f+~\xbc{\xfc\xb9\xf8p\xff2\xe1\xf4\x04Q-\xbf\x17<\xcf\xc1\xb3\xe0\xe0\x87qx\x13\xc4\x84l|\xf7\xf6\xa6\x18_\xdd\xde|\x1b:\x87r\x18\xe7>Nvz^+a\xca\xbc\xb7\xee\x83\x9a~\xa3\x06\x0f\xbd\xd3\x8a_\x0dF\x83\xd7\x9f\xb2\xcb\xcfC\xcf\x82\xd8\x7fQ<\x7f5\x98\xaf\x1cR\xf4\x19\xbc\x86\xfe&I{\xb4\xd3$\xc2dB\xd7}@\xedq\xcf)x\x1ai+\x85\x1eM\x94\x19\xed\x99\x9bRM\xbb\xf6Y6Q\xa6\xccDY\x12z\xff\xfdz]3\x14\xc2\xa9\xc2\xa3\x0c\x84E\xd4\x17\xad>\x0e\xea\xba\x0fSK\xa0\x18+P\xa61\xaf\x19\nA3\xdfE_\xaf\x1b\xbb\xc6\x0dM\x19=\xfb;\x8c\x05\xae\xfe\x13\x1b\x18q\xa6(8\xd2\xcdD\xbd\xe3\x18+\xff\xea5\xfc\x05Zy\xde\x0f\xb9\xb5\xcdj\xdb:f\\\xc1f\x13\xff\x8bh\xfbl[\xd7:\xa1\xb8\xfd\xaca|\xe2t4\x1c\xa9\xa5\xd28\xc3\xb2\xb3/\x9f\xcf\x81O\xad	\xff\x08\xe8\xd9w\x87K\xbaP\xef\x89\xa4\"[\xc3B\xba\x90\x1er\xa8\xef\xb2\xca\xd2\xea\xa4G\xa3nsQ\xd3\xd4\xa69\x915f,\xe2\x81\xa2u\xed\xa7|\x8d*?\x0b\xd6\xdf\xbc\x94Ob\xbc+r3Q\xa7p\x8f\xc5r*\xf7\xae\xcb!\xc1\xc1\xf8.\xad\x0e\x15\xde\xc6\xdb\xc9\xa7\xeb\xb0\x8a\x92\xe6\x90\x8f\x96\x82FZM\xea\x93\xa0\x91\xeb\xbf\xd2\x9a\xa9\x9a%\x11=o\xff\x03\x15\xa1(\xe3\xdd\xb57\x17]\x12\nf\xf4\xc4\xf1\xdd\xe2\x87\xda\xca\xc5\x01t\xab\xcc:\xca.F\x0c\xb2\xb2\xf1\xca\xf3\x07\xeeZM\xb2C\xdd?\n-\xe6\x1f\xc3+'\xe1\x10\xb7#\xec\xf8\xc5\xbb\x0d\x89\x8bRQwp\xf7/\xca,\x9a\xbd\x18U\xd3\xa9\xe4\xbe\x8dK\xbefJ\x0f\xe1Wt\xacyZ\xbeS\xc9\x03\xe1D\x13\xcfr\x9di\xdc3\xc3\x1d]\x13\n\xdeg:\xd2\xd3\xb3\\\xc7\x1b\xdc\xd0$\xef\xd0S\x1d\x7f\xb1fG\xba\xdc\x80\xbfUT\xbf\xf6W{\x1c\x89\xf19\xf0\x93\xa3p\x1e\xfd`D\xfe\x0e\x00\x00\xff\xffPK\x07\x08\x80\x81\xd3n\xa0\x04\x00\x00\xc8\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00R\x00	\x00roles/kubernetes/control-plane/templates/usr/lib/systemd/system/kubelet.service.j2UT\x05\x00\x01\xf0b\xe7[\xa4W{o\xdb8\x12\xff\xdf\x9fbj8\x95]\x84V\x13\\q\x07\x07\xbaC\xafH\x8b\xa0[4\x88\xb7\xd8\x05\x1cC\xa0\xa9Q\xcc\x0dEjIJN`\xfb\xbb/h\xc9\xd6\xcbI\xfa\xf8#\x019\xf3\x9b\x07g\xc63\xa3\xd97\xc9\xed\xbc\xf7>\xb6\xa8\x83H\xb1{\xd4c\x83:\xe7\x0c{7\xf8w\xc65\x9a6\xbd7\x9b\x16\xa7y\xefR\xe6\\+\x99\xa0\xb4\x1f\xb9\xc0\xc0G\xcb|\xac\x88u@\xf0\xe9\xeb\x97\xf7\x7f^\xdf|\xfd0\x0d\xd6k\x98\x0d\x87T\x1a\xbe\x10\x18\xa6Z14F\xe9\x90\xa9LZx\x03o\xc7\xff~7\xdapiG\xa7p6\x87\x0d$\xf4\x01\xb6\xdb\x86\xba\xcf\xff\x99\x86\x1f~\xfb6\xfd\xfd\xf2&\xbc\xfa\xf8\xfe\xc3\xa5\xd3\xcaDf,\xea\x90\xc7\x94a(i\x82\xb0\x01\x8d\xa9\xa0\x0c\x87\xfd\xbd\xc1\xfe)\xf4\xfb\xa3\x9d\xc2\x07dSK\xb5\xbd\xd6\x18\xf8\x0b.\xfd\xe4>\xe2\x1aH\n\xb7=\x00\x00?\xa7\xda\x17\xea\xcegJZ\xca%j\xd3\xe6\xa4*j\xd2\xf8\xc2\xbf\xcf\x16(\xd0\xee\xc9*\xb5>\x93|\x7fuQ\xaa\xae\xeb5\xa0eQ\x18QKCg|\xbbm\xabcTp\xa6\x8exk\x96@\x18x~ftq\x7f4\x16\x13f\x05\x18\xb4\xa4\x96	\xe8\x84+\xbc\xba\x0e\x06C\xdf81\x1e3%c~\x07\x83u\x07\xb7\x85\x0d\x1c\xf4\xd3\xd5=\xdcz\x833\x08\x02\xe8s\x89\xb6\x0f\xebTsiap\xbe\xbd\xf5\xeaP\x96Y \xd1\x04H|>\xf2\x9a\xae\x93\x03\xa8(-\xb8\xe7B@\x19\xb3\x17\xa0:\xe9\x02\x83\x0e(\x93@\x88N\x80\x10\x896X*c\x81\x90\x94G\x87\xa3\xe69\x17x\x87Q\x19jBv\xc5\xd2\xcc\x1b\xc9]r\x18j[\xe6e\xd2\xbajU!wYu\xf2Z\xa2E3i\xdd[@c\x84\xef4\x95\xb8\xea\xdaP\xe9\x9ee\x96T\xa3\xcf(q|\x1esFw\xda\x9ffu4\x08\xc5\xa8xN\xcfs\x80\xce\x13;\x1a\x8e\x11;R.\xee%\xb68v\x10\x1a\x8d\x12\xf9\xd8\x15\xe2\xa4Ch\xa0\xcb\x1f\xde\xe4p\xd0\xab\xd3\x9d\xf3Q\x1b\xc4\x17eEL\xdaw\xbd:\x82-\xb3?\xe9\x10\x8e\x190:\xaf\xa5\xd7O\xa8\xe41\xee\x1e\xf9$\xa7\xf1\x8a\xb2'L\x0e\x87\x86Ke\x8b\x98\x1c\x0e\x1d\x87u&'\x87C\x83k\x1e\x9d\x13\x8f-{\x11\xe6\x13\xf7\xef\xc8\xbb\x8b\xf62i\xdd\xe1\xb6\xb7>\x81Xi\xe0\x16\x13\xe0\x12h\x14q\xcb\x95\xa4\"\xd4E<\xc2\xc4u\xec0U\\Z\x03'\xdb\xda\xcff'T\xfcd\xf6G}\x08\xe2\xfa\x04PFN\xf7\xc9\xd6]x\x0cL\xa8,r\xb3 \xe7\x11\xea\x10%]\x08\x8c\x80\xca\xa8\xc5r\xbd\xc7Cv\xee\xd5\xec\xed\xc2DW\xc6\xfd\xd5J\xa8A\xd1\xe5\x8bP\xfc\xa8\xb5~n\xd2%j\xec\xb7-\xd6\xb2\\4P\xe3\x97\xd0\x9a\x13/\x81\x0e~\xc9\x88\xc7{\x0b\xae\xd1(\x8d\xa1\xc6T\x85Z)\x0b\xdb\xad\xa3eZ\xa3\xb4\xe1\xbe\xa6f^\x84)\xca\x08%\xe3h\xbc\xf9\xccssJ+ARA%:BY\xc3\xeeh4\xf3\xe6\xd5x)\x87Gq\x81]\xefcV\x849j\xc3\x95\x0c\x06\x83aI\x82\x924\xba\x80'\xc0\xa1\xb1\xd4f&\x18\x0c\xfeWa\x90-\x15\xf4\x07\x83\x16\xb6\x0f\xff\x05\xbf\xa4\x8dK\xda\xd8&i%\xc8c\x98AG\xae\xb4\x01D\"\xbc\x859l60\x83W@\xe2N.\xdc$\x18\xb7,T\x02\x83\xc10\xe2\xf1wJ\x1dst\x04\xf3\x0b\xb0K\x94\x07\x87\xabx\xa4\xd4.]\xe44\xd2Hpy\xef\xdc\x1b\x0c\x86\xab%g\xcb=h4\x82\xd7\xafk\xb2\xee\xb9\xb3z\xa0\x9c\x92\xbe+\xbc7\xfd\xe5c\x8a\xda\xd1\xfbo`\xde5\x0bP\xcc\xda\xb4+\xde?\xf6\xbeJ]\xd3\x05\x00\xb6T+	\xae\xd2&\x8e\xff\x92\xf8EM\x18\x85\xc1_\xf0\xa9\x84\xfc\x9cG{\xe1\xba?1o\xaaz\xae\x0e\xbf\xa3\x06*\xd51\xaf\xce\x06# \xdc9\xf5`\xff\x05\x81\xffjq!/\x8c\x9f\xa0\xa5\xbbE\x8e\x99,9\xf5\xfd~a\"\xb9\xc7\xf3\xb8\xe8C\x85oe\xcf\xfb\xf5\x9f\xf3\xd8\xa4\x82\xdb\xa1\xe7{\xa3\x199\x9b\x8f\x8d[\x88\xcc\x8a\xdb\xe5\xd0;\xa4\xcb\x1b\xed\xfb\n@\x95\xc4\xda\xb6\xb3k\x8b\x06+T\x93U\xefL\xb0\xdb\x96\xf2\xe0\xbc\x16aB\x8a\xce\x16<\xd5\xf0JuE\x00\xearREHx\x1a\x1cY<\xc3\xab\xebm\x03\xab\xf1\x8e\xbb\xed\x9e\xb8\xd7\x11\xb7\x8d[\xe3\x96~\xb7P\xe4T\x9b\x19\x979J\xab\xf4c\xe8Hn\xa1\xab\x02\x16\x16x\xcf}MD\x18\xd3L\xd8\xe1l>\x82\x0d\xe4T\xf0\xa8d\xc3\x06\xfeR\\\x0e\xbdSoT5\xca\x9a\xab\x82.P\xfc\x80\xd5\x02\xff\xa4\xd5\x82\xdd\xb5\xfa\xab#\xb1L\x8a\x03\x91=(\xa0+\xd3\xcc\xd9\x8e]\xcf\\}^\xfe\xec\xb0\xf4\xca\xe1\xf6\xbc/%\xe8\x05\x7f\x8eTR}t\x1e+\xdc#\xb6ZE\xdcZj\x9c\x8dp\x9f.\xaa\xefL]W\xb5\xbc\xb4\x97\x96\x82\xbf\xff\xb0Qi\xf7K\xc5X\x95\x02\xb1\xf0\xee\xf0\xc1r\x83\xbb_g\xa0$\x89)\x17\x99\xc6=\xe9\xa3\xd2\x0c/\x1f\xb8\x9d\x16stz\xf5\xe9\xfa\xea\xfar\xcf\x9e\"\x0b\xce\xde\xf6>s!\xbe\xa8\x08\x83\xf2\x8b\xb9\xd7\x9b]Ic\xa9\x10\xf3\xde\x1fTZ\x8c\xfe\xff\x18$\x99\xb0\x9cd\x06\xf5\xd8R}\x87\xb6\xf7O\x00\x00\x00\xff\xffPK\x07\x08(TL\x06L\x05\x00\x00\xe5\x0f\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00F\x00	\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[\x8c\x92\xbd\x8e\xdb0\x10\x84{=\xc5\xa6\x10\x94\x14>\xfbR\np\x95wHs0\x08\x1e\xb9\xb2\x16\xa6Ib\xb9\x12b\xf0\xf8\xee\x81~|q\xa18)\x89\xddofH\xce\x85\xbcm\xe1G\xf0\x1d\x9d+\x1d\xe9'r\xa2\xe0[\x18_+30\xa3\x97\x9d	^\xf0\x97\xb4\x903\x187$AV^_\x11J\xa9\"c\x87\x8c\xde`j!\x97j\x9d\xa7\xb6\xda\xddw\xdb\n\x00\xc0 \x0bud\xb4\xe0N\x0f\xd2\x07&\xb9-\x92\xc8\xa2,1\x94\xb2\xcf\x198\x04QF+\xc3\xa2:rxw\x9aD\x12\xf2\x88\xdcB\xae\x81:8s\x18bzk\xaez\xb2iN\x1f\x0e\xfdYz8\x1e\xe1\x15\xb4\xb7p\x9f\x00\xf9ey\x8e\x9d\xa0.\xbdHL\xed~\xef\x82\xd1\xae\x0fI\xda\x9c\xe12\xbc\xa3\xd2\x91TJN\xc5\xc0\x02\xa5\xe4\x1a\xd0Q\x07_)\xa9^\xab\xf5J\xf0\x01\xef!\xb8oP\x97;7R\\\xd89\xe2\x03i\\\x18\xac\x8a\x1cF\xb2\xc8\xf0\xe5\x08\x0d\x9a\xef\xcdC\x88\x9ca\x8a0j\xfe\xbc\xcc\xe1phNo\xdaZ\xc6\x94\x14\xf9\x11\xbd\x04\xbe\xa9\x8e\xd0\xd9\x13\x94\xf24o\xc2\xffQo\"\xd3\xa8\x05\x15\xc5\xe6_\x92\xdeR\x07u\xa9*\x80\xe9	7\xab\xb0\xd6d\xf9\xfa\xb52\xcb\xd7\xaf=\xd8\x82\xa6\xf9\x90\xb6\x87;m\xaf\xe4\x9fYN\xe4\xec\xf7\xb7\x8dO\x89\xd9cMCs\xa9\xff\xf4q\xab\x85\xd31\xbd\xcc\xf4\x8b\x99\x9f\xe1\x11\xbe\xe0fu\x1f\xa1\x0b\xde&\xe8w\x00\x00\x00\xff\xffPK\x07\x08\x81g*k\x81\x01\x00\x00`\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00M\x00	\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/remote-kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[\xac\x93\xcf\x8e\xd30\x10\xc6\xefy\x8a\xe1P\x05\x0ea\x17\x8e\x968\xf1\x0e\\V+\xcb\xb5\xc7tT\xd7\xb6<N\xc4*\xebwGvL\xd9B\xd9\"\xc1u\xfe\xfc\xbe\x89\xf3}G\xf2F\xc0\xe7\xe0-}\x1dT\xa4/\x98\x98\x82\x17\xb0|\x18\xf4\x9c\x12\xfa<\xe9\xe03~\xcb\x02\xd6\x15\xb4\x9b9c\x92^\x9d\x10J\x19bB\x8b	\xbdF\x16\xb0\x96\xa1\xf7Y\x0c\xd3\x8fY1\x00\x00hL\x99,i\x95qRs>\x84D\xf9i2*+\x01\xcfS\x1b\x01Xw`C\x02G\x1e\x81\xfc\xcb\x1dy\xde\x91u\xe7=g\x13\xe6,\xeb$\xc3\xb4+\x1d\x00\xf5\xc6\xb6^\xca\x999\x01zS\xb9\xbb2\xb4\"cZ0\x89*G\x16\xde\x12\xcb\x83\x92\xfdZx\x86}\x08\xee\x1d\xec\xca\xd6\x8e\xf3\xde\x91\x96\x0b\xa5<+')\x82\xf2\xe6\xf7\xaadv2\x86\x94/\xdbQ\x92U\x1aaW\x0e9G\x16ww\xebz\x05Y\x8a\xb8V\xff	-\xf5\x1at\\I\xeb\n\xc7y\x8f\x8d\xae\"\xc9\xeds\xfa\x887d\xb7\xdb\xd1\x91\x05\xe5\xf6\xd2x\xde\xfe\xd7\xc5\x11\x17\x9dM\xbfQ\x1b\xf1\x17Y\xb2\xa0]\x98\x8d\x8c),d0\xc1\x9bO0\xa2\xfe8^2\x0f\x81\xf3\xa2\x12?\x8c'U\x1f\xf3\xfe\xfe~||P\xc6$d\x96\xe4\x17\xf49\xa4'i	\x9dy\xbc\xa5\xca\xf87\xf41&Z\xaaC(\x8e\xb7\x90\xfdq\xaa\x0b\xeag_utw\xfb\xe6\xe0\xee\xfc\xcd\xc1\xdd\xce\xd7\x96j\x7f\xe6\xeb\xcdI\x99\x13\xf9\xd7$\xebf\xd3\xfb\xd3\xc4\x19\xd14\xfa5\xd4\xb2\xf9\"V\xaf\x87\xa9!d\x9d\xff\x0f	\xea\xeaG\xbc\x15\xe1M\xf5\x88\xff\x18\xdb\xef\x01\x00\x00\xff\xffPK\x07\x08\xe9\xc2\xafj\xae\x01\x00\x00\xa7\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/kubernetes/core/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xecZ_\x8f\xdb\xb6\xb2\x7f\xd7\xa7\x98k#p\xd2\x1b{\xe5M6m\x0d\xf4\x02\xbdhQ\x14\x17E\x1f\xda\xb7 W\xa0\xa5\xb1\xc5.E\xaa$\xb5\xbbn\xb2\xdf\xfd`\xa8\x7f\x94D\xd9Nz\xfav\xf2\x925\xf9\x9b\xff\xc3\x99\xa1\xa4\x83\xd2)&\xac,\xc5i\x07'4\x91\xb7\x90\x1c\x04;\xee`\xf1\xf1\x05\xf0\x03x\x1b\x9f\xf6J	x\xf1\xbc^\xbbE\xf8\xf8\x02Pf\xfc\x00/\x9e\x17Qt_\xed	\xc7\x13\x83\xfa\x01u\"T\xca\xc4\x0e\x16\xb9\xb5\xa5\xd9\xdd\xdc\xb8\xdf\xb92v\xf7\xf1#\xf4`#\x92Ri\x0b\xcf\xcf\x8bh\xb2\xba\x83\xc5\xbb\xbb\xbboZ\xee\x07\xae\x8dM\nf,\xea\xe4\xf0g&I\xcb\x8f@L\x1f\x986\xefaU\xef\xc5q\xbc\xfa\xf0~E\x88\xd5\x07\xf8\x04B=\xa2v\x124\x1e\xb9\xb1\xfa\xd4p\xbf\x8b\xe3\xb8_\x13\xfb~\xf9.\xcaTz\x8f:)l5\x92\xc2\xe5\x03J\xab\xf4)\xa1%\xc9\n\xfc\xf0>\x15\x95S\x8a\x1fX\x8aI\xbd\xb6*l\xe5\xc4gx`\x95\xb0/\xb7wq\xfc\xaa7\xb4\xa5\xc9\xa4\xd9\xc1b\xfb\xf5\xed\xe6v\xbb\x897\xdbx\x11E\xa9\xd2\x98h,U\xa2\x95\xb2\xb5\x02\x8e\xe8\x81k[1\x91\xf0\x92\x1cE\x01\xe2&\xc9Y\xcb\x0cP\x18\x84\x97\x9d\xae\x03\x87\xb0,\xd3hL\xd2\x1bp\xe0(\xb2\x0f\xc4%\x15\xaa\xca\x92R\xab\x07\x9e\xa1\x86\xff\xfa\x0eV\x98\xde\xaej~av\xabR\xf3\x07f1\xe1\xe5\xea\x03\xd9E\x81\x1d;sF\xc3A\x1c\xe0\xf9\xf9\xc6f\xe9\x0d\xd9=\xf1\xe3\x0e\x16L\x1a\xbe\x17\x98\xa0\xcd\xe3\xc5\x10\xf2e\xa1q1H%\xf7y\x0c`\x9b\x0c\x1fx\x8a=\xaeLP\xa6\xac4\x95`\x96+\xca\xbb\xef\xc5#;\x99\x05,!e\x12\xf6\x08\xc8m\x8e\x1a^.~=\x1c\x16\xa0t\x07y5\x0cw\xca3\xdd\xc6\xfb\xed&\xde\xc47\xdbw\x8b(\x92G.\x9f\x12.\x8f.D(\xd9^`\xb6\x03\xa9\xa2L\x9a\x841\xc6\x92\x0c\x05;\xf5[tl\xebD)\x98\xe4\x074\xd6\xec\"\x805\xd4nK\x95\xb4Z\x89u)\x98\xc45\x1dJ\x9e\xa2\x89\x00\x00\x06,h\xa1\xd4\xa8\xf1\xcf\x8a\x1bn\xb1]\xed\xf80\xc1S\xf5\x05tJc&\xbf@`&\xcd\x9a\xec];{\x87\xf4\x14\xa9\xb0?\xe0\x13\xb8\xf2D!\xbb$\x81\xe2\xb16\x96Y\\\x17h5O\xaf\xd2R\xaa\xa9ukVYeR&P_\xc9\"\x8aR\xd46\xc9\xb8\xde\xc1\x0d\xda\xf4\xa6\xbc\xe7Q\x1bv:\xecI\xca\x92T\xdb\xe4\xc0\x05\xd6\xb2F\xbb\x9bT\xdb\x8e\xc21\xbb\xc7\xd3\x14\xbe\xb9\xc7\xd3\x10\x16\xe4:\xe5\x96\x1a\x1d\x82\x19\x1dEa\xfd|\xbdZ\xc4P\xa5\x16A*Eh\xd3l\xc6R\x7f\xcb\xb1s\xceri\xcd\xb2\x82\xcb\x9d\xf3\xe9=\x9ev\xcd\x82\xe3Hk)\xd5\xedf\x8d\x08\x9d\xef\xb1\xe8\xd6J,\"p\xfc}\x1eN\x9eT\x19\x8e\xf8\xf4\xebC^\xfd:\xf1\xeb\xdc\xc6\xb2\x8cSY`\"q\xc9)\xac\xab3f\x07\xef?\x9c\x03\xf1r\x08i\x1a\xc5\x88I\xb4\x86E\xd7<\x17\xb3`bF\xd0\xed\xed\xd7TU6\xdbIQi*\x82@\xedlH\xd8\xe1\xc0%\xb7\xa7\xa4-\xb0G\xad\xaar\x07\x8b\xba\xca/\xa2A\xe1\xae\x0f\x9f\x83t\x8d\xa0\xee\xae(\x8f6\x87\xff\x81-0\x99\xc1K\xa9,d\xdc\xd0Ah{u\xce\x9a\xe3Y\xb7?W\x10\x05R+\x7fJJ\x95\x99\x1dl\xb7q\x14-\xe1\x88\x125\x13\x940\xf7\xc0\x1e\x18\x17l\xcf\xed\xe95Hes.\x8f\xf5\xc6#\x17\x82J\xae\xcd\xb9\x81\x03\x13\x06\xa3%\xd0I,\x98\xe5)\x13\xe2\x04\\\xa6\xa2\xca\xd0\xc0^\xa8\xf4\x1e\x8cU\x1a\xe11G	VW\x08\x94\xa7\xf7\xc3R\xeaVR\xc3\x07\xb5\xd7-\x16Jr\xab4\x97\xc7Q\xf1]6\xe7\x1dR,s\xc8\x98\xc9\xf7\x8a\xe9\xac\xa6\xea~\x06\xc4x{O\x165\xe5\xc4Dj\x8f\xa9\x87\x92\xaf\xdf\xbd\xbb\xf3D\x9a7\xc0%j\xeaV\x8d5j\xff\x07\xa66q\xa6\x06d\x0e\xb65\xcb\x94I\x8e\xcc\xe2\xe3\xa4\xa5t\"\xe8\\:x\xcd\x80~\x86\xb87N\n\xf5 \xdaY\xa7\xaa(\x94\x9c\xd6q?\x04\xe3\xea\xadJ\xd4\xcc*\xdd\x17\xd2\x83\x12\x19%a\x93\x8dc!-\xc1?,\xa6_\xfe\")R]#\xa4TJ\xfc\x9b$P\xb8\xd8\x11\xc7\x12\x9a\xe5u*\x981\xff\xac\xa8:\xed\xd6u\x1eM$\xbd\x0c\x88zU\x97\x91\xd9\x9c\x1e\x94\x92\xa0JK\xb0X\x94J3}\x82G\xa5\xef\x99V\x95\xcc\xa0\xa4\xdbJ[C\x0e\xfc	\xac\x82\x9c= \xd4R\x9a\"Q\x19\xd4\xf0g\x85\x15\xd2\xec:\xd8j\x1842\xcaj/x\n\xdc\x98\n\x9d\xca\xa5\x06Y\x15{\xd4\xc4\xf8\xa0\x84P\x8f\x9f\xe1!\x92;t\x90T\xb0\xfc\x07\xbdtA-w\xfaO\xc6\xba\xc6\xf9\x99a\x9b\x96\x8a\xbf\xad\x8eUJ\xec\xd5\xd3\xd8C\xe7y\xb5DM\x8d\xa22\xddV\xf3$U\x95\xb4;x\x13\xd8b\x14\xba\xa4\xa8\x84\xe5\xa5\xc0\xa4l\x1af_\x9b\x1d\xba2\xe8\xae\x19\x89DKY\xd6\x95\xcfR\xb0\x14\x0b\x94\x16TI\x1d\xdf\xd0%\xba\xee\x11\xd4\xec^\x83\xc9U%2\xea`\x1ae\x86\x1a3\xca\x18\xc3\xe5Q \x08.\x11\xfe0Jz\xa2:\x8eI\xc3\x91T\xdc\xc1bq\x16S\xb8\x8b\xcay\x8c2\xd9ELq\xd4\x0e\xb3\x84\xdfU{\xad\xa0^\xaa\x11\x1e\x98\xe6\xaa2\xd0^/\xba\xd6l\xd2\x1c\xb3\x8a\x02\xbf?\xb9y[K\xb4h^\xd3\xf1\x02\x9b\xa3\xe7\xa4T\xc9\x03?V\xda\xdd\xac\xc0`Z\xfbl\x8fB=nHh\x8e\x80O\xac(\x05BE\xee\x82\x15\x13b\x05\x8f\xce\x89\xee\x003!z\x15z\xd1Jz\xa2\x81Bh@0\x1a=2x\xe46\x87\x95V\x02\xbfk2nE	\x1c-\xc1*A\xe9\x84`\x19\x97\xd6\xd4HF\x13#\xa8\x03\xac\xda\xdaI\xecV\xa4_\xab[\x17Kr\x11\x19\xc6%\x9cX!\xa2%t\xff\x88\xe8\xfbf\xec\xda\xf9\x1b\x00\xee\x9a\xa01\xfb\xa1\xa2I\xe3\xb7\xda\x08.\x8f?\x1f\xa5\xea\x96\x7f|\xc2\xb4r7\xd0!q\xcd\xf97\x14\x98Z\xa5\x7fG]\x98	b\x0d\x05\xb3i\xfe\xe3SI\xd3 \xf9x\x02!\x90\x9b\x8c\xc9/\xd3M\xff\xa4\xfd,C\xfb\x0fLT\x18\xe2K\x9c}\xcf\xf9\x88ReA\x9f\xd0\xba\xb4<\xb8\xd7\x04ibE\xa3\xff\x9c(\xdf\x82\x1f\x9f\xb8\xb1\xed\xf8R'a\x921\xcb\xbc\xbbY\x9f=7\x84\xf2\xcb\x08\x93RY\xa7\xc0\x993\x19\x00M\x0fe\x004=\x95!N\xed\xb1\xf4PB$\xa5\xe6J\xd3T\xef\x9a\xfb\xce\xd5\x9du\xaa\xb9\x9b\x8c\x07\x85\xf0\xa8\x13\x8dFU:\xc5D\xf0\x82[\x93\xa4e\xb5\x83m\x1c\xc7\xc5\x05`A\xf7\xa1\xb7\xf1\xb7\xef~\xe1sHJh\x1a\x08\xaf`\xdaA}\xb6\xcb!\xf8z\xbb\x94\x0c\xdbu7\xd2 \x80s\xf2\xb7\xf1\xed\xdb\xa1Y>rh\xd69\x9eC\xab\x1a\xae\xcb!\xf8\x82UK\x9aA\xdc\xbc\xf2\xebo?\x98\xba\xd8	4\x06l\xce$\xbc\xfd\xe9\x7f_S1\x95pR\x15\x1c\xd1\xba\xca\x8aZ+\x0d\x86\x9d\xb8<\xd2\xd9X\x1c\x18\x17u\x8bI5Rak\x1f\xc5q\xe9:\xac)\xe9\x12\xe1\xba,\x89\xdf@O`,\xd35We2\xb3\x01\x8d\x07W\xc0\x15\xe8J\xd6u\\5\xb5\xf46~\xfbM\xb1\xa7\x12\xa9Y\xf1\x1a\x9aG\x89\xc0,\x08d\xc6\xba\xa8\x16\xfb\x8d\x9f\xd4\xcad\xd7e`\x08\x18\xce\xc0\x01\xf2|\x06\x86\xa1>[\x0f\xdc\x14\x14\xd7\xf4\xe9\x94\xb9f\xe2_\x1cC\xa0\xfa)\xa2\xa9\x9f\xfb,\xdb\xa2\x04\xcd2y\xf1\xc8\x1f\x9c73\xae1\x15\xf6\xe4\x82\x84e\xfe:Z\xba\xa1\xa1\xed-\x8b\xff\x97\x0f\x05\xc6r\xbbh\x1f7V\xa6np\xfc\x0d\xd8S\x89Mw\xe3\x120\xbduM\x0cr\x9a\xc7\xd4\x03\x8d\xa39\x02\xd1\x83{\x80\x0e\x99n\xa4\xba\x91\x84\x19`\x92\xc2\x1b0\xa4\xd6\x94\xc6\xb9\xfa\x01\xc0\"\x80\x11*m\x9f\x89\x0ejQ\xbb\x7f!\xc3\xbb\xeb\xa6q\x8fqkO\xd0m\xbbvPk)3\xa4b\xad\xba\xa1\x19\x0b\xf5A\xe9\x82\xc9\xd4\x8d\xe5LX\"p\xaf)Bv\xf4L\xeb\xeb)\xdcP\xa5wE\xfd\xa6\x01\xdd\xc4~\xbc\xfb\xe97)\xd0\xb2\x84\xeed\x89\xc6R\xf0\xda\xda\xd0\xf8\xe8\xd1\xb8>\xf2\x994EfZ\xc46\x8c`\xa9\xe5\x0f4X3\x99\xedO\xe3\xec\xf3\x80\x93\x19n\x14;\x9f\xe7\xa4\xb1\x8c\x03\xe9\xf3\xbdT\xad\xce\x8c\xbe=\x9b\xcf\x9b\x82\xaf\x18\xb0\xa8\xc3\x07&\xab/\x9c\xa8\xe6'\xa9\xb3\x13\xd4\xcc\xe4471\x05&\xa55\x14\x99\xe9F\x96\xf1\x84\xd3\xf0\xf7!\x81if9\x1d\xa7&c\x14\xd5\x96\xf6\xd1N\xeb$3.\xd4P`\xa1\xf4	T\xf3\xc6\xc6\xef0\xf5\xdd\x96\xae\xd2\xae\xd7\x84\x9b\x8c\x17o\xd7Z\x8eB\xed\x99Xw\x92w\xe7\x9a	5\xcb\xcfl&^\x9a^\xd3\xfd\xcf\xc0\xc3\x9d%Dpv\x168K0\xd3f\x067\xf9\xab\x0b\xcf\x80\xea\xea\xd23\xa0j\x1f\x05R/\xd9\x81\x99\xc5qI\xd5'\xc5P\xa5\x1a\x00\xbd&9\xb85\x0f\x1fC^\xd5\xfc\xcfQ8?\xd2\xe81\x9c\x02\x82$\x17\xc6\x81\xf34\xbe\xa09\xaa\xae\xfa\x8d\xca\xed\xd01]\xc1\x9dT\xda!\xb3\xbfQkkF\xcdC\xab\xff\x14\xdbp\xb1\xd5\xc7\xc7K\xc5\xd6\x87|i\xb1\xed\xc3\xdb\x9c\xc7\xfap\x06\x0e\xe4`\x7f\x9c&\xd3\xa1\xc6\xe5E\xf8\x90GKh3\x18\x0cZ\xcb\xe5\x91\xb4]\xcf\xfd#\x82\xe6=o\xfd_\xfd\x82hx`\x16\xb7wq\xb1\x08\x03\xeaf\xe10\xef~\xe1C\x90\x7f\xb6\xdd\x9b\xf6\x19	\xf5\xdb\xf6)a\xc7{\x8e\xb6\xe9T\xee\xcd\x92\xabOB$\xa9\xe4c\xf5\x9d\xf6\xc1\xfdN\xc2\xd6)\xefc\xc6\xca\xcf\xf1w\xd2\x03\x84\xbe\xf2gd\xd7\xca7\xd6\xd9S\x99\xb3\xb3\xce\x1f!f\xbc_\xa3f\xdc?\x15\xe2\xfb\x7f@\x1a\x08@P\x81\xda\x88\xe5\xf8\x05\xfe\xe8}\xfd\xc8\xae-\x995\x07\xe9\x03\x13\x93]#\xd8\xd8\xb23\x82\x9cjar\xdf\xba\xf3zt\xf6\xd5o^a\xcf\xd2{\x94Y\xd4\xbe\x89m~\x87-\x9c\xc3\xf4\xb1\xabM\x1c\xe1&6\x9e\x91U\x1b\x19f0\xb0\xf2\xbc.\xad\x999\xb2\xd2\xbd\x9ej\xff\x98\x18\xe6Rr\xba\xdb\xc9z\x17;\x9b:\xc4\xd8\x98 c'~L\xe2\xab?'\xb0\xd6[2)O\xcdJr\xbf\xf7\x1e\xde/n\xe3x\xd1\xec\x87J\xc3h\xc7\x17\xf9\xb2}\xed\xed\x7f\xc9\xb3\xfa\xf0\xa9y\xf5\xfd\x15\xccI\xfd\xc4\xa5}\x05\xff\x0d/\xbf\x8d\xe1+7\xd5\xbe\x82\xe7\xe7\xff\xe3\xad\xb4\xb1G\xa6\xda9\xab\x06`_\xb1\xa0\xce\x1eI\xe7\xab=3\x18p|/\x7f\x96(\xe8\xfa\x81*!R|\xb2\x9a\x11_?\x00w\xc5\x0c\xaeq\x9c\x07}K\x89\xe3\xa5\x01\x1eY:	\xdbm<H\xc01\xa8\xd3\xfc\xcdO|\n\x9bM\xc6\x80\xacQN\x0e\x18\x84\xfd\x13T\xc5\xcf\xd0\x06q)Q\xc3\x86{\xf9:k\xf4\xf5i;\xab\xca\xc5\xec\x0d{rVs?\x97g}x\xce\xac)\x83\x8b	>V\xf1\x12\x8bs\xe1\x9c\xcb\xfa1\xa3\xe6w\xf0\x0cl'\xa1\x0b\x93M\x8fD=\x9cDK\xf7N\x0b\xdc\xe7k\xd0~\xbe\xe6\x02\xec\x96\x92fi\x9a2q\xb1p\xaf\xc6sl>\x88\xa5\xbf\xd2\xb2r\x8f\x90\xa1\xa0\xbb\x82\xe0\xf7(N\xe0>\xb5i\xde\xe5\xd1\x9d\xdf\xbd\x0d\xcf\xa0*\xe11\xe7i\x0e\xecp\xa0\xebE\xf3\x88`\xb3\xd90a\xd4k8\xfe\xc5K\xe0\xa6}=\x0b\xfbS\xdbj\xce\xea7n\x17\x01\xec8\xc3.\x98\xebB3\xcf\xc6\x0f\xf1e\xc5\xfa/\x96&8\xc3\xff\x9a\xeb\x8a\xd7\xe0;=\xee\xe6\xecnI\xae\xb2\x7f\xaa\xcd\x9c\x1fFl/\xfa#\xa8\xf7E\xbf4\x17\x8f\xbf\x1d\xb51\xbf\xeeX\xb5\xa7\xe9\n\x9a\x8b&NM\xbb\x82\xab\x7fR\xbb	\xee\n:\x9bk4\xb9\x12\x19\xc5\xde\x1d\xe9\xf6c\xd9\xe6\xff\xe9\x08\xe9\x86\xff\xf1\xa6'\xd9\x89n\x01\x93\x91?\xc0\xb5\x9e\xf6\x87\x04\xbe\x97f\x84\xb5\xc3a\xb3\x0d\xde\xf7\xaf-E\xbf46\xe3\x8doE\x08\xd6w\xec\x81=\x1et\xce\xb4\x19\xa1\x03+\xa7lB\x06\xcf\xeb\xd5\xda\xee}S\xd0\x7f\xf3\xd7/z_\xee\x05\xc3x\x1e\xd9\xa9\xb4\xed\xab\xe1\x04\x1d\xaa\x07g\x85w\x19=\xc7j|@.)\xd8\xfa\xc2}a\xda~$\x0c\xfd\x17\xa6\xf3\x9f\x9e\x06}r\x19=\xce\xf5Y\x8a\xc94r\x91u7\x15\\`9\x98R.r\x1d\xfaI+u\xdf=@\xaa\x1f\xe6\xb4\xbf\xc6\x1e\xa9\xa7\xda\x19H\xef\x86\xfa\xaa?\x84\x8dm\x9f\x97\xe3\xf4\n\x12\xfbV\x9eU\xc2\xb1\xf8W\x00\x00\x00\xff\xffPK\x07\x08\xc7\xc1\xf8_q\x0c\x00\x00I4\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/core/examples/blocks.mdUT\x05\x00\x01\xf0b\xe7[\xd4\x94]O\xdb<\x14\xc7\xef\xf3)\x8e\xc4u\x1axx\x86&\xdf\x0d\x8a\xb6I@\xbb\x16\x81v\xd5\x9e:\x87\xd6\xc2o\xd8NJ\xbf\xfdd\xb7\x0da\x0d\xachh\xd3|\x81J|\xfc?\xef\xbf\x038\x7fDe%A\xe5qN`\xee\xc0\x19s\x0f3i\xf8=\xf8`\x1cA-0\xfd\x8a\xf7\\\xa2\xf7\x99'\x82E\x08\xd6\xb3\xa2\x98\x8b\xb0\xa8f=nT\x11_\xae\xff\xcc\xa4\x99\x15\n} W\xf4\x0d\xaf\x14\xe9\x80A\x18]$\xe1\x9e*\xe1\xce8()\xa0\x90\x9ee\xd9\xc1\x01\xa8\x95\x7f\x90@M8B\xcfaH\xce\x0b\x1fH\x87\x1b#+Eg\x12\x85\x02\xa3w\x82\xcc\xb2\xe9t\xbaB%3\xb4\xe2&\xbe2\x9aA}\x94\xdd\x0b]2\x18\x93\xab\x05\xa7LQ\xc0\x12\x03\xb2\x0c@\xa3\"\x06K\xe3J\xeb\xc8\xfb<\xf9\xcf\x00$\xce(\xc6\x04\x00\x80\xd6\xb6L2o\x89\xc7\x0bk\\\xd8X\xe4\xe9\x1f\x06\xc7\xc7\x87'\x19\x80'I<\x18\xd7\xf9<~\n\x82\x1c\x83\xad/.\xabX\xa3\xafC\x06WFS\x96\xe7yw\x02\x9d\x85\xe8H'	\xe7\xb6\xcey2\xd8+\x9bMo\xcfbk\xaf\x92J\xacn\xce\xc9.\xf2T\xe2\xbc$I\x812\x00\xe4\x9c\xbc\xbf4%%\xc9\x1cF\x84\xe5\xad\x13\x81\x06\x9aG\x03G\xdeT\x8e\xd3\xc6\xa3\xa3\x87\x8a\xfc\xb6V\x8d+\x06\xff\x1d~\x16;\xd9\xa2\xb5\xbehR\xee\x93\x95f\x15'\xe7\xbd\xda\xe6\x83\xc3@\xf3\xd5\xda(\xac,1\x18\x11w\x84)\xb9@\xcaJ\x0c\xb4\xben\xfb\x8c\xa7\xad\xffBow\xfb\x0b\xb0\xf5\x1d\x0f7:\xa0\xd0\xe4\x1a\x99\x1c\x84J\xf5H\x0f\xd8\x87\xdeI#\xd4\xeag\xf3\x8dt\xfd\x14@\xbe1\xb9\xfc>\xfev1\x19\x0d\x06\xd7\x93\xe1\xa7\xf1\xf8v0\xea76\x005\xca\x8a\x18\xf0\x05\xea9)jnZ#\xbc\x16k\x82\x1b\xb6\xe7y{\xba\xa2\xa9\xd3$^\x9aJ?Wz6\x89\xcd\xdc\xe6\x9b\xde\xb7DU|:\xc4\xb0`P\xd4\xe8\n)fE\xdb\xc5\xdaA\xabX{*\xdb\xaeea-\xc7i9\xae\xba\x16f:\x9d&\x185\x8d\xfd{@\xdao\xa6\x9b>nA\xf4\xf1p\x7f\x0c\xdd9\xa3\x03\xe92\xdbn\xc3\x85\xc1\xf2\x14%jN\xee\xf7a\xb4\xb4\xff*\x89\xe81\x90\x8e?#\x8ff\x14\xf0mP\xda7\xdb?\x85\xa3V\x9f\xf7&R\xa3\xc5\xfe\xef\x9d\xf4\x8er\xb4\xc8\x17\xf4\x13\x9ev\x1dv\"*2i8:\x1f\x8f'\xfd\xd3\xc9\x97\xc1\xf8z\x17P\xbb@\x7fE\xe2\x1d9\x97\xd6e{^J\xeau\xd2=\x85\xfe&\xda-\x97\xcbb\x11\xd4/`\xb7\x97\xf8[\x80\xd7^\xcaH\xbb\x1f\x01\x00\x00\xff\xffPK\x07\x08\xc3\xf1\xcb\xbc\xa5\x02\x00\x00\x02\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00roles/kubernetes/core/examples/filesystem.mdUT\x05\x00\x01\xf0b\xe7[\x94TKo\xdb8\x10\xbe\xebW\x0c\xeakm\xb9\xbb\xd8\x8bnE\xec\xed\x06\xd84\x81\"\x14\xc8\xc9\xa1\xa4\x91=0\xc9!\xc8\x91\x12\xfd\xfb\x05%?d;-\xb6<\xf81\x8fo\xbe!g\xbe\x19\xac\xdf\x95q\x1a\xa1\x0dj\x8b\xc0\x0dx\xe6=4\xa41\xf4A\xd0@\x10\xf6\x08\x1d)h4\xbe\xff`\xdd\x1aL\x02\"\xecD\\\xc8\xd2tK\xb2k\xcbE\xc5&\x8d\xb9\xe3G\xa9\xb9L\x8d\n\x82>]q\xd5\x1a\xb4\xa2\x84\xd8\xa6g\xe8\x85\xa9\xa1a\x0f5\x8a\"\x1d\xb2$\x99\xcd`\xc5\xd5\x1e=\xe4\xb8\xa5 \xbe\x07<\xf1#\xbb\x9dP\x00\xb6\x1fSM\x92\xd7\xd7\xd7^\x19\x9d(G?\xd0\x07b\x9bA\xf7%\xd9\x93\xad3\xc8\xd1i\xaa\x06*wl\xc5\xb3\xd6\xe8\x13\x83\xa2j%*K\x00\xac2\x98\xc1\xbe-q\xee\x0f,\xe6\xdd\xf2\xe0\x08NUG\xefX5\x01\xd0\xaa\xc4\xc8\x1f\x00@9w\x95<\x98\xbb\x13\x91\xe5\xf0?Fx\x8b\x82aA\x9cV\xba\x8d75\x0f\xe8;\x8a\xf8\x9f\xc4\xb7\xf8)	\x0e\xab\x08\xebG\xd2!\x83?\x13\x80\x80\x1a+a\xff\x1b\x05\x05\x8d\xd3JpL\x99v\x1b\xcf\x94\xff/ ?\xea\xe3\xff\xf7\x12#\x8f\xfd\xc4S\xb1\x15E\x16\xfd\xa9\xf0\xfcp\xf57E\xc9\xa8\xed\xc4\x9e\xfdq\xf2x\x0c\xdc\xfa\n'\xe4\x014\x19\x92\x0b\x0b@\xe5\xda\x0c\xbe,\x97\xe6\xc2j\xd0\xb0\xef\x07\xc7\x03\x9d<h\xbbs\xf2\x91T\xbe\xfev\xff\\\xe4/\x9b\x7f\x8a\xe2i\xf3u\xb5\xca'H\x9d\xd2-f\x90\xfd\xb5\\.\x7f\x9e\xf9\\<\xe6_\xbf\xad7\x7f\xdf\xff\xbb~~y.\xd6\x0f\x9b\xfc\xf1\xb1X\xdd\xe7\xeb\xbb\xe21\x7f\xb9\x05L;\xe5SMez\xfb\x0e\xc3\x12<pk\xa7\x9d\x1eK\x0e\x176\x1f\xd7\xe1\x0cjb\xf4\x93\x92\xdd\xaf\x80\x1d\xfbK\xc4\xd3;=\xb1\x97\x0c.Z\x84\x9f\xbd\x18\x80\xf3,\\\xb1\xce\xa0\xb8{:\xd8G\xd27\xef\xfd\x11\xdb\xf3\xa2O\xdf\xb1\xf6\xd4\xa1\xcf\xa0B\xb7[\xc4\xed\x8f\x03\x17\xbf'1M(z\x87c\xcc\xc4\xcc.\xae\xfc\xd5T4\xe1\xfb\xc8\x9fy?\xdfj.\x95\x9e\x0fz2h\xdel\xe0\x175Qvx\xa13\x0e+j\x08k {\xed\xbb\xcbW\x8b\xcb\xc9\x1b\xf7\xe1\xfbY<\x86j\x91\xde\xa1\xc2`\x86\xb7\x1dz\x1c\xd0\xf2(k\x874\xa0\x005:\xcd=\xd6\x17\xb03({\xa8\xb1Q\xad\x96!\xcb)\xd9\xc5\xe8\xf43\x94\xad@\xcf-T\xca\x02w\xe8=\xd5\x08\xca\xd6\xe3\x04\x80:6P\x8dI\xb7\x0d\x96\xfdAoO\xc0J\xc4S\xd9\n^\x918\x05\x986\x08\xe0;\x05\x89\xca|\x89\xf7\x19Xv\xe8\xdf(\xe0H\xe1\x08=)\xa9b\x17JF\xb07\xd2\x1a\x1aE\xfa\xaa\x9a\x1b\x877\xb0\xc14\xfeN\xc9\x06\xaa1\x8d\x97\xd9\x84(\xfc\xc9\x7f\x01\x00\x00\xff\xffPK\x07\x08\x96r\x91\x9c\xd2\x02\x00\x00\xd2\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/core/examples/object.mdUT\x05\x00\x01\xf0b\xe7[\xca())(\xb6\xd2\xd7/\xca\xcf\xcf\xd6\xcb\xcc\xd7O\xc9O.\x06s\xf4s\x13\x8bKR\x8b\xf4\xf3\x93\xb2R\x93K\xf42Jrs\xb8\x00\x01\x00\x00\xff\xffPK\x07\x08\xe2\n\x87\xb80\x00\x00\x00-\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00=\x00	\x00roles/kubernetes/core/files/etc/kubernetes/rook-bin/umount.shUT\x05\x00\x01\xf0b\xe7[\x9c\xce\xbdN\xc30\x14\xc5\xf1\xfd>\xc5\xc1\xb5T\x18\x90\xa7\x0e\x08e\x82\x0e\x1d\xe2\x0e5s\x95\x0f[\xb1\x92\xf8F\x8e\x0dC\x92wG \xc1\xc2\x82\xba\x9f\xdf\xd1\x7fw\xa7j\x1fT]\xcd\x1d\x919\x95\xc7\xf3\x9b\xb9\x9e\xf4\xf5r|9\xeb\xd7Kq \xda!\x8f\x9cCB\xea,\"s\x8f\xc6N\x1d\xdey\xc8\xa3\x9d\xc9qD\xa9\x0d|\x80\xbc\xaf>z\xec\xe5\x13\x8a\x02\xe2k%\xb0L\xd1\x87\x04y\xd8\xf6PS\xe4F\xcdvp\xea\xfb\xd1\x07\xc7\x0f\xcfh\x99\x80\xe4G\xcb9A.\x7f+\xb6\x9f\x02!\x97R\x9bM`]\xff\x07\x1e\x87\x1b\x8c\xfb5\xd4r\xb0\xf4\x19\x00\x00\xff\xffPK\x07\x08\x15\xdd1\x9b\xae\x00\x00\x00$\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00F\x00	\x00roles/kubernetes/core/files/usr/lib/systemd/system/rook-umount.serviceUT\x05\x00\x01\xf0b\xe7[\\\xcd1N\xc3@\x10F\xe1~N\xc1\x05l\x9f`\x0b\"R\xd0& \n\xcb\x85\xb3\xf9\x83G\xb6g\x96\x99Y\x88o\x8f\x14\x8b\x86\xf6\xe9I_\xff.\x1c\x03\xbd\xc0\xb3q	VI\xa6:7\x19ez\xaa\xabV	\xa7\x13\xbe*\x1b<]5\xcf\xb0\xd6a\xdf\x9cA\x07\xdc\xd4\xf0\xbf>\xdf\x02\x96\x04\xf1\xa367*\x0b\x0b\xda\x18\xed\x13A\xd4\x9f\xf7k\xa0\xe3\x1d\xf9\x1cZR\x87\xc8\xdd\\/0A\xc0\xbb\x87\x7fa\xe9v\xbe\xf5\x89\xde\xb6\x82\xa4\x02\x9f4\xe8\x84udy(\xc7;G\xda\xe0D\xfd\xabx\x8c\xcb2\xd0\xc7(\x81\xebaKk]\x82\x9b\xea\xb0?\xfd7\x00\x00\xff\xffPK\x07\x08\x931\x0f\xd5\xaf\x00\x00\x00\xed\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/kubernetes/core/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[T\xcd\xb1\x0d\xc3P\x08\x84\xe1\xdeS\xdc\x02^\xe0-\x13\x11qU\x0cH\x80\x0bo\x1f\x89\xa7\x14i?\xf1s'\\\x8c\x0b\xc9+D!\xaeHVK62\xe2s\xde\x16\xb7\xf7\x01\xd4SM\xd3u\x00\xf85\x7f\x07@\xb5\xf4\xbc\x9a\x9e:H\x97\xf7E]xX\x03*\xb4\xf0\xd7\x1e\xdc\xfc\x0d\x00\x00\xff\xffPK\x07\x08\x92L2\xc6[\x00\x00\x00\x85\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/kubernetes/core/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\xd2\xd5\xd5\xe5JI-H\xcdKI\xcdK\xceL-\xb6\xe2R\xd0U(\xca\xcfI\xb5R\xc8M\xcc\xcbLK-.\xe1\x02\x04\x00\x00\xff\xffPK\x07\x08\xccd6\xd0*\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/kubernetes/core/tasks/azure.yamlUT\x05\x00\x01\xf0b\xe7[\x84\x93\xc1n\xeb,\x10\x85\xf7~\x8a\xa3t\xd1v\xe1\xb8\x7f\xa5\x7fc\xa9\xab\xfb h\x82'\xf1\xdc`\xb0\x18\x9c\xd6\xad\xf2\xeeW`'m\xa5\x9b\xdb\x951p>\x863\x87\x1a\x9e\x06n\xf1\xabg{\x049\x87>hRPdh\x12\xe7@'\x12G;\xc7\x15 \xde\xba\xa9c\x13\x83\xe3\xb6\x02\xb0\xaa\xc7\xc86\x03\xcaT\"=\xaa\xd9\xc70\xb4\x19h\n\xd0\x90\x93SFT\x97\x13I\x95cB'\x91m\nQX\xc1o\xa2I+`/\x17\xfeH\xa9o\xb1\xf9\xf8\x80$\x1ep>o\xca\xb4&J\xdc^\xc5s\x05\xbcJ\xeaM\xde\xa4\x8b\xb2F\xa3\xf1\xd4\x1c\xa7\x1dG\xcf\x89\xb5\x19\xc8\xcb\x9e\xf3	U\x8d\x9d\x0b\xf6\xd8VU\xde\xb8\x14\xf4J\x920\xf9$\x0eY\x04\x1aE9\x9e8B\xf4\x9b\x0b\xc0\x14e9$\x0f\xddR_\xd6\x18\x1a\xc5,\"\xe3\x82%\x87\xf3\xb9\xe9\x99\\\xea\xdf7\xab \x97>\xa9\xb1\xa1\xe3\x16\xcfOO\xeb\xb4u\xc2>\x19\xcb1-\xbc<2\x9d\xc4\x8cX\x7fuK\xdd ~kc\xba:qU\x1ey\xfeQx\xe4\xf9*\x8c\x9c\xb2\xe9-\xfe{.\xff\x1d;\x9a[\xfc\xbf.\x1eD\x13\xc7\xf6\xd3\x05\xb3Z N\xd2\xbc\xb8\x90\xbdj\xf1p\xbf\\\xe9\x1e\xe2ol\x7f\x04\xf9\x0e\x0f\x7f_\xdc.r\xbc\xbcd;\x1e\x0b\xfa\x0ed\x93\x04\x8f\x9e\x14>\xe4\x80\x9d$L\xba4\x1e)\xc0\x86a\xa4\xc8[h\x1f&\xd7a\xc7\x18I\x15{\x12\xb7-\x0c\xdb\x93?pg^{\xf6-|\xf8\xda\xeb\xc8\xbe\xe3\x08z\x9fJ\xceC\xa4\x03\xc3\xba\x0c\xf8LI	3\x0f\xa3\xcbY\xbbt/\xda\x16\x9b[\xc1j\n\xb0^\x81u\x01\xb2ng\x1a\xdc\xf6\xf7\xf3\xa5a\x1dk\xee\xf1\xcdx\xfe\x83\xb2\xf9z	\x1b9\x9b\xc1;\xfd~\x05^J\xd7\x9e]\xcef\x17\xec\x91#\xf8\x8dm	\xa9\xe3T\xbe69\xd4u\x19\x05\xbf\x97\xc3Ks\xa2\xd88\xd95\xeb\xae\xe6s\x0d4\x8enF\xbd\xbf\xfd\xa8~\xaa\xfa\x0e\xec\xbb\xe5\xd9\xe5\xd7Z\x9a\xb2\xb9\x1f(\xc7\xacD\xe7\x10\xc34\x9a|5\xdd\xa0\xfa\x13\x00\x00\xff\xffPK\x07\x08\x15\xf987\x1e\x02\x00\x00\x99\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/core/tasks/defaults.ymlUT\x05\x00\x01\xf0b\xe7[\xccWOo\x1b;\x0e\xbf\xfbSp\xdd\x05&9\xd8N\x83n\x0f\x03\xe4\x90\xb6\x97\x05\x16=l\xdf\xad\x0d\x04Y\xe2\xd8jdi\x9e\xc4q\xea\xe7\xf8\xbb?P\xa3\xb1\xc7N\x9c8E^\xd1\\\x12\x8dD\xf2G\xf2\xc7?\x19\xc1\xd4zu[\x0e\x06\x00#\x88H\xa2\x92\x8a\xca\x01\xa4\x9f\xb9\x8cBa !\x9d\x16\xb7\xb8*a\xb8^\xc3\x99\x9b\x19\xf7C\x187\x0b\x18\xa3P\xdeQ\xf0\xd6b\x10d\xf3{\xeb\x95\xb4\xa2\x964\xbf\xd7X\xc9\xc6\xd2YQ\x9c\xc3\xbf\xae\xa0(@:\x0dO\xaa\xb8\xc5\xd5\x93\x1a\xce\xe1\x1e\xa6\xde[\xd8l\x86-\xf0\x9d\x13|rr\x81%Le\xc4\xf7\xef\x00\x9d\xf2\x1a\xe1\x8f\xff}\x81[\\A\xe5Ck\x1c\xb2q\xd8\x19\xcf^\xb7\xa6\xa5\"\xe3]	\xc38GkAI\x82\xf5\xfa%\xb8a\xb3a\x9c-\x8a{\xa0\x00\xc5\xb7P@\xf1\xcd\x15\xedq\xa4\xd3a\x98\xcdNQy\xc6\xed|\xfe\x10pf\"a(\x8f[\x9d\xbe\x7f\xb7\xb5|\xfd\x9c\xfb\x9c\x19S\x19%	\xff\xd10\x1c0\xe0W\xc6!\x99\xce\x81\xb8\x9b\xa3+\x1fP\xf88_f\xe80pl^N\x1c0\x158\xef\x10\xea\xe0\x97F\xa3\xce\xc8S\xccJ\x18\xe6\xa8%\x1c\xda\x04\xd8l&\xeb5l\xfd\xe0\xcf\x9c\xc1\xcaXd,/\x8d\xd8\x0b\x02\xc4f>\x9c\xec\xf7\xa9\x8cy\x05\xffU\xa0_\xe1\x7f\xb2\xf5\xa1O\x10\xe7\xe9\x08I\x0e\xbb\xe1\xb3\xcaO\xe8\x90{\x05;\x8e\xa4}C\xc2\x1a\x87\x91cx\x88\x03\xd0F<\xc9\xae\xf8\xb0\xaf\xec\x1ez]\x93\xfb\xe5wo\\\xea\xa0\xdc3O\xf4\x87\xa1\x9c\xeaP[y\xaf\xe5Q\x9b\xa6\x97\xb84x\x9c\xd0F\x81lh\x0es\xaae\x8cw\xfa\xf9\xc6\x979[\x07\xe3\xa8J\xce\x1fG\xcb\xfa\x05\xeb\x17M\xc4\x90\xa9[\xfe\xfb\xcc\xd7\xe8b\xb4\x90m\x8ed\x1d\xdeBq\xa2\xaa$\xe4\x83\x86\xcd\xa68\x1fn\x0bap*\xd3w\x9a:\xa7\x7f\x82\xce\x8fh)O\x0eF'\x91\xf3w\x98\x9f;i\x08\x1aG\xc6\xc2m3E\x90\xb5\x89\x18\x96\xdcF\"\xc8\xa54VN-&\x94M0\x1d\xdc&\xd8\x16\x01\xcb\x08Y\x1b\xd1\n\xb5\x93\x86[\xca\x1c\xa5\xa5\xf9_\x1d\xbd#Ij\x18\xa2\xc6\x12./.\xf2ge\x0d:\xeaq\xfb\xa0+\xf11\x8e\xa5^\x187V\x81z\xf5\x92%\xb7U\xfe\x84 3\xbd\x13\x0cH\xc1`,\xe1\xede:k\xb4rU\xc2\x7f\x0e\x12\xba\x8d\x82\xc8!0\xd6\xd0\xaa\x8d\x02\xc7\xaa\x84\xb3\xa2u\xa9\x00\xe3\x8e<?O\xcb\xd5\xd9\xe3\x97\xe3V\x1c\xae\xae8\x1c\xe7I\xf5\x1bh\x07<\x17*8\x0fu\xc0\xa5\xf1ML\xd1C \x0f\xca/j\x19p\x0cq\xee\x1b\xaba\x8a\x89\xd6PIc\xc7I\x87\x9aK7C-\xba~\xda\xcf\xb5\x8c\x11\x03\xc1$\x86\xe5\x84\x13\x17\x1c\x12\xc6\xc9B:Sa\xa4\x08\xf8\xc3D\x8aI\x0f\xb7\xff.\xdb\xbc8\x94\xc7\xc5z)\xc6\x12\xb4	\xa8\xc8\x87\xaeo\xdb&\xd4\x9d\xa2\x18\xd4\x13\xd9BR\xfa Y]>\xf8\xaa7\x0b^\xa8\xb3\xcf\x9c\x03\x9d\xfc\xea	\xa5\x07:\x93H\xf0\x9e\x84\x92{C\xf2\x98v\xf9p\xc3\xd9\xa5cX\x19\xa7\x81\x14pJ\xc9\x8f\xb5\x89\x94\xe2\xdeq\x9c\xb3\xe0t\xb9=\xb5\x99\x88\xfd\x0f\xacm\xd2\xc40\x89s\x19pB\xea\xb1;k\xa6\xc7o\xde\xbf{\xe4\xee\x81\x04\xc3\x12\xb4\xaa\xb1L\x7f\xeeC\"\x0c\xee\x01\xaa\xa2\xe7U\xb1\xbd\xdb\x05\xa8\xbd\x16|\xbd\x0b\xcca[\xe4\x1fR\xa2\xf7VT\xdej\x96\xe7\x94\xf7\xbe\x8f\x19V\xfczq3Nk\xee=\xf30\xcf\x80a\x7f\xc3\xd0.\n)\xa5\x14\xa9\xf2\x05:no\xba_%\x01\x9d\xc6\x00\xca\x07\x84}\x8e\x13.j\xcb$\xdfc\xc9\xb1\xb2H[\x15\xe1b\x9ca\x8cWra\xc7\xdf/\xbb\xecj\x8c\xdc\xf4\x8e\x96\xd5\xa3\xf2\xad\xf0\x9d\xa1\xb9\xe0\xbb\x98\xa9\xef\x03\x8a]\x19wll=N:z~\xbe\x01t\xba\xa5\xe4\xa0{3,\x16\x92\xd3\x92\xba\xd9,\xf8\xa6\x16l5\x0e\x07\x83\xfd\x7fJ3se]\xdb\x15|\x94\xd6(\x0f\x1f?\xffw\x1b\xa8\xd6p\xb7jj\xafn1\x00\xfe@\x95&\x85EJ\xbf\x15Y\x18\x8d\xd2_\xdeUfv5Y\xca\x96\xa6\xf9\xd5dw\x07\xad\xad\xf5\x9a\xb7\x05\xc5\xc3\xa6\xb6+QY9\x83\xcdfT\x1doK\x13\x95\xe0\xe5\xb0=D\xff\xe9\xf3\x17\xb8\xbe\xbe\xbe\x86O\xcc\x84\xdf\xd2\x03\xed\xe2\x88\xc9:Jd\xed\x13\xe0D.\xe7\x8e\xafl\xc3\xd9\xe5\x91\x1eP\xea\xd5\xeb\xbb8C\x02\xe7u\xda\x0cg\x01k\xf8?\xdb\xe9\x0e\xa3%|\xf6\xd4}\xbaS0\xb2\x87\xfd2\xe1\x12\xca7\x8e\xf2M\x1e\xd4\xcc\xef\xc4\xc8\xf8\xb5H\xdbFv\xa6\xb8\x81{\xb0\xe8f\xe9\x9f\xdaV]\x9e\xe6o/\xdb\xfd\"\x8f\xeaaOw\xb7\x05\xdd\x83q\xc4\xc3\xf79\xd5\x999\xc6)\xdbh\x14$\xe3mL\x91O\xd9\xd8\x8bu\xcar^\xc2\xa0\xb6\xd2!\xf0\xe07\n\xe3\xeb\x07\xfc\xa79\x95\x01\x8e\x12\xc0Q\x07\xf0a\x95t\xee\xf4\xba\xe0o\xe4\xc5\xeb\xf4\xc6\xed\xdc\xe1\xf7_\x8b\\@\xc5\xcdnP{\xcawu\xc0\x80\x7f6&\x1a\xc2\xe2f\xb0\xed\x9c\xc6-\xd1\xf1\xba#\xe6>R\x82su\x05\xb9\x9d^\\\\\x14\x83\xbf\x03\x00\x00\xff\xffPK\x07\x08\xdc>\xf9\x1d:\x05\x00\x00\xd6\x13\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/kubernetes/core/tasks/dns.yamlUT\x05\x00\x01\xf0b\xe7[\xbcT\xb1n\xdb0\x10\xdd\xf5\x15\x0f\xee\xe0\xa5\xb2b\x04]\x04\xa4@\x9blE\x8b\"\x19:\x1a4u\x92\x88P\xa4\xc0;\xb91T\xfd{AE\x96\x1b\xdb\xc9\x14t\xb2I\xbe\xe3\xbd\xf7\xf8t)\x9cj(\x87j[\xbb\xc7\xad\x0ft\xf7\xe3\x01\x8dr\xa6$\x96\x04\xe0\x9a\xac\xcd\xb1(\xbc~\xa4\x00z\"\x8d\xc7nK\x96d\xfc\xd5b\x91\xa6\xe3?\xefJS\xddd;\x152k\xb6\xd9\x84\xca\x8egS\x97\xbeG\xe9\x83\xa6\xcd\xb8\xdc\x94VU\x18\x86\xb4D\xc6a7\xc2\x83#!\xce\x0e48\xd3>P\xe1x\xb5W\x8d]$\xc9\xcc\x9a\x99\x82\xcc\xb4\x7f\xfa\x82\xa1\x02!\x90*\xf6\xefM\xbe\"A\x1b;\xa4n,Ny\xcfB\x0d\xfe\xa0\n\xd4b\xa2\xb8H\x80@\x95a\xa1\x90\x1f67\x07B\x81$\x18\xe2\x1c\xeb\xab\x04(\xc8\xaa}\x8e\xeb\xf8\xbfsbl\x8e\x04\x00R,\x96\xebl\xbd\x84q/oX\xb1\x14\xbe\x93\xc5\x8c\xba\xef\x9c3\xaez\x039{u[\x93~\x8c\xc6\x83\x9e\x0c\x1b1\xae\xc2\xb7n;\x1a\xa7\x82\x98Ri\xe1\xf7\xf6\xec\xc4\xaaha\xdf\xc3\x085+\xd9\xb7\x84a\x98\xd7\x91%\x86!j\xb3\xde\xb7y\x12\x15\xf6\x88\xb0\x1c\xcb\x07\n;\xa3\xe9\x8b\xd6\xbes\xb2\xfc8\x89Z\xc6\xdb\x0b\xc7)\xab%\x86\x97%\xf7\xde\xd290\xc4\xddS\xe8\xad\xed\xe2{]\xae\xd0\xe1-\xfcW\xe3\x8a\xf8\x02\xa7e\x17\xe9\xbc\x06N\xc3\xf6\xbc\xc7\xe8\xe0w\xd5\xbeD\xa7\x97\xee\xbe\xa3\xd6\xfa}C\xa7\xce\x1c\xc1\xc7H\xc6\xfdM\xccIt=>x\xa9\x8c\xa5b\xf3\xbb&wz\xba\n\x1a\x9f\xb1>\x86\xe8\x9e\x1a\xbf\xa3\xff\x9e\x9b\"\x9e\xd3\x1c\x95\x8b\xf9\xb9\x18\",\xfa\xfeL\x13qg\x85'\xd8\xb3\xec\xb18h\xdc\xdc\xe0\xeal\xbc\x1c\xd4\x1e\xc6\x11\xd4\x96\xc9\xc5\xe1X\x1aK\xf9\xf81\xb6J\xea\x1c\x8b\xd7'\xd8\xf4\xd2\xd3\x04\x8b%,Jb\x93\xc3e\xc0\xdc\xf8\x9722~\xaa\xb1\xadxh\xdf\x10\xba6\xd2UF6\xa5\x0fSW\x1f$\xc7\xa7\xebqQ{\x96\x7f\x04\xeb\xe7\x8cF\xe1\x93\xd4y\xe0\x8c\xc3\xe7\x03\x94\x16\xe3\x1dj\xc5p\x1em\xa0\x9d\xf1\x1d?\xf3\x9a\xba\xb6*\xd0\n\\\xfb\xce\x16\xd8\x12Z\xc5<\x06f\x95\x00\xbaV\xae\x9a\x93\xe3\xfc\xdf\x00\x00\x00\xff\xffPK\x07\x08\xa4\xebJ\xcdK\x02\x00\x00L\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/kubernetes/core/tasks/ebs.yamlUT\x05\x00\x01\xf0b\xe7[\x84S\xc1n\xeb8\x0c\xbc\xfb+\x06\xe9\xa1\xed!q\xb7\xc0^\x0c\xf4\xb4\x1f\"02\x13sCK\x86(\xa7\xf5+\xf2\xef\x0f\xb2\x9d\xb4\x0fh\xf0N\x96%\xceh4\x1cn\x11\xa8\xe7\x06\xffu\xecO Ut\xd1\xb2\x81\x12\xc3\xb2\xa8\x82\xce$J{\xe5\n\x90\xe0ul\xd9\xa5\xa8\xdcT\x00V\xf4\x90\xd8\x17\x82y+\x93\x9d\xcc\x1dR\xec\x9bB\xe8fBG*\xe7BQ]o$3N\x19\xad$\xf69&a\x03\x7f\x88e\xab\x80\x83\\\xf9\x07\xca]\x83\xcd\xe7'$s\x8f\xcbe3o[\xa6\xcc\xcd\x0d<U\xc0\xbb\xe4\xce\x95\"[\x90[\xd4\x96\xce\xf5i\xdcs\n\x9c\xd9\xea\x9e\x82\x1c\xb8\xdcPm\xb1\xd7\xe8OMU\x95\xc2E\xd0;I\xc6\x18\xb2(\n\x084\x88q:s\x82\xd8\x1f.\x00c\x92\xe5\x92\xb2\xd4E_\xc18\x1a\xc4- \xa7\xd1\x93\xe2r\xa9;&\xcd\xdd\xaf\xcd\n(\xd2Gs>\xb6\xdc\xe0\xf5\xe5e\xdd\xf6*\x1c\xb2\xf3\x9c\xf2\xc2WV\xae\x95T(\xd6_\xdbQ\xdbK\xd8\xf9\x94oN\xdc\x90'\x9e\xfe\n<\xf1t\x03&\xce\xc5\xf4\x06\xff\xbc\xce\xff-+M\x0d\xfe]\x0f\x8fb\x99S\xf3\xe5\x82[-\x10\x95<-.\x14\xaf\x1a<=.Oz\x84\x84;\xe5\xcf\xa0\xd0\xe2\xe9\xe7\xc3\xdd\x02\xc7\xdb[\xb1\xe3y\xa6~\x00\xf9,1\xa0#C\x88%`g\x89\xa3-\x8dG\x8e\xf0\xb1\x1f(\xf1\x0e\xd6\xc5Q[\xec\x19\x03\x99\xe1@\xa2\xbb\x99\xc3w\x14\x8e\xdc\xba\xf7\x8eC\x83\x10\xbf\xf7:qh9\x81\xf7\x86\xafT\xcc\xe1\xe5~\xd0\x92\xadk\xb7\x92o\xb0\xb9\x17\xa4\x9a\xf7\xb6\x9d\x93d9&\xdeM\xd4\xeb\xee\xff\xd7kgZ\xb6\xd2\xcc\xbb9\xfc	\xbe\xf9.\xd3'.\xcf-2K\x05\x1d\x19^\xcb\xe0,b\xadc-\xe9k\xa3?\x95\xd7|\xb0\x9fc\xa8\x9c\xe7\xaf\xcf\x8a\xedv^\xc5p\x90\xe3[}\xa6T\xab\xec\xeb\xb5\xaa\xfe:\x03\x0d\x83N\xd8\x1e\xee\x8f\xcd]\xb9\x0f\xe0\xd0.\x13U\x06q\xf6{\xf3\xd8SI\xd0\x9c\x8ac\x8a\xe3\xe0\xca\x9bl\x83\xeaw\x00\x00\x00\xff\xffPK\x07\x08\xb44\x19\x9c\x19\x02\x00\x00t\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/kubernetes/core/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x91\xc1j\xc30\x0c\x86\xefy\x8a\x9f\xde\xdb\xc2\x8e\xa1\xdd\xa5\xc7^\xf6\x06FI\x94\xd4D\xb6\x82lwt\xec\xe1G\x92\xc2\x18-\x94]\xe5\xff\xfb\xf5am\x11)p\x8d\xc3X\x1a\xb6\xc8\x99\xd3\xbeU\xe3\xf7\n\xe8\xb8)C]\x01@HC\x8d\x0f\xf31#\xd3\xf0\x98F\xaf\x86si\xf8\xec3N\x1a{?\x14\xa3\xac\x86\x89,\xb1U\xd5\x16>Lj\xd9eJc\xaa\xd1qOEr\xda\xdd\x82\xcc\xaf\xab\xc6\xe9\xc2\xed\x08\x12\xc1ESN c\xa4\xecE@W\xf2B\x8dp\x05\xf8\xd8J\xe9\xd8\x99\n\xaf~+=\x19\xb7s\xc12Z\xf6\xb8\xde4\xd4s\xa1[\n\x1d\x89\xbf\xf2bs\xef\xb8\xeb\x98\xea\xb8\xbbQ\x90\n\xf8\xbcp\\'\x8e\xe3\xbc\xb2\xc37\x1aUy\xc2q\x93\xfeb\xadh\xe9\xdcdz\xf5\x1d\x1b\x8eGl\xb8}\xdb<A\xe9\xab\x18\xbf\x84\x97\xd4\xe6\xf7\x87\x0e\xfb\xff]\xea!\xfe\xf2T?\x01\x00\x00\xff\xffPK\x07\x08\x8a]\xfc\x8e\xf7\x00\x00\x00\x13\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/core/tasks/rook.yamlUT\x05\x00\x01\xf0b\xe7[\xecXK\x8f\xe3\xb8\x11\xbe\xfbW\x14\xdc\x07\xcf`\xd7v\xcf\xf4f\x805\xd0\xb9\xe4\x9aS\x92\xe3\x02\x02E\x96,\xae)R\xcb*\xda\xad\xec\xf4\x7f\x0f\xf8\x90-\xdb\xfd\xdc\x99\x049\xec\xc9\x96XU,~\xf5\xfa\xa8%X\xd1\xe1\x06\xfe\xd6\xa2\xdc\x810\x06ZGL <\x02\xb16\x06\xc4^h#j\x833\x00m\xa5	\n+\xef\x0cnf\x00P\xb4{\x8f2\x1aH\xafX\xd0\x8e\xaa\xc6\xbbn\x13\x0dV\xc9`%\x8c\xde\xe3l6\xee'\x88\xd03(\xedQ\xb2\xf3\x1a	\xf0A\x13\xd3\x0c\xa0\xd1\xa3\xf5^p\xbb\x81\xf9\xef\xbf\x83f\xec\xe0\xf1q\x9e^\x13\x0b\xc6\xcdQy\x98\x01\x1c4\xb7U\x14\xa2,\xef\x9d\xdbU\x12\xfb\xb6\"v^l\xb1\x9an\x95\x0d\xfd/\x9cI\"KX#\xcb\xf5.\xd4\xe8-2\xd2:\xba\xb7\xac\xb5\x1d\x97\xc9\xef\xa7\xcb\x9d\xb0\xbaA\xe2,\xb8\x96&\x10\xa3\x7f\x9bp9\xef\xdb\x84\xd99S\xbb\x87Qx/\xfc\xda\xe8:)\x18\xe4\xf5\xde\x99\xd0ao\xc2V[*B't\x9dm\xf4\xb6R\x82E\x047\x012\xbbIk	5\x82V\xec\x11jD\x0b\x9d\xdb\xa3\x82`\x15z\xd0L\xe0\x0e\x16(\xd4\x8d3\n\xfd1\x0e\xd2\xa0\xb0\xa1\x07a\x07pFML\xbd#\x10\xa2&\xb4\xfct\x14^\x04c\xe9z\xf4\x82\x9d_\x0d\xa23oS)\x91y\x87F:\xce@\x8c\xdd;\x94\\\xfd+J^\xc6\xd0\xe2\xfb\xd5\x02\xbd\xcb\xc3\xde9\xf3\x0e\xf1\x92oKi\x04Q\xd6\x9b-\xa16N\xee6\xb3Y4\x90\x83{\x10\x9a!X\xd6\x06\xa21\x10\xbd&\xf4\xfb\x98\x10t\xd6d\x00\x82\xd79d\xf1\xaf\xc9\xa1\x8e:\x95\xe8u\x95\x95*\xe3\xa40\xf0\xf8\xb8nQ\x18n\xff=/\n1\x0b\x02U\xd2)\xdc\xc0\xe7\xdb\xdb\xf2Z\x1a\x8d\x96+\x89\x9e\xb3\xbd\xf8\xaf\xa4\xed\xba<\xd2J\xa8N\xdb\x95\xf4|L\xaa\xa3\xe6\x0e\x87W\x15w8\x1c\x15=rl$\x1b\xf8\xf49=+4b\xd8\xc0_\xca\xe2V\xc7\xbc\xd9\x9cP\xa8\n\x04\xdah\x1e2\n\x11\xab\x0d|X\xe4#-@\xdbg\xc4?\x82\xb0\n><\xbd\xb8\xca\xeap\x7f\x1f\xe1\xf8\x98L\xdf\x80\x90\xac\x9d\x85V\x10X\x17\xfb\xf7^\xbb@\xb9\x86\x80\x1dH\xd7\xf5\xc2\xe3\n\xa8u\xc1(\xa8\x11zA\x04\x8d\xd0f\x95l\xc8V\xd8-\xaa\xea\xd0\xa2\xdd\x80u\xd3Xo\xd1\xc6RB\xe8\xb1\xcb\xb5\xd9\xa2\x89\x81\x94\x82\xe1\x1aCm\xb7\x1e\x89Rt*\xe9\xb9\x8aE\x12-\xc1\xe3\xe3k\xe2;\x1c\xce\xc4\xffz\xa9P\xa4W=v\xf3'}\xac\x05\xe1\x97\x9f\x00m\xcc\x19\xf8\xd7\xdf\xff\x99\xd4u\xa3e\\m\x9c\x07Fb\xa0\xbb\x88\xfek\xa7\x99\xec\x06_G\xd3_\x81=,~\xf1\x0bX\xfcb\x17\xf9q\xa9\xd2\xc3\xfc\"\x1f\xe8\xae\xaa\xbf\xfcT\xb1\xc9\xc7\xfb\x83\x0e\xdfQ\xf4\x15\xa4\xb3\xec\x9d1\xe8\x81Pz\xe4\xd7\xbc\x9f\x80\xeb\x9d\xe3J\x8a\xabp|\xeb\xa1\xc4\xd5\xb9\xa4\xeb\x87\xdc\xeaC\xe7\x82e \xe9u\x9f}\x8dkc+ /70\x7ff\x90\xae\xb3\xee\x8a\xda\xb1n\x15R,\xf5\xe7&\xefT\xe1Eg\xd0\xef\xb5D\x08Vs\x9aA\xcf\xf8\x15(\x8f\xce\xdc\xdbU\xf9\xcd\xdb\x8d[eS\x97\x0e\xbeW\xf3\x84k\x1a\xc3Y\xa4*\"I\xc2:\xd6\xcd\xb0\x01\x8f\xc6	\x95\x9a\x83Gb\xe1\x19<\x0dd\xdc6\x1f\xb9C\x16\x1bhL\xa0\xb6j\x85U\x06}\x9e\xf2\xb9\xa6\x9f0\xbf*U?\x85\xccc\x1a\xeb	\xb4\xe3lHf\x18\xbb\xde\xc4\xa1|\x86\xd4\xcb\x8c\xa4L\xf5U\xa6\x06cR\xc67%\x03\xd3\x88Y\xfd\xfa\xf9\x12\xc6\xefa6\xdb|\x92K\x1e\xed\x1d;|\x06)\xd9@\x1bg\x97J\xaf\x8ds}U*o<\xb8\x115\x9a\x13e\x19\xf7<\xcf<\x8f\xb1|\x13\x8c#\x13\xa1\xb3\x82UN\xee\xd0\x03>\xa0\x84\xc2\xd0\xd2\xafd\x03\xcbe\xfa\x97(\xd9\xfd\x15\x8f;\xad\x81\xe8{3\xc4\xbao\x9c\x97q\xa2\xf6f\xa8\x1a#\xb6\xf0\xf8\xb8l^\xe3\x8b\xff5\x183PK\xd0v\x8f6\xd2\xe8tmHF\xef\xefa\xd1\x89\x98\xf1\xb7\xb7\xb7\x8b\xa3\xe4\x873\xe8c_r\xce\xc0\xc7\x8b\xe5\x11\xc9\xd3\xfa\x04\xf2\xc2\xfb\xcf \x8fl\xc4\xa3P\xc3\xf7\x87~\x8b\x0c\xbdS\x04K\x0b\x99=b\xdf.s\xb9\xc3W\xd8z\xec'\xefG\x87.\x8b~|_\x9d\xbc<q\x8dLw\n\xd7\xb8\xcbO\x85G\x94T\\\xc2|\xf1i\xfd)\xb1\x89sS+b\xe5\x02\xcf'\x82\xff\x08\xd6j\xbb}I\xf8\x99\x0cV\xd8\x1b7th\xf9\xcf\x1c\xfe\xc39l\x1d\xbf\x92\xc7\x85fC\xe2t\xb1\xdd:/\xfc\x00\x07\xe7w\xc2\xbb`\x15\xf4h\x95\xb6\xdb\xf1.\xf5\x10\xc9]\xba\x97\xe5\xcb\x01\xa4;\x05\xc4+\x02\xfc\x160 \xe8\xe6|\xa9\x18({\xf4\xa16Z\x82&\n\x98\x06K\xef\xc1\x86\xaeF\x1f\x0d7\xce\x18w\x98\x15YA\x14\xba\xb87\xb7\x82\xa3\xdd\xab\xbbL,\xb6r\xf8\x1f\xe1\xf2\xca\x02\x87\xc4<\x85!\x17\xe9\xe7\xd8cg\x05\x9b\xeb\nN\xaaG\xbb\xa7\xf2\xf8\xce\xd9\xf7d\x19_\xd7\xaf\xdf\x1e\x96\xf9\x82twv\xe6\xb1\xbe&\x15}r\xbc\x9az=)k\xb8\x01mc}Q\xc2\xd3\xc1\xa7\xdb\x87\x9fo\xa1\xf1\xae\x8b\x7f\xbf\xdc\x82\n\x89\xb77\x1e\x7f\x0bh\x19Xw\xe8B\xbcj[\xc0\x98\x8fA\x183@\xbe-\x0d\x90\xbb\x0e\x1dghj\x18?\xc7}\n\xe1\x17J\x81\xc8\x0b\xa9\n@$\xe2\x97\xafq?@\xe7\x94n4\xfah\xbe\xc4\xdf5\xe0H\xc1\x0f\x80\x0f\xecE\x8cw\xba\xeb\xa6xL\x07\xe4\xd8\x91\xca\xc3EK\xba\xc4\xe2\xa2+=\xd1\x97^\xd4(\xa9x\x95[\x9a\x80\x90#bc\xf15\xc2P\x820^\x86\"\x82\x9a\xa1\xc6\x08w\xec/\xba8\x7f\x0356\xf1D\xdc^\x94\x90&\x08\xfd\x8f@.*\xe6{\x15\xe7\x8f\x1f\xf1O&I\xa8`@\x86\xb3\x14\x9e\xd2\xa7i\n'7\xcf\xf9\xd45\xa3z\x1b\xa7*W\xf4\xa7?\nL\xa8\xd4[\xc9\xd4\x8b\xf6\xe6\xb3\xff\x17\xcc\x8b\x8dk\xc8_\x1aT\xdf\xb9Y|\xdb\xa8z\x0b\xd0y\xf6|H\x93\xe9|\x90\x94\xcf\x02i%+W\xb9L.\xc5\xa2\xa1\x1b@\xab\xf2<\x99\x8dF\xe7ef\xa5:\xdbz\x17\xfa*\x02Hs8}\xc8\x8d\x11\xad\x05\xb5 \x8c\x16\x94\xbe\xd4\x19mQ\xdb\xab\xefu\xf1\x14\xbc^Ea/s\xcaE\xc9\x0d,\x92*\x8cS8\x7fE\xb9?\x92\xe5\xbd0!\xb1\xe5E\xd2\xc9\xc1\xdb\xc0\x809`\xee`\xcb](_\x19\x93\x9f\xc7\xe74\xc0\x95\x96\x9c]\xd9\xa5j9F\xd1\xde\x1f\xfb\xf5|\\\xa7g\x04\nQ\x9b\xff'\x00\x00\xff\xffPK\x07\x08\xd2\x92Z*\x99\x06\x00\x002\x17\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00>\x00	\x00roles/kubernetes/core/templates/etc/pki/ingress_openssl.cnf.j2UT\x05\x00\x01\xf0b\xe7[\xc4RMo\xda@\x10\xbd\xef\xaf\x98\x0b\xa2\x15\x08!\xf5V	\xa9Q\x9aC/Q\x954'\xcbZ-\xec\x18\xa6\x98\xd9ew\xec\x06\x81\xff{\xb5P0	_\xea)'[3o\xde\xbc}\xf3\xb2\x80\xcb\\\x05\\j|\x15\xe4H\x8e#\x8c\xa0\xfe\xa2\x03.\x95\xa5(\xc4\xd3\x8a\xe2\x0c\xadf\xb3@\x18A\x02\x9f6Tv\xbe\x9e\xablG\x96\xab\xb1\x894\xb9w\x1c%\x18bI{\xee\xef\xbe\xfezzyPs\\\xbdD3M\xf4\xec\xf8	}e\xc9\x089\xee\x83\xa5)\x89)\x9fi\xcaF\xaa\x80}\x98\xe3\xea\x81'\xe4g\x18\x16\xc8\xa2b5\xfe\x8d\x13\xb9+\xe5q\xa7\xf0\x9b)e\xbb<\xaa\xec\xf0\x9b\xabu\x07\"\n\x1c*\xbapA\x17\x14\xa2\xe8\x85\x89\x82A\xcf\\\x14\x18A\x96\xbe\xb5	1\xeb\xee\x1a\xc3\xe1\xb0\x9b\x0f\x8a\xa5e\xd8@\xe9\xfe`\x80>\x9c\x07\xa5jb\xbf\xd5\xd7q\xe6\x82\xf4\xe1\x02\x8c\xb8F\x16\x17Vz?\x90C\xa79y\x02\x8c\xe0\x13\xf14`\x8c\xdaba\xaaR\xb4\xe5\xa8[@\xaf\x05\x18k)yj\xca\xb7\x98MtA>C\xef\xa63\x1b\xd8!7P1-+\xfc\xa7\xa8p\xe10\n\xc4G\xea:\x8d\xfa\xfe\xf8<X\xaf\xa1t\xce\x0f\x88-\xbeB\xd3\xc0\x08\xd6\xebv\xa4\xd9\xb2 \xdbD\xb4\xa3\xa4\xa2\xf5\xe5\x8c\x15\x03\x1f\xa86\x82\x9a\xfc;W\xc8\xff\xefY\xb3nK\xd6\xcd/\x9d\xcdp\xa4q\x89[\xaaK\x98\x96g\x7f+,#~\x80\xc0\xc3z\xb6T\xb4\x8e\xce\xab1\xea\x9a\x82T\xa6\xd4\xe4\xb5\xf1t\"n\xab\xad&\x9f\xd4\x9c\xc1_}\xd8\xf1\xec9	G\x16\\\xcbmj\xf7\xdeS^\x8f1\xf9\xb7!\xbel\xf2\x8d\x08\x93\xdf\x078\xa9\xe84\xea\xc7\xcf+\xe9%\x7f\x92\xdd\xbf\x01\x00\x00\xff\xffPK\x07\x08{fW\x08\xcb\x01\x00\x00P\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/azure-storage-classes.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xd4W\xdfo\xdb6\x10~\xf7_q@\x1e\xb2\x01\x91\xed\xfe\x00Z\x08\xe8\x83\x13'\x9d\xd1t6\xec\xb4\x03V\x14\x05E\x9e,\xce\x14\xa9\xf1H\x07\xee_?\x90\x94d\xc7m\xb3l]\x87\xd6O\x12y\xfc\xee\xee\xbb\xfbNt\x96e\x83\x13\xc8>\xfd\x0dN`\xf2\xfb\x9b\xe5%\x9c_\xcf/^\xc1\xeaf\xbe\x9c\xbc\xbc\xfc\x82-k\xe4[\xb4$\x8d\xcea\xfbh\xb0\x91Z\xe4p-\xc9\x0d\xa4\xc3\x9a\xf2\xc1\x00\xe0\x04\x16\xc1\x84\x1cj\x07[\xa3|\x8d\xc0\x15\x935\x01\xb3\x08\xd4 \x97\xa5D\x01R\xc3Ky\x0e\x85w0\xf9\xe8-B\xcd4[\xa3\x00!i\x13\x8d#Z!\x95B\x01\xc5\x0eV\xaf\xde@i,\xb0\x0e\x84\x03\xc9\x8f8\x84\x9b\n	\xc36\x81ez\x8dPZS\xc3\x93\xc7\x01>\x1cX=\x05c!\xc5\xf6\xb4\x85w\x06\x9e<\xbe\xe9\x0c\x9e\x8f\x83\xc5\xe2\xf98\xed\x0e\xa3q<pS!\xb8\xca\x1a\xbf\xae\x1a\xef\x80i\x01\xb3\xf9b\x05\x0d\xda\xd2\xd8\x9ai\x8e`J`\xb0\xb0XK_\xdf\xc9\x02\x046\xa8\x05\x81\xd1\xe0\xaa6\x1f\xe3\xaa\xf0\x12\xd3	p\xe1Yjr\x11*$\x14\xf0\xc2\xa26\x02)\xd0\x14^&\xafV\xc0\x95'\x87\xf6 \xba+c\xa166\x00\xc4h\x9c4\xfa\x0c\x08\x11\x16Vr\xa9\xd7\xd1\xc3\xe2n\xac\xaf\xdb\x08\xa7\x91\x88\x88\xf3S\xe5\\C\xf9h\xc4B%\x86\xb5\xe4\xd6\x90)\xdd\x90\x9bz\xd4$\xa8\x91@\xc7\xa4\xa2Q\x9ba\x16\xa9\x1a\xfd\x9c\x8a>\xbd\xbc\x99\xcc\xaeW\xd0\x01	\xc3\xe9\x08\x07u\xe6)y\x18\xb1M\xfb\x94P2\xb1\xd3\xac\x96<k\xb6	\xee3\xddw\xd8\x88]e\x04\x96\xcc+\x07\xe4\x8ce\xeb\xd8hD\xd0X\xb3\x95\xa1K)\xf4\x8acZ0+\xda&\x0b\xee\x86\xf1\xfc\xaa\xdb\xe8\x0eK\x82\x82\xf1Mj\xb6_\xa6S:\x8b\xec	Tr\x8b\x96\x80\x1br\x19\x96%r'\xb7\xa9\x98\xdd\xd1\xdbJ*\x04rR)(0\xf0\xde\xf7\x87\x1b\xee=\xf5\x9d\x0dR S\xb1\xf7\"\x0e\x8b\xe0\xd0\x83\x83\xc0mj\x0e$\x07\xb7\xc6n\x94ab\x18\xb8\xc9 \xc9n\x95\\_\x84\x8c\x07\x00\x00\x87\xe2l\xe3\x1an\x9e\xd3P\x9a\xd1\xf6Q\xb4\xa8\xd11\xc1\x1c\xcb\xe3\x1b\x80f5\xe6\x90\xca\xd0\xd1\x94\x15\xca\xf0M&P\xa1\xc3h\xd7\xb3\x896\x87\x8d/\xd0jt\x18q\xf7\x15L\x96\xcc\xb2\x1a\x1dZ\xea<p\xc6+\xa9\xd7\xb5\x11\x98\xc3\x12\x99\x98k\xb5k\xf7R\x1am3\xb6km\xdc\x8cs\xe3\xb5s\xbb\x06\xf3\x9e\xbd\x0f\xd7\xcbU4\xb3\x18\xe7\xc9\xc2(\xc9w9L\xf7\xa1\x9e\xb4\x03\xe7\\j!\xf5\xfaut;\xabk\x14\x929\x84\x11\xfc\xc6\xa4\xbb2\xf6JZr\x17F\x93\xaf\xd1\xfe\x7f\xa4\xda  \xfdC\x90\xba\xdc\x87\xfa\xafI}\xb0\x8a\xbb\x81\xd2\xb4#\xf4\x1e5w&\xc7b\xee\xa6\xef^a{)\xafV\xd3\xac`\x84\x02*\xb9\xae\xb2\x83\xc9}\x06\xca\xdcf\x8a9\xd4|\x97\xd0\xa0\xfb\x84\x05%F\x81\xbe}M`\xbd\xd6Q\xd5\xd6\x08\xcf\xc3\x94\xdd\x8b\x12fe?\xa1\xfb\x91\xbd3\xdeF\xa4vf\x83'\x84\xa3\xfc\xc2\xa0V\xc1\x8b\xfb\x0c\x071\xf7o)\xf8\xd6\xd1\xf7\xa7\xf7\xb6\x94?\xa0\xdc\xefR\xfa\x1d\xa9\xfdo(\xfdz\xb1\x0f\xbet\xbf\xec\xaf\x97W\xb3\xeb\xcb{o\x97\x0f\xbc\\&\xe1_I\x85\x04\xdc[\x8b\xda\xa9\x1d\x18\xadvQ\x91p+]\xf5\xc9\xa7\xbdU\xf5\xac\x0c\xba\x8cR\\\x1cK1h\xb0\xbd\xad\x96\xe1\x96\x13.\x88}\xd9\xbe\xa5\x0e\xfboD)\xd5\x03\x05\xd8[\xd6\xa1\xc4\xf3&\xcc\xa3\xbe_2\x10\xd2~\x08\xed\xf2b\xfc\xec\xd9\xb3~5\x1c\xfa\xcc\xb2\x97\xe2\xc5\xa3\xf1x\xdc/\xac\x0f\x17>mG\xda\xf8_c\xf4w\xbe \x91\xde\xe5\xf9\xe4\x02\x96&T\xc6\xe2\x9f>\xdc\xf1\xc3\xfc\xdcW,R\x870o\xd0\xc6\x9bjb\xf5\x90>[0>d\xdeU\xc6\xca\x8f\xd1\xe6\x88\xc9T\x82\x8b4T\x83\xaf{\xf8\xa5\x1d9\xac\xf3\xc4\x19W\xc6\x87\xe9j\xb6R\xa0M*\xf0\n\x0fhc\x8d|i\x8do(\x87w\xa7\xa7\xef\xdb\xf5 \x162\xder\x8c\xeb\x84\xdc\xa2\xa3\x83\xed-\xda\x82\xf2\xf8\xf8\xeet\x8d\xee\xf4\xec\x94[d\x0eO\xdf\xff\x17\xf9\xb5B\xfc\x9a4\x8d\xc2%\x96\xf9\x9d\x99q\xcc \xf4\xe9\xdf\x13\xe4?rK\xbe\xf8\x03\xb9; \xb8\x95\x0f\xda\xad\xe48I\xf3\xa9\xa71\x816\xfd_\xc7,\x891+\xa4\xee\x10;3j\x18\xc7\xa4\x8c,E1\xf8+\x00\x00\xff\xffPK\x07\x08\xa9i)\x89{\x04\x00\x00\xdf\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/calico.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec}[o\x1b9\xb2\xf0\xbb\x7fEA\xc6 \xdf\xb7\xb0\xe4dn'\xa3\x83\xf3\xa0\xc8\x8a#\xc4\x96\x04K\x9e\xd9\xc1`!P\xdd%\x89\xc7l\xb2\x97d\xcb\xd1f\xf3\xdf\x0fx\xe9{\xb7,'\x8e3\x03\xac\x1ef\xdcM\xb2X,\x16\xeb\xdeL\xb7\xdb=9\x85\xb9Hd\x80}\x08\x08\xa3\x818\xd7\x18\xc5\x8chT\xe7\xeeE7\x10|M7\xbd=\x89\xd8\xc9),\xb6T\xc1\xd0\xbe\xba&1P\x05\x89\xc2\x10\xb4\x00\xd7/\x91\x08\x04\x14\xb2uw+\x94\xc6\x10\x86\x16\x0cP\xae4a\x8ch*x\xef\xe4\x8e\xf2\xb0\x9f\xc39!1\xfd\x15\xa5\xa2\x82\xf7a\xf7\xea$BMB\xa2I\xff\x04\x80\x93(\xc3\xce#\xe3\xdf\xaa\x98\x18\xc4\xef\x92\x15v\xd5^i\x8cN\xd2A\xae\xfb\x92\xaem\x8f\x0e\xe5\x1a\xa5\xf9\xfb\x7f>~\x84\x80S\xd7\x00\x9f>uNN\x00N\xe1w\x91@\x94(\x0d\n5\x10\xe0\x82w\xff\x85R\xc0\x8e\xb0\x04a-$,\xf6\xf1\x96\x80\xc4\x98\xd1\x80(X!\x13\xf7\xbd\x13\x00m\xde/\x15\xca\x1d\x0dp\xe9p\xedp\xc1\xd1C\x1efT\xd1[Li\xb1\"\xc1\x1drK\xb5Da/\xc7\xd77\xf4\xa1\xb3\xa22l\x04q\xbd\xb8\xf5\xe3N\x00v\xa8\xb7\xcbH'}\xe8|\xfc\x08\xa1\x08\xeeP\x9a\xe7\x7fS\xae\xa1\x0b?\xbfL\x97hh\xb4\x0cX\xa24\xcae@C\xe9F\xd4^\xe7$Y\x18t'c\xe0\xa8\xef\x85\xbc\xcb\xb6\xd7n\xa0\xc1\xc0o(\x08\x0eH\x82-p\x11b\x0f\xec8\x15c@	\xb3p,	\x15P\x0e\xda\xb0\x8e\x03\x03\xf7\x941X!\x90D\x8b\x88h\x1a\x10\xc6\xf6\x10\x8b81\xbc\x17Z\x92p\xba\xf4\x93/\xdd\xa8>\xfc\xbb{\x02\x00\xf0\xd1\xfe\x17\xa0c\xe8\xdd\xe9C\xe7\xee\xb5\xea\xc6\"\xec\xfa\xfe\x9d\xb3\xb4C\xc0S\xbe2\xdd^\xf6~\xe8\xbd\xca\x1bc\x96l(W\x9d>\xfc\xe1_\xe5\xa0m\x07\xbd\x8f-x\xb7;\xd9@\xdb\xc6\xc4f\xc9p\x87\xacc\xf9k]i6\x8c\xa8\xb4\x90\xb8L\x81\x18ZK\x8e\x1aU\xb9\xa7\xa1[\xba\x8e\xe5\xf2\xfd\xed\x9b\xd1\xcdd\xb4\x18\xcd\x97\x93\xe9\xc5h9\x19\\\x8f\x96\xcb\xf2\x88H'\x9d>,\x97\xc3\xc9xy\xbd\xb8].K\xad4&Q\xa7_ZHm)]\xdb\xa9\xd0\xe3S	D,\x18\x0d\xf65 \x050w\xafU\xfb\xf0\xc2J\x1b@\x98V\xb7\x9f\xf9\x8a\x87\xd3\xc9\xdb\xf1\xe5\xf2\xed\xf8j4\x1b,\xde-\x97%\xe0'\x0d\xd34\xeeS,\xa4\x8eH\\\xa6\x96\xe2Dw\xfa\xa0e\x82\xa5\xf7\x01\x89\xc9\x8a2\xaa\xa9\xc3\xd3\x8e\xbe&qL\xf9F\xf9\x01\xc7O\xbd\"<\xbc\xa7\xa1\xdev\x0eO\x92\xf7\xab\xcd\xe0\xff\xfa\xc7\x89{:y@<\xdf\x85a7\x90\xa1r\x92\xb9(@IL\xf1\x83Fn\x9eT\xef\xee\xb5\xeaQq\xbe{\xb5BM^\xa5\x927QZD7\xa8,\xf4\x0b\\SN\xcd\xc1.I^/z\xd7\xc8\xe8\x87\xd2\xf1W\xbd@\x86\xbdX\x8a\xff\xc5@;\xc4zBnN\xcc\xb97\x03U b\xec\xc3\xd0	\x96\x13\x80\x8d\x14I\xdc\x87\xe6QF\x8c\xe5\xa2\xdf\x8bv;?8d\xdf\x1a\x04\x86E\x04lc\xcc\x12IX#~\xb6]Q\xbeI\x18\x91M=,q\xbf\n\xd1\x1c\xcd\xcc\xf9Z1\x11\xdc=3\xa9\xc6\xb3\xc1\xf5\x1b3o\x89B96\x15\xc2d\x0d'_\x9b v\x16\xb2v=\xf0\x99\xa9b)2p\x93\xefK\x94\xa9\xa0U!O\xb1u\xff\xd5Id6cKx\xc8\x9e\x9b<\x86i\xde\xd9\x89k\\\xe3\xf1i`\x1b\xd7\xf2,Dq'\xf7\x1b\x10e\x98Z\x9be\xa2x|\x1a\x88\xe2\xcd\xd3\xafM\x94\xd5&\x8e\x11\xe53S\xe4\xcd\xe5l\x86\x16N\xe1\xfcxL\xaa\x07\xc7\xbd~\x0eB|K\xc5\xf4\xe6r\xd6\xae\x96j\xb8\xd5iTVI_\x9bX4\x8e\x85`\xcfL\xa2\xf1l&\x04\xab\x1c!\x8bG\xed\xf8\x98\xb7_\x9d\x08\xd6)\xe5a,(\xd7\xcfL\x8awB\xe9\x91\x9f\xbaD\x90\x12N\x15\xb2\x14\xdb\xbe:q\xbc'h\xbc\x19\x19}\x8b\x03\xe5\xe1\x8cs\x04J\x84j\xc0\xafB\xaez\x8f\xafN\xb4\x0d\x13+\xc2\xbc\xe7i=\xa7g\xb7o.-\n\x13\x87\xc2\xcc:o%\xba5\xa2X\xa1\\\xbd\xcf\xd7\xb7xJs*|\xee\x03Y\"\xdb\x1cu;\xcd\x0cn\x87\xe8\xa5\xf0\xeb\x1f\xce\xcf\xe2\xb0I\x1a&\x0b\xbf\x98Z\xed\xecu\x98\xb1\x9e\x97\xa5\x1e\xcdLOO\xa1*'\xb5\xf3P\x81{\x1ep\xf6\xe5\x8a\x04\xce\xd1?9\x851\x0fX\x12\"\x90T\xdcI\xc1\\\x94Ro\xd1\x85C\x03\xc1\xb5\x14\x8c\xa1T\x10\x88(\x16\x1c\xb9>;9\x05\xc2CXQ\x1e\x02\xd5\xa0\x85\x1d\xe0CB\xb5q>\xa8I\x82@$\\g1[7\xe5\x8d`X\xdaF\x8b!I\xf4VH\xfa/\x17\xe5\xcd\xf6\xb2=\x9e[\x9d\xf4D&\xcc\x11\xf5\x14&\"D\x05D\"\xdc\x13\x1dl]\x949\x12\x9cj!\xedjCd\xe8\x94\xd4	@\xd70\xd2\xa5\xd9?\xd5\x87?:\x1d\x17F\x91\x9eg\xfc>\x99n\xdc\x80\xb5O;\x94\xabB\x83\x9d%{bT\xe9\xecac\xb7\xf4\x14f\"t\x18\xfd3AI}\xdc{\x8b\xc1\x9d\xc5\x07?P\xa3\xac\x03|\x14>\xb1\x08\x1b\xd1I\xe74\x8eH>\xce\xce\x1e\x11N}\xa4\x14\xee\xb7\xc8\xdd\x9al\x93\xa5\x89\x0b\xa0V0hd\xeav\xb4\x8aVZ\x053O\x9a/\x83\xdf\xe4y\xbbyK\xb1\x8a\xf4U\xd1\x11m$\x14\xd4w-\x90H4f\x8fI\x1c\x16\x1f\x1d\xa5\x1c\xa7!\x9a}\x0d\x02T\xca\x06\xd7m\xcfFs\xe8\x8b\xd7\xddf\xc3\x1cXT\xf3:\x8c\xc4\xa8\x1d\xc97\x94\x87\x94o\xbe\xca\xc9\x14\x0copm:\xa6\xeb?\x00\xfb\xa4b\xd2Yy\xf1\xd0\x14*Y\x192\xaa\xfeI\xd7\x8f\x9e;\x194p2\xe8A\x00^(\xd7\xf3BN\xbc\x1e\x16\x9c\x1e\xa89KpA0\x12|\x8e\x0f\xc8L\xdb\xf79\xe4\xa4\x99\xa8(\x1b\xd3\xec\x8cKa\x00\xb7\x0c\xac\x85a\x1b+P\xce\x9cH8\xb3\xb8g4y\x9c\xa0\xcc\x04SYj\xda\xa7\x0cb;\xef\x1e=O\xd9\xf51o<E\x1b\x80\x9f\xc2\xadO7\x86T\x05b\x872\xed\x0c\xe3\x99\xb2[I\xc2\x1dJM\x15Fhv#\x85\xd9*\xdd\x1b@\xdad\x9f\xea}\xee\x82,\xad\xce\x95&:i\\\x82\x117\x18Zd\x03\x86DR\xbe\xb1\xca\xce\xdb\x0f\xb7\x9c\xec\x08edex\x93\x91M\x8eG\\X\xc3i\x9aL\xb4Y&\x05JDXI\xd2\x15d\x0cP\xa7$\x80p.t.\xcb\xa0,\x19O\xe173\x85CmK\xf8\x06-W\xbd\xcf\x12:%+\x9062\x947j(\xdfx\xc6>@\xa8\x06\xc3\xf1\x18\xa5\xec\xb7l\xb5Oi`\xf0u\x06fq\xd1_\xc0\xede\xfe.\xf0\xa4?\xe5\x87\xf4b\x19\xf1\xdaY\xb5\x9b\x88\xcaN\xe8\x99\xe4\xd1\x88\xb62W\xce#\x19\x7fx\x8bI\xc1\x8eH*\x12\x05\xc3\x9b\x0bwR|f\xff\x8bU\x9as\x85\nI\x96\x9cjm\xb9\x19\xd3V\x8a\x1d\xe6p\xb2\xb8X\xa9g\x0b\x8c\xa2\x95\x02\xcd\xd6C\xbb\xf3[k\xcdL\xf46\xe6,\xbc/\xf5mS\xea\xa6\xad\x1e\xdd\x81\x16\xf3\xe7X\xbb\xe6\xbe\xb6\xc5\x89\xd2\xdeJ\xb0\x02\xdf\xdb/V$\xd8\xdd\x16\x1c\x94&R'\xf1\x97\xefv\x95\xe4\x87\xb6\xf8Hc\xa7\xcdP{\xa4\x8c\x13\xdc\xeaf[\x88\xf0\x98\x13\xd5\xea\x11\x1c\xb1\x03\x8b-*\x84\x18eD\x95\xf5`\xad\x15.8\xdb\x83\xc4\x7f&\xd6I0g-\x897\x92\x84\x08k)\"\xd8}\xdf\xfb\xd9\xa9\xe6\x80p\x0bf\x85 1\x12;\x0c\x81\xac5\xe6\xfd\x854\xcbZKT\xdbR	\xcd\x13X\xa2\xed\xa7\xaat2\x8f\xde\xabfR\x18*P\xe9\xa9\xe0\xb7\xd3HC-LW\xb3y\xce\xc3!\x8c\x89\xe0\xc9\x96\xf6\xa7q.\xbel\x1d\xd5lS\x8b\x8bh\xf3\x9b\x90\xaf\xd7	\x04\xc2\x940\x8ce\x99\xd5Z\x13\xab\xbd==n/\xa4H\x8c\xb8\xd8l$n\xda\xd4\xe5#\xd1=B\xa8U\xd4\xa2\xe3\x07\xcb\x00\x11\xdd\xf83]\xb2fCk\x89\xdbxN\x03\x87\x05\x84\xc3\xca\xb1_z~\xe8\x1a\xb8\xd0\xfe\x00\x19\xd3\xca\x1e9\xc2K\xc7\x07\x12e\x9a\x8cl\xee\x1a\xbec\x16\x87\x06\n\x908V\xed\x0b\xce\x91k\xdb\x1e\xe3x\x1cm\xfe\xb7\xbas\x0f\xf8\x05O\xee\x99Y\xb0\x8f\xf5\xc6\xec\xa0V\x0f\xec\xa1\x08\x97\x07b\xeb\xe9J\xc5\x86\x11\xe1t\x8d\x99\x82S@R<\xce\xe0~K\x83mVLf\xab\xe7\nf\xe1\x0b\xe5\xcb\xf6\xdc6\xf5\x0cD\xfb\xac\xa8\xb6\x15i+\xd4\xf7\x88\xdc\x15\x98Xyl\xf4\xc7`6\xb6\xd6\x1e\xca3\x90\x18&\x81\xe1\x94\x0c\"\x13$L5M\xde\xb3wR-a,Q\xac}\x03\xedz\xdb\xa9\x06\xc0\xc8\n\x99g\xaa\xbb\xd7\xaaK\xe2\xb828\x0dh\xc6Bj\xdf\xb1k\x1f\xfa\xf0\xd3\x8f\xff\xf5\x83g\xc7X\n-\x02\xc1\xfa\xb0\x18\xce\xfc;M\xe4\x06\xf5\xccv\xad\xe0\x03\xd0\x82\xa8B\x86\x81\x16\xf2\x10F.\xc8\xdb\xbe\x7f\x17\x183\xb17\xfe\x19\x88\xb5\xdf\x13\xed\xca\"-]\xc9J\xec2\xbf\xbaW\x0d\x17\xc7*?+9\xa4g!\xf1)L\x92h\x852G;\xad\x08\xed\x01,\x04 \xb7r\xd66\x9d\xd9bR[\xf4\xa8E\xbd\xa8\xf4o\x84\x87\x7f\xf3]\x9c\x00\xab\x97\x92Z\x93\xddB\xa4\xbc\x18v\xf0E\x94y\x19\xae%\x98\x91^\xa7\xce\x893j7\x10Q\x84\xc6\x18\xb4\x92\xceaK\xd7\xb0\x17	l\xc9\x0e!\x12\xb6\xa2\x94p\xf8\xe9\xa53\x81z\x00\x03K\xf9W/\xfd\x1b\xa0\x1a\xa8\x02T\n\xb9N+:\xff\x9f\x0d9:\xb0\x06\xa9\x82w\x98U=\xfe\xff\x1e\x18\x1f\x0d\x04\xc7\x94D.D\xbaC\xb97\xf0\xbb\xdf\xbf\xccg\x1d;#(\x96\"L\x02#\xaa\xce\xe0\xbe\xb8\x04\x99pnf#\x1a\x18\x12\xa5\xe1\x87\xbc\x12W\x0bwH]u,\x8db\x12X\xae\x92\x8213\xc6\x1bR\x868\xe9\x98>\xbc\xb2O;jX\xea\x1d5\x18\xef\xafhDu\x1f\xbe\xaf\xb1xd4\xd6U\x81E\xda\x98\x04 \x95f~`\xb1\x94\x0e\xcalv\x08\x8a\xf9\x15\xdc\xf4|\x80;Pg@\x98\xe0\x1b\xb8\xa7z\xeb\x8a\x8a%\xb5\x05\xb4\x830\x14\\M\x8d\xe9\xa9\x05C\xafLm\xa5\xf2\x19DD\xde)\xdb=\x16!\x10s\n\x03?\xae\x00\x9f\x84a\xd7P\x1f\xb9JlX\x82j\xa3\xc5\x14\xc4\x92\nI\xf5\x1eT\xb0\xc50\xb1\x94u\xf2\x92h0\xd2\xb4\x1c\xa0\x96h\xc5bX\x00M\xd7\x06\x98\xd9\x7f\x07\x11w4\xf0\x81j\xf7\xf3\x90Q\xf6\x083: \xaf\"5\xda1E\xb6\x1b\x8b\xb0\x0f/^d\xc3\xbc\x8b\xd1%\x89\x16* f|y\xa4\"k\xecj\xd1\xb5\x13\xf6\xe1\x85\x96	\xba\xe1\xe9\xa96?\xc3\x8a\xf3\xd2\xbe\x9b\xdf\nu\x15\x11\xa1\xfa\xc0(O>\xf8N\xc6\x84\xf0A\x11W\xd1\x99\xca\xd6l\x0bJ;xM\xe4]\xf36x\xe2;\xdb\x0cs:\xe7\x14\xea\xc2\x1d\xee\xfb\x0d\xfb\x9d\xf5\x00\x10\xb1\x99U\xc8>\x8c>P\x959\x9c\xa70\xa7<\xc8\x0c\xae\x80\xf0\x17:+\xed&\x16\x99\x84k\xcaR9\xa1 \x89\xed!4\xb6\x98=c	O\xdb\xb4B\xb6\xce\xe0\xda5X;\xca\x83\xc3\xd0\x80K\xb1V%\x93a\xd2`/\x80UN\x8e\xbd\x86\x8c(\xe5:9\xe9\xdcM\xb7\xb7\xc2\xac\xa7\xb0V\xd6\xd0\xb1~\xc3\xbd\xf2\xe2\xc8\xdb\xaa\x18V\xc25\xa0\xc5\x1d\x1a\x87DA\x88*\x90t\x85!lQ\xbal\x99\xdb\xdc\xf3\xfc\xcf\xd3\xd7\xdf\xff\x94)N\x85Abq\x13\\\xe3\x07\x9do\xa6\xc7\xa0\x0f?\xff\xf4\xd3\x0f?\xfa\xd7\x81\xe0\x9aP\x8e\xb2h\xc5Gd\x83}\xf8\xf8\x11\x02!q)1\x16K)\x84\x86O\x9f\xcc\xbbDJ\xe4z\x99\xea\xc9?^\x84\x18#\x0f\x91\x07\x14\xd5\x8b\x7f\xfc\xf1\xc2\x8c\xb2\xff/\xc8	\xf3\xacd\xf0\xe2\x1f\xf0)/!nT{\x9e\xc2\xb9q\xe0\x90\xca\x10\x9dU-\x85\x07 5\x9b\x12\x00\xc8w\xfdB\xa7\xae\x87q9\xbd\x1e\xfc}v3\x1d\xce\x0b\x8d\xe0\x94\xe0[)\xa2~\xe9une\xbf\xa5\xc8Bo\xd56\xf70\xe70\xa2Z\xf5\x828)t:\x85\x91\xd3\xc2\xae*\x1f\x98\xd8l\x0co\xac\xf6\x10\xe2\x9a$L\xf7\x00\x86\xd6op\xeaW@'\xc4U\xb2\xe9\xb8\xaf\x1a\x8c\x99\xa2\xd0\xda\xf2BQ\xbd\xef5,j\xf1\xfb\xec\xdd`y5\xbd\x9c\x8f~\x1d\xdd\x8c\x17\xbf\xcf\x877\xa3\xd1\xa4\xbe\xc2\xf4\xdb\x80\x12~\x17TY\x04S\xcc\xb4\x805e.t\xa3\xf6\x8a\x89\x0d({Z\xf5V(\x84P\x98\xc3\x1a\x91;\x83/W\xd6\x18\xc8u\xeeA\xf4\xd2\x82\xfaF\xc4\xdcW*\x07Fg\x8b\xfb\xbda\xef\x1a\x00\x9c\xc2\xb5\xcf\xceV\xcc\x02c'\xdbEz\x0b\x9bgFT\xaa\xd9\xado\xc6\xad\xfa0\xfa\x1eW\x84\x99\xc7\x12\xf0@p\x8eA)t]G{8\x9dLF\xc3\xc5x:\xb9\x19\xbd\x19\\\x0d&\xc3\xf1\xe4\xf2zz1j\\A\xe1K\x85V\x88\x17\x83\xc5`\xbe\x98\xde\x8c\x16\xbf\xcf>\x1f\xca\xbb\xd1\xe0j\xf1n4\x19\xbc\xb9\x1a]4B1\xba\xa3L\xcd[\xee\x0c k\"*4j\xc7\xc5\xe3\xbd\x99\x19K\x11\xa1\xdeb\xa2\x8c\xad!i`l)'\xe731^\x96\xccg%\xf8\xd66\x15\xb1\x15\x8cV@\xa4~\x8d\x19\x94:W\x11\xd9gJ\xc0\x9e\x98 \x91E\xb5]]\xe8\xecfz=Z\xbc\x1b\xdd\xce\xafG\x8b\x9b\xf1p\xfe\x985?\x00j6\xbdY4\xc2\xf9\xe5\xe5/?\xe4p\x18\xdd!G\xa5fR\xac\xb0(?\xb6Z\xc7\x97\xa8\xcb\"%&z\xdb\x87\xf3tP\xb9\xcd\xca\xc6_^\xfe\xf2\xba\xf4\xda\xd0\xa7\x0f6n`\xfe,\xb4\xc5(\xa9\x08\xe7\x18\x08\x1e\xaa>\xfc\xf0\xb2\xd0f\xa3\"\x84] #\xfb\xa6\x1e\xad\x8a\x06\xcc1\x19\xa8\x89\xe07B\xe8\x92\x95a~V\x03\xce$\xddQ\x86\x1b\x1c\x19#\xc8\xda\x1d}X\x13\xa6\xf2\x9e\x12IH\x1fM\x97l\xd4\x93\x12\xe6\xd5\xcb\x02Z\x95\xb0\x8a{\xf9\xcf\x04\x95Ve\x94\x828qj\xd4\xea\xa5\xa5s\x98\xd2\xae\xcb N\x8a\xca\x10\xac	\x1e	\xb9?0\xc6u0\xc3>~gL\xd4R/\xa7^,\\!\x1b\x9b\xfc\xf0\xef\x8a\xb3\xba\xa6\xfe\x03\x00\xbf\xfb\xf4\xf0\xca\n\xdd\x1d\x82\xc8C\xba6C\xdba7a\xd4N\x87\xf2\xa0\xfa$\xd9\xdf\x0fy\xf43\x11\x1a\xb5&\x93\xd8:\x1do\x92p\x83\xba\xf0\xe1\xa4\xf1\x80\x0d\x9b\xc2\xfb\xd7*\x0d<\xc1 3\xd9\xadP3\xf6y\xd9\xd7w)\xbdJ1\xd8L\x84\xf9Ln\xa2g\xf1\xfd#\xf2\xa1\x90\x99u>\xe4gz\x8aG\xc6\xc2l\"\xa31\x14\xe6C\x99\xaaV\x8b\x90Yug\xc6\xd6\xbdGf\xc6\x12\xd7/\xcfA:-[\xfe\xb2\x13\x04?9u_rF\xc4n\x8f\xe9c\xd5\x86t\xa9c\xca\x81\x14\xb5\xba7\xcd\xd3\x82\x87\xacd\xa21\\s8\x82\xf9\x99\x1b\xe4\xc2\x94i=\xdfc\xf7\xc2\xcf\xec\"\xf7s-\x89\xc6\xcd\xdeu\xd6\xfb\x18\xfbp\xe3B\x08\xb7yh_\x16\xdf\xa4p\x9b\x18\xe3\xcb\"\x01\x05\xcf\xe8\xa9\x03\x01\x05\x18\x07B\x02\xad\x81\x80\xc2\xf0?gH\xe0\xdb\xf8\xf4\xc64Od\xf9(\xda%\xa5+\xb0!c\xc2\x98\x0fye\x83\xbb\x80\xeb5\x06\xba\x0f\x131\xf7}\x0b\x92\xbb\xc5\x9b\xff\x86\x81\x842\xca\xa3\x0f\x18$\xfa\x08\x8c\x8f\x0b\x03\x9c\xc25\xe54\xa2\xff2N\xcf=\xd74B\x08\x1d\xff\x91j@\x0f\n\x05\xa0\xff\x0d\x1a\x19+\xca&- \x14@\xa0\xb3\x162\xc8\xc1\xa7\x03:}k\xf3\xa8\xfe\xf9y\x99\x13B\x11\xa8\xf3@\xf0\x00c\xad\xce\x0d\x030ABun\x8b.b\x11\x9e\x9fj\x94\x11\xe5\x96	\xbabmx/\xdb\xceB\xd3\xa5$\x01\xce\xca6Oj\xf2\xb4\x07;\x0c)\xaa\x91\x0ec5\x0ekA\x85T\x06\xe4\x02?\xcd\xa6\xaar\xaa\xb9\x92\xe1\xb2\x05\xab\x8e\xee4&Q\xaf\x00n\xac}6-\xad!5\xe7\xd3\xba\x08\xd4pW=\x05}f\xb6\xa0\x18Q&\xcc\x18\x8b\xfb\x02L\x8fJz\xd3A\xf3\xd4\xa9\xd1\xef;\xdb\xd6\x02G=q\xf4$\xe0\xb41v\x02`\x9c-b4\xd9\x1f\x9ds\x11\xeb\xf3\x80\xd3\xf3\x15\xe5\xe7\x05\xa4;g\xd0\xe9z<}V\xd0\xfd*\xc1\x8f|QM\xdf\xf3\x97:\x1e\x08\x85\x00\xac[\x83 \xbemf\x8dt#\xf4z\x86y\x0c75\xa21\x1c\\\x8d\x87\xd3\xe5d\xb4\xf8mz\xf3~<\xb9\\\xbe\x19\x0c\xdf\x8f&\x17\xc7\xe3\x12\xa4\xd9\x86\xf7\xb8oA\xa9\xa4\xd8\x83\xfc\xe3\xc9\xf2\xcf\xca\x1eo\x86\xfa\x0b/\n\xbd\x1a\xdd\x816\x87 7\x9c=c.\x03N\x0fz\x04%[\xb8qP\xd5%(v*{\x04\x0d-\xcd\xe6w\xd9%h\x01\xf8]\x15\xd1\xa6\xa5=\xe4\x12\x1c\x8dR+\x1d\x8ev	rH;\xc1\x92\x08\xafm]\\\xf5\x14D\xe6\xad\xe3\xd2\xf3\x1d\x91\xe7\x8c\xae\xec\xc1J\xab\xa8*H9\x0e\xca\x85V\x97\xa3\xee\x86T\x1e\x80j:\x17\xcfk#\xc4\x80\xd3\xee\x8a\xf2\x12\xa8\x9a\x04-Y\xd5\xc6Z^QNd^?b\xc3\xdd<l\xb8\x16\xc5\xc5\xefJ7\xa1\xd4\xc4\x9b\x87n\x04\xd0\xb7\x96n\x05Tzj{X\x90\x9d\xda/d@\xac3\xaa\x14\x97l\xd4\x89u\x02{\xcdbg2^\x0e\xa7\x93\xb7\xadb\xaf\x0f\x9dW/\xbb\x0e\xf9\x9e\x01\xcc\xa8\xd2\x9d\n\x02\xcdW\xd1\xb4\xdeA\xd3\x8a\x89\x97~Kw\xdb\xc87\x93|\xb5{m*\xcb\x9d\xbb\x9c\xb0=\x056\x0b\xbc\"\n\xb3\x9a\x83\xbb\xd7\xca\xb9c\xa6\xa9y\xad\xdfX\xe7\x9c\xda\xcd\xba^\xdc\xfa\x04u\x96\xc5n\xdd\x98\xeb\xc5\xed\xf1\xf8=\xedn\xa47(UV0\x93\xb8C\xae\xbd\x83\x9dI\x08kR)\x86\x18\xdb\xc2\"az\xc9\xe6M\x98_\x8dF\xb3\x16\x96\xb7q\xb9\"\x97\xffG\xe9\x1dZ\xda_Z\xe9}\xb6zj\x01\x85:H\x15h/l\x05VU\x9b\xa70\x08Cc\xc6\xbfe\xf8\x01~\xb5\xb8\xc3\x85\xa4\xc6\xf7\xb6\x0e{\x1e\xca\x8bQ\x1a\xaf\x06n9\xfd\x00\x17\"\"\x94\xc3\\\x04w.U\xe6\xa2x\x17\xf4\x8e(]R\x8c\xf6\x06\xb8(J8\x0d\x88F\x17\x94puU\xc2M\x82\xe0>\x1d\x85\xf9\x9e\x070\x98\x8d\xeb*r\xcd\xf0\xc3N\xb0nh\x11+,\xee\x89\xb5d,\xc2\xef]MX\xd7O\xd9\xa24\xffs.\x0f-\xed/u.\x9by\xac[IS@\xfd\xc0\x95\x98\xb1\x9e\xde7\xbc\x7f\x93\xd8RT\x1b\xc5-\x87a3\xcb\xa4\x10\x9b\xe0\xe9Ey\xb4x~\n\x8e\xbc\x14\x1bI\"\x95\xd9;\xfe\xcb\x16\x9b\x19\x15\x89\x01\xe1\xa1\x16\x86\x1bT\xeb\x07\xaa\x1eb\x81\xa7?M\x06z\xcb\xf9i\xb0&\xb3\xe2\xde<\xfd\xfe\xca&3\xe2\x04\x98\xfd\xferK\xb8\x8d\xa1\xe6\xbf\x07j	\x0e\x9a\x0b\x0f\xd7\x13<XQ\x00\xee\x83\xa3jF\xdb\x87\xd5\x8d\x0bk\xac\x81\xac\xf6\xad\xd9\x1e\xc8\xd2\xc8\xcbZ\x1e\xf9\xa1L\xb2\xb5\x81m\x1aE%\xb1\xcb\xbce\x1f=\xdarWk\x99\xa7fQ\xf3\xf4oGW\xe3\xbf/m^\xf5\xfd\xeb\xf9|t\xf3\xebx8z\x9ca\xf8\xb4\x86W\xbd\xda\xb1\xb2\xe2\xdf\x08\xd5\xd9\xb7\x99\x0f\x10\xf7\xb7\xc1x\xb1|;\xbd\xc9\x93\xf5-\x04\xae&\x9cS{\xfb\xb1&\xb6\xb1\xab\x1fG\xbd\xa70\xab\xb7B(\xcc\x98\xae|\x83h\xfe\xfb\xeb\xc5|\xec\xe2|RP\xefc\xebV\xd2\x10\xb9\xa6k\xc7\xdca^0l\xda\x9b\x97{u;_\x8cn\x0e\x1e\xaf\xd7\xeal\xb5\x89\xab\x0c0H\xb4\xe8\x86\xa81p\x8e\xd7\x9b\xcb\x19\x8cg@\xc2P\xa2R\xcd\xc4\x1d\xb7\xd9\xf6$\xd1\xc2\xc1\xaaN\xe3\xcb\x92\xc6\xb3\xca\xd8\xca~\x8dg\xbf\xfe8\x9bN\xaf\x96\xb5\x8e\xf9$\xe9-\xb6\xf1\x12y@b\x95\xf8\xaf\x1a>}jbn\xe3\x8a\xd9\x83\x94p\x8e\x0cB\xb4\xdf\xcb\xda{{\x8d\x96\x8e\xa9\xbd\xc7\xd7\x15\x95\x84\x07\xa4\xc7x6\x9e\x8cg\x7f>g\xad\xf4%\xc9\xeeG\x88\x85`\xe9\xc9pz\x16\xd4V$,tYA\xe8t\xf3\xb2B\x1a\xca\xceQ\xbb1\x1c_\xdc|\xabu\xd7\xae\xe9\xad\x10 -(\xb3!\x99\xb4\xaaL	\x7f\xd7\xaaf\xe6\x9d\xea\xd8dn\x0b;\xfb\xd5^\x8c\xe7\x837W#{\x11\xeb\xf2jzy9\x9e4\xc6I\x0eHRg\xf3\xa7\x1f<\x9am0\xa6IZz\x07\xc4\x16p\x99\xd7\x83\xe1p4[\x1c\xd2V\x17\xa3\xb7\x83\xdb\xab\xc5hr1\x9b\x8e'\x8b\xc5\xf4\xddt\xbe\x18\xd8\xe2\xae\x16\xa4\x1c\xcc*Z)y\xc6\xb3\xdd\xcfF\xc67\x17\xcf\xd5\xb9\xfd\xd7\x9f\xe7\xb7\xb3Z\xcd\xd1\x017\xbe(\x1e\x96\x83\xdb\xc5\xf4b\xb4p\xc5h\xcb\xeb\xd1\xe2\xdd\xf4[K^{\xebu\x0b\xbe?\xff5\x10.2Y\xa1~\xb2VeY\xdd\xcd\xc3\xb5\x9a\xed\xd5\x9aU8\xedu|\x87\x8eF\x19\xc6Q%r_\x00\xee\x00\xcb\xfe\xf2\xf2\x97WEX\x07j\xcel\xa2\xd2\xd5\x94\x85\xb5\x9a\xb3\xcft\x8c\xfd\xb6\x1a\xfb\xe6x\xc7\xb8qPK\xe1\x96\xed\xd4X\xb7Ul9\xc61n\x01\xd8\xea\x18\xb7\xf4?T\xb8\xf50J\xadt\xf8\x0c\xc7\xb8\xb5*\x12\x00?\xe4E\x13\xe9/\xcd\x17T^w\xa1\x98\x13\xad8\x96\xaeC\xd7~\x11\xde5\xf3\x95\xd9\xa9\xb5\x04\x10\xda\xaa#+}\xd6\x84\xb2D\xe2b+Qm\x05\x0b\xfb\xf0s\x89'\xdb\xea\x1b\xbf\xd2\n\xcb\xd9\xee\xacqEe\xd8\xd0vh\xf9\xc7\x06\x13\x19]\x9dG\"L\x186'\xce\x18]u\x9b\xdb\x0d>S\xce\xf6\xb5\x93\\\x9dB&\xfc\xfc\x836\xfaR\xf5\xb2\x8b\xa4\xab\xf3\xf8\x0e\xdd\x86\x0e\xf9D\xe5\xea\xd3\xfaL;\"\xedl\x8e\xd2\x8d\xf3\xec\x88\xec\xca\x84w\x1b\xbb<n&\x9bx<<\x93\xa1\xde\x13\xcdd\x0c/\x86\xfa<\xbf\xec\xbeq\xd6\xd6\xe6c\xb7\xcb\x88\x05\x94z\x19RY\x97\xa1^\xdf\xa2\xd4\x8f\xe2\x067\xcc\x05\x9d\xd4\x9e\x07\x95\xb1M[hN	\xd9 OCi\x8e\x9fK1\xb2\xf4\xc6\x96B\x98\xac\x1e\xafj\xe6_c@\xda	\xcb\xe7\xa9\xfdHt\x1fb\x9f\x83\x10\x0f\xf0\xa5\xab\x0b\xbc\xa0\xd2\xd6\x94\xed\xa7rX\xbc\x05\xa0<q#7=8q\x0b\x9b\x1e;q\xcb\xc1<8\xed\xc1\x13\xef&~K\x19\xb6\xce\xd9\xc8\xc4G-\xf4\x81S\x92\xcf]\x9b\xb3\xca\xd4\x87\xa6k9#\xf9\xbdOi\x16y8i\xc8I4\xa7f\x0e.\xaf-\xdbs\xec&6%p\x1e\x98\xb2=+\xf4\xd0\xa4\xa7`\xd5N\xfa\x85q\x98v\xb4\x11\x83j\xf5X\xf1\x92\x0e\xe7X\x97b\xd86\xa4\xe0\xbe\x0e.\xdf\xbeP\x80S.@\xcbnA)\xdc\x80R\x00\x98\xde\xe5\x90}k\\\xac\x11\xb3&C\x1e7\xafo\xdd\xc12\x92\xa3\x18\xb4\xb1R%g\x1c\x7f\xe5N{\xc6\xac.\x92\x1a\xc5j3.\x0f\xed\x1c\xd4EVU\x0e7qy=\x03XC\xf2p\x8e\xe4I\xb0-\x1e\x7f\xa7-|iz\xc3\x0d\xb1\xd5\xdb\x14\xd2\xfb'>\xb3\xa6\xfc\xc8\xea\xfb\xea\xe5\x82\xd9\xf5\xabs\xc4\xacztC\xf56Y\xf5\x02\x11\x9d\x97\xeeI9\xaf]M\xf8ew\x18\x1c\x7f\xd3\xe1\xe1\x8a\xf9\x1a\x9c\xfcf\x83\x85\xaft\xc8\xae\x8d%\xdc\xddc\xe4j;\xed\xb5\xb5\x0cm\x00g\x87\xd9\x07{\xbd\xdaw\xf5\x8f\xae\xc3oX\x9bj\xaa\xc6\xc7\xec\xca\x9d\xc3\xd5\xf5\x0f\x11.\xed\xd3H<8\xa2<\xbf\x05fc\xa9\xfe\xb7*a\xffs}~\x9e\x0d\xb6\x15\xceR0\xac\xa0\xef>9)\x00j-\x8b?PI\xde\xb2/\x8f\xfe\xb8\xbc)\xc9\xdb=\x8a\xad\xe0\xe9\xb3\xab\xd5\x99\x8e\xcf\xb4\xfat\x91\xfb\x8c\xb3x\xb2\xdd\xe7\xfc\xcd\xb1O\x1f\x8aZ\x0e\xa7\x93\xc5\xcd\xf4\xeajt\xd3\x98ku;\xd9\x08\xe1\x98Dg\xbe\xf9\x85\xf6\xa7\xf3\xdf\x13%\x9d\x0f\xbf\xc5\xe0\xae[\xb8+\xb1\xd8\xab\xdb\xf4\xcf\x93<Z\xd5\x1c/\x98O\xfe/\x00\x00\xff\xffPK\x07\x08\xf2\x19\x16\x06z\x17\x00\x00oq\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/control-plane-services.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbc\x94\xcdn\xd3@\x10\x80\xef\xfb\x14\xa3\xe4\xc0\xc9\x80C\x91\xa8o\xa8'.(\x12\x88\xfbfw\xd2\xac\xba\x9eY\xed\x8c\x0b~{\xb4\xfe\xa1MH\x95\x96Z\x1c=\x9e\x9fo\xe6\xb3\xbc\x06=\x04\x01g\xe9\x8d\xc2\x0e\xc1e\xb4\x8a\x1e\x02\x81\x1e\x10D\xad\x06\x07\x89=\xb4\x96\xc2\x1eE\xc1\x92/\xefz\xb0\x19\x81X\x81\x10=z\xd8\xf5C\x89c\xd2\xcc\x11R\xb4\x84f\x0d\xc2\xf0s\xee;\xf6\xc4|\x1f\x1c\xce#\x1cg\x84\xcc\x11A\x029,\xc9L\xb1\x7fT\xd1\x82ra\x13\x97m\x1a\x07\xa5\xcc-\xea\x01;1kp\xb1\x13\xc5\xfce+\x03\x92\xa0\x96\x82\xafL\x08;t\xb6\x13\x1cy=\xd3\x08;\xf5K\x99\x7f\x05\xf4\xc6\xdc\x05\xf2\x0d|\x1b\xb9\x8cM\xe1\x07f	L\x0d\xdc\xd7\xa6E\xb5\xde\xaam\x0c\x00\xd9\x16\x1b@u~z\x90d\x1d6p\xd7\xed\xb0\x92^\x14[\x03\x10\xed\x0e\xa3\x94|\x00\xc7mbB\xd2?e\x00\x1a07\xf3\x9d\xaa\xf1N\x00\x96\x88\xcb\xb5\x99\xa6\xd2\x87\x1d\xdf\x06~7.\xdf\xc0Js\x87\xab3	\x89\xb36\xb0\xda|\xf8T\xaf\x8c$t\xa5\x8b`D\xa7\x9c_\nS\x9a\x0d\x18\xd5\xb4s\x8b\x9a\x83\x93y\xae\xb2\xe3\xd8\xc0\xf7\x9b\xed\x18\x19f\x97\xd1\x06@\xfb\xc2y3K1\xf0 \xa8\x19\xac\x0c\\R\x0e\xfcy\xbf\x0f\x14\xb4\x9f\xe2\xa6\xaa\xaa\xd3\xf3\x1f\xbb9\x95q\xf6\xfe#\xf1\x10\x9b\xf6\x8a\x98\xab\xd6\x92\xbd\xc5\xfc\xb4\x9f\xa7\x0b\xfeQ\xd9d\xa4~\xbf\xf9\xb89g\xecD\xe9Eg/\x07|\x8e\x8a\xbfT\x1fTSu\xe4{\xd8cXc\x9ce\xf3-\xea\xf6$z\xfcU,\xafR\xdc\x01}\x17/\x1a|\x9c\xf7zq\xf5b\xe2\x9e\xc3\xb5\xb4\xaf\xfa\xac\xaf\xfa\xbf\xf8*\x7f\xd7\xfe\x82\xab9\xe7\xd5\x9e\xae\xae\x17\xf3t\x89iaGW\xd7\xe7\x1cM\xd1cG\xbf\x03\x00\x00\xff\xffPK\x07\x08\xf1R\xb0\xb0\xdf\x01\x00\x00\xac\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00S\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns-autoscaler.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xccW]o\xdb:\x12}\xd7\xaf\x18\xc8(\xd2\x02\x96\xdc\x04\xd8`\xa1E\x1e\xdc4\xe9\x1a\xed\xda\x85\x9dn\xb7(\x8a\x80&\xc727\x14\xc9\x1d\x92v\xbcA\xfe\xfb\x05%\xf9+q>\xee\xc5}\xb8zI\xa0\x999s\xe6p8\x1aw\xe0\xdc\xd8\x15\xc9r\xee\xe1\xe4\xfd\xf1)\\\xcd\x11>\x87)\x92F\x8f\x0e\xfa\xc1\xcf\x0d\xb9<\xe9$\x1d\xf8\"9j\x87\x02\x82\x16H\xe0\xe7\x08}\xcb\xf8\x1c\xd7\x96.\xfc\x1b\xc9I\xa3\xe1$\x7f\x0fo\xa3C\xda\x9a\xd2w\xffH:\xb02\x01*\xb6\x02m<\x04\x87\xe0\xe7\xd2\xc1L*\x04\xbc\xe5h=H\x0d\xdcTVI\xa69\xc2R\xfay\x9d\xa6\x05\xc9\x93\x0e\xfch!\xcc\xd43\xa9\x81\x017v\x05f\xb6\xeb\x07\xcc\xd7\x84\xe33\xf7\xde\x16\xbd\xder\xb9\xccYM67T\xf6T\xe3\xe8z_\x06\xe7\x17\xc3\xc9Ev\x92\xbf\xafC\xbei\x85\xce\x01\xe1\xff\x82$\x140]\x01\xb3VI\xce\xa6\nA\xb1%\x18\x02V\x12\xa2\x00o\"\xdf%I/u\xd9\x05gf~\xc9\x08\x93\x0e\x08\xe9<\xc9i\xf0{b\xad\xd9I\xb7\xe7`40\x0di\x7f\x02\x83I\n\x1f\xfa\x93\xc1\xa4\x9bt\xe0\xfb\xe0\xea\x9f\xa3oW\xf0\xbd?\x1e\xf7\x87W\x83\x8b	\x8c\xc6p>\x1a~\x1c\\\x0dF\xc3	\x8c.\xa1?\xfc\x01\x9f\x07\xc3\x8f]@\xe9\xe7H\x80\xb7\x96\"\x7fC \xa3\x8c(\xa2f\x13\xc4=\x023\xd3\x10r\x16\xb9\x9cI\x0e\x8a\xe92\xb0\x12\xa14\x0b$-u	\x16\xa9\x92.\x1e\xa6\x03\xa6E\xd2\x01%+\xe9\x99\xaf\xdf<**O\x92\x1b\xa9E\x01\x13\xa4\x85\xe4\xd8\xe7\xdc\x04\xed\x13fe\xdb\x13\x05,\x8e\x93\n=\x13\xcc\xb3\"\x01\xd0\xac\xc2\x02\xb8!\x14\xdae,x\xe38SH\xad\xc9Y\xc6\xb1\x80\x9b0\xc5\xcc\xad\x9c\xc7*\x01Pl\x8a\xca\xc5h\x00&\x84\xd1\x15\xd3\xacD\xcao6=\x9bK\xd3\xab\x8c\xc0\x02\xc6\xc8\x8d\xe6Ra\x92eYK\xef\\\x05\xe7\x91\xc6F\xe1\x1e7\x9a2\x9e\xb3\xba\xdb\xe5\xff\xeb\"\xf3\x9b\xbf\xd7X\x07Y7\x84\x8a\x83\xe4\xff G\n\n\xeb\xa8\x0c\x98\x95\x9f\xc8\x04\xeb\n\xf8\x99\xa6\xbfj$Bg\x02q\xac\xdfi#\xd0\xb5\x86\x05\xd2\xb4~\xa9\xa4\xf3i\x17\xd2%\xf3|^\x1b_\x81DX7w,\x98\x1b\xed\xc9(\x85\xe4zu1\x0f\x13\x94X\xe3\x07+\x98\xc7C	\xf0\xd6\xa3\xae[\xe6P*\x81V\x99U\x85\xda\xaf\xf1\xbb\xb0\xce\xef\xd0\xbf2i\x07\xc6X\x99E\xd3\xd0\xdc\xe8\x99,+f\x1dD\xf9\xc0\xc4\xa91Ee\x96 \x9d\x0b\xf5U\x9b\xc9[\x14E\xd2\x81\xad\xfc\x99\xd4<L\x997\xd4\xe3M?d\x96\x8c5\x14e`j\xe78;\xc7\xa7\xaf\xd3q\xcb\xe4\x89\x028aS\xc0\xc1^\xfc \xb5\x90\xba\xfc\x8b\xb5\xa4\x0b\xd3\xff\"\xf7mW\x1e\xbc\xdf\x11\xf1\x99\x8b\xfc\xe4U&\xa3p\x8c\xb3\x88\xfc\xf8b\xbe\xa6\xa4\xf5\x89<\xa3SRk\xbd+)\xb3\xd6E\xf5\x9a\x94\x1f7\x0d\xf9\xe7\xce%k\x9fTc_\xefu\xf3\xb9F\xd3\x02RO\x01\xd3\xdf}L\x16yL\xedP!\xf7\x86\x1a\x1aU\x1c\x03_vx=\xc7\xccce\x15\xf3\xd8\x86\xee\xa8\x11\x1f\xb5\x87\xf2|\x85\x00Lk\xd3~'\xb6!\x8e\xcfQ\x04\x85\x943e\xe7\xecAE<~C9S\x995\xa2\x80\xa3\xa3m\x18\xf2\xb8\x0f\xe4\x0ey \xe9W\x07\xa3\x9b a\xf8\x0dRO\xe0\x8c\x05\xe5\x1b\x88\xb52\x0d\xd4n\xe3\x0e\x9f\xebY\x00K\xd2\xc4|\xe7\x8a97\xdci\xc6l}fk\xcam\x807\ni\xbf\xe8\x0cnpU@z\xdez\xf6\xe3\x89\xba\x91V\xabtS\x9f\xb11\xcaP\x01\xe9\xc5\xadt\xde\xadMq\x183\xa9\x91v\xe0\x9a\xc6|\xc4\x15@V\xac\xc4\x02\xee\xee\xea\x82\xae	\xad\xb9&c<\xdc\xdf\xc7w\x81\x08\xb5\xbf\xae\x98\x963t\xfe\xe7\x91@\x8bZ\xa0\xe6\x12\xdd\xd1\xaf\x9fG1\xaa\xfe\xfb\xfc0\x8c.\x8e\xf8\xd1/\xb8\xbf\xdf\xe4F\xbd\xd8\x9e\xf2\x9a\xe3\xa7\xd1\xbf\xfa\xff\xf9:\x1e\x9dO6&\x80\x05S\x01/\xc9T\xc5\xce\xcb\xed\x18\xbd\x94\xa8D;\x12\x0e\xd9\x8bf\x01q9\xb7!yht\xbbQqyC\xe7\xdd>\x12\xb7a#\x91\xd0\xeez[\xd7\xf5:\xe0\x9a\xdb\xb0[\x1b\xd4w\xa12\xb4z1\xb2q\x8b\xc1wo@\xce\x0e\xf96\xf4\xeb\x1c\x86\x9eqh\xa1\xde\xec\xf2hL\xc5\xab\xc0\xdf\xdc\xbf\xb6\xee\x9d\xa0\x868j!g\x11\xe0\xa5<\x878\xbe\xa4\xd5~\xe8\xe3\x84\x9b\xff7\xccMU1-v\x8f1\x83\x97\xbe\xd8{\xceY\xb6\x99\xd8g\xfb\x03{\xd7g\xf3\xed>{r\x1e\xc4\xa7\x03\x93\xb9	J\xc0\x0d\xa2\x05\xcf\xa8\xc4\xfa\x07\x8b[i\xde\xfcTi\xa9\xf5\xea\xd9\xedzB\xbb^\x9dUh\x97\xafX\xa5\xf2)s\xf8 y\x83s\xb6]\x8ez-\x89\xbd\xcc\xdf\xe7\xa8\xd7\xf0q\xa9	.\xee\xe8*\xc6B\xbd\x0c\xbe\xad	T\x86\xb0\x96\xde\xbd\x8b+G\xfc\xe7+\xd2\xb8\xd9\xb1Rp\x0d\x7fa*\xa9\x99\xc7|/\xc5`\xd6\xa2\xba\x8a)\xd5\xa0v\xa1Y5_	\x12\xebi\xe7of\x19\xb1\xca\x9d\xdd\xa5Jjd\x94\x16wi%uZ\x1cw\x1f\xf1*N\xfev\xda}\x94\xa88>\xed\xa6\x96p\x81\xdaO\xa4.\x15~5R\xfbK&U L\x8b\xf8\xa9\xdc\xbb\xab1\xbb2\xa57\xce\x0b$:\x8b\x0e\x0f\xcc\x8b\xb3\x93\xe4\xb7\x00\x00\x00\xff\xffPK\x07\x08\xb4\xf0\xa3\xd5b\x05\x00\x00\xf3\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00H\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xacW\xdbn\xdb8\x13\xbe\xf7S\x10\x02\x8a\xdc\xfcr\x9a\xbfh\x91\xe5]\x9a\xa4\xdd\x00\xdb\xd4H\xd2b\x81\xa20hrdqCq\xb8$\xe5\xc6\x9b\xf5\xbb/(J2%+=\xed\xea\xc6\x12g\xe6\x9b\x03\xe7df\xe4G\xb0N\xa2\xa6ds2\xbb\x97ZPr\x0bv#9\x9cq\x8e\xb5\xf6\xb3\n<\x13\xcc3:#D\xb3\n(\xe1hAh\xd7~;\xc38Pr_\xaf w[\xe7\xa1\x9a\xe5y>K\xa1\xed\x8a\xf19\xab}\x89V\xfe\xc5\xbcD=\xbf?us\x89\xc7\xbd\xd2sU;\x0f\xf6\x06\x15\x0c4*\xb6\x02\xe5\xc2\x1biTX\x0d\x1e\x1a\xd1\x15\xa2w\xde2c\xa4^G\x1d\xb9\x80\x82\xd5\xca\xbb\xde\xd4h\x10\xed,\xb6\xb5\x02Gg9aF\xbe\xb5X\x9b\x069'Y6#\xc4\x82\xc3\xdarh\xcf@\x0b\x83R7`9q1(\xf1\xc3\xa0\x88/\xbd\xff\xe1s\x03v\xd5\xca*\xe9|\xf3\xf2\x85y^~\x9f>\x8db\x0c\xb3\x06\xff/c\xf9Zj!\xf5z\x10R\xa65\xfaF\xb2\x8d\xeb\x14\xe4 \xd4\xac\xf6X\x1b\xc1<P\x92y[C\xf6\xdf\xdf\x0c*\xb8\x81\xa2\xb1\xaf\x8d\xd5W|\x9d\x11r\x986O \xbbz\xf5\x07p\xdf\\\xfbd\x82\xfftZ\xef\x03\x8e\xba\x90\xebw\xcc\xfcL\xb1t\xec\xe7h\xa1\x90\n(\xf9\xbb\x89\xe9\x9c\xbe|A\x1e\x9b\xd7\xf0\x80\xb5h]\xffY\x02S\xbe\xec?-0\xb1\xed\xbf\xf6\xd7Ax\x0c\xd0\\!g\x8aH\x9d3!\xec\x9cY\xc3\x884\xaf\xe2\xcb^	iR\x9bH\xed\x80\xd7\x16\x92\xf3\x82)\xe5K\x8b\xf5\xba\x9cF\xe9yw\xfd\x9b\xb1X\x81/\xa1v\x84\xfer\xf2\xf2EO(\xd0~aV\x9099\x06\xcf\x8fC!\xa8\xcd\x9c\xa3.z\x16\xcex	\xe4\xc5\xf3\xfe@!\x9a\xc4]\x85L$4&VL1\xcd\xa3\xc9\xbb\x83\xabb\xc6\xb8}\x81\\\x80Q\xb8\xad\xe0\xe7\xba\xdb0\xf5\x991-9\n\x8d\x8b!\x82f\xe1v/\xaeo\xb3\x993\xc0iS\xfdFI\xce\x1c%\xff\x9f\x11\x12\xca\xc5\xc3z\x1b1\xfd\xd6\x00%7\xa8\x94\xd4\xeb\x0fM\xe1\xc5BMOh\xeb~\xc5\x1e>h\xb6aR\xb1U\xc8\x9e\x93\x00\x07\n\xb8G\x1by\xaa\xd0\x84~Kl>\xb4\xdaCeT\x0f\x9a\x06\xa5\x89\xef@v\xca\xe7\xce\xab\x86Z\x14RK\xbf\xdd\xf3\x87\xd6vvp\x1ab\xf0g--\x88\x8b\xdaJ\xbd\xbe\xe5%\x88:8x\xb5\xd6\xd8\x1f_>\x00\xafC\xed\xa7\x92\x11\xf3\xb6u\xf3\x0el\xe5\x86\xe4<z}\xf9`,8\xb7\xefu)\xc7=li\x83\x93\x87\xee3\xeay\x15\x0bu3\x92!\x04\x0dX\x16\"K.\x1f\xa4\xf3]A\x1a+\xd1J\xbf=W\xcc\xb9\xeb\xa4\x0d\xe5m\x01\xe6\xdcJ/9S\xad\x80\x1b\xf4\xa0\xebQ\xe6\x85\xc7\xa3\n\xaa\x86\xa6\xb7Fg\xe7-\xda\x99\x10\xa8\xdd{\xad\xb6\xd9l\xca\xc8,Z\x99%\x00P\x14\xc0=%\xd7\xd8\x06\x1c&%\x07\xeeq\xd4\x9eI\x0d\xb67%?\xa8\x96\xf8\xc8\x8a\xad\x81\x92\xc7\xc7\x86\xb2\xb4`pi\x11=\xd9\xed\xc2Ym-h\xbf\xac\x98\x96\x058\xff\xe9H\x80\x01-@s	\xee\xe8\xf3\xa7\xa3 \xd5\xfd\n\xdd\x1c9\xcb\x8f>\x93\xddn\xa8cQ+\xb5@%\xf9\x96\x92\xab\xe2\x1a\xfd\xc2\x82\x83\xa6\x9d\xc7\x07\xf4&\x8d[4\xf7\xed\xfbwg\xbf/n\xde\x9f\xdf&No\x98\xaa\xe1\x8d\xc5j\x98\"\xdd|~#A\x89v6M\xd1)Q\xb2\x92\xde\xcd\xb9\xa9gc\xa2\x1b\xe7;8?JEn\xea>`B\xbbe\xc7\xb5\xe4\xa6N\xdd&MeVh\xb7\xd3\xec\x91\x16$\x1e\x9f\x11Y\xf4\x0c\xd1\xba\x06\x0d\xed\xf8\xb4\x15z\x96\xaa\x89$\xfa4\xcc\xb3\xddW\x1dH8\xa31\xa0\x85,\x82\xd4$\xe2\x94	\x93\x9e\x0e\xf9\x0f\xa1\xfb\xf7\x0e\x83\xd9\xb5\xa3\xe4\x13\xc9\xf20[\xb2\xff\x91\xac\x997-\xdeq7q3\xf2\xb9\x17\xd9\xa0\xaa+x\x17\x8a\xd2\x1d\xa6\x0fo\x06}\x1e\x99\x12s\xab\xc0\xbf`\xbe\xa4$\xd50\xb8z&B\x99R\x12\xf6\xa7\x9e`\xd0\x0e\xf5\xf4\x95\xb6@\xeb)I\x86f7\x9f\x86\xb8\xc6\xa2G\x8e\x8a\x92\x0f\x17\x8b\x1f\xc5\xc9=7\x93Xw\xe7_\xc1\x1a\x8c\xf2\x0e\xad\x02o%\x9f\xb6,Ek\x16\x8b\xd0(Q{x\xf0i%0\xa5\xf0\xcb\xc2\xca\x8dT\xb0\x86K\xc7\x99j\xda\x1f\x0d\xcb\x87K\xc3\xcd\x99a+\xa9\xa4\x970\xaa%&\xc4x\x0e\\_\xde-__]_,o/o>^\x9d_\x0e\xc8\xc2\xa2\x19\x0b0\xa5&.\xee\x06\xd1\xbf\x91\n\xda\xedrx\x8dJn@\x83s\x0b\x8b+H\xf1J\xef\xcd[\xf0C\x15&&\xcah\x85#m:Pr\xfa\xfc\xf4\xf9\xe0\xd8\xf1\x12B\x90\x7f\xbd\xbb[$\x840O%S\x17\xa0\xd8\xf6\x168j\xe1(y\x95\x8azY\x01\xd6\xbe'\xbeLh\xae\xe6\x1c\x9c\xbb+-\xb8\x12\x95\x88\x9bC\xf7\x14L\xaa\xdaBB\xdd\xcb\x86\x90\xc8\x1fvw\xb8\xa2\x92\xbd\xb7'\xa7'\xdf\xf2Vh\xd7\xf5\xfa\x8b\xf8G\xa2%\xc4J\xfc\x81J\xe5\xdd\xaa>\xda&&\x87Yx\xa4\x87\xc3\xdd\xa2\x19\xc2]\xfb\x18\xd0:w{\xe2\x93\x7f\x1a\xda\x7f!\x13\x1bh\xb2Y=\xb9\x82\x1e\xfc\x89\xdb/\xdba{\x89\xa1\xcdB\xa9f\x13d\xc7-3O\xfd\x99\xfb\xd6F\xdbm4\xed\n\x93\xa0|\xef\xee;\\O\xc7\xfaZ\xfc\xab\x05%\xd9\xe3cCY\xb6gK\xa1\x1d\xd9\xed\x82\xae\xbes\xe6\xa3\xbeh\xd2\x867n\x8f\xf9D\xf3{B v\xad|\xa2\xbf\x99a\x1b\x1c\x88\xfc\x13\x00\x00\xff\xffPK\x07\x08+\x88?\x85\x87\x05\x00\x00R\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00P\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/default-backend.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xb4V\xdfo\xdbF\x0c~\xf7_A\xd8(\xf2$\xc7\xee\xba\xa1\xd0[\x96\xa2Y\x81\xae5\x92`\x18P\x14\xc6\xf9D[\xb7\x9c\xeenGJ\x80\x1b\xe4\x7f\x1fN\xbf\xac\x1f\xb6\x93`\x9b^\x12\x89\x1f\xc9\x8f\x1fyG\x0b\xa7\xfe@O\xca\x9a\x18\x8a\xe5\xe4A\x99$\x86\xcf\x8ax\xa2\x183\x8a'\x00\x11tA\xc29\xba,\x96\x13\x00\x80\n\xfd\x01\x9d\xb6\xfb\x0c\x0d\x97\x1f3d\x91\x08\x16q\xf9\x06`D\x861$\xb8\x15\xb9\xe6(ev\xd1F\xc8\x074IT,\xe7\x8b\x0e\x8a\x9c\x90\x18\xc3C\xbe\xc1\x88\xf6\xc4\x98\xd5F-6\xa8\xa9	\x08\x81\xc3\xf1\x88-\xa2h\xe8N\x1f\x1fA\xe6\xde\xa3\xe1u&\x8c\xda\"\xf1\xb7\x8b\x04\x1d\x9a\x04\x8dTH\x17\xdf\xbf]H\xeb1\xfcmb\xd6\xe1\xc2\xa7:\xd2\xc5wxz\x9aB\x9b \x90\xf4\x06\x19i\xae\xec\xa5\xd491\xfa\x88\xd0\x17*\xd40e\x9f\xe3\xb4E{\xab1\x86.Ir(\x9b\x82<:\xad\xa4\xa0\x18\x96\xf5\x17B\x8d\x92\xad?\x94\x9c	\x96\xe9\xe7\x81\x0e\xcf+\xc1\x989-\x18;\x81\x06\xed\x81#\xfa\xbe$r\xf5\xfcO:\xb79\xba2\x95\xef\x95\xc0WR\xda\xdc\xf0\x97\xd3\xa3E\xa2\xe3\xe5\xbc\xb2^\xf1\xfeZ\x0b\xa2\xca\xa93dQ\xaavi\x07\xcd\xe83e\x04+kn\xbc\x90\xb8B\xaflr\x87\xd2\x9a\x84b\xf8e\xd1\xc1JkX(\x83\xbe\xa7^tf\xe8{\xf2\xa1)\xfa\xaa7\x9e7_\x7f\xbf\xfasu\xfb\xf5\xfa\xaeg\x06(\x84\xce\xf1\xa3\xb7Y<0\x841\"\x9b{\x89\x1f\x15\xea\xe4\x16\xb7c\xc4\x01\x13\x83V\x99b\x9aK\x97\xf7`3\xb82{P\x99\xd8!(\x02\x17\xb4 \x12\x1b\x8d \x08\xb45;\x10\x83I\x99\xc1r\x0e\x9f\xb8\xec\x0d\x12\x08x\xb7x\x07.\x04\x10\x0c\x97\x03\xe8\xdb.\xf4\xedb\x01\xd6\x80\x80\xcb\x14\x85\xe6\xf4\x07\xa0I\x9cU\xf5M\xd2<%\x9b\x18\xc2\x84Y\x8fk\x8f\xce\xae\xbd\xb5\x0cOO\xffv\xea\xc8\xcbr\xe2z\xf9\xb4*\xd0 \xd1\xca\xdb\x0d\x0eU\x0c\xbd\xbcA\x1e\x8b\xeb\x04\xa7q[\xc9\xd8l=\xc7\xf0~\xf1~12\x91L14\xfd\xb7\xfb\xfb\xd5\xc0\xa8\x8cb%\xf4\x07\xd4b\xdfN\xe0O\xc3\x10\xac2\xb49\xb7\x80\x9f{\xf6\x90\x99\x86c\xd6\x0e\xee\xea8\xaffPhX\xa8\xc7\xbfs\xa4a\xc0\xf0H\x97\x97M\xaae^\xd72\xaf\x1b\x8f\xb5t\xf9Pj(o\xa4\xcc\xfa\xfdy\xd7\n\x13\xbc\x1f\xdf\x80\xda\x8e\x80\xd54\x97\x19\xac?e\xad\x83\xbc\x19R\xa8\xcc\xf1\xf3\xa1G\x9e\xa7\x8b\xeexU\xa4\xd1$j\x1b\"\x9c\xcdr\x8a\xe2Y\x95\xfa\xbe\xe3t\xed\xff\xa3e\xde\xdb\xe3w\xd5\xdd:\x81W-\xf1\x0e\xe0?\xdc\xdf/_\xaf\xdd\x0d\xd1\x9b\xf4\xa8=rmT\xe7-[iu\x0c\xf7\xd7\x87\x93\xc6\xc2\xef\x90G\xe7`\xbc\x83\x9f\xa3MHA\xd6\xab\xed6\x1c\xdb}\x0c_\xac\xc1\xda\xc6{\x871\\W\x85|Z\xbd\xac\x15\xf5\x9a{eG\x0e\xbb\xefDS&\xb3ar\xbf\x11r.rN\xadW?\xca\xcd7\x7fx_J_,\x03\xba\xe1U\xf3\xbf\xb5\x1a\x7fU&QfWY\x0f\xe4fG\xc8\xf5\xd9\xf9M\x05\n\xbf\x89\xca-5k\xd4U7\xde\xe6\xee\x0c\x9b\x06:\"\xd3\x18\xce\x88\xe2[\x18\xe5\x9b\xbfP\x86A)_\xa3\xe3\x9a?\x1f\x91D\x17tL\xe7W\xc9|\xbc\xb0W\xb6\xde7>>\xd7\xcd\xdd\x1d\xb5\xd2v\xce\xc6\xb4\xf9\x8d5\xba\xe8;\xb6\x02\xfd\xa6\xff\xfd\x9f\x00\x00\x00\xff\xffPK\x07\x08\xe2\xb1\xe5J~\x03\x00\x00/\x0c\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/dns-aaaa-delay.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x94TAo\xeb6\x0c\xbe\xfbW\x10\x0f(zr\x82\xdd\x06\xdf\xde\xbaa\x18\xb0u\xc5+\xb0KQ\x18\x8aL7ZdQ#\xa9\xacY\xd0\xff>(v\xd28\x89\x13<^\x12H\xfc>~\xfc(\xba,\xcb\xa20\xd1\xfd\x85,\x8eB\x05\xeb\x1f\x8a\x95\x0bM\x05\xcf\xc8kg\xf1\xab\xb5\x94\x82\x16\x1d\xaai\x8c\x9a\xaa\x00\x08\xa6\xc3\n\x9a \xa51\xc6\x94\x0dz\xb3\x19\x8e%\x1a\x8b\x15\xac\xd2\x02K\xd9\x88bW\x14g5xa\xec\xcc$]\x12\xbb\xff\x8c:\n\xb3\xd5\x8f2s4?T\x7f\xf0I\x14\xf9\x1by\xfc\xc9\x85\xc6\x85\xb7\x9b\nJ&\x8f\xe5b\xc8\x96\xb4\xf8\x1b\xadJU\x94p\xb1\xa1\xefm#\xb3\x7f\xc36W7\xd1\xfd\xca\x94\xe2\x95N\n\x80\xb3F\x0e%#\xbb\xb5\xf3\xf8\x86M\x19%\x96I\x90\x07\x97z\xcc\xcf\x06;\n\xcf\xa8#\xd7L\x8c\x92\x0d\xba\xe5\xc3t\x0b\x00\xde,\xd0KF\xe6.\xe2\x19T\"\xda|+\xe8\xd1*q\x9f\xd9\x19\xb5\xcb\xdf\x8f\xa0\x97\xc1\x00\x8a]\xf4Fq\x80\x1d	\xcd\xe1G\x0cS\x1c\x00{\x119\x96$\xfa\x88\xfa/\xf1\xaa\x02\xe5\x84\xc3\xb9\x92G\xde\x19~DX\xc2\n7\x15<\xb0Sg\x8d\xff\xda4\x14\xe4\xcf\xe07\x87\x0c\x00\x8a\x19G\\\xc1/\xefNT\x8e\xc0\xd8\xb6h\xb5\x82Gz\xb6Kl\x92\xc7\xdb8\x19=\xaa\xc7\xcb\xe3\xc8\x11\xd9\x11;\xdd<x#\xd2\xe7\xf5S)m\xffBJ;\xc8\x1e\x00\x96\x82\x1a\x17\x90G\x0dN\x0c|\x1f\xae3oX\xc1v\x0b\x96\x18k\xc6H5\x13)||\xe4\xb3\xc4\x8cA\xeb\xce\x04\xd7\xa2\xe8\xcb}\x83\x11C\x83\xc1:\x94\xfb\xd7\x97\xfb\x8c\xca\xbf\xe3\x02\xf9D\xd8\xde\xbf\xc2\xc7\xc7i\xb1\xa7\xe4\xfd\x13yg7\x15\xfc\xd6>\x92>1\n\xee\xf6k\x1f\x826\xedZ\xa7\xa0\xf8\xae\xd5\xd1\x15\x1c\xed\xc2h\xbe9\xbck\xd1n\xac\xc7S\x04>+\xc5\xf1!\x00\xbe\x7f\xbe\x9a\xcf\xb0\xd4u&\xef\xd4\xcb\x979cGk\x9c\xc9\xf2\xcb\xebQ\x1e\xa3Pb\x8b2\x063\xfe\x93PTN)mL;\x7f\x9b uv\xa8\xde9T\xef\xd3k\x1b\xd3\xd8%\xd8mBG\xbc\xb9\x8a\xebS2t{\x07\xae=\xcd\xf3\xaes\x03;\xf1\xc4\xe5@q7\xae\xde_V7i\xefNEOtz\x04\xe9\xc5bh\\\x9b\xe1\xd7*\\\xd6v\xcd\x991\xf0\xbc\xd4\xe1\xff'\xd9\x9a|\xea\xf0\x8f\xbc\x89\xa3\xb9\xed\xd7\xe6]\xcd\xc2\xa3\x94\x9e\xecj\xa4\xa3\xcb\x90'\xa3\xcb\n\xe6\x9c\xc2|H\x9c\x9d$\xeey\xbc[\x94j'\x19\xbc[\xcc\x0f\xd7\xbd\xa6\x0b;<!&\x7f\xf0v<\xe37\x7fC\xdb\xa4\xb2kt\xdb-\xa8\xad\xa3aT\xaa\x1b'Z\xb7\xe4\x1b\xe4l\xf6\xff\x01\x00\x00\xff\xffPK\x07\x08B\x8c\x03z\xbc\x02\x00\x00\x18\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/ebs-blockstore.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xc4\xd5Mo\xdb<\x0c\x00\xe0\xbb\x7f\x05\xd1\xf7\xfa\xca\x1fA\x1c\x0c\xbe\xe5kY\x81\x06	*\xb7;+6\x97\x08\xb1-A\x94\xd3&\xbf~\xb0\xdc\x15\xc5\xd6\x05\xeefc\xb9I$H\x91y\x003\xc6<\xa1\xe5#\x1a\x92\xaaJ\xe0\x14yGY\xe5	\xdcI\xb2\x9e\xb4XR\xe2y\x00\xff\xc1\x03_\x02\xee\x88\xed\xf5\x88\xe5X\xa0E\xf8\xbc\xb9\x87\xf5\x86\xa7.6\x9f\xf2%w\x99\x8be:\xbd\xbd\xe3p\xb0VS\x12\x04\xb9\xca\xc8\x17O\xe4\x8bR\\T\xe5g\xaa\x0c\xa6_\xf9r>\n\na\x91l\xf0@hV\xb5\xcc1X\xce\xf8\xa3*\xea\x12\xd3\xb3F\xf2\x0f\xb6,\xda\xf6\xec\xea\xcf\xa5\xec\xf5\x08\x18\xac\xb0B#\n\xd8\xd6F+B\xe0|\x01.\xdc\xd6\x05./H\x10\xc1J\xce\x80A4\x81T\xce\\|-\x9e\xe1v\xb3\xe5\x10M\xfe\x0f\xc3\xf0\xf5.=\x18U\xef\x0f\xba\xb60\x8aCX\xcbY@\xcd\x9b\x18\xb4\x9b\xe2V\x19\xb1\xc7y!\x88<\x00\x80\xb7\xfb\xa46\xe8\x1f?\x91/Up\x8a\\F\x89V\xe4\xc2\x8a\xc4\x9d\x00*Qb\xf2\xd3v]H\x1bu\x92M%4	\x1c\xeb\x1d\x9a\n-\xbaR\xe2\x89\x18\xee\xda\x8eZ\x18Q\xa2EC?*\xda\xb3\xc6\xa4\xd9\x88;\x1b\xcc\n!\xcb\xad*dvN`\xd16\x18r\x06\x83V\xc8j\xc0\x19\xee\xdb\x06]mH\x15\x01\x83\xed\xebS\xf2\xf6\xaf\xe6|\xf1\xab\x8d\xf1\x15\x1b\x93\xf1\xeflDM\xa0\xd5\x01\x83\xadV\xaa\xa8G\x1eRE/g\xa94m\xd1\xacf	\xdc\xc4\xe1\xcd\xcb\xed7J]\x1e>\xdb\xf1?p\xd4\x0c\xdb\x9f\xa3\xbf\x1d\xf6\x83\xe0\xc86\xe0\xde\xf8\xd8h+Ky\xc1\x1c\xbe,\xdeA\x17\x87\xe1\x15v\xf1\xfb\xe6\xe2\xe1\xc5\x91\xedS\x1c\xd9\xa8\xe3\xba\x07\xb6\xd5\x8c\xd5\x9f\xad\xeec}TQ\xd6(\x9a\xab\xe2\x8f\xd4\x8c\xe2\xeb_\xb1\x01\xd5d\xbd\xaa\xc9\xba\xaewh5Y\xafj:\x8f\xd5\xaa\xf9\x1e\x00\x00\xff\xffPK\x07\x08\xb9\xbd\\i\xf6\x01\x00\x00\xac	\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00I\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/heapster.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xecZ[o\xdb8\x16~\xcf\xaf8H\xd0\xba\x05*'\x99b\x16\x85\x00c\x91\xcd\xdc\x1e\xa6\xd3\xa0],vQ\x04\x06M\x1d\xdb\xdcP$\x97<\xca\xc4\x93\xf6\xbf/(\xca\xb2.\x94c\xbb\xd7\x87\xf8%\x12\xcf\x85\xe7\xc6\xf3\x91\x8c\x92$9bF\xfc\x0b\xad\x13Z\xa5p{~t#T\x96\xc2\xef\xc2\xd1\x91 \xcc]zt\x04\x90@\x87\x0b\x00 p\xbeC{+8^p\xae\x0bE%!Gb\x19#\x96\x96o\x00\x8a\xe5\x98\xc2\x12\x99q\x846q\xac1\xee\x0c\xe3\x98\xc2M1\xc3\xc4\xad\x1ca\xde\x9f\xcf\xce\x18\x1f\xb3\x82\x96\xda\x8a\xbf\x18	\xad\xc67\xaf\xdcX\xe8\xd3\x96%\x97\xb2\xf0\xfa\xdfj\x89\xff\x10*\x13j\xb1\x8b5\xdc\xce*\x82d3\x94n\xcd\x06\xa5MV!a9\x15\x0f\xda\x13\x17\xfcM\xe1\x98l\x81\xc757\xcb2\xadr\xa6\xd8\x02\xed\xb8-\x9a\xeb\x0cSx\x8b\\+.$\x96\"VK|\x8b\xf3\xf5l\xcc\x88_\xad.\xcc\x16g+\xce\x9e\xafCn\x95\xe3\xae\x98\xfd\x179U^%\xc39\xfb\x16Y\xda-=!\\\x85\xc4\xda\x89u\xb0\xea\\%p\xbcN\x84E\xa7\x0b\xcb\xb1A\xab\x1dp\x9b!\x9d5\xde\x8c\xce\xd6/\xb7hg\x0d\xd1\x05R\xfd,\xfd\x92X\xbf\xfc\xc9\x88/\x0f4\xc7\xcf}\xea\x88\xd1\xc0\xa4\xdc\"#l\xd9P\xbe\x9c\xc0oUTF\xce\xdb<UL\xa9\x15\xe4Z	\xd2\xd6\x01-\xb1\x8e\x1bdh\xa4^\xe5\xa8\x08\x9e\x82\xa0R\xe0\x99{\xfe\x02\x98\xca\xc0q&+\xffOJ\xb1\xdaL\xd0\xf3r\xa0!.\xe6\xa0\x90\xa3s\xcc\xae\xc6\x87f\xfd\x81t\x87\x9aJ\x8d\xce\x92\xd2\xa9\xc4\xb6\x0b;R\x7f\x81\xf8u\xd7\xec'\x14\xe1\xc35\x16W\x89w\x84\xca\x87\xdamQ\xbe\xc9\xd6\x0eu\\\x98\xcc\x97\xd7\xa7dr\x8f\xf6Zw\xd7\xef(\x8d\x9f\xd6z{=w\xb8x\xbfb\xf7e\xc6\xb8v\xa2~\xaa\x8bb\x87<\x1d\x92$fLOA\x88n\ns\xab\x15\xa1\xca\x0e\xcc\xe8\xed\xda\xad\xe3\xfb{\xe0\x85\xb5\xa8h\x9a3%\xe6\xe8\xe8\xfd(C\x83*C\xc5\x05\xba\xd1\xf5\xfb\x11\xd7\x16\xfd\xdf\xb51\xfe\xb9R1\xba\x86\x8f\x1f\x83bg\x90\xa7\xf5\"2Rp\xe6R8\xafF\x1cJ\xe4\xa4\xed\xc6\xbf\xdc\xf7\xf8\xdf;N\xc7\xdd&\xcc\x8dd\x84\x0d\xe1N\xb8!\x12\xc0!m\xe17\x10H\xf8R\xe1\xa9\xecQJSY\xfb\x1dC\x1d_bVH\xb4c&\xcd\x92u\xd6\x19\xb7\x82\x04g21:Ka4\xaaE\x9bA/\xdf[\xc5\xff\xc7@\xd5\xfb\x9f\xb1B[A\xabK\xc9\x9c\x0b\x8c\x8d\x9aL\x96b\xb1lp\x93\x96hcv\x9f\xc0kfoJP3:\x03\xe6\x80\xc1\xdaZ\xdf9\x12\xad`\xae\xado\xab\xc1C\xa1\x16\xe3\x96\x86\x04np\x95\xc2\xf1e%u\xe1\xdb\x8d{\xa3\xe4\xea\xb8\xc5\x07\xa0\x8d\xb7A\xdb\x14\x8e\x7f\xbe\x13\x8e\\\x93\x81kEL(\xb4\x1d\x03\x13\x109[`\n>\x97\xda\xe2\xd4\xa2\xd1S\xab5\xc1\xc7\x8f\x07\xe7\xd7Y^\xe6\xb6cat\xd5\xaf\x7f\xa8n\xd3\xcePRI\xfc\xfa\xe6\xf5\xc5\xbf\xaf\xde\xbe\xb9|\xd7a\x00\xb8e\xb2\xc0_\xac\xce\xbb\xb2\xd0\x00\xab_\x04\xca\xac\xd1t\xe3\\)H\x91\x0brcn\x8a\xa38\x8b\xebk8\x81\x1bD\x03\x16\xffW\xa0#\x98\x04%@:\x8c\xd3R\xb8M\xf0A(X\x14\xcc2E\x88\x19p_\\\x11\x8d\xf5>*\x18\x04\xc6\xea[\x91a\x06\xb3U\nK\"\xe3\xd2\xd3\xd3\x85\xa0e1\x1bs\x9d\x9fnVC\xf3Q8W\xa0;=?\xfb\xe1\xc7\xbf\xf5&\xa9\xec\x8d8\x04\xc0MQ\xd6\xc3\xda\x8c\xe9\x9ay\xcaM\xd1\xcf*\x94\x0d'\xd7v5 \x15\x88^\xf0\xfe\x89\xdf\xd0\xd5\x1c\xc1\xbdR\xab\xb6\xbd\xe1J\xecI\x7f\xbe\xc0\x90nQ\x17\x11\x8a\xf8\xd5\x10\x08\xc6\xa1\xca\xc4\xdc\x0b\xc75\x0f[\x14\x8f@[\xac?E\xfd\xdc\xb1S\xe79SY?5	\x9c\x0e,\x1eOK\x92P\xa4\x93FstE\x9e3\xbb\x9a2#\xd2u\xe14\xc8\x19\xceY!\xe9\xef\x85\xc3\xf6\xbe`\xe2\x11\xf1\xa9\xe7\x94H\xbfy\xc1\xd6\xc8\x95\xb64\xf1\x85u\xf6T(\x87\xbc\xb0X\xd2\xa3V\xd5\x0d\xd6x\xa9Wg\xaf~\x88\xb2\xe5HV\xf0\xc4/5Y\xf8V:i\x862P\xa7\x1bj\xbf\x0e\xbd\xfa^='\x9b\xc5\xe7\x8dN!:\xbf\xb1\x9a4\xd72\x85\x7f^^\xf5\xa8\xd5F+\x84\xb2\xd3\xde\x15\xa2_\x98\xa4\xc1\x1f\xf1\x1cd\xc2\xdd\x80\xd0\x10\xecu\xe0\x84\xe2\x08\x82\xa0p\x18\x0eI\x12\x17\x8c\xaf \xe4\xaa\xa3\xad^\xf8Z\xc9\x15\xb8\xc2\x94>\x81VX\xf1\x03#`@\"\xc7\x17\xe04\xfc\x89\xa5\x01~\xfapn\x03\xa64-\xd1n\xbc\xfe^\xba}\x12\xfc\xee\xad\xacr\xb4\xb3t\xef\x9f\x80C\xf2\xeb5\x10\xa6\x8e,L\xe0\xd9\xb0\xd4\x07GV\xa8\xc5\xf3\xcd\xdam	\xbfO\xce\xafa2\x81Q>\x8a\xea\x17\xcaw\xeegm\x9949\xbf\xfe \x14\xad\x95\xa2t\xb8\xb3tK0\xbe\xcc#\x80\x17\xea\xc9\xf9|\xce\xb0\x9c\x854\x9cW\xee\x80\xf4\x05FK\xa6\xeam\xe3\xfa\xb7+P\xfa=\xdbC\xd1\xb9\xbf\x87\xf7p\xfe\xa2\xe9\x8fw\xf0\x14\xce\xcf\xce\xce\x9e\xc35|\x80\x9c\xdd\xf9z\xf1!\x11s\x18\x8dG\x1e\xd7\xda\xd9\xda\xe8i\xab\xe9\x88\x97\x11\xf5\x85\xd7b\n\xc4*j\xc7\xad\x10>\x02s\x0b`\xc2:8\x0c\x9f\xbb\xc2\xc30\xdd[\xa3-\xb4nQ\xf7\x07\xedX\x03\xe8;0\xe4\xfa\xee\x10\xbe\xa3\x99[c\xf5\xcd\x01\xfd\xfb\x01\xf1\x97\xdf\x18\xc4\xfb\xf3\xef\x02\xe2\x15\x085	\x9f\x0f\x13\xcb\x9b \xef\xbb\xf8k\x1f`,\xafm\xaar-\x9fwD\xc3.\xef#\x06>b tI\x0f\x83I(\xa3\xbd \xa4#\xd2\x05\x8e^\x11k\xdb\x1e\xdb\x15$b\xab\xa1oX\xdb\x91\x87\x00aGC\"\xfe\x1e\xd8\xfc#%\xbe)\xd6\xd7\xff\x99^\xbd\xf9i\xfa\xc7\xc5\xeb\x9f#&l\xbd\xdc\x00\x98o\xbd\xd6\xa8\xe8W\x8c\x96i}!8\xf6\xd3\xeeb\xcd\xbb\xab\x8b\xcb\xafhRy\xed\xbb\x07j\xd6\xff\x80\x8a\xa2\x107\xc5\xa4N[\x8d>3\xe6p\xa8\xbc\xbd\x14\xde\x91e\x03\xb2%\xcd\x0bO\x0d\xda\xa9\xd2\x19\x0ei	\xe514\xfd\xa6x\x86-\x18\xd4\x10\x8c\x08\xe4\x07\xed\xa0\xa5E\xb7\xd42\x9b\xfc\x18\xa5o\xfek3\xd9\xba\x05\xa9\x91w;\x9b\xd1R&\x06\xad\xd0\xd9\xe4\xe5\x99\xff\xc5=t$rF\xdaN\xf0\xceh\x85\x8a\x04\x93\xed\x86vR^\x88\x0e\x9f\x92\xbf\x0f\xbcn\x1fg\xab\x06\xb1\xcfYv@\xe4\x11\xc4\x1fA\x1c\xba\xa4]A\xfc\x90\xe3`\\r\x00\xd2c\x07\xc1\x18iO\x80\xdf\xef\x088$\xb4\x0d\xeew\xb4n82\x8f\xe0\xff\xe5L\xfa\x82\xe0_e\xefS\xf6\x00]\x15\xd5\xfb\xe1;\x82\xa8M\x87l\x0c\xb6Z\xf6\xcd\xb7	I\xe4\xac\x0d\x9fi\xb7\xd0\xf9\x98\xa1\x14\x8e|#\xf8\xf9\xbf:\xd8\xefC\x826w\x98\xfdx\xfd\x19U\xff\xb3\x80\xce\xddGR\x0e\xa4\xf0\xaa\x19\x1cbv\x11\xaenz\xff\xca\x88\xff\x9bb\xa3\xa5ue\xd2\xd1\xf3\xb2\xa7\xa7\x95\xbd\xfe\xe7	\xad/\x07\xfe\x1f\x00\x00\xff\xffPK\x07\x08\x93*B\x9c\x15\x07\x00\x00\xc9)\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00S\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kube-state-metrics.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xccX\xcdn\x1b9\x0c\xbe\xe7)\x04\x17E.u\xda\x1c\x16\x08\x06\xc8!\x9bn{\xe9O\xd0,\x16\xbb(\nC\x96h[\x8dFT%*\xdb\xb4\xc8\xbb/\xa4\xf1\xfc\x8fgl\xb7(v.\xc9\x0c\xc9\x8f\xd4'\x8a\xa4<\x9f\xcfO\xb8U\x7f\x81\xf3\nM\xc6\xee\xcfO\xee\x94\x91\x19{\xa3<\x9d(\x82\xdcg''\x8c\xcdYG\x8b1\xc6\n\xcd[p\xf7J\xc0\x95\x10\x18\x0c%A\x0e\xc4%'\x9e\xa57\xc6\x0c\xcf!cwa	sO\x9c`\x9e\x039%|C\xec-\x17\x95\xce\x83'\xc8\xfbn\xdd\x92\x8b3\x1eh\x83N}\xe3\xa4\xd0\x9c\xdd]\xf83\x85\xcf[\x01]\xeb\xe0	\xdc\x07\xd4px4.h\xf0\x85fr\xfe\xdaa\xb0>c\x1fg\xb3O[{\x07\x1e\x83\x13\xa5ZT\x14hVj\x9ds\xeb\xabO\x1e\x84\x03\xaa\xdf\x0dJ\xa8\xdf,\xca\xa6jb\xb0\xfePz\xf8\x12\x90x\xf3\xb3\xd5J\xa4\x85\x0b4\xe4Pkp\xb5X\xab\\\x91\xe3f\xdd\xf4\x13\xb9\xf3\x04\x86\xeeQ\x87\x1c\x84\xe6*\xdf-n\x84[\xeeI\xfd	\x8c\xb4\xa8L\xb5\xa6{p\xcb\xc4\x8cV\x9ef\xcf\xd8\xec_Nb\xb3\xa5\xa9C\x1e|%0q\x17\xfd\x18\x8d\x92C\x8e\xc67i\x93`5>\xe4`\xa8\xc7CC\xef\x90P\xb8\xb5\xa3A\xa4\x9cX\x05\xfd\xff\x84_6D\xc3\xa9\xe8\xd0|\xc6e\xcdV\xe3\xe5\x10G<\x10z\xc1\xb52\xeb1w\xe9,\xa2!\xae-\xca\xd2\xa6\xce\xcaC<Z\xd4J<\x8c9\xb3(\xa5\xf2.\xd8x\x04\x96A\xae\xf7\xe0\xf0\xf0*\xf2$\xd5\x06g\x80\xc0G\xdc\x94\xb6l	+t\xc0\xce\xcf.\xce^0\xbf\xc1\xa0%\x0b\x1e\xa6\xe1\x8a\xa24Z\x8d\x06\x8a_-\x1c*Us\x07^}\x03\x97\xd4\x8e+Y\x8d\nTq\xb7\x06\xfa\xa1\xe3\xdb;\xab\xa5\xd2\xbb\xb8\xc8\x08\xd4_J\x05\xd8\x8a\xe2\x19\x9b\x05+9\xc1Q;XS\xfe\xbb2R\x99\xf5N\xe6\x0f\xefJiY\xa8\xe1\x03\xacJ\xa0\x92\xaa\x91\xc8\xb6\x9a\x9d\\\xd8s\x83}X~\x06A\xd5\x1e\xef\xec\xbb\xc7\xae\xe9X\x86\x1b\x9d\xf6h\xa2\x7f\x90\xcbn\xb3\x9f\xf0\xf6\xeb\xa9\x8c\xfd\xa0\xcd\xda\xcb\xea\x98\x1c\xce\xd6D\xbd\xd0|	\xba:\x91\x91M;\x02\xc5\xca\xea\x96\xb1\xd9\xf7\xefL\x04\xe7\xc0\xd0\"\xe7F\xad\xc0\xd3\xc7S	\x16\x8c\x04#\x14\xf8\xd3O\x1fO\x05:\x88\x7f\xfb\x88\xf1\xeb\x16\xec\xf4\x13{|\x9cU.\xe2\xfefl\xe5\xd0\x10\x18Yl\x82\x05Q\xc6X\xf6\xf2\x8c\x9do\xbfx\xd0 \x08]\xbd\x8a<\x96\xf27\x9d\xa5M-\x8e \xb7\x9a\x134`:D\xb3\x01\xc2\xa6q\x8b\xe7\x17\x10\x17\x9f\xba\x11\xc5\xb3'\x8a\\\x9fo\xe7\xc5\x8c\xcd\xc8\x05h[\x0c\xd0\xcd:\x94\xa7\xf7V\xc6\xbf\x1bO\xb9\xf8X\xa7\xd0)z\xb8\xd6\xdc\xfb\xa6~J\xbf\xf9F\xad7\x0d\xed8\x9ere\xc0\xb5\xa8\x9dO\xe5v\xf1\xa8\x9c\xaf!c\x91Xt\xb0p`q\xe1\x10\x89=>\xfe\x04\xb2\xbd\x13\x89\xe8\x96K0\xf7\xed\x1c(C}\xfd\xfe\xed\xd5\xdf7\x1f\xde_\xdf\xb6\xc4\x8c\xdds\x1d\xe0\x95\xc3<\xeb\x08\xea\xa6\xf7J\x81\x96\x8d\xd26\xa4\x93\x15S\xbb?\x136\xb4\xd4\x04\xe697\xb2\x1b\xd5\xf3	\xee\xe6l>\xb7\xe8\xe8\xf2\xe2\xc5\xc5\x8b\x9e\x84@C\xb4z\xa8t\xce{:\x1a\xd7\x84\x9e$8\xd7\x92E\x0b?L\xd2\x86\xc8\x0eF\xd3H\x84\x1bt\x94\xb1\x81\xa8\n\x88*\xb2I\xfbv\xc4\x0e\xb8T\x06\xbc\xbfq\xb8\x84.\xd31\xb0\xd7@\xfd\x0d\xb0\x9c6\x19{\xbe\x01\xaei\xf3\xad/\x1e\x0e\x961e\x14)\xae_\x82\xe6\x0f\xb7 \xd0H\x9f\xb1\xdf:J\xa4r\xc0@;\xe4\xbd\xb9\xa9|\x9e\xb0;\x00\xcb\x1c|	\xe0\x89]\x16\x99\xc1\x08\x8b\xef\xb4Q\xbe\xa6\x83)\xc3\xd6\x81;n\x08@2\x11Oe\x07o\x0b\xd4\xf3\xc3\x98\xb0!\x1d\xaf\x98K\x8b\x94K\x8b\xed\xee-J\xa3\x85\xb0\xa1{HX\xaa\xa19\xba\x87I\xebB-\x02|\x7f\xca\xd4jH\xb7\xc8\xfb\xe4\x07\xdd\x88\xc2\x16\xeai7\x96B\x9c\xed\xe5\xa0g<\xcaA\xc3\xb0X\x00\x18\xa9V\x11d\xca\xd7\xaeX\xa7xk\x9b\xf7\x9dV\xff\xd7\x80\xe5\xc1\xe1R\xa2iM\x8b\xe5\xf3\xb3\xcah\xcb\xc3\xce\n\xba3\xad\x8fL\xc3\xe4\xed\x87\xd3\xb1\x83\xb2GZ\x966\x93\xe9\xd9Q<:M\x07\x1c\x1e\x96\xae\x03\x00\xfb\xa6\xed\x9ek\xd8\x97\xe7#\xd2x\xa0\xf5\xd6\xb9\xfd\xf6\x9f\xc5\xcd\xfb\x97\x8bwWo\xff\xe8E4\xd2\x7f\x19[\x8dt\xde\xad\xf4&u\x80r$<\x8b\x0e\xa7\xa3\xb8\xbd\xb9\xba\xfe%\xa1\xa4\x01\xbfe18\x0f\xa4\x89\xc0\xa2\\\x18nL\xb7u\xc6f^\xf5\x8b\xcb\x89\xb9a\xabn\xc3\xe5\xf8\x16{ Rf=|\x1e#\x04|%\xc7\x0f\x00J\xfa\xbb\xe1\x8a\\\xda\x13\xabN\xbc]q\x1d\x04W\x846\x06J\x1b\x07~\x83Z\xee\x89X\xe9\x0f\xc3\xd5\xbf\x9f\x0cm\xd7^\xbf\xc2\xa7/?\xf3F\xc9\x8dAJ\xb7\xefF\x05\xb7\x0es\xa0\x0d\x84t-\xf1\xc2q\xdb\xbd\x8dt/V\x87\xddd\xc6\xae`\xcd\xabLk(\x1d\x1dG\x07F:\xe2n\x0dT\x0c\x96\xc36\x0e	\x05\xea\x8c\xfdy}\xb3\xaf\xd9\xee\x89\xd6\xf6G\xd8i\x0f]\x98\xfe\xedx\x17W\xff\x05\x00\x00\xff\xffPK\x07\x08\xf2\xb8\xe9 :\x05\x00\x00\xdb\x19\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kubernetes-dashboard.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xccX\xdfO\xdc\xb8\x13\x7f\xe7\xaf\x18\x81*^\xc8\xd2\xaa}\xa8\"}\x1f\xf8\x82\xfaC\xd7\x16Tz\xa7\x93*\x84\xbc\xced\xd7\x87\xedq\xed\xc9B\x0e\xf1\xbf\x9f\x9cl\x96l\xe2e\x17\xdd\xa9wy\x01\xc63\x9f\xf9\xcc8\xf3#dY\xb6'\x9c\xfa\x0d}PdsX\xbc\xda\xbbQ\xb6\xc8\xe1\x93\n\xbc\xa7\x18M\xc8\xf7\xf6\x002h\xc5\x97(=\xf2\x1e\x00\xc0\xc0,\x8a\x0c\xb2(\x04\x8b\xbc\xf9\x0b@\x8b)\xea\xd0\xfd\x15M\\\x0e7\xd5\x14\xbdE\xc6\x90\x15\"\xcc\xa7$|\xb1\xd4\xb0\xc2`Z!\x93\xe89\xf4\xd4\x82\x13r\xa9\x9b\x85:0\x9a\xe6\x90k\x879\x9c;\xf1\xa3\xc2u\xde~\xa1$\x9eHI\x95\xfd7\xf8\x07\xf14\xf9\x1e\xd7\xaf\xa4q\xc4\xd0O\x85\x9c\x88\x8a\xe7\xe4\xd5\x9f\x82\x15\xd9\xc9\xcd\xdb0Qt\xbc\x81\xfb\x13\\\x8c\xb2\xca\x08\xbd=\x9b\xbe\xd2\xd8\x0b\xff\x00N\xb4\xa6[8\xeb\x90\x80	\xa4G\xc1\x08\x87IO7Xgs\xd2\x05\xfaC\x08\xcd\xab3Y\xa2e1\xba\xf7\x9e*\x17r\xf8\xbe\xbf\x7f\xb5\xf2\xe21P\xe5%6\xf2\xd6(\xf4\x8e\x17\xe8\xa7\xcdQ\xeb\xb8w\xf2|z\x01\x99\x95\x9d\x85C\x90dK5\x03#\xdc3	\xb6\x86F\xb8\xbf\xc1q\x86|\x04\x95+\"Qa\x0b(P#cO\x07\xef\xa4\xae\x82Z\xe02\x89\xe1\x99$\xc7Y\xec\x8e\xbf\xc4\xcb\x8f*[\xaeo\xff\x08\xd2*M]\xa6B\x9f!G\xa36\xac\xf8[\x1b\xd5\x0e\xb9hr\xb0L\xc7\xcf\xbd\xb7\xdd\xb2\xd2y\xdf\x1e\xf5\x0e\xb1\x1ad\xafd\x80\xd2\x93\x819\n\x17\x18\xfd3c	ms{2\x92\x0e:E\xday\xba\xabW\x07\xcftz\xbcn\xfd\x94\xeb#\xd8\x9f3\xbb\xbc\x13\xe4\x9d$\xf4D\x1b\x92z5\xec\x90\xffW\xb6Pv\xf6\x1fi\x94\xa4\xf1+\x96\x1dd\x97\xc0'\xd8,5\x07\x1d\x7fg6\xa1\x9a\xfe\x81\x92W\xcd\xf9\x899\xb7\x05u5\x98v\x19Mg\xe84\xd5\x06\x13#T8\x176\xa5\xf8\x9f\x9b\xa3\xdb\xaf\"8\x94\x9d'\x8fN+)B\x0e\xafV\x92\x85\x8a|?\xa8\xc0\xe4\xebO\xca(\xce\xe1\xd5\xcb\xe5q@\x8d\x92\xc9?R5\x82\xe5\xfc\xd3\x80\xff\xf6\x08\x18\x8d\xd3\x82\xb1\x074\xc8	$\xf2\x02\xcbW)\x8f\xed\xc02\xdab\xedh\x9b\xd3\xf5\xe0\xe3#\xc9\xb2P\x16\xfd\xc0I\xb6=\xc5\xdds\xd0\xc4\x02\xa5\xba\x83[\xa1b\xe3\x83\x92|cy~	\xb7\x8a\xe7`\xf1\x16V\xe6\xa0\x8c\x98\xe1\x00\xa4\x91\xe5\xb0\x7f\x7f\x0f\x92<^{tt\xed\x89\x18\x1e\x1e\xa2\xac\xf2\x1e-_\x1baU\x89\x81\xbf\x1f\x16\xe8\xd0\x16h\xa5\xc2px\xf5\xfd0Z\xc5\x9f)\xc2Q\x1e\xbc<\xbc\x82\x87\x87\xfd\x81c\xb4\x8b| \xeab\x7f\x7f\xfe\xf9\xe4\xf7\x8b\xaf\xe7\xa7\x97\x03\x05\x80\x85\xd0\x15\xbe\xf3d\x86\xb6\xd0\xebo\xef\x14\xea\xa2W\xf4i\xad\x1ct|\xc7\xc2D\xbaj/\xad\x12\xc6\x08\x1e\x7fT\x188q\x02 ]\x95\xc3\xfd}\xef\xee\xaeW\xa9\xb8\xee\x0c\xaf\xa5\xab\xe0\xe1!an\xd0\x90\xafw@h\x15#\xc8\xfd\x0bPeZ\xbb\x0d\xae\xf1\xb6|)6\xa9,\xe1^\x8c9\xb5\n\xf9\x8en\x12\x00[r\xd23n\x83A[\xa82\x02m\xf7\xb8\x99\xf5\xf6L\xaeC\x8c]\xaf~_\x87u\xe4S\x17\x9f=\x16\xf3\x05y\xce\xe1\xed\x9b7\xaf\x13\xac\x9c'&I:\x87o\xa7\x17\x83s\xe1gI\xe4,\x13\x15S6C\x8b^06K\x9d*\x95\x14\x8ca\xa4~\x00\xbfZI&\x0e\x01\xe09BIq\xb3\x89}A+\x8bq\xaf1\xc2VB\xeb\xbaiG\xaa\xac\xe1\x97Uz\xe0\xe4\xe2#\xc4\xd5\x01=|\xa0\xc0	\xf4\x8f%X\xe2\xa5\xad\xc2\xe2\xa8\xb74\xdd*\xadAp\xecH\x1c=E\xdaP\xa8 )\x02F6=\xfc\xb8FJ\xb2\x16e\xca\x0d\x13(\x9e\xf4b!\xab\xeb\xf8:D\x94\x02KQi\x86\x8204ln\xc9\xdfL\x12(M\xee\x9cj=fs\n\xfc\xbff\xc59>6u&\x8a\xc2c\x08y\xbc\xd1d\xd6\xbb\xadg\xcdp\xb5\x04\xf6&\xdb$,\xe4$~\x03D\xb9&\xb9\xdaI\xbagA\xba2\xf89\x0e\xfd\xe4\x0d\xef\xf8e\xdd\x7fL\x04\xbb\x10<\xcf\xe1x\x93\xce\x01\x9c\xb6\xdfWd\xb3B\x85\x9b%\x8f\x98\xdc8_\x11\xf0\x0e%h\x9a\x8d\x8d\xb35\x07l\\\x02\xbee\xcd\xc6e-\xee@E\xab\x05Z\x0c\xe1\xc2\xd3\x14\xc7Q\xc7|\xbeGN\xf5\xd0 \xe7\x18\xa1?|\xfbv1n\xff\x00\xaee\x95:\xd9\\{\xca*VB\x9f\xa1\x16\xf5%J\xb2E\xc8\xe1\xf5\xcb\x91\x1e+\x83TqZ\xa5\x0d\xf4\x19\xe3:y\x7f\xed\x07\xdf8\xf2V\xfe\xe59`\xd9\xb6[\x88\xb5X\x9f)\x9f\xc3}\xbf\x95\x85\xb5M\xf4	\x97\xbd\x054v/E^q}\xaaE\x08\x8fF\xcb2\xc8\xe6j6\xefi\x1f\xc0i\xb2\x111\xe9\xd8\xc8\x14\xd9\x10+\xfa\xb1\x7f\x98*pS\xcf\xd3X\xe3q\x97\xc5\x02\xc8\x82\x11\xb1\xb2z\xc8=\x84\xe1U\xdc`\x9d\x83\xa5\x02\xb3\xb8\xaaM\x1ec\x8a\xdf\x18#\xa0\xf8`Y\xa2\xe4\x1c\xbe\xd0\xa5\x9ccQ\xe9\xd4\xbf\xa6\x1a\x9b\x9f\xfa?\xa9\x9e\xc2\x0e\xbb\xf4`,e\xcbJX/\x04\x16~\x86<\x9aO\xe3\xb5z3\xeb\xbf\x02\x00\x00\xff\xffPK\x07\x08=\xf86\x0f6\x05\x00\x00\x92\x14\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00N\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/nginx-ingress.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xecZms\xe28\x12\xfe>\xbfB\x95\xb9\xad\xecV\x9d\x03L\x92\xa9\x1djw\xab\x12\x02$\x99\xe0$\x80\xc9\x86\xa9)J\xc8\x8d\xad K^I&\x90\xbd\xf9\xefW\xf2\x0b1`^f2\xb3\xf7\xe1\xe2/)\xe4\xd6\xd3\x8fZ\xdd\xadn\xc5\x96e\xbd\xc1!\xed\x81TT\xf0*\x9aT\xde\x8c)w\xab\xe8\x8a*\xfd\x86j\x08T\xf5\xcd\x1b\x84,\xb4$\x85\x10B\x89d\x07\xe4\x84\x128!DD\\\xc7/\x02\xd0\xd8\xc5\x1aW\xe3_\x08q\x1c@\x15q\x8f\xf2\xa9E\xb9'A)K\xe1\xdcK\x15b\x02U4\x8e\x86`\xa9\x99\xd2\x10\xac*\x95CL\x0ep\xa4}!\xe9\x13\xd6T\xf0\x83\xf1\xaf\xea\x80\x8a\xd2\x02\x9d\x1a\x8b\x94\x06\xd9\x16\x0cv\xe6B\x9292\x9b##\x06*\x9b\x10\xb3hJ\x11\x85\xf3\xa1dxoo\xfeS\x82\x12\x91$\xb0$A\x04\x1fQ/\xc0\xa1Z\x18\x06\xee\x86\x82r\xbd8\xca\x85\x0b\x8b#\xa1p\x17\x07\x14\x10	\xb9i\x13\x90\xc3%\x95\xcc\xec[~\xe0\x11k\xe2\xbf|)\x8b\xec\n\x14{\xa0_\xaeE%\xbe\xb4\xa3\"\xf4\x92\x15\xc3T\x037\x9e\xa5\xb6\xb2J\xdd\xe4\x1f\xa1\xb5\x85L\xec>\x13\xe0\x9b\x9c \xf6<	X\xc3\xd2`\xb8\x13\x83o1LIi\xac\xa3\x8d\xf6\x89B\xd70\xfa\xd6\xb0\xfe\xaax\x9e\x07\xf2\xda\xec\x82~|\x94o\x8d\xde8\xac2z;\xfa\xd6\xf7\xa5\x99I\xda\x86E^\xfa-:\x83\x11\x8e\x98VH\x0b\xb4\xf7\x1b0 fk,\xea\xfea\xfd\xf6\x9c6\xb1R\x7f\xec-\xcc;\x07	U\xb4\xf7,#\xb8\x96\x821\x90\x16\x03\xec\x82\xfc\xc3\xfa-\xde\xac\xa5y]\x9f*\xe4\xe3X\xdf\x10\x10vq\xa8\xc1Et\x84f\"B\xc4\xc7\xdc\x03\x04T\xfb Q\x88%\x0e@\x83\\@x\xf4\x81#\x86#N|\xca=\xa4}X\xce\xf2s*\x07\x8b\xa6[\xcb\xd5\x8a\x01\xf6v\xdb\x1a\x94w\xf3\xec\xe7\xf7\xdc\xad\x02\xed\x0ba\xfe\x02m\xab'\xd2\x9a\xa5\xbe$|O)w)\xf7\xe2\xd1]\xa3\xd8\xe2Tak\x98\x9b\xb99\xa4\x05\x836\x8c2\xd4\xcc\x1e\x1bh\xa6\x92KIfKZQ\xd1\xf0\x01\x88\xce%\x8f\xb5\xa5\xd0:\xacy\xf9\xf3#\x0b\xa0\xaf\xb5x\xae\x0eZ5\xfc\x0bm\xbb\\\x97me\xf0?\xb4\xf4\xa2=\xe3Hl\xe1pw3>\xe7\x91$\x8c\xad\x14p\xa3\xef\xe6Q\xdf\xa6\xf1\x8f\x14hM\xb9\xa7\xd0H\x8a\xa0\x8a|\xadCU-\x95<\xaa\xfdhx@DP20\x92\x83\x06U\xca\xf4\xc7lJC&\x86\xa5\x00\x1bs\x96\\AT)R -/\xa2.\x94\x12\xbe\x89\x8eH\xc6{V\x9ag\x9c\x83\xc0\x9d\xd3\xe0B\xa7\x19\xd9\xbc\x8e\x14\xb8\xe8\x91j\xbf\x90I\x0cJ9\xc9Q\xcaLR\xd2\x12 #\x03S\x1c\x84\x0cT\x89DJ\x8b \xf5\x99Te(\xc5tf\x0d\x85;\xb3\x14}2G\xc9\xdf\x7f'\xe6\x1d\xa4X\x83g\xf3\x0eb\xe9\x81\x91\x1e\x18i\xf4\xe5K\x96\xea\x8c\x0fI\x8b	\xcfb0\x01\xb6\x05'\x96\x1e0\xe1\x0db\xe9\x1c\x8eR\xcc\n\xa5\xd0\x82\x08\xa6\xb6\xa0(\xc5\x06s\xd9\x1cF\xb2&	\xd8\xb54\x0d@Dz\xa7e\x99	\x83t\xc2\n\x9a\x02\x1e\xa3\x89\x08vC3\x13\n\xd0\xde\"\xe0x\xc8\xc08\xb6\xa4$;\x00\x92Ak\xa2\x95\x95\x94vU\xb4\xa7e\x04s\xb3\x80\x9c\x98\xf3\x11\x07`\xf9X\xf9\xd60\"c\xd0\xbb\xecY2u`\xa6\x0e\xcc\xd4A2uy\x03\x8d\xe1%\xb8T\x02\xd1s\xe5\xdb:QS`m\x08\xd3M\xb44S\x83\xa4B\x8b\xa9\xa1/_\xb6\x87,B\x0c\x0f\x81\xe5\xceI\x1c\x86\xcb\xd9\x80\x0d\xe7o'\x19mc \x12I	\\\x0f\x02\xcc\xe9\x08\x94\xfe\xb4\xefB\x08\xdc\x05N(\xa8\xfd\xcf\x9f\xf6\x89\x90`\xfe\xae\xe6\x153\x9a\x82\xed\x7f\x9e\x1bM\xcfB\xa8\xa2\xeb\x10\xff\x15\xc1JJq\xfd\xb8l:\x08!\xa8\xa2\xabNY_u*\xb5\xb6\xe3^v\xe9i\xbdS;\xbd\xbd\xed]\x9ev\x9d\x9e\xd3\xee]v\x93\xf7\xe5\x8f]\x87]\xde\x8e\xed\xd3N\xd9>u\x9c\xde\xe9\xc7\xb2'I%,\x93C\xff\xe2\xae~{D\x9a\xd3\xf1}\xb7\xd7\xb2\xd9\xe5\x98\xbc\xb3g$\xb8\x98\xf5\xc7\xd3\x1e.W:\xb7e\x16a\xdePCv\xff\xd4w\xd8\xd5]Pi9\x95\xf6Q\xe71,\xdf?\xb4/\xa0\xa6\xdf\xb7\x0e\xc3\x8a[v+\xed\xfa\x07\xe1\xd6m\xbf\xd3\xf5\x83\xee]\xeb\xf8\xee\xdc;\xea\x95\xed\xbe\xeb\xd8\xfdN\xb3\xd1\xb7\xcf\xec\xb0\xd3\x1cOZ\xe7\xec\xcan6X\xa7>\xd5\x1f\xcb\x97m\x08.\xfb\xa4\xa9\xef\xdd\xf36\xaf\x05\x97\x93\xfbrX\xeb\xd5/\x9da\xd0\xab\x80\xf3x|\xfb\xd4\x92wc\xf5\xae\xf5p\xf1\xe40\xdfw\xcf\xdcCRS\xe5\xee\xe1\xe9y\xff\xfc\xf4\xd8-\x7f\x08\xae\x9d\xe3\xf7\xa4\xd2x\xd7w\xf4\xa9\xdd\xeb;\xce\xecC\xbf\xd3e\x7f\xdes7\xe8\xf7Z\x1fqY<]\x9f\xf9\xc7\xceC\xe3p\xe8\xd8\x8d[\xc7~r\xceOC\xecLq\xeb\xc1+\xbb\x0fm\xdaj\xda\x81\xfb\xae\xf2W\xab\xa6\xed\xee\xb8\xf7'a=\xd9\xaa\xe9\x9b\xf6Lwo\x03\xf3~\xfa\xfe~|1s\xef\xf49\x1e7l\xe7$\xec\\\xdf\xf9\xb5vPiv\x02\xe7\xa93&\x87\xfdf?\x1c6\x1b\xe3\xab\xf2\xb4L\x1a\xac\xdd\xae\xeb\x89\x13h\xd1\xab\x84\x97\xd7\xcd\xe9-i~x\xd7\xae\xd8\xadvp\xf9x\xdd\xedw\xee\x9a\xfa\xe9\xfe\xcf\xd3\x07\xc2\xbdc\xa7\xd9\x9ft\xcf\xd8um||\xdd{p\xef\xee\x9c^\x93<\xd9\xf6\xf0\xee\xf8\x82\x94u\xff\xb6\xdb\xd3\xc3\xa6-\xae\xca\xfd\xf7\xads\xff\xbc\xf3\xe4\xd3\xd6\xb9{y;n\xf0\x9bn\xf9c\xea\x0f\x8d\xee\xf8\xd6k\xd7=\xcf\xa97:\xb7N\xa5\xd1\xab\xf7:\xce,\xf6\x05]\xf3~\xff=\xf5%\xcd\xd4\x01\x91i\xde\xf1\x85\xd2\x13,\xd5'\xcaMc*\xe4l`\x86L\xd8|\xfe\xb4\xbf6\xde\x86\xef\x8f\xe2\x98# u\xce\x87\x13\xf01\xcc\xbe\x0b\xf8\x18f)\xf6\x0f\xcc\x1cC\xac(\x19\x98\x02\xe8\x87&\x90<)\xa3\xec\xc5\x06\xca\xf1\xf6u\x88\x95zt\xd7%\x93\x15\xeb\xe10T\x8be\xe7\x19\x86@\xf0\xceF+\xae+\x93\xb6\x1bJ\x85@\xaa\xf3\xb3\xc7t\xa3B>\x1b-\xc0\x9a\xf8WK\x96\xdclM\x844\x04!\xc3\x1ar0K\xa4Q\xc1\xfelCM\x9e\x1f\x9c\xe8\xb3\xe7\xb9\xd02]@Z;[\xe9%\xda\xd2\x89\x9d<\xa6\xb0\xae\x9ab\x92k\xe0n\xee\x15\xe6\\\xe8\xb8\x10[Z\xac\">\xb8\x91i\x9a1\x0b}|\xb0\xa4SRM	fV(\xdc*\xda\xdf_\x98\x1aJ\x11\x80\xf6!\x8aEC\x11g\x8cJ\xf9\xdd\xf1\xd1\xde\x069E$\x0eW\xd9\xe7=\xc0<\xc6\xcbm\xd0\x8fB\x8e\xab\xc8\x88\xe6\xde\xa9\x85\xee\xc0\xde\xdc\x14\x18\xfdTH\xaag5\x86\x95J\xa4s\xfeg\xf9\xd4\xf3s\xd2Z0\x90E\x96z\x8bZX\x8e\xe3\x8b\x87P\xb8\x08+\x84Qf\x1f\x84]\xd7\x12\x1c\x8d\x844=xbS\xca\xbd\x83\x05\x04\x0b%\x89\xaf\x96\xce:q]\xc1\xd55g\xb3E\x83!$B\xc3A\xc8*\xda\xabO\xa9\xd2jo\x89\xca	c\xe2q\xceE\x0b$#\x8e\x04\x8fG\x92:\xfc\x00%\x97.T!	\x7fET\x82k\xe8-\xe1<\xcb\x1b\x10\"\x82 \xe2\x94`\x0dq\x1f\x10_r\x15\xae\x81\x0b\x17\xe2\x86y\xc9a\x12\xac\xa5\xd5\xc0h\x14Wy\xb6\xe8\xa4\xde\xf6\xe6\xef\x9f\x10\x1d\xad\xcf\xb9\x06~\x80G#\xca\xa9\x9e\x0d0Wt\xc8`\xe0\x99\x86\xf4?nru\xf5\xf3\xfe\xfe/q\xffB9\xfa\xb4\xbf\xffo\xb4\x8f\x193\x7f\xb8\xe0\xb0\xff\x19\xfd\xf4%\xef\xfe)\xd4\xe2\x8e\x1a-'\x85o\xd0\xdcfg\x91\xa4\xdc\xeb\xccw\xf4\xc2\xe3b>\\\x9f\x02\x89\x8c\xab,\xcfN\xb0;i&\xeb\x82\x0c\xd4\xaa\x88\x95$\xb6\xfa44\xcb_u\xb8Lj\xa3\xc17\x9d\\\x1b\xac\xf8||->\xcf\x8e\x97\xf8\x9d\xd9(\xe0.\x1d-\xda\xd3(\xc1\x94\x83\\\xb9\x99\xde\xe9$\xc8\x1e\x1a`/9}M\x8a\x1cH\x08\xc5@\na\x1a\x98\xef\x90X\x95$qR]\xd9\xd9\xc2\x8bw\x94n:(]\xb8\x0f$\x8c6\xd7	\xd9\xe4\x01	\xa3b\xf3\x06\x10\x089\xdb\x11%\x116@[b\x85\xd1\x80\xa6Z\x85\xdc*\x96\xc2\xfe\xb4\xca/\x11\xa8~\x85\xba\x02\x90\x1d\xec\x94\x03H\x167\xf7\xaf\xdd4\xaf_\xc1n\x16^\x84Y\xa5P\xe0\xee\xe6\xc1\xd2+\x8c\xe1\xd2\x8e\xbend-+M^\xd6\x10\x93\xb1i\xf1\xd3\xa3\xec\xf7\x7f\xfd|s}6\xb0OZ\xf5\xce\xcdI\xad\xfeK)\x13\xf4\xb5\x0e3\xe9B\xc4\xf9\xbd\xce*\xc6:f+\xd7V\xc5\x1cM\x8bn\xcaw:\x8aO\x84U\xfco\xea\xb7\xb3\x07\xf8\xa4\xc8\x9cI\xfe\xc8\x14\x15l\xf1\x04\xb3\x08\x1aR\x04EA\x8a\xd0\x88\x02ss\xf7\x98\x85\xefo\xb0\xa9\xaf\xb3z\xf0\xc0\xe8\xdcJ%^\xf3?\xc7'.\x94\x97\xe6\x98\x12\xab\xd0\x07\x13\xa6\x8bW<\xf9g\x9e\xaeo\xe2\"-\xae\xd1\xd2h\xa3j\xe0\xe3AZ[\x16\x05U\x86n\x1cq;\xf4\xaf\xe5\x8d\x00;\x90;::\x8c\xa3\x90)\xf8!t\x92\xd2\xf2{\xb2]\x15z\xd6\x90\xad\xa68\xa7L\x04\x8b\x02h\x99*\xb6\xb8>0o\x12\xe7(\x81&i@+\xc5J\xe9uO\x81\xee\x84\xfe\xea\xfbD\xd7\x9a\xf3\xba\x18.\x89\xdfUb\xc9\xb8\xbd\xb5s.\xc8\x01\xeb\xbdn\xfb'(\xf1\xc8.\x9d\xe7\xbc[\xfb\xaa\xd6\xbc\xa0w\xca7%I\xc3l\x0b\x17\xcc\xce\xa6\x83\x0b\x11Y\xe8\x9a\xe1\xb2\xa3i,=X\xf5?\x9e\x02W\xd1a\xb9\x9c\x1b\xcf.\x9c\xab\xa8[\xbb)P\xa4\x964\xe5\x1d2\xaf*?\x9e\xd7\x95\x1f/\xd2\xb5\xda\x8d\x17\xb7\xc7y'\xdfi7_?(\xda\xe9\xbf\xc2\xe8\xf5\x83\"\xf4\xfaA\xd17\x9b\x08\xbd~P\xb4C<\xbf~P\xb4\x1b\xcd\xd7\x0f\x8a\xd0\xeb\x07E\xe8\xf5\x83\xa2\xd7\x0f\x8aV\xea\xa0\xff\xa7\x0f\x8a\xfe\x1b\x00\x00\xff\xffPK\x07\x08\xd7P\x8a\x8d\xde	\x00\x00V/\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Z\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-cluster.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xcc:]o\x1b9\x92\xef\xfa\x15\x05\x19\x03%\x80,\xdb\x99\xc9\xce\xad\x80{\xc8\xc4\xb3{\x01\xce\xb1\x11'7\x0f\x839\x81j\x96\xd4\\\xb3\xc9>\x92-E;\xeb\xff~\xa8*\xb2\xbb\xe5\x8fd6\x07,.\x0f\xb1\xd4]\xac\xefo\xea\xe4_\xfdor\x02\x97\xb81\x0e!\xd5\x08\x11S2n\x1ba\xe3\x03?\x08\xde\xdf\x9dV\xd8\xd6P\xd9.&\x0c\xb07\xa9\x86\xca7\x8dw\xc7\xe0\n\xda\xe0uW%\xe3]\x81^LN\xe0\x8d\xb5\xe0\xbc\xc6(G\xd5N\x19\xab\xd6\x16!\xa8=h\xdc\x99\x8a_Y\x0bk\x84.\xa2\xee\x89\xbf\x1d\xd1]\xc0\x9b\x04\x16UL\x90\xea\x80\x98Q\xaa\x80\x10\xf0\x7f:\x13PON\xc08H\xb5\x89\x80\x9fU\xd3Z\\\xc0-\x8a`\xdaW]\x83.)\xe6\x8e\x084> hL\xca\xd8\x08$K\xf2AmG*\xe8\x19]L&'\xf0\x17\x1f\n\xd69$\x0fU@\x95\x04w\xe6p99\x01\x80\xbbn\x8dU\xb2\xe5\xfd\xe9&\xab\x90\x15\xb68\xa8\xc6~	\xcc\xb7\x18T\xf2\xe1\xab\x80E+\x02\xf7\xaf\xfe7\x99\xa8\xd6\xfc\x17\x86h\xbc[\x02\xb9\xc7\x82\xb8Z\x18\x7f\xb6\xbb\x98\xdc\x19\xa7\x97l\xbc\xb7\xc2\xe5\xa4\xc1\xa4\xb4Jj9\x01p\xaa\xc1\xe5\xe0W\xf9IlUu\xf48\xb6X\x118})\x94&\x00\x00'\xf0\x91t\xee]R\xc6a\x00\xd3\x90\xd5\xd8o\x92\x07\xab:W\xd5\x83\xf7h\x85\xe4\xa8\xad\xd7\x11^4\xde\xcd\xa1\xd9\x869\xf8\xa8\xe7\xd0\xe88\x87\xb0\xdd\xbf\\d\xc4\xbb\x8b\xef\xc1DhLc\xaa9\xec.~\xa0oNu\xc9\xd8.\xceA9\x0d\xbb\x8b\xd7\xf4\xd0W\xc9\xb7],\x07?\xfc\xfc\xf6\xfa\xea\xea\xe7\xf7\x97o>\xbe\xbb~\xbf\x84wn\x14\x0bsb\x0e\x14\x90Dfc*\xd8\x898\x90\xd4\x16\x8c\x8b	\x95\x06\xbfa\x9e\xb7\xe80(\xcb\xb47Vm\xe7\xb0\xafMUC\xdbY\x1b\x19\xc2\xaa\x841A@\x8a\x05d\x9e*\xdfY\x0d\x01cg\x13E\x806\x9b\x0d\x06t\xa9\x88%\xf4\"\x84\xce9\xe3\xb6\x1c\x87\x1c)8D\x17\x07\x8a\x1a\xbb=\xa8\x04uJm\\\x9e\x9d\xd5\xddz\xa1}u\x87aQ\xf9\xe6,\x9c\x91Y\xe4?:tV\x14\xf1n\x03\x07\xdf\xc1^\xb9D\xd6X\xa3\x84Y\x1b\xb02\x11\xe7\xfc\xb2R\x0e\x94\xdd\xabC\xcc\x8aI\xa6\xc1\x98T\xd3\xb2FbW\xd5\xd0\xa3_\xee.~X\xbcZ\xbc>}u~\xf1\xe7\xf3?_\xfc\xd8\xfb\x80\x89\x0c\xde\x98m\x9d\xc0\xf9T\\\x02\x148\xdc\x8b\xf5\xb3\xe4s\xf8[\x17\x13D\xac\xba`\xd2\x016\xe63F\xd8\x04\xdf\xb0\x0e:\xa71\xd8\x03iF\xe2\x8f>\xc5CL\xd8\x14\xfdsz\n\xa8\xbb\na\xd7Y2\xd2\xdaX\x93\x0cFf\x88\x9dp	\xbf\xff\x0e\x95\x0f\xb8\n\xd8\xfaU\xf0>\xc1\xfd==\xeb\x02\x99c\xd5(g6\x18\xd3\xaf3\x8d-:\x8d\xae2\x18g\xbf\xfd:\xa3S\xfc\x17\xdb\x9a\xfe\xc6P\xcd~\x83\xfb\xfb,\xed/5\xa6\x1a\x03\xa9TY\xeb\xf7\xd0\xb9\xd8\xb5\xad\x0f	\xf5`^\xbfa\xa9\x17\xf0V\xe8\xd9\x83\xb82;Iqc\xce\x98\xfd\xe1\xb9\xe4d\xce\xf4H9\n\x9d\x96\x14\x99<t\xed6(\x8d\xf4\xb1\x9c.v\xbe\x16\xff\xa7P\xa0\xb3\xc5\xa3\x999\xd4\xb0\xaf1'b\x13)\xa1\x12\x8a\x14:,\xc7/=[l\xf4\x86\xfcv\x08\x19\x81cl\x9f\x06I\x97\xb0Q6\xe2\xa4\xe4\x80V\xa5\x9a27qP\xfb\x98\x88l\xe0\xd4\xb01\xdb.\xe4To\xec\xa8\xbc\xb4\xc4hL\xa8\x17pE.\xb1\xc6\x12\x96\xa8\x17\x8c\xf8]C\xc4\x94KK0\xe2\xcc\x01)H\x95\xb5\xe3\x80\x99C\xa3\xeeH\x91\x01\x19H\xa3E.\x07&\x826\x01\xab\xe4\xc3A\\\x0cUU\x0b\x7fTBl\x94\xa2\xd1\x90\xc5\x98\xad\x8d2\x96\xd4\x10\x93\n\xa9\xc8C.\xdc\xd7P\xe6\xcb\xc1\x95q\x86\xca\xc1\x9c!fg\x94Qg#j&\xf6\xb2K2\xcc\xd2\x82\xaa\x82\x8f\x11\x02\xae\xbdOq\x01\x9f\"\xc2\x94\x8f\x9fQ\xc2\x9d\x92\xf2\x0br@\xb73\xc1;*\x96D\x99\xa0.M\xf8\x0f\x1f\xd3\x8dJ5\xfb8\x1dZ	\xa9\x15\xbd_i\x13\xc4Y\x07W\xf5\x81M\\|(\xd6\x9c\xa4(F\x8d\xeb\x10p\x87\x8e\x14\xac\xa0\xaa\xb1\xbac%\xc4I\x1f\xd9\x0d*\x17\xd9\x9bg\x91\x14\x93\xba\x98\xd3\xdc\x9a\x8a6\xe3\xd4\xec\xd7{\xaa\xedn\x96\x06\xff\xcd4)\x88\xd7]b\xebH\x92\xd0X\x19\x8d\xe0\x89\xbf\xbd\xc9\x8eD\xbaP\x0c\x15\xe0\xfa\x97\xf7\x10L\xbc\x13>\xbc$\x86\x98\x88\xcc\x07\xef\xeff\xb1\x17\xa7\x0d\xbe\xc2\xd8\x87\xdc\x1c\x02%\xf1\x92-K-\xd4\xbe\x8a\xfc\xe5\xacQdI\xceh\xa7\x19\xc7\xa2N\x8d=\xe1'9|\xca\x1b\xd2C\xbc3\xed'\xf9\xfa\x96\x14\x14\xc7\xce\xcfqS#\xa8\xc6w.\x11\x13\xecM\x92p\xd9\x8dPO\x80\x1eJ\xc9\xac\x08ld9l\xebU\xe3\x9dI>\xac\xf8]\xc94\x1cqW\x9dM\xa6\xb5x\x83\xe1\xbd\xd7\xf8\xcc9\x06]5\x19v\xd5bXQG\x06\xff\x80\xb5\xf7V\x106\xdb \x0c4^w\x16c)\xe0\xb7\xb8\xe3*\x97\x1f\x17\xe7 \x7fq(\xae\xbb\xa6\x8cP\xd9\x8e\xcc\\\x9a:kbZp\xe4O\xb5\x8a\xf5\xda\xab\xa0\xa7\xec\x04\xd3\xcc\x95q\xdbi\xc1\x9aiQ\xbaS\x96\xccs\x00tT\xd74\xac\x0f\xe2\x04C\xafw\\\x0d\xe1\xed\x07FK\x8f\xa6\x12!\x82\x94B,\x97\xae\x8cK2\xd5i\xeej\xda\xedJu\xc9\xc7JY\x0c\xfc\x06\n\xe0\x92\xd3\x1c\x9bO\x9e\x08A\xe9Q\xb20\xdc\x97\xee\x0c\xee\xc9w\x0b/\xe2\xfd\x1c\x89\x19L\xd4\xd8\xe3-\xe6\xe9\xdf\xaf\x8a\x9cc[\x88\xdf\x84]n\x87{\x92\xec\xe3\xd4\x9dtk\xce\xa7/\xba\x88\x9b\xceJ\n\xa7\xd8a\x05V\xe4\xed\xc4\xd5\xf1\xe1\x9dQ\xa0 \x909#\x07\xc5\xe7\xc3\xcbL\xab\x0b\xf6&\xe0\xc6|^\x82\xb8}\x7f\xea\x0b\xcc(\xf1\xeb\xad\xa1\xec@yX\xd4K\x9f\x9e\x92\x93\x9e\xc3?@\xe3Fu6\xbd\xf8\xf1O\x7fz\xfd\xf2+\xd2\xb2\x10\xb7\xb7\xffY`\xa2}l\x986\xf8\x86\x92\x18\x15K\x8b\x81\xdb\x01\xb2L\xb6\x88DVv\xb7\xe2\xd2y\x06\x89p3\x1c\x167n\x03\x9e\xe6\x02\x82\xfai\xcb\x0d\xe8\x9e5]\xdf#\x13V\x8d\xad\xf5\x87\x11\x9f\x1f\xd87\xdd\x82\xfa/l\xdat\x98\x8f\x0e\xe4\xd6r\x98\xdf\x86I\xab\xef_K\xeeD\xbd<\xee\xe4j\xb5\xe3\xde\xd5\xb8\xad}b\x1a\x9c\xf7\xb9(P\xcc\xbd\x1fs\xc9\xf3\xa4jp\xc4\x8a\x8aG\xac\xf8\x00w\x88-\x98$\\/\x9e\xa0]2\xccc\xda}\xd42\x91\xbb\x7f\x8b\x03SU\xed}\xae\xb3\x0f8xa4*k\x8f\x14\xc4-\xd0\xa0\xcc\xcc\x84(\x19\xf5K\xae\xcd\x98\x1eJ\xc8#\xefqS\x10\x17p]\x8a\x8b\xf4\xba\xac\xec-&\xd0]kME\x13\x1c\xbbT\x9e\x85{\xe1\xf8!9\xb2q\x86\xda\x96\xdch\x1d\x93|05a\xda\xfbpW\xec\x95\xfc\x96,Dm[\x94v\xe8\xbd\x00\xf0\xfb\xd1\xf7\x07\xf9\xbc\x8b\xb8\xa2\xb7\xab\x8c\xef\xc8\xf3\xc2Z_\x99\x10\x8e<\x9d\xf2\xaf\xeb\x9a5\xd9o\x93\xe7+2\xabJ\"m\x8ba\xe3\x83t\xd6a\xad\xa1)\x08\x8au\x8f\x1eB\x93\xdb\xb0Q\xe7\xc2\xaa\x99\x0e`\xd3\xa1U'\xbe!yo\xd7\xfe\xb3\xe0#\x9e1\xc4%\x9c\x97\xaaM=F\xf06w\x83;\x15\x8c\xef\"g\x83\xa3MC\xacj\xa4\x9c\xce\xe5\x80:\x9f\xe00a\x94i\x8dh\xb5VUHm\xd0\x83\x8e2\"\xf7\xa8\x11\xd6h\xfd~\xd1\xb7\xa3y;\x903\xeaLY;\x83=\x176	\"k\x07&\x06\xe2\xde\x8d\x88\xe7\xa5\x86Uk\xb4E\x11\xb3\xe0-\xfe{\xdeN\x9c\x12\xc0\x8c\x8a\xd3D\x8cni^\xa1\xc9\xcd\xb8\xe2T\n\xee\xf0@\xb6\x99\x1d\x1d\"F{\x91\x96\x93\xdfOH\xc8 %\x0d\xd6\x1d\x8d\xa2\xa2^\xea\xca\xac\xa5\xe7\x1c\x94g$\xcf\xc68RT\x97@9)\x9d\x1b\x83V\xf3|\xec\xddi\xce9&A\xab\x9c\xa92#\xceXh\xbdq\x14\xea\xf8\xb9\xc2\x96\xd5wr?\xf9\xfd;\"2\xf8`\xcf\xd6\xca3L\xa4\xee\x82\xb2\xc3\x97@h\xac\xff\n\x88\x8f\xfak \xcd6\xc0w}\xf3\xf3 6\x9e\xe6+\xe7d\xea\xae\xbe\x06N<fp\x1f\xf5W\xc1\x89\xdf\x82}\x1b\xbe\nN\xbc\xdf\xb36\xd1i\xb3\x119\x94s^\xb6\\\xf1\xff\x97\x91\x07\xc6\x9e\xb7\xf2\x130\x8f\xcc\xfc\x04\xcc#;?\x85\xe7K\x86~\x86\xb7g-\xfd\x0c\x9f\xcf\x9a\xfa\x19\x9e\xfb\xea\xfen\x03\xce\x93\xcd\xc7\xe6\x93I\x1d\xd3|\xdc\x90\xc4*\xa8\x16\x8f\xc0\xfa\x84\x86\x89RY\xee\x86\x16\xcf\xb8\xd13\xaay\xe4Gm0>\x98txkU\x94\x12\xb4\x1c\x94\xc7\x0d\x9dlHN\xab`\x92\xa9\x94\x1dT\xf5\xec[V\x8c\xbc\xcd\x99\xe9\xf801\xfb\xcc\xe1\x80\xd1w\xa1\x1af\x88\x8f\xb5,~1\xa6\xc8\xcd\xba5\x8dI\xb2m \x97\x9f\xe7M	\xcf\xda\xdb\x00\xad\xd7}qTvC\xf9\xd1;\x84\xb77\x9fxe\xc3(.`k\xb6j}H\xdc45\xd8\xf8p\xe89\xe3\x0f\x90\xc9\x94o\x00U\xdb-az<\"m\xc3\xaa\xf0\xbb\x12\xf8U\xd5vp\x7f?\xed\x8f	\xf2?r\xb2\xc1ft\xb2\x88\xfc\xc7\x19('\xbe\x85\x85\xfe\xec\x98\x89~\xac\xfcc\xca\xf0\xee[\x95\xf1\xc4\xc9oQ\xc6\x18\xcd?\xab\x8c'\xcf\x8e\x99 \x97\xfe\xe3\xca\xf0Q\x7f\xa32\x9e:\xf9\x0d\xca8B\xf3O*\xe3\xe9\xb3\x03\x13\x12\x93\x92TxS\xd9%\xdf(\x0e`{\x80\x80\x8d\xdf!\\\xdf^\xe6f\x91\xb2\x9b\xe7Z\xa3%\xd3\xa9M\x1enb\n\x9eg\x019CG\xdem\xae\xbb\xf4\xc6\xe9[\xb5\xc1\x8f\xfe\x03?\x1f6\"9\x9b,\xe1\xa4\x1f-,\xee\xd0\xf6w:\xc7\x0d\x1cQ\x8ch\xa5\x8dc\xa1\xbb\x88o\xac}O\xcd\xd7\x83t\x991p\x9b\xac\xac]I\x83v<\x9d\xc9\xe9K\xb9\xc9\xfa\xca\xf9r\xdfu\x8cA\x9e\xfe\xc5\xd8\x84\xe1\xa1\xda\x0b\x06\x81Ym\x18\xa87\x97\xf5\x15\x0b\xf5\xdc\xa9\xf2\xbe? \xaa(N\"6\xcb%\x83\x15\x13\x86Q\x90\xd5\x87\x1f\x0f-\xf7\x0f\xfa\xe0T\x93\x8d\x99\x17\xb7kK>@\xd9\x93\x86\xa1\"\x1aa\xe1\xad\xeb\xf0&\xaf(\x0d\xe6\xb9F\x96@28\x0eD\xf03\xcdH&\xd9\x03xg\x0f\xd4>\x98D\xa4\xcb\x05\x1fo\x88r\x12O\x03\xdf\x03\xce\x1e\xd7r\xe0\xad\x7fY.\xa1\xc4PK\x986\xfa|J\x87x\x01|\x00\xc5]N\xc8\xd5Q\x0d\xde\x13\xbdT\xa5\x9d\xb7]\x83Ro\x89\x05\x93h\xa0][_\xdd\x81^g\xf9\xa9r\xf4\xb4\x07\xce\x88\xf0ZE\xbc5\x7f\xc7\xab\x9f\x960\xbd8\x7f\xf5\x03Q\xef\x9c\xe8;\x91\xb8,\x94\x89w\xb9\xf67\xcaZ\x0c\x14.\x0e.\xce\xcf\xe1\xaf?\xf5\xf8\xfe\xe6\xbb\xe0\x94}\x80\xee\xcb\xf8^\x11\x06j\x942\xe2\x8c\xccG\x1do0\xf4j\xb9 \xb6x\xe1\xb6S\xb6C\xbe\xafY#\xf8\x1d\x86`\xb4FW\xd64\xbc\xef\xeb\x0d/!\xd73\x88\xae\n\x876\xa1\xee\xf1\xa6\xd0\xa1\xa0\x1e<N(\xc8}\xaf\x89%y\x98\x08S\x8e\xed\xe9\xe4\x04\xde\x1eE\xb45\x91\x17\x9e#\x97*\x1eAXz\xbf;%ekJ7\xc5\x8c\xbc\x1c\xe9\xb5\x83z~tS\x9c'\xb5|\xcb\xaa\x1c\x9f\x94IN6HG[p\xb1\xeb\x88\x87%uO\xd9\xd5K\x8f\xfd |G\x0c\xe7>\x14\xe0\x94\xef08e\xe4\x15\xba4a\x84\xe9\xbb{\xbe\xff=\xa6r2>u\xb6S\xe1\xcc\x9a5\xaf\x98''\xf0\xcei\xb33\xbaS\xe5\xbe=\xef0M\xc8Q_L\xd9_y\x90\xff\xee\xd1\xda9w\xfd\xb3Q\x1a\x9c\x81Z{^\xbf\xe4[\x12\x89x\xb6\n\xef`\xdd\\\x82\x94\x1dA5|\xfb.Ty,>\xbe\xd0W\xb1\x0f\xa6\xbe\x91[\x00\xfc\xac\xaa\x9a\x0f\xcd\"\xcc\x08\xc9,O\x1cy!\xdc\xa8$w\xb9&\xc0l\x98\x92\x17\xc6\x9f\xd5>&9\xc1\xc3\xf2B4\xc3\x0c\xf4Z\x92\x9d\xec\xf4\xe2\xc7W\x8b\x8b\x1f\x17?,.\xce/\xa6\xf2\xeeX\xab}\x0e0\xd5s~\xd5\x17\x92\x87\n\xa4\x97X\xc4(\xc8\x8b\x81\xa6\xb2\xfc/\xcd\xae6\xa1\xa7?\xeag\xf3\x93\xa1\x81\xe8\x1f\x94\x12\xfe\xfa\xfc\xbc\x99\x8e\x1f\xf7\x05\x9a\xc2\xfe\xca\x8c\xde\x0d]\xc0\xb7by\xac\xb7Wc\xbd\x95*7\xd6YN\xfc\xff\x07}e\x92Q\xaf\xa7\x8f\x1e\xba]\x83\xe7\x9c\x94\xfau\x19\xa5\xac\x82[B\x96\x17)\xb5\xd9\xd6e\xff\xa4\\\x85\x85\xb3A\xe8R\xfdFZx\x98\xfe^\xf7\x1cd`\xea*\x8e\xfa\x87\xc7Q4\xca\x87\x92\xa6\xe4\xd68\xa7L<\xdez\n\x84\xa0\x1c\x18\x19\x95\xae>\x89=g\x90\xef\x1f\x19\xa4o\x1d\xfe;\xea\xc5\x97\xdf\x95\xa2\x9f\xd7X\xb2\xd9\xce\xf2\xf1BN9\xb5\x95\xbdW\xbf\xdf\xa3\"\x12:\x19\x17A\x0b\\\xb9\n\xa3\xac\x8b\xae\xca\x0b\xbe\x01\xf0\xaa\xc73Z)S\x0d\x90\xeb\xcb\xf2K\x17I\x14}\xde\xd5\x99>\xdcx}\xd9\xe3\xfa\xa9\xd3[L\xf23\xa3\xeb\xdb\xcb9\\y7\x87\x0f\x7f\xfdE~\x94quy[\x16\x91\x0bN\xdc7\x97?I\xc9\x13dz\xdc\xbcdfvF\xe5\xf6#\xa8\x84\xdb\x03\xf5\xa2\x96\x17 y\xab\xfc\xab\xc6h\xb6\xee\xb7\x17\xe5VokR\xdd\xad\xe5\xf7\x0f\x14\xd6\xfc\xdf\xda\xfau\xb9\xdd\x93\x03r\xdb\x91	\x9f\x0e\xfaX\x8b\x0c\x8bF\xbf\\\xe4Ny\xa4\x81\xcc\x954\x13d6\xb6\x8d\xdfH\xc3<\x8c\xf5,o\xe7\x04.\xd5\xd8p\xdfl\x0fr_\xa3\x832yq\xa01a\x95\xca\x8a_\xd8\xb9\xf1:kr\xe8\x9d\x89\xea\x1b\xb2\xa9\xf8\xb6q\xd0\x18\xd7%\xcc]:\xa1	\x8dq\x18\xa1\xf6{\xb0\xdem\xa9:\xa2K\x86\x9a;el\x17\xf0\xd27\xca8\xb0\xe6\x0ea\x1apk\xbc;\xfb\xbbw\xc8Yz\xda\x97\x82\x9a\x97I\x0e\xa6\xce\xfb.M\xe1\x85q\xa0\xb46e`Hua\xa8\x08{y\xfd\xcb\xfb\xb3\xebO\x1f\x81\xf7K;e_\x8a\x9c\xd2\x16\xb2\xb4\xe4wrgL\xfd\x83\xe3I\xc3\xe2N\xb9$\xa00}(\xfb\x94\xfb\x0b\xeeF\x16G\xed\xaf4#\xf4\xf2\xfb\xf3iQ\xc3\xa2\x8cyW\x8a\x98p\x94W>\x9a\x06}\x97\x96\xf0\xfd\xf9\xb7\xb8\xf6\x95\xaaj\xe3\xf0\xb1{'\x0f\xe8\xf8'\x05lu\x9e\x90H\"\x8a\xaf\xe1W\x15C&1\x11jT6\xd5\xfd\xcd\xc95\x81\x0f\xbf\xe0\xf1\x0e\xae[t\xb7\xb5\xd9\xe4.\xf9Dxx\x8e\x85c\xbf\x18.;\x8c\xcb)-y\xd8sU.\xbf\xd1{\x0eSO\xee\xc9\xd7\xa3;\x0d\xdf\xa2\x8b\xc4\xe0i\x86=U\xad\x99\xfco\x00\x00\x00\xff\xffPK\x07\x08\xad\xa5\xbanm\x0e\x00\x00\x08)\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Y\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-common.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec=ks\xe3\xb6v\xdf\xf5+\xceX\x9d\xd9$]i7\xc9\xcd\xcd\xc6\xed\x17[\xf2&\xbe\xb1,\x8d\xe4\xdd\x99L\x9av \x12\x92\x10\x93\x00\x0b\x80\xf2*\xbd\xfd\xef\x1d<\xf8\x06\x1f\x92%\xads{\xf9\xc52A\x9e7\x0e\xce9x\xb0\x7f\xfe\xab\xd7\x87\x11\xc7Hb\x90\x1b\x0c\x1e\x0bCF\x81c\xc1b\xeea\x01r\x83$ \x8e\x81b\x0f\x0b\x81\xf8\x0e$\x03!\x11\x97\xfa\x05\x16a\x8e$\xe3\x80\xa8o \xe0h\x03^\x10\x0b\x89\xf9\xb0\xd7\x87\x87\x0d\x168\x07\xf0\xab0\x16\xf2+Xb\xf04Z\x1f\x96x\xc58.@\x1b\xeeP\x18h\x90	$}\x83q\xf5\x14\xe1\xb0E\x9c *\x85E\x00\x02\x85Q\x80\x05\xa0 \x00$D\x1cbK8\x08B\xd7A\x8e\xcc'\x12\x04\x10\"\x8a\xd68k\xb5H\xc0\xe3>\x10\xaa)\x11(\xc4p\xc1\x19{\x1c(\x8e.\x80\xa2\x10\x8b\x08yx\xd8S2\xbb]\x15\xd9\xa7\x18\xfbB\x89\xc6\xc2\x0e\xe3@\x92(\x83-\xe0\x0bB\xc1'\xab\x15\xe6\x98\xca\x0c\x9c\xf8\xf25\x08l\xd8\x17\xd8\x93\x84QX\xe2\x80=\xf5\xfa\xb0b\x1c.,\x80\x81\x88\xb0GV\xc4\xcbdy1\xd4\xccg\xb2\xd5\xef\x19\xce\x13P\x9a_E\x9c\xa2-'t\x05\x1a#o\x93\x11\xd2\xeb\xc3\xd3\x06\x97\x14Q\xe1K\xab\xd8\xea\xa4\x84\x1d-\xd9\x16\x17\xb1\xfb\x0c(\x93y\xbch\x8d\x08\x1d\xf6\xda\xac\xf2\x04W\xaf\xd7\x87\xfb\x84Y\x17\xab\xca\xdc\x98\xdc`\x0eJ\xedy\xc6xJ\x7f\x0fE\xe4#\xe6\x820z	\xdb\xaf{\x8f\x84\xfa\x97\x19\xd8^\x88%\xf2\x91D\x97=\xd0\x92\xbd\x84\xd4\x84\x06b'$\x0e\x01\xfa\x10\xb2\xadR\x01ga\xd6\\P\xc4\xf4nr	\xd77?\xde\xde\xc3\xe8f\xf6\x13\x8c\xe6ck\xe9\xa3\xf9\x18|\xec\x05\x88#%a\xd1\x1b\x0c\x06\x05\xa2PD\xf0'\x89\xa9\xfaO\x0c\x1f\xdf\x89!ao\xb6_/\xb1D	\xb9\xa3XH\x16\xce-\x7fc\xbc\"\x94(`\x0e\xea\x15e\x89\x05\x0f\xd5?CE\xf0\x90\xb0\x9e2G\xf5\xdc\x9a\xb382\x0f\xa6m\xe6m\xa1\x9a\x01,N\x1cmF\x06\x90\xbe\x1b\x10!\x7f.\xb7\xdc\x11!uk\x14\xc4\x1c\x05E\xf4\xbaAu\xd88@\xbc\xd0\xd4\x03\x10\x1e\x8bpN\x11~\x0f`\x9b\xd3\x13\xc0\x16\x05\xc4\xd723d\xb1\x08\xd3\xab\xd9\xed\xc7o\x17\xde\x06\x87\xc8\xdc\x04\x88\xb8\xb2\x07I\x12\xea5R\xcbjr\xb9\x9eQ\x17\xa2\x94I\xa3\x96K\xf8\x9f\xff-\xb4)r\x13\x1d\x15\x1a\xea\xc1\xa9\x0b\x05\x01{\xfa@E\x1cE\x8cK\xecW\x9f\x00\x90;\xc5\xfa\x92\xb1\x00#Zi'!Z\xe3\xfa\xd7\x84\xe4\x84\xae\x0b\xcd>\x12\x9b%C\xbc\x82\xac\x89PL\xd128\x84\xbe\x98\x073\x8eW\xe4\xd3^4\xaaKI\xa4\xfe%B%^[c+^!\xa1$\x8c\xc3Kx\xebjD\x9fL\xe3_\xbf\xfb\xee\xdb\xef*\x0f\x08\x11\xec\xc7\xa2\xeaNc\xc2\x7fbB\xce\x90\xdcTD\x8a\xa4\xc4\x9c^\xc2\x7f\xbe\xf9\xe2?\x16\xff\xfae\xcf\x05\xd7\xa5#\"x\x1c)S\x9bh\xbf\x1cbZ\x91E\x93\xbaB\xe4m\x08\xc5\xe3\x14\xccu\xec\xaf\xb1L\xfb\xcf\xde\xca0\xc3\xc3\x8c\xf9\x06\x90\x03e\x9b!0\xe1O\x90\xd2\x1aE\xd4\xc3\x0f$\xc4,>@\xbf\x86\x90\x89\x9b\xbf=\xc9\x12\x8f$\xfa\x10\xad9\xf2\xf1h\x83\xbd\xc7\xca\xeb\xf5\xaf\x86\x07\xf4\xf3\x89\x8d\x19f\x98\xdf3\xbfA\x07u\"\xf4X\\5\x03\xc8[\xf5\x0f\xfb\xf6\x87faoY\x10\x87x\x14 \x12>\xe00\n\x90\xc4\x15\xcf\x17\xae\xf9>\x92\x08\x99\x1f\x07\xae\x06\x00\"q\xe8lh\x86h.=\x9e\xd5\xb4\xb5\xd8\xb6\xb9\x1a<\x9c\xb9\xeauC\xb1|b\xfcq\x1f1l\x98\x90\xf7\xee\xd7\xa0\xd5\x0e\"\xce\xb6\xc4\xc7\x15\xb9C\x1b\xa7\x02\x07\xd8\x93\x8cW\xc7/!\x19w\x0c$M,t\xf1Qm0\xe0@_\x05m\x9cBG\x9f\x05\xad\xc2\x86}|\x17\xb4v)8\xc8\x87A+\x99\xb1\xc0WA\xa0\xfc\xca\x9eNP]\xb4\xee\xb5\xcf\xdb%=FWd]\x0f\xa2\x9d\x04u%Q\xef\x18oI\xbd5\x99\xab\x03Q`{\x0b~P\xcf\x1e\x03Z>L\xf8bE\x02\xac\xc1\xff}\x19\xc4\xe6\xd7\x97\xff\xd2\xf0\xb2\xe2l\x89\x04^\x90?\xf0\xe4\xfa(\xf4<\xa1\xe0\x88\xd0~g1\xa7G\x85\xc8\x84/f\x98\x1fQ\x9f\x98z|\x17I\xec\x1f\x11fA\xab\x92\xc7\xf8\xef+\x14\x88ze\x9a\x0el\x08h\xb0\xe7vg\x05\xe0k \xefI \xddc\x84\xb9:\xf0a\x00\xa9\xc0\xf6\x18\xc0\x08\xd7\x83Ocw5p\x10\xe7hW\xfbL\x83S2W7\xbf\xa0\x15T\x89\xd8\xcbW\x17\xc6\xba\xe9\xecLL5{]su`\xca\\\xd6\x03\x97\xa3\x85\xecJ\xab(5\xcf4\xb1\xdej\xf1\xcd\xb6\xdef\xe5\x8dLv\xb1\xecf\x00\xcd\xd6\xdc\xac\xf2g\x0d\xab\xcdV\xdbHt\x83:m\x008\n\x90\x10F%\x0b\x15\x8bT\xa2}F\x89\xe2\x9aV\x86\xe5\x93T\x0f\xb8\xca\x15\x0e\xcbZ\xf9\xd2\x9f\x10\xce\xf7\xa6U\x85\xe4\x987(\xd5\x15\xd9q\x1c\xb2-\x9e.\xc6\xe2v5\x8d\xe5\x15\xf5\x17h\x85\x1f\xd8\\\xdf/\xc3\xaa\xe7\x19\x7fR#\x06\xaa\x14!\xda\x85\xbb\x9fl\xa3\x00y&`/+\xb8\xdc\xa1\x91\xef\xeb\xe2!\nf\\q\xceG*'\xa4\x96\x8e\x81\xad#\x8e\x8be\x90^\x1e\x7fA7>\x16\x1e':\xdc\xbd\x84\xb1\xedC;\xe5\n|`\xa64\xff\xf3;a\x02R\xfb\xca\xdf\x16\xd3{][\x81\xa1\x88\xb07\xf4\x1d\xb8\x12:&\x8c\x8eT\x92\xdc\x91\x80\xfb8\\b\x0el\x05\x93\xe9}\x1d\xbe\x90\xd1\xa1\x97\x02M0]\xadq\x01\x89\x8f$\xae\x02Hb\xcf\xa1\xae/\x13FU\xee $\n\xa3\x02\xb0\x85\xcc\xden\xa3y\x14s=\xc3\x90\x7f'O\xb2D2\x16\xfa\x0f.\xe0\xf8	\xa3\xa0\xb3fF8\xda\x14\xdf\xa8\xa2\xd0%\xe1\x8dy\xc6\x96\xb4o\xee\xc7\xf9\x82v\xa5\xcc}w{s\xff\xa0\x1b\xcfQ\xd6&\x98\xcacT\xb5\x15\x1c}\xb3\\\xd4V\x0d55m\x8d[\xdf/\x97\xb4-\xb4\x17Q\xd1\xf6PTq(\xc64\xd8\xf2w\xec\xc9\xaa^3\x0dV\xd5\xfb~q&\xd5\xea\x1cI\xcf\xb6<_\xbd\xefSX\x0e\x15g\x8dN5\xe7\xe8p\xa8z\x95\x87\xfc\"\xd4\x9dHs\x81\xf9\xb6\x1a\xf24\xd6/=I\xb6xT[\x7fLJ\x8c_\xbb\x1a\x93\xe2\xe4\xd7\xfb\x17 \x0d\xde\x85D\xd4_\xee\xf6\x1b\xe2\xa0e\xc6\x06\x1a\x87Ah\x8cm\x13A\xce\x18\xdbk\xa8^!\x12\xc4\x1c\x8fY\x88H\xa5~\x0cm\xd1\x1b\xc7Q@<T3M\xd4\x168\n\xf2Gm^\xd0\xa8?h\xd5!\xb4\xea\x11s$b\x8eG\xcc?\x8cx%\xec\xd1&\xa6\xd5\x12}r5\xe3\x07\x1d\xfc\xfa\x84\xae\x0f\x85\x92\xa8\xbb\xc6_\xba\"\xfd\x9a(\xbf\x99\xd7V\x13i\xcd\xde\x9a\xcd\xa4\x0d?\xb4\x98J\x07c\xe9b.\xed\nk3\x99.\x8c\xb4\x9bM;\x1d\xd0\xd1t\x9a E\x1c\x0b\xe5s\xb5\x01M\xe9\x18\x07X\xb6f\x05\xdd\"\xef+\xed!'\xe3E/\x0f\xa5K\xc8\xebcA8\xf6\xad\x8f\x85\xc9x\x01>\xc2!\xa3\xb5\x81pa\xf8\x18\xe6\xc6\x84\"E\xcf\x8d\x8c\xcbA\x87\x8d+\xaa\x01\xc7\xfd\xd9\"\x0e\xba\x12\xf8\xf9\xb1\xc6\xfd{\xa3\xa5b\x90q\xff~\xe1\x8c.4N}\xb7\x18W\xd0\x95\xbd\xbba\xdc\xcc\x92\xa4\x16\xa1[^D\xa8\xc1\x91\xcf*]\xa5\xa9\xc3F\x8e\xa1\x14\xda\x9c\x1d=\xa82 \x0e\x0c\x7f\xea\x91\xd4\x86/G\x8c?\xca\xbd\xe2\xbe\xb6[L\xaf\xffv3z\x80\xc5\xc3t~s\xa6\xfea\xd2\x05=[\xf1\xfcn2\xd5\xc0\x16\n\x98\xa3\xbb\xe4Z\x9d\xdd&O\x8a\xa3\xf7\xe4\x9a_J_Y#\x89\x9fP%\xbcm2Hmv\xfb\xd8<\x98\xc5-#\x05p\xa5\xa3\x839^\xb9m\xf2\xa0e7\x02{1\xc73\xf5\xae\x0b(\xa1B\"\xdaX\xe6=G\x1f\xca\xb7\xfc\xa3\xc5\xf0\xff_\x02\xf0\x7f\xea\xaa\x0d\xf3\xe7\xd7\xd5\x01\xf1nyx\xab\x0cb-\xe3\xdc\x87\xc5\xcd\xfc\\\xd1`n\x0c\x89\xc51V\xce\xe6\xc6\xb4\x0f\xc2\xb9\x82\xb6\xf4D\xdb\xc8\xa7\xc9\xd2\x0f\xd4\x8e~q\x82\xa8\x1aGr\x8f\xc5\xf6\xa7y\xc1>\xdb6Z6\xea0SPU\x93\xd7w\xd3\xd1\xcf0\x9bN\xef\xce\xa4\xc1e\xc0\xbcG\x15r>_w\xd7\n\x942t\x87\xd6\xd26\xa7\xbe2\"\x1c\x9aJ\x1b\x0f\x90{I\x9cUy\x7f\x9c\xde}\x98\xdc\x9cK\xe0f\x15\xa1\xa8\x13r\x83|?\xea7K\x8257+\x12\xb5XJ\xa2\xdcf\x10\x1cv\xbem\x17.\n\xa2\x0d\xfa\xa6\"\xe2\xb2\x04\x0b26v\x7f\xfda\xf4\xf3\xcd9f;L\x1f]\xc6\xde#\x96b\x98\xff\xcf!\xedrs\xcal\xa9\xae`9Oj<\xda\x9d\xfb\x97 y\x9c$\xf3\xc9\x92\xc1\xe4^E{\xc6g]kT%\x1d\xe6\x9b*\x9a,\xf0S\xd2g\xbe\xcd\xa9U\xe3\xb4r?s)q\xb6AA\xc4\xcb,24p\xf4\xa4V9\xd3\xaah\xb2V\xcf\xa3\xbb\xab\xdb\xc9\x99\x95\xed\x05\x88\x84\xf5\x1a?\x96bk-\xa7Q\xe3z\xd5p\x83\xdau{\xa3\xee\x0d{\x0d\x06\xe0\xa5(\xdcV\xe0\xe5\x7f\xd7\x95F\x0e1\x85L\xd9\xf5\xfd~>\xbd\xbb\xb9\xbe\xbd\x1f\xdf\xde\xff\xa8M\xc2j\xda\x98\xe0\x9c\x05\xf8\x9aP\x15c\x15l\x85/\x917D\xb1\xdc0N\xfe\xd0IOf0\x8d\x9b\x8f\x8cX\x06\xb6cp\x16\xe8\xfc\xae\x07\xca\xfa~\xb4~\xb6\x0ev/\x1d\xce2\xe2ZQ\x88\xd8\x84\x12\n\xc7\xc0\xbe\xbf\xc0|K<|\xe5e\xd3\xe5e \xb9\xa9\xb6\xacx\xf3\x8cMT\x95N\x9a\x17{Q9\xb3\x9b\xf9\xd5\xc3t\xae\x9f\xd0\x1a1;\xae\x92\xcd\x81Jfz\xdb\x9c^\x9dK\xe8Z\xef6\xcc\xed\x87s\xec\xd0\x03B\x01\xe5\x88\xea\xac\xca\xaa\xbc\x9b\x94\x9b\x10\x10\xaeC%\xd5\x00-q2\x0f\xc1\xec\xee6\xf3x/\xd7}\x07K\xe4=b\x85F\xc1\xe8\xa1\xf5\x9a\xe3\xb5\xa6c\x1e\x9b\xe5\"^\x86~\x91.\x0c\xd7\xea\x0c\x91\xf46w94`\xd8\xc9\xc7Co\x12\x88x \xd9\xc0M\xec%\\(\x1fr\xd1\xd3\xebx.\xe1\xd7\xdf*\xde\xf1Db\x1ah\x8c\x87\n\xebX\x0c\xf7\x06i\xff\xb3\x92\xbd\xb8\xe8\xe5\x8b\x12\xfa\x9e\xc0\x1e7#\xdd\x00\"\xe6g?\xde\x04lm\x9f\xd0\x1d\xcb\xb4\x98E\\!\x8a\x84\xf1\xf1K\x0bf\xadG\xc4\x81v\xb7\xfa\xc7\x93\xd2\xa2\x01\x96\xfe2\xfb\x1c\xf5\xcf8\xf2\x93\x9f\xbeN\xd0\xaa\xd4\xa2(28\xb3\x01\xacJ\xbe\x8f\xa3\x80\xedB\xbb\xe0a\x90L\"\x18\x8e\xba\xd1\xd7LU\xdaU\xd3.Z\xd8\xd7\x99m^-\xf4K\"\x05\xb0'zx\xefl\xb5\xb7\xd4\x97=\xcf\x93\x1dh\xa3\xfbXXjU\x05\xdb)\xd8U7E\x9d\xd6\x90\xf2\x863\xd0\xc30^\xc5Az\xa3hh\xdd\x08\xeehY\xf5\x83\x802\xb59c\x8fj\xb4\x17\xfb\x9aPW\xcf\xb5\x0e\xd8\x12\x05\x87Z\xc2\xd9\\\xbb!\xf3\xbcN\xdd\xe0<\xa3;w2\xd9\xa5\x9b\xf5a\xc6|@\x9e\x87\x85\x00\"\xf4\xd6z\xbb\x13\x7f\x85\xa9g*\x8ci_\xec\xc3=\xf3\xb1\xfbq\x1fK\xccCB\x95\xfd\xe9\x95\x90v;\xbb\xea\x1d*\xd4\x8d\x03\x1fxL5\xb8d\xa5\xa4\xfd\xf5&\xe2\xec\xd3\xaeS\xf7\xe8\xc0\xd2\x00\xf06]\xc8\xd6\x87\xd9G\xa1w\xd1\xcf>\x8e\xcc\xaey\xe3w}X\xee\xb2~\xa27\xa7)3\xd0\x89\xd6\x00\"e\x14Bb*\xb3\x0c\xbdz7\x8d\xf5\x07\x80\xa9\x1f1\xb2O'?\xd4+Yk\xc9\xc2\xe0\xf2\xc0l\xda\xbd\x00	\xd1\xd1IVp,-m%\xd0\xbf\x9b\xc4\xb4\x1d\xe0\xfe\\\x95\x8aV%\xc4\x17_]\x14\xf1\xaa\x1b\x15\x18\xcf|=b\x01\xf1v\xfag\x07\xcf\xaf\\0\xd1} \x19\xdb\xad\xfb\xcav\x18\xaa\x81Kr\x16\x04\x89Q1?k\\\x9a}s\x15HK&7\x0eP\xca\x82U_\xf19\"y\xc0\xaeAf\x90L\x02T\xc3\x19'\xe3f9\xac\xb7\xc1\xde#\xa1\xeb!\x8b0\x15\x1b\xb2\x92NY\xda\x8d\x8f.FNa\x17\x16]7\xa2NED[\x97\xf3\x04\xf19\xd9\x1am\xe4\x08\xb0X\xcc\x88}\xa521\x15\xde\xad\xb4\xb1\x0f\xc257\xe7\x92p\xfc\xdf1\xe1Y\xc6\xf6DR\x17\xeb\x18v:\x8fXM\xe3S\xb8\xe6\x83\xec\x9c\x8a\x83\x86\xa7\xb3\x8d\xdf9Zk\x06\xf1\xd3\x0b\xe9\x8c#y=\xbb\xdd\xc6\xbeR\xb0|\xae\x91\xb6j\xf5P\x18\xe3R\xe0	6\x8b\xe4t\xea+V~^\xa4\xeaJ\x8e\xb91\xc7\xce\xe9\xb5\x04\xac\xddCAcX\x90i%\xa7\xa8&;(\xd5T\xbbp\xa2n\xe6\x8b_\x85\xb2V\xa1\xe2\xb5\xb8\x99\x7f\xbc\x1d\xdd\xc0\xd5h4\xfdp\xff\x00\x8b_\x16\x0f7\x93B>\xcd\x1e\xc1\xe6\xa96\x15T\xde\x92\xc5T\x9a\x1d96\xaeKt\xfc\x1a\xd0\x1aS\xf9Z\x0f\xa1>\x11\x1e\xdbb\xbe3\x11\xad\xf3\xe0\xa4RA\xf0\xe5&\xd3}s\x8e\xce,\x0e\x82\x85\xb1\x94\xcb^?-\x99\x87\xbb\x01\xc7k\"$\xdf\x0d\x8c!\xf5\xf2*\xa8\x91s}\xf51_\x16\xee\xc3\x8f\x1cQ\xd9E\xce\xfa\xa6HR\x06\xc9\x8a\xf5\x0e\x05 \x93CV\xc28j\x99\xf9\xf3j\xe9\xc0\xb2vM=\xdb\xf2\x92\x15\xb2k\xcb\xd8'\x92BY\xfd\xf9\xeehk!\xaeH\xa6tZ[Z\x9cx\xad\xf32c4V~\x90\xf8\xa8\xd3N>\xa4\xe5\x8b\xe7\xc9\xe3\xbcV\xd1<\xd9aY\xfa|\xc6Q\xe7\xe2\xd3\xb9\x8ds_\xbd>\\\xe35\xa1\xba*\xa1\"\xef\xdaY\x11sp \xfe\xa4\x0fm4'\x14\xa6\x876f\xa56}?wr\xa0ua\xb93\x19{\xfdL\x1eC\xb8]\xc1\x8e\xc5\xf0\xa4{\x0b\xb3\xaf\x15 \xea\xc9\x18\xc7\x11\x8c\xaf\xcd\x8b\xf9\x93\x12C\xe6\x93\x95\x1e\xdc\x84)+\x8b^_w\x9c\xa5\xe9\x17\xda\xcb2\xae~\x06\xbb\xcfp\x9c\xa1\xf5\x0d\xae\xf3\x0c\x13n\x1d\xb3Q\xc79\xc8\xd0\xe2^\x94\x02\x82$7\xd7[C\xa7\x8b\xb1\x18\xc2$\x16\x12\xf0'\"\xa4\x96\x9d\xa7\x97j*\x9dr\xac`\xfa\xc3\xe6xd\xba\x18?;l`\xc2\xaf\xe9j\xc7\x1d\xd3\x15\xad\x8d\xccL~\x9cw\x11\xdcd\xcd\xbb\xc8\xed\xb9b	\xd7\xfc,bQ\\\x17\x97\x17M\xc60\xbf\x99M\xe7\x0f7\xf3\xf2\xd3\x952\xf1!\x9cy\xa1?\xe0X\x9f\x96\xd8\xc0b\xb6X\xa7\x89\x9e\"\xe5w\x1f\x16\xea\xa1tz8\x17<\x1ce\x94l0UGj\x03\xbf^\\\xfcV\x88\xfe\xe1\xd7\x8b,s\xd1m&U\x80_\xe1b\x8d\xe5\xc5k\xb8P\x19\x87\xfa\xab\xd3\x0d\xf5\xc3x\x03\xf5\xcb\xd4f\xd4/S\x9a\xb9\x80\xdf\xca\xf8\xf2\x89\x99\x03w\xee\xacN\x0d:\xf7\xff\x9b\x15\xa1( \x7f\xa8\xa6F\xc2Z\xe89a\n\xbb\x97\xf0k\x93\xf4\xa4\x06P\x97\xf3w\xacOe\x81\x9c>\x16\xd8\xc4\x04\xe5\xe0\xfd\xe82PIuctr\xdeJ\x94!\xe5\xfc\x85(\x837\xadC9\x05Q\x0cE\x8f\xc1X7\xfb\xdasn\xbf\xc5\xdcL\x08\xadB0\xb9\xb1\xe1\x95U\xe7+Q1\xb7\xa3\n\xf9\x18}-\x9d\xb4\xdek\x96\xfa\xcf9\x01\x93\x1f\xb1\xf2\xe3P\xfd\xd8z\xb2a\xaa\xcb\x00{\x90\x1a\xf7\xb4\xedV\xb9\xd7\x8d\xf2\x0e\xc1\xe5DZ\xac\xbd\\\x05\xe6D\xf5\xe2r\x12\x9b[\x94\xca+D\xb4\xf4\x9e\xa3\xe6\xd4\xa55_n5\x9c\"\xf3- \xfe|\xf9oE?\xc2\xd7\xa6\x94\xea\"}Ti\xec\x89\xf1G\xed\xe6\xf2Vv\"\xcd4\x85\x12\x87)\xa4n\xc1\xa5\xf0\x0fQ@\x03}e\xa1j\x99\xeb\xe1\x82\xa51I\xf3\x82\xc7\xec3\x11IZ\xa3^\xb7\x87\xfa\x9eJ\xe2M\x03\xcaQ%\xae\x10\x1d \xf1\x06\xfa:J\x9c\xe7J\x7f\x9f_\xd8-Q\xe2\x812ov;9\xb4g\xd7@\xa1\xc8\xba\x9f\xf8OUR\xcdO+\x9fL\xde	\x82\xe3\n\xdc-q\xe5\xbf\xf7\x94x~\x19\x94\xf9.\x8cd\x11\x0b\xd8z\x07\x01\xf34\xcf'\xd6\x82r\xa5'\x91\xfe\xf1]{]\x08\xe9\\\x0b_\x89\x97\xaa\x1b\x15\x8e.\xcbNq\xe5a\xb2\xae\x8bd\xf2\x18\x0f\x90v\x17\x8a\x9b\x82\xd0\x17S\xaa\x8f\x98o6\xe8\x13\xb9\xb3k\xb3\x8eS\xad\xef\xf5k\xbe\xa1t\x8aj}\xafo\x87J\xfd%\xa8\x17T\xabw\xe6\x1a\xb3\xe9\x18\x167\xa3\x0f\xf3\xdb\x87_`6\xbd\xbb\x1d\xfdR\xa9\x7f\x1aU\x94\xf6\x82\xcd\x98\xbf\xb0\xba\x9a\x99et5\xbd*\xe2dK\x02\xbc\xc6~\xba\xe3+\xbb\x95n\xde\xd2_\x9e\xc0\xfe\x08EhI\x02\x92\xed\x1a\xef'\xd5(=\xcd?Z\xdc\xda\xa5\n\x8b_\x16\xffu5\x9e\xdc\xde\xeb\xf5t+\xa1{!\x0c\xb4\xecW\x01\xfed\xb6_\x9a\xc9h\xd8 \x91>\xe2\xe5Ph\xfdxz\xa1j\xc4$\xa6\x92\xa0 \xd8)\x03Btg6\x94\xf5 y\xd3\xd0\xa3r\xcaK\x98\xc7\xf4J\\\xd1\x9dF\xce\xd5?\x1f\x04\xe6\xafA\xc4Q\x14\xe8c\x1eP`2N\x18\x98\xa9\xcf\xf4\x0bV<\xa6 X\x88M\x9a\x80\x84\x92\x92\xd4pt\xe5]\xdf5$-\xb1~\x18\x89\xac.\x1fk,\xcbX\x9a\xca\x89\xfa\x17\x88\xa0\xaf$\xa0\xe0	\xed\x04<R\xf6D\x01m0\xf2U\x97\x92D\xef*M)\xac\xe1\xa1Jv-\xb3\x02\xdf\x11\x1a\x7f\xd2\xf5\x0e\xf3\xcbcT\xe2O\x12\x88\x80\x98:\xf0\xff\x1b\x08,\x81\xacL>D\x04<\xe1 \x18\xe8\x07\x15j\x03\xa5\x06\x9f]\x8b\x9b\x99\x82\xc7\xc2\x10S\x1f\xfb\xc9\xf9^\n\xb85	\x93SMPrh\xa9\xcf\x9e\xe8\x13\xe2\xfe\xd5,1\x1a\x1cFr7&\xdc\xfe\x9b\xad\xee\xfd\x98}A$Y=cf\x15\xec\x83\x9c)\xaf\xac7\xe2\x15LR\x8d\xff\xf3d\xdew\xa0?\x97\x91;\xec5\xb3C-:k\xe3\xc9\x89\xb0\x02<D\x95\x92\x95x$\xd3p^%\x1aT\x90,\xf7\x10\xea\xf9\x19\xb3\xd8\x18\x9e6X\xfb\xb4\x9d\x9eF[\xc5A\xb0Ke\xd9O\x8f{\xde\x0d\x96H`_O\x80A\x88\x1e\xb1\x11\xfe\x06q\xed\xa9(\"\x81\x16\x8f\x93\xaeK}\x17\xccj\xae\x8d\xfd,\x12\\\xbc\xe11}\x13\xfbx{\xa1{\x1c\xe3\n:D\x1cG\xf6y\x00\x8e\x91?\xa5\xc1\xee\x12\xf4!\xf25p\x8e\x00b\x8b\xf8\x9b\x80,\xdf(\x0f\xa3a1\x0eO\x1b$\xf1\x16\x9b\x10\xd8/\x9e\xbe\x0b[\x14\xc4X\x19\x9f\x11v#:\xdd\xd3\xac\x8a\x85\xd1\xc4\xedl\xa4\xc9\x15XJ5Z\xc5Qv\x18\x7fr\xb0z\xcf|.\xe5v6J\xbdZ?\x9b\x86LG	\xb1Av\x8aT\x7f\xdcov;\xce\x06\x15\x0bbv;\xce\x81\xc8}\x83\xa5d1\xaf4\xcd\xafT\xdf\xd2T\xda\x0f\xbc(\x02\x8dS\x88\x856\xd9\xfcW\\\x12\xb8\x1a\x0f\xe32\xed[\x9a\xd2\x10\x0b\x81\xe9\x1ase\xf2\x92y,0\xc7\x0b)\x0d\x84\x84^\xc2_\xbf\x7f\x97|\xbc'D\x9f\xd4\xff?\xbc\x85>\xfc\xfb\x00\xec\xc7\xc1\x80\x05J\"+\x14\x07R\x1f\xd2\xd3\x06\xfe\x9b<\xf8o\xbf}\xfb6\x0f>\xfd\xdf\x02\x98_\x8d\xa7\x0b\x0dV$\xe6#^\xc3d\xbc\xb0;\xea\x13*\xdf\x15\xc1|\x9f\x81\xb1\x80\xd2\xaf\x8bi`\xf0\xd3\xc3\xc3\x0c\xbe\xa0L\xe6]\xcc\x97\xf6\x15\x0b\xf4\xfb\xb7)\x90\x04\xec\xdb\"u\x0e\xa0\x8b<Y\xef\xfe\xf2\x97o\xf3d\xa5\xff'\xe2Ys\x98q\x16b\xb9\xc1\xb1\x80	\x96\x9cx\x05\xc6~\xf8\xe6]\x01\x82\xfe\xdf\x11R\xbb\x86\xf5\xc2\xf8\xefx\x00l \xb88\xfe>\x99W\x91\x88.U_}\x95\xd4d\x01*k\x1a!\xbf\"\xbf\xb2\x9e1\xa9\xd1\xda\x98C?H\xac\xd6\x93GK;\xab\xcb\xe1\x87\xba\x9b[\x03\xa9\xcb\xb5\x02?\x93\xdb$\xf9h_\x8e6\x88Dth\xea\xe0J\xd3rB\xfd\xdc\xbb\x9c\x0f\x10aW\xd9YW\xa2\x85w\xdct\xcc%\xd3D\xa4\xdd\xf30K_SY\xe7D\x92a\xc2\x7f\xb1R\xe9T\x0b8\xa1l\xc25\xff\x13\xc8\xa6\xa5\x1cx\"\xd9\xe4\xeb\x05\x7f\x02!\xed[\xde\xa8\x1f\x01\xb3\x01\xae\x98\n/n\xf5a9\xef\x17G_	$\x88\xa6o%\x06Q\x10\xaf	\x1d\x08\xd4U\xe1\xcf@\x95\xedll\xc0\x97\x17Y\xbd\x00\xea\x04u\xd4i\xdd\nm	g\x96\xa1\xe4k#\x05\xce\xbc\xd5\xda\x1dK$+\x92J\xeb\x82\xd2\xed\x9a\xb61Y\xfb\x93,\xfdI\xd7\"%k\x80\xecz\x9f\xc2\x1a \xbb.\xe8\xb7n(K\xcb\xa0\xaa8\xab\xcb\x8d,R\x07\x02\x8f\xe9\xdaQ^\x96.\x9c\x01F\x02\x1f\x87G\xb7\x858\xa6\xad\x8b\x8d'\xab\xd1ZkP\x96\x9e7\x04\xe5\xa6\xb45\xd4\x18\xd3\x01\xb1QC_j\xf7\x95\x8eZo\x9b\x1dws\xb0\xf5\xfap\x17\xcf\xb3gj\xd6\x0c\x1ei\xd9RN/\x94\xf9\xd8x\xbaS\xaf\xd1r\"=\xc7\"-'b\xe7n\xc1#p\xd0\xcd\xcf\xe8\xd5~-.\xc6\xf6\xef\x8e\x9e+\xb5\xf1F\xb0\x1d\x81Uv\xd6\xb7\x90\x9az\xa9z\x9a\x8b\x9b\xdf\\H\x0d*$%\xf26z\x97\xf4\xf3\x91\x1e\xee\xe2Og\x86N\x97\xc2cJ1?S\x0fl\xa0\xe0\x8c\xdd\xb1\x81\x8ag\xf4\xcd\xee\xbcu\xb3\x16\xbb\xbb\xf3s\xf6\xa9J\xa8\xb1\xb7\xc9\xbb\x8f\xc4\xe8\x8a\xff9}\xba\xb8\xa1\xb5\x13\xc6\x8e<\x99M\xcde\x88\x9d\x96\xa9Gux\xda\xf99\x93\x8f\xea2<$\xd2r\xc7\x18\x0dk=\xdd\x0f\xe5c\xc0\xce\x9d{\xef\xd2\x9a#\xbbzY56w\xfa\xd71\x01<\x99\xac\n!\xed\x8b\x15X\xc7\xc0\xdb9\xa0\x1c7\xd9\xc8\x05\xb5\xcfc\xa9\xdd\x06*i\x84K\xc4n\xd2\xba\xa9\xee\x1c\xf2*'g\xcf\x94ZGC\xd8GtMQS79\xb6\xfb\xc9\xda\x9cl~]\xd9\x9fv\x8c\xe2\x0f_\xfag)2i<\x87T\x98\\|;\x85s\x9e\xda\x92\xe2\xe3\x1f\xbe\xb0T\x1f\xf690}\xf6\nS\xaa\xfcZ\xb38YmIY\xc3y\nK\xce\xfe\xd3\xd5\xa7\x15W\x106Y\xf0An\xac,f\xa7\x1eN[LJ\x14q\xbeJR\x15\xe39\xf2\xd6*\xd6C\xf2\xd4V\xda\xbb\xf9\x93\xe3\xe5\xa5]r\x8d=\xb3\x97\x7f\x96\xa2\xba\xf1\xd6mP8\x91)\x7f\xc6:T\x0b\xfasu\xe6#W\xa0\xf6\xe3\xaa\x9b\x85\x1c\xaf\x9b\x1f\xdc\x8f\xfe\xcc\xe5\xa73\xf5\xe3..\xb4\xb6\xb8\xd5\xce\xc5\x9f\xac\x88FQ$6L\x0e\xdb\x19\xb3\x96h_\xe8\xc6Y\xb3E\x1c\x8a[/\x88v\x88\"\xe3\xbc\x85\x98\xc60\xfdp\xb2\x9e\xa7r\xd7\xf7a\x9c\xe3\x90\xfeHLr\xcbO?\x12\xd3 \x8e\x8a\x1c2\xe7`\xa9;\x81\x96\xc4\x1b\xf3\x01\x952Y)*Gp\xdc\\\x08-?q\xb6*h!\xf7\x7fq\x15\xbdre\xa2cm\xe24Rz\xd9\xc5\xcf}\x92\xc3SU\xf2\x1c\x19\xd83\x98i\xd7{\xa7\xc2\x9d\x83\xa8n\xea:\xb5\x94\x8eT\xec\xdcG\xf1\x9d\x05v\xf42g\x9d\x83\xfb\xbf\x00\x00\x00\xff\xffPK\x07\x08\xe1Z\xf1\xc4i\x16\x00\x000\xa2\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00[\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-operator.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec[mo\xe3\xb6\x93\x7f\x9fO1HPt\x17\xe7\x87\xcdv\xf7\xdf\xd6\xd7\xf6\xe0\xd8Jb\xacc\xfbl%\xed\xa2(\x0cZ\x1a\xd9D$R%)g\x8d\xfe\xf7\xbb\x1f\x86\x94d\xd9V\x9c\xa4\xb7\xed\xdd\x8b\xfaMb\x8b\x9c'\x0eg~3\xa4\xce\xfe\xee\xcf\xc9\x19\xf8+\x84\x10\xd3Xn\x12\x14\x06\"\xa9\xc0\xac\x10\x94\x94\xf7 ST\xccHur\x06=)\x0c\xe3B\xdb\x87\x81L\x12)@\xa31\\,\xb5\x9d\x94Hm\xe0C\xb6@%\xd0\xa0\xae\xd0\xd4\xad\x933\xb8\x94\n\xf0\x13K\xd2\x18\x1b`$\x04\n\x99\xc1\x92U3\xc0t\x05A\x9ci\x83\xaasr\x06\x00\xf7\xd9\x02\x03\x13\x17#\x9bQ>\xd0\xf2nmX\x12\x1f\x1bV\x88\xfe\xe4\xc0\x9cg>\xee\xe4\x0c\xba\xb1\x96\xa0\x11A\x9a\x15\xaa\xd2\x06\xa0\xad\xf0\x10\xf1\x18\x9d\xc6k\xa683\\\n\x0d2\x82\x1d\x86\xa4A\xb3\xfc\x89\x84\x11z\xc5#\xe3\x1eB\xaf\xc6~*\x13\x82\x8b%p\x01\xe3\x14\xc5\x8c\x86\xefRy\xe0f\xd5\x0c4\xcf\x89x\x82-bgA2\x1e=\x81P\xf15\xaa\x93\xa7\x96\xfd\x8b\x7fN\xce`<\xbc\xe9\xc0\x85w5\x18\xc1x\xe2M\xbb\xfex\n}o2\x1c\x7f\xbc\xf1F\xfe	K\xf9\x1d*\xcd\xa5\xe8\x00KS\xdd^\x9f\x9f\xdcs\x11v\xa0_:\xcaI\x82\x86\x85\xcc\xb0\xce	\x80`	v\xb6\xbeQ.h\xfeH\xa7,\xd8y\xae7\xda`\x02p\x06\x89\\c\x08\x91\x92I\xc5\xb5\xca9'\x001[`\xac\x89	\x94\xd6u\xa4\xecO\xdaH\xc5\x96\xd8\\\xb0\xe0\x1eIB\"p\xa2S\x0ch\x8a\xc6\x18\x03\x9a`\xc7&\xcc\x04\xaba\x85\x1e\x90v\x8f\xc8\xad0\x8dy\xc0t\x07\xceO\x00\x0c&i\xcc\x0c\xe6\x84*\xaa\xd3'\xde\xa1y\x8c*@!\x1a}R\xc5\xa5\xe2f\xd3\x8b\x99\xd6#k\xc3\x8ay\x9a\x81\xe2\x86\x07,\xceGkTk\x1e`7\x08d&\xcch\xcf\xe4nN>4p\x01\x00U)T\xf3\xd8\x1a\xb9\x0fO\xd8\x12;\xf0\xc7\x1f\x10H\x85s\x85\xa9\x9c+)\x0d|\xfeL\xbfeJ\xa10\xf3\x84	\x1e\xa16\xbf~\x1db\x8a\"D\x11p\xd4_\xff\xf6\xeb\xd74\x8b\xfe\x96,\xe8\x8bV\xc1\xd7\xbf\xc1\xe7\xcf[\xdb\xa8\xa5\xee\xc0\xaf\xa74\xe0\xb4\x01\xa7\x85\x18\xa7\xbf\x95C\xd62\xce\x12\xbc!-+FmBB\xbfL\x98Yu\xa0\xbdf\xaa\x1d\xf3E\xbbt\x04\xf7\xa9*)E\xc4\x97\x8fLG\x13\xb4\xad\xa7\xecO\x0d1bYl\xf2\xd9\xcd\x90o\xed\x83b]\x95\xc6\x8d\xbf\x1a\xdft\x7f\x99L\xc7\xbdY\x85\xd4\x9a\xc5\x19^*\x99t*?\x92Ki\x99\xa9\x00/9\xc6\xe1\x14\xa3\xdd\xa7\xdb\xe7\x1d\x88y\xc2\x8dn\x05iV\x0e9\x03\x17u\xad\\\x99\xb2\xb1\xcc\xc67H\x99Y\x1d\xc8\xd5\xf3&\xd7\xf3\xdext\xb9/\xd6\x9e\xed\xda\xe5jm\xffk\xe5\xa6\xab\xf0\x1eD6vm\xe3\xebJfq\x08R\xc4\x1bx\xa0me\xc3b\x1e\x9e\xa17\xedk\x8a\x8d4E\xb3\x04\xb7\x1b\xbaAq\x14\xcc\x8ak\xca+\xa7Fex\xda\xda\xe7\xc35p\x0dB\x1a7X\x02\x0dk\xec\n\xf0\xc0\xe3\xf8\x08g\x16\xc7[\xa6\xbau`\x9e\xe9x\xfca\xde\xbb\x9dN\xbd\x91?\x1fuo\xbc\xd9\xa4\xdb\xf3\xe6\xe3\xd1\xf0\xe3\xa1\xc1N#\x16k<\xad\x88\xe9K\x08\xb9\xb6!}z\xd1\xed5 \x13\x94\xea('\x93\x94\x91\x8cc\xf9\xc0\xc5\xf2\xd0_h\xf8\xdc\x1bu/\x86^\xbf\x86\x935H\x85\xd1\x942{wi	\xcb\x18\xdd\xb2\xb7\xe0g\xd2>\xff\x01\xad\xb2\xb4\xdd\x8d\x06J;\xf6\xfb=nt\xd5\xb0\xbd\x95\x94\x1aa\x81\xe6\x01Q\xc0H\xce\x82\x15\x86\x19%\xf8\x89\xc2\x08\xd5\xf6\x17`\"\x84\x91\xf4>a\x90\x15\x11\xaf\xaaB\xf7\x8a\x8c\xe6\x8f\x87\x948\x06\xe3Q\x8d\x1a[bUe^\x8dS\x12\x9f\xc5\xaf\xeb\xf5\"\xa1[0\xab8\x08\x19\xf3\x1e7\x94\xb2\xe9_\xab$ld\x06\x0fL8\xcf\xc8mP\xe1\xf2\x98\x98\xf3\x0f\xde\xc7\xca\xb0\xad\xb0?|\xc0\xcd8\xf2W\xe8\x13y_\xfa9\xcd\x9f^\"\xbb\x86\x98k\xd3\x82If`\x85\n\xedW+\xb7[\x98:\xa1\xc9Q?vo\x86\xe4\xc1	3\xadg(1\xab\xd3\xe0\xdf;?\xd2Ba\x14a`:\x95e\xde\x1b\x02d\xd6\x0e\x08\x19bS\xc9\x18[\xf7%\x18lq\xd9\xa6\xfc\xa1d\x9c\xc6L\x1c\xce\xdcfa\xef\x13\xd7F\x1f\xe1\x9e\xfb\xd0\xcb\x98\xa3	\xc2\x970\xad_\x9a\"\xb7B@\xc9\xd5\x9a\x93lO\x11E\xba\xc8\x94\xca\xf0\x95~\xfd\x88{O\xa6\x83\xf1t\xe0\x7f\x9c\xf7\x86\xdd\xd9\xcc\x86\x88\x1a?\xaf\xcb\xd6O{\xcdH\x86\xd8\x8d\".\xb8\xd9<\xbe\xe8\xa3q\xdf\x9bw//\x07\xa3\x81_\xef\xb8d\xbb\x1f\x0b\x00D\xd6\xfc\xcf\x02\x0e\xfdH\xb1\xbcaS\xec\x93\xd2\xd8\xb4\x08\x1a\x83\xcc\x9a+\x91!\xb6\xa0\xc7\x04,6p\xda\x15\x9bS\x90\nN\xa7\xa8\x8d\xe2\x81\xc1p'^\xbb\x01\x99F\x0d=JO,L\xb8 \xd4\x1e\xa20\x9c\xc5\x9a\xa8\xe49\xb5\x1d\xb18&\x9cV%@\xa5F\xa6	KWY\xd8\xed\x92d\xda\xc0\x8a\xad\x11\x98#\xae1Phh\xd7 \x0b*(\xb1\xd0\xba\xc8J\x0b*z\x84\xce\x92\x02Y2Qu(r\x81S\xab\xf5\xadFuZ\xc4\x19\xcb\"\xd3\xa8\x1a\xf9\xd3\x99eW>\xaf\x94K\xb9 $@U\x97|\xe0V..\xe0a\xc5\x83\x95\xfdy\x97h=5;\xedq\x97\xb8\x19\xdf\x8e\xfc\xf9\xcc\xeb\xddZ\xd7\xbc\x19\xf7\xbdZ\xc7\xa0E\xa9<p\x11\xd5\x01\x05x\xb0\xd1\x89\xbeZ\x1f`\xd6\x07\x02FXB\x84.{\xc5\xf8)\xc7`\xfa`w\\\x0e\xbd_\xee\xc6\xc3\xdb\x1bo\xde\x1fL\xe7\x93\xae\x7f]\xb3/J\x88A;;F\xd3v\xe4\xd28[r\xa1\x9f\x90\xed\x9e\x0c\x13\x93#fT\xbd\x91l\x0b\xca\xa9\xd9\xce*\x16\x02\x0d\x07\x17d\x88\xdb\xa17;\x94\xe8\xac\x12\xe5	\xf9\xf9r\xc8\x177\x8e\xf0Nx\xb7`\x13\x98\xd8\x00~2\x8aA\xc8\x95-\x1c8\x12\x9c\xc8\x176\xb7U\x81\xad\x8f/\xd3\xacN\x08-\x13\xb4n\xf0c{%\xb5i\x93\xd6\x9dvI\xd1~o\xd0 [\xce\xee\x8f|\xbb?\xf4\xed\x01T\xe8s\x1d\xc85\xaa\xff{\xb4\xb05J\x7f0\xeb\x8d\xef\xbci\x1dd8{1h\xa8\xd1\xf0\xcb\xe2\x86\x1aq\xbf,t\xa8\xd1\xe0/B\x0f5\xaa\xfc\x03 \x9e\x06\x10\xe5\x02\xfdi\x0cQ\x1a\xfe\x8b\xc3\x88R\xb6\xe7A\x89R\x90/\x85)`\x1fTt\xa9\xd4q\x1d\xc8m\xa70\xc9b\xc3\x8b\x06\x1c8\xcdt\x0bF\xd2`\x07\xfc\xbc\xc0\xa3\x1c\xcdCTX],&\x00?\xa5\xa88\x15S,\x86\x08\x99\xc9\x94uv\x0704\x84\xa8\x03\xc5\x17\x18\x023\x95\x99+c\xd2N\xbb\x1d\xca@\xb7\xf2*6\xb1\xdf\xda	\xa3\xda\xd0\xd6\xfb\x91nW\xc97s\xf2\xba}V\x88\xdc\xb4=C'\xb1\xed\xe2q\xd1d\xaec\x92\x17\x99\x15\x9e.\xbb'|\xb9\xa2\x1c\x9ai\x84\x846\xb35\x04\xd3VZM\x01\x94\x0b+\x9e\xee\xb4\xdbKnV\xd9\xc2\nW\xd6\xdfm\xaeu\x86\xba}\xfe\xe6\xed\xb7\x07\xbedK\xd5\xeep8\xfey~s;\xf4\x07\x93\xa17\xbf\x1c\x0c\xbd\xd9\xc7\x99\xef\xdd\x1c\xb4\x1d\xb6\xe5jEN\x7f\x85\x10\xcb\xe5\x92pV\x8ck\x8c\xcb\xd6\xf1v[\x0cF\x97c\xf87\xf4\xbd\x8b\xdb\xabz!\x86\xe3\xab\xf9\xd0\xbb\xf3\x865<i\xf6\x01K.\x0c\xaa5\x8b\xadAV\x18\xdc[\x96+d\xb1Y\x15\xb1\xb9\xdaK\xb6\xb9$K\xc3\xa2\xd9\xac\x0d3Y\xd9I\x082mdRvI\x1e+\xea\xbd\xc9\xf5|\xe6w\xfd\xdb\xd9\xbcw\xed\xf5>\xcc\x07#\xdf\x9b\xdeu\xeb\xa4\xfe\xd7\x1b\xfd\x0c\xa1y\x04\xb8FE\xd8X\x90\xe3\xe6\xf2\xfc\x9eI\x95%\x8f\x88q3\x1e\xcd\xaf\xbd\xee\xd0\xbf~R\x88w\xef\x0f\x85\x08\x8b\x0e\x8f\x91\xf0\xc0\xb8\x81\x05F\x92p\x9b\xda\xd0\x1a\x1a	\x11\xe3\xb1\x0d\x04R\x81\xc2D\xae\xb1\xad0\x8d	v\x9aU5`\xe6\x8d;+\xbcK\xf8 \xf0\xc1~}\x95i\x8c2\xe7\x0c\x81LR\x14\x9a\x19\xa2\x1f\xc5,M\xe9\x1f\x81\xe6A\xaa\xfb\xd7G\xb4\x1c\xdf\xfas\x7fp\xe3\x8do\xfdgZ\xb8T\xae\xc0\x17a\x1e\xd4\x88c\x88k\x1e`id\xdb\x94*\x9eC\xc8\x906\x18\x9aG\xe4)\xc3]\xdf\xbb\x1b\xf4\xbc\xd91\xb3\xbfOv\x04\xfby\x85\xf6\x08\x81\xe2\xbba\xcaPh\xd7\xb4\x81S\xc5\xd7<\xc6%\x12:fE\xbd\xc4\x80`\x99\x85\xad\x8d<\x0cp\x11\xc4Y\x88z[M\x90\x8d\xc9\xa5\xa5\x0e-\xb9\xd6\x8e!\xf26\x17\x06\xa85S\x1b\xbb\xd4R\xdd3EH\xd7\x01N\xb1\xc9x\x08.:\x10:\x16\xe5\xc9\x83\xac\x9c<\xec\xd7S	yJ\x88\x86\xf1\xd8\xc6\x9f\xe7\xc5\x9eo\xce\xdf\x9d\xd9\xff\xf3NV\xf3\x9b\xf7\xef\xbf\xfd\xfe\xfb\x7f\xbd;\xaf\xb7\xf5\xf5x\xe6\x13\xd8\x9eO\xbd\xff\xbe\x1dL\xbd\x19%\xbb\xbb\xc1\xd0\xbb:\xd2\xd9\xaaH:\x10@H\x1747Y\x0e\x82f\xde\x90\x8b\xec\x13(\xb4\x1d\xf5\x98\x14](d\xf7\x1a^\x19\x9e\xa0\x06\x99\x99\xd7\xa4{\xcc\xd42?\xdeq\xa1\xbaa\x0d\x1dJ\xd4\xe2kc\xed\xe8\x9c\xdd\x05}\x98\"\x0b\x7fV\xdc\xe0\x0da\xfc\xbc\xb8\x81W1\xd3\xa6\xe0\x06\x0f\\\xe8\xd7U[\xf6\xf3\x16\x1f\xcfA\x19\x8f,\x10\xb3%\xa9\xe6	\x8f\x99\xca\xd7\xe6\x8b\xac\xc0\xdbw\xe7\x8fD\x7f\xd7/\x9c\xcf\xbc\xe1`t\xfb\xcb|\xea\x0d\xbb\x17\xf4\xff\xd53\xed\xec\xacUh\xcd\x8d\xeb\x9e\x1av\x8fn	\xc8\xb6.\xdc\xc9\x07\xd7;\xb5u Y\xb7u\xd4\n)*\x8b>\x05\x15\xbb_\xd0\x12o\xdf\xbf;j\x89\xcb\xd9\xd5t|;y\x8e\xfa\x85\xf8,32a\x86\x07 U\xb0Bm\xf2\x18dw\x15\x05\xc4\"\xf00\x128\x0f8\x15h\xb2\x1fg\xac\x1c.\xcc\xcc\xaf\xc7\xfedx[\xb7\x1a\x87\xa9x\xa2\xe4\x9a\x87E6\xe3\x1aCP\xb8\xc4O\x14j\xc8\xeav\xa6\xce{\"\xb4\x15Y\xcb\x9d\xc7.[\xf9@{\x00\xb9\x08a\xc1h\xb2[\xd5\x86\x9b\xe7\x16vA\xf5\xc3=\xc2\xe9\xab\xff\xe2\xaf\xd5\"\xfc\xf5M\xf3\xfb\xdf\xfec\xb7\xcf. `\x1a)	\xdb\x152+&@\nt,\x1a@P\xc62wx7u\x15G\x11\xaf\xcd\n\x93\x9d\x8d\xe2\x1a<\xb9|\x85\x0c\x96}\x984\x1d\xfb\xc6\x8e0\xf6\x9b(E\xab\xd0\xea\x86\x85A\xf0S\xaaPkZ$\x16\x11:H3{\x04\x0bl+\xda\"f\xc1\xbd-\x96\xa8f\xd7\xf7U\x15\xa3\xdc&\\\x03&\xa9\xd9\xb8\x13\x84\xb0V\xd6Lcx\x98S\xb6\xe9\xa4\xebQ\xaa\xbb\xed{w\xf3\x8ba\xb7\xf7a8\x98\xd5e\xbb\x17h\\\x9fzp{Nl\xdb0\xee\x8c\xb8\x05\x17e\x17\x8d\xf6!id\x07\x866\xeaq\x0dQ\x16\xc7\x1b\xd0Y\x9aJe0l\xc0\"3\xa5r!\xa6\n\x03f0\xa4\xacjw|\x94YT\xad0F\xa6\xabh\x81\x0b\x88\xd8Z\xaa\x02\x9e\xf5f\x83B\x88\xe3;r\xe8\xfd2\xefO\x07w\xde\xf49\xbb\xf2 \xdb\xda\xa5\xc9w\xdd&\xcf\xf3\x0e\xfc\x14G<\x8a=\x94\x8d\xbeb\xafJa\x8b\xc0-D\xcc\xef\x07\xec\xe7\xd9\x9c\x1e\xa5\x07{\xae$\x90\xb2\xb9\xa4lZ\xc43\xda\xf6\xf68k)s\x84\x95\xd72\x1b\x99)\x18\xcf\xfa:\xdfpR\xc0\xcc\x89a\x8fm\xfbV\x94\x19\x16\xed\x94\xc9]\xaf\x1a\x04\xeblU\xb8\xd5\xc7\xdc\xaf*\xd2>n\xb1\xca\x0d\x82\xc2\x13\xd6\xee\x8c\xbe\xbaT\x04;.g\xa5\xdb\xf8\x85y\x99p\x17$j\xa6\xb8\xb1\x0d\x1b\x9c\xed\x110\xa4\x8a\xaa\x02\xc3Q\xc3\x02c\xf9\xf0\x98:\xbd\xd9\xa0P\x89P\xf7em\xcb\xebO*2\xbd\xe8\xff\xfdZL/\xfa\x8f\xab\xf0\xe4\xec\xab\xe9\xa47\xbf\xf1\xfc\xe9\xa0w\xc4\x12\x07\x86\xa8\xdc\xe7\x91\x11h\xc1R\xbd\x92\x86\xe2]\xd9\xec#\xf7.\xaf\x8d\xa4\x94@H\xfd\x1d?/\x84\xaa\xc83\x1bu'\xb3\xeb\xb1\xefW\xb6\xe4\xd3\xe2X\xe0\xfa\xc1\xb5`\x83\x98\xa30v\x9b\xe5M\xd9\x1f\xe0]\xeb\xfc\xdb\x1c\xf0\xe6\xe1\x86\n!\xc3\xdc\xc5\x98\x9e\x85[\xbbQ\x98vWqZ\xea\x1as\x15\xe2\x0d\xb7\xbfXjo[\xd8\x8c\x9c\xb0\x8d\x8dZ\\\xab,\xa5\xa0\x15f\xb62\xc8\xd2\xa5b\xe1N{|\x86\x8ed\xfe\x08\x96\x19\x0f\xb1Sb\x0c\xc2\x14-.]\x17\xc0\x02\x8c\xf5y\xeb\xadm\x044\x0bj+\x93\xc4\xf5f\xbc\x1cO{\x85g\xcf?x\xd3\x917\x9c\xf7\x86\x03o\xe4?\xc7\x9c\x95\xdd\xe8\xda\xde\xdb\xe2\xa5(q-\x06\xc1\xe5\xa6\xb1\x0d\xdcE\xfa\xa7h4\x16}\x8c\xd1\xb8\x0e\xebTZ8|kg\xd6\xe5\xde2\xd7=6\xb2\xaaZ\xae\x14\x81\x96\xc1h~;\xe9w}o>\xf3\xa7]\xdf\xbb\xfaX\xa7]!\xcb\xbe\x86\xd3E\xf8\xffP\xbd\xe9E\xff\x7f\xa9\x9b_\x1f\x9dH\xe5\xad2\x8b\x8d\xeb\x17\x16y\xd6\x06)\x0cm\xc0\nVL,\x9ds\xe6\xf3+\xd4\x0f\xe2\x96\xcd\x832A\xb3\xb2\xb5\x9dK\x8d\x04\xc9\x1e\xa8\xe2\xe4\x1ad\x14\xf1\x80\xb3\xbd$\xef\x98T\x08\x9b\x15\xea<\xfe\x95}\xf0\x105W\x16e\xda\\\x7f,\xb1\x1f\x866\xdbM\x19\xdct\xaf\xbc:\xcb\xfd\x9e\xb1\x8d\xed\xf6b\xba\n4/\xfevh\x97\xb5\xde\x1e\x0b\x99S\xefj@k2}\x9a\xf8\xfdw\xda\xd2\xd6\xdc\xf6#\x9bN\xe8\xa6\xc2%'\x0fS\xc4\xed\xbc\xf5\xe6\x18\xb7\xc9t|7\x98\x0d\xc6#\xefe\xfc*\xa1\x96\xb8\xbc;\xce\xa5\x12o_\xc4\xa5\x12\xf2\x9fa\xb9\xae\xefw{\xd7/d\xc1\x8ca\xc1\xaa\xa0_\xd5\x02\xf2\x13\xba\xf2\xc4k\x93\xf74xT>*\xee\x1c9\xccD\xa5A\xc5C\xf7\x8f\xfa\xec\xf4c.\xf5\xe1\xf6\xc2\x1bz\xfe\xd1\xc3\xba}\xa2\x8f4\xc3m\xae\x9al\x17\xe9\xc9v\xf8\xbe3\xfc\x05\xfd\xf0\xaax\xde\xe4\xda\xee\xb3\x8a\x1f\xfdu\x87>\x07\x9c\x1e\x8a\xc3\xf0\x05j\xb3E\xda\xb2z/\xcb\x82g\xa6\xf3\x05\xb5\xbd\xd8<\x8e?\xc7|\xff\x1c+=\xf3X\xc9:\xaa]\x1f\x97+\x9f\xe5\xa7.\x81\xfd].\xea\x04\xfb\xcb\xbcs\xcbC\x97\xd5\xd76e\x92S\x16m'\xe7\x93\xdbk\n\x05\x08-f\xb9\xfe\xab;\x1e\xb0Z>a\xc0\x7f\x9c\xf4\x18\xd3^\x11\xdb\xed\n\xe5\xdd\xd2\xa5J\x03\x0b\xcbb\xbeF\x81ZC\x82F\xf1@\x03!\x8f\xc3\xd8^\x81\x94\xd5\"h>\x19Ok\xb1\xf2\xf7o\xbe?\xaf\xc9q\x152\xc3\xc1\x9d7\xf2f\xb3g\x90\xfa\xee\xfc\xf4Q}\xd4\"\xfcS\xca\x10\x80|\xae&u\x98\xa0\xa0\xf1\x125\xde\xec\x9e\x93\xe4-Y{\xf8\x93	\xc3\xb7\x9b\x03r\x07\x8b1\xbf\x06\x9b\xc8u~\x9b\xc7\x9eY\x18\xe9\xc2y\x85Z\x1e\xe7m\x17-D\x83\x81\xeb\xa3	\xc8\x84B\x16\xaclmF\x83\xaa{ib\x0f@\xac;:\xb0koW\xe4\xefA\x10\x84\xaf^\xf6H\x96\xaaA\xe6n@\x12\xea\x06\xa8\xe5C\x03D\xa4\x1b0\xb9\xeb\xe5\x8d\x13{4\x9a\x1f\x87\xe4\xfdz\x9bp\x8c\x94\xf1B~\xaa*_\xf4Am_\xce5w\xb8v\xefp\x90\xa4\xf9A\x97\xdem\"\xd8	2\x82o\xde\xbc\x01\x8dA\xd5\xcbY\x18b\xb8m\x01[\x1c\xbd\xd8\xc0\xfdw6\xf9\xf9\xdb\x9b$\x91\xac\x9a\xed\x07k\x92\xdd\x0dV\xb1\xd8O{\"\x1biX\x0c\xcc\x05(\x8a\x94\xf9\x1a*\xd4\xc5e\x99\xed*q\x91\x1f\x84n\xdc\xeaTh\xe5g}\x95\x85\"I\x0c(d\xa1\x1b\xfd\x13\xf9@\xc8]\x03;\xaf>lD\xcc\x12\x90Qu]\xa0\xd9\xfc\xc9\x05\x8bD\nn\xa4j.\x15\x0b\xb0\x99\xa2\xe22\xec\xc0;k+)B\x0d\xaf\xc8\x1c\xa4ls\xeb`\xcd\x84	\xb6D\x05Q\xcc\x96\xaf\xf7\xe9ZLw;\x9az\xdd\xde\xb5m9\xd8dU\xb973\xf3z\xe3Q\x7f\xd6\x81\xf7\x05\x97\xc3\xbd\xf2\\\"u\xdb\xe6\xfd\xc1\xd9\"\xd1,J\x1c\xbbY\x8c\x84\x94\xe9\xbc9g\xfdE>\x88\x07\xa6B\xe8N\x06\xe5\xe4B\x18\xcb\xbb\xee\x96\xc6\xe1m\xfe\xe8\x91[\xfc\xf6w\xf7\x9a\x81N1h\x91\x14#\x96\xe0\x9e\xa0\xa9\x0c\x9d\xb0/\x13p2\xee\x7f9\xf9\x8a\xf7XZ\xe2q\xf9\xdcM\xc6?'\xa4\xbdT\xff\xe5%-^\x0dr\x9f\xe2>\x80\xaeNW\xf8{\x86\xda\xe8]\x92A\x9a\xd9\xf7[\x08\x16\xcd\x8b\xa48/\xc6\xce\x834\xab\xbe\xa9\x02\xf6M\x9fD\xaa\xcd\xb1In\x04\xcd\xfb\xe3+*\x9av\x87\xb9\x179,e\xa9\xea\x9f\xe5\x04\xbe\xaa2v\x8f:O\x91\xfc\xea\xf33\xd4\xab\x8cwB\xa2\x08yDs\x8fP\xaf\x13\xea\x885vg\x1d\xb2)\xffwt\xf2\x93\xc8\xfaw\x93v_\xdb\xb1'7}\xae:\xf0\xc7\xe7\xbd\xe1\xc7^\xd5\xa9\xce\xca_w\xf3F\xfd\xda\x97\xdd\xfe'\x00\x00\xff\xffPK\x07\x08\xb7\xb5m\xb2\x8e\x10\x00\x00\xc89\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-filesystem.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcUM\x8f\xe3D\x10\xbd\xfbW<\xed\\@\xda\x04!n\xb9\xa1\x8c\x908,\x8b4\x08\x8eV\xa5\xbb\x1c\x97\xa6\xbf\xe8\xea\xce\x10V\xf3\xdfQ\xdb\xb1\x13v\x99\x1d\xf1!|J\xa7^\xbdz\xaf\xca]\xbe\xfb\xbf\x9f\xee\x0e\xfb\xccT\x18\x84A\x1c\xebY\x0b{<I\x19\xa1\\\x8a\x84\xa3\xce\xa7\xcc\xc9\x89\xa1\"1\x80\x03\x1d\x1c[\x0c1\x83\x90r\xb4\xd5\\\x02'\xc91x\x0ee\xdb\xdd\xe1[x	\xe2\xabG\x1c\xf0\x0d\xde?\xdc+b\x80\x95a\xe0\xcc\xa1 D\xcb\n\xca\x8c\xcc\xbfV\xc9l!\x01e\x14\x05\xffF>9n4x\xac\x076\xc5\xc1\xccR7\x03r\x8c\x8f\x9b\xab\xe0\xed\x99\xbc\xeb^3\xfb\x9f?]GI~\xe6\xac\x12\xc3\x0e\x86\xd3\xb8m\xc2\xb6\x12\xbf:}\xdd=J\xb0;\xec9\x8d\xdf\xadB;\xcf\x85,\x15\xdau@ \xcf\xbb\xd9\xca\xd1\xc5\x03\xb9\xd9Q\x89\x99/QMd\x16H\xa3\xef4\xb1i\xa9w\xf8id,dH1:\xb4\xd8\x16\xef\xaa\x16T\xe5\xdb\x81m;\xac\xd8\x1fct\x8d\x01+\x80\xed|\x06T~\xe7\x1d>|\x98\n\xf6\xad`\x7f\xedq\xdf\x18\xfaV\xa9\xbf\xa1\xeeM\xac\xa1\xe0\xf9y\x15\xe5DK\x9b\xf7\x9f\x85\xe9\x16{\n\x1f\x0bC\xcc\xe0LZ3\xc3D+\xe1\xd8\xa4.2u\xd6\xb5\xc1@\xe2j\xe6\xfb\xe8I\xc2\x0eQ\xedE\xf0\xa7\x16^3\xd1\xb8_1\xf1\xcb\xc8e\xe4\x8c\x12\x912+\xe7\xd3M\xa7)\xd8\xab\xb3\xe9m\xbe\xb96\x96\x1d7\xbe\x0ek\xe6\xe4\xe3}\xb8o\x11\xde\xa1\xe4\xca\x9f\x8e\xaf!\xc50\xbe\xf0V\xbf\x84\x89a\x90c\xcdt\xa1Z`\x0f\x8d/\xcfFg\x82P\xfd\x81sk6\x99\"'\xc6\xbb\xfb\x07H\xd0B\xc1\xb0N\xc09\xb0o\xfe>3Y\xab\xb7-\xb8m\x02\x93\x19\xff\x8a\x1dO\xe2\x1cF:1(,\x80\x16\xb2\x87\xf3\xbc0\x08O\x94\xfd\xd5\xa4!3\xf2\xb42\x06\xd2\xc2y\x9aj<q\xde^J~?` \xa7\xfcv\xe1\xd1\xb9\xc8\x81A'\x12\xd7v\xce[\x1cj\x99\xff\x0e\xb1\\\xea\xcf\x85&\xfe\xed\x8d\xe9\x87\x99\xe5e\xdb3\xac_T\xaf\xde[ki\x18$H9#W\xc7\xda\xde\x05J\xc9\x9d\xdb\x8f\xd2Fg\x15\x96\x93\x8b\xe7\xb6\xeb\xa6\xbc\xe4\xc8p;\xbd\\p\x85\xf41\xb5\xe1\xeaR\x93B\x88e\x9a\xb7~F\xee\n\xfa8=e\x89Y\xcay\xefH\xf5\x87i\xab\xb4\xe4M[%t\xe4\x8d\xc9R\xc4\x90\xbb\\{\x8d5\x1b\xd6\xe5\xca8\xf1R\xf4z\x81L\xaa;\xbcyI\xc5\x92\xde\xcfi\xbdI\x15\xcf\xcfo\xd6l\xcf>\xe6\xf3\xdf \xf0\xeco\x08\xdaw\x80\xf5\x1f\xc8Y\x12\xff\x85\xa0\x95\xe2\"\xe9\x8f\x00\x00\x00\xff\xffPK\x07\x08\xf4K\xbb\x13\xb4\x02\x00\x00\x8e\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00_\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-store.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcTMo\x1b7\x13\xbe\xef\xafx\x10]\x9c\x83\xf5\xfa\xad/\xc5\x02=\x182\xd0S\xe3 )\x9a\xe3b\xcc\x9d\xd5\xb2\xe6\x92,\x87kW\x0d\xfc\xdf\x8b!\xb5\x92b\xa4J\x93\x16\xd5M;3\xcf\xc7\xccpV\xff\xf5\xafYa\x93\x982\x83<\xc2\xfd\xafl2$\x87\xc4x\xb2y\x84p\xce\xd6o\x05CHH\x1c\x9d5\x94m\xf0\xb0\x1e\x84\x98B?\x9b\xf2\x9f\xfd\xa3M\xc1O\xec\xf3\xbaY\xe1\x06\x93\xf5v\x9a'\x84\x01\xd7\x18\x83d\xa9\x88w\xefo\x05\x94\x18\x89\x7f\x9bm\xe2^\xa1\xf2h\x05\xfc;M\xd1\xb1\x96\xe3a\xbeg\x93\x1dL\xd5v9 \x85\xf0pY\x05^\x16\x81\xeb\x1dM\xae\xf9\x92\xbf\x7f\xfd\xd74\x14\xed/\x9c\xc4\x06\xdf\xc2p\x1c\xd7*mm\xc3\xff\x1e\xff\xdf<X\xdf\xb7\xd8p\x1c\xef\x8a\xd4\xf7\xaa\xb4\x998SO\x99\xda\x06\xf04q[\xdd\xc8\xf5'\x86\xf6A\x89d\x96\x0c\x85o$\xb2\xd1\xca\x15~\x1e\x191\x04\x07\xfd\x84Y\xb8G\x0eK\x8f\xf2\xc8X\x88J\x96\xac\xf1\xd3,Y\xf3NG\xb7np\xc8{\x1b\x82Sh` \xeb\xe6\xc4\xb7a\"\xeb\xdb2\xb0\xf2})\xe4\xbe\xe6\x01b\xff\xe0\x16\x1f?\x16\x85\x9d*\xec\xaa\x8b\xae\xb8\xe8\x14\xbbS\xfe\xee\x84\xb43a\xf6\x19\xcf\xcf\x7f\xcf\xc7\xc1\xc3\x1a\x1b\xf2/\x1d $p\"\x99\x13\xc3\x84\xde\xfa\xadz:\xeb'H\xff\x8dv\x14\xf6\x0bv>\x8c\x9cGN\xea!&\x16N\x8f'\x93 \xdf\x1f\xed\x08\xc2\x8bW\xd6\xb3cElp\xa8U\x13r\xe7o5\xc2-\x06r\xc2\x87\xb6m\x893?\xd1\x0e\x9aj\x8d6\xc0\x0fv;'\xda\xa3l\xa9\xc4\xab\xbd\x15\xf2.\xb2\xbe\xc1\\jk\xe9\x85\\\xbf.a\x0d\x9e\xf3\xbe/\xe8\nH1\xab\x907H<pboX-+\xb2\xb0I\x9c\xebK\xe6\x82v\\e<\x8d\x9c\xeaXE\x1c\x0c\xa7l\x872\x03X\xa9]\xa8\xb3\x11q\x9bc\xf0\x1d\x0f-V\x9f}(\x97\x91\xa7\xbd\x96\xbaI)#\x8f\x94\xf1\xee\xc7\x0f\x88\xa1\xd7K\xe3\x1c\x9c\x95\xcc^;~1\xe6\x1c\xabeMn\xf1\xdd\xd5\xd5\xf7W_\x0f!\xaf\xd7\xb8\xf1\x9f\xb3\xb1\x1c\xb3uu\xc2fN\xfcV\xa9NH\xfc<\xdds\xd2a\x14\x82\xa5Y\xdb'\xf4\x1c]\xd8\xe9\xed\xc4\x85\xdd\xfaP\xae\xe2\x00r\xeeM\xe8Y~\xc8i\xe6*\xdfz\xc9\xe4\x0d\xcb\xb9\xb1-I\xa7[\xfa\xc9\x9e\xeei\x8b\x0c\x19\xc3\xecz\xdc\xf3^\x05\xf7j\x97\x9c\x83Wn\x90\x80\xd0\x13O\xc1\x0b\xd7\x9b\xb0\xe8:\xa7\x81\x9c\xeb*\xc0\x81^{@\xc3`\xbd\xcd;\xa4\xd9\xb1\xe8\xfaP\x8cn\xb7\xec\xd1\x8bf\x84td\xae\x9d\x8d\x8e\x0ck\xec\x1c\xf7!i\xe1&\xefC.\x0f\xe4\xbc\xe6c\xdaR\x19\x93\x0d\xc9\xe6\xdd\xc6\x91\xc8\x9br\xba\xb5\xae\xec!m\xf9\xd2$\x9b\xad!\xb7?.\x12\xe6\xa4\xc3\xd9\xdf\x16g'\x9b\x0f\xff\x00\x13\xe7\x16\xaf\xfeZ\xc0\x02\xd0\xd5\xc2\xce\xc4\x19\xcf\xcf\xaf\x0e\xf5\x13O!\xed\xbe\nb\xe2\xe9\x04B\xd7\x94\xe5\x9b$-\xa5\xffH\xd4\x01d/\xeb\xcf\x00\x00\x00\xff\xffPK\x07\x08\x92\xb3\x14\xae\x15\x03\x00\x00\xef\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbc\x90\xb1N\xc40\x10D\xfb\xfd\x8a\xd1\xa5N\x10\xba.\xed\xf5P \xe8\xf7\xec\x8db\x92\xd8\x96w\x83t\x7f\x8f\xec\x84\x9a\x061\xe5\xac\xf6if\xba\xff\x16u\xb8\x15a\x13pD\xba\x7f\x8a3\xa8\xa5\"\xd8U\n\xa6T\xc0\xce\x89*,\xc1f\x81^!\xd1\xe7\x14\xa2\x0d\xd4\x01\xcb~\x17g+\xdcA\xe9'\x94\x94\x96\xfe@\xf5\x152<x[\xe9\xb7 \x7f.\"\xce\xe1C\x8a\x86\x14G8\xc9\xf3P\x93\x0d!=}=\xd3\x12\xa2\x1fq\x93<\xbf\xb6\xa4o\xb5\xf3\xbbJ\xa1M\x8c=\x1b\x8f\x04D\xded\x84\x97\x89\xf7\xf5(s\x9a\x9a\xd9\xc9xT\xadh\xd2,\xae~\xb4\xed\xce\x83^\x7ffh.\x01>h^\xf9\xf1\xd2\xb0\x97\x93\xdb\x96\xbe|\x07\x00\x00\xff\xffPK\x07\x08\xe2\x85\x10u\xc6\x00\x00\x00\xfa\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-pool.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcR=\x8f\x131\x10\xed\xf7W<)-\xb7\xa7\x13\xddv\xc7\x9dhA\x02\xd1F\x13{\x96\x1d\xc5;cloB\xee\x94\xff\x8efsA\xa9\xa0A\xb8\x1b\x7f\xbc/\xbf\xcd\xff^\xdd\x06O\x85\xa91\x08O\x9c'd\xb3\x84\xa3\xb4	\x95[\x13\xfd^1ZA\xe1\x9c$P\x13S\x88\"\x17\x8bKX'\xd6\x83\x14\xd3\x99\xb5\xd5\xbe\xdb\xe0\x11\xb3\xa8\xcc\xcb\x0c\x1b\xf1\x1e\x9f\xbe<W\x98\"\xca8ram\x98\xac\xb6\n*\x8c\xc2?\x16)\x1c\x1d\xb1MR\xc1?i\xce\x89\x1d\x06\xfbe\xc7\xa1%\x84\x8b\xbc\xbb\x11\xc5l\x7f\xe7\xfa\xfa\x13\xcd\xa9\xfb\x9b\xb5\x7f\xbe\xba\x8e\xb2|\xe3R\xc5t@\xe0<\xf5.\xa9\x17\xbb?<t{\xd18\xac\x19~H\x16\xf6\x9f\xcdR7s\xa3H\x8d\x86\x0eP\x9ay\xb8\xe6\xe8.\xde\xf6j\xa6\xe0\x07n\xce1\xbb\x9a9\xf8\x83\x0d\xbeN\x8c\x91$-\x85\x11m&Q\x1c%%\xd4\\\x98\"\xda\xc4W\xbc\xeaa\xfb\xecl\xa0P\xac\xd6\x9b\xc8\xaf /\xa6\\;\\\xe7\xe7\x15s\x80\xd5\xb8\xf2}\xb4\x02\xba4`G\x95\xa3\xff[\xa1#\x82e\xe1\xfa\x0e\xaeL\xc6\xd3J\xa4\xcb\xbc\xe3\xe2\xb4\x97\xd3\x1e\x8f\xa8\xf2\xc2\xbe\xf3\x00\xd1\xe8m\xe1\n5\x14\x8e\x8bF\xd2p\xea;\xfcn\x12G7\x89\xf5\xd1\x80\xd7\xd75\x81\xad'\xb0}\xbb\xb2u%\xdb`\x8b6\x9c\xcf\xab\xc2G\xec\xf9t\x7f\xa0\xb40\x92\xd4\xe6l\xa4jmm\xa6[\xbb\x99\xfe\x84zs\x0d\xe7\xf3\xaf\x00\x00\x00\xff\xffPK\x07\x08\x12\xe0\x1b?\x86\x01\x00\x00w\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00`\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-storage-class.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xecTMo\xe4D\x10\xbd\xfbW<e\xb4\xd2\xae\xc0F\x11{@\x96\xf6\x00	\x07\x84\xc4\xae\x08,\xc7I\xa7]^\x17\xd3\xee6]\xed\xcc\x0cQ\xfe;\xaa\xeeq2	\x91\x82\xc4\x878\xc4\xb7\xa9\xa9~\xaf^\xbd\xaaZ\xfd\xd7_\xb5\xc2Y$\x93\x08\x06\x92B4\x9f\x08\xd6\x19\x11l9\x0d0\x98BpH\x83I\x10J\x82H\x93ck\x12\x07\x8f>DM\x88\xa1\x9bm\x0e\x90\xbf\xe6\x18\xfcH>5\xd5\n_cd\xcf\xe3<\"\xf4\xf8\x12>tt\x80}\x7fq.0\x91\x10\xe9\xb7\x99#u`\x8f4\xb0\x80vf\x9c\x1cA\xd8[B\x1a\x08\x1d\xf5fv	\xbda7G:\x0f\xa3a\x0f\x16\x0cA2\x0d6\xf3\x15\xd9\xe4`\x8b\x90\xbaG\x0caS\x1f\xe4\xd4YN\xb37\xa3\xab\x9e\xeb\xc6?\xfeU\x95\x99\xf8#E\xe1\xe0[X\x9a\x86Fkk8|q}Zm\xd8w-\xceh\x1a\xbeq\xc1n>\x84\xe0\xaa\x91\x92\xe9L2m\x05x3R\xbb\xb4\\\x8d8\xc4d2V\xffP\x95\x8aY\xc9DV\x1f,\xeeP\xa7\xbf\x00\xe1\xdf\xa9\xc5\xcdMN]k\xea\xfa\xd0\x95u\xee\xca\xfa\xc8\xce\xb5\x0d\xb3O\xb8\xbd\xad\xea\xba~P\xf6\xe1I\xb3\xf9J\x1e\x14~Q\xe2g\x8a\xf4T\xddKy\xf5\x95\xaa\xab#%\xc3\xbe\x02\x8c\xf7!eNi\xab\x9bW\xe0~1y\xa9\xaeL\xe0\xbbw8y\x1a\xe4\x04\xafn\x8b\xc0\xa3\xfcF\xe7 zJ\x94\xcbd\xa9\x0f\xa8e\x02Z\x9c\xa48\xd3\x892\x92\xef\xb8W\x8c)\x86kV\x95\x14\x1f\xd9\x93\xe9\xaa\x15~	q#y\xd4\xbf\xbf\x83\xc7is\xfa\xf6\xb3\xca8\x17\xb6\x1f\x83\x9bG\xfav7\x19_\xba\xa5$\xd5d\xa2\x19)Q\x14\xed\xc7\xd5\xe2\xeec3W\xb8\x98\xc8r\xbf\xcf\x93~g\xad\xee\x8b\x06\xb4\x16X7K\xa2\x88>\x86\x11\xdb\x81\xed\x80\x14\x96Y\xbf\xce\xec\xd2d\xac\xefz\xf8\x90 \x19\x92\xa9\xfb\x1c\x9c\xb0e\xe70\x0b\xe1R\xd1.a\xe4\xc1V\xfd\x89\xf3@W\x10\x7f\xd2\x95d\x81q\x12\x1e\x95\xb8\x1d(\xd2\xf1\x8b\xc2tE\x15\x96\xc8\x0fO\x8d\xeac\xd1=;\x92\xbd$\x1a\x91\xf6\xd3]\x19EX\xf3\x9c&\xda\xa5\xb7\x97Zk/\xfa\xba\xc5\xae\x97L\xf1\xfa\xfd\xa4\x03f\xdc\x9b;6\xe3A;\x96\xc4\xfeS\xde9E\x88\xe5\xb4\x1dJ\xd7H\x97\xbd\x1eu\x174q\xb9\x89\xf9l\xe5\x0bu<\xf5\xb9K9\xf7g\xd1	R\xc4\xd3\xbf\xc2\x7f4KB6R1\x026x\x1do\xcd\xf8u\x96\x84\xe0	\x1b\xdac\x08\xae\xd3\xa0v\xe6\xbe\xf4\xf2rq\x8a\x16\xa4Q_f&\xbd\xaad\xecpo\xdbkys\xe4\xdc\xbd\xb8\"\xdf\x06/\xf3H\xdd\xbd\xac\x8b\x0cYV\xa3\xce\xea\xea\xc2RE]:\x1e?\x04\xc7v\xdf\xe2\xc7\xb2\xdc\xff\xd2\xe9\xe8\xc8Q\xa2\xbfy:\n\xc8\xcb\xe9x9\x1d/\xa7\xe3\xfft:\xce\xf3^\xfe\x11\x00\x00\xff\xffPK\x07\x08\x96%\xe0 \xe7\x02\x00\x00\xfa\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Z\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/toolbox/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x8cT\xcfO+9\x0c\xbe\xf7\xaf\xb0\xba\x87^\x18f\xe18\xb7\x15?\xb4\x88\x05*v\xb5\xef\x80P\x95\xc9\xb8\x9d\xa8I\x1c\xc5\x9e>*\xd4\xff\xfd)L[&\xb4E/\x87A\xd8\xfe>;\xdf\xe7T\x05\xf3?F6\xe4+P!p\xb9\xba\x18-\x8do*\xb8\xc6`i\xed\xd0\xcb\xc8\xa1\xa8F\x89\xaaF\x00^9\xac \x12-\x0b\x8d\xa1-\x84\xc8\xf26\xceA\xe9ar\x04`U\x8d\x96\x13\x10\x12\xff!\x92\x03\xea\x94\x8e\x18\xac\xd1\x8a+\xb8\x18\x010Z\xd4B\xb1\x07:%\xba\xfdg\xc0t\x82\x0b@\xd0\x05\xab\x04\xb7\xb8\xc1\xdc\xe9\xd8\x8c\xe2$	\xc0n\xa8t\x1a\xcfS\xb2F\xaf+\xb8\xb2\x1d\x0b\xc6[\x13Y~\x18i\xff&\x96G\x94m\xa1&/\xcax\x8c\xfb\x0e\xc5I\xb5\xfac\x9cZ`\x05\xef\xef\xa0)\xe2,b\xa0Y$\x12\xd8lR\xac\x8b\x11\xbd\xcc\x9c\xf2f\x8e,/\x93\x06\x03\xfa\x06\xbd6\xc8\x93\xd7\x97IB\xa5\xbf{\xfe\xf4\x0fG=y\x85\xcdf\xdfE\x93s*9\xfa2.\xc5x3~\xfd\x14 .8\xc5\x8b\xc5\xf8\x0c\xc6E\x91\xbee\xc7\xb1\xb4\xa4\x95-k\xe3\xcb4qMo\xe7\xdc\x0ep\x1f\x83O;kw\xca\xdc\xcd\x1fI\xa6\x119\xad\xcb\xae\n\xfd\xeaS\xebO5\x9e\x9f\x9e\xeeg\x7f]?\xdc=\xce\xfe\xbd\xb9z\xbe\xf9oP\x03\xb0R\xb6\xc3\xdbH\xae\xca\xc2i%tD\xb9\xc7\xf53\xce\xbf\xe6\x0e\xd7\xd2\x91?\xa8Y\xe2\xba\x02\xd58\xe3\x8b\x9el4\xe0\xee\xa2\x91\xf5\x15y\xc17\x19\xd2\x87hV\xc6\xe2\x02\x9b\n$v\xb8O\xad\xc8v\x0e\x1f\xa8\xf3\xc2\xf95]\x8aM\x95\xb4\x15\x94\x0d\xae\xb29\xfa9\xf3h\x8e\xe05\x97u\xc7GP\xbc\xe6<\x91\x03\xad\xa9KGMg\xf1\x18\xd8\x9a\xfa0\xb9\xb3\xc4\x91/\xd07\x81\x8c\x97\xa2\xbfXF1l\x83\xa2\xcb\xa4\xf3\xb6\xe0\x0f0sh\xfb\x87\xf0\x93\xe2\xb2\x82\xb9\xb2\x8cg -\xc28\xd6\x0d8\x15\xc6\xbb%\x84V\xf9\x05\x9f\x01#B+\x12\xb8*\xcb\x85\x91\xb6\xab\xcf5\xb9\x0f\xde\xfec\x98;\xe4\xf2\xf2\xcf\xcb\x8bm\xa3\xac\xc7\xc0\x89~\xdc\x81\x05\xc5Q\x91\x13\xfc\xe3\x06\xd9\xc5\xc2\xa1K\xc5)\xb5\xbfe\xf8\xeaZ\xf1\x9d\xec\xdf2\x1d\xb3\xf1\xf7|\xd2\xe4\xe7f\xf1\xa0Bu\xc4\xff\xeci\xecI\xf2M1\x82\x8esp\xd1\xbf\x9a\xf4+\xfa\xe5=\xf5\xe3fd\xbf\x02\x00\x00\xff\xffPK\x07\x087\x01\xd2\x91j\x02\x00\x00M\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-http.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xa4\x91AK\x031\x10\x85\xef\xfb+\xde\x1f\x08\xac'!7\xf1*RP\xbc\xca4;\xed\x86f\x9303\x16\xfb\xef%u\x1b\xcb^=\xe6{o>\x1e\x84j\xfc`\xd1X\xb2\xc7\xf9a8\xc5<y\xbcD\xb5!\x1a/\xea\x01\x0c\x80\xc3\xa6\x07\x00\xbf\xdd7\x96s\x0c|%\x0b\x1bMd\xe4\xaf/ \xd3\xc2\x1eR\xca\xc9\x05\xae\xb3[\x8e\xe2&\xd2y_H&\xc7\xdf\xc6\x92)\xb9\xd9\xac\xde]h\xa5p\x7f\xb6F\x89\xf6\x9c\xf4\xa6\x06\xa8\xd6\x8d\xbbG\x8d~\x86\xf4\xa5\xc6\xb2\x15i\xe5p\x93\xd4\"\xd6\x8dn\x9d\xdb\x07v]\xaby<\x8e\xe3\xf8\x87\xa4X	%y\xbc?\xef:5\x92#\xdbn[WN\x1c\xac\xc8\xbf\xb77\x95\xb6?x:\x1cb\x8ev\xf1x-\x99\xd7\xcc.\x95\x1b\x98\xb8-\x18~\x02\x00\x00\xff\xffPK\x07\x08+o\x971\xd7\x00\x00\x00\xda\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-https.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x9c\x90\xb1N\x831\x0c\x84\xf7<\x85_ \x03\xa2\x03\xca\x86\xd8Q%\x10+r\x13\xb7\x7f\xd4\xfc\xb1e\x9b\x8a\xbe=J\xa8\xd0/\xc6\x8ew\xb9\xefr2J\xfd \xb5\xca=\xc1\xe5!\x9ck/	\xdeH/5SX\xc9\xb1\xa0c\n\x00\x1dWJ\xa0\xcc\xe7\x98I\x96\xb8\x9e4\x16\xb4\xe5\xc0\xa8%\xd2\xb7\x93vlqq\x17\xbb\xc5M0o\x99\x00\xd0\xf0@\xcdF\x1f\x00\x8a\xfc+\x9c\xf6p>s\xfb2'\xdd\xc2&\x94\x07(\xac>\x1b\xe2m\xd3\xdf\x8a\x89\x8f\xe7\x04O\xbb\xdd\xe3\xafTv\xce\xdc\x12\xbc\xbf\xec\xa7\xe3\xa8'\xf2\xfd6f\xd4(;\xeb]\xbb\x06n\xe3\x80\xcf\xc7c\xed\xd5\xaf	^\xb9S\x00\xf0\xab\xd0\x10\x85\xc6o\xe1'\x00\x00\xff\xffPK\x07\x08\x8c$\x8d\xf9\xc3\x00\x00\x00k\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00`\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus-service.yamlUT\x05\x00\x01\xf0b\xe7[d\x8e1\x0e\xc20\x0cE\xf7\x9c\xc2\x17\xa8\x14\xc4T\xaf\xec\xa8\x12\x88=\xa4\x16\x8d\xda\xc6\x96c\x8a\xb8=j\x9bN\x8c~\xdf\xef\xeb\x07I\x0f\xd2\x928#,'7\xa6\xdc#\xdcH\x97\x14\xc9\xcdd\xa1\x0f\x16\xd0\x01\xe40\x13\x822\x8f\x8d(\xcfd\x03\xbdK\xe5EB<\xc2H2\xb8\"\x14W\xc9\xbeB\x08W\xee\xa9c5\x07 \xacV\xd6\xa4\xa9\x85\x1fz:\x00\x80\\\x7f\x10\xce\xbe\xf5~c\xb2\xdd\xado\xeb\xa9l\x1cyB\xb8_\xba\x8dX\xd0\x17\xd9\xae\xedE\x85&\x8a\xc6\x8a\x87Q\x87\xfe/\xff\x05\x00\x00\xff\xffPK\x07\x08	\xbd\xf9B\x9c\x00\x00\x00\xf9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00X\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus.yamlUT\x05\x00\x01\xf0b\xe7[\xa4\x92\xc1n\xd4@\x0c\x86\xef\xf3\x14V\xce$-\x12\x07\x94\x1bp\xe0\xd2\"\xb4\x15\\P\x0f\xb3\x13wc63\x1el\xcf\"xz\x94\xc9\xd2.\x9b\n\xaar\xcaL\xc6\xfe?\xfb\xb7}\xa6\xcf(J\x9cz8\xbct{JC\x0f7(\x07\n\xf8&\x04.\xc9\\D\xf3\x837\xdf;\x80\xe4#\xf6\x90\x85#\xda\x88E\x8f\xbf4\xfb\x80=\x08\xf3\xbe\x0d\x98G\xd7\xb6\xad;\xd5\x96\xad\x0f\x9d/6\xb2\xd0Oo\xc4\xa9\xdb\xbf\xd6\x8e\xf8\xe2\x9e\xfan*j(\x1b\x9e\xf0YH)\x13j\xefZ\xf0\x99\xde\x0b\x97\xac=|i\x9a[\x07 \xa8\\$\xcc\xcf\x00-$\x1eP\xebI\x97N\x97\x0b\xa6!3%[n\x99\x87\xf9p@\xd9V\xa1\x1dZ\xf3\x02\x9a\x89\xb4~\xbf{\x0bcs\xfb\x14\\\xe0tG\xbb\xe8\xf3JoNO\x9c6\xc7\xf8O\x9b\xab\xfat\x11\xd1\x84\x82V\xb1\xf3\x84\xff2\xf6-\xa5\x81\xd2\xeey\xfe\xf2\x84\x1b\xbc\x9bS~\xb7\xfc\x17\xbc\x03X\x8f\xf5\x11\x98\x96\xedW\x0cV\x07\xf7\xe8\xf6=\xbd\xc0sk\"'2\x16J\xbb.\xb0 k\x178>\xd8\xf2\xf1ApmGU\xfd7\x12`\xf2[\x9c\xea\xa0\xe1\xa4\xc4\xb5\x80f\x0cs\x94\xfe\xd1\xdd\x87uk\xc7\x80\xeb\xa5\xf8\x1b\x9c0\x18\xcb\x02\x88\xf3\xd2]\x9d\x10\x01\x0c}\\h\xe7\x8b7_\xbf\x15T\xbb\x8f\x8d\x18Y~\xf4\xf0\xea\xf2\xf2\x9a\xdc\xaf\x00\x00\x00\xff\xffPK\x07\x08\x917\xd9qn\x01\x00\x00\xfb\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/service-monitor.yamlUT\x05\x00\x01\xf0b\xe7[d\x8e=n\xc30\x0c\x85w\x9d\x82\x17p\x82\x0e]t\x86\xb6K\x80\xae\x05K\x131\x11I$H\xd6\xe7/\x1cgp\x10mz?\x1f\x1f\x9a|\xb3\x87\xe8\xa8\xd0uH\xaa\xcb\xb8\x9eH\x9d5N\xa4\xfd\xbc\xbe\x95\x9b\x8c\xb9\xc2\x85}\x15\xe2\xcf=U:'\xce\x98X\x0b\xc0\xc0\xce\x15\\\xf56\x11\xdb2\xf5\xab?\xd40\xa4\xa3U\x00\x1a\xfer\x8b\xad\x06\x90\x8c}wK\x18S=\xb6.\xdc\x98R}OvLZ\xbe6o\xff\x03LO\xd4xM\x7f\x1c\x0e\x01\xa0\xd9\xeb\xc4\xedm\xda\x0f\xb5\xbfH\xf6\xe7\xa5<fS\x19ygL`\xeaYa\xc9\xb4\xa9s\xbaP\xdc	\x86\xb9T8\x1f%\x19\xc9\xbeb\xab\xf0\x1e\xe5?\x00\x00\xff\xffPK\x07\x08\xfa,\xfc\x87\xbe\x00\x00\x00c\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Q\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[,\xcc\xbb\x0e\xc20\x0c\x85\xe1\xddOauO\x11b\xcb\xca\x0e\x03\x82\xdd$F\x0d\xbd\xd8\x8aS$\xde\x1e\xb9t=\xbf\xceGZ\x1e\\\xad\xc8\x121\xb1\x0e}\x15\x19\xfb\"\x87\xcf\x11\xc6\xb2\xe4\x88g\xd6\xe1\xfa|sj\xb7&\x95\xef\xc6\x15fn\x94\xa9Q\x04\xc4\x85f\x8e\x98\xf9E\xeb\xd4\xc2\xea\xf9?\x9aR\xe2\x88\x0e\x06\xa7\xc1\x94\x93?\xcc\x9d=\xd8)\xc8\x86\x87m\x05\xc4\\L'\xfa^6\xb6\xdb]t\xb7\x83_\x00\x00\x00\xff\xffPK\x07\x08\xb9\xff\xa0\xe3\x83\x00\x00\x00\xae\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00M\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x8cT\xcfO+9\x0c\xbe\xf7\xaf\xb0\xba\x87^\x18f\xe18\xb7\x15?\xb4\x88\x05*v\xb5\xef\x80P\x95\xc9\xb8\x9d\xa8I\x1c\xc5\x9e>*\xd4\xff\xfd)L[&\xb4E/\x87A\xd8\xfe>;\xdf\xe7T\x05\xf3?F6\xe4+P!p\xb9\xba\x18-\x8do*\xb8\xc6`i\xed\xd0\xcb\xc8\xa1\xa8F\x89\xaaF\x00^9\xac \x12-\x0b\x8d\xa1-\x84\xc8\xf26\xceA\xe9ar\x04`U\x8d\x96\x13\x10\x12\xff!\x92\x03\xea\x94\x8e\x18\xac\xd1\x8a+\xb8\x18\x010Z\xd4B\xb1\x07:%\xba\xfdg\xc0t\x82\x0b@\xd0\x05\xab\x04\xb7\xb8\xc1\xdc\xe9\xd8\x8c\xe2$	\xc0n\xa8t\x1a\xcfS\xb2F\xaf+\xb8\xb2\x1d\x0b\xc6[\x13Y~\x18i\xff&\x96G\x94m\xa1&/\xcax\x8c\xfb\x0e\xc5I\xb5\xfac\x9cZ`\x05\xef\xef\xa0)\xe2,b\xa0Y$\x12\xd8lR\xac\x8b\x11\xbd\xcc\x9c\xf2f\x8e,/\x93\x06\x03\xfa\x06\xbd6\xc8\x93\xd7\x97IB\xa5\xbf{\xfe\xf4\x0fG=y\x85\xcdf\xdfE\x93s*9\xfa2.\xc5x3~\xfd\x14 .8\xc5\x8b\xc5\xf8\x0c\xc6E\x91\xbee\xc7\xb1\xb4\xa4\x95-k\xe3\xcb4qMo\xe7\xdc\x0ep\x1f\x83O;kw\xca\xdc\xcd\x1fI\xa6\x119\xad\xcb\xae\n\xfd\xeaS\xebO5\x9e\x9f\x9e\xeeg\x7f]?\xdc=\xce\xfe\xbd\xb9z\xbe\xf9oP\x03\xb0R\xb6\xc3\xdbH\xae\xca\xc2i%tD\xb9\xc7\xf53\xce\xbf\xe6\x0e\xd7\xd2\x91?\xa8Y\xe2\xba\x02\xd58\xe3\x8b\x9el4\xe0\xee\xa2\x91\xf5\x15y\xc17\x19\xd2\x87hV\xc6\xe2\x02\x9b\n$v\xb8O\xad\xc8v\x0e\x1f\xa8\xf3\xc2\xf95]\x8aM\x95\xb4\x15\x94\x0d\xae\xb29\xfa9\xf3h\x8e\xe05\x97u\xc7GP\xbc\xe6<\x91\x03\xad\xa9KGMg\xf1\x18\xd8\x9a\xfa0\xb9\xb3\xc4\x91/\xd07\x81\x8c\x97\xa2\xbfXF1l\x83\xa2\xcb\xa4\xf3\xb6\xe0\x0f0sh\xfb\x87\xf0\x93\xe2\xb2\x82\xb9\xb2\x8cg -\xc28\xd6\x0d8\x15\xc6\xbb%\x84V\xf9\x05\x9f\x01#B+\x12\xb8*\xcb\x85\x91\xb6\xab\xcf5\xb9\x0f\xde\xfec\x98;\xe4\xf2\xf2\xcf\xcb\x8bm\xa3\xac\xc7\xc0\x89~\xdc\x81\x05\xc5Q\x91\x13\xfc\xe3\x06\xd9\xc5\xc2\xa1K\xc5)\xb5\xbfe\xf8\xeaZ\xf1\x9d\xec\xdf2\x1d\xb3\xf1\xf7|\xd2\xe4\xe7f\xf1\xa0Bu\xc4\xff\xeci\xecI\xf2M1\x82\x8esp\xd1\xbf\x9a\xf4+\xfa\xe5=\xf5\xe3\xe6d\xbf\x02\x00\x00\xff\xffPK\x07\x08\x9dq\xfb\x8aj\x02\x00\x00N\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/ha/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[|Smk\xe38\x10\xfe\xee_1\xb8\x94\xb4,\xce\xe6\xe5\xb2\xe4\x0c{\x90K\xc2va\xf7\xae\xac\xef\xcbQ\x8aP\xa4I2D\x91\x8c4v/t\xfb\xdf\x0f\xc5n\xd3\xa4\xcd~\xb2\xf4\xbc\xe9\x19\x0beY\x96\\\x804\xc6=\x04X:\xaf\xc8\xae\x80\xd7\x08U\x90+\x04\xb7\x84\xaf\xb7\x01\xc8\x06F\xa9\xe3v\xf6W\x01h\xd9\x13\x06\xa0%XD\x8d:\x91Z{\x0cA\x90\xad\xd1\xb2\xf3;\xb1$4:\x87\xf4\xf12\xca\x94q\x95\x16\xa5w5i\xf4\xf0\xf93tP\x0d:p\xf9Tz\xaa%\xa3\xa0\xf2\xf1\x12\xd0\x04|\x85i\x1b\"h5-\xe1\xf2)M\x92\x0b\x98\xe1RV\x86\xa1&\xcf\x954@%P\xd8\x17\xfez\x1b\xeb\xc5\xd5\x92|`\xb8\x92V\x83\xb3fw\x0d[\x19\x18}7\xd9T\x0b\x14\xadSP)dI\xb1\xe1#\xac]\xe0Z\xfap\xb7\xf2\xae*\xc3]\xa7qt\xee\xefz\xf7\xf7w\x9dC\xc7\xce=<\xc5\"\x14\xc4Z\ne\xaa(k2N\xad\xf0\x13\x0c\xda\x15\xaf\xe1\x0f\xe8C,se\x1d\x83\xa6 \x17\x06E#\x13k	?a\xe1\x9c\xb9n\x82\xd7\xb2\xf4\xee\xbf\x9dP\x86\xd0\xb2`\xda\xa2\xab8\x87t\xd8\xdb\xa6/l@_\xa3?\xc7\xdaj+TY\x85\x1c\x06/\x98q+a\xb0F\x93C\xfa \xbd%\xbbz\x15\x17\x8cX\x90\xd5\xc2\x95L\xce\x86\x1cR\xeb\xb2\x10L=\x04\xeb26\xa1\xee\xf7^V\xfdv\x951\xa9\x0drH\x01.\x80M\x80\xba\xdf\x1d|\x00\xb8zp\x95\xd1\xb0\x965\x02;\x90Z\xef\xd9~w\x08\x8a\xca5\xfap\xfd\xf6\xe4\x96\xc9!\x9dOg7\xf3\xecG1\xc9&\xf3\xa2?\x18g_\xa6\xdf\xb3\xe2f2\x18}\xca\x1bn>\x9d\x1d\xd8#\xe6\xc7\x19\xfc\xd4\xf1\xae<\x7f\x07:\xb1\x0fF\x9f\x9e\xeb\x0c\xc7\xbf\x1d\xa7\xbc\xcb\x1d9\xcf\xb9~\x81\x9f\x89\xc9\xdf\xfe\x9a\xe3\xb1_\x8d\xf0\xb6\xda!\xa7\x95>\xa7\xc6\xa3fEq\xf4O\xe6E6\xfds:\x8c\x9b4\xb98\xbd\xc9\x1c\xe0\x9foE\x7f\x18-\xd9\xc91\x07\xe2\xa4jCLo&\xd3\x9b\xc9\xa0\x97\xdd\xfe\xfd\xed\xdf\xfe\xb07z\xb9\xb28\xf3\x87\xc9\xbc\xf82\xfd\xden\x9e\xb5I\xa2\xd0\xb3\xd0\xe4s\xf8\x88\xac>\x96\x1bJ\xbcs,\x94\x14\xca\xb3X\x92A+\xb7\x98C\x8bv\x95\xe7\xc4:\x8db\xef\x94V\x8b\x0d\xee^\xe9\"\xd7-q\xdbD\x87<\x81=\x14\xbf\x00\xcas\xab\x881\x11\xd9\xe0\xaeE6\xb8K\x12\x8f+\n\xecw\xa2tQ:\xea\xf5z\x07\xcc,\x0e\xf0(I\xd0\xee\x1f>\xb2\xd2\xc28%\x8d\xd8\xbf\x81\x18\x97\xec\xc1\xc0\xd23\xd9U\x83\x0b\xa9k\xf4L\x01\xdb\x94\xf1x<~WH\x96\x98\xa4yc\xf8=\x1a\xfe\x0f\x00\x00\xff\xffPK\x07\x08\x8a\xe3\xa3a\xbc\x02\x00\x00\xe1\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x007\x00	\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-haproxy.confUT\x05\x00\x01\xf0b\xe7[4\xce\xc1N\x021\x10\xc6\xf1{\x9fb\x02\x1bo\xbb\xb3\x10B\"7c\xe2IO\xea\x03\x94\xed\xd0Nl;\x9bv\xa4\xe0\xc1g7k\xe0\xfe\xfd\x7f\xf9\xd6\x1f\x81+4[a*d\x95\x1c\x9c\x8a\xa4\x83Y\x07\xd5\xb9\x1e\x10[kC\xe5\xf4\x1d\x139\xb6\xc3$	\x8fQ<n\xc7\xcd\x1e\xc7-n\x1eq\xa2\xac\xc5F\xfe!\xd7;\x99\xbe\xa8\xf4Q\xbc\xe7\xec\xfb\xc6\x1a\xfaR\xafuIL\xf7\xe4\xdcg\xe6\xcb+W\xa5\xfc\xbeL\x15\xf0l\x0bF>b\xb0s\x91\xcb\x15\x1d\x9d1\x8a7\xa6{\xe1H\xcf\xff\xb7\xde\xc4\x11\x8c\xfb\xdd\xce\xf0	\xba\xb9\x88/6e\x9b\x08&\xc9j9WX\xdd\xfa\x15h\xa0|c\xc5\xdf\xd9a!\x1f\xe0\xd7\xfc\x05\x00\x00\xff\xffPK\x07\x08\x99\x11\xdc\xba\xc4\x00\x00\x00\xf2\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00:\x00	\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-keepalived.confUT\x05\x00\x01\xf0b\xe7[<\x8d\xb1N\x031\x0c@\xf7|\x85\xd5Vlw\xbeVU%\xba\"\xb1 6~\xc0$nb5\x89ONh\x04\x03\xdf\x8e\xca\xd0\xf9\xe9\xbd\xb7\xfdH\xd2`P\x03oL\x9d\x03\\L\xcb\xd9mS\xefk;#\x8e1\xe6&\xe5+\x17\x0eB\xb3\xd7\x82\x9fY#\x1e\x96\xfd	\x97\x03\xee\x9f\xd1s\xedFY~8LA\xfd\x95m\xca\x1a\xa3\xd48\x0d\xe9i\xb2\xf6\xdd\xee\x8a\xdb\xbdJ\xe6\x97\xff\xcf\xbb\x06\x86\xe5t<:\xb9\xc0n5\x8dF\xa5Ra\xf0Z;Im\xb0yc^)\xcb\x8d\xc3\x06z\xe2\nx#\xc3{\xe9\xfa s\xd6\xe8\x9e\xe0\xd7\xfd\x05\x00\x00\xff\xffPK\x07\x08\x18H<R\xab\x00\x00\x00\xc9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x003\x00	\x00roles/kubernetes/ha/files/etc/rsyslog.d/remote.confUT\x05\x00\x01\xf0b\xe7[R\xf1\xcdO\xf1\xc9OLQ\xc8\xcc-M)\xd0+\xce\xe7R	u	\x08N-*K-\n*\xcdS054A\x12qLI)J-.V042\xd73\xd03\xd03\xe4\xe2Rq\xcb\xccIu.JM,I\xf5\xcdOIU0031\xe1Rq\xc9,B\x16375\xe5\x02\x04\x00\x00\xff\xffPK\x07\x08\xd5\x9e\x80<]\x00\x00\x00j\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x002\x00	\x00roles/kubernetes/ha/files/etc/sysconfig/keepalivedUT\x05\x00\x01\xf0b\xe7[l\x91Oo\xd4<\x10\x87\xef\xf9\x14?5\xd2\xfb\xee\x1e\xbc\xe2\x82@H=T$\xa2\x11\xa5\x896%\xd7b\x92\xc9\x1f\xd5\xf6X\xb6\xb3(\xdf\x1e9\x01vY\xea\xc3\x1c\xc6\xf3<\xe3\x19\xa7(m\x98\xd8x\xf4\xec\xf0Bd\xa5\x9aN\xd4\x1dP\x13\xe1\xdb9\x01!FR\xf6\x7f\xf0\x1c\xec\x1c MwQ\xbe{\xbf\x8f\x99$\xbdT\xb4l\xfa\xdd\xdb=\xb44\xb0r\xa0\xad\x87\x84\x9a|\x00\xf7\x90J\x81\xb7\xee\x07\xdc\x93#HG\x08#A\xb3\x0fI\x8a\x96\xb5f\x036\xe4\xf1!I\x93\x14B\x9c\x9c\xb3\xf8\xfb\x88*\xc6\xd2\xa8\x05n6\xf81\x85\x11\xcd\xf1X\xc1\xcf\xdf\xfd\xe2\x03\xe9\xc3\x8a\xb6#\xb5/W\xe8\xc7\x7f\xd1{\x92*\x8c[5\xb9kI\xc7&\x08G\x8a\xa4\xa7_\x8f\x11M\x94dl\x02\x1ci>\xd1\xd6\xbd)*\x8f\xff\xd0\x1c\xcb\xafO\xb9\x07\x1bt\x92\xe2<>\xb0}E6\xd9\x93\x07Dq-+\xaa\xa6F`\xcb\x8a\x87\xe5u\xcd\xac\xad\x88\xdb\xbe\x18\xac[5\xb3\xb6\xebB\xe3\xe54\xccN\xc6m\xa3\x93An\xa4\xe2At\x14\xe4\xa4\xced\xb6\x92k\x92:(\x1e\xa0\xc9\xfb\xf8\x7fg\xa6\x97\xed\xa4\xa6\xb0\xfcf\xea\x18\xdf\x88w\xa8)@q+\x15\xfc\xe2#\xfb\xa7r\xd7Q/g\x15n\x1f\xcaO\xcf\xd9]\xfe\xa5|\xdc'i\x92|\xce\xf3\xea\xee\xa1h\xf2\xec\xb9\xac\x9e\x8a\xf2\xb1\xbe\xbd\x11\x19Du\x93\xfc\x0c\x00\x00\xff\xffPK\x07\x08\xa9\xea\xad\x9cs\x01\x00\x00\x9d\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/ha/files/etc/systemd/system/bynet.service.d/bynet.confUT\x05\x00\x01\xf0b\xe7[\x8a\x0e\xcd\xcb,\x89\xe5\nO\xcc+)\xb6\xcdNM-H\xcc\xc9,KM\xd1+N-*\xcbLN\xe5\x02\x04\x00\x00\xff\xffPK\x07\x08\xf8+\xff\xa2&\x00\x00\x00 \x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Q\x00	\x00roles/kubernetes/ha/files/etc/systemd/system/keepalived.service.d/keepalived.confUT\x05\x00\x01\xf0b\xe7[\x8a\x0e\xcd\xcb,\x89\xe5rL+I-\xb2M\xaa\xccK-\xd1+N-*\xcbLN\xe5\nO\xcc+)F\x13\x0bH,*\xf1OC\x13\xe4\x8a\xf6\xcc+.I\xcc\xc9\x89\x05\xebIMq\xaaDS\x01\x08\x00\x00\xff\xffPK\x07\x08\x9a\x97F\xfeB\x00\x00\x00f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/ha/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xc4\xceM\xaa\x840\x10\x04\xe0}NQ\xb8q\x95\x0b\xe42\xd2\x92B\xe5\xe5G\xd2y?\xb9\xfdc\x1cE\x06dv\x83\xdb*\xba\xeb\xb3\xd6\x1a\x8b$\x91\x0e\x85!\x8b\x87$\x8fB\xadR*\xbe\xc8U\xc2\xf2Co\x00mZ\x19\xbd3\x00\xf6\x93\x97\x1e\xd0*u{\xb4]\xef!\x93\x8c\x81\xde\xa1Q\xb7\xc0\x0bcN\xc3s\xee\x88\x7fg&\x87\xae\x8f\xa2\x95\xa5\xc7\x920\x95\xfc\xbd\x0e\x8f!\xed\xcc;\xe5,k\xc9\x7f\xed\x9ax\x96\xb7\xf9\xc6\x96X\xafuGu\x9b\xadh\xd3\x90\xa7k\xddY~\xd4\xf7\x1f\x00\x00\xff\xffPK\x07\x08\x89B~\x8a\x9a\x00\x00\x00\x85\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/ha/tasks/haproxy.ymlUT\x05\x00\x01\xf0b\xe7[\x9cT\xcd\xae\x9bL\x0c\xdd\xe7),>}bSB[]u\xc1\xae\x8bJ\xed\xee\xbe\x01\x1afL\x98f\xf0\xa0\x19\x93\x04]\xddw\xaf\xe6\x07.i\x95\xaa\xed\n\xb0}\xfcs\x8eM\x05\x9d\xb1\xf2\xdc\x1c\x0e\x00\x15\x90\x18\xb1\x81/\xe4g\x870\x88\xc9\xd9\xdb\x02'g\xe7\xc9\x03\xde\xb4\xe7\x03\x00$C\x13_!C\x8a\x97\x17\xd0\x8c#\xbc\xbe\x16\xd9\xe1Y0609\xf4H	x\xd5<\xb4!\xcc't\xb5\xd6\xc8_\xe7\xb9\xc3}#B)\x98=:(s\\	l\xa1\x0cae\xea\"\x02C\xc8};\xfb\xb4\xb9_\xbfY\xdf\xa5:\xc9'\xa6	I5\xb0\xa0\xdfW\xf6\xc8\xf0\xf5\xf3s\x9c\xdf/\x9eqT0\x93Nc0\x8e\x93	\xc3\xad\x93:\xd9\xc0\xec]mtW\xe7\xe8\xfc\xacs\xcd\xa3Gw\xd1\x12\x8f\xdf?f\x90B\xcf\x0d\x14\xf5\x9f\xe1VRG\xab\xb0\x81\xf7\x9f\x9e\x9e\xb2\xc1^	]\x03\xceZ\xdeO\x9b-{.\x8d\xb1\xd7m&\xb6\xd0iR\xe1)\x80,U\xc6Ja\xea\xf0\x16uFb\xf8\xf6\x1c3\xfa\xc5K6\xf7\xfc\x12\xf2QO\x97\xa7\xa3\x9eZ\xb2\x14\xc1mH\x98\xa3.\xc2\xcc\xd8\xc0\x87\x95\xa0\x98\xa2\xf5\xc8\x89\xe8`sx\nu\xdc&KK\xc8\xd2R\x1f\xbddY\xf7\xcbZ\xb4\x02\x87\xc6\n\x05\x82\x148\xf4,\x1co\x1a\xeff,\xa4\x9d\x16(kd\xb9\x12\xb8\x11)\xfbSY<\x96\xef\x01\xe4W\xbd\x1e\x04\xfe\xbd@\xf7;\xfa\xdfE\x18\xad\xe2\xc5\xc4\x95\xe84\xd5\xca\xca3:\xc0\x1b\xca\xed\x18\xab*\xf9}\x08\xd8\x8c\x12\xaa\x1e\xfe\x7fD\xec?\xb3:\"\x8b\x06z3\xfb\xa1\x1d\x04)\x83.\xd5\xb8\x0eH\xf7\xf9\x8fr\x10tB\x05\xd6\xfd,\xe8\xea\xda+\x85\xd4['\xdf\xfe1n&\xd2tZ\x17.\\\xc2\xef.:\xff[b\xcb\xb8.\x1d\x92\xe8\x0c\xbe\xddrj\xb2(G\x11\xe8(AS\xa2\xbe\x0d\x19}q\xf8\x11\x00\x00\xff\xffPK\x07\x08n\xbe2\x84\xf1\x01\x00\x00\xfb\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/ha/tasks/keepalived.ymlUT\x05\x00\x01\xf0b\xe7[\xec\x96\xb1n\xe3<\x0c\xc7w?\x05\xa1%\xed\x078)\xfa\xb5w\x80\xd7\x1bo9t\xb8\xd5\x90%:V#K\x86$'g\x14}\xf7\x83\x14%\xd19\x8e\x9b\x16\x1d;\xb5\xa1H\x8a\xfc\xfdI\xdby\x9egY\x0e\x95\xd4lSd\x19@\x0e\x8a\xb6X\xc0\x0f\x83\xd4!\xac\xd0\xb1\x95\x1d\xac\xc3\x96\xc7\xbf\xab\x97\x17\x10\x0e[x}\x05.\x0c2\xa7\x8d@\x9b\x01\x00\xd4Bb\x11\xfe\x03\xe8\xa8k\n \xf3\x19Ht\xb6\x8e:,\x8e\xf9\x86hn5\xc7\x02\xee\xbe?>F\x83\xde)4\x05\x18\xad]\xb4\xac\x8d\xee\xbb\xc4\xb2\x13\xae)}v{($\x07R\x0d\n\xdd\xd2\xa2\xd9\n\x86KNN'\x1b\xc4\x8eJ\xb1E\x9e\x1e\x07\x10\x16]YS\xe6\x0eyB\x92\x12\x15\xad$\xf2\x02\x98\xec\xadCS\x8a\x9a\xfa \xf4\xb1 ,\xb4\xd4\xb1\xe6\xc6_\xb9\xfc\x8f\xdc\xa6L-:\xf8y\xbc\x0f\"\x14\xe8\x95\xd8\x97\xee\xb0\xed\xa4\xe7p\x80bX\x01\xbd5+)\xaa1\xc2\x89\xba\x9f\xefc\x1cG\xeb<\xf9\xabC\xc9\xbf\xb8\xbf=<\\\x85{\xd4\xda)/0\xadj\xb1\xee\x0duB\xab0\x15\x97\x1b$~@N\xb1iy>\xcd\xf2\xf9\x9e\x8c\xfa\x9a\x0f o\xd5~\xf2\x9fn\xdb\xe0ZxeS\xcf\xd2g\x0e\xa7J;Q\x0f\xa7\xd92(5\xe5@\x15\x07\x83\xd6Q\x93\x82H\x11\xb5t\x83)#\xad\xe4\x00\xa6Wab\xe1\xf7\xd3\xd3/\xb0}\xb5\xd7(dg\xba\x1b\xceI\xd9\xc1\xee\xe9&}O\x01\x9a\xf3{{\x8b&f\xa1\xd6\x86a\x01C\\\xf5IJ\xf1\xd2O\x00\x15\x96\x0d6J\xef\x80V\xbawc\xd5.\xc2I'}\xb4\xf6\xf1w:$#b\xef\x0b\xfe\x0c\x8c\xbb\x06\xd5\xb5\xcf\x12\xd0\x06n\xba\xbe\x92\x82\x95[\xd1\xed\xbd\x03\xd0\xb1\xf1b\x8a\xdb\x0fH3\xe3\x15\x98\xcc\x0d9\xc7\x0e\x15G\xe5@\xab\xe8}\xb5zS\x8f\xe6K\xcb>\xa3\xe3{\xd2|)z\xa6(\xf1Z\xc1\"\x905v\xb0R\xaf\x97|\xf5\xff]\xde\xd0\xce\xe8?C\xe0\xb7 \xd3\xb2\x9e\x05\x8d\xb8O\xa87\xe7\xfe\xc1\xf7\xd4\x95\x80\xe2\xcd_\xcf\xeck\xc7(\x80j\xd1\xd1\x02j\xd9\xdb\xa6l\xa8\xe2\x12M\xba\x07\xa3W\xe8\x925T\xad=I3Q\xc3\xe14\x95\x00Uh\"U\xc1\xf4J	\xb5\x0e\xb7\xc4e?\x94\xbc\x8f9\x1b\xf5\xf8y\x19:8\x1a\x8f_r\x1eOv(\x98,Z\xea\x19-@\xa8=\xe2\xd2'\xb5$\xfb\x1b\x00\x00\xff\xffPK\x07\x08_\xc3\xc3\xcdt\x02\x00\x00,\x0b\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00roles/kubernetes/ha/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x91\xb1j\xc30\x10\x86w=\xc5O\x86&\x19\xda\xec&t\xc9\x98\xa5o \xce\xf6\xc5\x16>K\xe2$\xa5\x0d\xf4\xe1\x8b\x9c\x84\x96R(Yu\xf7\x7f\x9f\xb8\xff\x19\x9efn\xb0\x9fJ\xcb\xea9s\xda\x8d\xf4j\x80\x9e\xdb24\x06\x00\xe644xS\xe732\x0d\xbfwq\n\x8aci\xf9\xe82\x0e\xc1\x9f\xdcP\x94rPD\xd2\xc4j\xcc]r\x18\xb9\x9b@\"\x18C\xca	\xa4\x8c\x94\x9d\x08\xe8LN\xa8\x156\x80\xf3\x9d\x94\x9e\xad\x06\xe1\xab\xff\x9a\x8e\xca]\x05,O\x99\xd2\x94\xecI\xc3\xdcT\xa0]\x80\x96\xc4\x9d\xb9\xfaZ	\xddT\xc3\x06\xb8\xdb\x9dO\xb9\xba\x9f\xd0\xdd>\xc9\x98\x98\xe3\x12\xea\x17\xaa\x9bc\xd0l\x17x\xf3c\xf8r\x99\xc5\xfc\x83\x1a)j\xf8\xb8\xfc\xc1\xb9M\xee\x90\xf7\x91}\x83\xd5\x06\xeb\x99Rf]\xc3y\x0c\x1aJ\xb4\x95\x9e\xb0\x05\xf9\x1e\x1b\x97\xecH\xb6\x93R\x97\xf0\x896\x04\xd9\xae\xbe\xaf\xb9\xdf=\xd2\xd9\xee\xc1\xd2\xbe\x02\x00\x00\xff\xffPK\x07\x08\x92rq\xa5\x04\x01\x00\x00\x19\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/kubernetes/ha/templates/etc/haproxy/haproxy.cfg.j2UT\x05\x00\x01\xf0b\xe7[\xecW[o\xdb6\x14~\xd7\xaf8CQ\xb8E+\xc9v\x9a\xf4\x02\xf4a\xd8\xb2\xeda\xc0\x024\x03\x06t\x05A\x91G\x12\x11\x8aT\x0f)'\xa9\xe3\xff>P\x17[\x96\x9b4\x85\x87=MO\xd2\xb9\xf3;\x1f\x0f\xa9'\xa5\xf7\xb5{\x97\xa6\"\xb3\xc6cR(_6Y\xa2lZ\xf2\x9a\xec\xcdm,\x855\xabt\x91\x9c\xa6\xc2\x9a\\\x15\x0dq\xaf\xacIJ_\xe9''\xc9ITh\x9bq\x1d\x01\x98\xac&+`\xbd\x86\xde\x97\x99\xa6b\xa2n\x1cl6\xd1\xfa)\xe4\x96@\x812@\xdc\x14\xf8lju\xa7\x8c\x7f\x0eO7\x11\x80\xa8\x9b\xb8\xe2u\x88\xa5\xe0\x05,`\xb3\xe9\xde\xbb@hd\x88\xd5\x99\xf28\xe3\x0ea\xbd\x16H\x9eIE\x9b \xd6\xb6\x80\xc5\xf2u2O\xe6\xc9\x02\xb4\x15\\\xcf\xc7\xa5i[0\x8d+\xd40\x98\xc7\x0e\x8d\x8cK\xeb\xbc\xe1\x15F\x00\x15\xbf\x11\xd6\x18x\xb3x\xbb\x0c\x89J\xb2\xd6C\xba\xe2\x94j\x95\x0d\x00E\x00\x8dC\x82\xddgA\xb6\xa9G\xdf\x92ceM\x04\xe0\x1b\x83I\xd6\xe4N}A8Y\xbe>{3\x08\x9d\xd3\x89\xc4\x9c7\xda\xc7\xb2\x8ckN\xbc\x82\xe5\xfc\xd5\x9e\x81\xe0\xa2\xc4\xd6w1\x0f\xcfX\xa7U\x8e^U\x08g\xfb\xf2\x8a\xdf\x10\nK\x12\x16\xaf\xce\x82\xc69\x1d\x0f\x992edl\xeb\xd0N7\xc6\xc69\xcd\x82\x8e\x0d\xba\x16\xa2\x03O\xa1\xea\x12\xe9\xeb\x9e\x83\xae\xf5|\xd2\xfa:\xa4\x15R\xbcBR\xf9-\x18k0\x8a\xfax\xae5\xca\xb8\xe6F h\xe4\xce\x07\xe4\xfb6\x86gK\xb2\xcaJ\x0c\x82\xc0\xdb\x08\xa0\xab\xb0\xeb_\x89\\\xfb2\x16%\x8a+7\xd19\x0c\x98z\x8c\x91\xc8\xd2X+\xad\xf1\xc1\xc2X\xaa\xda\x0c\x13\x85i\xf4X\x1a\xf2j[L$\xc3\xe2\x84\xb6.P\x87\xd0\x93B\x07'#;B\xa9\\\xcd\xbd(G\xdcZ\xf6}T\x15\xda\xc6C\x90\xa1\xf0a\x81\xa7\x13\x8dVhZ\xc5\x18\xefN\xca\x06\xa3;\xe8\x01\xdd\xee\xad\xae\xae\xc1\xe0%xj\xf0\xf9\xc8nv2\xaff\x83\xb8m\xd6\x10\xab\xf3\x9c&\xdc\x8f\xf7\x95\x84\xfb\x15};a\x94S\x18<F\x02\xafU\x17\xbd%\x03\xe1g.%\xfc\x15\xffb\xe9\x9a\x93D\x19_\x90\xf5\xf6\xdd\xdf-\xe0\xa1\x83\x81h\xa1\xb6\xab&C\xb6R\xe4\x1b\xae\x99\xaa\x19\xaf\xc3\x9cx\xb7\xd3\xb4\xa2\x96\x9b\xb5%\xdf\xcf\x10\x95C\xdddZ\x89\x91+p#\x0f\xa5;\xcfv\xe0\x0ci\x0f\xbd\xbb\xa4\x0f\xf8o\xa7\x97\xcaG\xb1\xb6c\xea\xc1\x92{\xea{1\xa2\xbd\x17=\x17{tY\xc6\xc5\xd5>\x96;|	\x0b\xe5<\xdd>\n\xb9\xc1\x98\xe9l\xa8 lb\x10\xe4!m\x1c\xa5\xedDM\xd1\x8ba\x0e\xa6\xc6JLj\xac\xee\x05\xf7Q\xe8\x1d\x99\xf8Al\x8f\x89\xdd\xc3\xbf?v\xf2\x8e\x9b\xb9\x0d\x9c\xe5B\x83\xb4\xe2\n\x89]\x93\xf2\xe8\xa0B_Z	\x17\x7f|\xb8\x84\x8b?/\xe1\xe7\xf3\xdf\xcf/\xcf\xe1\xe2\xc7\xcb\x9f~\x8b\xfa\xc1A\xf8\xb9A\x17\xb6\x9b\x0f\x03L\"\x1dr\xbec|\x00u\x1d\xeae\xb9\x80\xcd\xd4_\xa2\xb9\x0d\x16{\x15D\xdd\xd4\xa10R\x9c(\xb1\xc2]\xa8\x1f\xf6bM	\xb4%KtH\xa9\x07\x99\xd8\x7f\x874\xa2\xbc\x82_\xcf/!\xed\x06\xf3\x97\xa1\xe4v@\x03\xde\xd4mY\x9e\x94)\xc0^\x85\x8e\xf5\x07\x00\xd9\xc6H\xb2\x992\xbbU\xba\xda\x1a\x87\xfb0}\xe8F\xd4S\xb7[@?\x88A\x19\x8f\x04\x8b\xb9\x03i\xafM\xf7u\xea\x80\x94CXB\xce\xb5\x86%8m\xaf\x9d\xe7\xe4\xe1l\xeev#\xf9t\x1e\xde?7\xd8 ,O\xcf\xe0\x1aUQ\xfap\xe8F\x00\xfd5&\\\x13\xc2M\xa6=\xeb\xdd\xc7Y\xc5\x9dG\x9a}\x82\xb8\xe5^\xb7\x05\x94Y\xa1\xf1\x96n\xd9p\xab\x80\xf7\xef;\xd7\xc1\xca\xa1\x87\x1ea\xd6\xe7y\x0f\x8b\xad\x1a\xb5\xc3\x87m\x9fM+\xb8\xd3h\n_>\xdf\xc5\xd8m\x89\x1e\x9cm+\xd7k\xd0\xd6\xd6\x892\x12o\xfa;V(o\xc5\xc9}\x0c/\x9f>r)	\x9dc\xbb\xb5\xe4\n\xb5\xfc4\x9e\xb0\x93Q5\x00\xb6^O\xcb\xddl\xc2u-W\x1a\xef\xdbo\xebu\xb8d1\xc1\x99 \xcf\x82e\x0b[p\x0c\xac\x89\xc3v\x1d\xdf \x86\x15\xf6\xd7\xc1\xe8\x80\xbf\x8f\xde\x01\xdf\xda\xde\xbdl\xef\x14\xff\x1a\xd9W\xcb\xf4\x7f*\xffgT\x1e\xfa|<\x93\xb7\x87\xc3cX<\xa1aG\xce\x032v\xd0\xa1\xe1\x99F\x86^H\xd6\x12\x9e\xb5T\x0f\x16\x8f\xe9\x80V\xce\xa3\x81\xe0\x1e\x7f\xc7\xa2\xee\x80\xb0\xd6\\\xe0\xb3Y2{	\xb3x\x16\xae[\xb12\xca+\xaec.WH^9\x8ck\x9c\x8e\xf4\xc3\x83\xb3\xad\xbd\xa5\x972EW>\xeb#\xb1m\xa4\x16\xb8\xf0\x0f\x07/F\xbd\x98\xf7?\x0f]\xbbB\xa0i\xab\xee\xa9\xe9;;\xb8<y3\xff\x97\xb1:\x1e\xa3\xe3\xb19\x12\x93\xd7o\xa3\xfd\xff\xe5\xf1&\xfa'\x00\x00\xff\xffPK\x07\x08z\x9cl\xa0\xe0\x04\x00\x00\xfc\x0f\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00?\x00	\x00roles/kubernetes/ha/templates/etc/keepalived/keepalived.conf.j2UT\x05\x00\x01\xf0b\xe7[\xdcTQk\xe3F\x10~\xd7\xaf\x98\xbb`\xfc\xd2s$][\xdaB\xa0n\x9aB\xe9\x1d\x1cu/}\x08aY\xafF\xf6\xe0\xd5\xee2\xbbRb\x8c\xfe{\xd1ze;q\x02m)\x94\xf6M;\xb3\xdf\xcc\xb7\xdf\xcc\xa77pmMM\xab\x96e k\xe0'\xd2\x08\xb5e\xd8 :\xa9\xa9\xc3*\xcb.`\xa5\xedRjQa\xeda\x97]\x00\x80\xb1\x81jR\x11%\xb0\x91\xa4S\x02@*\xe9=\x9a\xefkb|\x90Z\xcf\xb4U)UK\xd2\xb6C~)\xe7\xb7^V\x0d\xbd\x80\xeb_\xe9(j\xb6\x0d\xcc5>JS1\xce\xae_\xe9\xeb\x9b\xe0\x84G\xee\x90\xa1\xf8\xb6\x9c\x15_\x7f3+\xf3|V\x1c\xb3\xca\x1a\x83*\x88@\x0d\xda6\xc0\xfb<\xe6\xd8\xb6\x01YP\x05\x1fn\x17\xe2\xc7\x9b\xdb\x9b\x0f\xd9\x05\xf4\xd9S=\x00\xd0\xc8\xa5F\xe1\x15\x93\x0b\xc2\xa3j\x99\xc26\xeb\xb3\xaccv)\x0ek\xb5\xde\x88\xb5tl\x1f\xb7\x11\x06\x902o/[\xcf\x97K2\x97#\x81\x12\x0e\xa1\x0di-\xb5\x86w9$\xf0\xdb\x88%\x13\x90;\xa9\xa1\x8c\xc7\x07\xa4\xd5:\xa4C=\x00\xde\xc7O&\x8fP\x1e\xb8\x90\xf1A\x1a\x85p\xfb\xb3(F\x16A\x06\x84\xdd\x04\xa8\x062\x1d\x9a`y+\xd6\xd6\x07#\x1b\x84\xab+\x986\xd2\x07\xe4<\xcf\xa70\xe9\xe1\xe3|\xf1\xdb\xcd\xaf\x03\x02\xb5\xc7!\xf2\xc3\xfc\xfa\x97\xcf\x9fb\xc4TT\xc3\xa4\x8f\x95\x8du\x8c\xd8\xb8\x90\x1d)\xd7R!\xecv\xa0t\xeb\xa3\xbaC`VaG\n\xa1\xdf\xe3.\xe0c\xab\x03)\xe9\xc3w\xc3\xe4\xe1\xc1\xf2\x86\xcc\n\xac\x81\xf9\xef\x8b/`\xa9\x80\xc2\xd4\xc3R[\xb5\xc1*\x81\x9a\x01 <+Anh\xb1i\x97(d\xd5!\x07\xf2\xc3W\xc5\xe8\xfd\xb1\xc9g\x93Z\x0c\xe5}*>\x12\x03i*\xc0\x0ey\xfb\xb0F\xc6\x08i\xf7\x80?\xddb\xbc\xef\x109\x89\xbd\x9bD\x83\x0d\xea\x02\x19X\xb1m\x9d\xbfK\x02O\xef\xe1]\x92\x0e\xd2@\x86\x8b\x9dd\x7f7|\xdc\xdfM\x1dS'\x03\nr\xd3{xs\xf5\x1a\x81C\x15\x18X>+\x92.\x89\xe3\xb0kB]\xdd\x8f\xb4\xe1l\x90\xfb\xf3@<\x05\xfa\xfdD;\xe2\xd0J-\x8eN\xf9\xaa\x88	\xc7d\x07\x13\xfc\x95\xb5*\xf2\xe2t\xa7\x8a<\x7f\xc2#\xd6\xdd\xbfT\x90\x19W]\xb6a\x8d&\xa4?C\x12yL\x88\xb0u\x08\x9f\xe6\x8b\xc5\xd3\xb0\x93\xdeCQ\x96\xc5Ko!7\x8a\xb8;\xd50\n}\xbc#\xa4\xa3Q\xafT \xb0T\x9b\xd1\xedG\xec\xa9\xef\xd3\xf5>\xcb\xf6\xba\xb8v\xa9I\x9d\x94\x8d[w\x88\xba\xbd;b\xf00\xc3\x13\xd5\x86}\xd8\xdf\x8d\xeb0\xe9\xcfM^\xfe\xab&\x7f\xfe\x92g>?\xf7\xd3\x19\x80\\\xf7\xe5\xec\xbfa\xab'\xb3\xf8'\xacT\xfeO\xadt\xbe\xf5\x7f\xdfH\xa7\xcf\xfa#\x00\x00\xff\xffPK\x07\x08\x0f\xd9\x11u\xd8\x02\x00\x00\xcf\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/haproxy.service.j2UT\x05\x00\x01\xf0b\xe7[\xacTA\x8b\xdb<\x10\xbd\xebW\xcc\xe1\x03\x7f=(.\x85^\n:\xec\xb2\xe9n\xa0\xb0&ihK\x1a\x8c*O\x12\x11YrG\xe34\xc1\xf8\xbf\x17g\x9d\xa4$\x9b\xdd\x1ez\xb2\xf4\xf4\xe6\xbd'<\xa3\xd9\xd4[\x9e\x8b;\x8c\x86l\xc56x\xf5p\x93Q\xd8\xee\xe0S\xd0\x05\xdcj\xa7\xbdA\x127\x0bFR\x1e\xf9W\xa0\xf5\x805-\x91\xa1\x08f\x8d4\x88H\x1bkP\x8c\xf1gm	\xa3:\xc3\xc5l\xf2\xb4\x9a\x8b\xcf\xb6\xc4P\xf3\x845\xf1\x04\x8dz+\xc6\x18\xbb\x8d\n^.\xb4u5\xe1\x01\xea\xce\xdf\x8b\xe1\x16\xcd\x9e\x9e\x11*\x99\xd6\x91\xd2\x1f\xd6\xa7O\x16\xb0\xb6\xce\xc1JW]\xe2W\xa8T>O\xdcs\xe2\n\xa4\x81\xe4X\x13w\x91\xb14\xec \"K\xf4\x1bK\xc1\x97\xe8\x19\x1en\xb2\xf1\xe3\xd7o\xf9t2\x1c\xe7\xa3;\xf5\xdf\xff\xc7\"[\x80\xac\x0f&o\x92\x7fbs?~\x9cf\xcf\xf8,\xff\xf09\x19\xa9\x8bK\xd7\x1e\xa4\xa4\x12\xa4\xec\xff\x9dZ\x85\xc8\xf0]\x00\x00H\xbc\xbcNs\x86\xb4\x97\xdcS\xa6\xe6\x1c:\xb27\x90\"\x9b\xb4\x0fy\xf8\x0e\xccb\xf9a\x9f\xd1\x05\xa3\xdd5\xcaI\xa4i\xc0 q^X\x82\xb6M\x9b\x06(\x04\xce\x8d\xce\x0dq\xbe\xb0\x0e\xbd.\x11\xda\xf6\x9a\xea\xf5\x8a\x97L\xbam\x1c\xf8P\xe0\xc0\x10\xbf \x7f\xa4\xfc\x9d\xd8\x1aw\xaf\x89u\x94^L\xee\x93\xf6\xa7=\xc8\x85\xd9k\xd6D\xe89/\xb5\xb7\x0b\x8c<K\n\xac\xd0\x17\xe8\x8d\xc5\x98\xccg\x89	\x9e)8Y9\xed\xb1\x03z\x9dn\x19\xc9$sh\xdbC\xe7\x84\xea\xa2q\"\x87\n$\xc3\xbb\xe3\xd0\xf4S\xf91\x90\xc1\xe1\xd6v3\xccuT\x93\xd1}6\xca\x86B\xccF>\xb2vn.\xbeh\xcfX\xdc\xeeTY;\xb6\xb2\x8eH\xfd\x8b!~\x07\x00\x00\xff\xffPK\x07\x08\x9c\xb0\xf0\xf8\xd7\x01\x00\x00p\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00J\x00	\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/keepalived.service.j2UT\x05\x00\x01\xf0b\xe7[\x9cSQ\x8b\x131\x10~\xcf\xaf\x98\x97\xd2\xa7\xe9\x8a \x82\xb0\x0f\x8aU\x8a\x88\xe5\xaa\xf8P\xcb\x91&\xb3uh6Y'\xb3\xf5J\xed\x7f\x97\xed\xed\xd9^[N\xb8}\xd9\xf9\xbe|\xf9\x12\xbe\x99\xcc\xbfE\xd6\x85yO\xd9	7\xca)\x96\x9f\x88\x1a\x1bxC\x1e\xaa$\xf0\xd9f%\x81\x98<e\xf3\xb6R\x92\xd2'\xb7&\x19e\x92\x0d;\x82\xdd\x00\xb8\x82\xe56\x92\xdeR\xb4\xcb@\xfe\xcf2\xa5\x00\x83\xfd\x81|\x10\xee\x06@\xd1s\x05\x83\xbd\xb9\xa1_-\x0b\xe53/\xf3\x84\x97\xf9n\xa3\xe6\xf2\x91\xa5\x99Z\xd1/\xd5\x19yz\x90\x99\xcf\xee\xd9\x85\xf9\xca5\xa5VgjEg\xe4\xca\x17\xe6\x86r\x07\xca\x14\xb1\xb2\x1cZ\xa1\x07\xaa[\x7fe\xc6w\xe4\x0e\xf2\xa9P\x89E\x9b\xa5Xr,\xee\xef\x0ck\x0e\x01\xd6\xff\xd2\xfa\x8fZ\xea\xab\xda\xf2B\xd7F@\x94\x1a\x10#\xe9\xef$\xeb\xf2g\xca\n\x88\x8d\xf0\x86\x03\xad\xc8\xc3\x0f\x03\x00\x80\x1b(H]q\xf4}s\x8e%\x9di\xf36\xbb\x14+^]\xec\xba\xbarm\xbfR\xed\xfb\x7f\xf1(\xf8\xd1\xd1\xe9)\xcd\xd1\x13\xa3\xad\xe9$\x96\x9eW\xef\x8a\xdd\x0e\\+BQok\x1b\xb9\xa2\xac\xf3\xa1\xa7\x86\xa2\xa7\xe8\x98\xf2p1\x1f\xba\x14UR\xc0&\xd8H\x1dq\xb4\xeaP\x167\\\xc0~\xdf\xdbv\xdf!\xed\xdc\xc5}r*\xa2OQ\xb1J\xb2\xee\xea\xb6n\xb0K\x02\x10CZueN\x81z\xe4I-\x87\x1eT\xd6q`\xdd\xc2k@\xdc\x884\x80\xd5yKN\xcaQ\xe7j\xfa\xde\xa7\xe6\xa2\xf5YS\x03\xa8\xf0\xf2tR\xfa\x81\xfc\x90\xc4\xd1\xf8\x8e\xbb\xf1\xd56\x97\xb3\xc9\xc7\xe9d:6f>\x89Ym\x08\x8b\xc3\xfb \xffn[\xd6mP\xc66\x93\x8c\xd4\xca\x8a\xf4YO\xf4o\x00\x00\x00\xff\xffPK\x07\x08\xa4\xb2\xbbS\xd1\x01\x00\x00\x1b\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x004\x00	\x00roles/kubernetes/security-policies/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x91\xd1j\xf30\x0c\x85\xef\xfd\x14&\xff\xb5\xff\\u\x8c\xbc\x8cq\x15\xb5\x11qlOR\n\xa1\xf4\xddG\x9cl][\x18\xec\xce\xfat\xce\xf1\xc1\x1e\xe7#\xfaP\xc8\x0b\xf2\x05\xd9\xc7\x0c!v\xb6\x19T\x8btm[\xe7!\x8bv\xd7\xab\xbd\x8b%\xfa\x92Y\xed\xed\xd6\x98\x17\xda\xd9\xe6\xedpxo\x8cI\xa8\xbe\xe4H\xb0\xf8)$:\xa1\xa8t\xc6Zg\x8f\x01FL\xbd\xdb\xb6\x15\xf5x\nsT\xd7c\xdaA\x92\x9f\xfb\x13\xe7\xa4O\x9e\x01C\x11E\xbe\xb3\x7f\xae\xd6t\xa2A\xd1M\xa8L\xf0\x1dc\x8a\x94\xe7&\x85\xe9B\x11\xcf\xd8\xd7\x91QV\x8bbo\x0c\x1f\x03\xfcA^\x982\x93.\x1eb\x10y6n\xad\x16Q\x9c\x1c0)A\x88/\x8b\x81\xceC\x85\x80ex\x94\xed\xcfc\x0c\xa3\xe4\x99\x01\xfd\xc7\x9c5\xfcr\xcdV0\xe7\xd1\xadi\x8f\xd3\x97\xc4\x00\xb2\xfa\x9e\xb8\xb3-*\xb4e\xa4\x8d\xd5\xb8\xd0O\x94\xd6\x83\xb5#.\xdd\x0e\xfe\x8f\xb8T\x06\xeb_\xef\x0cX\xcdg\x00\x00\x00\xff\xffPK\x07\x08Sd\xb5!\x0d\x01\x00\x00M\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/backend-policy.yamlUT\x05\x00\x01\xf0b\xe7[l\x8dAn\xc3 \x10E\xf7\x9cb.`W\xddU\x9c\xa1\xaa*U\xea~\x0cc\x17\x013\x88\xa1\x89|\xfb\xc8\"8\x8b\xe4\xaf\xf8<\xcd\xfb1\xb0\xb7\xf0E\xed*5~K\nn7X\xc2/U\x0d\xc2\x16\xb8\x93\xc0\xdb\x1c?t\x0e\xf2vy7\x99\x1azlh\x0d\x00c&-\xe8\xc8B\xfc_h\xd2]\x1b\xe5;\xb0\xb0\xa0\x8b\xc4~*]\xad\x85\xdcqU\xc4\xffP\"\xd7\xa4\x1e\x15 cs\x7f\x9f\xb8P\xd2\xfe\x01P%=\x04\x06 \xf0VIO<\xc1Z%\x8fv\xf4'\xe9\xc8\x0b\xf9H\x1fY\xabp\xeb+#\xe7\xd3\xdc\x02\x00\x00\xff\xffPK\x07\x08\xac\xaf\x17\xec\xa5\x00\x00\x00#\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00[\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/default-deny.yamlUT\x05\x00\x01\xf0b\xe7[,\xcbA\n\xc2@\x0cF\xe1\xfd\x9c\"\x17h\xc5\x9d\xcc!D\x10\xdc\xc7\x99_	\xd3&C\x93*E\xbc\xbb\x88n\xdf\xe3k\xa25\xd3\x11\xf1\xb4\xa5\x9dl\x92\xb2%\xeer\xc1\xe2b\x9aI\x7fG\xf4>\xb6\x83\x8fb\xbb\xc7>\xcd\x08\xae\x1c\x9c\x13\x91\xf2\x8cL\x157^\xa7\x18*t\xfbG\xef\\\x90\xa9\xadW\x0c\xbey`N\xdeQ\xbe\xa6[=cB	[2\xbd\xde\xe9\x13\x00\x00\xff\xffPK\x07\x08\xa9\x91\x81xu\x00\x00\x00\x85\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Y\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/dns-policy.yamlUT\x05\x00\x01\xf0b\xe7[,\xcbA\xaa\x021\x0c\xc6\xf1}O\x91\x0b\xcc<\xdeNz\x06\x11Ap\x9fi\xc3\x18\xda\xa6\xa1\xa9\xca \xde]\xc6\xba\xfc\xbe?\xbf\xc4\x12=\x9c\xa8?kK\xe7\x9a9l\x0e\x95\xaf\xd4\x8c\xabx\x90QX\xd69\x1dl\xe6\xfa\xf7\xf8w\x85:F\xec\xe8\x1d\x80`!\x0fQl\xd2\xa1\xc7e\x8a\x81<\xa4\xfbB\x93m\xd6\xa98S\n\xbb\xd0\x1a/\x94)\xf4\xda\xf6	P\xb0\x87\xdb\x11\x17\xca6\x0e\x00T\xfd\xe1(\xe6\x00X\xd6F\xf6\xcd\x13\xbc\xde\xee\x13\x00\x00\xff\xffPK\x07\x08&\xe1_0\x8c\x00\x00\x00\xb7\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/frontend-policy.yamlUT\x05\x00\x01\xf0b\xe7[l\x8f1k\xc30\x10\x85w\xff\x8a\x83\xceN\xcfuRdm\xddK)\x14\xba\x94\x0e\x8a|N\x84e\x9d\x90\xae\x0d\xf9\xf7%\xd8\xa4\xc1\xca\xa8\xef\xbe\xf7x\x1a]\xe85\xbc\x91\x9c8\x8d\xef\xec\x9d=W&\xbaOJ\xd9q\xd0\x10\xe6\x8b\x0b\x87\xcd\xa8\xf2\xc6\xf1\xe3oSM$\xa67bt\x05\x10\xccD9\x1aK\x1a\xc6\x9f=\xd5\xf9\x9c\x85\xa6\xe5\xa0aH\x1c\x84B_\xc7\xb9;G\xb2\x97X\xe4\xfe\x83<Y\xe1ty\x02LF\xec\xf1\xd5\xec\xc9\xe7\x19\x00$\xf67\x0dP\x01\xb8pH\x94\x17\xa1\x86\xc8I\xae\xf6\x03\xbcx\xcf'8\x8aD\x90d\x86\xc1Y\x10\xfe\xcfG\xee\xf3\xe2\xceQ\x0d\nW`\xbbmW\xa4E,\xac\x16Ko\x87Xj\x05\xda=cSl(\xfa\x15\xaa;\xd6S\x89\xd6#:T]\x81\xbau}\x87\xdd:\xa8\xee|\xa8\xb9ZC\xe2I\xc3\xd7w\xf5\x17\x00\x00\xff\xffPK\x07\x08\x93v\x94\xe0\xed\x00\x00\x00.\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/heapster-policy.yamlUT\x05\x00\x01\xf0b\xe7[\x9c\x91\xcfJ\x031\x10\xc6\xef\xfb\x14C{N\xadzY\x02\xbe\x81\x88 x\x9fM\xa6\xdd\xb0\x9b?\xccL\x95\xbe\xbd\xa4i\xad\xb6(hN\x99\xf9\xf2\xfd\xf213\x85\xe4-<\x91\xbeg\x9e\x9e\xf3\x1c\xdc\xbe\xc3\x12^\x89%\xe4d!5%\xa4\xedj\xeae\x15\xf2\xcd\xdbm\x17I\xd1\xa3\xa2\xed\x00\x12F\x92\x82\x8e,L\xbb\x81\x8c\xecE)\x1e\x05\x0b#a\x11%6\xa5\xb1\xa5\x90\xab\xb6\x92\xfd\x0b\xcd\xe44s-\x01\"\xaa\x1b\x1fq\xa0YZ\x03\x00K9\x03:\x80\x90\xb6Lr\x90\x0dl8\xc7\xf6\xd0\x9c3|G\xfe\x80\xad\xe7D\xb5'\xe8\x91t\x15\xeb\x17\xc6e\xbc\xbf\xfa?G\x13I98\xb1@	\x87\x99\xfc?X\x87,u\xfe\x9cHI\x8cG\x19\x87\x8c\xec\xbbeC\xb1\x8a\xadw\x80%\xec\x84<l2\xc3\xa2:\x9c\xce\xa0\xb9\xd4\xef\x04\x8c\xf9\xb20\xd6\x87~\xbdh\xae\xc6\xb0\xd0\xaf/\xeb\xfe\xee\xaas\xdf}\x04\x00\x00\xff\xffPK\x07\x08\x1d\xcf\xca\xf8\xfb\x00\x00\x00W\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00h\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/kube-state-metrics-policy.yamlUT\x05\x00\x01\xf0b\xe7[\x94\xce]JC1\x10\x05\xe0\xf7\xacb6\x90\x8ao\x925\x88\x08\x82\xefss\x8fu\xc8/\x99Q\xe9\xee\xe5\x9a\x16\x91\xb6B\xf3\x96\x99\xe4;'I]\x03=\xc1\xbe\xdaH\xcf-K<8\xee\xf2\x8a\xa1\xd2j\xa0:7R\xf7\xbb\xf4\xa0;iw\x9f\xf7\xae\xc0xe\xe3\xe0\x88*\x17h\xe7\x88@\xe9c\x81\xd7\x83\x1a\xcaqq\x9a\x19\x1b|\x81\x0d\x89\xea\xfbL\xd1\x8e\xb8\x01\xbd\xad/\xc8\x88\xd6\xc6v%*l\xf1\xfd\x91\x17d\x9d\x03\"\xee\xfd\x12\xe5\x88\xa4\xee\x07\xf4\xe7\xa1\xa7\xb7\xd1\xca\xfc\xe2\x7f{\xfd\xc5\xaf\x04l\xe7\xdc\x0f'\xfeh\x9eU\xfdG\xbb^\xf9V\xe9R/T^2V\xf7\x1d\x00\x00\xff\xffPK\x07\x08\x83\xa9\xf3\xad\xb6\x00\x00\x00\xbf\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00c\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/ceph-critical.yamlUT\x05\x00\x01\xf0b\xe7[\xcc\x90?n\xeb0\x0c\xc6w\x9d\xe2\xbb\x80\xfd^\x87\x02\xad\xd7\xf4\x00\x1d\x8a\xee\x8c\xc4\xd8Dh\xc9\x10\xe9\x14\x01z\xf8\xc2\xae3\x14\xdd\n\x0f\xd1\xa0A\"\xbf??\x9a\xe4\x9d\xabI\xc9\x1d,\x0e\x9cf\x95\xdc\xb7\xe7'k\xa5\xfc\xbb<\x84\xb3\xe4\xd4\xe1\xb5J\xa9\xe2\xd7\x83\x92Y\x18\xd9)\x91S\x17\x80L#w\x88<\x0dM\xac\xe2\x12I\xc3\x85t\xe6\x0e\xcf\xff\xb7\x13z-G\xd2\x17>\xd1\xac\xde\xe1Dj\x1c\x12[\xac2\xf9\xea\xfd\x19\x80\xc3\xb6\x8f\xa9$\x83\x0f\xe4\xa0\xca\xc8\xc5\x11K6I\\9\xc1\xae\xe6<B\xf9\xc2\n\xca	\x95\x97/H\x86\x0f\x8cZ\xca\xb9Y\xe3l\x83K@\x9b(r\x1b\x80\xb7A\x0c\x1f\xa2\n\xf3\xe5\xee\xd9\xe1u\x1e'N8^W\x81\x1f\xfa\xb7J\x88Kq\xb664M\x13\xf6\x84\xf6mw\xa7\xec\xd6\x91\xdb\xe3\xdd\x93\xf4R\xa9\xe7\xdf(\x1f\xff\x88r\x13\xdc\x07\xe9\xde\xf4\xbe\x02\x00\x00\xff\xffPK\x07\x08\xb9d\xfd\xfa\xf8\x00\x00\x00\xb9\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/default.yamlUT\x05\x00\x01\xf0b\xe7[T\x8eMN\xc40\x0cF\xf79\xc5w\x82\xf2\xb3B\xd9\xc2\x01f\xc5\xdemLk\x8d'\x8ebwFH\x1c\x1e\x11\x8aFx\xfbY\xef=j\xf2\xce\xdd\xc5j\x86/\x1b\x97]\xa5\xae\xd3\xf9\xc5'\xb1\x87\xebS:K-\x19\xa7.\xd6%>_\x95\xdc\xd3\x85\x83\n\x05\xe5\x04\x00\x95.\x9cQ\xf8\x83v\x8dt%\xdd9\xe3\xf9\xf1\xb8\xb4\xaa\xcd\xa4o\xbfsF\xf4\x9dSa_\xba\xb4\x18\xda\xafA9YqT\x0b\x90\xbb\xac\x95\x0b\xe8\xbf\x157Q\xc5\xcc(\xddZ\xe3\x02\xa9a\x88\x8d\xff\xd4X\x8e\xb7\xd80*\xee\x11\xd3]1f\xb5\x1bw\xb4\x03\x0f\xea\x0c\x8f\x1f|3w\x99\x95\xd3w\x00\x00\x00\xff\xffPK\x07\x08\xc6Wi\xeb\xb4\x00\x00\x00\x18\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00j\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-critical.yamlUT\x05\x00\x01\xf0b\xe7[T\x8f1N\xc4@\x0cE\xfb9\xc5\xbf\xc0\x06\xb6C\xd3.\x07\xa0@\xf4\xce\x8c7\xb1\xe2\xccDc'(\x12\x87G	\xac\x04.\xdcX\xfe\xff=Z\xe4\x83\x9bI-\x11\x96F\xce\xabJ\x19\xba\xe9\xc5:\xa9O\xdb5LRr\xc4[\x93\xda\xc4\xf7\x9b\x92Y\x98\xd9)\x93S\x0c@\xa1\x99#\xa6\xb5\xe7\x8b\xed\xe6<_R\x13\x97D\x1a6\xd2\x95#\xae\xcf\x8f	\x83\xd6\x9e\xf4\x95\xef\xb4\xaaG\xdcI\x8dCfKM\x16?\x19\xbe\x02p\xfb\x0d\xc0R\xb3\xc1GrPc\x94\xeaH\xb5\x98dn\x9c\xf1\xd3\x06\xe5\x8d\x15T2\x1a\x1f'H\x81\x8f\xfc\x97\xe8d\xb4\x85\x12w\x01x\x1f\xc5\xf0)\xaa0?\xf6\xc0\x0eo\xeb\xbcpF\xbf\x9f\xbf\xff\xa2\x1f:H\x87;[\x17\xbe\x03\x00\x00\xff\xffPK\x07\x08y\x84\x11Z\xcd\x00\x00\x004\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00f\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-high.yamlUT\x05\x00\x01\xf0b\xe7[d\x90\xb1N\x031\x0c\x86\xf7<\xc5\xff\x02=`\xab\xb2\xc2\xc0\xc8\x80\xd8\xdd\xc4\xbdX\xf5%Q\xec+\xaa\xc4\xc3\xa3;:T\xc2\x83\x17\xcb\xff\xf7\xe9\xa7._<LZ\x8d\xb0T8\xaf*u\x9e.G\x9b\xa4=]_\xc2Ej\x8e\xf8\x18\xd2\x86\xf8\xedU\xc9,,\xec\x94\xc9)\x06\xa0\xd2\xc2\x11\x97\xf5\xc4\x07\xbb\x99\xf3r(2\x97p%]9\xe2\xf8|\x9f0k;\x91\xbe\xf1\x99V\xf5\x883\xa9q\xc8liH\xf7\x1d\xff\x13\x80w\x99\x0b\xfa\x1d\x86\xde\xb2\xc1\x0b9h0js\xa4VM2\x0f\xce\xf8\xa3A\xf9\xca\n\xaa\x19\x83\xb7\x13\xa4\xc2\x0b?\x1a\xed\x8e\xd6)\xf1\x14\x80\xcf\"\x86oQ\x85\xf9\xb6gv\xf8X\x97\xce\x19\xa7\xdb\xfe\xfb/\xfa1,\x0dqI\xa4H[\x17lS\xf8\x0d\x00\x00\xff\xffPK\x07\x08\x10\xae\x8f\xb7\xd4\x00\x00\x00D\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/privileged.yamlUT\x05\x00\x01\xf0b\xe7[t\x8eMk#1\x0c\x86\xef\xf3+t\x0b,\xecd\xc3\x92=\xf8\x16\xb2\xa5\x04J\x18Z\xda\xbb2\xa34\"\xb6e,9\x1f\xff\xbex\x9a\xa6\xa4\x90\xa3^=z^a\xe27\xca\xca\x12\x1d$\xf1\xdc\x9f\xa7\x87\xd9\x86\x0cg\xcd\x9e\xe3\xe0\xa0\x93\xe1\x85\xfa\x92\xd9\xce\xdd\xb8o\x02\x19\x0eh\xe8\x1a\x80\x88\x81\x1c\xa4\xcc\x07\xf6\xf4NC\x03\x801\x8a\xa1\xb1D\xad\x04\x80R\xdfKH\xad^4-\xfa\xb4\xc3v_6\x94#\x19i\xcb2E\xef\xe5HC\x97e\xcb\x9e\xd6\x18H\x1dL~M\x1aM\xd4W\xcfw\x87\x03\xcb\x85jS\xbd\xe9\xbe\xf2\x07\xed\xd1\x8f\xbd\xb7\x00\x0dKL\xb8a\xcf\xc6ty\xe9\xf7\xa8\x068\x88/\xe1g\xb8\x13\xb55\xd9Q\xf2\xfej\xaaY'\xd9\xaeh\xe0\xe8\xe0\xcf8\x00\x04<9\xf87\x9f\xff\x9d_\xd0U\xb7\xbc=]\xfd\xbf\xce\xb9\xc4\x85\xbe*\xe5OU.\x9e\x1cL\x9ek\xba\x88\xe7\xfa\x80\xd2\x13\xc7r\xba\xbf/)y\n\x14\x0d\xfdc\x96\x92\xf4.\xba\xd5\x11\xb8\xb3\xff\x08\x00\x00\xff\xffPK\x07\x08\x94\x901G\x11\x01\x00\x00\xfd\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/restricted.yamlUT\x05\x00\x01\xf0b\xe7[\xbc\x94Ao#7\x0c\x85\xef\xfe\x15\x04\xf6\xe0C\x1b\x07A\x91\x1e\xe6f$\xbbE\x80ljd\xdb\xbd\xd3#\xda\xa3FC\xaa$e\xaf\xff}!yl'\xdb\x1e\x02\x14\xd8\xa38\xd2\xf7\x9e\xf8\xa8\xc1\x1c\xbf\x92Z\x14\xee K\x8a\xfd\xe1zw\xb3&\xc7\x9b\xd9K\xe4\xd0\xc1J\xc2\x17\xea\x8bF?\xac\xda\xf7\xd9H\x8e\x01\x1d\xbb\x19\x00\xe3H\x1d(\x99k\xec\x9d\xc2\x0c\x00\x99\xc5\xd1\xa3\xb0\xd5\x1d\x00F}/c^\xd8\x84Y`\xca\x03.^\xca\x9a\x94\xc9\xc9\x16Q\xae1%\xd9SX\xa9lb\xa2'\x1c\xc9:\x98\x07\xe9_H\xaf\x03m\xb0$\x9f7\x1c\xe6\x8c:\x8a^x\xd5\xee\xbbpZ\xd8\xe3Hoy\xef\xb27\x9dx\xc5\xeb\xe0\x7f\xd8\xfbo\xdc\xbf\xecY\xa6\xbe\xf60k\xdc\xc5D[\n\x1dl0\x19\xcd\x00>\xc03\xfd]\xa2R\x00\x17\xc8J;b\x07\xb2\x1e\xd3\xb1\xf9\xb5\xac\"\xbe\xa8\x99\xd4v\xacN\x94\x8f\xe7M\xafq\x7f\x0c\xd1 \x1a(\x85\xc2\x01\xd9a\x1f}\x00\x16\xbe\xaa\x18\xf8	B\xb4\x06\xba\xf8y\xa5\xf7s\x83\xac\x8b\xc3\x9e\xa0G\x86\xac\xb2\x8b\x81 :lD!\xd0\x86\xd8\x08\"C\xa0\xecC\xb5\xa5\xd3\x0d\xeeU\xf2\x1df\\\xc7\x14=\xd247W\xb0||l\xd4eS\xedE	v\x92\xcaH\xe0\x87LV\x11\xc7\xf5\xf9\xc4\xbc\x17\xde\xc4\xedg\xcc\xf3S\x85\xc6\xec\x87\xfb\xa8\xe7BV\xf9\x8b\xea\xb0\x9e+F\xbd\x92\x9f\x97A\xf6\xbcG\x0d\xcb\xd5\xc3\xb1\xf6\x01\x96fMv@\x87\\\xdf\x8b9\xb1\x7f=\x8a\x83\x91C\xc9\xb0>\x80\x0f\x04}*\xe6\xa4\x80a\x8c\x0c\xa8\x04\x86\x1b\xaaq\x14\xa3\xc5\xd9\xc5w\x94\xbb\x84q\xacr\x83\x98?\x91\xefE_.\xf1\xd4\xe2\xc3\xea\xeema\xf5p\x7f)h\xe1\xa5\xfdi\xa4\xdddy\x1a\x8f\xa3%a\xc7\xc8\xa4m(\n\xb7h\xa5x\x1b\x90K\x9cvt\xa7%Q\x07\xf3\xcf\xc5\xfc\xb9R\x9f\x84\x9fEZ\x7f\x8c\x1e#\x97o'\x8d63\xc7\xdf\x06`k\x915=\x96@\xd6\xae^,\xf2\x16\x969/\xeb\xa3\x00E\x1f\xaa\x8b\x01\x19\xbe|l\xac7\x9aMo\xc9\x87\xa6UrN4\x12;\xa6\xdfTJ\x9eR\xfe\xde\xde1#E\xde\x9e\xe6\xa0Z\xfb$\xba\x8e\x010\x84j\xa0\x9ajw\xddV\xd0b\xdau\x05c\xe4\x0en\xa6%\xc0\x88\xdf:\xf8\xf5\xf6\xf6\x97\xdb\x19\xc0\xc6\x9a\xea\x8f\x15U\xc2\xf0;\xa7Cm\xf8\xa7\x98\xc8\x0e\xe64\x9eb\xfe'\x00\x00\xff\xffPK\x07\x08\xc5_\x81\xb6z\x02\x00\x00\xae\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/privileged.yamlUT\x05\x00\x01\xf0b\xe7[\xac\x92AO\xc30\x0c\x85\xef\xfd\x15\xfe\x03\x1d\xda\x0d\xf5\x06\x1c\xb8 \x0eCpO\x93\xb7a\x96&\x91\x9dT\x1a\xbf\x1e)]\xa7\x15\x8d!$\x8e\xb6\x9f_\x9cO\xcf$~\x83(\xc7\xd0\xd1\xb8n\xf6\x1c\\GO\xac\xb9\xe1\x8cA\xbb\x86\xa8\xa5s\x91\xf4\xc6\xaeL\xc9\xefQ\xf8\xd3d\x8ea\xb5\xbf\xd5\x15\xc7\x9bq\xdd\x10\x11M\x16\x0f\xbeh\x86l\xa2G\xed\x0e\xc8\xc6\x99l\xbaZ\x11\x053\xa0\xa3$<\xb2\xc7\x0e\xaeM\x9a\xda\xa2\x90:\x97\xe2\xa1\x93\xb4\xbe\xfe(\xb1$\x9dw[J\xd1\xb3=\x1cK\x81\xc6\"\x16\x8b\xb9S\xd8\"\x9c\x0fU\xca\xd0o\xe2g3,\x16N\x87\x1c[#\xa4?\x9b\x17E\xf3\x0f(\xee98\x0e\xbb\x8bD\xae0\x99\x8e\xd7\xd2\x7f\xc0\xe6\x13\x97\xc9\xbd\xa29Z\xcc\xa4\xae\x9c\xf6\x1b~\xfd\xd1|Z1n\xe0\xb0\xd0\xbc@F\xb6\xb8\xb36\x96\x90\x17b\x87\xad)\xfe\xbc\xa7\xc9Xt\xb4/=Z=h\xc6\xb0\xf0z\x9d\x030;T\xa1I\xac\x90q\xceF\xf4\xd8`\xdb\xfd\xf9\xcf\x97\x83y-\x8a_\x01\x00\x00\xff\xffPK\x07\x08\xb8\x81\xa92\x1a\x01\x00\x00\x1d\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/restricted.yamlUT\x05\x00\x01\xf0b\xe7[\xacR\xbbN\x031\x10\xec\xfd\x15\xfb\x03w(\x1dr	\x05\x0d\xa2HA\xef\xb3\x97\xb0\xc4g[\xbb\xeb\x93\xc2\xd7#\xf9.\xe1x(\x10)\xa5wfg\xc6\xa3u\x85\x9e\x91\x85r\xb20m\xcc\x9eR\xb0\xf0H\xa2\x86\x14G\xb1\x06\xa0\x835\x89\x07\xe7{W\xf553\xbd;\xa5\x9c\xfa\xfd\xad\xf4\x94o\xa6\x8d\x01\x00\x98%\xeec\x15E\xde\xe6\x88m:\xa2\xba\xe0\xd4\xd9\xf6\x02HnD\x0b\x8c\xa2L^1tEJW\x05\xb9\xe1\\#\xcaLm\xee\x0f\x9ck\x91\xe3n\x07%G\xf2\x87\xe5\xc9(\xb9\xb2\xc7/x\x10\xf4\x95I\x0f\x8dJ(\xdf\xc8On\\/|\x06YF\x13\xf2\xb0\xc2\xab\xa0\xb9B\x15w\x94\x02\xa5\xdde\x8d\xcc\xd1\xa5\x0eo\xe8u\x95iVo\xd5,38uu&\xdc\x89\xfb\x87\xe1\xf5,\n\xd3D\x11w?,8G\xdc\xe2\xcb\xf1K\xffW\xfe\xfd\xc6\xce]\xd5G\x00\x00\x00\xff\xffPK\x07\x08n\x8d\x7fZ\xf0\x00\x00\x00\xe8\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00a\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/kube-system.yamlUT\x05\x00\x01\xf0b\xe7[\x84\x8f\xbdj\x041\x0c\x84{?\x85\xd8\xdeEZ\xb7!E\x9a\x90\x1fH\xafh\x87\xac9\xafm$y\xc9\xbd}\xd8\xbb\x0d\x04\x12\xb8v>i>\x86{~\x87Zn5\xd1v\x17N\xb9\xce\x89^am\xa8\xe0e4\xe7\xb0\xc2yf\xe7\x14\x88*\xafH$e\x98C\xa3A\xb7,\xb0\x03XgA\xa2\xd3\xf8@\xb4\xb39\xd6`\x1d\xb2\xff\x99\xb4\x8e7\x14\x887\xdd\x03\xa2\x95]\x96\x87\xaf\xae\xb0]o\xd74R\xebP\xf6\xa6\x94\xe8\xb1^\xb2\xe3\xfd\xe9\xe2~\xd6\xdc4\xfb\xf9\xbe\xb0\xd9\x817.\x03G\xc1^1]\xed\xb1\xb6\x19Q4{\x16.\xd3\x1f\xfc3\xe3\x9f\x8b_\x1bn\xe1%\x7f.S\xf8\x0e\x00\x00\xff\xffPK\x07\x08\x9a2\x8aI\xb8\x00\x00\x00H\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00f\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph-system.yamlUT\x05\x00\x01\xf0b\xe7[l\x8f\xbdn\xc30\x0c\x84w=\x05\x91]CW\xafE\x87.A\x7f\x80\xee\x04s@\x84H\xa2@\xd2F\xf3\xf6\x85\x1d\x17\x1d\xea\xf5;\xf2;\x92G\xf9\x82y\xd1>\xd1\xf2\x94n\xa5_&:s\x83\x0f\x16\xa4\x86\xe0\x0b\x07O\x89\xa8s\xc3D\xa6z\xcb\x82q\xcd~\xf7@K9\xe7th\xf9\x80\xebl\x82\xf7Y\x83\x0fLRg\x0fXv\xd8R\x04\xbe\x07[\xf1A\x8f\x0f\xc8\xba\xec\xa2\x03\x9f\xa8\x90P[\x01Q\xe3\x90\xeb\xcb\xf70\xf8z\x83?h&\x1d0\x0e5\x9a\xe8\xb5ol_?o\x07\xbcYQ+q\x7f\xae\xec\xbe\xc7\x0b\xd7\x19\xbb`U\x9c\x1e\xed\xb9\xeb\x05Y\xacD\x11\xae\xa7\x7f\xf1\xef/\x07\x13\xdb\x0f\x7f<\xfd\x04\x00\x00\xff\xffPK\x07\x08\xa1\xaa,}\xc1\x00\x00\x00r\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00_\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph.yamlUT\x05\x00\x01\xf0b\xe7[l\x8f\xbdj\x041\x0c\x84\xfb}\nq\xbd\x8b\xb4\xdb\x86\x14i\x8e\xfc@z\xa1\x1b8s\xb6e$\xed\x92{\xfb\xe0\xcd\x06\x0e\xb2\xed7\x9e\xf9,\xee\xf9\x0b\xe6Y\xdbL\xeb\xd3t\xcb\xed2\xd3\x99+\xbc\xb3`\xaa\x08\xbep\xf0<\x115\xae\x98\xc9ToI\xd0\xafSJi:\xac\x7f\xc0u1\xc1\xfb\xa2\xc1\x07\x13R\x16\x0fXr\xd8\x9a\x05\xbe\x07\x9b\xf1Q\xe0\x1d2Z.\xda\xf1\x89\x02	\xb5\x01\x88*\x87\\_\xbe\xbb\xc1\x87\xdc\x7fi\"\xed0\x0e5\x9a\xe9\xb5ml\xaf\x9f7\xf3\x9be\xb5\x1c\xf7\xe7\xc2\xee{\xbcrY\xb0\x0f\x8c\x89\x93\xdf=PS\xd3\x0b\x92X\x8e,\\N\xff\xe2\xbf#\x0e^\x8c\xcf?\xf0\x9f\x00\x00\x00\xff\xffPK\x07\x08\"\xf4\x0d\xcc\xbe\x00\x00\x00c\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x001\x00	\x00roles/kubernetes/security-policies/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xc4XM\x8f\xdb6\x10\xbd\xfbW\xbc:X\xb8=\xf8#@\x9a\x83\x90-\x90,\xd2\xa2\x08\x90\x14E{J\x03\x81\xa6\xc6\x16k\x9ad9\x947\xae\xe3\xff^\x90\x92-{\xab\xf5Gr\xd8\xcb\xaeE\xcd\x1b\xce{\x1c\xce\x90\x1a\xc2\x88%ex\xb5\xa8\xa6\xe4\x0d\x05\xe21\x93\xac\xbc\n\xeb\xa1\xb3ZIE\xfcS\x0f(hZ\xcd\xb3\x1e\x00,y\x9e\xe17\xafL@\x10\xf33P\xcc\xac\xc7\xbbjJ\xefT\xc0\x9d535\xaf\xbc\x08\xd6\xc3	\xcf\xe4{\xbd]\x08w%\xc9\x05\x84\xd6(-\x07\x86\xf0\x04\x0eJk\x88\x95PZL5\xf5\x00e\xa4\xae\n\xca\xbd\xd5T\x87S\xa3\x9d'\x19\x1d\xa4\xa1 x\xc1\xf9\xcc\xdbe\x16\x1d\xe6\xc9a.\xb4ZQ\x9co\xaa\xad\\d\xbd\x1e\xf0\x0c\xef?\xfc\xf16C(	\x95WX\xda\xa2\xd2\x84\xc2\x12\x9bA\x80\xa6\x80\x8a\xe1\x04s2\xb9{\x0dI>\xa8\x99\x92\"\x10\xeeU(m\x15\xf0w\xb5t\xca\xcc\x11Jo\xaby\x89\xa5\xf5\x84\xd2Z\xc7=`G\xef^\xa8\x80\xca\x04\xa5\x11\x05\x83p\x8a\xc9\xaf\xc8C\xf1\x11C\x80K\xd2:\xc3\x97\xf4\x00\xc8\xcak\x0c\x19\x9b\x1b\xa8\x19\xe6\xdeV\x8e?\x0e\x96\x82\x03\xf9\xc1\xa7/\x9a\xcc<\x94\xb8\xbd\xc5s\x08S`\xf7\x06\xca\xd4\xc6y\x0c\x80q\xb3-Cp\x9c\x8d\xc7\xdaJ\xa1\xa3&\xd9f\x93\x82\xc9\x85S9\xb3\xce\x9d\xf5\x01\xdb\xed\xe6\x06\xa4\xd5\x0c\xdf+\xceK\x91K]E\x8f\xf8\x82\xa9\xb5\xfa\x07\xdclw\xb8\x95r5\xb6f\xd2\"\xa5\xb6U\x91;oW\xaa \x8f\xefn1\x10\xf7<8\x08b\xb3I\xeb\xbc\x12~Of2\x99\x0c>}\x14E\xe1\x899WfE&X\xbf\xceg\x8at\xf1	\xdb\xed\xc9x\x99.\xf1>p^\xadD\xa0\\\xb9\xc19\x97\xa6P3\xdcl\xc7%	\x1d\xca\x7f\xf1W\xb3 \xc0p(E\xcc\x04l6)#\xf2BE\xf6qZom\xc8\xa5\xc8\xa5\x0f\xf9Li\x8a\xe2c\xbb=\x06wC\xe3#\x8fD\xb1Tf$S\x14G\xa8\x05\xad\xcf\x80\xa2\xc5v\x9b \x9e\x82W\xc4\x19^Nb\x9aG\x8a1\x9b\xef\xe3~\nbA`\xbb$\x04\x15\xffXh+\x8a\x94\xe1\x1cDP\x12\xce\x16X\n\xa3f\xc4\x81\x93\xbf\x82\xb4Xgx>i\xbc\xcfU\xd44k\xd38orXi\x15\xd6\xc9(%{\x86\xfe\xc0.R2v\x9b\x8e8\x14\xb6\n\xfd\x04y\x06!\x83\xb2\x06\xa5`\x18\x1bw\xf5J\xd9\x8aS\\)Ri\x97Nx\x1a\x81K[\xe9\x02S\xaa\xb7\xe7L(=J>d)\xcc\x9c\x8a\xfc\xbe$\x93\xc1\xd8\xde\xc1&\x14\xccQ\xfa1\xfb\xd5\xf8\xa0l\xed\xa9n6P\x81\x96Qy\xfa\xacv\xdc\xe32f\xcdB8\x11\xca\xecq\x07\xe3\xd6C\x03H\x91g(\x94'\x19\xb39\x0d\xc7\xca\x91G;\xde\xf9\x1d\xc2O\x85\xdc?\x18\n\xf7\xd6/\xf6\xcf\x8e]\xfb\xdb+\x9b\x8a\xac\xd4\x82\xb9\xc5\x13\xdb\xcaK\x1a\xfeS\xd9 \x0eYK\xeb\xd6\xc9}\xbb\xa6Q\xca\xc7I\xecC\x89\xc8]\x84\xece\x86\xfeI\xcc\x01\xfb\xd1Z,u\xbf\x81\x16\xc4!C\xff\xf4\x84\xdd\xe0\x03\xa5\xd0\x8f\xdbk*d\xde\xd2\xd8n\xfb\xffc\xea\xd8\xa5%;Cr'\xe9\x15\x1c\x1d\xbb\xd3\x14O\xce\xf6\x10\xda\xc9\xcf\xb1;C\xafI\x0d\xa4\x16\xbb\xbe\x84\xe9a2]\xc1\xb6\x81}-\xe3G\xe0\x9d\xac\x0d\x85\xbc\xe6snm\x9b\xdcG\xca\xfdK\xc8w\xec\x96+48F\x7f\xad\x14\xa7\xbdt\xe7A\x03\xc9\x13\xe4\x8c*\xbb\xad\x8f\xb4\xf5/Q\xe5A\xb1\xb8R\x95c\xf4\xd7\xaar\xdaK\xf7\xeeo y\x82t\xa9\xd2\x9c\xed\x90\x9a\x89\xb1\xa0\xd9,\x1eDM\xc0\xbdX\xa7\x06\x92\x8e\x99\xa1$\xa6t6\x1d4\xfdbPW\xeaQ/a\xf7\x0d\xc39\xbd\xc6\xefo^\xdf=\xe8\x87\xfbSZ\xbf\xb0rA\x1e\xf4\x99\xe4\xbe\xcd\xc6\xff2\xe8\xd8\xb4\xe3\xaft\xec\xbd\x1d\xaf\x84\x1fk5\x1d7V\xe3\xf6]3\xcfpv\xa6(?&\xf5\x05U\xb26<l\xdcN\xaf\xf3\x83\xa6\xd3\xb4\xebv|\xe4e<UN\xf6\xd0\xe6D\xf1|\xb2_\xe0t&\xf8\xb1K\xb2X\x84\x9fZ\xb1\x13\xe5\xfa|\xd9\xed\xd6\xab\xed\xc3Gr9v\xdf\xa4\xd6\x83\x9a\xfe\xd4\xc2\x9d\xa9\xfc\x17W\xefn\x0d\x0d\x05gu\x97\x8c\xf5\x9boR\xf2A\x83xj%/\xeb\x1fW\x15\xffnQ]\xe76v\xdf\xb6\x89\x1f\xf4\x95\xa7\x16\xf3\xb2\xb6sU\xcf\xe8\x16\xf3\x18\xd2Y\x1f\x8f,\xae\x14\xb9\xbe\x99\xb4\xf7\xdbxSM\xd7\xc4\xdb[\x1c\\V\xa3qm\xda\x7f\xe4N\xdfo\xbf\x9e\xbc.\n\xbc\xfb\xf3\xcd\xdb\xbb\x0f\xef\x7f\xfe\xf5\x97\xd4\xfc)\xc8\xb1\xf36\x9e\x06F\xe9\xca\xdf\x03\xb42\xa4L{\xa1i\xae3]\xa6\xb5q\x86\x01}Nw\xe2\xd6\xfb\xa9u\x1c$ds\xe9q\x9e\x98LHC\xd2S\x1a[S\x9d>K[P\x86\xc9\xcb\x17/Z\x12\xa2(P1\xf9\x18~tYs\xed!\x0d\x1e~\xed\x89\xeb*\x0c\xab\xa9\xa6<\x01\xf6\x8b)\x9c#S\xb4\xd3\xd4\x9fK\xb2\xe4\xae\x9d\xe8\xd5a\x82]\xfb\xbd\xeb4\xf6\xec\x07\xaf\xff\x02\x00\x00\xff\xffPK\x07\x08\xff\x0bY/\x1c\x05\x00\x00s\x13\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00roles/kubernetes/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xca.MJ\x8d/K-*\xce\xcc\xcf\xb3RP\xaa\xaeVH.-*J\xcd+\x89\xcfM\xcc\xcbLK-.\x89V\x07\xa9)\xcaK-I-\xd6\x85\xaaT\x8fU\xa8\xadU\xe2\x02\x04\x00\x00\xff\xffPK\x07\x08,F\xb9}<\x00\x00\x00=\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00roles/kubernetes/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[d\xce\xd1\xa9\xc30\x0c\x85\xe1wOq\x16\xd0\x02^\xe6\"\xe2si\xc0\x96\x8b$\n\xd9\xbe4N\xa0\xd0W\x1d\xfe\x0f	L\x07+6\xa7&\xa1\xd6\xe0\x1c\xf3E\xc4\x11\xc9\xd1$\xc7\xf3\x7f\xef\x8c\x02\xc4\x83\xbd\xd7\x9f\x05\"W.\xb2\xe2Rn\xd7\xd9\xa7\xb6\xcb\x8dTOl\xd3Rw\xa3\xb7\x0f\xb9\xacZ\x00\xdc\xaf|\xef@\xa4\xe6	\x9d5\xd7\xb1)\xc7\xb4\xbf\xa5W\x1c\x8c\xf2\x0e\x00\x00\xff\xffPK\x07\x08\x1bv\xd3\x9av\x00\x00\x00\xca\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/kubernetes/systemd/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\xd2\xd5\xd5\xe5JI-H\xcdKI\xcdK\xceL-\xb6\xe2R\xd0U(\xca\xcfI\xb5R\xc8M\xcc\xcbLK-.\xe1\x02\x04\x00\x00\xff\xffPK\x07\x08\xccd6\xd0*\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/kubernetes/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xacV\xbbr\xe38\x10\xcc\xf9\x15SN6:\xf2jKu\x01kw\x13\x07\x178\xb9?`A\xc0\x90\x84\x05bX3C\xf9\xf4\xf7W\xe0C\xa4,\xb9,\xdd:\xb2\xf1\x98\xee\xc6tC\xe0\x1f\x10M\x87%\xfc8\x0c{\xe4\x88\x8aR\xc8I\x14;\xf7+\x03p\xb8\x1f\x9a2\x03\x00\xe8\xa4)\xe1\x1f\xf6QAMs\xb3\x00jbx\x19\xf6\xf8\xe2\x15\x9e)\xd6\xbe\x19\xd8(1\xf4\x86\x059\xcb\x16\xba\xe7\x16\xed\x01L\x08\xd0\x92\xa8\x80a\x04Q\x1f\x02\x98\xa3\xf1\xc1\xec\x03f\x00>\xda08\xac\x98\x02N\"\xa6\xea\x9e\xd1&\x80qJ\x8d\x1c\xa4\xaa\x99\xba2\x01V#`e\x82?\xe2\xcagD\x90\x15z\xe4\xce\x8bx\x8a\x02\x14!	/l0\"\x85\xeb|\xe1]\xd13\xb9\xc1j%\xc8\xde\x84\x0c\xa0\xf6\x0buo\xb4-\xef)\x01\xe8\xc8a	\x7f\xeev\xbbU\xc1(\x18|\x0d\x96\xa2\x1a\x1f\x91].\xc8Go\x11\xf0_/*\x19\x80\xa8\xd1\x12\xb6t\x83p\x11\xfc~i\xf1\xfc\xb7\xb8\x06\xc9\x00\x18\x1b/\x8a\\\x82h\"\xde\x07\xb2\x87$~\xd1P\x13[\x84\x19\n\x94\x80\x91\xd1\xb8$\xa9\xf6\x8d\x80\x89\x0e\x18E\x0d\xebF\xe6(g.\x9aZ\xb1\xf8\xf0n\xcf\xa4\x1f\xcb\x05\x03\x97ig\xb0\xa3X1\x062\xae\x84\x13J\xb6Q5{S\xa0\xda\xcf\x8f\x99\xbb\xe2|\x80\x9a\x82C^\xbb\xb7uki\xe0\xd3\xdd\xb0O\x97gp\x9e\xd1*\xf1i+\x951&\xc6\xb5\xfe\xac\x85\x8e\xc8\xec\x1d\x8e\x12\xa6\\b\xd7\x87\x04\xb5\xe0\xb2-\xe1\xe9^5\x0b^\x9e\xbc\xc9_\xbf/\xea\x1c\x8a>r\xaa\x0b\x9c	d\x8d\xc9ZQ-\xfb\xa6[F\xea\xebS:n2\xec\xa3X\x8c}\xe9PM	u\x18\xa4\xadZ\x13]@\x9e\xacxk1\x96\xf0k\x96}\x83)\xb7\xad\x89\x0d\x8e8\xd3f\xd1<5?\x9f\x0c\x85\x9f?Ay\xc0\xdfN\xb2#{@\xfe8\xc5\x9b\xf5\xafO\xf0\x04\xfe\xa5\xe9\xbd\x82| \xb9S\xedW\xa4\xf6J\xc5\xffH\xec\x15\xc6<q+\xab\xd3\xd2\xdd9\x9dM}$\xa3\xef\x18\xee\xc9gm\x82l^\x99\xbfQ\xc7\xff\x80j\xd0\x16\xc7\xb7\x0dx\x88\xd1\xc7\x06L\x14?=k\x81\xac	\x95\xb1\xea)\xa6K\xd8uIy\xda\x9c\x8a/~\xc8\xd3d5#T+\xc2\x1e-\x8d\xd7\xe0\x92\xdfR\x7f\x82oc\xa7;R\xf7-{\xef\xe7\xe8\xe6\xb2\x9c\xbf~\xcfV\x83\xceU\xdb\x17\xec\xaf\xddn\x1c\xd2[Lj\x98H\xc7q\xc34\xf4\xf3\xf8\xcc\xfe\xa3x\xf8\x1b\xe2V\xc5\xa7\x1f\x11\xff\x05\x00\x00\xff\xffPK\x07\x08mM\xef\xca\x8f\x02\x00\x00\xb3\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00roles/kubernetes/systemd/templates/etc/motd.j2UT\x05\x00\x01\xf0b\xe7[T\xcd\xb1J\x041\x10\xc6\xf1>O\xf15[\xea\x03\\'\x1c\xe2!\n\x82\xd8\x86\x9c\x99\xbd\x1dnoFg\xb2Z\xacyw\xc9\xc5b-\xf3\xf1\xfbg\x9e\xd2\xfb\xc4B8\xecwXW$q>\xce\x14/}\x8e\x9cQk8H!\x934\xe3\xfee\xff\xfc\xcf\x8d\x9fY\xf0\x83Y\xbf\xc9\x9a|\\\x8et\xe6\x8272g\x95\xab=\xf7-~\xf5\xad\xb1\xbb\x9e\xe3A\xbd\\\xcd\xa4^\xa2-\",\xa7\xf8\xf7\xf9\xad\x97\xacKi>\x84\xd7\x89`:\x93CG\x94\x89\x1d\xa2\x99\x90\x8cva\x1d0\xaa\xe1d\xba|DI\x17\x02\xcb\xe6\xe5\x18j\x00\x80\x9bvi\xa3jm)In\xf5P\xc3o\x00\x00\x00\xff\xffPK\x07\x08b\x86\x86m\xb5\x00\x00\x00\x0c\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00[\x00	\x00roles/kubernetes/systemd/templates/etc/systemd/system/containerd.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[t\xce\xc1J\xc3@\x10\xc6\xf1{\x9eb!\x97V4\x93\x18\xa4V\xc8AD<H\xa9\xd8\x8bPJ\xc9\xee\x0e\xedBv&\xec\xcc\x16\xa5\xed\xbb\x8b\xe2\xc1Ko?\xbe\xcb\xf7_\xaf0\x1d\x82\xc3MQ\x9a\xbd\xea(\x0f\x00\xbb\xa0\xfbl+\xc7\x11\x1c'd\x01\x9bw\x02A$\xa3@3\xaf\xe7\xe5\xaf\x1d\xc7\x88\xa47m\xdb\xde\xce\x9a\xba\xbd/\x9e\xe9\x10\x12\xd3\xcf\xda\xbd,\x17\x8f\x1fo\xef\xcb\xa7Uw<\x9a\xf5d\xd2\x93\x04;\xe0vL\xecP\x84\xd3\xd6q&5W\xa6\xaefw\xd3S \x9d^\x9bfcN&\xf6\x9f\xe6|\xbe\x94D\xda\x07\xc2\xe4\xff\xd3\x0el!\xf6\xa2\x98\xc0\xb3\x13\xe0Q\xaa\xe8K\xf9\x12\xc5\xe8\x8b\xd70\x0c\x0b\xf6\xd8\xfd\xbd\x17\xdf\x01\x00\x00\xff\xffPK\x07\x08m\xea\x009\xc9\x00\x00\x00\xf9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/systemd/templates/etc/systemd/system/docker.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[t\x8f\xcdj\xc2@\x14\x85\xf7\xf3\x14\x03\xd9h\xa9\x99\xa4\xa1U\x0bY\x94bE\x8a(\x86\xd2\x82\x88L&\xb7:437\xcc\xbd\x11E}\xf7\xd2\x1f\xa1\x9b\xee>\xbe\xb38\xe7,_\xbc\xe5\x95x\xd5\x9e)\x0f\xb0\xb1\xc4\xe1\x10\x13\x84\x9d5 \xc4\xb2\xf8\xa1\x95\x88\xa4A\xcf\xdaz\x08\xd5%\x97\x95%\xdd4\xa0\x03I\xebe\x85\xe6\x03\x82L\x07qr'\"\xb9en\xe8^\xa9\x8d\xe5m[\xc6\x06\x9d2\x18\x00I\x95\xed\x86\x94%j\x81T:L\x86\xd17\x1bt\x0e<\xf7\xb2,\xbb\xe9\xa7I6\x10#\xbf\xb3\x01\xfd\x97\xcd\xc7\xb3\xe9\xc3\xdb|1{,\xf2\xe3Q.;\x1d\xed\xc9\x965\xac\x9b\x80\x06\x880\xac\x0d\xb6\x9e\xe5\x95L\xe2\xfem\xf7d=w\xafe\xba\x92'\xe9\xf4^\x9e\xcf\xffM\xba\xbc\xfa\x8be\x8d\xa5r\x9a\x18\x82\xaa\xd0\x90\xc2\x86bWEt \x06W\x89g[\xd7S\xac \xffm\x17b\x01\xc4:p\x8e\xbe\xf7\xaem\xdd\x06\xb8\xa8'\x0c\x06F{\xcb\x05kn)/&\xe3\xf9d>\x12\x9f\x01\x00\x00\xff\xffPK\x07\x08\xbc\xc0\xa9+\x16\x01\x00\x00{\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/manifest/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[*K-*\xce\xcc\xcf\x8bO\xcb\xccI\x8d/H,\xc9\xb0RP\xaa\xaeV(\xca\x87r\x15jk\xf5\xf5\xf4@(\xcc5(\xd8\xd3\xdfO\x89\xab(5'5\xb18\x95\x08-A\xae>\xae\x8e\xc1\xaeJ\\\xb9\x89y\x99i\xa9\xc5%D\xe8\xf1u\xf4\xf3ts\x0d\x0eQ\xe2\x829-7\xb1$9\xc3JA=NCOKS\x1d.\\\x94Z\x90\x93\x98\x9cj\xa5\xa0\x1ec\xa8\xce\x05\x08\x00\x00\xff\xffPK\x07\x08l|\xb3(x\x00\x00\x00\xc8\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/manifest/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4UMo\xdb0\x0c\xbd\xfbW\x10\xb94\x01\xb6\xf4nt\x03\x86a\xa7^v\x1f\x06\x81Qh[\x88-\x19\x14\xd5\xb5h\xfb\xdf\x07\xf9\xa3u\xed\xc9IW_\xf5H>>\xbdg}\x06\x8b\x0d\xe5p\xd3\xa05\x05y\xf9\x9a\x01\x1c\xe9\x10\xca<\x03\x00h|\x99\xc3O6V@\xb0\x9c\xc0\xa0p\x0c\xb7\xe1@\xb7F\xe0\xbb\xb3\x85)\x03\xa38\x86\x16\xd9\x13g\xd9\xd8z\xa3+\xd2\xa7\x0e\xff\xf8\x08w\xc4\xde8\xab\nS\x93jQ*x~\xded\x00\xb5\xd3X+\xd4b\x9c\xcd\xc1\x0b\n\xc4\xd3/\x9b\x95\x9a\x03i\x17'X\x97\x010\x95\xc6\x0bq\xfe\x02o\x99<YM)*L5\xa1\xa7wQI\xd5$\xa8\x8c\xf0)\x15O\xa2\n\xd4\xd2\x0b|\n\x07:\x19Q\x03\xe9\x1c\xe2\x94\xda\xb9Sh\xb7Wq\xca\xd5\xa7\xe5\xfe;x\x8aC\xe8^1\xb55j\xda\x8e\x90\x06EW\xaf\x15\xc3\xf1n`\xf9\xa7\"\xbb\xd4g\x1fW\xdc\xd3\xbd\xf1\xe2\xff\x8f\xe0B\x95\x8f\x11\xb4NVI\x02\xda\xe3B\xda\xf9\x16\xc3\x8d\x7f\xf3\x9eX@*\x94\xf9*`<\x1c\xa90\x96\x8eq6v\xc8~\xe9\x08\xcfW\xf0\x91A\x07\xec\xbf\xed:]\xc7\xabla7k\x17\xf7?\xd3\xf2\x9c\x02\xb0{\x8d\xef\xd3\xa4\xf5\x0f#\x15%\x83\x08\xe9`\xc4\xa3&x\x81\xae\xff>\xeae\x1f\x141;\xf6\xaa@\xc1:\x07\xe1\x90\x0c\xdb\xf8\xe3xW\xda\x92E\x89\xb8\xbd\xe0\xa7y3V\xd7\xe1H\xea\x0e\xd9\xf7\xeeM\xb6\xed\xed\xb7\xe8\xb2\x1e\x10\x1d\x98\xc9\x8a\x1a\xcb\xfa\x19\x83\x86\xfe\xd7\xccD\xbf\xdf\x8c\x1aQ\x13k%\xbc;\x9fr\xd6\xbc+\x05\xff\xb6FJ\x98\xc9\xb5\xbfE\xcf\xe31B\xbb\x88\x03Z +\xfc\x00\xc6.\xb8\\\xe0\x9e\x9b\xeb\x0b_\xa4\xeb\xcb\x9f\xa4\xbf\x01\x00\x00\xff\xffPK\x07\x08]\xc9\xcb\xca\xcc\x01\x00\x00\xed\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/precheck/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xecV]\x8f\xda\xbc\x12\xbe\xe7W\x8c\xa8V\xbdJHB\x08\x01\x9d\xd3?RU\x91\xe3L\x12\x0b\xc7\x8e<\x13X\xb4\xdd\xff~d\x03-\xbb\xec\x07G\xafz\xf1J\xdd\x9bE\xf633\xcf|x\x9e\x8c\x0ee\x8frG\x15\x1aQkl\xb6pD\x9a\xcd\xa8G\xad+l\x14+\xd3U\x83mp\x0b\xf3\xf9l\xd6(\xf2\xb0j\x10\xc4\xe8\xaa^la\xfe\xf4\x04\x9d\xb3\xd3H\xdf\xbf\x9e\x8e\xbf\xfe\x80\x9f\xa0\xd1t\xdc\xc3\x7f \x83\xe7\xe7\xf9l\xf6\x05z\xb1G`\x0b\x84\x0cg{V\x03V\x81\x00,\x13 \x94Pc\xafL\x03\x82\xe0\x80p@\x87\xd0\n\xa5\x95\xe9\xa0\xb5\x0eR\x8f\xb1\xa6\x01o\x08\x8dj[th$\xcen\xfc\x9dx\xf5\x96x/\x1c}\x7fM\xf0g\xab\x1c\xf1\x8fX\x18R>\x9fF0\x06\xeb\x18G+\xfb\x9f\xca0D\x9eS\xe0NG\x92\xac+B\xf6\xe5\xa0\x93\xef\xf3a\x83\xad\x984\xd3\x0b\xe4\xe5p;\x03\x98\xef\x87x\x10\x8f\xd5 \xc6J\xda\xc9\xf0|\x0b\xf3\xac\xc8\xd2<\x9f\x03|\x81V=\"A=up\xe8}\xbe\xa8\x05\xb1\x92\x84\xc2\xc9\x1e\xa4\x13\xd4\x13\x124S(\xde\x80\x038$vJ\xb2\xb2\xc6\xfb7\xc8\xb1\x1a\xf7y\xac\xc6\xaa\xb5\xee \\\xe3C\xa4\xf3\xcbe\xedT\xd3\xe1\xf9_d\xdaH\n\xad#5\xb2o%\xfd\xc2~\x81\xc8\xff\xc1%Mh\x9d\x1d`t\x08Y\x9c\xc4\xeb\xd7\xa1\xb4\x95BW\xa3u\\9a:\xf4~\x96\xd9\xba(\xa1H6\x9b\xcd\xfc\x05\x9e\xe5X\xf1\xa1r8Q\x00&\xb7\xd7\xad2\xa1\x03v\n\x15*~C\xa4u\x18\x93\x1d\xc4\xa3\xb4\xc6\x04\xbeY\xf9\xf2\xd6 7\xb8\xaf|\x9dk!w\xdav\x01\x96$\xaf\xbc\xb8\x01\x07\x8f\xf2\xb7\xe5\xb2,\x8b\xe4\x95\xa3\xc3\x07\x80_T}\x18:\x9a\xebP\xd9\xaax\x1b\xc7\x87\xaa\x9e\xe4\x0e\x99~\x15\xe8\x16hl5\xa0\xef)U$\xf6\xefU\xc8\x93\xf7Wy\xb2)\xa0\\/\xcb$\x81w9z~\xce\xfb<u\xf8\x0dvt4B\xee\xaeA\xab[\xd0\xe1:fZ,\xcb\xfc6\xa6An\x95ft\xb1i+\xdf\"v\xde\xf1\xb9\x8a\xe7Q\xff\x04\xdc\xa1A\xa7\xe4\xcb	H>\xb3\ncu\xb2\xa8\x90\xfc<+\xea1\x8c\x7f\xbe\xcc\xae\xbb\x1f\x122\xa8\xba>>?\xce\xb8\x93\x15\xf7\x0e\xa9OC\xb3\x93{\xe1\x99\x87o\xee\x86//\x93x\xc2_^\x99\xb3v\xb7\x908\xf6\xe0P\x0b\xc6\xe6\xb2*\x94\xa9Z\x87X\xed\xea#\x9f\xda\xf2\xf4\x00\xaa\x85\xcb\xaa\x1ap\xb0\xeeX\x0du\xecP\xe8\x98-\x0b\x0d\xdf\xfe\x0b\xa7:\xc3\xc3\xf32\xcdW\xeb\xac|z\x00\xd4\xaa\x85t\x99&\xeb\x0c\xbe}\xea\xa0X\xad\x96kxx\xce\x92\xcd:]e\xc1\x9e\x10\x1e\x9e\xd3$/W\xeb\xc2\x1f\x98F\xb5\xf0\xf0\x1c\x12\xdf\xa13\xa8\xe3Q5\x97V\xe7\xe9&_&\xcb\xeb4\x0f\xd6\xed\x84\xb3\x93i\xc2\x02\x0fY\xef\xa6\x1a52\xb0\x13{t\xe4\x97\xfb\xa8\xa7N\x19\x02E4\xe1\x16z\xe6\x91\xb6\x8bE\xa7\xb8\x9f\xeaX\xda!\x189\x83\x8ct\xfd3\xe0i\xb1\xce\x8bb\xe39\xb5\x14+cY\xb5\xc7\xb0s'BW)C,\x8c<\xd5\xb2L7\xd9\xfc=\xe4A\xb0\xec\xcfO!\xcb\xb3\xb2\xbc\xce\xe4\xc2\xe9w\xf4X\xd9E\xadm\xb7\xc8\x92t\xb3H\x96\x8bl\x13n\xa3\xd1\xd9\xc7cDS\xcd\x1aY!E\x0d\xd6S\xd7)\xd3E\xc2D\xca0\xbaA1\xa3\xe1\xc8\xcf2\x86U\x1e9$\xe4\xc5\x1d\x13_c\xa5U\x8dN\xe8\xf3\xee\xbe\xc8\x0eO\x06\x9b\x7f\xa5\xe68\x94v\x18\xd04\xc2G\xa00+\xdc#\xb4Vk{\xf0\x13\"\xed\x80A\x91~O\x87\xaf},m\x83\xd4\xab1\x0c\x89\x9b\x8c\xf1U\xf6\xcf\xcd\x97\x96\x852\xe8(R&j\xac\xdc\xa1\x8b\xe8 \xdc\xb0\xf8?\xb4l\xe5\xdf\xad':\x8d\xfe#\xa1\xf1\x05\xc1G\xa9\xa7\x06A\x98#\xf7\x9e\\\x8d\xda\x1e\xc0\xd8\x06\xc1;\x81\xe0\xe4C\x01L?\x15\xc0t\xf5\x81\x00\xfau|\x8f\x02\xde\xe2\xae\x150-\xd6\xebu\x96\x16\xefK\xe0\x0d\xe2C\x0dL\xf2\xf2\x0d\xd9\xbaU\xc1<\xb9\xdd\x9e\xef\xc8\xe0\x1bu\xba\x95Ax\x9f\xe6+\x19\xcc\xee\x91\xc17@/d\xd0/\xca\xe26\xe6\x1f\x92\xc14\xfb'2X\x16\xf9\x9d:uV\xc1Mq\x17:;Q;\xef\xc8O\xe1'\x11\xf4\x9f\x0f\x7f5\xf0\xaf\x06\xfe	\x0d\xfc_\x00\x00\x00\xff\xffPK\x07\x08D\x0f\xbb\x8ca\x04\x00\x00\xca\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x009\x00	\x00roles/precheck/files/etc/modules-load.d/br_netfilter.confUT\x05\x00\x01\xf0b\xe7[J*\x8a\xcfK-I\xcb\xcc)I-\xe2\x02\x04\x00\x00\xff\xffPK\x07\x087\x01\xee\x7f\x13\x00\x00\x00\x0d\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x009\x00	\x00roles/precheck/files/etc/modules-load.d/ip_conntrack.confUT\x05\x00\x01\xf0b\xe7[\xca,\x88O\xce\xcf\xcb+)JL\xce\xe6\x02\x04\x00\x00\xff\xffPK\x07\x08\x00\x08\x8aR\x13\x00\x00\x00\x0d\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/precheck/tasks/all_hosts_alive.ymlUT\x05\x00\x01\xf0b\xe7[\xd2\xd5\xd5\xe5\xd2UH,.N-*\xb1\xe2RPPP(\xc9H\x84\xb2\x14\x14t\x15\x12\xf3\x8a3\x93rR\xe3\x0br\x12+\xe33\xf2\x8bK\x8a\x15lm\xb1\x88\xc6'\xe6\xe4p\x01\x02\x00\x00\xff\xffPK\x07\x08\x80\x03e\x85=\x00\x00\x00M\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/precheck/tasks/ansible_version.ymlUT\x05\x00\x01\xf0b\xe7[t\x91M\x8b\xdb@\x0c\x86\xef\xf9\x15/\xb9$\x818t\xb7\xbd\xd4\xa5\x0b\xa5\xd0[)\xf4\x0bz2\x8a-\xaf\x87\x1d\xcf\x18I\x93\xc5\x0d\xfe\xef\xc5\x8e\xe3~\xd0\xe8f\xc9z\xfc\xbcr\x86@-\xe7xX\x01\xc0w\x16W\xf7\xb0\x86\xf1.\xa8;z\xc6\x89E]\x0c8\x9fA\x97V1\xb7\x0eu\xf2\x1e\xc30m:\x85\xa6\xae\x8bb\\\xe1\xd8\xe3)\x1d\xf9\xc9\xd9\n U\x16\xcb\xa7\xb7\xac!\xcb\xb1\xbe\xc5rz\xfd\\Q\xc6\xb6#\xe1\xed\xe6\xfe\xf0j\xb3\xc7\xe6\xe1\xedf\x87aXO\x98V\x1fs\xac\x7f\xc4\x846\xa9!\xe9o]\x8b\xd3\xe3\x98`V@\x19C\xed\x1e\x93\x90E9\x8c\x00I\xa1\x88\xa1\xe4\x1c=\xebh\x18\xfa\x82E\xa2hQ\x93\x91\xcfa\x92x\xb5\xba\xdef\xbe\x8a\x0b'\x0e\x16\xa5G\x13\xd5\xc6\x11H\x18\x9f?\xbc\xcf\xee\xee\xee_bT\xf6\x8e\xc2\xed\xd0\x0b\xa1X\x08N\x17Zq\"\xef*2\xfe;\xe7\xe5\xcf\\j\xca\xfc\xf1\xdb\x97\xaf\xe0\xa0Ix\xd9\xcd\xf1\x7f\xfc0L\x8e\x7f\xaa]\xeb\xd9Y\xb3\xc8\x1f\xf0)\xf8\x1e[\xca~\xbe\xc8^\xef@\xa1BE\xdal\xb3\x1d\xc8\xfb\xf8\xcc\xd5\x1b\xa8\x91\x98\xfe\x8b\xa0\x00\xf2]C(\x1b\x12*\x8de\x0f\x0e\x95\xce\xd3q\x14R\xcb\xe2\xca\xfd\x84u\x8a\x91'(Iy}\xfb\xfc\xbf\x02\x00\x00\xff\xffPK\x07\x08oO\xe7$[\x01\x00\x00\x9a\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/cloud-init.ymlUT\x05\x00\x01\xf0b\xe7[\x8c\xcdAj\xc40\x10D\xd1\xbdOQ\x170r\xc8N\x17\xc92\xb4\xedV\xdc w\x1b\xa9\x94an?`\x98\xfdl\x1fE\xfd\x19.\xa7f\xfc\x88\x11\xc3i\x15<\x14\xc5\xaa\"\xfdKK\xd5\xd6\xb4\xd5\x18{2\xef\x14\xdf4\xad\x11\x9c\x8b\xb9\xf5CwX\xc7\xd5\xb4\xab\x13\xab\x96h\x8a-\x9c\xe6\xc3\xfco\x02\x1eb\xfc-\xd1\xf2\x04\x00\x97\xf0\xc8\x9f\x1d\xdf\xfbN\xa1\xe6w\xe0&\xda\xa91\x98\xf1\xbd,7\xecZ\xe5\x99\xf1\xb5L\xaf\x00\x00\x00\xff\xffPK\x07\x08\xeax\xb7\xad\x87\x00\x00\x00\xcd\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/fix_stacki.ymlUT\x05\x00\x01\xf0b\xe7[\x94QMO\xc30\x0c\xbd\xe7WX\x02\xa9\x80h\xcb8F\xda\x8d\x1bG8NLi\xe7\xaeV\xd3\xa4\x8a\xdd}\xa8\xda\x7fGi\xbb\xa1\xb1\x03\xe2\x16\xfb\xbd\xbcg?\xa7`\xc9!\xb9\x8a,j\x05\x00\xd0\x19\xa95\xe4(e\x1e\x90\xbd\xdde\xa5w\xd5\x08\x05\xdc\xe2\xa1\xd3\x90|1\x9aP\xd6\xf0\x90==\x0e\x03\x90`\x0b\xa7S\xac\xee\x93\x91\x19E5$3m\xb5X\xbdN\xfd\xc2\x94M\xc0\x8a5\x1c\x91/\x9d\xbe;\xd7{\x92z\x1d\xe5x\x1a&\x85\xe2\xe8^~\x9e\x8b\xf9I]KJ\xdd}\x88)\x1b\x82\"\xf8\x06\xc1\xfam\xf0b\x04\x9f\xe1\xbd/\xb0!\x81\x8a\x0e\xb8\x01\x92L\xa5\xe0L\x8b\x1a\xde<\xf2\xb4\xdc\x85\x9emr\x8e:\x80\x07bQ\x00,Fn\xb2\xb8\xa1\xab1\x0fb\xc1\xa0\xc7/\xeb\x80\xdc[Q*\x85\xc2\xfa\xb2\xd1J\xc5Q\x03v\xd6\x94s\xb8\x7fJ^\xc5<$\x97\xce\xa4\x01\xc9\xb0r\xd0\x123\xb9\xado\x92\xc9\xe1\xf7\x05\xffg\x92\xefL\x88\xac\xbc5d\xcf\x86\xf3\xfd\xae\xb0\x8c\xa7\xb4\x87\xd1v_\xa3\xbb\xda;\xc2\x92\x8d\x192,\x97\xf0\x19zT\xdf\x01\x00\x00\xff\xffPK\x07\x08\x05~\x94\x8c)\x01\x00\x00_\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/precheck/tasks/format_check.ymlUT\x05\x00\x01\xf0b\xe7[\\\x8e\xb1\xaa\x83@\x10E\xfb\xfd\x8a\x8b\xf0\xb0\xf2\xd9\xefw\xa4\x97\xd1\x9d\xd5!:\x0b\xb3\xa3\xc1\xbf\x0f1`\x91\xf6r\xcf\xe1tP\xda8\xe2`\x93|\x8a\xce\xe8\x0f\xb2~\x95\xb1Oez\xb2A*\xb48F\xb7\\\x030\x95m#M\x11\xd5\xc9\xd1et\x13\xfe\x1e\xbfT\x00\x8cg\xa9\xce\x16\xf1\x9d.:\x93\xac\x9c\x86\xd7\xc2\x1a\xd1\xb4\x97\xb4\x85\xe8\xfd\xf9\xaf\x9e\xca\xeeM\x00H\xcf\x81\xcd\x8a\xd5!\x93\xd3\x1a\xe1\xb6\xf3'a!\x9do\x8b\x96\x00\x84w\x00\x00\x00\xff\xffPK\x07\x08?\xd3\x8a\x0c\x8d\x00\x00\x00\xc8\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/precheck/tasks/ha_host_check.ymlUT\x05\x00\x01\xf0b\xe7[\x84\x91\xc1n\xea@\x0cE\xf7|\xc5}\xbc\x05+\xba\xe82j\xbfe\xe44\x86XL<\xd1\xd8CU\x89\x8f\xaf&SU \x02\xcd6\xd7g\x8e}\xf7\xe8c\xfa8u\x1b\xe0?\x061\xea#\x87\x89\xcc9\x87\x910\x15s\xf4\x0c\xcf\x85!\x07\xb4?\x867\xbcn\x80=\xc8\x8c\xb3\xd7\xe9\xfaMv\xec\xb05\xf6{R\xd7\x10\x9f#\xeb5d\xfb3\xe9#y\xb7\xf2\xfe\x05}Jq	\xd5\xd1\x0e8\xe6Tf{i\x11\\\x10Y\x8f>.B\x0f\x8dN\xa5\xe7p\x96\xec\x85b\x909\xd0,m\xb3\x91\xce\x0cR\xc8\x0c\x1a\x86\xccf\xcd\xf0^D\x0c\x07\x8a\xc6\xb7\xc2\x9a\x1ckp1\x14\x1d\xf8 \xca\x03H\x87\xb5\xd0\xc5\xb3L\xf8\xf7\x8e\xddn\x89<aiR\xbe\xbeA\x8d>\xbc\xd5_WX\xa8\xc1,\x869e\xffm\xb8\xb6\xf6x\xf7Z\xde\xed\xea\xab\xb4k\xc9g\x82-\"zf\xf5\x94\xbf\xc2\x98\xcc\x95&\x86\xe8m\xc1\x9b\xef\x00\x00\x00\xff\xffPK\x07\x08\xd1\x98\xbf\xe4\xfc\x00\x00\x00\xa0\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/precheck/tasks/ip_precheck.ymlUT\x05\x00\x01\xf0b\xe7[\x94\xd0Aj\xc30\x10\x05\xd0\xbdO\xf1\xab\x8dW\xbe\x80 \xdd\xf4\x14%\x043\x8dF\x95\xa8-\x85\x99q\xc0\x84\xdc\xbdDuKJ\xe9\"Z	\xe9\xcf\x83\xf9\xc30t\x1d0\xa0\xd0\xcc\x1e\xee%\xf1\xf1\x03\x96\xc8`\x89q\xb9 \x973\x17\xab\xb2\x8e\xa9\xaa\xddb\xb8^\x91HAE\xf3\xdb\xc4\xed\x1d\x81c.\x1c\\\x07\x00\xa4\xcab\xbe\xdd\xd14\x8f[\xeaL\xa2\xfb\xbf\xe0a\xdf\xdf[\xfd\x01Y\xbfAP	\x9b\xf3u\x1er\x9evpn\x1b\x9f\xf5\xdd\xe3\xf9\xder\xafu\xc1\xbc\xa8AO|\xccq\xc5\xaf\xf9]\x8f|\x02\x85 \xac\xca\x8aX\xa5\x95\xd2\xf6\xfd\xb7\x99\\Z\xe8\xe7\x131O\xbc\xd5R\xd6\x91E\xaa\xe8\x18\xc9h\xf20Y\xb8\xfb\x0c\x00\x00\xff\xffPK\x07\x08~\xa40\xf1\xc6\x00\x00\x00\x84\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/precheck/tasks/ip_validate.ymlUT\x05\x00\x01\xf0b\xe7[\xbc\x93Ak\xdb@\x10\x85\xcf\xd5\xafx\xcdEP\xb0b\x05\x93\xc0B\x0f\xa5\xa7^\xd2K/%I\xc5H\x1a[\x8b\xe5\xddeg\xa5\xd4\xb8\xfe\xefe%\x12\xecJ\xb4\xb4\x14\x0b]\xf6\xed0\xef\xbd\x0fi\x01C;V\xa8\x1a\xae\xb6\x08\x0d\x05h\xd7\xaf\xd0\xda\x8a\xda\xc6J\x80\x16\xd4\xbc\xd6\x86kh\x83k\x0e\xd5u\xd4%\x01ZmX\x9b\xb5nY%\x00\xe0(4\xea|\x04\x90@\x81\x15\x9cga\x13\x06\xc9\xf3\x86\xbf;\x85\xf4[~s\xf7\x98-\x877\x7f\x94w\xaf\xae\xe90\x17\xf7+\xa4\xf9\xcd]\xb6\xcc\x96Y\xfe\xe6\x97{\xfbl\xd8+xk\xc7\xbd\x1bo;wr\xde\xd9\x9a\x15\x96\xb7\xabU\x82\xb1a1J{\x96$\x99\xaf~{\xa9\xeaJ\x9d5\x1e\xbc\x17\x93\xa3u%U\xdb3\x1cJ\xe58}\xfef\xc5\xff!\xd6\xb3\xd7\xeb\xfd\x88l\xdb\x95\\\xf4\xda\x87\x8e\xdaB\xbb\x82\x9c\x8e\xdc\x8c\x0d\xe8\x84k\x94{\x8ca\x0c\xb4\xe9\xd9\x04\xeb\xf7	@\"\xec\xc3\xc8.\xeeQ\xc3TO^\x1e^\xc7\x8a(E\xc7\xa7\x87\x94\x8c\xe8\xb2\xe5AJ\x9f\xf0\xf6\xfd\x9c\xf1XA6\nW_m\x87\x8f\x1f\xee\xef?\x7f\x89Vzcp8\xccf=\x1eA\x02\x9a\xbd\xa3\xba\xf6,\x92\x01\x9f\x86\x8f!\xd0\x96Mlt8`\x9a\x12\xc7\xe3U\x02<7l\xd4\xd0\xbf\xd6B1\xf3\x8e$\xb0/\x1a\xc2\x0f\x94\xd6\xb6\xf3 ]W\xb6\xba:\x89p\x19\x8c\x13\xdb?A\x9c\xe6|A8\xbd\xf9w\x80	\xb0\x98G\xf2\xf2G\x92\xa9g\xc3/~\xcf\xfeg\x00\x00\x00\xff\xffPK\x07\x08S\x8a\x05\xea\x94\x01\x00\x00\xf1\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/precheck/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x8c\x92\xcdn\xab@\x0c\x85\xf7<\x85\x95=7\xd2]\xa2\xa6\x9b.\xb3\xe9\x1b\x8c\xcc`\xc0b~\x90mH\xf3\xf6UP#5U\x87f\x0b\x9f\xbf\xf1\xb1N\x0d	#5\xf02\x0b\xf9\x91\xfc\xf4Z\x01t\xd4.CS\x01\x00D\x1d\x1ax\x17N\x06\x86\xc37\x0c\xfa,p^Z:\xb3\xc1[N=\x0f\x8b\xa0e\x81\x19EI\xaa\xaa\x866d?\xdd<5p\xf2a\xe9\xc8\x19\xea\xa4\x0d`\x08n\xccj\xea0\xf0J\xff\xae1\xfc\x8a%\xe56\x90[I\x94s*a\x91b\x96\xab\xdb\x16+1z\xc1y\x9fX\x94\xc4)\x99q\x1a\xb4\x04\xf5Y\"\xda\xbeh\xc4-\xdb>\xc4\xb3\xbb\xdf\xb2\xb8\xb2w>\xe4\xa5\xf39\xf5\x7f\xcaV\x0c\xdc\xa1\x15O\xa9W\xf5\x16J\x7f\xf3LI5\xec\xbf\xd2\xf3\x87SC?q\x890\x8e\xf4\xe0\xb8\x8c\x94\x1a\xb8\x07UG	\xdb@\xdd\xad\x1c?F\xb7\xa45'\xb6\x87\xd1\xed\xb3\x9b%\xaf\xdc\x91\xc0\xe9\x04\x07\xf2\xff\x0f7\xc1Wq\x8fO6\xf7\xf8|u?\x03\x00\x00\xff\xffPK\x07\x08\xdd\xc60\x0c\x0e\x01\x00\x00\x15\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/precheck/tasks/memory_check.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\x91Ok*1\x14\xc5\xf7~\x8a\xc3\xacu\x1e\xbe\xf7\x10\xdf\xf0Z\xa8.\xa4\x8b\xba(\x85\xd2\x95\xdc\x19\xaf\x93`\xfeHnb\x19\xc4\xef^2#m\xa1\xc5M\xb3\xca\xe2\x9e{~\xf9e\x02G\x96+\x14K\xc5\xcd\x1eQQDT\x0c\xed$\x92k\xf8\x97t\x12\xd9B\x91\xe0\xf6\x06\xf3\xd5\x02\x81l1\x02H\x84C\xacF\x00\xfa\xd8p\xcbg\x02r\xa2k\xc3\x1b\xcb\xd6\x87nc\xeb20\x992\xfaH\xa6_3\xfd\xf7\xbb\x1f\xdf\x916\x1b+m\x85\xe2\xc5'l=\x9c\x8fPtd\xb0\xf3\xa9U\xb9\x0d\xda}\xc7T\x029c\x93\\\x12\x17>\xbf\xcb\xa1\x12xR\x1c\x18Zp:]':\x9f\x1f\x16\xa0#iC\xb5\xe1\xa2'\x93\xd44,r\x81[z\xd7\x06\x8a\xc9P\xd4\xde\xc9\x18\x9dO\x01\x9f\xdc|\xd0\xfe\xa4x4\xc1\x96\xeb\xd4\x0e.\x87\xea\xe7\xbb\xc7\xf5\xfdzU\xe5\xad_\xbeE\x94Of;<\x9f\"\x0c\x93DLg\xef\x16\xc6Y\\`X\xeaP3\x0e\xc1\xd7\x86\xad\xa0\xf1n\xa7\xdb\x14\xb4kq\xa0\x10%\xcf\xefS\xcd\xc1qd\xc9\n^\x15\xbb\xea:\xfe\x7fLg\x7f\xe6\x7f\xdf\x02\x00\x00\xff\xffPK\x07\x08\xe3\xcf\xec\x1e.\x01\x00\x00D\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/precheck/tasks/openssl_check.ymlUT\x05\x00\x01\xf0b\xe7[d\xca1\x0e\xc2@\x0c\x04\xc0\xde\xafX\x1e\x00\xa2\x8eD\xc9;\xa2\x13Y\x88%\xe3 \xef\x01\xdf\xa7A\xd7\xa4\x9e9\"\xdb\x93\x13\xae\xa9w\x11\xdb\x8b)\x05\\\xf0To\x11\\\x0c\xd0\xca\x88i\xe8\x87%\xdf\xd2\x80{\xf3\xe02\x7fW\xe6\xe0\xf9\xcf\xa7\xba\xe1p\xc1\xd9\x80\xe2\xc3\xd5Y\xbbc\xbf\x00\x00\x00\xff\xffPK\x07\x08/\x0e\xc9\xe3]\x00\x00\x00\x80\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00roles/precheck/tasks/sc_cloudconf_check.ymlUT\x05\x00\x01\xf0b\xe7[D\x8d\xb1n\xc4 \x10D\xfb\xfd\x8a\x91{|=\xd2}\x0bZ\xe1\xe5\x8cB\xe0\xb4\xbb\x0e\xc9\xdfG\xc4E\x8aif\xf4\xde\x84\x10\x88\x02:\x7fJD>%\x7f\xd4\xfeB-\xc8m\\\x07\xf2\xe8\x05\xa56\x81|Ws\xc3\xe8\xe0i\x04\x98\xb3G\x02\x807\xfb\x19\xb1=\xc4\xf3\x83\xa7\xad\xec\x8b\xdb\x08PyUs\xd1x\xfbVM@\xe1\xda\xe4H\xf3\x94\x1e\xd1\x87\xff\x8f\xfb\xd2\xee\xf7\x17\x01\xdc\x7f\x92\xa8\x0e\xb5T\xd8\xb9E\xb8^B\xc0\x8d\xfea\xe9\xad\xe3\xab\x1e\xa2x>\xb1\xf1\xb4\x8d~\x03\x00\x00\xff\xffPK\x07\x08\xb9\x18\xec\xc8\x97\x00\x00\x00\xd5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/swap_check.ymlUT\x05\x00\x01\xf0b\xe7[T\xcb1\x8e\x830\x10F\xe1\xde\xa7\xf8\x8b\x95\xa86\x90\x96\x82#\xa4\xc8\x05\xd0\xc4\x1e\xf0\x08c\x90g\x12\x8a8w\x8f(S\xbf\xf7\xfd#\xd3\xca=|d\xbf@&\xe8A;D\x11D\xe9\x9188@#\xa7\xd4\xc3\x93\xa1\xdd\xcb\xe6\xdb\xf3QT\xd0\xb1\xa0\xb9\xdd1\xe0\x8a\xf7^$\x1b\xfe\xbaO\xe3\x80\xc2\xb3\xa8q\xe9A\xde\xe4\xc5\xe3I\x1c0\x91$\x0e\xe3\x119\xff\xa4\x8bZ\xd8\x9eV\xad\xc8Z\x13\xe7\xd9\"\x06t\xee\x1b\x00\x00\xff\xffPK\x07\x08F\xc2\xac\xba\x84\x00\x00\x00\x9f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1f\x00	\x00roles/precheck/tasks/sysctl.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x90\xc1n\xc3 \x10D\xef|\xc5(w\xdbw~\xc6\"\xb0IQ0X\xec&\x15\x8a\xf2\xefUp\x93R\xb5q\xdd\xe3\xee\xbe\x99\x81\xe9\x10\xcdD\x1a\x86\x99\xb2`Jn\xceiO\xd8\xe71\x92\x1c|\x10\xca\xf0\x91\xc5\x84@N\xe1Ih\x05\xe0S\xdc\xc2u\xcdb\x844\xe6LLQ\x94z\xa4\xb0\xb9\xd0\x8b\x0c\x9b\xe2\xc1\x1f!	\x03\x89\x1d\xa6\xe4\xce\x81\xb8\x0b\xc9\xb8\xde\x0d-\xda\xdfQ\x05\xd84\x97\xe5\x15\x9c\xad\xc6F\x15\xe0\x88EoLQ\xaf\xfa\xf1\xf3hS\x8c\x92\x8d=\xfd\xd9O\x0b\xff\xa3\x9fo\x19\xeb\xfd\xb4\xe8\xf6~~S\xad\xf5\xf3\x93W\x1d\xb8\xb0\x95\xd0~ww\xbd\xc2\x0bM\xfd\x89\nn\xb7]=]L8\xb7\xb7:?\xaf\x8b\xc9\xc8$\x1a\x85\xb8\xee2\xddc\x1f\xf3\xbb\x97\xb7\xd1y+\x8b\xc7\x97@|<r5\xfa\x08\x00\x00\xff\xffPK\x07\x08\xe5\xb5\xe9\xfd\xe2\x00\x00\x00\xcc\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/time_check.ymlUT\x05\x00\x01\xf0b\xe7[\xb4R\xc1n\xdbJ\x0c\xbc\xeb+\xe6\xc9\x97\x97\x83\x8d\x02\xbd	M\xbf\xa1\x87\xde\x8aB\xa0W\x94\xb5\xf0j\xd7 )\x07n\xaa\x7f/Vr\x1c9\x89[ hu\x12\xb8\xc3\x99\xe1\x90+\xac\x11\xa9\xe7\n\xa4\xcab0\xdf3\xbc\xc2%\x11v\x86\x14A!\xa0Kj\x8a6	\x1c\x8b\xf9\xd6;2\xd6b\x05\x9c\x1b\xab\xe9\x1f\xb0\x8e\xacBOj,u&\xab]\xc7n\x8fO\xf7\x13\xc7\x91D\xbf\xf9x\xe4hINu.e\xf9\xef\x1b\x8a\xea\xb7\x81\xeb\x86\x8c\xa7\xbe\x0d\x1f\x92\xeb\xce\xac\xbd\xee*\x94_;\xc6T\x9d]\xa6\x88\xc7G\xbc&\xc38\xa2\xcaO\xef\x11\xc48\x9e5\xe7\xef\x81\x14\x81U\xf3`\x11\xd6\xf1E{\x9e1\xeb\xec$\x0d\x07\xdd\xcc\x85\x9f\xad\x17\xb5\x8b\x87\xd7I\x8c#\xb6'\xf4Ix\xe6\xfc\xf8\x01\xca.\xc5F7W\xca_\x02\x932z\xda3t\x10~k5\xd9\xcf\xb4\x9a\xb2(V\xb0\xce+\"s\xa3 4\xbemY8\x1a4\x85\xc1|\x8a\xb9a\x97\xb0\x1f\xb6\xbc\xf7V\xac\xb1\x0d\xc9\xed\xab\x02\x97\x1b\xd8\x91u,8R\xf0\xcd$\xf7#E\xd6\"\xbbq\xa9\xef)6\xd5T\xce\x999\x0b\x08^m}\x8d\x13\xde\xf9<q\xb5\xe8_(\x1cY|{:+d\xe7\x17\xd8\xd4\xfdtKX\xdcRF\xd5O(\xf8\xf8L\xbcQk\xd2`g\xf4t#\x9f\x8bE\x82\xe5uk?\xa8a\xcb\xa0\x17\x03\"\xaf8\x9bnp\xf4\xb4\x1c\xb0\x9cM\xc5S\xcd\"I\xb4n\xc9(T0\x19f\xbf\x0f\x1d\xc7\n\xff\xbf\xf4\xa8h\xb8\xf5\x91\x1b\xdc\xdd\x1c\xde\xa5h\x92\xc2!P\xe4?\x87\xb0D\xbf3\x8c\xb7)\xfea(7<?\x87C\xb1\xb9\x01\xfa\xef\x1ee\x89;\xe4\xf4\xfe\"]\x92K\"\xbf\xdd\xd9\xaf\x00\x00\x00\xff\xffPK\x07\x08\x8bw\xff^\xc7\x01\x00\x00\x16\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/precheck/tasks/user_settings.ymlUT\x05\x00\x01\xf0b\xe7[l\xd01o\xc20\x10\x05\xe0=\xbf\xe25E\xca\x14\xaa\xae\x96X;v\xecRU\xe8\xc0\x07\xb1\x08\xe7\xe8\xee(B@\x7f{\x15C\xa3\x0e\x8c\xb6\xce\xef{\xe7\x16B{\x0e\xd8\xb2c}PeqpL\x9ed\x8b}\x8e\\\x01\xd6q\xdf\x07\xac\x92D\xb4\x1f\xb8\x80\x8e;4\xb3W,\x16\xa8\xef\xb3\xed8[\xe3<h\x12\xc7\xec\xfd\xed\xda\xe0\x02W\xb4\xeb\x88\xe63P?t\x14\xbe\x9a\nP\xde&s\xd6P\x9ceA\xaa\xbf\x1a\xc67\xbe\xd8H\x1b\xfc\xcfG2\x1c\x8c\x15\x83\xe6\xef\x149\x82$B\xb2\x83ze\x8a\xa7\xf1y\x05\xf4I8\xc9&\xf5\x1c*\xe0v\x0e\xa8\xc7\xec6\xe3|\xbem\xb4\xbcG\x97\x06\xb8^k\xe0y\xf2'2f6iF\xe1H'\x83\xd3\x8e\xb1\xc9\n\xcb{\x862Y\x96b\x0c\xe4]@\xfd\xf32_\x91u\xba\xae\xcb\xad99\x07\x0c\xca\xc62V;v,\xe1\x91?n2\xfd\xc7\xdc<\xe6\x83\xe3i\xf1`\xb4\xfa\x0d\x00\x00\xff\xffPK\x07\x08\xe4\x07\xf9\x1f\x01\x01\x00\x00\xb3\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x002\x00	\x00roles/precheck/test_plugins/hostname_validation.pyUT\x05\x00\x01\xf0b\xe7[lROo\xdb>\x0c\xbd\xfbS\xf0\xf0\x03d\xf7\x97\x04\xc5n\x0b\xe0\xc3\x0e\x1b\xb0Co\xbd\xad\x99\xcd\xd8\xb4\xadL\x96\x0c\x91J\x9b\xad\xfb\xee\x83\xe5?I\xd1\xfa`I\xd4#\xf5\xf8\xf8t?8/\xe0)i\xbc\xeb\x01-\xeb\xa3!\x98\xc3\xe4\xbd\xf3\x9c$J\xa9\xe4\xb1\xd3\x0c\x9eZz\x81!\x18C5\xc4\x8c/sF\xe5j\x8a\xb8\xa4\xa6f\xc2\xa5g4\x81r\xa560\xa0\x08y\x1b\xf7\xba\xb5\xceS\x85L\xf974L\x1b\xe8\x83\x11m\xb4\xbd\x06P\xaa\xae\x90\xcb@\xb9bB_u*\xdb'\x00\x00J)\xf8\xfa28&(=\x95\x80\x0c\x08G\xe7\x0c\xa1\x85F\x1b!\x0f\x81\xb5mA:\x82rJ.\xa1'\xe9\\\x0d\xc7\x0b\xd4\xd4`0\xb2\x8b\xd5\xc6/\xf6\xa5\x19\x8c\xfeE\xe6\x02\xce\x9a\x0b\x04\xa6&\x18h\x9c\xbf\x96@[C\x19\x89\x95\xf0\xdc\xe9\xaa\x034\x9e\xb0\xbe\xac\x95:<\xd3\xf8\xac\xf6\xe0\x9e\x176\xbc[x\xc7\xb51\xd82\xe4p\x1fO\xba\xb9Qc\xbf\x16\x9a@\xaf9x\xda}_\x80\xabH\x1f\xe3\x1eb\xb4\xf0\x04\xf1X\xb9~\xd0\x86\xd2Y\xf8\xcd\x84\xcd\xe3?\x9b\xa0\xa3j\x90CQ\x1c\x836\xa2-\x17\xc5\xae%I\xd5x\xa1&\x90'	\xdeN\xd8\xb4%A\x11\x9f\x16\xfe\xcd\x886\xb0\xceh\x9ax\x96M&\xe8\x1c\x8b\xc5\x9e\x8a3\x1a]\xa3P\n\xf1\xfe\xc6\x0e?\xd3\xf4\x07n\x7f\x1f\xc6\xdf\xfd\xf6\xf3\xd3\xf6p7o\x0f\xd9\xd3\xee.\xfb?]\x8e\xaf\xcb\xe6#\xe8\x7f\x8b=f\xbe7\xf6[\x1f\xdb\xc0l\xaeyYI'IR\x19d\x86Gbypu0\x94\xba\xe3\x89*\xb9z.\xaeU`q=\x9c\xb4=\xe1'\x10b\x89\x0e\x99\xbb\x1b=\xb7t\xcco\xf2F-F4\xa7L\xa6\xc9\xae\xf3\x9b\xd9\xfeY\x031\xeb\x9dlj\xff^\xca5\xe5o\xf2/\x00\x00\xff\xffPK\x07\x089$\xe2\xbc\xdd\x01\x00\x00\xc3\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00roles/root-cert/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[JN-*\x89O\xc9,\xb2R\xd0O-I\xd6/\xc8\xce\xe4\x02\x04\x00\x00\xff\xffPK\x07\x08vJ\x11J\x19\x00\x00\x00\x13\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x00roles/root-cert/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94SAk\xdcL\x0c\xbd\xfbW\xbco/\x9b@\x9c\xcdG\x1b\n&-\x94\xd0S.\xbd\x97b\xb4\xb6l\x8b\xcc\xce\x18I\xb3i\x08\xf9\xef\xc5\xde$N7\xb4\xb4\xc7\x91\x9e\xde{\xf34S\"\xd2\x8e+\\iJ^6\xac\xfe\xa9\x00Z\xde\xe6\xbe*\x00`g}\x85\xaf*\xd1\xe1\xd4\xbf\xc6\xa1K\x8a\x9b\xbc\xe5\x1bq\\\xa7\xd8I\x9f\x95<)FRc-\x8ag\xf2\xeb\x81\x9b[P\x08\x18\x92\xb9\x81\x94a.!\x80\xf6$\x81\xb6\x81\x0b@b\x13r\xcb\xb5\xa6\xc0\x07\xed\xc3\xf4\xa8\xdcL\x04s\xc9\xc9n\xad\xee4\xed\xaa\x89\xb0\x9e	k\n\xb2\xe7E\xefK\xb4\xac\x8c4r4\x0b\x10\x83Ds\n\x81\xdb\x02\xb0\x81C\xa8^\xba{V\x93\x14\x0b\xa0#	\xdc\xd6w\x03\xc7\x97v\xfd\xd4>\xd7\x06\xff}\xc4E\x01(\xf7b\xce\xfa\x06\xb3\x18\xe0\x83\x81\xf5\xc3\x03\xa6\xa8\xeaV\x14\x8f\x8f\x1b\xd7l\xbe\xa1\xd8\x0cIm\xb3\x06\xff\x10s\x9b\x94\xe5\xf9\xca#\xf9Pa\xf5\xc7\xc1\xd5\x8c4'\xe7\n\xad(7\x9e\xf4~.\xa6\xbb8\x19\x9b\x964\x9f{My|u\xde\xa5\x96+\\|\xb8\xbc\\\xcc\xe6\xb1%g\\\x7f\x9e%\xa5\x93\x86\x9cm	ju\x00\x94\x0d\x95\xaf\x01\xab\x85\xc1\xd9\x1c\xd2\xe16o\xb9\xa4Q\x8cu\xcf:\xe5\xae9F\x89\xfdBf\xf7\xe6\xbck<\xcc\xfe\xb3\x1d\xcd\xfc\x12\xefK\xb5V\xb6\x1c\xfcxE\xc7\xfdiG19$\xe2\xdb\xc5\xd9\xbb\xb3\xf7\xdf\x0bL\x05\xe9\xee+(\x87D-(\xb6P6'\xf5\xb7\xd2\xcd@\xb1\xff=\xbdy\x9b\xb2\x9fw\x12\xdb\x93\xf5\xc9\xd3\xd5N\xd7\xa7\xd3\xc3(\xff_\xe2\xb8\xda\xfc\xedg\xda\xfc\xc3o\xfa\x19\x00\x00\xff\xffPK\x07\x08\x07V\xc9\x17\xb6\x01\x00\x00\xaa\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00roles/timesyncd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[*\xc9\xccM\x8d/N-*K-*\xb6\xe2\xd2UP2\xd0+\xc8\xcf\xcf\xd1\xcb+)\xd0\xcb/JW\x02	\x19b\n\x19a\n\x19\xa3	\x01\x02\x00\x00\xff\xffPK\x07\x08\xf8V\x85<1\x00\x00\x00Z\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x00roles/timesyncd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xd4V\xdfo\xe44\x10~\xdf\xbfb\xc8!\xa5E\xec\xaex\x8d\xe8I\xa8 !\x1d\x82J=\x9e\x10D\x8e=\xd9\x98:v\xe4\x19\xef\xdd\xb2\xed\xff\x8e\xfc#m\xdak\xa1\x95*\xc1\xbd%\x9e\xf17\x9f\xe7\x1b\x7f\xf2\x1a\xac\x18\xb1\x81oY\x8fH\x07+\xd5\xdb\x15\x80\xc2.\xec\x9a\x15\x00\xc0H\xbb\x06.\xbc\xb6\x0c,v\xcb<\xe8\x9d\x87w\xa1\xc3w\x9a\xe1\xdc\xd9^\xef\x82\x17\xec<L\xc2\x13\xfa\xd5j\x0d\x9dq\xf2\xaaY\xad\x00\xe6B\xe7\x03\xca+\x10\xc6\xc0\xe0\x88	\x84G \xd6\xc6\x80\xd8\x0bmDg0\xd5\xd5V\x9a\xa0\xb0\xf5\xce`f\x02\x05a\xf2(#HYdAW\xd4\xf6\xde\x8dM\x84m\x13l+\x8c\xde\xe3\xb2\xee%2D\xee\x7f9\x9b\x0b\xcc?\xf7\xc1\xab\xe31\x11k\xe70\xdc\xdcT)\xe3\xc3\x80\xb6y\x10\xd3\x04\n{mQ\xc5Ro@:cP2\xf4B\xc6\xa3Y\x05t \xc6\x11\x08\xfd^K$`\x07\x89<X\x9e\xd4\xbc\x0c\xc4\x82\x03-\xd8\xfe+P\xa2T~\xda\x94\x95\xcf\xe1q\xa7\x89\xd17\xb7\x99m\x04\xc7\xcc\x8f\x90Y\xdb\x1d\x84)\xf2\x16\xc1\xe4\x9e$=\xd3\x01#\xadx*m\x85\xe4\xd8\xc1;J\x84\xcb\xe4\xf8\x95J\xa0\xcf\\\x8c\xb6\xa8m\xaf\xef\xd4\x9a\x04\x0f\x0dl\x91\xe56\x93W\xdb\xdb\xfd\x1b\xe9l_\xf2<\xee\xf0\xe3\xd4@\xfd\xc7\xcf\xef/\xce\xea\xb2\x1a\xf1\x1a\xa8\xe2\xd2\xf1\x98\xca\xb5\xa5\x1c\\\xc3\x9fN\xdb\x93\x1a\xea\xd3\x07\xea\x9c\xdc?\xf4FX\xd2\x9d)\x0d\xda\xcc\xc1\xdf\xaa\xd8\xfc\xea\xf7\x85|\xb9\xc5/\xda|v\x065\xb1\x9b&T\xf5i\xda\x7f2\xefP\x9a\xd8\xeb.\xb0v6\xe6U\x97?\xfdpY\x81\xf3\xf0d\xc6\xaf]\xb0\x1c\xaa\xd3'u\x92\x83w\xf6\xf0\x0c\x91\xc24\xe7\xce\xd3\x15\xef\xa9\x9b\xd0\x8b\x84\x99\xb5 \xe0A0\x04\xc2\x92]\xa6g2B>\xa6`Nz\\\xb6\xac\x0bl\xbe\xfa\xb2\xbe\x8de\x1c\xa8J\xecx\x04\x1d\xc7\xf7\xe6\x06t\x17<qQM\xf3\xd0\xc6@\x99\xdeu\xba\x7f\xf7\xc4\xfe?	\xfc\xa4z\xe7h\xf9\x97\xcb*\x8b'\x88\xc2\x88\x04\xfb\xf1\x83\xf0\xb8f\xe7L\xe7>\xae\xe5\xa8\xb2hp\xf1\xdd\xfb\x1f\x97w=\xf9\x81\xee\xcb\x06\xd8\x8fq\x0b\xc5\xe4\x0e\xd3\x14\x10\xaah\x1c\xf1\xe6\xa4\xe6\xe4\xcb?\xa01\xcdcU\xe6[Vl\x05\xae\xaf\x01\xe5\xe0\xa0\xfe^S\xf4WU?\xb0\x8aR\xb1\xcd\x8d?X\x99\xe2o \x8e\x97\xb30\x08\x02\xeb\xa2\xef\xee\xb5\x0b\x94`19\x99\x1b'\xe1q\x034\xb8`\x14t\x08\x93 \x82^h\xb3I\x18r\x10v\x87\xaa\xcd\xeaY\xb7td\xb4\x91\xcc\xc2S>i\xc1\xd7\xd9%u,\xcf\xff\xdc\x8cl0\xf3\xe0&\x86\x0dx$\x16\x9eQ\x95e%pt\xb6\xf5h\x9cP\x0d\x1c\x8a\x8d\xce\xde_@\xd6\xb7\x8c\x16\x83\xf7I\x8b6\xc4\xca\x05\xde\xf4\xda\xaa\x93\xbb\xce\x9e\xc2[X\x7f\x93\xed\xe0e\xe3\xf6\xca\xb3\xfaZf\xf4@\xad\xec\x03\xff\xbdV\x85\xc7\xe7\xac\xd0s\xdc$\x1fM\x1a\x17T;y\xb7\xd7\n=|q\x06\x15\xb1\x90W\xba\x8ao\xac\xf2\x80\xdb>\xf7\x05\xb7}\xc1\x13\xee\xef\x00\x00\x00\xff\xffPK\x07\x089\x0e)\xf8)\x03\x00\x00\x1f\n\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd0@\x0f5X\x00\x00\x00}\x00\x00\x00\x1b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00roles/dns/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x83\xeb\xc5{\xad\x01\x00\x00\xaf\x04\x00\x00\x18\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xaa\x00\x00\x00roles/dns/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xaa\xc2\x8c\xc6\x9a\x01\x00\x00\xe3\x03\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa6\x02\x00\x00roles/docker/registry/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMm\xa3$\xadC\x00\x00\x00j\x00\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9e\x04\x00\x00roles/docker/registry/files/etc/systemd/system/registry.service.d/registry.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM:\x1cM\xc4g\x00\x00\x00\xfb\x00\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81g\x05\x00\x00roles/docker/registry/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0*\x00\x00\x00$\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81,\x06\x00\x00roles/docker/registry/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc2u\xca\x98\xe1\x00\x00\x00\xeb\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb0\x06\x00\x00roles/docker/registry/tasks/certs.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92\xac\x07\xbd\x8e\x02\x00\x00\xb2\x06\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xed\x07\x00\x00roles/docker/registry/tasks/images.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM*\x82\"\xda\x11\x05\x00\x00\x95\x0f\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd8\n\x00\x00roles/docker/registry/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMu@\x04\xf8-\x01\x00\x009\x02\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81D\x10\x00\x00roles/docker/registry/tasks/manifest_preload.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xba\xa3}\x8d\xdb\x01\x00\x00q\x04\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd8\x11\x00\x00roles/docker/registry/tasks/registry_image.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xbc\xc0\xa9+\x16\x01\x00\x00{\x01\x00\x00R\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x18\x14\x00\x00roles/docker/registry/templates/etc/systemd/system/docker.service.d/docker.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM@\x8fM\xad:\x01\x00\x00\xbc\x02\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb7\x15\x00\x00roles/docker/registry/templates/etc/systemd/system/registry.service.d/registry.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x93\xb1\xda%\x85\x02\x00\x00c\x06\x00\x00J\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81~\x17\x00\x00roles/docker/registry/templates/usr/lib/systemd/system/registry.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\"\xd5\x9aI\x11\x02\x00\x00\x95\x04\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x84\x1a\x00\x00roles/docker/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM<\x00\x98w\x87\x00\x00\x00\xb8\x00\x00\x001\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf2\x1c\x00\x00roles/docker/systemd/files/etc/logrotate.d/dockerUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM*\xab`\x88\xa6\x00\x00\x00\xc1\x00\x00\x007\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe1\x1d\x00\x00roles/docker/systemd/files/etc/rsyslog.d/30-docker.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMt\xae\xdf)\x9d\x00\x00\x00\xc0\x00\x00\x00A\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf5\x1e\x00\x00roles/docker/systemd/files/usr/lib/systemd/system/logrotate.timerUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x91\xcbw0\x9b\x00\x00\x00\xc3\x01\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\n \x00\x00roles/docker/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x05Ds\x7f\xe9\x01\x00\x00G\x05\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x02!\x00\x00roles/docker/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96\x1e\x11\xca\x8d\x00\x00\x00\x06\x01\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81E#\x00\x00roles/docker/systemd/templates/etc/docker/daemon.json.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMpE\xdb\xc5\xc6\x00\x00\x00g\x01\x00\x00\x1c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81A$\x00\x00roles/etcd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa6\xf2\x7f\x92\xcf\x00\x00\x00\x1b\x01\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Z%\x00\x00roles/etcd/files/etc/rsyslog.d/30-etcd.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x05\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8b&\x00\x00roles/etcd/files/var/lib/etcd/.pidUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2_=\xd8W\x00\x00\x00}\x00\x00\x00\x1c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe9&\x00\x00roles/etcd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM!\xc3\xb0\x19\n\x06\x00\x00|\x14\x00\x00\x19\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x93'\x00\x00roles/etcd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM!\x7f\xb7\xa1\x81\x01\x00\x00\xdf\x02\x00\x002\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xed-\x00\x00roles/etcd/templates/var/lib/etcd/update_ionice.shUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMC\xe9 }\x18\x00\x00\x00\x12\x00\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd7/\x00\x00roles/ipsec/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe4\xf6GL\x88\x00\x00\x00\xe9\x00\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81C0\x00\x00roles/ipsec/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x13j{%\xe0\x01\x00\x00\x1a\x05\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1c1\x00\x00roles/ipsec/tasks/strongswan.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8b\xf7\xa5\xf7\x0c\x02\x00\x00\xd5\x03\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81S3\x00\x00roles/ipsec/templates/etc/ipsec.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0bj\xe4\x15.\x00\x00\x00-\x00\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbd5\x00\x00roles/ipsec/templates/etc/ipsec.secrets.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfd\xc5\x8c/\xae\x00\x00\x00\xe5\x00\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81L6\x00\x00roles/ipsec/templates/etc/strongswan.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8c3\xa50\xbe\x00\x00\x00\x15\x01\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81]7\x00\x00roles/journald/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM(\xf1B1\x7f\x00\x00\x00\xcf\x00\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81r8\x00\x00roles/journald/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc4Kuq1\x02\x00\x00\xbe\x08\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81H9\x00\x00roles/journald/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf7x\xc4T\xa8\x0d\x00\x00\x15+\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcd;\x00\x00roles/kubernetes/control-plane/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfb\xe7\xc1S\xb5\x00\x00\x00\x87\x01\x00\x00?\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdcI\x00\x00roles/kubernetes/control-plane/files/etc/logrotate.d/kubernetesUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM>\xd4	\xb7\xaa\x00\x00\x00\xc6\x00\x00\x00E\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x07K\x00\x00roles/kubernetes/control-plane/files/etc/rsyslog.d/30-kubernetes.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb3iZ\xa8f\x00\x00\x00r\x00\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81-L\x00\x00roles/kubernetes/control-plane/files/srv/kubernetes/manifests/audit/audit-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMp\xad\x17%J\x00\x00\x00I\x00\x00\x00:\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1fM\x00\x00roles/kubernetes/control-plane/files/usr/local/bin/etcdctlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xff\xe6\x92\x06^\x00\x00\x00\xca\x00\x00\x00:\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdaM\x00\x00roles/kubernetes/control-plane/files/usr/local/bin/kubectlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf5K9{Z\x01\x00\x00\x81\x03\x00\x007\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa9N\x00\x00roles/kubernetes/control-plane/filter_plugins/filter.pyUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x14\x7f\xe8\xf6h\x00\x00\x00\xfb\x00\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81qP\x00\x00roles/kubernetes/control-plane/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96:\xb0IQ\x0e\x00\x00E:\x00\x00-\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81@Q\x00\x00roles/kubernetes/control-plane/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x85|\x8f+l\x01\x00\x008\x04\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf5_\x00\x00roles/kubernetes/control-plane/tasks/taint-and-label.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMU@N\xe1m\x00\x00\x00|\x00\x00\x00<\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd0a\x00\x00roles/kubernetes/control-plane/templates/etc/aws/aws.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xff\x95\xe8Q\x0b\x01\x00\x00\n\x02\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb0b\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/admissioncontrol.cfg.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xef\xdd\xfa9\x8b\x00\x00\x00\xd4\x00\x00\x00Y\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Id\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/encryption-config.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0d\xc77:\x88\x00\x00\x00\xaf\x00\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81de\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/eventratelimit.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x17\x0fGf\x86\x02\x00\x00\xbe\x05\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81yf\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/kubelet.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa4o\xdc \xfd\x01\x00\x00\xa2\x03\x00\x00Q\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x85i\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/scheduler.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf16\x8d\xa6#\x01\x00\x00\xb3\x02\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\nl\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/vsphere.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf9\xfcv\x0e>\x08\x00\x00\x9e\x1b\x00\x00N\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb3m\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/etcd.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMO\x00\x1b\x86#\x06\x00\x00\xa0\x16\x00\x00X\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81vv\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-apiserver.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x99\x91\xfd\x8c\xa7\x04\x00\x00\xd2\x11\x00\x00a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81(}\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-controller-manager.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM,c\x93Q\x93\x03\x00\x00\x84\n\x00\x00X\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81g\x82\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-scheduler.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9a\x97F\xfeB\x00\x00\x00f\x00\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x89\x86\x00\x00roles/kubernetes/control-plane/templates/etc/systemd/system/kubelet.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\\\xef\x8fe2\x00\x00\x000\x00\x00\x00N\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81`\x87\x00\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-serial.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x94b\x82\x060\x00\x00\x00.\x00\x00\x00L\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x17\x88\x00\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-uuid.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x80\x81\xd3n\xa0\x04\x00\x00\xc8\x0e\x00\x00b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xca\x88\x00\x00roles/kubernetes/control-plane/templates/srv/kubernetes/manifests/control-plane/kube-proxy.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM(TL\x06L\x05\x00\x00\xe5\x0f\x00\x00R\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x03\x8e\x00\x00roles/kubernetes/control-plane/templates/usr/lib/systemd/system/kubelet.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x81g*k\x81\x01\x00\x00`\x03\x00\x00F\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd8\x93\x00\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe9\xc2\xafj\xae\x01\x00\x00\xa7\x04\x00\x00M\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd6\x95\x00\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/remote-kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc7\xc1\xf8_q\x0c\x00\x00I4\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x08\x98\x00\x00roles/kubernetes/core/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc3\xf1\xcb\xbc\xa5\x02\x00\x00\x02\n\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd7\xa4\x00\x00roles/kubernetes/core/examples/blocks.mdUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96r\x91\x9c\xd2\x02\x00\x00\xd2\x06\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdb\xa7\x00\x00roles/kubernetes/core/examples/filesystem.mdUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\n\x87\xb80\x00\x00\x00-\x00\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x10\xab\x00\x00roles/kubernetes/core/examples/object.mdUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x15\xdd1\x9b\xae\x00\x00\x00$\x01\x00\x00=\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9f\xab\x00\x00roles/kubernetes/core/files/etc/kubernetes/rook-bin/umount.shUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x931\x0f\xd5\xaf\x00\x00\x00\xed\x00\x00\x00F\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1\xac\x00\x00roles/kubernetes/core/files/usr/lib/systemd/system/rook-umount.serviceUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92L2\xc6[\x00\x00\x00\x85\x00\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xed\xad\x00\x00roles/kubernetes/core/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0*\x00\x00\x00$\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa6\xae\x00\x00roles/kubernetes/core/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x15\xf987\x1e\x02\x00\x00\x99\x04\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81*\xaf\x00\x00roles/kubernetes/core/tasks/azure.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xdc>\xf9\x1d:\x05\x00\x00\xd6\x13\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa5\xb1\x00\x00roles/kubernetes/core/tasks/defaults.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa4\xebJ\xcdK\x02\x00\x00L\x06\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81>\xb7\x00\x00roles/kubernetes/core/tasks/dns.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb44\x19\x9c\x19\x02\x00\x00t\x04\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe4\xb9\x00\x00roles/kubernetes/core/tasks/ebs.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8a]\xfc\x8e\xf7\x00\x00\x00\x13\x02\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81X\xbc\x00\x00roles/kubernetes/core/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd2\x92Z*\x99\x06\x00\x002\x17\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xaa\xbd\x00\x00roles/kubernetes/core/tasks/rook.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM{fW\x08\xcb\x01\x00\x00P\x05\x00\x00>\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9f\xc4\x00\x00roles/kubernetes/core/templates/etc/pki/ingress_openssl.cnf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa9i)\x89{\x04\x00\x00\xdf\x0e\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdf\xc6\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/azure-storage-classes.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf2\x19\x16\x06z\x17\x00\x00oq\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe7\xcb\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/calico.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf1R\xb0\xb0\xdf\x01\x00\x00\xac\x07\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdf\xe3\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/control-plane-services.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb4\xf0\xa3\xd5b\x05\x00\x00\xf3\x0e\x00\x00S\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81L\xe6\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns-autoscaler.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM+\x88?\x85\x87\x05\x00\x00R\x11\x00\x00H\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x818\xec\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\xb1\xe5J~\x03\x00\x00/\x0c\x00\x00P\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81>\xf2\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/default-backend.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMB\x8c\x03z\xbc\x02\x00\x00\x18\x08\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81C\xf6\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/dns-aaaa-delay.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9\xbd\\i\xf6\x01\x00\x00\xac	\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x85\xf9\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/ebs-blockstore.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x93*B\x9c\x15\x07\x00\x00\xc9)\x00\x00I\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x01\xfc\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/heapster.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf2\xb8\xe9 :\x05\x00\x00\xdb\x19\x00\x00S\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x96\x03\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kube-state-metrics.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM=\xf86\x0f6\x05\x00\x00\x92\x14\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Z	\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kubernetes-dashboard.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd7P\x8a\x8d\xde	\x00\x00V/\x00\x00N\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1c\x0f\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/nginx-ingress.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xad\xa5\xbanm\x0e\x00\x00\x08)\x00\x00Z\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x7f\x19\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-cluster.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe1Z\xf1\xc4i\x16\x00\x000\xa2\x00\x00Y\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81}(\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-common.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb7\xb5m\xb2\x8e\x10\x00\x00\xc89\x00\x00[\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81v?\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-operator.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf4K\xbb\x13\xb4\x02\x00\x00\x8e\x07\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x96P\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-filesystem.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92\xb3\x14\xae\x15\x03\x00\x00\xef\x08\x00\x00_\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdeS\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-store.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\x85\x10u\xc6\x00\x00\x00\xfa\x01\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x89W\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x12\xe0\x1b?\x86\x01\x00\x00w\x03\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe4X\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-pool.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96%\xe0 \xe7\x02\x00\x00\xfa\n\x00\x00`\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf8Z\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-storage-class.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM7\x01\xd2\x91j\x02\x00\x00M\x06\x00\x00Z\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81v^\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/toolbox/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM+o\x971\xd7\x00\x00\x00\xda\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81qa\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-http.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8c$\x8d\xf9\xc3\x00\x00\x00k\x01\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdcb\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-https.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM	\xbd\xf9B\x9c\x00\x00\x00\xf9\x00\x00\x00`\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x814d\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus-service.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x917\xd9qn\x01\x00\x00\xfb\x03\x00\x00X\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81ge\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfa,\xfc\x87\xbe\x00\x00\x00c\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81dg\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/service-monitor.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9\xff\xa0\xe3\x83\x00\x00\x00\xae\x00\x00\x00Q\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb6h\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9dq\xfb\x8aj\x02\x00\x00N\x06\x00\x00M\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1i\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8a\xe3\xa3a\xbc\x02\x00\x00\xe1\x05\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xafl\x01\x00roles/kubernetes/ha/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x99\x11\xdc\xba\xc4\x00\x00\x00\xf2\x00\x00\x007\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc7o\x01\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-haproxy.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x18H<R\xab\x00\x00\x00\xc9\x00\x00\x00:\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf9p\x01\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-keepalived.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd5\x9e\x80<]\x00\x00\x00j\x00\x00\x003\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x15r\x01\x00roles/kubernetes/ha/files/etc/rsyslog.d/remote.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa9\xea\xad\x9cs\x01\x00\x00\x9d\x02\x00\x002\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdcr\x01\x00roles/kubernetes/ha/files/etc/sysconfig/keepalivedUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf8+\xff\xa2&\x00\x00\x00 \x00\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb8t\x01\x00roles/kubernetes/ha/files/etc/systemd/system/bynet.service.d/bynet.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9a\x97F\xfeB\x00\x00\x00f\x00\x00\x00Q\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\\u\x01\x00roles/kubernetes/ha/files/etc/systemd/system/keepalived.service.d/keepalived.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x89B~\x8a\x9a\x00\x00\x00\x85\x02\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81&v\x01\x00roles/kubernetes/ha/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMn\xbe2\x84\xf1\x01\x00\x00\xfb\x04\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1cw\x01\x00roles/kubernetes/ha/tasks/haproxy.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM_\xc3\xc3\xcdt\x02\x00\x00,\x0b\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81iy\x01\x00roles/kubernetes/ha/tasks/keepalived.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92rq\xa5\x04\x01\x00\x00\x19\x02\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81<|\x01\x00roles/kubernetes/ha/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMz\x9cl\xa0\xe0\x04\x00\x00\xfc\x0f\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x99}\x01\x00roles/kubernetes/ha/templates/etc/haproxy/haproxy.cfg.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0f\xd9\x11u\xd8\x02\x00\x00\xcf\x08\x00\x00?\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe8\x82\x01\x00roles/kubernetes/ha/templates/etc/keepalived/keepalived.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9c\xb0\xf0\xf8\xd7\x01\x00\x00p\x04\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x816\x86\x01\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/haproxy.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa4\xb2\xbbS\xd1\x01\x00\x00\x1b\x04\x00\x00J\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8b\x88\x01\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/keepalived.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMSd\xb5!\x0d\x01\x00\x00M\x02\x00\x004\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdd\x8a\x01\x00roles/kubernetes/security-policies/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xac\xaf\x17\xec\xa5\x00\x00\x00#\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81U\x8c\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/backend-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa9\x91\x81xu\x00\x00\x00\x85\x00\x00\x00[\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8e\x8d\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/default-deny.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM&\xe1_0\x8c\x00\x00\x00\xb7\x00\x00\x00Y\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x95\x8e\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/dns-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x93v\x94\xe0\xed\x00\x00\x00.\x02\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb1\x8f\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/frontend-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x1d\xcf\xca\xf8\xfb\x00\x00\x00W\x02\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x813\x91\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/heapster-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x83\xa9\xf3\xad\xb6\x00\x00\x00\xbf\x01\x00\x00h\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc3\x92\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/kube-state-metrics-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9d\xfd\xfa\xf8\x00\x00\x00\xb9\x03\x00\x00c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x18\x94\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/ceph-critical.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc6Wi\xeb\xb4\x00\x00\x00\x18\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xaa\x95\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/default.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMy\x84\x11Z\xcd\x00\x00\x004\x01\x00\x00j\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf2\x96\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-critical.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x10\xae\x8f\xb7\xd4\x00\x00\x00D\x01\x00\x00f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81`\x98\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-high.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x94\x901G\x11\x01\x00\x00\xfd\x01\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd1\x99\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/privileged.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc5_\x81\xb6z\x02\x00\x00\xae\x05\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81n\x9b\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/restricted.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb8\x81\xa92\x1a\x01\x00\x00\x1d\x03\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81t\x9e\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/privileged.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMn\x8d\x7fZ\xf0\x00\x00\x00\xe8\x02\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1b\xa0\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/restricted.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9a2\x8aI\xb8\x00\x00\x00H\x01\x00\x00a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x98\xa1\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/kube-system.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa1\xaa,}\xc1\x00\x00\x00r\x01\x00\x00f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe8\xa2\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph-system.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\"\xf4\x0d\xcc\xbe\x00\x00\x00c\x01\x00\x00_\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81F\xa4\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xff\x0bY/\x1c\x05\x00\x00s\x13\x00\x001\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9a\xa5\x01\x00roles/kubernetes/security-policies/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM,F\xb9}<\x00\x00\x00=\x00\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1e\xab\x01\x00roles/kubernetes/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x1bv\xd3\x9av\x00\x00\x00\xca\x00\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbb\xab\x01\x00roles/kubernetes/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0*\x00\x00\x00$\x00\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x92\xac\x01\x00roles/kubernetes/systemd/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMmM\xef\xca\x8f\x02\x00\x00\xb3\x08\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x19\xad\x01\x00roles/kubernetes/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMb\x86\x86m\xb5\x00\x00\x00\x0c\x01\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x06\xb0\x01\x00roles/kubernetes/systemd/templates/etc/motd.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMm\xea\x009\xc9\x00\x00\x00\xf9\x00\x00\x00[\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81 \xb1\x01\x00roles/kubernetes/systemd/templates/etc/systemd/system/containerd.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xbc\xc0\xa9+\x16\x01\x00\x00{\x01\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81{\xb2\x01\x00roles/kubernetes/systemd/templates/etc/systemd/system/docker.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMl|\xb3(x\x00\x00\x00\xc8\x00\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1f\xb4\x01\x00roles/manifest/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM]\xc9\xcb\xca\xcc\x01\x00\x00\xed\x06\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xee\xb4\x01\x00roles/manifest/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMD\x0f\xbb\x8ca\x04\x00\x00\xca\x0e\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0e\xb7\x01\x00roles/precheck/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM7\x01\xee\x7f\x13\x00\x00\x00\x0d\x00\x00\x009\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc6\xbb\x01\x00roles/precheck/files/etc/modules-load.d/br_netfilter.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x08\x8aR\x13\x00\x00\x00\x0d\x00\x00\x009\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81I\xbc\x01\x00roles/precheck/files/etc/modules-load.d/ip_conntrack.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x80\x03e\x85=\x00\x00\x00M\x00\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcc\xbc\x01\x00roles/precheck/tasks/all_hosts_alive.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMoO\xe7$[\x01\x00\x00\x9a\x02\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81h\xbd\x01\x00roles/precheck/tasks/ansible_version.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xeax\xb7\xad\x87\x00\x00\x00\xcd\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\"\xbf\x01\x00roles/precheck/tasks/cloud-init.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x05~\x94\x8c)\x01\x00\x00_\x02\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x03\xc0\x01\x00roles/precheck/tasks/fix_stacki.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM?\xd3\x8a\x0c\x8d\x00\x00\x00\xc8\x00\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x86\xc1\x01\x00roles/precheck/tasks/format_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd1\x98\xbf\xe4\xfc\x00\x00\x00\xa0\x02\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81o\xc2\x01\x00roles/precheck/tasks/ha_host_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM~\xa40\xf1\xc6\x00\x00\x00\x84\x01\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc8\xc3\x01\x00roles/precheck/tasks/ip_precheck.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMS\x8a\x05\xea\x94\x01\x00\x00\xf1\x04\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe9\xc4\x01\x00roles/precheck/tasks/ip_validate.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xdd\xc60\x0c\x0e\x01\x00\x00\x15\x03\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd8\xc6\x01\x00roles/precheck/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe3\xcf\xec\x1e.\x01\x00\x00D\x02\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81:\xc8\x01\x00roles/precheck/tasks/memory_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM/\x0e\xc9\xe3]\x00\x00\x00\x80\x00\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc4\xc9\x01\x00roles/precheck/tasks/openssl_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9\x18\xec\xc8\x97\x00\x00\x00\xd5\x00\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81~\xca\x01\x00roles/precheck/tasks/sc_cloudconf_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMF\xc2\xac\xba\x84\x00\x00\x00\x9f\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81w\xcb\x01\x00roles/precheck/tasks/swap_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe5\xb5\xe9\xfd\xe2\x00\x00\x00\xcc\x02\x00\x00\x1f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81U\xcc\x01\x00roles/precheck/tasks/sysctl.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8bw\xff^\xc7\x01\x00\x00\x16\x05\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8d\xcd\x01\x00roles/precheck/tasks/time_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe4\x07\xf9\x1f\x01\x01\x00\x00\xb3\x01\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xae\xcf\x01\x00roles/precheck/tasks/user_settings.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM9$\xe2\xbc\xdd\x01\x00\x00\xc3\x03\x00\x002\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0c\xd1\x01\x00roles/precheck/test_plugins/hostname_validation.pyUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMvJ\x11J\x19\x00\x00\x00\x13\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81R\xd3\x01\x00roles/root-cert/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x07V\xc9\x17\xb6\x01\x00\x00\xaa\x03\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc3\xd3\x01\x00roles/root-cert/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf8V\x85<1\x00\x00\x00Z\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xce\xd5\x01\x00roles/timesyncd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM9\x0e)\xf8)\x03\x00\x00\x1f\n\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81W\xd6\x01\x00roles/timesyncd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x05\x06\x00\x00\x00\x00\xae\x00\xae\x00\x89N\x00\x00\xd5\xd9\x01\x00\x00\x00"
+	Data = "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00roles/containerd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xac\x921o\xdc0\x0c\x85w\xff\n\"\x19n\xe9U\xbb\xb7\x0e-\xd0\xa1]\xda-\x08\x04Y\xa2\xef\x88\xc8\xa4A\xd2\xd7\x1e\x82\xfb\xef\x85\x9ds\xebC\x81 C6Az\x8f\xfcD\xbe,\xec\x89\x18\xb5\xc4,\xdc\xd3!\x8e\xc9\x8f-\x04\xf4\x1c\xfe=\xce\xc7\x9e\x0e\x1f]\x86\xdal<*\xe2-\x84S\xd2P\xa9\xdb\x18\xb6\"\xf3\xe4\xd8B\xd0\x89\xb7\x8a\xe6\x1e\xfc\x88\xd0\x11'%4H\x8a@l\x9ej\xc5\x02\xbd\xca\xb0\xbc\x8f\x8a]zB\x98\xb9>\x00\xf5@\xbe3\x18\xc8\x8c\xf80+\xce\xb3\xb3\xb9\x87\"\xbf\xb8J*[\xb3i\x06\xe9g\x15|\xfb\xf4\xfd\xeb\x97\xcf?~n\xc9\xd6\xdem\x03\xb0\x87!1\xf5h\xde\xc2\xdd\xf33\xe4I\x15\xd9\xe3z\xfb\xb0+8\"\x17\xe4Lh\xbb\xc7\x87\xdd\xdfB{\x9d\xd8i\xc0\x9b\xcb\xb2{\x84\xcb\xe5\xae\x01\x00(K\xd50\x99\x86*9U\x80\x97\xbf'\xcdG:!\\+\xd9:\x90\x00\x85\x14\xb3\x8b\x9e\x17\xffU\xd7\xc2\x19\xed\xfdPu\xe2\xfc\nd\xb0\x8ex^Z\xbee`y\xc7i)e\xaf\xafAt\xc4\xff\x8f\xe0&]\x89K'\xbf#\x0d\xe9\x80\xd7\xd5\xb9F\xc5Q\x96x\xc2\xe5\xf2\xe6m\xaa\xd4\xfdX\x13/lc\x9al9\x98\xaec\xda\xf6}Be\xacq\x902\xd55ArB\xadi\xde\xd9\x1e:\x8d\x8c\xdeSu\xd4\xa6Y\xc3\xf9\x82i\x91\xfax\x8dp\x0bg\xb4\xe6\xcf\x00PK\x07\x08\x92\x9bC8n\x01\x00\x00\x88\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00)\x00	\x00roles/containerd/filter_plugins/filter.pyUT\x05\x00\x01\xf0b\xe7[\x84R\xc1\x8e\x9b0\x14\xbc\xe7+f\xb5\x87G\xb4\x08\x9a\x1e\x91\xb8\xf6\xd6/\xa8V\x95\x83\x1f\x9b\xd7:v\xf4\xec\x1ch\xd5\x7f\xaf0\xacKP\xa5}\x17\xd0x\xe6\xcd\x8c\xe1\xf9\xa9\xbdGm\xcf\xe2\xdb\xdb\x94.\xc1\x1f\x0e\x8331\xe2\x8b\xb8\xc4\xfa5\xd8\xbb\xe3*\x9c\x7f\xf0\x90\x8e\xdd\xe1\x00\x00\x96G\x8c\xf98V\x91\xddx\xec2<\x8fr\xba\xab\xc7\xef\x02\xccC>\xe8\xd58\xf9\xc5\xf6\xbb\\\xcd\x1bS\x87Y\xd7\xec\xf1\xa2\xfa\xb3\x18=c\xcfX\x0d\"\xd2\x851\xde\x9d\x837WF\x183\x90\x97\xc3DH\xa2\x88\x98\x82\xb2\xc5yZw\x0d\xc1'#\x9e\xd5\xd6\x90\x86\x1b\x90\xf2\x9b\xc4\xa4S\xf7\x99 \x11d\xc3\xf0\x93\xb5\x91\xd0:9\xab\xd1\xa9\xdd0J\xf5}\xa6|\x07u\x0e\xb2\xb9\x89\x9b\xd1\x14\xd1g\xb8\x897'\xa9\xa2\x96j\x9c\x8e\x85\"#\x1c\xfb*3\x8f\xe8{\x9c\x10\x14\x155\x04\x1f\x12\xc4/K\xbe}z\x85\xf1\x16\xd4\xfd\x1f/\xa4\xa7\x1e\xe4\xc2`\xdc%\xc4D\x9b,\xf3\xd8p5\xe2k(\xcfO\xcb\x8a~S\x98\x96\xfcE\xc1.\xf2\xc7\xfaw\xe7z};\xbd\x16\x89\x8c\xab#\xfa\x07\x9f\xa5I[\x9a\x948\x8fn\x0f)\xdf?\x06\xe1\xe5\xdf\xc1\xfe\x97[\xdd^@;\xde\xdf\x01\x00PK\x07\x081\x0c*hH\x01\x00\x00\xe2\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00roles/containerd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[T\x8d\xb1\x0d\x031\x0c\x03{O\xc1\x05\xb2\x80\x96	\x98\x88E\x80\xb7\x0cHj\xbc}\x00\x7f\x9a\xb4G\x1c\xef\x81\xe0\x94!u-:\x18\x8eT5\xb3\xf1^\xd1\xfc\x84\xd2\x07P\xbbZ\xd3m\x00\xf8)\x7f;P\xcd>G\xc7\xd6\x0d\x15|]r\xc3V\x1d\xe0\xd4\\\xf1\xbcs\x86\xad\x1a\xdf\x01\x00PK\x07\x08}\x1e\xbb_X\x00\x00\x00\x83\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x00roles/containerd/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00$\x00\xdb\xff---\ndependencies:\n - role: manifest\n\x03\x00PK\x07\x08\xccd6\xd0+\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/containerd/tasks/binaries.ymlUT\x05\x00\x01\xf0b\xe7[\xbc\x95\xcdn\xe3:\x0c\x85\xf7y\x8a\x03o\xbci\x8b\xbb).\xe0\xed<F\x11\x18\x8aE\xdbBm\xc9\x10\x99\xb4F'\xef>\xa0\xfc\xd3\xa2\x19O\x9a\x023\xbb@\xa1H~\x87\x87\xf2=\xbc\xe9\xa9@f\x98)\n\xde\xdepp\xde\xc4\xf1)\xef\x8dw5\xb1\xe4\xfb\xa7|\x88t0\xcft?\x18i\xf3=~\xc2\xba\xa8\x17q>\x83^\x1d\x0bg;\xa0v\x1d\x15;\x00\xd0\xb8\x02\xd9\xed\xd94\x0d\xc0b\x84\n=\xa6JB\x1c\xd3a\x1f,\x15\xf8\xef\xff\xc7\xc7\x1d\xf0\xd2\x92/`\xc3\x8b\xef\x82\xb1\xa5\xebMC\\\xba\xba\xec\x1d\xb3\xf3\xcdn\xb7\x92U-U\xcfp\xf5\x16\x9br\xe4{%q\x0c#\x90\x960w\x988\xb4%m\xe8\x1bd\x13O\xa4\xc6\xb1P,\x96\xb4\xb6\x9cT\xb9\x8d\xe3\xcf\x13z\xa7h\x0d\xc3 Q\xf3\xb1\x87\x04\x9c(\xbazL`\x8bb\nU\x1b\xd7MP=7\x052\xfd\x7f\x19\x13l \x86\x0f\x82\xd6\x9c(]\xdd\x90o)T\xca8\xcc:\xae\xb5\xc3\xa6\xe8\x1c\xab\x14{\x07'\xa8\x8c\xd7J\x87\xf7\xf6\xc8\xe2\xc5I\x1b\x8e27\xef|\x03'\x0f\xf8\x11\x86	D\xbd\xa6l\x1b\xf9?\x19\xf6|F\x8882\xc1 RG\x86)\x15H\xa9\x96v\xb3/\xcc\x03\xc6\xdb$\xcb\xa7Q>\xa8C\x1e\xa6UXc~\x07\xbe\x14\xcb\xf7\xdf\xb2\xe8T\xe1N\xed\xac],z\xa9\x12\x8aB\xaf\x03UB\xf6\xc2\xc0\x0dIy\x8c\xf3\xb8\xf5\x07\xb2+\x93Q5\x00K,_^\xe4\xe5\xd2\xc2\xb8}\xf1\xd24\xc5\x95\xc85\xfd\x0d\x9b\xef<\x8b\xe9\xba\xeb+\xb3\x11p\xa2\xc8.\xf8\xb5\xf4\xd1\x9bX\xb5\xee4?r\x1c\xab\x9b\xa5\xb9\xd4SO>\x04D\xea\x83P\x99r\x8f\xc4;\xe8\x9c]=\x16\xea\xdb`l2W$\x16\x13\x05U\xf0b\x9c\xa7hW\xeb.\xf6\x99;M\x8f\xf5!\x84\xee/\xcaR\x85a\xfc\xa7\x8a|\xfa\x16\xdc&\xd0\xc7\xc5\xbc\x10\xe9\xd7\x00PK\x07\x08\x89qK\xb7\xef\x01\x00\x00\x0f\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00roles/containerd/tasks/images.ymlUT\x05\x00\x01\xf0b\xe7[\xacT\xddn\xdb<\x0c\xbd\xf7S\x9c\xe6C\xe3\x04H\xd2o\xbb4\xd0b\xc0\x1e#\x08\x0cUbl\xad\xb6\xe4IL\xd24\xc9\xbb\x0f\x92\x95\x9f6k\xb1b\xbb\xb3A\xf2\xe8\x1c\x1e\x92S\x18\xd1R\x01Y\x93|\x82^B\xb7\xa2\"h\x0f\xd18\x12j\x8b\xc6\nE\nl!\xada\xa1\x0d9\x95\x01\xbe\xa6\xa6)0\xd8\xed \x9d\x96\xdc\x94\xb2U8\x1c\xa0\x8d\xefH\xb2\xc6\xf4'X\xc9\xbb\xdd\xae\xc7\x9c\xe7\xde\xc9|\x11R\x1e\xee\x14\xad\xef\xcc\xaai\xf0\xf5a\xf8\x05\xc3!H\xd6\xf6\xf8\xd4~\xdf\xff\xb6\xda{m\xaaA\x068\xaa\xb4gr\x05\xb4/#\\\xd9'g\x80\xac\x85\xa9H\x95\x9b\x9aL\x01c\xb3l\x8a\xc7\xc6\xca\xa7\"\xcb\x00\xe0\x1d\x89\xc2\xc9Z\xaf	\xf4\xac={\x08\x06\xd7\x84\xce\xd1\xa3x\"t\x82\xebX\x0dx\x16\\\xa4o\xc4@/;\xa9J\x15\xd3\x10\x88\xf2\x06)\xf7\xcc9\xa5\xa8\x9ey\n\xf7t\x95\xdd\x98\xa0\xa4\x0f\xf9R/\xcb$\xfb5y\xe1=\xb9\x9eb\xccD-<\x04\x94\xae\xc8s0\xa7\x0b\xdd\xd4\x9c\xc0\x97B7g\xce\xad\xaf\n\x0c\x82\xbcV\x18\xbd\x0c\x15\xca\x92\x87\xb1\x8cZ\xac)\xc2\x9e}\x8a\xb3\xe0Wm\xc9\xdb\x8e\xa2\xa4\xe3;v\x89k;'\xd0\x0c)L@{\xa4H\x84\x146\x9ak\xbbb\xac\xc9\xe9\xe5V\x9b\n\x9ag\xf8n\xbb\xed\x85\x88\xa3\x07l/p\xaf\x1a\n\xeb\xb0\xf2\x04\x01G\x0d	O\x11<\xc2\xf4\xbc\x06\x7f\xd8R\x08\xa3\xa2\xe8\xd7\x86\xcc\x82\xc3\xb3\xe3\x1c\xa4\x947\xbd\xc8\x17\x9f\x18\xa6IX\xa4\x00\x12zq\x96\x9bX\xa6\xcdyH\xbf\xc0\xee\x16\x9e\x18\x8e:\x8b{\x8c\xe6\xc7\xb7\xe3\xbe\xcc|\xd7h\x1e\xe5E>\x9e\xff\xbf\x98 \xff\x96O\xde1j\x82\xbc\xb8\x0e\xe6\x0b,\xb0\xc7\x0f\xab\xcd(\xcf\xc7c\xdc\x1eN/\xcf1\xbdt\xf4\xba\xf3\x0b\xec\xf7\xa7\xec\xd1\xd5\xaeG}\xbb]O\xfdp\xc0pxJ\x0e\xb9\xecNG!<\xe0\xc1\xa2:\xa5\xefa\xackE\xa3_\x8e\x8b\x11\x10RY\x00,\x9d\xb5\x8c\xc3\xe1\xfa\x80\x8c?\xb5B\xff\x9d\x1d\xf0\x10\x8e\xe0\xc5:\x0dh\x8c\xe4\xe1J\xe5a&\x96\xfay\x02\xcb5\xb9\x8d\xf6q+\xb6\xb1\x80EU\x91z\xe5\xbfn;\xeb\xd2\x94\xf8\xf7\x8c\x9d\xe3\xe6s\x0d~~\xc1T\xc9\x8f+\xf6\xbfom\"4\xbd\xf4`\xf4/\x8e\xf3\xc5\x00|\xe4i\xe2\x1bg\xf6\xaf\xbc\x1d\x07\xd7\xfa\xe3\xf8\xe6\xd6\xcf<\xabpRn\xee1h\xacP\xa4\x06\xd9\xaf\x01\x00PK\x07\x08Xx\xdc{\x80\x02\x00\x00\xc0\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1f\x00	\x00roles/containerd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xacV]o\xdbF\x10|\xe7\xaf\x98:\x0f\xb2Q\x8b*\x82\xa4\x05\x88\xb4@\x11\xb4@\x11\xb4(\xd0\xbe9\x06q\xba[J\x17\x1d\xef\xd8\xbd\xa5R\"\xd6\x7f/\x8e\x14M\xcaVR\x1b\x88\x1e\xfc!\xee\xee\xec\xcc\xdc\xedr	\xafj*\xf0F\x07/\xcazb\xf3S\x06\x18Z\xb7\x9b\"\x03\x80:n\n\xfc\xc9\xd6\x0bDmN\x02Q\x05\xc6\xbbvM\xef\xac\xe0m\xf0\x95\xdd\xb4\xac$0\x1a\xc5\x918\xcb\xc6\xf2o\xb7\xa4wP\xcea\x1b\xa2D(&D\xb1\xceA\xed\x95uj\xed(\x03\xac\xd7\xae5Trp4\x80\x0f\xcd5L:\x15\xe8\xbf\x12\x15w\xb1\xac8\xd4E*X\xf6\x05K\xe5\xec\x9e&<\x17\x94\x81l	;bO\x0eu0\xad\xa3\x08\xa6\x7fZ\xcbd\xb0\xee0\x11\xc9\x90\x02\x1a\x0e\xeb\x13\xd8\x8bO\x9f`\x85j\x1c\x0e\x17=t\x14%C;\x91\xbcd\xc0G+\xdb2\x85\xc4!z*Y\x0e\xc0\xe5\x08\x9cJ\xdcw\xd7\x10G\x1b\xe5Y\x0d\xea\xd0tCs	\x84\xbc\xfc\x1f\xe2\x1d>\x04\xeb/\x17\xef\xfd\xe2\n\x87\xc3{?p0\x14\xa5\xc0\x8aD\xaf\x8e\xbd-\x93X\xb9YMp\xb9\x0e\xbe\xea\xa3\xeb`\xa8\xc0w\xdf\xbfz\x95\xba\x1f\xfd\xe9-(\xb0\xb6^\xb1\xa5\x98w\xb5\xcb\x00\x17B\xf3\xa8\xa91\xe6\xa8a\x8a)\xd3c\x0en`\xe3\xd4\x9a\xdc\x90\xd6\xc7v7\x8bZy[Q\x94\xc5\xed\xcd\"	\xb6\xb8\xbdw\xa0\xcf\xdf+>\x82w\x93\xa6*Fb\x19\x88ML@\xff\xda(1\x03*;\x9e\xa9F\xc9\xb6x\x188\xf7\xd7X&-\x81\xbb\xb9\x04?\xbc~=\x811yC\x0c\xa1\xbaqJ\x08\x12pJ;	h7e\x82\xc2\xe1\x90\xe1>t \x1dY\x178m \xfdY\xd9M.\xa1v\xf9\x87\x973\xaf.\xbeT\xfab\xdec\xb2	`\xda\xd8(\xc4\xc5\xe3\xac\x0c\xf0Al\xd5\x15`J\xb6Cy\x03\xa6(\x8ae\x16\xfeE\xa6\xab6\xf2\xca\xd9\xf5*vQ\xa86\xc7\xdf31\xf3H\xbc\xb7\x9a\xce\xf3~r\xfa\xa9\x0c\xcf\x82}\x8a$\xad\xb7\xf2u\x04\xe9\x9dd\xab\xc5\xe5\x9d\xea\xef\xc2\xe7\xec\x9e\x82\x1e\x90{\\\xe2\x84B\xb6DM\xa2\nT\xae\x8d\xdbr\xab\xbcq\xc4\xe9\\\x7f\xdc\x92?\xe3t\xae\xb7\xcao\xc8 \xf0\xfca\xe2<>\x9aL&_\x05\xd64\x0bL\xa3\x8e\x85\xd2\xb58\xda4\x1f\x8c\xe7\xaf\xcd\x94\x02\x90O#\xdd\x14\xe8(\x9e\x99\x1c\xe3\x0d/\x9b\xe1\x18\xf6\x13${\x91\x06\"\x13l\x84\x0fG\xcf\xb8\xeb\x87\xec\x0c\xf2\x1a\xb4'\xee\xe0\x83\xa1~\xce\xc7\x94\x06[\xab\x0dE\x84*\xfd\x97\xbd\xc0\xef?\xff\xf1\xdb\xaf\xbf\xfc\xf57\xd2\xa6H\xdf\xa5-\xb2V;\xea\xef\x7f\xb6\xc4\xda\x05\xbd+\xb2\x9e\xd6\x12\x91\xa4\xac\x94\x96\x81f\xfa\x0c\x05K	\xe5\x84}\xdc	g\x9e\xdc\x19\xaaT\xeb\xe4\xf2\xe6\xf6\n\xdf\xe22-\x85|\xaf\\K\xc3\xcfxyu\xe7\xd2\xcc\xb7U\xbfS\xf2\x1du\xf8\xe6G,\xb4\xb7\x0b\x90\x8b\x84s)\x91\x1ciQ\"|9\x8c\xc2k,j%z\xbb\xb8\x86\xf66o8\xec\xad!\x1e\x8a_\xdd\xcf\xc93\xab\xa9e&/\xe5(}n\xa8IG\xd9k\xdb/\x0bc\xb5\xbcL-\x8c\xa3:}\x86\xc3u\xda\xef(\xc6\x92[/\xb6\xa6\xc5(\xe1\x83\xed0\xa8t\xdc\x0d\xe3\xec\xfe\xbc\x803\xd4\xc7K\xe2\xc1\xa2\xe8\xf3\x1f\xed\x86\xd3\xfd\xd0\xc7\xa4\xd6Dmbql1\x1d\x97\xf2\x9c}\xd3]x\xb3z\xf2\x9b\xd0\xea9\xafB\xff\x0d\x00PK\x07\x08y$\xc9]{\x03\x00\x00i	\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00roles/containerd/tasks/manifest_preload.ymlUT\x05\x00\x01\xf0b\xe7[l\x90\xcd\xae\xd30\x10\x85\xf7~\x8aC\x90\x9a\"\x91\x96u$\x16,@b\x01\x1b\xd8!\x14\x19{\xd2\x0c\xf8'\xb5'*\"\xc9\xbb\xa3\x84\xb64Ww9\xf3\xf9\x8c\x8f\xbe\x97\x90\x8e\xd0'rQ[\xb4\xec\x08\x9c\xe1I\x07\x81D\xac[\x8901\x88\xe6@\xc9B;\xb7f,\xb5\x1c\xc8.\xd9\x1f\xfa\x17U\xbd\x96.\x83\xc3\n?\xbd\xfb\xfc\xf1\xc3\xfb/_U\x85\xa0=\xd5H\xa4\xed}\x0b\xf6\xfaD\x9b\xe4\xa6\x82\x02rG\xce\xd5(\xbe\xa1jqL1\xca\xd1\xeb\xc0-e9\xe4d\xf0\x1d\xbb\x1d\x8c\x96\xe7\xd84\x81L\x17Q\x96\x85\x02\x12\x9d8\x0b\xa5\x1a\xb7\x03MNF\xa9\n\x99\xa4i\xb5\x91Z\x01\xf8O\x97:\xb9\xb9\xf6\xa9Q\x8c\xe3&y\xc8b\xe3 \x8d\xe3@\x19\x13\xbc\xee\xf7\xa5$\xf6\xe5+LH\xf4\x93\x8c\xecK:\x0f\xdaI,_\xa3\\\xf7\x8e\xb3`\xc2\x10\xf8<\x10\xe6\xb9Pw5\x0fn\xd9\xf71\xc9\xc6\xe0]\xcc\xd5\x96\xc5\xda\xefA\xd1\xef?\xa8\xaci1\x8e`!\x8fy\xc6\xb4\x0cFRc\xbc]\xe6\xd5w\xbe\x9d\xaf\xb6Z\x96w\xff\x88\x02Z\xcd\x8els\xe9(<\x92C2x\xf1\x16o\x14pa\xe9\x9a\xe5\xa3\xfc\xc4\xcd\xc6\x1b\xe6\xb9P\x7f\x07\x00PK\x07\x08\x85\x04\x7fXB\x01\x00\x00]\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/containerd/templates/etc/containerd/config.toml.j2UT\x05\x00\x01\xf0b\xe7[t\x90\xcdN\x840\x10\xc7\xef}\x8a	w\xcb\xe2\xc5p\xf0I6\xa6)\xed\x80\x8d0C\xa6\x03q\xb3\xd9w7\x05\xa3\x98]\x8f\xcc\xff\xebG\x85Y\xe1\x15\xaa\xeb\x15\x02\x93\xfaD(\xd1m\xd7\xdb\xad2Y\xbd\xe2\xbd\xbe\x9f\x8b\x81yr9\xb0\x14\xd3S\xdb\xb6\xc6\x9c\x07\x99\xc3\x9b\x01\xf01\n\xe6\xfc \xcd\xe1\x03\xf7~s\x9e\xc7eH\x94m\x90TBY\x05\xfd\xe42\xca\x8a\xe2\x0e\x15\xcd\xf3\x8b=\xd9\x93m\xaa\xe2\xf2\x14;\xfeti\xf2\xc3#\xbc?r\xc1\x048\x0e\xd9_o\xd9\x04\xc8\xe4\xe7\xfc\xce\xaa(\xa5\x8dW\x94\xd1_\xfa\\\xb6\xfe\x8d\xda\x88\xbd_Fu\xb2\x90\xa6	\xf7*\x80\xefO\xa7\x97ycK|\x0c\xc9B\xc1\xae\xcd=\x12m\xff\x0f\xd0%r1m\x1c5\xcfZ\x07Ju\x97\xa8\x04\xa0\xbcb\xff\xa3\xa2\x86M%T\x1b+\xf35\x00PK\x07\x08\x0b\xcd!\xd0\xe2\x00\x00\x00\xcb\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00-\x00	\x00roles/containerd/templates/etc/crictl.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x00l\x00\x93\xffruntime-endpoint: unix://{{ containerd_socket }}\nimage-endpoint: unix://{{ containerd_socket }}\ntimeout: 10\n\x03\x00PK\x07\x08&\\g\xd3s\x00\x00\x00l\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/containerd/templates/usr/lib/systemd/system/containerd.service.j2UT\x05\x00\x01\xf0b\xe7[\\\x8e\xc1\x8a\xe2@\x10\x86\xef\xfd\x14y\x81\x98]Xw\xd7\x85>\xb8\xea\x80\x8c\x1a13\xccAD\xcaN%\xd6\xd8\xe9\x0e\xd5\x155\x88\xef>\x8c\x0e\x06\xe6V\xff\xc7W\xf5\xd7\xfa\xd5\x91l\xd4\x18\x83a\xaa\x85\xbc\xd3\xc6;\x01r\xc8y\xf4\x18#n\x9cP\x85j\xecMS\xa1\x13\xb8\xa9{\x91:\xfcK\x92n\xa5G^\x0d\x0bA\xd6\x0e\xe5\xe4\xf9\xd0\x13\xe0\x12%\xb2\xde\x80\x8d\x8b\xf0\x95\x95Zg\xc8G2\xb8Q\x933\x9aL\x80e\xc9\xa8\xe3$\xec\xc8%\x95\xcfk\xf6;\x8c\xfc\x11\xd9B\xdbI:i\x02'\xb7s\xc9\xa7\xd9uGql\xbc+\xa8\x8c.\x97\xee\xf5|{\x87\xdb\x1ad\x1f]\xafj\x8c\x16K\x10\xd4-\x06\xf5L\xd6\xce}\x8e\xbafo0\x04\xb5\xc2pk\x01{\x82\xf6\x1134\xba\xaffT\x91,\x96\xabt\xa4\xc9\x15\xe4H\xda;\x1b\xa5\xab\xc97\xb4H\x9f\xa6\xb3\x89\xfe\xf9\xe3\xd7\xdf\xfe\x9f\xdf\xea\x05\xc2!\xcc\xe1\xdcYi:\xcf\x8cg\x1c\xe6\xefM\x10\x1d\x0f\x06\x03\xa5\xd6S\x17\x04\xac\xdd\xa87p\x82\xf9\xffVW\x8d\x15\x8a\x9b\x80\xdc\x13\xe0\x12E}\x0c\x00PK\x07\x08\x17\xcf\xca\xfd&\x01\x00\x00\xb4\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1b\x00	\x00roles/dns/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00}\x00\x82\xff- name: reload and restart network\n  systemd:\n    name: network\n    state: restarted\n    enabled: yes\n    daemon_reload: yes\n\x03\x00PK\x07\x08\xd0@\x0f5\x84\x00\x00\x00}\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x18\x00	\x00roles/dns/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xcc\x94AO\xdbL\x10\x86\xef\xfe\x15\xef\xe7\x0bp@\xbe\xaf>*\xa14m#P\x82\xe2\x88K\xd5\xae&\xde\xb1\xbd\xcdf7\x9a]\x87\"\xe0\xbfW\xb1]\x8a\xaa\x16P/\xed\xd1\xe3y\x9f\x19?\xb2\xe6\x14\x9e\xb6\xac\xf0\xbf\xf1\xf1M\x06\x18^w\x8d\xca\x00`\x1b\x1b\x85+\xb1>!Q3t\xa0\x0e\x82\x8bn\xcd\x176a\x12|m\x9bN(\x05\xc1\x8e$\xb2d\xd9)\xd6.T\x1b\x95e\xc0w\xf8\xa4\xe5j\x03r\x0em\x88)\x82\x84\x11\x93u\x0e\xb4'\xebh\xed\xb8\x9fh}\xe5:\xc3Z\x82\xe3a\x07\x8c\x84\x9dpu\x80\x8c\xc5Dq\x13u-a\xab\x0eX\xddc59\xbb\xe7a\xae\xb3\x9e\xad\xaf\xed\x0f\xce\x8eR\xabPp\xaa\x8ax\x1b\xab~\xf5\xc2s\xba	\xb2)\x86\xc7\x11\x1e\x13\xa5add\x9f\xc6\xa2p\xc3_w\nG\x9f\xe7\xd3\xd5d1\x7f7{\xaf\xdf\xceK]\xae\xceW\xb3\x89.\xa7\xe7\xcb\xc9\x87\xcbY\xb9:\x1a\x03\x87\x0d\x14\x8e^\xe8>\xcb\xef\xee`|\xd4\x91I\xaa\x16\xf70\\S\xe7\xd2\xf1\xc7\x1c\xf9\xa7\x13\xdc\xe3K\xb0\xfe8G~\x82\x87\x87|\xa0\xdf\xb4\xec\xd5\xd3\x98\x8d\x87\x9c\xf5l\xfa\xf7>$[\xdf*\x08\xbb@\x06\xe4\x0d\x84c\"I\x18\xbf\xf8oYZ^O\x97\xe5\xeb\x14\xf5\xadO\xfc\xc8\x9e%\xfeN\x10\x9e14\x04\xffuE\xcbi\xb9\xb8\xbc\x9e.\xf5\xe2j5[\xcc\x9f\x97\xf4s\xf3\xa3&\x92&\xfe\xcaB_\xffC\x05[N\xa4P\xbb.\xb6\xba%o\x1cK<\xfc?\x83\xe3\xca\x85\xce\xe8\x9d\x84\xbd5,\xf8\xef\x0cyLTml\x9ee\x8f\xc7\xa5x\xf9\xba\x14\xaf:/\xdf\x06\x00PK\x07\x08\x83\xeb\xc5{\xac\x01\x00\x00\xaf\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/docker/registry/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x9cR\xc1n\xdb0\x0c\xbd\xeb+\x88\\\xbc\x1d\x1c;Y\x8a%>\xecG\x82@`$:\x16,K\x06\xa5t0\xda\xfc\xfb \xdb1\xb2\xb6\xd8\x8a\x9el\xf0=>>=\xd2\x04\xd9\xa0T\xf6\x1a\"q\x05\xab\x97\x17\xb8\xb0\xbf\xf6\xe1\x98u\x98j\xd9	^\xc1\x92\xbb\xc4\x06~\xc1\x06\xd0i\xf8\xe6|\x04m\x02\x9e-\xc9\x89&\x1b\x84W8{o\xbf\xc3\xed\xb6\x12\x82\xe9bB\xe4A\"\xab\xc6<\x93\xec16\xd3\x00ue&\x17e\x87\xce\xd4\x14\xe21\xd3\xd4\x93\xd3\xe4\x94\xa1\x90\x9d\x8e\x99\xf2L\xe9{\x17I\xff=\xd3\x19[\xca\x93Nv\x1a\x87,3L\x87\x17\xaa`\x15\xb5*\xbe< \xb0z\xab{\x17\xf8\xba\xef7a\xf4\x9ec\x05OeY\n\xb4\x91\xd8aL\xd9,\x0f\x99\xf0\x1f\xdbrS\n\xa1\x88\xa3\xd4\x86+((\xaa\xa2o\x8d`\xef\xa3T(\x15GY\x1bK\x0e;\xaa`\xae\xae\x15\xc7\xa9\xa9\xa5\xe1\x01v^\xd3\xba\xa5a\xc2\xfen\x1d\xb1\xd4'\xb4W-\xf1\x83\x95q_\xc53ra\xcd\xb9\x98\xe0\xe2\x0e\xdf\xe9\xa3\xe4LM&gZ*\x87\xb5~'\x1aH1\xa58\xb1\xae\xb7\x9b\xbd\xa6\\\xed\xd5>\xdf\x95\xe5.?\x94\xf5S\xbe\xd1\xdb\x03\xe2\xcf\xdd\xe1P\xd3J\x88\x1a\x8d\x95\xa6\x9e\x16\xbc\x9cRgB0\xee\x92\xdc\x0b\xed\x7f;\xebQO\x94\x90\xc8\x0b<PX\xde5:\xaa\x04@\x0e\x81\xd5\xe35\xce)\xc3\xed\x96n\xe7\xa3\x84\xc7%\x02\x00h\nS\xee\x15\xccq\xffW\xef]\xe6\x1f\x8aYC.\xae\x15}Z\xf1q\xc3\xffPli\x10\x7f\x06\x00PK\x07\x08\xaa\xc2\x8c\xc6\x99\x01\x00\x00\xe3\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/docker/registry/files/etc/systemd/system/registry.service.d/registry.confUT\x05\x00\x01\xf0b\xe7[\x00j\x00\x95\xff[Unit]\nAfter=docker.service\nWants=docker.service\nPartOf=docker.service\n\n[Install]\nWantedBy=docker.service\n\x03\x00PK\x07\x08m\xa3$\xadq\x00\x00\x00j\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/docker/registry/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\x8d\xc1	\x031\x0c\x04\xff\xaeb\x1bH\x03n&(\xd1\x12B\xce2H\xfa\xb8\xfb\x80\x0e\xfc\n\xf9\xdcw\x96\xd9\xb9\xc1d\xb0\xc3yLQ\x88)\x9c\x91\xe2	\xe7\xeb\x1d\xe9\xab\x01\xb1\"9\xb47\x00[\xd8+\x10)Y'eR\x0b\xd2\xe4qP;\x16\xa3\x80\n\xc7\xb4\xfb\x99:q\xfb\xd3\xd7\xf9\xfc\xd0\x7f\xd7\xf7v\xa1\xfd\x1d\x00PK\x07\x08:\x1cM\xc4d\x00\x00\x00\xfb\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/docker/registry/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00$\x00\xdb\xff---\ndependencies:\n - role: manifest\n\x03\x00PK\x07\x08\xccd6\xd0+\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/docker/registry/tasks/certs.ymlUT\x05\x00\x01\xf0b\xe7[\x9c\x91\xcdj\xc30\x10\x84\xefz\x8a\xc1\x17\x9fJz(=\xe8UJ\x10B\xde\xc4\xc2?kv\xb7MM\xf0\xbb\x17)!!-\xed\xa1\xc7\x19\xd0\xcc|\xda'\xccq\"\x8f&\xaa\x92\x18\xcegt\x9c\x06\x92 t\xccj\xb2\x86\x9e\xd5\xb0m\xa0\xcf\xac\xa6\x8d\x03\x0ey$\xef\x00`\x89\xd6{4\xf7W\x89\xc4Bq\xb1m\xbb\xdf\xc3J\n\xa0\x16\x8d<\xba,\x94\x8ce\xad\xe6\xc4\x1dy\xbc\xbe<W\xc5\xa7\x99\xc4C\x98\xad\xea\xa3\xf0\xfb\xe2\xaf\xb1\xce\xdd\xe6\xeb:\x8dy\x1eP\xfa\xf3!\xa7h\xa4\x88s\x87\x81V\x18\xff\xc1\xf5\x08\xa4\x92.<\x851d\xa3\xe9\xadUI\x15\xa9\xdd\xdf\x96w\xa4\xf6\x1f\xee\xddcr\x89	E\xb7\xfb\xef\x9fRh\x1cp\xca\xd6\xd7\x19\xfa\xa3N\xafkF\xe6%$\x9eMx\xbc\\\xa5:\x1fQ\xfc\xbd\xcb\x01_\x03\x00PK\x07\x08\xc2u\xca\x98\xdf\x00\x00\x00\xeb\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/docker/registry/tasks/images.ymlUT\x05\x00\x01\xf0b\xe7[\x9cV\xd1n\xea8\x10}\xe7+\xce\x8d\xda\xba]5\xa4\xbd\x8f\x91\xb8Zi\xa5+\xed\xcb>\xac\xb4O4B\xae3\x10/!\x8el\x03\xe5\x92\xfc\xfb\xcav\x08\x81\x02{w\xfbT\x98\x99\xe3sf\x8e\xc7\xc4\xa8\xf8\x8aR\x88\x82\xc4\x12r\x0e\xb9\xe2\x0b\x824\xe0\xa5&\x9e\xefP*\x9eS\x0e\xabP*\xc1KhZHc\xf5n\x04\x98\x82\xca2E3\x02\x80\xfd=\x0c\xd9P?\xd3T\xab\x99\xe5\x0bL\xc2\x17Sf\xb4`\xd9\xd8\xd4\xa5\xb4\x8f,eO\xb8o}\x99X\xeb\x12\xb1A\xac\x90\xe4\xb4I\xaauY\"\xfe\x1d\xf1\x16\xd1\xfd\xbe\xb0\xb6\x9e	\x95S\x1b\xe1\xcd\xe7\x03q,\xb8 m\x91\x90\x15I\xae\xc4\x92t\xe2\xbe0\xe3<\xf1\x1c\x0be\xec[\xba\xdf\x1f\xa8\xcej\xa5m\xdb&\x82\x8f\x85\xb6=\x90\x037ir\xac\xb9P\xb2\xf9\x9ax\x01&\xb1\xb9H\xf6\xfb3}\xd34~\xcd\x9a\xbf\x95\xac\x82\xa8\xb6MV\xbc\x92s2\xd6\\\xc8\x8e_3\xb4Nw8\x86t\nif\x012\xf4y4\x8a\xf1^*\xb1LG^\xee\x95\xf9p-\n\xb9!\xd0\x874\xd6\x80[\xd8\x82Pkz\xe7KB\xcdm\xd1i4\x96\xdb\xb4\xfb\x1f>\x90\":\x10\x9b\xb2\xae\"v\x01\xe6\xb8E]\xee\x91`\x97\x92\x07\x9a]x[P\x95\"W\xdb\xca\xd1\x0e!3\x93\xf3\xd9J\x1a#\xab\xc5)yn\x8c\x9b\x97\xa3\xe83Qp\x03\x8e\\.\xc8X\xe7\xac\xda\x0d]\xda\x0e|\xceey\xe4\xbc2\x8b\x14\x91\xab=t\x16\xb9\"\x83JY\x14|C\x1e\xf6\xa8\xc8\x1b\xd9\xacW3\xbb\xab\xc9K:\x9c\xa3\xe6\x834oG\xb4\xed3\xa4\x85\xe0\x95C{'O\x84rl\xa5-\xd4\xdabCZ\xcew\xb2Z@\xda1~S\xf5n \xe20\x03\xab\x06\xb8\x9f\x1a\n\xa5\xb16\x04\x0eM%qC\x1e\xdc\xc3\x04^\xd1O\xb6\x14\xbc\xca\xbd\xe8\xd3\x81\x8c\xdd\x84\xc7\x07\x1ft)g\xbd`\xd9\x7f0\xd3\xb3\xdb\x02\x0e\xe40]'\xd0\xd1\xa5\x8f\x9a\x84\xa5\xfc\xa2\xcd\xc2&\xf8\xd6O\xad\xdb\x06n\x0f`\x82\xc7\xe9\xb550}\xc9\x9e\xc1~e\xcfW\xe6\xf7\x0c\x96~\x0e\xb2\x0c\x19\x1a\x84{\xc7\x9e\xfae\xe2\xfe\xc2B\xf0\x93ts\xf1\x0c\xda\x16\x0f\x0f\x87\x88[K\x83\xc0\xf0^wk*\xe4\xf7ZV\xcb\\j\xc4\xf5\xf517\xc8\xa5v\x8b\xf4\xac\xb2;\xd1x\x9b^>\xa7\xe9\x8f\xf9\xf8\x81\xf8\x07\xbe\xdd2\xd3\xcf\xde>\x97\x04p\xbd0\xc7{$4qK\xe6\xdf\xae\xff\x89Q\xdc\xe5\x0ed\xcc\xb5A\x07\x89~{M\xee\x1e;\xc1\xee\x13byK\xcaSO\xec\xe1\x01\x8f\xae\x80\x1c\x00\x89B!\xba\x1b\xa0Fh\xc0\xb7K\xb0}\xadeeq\xf7\xc7\xf7\x96\x9dTO\xa7\x88\xee\x02B\x84/\x13D\xae\xd5\xd1/\xc8\xb2a\xd6\xe3\x14=\xfc!9 \xc7\xdfY\xca\x8e\xf8\xaf-;\x06\x92O\x81\x85\xa6\x1a\xecm\xcc\x9e\x90\x9d\xb9\xea\xc8\xc2S\xe8?5\xcd\x8d\xac\x10\x19K\xd5\xe7\x1f\xc55\x0dBCn4\xf2\xec\xa1\x8e\x9eNG\xf8'9\xbf\xfbb\xd3?\xe2\x852\xf6\xf4\xe6F\x03\x82W\x9cz\xeb\x99\xbc\\r\xe6\xa6\xbf\xea\x81\x9f\x86?(ju\x99L\xbd6\xc5\xff=6\xbcPg\xaf\xeb\xd8\xd8\xdc\xed\xf5/\x13D__^\xa2\xd1?\x03\x00PK\x07\x08\xdcp\x8c\xd6F\x03\x00\x00\xff\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/docker/registry/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4X\xcfo\xe3\xb6\x12\xbe\xeb\xaf\x98\x97=(\xc1Kd'\xd8}\x0b\x08\xbb\xef\xb2\xa7b\x81\xa2\xb7\x1e\x82@\xa0\xc8\x91\xcd\x9a\"Ur\xe8\xd4M\xfc\xbf\x17\xfc!E^;N\x16\xf5!\x81\xa9\x99\x8f\xf3\xcd7\x1c\x8e|\x03\x9a\xf5X\xc3\x17a\xf8\x06\xed\xc2\xe2J:\xb2\xbb\xff\x17\x00\x02[\xbf\xaa\x0b\x00\x80\xde\xadj\xf8\xcdJM@lul\x0d\x9d\xb1\xf0\xdd\xb7\xf8]\x12|3\xba\x93+o\x19\x19\x0b\x03\xb3\x0emQ\x8c\x1b}[#\xdf\x00S\n\xd6\xc6\x91\x03f\x11\x1cI\xa5\x80m\x99T\xacUX\x00H\xcd\x95\x17\xd8X\xa30E\x90\xc2\x1c,\xf2\x00\x10\x97\x88\xb9\x8dk:k\xfa:\x006\x11\xb0aJn1\xec\xe7\x90\x9a\x8eqJ\xfe\x89_3\xf2\x8b\xc6\x8d\x92\x8e\x1aI\xd8\xd7\x10\x8d\x00n\xe0\xe2\xe9)\x86\xb6e\xd6\xdd\x87g\x0f\xf7%\xd3N\xb6\n\xa3S\xf9\xf0,\xb0c^\xd1e\xa9\x0cg*.^\xc1~\x7fq\x16c\xf0\xad\x92\xbc\x91\xc3\x1c\xe0\xf6\xees\xb5\xac\x96\xd5\xed{\x00\xac\xdc2\xc2\xf3\x08\xe7y\x8c\x10B\xbb34\xde\xc0H<\xceC\x14\x00\x8f\x92\xd61\xb7\xae\x8e8+k\xfc\xe0\xaa\x9e9B\x9bm\x92\x1ah\xeb3\xf2Xt^\xd1\x81\xa0p^\xd1\xb4\xdf[\x88U\x02v\xf0\x0c=\x1b.\x19\x91\x95\xad'\xfc:\xa9\x1d\x8a\xc7U\xaf\xe3\x04r\xe5\x15<C\xf8\x96\x19\x01\x147S\xf5\xc6\x02\xad\x81\xa3%W\xedzU\x00\x84L\xbe^\x90)\xf2\x80[-a\xbf\xaf\xc7/\xb7\x19=\xe6T\xa3#\x149	\xa9^\xefa&\xc0\xf5\xeb\xd4\xe1\x01\x9e\xa1S\x8c\x085<\x83\xd7\xf2O\x8fS\xe47p\x1f\x84\x9a\xdc\x06c#\xad\xeb\xb8\x07S\x84V3\x92[l\x8eL\x1e\x8a\x7fO{\xe3[l\xb6\xd2\x92g\xaa\x91C\xc3\x069\xcb\xc1<\x03!'9\x8b\x89\xffQ<\xa7\x9e\xa9\xf6\xd4\xe3\xb3\xb4B\x19\xafQ\x8f[I\xd7\xacY\xc3\x95\x0f%\x9c\xd7NE-\x1d\x08\xec\xa4Fq\xc6\xe8?_\xa1,C\xdaZe\xf8\xa6.\n\x80\xa3\xca\xe9\x99\x96\x1d:j\x06\x8b\xca0\x11\x8b\xe8\x94\xe1DR\xf6l\x85/f\xa9k2\xe7\xd0\x12\x9c8\x14\x03\xa35\xec\xf7\x80\x7fIG.F\xdb\xc9\xb1\xe5\x02\x84\xc7\xa7OSv\x1c;\x96#FX\x83\x90\x169\x19\xbb\xcb\xcb\xbd\x11X\xc3\xf2\xf3r9\x0f\xc7\xa2\x16h\x81\xb0\x1f\x14#\x042\xb0\xf0\xce.\x94l\x17n\xe7\x08{\x91\xffO\x17L\xe5\xd0n%\x0fW\x03L\x8ec\x94\xce\xf2\x1a\xde	P\xfdq\x97\xbd\x04\x86\xf3\xf6S\x1bO\x84\xfe\xf7\xf1cDy\xe9_\xe3>\x8d\xd7\x92\xe23mHv\xbb MP\x0e\x98\x16`\xd1\x11\xb34\x19\xcf\x93\x82\xday\x8bP>=\xc5~\xd1\x08\x19\x9ad\xf9\x862\x0b$\xfec\xe8\xb9i\xc0~?\x06_\x89\xc5\x1bB\x99G\x1dY\x18C\xd90\xb6\xeb\x83\x95Q\xccO\x9f\xe2\xc2\xac\xbfg\x97\x1b\xb8He2nv\x03\x17#\xd5\x8b9W&D.(\xc8\xb1\x87VD`\xb6h\xad\x14gT>A7\x01\xcd\xa8\xe6\x05ntw\xa4\xf6O\xfa\x1f2\x1fU?\xa3l\xf2\xfd\x91\xeb\x98\x843l\xb9\x19vo1\x1daf\\\xa7\xa5Y\xb4\xafS}'\xc0\xfb\xe9\x8e\x80\x89p\x8f\xc4j\xe8\x94w\xebf\xcd\xb4PhS\xe1\xc6\x1e:\x19\xc7#R\xf15\xd3+\x14\xf3T\xa1\xee\x8c\xe58\x19\x82\xf5ZK\xbd\x8a\x18\x99\xca\x98\xa3\xe41Z\x1e\x16w>f(\xe0\x03hD\x11\x1aL'\xb5\x00\x06-R\x98>\x1e\xd9.,\xa6 \xe3y\x83\x14\x8f\xcbH\xa8\xc3 *j\xd8\xa1\x9b\x87\xf8;\x93\x04~\x08\xcew\xd0K\xed	]\x9c}s9\x93	A\xb4R\xc9\xbf\x93\xae\x8fLR\xd3\x19;\xc6\x1dn\x96\xfa\xcc\x9d\x15\xe4SlW\xc3\xed2{\x90\xec\xd1x\xaa\xe1\xf6\xee\xa0\x8b\xa6A:\xec\x1d[\x84\xd4+\x883\x00\xc4+ \x11\xf1V\x8e\x1b{\xabjX\x13\x0d\xae^,\xa6a\xa1\xfe\xb4\\.\x17\xdb\xbbE\xc3\x191eV\xd9\xda\"y\xab\x1bn4\xa1\xa6\x94\x86\xb0\x1e\x06\x18%E\x18CC\xd6\\\x0d\xda\xe4\x07\\I\xd4\x14\x97\x13\xc1Y\x1b[\x8c]\x8d[jB\x1b\x0b\xd9\x9c.\xe2\xc9w\x83\xbb\xd7]7\xb8;v\x9d7\xe0\xc1\xe4\x9eLV\xa2\xab\xe1\xae8Lg\x01\xf0!\x9aAh\xc8!_\xdcx%\xa0E\x10F#H\x0d\xe1\x9f#\x1cbs\x03Z#\xe4Yp\xd47\xe66\x02\xf5Fx\x85\xd7\xd0z\x8a\x86\xe9;\x08\x1cP\x0b\x07F\xc3\xb0\xcbNFG\x0b\x8b\xbd!\x04m\xc4XS/7\x7f\xf8|\x88V\\\xcb\xac`|5\n\xa1\xa2\x00\xa3\xd5.j\x1dL\xbe\xfd\xfa\x0b\x0c\xd6le\xb8E\xa5\x06\xef0k\xf0\xe3KO\xfa$\xb8\x86\xcc4\xe0\xe49\xf3h}\x9a\xe7\xef\x1f\xae\xe0\xbfp\x19\xee\x91j\xcb\x94\xc7\xf4\xd7]^=\xc7aWvq(\xab6\xb8\x8b\x83\x0c\xd7\xb2\x04T\x0e\xe1\x94\x8bC\x85\x9c\xc2\x84}Y\x06\xe5\xcbk({F|]^\x07\xbe\xd5H&\x81\xcf\xdf\x84\x0e\x06\xbe\x184\xf7\xd6\x86:\x1bG\xa3*e\x1c5\x97\x18fy!9\xdd\x85 \xdc!LlA\x871\x1bMLj\xb47\xd6\xebp\xca\xcaQ\x89\xa3Q6e*\xcf\xb2\xe9\xa3\x8c\x19^K\xe3\xc1\xce\xc10\x1e$k\xd4x\x18\xc3G\xb1\x16\xd5\x0c\xe1>\xa5\xe6\xe1\xc09\xbbo\x99\xcdA\x14\xf9\xcdw\x95\xe7\xdf\x10l\xa8\x91\xe6X\xccb\x9c^\xe1\xa2Lo]e\xa8\x96x\xb17a/w\xf1\xf2Z\xfe%_{\xef\xfd\x01`\xf1\xd3\xbf\x00\xfc3\x00PK\x07\x08cwF\xff\x8e\x05\x00\x00j\x10\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00roles/docker/registry/tasks/manifest_preload.ymlUT\x05\x00\x01\xf0b\xe7[l\x901o\xdb0\x14\x84w\xfe\x8a\xab\nX.P\xd9\x9d\x05t\xe8\xd0\x02\x1d\xda%\xd9\x82@`\xc8'\xeb\xc5\x14i\x93O\xf0 \xe9\xbf\x07R\x1cG\n\xb2\xf2\xde\x1d\xef\xbe\xaf\x90\x86p\x8a\xe4\x82\xb6\xa8\xd9\x118\xa1%\xed\x05\x12`\x839R\xc4,j\xe7\xe6cK5{\xb2\x93\xe9I\x1f\xa98ii\x12\xd8\xcf\xe2\xbf_\xff\xff\xfe\xf9}w\xaf\nx\xddR\x89H\xda\xde^\xc1\xad>\xd0\xca\xb9\xfa[\x01\xa9!\xe7Jd\x0f(j\xecc\x08\xb2o\xb5\xe7\x9a\x92\xecR4x\xc4f\x03\xa3\xe53m\x18@\xa6	\xc8\xf3L\x01\x91\x0e\x9c\x84b\x89\xb7\x80*E\xa3T\x81DR\xd5\xdaH\xa9\x00\xbc\xabS\x9dT]\xfb\x94\xc8\xfa~\xe5\xdc%\xb1\xa1\x93\xca\xb1\xa7\x84\x01\xad>ms\x89\xdc\xe6\xdf0 \xd23\x19\xd9\xe6t\xee\xb4\x93\x90\x7fG>\xbf;N\x82\x01\x9d\xe7sG\x18\xc7L\xdd\xd0,\xe1.\xd9\xdd\x90\\9Y\xcc\xcd\x16p\x96\xce\x82\xd1\xf7`\xa1vN_\xee~=\xab\xa69\n\xa85;\xb2\xd5\xa5!\xbf\x92v\xd1\xe0\xcbO\xfcP\xc0\x85\xa5\xa9\xa6\xa8\xf4a\xfe\n\x0d\xc61S/\x03\x00PK\x07\x08u@\x04\xf8-\x01\x00\x009\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00roles/docker/registry/tasks/registry_image.ymlUT\x05\x00\x01\xf0b\xe7[|T]s\x9b0\x10|\xe7Wl\x19\x07\xe1Np\xdaWf\x9c\xe9S\x1e\xfb\x07(\xc3\xa8p\x01\xd5|U\x92\xe38\xc0\x7f\xefH\xc2\xd8M\x1c?\xca\xb7\xbbw\xb7\xb7&B\xcb\x1b\x8a\x91W\x94\xef \x9e!\xa9\x14J\xcb#\x84B\xdd\xf1\x82\n\x0fP\x15\xd5u\x0c\xbf\xe8\xf2\x1dI\x88\x86\x97\xa4\x10\xfd\xc50,\x84\xcc\xfe\x8ai\xf2=\xcc*$c\x08\x95-\x88Y\xcf\xfb\xbc)\x97y%^\x08\xf4*\x94V\xf7f\xa0\xb6\xd3(\xbaCk\xc8\xd0\x1dte\xca=\xe5\x9a\n\xf4\x92~\xf3\x1d\xa1\xe7\xba:\xcf\xf9\xe8\x01\xc0p\x07E\x1a\x92\xfa\x0e[\x84\xc9\xb2\x9a\x9bt\xa3\xfaZ\xe8\x90\xc5l\x9d|K\xef\xc1~\xb0\xfb3\xa4\xe1\xadx&\xa5\x13f\x9dQ\xfb&\xd3\xc7\x9e\x98\x01\xc6\xb7\x81,E\x8a\x11\x7f:\xd1\x86\x8c\xad\xd7\xb8\x9b\xec<\xb3y\xfd\xbe\xae\x9do}\x87iB\x10\x9c*\x9a\x97\x97\x85k\xde\"\x08\xacV\xb3+\x84D\xd4\xff\x07\x9a\xcd\xcb\x8c\x19\x18Q\x08in{\xc1\x9a\xfb(\xfeB\xd7\xd5G\x0b{}C\xf4\x86\xc7\xcf\xb5'\xb3\xd0\xa1\xa26^.\xe34T&\x9e\xb3F(%\xda\xd2\x03\xb8,Ul%sI\\\x93\x8a\xe1\x0f\xc35\x93\xe7;FF\x9e\xa56DKJ\xec\xe1O\x1c\x97\xbd\xf7\xa7v\x8b\xd9|mW\xe1\xbc\xa6y!\x12\xb7\xd6X\xdb\xe1\x82\x00\xa1\x01\x93!S^u\xf0W\x17\x8a>F\xf0\xc3\x0el\xe8\xa5h5V?\x9f&\xb60\x93\x04\xfe\xca\xb1}|\xd9\xc2\xd7E\xfe\xe0\x7fE\x9a\x9e\x10a\x82E\xf6\x04t\x8a\xd1\x13\x8b\xd9Y\xf7\xfb\xc4\xce\x85\x87\x0f\x85RR\x0f\xf6k\xc3\xd6H\xdf\xa5\xe6<\x81m\xbf\xbc\xc6\xf1\x06\xcaU6\xa2[\xf0n\xa9q\x843\xe1\x86q\xe0\xb5$^\x1c\xe7O\x84e\xba@|\xfc\xbfo\x94.\xba\xbd\xc6v\x0b\xdf\xf7\xfe\x0d\x00PK\x07\x08\xba\xa3}\x8d\xde\x01\x00\x00q\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00R\x00	\x00roles/docker/registry/templates/etc/systemd/system/docker.service.d/docker.conf.j2UT\x05\x00\x01\xf0b\xe7[t\x8f\xcdj\xc2@\x14\x85\xf7\xf3\x14\x03\xd9h\xa9\x99\xa4\xa1U\x0bY\x94bE\x8a(\x86\xd2\x82\x88L&\xb7:437\xcc\xbd\x11E}\xf7\xd2\x1f\xa1\x9b\xee>\xbe\xb38\xe7,_\xbc\xe5\x95x\xd5\x9e)\x0f\xb0\xb1\xc4\xe1\x10\x13\x84\x9d5 \xc4\xb2\xf8\xa1\x95\x88\xa4A\xcf\xdaz\x08\xd5%\x97\x95%\xdd4\xa0\x03I\xebe\x85\xe6\x03\x82L\x07qr'\"\xb9en\xe8^\xa9\x8d\xe5m[\xc6\x06\x9d2\x18\x00I\x95\xed\x86\x94%j\x81T:L\x86\xd17\x1bt\x0e<\xf7\xb2,\xbb\xe9\xa7I6\x10#\xbf\xb3\x01\xfd\x97\xcd\xc7\xb3\xe9\xc3\xdb|1{,\xf2\xe3Q.;\x1d\xed\xc9\x965\xac\x9b\x80\x06\x880\xac\x0d\xb6\x9e\xe5\x95L\xe2\xfem\xf7d=w\xafe\xba\x92'\xe9\xf4^\x9e\xcf\xffM\xba\xbc\xfa\x8be\x8d\xa5r\x9a\x18\x82\xaa\xd0\x90\xc2\x86bWEt \x06W\x89g[\xd7S\xac \xffm\x17b\x01\xc4:p\x8e\xbe\xf7\xaem\xdd\x06\xb8\xa8'\x0c\x06F{\xcb\x05kn)/&\xe3\xf9d>\x12\x9f\x03\x00PK\x07\x08\xbc\xc0\xa9+\x13\x01\x00\x00{\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/docker/registry/templates/etc/systemd/system/registry.service.d/registry.conf.j2UT\x05\x00\x01\xf0b\xe7[|\x91_O\xc20\x14\xc5\xdf\xf7)\x8ed\xa1`2\x16_%{\xd0D\x12_\x94\x84\x18\x1f\xc6Bjw\xb75\xcc\x0d\xdb\x8a\"\xec\xbb\x9b\xad\xfc\x19\xc3\xf8\xd6\xdc\x9e\xf3;\xb7\xa7\xe1K!M\xe4\xdc%\x86T\x10\x97bIj\xa4I\xad\xa5 \xe7\x95\x17Fw\x87S\xae\xccs\xd2\x9d:\xe1c\xa1\x0d\xcf\xf3\xa8qQ|\xbf\xb9\x94\xcc\xec)r\x1e\xbeI\xcc\x0cWf\xaa(\xf0\xdfd\xe1\xeb\x0c\x9e@o\xee\x002A\x08\xef\x07\xee\xc0\xff\xd4\xaa\xb9\xb5$\xc8w\x9e\x92\x86\xf7\x81\xed\x16\x8aR\xa9\x8d\xda,\x9a)\xaaj\x88h\x0c\x93Q\x81\x9a\x02X\xd3\"/y\x1c\\\xb2\xea1<yF\xe2JdrM\x8b\x157Y\x03\xb4 \xa0\xdf\xc7\xa06\xd0\x19\x89DV\xc2m\xc5`\x87c\x0c\xffZ\x82mWJ\x16\x06\xee\xd3\xa4bg\xb40\x84k\x81\xb8\n\xc0L,|v\x8d(jk\x06a\xbb\x02\x1b\xb6\xf7tr\xbc	\xbbe\xa7\xb4\x9b\x8a\xfd\xa1\xf0/\x14\xa9\xa2\x15\xd8|\xc4\x86\x88\xea\xa5\xba\x15\x19\x9e\x1e\xb7\xac7<\x9cw;t\xdb\xecJ\xf7_/\xcb\x83\xe9\xf4\xfa\xb6\xbby\x14\xfb\xe7\x0b\xc0sE<\xde\xc0F\xefKL\xe4\x18s\xa7\xe7\xfc\x0e\x00PK\x07\x08@\x8fM\xad;\x01\x00\x00\xbc\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00J\x00	\x00roles/docker/registry/templates/usr/lib/systemd/system/registry.service.j2UT\x05\x00\x01\xf0b\xe7[\x84T_o\xa2J\x14\x7f\xe7S\x9c\xdb\x98\xd2\xde\x04\xf1\xde\xa4/6<x-\xf6\x92\xedV\x03l6\xc6\x1a2;\x1eu\"\x02=3\xd8\xba\x96\xef\xbe\x01\xc1*\xfei|\x913\xe7\xf7g~sfF?\"\xa1\xc6\xda\x03JN\"Q\"\x8e\xac\x01\x89\x15S\x08\x0f1_ \x81\x8b3!\x15\xad\xc1CZ	\x8eZg\xaa\x90\xacI\xb1\xda\x94e\xd1\xc5\xd7T\x10\xcaz]\x1b\x95\xb0\xb1\xe6\x8b%\xc6\xa9\xf2\x14#\xe5!\xb7Z\x9a\x8b2\xff\xb0\xe2\xc8\x982\x11\xa6\x84U)_\xbf\xd3\xecw\xe4E\xfb\x80\xd02\x7f\x89\xc8\x94s08\\\xbdh\x00b\n#0~C\xe3\xc6L%\x15\xab[m\x10K6C	\xc6+l6@\xa5\xfd\xa0\xa8B\x96\xdd\xc2\xf8\x1e\xd4\x1c#\xc8Y\x00\xb6\xa0 \x8c\xd9\xc4:\xe6\xca\xcb`\x88\x03&F|.V\x18$L\xcd\x0b\xc2-\x11\xc0\xf55\xdc\xe4\x00<`B>\x8f\xa1\xb1'\x03\x1f\xb0\x93ao\x0b\xd07	\x89HA\xe3\xb9\x97\xe9\x07l\xa3\x114\xb6\x84\xf0\x97\x05\xba\x9apS\xff\x1b\xc6\xe3\xfd\x9e\x9b\xd1~\x04[\xb1\x12S\xd31zz[\xffT\xfb'\xd3Ot\x98G\x1d3\xc2\x04\xf4\x97\xa6~\x0b\xe3\xdcT=\"\xc5f;\x97\xb9\xc3\xea\xff\xc7\x07\xd4\xd3\xac\xb7\x96\xc3\"\xe2\n\xf4\xb9\xfb}t\xb1)\xfd\xc2\x11\x00\x0b	\xd9d\x0d[\xe92\xc4\xa9\xb8\x87\x17\xed\xeap\x8c\x8c\xba\xfd\x85\x08\xc3\x1d\xf1\x17\xbd\xb4<\xd1i\xd5\x19)\x8d\xc00hY\xee\xc5@p\xedG\xc7\xf3\xdda\xf0\xbf\xef\x0f\x02\xff\xc9\x0b\xba\xb6\xeb;=\xa7\xdb\xf1m\xcb\xe4HJ\x9a<\x14\x18\xa9&'u	\xf8\xcd\x1e\xd6\x00\x0b\\_Tzr\xecg\xbf\xdb\xf1\x82\xd6\x0e\xc8\xce\xa8x~\xdf\xed<\xdaA\xcfy\xb2\xbd\xa1\xe7\xdb\xdf\x03\xb7\xdf\xf7\x1f\x1c\xd7\xee\xfa}whm6\xd5}\xa9r\xa8n\xc19\x0b\x9e\xddum\xdf\xd2O %rB\x05Y\xa6W\xe0U~\xcf\xf24\x82\x89 \xc82\xb3\xfa\xe4\xa4\x82\xa9\x081b\xcb\xfc\x0e\xb7\x8f\"kS\xfc%\xc9\x02\xd7\x17H\x16\xb8\xfe\x82\x84\xe2X\x05\x9c\x9d5S\xa4Z\xe78\x13W\xfb|\x92mz\xab\xb6\x92@\xabY\xfc\xda\xfb\xc3\x9f\xc4\x94\xa7\xd6\xbek\xb5ZU\xa7Q\x98\xa9\x0e\xa5\xac\x9ez\xfe\xca	\x8f\x93\xa3\xb1\x95*N\xc0P\xf0\xef\x0eT\xbd\xc6\xbd\x988\xda\xef\"\x7f\xbbU*-\xcfy\x1c8\x03[\xd3FN$\x15\x0b\xc3\xb1\xf6\x93E\n'\xff\xad\xade\x1a*a\xa4\x12\xa9\xa9\x18\xcdPi\x7f\x06\x00PK\x07\x08\x93\xb1\xda%\x8e\x02\x00\x00c\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/docker/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x9cS\xcdn\xf3F\x0c\xbc\xeb)\xa62\x0c\xdb@\x9cZ\x01\x92\x00\x02z\xe9\xbdO`\x18\xc2J\xa2\x14\xc2\xab]uI\xa9q]\xbf{\xb1\xb2\x95\x9f\x0f\xdfwH\x8e\x1a\xcep8\xd4r\x01SU\xd4\xab)-ap\xac\x82\xc6\x07\xd4\xbe:R(\xaco\x0b\xe1\x7f	&\x10\xf4\x85\xc0\x8e\x95\x8d\x95<Y\x008b}d\xeb\xcb\x93\x92l\xee\xd0a\xddQk\xca\x93\x92l\xee\xd0b\xddrk\xae\xd5d\x01!Uv\xed\xd4g4v \xa8\xc76\xbb\x8b\x05\x01k\xfc\x1c\x9c\xe5\x8e\x95\xea\xe4\xc3\x04\x9dy\x9d\xa6\xc8\x91fO]\n,P\x92*\x85\xa8\x10R\xf8\x91\x02\x1a\xb6T\x92QD\xfad\x8a\xde\x04\xd3Q$\xc6L\x06\xc2\xae\xb5\x84\x8eDLKX\xd7\xd4\x98\xc1*|\x83l\xf7\x17\xff\xb9\xf9\xd15\xf6\x94\x1c\xe9\xe3d\xea\x88j\x89\x9e%\xc5f\x1a\xd8\xb5\xb3\"\xb2+\xef\xaa!\x04rZ\xd4\xfe\x1fg\xbd\xa9%G\xb6\xfb\x05g\xe8\xdf\x19I\xa0\x96E\xc3\xa9\xe8}\xd0\x1c\x8f\xbb\xdd\xbbL\x87\x1c\xe9\xf9\x8c\x17/:\x9a {v#9\xf5\xe1TD\xc8\x99\x8e\x0e\xfb\xca\x0e\xa2\x14\nnLE\xc5\x15[u:\xac\x0e\xf8\x0f\xb7\xa0\xeb\xecq\xb7\xdb\xe0rI\xe7\xde&\xb4\x92\xe3|If\x80\xafOAr4\xc6\n\xcdp\xc9}\xdc\xfe\xf3\xc3}\xf6|\xbf\xbb\xcf~\xcf\x9e\xd27Mm\xa8\xf3.\xc6o\xb8\xcd\x13 -\xb9O\xaf3\xbf\xeb'[ \xad\x9dL\xb5%\xb8Ae\xfdP\x17}\xf0#\xd7\x14\xf0\xdb\x1fX\x89\x9a\xea\xc8+,/Q\xee\xa4\x10\n#\x05\xf9\x10c\x7f\x88!\xceK\x90\x15\xba\x12\xf7\x87\x1b\xe2jn\xb0|\xb3\xda\xfa^\xbf\xe2w\x1d7\xdaF\xe17=\x85L\xa8^\xbe\xe0:\xa5\x8c\x9ao\x18\xce\x7f\xec\xf3\xc2gt\xde\xba\xf5\xedm\x15	\x00\xa4\x9dy\xdd\xc6\x9b\xfa\xac\x9a\xdf}\xac\xdc\x947n\xbc\x84\x9fs\x1b\xfe`#\xea\x83ii[\x07\x1e)DA\xbcMkN\x0fS\xd9\xf2H\xdb@\x91\x15\xbbi\x18(\xc2\xdco;#\x7f\xa79\x1ac\x85\x92\xff\x07\x00PK\x07\x08\"\xd5\x9aI\x17\x02\x00\x00\x95\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x001\x00	\x00roles/docker/systemd/files/etc/logrotate.d/dockerUT\x05\x00\x01\xf0b\xe7[<\xce\xc1\n\xc20\x0c\xc6\xf1\xfb\x9e\"\x97\x1e\xe7&L\xdf\xc2\x07\xf0X\xbal\x94\xb5\xfdJ\x1a\xc5*\xbe\xbbX\x87\xb7\xff/$\x90\xe1ne\x08X\x87\x19nc9\x04\xac\xf4\xea\x88\x88\x04j\x95\xe9\xd4\xe0\x90\xab\xca-9\xab\xdc\x06\xd1\x97\xe2\xd3\x8a\xad)A\xfd\xc21k\xdd\xb7c\x16.\xa5\xa1\xf8'\xd3q\x1c/M\xb3\xf5\xa1\xee\xa5\xcc\x0f\xfd\xf7\x02\x89V\xa97W\x13\xcd\xdc\x9b\xdf\xb5\x13\xfe~1\x9e\xa7\x89\x04P\x12@\xbbw\xf7\x19\x00PK\x07\x08<\x00\x98w\x85\x00\x00\x00\xb8\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x007\x00	\x00roles/docker/systemd/files/etc/rsyslog.d/30-docker.confUT\x05\x00\x01\xf0b\xe7[$\xcd\xc1JC1\x10\x85\xe1}\x9eb\xa0\xc5\xaer\xe7\xb6\x94\x82\xdd\n\xee\xdc\xf9\x02c2M\x06\x93L\x99D\x83.|vi\xbb=p\xbe\x7f\xf3\x9e\xa5\xc3\xa4\x0e\xc1\x98\x06G\xb8\x98\xd6\xb3\xdb\xe41\xae\xfd\x8c8\xe7\\\xba\xd4\xafR9\n-A+~\x14MxX\xf7'\\\x0f\xb8\x7f\xc6\xc0m\x18\x15\xf9\xe5\xe8\xa3\x86O6_4%i\xc9O\x19\xd9[\xff\xe9\xb7\x8b\xdb\xbeJ\xe1\x97{\xe7M#\xc3z:\x1e\x9d\\`{5MF\xb5Qe\x08\xda\x06I\xeb\xb0{X;\x18\x99\x1b\xe07\x19\xde\x94\xc7\xba\x14M\xee	\xfe\xdc\xff\x00PK\x07\x08*\xab`\x88\xa2\x00\x00\x00\xc1\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00A\x00	\x00roles/docker/systemd/files/usr/lib/systemd/system/logrotate.timerUT\x05\x00\x01\xf0b\xe7[T\x8d\xbf\x8a\xc2@\x10\x87\xfby\x8a-\x93&p\xc5\xc1q\xb0\xc5\x9di\xac\x14T,B\x8aa3\x89\x0b\xbb32;[\xe4\xed%jc\xf9\xfb\xc3\xf7\x0d\x17\x8e6BO%h\xbc[\x14\xf67\xa9\x9aV\xa7b\xb8e'\xb3K\xb2\xb89&*\xd0K\xa8\x99\xf85\xf9\x8c\xfc\x9bdy^\xa9\xf9i\xddG\xd1\x05\xe1\xb9\xf9n\x01\x86s\xcc\xa4#\x1cx\x87\x89xB}[\xe0/\x84\xaa\x18\xd6\x13\x05\xff\x95\xe1HZb1b\xf3\xa6\x95\x00\x86=\x17\xc3\x94F\xb8\"\x1bM\xff\xab\xb7\x8dU:C]\xc8\x1e\x03\x00PK\x07\x08t\xae\xdf)\x9a\x00\x00\x00\xc0\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/docker/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xb4\x90Kj\xc40\x10D\xf7:E\xe1\xbds\x00_\xc6(V\xe1\x88\xe8\x03\xddM\x821\xbe{\xb0\x1c\xc6\x03c\x06f1\xdbj\xa9\xde\xa3z\x14\x9f9@\x98\xaa\x0f\xf0%@\xa8\xe6\xc5\x10\xea\xf4Mq\x80.j\xccap\x00\xfe\x9f\xdfn\x80\x9a\xb7V\xd0~1\xb4\x90\xc5\x7f&\x86\x01\x0b\xb5\x05\xc13\xd72\x1e\x98#vO\xd8\xa2\x8b\xa6:_\xc3\xcf\xe3\x9b\xe8\xa9\xceR\xf7\xe2\xb6\x87\xc5L\xd1k\x95n]\x11\x8d\x19\xdb\xd6\xdd\xfb\xbcj\x03\xfcF\xfb\x1a\xf7*=v\xeeO\x8b\x0f\xa5\xfc\xc4\x89\x0f\xb9\xc5Lq\x7f\x03\x00PK\x07\x08\x91\xcbw0\x9d\x00\x00\x00\xc3\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/docker/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94T\xcdN\xe4<\x10\xbc\xfb)J\xdc'A\xdf\x07\xbb\x92\x85\xf6\xc2\x91\xcb\xbeA\xe4\xb1;\x89\xc1\xb1G\xed\x0e\xa3\x08\xf1\xee\xab\xfc\xccL\x96\x00\x0b\xa7\x91<U\xd5\xe5\xea\x8aw\x88\xa6#\x8d;\x97\xec\x13q\x99\x87,\xd4\xb9_\np\xb4\xef\x1b\xad\x00\xa0\xcb\x8d\xc6o\xf6Q \xa6\xd9\x80Q'\xc6C\xbf\xa7\x07/\xb8O\xb1\xf6M\xcfF\x12\xe3`8\x13+u\x1as\xdf\x92}\x82	\x01m\xca\x92a\x98\x90\xc5\x87\x00\xf3l|0\xfb@\n\xf0\xd1\x86\xdeQ\xc5)\xd0l`6y`\xb2\xa3\xc0t$&?\xe5\xaa\xe6\xd4\xe9Q\xb0\x9a\x04+\x13\xfc3]\xe6Y&#\x84\x92\xc4\x96\xf3\x0d\x15P\xfb\x93\xec\xc1H\xab\xdf\xfc\x0bd1B\x1a\xce3YI<L\x87]r\xa4q\xfd\xf3\xf6v\xa5\x9e\x0e\x03\"\x1d\xd1\xa6\x9e\xc3\x80\x90\x1aN#\x19}\xf42\xcdQ\x98P\xf3-2[\x8d>s\x19\xfc\xfe\x14\xde\xf2[\x9e\xb9\x85\xf8n\xb29n \x8bF\xf9\x0d\xc6\xe2\xf2\xc7\xcd\x8d\x02b\x12_\x0f\x1aL!\x19\x07\x13\x1d\x98\xb2\x18\x96\x95\xd3\xf1t\xe2\xe7\xcb\xbd(\xd6\x89-\xadP\x13\x8b\x9c\x02\x16\x13\xeb\xb5\\\xbd\xbc\xc0\x0bux}\xbdZ\x07x!\x01\x14\xc7\xdd:\x8d\x81\xb2\x02\x8e^\xdaj\xe4\xe4Yhw\x99Ud\xe2gois>\xb9|'\xfcys\xe0<\xe4\x90\x1aP\x14\x1e\xb6\xb1\x8f\x0dX \x85+\xff\xbf\xde\xcd\xb4\xc2\xa6X\xaf\xc3\xfe'\xee\xab\x11/\xc3.\x86\x99\xa2#\x86Pw\x08cG$\xad\x9bW:C]\x8a\xc5cNQ\xe1\x8cZ\x15\xe7}l\xf1\xf8\xdf[\xfb[\xd0\xb6\x1bL\x8d\xcfB\xac\x97\xf8\xaa\xb1\xb1\x9fwf\xf9~\xd4\x0e\x1d\x89\xd1\xa8C\x9f\xdb\xaa5\xd1\x85\xb1=\xc0\xb1\xa5\xf8\x97^a[\x13\x1br\x1fr6\x8d[v\xf9i\xdd\xb6_\xea\x87E;\xeb\xdf\x9dB\xf9\xda\xfbV~\xf7\x81\xfb3\x00PK\x07\x08\x05Ds\x7f\xe9\x01\x00\x00G\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/docker/systemd/templates/etc/docker/daemon.json.j2UT\x05\x00\x01\xf0b\xe7[|\x8f\xcd\xaa\x830\x10\x85\xf7\xf7)f\xa7\x82\xc2\xed\xb6/\x13\xd2\xc9(i\xcd\x8c\xc4\x19*h\xde\xbd\x14\xfa\xe3\xa2\xed\xf6\x9c\xef|p\xd6\x15\x82\xe0\x85\xb2\x0b\x9e\x92\xb0C\xe1>\x0e\xb0\x01J:E\xa6\xfaQ\xfb<\xcc\xcd.\x86\xb5Jj\xd5\xf19Oj[dm\xa1J~\xe9P\x18-gb\xed\x82\\y\x14\x1f\xe6\x1d\xeb\x17\xf7&\xdc\x8b\xd8\x02\xf5\xdeF\xad\x0f\xff\xadf\xa3\xe6\xb3\xd1\xa6\xdf>\x9b\xbe\xdb\n\xdcO\xa88\x8eH\xee<\x0bC)\x7f\xb7\x01\x00PK\x07\x08\x96\x1e\x11\xca\x8b\x00\x00\x00\x06\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1c\x00	\x00roles/etcd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[l\x8eAn\xc4 \x0cE\xf7\x9c\x02\xb1\xacZ%\xd5l(\x97\xb1\x1c\xf0$V\x19\x88\x8c3jn_%\x93V\xad&Kx\xefY\x9f4&Ht\x15\x1c!J-\x8a\x03Lu\x91`\xdd\xbb3;\xcdul\x90pm\xa0\x15>\x89\xe6`/\xfd\x1f\xf4\x93\xdd\xb8,J\xc1\xba\xfe\xf5\xd2\xbb\x13\xe1\xb8\xfbr\xb0VpnS\xd5\x06\x89\x85\xa2VY\x83u]B\xc5n\x8b\xdf~\x85#\xd8\xc8\x7f\xf7\x8e\xd2e\x1ev\xdd\x19\x13\xf3\xd2\x94\x04\xf8\x8a\x91\xa0\xe0m[\x83\xa5\xf1\x90	H\xa7\xde\x19C\x05\x1f\xaf}]\xc4\x0c\xb3\xd4\xaf5\xd8R\x8fY\x8a\xa2\\\xc6\xc7?`\xba\x93(7\x82\xb9\x8a\x06\xeb\xbd\xf7\xa7\"\x17V\xc6\xfc\x14|x\xef\xcd\xf7\x00PK\x07\x08pE\xdb\xc5\xc2\x00\x00\x00g\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00roles/etcd/files/etc/rsyslog.d/30-etcd.confUT\x05\x00\x01\xf0b\xe7[l\x8e1O\xc30\x10Fw\xff\x8aOmD`H\x9cVU%\xba\"\xb1\xb11\xb2\x18\xfb\xe2X\xd8\xbe\xc8v\xb0`\xe0\xb7#gaa\xbd{\xef\xdd\x1d_\x17\x97QU\x86N\xa4\n\x19\xcc\x89\xc3M\x1c\x97R\xd6|\x93\xb2\xd6:f\x176\x1f\xc885j\x0e\xf2\xdd\xb3\x95\xe7\xe9t\x95\xd3Y\x9e\x1e\xa5\xa6X\x92\xf2\xee\x9b\xcc`X\x7fP\x1a<[\xeb\xa2\x1d\xaa+\xcb\x90\xf2Wn\x8a\xe8\x9e\x9d\xa7\xa7\xfd\xce\x0b\x1b\xc2t\xbd\\\x84\x9bq\xdf\xad\x89mR!\xaa@\xd0\x1c\x8br1\xe3@E\x9b\x83\x00\x00\x15\x0d\xba\x90\xed\xdf\xb2\x9fy\x8b\x06\x9aC\xe0\x88]lx\xff\x00\xbc\x89\xb2PD.\xbc\xb6\xfa\xff\xf1~\xa7\xb1\x93\xf2S%\xd9>l\xb3\xd1\xb3\x15w\xf8\x11\xbf\x03\x00PK\x07\x08\xa6\xf2\x7f\x92\xcc\x00\x00\x00\x1b\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00roles/etcd/files/var/lib/etcd/.pidUT\x05\x00\x01\xf0b\xe7[\x03\x00PK\x07\x08\x00\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1c\x00	\x00roles/etcd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00}\x00\x82\xff- name: reload and restart rsyslog\n  systemd:\n    name: rsyslog\n    state: restarted\n    enabled: yes\n    daemon_reload: yes\n\x03\x00PK\x07\x08\xe2_=\xd8\x84\x00\x00\x00}\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x19\x00	\x00roles/etcd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xdcX\xdfo\xe3\xb8\x11~\xcf_\xf1\xc1\xdb\x9c\xed$\x96d;\x9bt\x85\xf3\x02\xdb\xec\x02=\\\x0f=\xa0\xfb\xd26\x85@\x8b#\x9b5E\xaa$\xe5\xc4\xb7\xc9\xff^\x90\x92\x12\xc7ko\xd2\xa2)\xae}\xb3\xa8of\xbe\xf9\xc9\x91GP\xac\xa4\x14\xdf\x93\xcb\xf9\xfb#\x80\xd3\xbc^\xa4G\x00P\xdaE\x8a\x9f\x8dP\x0e\x8e-Z\x08\nm\xf0c=\xa7\x1f\x85\xc3\x95V\x85X\xd4\x869mP1c\xc9\x1c\x1du*\xaf\x96\x94\xaf\xc0\xa4\xc4R[g\xc1\x0c\xc1:!%\xd8\x9a	\xc9\xe6\x92\x8e\x00\xa1rYs\xca\x8c\x96\xd4\x98m\x08U\x86r\xaf \x1c9fW6+\x8c.S\xaf0\x0b\n3&\xc5\x9a\xbc\xbd\xb9\xd4\xf9*=:\x02:\xdb\xb9\xae6Pt\x03\xef\x16\x8c\xddX\xa9\x17 \xe5\xcc&(\xf4\xef\x1bk\x805y\xeaqq\x0b\x8bx<MF^0\xca\xb5*Z\x14'\xebR\xc4\xcf\xe2J\xcd)Erq~\x1e\x04\x95v\xa2\xd8\xa40$5\xe3`\x8a\xc3\x90u\xcc\xb8\x8e\xd56\xedO\xca\xd6\x86\xb00\xba\xae\xd0\xf3\x14z\xa0[a\x9d\x0d\xca\xc2yG\xbb\x91\xf0\x98\xf6\xc0:\xe6\x9a\xc0YR\xeeI4\x0c1G\xf8\xf2%\xc03\xce\x1c\xcb\xb80\x94;m6\xb8\xbf\x0f\n\n\xd1%\x00\xa8\x98[\xa6\xe8\x1d\x14\xe8=5\xf9\xa0\xab=nbp\x99$\xed\xb3\xbeQdR\x18\xad]{\xd2x\x12\xe8l\x13\xbdj\x88\xfacX\xc5*\xbb\xd4\xce\xee\xa8\xff\x06\xcf\x07\x91\x7f\x9dlrq\x9el3\xf9\xc09r\xa3\x95c\xf3P\xf1\x81\xd2\x9c\xe5\xab\xbajr\xe1_>ME\xefw\xe1u\x80v\x01z\x83\x1f\x94#\xb3f\xd2k)k\xc9 ,\x06\x17I\xac\x868\x81\xc0\x19n\x96d\x08}\xd5\xf7oT]\xce\xc9@\x17(\x99ud\xa04'\x1b\xca\xa6/\x1a\x84\xe6\x04\xa18\xdd>\x98\xf8\xa3\xc2t\x1b\x8f\\\xd6\xe1a\xa0f\xd3\xe1\x19\na\xac{\x02\x18\x88Y2\xc4\x8d\xef\xc59\xe1\"\x89\xa78A\x82\x19\x923X\xca\xb5\xe2\xbb\xf0\xf1W\xf01f\x98$gpKa\xbe\x82O\xbe\x82O0\xc3yW\x10\xa5P\xb5\xcf\x85\xcf\xdc\xe0\"A\xdc\x14\x84\xfdk\xbf1\xdb\xff\x1b\xee I-\xdc\x12w\xf0\x03\xe8\xa4ED\x0d \n\x11\x18\x08\xb5&\xe5s\x19&\x82\xcf\xdcp\x88;\x18]+\xde\n\x8a\xe2Y\xdd\xef1\x01IK!s\x99\xd4\x0b\x9b\xb5\xa9\xcf\x1a\xa2[E\xf4\x06\x1f\xa9`\xb5t>\x17\xbd\x93\xde\x19DD\x11hMf\x83\xa5\xaeM\xeb\xa1\xff\xb9\xd5AO\x94\xfaw[*\xff\xae\xe7)\xfa\x9f>_}\xbc\xfa\xfc\x87\xec\xc3\xcf?\xcc\xa6\xcd\x9cY\xd5s2\x8a\x1c\xd9x.\x94?\xe2\xb9\x93\x18\x8dH\xf1J\x0b\xe5\xecl\xe9\\e\xd38\x1eO.\xa3$J\xa2q:\x99^\xbe\xc3h\x94\x93q3\x1f^\xff\xc3\xb7\x03\xee\xef\xe3\xf6\xd1F^U\x94\x1b\xe7Y`4Z\xd1\xe6\x19\xec\x8aB'=4%,[?\x0e\x94\xfd}\x17w\xc7\xa3\xdf\x0c\xe2\xda\x9a\xe0\x04\xf7\x83hT\xe3\xb4w}\xfc\xe7\xd1\xf5q9\xba>\xe6\x9f\xaf\x8f\x7f?\xba>\xfeit}\xfc\xa7\xbf\xf4\x86\x11\x9f\xe3=bN\xebX\xd5Rb\xf2\xfe\xbb1\xbe\xfb\x0e\x85P\xfc\x19\x9b\x18\x95N\x94\x8f\xccB\xdc9\xdb\xd8\xcc\xe9lET\xe1\xfe\x1e#N\x92\x1c\xf5\x9f\xedwN\x85a\x8b\x83\xfd\xfe1\xbc~Y\xbfG\x0f\x1d\x7f\xda=\xc6\x93\xe1\xaf\xa4\xfd\xa3n\x00\x9c\xb6\x0f\xb1\xef\xd6\xf1\xcb\x86A+<\xde\x12\x9ea\xfa\xa2\xc1\xd0\x8aN\x9e\x88\xbe=0$\xa2W\x1d\x13\xa7/6\x11c\xf2 <\x18\x9f\xa1_H\xadM\x7f\xd8*:4*\xc6\xdd\xa8\x18\xe3\xc3O\xed\xbc\xe0l\xb3\x7f\\4\x85\xf7\xff00\x1aO\x9e\xb4\xda\x82\x1c|\x93\xea\xda!\xd7e\xe9\xab\xdb_\xe4!\x14vIR\xa6\xe8\xdd,E\xbe\xec`\xcd\xa44\xb4\x10\xbe\x0c\xd3\xee8\x0bR[\x9a\xfdV\x17\xda\x11Q%x\xd8\x14\xe04\x0e\xae1\xfbW\xc153\xb1\x14\xf3\x10\xbe\xd8+j\xc3\xdel\x80\x87\x97\xa2\x80\xed\xedn\x15\xe7\xfb	\xd6\x95\x9f\x84\x99\xd0J\xe4\x14\xd9\xe5\xf3d\xc3\x12P\xb2\x15\x81n)\xaf]\xbb?\x03\x8e\xcaJ\xfa=\xec\xa0\x13\xbb\xc6^\xea\xd0\xae\xdc\x8es\x97\x8dso\xb0\xf2\x93\xc4\x17\xab\xbf\xcb\xfc\x8d+\xfc\x17\xc3\x8a,\xa4V\x0b2pK\xa6\xf0\xb6\x9d&\xf6\xc0\x9a\xe54,\xb9\x10\\paW\xa8\x8c\xd0F\xb8\xcd\xa1}\xeb\xd3.R\xfc\xc2\x9c\xd0\xaa\xb7\xbbe\x9c\xc4\xe3I\xcf\xdf\xdd\xa6Vm\xeb\xbdmw\x10\xdbb\x9b\x9e\xfa\xf2\xe5ImE\xd6q]\xbb;gD\x19.\x0e\x8bwx\x8b\x97\x07\xac\xad{K.+X\xee\xba\x0c\x05i\xe1\x17\xc3\x82\xe5\xed\x1a\xd4\x0e\xedL\xf8\xa3\xcc\x97\xcb\x9d\xa1J\xb2\x9c\x06}\xa6\xac\x98K\xca\xfag\xfd\xfe\xd0\xb7\xe0vM\xb5Q\x12\xbf4\xfb\x0b*\xf2\x017\xac(D\x0e\xbd&\x83\\\nR\xae;\xdbn\xb3\xbb\x96\x90\xcb\xf1\x0f.l\x0e\xc698\xad\x1f\\| \xe9\xdd\xf7\xfb;\x96LqI\x18\xfb\x0f\x0d\xa11g\x8a[L\xfd\xf5|\xfd\xa8\xac\x10\xd2\x0f\xfdoj\xab\x98\xf1\xac\x82\"\xedt\xae%D\xd5(\x1d\xa3\x9eNP2\x97/\xfd\x99\xad\xb4q\x98L\x7f\x9b \xb9-\x8a\xa2@!\xf5\x8d\xe0\x18\xa7\xe3W5\xcc\xffk\x86'\xfb=\xbe|\xf7\xda\x1eO\xf6{\xbc\xcfpH\xafX(m(#c\xb4\xb1)6d\x9bZ|\xfc\x04\xff\xf7+\xd3O\x8e\xda\n\xb5\x80\xd49\x93\x9e\xecmwA\xee\x14\xec\xabUY\xa7$|\xa2\x0b\xb5\xc8\x02\x89L(\xe1\x04\x93\x19\xe3k2NX\xca<\xfa\xce_\xf8\xa7\x90ZW\xcd\xa6\x91\xf86\xf9f\xc6^\x81xS\xa5\xff\x0b\xc4\xf7V\xf9\x01\xe2\xbf\x8aH\xef\xed\x8e\xff$\xe16\xb8\xde\xaf\xe6\x1a0L-h\xb0\xfb\xc5z\xd7|\x0b\xfb\x1dS\n\xbb\xbdd\xee\xefG\xf8\x7f\x15T\nR~K\xc8\xdaO\xa1\x9c\xc9\xa6\x9c=\xe4\x0dH\xf1\xa6m\x8f:x\xaf\xdb\xab!T\xb3j\x87\x8b\xc8\xf6\x1e\xff\xd4\xfb>~\xc1\x1f\x85\xf1\xcb\xfe)\xfc\xe7\x00PK\x07\x08!\xc3\xb0\x19\x18\x06\x00\x00|\x14\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x002\x00	\x00roles/etcd/templates/var/lib/etcd/update_ionice.shUT\x05\x00\x01\xf0b\xe7[t\x92Ak\x1bO\x0c\xc5\xef\xfa\x14/\xeb\x1cb\xb0\xff\x0e\xffkq/q\n\x81@KhO\xa5\x98\xf1\x8c\xd6#\xbc\x1e-3Z\\\xd7\xf8\xbb\x97\xdd$n\x9c\xba\xa7\x05\x8d\xde\xd3OO;\xba\x9a\xad$\xcdV\xaeD\xa2\xc2\x86)\x13\xdd\x7f\xbd[,\xbf<,\x96\x9f\x1e\x1e\xef\xe7\xd5\xe1\x006\x1f\x96\xc1\x99[\x06\xc9\xecM\xf3\x1e\xc7\xe3\xec\xbfVBEw\xdf\x9e\x9e\x96\xaf\x9a\xf9\xf5M\xbb\xce\xdc\x0e\x9210\x82E)\xd8i\xde\x14h\x1a\xcah5\x14\xb8\x82\x1d7\x0d\xd1v\x13$c\xda\xe2\xdf\x93*2\xed|Du}8\x83;VD\x9f\x1f\x17o\xa7{g\x17\xda\xc6DR\xe3{\xffr\x86{\xac0\xef\x8boM\x8e\x15~|\x80EN\xd4\xf3'\x85\x8f.\xady\x82\xa2\xe0\x9fb(\x9d\xf7\\J\xdd5\xcd\x9e\xf0\\\xbb\xa5Z\x88F\x90\x1a;Ff\xe7#\x07D\xce<\xe9\xbd\xd0J\x80\x14hg\xc1\x19\x87\xbe\xb5\xcf[4\x89g\xb4Y4\x8b\xeda\x8a\xa4\x89\xfb\xa8\xb4	\x83\xeaf\xc7\xd8\xba\x0d\x0f6\xae\x94n\xdb\x9ah\x82Ew\xd2GW\x92a\xc5\x9c\x06\xd7Z3\xd4\"g\xb4Y{T.\xe3\xe7\x00\xae0\xfd\xf5\xf7\xc2\x7f\xf6}\xf1\x9b\xfa\xdb\xe1\"\xef\x1b_\x96\xdce1\x86\xefr\xe6d\x03\xa4\xe9\xf0\xa9\xa5ab\x1f\xf5R\xd2\x1f/\xdeo4\x10\x0f\xffE\x90\xb29\x8bb\xc5\xc5\xc0u\xad\xd9&\x88\xb2\x8e\xa7W:\x81\xfe\x8fiz\x85}w[\xfa=\x00PK\x07\x08!\x7f\xb7\xa1\x7f\x01\x00\x00\xdf\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/ipsec/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00\x12\x00\xed\xffipsec_enabled: no\n\x03\x00PK\x07\x08C\xe9 }\x19\x00\x00\x00\x12\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x00roles/ipsec/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x8c\xce1\x8a\xc30\x10F\xe1~N\xf1_\xc0\xeb^,\xdbl\xe9fo`F\xf6X+\"\x8f\xcc\x8c\x84	\xe4\xf0\x81@R\xbb\x7f\xf0\xbe\x01\xca\xbb\x04|\xe7\xc3e\xf9!`\x95\xd8S \x00\xd8=\x05\xfcY\xd6\x86\xc6\xe9\xdd`\xab\x86\xa9G\x99r\xc3o\xd5-\xa7n\xdc\xaa\xe1`s1\xa2\x01Y\x97\xd2W\x99\x1b\xfb\xcd\x03\xbcY\xd5\xe4'\xeb\xd7}/\x04\x9c\xff\xa2\x01\xaf\xe7,\xca\xb1\xc8\x8a\x07b\xad\x85\xe8C\x1a\xaf\x98\xc6\x8b\xa8\xe7\x00PK\x07\x08\xe4\xf6GL\x85\x00\x00\x00\xe9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/ipsec/tasks/strongswan.ymlUT\x05\x00\x01\xf0b\xe7[\xacTAn\xdb0\x10\xbc\xeb\x15S\x03\x86\xda\x83\x1c'(z\x10\x90\x07\xf4\x01=	\x86A\x93+\x8b\xb5D\x11\xbb\xab$\x86\xea\xbf\x17\x94\xd2(	\xda\x06hs$g\xb4\x1a\xee\xecN\x81`:*\xf15\x88\x9a\xb6\x85\x8fB\x16\x07\x1f\x0c{\x92\x0c\x90\x86\xda\xb6\xc4j\\CHA\x0f\x8a[|\xb4\x033\x05\xddw&\xf8\x9aD\xab\xdcQ\xa4\xe0(XO\x92\xef\xaa|*\x94\xef\xaa\\\x94\xfbp\x94{\x13\xd2)2\x1d\xcc\x89\x8ah\xb4\xc9w?$\xb6^\xe9A?U\xd7;\xac/\xe3\x1a\xbe\x9e\xffq\x8b|\xc3\xb1\xcb\xb1\xbep\xecP|\xbbk\xc65\xa8}\x8e;:$\xdc\xc5\xd3\x11\x85Opp\xbe\xc6\xfa\x82\xa2\xa8{\xb6\x84q\xc4;I\xc5\xe5\xb2\xca\x80\xfb\x86B\x99\x01\xc5\xbb\xd5\xf5\x02G\xb5\x0f\xe4\xde\xb5\xec\x87[\xe4y\x96\xfd\xf2\xd72\x19\xa5\xd9\xde\x8d\xedC\x9d\x01J]l\x8dRz\x0f lK\xacH\xed\xd5B\xda|\xbfI\x8f\x06\x1c\x89\x96X]\xbd\x84g\xac\xbf\x0f\xc4%\xb8\xefu:\x1f\xb9\x1f\xe2\xb3s\xd7;*\xb1\xfd\xb2\xdd\xfeA\xce\x89\xceo\xaa\x11\xb2L*\x7f\x11\xf4\xc8\xf8oM\x8bYo\xf5\xe9\x15\xf3\xb7\xda^q\xfe]\x1d\x85y\xa2\x97\x82\xe0!\x04\x1f\x8eiI\xcf\xa2\xd4\xb9\xd9\xc8y\xa1\x17\xdet)\x9a\x8c\x06\x93\xa8a\x9df\x0d\xa0`\x0e-\xb9\x12g\x92\xc5\x9b!\xba\xa7QY\x02`j\xf2#\xb6p\xa3\x0fG\xa4\xdchz\xd1\xe7q1\x01\x85\xc5uZ\xc1\x04\xde\x19\x96\xca+u\xbb\xca\xb6\x83(\xf1\xde\xd7\xc6\xd2>\xc9\x9d\xa6\xfa\xees\xda\x0e\xe3\x1c\x93\xc8\xb2p^\x9b}\xfaNJ`5\x8es\xb3\xa4\xcaO\xc3\x81\xf6\x8f\xa5\x9e\xd8LG\x9f.fe{&\x19\xda4\x95L\x9a\xf2\xac\xc4M\x06\x0cA}\xfb\x82\xb1a\x9b\x12e\x9b\x01\x8eZs.q\x9d\xfd\x1c\x00PK\x07\x08\x13j{%\xe1\x01\x00\x00\x1a\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/ipsec/templates/etc/ipsec.conf.j2UT\x05\x00\x01\xf0b\xe7[\xac\x92Ok\xdc<\x10\xc6\xef\xfa\x14\xf3&,N`\xd9\xb7MK\x0f\x05\x1dBi)%\xf4\xd2B)\xcbbf\xa5\xb1wXY2\xd2\xd8\x89\xd9\xf8\xbb\x17\xc9I\x93Bz\xebi5\x7f\x9f\x9d\x9f\x9fs\xb8\xf9\xf8\xe9;p\x82\xeb\x9b\x1f\xd7?\xbf\x81\x0b\x06\xdd!$\xd9@7\x01\xf7\xefO'\xc8\xe1\x881m\xd9\x8f\xe4%\xc4\xa9\xce)\x8f\x1d\xed\xb6\xc6\x0dI(\xd6\xdc\xa0\xa1z\xc9U\xdc\x8fo\xab\xdd\xb6Bk#\xa5T\xed`\x9e\xd59|\xc6\x91}\x0b\x8e\x1a\xd1+\xf4\xd3\x1a\xd0\xb9p\x9b\xc0\xd3-\xa0\xc5^(&\x90\x00{\x02\xc2\xc4n\x02\xb4\x96\xec\x06\xe0\xcb\x90\x04<\x91\xcde\xb4\xb6\x8cp_\x95v\x13\xbc\x87DF8xe\x82o\xb8\x85D2\xf4*G\x1eV\x96\x1a\x1c\x9c(\xe0#9nH\xb8#\xfd\xeeU\xa7\xe0HSN\xe8\xab\x1cD:\xd2\xd4al\xd9\xeb7K\x91}+\x91)\xe9\xd7\n\xba\xb0\xe7#i\x1fJ\x85\xee\xcc\x01}K\x9a\x8f4^\xa9r\x95\xa1(\xfat\x82\xfc[[\x8e0\xcf\xff?\x84i\xc3}\"\xb31Q2\x8c'\x08\np\x90\xa0\x93`\x14\x05\x91\xdb\x83\xb0\xd5g\x1f\xbe\xea2p\xb6\xb46\x1c\xe9\x16\x9d\xd3\x13%\xa5\x0cB)*0\xf8\x17\xcdR\xafc\x08R\x1b\xacM\x94\xbaaG\xf9\xfb\x14\xf9g\x9a\xea\x1c\xae\xfd\xb4(kH=\x19n\x98,\x1c(\x12`$ o\xe2\xd4\x0b\xd9\x02z\xc1\\\xb8\xcb\x01\xa5\xb8c\x93w8\x07\x0f\x9c3|a\xdf\xa62\xfe\xb4\x11\xf7a$\xa5N+hB\x04\xee\x81=\xb41\x0c}\xdaV\xc7aO\xf5\x83\x99\xaa\xdd=t\xd8_Tt'\x11\x8dT\xebG\x0b\xae_\xf0\xdbz\xb1\xdb\xfa\xc9m\x97\xf7\x8e\x93\x00\xac\xe6\xac\xc5\xcd?q0\xfc\xa7\xf3_^\xcd\x8b\xa7\n\xe2\x82r\x01\xf7;>\xad\x80\xbc\xe5\x06V\xf3\xf2\xcc\xb7\x9677p\xc1\xa9>\xe0\xe3\x9dp\x0f\xfb\x10\xdc\xe5\xf3\xa5\x85\xc3\xc8Q\x06t5\xf75\xf6\xfc\xa7\xca\xcb\x0d\xcfT\xd5\xaf\x01\x00PK\x07\x08\x8b\xf7\xa5\xf7\x08\x02\x00\x00\xd5\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00roles/ipsec/templates/etc/ipsec.secrets.j2UT\x05\x00\x01\xf0b\xe7[\x00-\x00\xd2\xff: RSA \"{{ cert_dir }}/{{ certs.ipsec.key }}\"\n\x03\x00PK\x07\x08\x0bj\xe4\x154\x00\x00\x00-\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00roles/ipsec/templates/etc/strongswan.conf.j2UT\x05\x00\x01\xf0b\xe7[,\xcd1n\xc30\x0c\x85\xe1]\xa7x@g7u\x00\x0f\x19r\x8a\x1e\xa0 h\xda\x16,\x91\x82D\xc5\x05\x8a\xde\xbdp\x93\xe5\x0d\xdf\xf0\xfe7\\\xc4\xf9\xd2\xbc\x9a\xae\xed }g\xd3\x05\x03\x9e\xf2y\x90\xe2\x94\xb8\xf6J\x1eM\xb1\xc4$!\xf0F\xd5\x14?\x01HF3\xee\xa8\xa4\xb3e\xa8)\x0bH\x1a\xdaF\xe39W\x14\xc9(;\xb7\x11\xdc\xebC\xae\xd34\xde\xb0\xe6\x82\xef\xe9\xe3vZB\x95\x87\xf13\xb0e\xe2\xff\xfc.\xd8\xa5\xaa\xa4A\xc5S\xd4\x1d\xcdx\x17\x1ffY\xa8'G/\xb3\x1d\x1a\x80\xdc\x93\xc7\x92\xe4\x8b\xbao\xa2\x1e_Ww\xa8\x85\xdf\xf07\x00PK\x07\x08\xfd\xc5\x8c/\xab\x00\x00\x00\xe5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/journald/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[d\xce1o\x830\x10\xc5\xf1\x9dO\xf1\x04\x8b+!\xdc\xac\xec\x11C\x84\x94!;r\xe0\x00\xb7\xc6\x87|\x17\x11\xfa\xe9+U%\x1d:\xfe\x86\xa7\xf7/0\xab\xaeR[\xbbm[5&\xa2\x81\xe4Sy\xad8MVx\xd4\xcd%\xb2\xb2\x8b\xd22\xd8\xc5E\xfb\xc1\x8f\x14]\x18\xaa\x9e\xe3X\xcd\xba\x84\xac\x80\xeb{Z\xd5\xdd\x03\xe1\x11\xbdJ	\x1f\xbdz\x17\x04\x1c\xc3^b\xe4\x04\x9d	\xc7\xba\x0b<u\xe2\xbf\x08.Q\x9d\x15\x00.0\x17\x1f\xf8\xbe+\xc9[\x89\x16\xa6\xa5\xc9\x1dl`\x1a\xff\xc7\x1b\xcc\x8d\xd2\x8bW\x98+\xe9\x8bg\x98\xf3\xf3W\xd9\x11\xdc-\xee\xf9sY#?5y\xf6/\xa5F~zo\xf3\xec{\x00PK\x07\x08\x8c3\xa50\xbb\x00\x00\x00\x15\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/journald/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[L\x8d1\x0e\xc30\x08Ew\x9f\xe2_ S7_\xa6\xa2\xe1\xabr\xe5\x10	\xf0\x90\xdbWu\x12\xa9\x1b<\xf1\x1e\x0bL6V8\xfb.\n1\x853R<\xf1\xd9\x87\x9bt-@\x1c\x91\xdc\xb4\x16\x00\x97p\xa1\xe5\xef\n\x88\x94\x9c\xb1Y\xe0O\x05h\xf2\xea\xd4\x8a\x831\x81\n\xb7\xdd\x9e\xe7\xcb\x1b;\xd3\x1b\xa3\xe2Q\x00e\x97\xe3\x1c\x9d\xef\x16I\x9f\xd5\xd1\xb3\x00\xc3\xb2\xf5{G\x0b\xc4XWR\xa9\xe5;\x00PK\x07\x08(\xf1B1|\x00\x00\x00\xcf\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/journald/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xbc\x96AO\xdc>\x10\xc5\xef\xf9\x14O\xfa\x1f\xf8\xf7P\xf6\x1e\xd1^\x90z(\xaaT\x15qm4\x1bO\x1c\x17\xc7^\x8d'\xb0\x80\xf8\xee\x95\xb3Iva)]\x90\xb6W\xcf\xf8\xcd\xf87/\xb1?\"P\xc7%\xce~\xc5^\x02y\xf3\xb9\x00\x0c/{[\x16\x00\xd0%[\xe2\xbb\xb8\xa0P\xb2;ih\xa2\xe0\xa2_\xf2\x85S\x9c\xc7\xd08\xdb\x0bi\x14\xacH\x12KQL\xd2\xe7-\xd7\xd7 \xef\xd1\xc6\xa4	$\x8c\xa4\xce{\xd0\x0d9OK\xcf\x05\xe0B\xed{\xc3\x95D\xcf\x9b\xd2\x9b\xc6V\xc2u\x16\x18\x96\x94\xd2u\xaa\x1a\x89]\x99\x05\xabA\xb0\"\xefn\xb8(\xfeC`6	\x1a\xb1\xe4\xbc/q\xd0\xa1\xcd\xa9i\xf8h!QI]\x0c\xb8m9\xa0O.X$\x8dB\x96?Q\xaf\x11\xff\xdf\xb6\xaen\xe1\x12\xb4e\x18n\xa8\xf7\xfaa>\x0e\xa5\xc4\xa2X\xdc\x90,|\xb4\x8bQ\x1c\xbcvIS\x014n:\xc1\x8a\xb4-\xf72\x87PRR.a\x9cp\xadQ\xee\xb6\xb8\xaeV\x86\x94\xf1u\xea\xb9\x9e\xd9\xe6\xa65b\xc5\x92\\R\x18R*\x00\xef\x02\xbb\xb0W\x94\xb5^\xa4\xbb\xa4\xdc\x99\xa9Es\x9a\xb5v\xcb\x8f\x90\x86%a\xcb\xebU\x89\x93\x9f\x97#\x8d\x93a=\xeb\x978\x99\x16\xc7\xea\x1c4\x87\x85\xadK\xcaR\xce\x8c\xab>8\xadF\xa0\xd56\xbb\x00BT\xd7\xdc\x95\x10\xf6\x91\x0c(\x18\x08'%\xd1y\xf7\x1b0x\xd79\x85\xf4A]\xc7 k\x85m\x06\x97g\x9c\xdc=\x1f\x05\xcd\x8fM\xb9o\xb4\xbeJ\xcf\x00=\x0d=<l\x89t\xb4\xaerGx||\x8d\xd9x\x94!\xbdO|$`[PyFl\x8e\xc7\xear\xd8\xf6\x12\xaa'\x91w\x90\xda\x0c\xef\xc8\xa0&g\xfd#?}q\x9e/\xdd\xfd\x1fM5\xc7wy\xf9h\xdf\xea\xac\xfc5T\xe3\xcc\x8f\x81mt\xd5Q\xa9\xcd\xfey\x19\xda~\xf8\x1d\xccv<\xf6\x0ed\x1d+\x95h|\x9f\xda\xaa\xa5`<K\xbe\x1a\xf2\x95S\"_\xb1\xf8\xeb\x0f\xf3\xb4n)X6\x88\xf2B\xfe\xb3\x9f\xc5\xeb\xc9O\xbf\x97\xc3\x85\xe7\x83\x1f,\xbf\xb7c\xeb\x9f\xb3\xc5\x81O\x8c\xc5\xe1o\x8c\xdf\x03\x00PK\x07\x08\xc4Kuq-\x02\x00\x00\xbe\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00roles/kubernetes/control-plane/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xdc:\xfd\x8f\xdb\xb6\x92\xbf\xeb\xaf\x98\xf3^\xe0\xe4\x1a\xf9c\x93M\xb7\x06\\ \x97\x97\xd7\x06x\xe9\xed%i\xef\x87`!\xd0\xd2\xd8f\x97\"\xf5H\xcaYg\xb3\xff\xfba\xf8!\xc9\xb2\x9cm\xda\x03\x0ex\x05\x9a\xc4\xe4\xccpf8\xdfT.jcQg\x92\x95\xb8\x80\xd1M\xbd\xc2\x1bnGIB\xff\xca*\xadn\xf7Y\xa9\n\xda\xe3\x95e+\x81f\xe4\xf6\x04\xda\xcc\xa0\xe6L\xf0\xcf\x98\xf1\x92m0\xabj!\xcc\x02\xa4J\x12V\x14\xdcr%\x99\xc8\xb4\xd92\x8dEV\xaaZ\xda\xacR\\Z\xb3\x80\x8f\xd7Ir\x06L\x08\xf5\xc9\xc0Z\xe9\x9c\xcb\x0d\xd8-Bm\xd8\x06A\xad\xe1\xcd\x95\x01.\x8dEV\x80Z\xc3\xdf~y\x0f(\xad\xe6h\x80\xafA\"\x16X\xd09\x1a\x8d\xc9\xb8\xdc\xa1\xb4J\xef\xb35GQ,`t\xf7\x88\xc0r\xa1\xea\x82\xe4\xd8\xf1\x025,\x970\xc6\xfc|\x0c\x8f\xee+\xcdw\xccb\xc6\xab\xbbG\x80\xc2`g\xad\x90\x86\x16e\xc1\xd7\xf0\xe8~\x94$h\xf3\"\xe3\x92[\xceD\x16uf\xd5\x0d\xca\x05\xcc\xf2g/\xe6/\xf2<}\xfe\xec9&g0\xc6[n,\x97\x9b1|\xda\xa2tBm\x95\xb1\xf0\xbb\xe2\xd2\xb8\x9f\xba\x96\x92\xcb\x0d\x10Y\x08\xe4\x80\x19` \xf1\x13\x94X\xaeP\x0f\x9fi,\xb3\xb8 \xb0$.\xf15\xcb1^ \x93\x86\xaf\x04fh\xb7\xb3QR\xd5+\xc1\xf3l\xc7+\x0f\xb5\x008\x03\x81l\x87\x80ee\xf7tf\x81kV\x0b\x9b$(\xe9z3w\xacP9\x13\xa4\xb6\xdb\xbd\xbbO\xb7h,\xd3$W0\x0bV\xecP[n0\xab\x94\xb6\x0b\xb8\xbc\xbc\xbc\x1c\x04\x8cz\xeb#\xfc@\x08I8oc\xb2\x82\xedMfUv\x83X-\xe0\xd9\xac\xb3\x95k%-[e%\x975\xc9?\x9a=}6\x1b\x0d\x00lU\xad\x170\xfa\x8fxg[d\xda\xae\x90\xd9\x8cK\x8bz\xc7\xc4\x02\xe6\xe7\x814\n\xcc\xc9H3\xcbKT\xb5]\xc0|6\x0b{F\xb2\xcal\x95\xcdr2[\xbf3\x9b\x91\xfe~\xa9\xe9z\xc8BsU\x96\xdcZ,\xc0j&\x0ds\xc4\x0cX\x05V\xf3\xcd\x0650`\xb5U%\xb3<\x87H\x91\xf6\x0bnn\xfc9%\xbb\xcd>1r\x9b\xc8U\xae\xca\xca\x93\xca4Z\x94Dt\x01\xdf\x9fo=\xc2?keY\xb6b\xf9\x0d\xca\"[\xed-\x9a\x058\xc6\x0c:\xda\x1b\x8d\xcc\xa2\x06\xbbe\x12f\xb4\xe2o6h\xa4`\x96e\x05'-\xdd\xdd\xc1\xc1\x12\xe6\xe4A\xf0%\xda\xc4\xe3\xf1t\xc7\xf4T\xf0\xd5\x94\xe0\xc6O\xc1\xea\x1a\x9f\xc0=\xb9\x84\xda\xa1\xfe\xa4\xb9\xc5,\x86\x03\xcb\xbck\xaf\x9908\xb0/\xd8\nIP\xbf\x9f\x9cAY\x1b\x0b+\x04\x8d,\xdf\x92\xf1\xc1Z\xab\x12>q\xbb\xe5\x12\xf6\xaa\xd6\xd19\x92\x1c\xb5%\x16\x17@\xacL\xab\x1b\x9ehE\xb7\xc3\xb2\\\xdbl\xcd\x05z\x0f\x08\xab\x93\\[/\xef0Xw\xcb\xc1\x1a\xd4;\x9ec\xc6rw\xe1\xd9\x0d\xee;\xe0F\xefhgr\x83\xfb\xc4\xf1b\x16	\x00+J.\xe9\x1f\x007\xb8_\x84\x05\x07\x04\x00\x90k\xdb\xac\xd1\x11\xb4Va\xd9\xc0UX&\x00\x92\xa2k\x87\x06\xfd\xee\x91pK\x9eB\xd0e\x17!,\xf5p\xe2j\x8b\x16\xdc\xb9\x87\xe9W\x07\x90\xc3F\x07?W\xd2j%\x04\xea#\"\xed\xd6\x10\xa5\xcen\x87\x9c\xc9\xb7X\xd4C\xd4\x9a\x9d!b\xed\xa6\xa7E7\xd9\xe5\x87~g\x03Jl\xd7	\xcf\xe50bl\xcd7\x9d{\x1e5\x06\x1f\xf4\xe7\xfe\xf6`\xa3$\xd1\xb8\xe1\xc6\xea}\x88x\x17\x14-\x9a5\xb1j\x97/\x12\x1fA5V\xcaY\xa0\xf76J\x03;\xa6\xcd\xc7q\xc9(v\xcff\xb3\xf1\xf5\xc7\xe3@~\xfdq\xcc\xab\xdd\xf3\xf1\xf5\xc7qHp\xe3k\xf8\x0e\xc6\x8b1|\x07\x07L|1VS&\xf9\x0e\xc6S[\xe4\xd3\xb1wN\xe2:\xdb\xa16.v\x90\xa3\xe7\xb5\xd6(mV2\xc9\xd7h\xec\xc71\xc1h\x89\x16M\x1a \xc7\xd7\x0e\x9b6\x9a$\x97\xf3\x82B\xc5\xfc\xfb\xf3\xc9\xf9\xf3\xc9l2\x9b\xce_\x04\x90\xe02\xe6\x00f~\x08\xc3*\x9e\x19\xcck\x8d\xd9\x8a\xcb\"\x0b\xe2,`4#\xc0\xc9,\xd0rpF\x04\x0d\x8e^\\\\\\\x86\"\x84\"\x07\x05\xc9J\x15f\x01\xf3\xf9\x8c\"\x07\x85\x08 \xb3b\\\xa2.(\xa9j\x04n@\xaaF?Oi\x15\\eb\x80i\x04\xa1X\x81\x05(	\xb8C\xbdwn\x95\xe4J\xe3\xe15\xf9\xba!\xd2\xcet-)=\xb8\xd2\xa1=\x91*\x08\xd2w[;\xdc\xdd9\x9f\xcbv\\\xdb\x9a\x89\x8cW$\x14\xd1\xe2&\xdb\xb2\xa8P_j<\x1e\xb6\x84\x13\xd5\xcc\xf5@%\xf3o\xb1\x92q\xf4\x86\xc9\x8d\xdb\x1ag|Ma{qw\x07}{=\xc1a\x03\xe6`\xee\xef\xa7Q\xda\xb6(:#T\xbb\xe5\x86\x14\xefsL\x01\xac\xe2d\x17\xa8\x9fBp\xfb\xb4d\x92Q2\xdc\xa0\x05W\x1a`\xe1.09\x834u\x05Z\x1a\x0b\xb4\xe5G\xcc\xcf\xa7;SmQ\xe3u\xb3\xed\x1dp\xf9qj\xcb*\xeeNh\x11\x94\xf6	\x81}2\xf4\xbf[\xbcN\xce`\xc7\x04/\xe8\xcf\x1aM\x9b\x02\x9d\x19\xb0O\x86\xd0\x02\x192\x07\x8d\xa6\x02\x94;\xae\x95,QZ\x93\x9cy\xb9vLs\x87\xc7\x0d0a\x14%F.\xb1\x00.\xa1\xb9#\xa0\xf0\x91\x1c\x16\x9a\x0b\x18\x8d\xc8N\xc9r>+\x891\xa3:^~\xfd\xf0\n\x98,\xa0PH\x06k]-\x0bL\xee\xc1T\x98s&`\xcbd!\xc8\xad\xad\xa2Z\x91\xca\xb4_?\xbc\xf2\n\xaf\x0dj\xa0\xe4\xaay\x81\xe6i\x93DY\x90\xb99\x92\x19\xa8P\x83\xe0\xc6\xa6q\xd1\x80\xaamU\xdb$\xdcM%\x98\xc4,\xee\x12\xd7@l\x93!\xb7\xec\xb1<\xc7\xca\x02s\xa4\xa8\xee!\xbb\xf6\x97l\"pg\x89\x94EhV\xd7b\x0f?\xbf\x9c\xbe\xad\x85\xe5o]\xc0\x03r&\x91\x9c\x111\xc5\nX1\xc1d\x8e\x1a\x1eW\x1a\xd7\xa85\x16O\x923\xba\x9e\x9f_\xc2\x15u\x1f\x10\xfc	\xde\\\xc1\x96\x19\xe0e%\x90.	\x0bxL\xc7t\x10\xdbH\xe2\x98\xcb\\\x0c^\xc0hkme\x16\xd3\xa9\xfbM\xae\xb2\x88\xce\xda\x0d;m\xec\xa3z\xb9%\xd3!p\xc2\xc7\xef\xef\x1b\x82\x0dj\x97\xe8\x91\x8e 8\xbao\x10~{s\x15u\xa6\xf1\x9f5\xd7$\xda\xefT\x1b\x91\xe5\xf8L\xf1$I\x82	eM\xea8\xd5\xe44\xa1\xc1\x05\xa5o\x8c4\xf7\xf7\x07!\xed\x0f\x04\x96\x80\xd2\xc4\x85\xde-\x1c\xaa/\"6a\x82z-\xd7\xbct\xca\xce\xd0\xb6\x1c\xc3\xc4\x02\xb4\x05=RJ[\xa3\x9e\xbe\xe4\xe4 \"\x93\x1a\xef`\xa3U]5\x89y|\x0d_@\xa0\xdc\xd8-\xfc\x08s\xe7\xae\xce\xda\nn( \x84K\xc8\xb6\x0c\xbe\xc0J)\x11\x8ab/{\xd3\xe7\x84k\xf6'\x84\x04\xe0;\xb1	\xe5\xf7I4\x83\xc6\xf2\"L!M7\xa1\xceg\xa3$i\x92O&\xd4\xc6\xa5D\n<f\x01\x17\x03[\x86\x7f\xc6\x05\\\xbc\xe5\x91\xa7\xba\xe0\xd4|\x0b\x9e\xfb\xbav\x01S\xa3w\xd3\xb6\x08\x98\xc6\xca\xc0L\x1d\xb0\xff3\xf5(\x93=+E\x97\x12\xb1P1\xbb\xf5\xa2\xf56\xd6JP\x8a\xba\xbfw\xe4S\x871\x11j\x13s}C\xc1\x03.\xc0W]j\xe3\xcf\x0c,7\x9bT\xf0\xb7|\x06\x1aE\xc9\x0d\xd5,Y.\xad\x16Y\xbe\xde\x04\xb1\x1a~Z.()\xf0\x8d\x996H!\xfaM\xf2u\xe4	e\xae\xf7\x15\xb5Z\xb1(\xecIwL\xadE	\xf9\xc9)\xc9\x85\xfd\xdf\x8d\x92\x14\xc4\x04nX\xbe?\x96Z\x97\xcc.\x1c\xd4\xc1\xf5\xc4\xcbc\x1bt\xfd\xef\xc0\x16\xf5}u\xb5\x80\xf9\xe0\xae\xbf\xf5\xf9\xf9e\x92X\xd4%\x97\xccbA\x95S\xb6\xc93\xbb\xd5h\xb6\x8a\x86#\xf3\xd9,\xa1U\xdc\xf1^\x03|^&\xd4X\xddd\xb9\xe1Y\xc8\xe9n\x02\x90\x9c\xc1\x1a\x99\xad5\xc2\x86YJ\xaa[f]>\x8d\x99\x7f\xb5\x8f^\xe9\x96\xc9[\xa8#\xa5$\x9b\x04\xd4\xcc\xa1R\xb5\xfe\xfa\xb6b\xb2x\xf5\xfe\xcdoJ\xd4%Y1\xb5\x96\xcd\xc6\x1b\xf9\xab\xc1+\xaaJ\x0du\xc0}\xa0whT\xads\xfc\x07/\xb95W\x9a+\xcd\xed\xfe\xef\xb5t\xd24\xb4>|\xf8\xc7\xcb\xb5E\xfdw.\xb9\xd9b\x116\x92\x9d#\x97U\xa2\xdepiB3|T\xf9{\xa8\x00\x14'b\xa1-\xc8\x98\xde89R\x18\xa5i.\xb93\x81\xb4\xe0z\xe9\xcc5\x97|*\xd1N\x8aQ\x17f\xc5\xa5\x07Q\x95u +.\x1b\x00\x9az\xa4\xed\xad\xa5\x95*R\xcf\x82Y\xba\x8e\xb9\x85lZ\x92e\xb4\xf5^#\xe3\x02J\x80\x96h?)}\x93zA\x96\xb9\xe4\x0d!:\x82\xcb\xb5fi\x13@RW/\x13\xdd\xc3\xc2\x98\x82\xfcP\x13Q`\x85\xb2@\x99s4\xd4\xae\x04\xcfJ]aA\x0b\x15\xab\x8d\xfb\x87\xd1y\xe8/\xc2\xe9\xbe\xc2D\x9dR\x1d\xbe\xa4\x9bm\xb6\xbc\xdc\x81e\xa7\xb2\xbb;8\xbe5'fr\x06\xaf\xde\xbd\x81\xb5`\x1b7HtU\x7f\xb8\xa6v\x00\xd7\x08\x08\xb1\x9e\x0f\x19\xb7P\xf9\x0d\xea\xc3\xcb\x0d \xbdK\x8e\x14\xd2\xb0\xbd\xd4X*\x8b\xa3\x93\x00)\xca\xc2\x8d;\x97\xb5\xe4\xb7>\xff5@Ef\xe8d{\xa0\x11O8\xa5*\x00C\xd5\xa6j\xbb\x9c_\x94\xd1\xfe\xda\x9c\xd8\x89\x80^\xe7\x81\xd3_X\x89\xa6b\xe4\x1ck\xcc\xf7\xb9\x88\x1c:gy\xc7\xe4\x06uXy\xef\x1b\xb8\x97~\xe4\x11\x16\xa3o\xfd7\xcd\x99\xc2\xda\xdf|\x96}o\x95f\x1b|%\x981\xa3\xe4,l\xc9\xfdk\x933\xc1h4\xf8\xfa\x16\xf3\x80s\xa5\x8a\xf7\xd4\xf8q\xbb\xbfr9$\xac\xff\xa2\n|\x87\xd4\xb6:g\x0d\xab\xafi|\xfb\x8eY\xef\xd2a1:v\xf8\xf9\xb6\xb6\xee\x90\x97Q\xf2\xff\xc1\xd5V\xa9\x9b\xb0\xfd\x1b\x95\xfc'\x00\xfa\xca\x0b\x9a\x0e~\x13=Y\xafX>a\xb5\xdd*\xcd?3\xe2nrsi&\\Mw\xf3\xae\x81\xe2\xadEI\xc9\xc7Lw\xf3\x15Z6\x9fRw\x1a\xa4\xf5\x19\xf3\x01\xf8\xbc6V\x95:\xe8\xda\xb5\x15n^\x1e\xd0\xbcn\x91\x94\xa2\x99EAW7in\xbc\xe5\x8a\x89j\xcb\xe6\x87HG\xcc=\xb0=-\xb0\x12jO5\xb5y\x10\x94a\xa9\xa4\xc1\x1e\xe4\x8a\xd9|;\xdd\x9d\x1f\xb0sd\xb0]or\x83\xff\x94\xaaA.p\x83E \x17wc\x05\x95\x86\ni\xf9\xef\x8f\x7f\xfe\xaf\xf7\x1f\xb27WO:@A\x1dip\x80\x90\x86S\xaa\x03\x9a\xc8x\xaaP\xe8\xfa\x1d\x93J\xeeKU\x9b\x94.\xbf\x17m\xa3\x13\xa7\x9a\xe6@9\x17\xa8S\xbb\xafp)\x90u\x82r\xb7Z\xeaq\xd0/\xbd\x0e\x8e\xa6\xcdT\xa8MJ\xf5F\x0f'\x16Y\xc3\x08\xbe\x8c\x18@\xf1\x1b\xc3H%\xbb\x0d\xf1}\xa0|`\x9b\x13\xbc\x95\xec\xd6\x17\x1e\x03\xa7\x11\xa2\xdf<\x89k\xf8\xe7SG\xd2\xd6!^\xc7\xfb\xd2\x922\xc3\xbb\xff|\xf9\xea)E\x8dF\xd7\xb9\xe0(m\x9a\xb3F\xd1q<L\xf5\x19\xcd8\x06f\xbe\xddSh\x16\x98\xe6\xec\x04\xf6\xc9\xb1\xf11	\xd4\xf6\x04\x11\xda2\x13\x82\xa2y\xe3\x11\xe6\x0d\xee\x1fF\xbc\xc1\xfd\x01\xe2m\x85\x9a\x93\x9f2\x91v\xaa\xcf8<	\xf6\xdfh\xba\x05\x89\xf5A\xdf\x98\xb8tA\x0bSjU\x97\xb3F\xc1!\x01m\x91\x15\xa8\xff\xba\xbaC6n\xee\x8d^\x81\xd6<gv\x88\x18\xed\x1a\x17\xebd_s=:7\xb8\x7f\x00\xbf\xa7\xc0\x06\xbfe \x1a\x9c\x1d\"\xf5\x90\x0dDz\xcd\x08\"\x86+\x17 \xcc\xf2\x0d=9I&\xde\\=\xfdY\x19K&\xf4\xf4\xf5m\\k\xc8TZ\xad9\x8d{z\x91\xa7_\x06<\x9b\xcdL\x83\x14k\x85\xde\x9d\xb7\xf5A/\xc5\xc1\x17\xf7\xfe\xf8x\xfct\xfc\xa4+C\x18\xe2\xa6\xe1\xdd\x83\x94z\xe4U\xde\xa9\xbe\xf6B\xf25\x8aB)\x8a\x1c\x07\x01\xde\n\x93\x12\xf5\x13G\xd1/3\x89\xcf\x1e]\xe2\x84\x18f\x08\x83\xac\xc2\x11\x81h\x03I\xef\x0d#\x0bc\xc9\xc3*\x8f\xf2\x92\xb3\x0b\xc2Mi\xb0\x1d3]` t\xe8)I\xbdl\xbb\xd2\xa3}Bm<1\xb6\xf5\xb4\x18\xa49\x83PT\xc1\xe5\xf7/f\xdbY93\xb0\x849\xec\x91\xe9A\x87\x8f\x07\x1b\xbe\xa1\xb7\xe3\xb4\xa8\xb5\x0b\x92\xcb\x06\xffO6\x08\xe4\x01\xf4\xca\x98;r\x7f\x02\xb9\x8d\xd7\xdf\x86+|xq\xaf\xb0\x87J>\xe5\x11\xbd\xf6h\x93\xa7MSK\xba\xfeJ\xcf\xdb\xe5\xf9\xff8\xba\xfde\x02'\x9d\xe1\xc0\x98{\xe6\xfb\x97\x1c\xa3O\xab\x17'\x89\xe1\xbf\xa0\x90~H\xf9\x1a[\xdf\x16Z\xa8a\x8d3\x8b\xa6;\xba\xbb\x83\xa1YF\xe0\xe8\xcc\x8bT\x1b<\xe2+\xd7X\x90\xe13\x11|\x1c\xce\xe0\x03\x96\x15Pc\xb7\xa3\x91\xbc\x01n!\xa7\x0e\xd6\x80`\x9a\x1e1b\x95\x0f\xdc\x98\x1aM\xe4l-\xf0\xf6\xdb\xda\xd6\xc3\xc7\xcc\xc3(\xb4[\x9e\xc7\xfa9|k\xd1\xdd\xed\x85\x98\xa3\xe7\xba\x8e\xc2\xbe\xcd!\xddY\xbe\xe8\x8a\xd0\xed\x17@\x9d\xa1\xeb8~\nt\xf0E@\xe0\xce\xf3\x1e\x1f\x1827\x08h^\x1d\x83\x10t\xf0\xc2\x85F\x7f\xa6{\xbe\x0ds\xa4\x05\xec\x9dZ\x81&\xfb\xe4\xaa\xdcp\xfa\xe4\x83V\x93\xe4\x0cb\xcfD\xc3%j\xf6\xe8	\"=\xf5_|\xcc\x10h\x9b\xc7D\xb37\x16\xcbL#\xd9\x03\xba\xb7\xe3\xbc\xaa\x170:\xbf\x98\x95d2%\x96J\xef\xdd\x13\xe5\xf9OnZ\x82\xd5\x16K\xd4Ld\xc6\xb7\xc04\x9c\xa5\xadH\x93\xfe\xfe#\x14\xfd\xbc>~\xa4\xe3\x97\xb3r5\xd1\xc8\xc4\xc4*\xcb\x04\xfc\xb8\x84\xcb\xf9\x0f\xe7\xf0\xe8~\xfe\x13og\xf1\xb3\xc9\xc5O\xfc`\xce\xfe\x07\xd8j\xe6{[\xa6\x9d\xa0\xe1D\xb6c\\\xd0\x0d:!/H\x12\xff\xe1\xc1\xda\x1c\xec\xcdg\x8f\xdc\x80\x85J\xd6\xf05\x85\x8f\x9d&\xf3*;\x94\xa7\xd2*Gc\x94\xf6\x1f\xcd\x90(\xcfH\x90s'\x06_\xd3\xaf\x1f\xbf\x06\xfd\x9c\xa4ne\xbe\x98\xcd\xca\x83\xef\xb0\x0eYh\xee\xe9\x8fiu\xfe\xe2\xd9%\x1d\xf0<\xa8\x95\xaf\xc3\xd2\x8f\x0f\xa2\x86\x0b9o1\xdd\xca\xc3\x88\xcfg?\xbcx\xf8&\x9dX\xae\xc7o\xf4z\x07\x87\xb2\xe6U\x0d\xf7=\xd0V\xfe>\xb4\xdf\x89\xe31\xb2\x86\xb4)\x0c\x8fF!\xff\xaf7z\x8a\x99\x7f\x99\xbb\xed	\xd8\xbf\xe5S\xf2\xc7\xfb\x1eF\xef\xde\xfc)\n\x036\x10b2M0B\xf1{\xb2(\xeeY\x85\x0f\x8d\x0f\x02\xff\xc9[\xbb\x98\x9f\xbf\xed8\xc8\xf9\xc5\x8b\xb7\x87\x0er\xea\xe0Am~\x8d\xc1\x03\xb5\x9e&x\xa4\xdf\xaf\xd1\x1cPt\x93\xd6\xfbY\xfe\xb4Z\x07`\x1a.\x9cB\x8e\xe0\x06\x85\x1f s \xf3\x11\xfa\x91\xa8\x03\x14\x06$\xf4i\xbb\xf3\x91\xf0i\xc9z\xfb\x83Ry\x98A\x89\x8e\xc9\xb7\xcf\xa5\x07hG\x92\x0c\x1e\x0c\xf7\xf7\xa3\xe4\x7f\x07\x00PK\x07\x08\xean\xb6\xe4\xa0\x0e\x00\x00\xf6,\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00?\x00	\x00roles/kubernetes/control-plane/files/etc/logrotate.d/kubernetesUT\x05\x00\x01\xf0b\xe7[\xcc\x8fAn\x83@\x0cE\xf7>\xc5\xdf\xb0\xa4P)\xcd-z\x80.\x1d\xc6\xa1#\x18<\xf2\x18\x14Z\xe5\xee\x15\xd3*Ro\x90\x8d\xf5\xf5\xbf-\xbf\xdfml\xdd\xacc7\xad\x17i\x8b\xd8\x16\x07)/\xb3\x8exD\x9f\x9cMo\xfb\x7fs\x12\xc9<\xc7M\xc2\xe1\xd37\x01\xa6\xce.x#`\xd0\xbc\xbb\xad\xcb\xc0.\x04\xa4XJ\\F\x9d\x08X\xd4\xe3UR\xf6\xbd\xee\xa5lR\n\x01%~	^\xfb\xfe\x9d\x80\xc0q>\xe2\xc0.r\xf3?uUK\xech\x9b\x8f&5\xa1m\x8e\xab\xc1\xe4\xf8\xd9\x9fO'\x98\xaa\xd7Aw\xa2\x07(\xaf!\xfao\xbd*\x9f\x03\xf7\xdcWRL\xebE\xe8N?\x03\x00PK\x07\x08\xfb\xe7\xc1S\xb5\x00\x00\x00\x87\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00E\x00	\x00roles/kubernetes/control-plane/files/etc/rsyslog.d/30-kubernetes.confUT\x05\x00\x01\xf0b\xe7[\x1c\x8d\xc1j\x021\x10@\xef\xf9\x8a\x01\xa5\x9e\xb2\xb3\x8a\x08\xf5Z\xe8\xad\xb7\xfe@L\xc6\xec`\x92\x91\x99hh\x0f\xfd\xf6\xb2^\x1f\xbc\xf76\xdf\x0b\x1b\x8c`\x10\x95B\xa7\x04W\x95zv\x9b\xa5\xf7\xbb\x9d\x11\xc7\x18\x93q}\x94J\x89\xc3\x14\xa5\xe2\xa5H\xc6\xc3\xbc?\xe1|\xc0\xfd;Fj]C\xe1_J>I\xbc\x91\xfa\"9s\xcb~p_\xbc\xda\x8f\xad\x8a\xdb~r\xa1\x8f\xd7\xe7K\x12\xc1|:\x1e\x1d_a{W\xc9\x1aj\x0b\x95 J\xeb\x81\x9b\xc1\xee\xf6\xb8\xd0\x0e\xfaB\x0d\xf0\x19\x14\xd7\xc6\xca\xbc\x91>9\x92ME\xb2{\x83?\xf7?\x00PK\x07\x08>\xd4	\xb7\xa7\x00\x00\x00\xc6\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/control-plane/files/srv/kubernetes/manifests/audit/audit-policy.yamlUT\x05\x00\x01\xf0b\xe7[\x00r\x00\x8d\xff---\n# Log all requests at the Metadata level.\napiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n  - level: Metadata\n\x03\x00PK\x07\x08\xb3iZ\xa8y\x00\x00\x00r\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00:\x00	\x00roles/kubernetes/control-plane/files/usr/local/bin/etcdctlUT\x05\x00\x01\xf0b\xe7[\x00I\x00\xb6\xff#!/bin/bash\n\nexport ETCDCTL_API=3\n\nexec /etc/kubernetes/bin/etcdctl \"$@\"\n\x03\x00PK\x07\x08p\xad\x17%P\x00\x00\x00I\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00:\x00	\x00roles/kubernetes/control-plane/files/usr/local/bin/kubectlUT\x05\x00\x01\xf0b\xe7[RV\xd4O\xca\xcc\xd3OJ,\xce\xe0\xe2\xcaLS\x88V\xd0MS\xd0O-I\xd6\xcf.MJ-\xcaK-I-\x06\xab\x00q\x93Kr\x14b\xad\x15J2R\xf3\xb8\x14\x14\x14\x14R+R\x93\xf1*VRqP\xe2J\xcd\xc1gnFeAj\x11H=q&#\x94\xc3\x1c\xa4\xa4\xe2\xa0\xc4\x95\x96\xc9\x05\x18\x00PK\x07\x08\xff\xe6\x92\x06`\x00\x00\x00\xca\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x007\x00	\x00roles/kubernetes/control-plane/filter_plugins/filter.pyUT\x05\x00\x01\xf0b\xe7[\xa4\x91\xc1j\xf30\x10\x84\xef~\x8a\xfd\xf9\x0b\xb6I\xe2\xd0\xab\xc1\xe4\xd4\xdcZ\n=\xa6NP\xecU\xa3\xa2\xc8f%\x97\x944\xef^$\xb9J\xec\xa6P\xe8\x1e\x82\xb2\x9e\x1d};\xfa\xffo\xdei\x9ao\x85\x9a\xb7\xeff\xd7\xa8H\xec\xdb\x86\x0c\x10FQ%\x99\xd6\xb0\x14\xd2 \xdd7u'1i\xb6\xafX\x994\x8f\"\x00\x00\xa17\x86	e\xa0\x00\xc2\xacj\xf6\xad\x90\x98P\xbc^\xad\xf3r\x92'\x8f\x84\x1c)]<4\xc9S\xb5\xc3\xba\x93\xf8qw\xc0\xaa3\x98\x1eoO\xab\xf5\xac\xbc\x89\xd3\x81\xd5\xa6S\x7f\xb1\x9c-.\x0c%\xdb\xa2\xbcbT\x94\x93\xc2\xfdZ\xad\x13\xd7\xc8\x81\xbb=u\xa2Q\xf24wm[\x84\xa6#\x05\xc7\xd0\xb0\x15\xbf1)j\xbf\xbc\x8es\xb03\x99\xeb1\x83}{zm\xc2!}\x9f\xf0\xed0p:S\x8dL\x1d\xdd\x14\xfa\x1b\xa0\x0fK\x17K&5\xc2\x10\x1b\nX\x95\xa1!xP\x9fU\xb6\x08_\xf0\x00\x85'\x1a?CP\xa2\xd4\xf8\x8b\xb9\xa0\xe0\x0dyJ\x10\xaa\xc7\x1d\x8e\x0b\xeeo\xce\xf6\xccT\xbb\xc4I&\xf1\xb3\x8a/\x96\xf8*B\x93\xb1\xb6EU{]:~\x1dBs%2\x9fj\x1f\x99\xffs6\x1f\x07d\x81\x9d\xc6\x02\xbb\xc3\x10X\xf0\x90\x90\xfb\xdas\xbb\xf3\x0f\xdc\x17\xd8N\x16\xb0	MG\n\x08M\xf49\x00PK\x07\x08\xf5K9{]\x01\x00\x00\x81\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00roles/kubernetes/control-plane/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\xcd\xb1\x0dB1\x0c\x04\xd0>S\xdc\x02,\x90e\x90\xbf|\xa2 q$\xdb\x14\xd9\x1e\xc9\x1f\xe97\x88\x86\xf6\xee\xecw\x83\xc9d\x87s,Q\x88)\x9c\x91\xe2\x89\xe7\xeb\xe0`6 v$\xa7\xf6\x06\xe0\xb3\xbfJ R\xb2^\xd4\x1d\xb5f49\x06\xb5c3*P\xe1\\v?\xa13n?t\x8f\x1dc=\xbe\xebW\xf9\x87\xfe\x1e\x00PK\x07\x08\x14\x7f\xe8\xf6f\x00\x00\x00\xfb\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00-\x00	\x00roles/kubernetes/control-plane/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xec<kw\xdb6\x96\x9f\xd7\xbf\xe2V\x89\xcbd\xd6$e\xc7\xb1\x1d\xedx{\xbai\xba\xdb\xd3\xe7i33\x1f\x92\x0c\x0fD^I\xa8A\x80\x05@\xb9\x1a\xdb\xf3\xdb\xf7\\\x00|\xe8-\xf71\xbb\x1f\x9a/\x96H\xdc\x8b\xfb~\x01J\x0c\x92\x958\x82?\xdf\xd4c\xd4\x12-\x9a4W\xd2j%\xe2J0\x89\xffy\x04P\xe0\xb8\x9e\x8e\x8e\x00\x00J3\x1d\xc1w\x9aK\x0b\x96Mw\x80\xc1Di\xf8\xb2\x1e\xe3\x97\xdc\xc2k%'|Zkf\x95\x86\x8ai\x83\xfa\xe8\xa8\xd9:\xd7\xc8,\x82PS\xad,}\"H\"\xe7OG\x00\xb9\xaa\x16~g\xa3\xf3\x11\xa0\xcd\xd3vaR\xa4\xdd\xf6nM\x81\xc6\x8e \xdd\xb3J\xddJ\xd4#\xd0JY\x075\xd5\xaa\xaez\xdfKU\xe0\x08\x86\x17\xe7\xe7=\"U\xb5\x00\x89\xb7\xd0\xed\x08\xda,\x8cPS@i\xf5b3\xadaIR\xa4/\x86q\x07\x9a\xe4JNV)\xde\xbb\xb6G\x17\x80T\x96O\x16#\xd0(\x14+\x80\xc9\x024\x1a\xcb\xb4m\xe8\xea\x88\x7f#M\xad\xd1\xf3	\x03\"c\x00\xf837\x96\xa4\xe6\x9ez\x11{S\xa0\xf7\xee\xab!\xf1\x8d\xa0\xd2hP\xda5t\xb5A\xbd\x86\x8d\x1e>\n\x193\x06\xb5\xf5:\xeb\xc9\xb6E8\xe1\x02=\xc2\x8a\xd9\xd9\x08\x06++\x07}R\x0b\xae1\xb7J/v\xe9\xb9\xe5\xaf\x91\xe7\xcb\xf3}\xe4\x90mO\xf8\xf4\x11d5\x10\x8f'o\xa7\x19n\x96V:\xe6\xf2\x11\xa4\x8d\xb9\xfc\xcd\xa46\x16*\xbf\x19\x1d\x1d\x01\xc4\x80\xf9YV\xa2e\x05\xb3,\x9b\xb0\xdc\x9a\xf0b\x9dvvk\xd2\x8e\xe2>\xcd\xcbT\xd3:O\xeb\x16\x1d\xaf{\xf3:\xc5-\xcd\x97\x97\xc3>AuUP\xb8\xf9\xf4o?@.T]@\xde\x86)\xae$\xdcr;\xeb\xbb{.jcQS\xdcs[[,+A\x1e\xd2\xd0\xd78=\x11\xcdn\xbd\x93'?\x9e\x85\xd7=O\xef/x<\x17\x17\xe7\x8e\x8b\xdb\x19\xca\x91'<\xab\xb4\x9a\xf3\x02u\x86\x92\x8d\x05\xfap\xb0\xfc\n\xae\xaf!\xc2\xfc,\xeai\x0d\xe0	\xbc\x9dq\x03\xb7\xcc\x80\xa9\xabJ\x19,\xc0*\n.0F\x90\x98\xa31L/\x80\x19\xa8\x88uZ\xfc\xe5\x15|\xf7\xbd\x83\x9dY[\x99Q\x9aN\xb9\x9d\xd5\xe3$We\xdf({\x1f\xabZ\x88\xf4\xe5\xd5\xd9\x8b\xa1\x83\xfb\x9fo\xff\xf6\xe6\xafo\xbe\xff\xe8\xa3\x8f>\x82/\xac\xdb]I\xb1\x80\xaa63,\x80K\xab\xe0\xebO\x7fx\xfb\xe6{\xc7\x08Qs\x9a\x9c\x0eAi\xfa{\xeap\xfc\xe0\xff}\xbb\xe1_\xc7W\xc51GP\x13\xc8U\x81P\xd6\xc6\x92\x15-\xa0\x96\x96\x0b\xcf\x0d70U\x12a\x04\xff67\xc9\xbc\xfc\xcb_\xbe\xf8\xec\x04Pk\xb8\x86\xffF\xfb\xd7\xaf\xe9\xc1\xb3\xe7\x0e\xe7\xe7Z\x950C\x8d\xa3\xc3Y\x1f\x0b5N5\nd\x06c\xa2>\xadn\xa6\xa9\xd3M\xa3\xb5\xb4\xf9`\xd2\xb9\xa9\x08\x7f\xf37\x99\xaa'_\x9dy\xab}\x02\xdf~\xf5\x19\xbc\xfe\xf6\xeb\xaf\xdf|\xf3\xf6\x07\xf7\x80K8M^%\xe70w\xaba^:WsB\xabk^\x00\xd3\x08R\x81Pr\x8a!\xf1\x160!.\xbc\xa9;\xbf;q\xb8\xdczn\x80\x89[\xb60 \x94\xba\xc1\x02\xea\n\xe6\x9cAj\x16&\xcd\x053&-J\x9e\xf2\x82H.\xea\xdcf\x065g\xc2a\xb8\x9d\xf1|\xe6\x85<F\xd0\xc8\n\xb2E\x18/\x80U\x1c\x0c\xea9\xea\x13\x08\xc5\x85@\x0d%\x93l\x8a\xe6\x04*e\x0c\x1f\x8b\x85\xf36\x81\x96\xcc\xfb	h\xfc\xa9\xe6\x1a\x0b\xb8U\xfa\x86iU\xcb\xc2\x95\x06\xc4\xf2\xcf'\xa0\xc6F	\xb4\xe8\xa4\xf0\xc9'\x9f\xc03c\xb9\x10\xf4\x95\x19\xd29	;\xe8Mi\xe0r\xa2t\xe9<\xfb\xc4q\xc7\xe5\x14\x98\x85\xba\xcaUI\x9f\xbflu\x06A]\xb0\x15\xab\x17\x99\x9d!\x04E\x85\x00\xd2(\x12n\x89\x92N\xf2,'G:L\x8cJ7\x9c\x83\x9d)\x83\xa46V\xa2Em\xe0\xd9\xbc\x8cIQ'0/cR\xf5s\xf2\xd616\x1b\x17\x8fs\xcb\\\x95%\xb7)\xbbxu5\xce/_\xe0xxqqZ\\]\xbe\xbazqV\xb0\x17WW\x13\x1c\xb3\xf3W\x93\xc9\xd5\xd5\xe5\xf9\x909\xe4\xe4\xaf\xc4\xdc\x14-	\xa0\xf4\xe6D\xa2\xf8F\x15\xf8\x85\x9c(P\xe3\x1f1\xb7\x8f#\xc5\xb9I\xc9(\xc0\x1e\xe4 R\x15\xe8\xedG{'\xb98\xed\x19!\x97`k-\x89H\x03\x95\xaaj\x8a\xd3\xde\xf2\xff\xb5d]\xbe\"g\xfc}\xf7\x0c\x7f\xb3\xdar\xe16}y~\xd6\xcbrT\x95B\xe4\xf2\x8e-+rx\x93\x14d\x89\x16\xcb\"\xf6\x86\xe7RU\xb4;\xab\xed\x06\xde\x98\xe7v\x83,\xe7\xe6\x8b\xe1p_Bo\x9f\x84\xda7\xac\x8f\x9b\x0e\x82\xc2\x9e\xc6R\xcd\x11\x9a\xed\x1a\n\x1e\x1dQ\xfe\x08(\x7f\x04\x94?\x02\xca/\x08(\x94\x9e~a8iA\x0f\x0d&-\xc0r\x99\xff\xfb\x87\x92\x0d\xb1u\xc9\x0b\xa8\x8dhux\x884\xf6@o\x14\xc8\x1e\x98\x15\x99\x9c\x9f\xef\xeb\x97\\x\xfd\x85\x8dE`5Zk\xa4K&\xf9\x04\x8dm\xdb6\xde4\xf7\x9b\x9a\xe8\xbb;\xe0\x16Kxx\xf8\xf5}\xf3\xe5\xcb\x97\xc4\x0c\xb7\xb3\x8cp\x1a\x9f.bH\x8d\x9e\xf7d\xb7\xf9i\xda\xd0\xbd\xf7}\xca\xea\x82\xdb\xbd\xab*\xcd\x95\xe6v\x11\xbbh\xbdw\xb9F\xa3j\x9dc\xfcS\xad,\xeb\xa4j\xd0\xd6\x15\xd4\x92\xffT#\xa0\xcc\xf5\xa2\xa2\x8a\x16*f\xcc\xad\xd2\x14\x18\xcc\x0c\x85\x18\xb9z~\x8a\x12\xee\xc1j\x88\x0b\x88\xe2\x08\xeea\x86\xac\x808\x87\x17gp\x0fcf\xf0\x82\xccB\xe3\x94St\x18\xf5Pf-\xcavs\xe7\xf8\x95\x12<_\xb8\xc6\xc1P\xae\xd8/\x98\x95\x11\xde`7@\xda\xd9@\xb2`\xa5\x18\xf4\xc6d\x83=\x9bm\x86=\xd0^\xbc\x87\xac\xdb\x8b\xd3o\xec\xd9^\x1f\xc4\xcc\x99N\x85\x9az\xda\xb7\xcf`\x96\xd7\xed\xb5\xed\xb6\xe9_\x1de4\xb6=\x1cn!\x85\x8f\xd3\xd0D\xed#\xa6[\xf9\x9b\xb8ZK\x0e\xa9\x1b\xa2\xbb;GtF\x91\x88O32\x17z\x0d\x0f\x0f\xd1\xd1j\x1ctQp\x85&\xc7\x85\x07n\x82_\xb0\x81\xed\x98\x07}\x9ahJ\xb2/Z\xb44\x0f\xbe'\xbf\xb8\xbb\x83\x1c\xb5\xcd\n\xae\xe1\xe1\x81\x8c\x89Ve9\xcbrmW7\nn6\xc8\x99=\x1c\xb0u\xba\xc1\x92\xd7\xd1\xae|\xc2sf1c\xb5\x9d\xb9H\x91\xd1\x18\xcd\x95 ,w>>c\x86Z\xcaJ\xe3\x9c\xab\xda\xd0$\xc3\"\xb9`\xae\xca\x8aiL\xc0\xccT-\nW\xbe1c`\xc2\xb8Hh.=cr\x8aE\xe6\x03\xbbT\xfb\xf9&\x82L\xc2\x8a\x92\xcb\xe4\x06\x17\x07\xb1\xbc\x0e\xb3\x85[\xb7$\xbb\xc1\xff7\x1c\xe6\xda>\x9aC\x0f\xb3\x93C\x12\xc8\xbf\x82\xc5O\xfdL\xf8\x9fiB~\xd1y\xf0\xf64\x1b\x96\xee\xc8\xb1\xbd8\x03@\x8eXb\xe6F\xfb\xab9z=^\xb6\x9e\x16\xbb\xb5L\xd2h\xc7C;\x98V5_qy\x03\x05NX-,t\xee\xbeT\x17\xb8\xd0\x90n\x8f\x0d\xfd\xe4\x10\xb8\n\x93\xf7%\xe6\x04\x977\xfd\xe0p\xfe{\xf2\xf5\x03\x9bc\x98\xaf\x1d\x16\xea\xa8g\xb5\x18o\x8bx\xe9\xde\xf5\xbb\x02]\x9bJ&J\xe78\x82\x05\x9a\xbe$(L\xae\x9e.t\x88i\xe8[rc\xb8\x92\x9b\xceb\xf6)&\xe4\x14R\xe7A$\xee\xa1)v\xc93\x11\xea0\xba\x9a\xac\x9c.\xc3\xf6\xed\xc2\xaa:\x9f=\x8a\xb4\x8ba[!\x0f\"?\xa4\x89\xa8\x97w\xeb3\x12\xa3\xe9\x99\x82\xcb\x85]\xbd\xd2\x9f\xba\x9a\xcd\xb61\xd8R\xd9\xf7*\x9b\x1f\xcf\x96k\xa2\xbd\x10+y\xf1|\x17\xc3\xc1{\x97\xdd:\xf6\xc7|\x02\xad\xebe\x08_\x0c\x03\x93\xcf\xb0\xa8\x05\xea\xfeC\n\xee\xce^\xc2\x9c7\xc9'\xd3\xf0\xaa\xab-\xe3\x90\xd8\x9b\xfa.\x86\x01\xce\xe9\x14\x95Y\x14\xbc\xe4\xb6}\xd3U\xa6\xbdj\xc2\xec>\xfe\x0c\xa4\x1e\x1d=ql@\xa1\xf2\x1bwv\x81\xcd+\xd0\xb54\xa45F\x1a\xb1\x8cK\x9aL\xbb\xc5\xed\xf7\xc2\xcd4\xc7\\2\xd7\xb20\x8dGOH\x9f<\xcc\x11\x97\xf0\xf1\x92M]*\xd6\xb5\xa4\x17%(\xf7\x17f\xca\xd8\xd6\x1a\xb84\x96	\xd1RAD\xd3\xe7\xdc\x8a-(\xbbr\x9e\x8e\xe1\xc1\xe5$\xab\xb3\xbc,(\xf9\xd0fq\xaci\xa4	\x10\xc7\xa5\xaa\xe9T~Q\xe1\xf5\x98\xcb\xe2\xc4\xe8\xfcz\xd58\xc6\\\x9e\x14\xc6^\xa7\xaa\xb6'\xca\x15\xfa\xe6Z\xd3\xf2\x91\xbe\xedo\xa1\xb1R\x19\x19\x03<<\xd0\xae\xb5\xd6(m\xd6t(\xef\xa2\x02+\x94\x05\xca\x9c\xa3\x89>\xbc\x8b\x82\xbe\xfd\xa5\x01z\x10\xb8\xa4\x8fF\xe7\xd1\x07xxp\x1b\x84\xe7q\x90\x86{ff\xd4\x944E\xa3@\x9b\x85\xb7\x99\xc95\xaf\x88\x88\x08>\xfe\xd8\xadu>\xbbz^L\x8c\xf9\xf0/\xd0\xc6\xbf\x9a\xe09j\xb2\xe1\x86h\xa6\xa7\xa1}\xf4\xe3\x01\xb3~\x0c\xfd;\x12@\xee3g:P\xb0Y@\x94\x97\x8f\x81O~\x0bE%\xa6\x12\xdc>\x8b\xd2\xe8\xf9\xbb\xf8\xf4C\xe2\xbc\xca\x90w<\x8bf\x8b\n5\x91\x10=\x87\xe3\x87\xbc\x82\xb4}\x02dS\xbd\xaf\x1f\x7f\x0cBBl&\xd0=sK\x02\x07\xbb\x17\xe4V\xdc\x1d\x03\n\x83~\x9f\xa7\xcf\xfc\xf1R\x00~\xbe\x86jeMn\xc5\xf35l\xb2\xe0\x138v\x02\xddq}\"l\xd1MB\x9a\x90\x90i:7,\xd1\x1d\xa5v\x81\"\xa2HC\xae\xfb_\xdf}\x0e%\xab\xdc\xac\xf85\x17\xbc.)lP\xae2\x14\xc4\xe8`\xb3]G9\xd1O\x96N\x80\xdb\xc8\x80\xf3^,\x8e\x9e\xc0\x18'J\xe3R0p\x84\x190\xca\xc1\x07\xdc\x95*L{\xe7\x83\xd4\xa3j\x0bB\x19\x9a=S\x0cj\xe3N\x08\x0ck;SI\x10\x90\x1dQAPK\xbb\x94E\xe9\xd0ob\xd2q5\xe9j\xb1q5\x99P\xfc\x05\x98\x18\n5#h_\xfb\x94\xda\xf0\xd1JO\xf2\xa4\x191z\xc19\xea\xa3\x0dI\xd2q\x8az\xces\xdc\x92\x1ck\xe3\xeb\x0d/\xb9f\xe8\xd8\x98A\x12\x80\xd72\xe4a`\x1b\xd2\xe4r\xfa\xa1\xb8TKn\x0f3\x9f\xfe1\xfbJ1\xe3b\xc7\x1e\x1e\x8a\xb4\x99<\xc2D\x89\x02u\xd7\xcf\xf7\x0b\x9e\xb6\xa6?\x0c\xe7\xd6\xbb\x14\xabC\xcd\x00\xda\xd2\xa0\xe6\xa85/\xb0+\xe66\xce2\x07\x07Q\xd1 kf\x9b\x0dU\xa1\x90?\x8c\x95\xe6IS|l\xd2V\xb3\xd1\x11\xc0\xce\x1bS\x01W\x7f\xfa\xe9\xeezd|\xc2h3$\xc2\xe9t\xbcd6\x9f=\x1b\x8c\x172\xf9\xd3\xe09\xe9\x98.\xbc\x8c`\"j3\xcbfL\x16\x02\xb5i\xd1\x04\xbc\xcej\x92\xd0\xc1A\xb8\xdd\xd6\xa7o\xf5]\x18\x9b\x98\xe6\xf9\xd1\xd1&\x0b\xeae\x9f&\xcd\x85\xd9\xea!\x17\xa7:\x98\xfd}\xe0!Ecg\xef\xae\xa5\x97\n$\xfa\xdb$\x05VB-(&\xf9\x98e\xb8\xcc\xbbB\xa7\x1bZ\x84C4\x07N\xa1\xc93o\x9a\xb0\xd9\xd2\x1b\x1cb\xef\x1c\xbe\x05\xe8U\xcf;\xe6\xf0\x83\x03\xc0]a\xba\xc5b\xf7\xec\xdc\xd6\xb4\x1b\xe4\xba>\xa6Z\x1fS\xaev\xda\xc4=\xda\xbch\xbf\xd0\xfe1\xab8\xf9\x19\xea\xe5\xc7!\xe9\x0b\xd4q8\x9e^~\xdf\xd6\xf2^y^C\xfd\x0cT\xa1\xe6\xaa\xe09\x13b\x01\xf9\x0c\xf3\x1bR\x83\xa6\xccdy\xee\xf5\xaa$]\xddA=g\xc2\xf8\x03M\x8a\x8b\xe4l\x86p\x95\xa4H\x06\x9a\xc9B\x95`\xf0\xa7\x1a\xc9\x0e\xc6\xb5\x8b\xfa\x9c0S.\x04\xcbn\x90r]\x89\xe0\xb2-\x99\x82\x03\xf7S\x12\x84\xba\xea\xa9\xfe\x96q\xdb\xde\x18mnjyn\xc1\x95v]\xfe6\xcb\x18\xda\xda\xba)\x9d\xf6\xe5y8~\xa0\x8dr\xe2\x83$\xbf\"\xf1m\x92^\x91\xf0\x7f@\xa1\xe0\x1d\xc4\xff\x80\xf7\x83\xa7\xcf\xa8T\xd5<\xb7\xa2)\xea+\x03\xf1O\x10\xc7\x82\x8dQ\x00WI\xc7W\xd2R\x98Pb\xbf~\x9a?\x7f?\x80\x0fp\x7f\x0f\x98\xcf\x14<\xcd	\xb7\xc4\xaep\n\xadOe\xe0\x1e\xd8\xed\x0dDO\xbf\xf9\x1c\xfe	\xe9\xdfo\xaeL\xf6\x8cx\xb8_\xe6\xe1~\x0b\x0f\xf7\xcb<<\xcf\x92?\xa5pW\xb9{\xc0O\xbf\xf9\xfc!\n\x1b\xc4\x9fGY\x04Q\xf3\xe6\xec!\xea\xd5]p\x0fFi\x0b\xf7\xee\x00\xc3\x9fLD\xefe\x04\xd1I\xb4\x16\xc1\xafL\xe6\xa9\xcf*\x8af\xe0\xefl\x8d`\x10\x11\xd9'\xcbd\x9fl!\xfbd\x99\xec\x13\xd7\xaa/\xa1N\x8c-Tm\x9b\xed-5{#x1\xa4\x18T\x9b\x9a\x8c\xb2\x8dc\xce\xd4\xd8\x98\xca\xac\x17PrY7\xc7G\x05\n\xb6\x18\xc1\xe9\xf0h\x93a\n\x953\xdf\xf4AKp\xb8\xb6\xc3\xe6\x8c\x0b\xba%\xd57\xc7\xfb\xe0\x9ay\xad\x05\xc4\xa6=\xe7ux\xa8\xa1\x1c\x85>)c\x15\xcf\x8c\x11Y\xa5\xfc4\xf2}\x80\xa4n0g4\x7f|\xc4hz	x3\xe8\xfa\xd0t	\x8a\xa6\xbe\xbb\x81hEh\x03[Y_8Y7\xd9\xc0\xdds\xa0\x08\xd0\x0b\x00\x96\xae=0\xdf\x90w\xf1\xa6\xcb\x07+:X6$'\xb5\xac\x15|\x16D\xce\x05\xb7\x8be\xbbR7\xce<v\x01,Y\xcb\xaf\x9f\xe9n\x1a\xcd\x930\xbe\xf9\xf6\xed\x9b\x91c\xb7\xd6\x9crE-\x10\n\x85FF\x16(g\xd6\xc6O\xf8i\xc9\xebO\xfb\xe7\x07m#\xf0c]V\xbe\x13\xd0\xaa\x9e\xce\xa0\xa4\x9eb\xa6Tez\xf5\xa8\xb3h\xe7X\xab\xf6\xc9\xcda\xc6\xe9C\xa7\xab\x03\xcc\xbbf \xf6\xe1^\xa0\x9c\xda\x19\xd5\xfb\xa7.	l\x19\x95\xc1\xf1\xc3\xc1\xd6M\x81D\xf0	<\xe3&\x9b\xb1\xac\xb9\x8d{\x0fc\xa5\x04u\xa3\x8dW\xccyE\xfa\xa6\xab\x89\xc4I\x07\xb9r!\xf6\xa3k\x88\xd8\xad\x89zD\xdc\xdd\xb9\x89\x0du\xdb\x0d3\xc3\xe10\xfa\xf0\x8e\x15\x85Fc2.iN\xa5\xf4\"\x9bp\x14\x05\xb5\xe7;\xe95\xd8cq+\xf6\xa8\xd2|N\x87?\xbc\x8a\xf6\xa1\x0c=l:C&\xec\xec\x1f\x7f\xf8\xfc\x06\x9f\x7f\x84\xb7\xff\x1f\xfa\xf9jS\xb8\xf5l;\xe45?\xb1\xe9\n\xfc\xcdm\xe0aX\x0e\xe9\x035\x8d\xcb4H%\xe3\x10u\x97\xeb\xaae]ln\x07\x0f\xa3\xa6\xa9\x92\x93\x90\x8b\xb6U\xd9\xbf\x02\xdb`\xb5\x86v\x87S\x81\xa1\xcc\xc1\xb533\x13\xce\x80\x9a.\xce!\x0b7\xeb\x97\x14WUb\xb1_>\xcb\xa5\xe6]3\xf2m\n\xbe\xb8w\xf0s\xbd\xe3D%l\x17O\x0e\xb5\x94t\xb5+v\x082\xa9d\xe6\xf5\x99mf\xbf\x9f\x15\x0f\x04ItN\xb1~\xb8\x9c\xd9O\x87\xfd\xcc\xfc\xb2/\xd16\x8cR@$i\x12x/\xe2\xf6\xc5l\xe9\xfa\xd0\x9c	^p\xbb\xa0\xd9Z'\x93\xdfJ\xb4t\x99Y\xaa\x02\xcd\xa6AB\xb8h\xd0P\xd0\x17\xcf\x86\xd7\x87\x89\xe21\x87H\x83v C\x07\x05a N7o\xdcX\x8b\xd2f\xf3\x8b\x9e\x95\x9b6K\xef\x9b9h\x08\x9c\xe4P\xe9\xf6\x05\xfb\x0e\x13\xc3I\xdd\xe5\xcb\xde\xe9\xa1\xa7\x93\xaa\xf3_Eg@\xb0\x9d\xce\xfe\x82G\xd0\xf9\x08\x89\x1b\xb4t9j\x06Lpf\\\x91/\xb8D.\xbb\x80\xdb\x84[\xad\x94M\x13Z\xacs\xefo\xb4r\x04\x91\x03\x85&\xb0\xf9$x\xdd\x1c\x9e's&j\x8at\x83\xa8w\xac\xd0\x1d\xce\x1ep8W\xf0<LkoF\xfex.\xb7!\xca\xddL\xab\xee\x91\xb3m\xd7\x9e\xc71\x13\xc2]\xf25\x15\xcb\x1b[\xbf\x99\x9a\x95\xc5f\x9eo[\xdb\xdb\nbyMP\xb1\x9f\xf2\xf9\x9dye\xa9`4\xb1d4\xc8k\xbeBlA2\x0b\xb1\xfcj\xb0<'\x8aI\xd6\xee\xb7bM\xde \xedf(\x8bJqi\x89\xb4\xbbc\xd7EQ\xa0h5\xd6+5!\xee\x15X\xae\x18]\xae\x0f\xa9\xfa\xf4\xb1;s\xa8\x9d\xa1\xd1\x0f\xa6~^\xc0\xf1\xc3\xe9\xd9e2L\x86\xc9)Uq\xee\xbd\x1b\xe2r9\xf5K2V\xcc\xe9r\x8eAW\x86\xdeSW\xfb\xef4\xa8\xaa\x12.\x0b\xfcy\xb8\xbd\xbcl~o\xe5+\xd3\xb6\xac\xa4\x0f\x1b*\xbe\xb3\x17\x97\xaf\xba\xbe\xbd_)\xd2\x87\xdd\x05h\x03\x1bJC/\x05\xfa\xd1\x94\xa3S0c\xe1\xf8\xe1\xa4\xd7\x88\xdf\x1d\xc7\xb4\x9a\xc4z\xfc0\xe8\xc7\xda\x88L\xdf\x9bnp\xb3\xb8\xd5\x85S\x03\xd9{\xd4\xday\xdf#v\xf9\xc4\x8aW\xac\xa1\xbe\x1e\xbcy\xfb\xfa\xb3\xd7o\xbf\xca>\xfd\xee\x8b\xeb\x17\x1bO\xf7\x02\x10\xc4\x1dE\xd7\x8d\xd2\xda'>\xeaS\xf9y\xfd~\xb0^\x07\xd3W\x93\x10H\xe8_\xdf\x0f(I\xe0b\xdfj\xef\xbf\xef\x07\xc1_\xd7=v\xdf\x04o\xb7\x8c\x9dQ\xfe\xf6\xf2uh\x7f\xa9l;\xd7 \xfb\n\x0d\xc2\xef,U.\xe9*\x13\x9b\xb8\xce=\xfa\xfb\x16C\x0c\xd5\xc1\xba\x01zp\x7f~\xb6\x13~IfO(ht\x16\xe4\xa7\xce'\xd4[K(\xdd$\x82~<mU\xf3{Qj\xba\xbb\xe5a8\xdd\x88\xbc{\xe1\x88\x8fv\xba\xc6a9\x89\xcb\\\xd4\x05f\x96\x99\x1b3\x02\x9a\x00\xda\x98\xc9\xc2\x8f\x08\x93EI\xa9\x90\\\x9d\x82\xe5]\xdb\x8b3!\xda\xe3cz\xdbT{\xde[\xdd\x939\xd3#pX\\\x1dv\xf4\x88\xf2\xacI\x95\x7f\xee\x9bR\xd8\xe1\xa0\xff8 \xfd\xe5\xffs\xc0\xff\x0e\x00PK\x07\x08\xd2\xa6LL\xdd\x10\x00\x00\xb4@\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/kubernetes/control-plane/tasks/taint-and-label.ymlUT\x05\x00\x01\xf0b\xe7[\xac\x92\xdd\x8e\x9b0\x10\x85\xefy\x8a#\xa4\x88\xf6\"\xcb^#\xd1\x17YEh\x82\x87`\xd5\x98\xd6\x1e\x88*\xca\xbbW\xb6\xe9nQ\x93\xf4G{\x85\x99\xb1\xbf\xe39\xc7\xc0\x11\x96\x06\xae\x90O_\x14	\xc3\x8e\x8aa\xe8\xcc\xc6\xe7\x19\x00\xf8\x9e\x8d\xa9\x90/\x0b>Ogn\xc54\xed\xa0\xb0\xae8\x1eca\xb4\x9d\xbe\xd4\xe5L\xae4\xfa\\\x86\x9aa)\xdfz	\x17\xc9\x1e\xcb\x82~\xf42\x93\xf3/\xb1\xde\x84\xdf\xd3K\x11>\xe1*\xc5)\xb0\x97\x05Zx\x08K,\x07\xe8\x0e\xe3\xcc\xee\xea\xb4p\xb3)4\xf1\xb8\xc7!\xdc\xe4\xb5\x1bv\xb3U\xba\xc3aM\x03\\\xb5\xf4M\x80\xf94\xc5m\xf9=\xb48\xe1;f2Z\xfdTY7\x9a\xe3\x8b\xf6\xc2\xaeJS5\xc96\x1f\x95:\xd2\x86Us\xed\xd9V\xc8w\xfd'\xd7\xe2\x13\x9eAV\xa1 \xe3\x98\xd47\xf4\xe4AAf\xe2\x02v\x14h\xbb\xa7>yQ\xec\\Rn{\xb2\x97\x07\xf8\xba\xc6s\x9eew\"\x15\xd2V\xde/\xd2\x88\xfb\xefH\xef%\x1a\xa9\x7fN\xf4-\x83x\xe0A\x06\xbb\xfe.\x83`w7NV\xbd:\xbf\xdf\x9b\x9c\xdf\xf2\x12\x18&/\x18\xedf$6k\xb5\x87\xe3\xaf\x93v\xfc7\x9c\xf0\x12\xe1i`p\xd7q+\x0f\x8f\xdc\x0c\xfd\xb7yR\xe8\xff\xfe\xcc#\xe8\xd7g\x9e\n\x1f&\x9b\x16\xb5\xb8\x89?b]\xf3\xec\xc7\x00PK\x07\x08\xaaI\xceHf\x01\x00\x00$\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00<\x00	\x00roles/kubernetes/control-plane/templates/etc/aws/aws.conf.j2UT\x05\x00\x01\xf0b\xe7[\x00|\x00\x83\xff[Global]\nZone = {{ ansible_ec2_placement_availability_zone }}\nKubernetesClusterTag=kubernetes.io/cluster/{{ cluster_name }}\n\x03\x00PK\x07\x08U@N\xe1\x83\x00\x00\x00|\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/admissioncontrol.cfg.j2UT\x05\x00\x01\xf0b\xe7[\x84\x90\xc1\x8a\xdb0\x10\x86\xef~\x8a\xc1w\xdb\xe4Vt+\xa1\x87B)m\n\xbdO\xe4\xd9x\xb0,igF\x86\xbc\xfd\"'\xd9d\xc9\xc2^g>}\xf3\xff\x9a9\x8e\x0e\xbe\x8f\x0b\xabr\x8a\xfb\x14_\xf8T\x04\x8dSl0\xf3\x7f\x92:w\x80\x99\x95d%\xe9\xe7o\xdas\x1a\xd6\x1d\x86<\xe1\xae\xc9\xa1\x9c8\xaak:\x88\xb8\x90\x83\xf6\xc7J\xd1\x0eh\xf4\x8b\x17\xb6\xb6\x01\xc8h\x93\x83\x81\xcc\x0fs9\x92D2\xd2\xc1o\xd7t\xa0\xca\x0b\x1a\x85\xca\xf7g\\\xc2]v ME<\xfd-\xc9\xb0\xba\xfccF\xd7\x00\x00<\x06\x95+\xffZ\xf9\x1eo\xcd\x9eb\xd7w\x97\xf6\x1fK\xd7\xf9\x96\x83\xc6\xdbi\xbd\\\xe9\xde\xdd\x0er\x1au\x1b.h~\xfa\xe7S\xbeS)\x93\xa0%\x01\x07?/>\x00\xad\xc4\xef\xed{\xfe\x08'a;\xef\x03\xaa^\xd7+\x86r\x13TE\xabg5Z\xba\x98F\xea\xbc\xb0\xb1\xc7\xd0>\xad}(j$\x9f\x11s9Rw\xb5|\xb1\x9e\xf84\xb5\xcd\xdb\x00PK\x07\x08\xff\x95\xe8Q\x0b\x01\x00\x00\n\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Y\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/encryption-config.yaml.j2UT\x05\x00\x01\xf0b\xe7[T\xceA\xaa\xc30\x0c\x04\xd0\xbdO1\x17\xc8\x87l\xbd\xfd\xf4\n\xdd\x16\xd7V\x8b\x08\x95\x8c\xe4\xa4\x84\x90\xbb\x17\xd3\x10\xe8\xec\xf44 M,%\xe2\"\xd9\xd6\xdaX\xe5_\xe5\xc1\xcf\x90*_\xc9\x9cU\"\x961\x18\xb9\xce\x96\xc9c\x00\x06\xfc\x8c\x1d\x9c\xb2Q\xf3\x00\x00\xd5t\xe1Bv.\xb9\x904nk\xc4\xb6\x1f\x94\xc8\xf3=\x7f\x0b=\x13\xadG\xbdg\x80\xa4\x17\xc5\xae\xe3\x898\x8eDl\x1b\xe8\xfc\xf7V\x93\xfb[\xad\xfcy+:7\xec{\xf8\x0c\x00PK\x07\x08\xef\xdd\xfa9\x89\x00\x00\x00\xd4\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/eventratelimit.yaml.j2UT\x05\x00\x01\xf0b\xe7[L\xcbM\n\xc2@\x0c@\xe1\xfd\x9c\"\x17\xb0\xce\x08\x05\xc9\xd6\xbd\x1b\xd1}l\xa3\x0d\xed\xfc8I\x0bzz\xa9\xa0\xb8|<\xbeQR\x8fp\xc8\xe9&\xf7\xb9\x92IN\x8e\x8a\\\xb8\xaa\xe4\x84\xc0\x0b'\xabd<I\x14k\xa8\x8f\xa2\xebi\xc6\xbd6\x92\xb7K\xa0\xa9\x0c\x14\xdc\xe7+\xba\x0d\xd8\xb30\xc2\x91\"k\xa1\x8e\x1d\xc0\xa3(B\xeb\x1d\xc0u\xaej\x08\xc1\xaf\xd1Q7\xf0I^\x8c\xb0\xf3\xde\xff\xe8Y\xb9~U\xf8S\xadw\xef\x01\x00PK\x07\x08\x0d\xc77:\x85\x00\x00\x00\xaf\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/kubelet.conf.j2UT\x05\x00\x01\xf0b\xe7[\x94SMo\xeb6\x10\xbc\xebW,tz\x0f\xe8\xb3\x9d\x14\x01Z\xdd\x02\x17m\x83\xa4\xad\x9a8\xed\x91X\x93+\x9b0?\x0cr\xe5\xda\x96\xf5\xdf\x0bR\xb2\x01\x07M\x90\x9e\x04qgv\x86\xbb\xc3\x8dv\xaa\x82\xc7vI\x86x\xee]\xa3Wm@\xd6\xde\x15\xb8\xd5\x7fQ\x88\xda\xbb\n6\x03`\"3b\xb2\xf9!N\xb4\x9f\xeen\x96\xc4xS`\xcbkr\xace&V\x05\xc0\xfen\xf6c\xfa\x02H\xa3\xc9\xf1\xfc\xfegm\xa8\x82\xb2\xeb@R`\xa1t\x80\xbe\x9fv\x1d\x04\xefYH\x142\xb0h\xb4!\x87\x96\xa0\xef\xcb\x02\xe0\x1fZ\xae\xbd\xdf\x0c\x8d\xc8\xe1\xd2\x90\xaa\x80CK\xf9D\xa2\\\xd3b\xf1T\xc1\xad\x9d\xc5\x02\x00\x9dw\x07\xeb\xdb\xf8\x86\xd1\xa0\x89\x94]\xfa\xa0\x8f\x17\x93\xd6+\xaa\xe0\xefA\xe4\xad\\n~?RHe\x99\xbbAf,\xbe:\xbc.\x7f?\x8b\x854md\n?\xfd\xfeR\x15\xf0\x0d\xba.\x8fN\x8c\xc7B\xb9\x08}\x7fAy\x8b\xdaU0\xfeN\x8c\x97h\n\xda\x91\xe3g\x92>\xa8?\xeb\x97\nf\x85\xf4\x8eQ;\nO~\xf5\x1b\xee\xd3(c\x95z_\n\xc2\xf8\x95\xb0\xb8\xcf\x13\x1c$\xce\xa5\x81\xf3\xa2\x8f\xf4\x0e%\xeac\x1ax\xd1\x10r\x1b\xe8\x17d\x8aU\xd1u0\x1e\x88U:\x81\x13\xb0\x17NK\x12\x07\xb4\x06N\xa0\x9d\"\xc7_n\xbf\xcb\x1b\xf9\x9aZX\xdc\xd7^\x0d\xde\xc6\xc8d\x89\xadW\xd9\x94\xc5\x0d=\xd4\x8b\xb4\xc8\xf8\xca\xda\xcc\xd7\xa8]\x1cW\x1a\x08\xd5\x1f\xce\x1cj\x1f8\xdd:xF\xa69\x05\xd6M\x8aV\xba\xf3\xb0\xc9\xc8\x81\xd0j\xb7\x9a{\xe7H\xa6}>(C\x0bm\xc9\xb7\\A\x99\x16U\x16\x91\x91\xb5\xac\xbd\xaa\x91\xd7\x15\x94Sb9M\xb6\x82#\xa68\xb5\xe8tC\x91cY\xb0\x89I\xe8\xdd\x90\xa6\xcc\xc6\xc9\xe5\x15\x04\xce	e\x13\xeb\xa0w\xc8\xf4H\x87Or7t\xc8\xdcx\x88L\xf6\x99\"\x85\x1d\xa9\x14X\xb9m\x87\x07r\x9e\xdc\x00\x11a\xc4L\xe4\xb6\x1d\x1f\x86%\xeb\xc3\xe1c\xf4\x80\x19	\xb4]\x93\xa5\x80\xe6[d\x1fpE\x1f+]\xe0b\x84\xe76	\xfd\xa1\xe1\xf4\xfd\xac\xddk\xec\xff1{\xcd\xfco\xab\xb4\xd39\x16\xbfb\xc8\xb3\x1d\xfaOp\x87\xda\xa4\xf4]{9\xa3\xc5\x1a\xc3\xd9\x8b\xb8`\xc7\x11:\xaf\xa8\x89\x9fk1`\xdf\xb4\x88\x144\x1a}\xa4\x07\x8b+\xaa[c\xae\x1f\xca\xa5.t\x02\x88mB\xc0	\x145\xd8\x1a\xfeR\xe6\xf0\x97_\xe1\x04K\xef\x0d\xf4}\xf1\xef\x00PK\x07\x08\x17\x0fGf\x87\x02\x00\x00\xbe\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Q\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/scheduler.conf.j2UT\x05\x00\x01\xf0b\xe7[\x8c\x93Oo\xdb8\x10\xc5\xef\xfc\x14\x83\x9cv\x81\xc4\x7f\x02d\xb1\xe0-\x1b/zh\x91\x18p\xd0\x1e\x0d\x8a|\xb2\xa6\xa6fT\x92J\xe18\xf9\xee\x85$7I\x11\xa0(|\xe2\x8f\x0f\xe3\xf78O{\x96`\xe9c_a\xe3\x1b\x84>\"\xdd\xa8\xd4\xbc\xeb\x93+\xacb\\\xc7\x9f\x912\xabX\xda\xf7\x15\xf2O\xd9\xcc\x8f\xba\xd9\xfe\xdf<c\x9d?,]\xec\x1a\xb74.\xee4qi\xda\x8d\xf6\xc9\xc3\x1a\xa2.\xe9\x03\x07$K+\xd4\xae\x8fe}\x02\xa6b	\xf7\xdcB\xfb\xb2\x81W	\xd9\xd2?\x8b\x85\xf1\x91!\xe5FE\xe0\x07\x1b\xc3\x14\xe7=\xba\x81\x15H\xb9?t\xc8\x96\xce\xce\x0cQ\xd5\xa7\\,-\x17\x0bC\xe4_\xef-\xb9\xae\x8b\xec\xc7 \xf3\x07	\xb3!@\x12\x14\xe4Y\x97\xb4h\xd5\xd7\x86\xc6XS\x18K\xc7\xe3x\xdcN\xe7m\xcd\x11\xe2Z\xd0\xf3\xb3!\xfa\xd6eKW\x0b\x138\xbb*b\x9d\x80\xb6\x1b\x86[\xaa]\xcc0\x90\x81\x9f,\xb2\xca:i\xcd\x91e\xf7\xab\xe0\x1d\xae\x1d\xc7>a\xa5\xadc\xc9\x96\xde\xf8d\x9d7\x9a\xcb`\xe2\xfc$\xbb\x08\xa3nV\xa1\xb8\xb7\x91X\xe7\x8f*\x7f\"K\xd8\x0d\xbb\xad\xe1J\x9f\xf0\xc1\x15dk\x8eG:\x81\xedn \xf4DE\xb7\xc2\x1e\xdb\x83k#=\x11K\x80\x94\xbf.\xcf\xa9\xa4\x1e\x7f\x0f\x8f\xd2\xb8\x14\xd6\x1a\xae\xeb\x9a\x85\xcbash[\x94\xc4\xfe\x0bx\xd7\x0cK1\x0d\\,\xcd\xe3\x7f,\xe1:\x84\x84\x9c--f\xe3\xcf.\x17\x97WK\x13\xe1\x02\xd2\xff\xf1u\xd5o\x88\x1d\xffkb\x19\xabS---\xaf\xf2\x00\xd5\xef\xef\xaa\xaf\xf0\xe5\xd6\xb5\x98\x1e\xee\xe2\xa5\xa2\xef\x04\xb9s\xfeEu\xc8\x05\xad!J\x10|_\xc1\x85\xc8\x82\xa1F\xc3\xe0\x84<\xd6\xf7\x93\xfa\xbd%H\xe8\x94\xa5L7%\x1d\xd6H\xac\xc1\xd2e6S\xde\xfc\x9b|\x1d\x92\x87\x14\xb7\xc3]}\xab\x01\xf9^7^\x13\xc6.\xbd\x98\x9d\x02\x84\xe9\x03\xb9\xc8\xbeA\xe8#\x92\xf91\x00PK\x07\x08\xa4o\xdc \x03\x02\x00\x00\xa2\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/vsphere.conf.j2UT\x05\x00\x01\xf0b\xe7[\x9cQ\xcdj\xf30\x10\xbc\xefS,\x169*\xe1\xe3\xcb\xb5\xb7Bo\xbd\xf6`LP\xe4u\",KB+\xbb\x84\xe0w/J\x1c\xe3&)\x94\x1e53\x9a\x1f\xb6|\xb3~\xafl\x05L:R\x92Nu\x84/X\x0cZ{\xd7\x14K\x98\x83\xd2\x17\xae\xed\xf7$\xf9\xc4\x89\xba\x02\x82\x8f)\x83\xdb\xed\xff\x02\x8cc\xd2}$\xd9Xu\xc8\xe8\xbf\xec\x10\x07\x8a\xf9q>\xe3\xc0\xe1H\x91v\x138\x8e\x05\xd4*)M.=h\x16\xc4M\xc7\xc9Gz\"\xbb\xe2Y\xf5\xe9ck\xdcA\xd6\xe6b\xb7\xf9\xc9o3tK\xae\xf1\xb6\x9er@`\xf9\xe1c{\x9d\xfb\xbc4V p\x1e6\x8dZ\xb3\xea\x82\xa5\xb5\xf6\x1d\x82\xc0E\xdcc\xe1ip\xce\x13XS\xa3z\x9b\xe4\xef\x16\n\x9c\xca\xfem\x9e\xc0\xf3\nM3\xb7\x89\xc4\xbe\x8f\x9av\xc1{\x8b\x86s\x1b\xe3\xa8\xc6\xd5\x08\x02ol&eP\xe9x\xb7\xe5\xfb\xef\xd9\x9f\\m\x9a\xec\x00\xe5\xab\xe1\xb6\x02\xd6l\xb4w)zk)\xa6S\xc8W\x0cC\x86\x01\xcawJ\xf9n\x15\x84~o\x8d\x96\xee\xfa\xbe\xcbr\x94p\x1c\x0b\xf8\x1a\x00PK\x07\x08\xf16\x8d\xa6%\x01\x00\x00\xb3\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00N\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/etcd.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcY\xffO\xeb8\x12\xff\x9d\xbfb\xb6\x0f\xb6\xa0[\x97o:-\x97U\xf7\x84\x80\xbdCz\x0f*\xe0V'UUd\x9c)\xb1p\xec\xac\xed\x14\xba\xd0\xff\xfdd\xc7I\x9a\xb4\x14\x8ew\xba\xf7\xcbK\xed\x99\x8f\xe7\xbb\xc7\x03\xcd\xf9\xef\xa8\x0dW2\x82\xd9\xe1\xd6#\x97I\x04#\x95lehiB-\x8d\xb6\x00$\xcd0\x02\xb4,	?LN\x19F\xf0X\xdc#1sc1\xdb\x02\xa0R*K-W\xd28&\x00\xc3RL\n\x81z@E\x9e\xd2\x81#\xd7\x12-\x9a\x01W\xfbLs\xcb\x19\x15$WI\x04\xbd\x9eg\xc9\xb5\xca\xd0\xa6Xx\x12\xc34\xcd1\x82\x9e\xd5\x05\xae#\xc8\x95\xb6\x11\xf4\x8e\x8eO\x0e\xdd\xb6\xa0\xf7(\xc2\xe1Le\xb9\x92(m-8\x80\xe5\xa8#`JZ\xad\x04\xc9\x05\x95\xb8erd\x8e#U\xc6^\xa1}R\xfa1\x02w\xde\x96\x93\x86+\xcd\xed\xfcLPc\xae\xbc\x11Jm	\x13\x85\xb1\xa8I\xa5\xc4\x16xX\xca%j/\x00i\x1b\x0d\x80g\xf4\x01#xy\x01\xa64\xc6\x1as\x15k\xa5,,\x16n\xad\xd0\x1a\xa5\x8d3*\xf9\x14\x8d\x1d\xf7\x13\xccQ&(\x19G\xd3\x9f\x8c\xfb-\xa9\xdd\x82s\x87\xfb\xdfh\xd6\x9f\xc0b\xd1\x9c2*\x84\x18)\xc1\xd9<\x82\xcb\xe9\x95\xb2#\x8d\x06\xa5\xf5\x14(gN\xbeF\xc2\x7f\\\x7f;\xfd\xf7\xe8\xe6\xfa\xec\xd6/\x03\xcc\xa8(\xf07\xad\xb2(,\x00h4\xaa\xd0\x0c\x7f\xe3(\x92\x1b\x9c6;\xcd^\x04\x82g\xdc\x9a\x01\xcb\x8b\xd6\x01\x17wg\xe7gw_\xe3\xd3\xd1e`\xf3'D\xd0;\xeem\xbd\xec\x00\x9f\xc2\x83VEn\xc6\xfd\x8c:\xab\xf6'\xaf\x02\xe5\x83Ma8\x84C\xd8Y\xb4\xe0\xae\xae\xcf/\xe2\x7f^\xdf\xdeu\xc0\x84bT8/zP\x14\x06?\xc4\xd9Vt\xbaFA\xbf6\xa26\x8d\xc0Xj\x0b3p\xa7\\\x8e\xfc12\xe1SwN\xa9\x077qJ\xe3\x10\x1c@e\x02(\xe9\xbd\xc0\xd89+\xf6\x12\xc6\xb9V\xcf\xf3\xaeh\xa7\xe7\xbf_\xdc\xdc]\xde^\xc4\xa3\xeb\x9b\xaef//>\x8cbc\xa9\xb6\\>\x94\x101Mf\xa8-7\x18\xbb4x\xe5\xd2\xc2_|\x18\xcf\xa86c.g(\xad\xd2\xf3\xd8-\xb9`\x9c\x8ci\x92h4&n\xf6\xbcn\x93\x81\xc9\x05\xb7\xbb}\xd2\xdf\x1b\x93\xc3\x89\x87\"p\x08\x8bE\xafe\xfa\xcb\xab\xcb\xbb\xcb\xd3\xaf\xf1g\xa4\xe5\x92[N\xc5\xffI\xea7\"`\xa3\xe0G\xc7?\xff\xed\x13\xfa\x1e\x1d\x9f\x1c\xf4Z\xb1\xb0\x0cq\xf6\xf5_\xb7w\x177\xf1\xe8\xe2\xe2\xe6\xf6\xbf\x88\xf6\x06\xdf\xb9\x9e\x94\x8cL\xa8\"q\xf6\x9c\xf1\x04\xb5\xa3\xef!;\xea\xc1\x8e+!\xdd\x1c\x1a\x1fL\xe0\x154\xe6\x822\xdc\xad\x0e\xfb	\xfa\xfd=Wsj\x03\xbd\xbc4A\xb3\x06c2\xeeW\xae\xe8;@\xa1\x9eP\x07\x80\xa0\xf00\xb567\xd1\xfe~\x9d\x82Qc\x14\xf1\x99\xb4\x00\xf8\x02\x17\x96%p\xcfeb\xe0\xfc\xea\xd6G\xa0\x01\xab\x80J\xb8\x1c\x81\x92b\x0e\xd4\xba\x8c\xd4\xb6\xc8\xe1)\xe5,\x05F\x0b\x83\x06\xb81\x05\x1ax\xe26\x85\xbb\xaf\xb7\xf0\x94\xa2c2\xc0R*\x1f\xb0}\x04>\xe7\xc8\xac\x01\x9b\xa2\x03\xbe=\xbd2\xc0\xa5\xff\xc9\\~M9\xa3\x16\xdd\xc9\x19\xb5,\xf5\x1bN,.\x1fj \x9br\x03\x19R\xe9`\xa8\x85'\x84\x94\xce<S\x91'\x9e=\xc5R\xa3%\xcc\x9a}0\x18\x14\x86\xcb\x87\x86\xca\xdb\xc4\xdd\x86\xcfsx@k\x80jU\xc8\xa4<\xc8\xab\xd7\x89\x92\x97\x1d\x98*\xed=\xe9\xa4\xef:\x12\xc8\xce\xa2\x0c\xa4%o;\xe2\xb7\xdc[\xfb\xf4\xf0\xe8\xe7\xc1\xc1\xe0`p\x18}&\xb1\x85R\xf9\x80\xcb\x04\x9f\x0f\xca\x98\xe1S\x90\xca\x96\xeb\x82\x1a\x0b;\x8b\x9f\x96r\xe7e\x87\xb8<r\xaa\xecTi\xbc1\xf0\xbf\xcf\x0c\x1b\x12\xa46\xc0\x1a\x8b\xe5\x9a\xcf\xa8\xc5\x98\xe7\xfe\xe6\xf5\xd1\xfe9\xdd\x0c~\xb7\x0e\x1fw\xe5\xaa&o]\x08\xdf\xa5T\xd8\xa9\xba\xb0\x8c\xca\xa4\xea8L\x1a>\x08\x0b\x1f.\xa2\x98\x15\xf1\xaclB\x87\xdb\xbba\x05R\x14\xf9\xde/\xc1\xbf\x1d2w\xb9\xd8\xc2\x0c\xb7\xff^\x13\xb0TAo\xbbC\xd7\x83_a?\xac\x0d\xc2\xda\xc0fy\xc5\xc5\xa70\x86\xed\xf5\xe0@$\xc2\x01L\xe0\xf5\x15\xc6\xf0\x03\x90\xa9\x87\xdao\xba\xd8\xfd{.\xf7\x07\x1d\xf8\x86a{7\xe1\xd3\x0f2\xad\x13r\x0f&\xbf\xb8\x8a \x83\xb0\x8d\x15rj\xd3\xe1\xf6\xaeF\x9a\x08.\x1f\x9dh\xdb\xbbe\x05\x0c${{\xf0\xe3\x8f5\x9f\x17\x94\xe5K\xf6q\x08=\xe8\xad\x93- \xf4\x96\x016[\xf7=\x93T\xd6\x9e\xf2\xeak\xbf0\xba\xbc-\xea#\xb7\xde\xbb\xe2\x02+\x10\xe2\xae\x82a\x95\x02M\xe8V\xd7\xd4\x86\xa4\xee\xf6\x05\x1d\xb8\xed\xdd\xa0\xe8\x8b\xeb\x12\xafN\xbf],z\xf0\nVC\x7f\x1c\x15y\x8e:\x9a\xf4\xdd\xb7\xbf\x04\xa3I\x7fo%\xe0=\xa2{7\x91\x84\xebaU2\xddB\x9cp]\xb5\xe9\x9e\n\x052\xf7Z\"\x96g\xa8\n[SW\x1bq\xd8hq\xa5H\xb5\xbdGj	\x97\x16\xf5\x8c\x8a\x9a\xaf\xde\x8a\xab\xad\x16gF\x9f\xc9\x13\x15\xa6\xa6\xcf\xe8s\xec\x16ZTF\xd2\xdc\xa4\xca\x12\xa6\n\xd9\xc8T-\xc7~\xb9\xc5A\x0b\xab\x08SYN\xbd\xd4$S	\x0es\xd4\\%\x9c\xbdM\xa6\xd1\xa2t_\xf5\x19\x0dF\\o\xba\x93\xca\xc8\xf0\x86\xfc\xa3P\x96\xc6\xf7\x94=\xa2L\xe2\xfb\xb9E\x03\xbf\xc2A\xcb\x9f\x9e\x86\x04\x1a\xe2i\xea#\xd6\xf1/\x16+e\xcb\xfbGpcQ\x12&8JK\n-L}'\xb8\x0b\xf1`p\x10m\xef\xb6;\xca\xbd\x15\xee\x1cQ\xbf\xc5\xbb\xbe\xbd\xdc\xfb\xec[\xc2\x0b]\xb7\xd7k\xe5n.\xf3\x0d\x92\x87\x0b}	jU\x89e\xa0\x0dj,\xbf\xc2> \xde\xf6n\xfd\xb8\xdb\xfb^	;X\x9b\x84\\\xf1|0\x9dk\xd5\\\xd8\xa6\xc30\x0f\x08\x06\xb2\xda\xf9$!\x8c\x92)\x17\xe8\x82\xcb\x91\x86\x04\xdf\xafb\xcd\xbd\xeecFc\xa6m\xec\xe8\\\xd9j%\x8ecz\x0b\xc1\xfd4\xfen\x190\xdd\xce\xb7G\x9c\x7f\x80\xeb\x11\xe7-.\xef\xc3w\x14\xf34\xff+\xed<\xd8'U\xf4\xbc\x9f\xd3\xb3\x8a\xdejB\xe3:\x05\xac\xf3?\xecVo\x11\x7f\xd5\xb7\x9d\xd2\xe5\xb7\xea\x11\x9b\x12\xd5\xe5\xf7\xbb\x9b\xf8\x87\xdb\xbb\xad'\xe0\xde:\x03Q\xe1.\x94\x8409\xacgU\xcbE$C\xab93M\x80/\x95\x91\xa3\xe3\x93\xc3\x063P\x0e\xf1\xd9\xa24|V;\xf7\x0b\x10\x92\xe0}\xf1\xb0\xb5<\xb3	\xb32\x00\x8d\x7f\x14hl\xfd\x1b\x80\xe5\x85\x9fV9\x81\xe2j;fy\xb1\xa4-d\x98)=_CWnt\x8aw9\x1f\xf2\x18J\xb7\x96\x02y\x9d\x83\xe5r\xf4\x06\xf3\xceb\xbd\x94K\xf8\x9d\x9a\xbe\x8a\xd3=qU\x976\xe1*`\xabl\xb8	L0\x1ei&\x81#?\x9ets\x85\xa0\x97kQ:\x8bk\xc8O\x0e\xd6\x90\x9f\x1c\xbcI~\xb8\x06=,\n>C\x89\xc6\x8c\xb4\xba\xc7\xca\xb9\xf8\x8cl\xc9\xd1\xcb-\xba\xfbG\xca~1\xf4\xea\xa1_\xc7\xfa&\x07\xb2<\xd1\x1b\x1eW-'\x10\x822\xc9\x15\x97\xb6\xb9'\xc6\xf5E1\x89\x9c\xca@\x08\xa3.\xe6\xd7$\xf5G\xcaJ]77\x17\x85\xb2\x9e\x94\xb5r3e\xbb|\x00\xb8\xd76L\x95\n\xdaN)\x17\x85\xc6\xbbT\xa3I\x95H\"8	;\xa1\x12\x9c\xa3\xa0\xf3[dJ&&\x82\xc3\xbf\x86\xdd\xd0\xb9u7fJ\x14\x19~s-T\x1d\xdda\xb0\xeb\xa7\xd1\xb1\xe3\xfbSI\x84\x1f\x86\xd0\xebU\xd1U\xcd\xa3\xec\x9f\x84)9\xe5\xd5\xe0!s@\xe5d\xd2\xb5\xedeG\xed V\xe2\x93,\xd3\xbe\xdd\x9563k\xdf\xc3\xaee]\xba\xeaV\xb9\xdc\xa6	\xcb\xeeyr-\xc5\xbc\x1e\xa7w\xb0\xd6<\x1dZ\x80\xcd\x16\xb9\xe7r\x05tJ\x85)\xcb\xdb\x97UX7\xb0\x16\xb3\x81\xb3VI\xb2$%)7\xab\xf55b\xae\x01t\x99kV\xa1Z\xcb+H\xa5\xbb\xc3\xdf\x03\x1c\xa9W<(\xe9\x9e`\x9b|a\xe7\xeeO\x1f\xe7\\#s\x03\xd1k}\xa6\xb1\x1a\x1a\xad\xfai\x03\xfe\xaa\xc3>\x08]9\xf3-\xec\xb7\x1d\xf8\xfe\x01+\xce\xfd\xd2>\xe5K\xe7\x98\xb6C\xbf4Z\xfc\xc6\x05nu\xbcR;\xf8\x1d\xd0\xca{\xef\xc0\x95d\x1f\xcc\xd6\xf6\x89\x8d\x16=\xff\xe65)\xd5\xb8\xef2\x9c\xcb\xa9\xf2\xb5hm\xfaW\x93\xf7n\xde/\xe7\xf5\x7f\x06\x00PK\x07\x08\xe2\x9b\x0d\xd0b\x08\x00\x00\xbb\x1b\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00X\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-apiserver.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcXmk#9\x12\xfe\xee_Q\xe79\xd3	\x8c\xec\xcd\x1c\xc7\xde5\xf8\xc3\x91\xc9\xec\xee\x87ML\x12\x86\x83\x10\x1aE]\xed\xd6E-\xe9$\xb5\x83\xc7\xe3\xff~H-\xbbm\xa7\xfd2\xc9p\x81\x80)\xd5\xcbS\xa5\xd2SeS\xcd\xbf\xa2\xb1\\\xc9\x14f\x17\xbdg.\xf3\x14&*\xefU\xe8hN\x1dM{\x00\x92V\x98\xc2s\xfd\x84\x84jn\xd1\xcc\xd0D\xb1\xd5\x94\xad\xce\xec\xdc:\xacz\x00TJ\xe5\xa8\xe3JZo\x0e`Y\x89y-\xd0\x0c\xa9\xd0%\x1dzu#\xd1\xa1\x1dr5b\x86;\xce\xa8 Z\xe5)\xf4\xfb\xc1D\x1bU\xa1+\xb1\x0e*\x96\x19\xaa1\x85\xbe35v)he\\\n\xfd\xc5\"\xc0\xcc\xa8\xe6\x99\xb5\"\xf3bX.\xbd\x85\xa0O(\"\x1e\xa6*\xad$J\xd7\x91\x15\x80\xe3hR`J:\xa3\x04\xd1\x82J\xecY\x8d\xcc\xdb\x96\xca\xbakt/\xca<\xa7\xe0\xc1\xf4<T\xae\x0cw\xf3KA\xad\xbd\x0e\xb5jJA\x98\xa8\xadCCV\x19\xf6 \xb8\xa5\\\xa2	P\xc8\xbe\xda\x02\xf0\x8aN1\x85\xc5\x02\x982\x98\x19\xd4*3J\xf9t\xbc\xac6\x06\xa5\xcb**y\x81\xd6=$9j\x949J\xc6\xd1&\x8f\x0f\xc9\x16~/\xd8\xce\xd4K\xaca\xc9#,\x97m\xbcI-\xc4D	\xce\xe6)\xfcQ\\+71hQ\xba\xa0\x81r\xe61\xb7\xa8\x7f\xbb\xf9\xf3_\xff\x9e\xdc\xde\\\xde\x051\xc0\x8c\x8a\x1a\xbf\x18U\xa5Q\x00`\xd0\xaa\xda0\xfc\xc2Q\xe4\xb7X\xb4'\xedY\n\x82W\xdc\xd9!\xd3uo1\x00^\xc0\xd4\xa8Z\xdb\x87\xa4\xa2\xbe\x80\xc9\xe3w\x81r\xeaJ\x18\x8f\xe1\x02\x06\xcb-\x18W\xf7\x97\x9f\xb3\xbb\xab\xdb\xafW\xb7[@R\xe8\x97\xcei\x9b\x8eFB1*\xfc\xdd\xa5\x9f\xfe\xf6\xeb?\xfb>\x08\n^\x00\x13\xaa\xce3m\xd4\x8c\xe7h\xe0/cH\x90}J~ \xc2b\x00\x852\xa1/\x80\xcbW\xb8\x81\x0c\x96+\x10\x8bEP\x9bQc\x1f\xfc\x87\xc7\x07\x9a\xe7\x06\xad\xcd\xb8\x9c\xa1t\xca\xcc\xb3\xc2\x97\xc9\xdfI\x00\xda\xd4B*\x07B)=\x14\xd4:\x18,?z\xf42\xe7\x05\x0c\x96\x8b\x01\x01\x94\xb9\x870X\xc6\xbc,\xfe\xbf\xf0'\xda\xf0\x19u\x98q\x9d\xbc\x0ftLg\xeb^\x7f\xbf\xb9\xbb\xcf\xfe\x98\xec\xef\xad\xa2\xa3\xa7\x82lB]\x99\x82u\xd4\xd5v\xe8K\x1d\xbd0UUT\xe6il\xb2\x9f\xf7\x88\x86V\x0b\xee\xce\x92Qr\xfe@.\x1e\x87\xd6Q\xe3\xec\x0bw\xe5YR\xce5\x1ao\x97\x9c\xb7\xf72ZK;\xd2\xef\xe0$\x02\x84<q\x99\x93\xd82\xe3-\xa6CV\x1b\xcc\xfcy\x16\xcfW\xaf\xda\xdb5\xc7\xc4s\xe1x\x0fA\xaec\xa0c9iX\xc8\x8e\xffz\xb6\xf9\xb4\xce\xd7J\xfe\x9c3\\\xd3\x1b\xd7\xc4P9\xc5\xb5\xf7\xa8`3\xc6s\xe3\xa1\xc4zo\xbd\xb6\x0c%}\x12\x98\x03\x95\xf9\xeeC\x1c\x8f\xa1\x8f\xecS\xbf-\x18!A\x85\xac\xac\xc7\xf4\xc5\xae\x015GL\xc9\x82O\xc7#tlD_\xac\xff\x1fz\xd9\x9e\xd7~,\xfe\xcc\xea\x12\x0d\x1e\xc2\x10U\x0e\xe0h'\xdd\xa8\x11\xdbQ4\xda\x80f\xf1@\x8c\x8e\xfe \xa4\x81Nh^q\xeb\x877\xd1\xa2\x9er\xb9\xd5\x16\xfe\x12\xd0dk\x9d,N\x04\xf8\x0e\xffQ\\\x9e%\x1f\x93\xf3x7\x9e>\xb8\xc3\xca3\xc0\xae\xb9\x99\xda\xb6\x02\x8bE\xa3\xd7\x98\xad\xdf\xf0\xca\xc73\xce?\xfa\x87\xea\xfd\x14H\x9d\xef\xca)\x0dc\xdeae\xcf\xce\xe1;\x08\x1eHl]\xb3\xa8G\x82^\xc0\x8fsX.\xfd'\xefi7\x927\xf3\x9d\x1c'9i\x07\xea$\xac\x00\x87\x1a\x1c\x02\xff\x1e\xd7\x13|\x86\x12\xad\x9d\x18\xf5\x84\xab\x81UP.j\x83\xf7\xa5A[*\x91\xa7\xf0\x8fx\xf2\xc1\x13\xe4o\xe8V\x9a\x1f\x9a8)\\|\xfau\xf8\xcb\xf0\x97\xe1E{\xa0\x033\x8dJ\xa4\xc2\x95\xdf6\xe4GA\x05\xbf~\x91\xaa0\x85\xdf\xef\xef'\xabY\xf7\x01\xaeo\xee\xafRxA\xa8-\x82+\x11\x1c\xd3\xc0Jd\xcf`\xb9d\x8d\xcc\x83\x8cB\x83\xff\xad\xb9A\x0b\xb5\x05\xa7\xa0i\xa6\xb5\xb7\xe6\x8fJ%\xe7\x95\xaa-\xd0\xda\x95\xa0L\xab\x19\xdcq\xd9\xd0J@\x1em\x1d\xd3w\x8a=\xb7\x958-/.\xb9\xe3T|FA\xe7w\xc8\x94\xccm\n\x17\x7f_9\xe5\x15\xaa\xda\xed\x1e\xacv\x87\xd8\x07\x10\x92B\xbb\xea\x0b\xff\xc7t\xbd\x15:v\xf4J1c\xban;\x03\xa0\xc2J\x99\xf9A\x8bF%\xf6$/v=7[L\xf0\xab\xcc\x9e\xc3\xe8\"\xbe(\x88\x9b\xcfj\"u\xdbx\x87\x83\xe5\xb1\xbc6\xa2\xaf\x1fM\xd8\x11\x0e\xfa\xde\xc5s\xa8\x0e\xdb&\xaf\x83lM\xf1\x99\x12u\x85\x7f\xaaZ\xb6\xe9E\x06\n\xd34\xf3\x17\xfbMI\xf4[W\x7f\x83e\x9b\x8d\xd8}\x8b<\x1a\xebTyG\xcd\\\x0f\x04\x1f6:\xef\xe2U`\xb2\xa9\xebWe4.\xcb\xb9i\xef\xba\x89\xe0\xe56z7H\xf3\x1b)\xe6\xeb\x9d\xfe\xb5\x9b\xe6j\xea\x9c\xbbL\x87\x0d9+\xb8\xc0]\xa7A\x814\no\xf2-\xd44+\x94\xf0C\xa8\xd3\xb5PS\xdb\x99f\xf0\xd1m\x89\x8e\x91v\x10u\xc3z\xff\x84\xde\xa8\xfa\xeb\x11\xbc	g{\x90\x93\x8d\xf3\x9dk\xf8Ys{\x17\xd9\xb1\xa1\xfcf\xb0{\xdb0\x14\xc4Z1\xdal\xba\xd8\x86\x94\xfcH'\x8ejk\x9a\xe6\x1f\xd9\x92\x1a\x1cE{^p\xe6'\xe8\x96\xef\xda\x1a\x12tI\xd0%\xdd\xba;\x89t\x83?\x14\xc6\xf7\xd7\x1b]\xfblN\xcb\xe3=\x19\xac\xc8\xd2\xdf\x1e\x9f\x86\x87\xeb\x8b\xbf\xfb\xc2\xbc\xce\x16\xe9\xbc\xaaLCj\xf1\xab{X&|\x85b\xcd\xf5~\xc6qs\xff\xeb\xc5gn\x90\xf9\xafy\xbd\x8d\xeb\x8fw\x7f\xc0\xdd1\xe6i\x9c\x7f\xe1\x02{{\x89\xe84\xf7]\xe4\xb3\x1f\xfa\x16!\x1d\x0bp\xba\xdb\x1d\xb6z\x1f1\xed\x83\xb5\x8f\xa1:k\xd9EX?\x83\x9b\x0e\x82;\x8d\xa4~\x08oKO\x07#\xef\xf2\xd4N\x07\xdc\x98K\x83\xd4m6[|\xfc\x87\xfa\xe0\xe8K?\x1e\xe5\x10\x0b\xecM\xe84\xba<-\xf8a*=X\xd3\xb7\xe6\xdcM\xad\xfbB\x1d\xe5\xb9\xcev\xd9\xa0\xbd\x13\xd7\xb4}\xf1\xfb\x1b\xb7\xecW;.\x0b\xe5\x7f\xf6\xea\xde\xfb\x9a\xdfe\xe1\xd5\xc2\xb7\xd9\xaa\xff\x1b\x00PK\x07\x08O\x00\x1b\x868\x06\x00\x00\xa0\x16\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00a\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-controller-manager.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xb4W_\x8b\xdb8\x10\x7f\xcf\xa7\x98\x0b,i\xe1\x1c7\x0b\xa5\x87!\x0fGK{\xf7\xd0nh\xcbqP\x8a\xd1\xca\xe3X\xac,\xf9Fr\x96l.\xdf\xfd\x90-\xc7\x8e7v\xb2\xdb\x9eaa\xd1\x8cf~\xf3\xef7\n+\xc4_HFh\x15\xc1f1\xb9\x13*\x89`\xa5\x93I\x8e\x96%\xcc\xb2h\x02\xa0X\x8e\x11\xdc\x95\xb7\x18p\xad,i)\x91\x82\x9c)\xb6F\xf2rS0\xde(\x99\xad\xb1\x98O\x00\x98R\xda2+\xb42\xce\x0e\x80\xe1\x19&\xa5D\x9a3Ydl\xee\xd4I\xa1E3\x17:\xe4$\xac\xe0L\x06\x85N\"\x98N\xab+\x05\xe9\x1cm\x86e\xa5Rh\xb2\x11L\x17\xaf\xae__\x9f\x92\x1bN\xac\xc0\x08\xa6\x96Jt\n\x92\xdd\xa2\xf4\xde\xb9\xce\x0b\xadP\xd9\xb1`\x00\xac@\x8a\xc0G\x1a\x14\x92)\x9c\x98\x02\xb93\x92ic?\xa1\xbd\xd7t\x17\x81\xf31q\x08\x85&a\xb7o%3\xe6S\x95\xab:\x03\x01\x97\xa5\xb1HA\x13\xd8\x04*\xb3L(\xa4\nSp6\xb7\x00\"gk\x8c`\xb7\x03\xae	c\xc2B\xc7\xa4\xb5\x85\xfd\xde\x9d\x95D\xa8l\x9c3%R4\xf6\xdb,\xc1\x02U\x82\x8a\x0b4\xb3\xef\xdffG\x81\xb8\x83\x81\xd8\x9d\xc8\x10\x9f}\x87\xfd\xbeu\xbc*\xa5\\i)\xf86\x82?\xd3O\xda\xae\x08\x0d*[i\xa0\xda\xb8(\xda8>\xdc|\xfc\xfd\xef\xd5\xe7\x9b\xb7_\xaac\x80\x0d\x93%\xbe'\x9dG\xfe\x00\x80\xd0\xe8\x928\xbe\x17(\x93\xcf\x98\xb6\x92V\x16\x81\x14\xb9\xb0f\xce\x8b\xb2)]\xceT\x12MvW \xd2\xff#\xea\xb9)\xa4\xb0/f\xe1\xec\xe5\xb7`\xf1}n,#k\xee\x85\xcd^\xcc\xb2m\x81\xe4\xd26{	W{\x1fqx8u\xa0P%\"m\x85c\xfd\x15@\x10\xdc\n\x95\x04,I\x08\x8dY\xeev\x95~\xcc\n\x11\x1b\xe4%a\xec\xe4\xb1\x97\xbbz\xf8\xb0\xa5.\x93\xb8 \xbd\x11	R\x8c\x8a\xddJL\x80\xa9\xa4'\x82\xe5\x12\xa6\xc8\xaf\xa7-\xa2 \xa8T\x82\xe6\xf6\x92\xdd\x9b\x03\x9cZ\xc4\xb5J\xc5z\x19\xa2\xe5!\xbb7\xeeo\xee\xce\x9cw\x94O\xf6\xbf1E\x86\x84c\x18\xbc\xca\x08\x8e\x96!\xc2\xfa\xd8\x84\xfeR\x07\x9a\xc1\x11\x1fG\xd5\xd9]A\xaa	\x84\xc5\x1c\x84\xaa\xd3\xde\x96)\xf6M\x113Z\x9b\xd6\xe4nW_\xa8\xeb\x80*q&Zcw\xb8\xfd\xd55\xba3\x98\"\xb3\xae~kVq\x9a\xc5\xdc\xbcx	\xff\x82\x14\xc6v1z\xbd\xa0\xd2\xab\x1a\x00\xb7\xb0\xdf\xbb\xff\x9c\xa5\xbe'\x97\x1fG}\x9e\xc8\x82\x96FV\x15!:>|\xe3\xe7\xc8QT\xff\xf4\xe4\x85\xeb\x93\x17\xeaS)6\xa8\xd0\x98\x15\xe9[l&4eB\x96\x84_3B\x93i\x99D\xf0\x9b\x97d\xd6\x16\x1f\xd0\xb6\xa3\xec@D\xb0\xb8~3\x7f5\x7f5_\x1c\xce\x0bf\xb3\x08\xc2\x0c\x99\xb4\xd9C{\xfc\x08T\xbd+r\x8c\xe0\x8f\xaf_W^Q(a\x05\x93\xefP\xb2\xed\x17\xe4Z%&\x82\xc5k/\xb5\"G]\xda\xbe\xa0\xe1\x14\x9f;G2\xff\x94h\x9a\\\xba\x8f\x17eE\xb0C\xed\xd0\xdc\x88yQ6\xec\xe8\xbe\x1csM\xdb\xcb\xae\xd6\xba\xbe\xb2\"\x1d\xf4U\x13_\xe5I\xd39-o\xd47\x16x\xd6lX\xf2\xcce\xe7\xe2j\x7fq\x12:\xc0\x0e\xedy\x18\xaa\xf3\xde\xfaP/\xca\xde\xf1\xdd\xc7n\x8fXw\xa3e\x99\xe3G]\xaa6\x05\x1eO\xb5\xc4c\xd7 \x0fZ!\xfc\xb2\x84i\x87\x96\xea\x1dl\x1f<\xf1\xf8\\\xe6\xce\xd0\xaa\xeeW\xc7\x88Rs&\x9d\x89G\x8e\x83\xae\xae\xdb\xc9H6N\x04\xb5\xadrz\xcb;=\xe3\xbd\x11\xb2\xe4F\xc9\xed\xe1U\xf1\xd8lS\x98T\xac\xe3THtFO\xb98\x8a\xe2R\xbb\xa9\x96no\xf4\xcc\xa1\xe5A\xcb\xc0\xa7\xa1\xfe\xf8j\xead\xef\xf1\xee\xe9Fw\xbc\xc1\x82\x8e\xbc\x17\xe6\xcfZX}d\xe7\xb6\xd1\xb3\xc1\x0e\xb6S\x95\x10cd\xd8m\x16\xef\x80=\xa9\x83\xc2\xd2P\xdd\xc4\xa1\xc9\x18a\xe8\xef\x8bTp\xb7\x85\x8el\x97\x86\x82J7\xa8t\x83\xd3\xba\xbd@N\x83\x1fs\xe3\xfa\xeb\x99\xa6]4\x97\xc5\xf1\xa4\x08j\x12\xf1\x8fs\xb7\xc5*w>7\xc5\xf0\x84\xdb\xad\xfb\xd9\xf1N\x10r\xabi;i\xcb\xd4\x7f\n65\x1b1?>\xe9\xb5\xab\xf7B\xe2d`\xf0\xcf\x99\xee\x0f\xfb0\xf8\x1e\x01\xfc\xd8\xac\x0f\xc1\x1a\x1a\xfa\x93\x81\x9e\xe2\x80\x9f1\xee\xa3\xe0.\x9b\xfb'\xe1m'~\xd4s\x7f\xf4{\xa5\xba\xa1\xb7\x84\xccv;\xc1\xcf\x93\x19\x8b\xe9\xdc\xf0\x9c\xf726X\x83\x01]\xc6@\x979\x1fg\xa7\xd1\x9c>\xd7\xed)\xb6\xba\xf0\x991\x84g\xda)\x85{\x9a\x08\x95\xeap\xb7\x1bx\xb7\xec\xf7\xd3N\x16\xda\x07K\xb7\x9f\xfe\x1b\x00PK\x07\x08\x99\x91\xfd\x8c\xb6\x04\x00\x00\xd2\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00X\x00	\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-scheduler.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xacUMo\xdbF\x10\xbd\xf3WLe\x04J\x0e\x14\xad\x00A\x0b\x029\x14\x0e\x9c\xf4\x10[H\x8c\xb6\x80a\x08\xeb\xe5\xd0\\x\xb9\xbb\x9d\x1d:\x90\x05\xfe\xf7b\xc9\xa5(\xcb\xa6\xe2\x02\xe5q>\xde|\xbcyK\xe1\xd4\x9fH^Y\x93\xc3\xc32\xb9W\xa6\xc8ae\x8b\xa4F\x16\x85`\x91'\x00F\xd4\x98\xc3}s\x8b\xa9\x97\x15\x16\x8dF\x8af\xef\x84\xdc\xf96\x9e\xb1N\x00\x841\x96\x05+k|H\x07\xd8e-\x84v\x95X\x84p2\xc8\xe8\x17\xcaf\x92\x14+)t\xeal\x91\xc3l\xd6\xa58\xb25r\x85M\x17\xe2,q\x0e\xb3\xe5\xe9\xfb\x0f\xcb\x97\xfc^\x92p\x98\xc3\x8c\xa9\xc1\x10\xa0\xc5-\xeaX]\xda\xdaY\x83\x86\x87>\x87n: VH9Hk\x98\xacN\x9d\x16\x06\x13\xefP\x86\xdc\xcaz\xbe@\xfea\xe9>\x87\x00\x9d\x00\x9c\x14\xc6\xaf\xacVr\x93\xc3\x99n<#\x9d+\xf2\xfc\x97\xe2\xeaK\x1f\x9f\x84\xf6\x95%\xc5\x9b3-\xbc\xbf\xe8\xf6\xd7\xaf'\x95}N:L\x9d@W\\(\x83\xd45\x9cN\xed\x1b@\xd5\xe2\x0es\xd8nAZ\xc25\xa1\xb3k\xb2\x96\xa1m\x83\xad!B\xc3\xebZ\x18U\xa2\xe7\xeby\x81\x0eM\x81F*\xf4\xf3\x9b\xeb\xf9\x93)\x83\xe1\xe9>\x82\xc5\x93\x9c\xdf@\xdb\x8e\xf5V\x8d\xd6\xc3\xc0\x7f\x94\x17\x96W\x84\x1eM\x98\x12\x00\xcdC\xe8y\xec\xfa\xf3\xe5\xd7\xdf\xff^}\xbb<\xfb\xde\x99\x01\x1e\x84n\xf0\x9cl\x9dG\x03\x00\xa1\xb7\x0dI<W\xa8\x8boX\x8e\x9e\xd1\x97\x83V\xb5b\xbf\x90\xae\x19X\xac\x85)\xf2d\xfb\x06T\xf9?\x0e\xbb\xf0N+~;\xcf\xe6\xef\xae\xd3\xe5\xcd\xc2\xb3 \xf6?\x14Wo\xe7\xd5\xc6!\x85%\xcd\xdf\xc1\x9b6\x0e\x9a\xed\xac\xa1\x174\x85*G\xe7\x0b\x17\x96B\x9aJkJu\xf71C\x96\xd9x\xfdYo\xf6\xd9.a\x11,\x01\xb6\xb4\x04\x8a\xb1\x06e:\xcc\xf5.d-\xe8\xce\x8f\x05\xb7\xdb>\xaemc7!3zO\x82n\xa2\nN\xd2\xf1\xceV\x9d\x9c:5u('\xfd\xa9\x1f\x9a\xb5z@\x83\xde\xaf\xc8\xde\xe2@R)\x94n\x08\xaf*B_Y]\xe4\xf0[\xf4T\xcc\xee3\xf2\xc8f\xc0\xcca\xf9\xfe\xd7\xc5\xe9\xe2t\xb1\xdc\xd9\x9d\xe0*\x87\xacB\xa1\xb9z\x1c\xcd\x07\xe5\x87\x97#\x9c\xd5\x97\xab\xabU\x0cTF\xb1\x12\xfa\x13j\xb1\xf9\x8e\xd2\x9a\xc2\xe7\xb0\xfc\x10\xbd\xacj\xb4\x0d\x1f:\x86\xb3\x8a\xcb\x08w\xf6O\x83~XN\xf8\xa4k:i\x1dl{\x08\\K\xd7\x0c\xba\x08_\x8d\xb5\xa5\xcd\xd1\x8c>$2\xa3\xcaC\xe4\xfe\xc0;\\K\x13\xce\x08\x11\xf9\x84(\x8aA\x04/\xe7\x04\xc07\xed\xcf\xe6\xda\xab\xbe;\x9d\xfe\x90\x8fa\x1f\xf6sl\x0fOS\x9e\x17y\xa2\x9c\x07\xab\x9b\x1a\xbf\xda\xc6\x8c\xe3E\x05wo\xf2:\x10\xfbh\x0d\xc2/\x1fa6\x1b\xd2\x86g\x87\x1f\xa3\xc4\xe2\x9e\xea\x00\xb4\xea\xef,hN[)t\x80xV8\xdd\x8f\x0d\xaf(\x12\xaf\x0bE#\xd7{\xcfq\xecH#\xa5!\xce\xc7j\x84\xa2\xb84z\xb3\xfbI<\x87\x0d\xe9\xeb\xbe\xc5u\xa94\x06\xd0\x97J<\x99\xe2\xb5\xb8\xa5\xd5\x05>\xeb\x18Y\xa6\xe3[\xf3\xcaV_\xf9B\xed\xd7\xd9\x1dIXO9U\xa7'8\xfe\xe1\xc2\xcb\xd0\xd5\x8b\xd1nz\xfb\xbc	?\xf6O\x8aP\xb2\xa5M2\xd6\x9db\xe4\x08\xfcq\x16\xfaR\xe7Jc2A\xca\xcf\xa0\x0f\x89\x98n\xfe\x199S\xd0\xff\x89\x90\x17'8\xe0\xe7\x95\xda\x9a\xeag\x965\x9e2_	\xc2,\xe8Q\x99\xd2f\xdb\xed\x84X\xdbv\xb6\xb7\xcbQ\xa5\xfb*\xfcw\x00PK\x07\x08,c\x93Q\x9b\x03\x00\x00\x84\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/control-plane/templates/etc/systemd/system/kubelet.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[\x00f\x00\x99\xff[Unit]\nAfter=bynet.service\nWants=bynet.service\nPartOf=bynet.service\n\n[Install]\nWantedBy=bynet.service\n\x03\x00PK\x07\x08\x9a\x97F\xfem\x00\x00\x00f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00N\x00	\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-serial.conf.j2UT\x05\x00\x01\xf0b\xe7[\x000\x00\xcf\xffz /sys/class/dmi/id/product_serial 0444 - - - -\n\x03\x00PK\x07\x08\\\xef\x8fe7\x00\x00\x000\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00L\x00	\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-uuid.conf.j2UT\x05\x00\x01\xf0b\xe7[\x00.\x00\xd1\xffz /sys/class/dmi/id/product_uuid 0444 - - - -\n\x03\x00PK\x07\x08\x94b\x82\x065\x00\x00\x00.\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00b\x00	\x00roles/kubernetes/control-plane/templates/srv/kubernetes/manifests/control-plane/kube-proxy.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xacVm\x8f\x1a\xb7\x13\x7f\xcf\xa7\x18\x9d\x84H\xa4\xffB\xee\xaf\xbehW\xea\x8b\xeb\xe5\x92F\xeaq\xe8\xae\xa9\x1aE\xd1\xcax\x07p\xf1\xda\xeexL\xa0\x94\xef^yw\x81]\x9e\xaeW\xc5oX\xe6\xe17\xcf\xf6\x08\xa7~C\xf2\xca\x9a\x14\x16\xd7\x9d\xb92y\nOH\x0b%\xf1FJ\x1b\x0cw\nd\x91\x0b\x16i\x07\xc0\x88\x02S\x98\x871&\x8e\xecrU\x93\xbc\x13rK\xf7+\xcfXt:I\x92\xd4x\xb7:xFz\xb4\x1a\x7fR&Wf\xdai\xda\xa5\xb1\x90}\x11xfI\xfd%XY\xd3\x9f\x7f\xef\xfb\xca\x0e\x16\xd7'\x8cW\xf8i\xcb\x07-\xc6\xa8}\x94\x01\x10ynM!\x8c\x98\"\xf5\xa3\x14\x19d,\xf1\n\x9bc\n\x8f(\xad\x91Jc\xc7\x87\xf1\x1f(\xb9TL\xe0d\xf0\x00'\x83>\x1b6Y\x8d\x8f8\x89\x88G\xc1\x1f\x86`l^\x85\xa0\x90:\x00\xc2\xa9\xf7d\x83\xbb\x90\x91*\xab\xcd\xe4	\xe7\xfc`W\xb9\xb7\x02\x0bk\x9e\xf0\xbf\x16\xad\x9dIi\x0bg\x0d\x1aN\xa1\x95m\x00VH)Hk\x98\xacN\x9c\x16\x06;\xde\xa1\x8ca{\xd4(\xd9R\xfc\x06(\x04\xcb\xd9/\x8d\xf2\\\x82=\x0d\x0c\x10\\.\x18\x9f\x98\x04\xe3tU\x01\xf3\xca\xc5ZZ\xad\x95\x99~,\x05J:5)[\x93\x85X~4b!\x94\x16c\x8d)\\\xbf\xe9v\x00\x18\x0b\xa7wR\xcd\x8c\xc5#\x8c\xb1\\&\xbfn\xacx\xbc\x9ca\x1e4R_h7\x13\x07\xfd%I\xb1\x92B'\xce\xe6)\\]\xed\xd4\x1c\xd9\x02y\x86\xa1lCg\x89S\xb8\xba~\xf3\xff\xef~8'\xe3%\x89\x18\xe0\x15S\xc0\xadP\xb38\xcfe\xf2\\.\x01^8$Q~[\xdbxf\xd6\xf3\x10\xf9\xab\xa5y\n\xd1\xbb\x9a\xeeHYR\xbc\xba\xd5\xc2\xfbacT\x93\xb2\xcf\xb7\xb9\xa9\xa5}\xeb\x8e\x19\x1e_+\xf1\xb0\xd5H\xed\x12$0\xc7U\n\xb75\xdaM\x1cv\xff`\xf4V\x05\xc0\xba\xa8c)\x85\xbb\xa5\xf2\xeckF\x028\x99\xa0\xe4\x14\x86\xf6\xa9\xae\xe2Kt\xee\x96(\x03?\xab\x12gB(\x83\xd4\xf0\xf8\xe4\xfd\x11\x8f*\xc4\x14SX\xafAZ\xc2\x8c\xd0\xd9\x8c\xace\xd8l\"-\x10\xa1\xe1\xac\x10FM\xd0\xf3\xe7^\x8e\x0eM\x8eF*\xf4\xbd/\x9f{\xad\xdaF\xc2>\x83\xf1\x9f'\xd9\xfb\x02\x9bM\xdb\xde(h=\xb2Z\xc9U\n\x1f&C\xcb#B\x8f\x86wRh\x16[\xdf\xf7\xde\xbf\x7f\xb8\xbf\xf9}\xf4\xf8p\xfb\xb4c\x01,\x84\x0e\xf8\x8el\xb1\x97\x8f\x87\xd0\xdb@\x12\xdf)\xd4y}\x19\x9e\xe2\xa7\xa0U\xa1\xd8\xf7\xa5\x0bG\x06\xef?e??<\xfd\x9a}\x18=opr\xc6PI\x1f	\x9e\xa5\xe0Yp\xf0\xfd\xd8\xbc\x1fFG\xd1\xdd\x7f\xca\x86\x0fo\xef\xb2\xe1\xcd\xfd\xdd\xb71\xe7P\xf6c\xdf\xc7\xce\xde!J[\x14\xc2\xe4ig\xdd\x055\xf9F\x05\xee{\xa7\x15\xbf\xea\x0dz\xaf?'\xd7_\xfa\x9e\x05\xb1\xff\xaax\xf6\xaa7[9\xa4\xd8\x14\xbd\xd7\xd0\xdd\xf7A\x02\x83\x1d\xa7Al\xcc\xdf\xba\x0b\xa8=\x1e(\x05O\x03m\xa5\xd0\x83\xb12\x83\x03q\x93\xabI[>I\xc6\xca\xe4\x89\xc8sB\xef\x7f\\\xafK\x0b\x99p*\xf3(\x03a\x16\xf9Y\xcd\x8f\x8d\xba\xee\xc2\xc4\x12(\xc6\x02\x94\xa9\xc4\xcbh3AS\xdfF_\xaf+\xb9J\x0dM\x1e5\xbb;\x8c9\xae\xfe\x17\x1b4\xe2LPp47\x15\xe5\x1d\xc7X\xf8W\xaf\xe1o\xd0\xca\xf3\xa1\xcb\xb5lR\xca\x96>\xe3\n6\x9b\xf8\x15\xd1\x0e\xad\xd5\x9aP\x06\x14o?k\x18\x97\xdcl\x18Gj\xa14N1o\xdd\x97\xfb9\xd9]\x15\xf1\x10\xfe\x19\xd0s\x8b\x06 ](\xef\x89FF\xb6\x82\x99t\xa19\xe4\xf1\x14XXZ\x9d\xd5\xa8\xd8u,j\xd2D\xad&\xb2\xc4\xb4t\x82Q\xab6\x92\x06\xf5\x14o\xbb\xfa4Xw\xf3\\<\x0d\xe1]\x92\xab\x8e:\x87{\xca\x97s\xb1\xb7U\x8e\x0d\x1c\xb5\xef\xc2\xeaP\xe0}|\x9d\x1a\xb5H\xa0\x88\x94j\xc8\x07\x0bA\x03\xad\xc6\xe5$h\xe4\xf2WZ3Q\xd3\x9d\xc2v\xe5;\xc9\"\x14y|\xbb\x0e\xfa\xa2m\x84\x82\x19,9\xee-\xbe\xaf\xad\x9c\x1fA\xd7\xcc\xa4\xc5lcD'\x0b\x1b\x9f\xbc\xeds\xb8\xdfk\xb5\x1a'\xc7\xbc\x7f\xe5Z\x8c?\xba\x97\x8f\xc31n\x8b\xd8\xd2\x8bo\x1b\x12g\xb9\xa2v\xe3\x1e>\x94I\x14{\xd6\xab\xaaR\xbb\"%\xe5\x86RzXS\xe2q/\xa8X\xb5Z\xbeS\x8d\x05\xe1L\x11/\xda\xbaP\xb8\xbd\x85\x07\xba%\xdc\xee\xae\x17kz\xd1\xd6\xe9\x02Wf\x1a{\xe8\xb9\x8a_\xc4>S\xe5\n\xfc\xad\xa2r\xdb\xdf\xef_\x95\x8d\x86\xf0%\xf0\xb3\xadp\x19}\xff\xf4$\x12\x89}\xe7\x9f\x01\x00PK\x07\x08\x80\x81\xd3n\xb4\x04\x00\x00\xc8\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00R\x00	\x00roles/kubernetes/control-plane/templates/usr/lib/systemd/system/kubelet.service.j2UT\x05\x00\x01\xf0b\xe7[\xa4X\xffo\xdb\xba\x11\xff\xdd\x7f\xc5\xd5P\x9e\xec\"\x8c\xda`\x0f\x1b\\hCW\xa4\x0f\xc1\xdb\xc3\x0b\x9a=l\x80k\x084u\x8a\xb9P\xa4FRr\x03\xdb\xff\xfb@\x8b\xb2\xf5\xc5N\xda,@\x02\xf2\xf8\xb9o\xbc\xe3\xdd)\x9b\x0b0h\xe1\xb1\\\xa2@\x9bd\x82>\x18\xb8\xd8\x8d\xc0\xff\x10R\xc5\xd7\xf0\xb5\xb5gJf\xfc!\x8e\xd0\xb2\xc8\xb1i\x89\x16MT\x93M\xe4%]\xb9}\x87O\xaa\x14	/\xe2`\xf3\xeb_\xee\x93O\xff\xf8\xe3\xfe\x9f7_\x92\xdb\xcf\x1f?\xdd$\xb7w\xbb\x0eV\xe3\x037\x165Ys\xbb\"\x96riM\xbc\xd9\xc0J\x19[Qm\xe6\\V(\xad\xd2O\x89#I\x9a\xe3b\x1e6N\xd4\xf8p\x01[H1\xa3\xa5\xb0\x93\xf9b\n[\xa8\xa8\xe0\xa9?\x86-\xfcGq9	/\xc3)\xec\xba\xea\xf7\xa6\n\xbaD\xf1\x03Zk\xfcY\xad\xf5\xf1P\xeb\xe6\x02x\x06L\xa82M\n\xad*\x9e\xa2NP\xd2\xa5\xc0\x14\xa8L{G\x10\xc7\x10\"\xbb\x0e\xbbA\xda\x83H\xc3\x1f\xd3\xb5\xe98T\x1f\xb7#G\xd7\xc6\xfd6a\xda\\\x00\x8a\x1f\xb6\xa32\xc5\n5>o\x8b\x07\xbd`\xcf\x89L\xf2\x8c\x1d\x13\x0d>\xab\xcb\xc3d\xca3\x87\xdb\\@\xa64p\x8b9p\xb9O\xf2\xa4	\x17\xd5\xddD\xdflj\\\x13\x14\x94\xa9\xe3\xad\xa5\xb8\x9bQ\xd2%\x0e\xeaD\x97\xd2\xf2\x1c\xe1M\x0ca\xaa\xd8#\xea\xf0%e\x9e\xe5\xc7\x94\xb6\xdd@\x99\xbagz\xb1\x1b\xcd\xff\x90\xdc.F\x1f3\x8b\xda%\xe7\xc0\xae\xc4\xa0\xae8C\xd8\xedF_\xf0\xbf%\xd7h^\x04\x8e\xe6\xf75\xd7bt#+\xae\x95\xccQ\xda\xcf\\`\x9d.x$\xb6\x01\xf1/\xbf\xff\xf6\xf1\xdfw_~\xfft\xefT\xcc'\x13*\x0d_\nt\xf9\xca\xd0\x18\xa5\x13\xa6Ji\xe1-\xbc\xbb\xfa\xf3\xcf\xd3-\x97vz	\xef\xdd+\xc9\xe97gc[\xdc\xa028\xa9L\x94\xae\x16$<\xa3\x0c\x13\xf7\xd6a\x0b\x1a\x0bA\x19N\xc6\x8d\xc2\xf1%\x8c\xc7\xee%\x8fn\xbe!\xbb\xb7T\xdb;\x8dq\xb4\xe42\xca\x1fS\xae\x81\x14>\x07\xa3\x8a\xeaH\xa8\x87\xe8pw\xa6\x7fR\xa8\xb4K\xe3\xcb\xa6\xb25dU\xd8\x88I\xdel]\x12\x1f\xb7\x9b\x0d\xa0ei\x92RK\x13\xa7|\xb7\xeb\x8bcTp\xa6NXkV@\x18\x84Qi\xf4\xdez\xf3d,\xe6\xcc\nW\xa9I+\x12p\xaa\x90\xc6\xc1$2\x8e\x8dg\xee\xe1\xf0\x078Qpw\xb0\x85\x83|\xba~\x84\xafa\xf0\xde\x95\x961\x97h\xc7\xb0)4\x97\x16\x82\xeb\xdd\xd7\xb0\x0de\xa5\x05\x92\xce\x80d\xd7\xd3\xf0\xec\xc3\x88\xbb\x0f\xa3\xe3!9\xa8\xad\x11\xf0\xc8\x85h\xda\xcf\x0bP\x9d\x0f\x81q_\x9e.%\x10\xa2s D\xa2\x8d]\xa1\x06B\n\x9e\x1e\x96\x9aW\\\xe0\x03\xa6>t\x84\xecs\xca\x8bn\x88\x95\x8b!Cm}\xf8f\xbd\xadVGd\xaf\x82\xcdz\xfb\x1e\xd0\x18\x119If\xd6\xdbvD:\xb7\xcc\x8aj\x8c\x18%\xee\x9cg\x9c\xd1\xbd\xf4\xf3G\x03	B1*\x9e\x93\xf3\x1c\xa0#\xcd\x99:\xb0\xe4\x14q\xc0\xe5\xee\xddc\xeb\xe5\x00\xa1\xd1(Q\xed\x0b\xfdl@\xe8\xa0\xfd\xfb\x9c\x1d\x16z}\xb97\xfe\x10\xcc\x06\xc4\x97>#f\xfd\xbd^\x9f\xc0\xfa\xe8\xcf\x06\x84S\n\x8c\xae\xda\x0d+\xa7\x92g\xb8w\xf2\xecI\xc7\x0b_:f\x87E\xc7$_If\x87\xc5\xc0`]\xca\xdaP\xb7\xe8\x9c\x9a'g\xc4SO_\x8a\xd5\xcc\xfd\xe9\x89q~\xd7U\xe8\xe8v\xbd\x87\xaf\xfd\xdeI\xd3\x94[\xae$\x15\x89\xae\xef#\xc9]aO\n\xb5\x9f\xa6|W&U\xab\xa7\xcdZK\xcft\xa6\xb7\xbez\xfa!\x15\x0c\xc6\x99\xd9\x90\xa2\xd5kg\x9c\xb1\x9fB\xc6-\x0f\xcf\x8d\xbe\xed\x81evn>\xee\x80\x0ev5\xad\xde\xdd\xa1+4Jc\xa2\xb1P\x89V\xca\xc2n\xe7h\xa5\xd6(m\xd2d\xdb<L\xb1@\x99\xa2d\x1cM\xb8\x98\x87\xae\x9di%H!\xa8\xc4\xf08\x9a\xba\xa5\xd1,\\\x1c\xbb\x90\xef1\xcdL\xe6\x80\xcc\x8a\xa4Bm\xb8\x92q\x10L<	<i\xfa\xc1'\xcf\x00\x9c\x18Kmi\xe2 \xf8\xdb\x11\x83l\xa5`\x1c\x04=\xec\x18\xfe\n\x91\xa7]y\xda\x95\xcd\x8b##\xcf`\x0eApZ\x07\x10\x89\xf0\x0e\x16\xb0\xdd\xc2\x1c\xde\x00\xc9\x06\xb1p\x9d\xef\xaa\xa7\xe1\xc8\x10\x04\x93\x94g\xdf\xc9u\xca\xd0),>\x80]\xa1<\x18|\xbc\x8f\x82\xda\x95\xbb9\x8d4\x15\\>:\xf3\x82`\xb2^q\xb6j@\xd3)\xfc\xf4S\x8b\xd7\xb9;o_\x94\x132v\x1d\xf4\xedx\xf5T\xa0v|\xe3\xb7\xb0\x18\xaa\x05\xd8;\xcb\x8a!\xfb\xf8\x94\x7fGq]\x13\x00\xd8J\xad%\xb8L\x9b9u/\xb1\x1f\x83\x05\xf5D\xfez\x9b<\xe4u\x165\xccm{2\xde\x15\xf5\\\x1e~G\xe6\x1cEg\xfc\xb86\x98\x02\xe1\xce\xa3o\xf6O\x10Go\x96\x1f\xe4\x07\x13\xe5h\xe9~\xdec\xa6\xcc/\xa3h\\\xa7Y\xfe\x88\xd7\x99\xff\xd0\xda\xdb\xe6\xc7\xa6\xff\xff9_\x99Bp;	\xa3p:'\xef\x17W\xc6MN\xc6}2O\xc2C\xb4\xc3iS\xb9\x00\x8e9\xd0\x9av\x06\xdfU\xdd\xa3\xc3G\xc8\xa6\xf7O\x82\xddn\x8f\x86\xd0\x0fc\xaa\x18\x0ew\xc6\xaa\x02\x88\x85\x9f\x1b\xde\xb6\xb6\xc3\xb0\x17\x9f\x8b\xae7\xf0\x9cf\xf7%\xe9\xb2\x96\xa4\\\xc7\x87\x0e\xe6\xb1\xbe\xcf\xd4_P_p\x7f7\xb1\x92$\xa3\\\x94\x1a\x1b\xd2g\xa5\x19\xde|\xe3\xf6\xbe\xaeb\xf7\xb7\xbf\xdc\xdd\xde\xdd4\xc7\xf7\xc8\xe2\xf7\xefF\xbfr!~S)\xc6\xfe\xb3f4\x9a\xdfJc\xa9\x10\x8b\xd1\xbf\xa8\xb4\x98\xfe\xfd)\xceKa9)\x0d\xea+K\xf5\x03\xda\xd1\xff\x06\x00PK\x07\x08\xfc\x0e\x1d\x87\xae\x05\x00\x00]\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00F\x00	\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[\x8c\x921o\xdb0\x10\x85w\xfd\x8a\xeb`\xa8\x1d\x1c;\x1d	x\xea\x7f\xe8\x12\x18\x04C\x9e\xac\x83i\x928\x9e\x84\x1a\x0c\xff{!YJ=\xb8I\xe6w\xdf\xbbG\xde;Sp\n~\xc5\xd0\xd1\xa91\x89~#g\x8aA\xc1\xf8\xdc\xd8\x81\x19\x83lm\x0c\x82\x7fDA)`\xfd\x90\x05Y\x07sA\xa8\xb5I\x8c\x1d2\x06\x8bYA\xa9\xcd\xa2g\xd5l\xd7Y\xd5\x00\x00Xd\xa1\x8e\xac\x11\xdc\x9aA\xfa\xc8$\xd7\x9b%\xb2hG\x0c\xb5\xeeJ\x01\x8eQ\xb45\xda\xb2\xe8\x8e<\xae\x9b&\x93\x8c<\"+(\x1b\xa0\x0eN\x1c\x87\x94_\xda\x8b\x99V\xb6\xc77\x8f\xe1$=\x1c\x0e\xf0\x0c&8X\x15\xa0p\x1b\x9ecg\xd8\xd4^$e\xb5\xdb\xf9h\x8d\xefc\x16U\n\x9c\x87W\xd4&\x91\xce\xd9\xeb\x14Y\xa0\xd6\xb2\x01\xf4\xd4\xc1w\xca\xba7zy\x12\xbc\xc1k\x8c\xfe\x07l\xea\xca\x8d\x94n\xec\x1c\xf1\x8e\xb4>\x0eN'\x8e#9d\xf8v\x80\x16\xed\xcf\xf6.D)0E\x18\x0d\xbf?f\xbf\xdf\xb7\xc7\x17\xe3\x1cc\xce\x9a\xc2\x88A\"_uG\xe8\xdd\x11j\xfd0o\xc6\xaf\xb8\xb7\x89i4\x82\x9aR\xfb\x99ep\xd4\xc1\xa66\x0d\xc0\xf4\x85\x0f\xab\xb0\xd4\xe4v\xfa\xa52\xf3\xd5\x96\xc1\x87\xd0\xa4\x0f\xf9\xb1\xb85\xeeB\xe1\xa3\x95\x139\xef\xfb_\xa8w\x8birMCs\xa9\xff\xf5\xf1Q\x0b'9?\xcd\xf4\x93\x9d\x9bp\x0f\x9f\xf1\xfa\x19t\xc6+\xd4\xda\xfc\x1d\x00PK\x07\x08\x81g*k|\x01\x00\x00`\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00M\x00	\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/remote-kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[\xac\x93\xc1\x8e\xd30\x10\x86\xefy\x8a\xe1\x10\x05\x0ea\x0bGK\x9cx\x07.\xab\x95\xe5\xdac:\xaak[\x1e'b\xe5\xf5\xbb#'\xe9j\x0b\xa5E\x82\xab\xe7\x9f\xef\x9fL\xfe9\x927\x02\xbe\x06o\xe9{\xa7\"}\xc3\xc4\x14\xbc\x80\xf9S\xa7\xa7\x94\xd0\xe7Q\x07\x9f\xf1G\x16P\nh7q\xc6$\xbd:!\xd4\xda\xc5\x84\x16\x13z\x8d,\xa0\xd4n\xab\xb3\xe8\xc6\xb3Vt\x00\x00\x1aS&KZe\x1c\xd5\x94\x0f!Q~\x1e\x8d\xcaJ\xc0\xcb\xb8H\x00J\x0f6$p\xe4\x11\xc8\xbf\xed\x91\xaf=\xb2\xf5|\xe4l\xc2\x94eS2\x8c}\xdd\x00\xd0fl\x8fm\xb63s\x04\xf4\xa6q\xfb\xda-\x8f\x8ci\xc6$\x9a\x1dYxO,\x0fJn\xd3\xc2\x0b\xecCp\x1f\xa0\xafk9N{GZ\xce\x94\xf2\xa4\x9c\xa4\x08\xca\x9b\xdf_%\xb3\x931\xa4|Y\x8e\x92\xac\xd2\x08}=\xe4\x1cY<<\x94r\x05Y\xab(\xe5\x16\xb4\xb6i\xd0q#\x95\x02\xc7i\x8f\x0b]E\x92\xeb\xe7l\x12o\xc86M\x0f\xe8\xc8\x82r{i<\xaf\xff\xebb\x88\x8b\xca\xea\xbfP\x17\xe2/\xb6dA\xbb0\x19\x19S\x98\xc9`\x82w_`@\xfdy\xb8d\x1e\x02\xe7Y%~\x1cN\xaa-s\xb7\xdb\x0dO\x8f\xca\x98\x84\xcc\x92\xfc\x8c>\x87\xf4,-\xa13O\xf7\\\x19\xff\x86>\xc4DsK\x08\xc5\xe1\x1er[NKA[\xc8\xd5Doi_\x13\xbc%\x7fI\xcd&\xbc\xda\xd4\xea\x13_/\x8e\xca\x9c\xc8\xdf\xb2l\x9d\x8b\xdf\x9f\x86zE4\xe5y\x1aZn\xf3\xcdY\xdd>\xa6\x05!\x9b\xfe?\\\xd0\xe6~\xc4{'\xbc\xba\x1e\xf1\x1f\xcf\xf6\xe7\x00PK\x07\x08\xe9\xc2\xafj\xab\x01\x00\x00\xa7\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/kubernetes/core/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xec[_\x8f\xdb8\x92\x7f\xd7\xa7\xa8\xb3\x118\xb3\xd7v\xdb\x9dtf\xd6\xc0\x1c\xb0w;X\x0c\x0e\xb9}\x98}\x0br\x02-\x95mnS\xa4\x86\xa4\xdc\xedI\xf2\xdd\x0fE\x89\x12%Q\xb6\xa7\x0f\xf3\xb6y\xe9n\xb2\xeaW\x7fX\xac*R\xcc^\xe9\x0cSV\x96\xe2\xbc\x853\x9a$\x18H\xf7\x82\x1d\xb60\xfb\xf2\x06\xf8\x1e\x82\x89\xaf;\xa5\x04\xbc\xf9\xb6\\\xbaA\xf8\xf2\x06P\xe6|\x0fo\xbe\xcd\x92\xe4\xa9\xda\x11\"O\x0d\xea\x13\xeaT\xa8\x8c\x89-\xcc\x8e\xd6\x96f{\x7f\xef\xfe>*c\xb7_\xbe@GlDZ*m\xe1\xdb\xb7Y2\x1a\xdd\xc2\xec\xc3\xe3\xe3\x0f\x1e}\xcf\xb5\xb1i\xc1\x8cE\x9d\xee\x7f\xcd%i\xf9\x05\x08\xf4\xc4\xb4\xf9\x04\x8bzn\xbd^/>\x7fZ\x10\xc5\xe23|\x05\xa1\x9eQ;	\x1a\x0f\xdcX}n\xd0\x1f\xd7\xebu7&v\xdd\xf0c\x92\xab\xec	uZ\xd8j \x85\xcb\x13J\xab\xf49\xa5!\xc9\n\xfc\xfc)\x13\x95S\x8a\xefY\x86i=\xb6(l\xe5\xc4\xe7\xb8g\x95\xb0o7\x8f\xeb\xf5w\x9d\xa1\x9e'\x97f\x0b\xb3\xcd\xf7\x0f\xab\x87\xcdj\xbd\xda\xacgI2\x87gn\x8f\x90)i\x19\x97\xa8s\xb0G\xd4\x08\xdc\x80T\xe05\xbe\xa3Q\xe0\x05;\xa0\x01\xa6\x11\x84b9\xe6\xa0$\xe0	\xf5\x19\xa4\xca1\xc9\x94\xc6Tc\xa9R\xad\x94m\x17\xb6\xc5Nu%-/\x10~\xfc\x11\x16\x9d\xc4\x05\xbc\xf9f\xf3\xec\x9eVY\x18\x847\xdf\xfc\xba\x9d\xb8\xb6\x15\x13)/i\xbd)H\xb8I\x8f\xcc\x1bT\x93\xbfmW\xa5\xb7(,\xcf5\x1a\x93vN\xdcs\x14\xf9gB\xc9\x84\xaa\xf2\xb4\xd4\xea\xc4s\xd4\xf0o?\xc2\x02\xb3\x87E\x8d\x17\x87[\x94\x9a\x9f\x98\xc5\x94\x97\x8b\xcf\xe4[\n\xae\xe1\x82Nh\xd8\x925\xf1w\xef\xad\xedbz\xbc\xac[\x981i\xf8N`\x8a\xf6\xb8\x9e\xf5I^\x17).$2\xc9C\x8c\x1e\xd9*\xc7\x13\xcf\xb0\xa3+S\x94\x19+M%\x98\xe5\x8a\xb6\xc1_\xc43;\x9b\x19\xcc!c\x12v\x08\xc8)b\xe0\xed\xec\xef\xfb\xfd\x0c\x94nI\xbeK\xe6.l\xfe\xeb\x7f~\x86\xd6\xd7\x0dS\xc6\x04\xcf\xd4\x1dd\\\xf0\xaa \xae\xbd`R\xa2 \xf5\xb6	\xb4\x0c\xdb\x864\x81\x86\x96&\x01lE\xc4[8\xbd\x08&;]\xdc\x9fwp@\x89'$\xd4\x9c\x1b\xb6\x13\x98;\xa6R	\x9e\x9dS\x94.\xab\x14(\xed\xd6o\x99\x0e\xa2\x19\xb8\x03\xe6\xac \x10\x02\xd3	x\x15k\x0dv,{B\x99\xc7U .Z\x9c\xe5\xe1\xb9\xbf\x053\x9ek\xbf\x07\xdf\xaf\xd6\xab\xf5\xfd\xe6\xc3,I\xe4\x81\xcb\x97\x94\xcb\x83\x0bY\x94N\xe7-H\x95\xe4\xd2\xa4\x8c1\x96\xe6(\xd8\xb9\x9b\xa2TZo\xb8\x82I\xbeGc\x0d\xe9\xb5\x04J	[\xb7\xe7\xb4\x12\xcbR0\x89KJ\x94<C\xe3\x14\xefA\xd0@\xa9Q\xe3\xaf\x157\xdc\xa2\x1fmq\xbc\xf3\x03>\x175\x92\xaf\xfc\x12\xd5\xdb\xd9\x11.\\\xe4\\\x05u\x8b~\x0b\xa8#\xbc\x0d\xb4Y\x9c\x1bP\x1b\xca\x1buU\x1as\xf9\n\xcf\xe5\xd2,i\xe1\x96n\xe1\xfa\xfc\xe4\xc1\xf8\xc2\xc2Wp\xb5\xef\x16\xd5(\xb0\x96\xc62\x8b\xcb\x02\xad\xe6\xd9MZJ\x15.om\xdd\x92UV\x99\x8c	\xd4\xfd\xa5\x9e\x84H\x92\x0c\xb5Ms\xae\xb7p\x8f6\xbb/\x9fx\xe2\xe3\x97\xb2\x7f\x9a\xb14\xd36\xdds\x81\xb5\xba\x83\xd9U\xa6m\xcb\xe1\xc0\x9e\xf0<&_=\xe1\xb9O\x16E\x1d\xa3eFG\xd02\xa3\x93$\xae_\xa8\x97\xa7\xe8\xab\xe4)H\xa5\x04m\x96OX\x1aN9\xc5\x9c\xb3\xdc\xfedy\xc1%\xfd\x02\xf0\x84\xe7m3\xe0\x10\xc9\xf1\x99\xb6\xed\x181\xd2X\x89EKW\"m\x1b\xc2\x0f1\xe8\xefT\xaa\x1c\x078\xddx\x1f\xab\x1b'\xbc\xd6\xbb,\xcf9%y&\xa8SH\x99\xb0\xaej\x98-|\xfa|\x89\x88\x97}\x92&\x83\x0e@\x92%\xcc\xda\xcel6IL`D\xbay\xf8\x9e\xd2\xe3j3\xca\x8eMj\x13\xa8\x9d\xcd)\xdb\xef\xb9\xe4\xf6\x9c\xfary\xd0\xaa*\xb70\xab\xbb\x81Y\xd2\xeb\x19\xea\xcd\xe7H\xda\n\xefz'\x81\xf2`\x8f\xf0\x1f\xb0\x01&sx+\x95\xf5\xd5\xc37\x82G\xd6lO\xaa\xffM\xab(\x90\xfa\xc4\x97\xb4T\xb9\xd9\xc2f\xb3\xa6\x96\x8a\xea\x8ff\x82\x02\xe6	\xd8\x89q\xc1v\xdc\x9e\xef@*{\xe4\xf2PO<s!\xa8\xe2\xd8#7\xb0g\xc2`2\x07\xda\x89\x05\xb3<cB\x9c\x81\xcbLT9\x1a\xd8	\x95=\x81\xb1J#<\x1fQ\x82\xd5\x15\x02\xc5\xe9S\xbf&\xb8\x91\xcc\xf0nT\xaaz\xb0P\x92[\xa5\xb9<\xf49\x92y\x932 \xc3\xf2\x0893\xc7\x9db:\xaf\xb9\xda?\xfbL\xc3\xb9\x17\x8b\x9a\x02g$\xb5\xa3\xa1\xe6g\x0b\xdf\x7f\xf8\xf0\x18\x884\xef\x80\xba\xce=\xcb\xbc5j\xf7O\xccl\xeaL\x8d\xc8\xecMk\x96+\x93\x1e\x98\xc5\xe7QmlE\xd0\x0ewh\xb5\xd2\xf4g\x0c\xbdqR\xac\x98\xd2\xcc2SE\xa1d?\xc3R\x1e\x0f\x97`\x98\xbdU\x89\x9aY\xa5}\xe6\x06\xd8+Q75u\xd7\x15\xa4b\xc2Yz\x86?XL'\xfdU\xc6Hu\x8b\x90R)\xf1Zw\x0d$\xd0r\xb1\x03\x0e%4\xc3\xcbL0c\xfeXQu\xd89\x898\x96\xf46\x12\x04\xdf\xd5id\x1c\xb4C\xb2I\x95\xe6`\xb1(\x95f\xfa\x0c\xcfJ?1\xad*\x99CI\xc7\x06\x9fC\xf6\xfc\x05\xac\x82#\xa3v\xd7Ii\x92DeP\xc3\xaf\x15VH\x87\x92\xdeT\x03\xd0\xc8(\xab\x9d\xe0\x19pc*t*\x97\x1adU\xecP\x13\xf0^	\xa1\x9eoY\x8c\xc6C$\xb7\xef \xa9`\xfe\x07z\xe9\x8aZ\xb4\xdd\xcd\xd9X\x8c\xf4\x9b\xd7\x14\x1a\xa7\x8a^\xfe\x7f\x8d:V)\xb1S/C\x0f]\xc6\xf2LM\x8e\xa24\xed\xb3y\x9a\xa9\x8a\x8e1\xef\"S\x8c\x96.-*ay)0-\x9b\x82\xd9U\x04G]\x19t\x87\xc6T\xa2\xa5(k\x8bB)X}H\x02UR[`\xe8\x86\xa6\xae\x11T\xec\xee\xc0\x1cU%r\xaa`\x1ae\x8e\x1as\x8a\x18\xc3\xe5A \x08.\x11\xfei\x94\x0c\x14k\x11\xd3\x06\x91T\xdc\xc2lv\x91\xa6P\xf2*\x8d2\xf9u\x9c\x03\x15\xffY2\x87\x7f(\x7f>\xa2Z\xaa\x11NLsU\x19\xf0\xe7\xa4\xb64\x9b\xec\x88yE\x0b\xbf;\xbb~[K\xb4h\xeeh{\xb9\xb3m\xab.!\xee\xf9\xa1\xd2\xee\x9c\x0c\x063\xfai`\x87B=\xafH\xe8\x11\x01_XQ\n\x84\x8a\xdc\x05\x0b&\xc4\x02\x9e\x9d\x13\xdd\x06fBt*t\xa2\x95\x0cD\xbb\x9b\x16\x03\x82\xed\x90\xe6\xdc\xe5\xcdB+\x81?6\x11\xb7\xa0\x00\xa6\x93\xb7\x12\x14\x9a\x08t\xcbb\xc9${\x04F]'\xa8=,\x1a\xea%\xc1-H?\xaf[\xbb\x96\xe4\"2\x8cK8\xb3B$sh\xff\x11\xd3_\x9a\xb6k\x1bN\x00\xb8\x93\xa4\xc6\xfc\xaf\x15u\x1a\xbf\xd4Fpy\xf8\xf9 U;\xfc\xd3\x0bf\x15\xf9g\xc0\\#\xff\x82\x023\xab\xf4?P\x17fD\xb1\x84\x82\xd9\xec\xf8\xd3KI\xdd \xf9xDBD\xae3&\xbf\x8c'\xc3]\xfb\xb3\x8c\xcd\x9f\x98\xa80\x86K\xc8\xa1\xe7B\x8aR\xe5Q\x9f\xd0\xb8\xb4<:\xd7,\xd2\xc8\x8aF\xff)Q\xa1\x05?\xbdpc}\xfbR\x07a\x9a3\xcb\x82\xb3Y\x17=\xf7D\xe5ii\xf73)\x95u!{aOF\x88\xc6\x9b2B4\xde\x951$\xbf-\x03*!\xd2Rs\xa5\xa9\xabw\xc5}\xeb\xf2\xce2\xd3\xdcu\xc6\xa1\x05\xc5A\xa7\x1a\x8d\xaa\xe8\x9eY\xf0\x82[\x93fe\xb5\x85\xcdz\xbd.\xae\x10\x16t\x1ez\xbf\xfe\xf3\x87\x8f|\x8a\x92\x02\x1a\xcdM\xa0-i\x08\x1b\xd8E\xc4\xb7\xdb\xa5\xe4H]\xa7\xc2\xe3\xc0\xac\x08\x9d\x93\xbfY?\xbc\xff\xc8\xa7(\xfbf]\xc2\xec[\xd5\xa0\x86V)y\xcd\xaa9\xf5 \xc4\x01\x7f\xff\xe5\xaf\xa6Nv\x02\x8d\x01{d\x12\xde\xff\xed?\xdd\xfd\xb2\x84\xb3\xaa\xe0\x80\x96\xfe\x00\xd4Zi0\xec\xcc\xe5\x81v\xe2l\xcf8e<\xab \xd3\xc8,\xfa\xe6\x96\x12\x14\xf5\xb6\xa6\xa4C\x04IY\x92^+\xe8\x18\x8ce\xbaFU&7+\xd0\xb8w	\\\x81\xae\xa4\x1b/U\x93K\x1f\xd6\xef\x7f(v\x94\"5+\xee\xfc5$0\x0b\x02\x99\xb1.X\x8a\xdd*,Y\xca\xe4\xb7E`\x8c0\x1e\x81=\xca\xcb\x11\x18'\x0da\x03\xdc&\xa1\xb8\xa2O\xbb\x8c\xd2\xad	NWQ\xa2\xfaN\xd8\xb8\xbe!\x99\xfb\xa4\x04\xcd0y\xf1\xc0OH?s\xae1\x13\xf6L\xbf\x13\xd4]2wM\x83\xaf-\xb3\xff\x95\xa7\x02\xd7r3\xf3\xb7\xad\x95\xa9\xbf\"\xf0w`\xcf%6\xd5\x8dK\xc0\xec\xc1\x1518R\x83\xa8N\xd4\x8e\x1e\x11\x88\x1f\xdc\xd7\x19\xc8u#\x95$\x013\xc0$-o\xc4\x90ZSj\xe7,\xeaAF\xf24t5\xd1\xdcp\xf7r\x91\x9f\xbf\x12\xe1\xedq\xd3\xb8{\xdf\xda\x13t\xda\xae\x1d\xe4-e\x86T\xacU7\xd4c\xa1\xde+]0\x99\xb9\xb6\x9c	K\x0c\xee\x1bX\xcc\x8e\x0e\x94\xae\x7f\x96\x00\xf7\x94\xe9]R\xbfo\x88\xee\xd7\xe1zw\xddoZ\xa0e)\x9d\xc9\xe8\x0b\x8d\xe0\xb5\xb5\xb1\xf61\xe0\xc9\xd9\xef\xe7)r\xe3Q7qMXf\xf9\x89\x1ak&\xf3]\xf3U0J\xd86W\xbe6\x0dz\xbc\x10sTX\x86E% \xbe\x9a\xad.\xb4\xbe\x1d\xcc\xef\xeb\x82oh\xb0\x92y\xb4\xb3zeG5\xddI]\xec\xa0&:\xa7\xa9\x8e)\xd2)-\xa1\xc8M\xdb\xb2\x0c;\x9c\x06?$\x89t3\xf3q;5j\xa3\x92yw\xb5\xe3\x9dd\x86\x89\x1a\n,\x94>\xd3\xf7\x18\xf7).\xac0\xf5\xd9\x96\x8e\xd2\xae\xd6\xc4\x8bL\xb0\xde\x14K\xcb\x83P;&\x96\xad\xe4\xed\xa5bB\xc5\xf2w\x16\x93N^\xbc\xa6\x0c*\xf5\x05\xf2xe\x891\xf4\x0b\xcc\x0d\x12Z\x86\x892\xd3\xbb\xef\xb89\xf1\xf4\xb8nN==.\x7f\x15H\xb5d\x0bf\x92\x8eKc)\xe9\xc62U\x0f0(\x92RM\x11\xb5~\xb9X\xfc/q8?R\xeb\xf1\x91_e\xb9\xd2\x0e\\\xe6	\x05M)\xd7f\xbfA\xba\xed\x11u	w\x94i\xfb`\xff\x8f\\[\x039\xa5\xfe\x95l\xa7\x92\xad><_K\xb6!\xc9k\x93m\x17\x06M+P\xf7\x12\xcd\xfey75?\x0c\x93\x8e\xcc75\xae\x06\xc7{\x92d\x0e~k\x81Ak\xb9<P\xd3\xb4\x9c\xfaG\x0c\xcd\x07\xeb\xfa\x87\xdb\xba\x83\x0d3{x\\\x17\xb38A],\x1c\xcd\x87\x8f\xbcO\x14\xeem\xba<\x8c\x02de\xe5\xees#\x8c-\xf6\x14oS\xa9\x88=q\xf9I\x88\x94\xdej\x0c\xd4w\xdaG\xe7[	\x1b\xa7|H3T>\xca\xef\x95\x8f0\x86\xcaGyC\xe5\x1b\xe3\xed\xb9<\xb2\x1e\xfa\xd0\xf9\x03\x8aVH\xdf\xfb5\xd5\x84\xfb\xc7BB\xff\xf7XC\x1b\xe2*\x86F\xcc\x9b\xe7&I\xfd#e\x07\xeaC\xe3\xd6D)F\xd6\x84T#k\xa2\x10m4EX{\xd6D\xb9{KRS\xf8\xad\xdf\x13A\x01\xb3\x0e\xec\x18\x13\xb5\xb26\x0f?|\xe4c\xc2	k\xa2\xd2\x9c>\x13\x00\x11\x9b\xa6\x94q0\xc9\xbc}\xf5\xd1\xfc\x8c\x1b6\x9al\x05=\xae?\xf2n~h\xc7\x88\xcf/\xc8\x80!\xd4{\xc4\xd3\x0f\xaa\xc1\xab\x90\xc1#\x90P\x10\xb9\x9b\x94\x9f\"i\x85n\x9c\x0d\x03\xb2\xa1)S(\xde\xa28{h\xd8\x14\xc2\xc0\xbe\xe69U\xf3:*\xf1o\x01\x9a\xbf\xe3\x16N\xd1\xb4\xe2\x1fj\x13\x07t#\x1b\xa7pZ#\xe3\x00=+\xa70\xfaf\x1e\x91\x95\xee\x0b\xaa\xff\xa5'\x8c\x16\xc5\xa5\xe9\xf1l+\xeb\xc3\xda\xd9\xd4R\x0c\x8d\x19\xb3z+\x86,\xa1\xfac\xaePo\xc9\xa4<7#\xe9\xd3.\xf8\"4{X\xafg\xcd\xfc\xc0\x12g\xc8`&\x14\xf9\xd6\xbf\xa5\x08\xdf\xb9->\x7fm\xdeS\xfc	\xa6\xa4~\xe5\xd2~\x07\xff\x0eo\xff\xbc\x86?\xb9\xa3\x12=\xab\xf8o\xee\xf5\x18zd\xac\x9d\xb3\xaaG\x1c*\x16\xd59`i}\xb5c\x06\x1bcC\x17v\xf2'\x99BqC\xbe\xc0\xf1\x03V|\xb1\x9a\x11n\xb8\x00\x8f\xad\x97[\xa0\x9a\xaeY\xae\x80\xf4=\x05N\x10\x06x`\xd9h\xd9\x1e\xd6\xbd\x00\x1c\x12\xb5\x9a\xbf\xfb[/\nk\xb2\xa1\xeb/\xc9r\x16N\x00\xc4\xfd\x13U\xc5\xc14\x1eh(\xae\x05\xea\x10h\x14\xafC\x82W\x84\xed\xa4*W\xa37\xee\xc9\xb8b~k\xc7XC\xa5\xe3\xdc\xa3Hkd_\x0d\xf0\xa1\x8aA\x9cG!BU&P\xae\xea\xd2\xfc\x1d\xdd\x03\x9b.\xd5\\d\x1bo\x89\xba\xe3M\xe6\xeeC)]\xf7[\x04\xff&\x92\x86\xe8\xb2\xd1b\xda\x0c\x0d\xf7\n\xddx\xcc\xe8-\x04]\xde\xd4O\xf8\xe9\xb7\xac\xac\xdcw	(\xe8\xd5\x84\xe0O(\xce\xf4XK\x1e\x9a\x0f\xc4\xf4\xa9\xc2=\xb1\xc8\xa1*\xe1\xf9\xc8\xb3#\xb0\xfd\x9e\xce\xac\xcd\xbd\xd3j\xb5b\xc2\xa8;8\xfc\xc6KzS\xef\xdf\x11\xec\xce\xbe\xd4\\\xd4oX.\"\xb4\xc3\xbdz	\xce\xc7\xd94L\xb8\xc4\x97\x90\x82u\x8e\xa1i4\xfc\xb7\xa9\xaax\x0b}\xab\xc7\xe3\x94\xdd^\xc4M\xf6\x8f\xb5\xe9\xfew\xc4E\xd8\xab\xfe\x18 \xdf\xe8\x97\xe64\xdb\xb8\xc5\xffw\x87!\xed\xed\xdaz\xbcv[\xf9\xee\xf1\x06\x9e\xab&\x8eM\xbb\x015\xdc\xa9.m_YFo\x81=j4G%\xe8=\xc7\xa3\xeb\xb5\xfc\x0b\xec\xe6\xe7\xc4\xd9e8\xd9Z\xf5P7Z\x9e`\x18.#F\xbfG\x06\x0c\xa1\x97F<\xe1\xaa\xb7\nC\xf0\xa8\xdastCC3\xde\x85V\xc4\xc8Z\x05\xde\xf5\xec	H\xa7L\x8b\xa1\x0d\xad\x1c\xc3\xc4\x0c\x8e!\xf5m\x0f\x1e\xaa\xb4/?\x93n0x\x0e\x1a=\x82^\xa6lU\xda\xac\xdbp\x1aQ\x0f\xbdp\x19\xd2\xfb\xe1\x12T\xe8\x89\xcbh}_\xb8g\xcb\xfe\xd9\xbb\x7fqDO\xec\xfb\xff\xdb\xa3\x9b\x88\x1f\xcb\xafS\x0fc}\x92c\xe8\x9b\xeb\xd0\xde?\xd7 C\x1f]G\xed\xfb\x89\xee\x04\xdb[\xc9\xe6\x11\xf0\xe8\xd8\x1fv\xb5\x13$\x9d\x1b\xea\xfb\xa3>\xd9\xd0\xf6	\x10op\x949\xb4r\x82\xbf\xc0B\xe93|\xfb6K\xfeo\x00PK\x07\x08z\x9bV\xf3\xf2\x0d\x00\x00\xfb8\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/core/examples/blocks.mdUT\x05\x00\x01\xf0b\xe7[\xd4U]O\x1aA\x14}\x9f_q\x13\x9f\x97\xd5\xda\x9af\xdf\xaa\x98\xb6\x89\n\x05\xa3\xe9\x13{\x99\xbd\xc2\x84\xf9rfv\x91\x7f\xdf\xcc\xc2\xaek\xa0\n\xa9i\xd3y0\xc2\xfd>\xe7\xde\xc3\x11\\>\xa1\xb2\x92\xa0\xf48#0\x0f\xe0\x8cY\xc0T\x1a\xbe\x00\x1f\x8c#\xa8\x04\xd6\xffE;\x97\xe8=\xf3D0\x0f\xc1\xfa,Mg\"\xcc\xcbi\x8f\x1b\x95\xc6\xc8\xf5\x9f\xa94\xd3T\xa1\x0f\xe4\xd2\xbe\xe1\xa5\"\x1d0\x08\xa3\xa3\x85/z\xaa\x80\x07\xe3\xa0\xa0\x80B\xfa\x8c\xb1\xa3#P+\xff(\x81\xdav\x84\x9e\xc1\x90\x9c\x17>\x90\x0ewF\x96\x8a.$\n\x05Fo5\xc9X\x9e\xe7+T\x92\xa1\x15w1\xca\xe8\x0c\xaa\x13\xb6\x10\xba\xc8`L\xae\x12\x9c\x98\xa2\x80\x05\x06\xcc\x18\x80FE\x19,\x8d+\xac#\xef\x93\xba>\x03\x908\xa5\xd8\x13\x00\x00Z\xdbqa\xde\x12\x8f\xa1\xd6\xb8\xb0\xf1H\xea\x0f\x19\x9c\x9e\x1e\x9f1\x00O\x92x0ngx\xf4\x0f\x82\\\x06M-.\xcb\x88\xd1\xf7a\x067F\x13K\x92d\xf7\x00;\x81\xd81N\x9d8\xb1U\xc2#R\xfbM\xb3\xe1\xf6\"R{S\x83\x12\xd1M8\xd9yRC\x9c\x14$)\x10\x03@\xce\xc9\xfbkSP=~\x02#\xc2\xe2\xde\x89@\x03\xcd\xa3\x83#oJ\xc7iS\xd1\xd1cI\xbe\xc1\xaa-\x95\xc1\x87\xe3\xafbkZ\xb4\xd6\xa7-g}\xb2\xd2\xac\xe2\xe6\xbc\x17m>8\x0c4[\xc54\x00ae)\x83\x11qGX\x0f\x17HY\x89\x81\xd6\xe6nM\x80\x97k\xf1\x1bn\xb7\xf9\x05hV&>nt@\xa1\xc9\xb5x$ T\x8dG\xcd[\xf6\xa9w\xb61\xbc\xe0\xb3\xfd\x8et\xd5DF\xf0\xd7\x1b|\xfds\xfc\xe3j2\x1a\x0cn'\xc3/\xe3\xf1\xfd`\xd4o}\x00*\x94%e\xc0\xe7\xa8g\xa4\xa8\xb5tV8\xbe\xe4\xb9\xb9aw\x9f_\xeb\xa6\xaaO\xf2\xda\x94:\xf8\xed\xb66\x9b\xd8\xeem\xb2\xe1\xbeu\x04P1t\x88a\x9eAZ\xa1K\xa5\x98\xa6\xdd\x12\xeb\x02\x1d\xb0\xf6\xcclw\xa9F\x93%\xbe\xfa8nv\x1dL\x9e\xe7\xb5\x18\xb5W\xf2\xef\x04\xe9@)j\x84\xe8\xf3\xf1\xfe2\xf4\xe0\x8c\x0e\xa4\x0b\xd6\\\xc3\x95\xc1\xe2\x1c%jN\xee\xcf\xc5hi\xffW%\xa2\xa7@:R\x16\xf5hJ\x01\x0f\x13\xa5}u\xf7o\xc9Q\x87\xe7\xbd\x15\xa9\xcd\x95}\xec\x9d\xf5N\x12\xb4\xc8\xe7\xcf7\xb6\xbd\xac\xafHT\xd4\xa4\xe1\xe8r<\x9e\xf4\xcf'\xdf\x06\xe3\xdbm\x81\xda\xfe\x1dn\xfa\xd9\x91\xe2\x1du\xae>\x97\xb7\x86z]\xe9\x9e[?H\xed\x96\xcbe:\x0f\xea\x0d\xb1\xdb+\xf9!\x82\xd7=\xca<\xcf\xd9\xaf\x01\x00PK\x07\x08\xc3\xf1\xcb\xbc\xa8\x02\x00\x00\x02\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00roles/kubernetes/core/examples/filesystem.mdUT\x05\x00\x01\xf0b\xe7[\x94TMo\xe36\x10\xbd\xebW\x0c\xd6\xd7\xb5\xe5m\xd1\x8bn\x8b\xd8\xdd\x06h6\x81\",\x90SLQ#\x8b0\xc9!\xc8\x91\x12\xfd\xfb\x82\x92,\x7f\xa5A;\x17\x1b\xf3\xf1\xe6\xbd\x11g\x16\xb0}\x17\xc6i\x846\x88=\x02\xd5\xe0\x89\x0eP+\x8d\xa1\x0f\x8c\x06\x02\x93G\xe8\x94\x80Z\xe3\xfb/\xd2\xad\xc1$ B\xc3\xecB\x96\xa6{\xc5M[\xae$\x99\xd4\x13\x1d\xd2\x08\x90\x96\x9a\xca\xd4\x88\xc0\xe8\xd3\x0d\xc9\xd6\xa0e\xc1\x8alz\x82^\x99\nj\xf2P!\x0b\xa5C\x96$\x8b\x05lH\x1e\xd0C\x8e{\x15\xd8\xf7\x803?e\xf7g\x14\x80\xec\xc7T\x93d\xb7\xdb\xf5\xc2\xe8D8\xf5\x0b}Pd3\xe8\xbe%\x07e\xab\x0crtZ\xc9\x81\xca\x1dY\xf6\xa45\xfa\xc4 \x8bJ\xb0\xc8\x12\x00+\x0cfphK\\\xfa\x89\xc5\xb2[O\x81\xe0\x84<F\xc7\x01%\x00Z\x94\x18\xf9\x03\x00\x08\xe7\xae\x8a\x07w7\x13\x89H0dx\x8b\x8ca\xa5(\x95\xba\x8d\x93Z\x06\xf4\x9d\x8a\xf8_\xd8\xb7\xf8%	\x0ee\xa4\xe4G\xd2!\x83\xdf\x13\x80\x80\x1a%\x93\xff\x1f\x0d\x19\x8d\xd3\x82q,9W\x0bp\xc9\xff\x13\xc8\x8ft\xfcw-1\xf3\xa8'\x9a$\xcbBY\xf4\xd3\xe0\x00\x96\xd3\xe8o\x9a*#\xf6g\xfe\xec\xb79\xe21P\xeb%\xce\x18\xd1\xb42\x8a/<\x00\xd2\xb5\x19|[\xaf\xcd\x85\xd7\xa0!\xdf\x0f\x81\x075G\xd0v\xa7\xe2#\xa9|\xfb\xe3\xfe\xb9\xc8_^\xff*\x8a\xa7\xd7\xef\x9bM>\xa7\x00tB\xb7\x98A\xf6\xc7z\xbd\xfe\xf7\xca\xe7\xe21\xff\xfec\xfb\xfa\xe7\xfd\xdf\xdb\xe7\x97\xe7b\xfb\xf0\x9a?>\x16\x9b\xfb|{W<\xe6/\xb7\x80i'|\xaaU\x99\xde~\x87a	\x1e\xa8\xb5\x1cn\xc9\x0e\x03[\x0e\x9b;\xc7\x00L\xcc~\x12\xdc|\x06\xec\xc8_\"\xce\xdf\xe9\x89<gp!\xf1\xb8,7(\x00\xce\x13\x93$\x9dAq\xf74\xf9G\xd23\xfaglO\x8b~\xcc\x8eVy\xd5\xa1\xcf@\xa2kVq\xfb\xe3\xf2\xc4\xdf\xb3\x9c:\x14\xbd\xc31\xe7\xccM.\xae\xfc\x99\xb2hu\xf890\x88\x10\xcb\xbd\xa6R\xe8\xe5pO\"\x17X\x0c\xfc\xe2M\xe4\x06/\xee\x8cC\xa9j\x85\x15({\x1d\xbb\xcb7\xab\x8b\x1e\xd3n\xff<\x1d\x8f\xa1[\x940u\x18\xdc\xf0\xd6\xa0\xc7\x01-\x8f\x17x*\x03\x15\xa0B\xa7\xa9\xc7\xea\x02v\x01e\x0f\x15\xd6\xa2\xd5<T9\xc1M\xccN\xbfB\xd92\xf4\xd4\x82\x14\x16\xa8C\xefU\x85 l5\xbe\x00\x10G\x01r,\xba\x15X\xf6\xd3\xbd\x9d\x81\x05\xb3We\xcb\xe7\xef	`qJ0m`\xc0w\x15\x18\xe8z(_\x81\xb8A\xff\xa6\x02\x8e\x14\x8e\xd0gs\x13Q\x85\xe0\xb1\xdb\x9b\xd2\x1aj\xa1\xf4U\xb7\x18\xcc \x0dd0\x8d\xffSe\x83\xaa0\x95\xe8\x9a:$\xbb\xdd.\xf9g\x00PK\x07\x08\x96r\x91\x9c\xd6\x02\x00\x00\xd2\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/core/examples/object.mdUT\x05\x00\x01\xf0b\xe7[\x00-\x00\xd2\xffhttps://rook.io/docs/rook/master/object.html\n\x03\x00PK\x07\x08\xe2\n\x87\xb84\x00\x00\x00-\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00=\x00	\x00roles/kubernetes/core/files/etc/kubernetes/rook-bin/umount.shUT\x05\x00\x01\xf0b\xe7[\x9c\xce\xb1N\xc30\x14F\xe1\xfd>\xc5\x8fk\xa90 O\x1d\x10\xca\x04\x1d:\xc4\x1dj\xe6\xaaMm\xd9J\xe2k96\x0cI\xde\x1d		\x16\x16\xc4\x03|Ggs\xa7\xae!\xaa\xebe\xf2D\xe6\xd0\xee\x8fo\xe6|\xd0\xe7\xd3\xfe\xe5\xa8_O\xcd\x8eh\x83:r\x8d\x05\xc5[d\xe6\x1e\x9dM\x1e\xef<\xd4\xd1N\xe48\xa3\xd5\x06!B\xde_>zl\xe5\x13\x9a\x06\xa2\xb3\xc9\x0b\xcc)\x87X w\xeb\x16*e\xee\xd4d\x07\xa7\xbe\x8a!:~x\xc6\x8d	(a\xb4\\\x0b\xe4\xfc\xfbb\xfd>\x10rn\xb5Y\x05\x96\xe5o\xe0q\xf8\x87q?\x86n\x1c-}\x0e\x00PK\x07\x08\x15\xdd1\x9b\xac\x00\x00\x00$\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/kubernetes/core/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[T\x8d\xb1\x0d\x031\x0c\x03{O\xc1\x05~\x01/\x13(\x10\xab\xbc%@\x92\x0bo\x1f@A\x8ao\x89;\xde\x05\x93\xc5\x89\xe0\xed\xa2\x10S\x04\xb3$\n\xe1\xfe\xb9\xf6\xf2m5\x80<Y\\:\x07\x80\xbf\xf3\x00\x80,\xa9\xbej\x9f\xda(M\xde7u\xe20{P\xe1r{\xfd\x82\x13\x879\xbe\x03\x00PK\x07\x08\x92L2\xc6Z\x00\x00\x00\x85\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/kubernetes/core/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00$\x00\xdb\xff---\ndependencies:\n - role: manifest\n\x03\x00PK\x07\x08\xccd6\xd0+\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/kubernetes/core/tasks/azure.yamlUT\x05\x00\x01\xf0b\xe7[\x9c\x93\xc1n\xdb0\x10D\xef\xfa\x8a\x81rHr\x90\x94\x06\xe8E@N\xfd\x10bM\xad\xad\xad)R\xe0RN\x9d\xc0\xff^\x90\xb2\x1d\x07hP 7\x89\xe4<\xee\xce\x0e\x1bx\x9a\xb8\xc7\xaf\x91\xed\x1e\xe4\x1c\xc6\xa0IA\x91\xa1I\x9c\x03\x1dH\x1cm\x1cW\x80x\xeb\x96\x81M\x0c\x8e\xfb\n\xc0Y=G\xb6\x19P\x96\x12\xe9^\xcd6\x86\xa9\xcf@S\x80\x86\x9c\x1c2\xa2\xba\xdcH\xaa\x1c\x13\x06\x89lS\x88\xc2\n\xfe#\x9a\xb4\x02\xb6r\xe1\xcf\x94\xc6\x1e\xf5\xfb;$\xf1\x84\xd3\xa9.\xcb\x9a(q\x7f\x15\x1f+\xe0U\xd2h\xf2!]+k\xd0i<t\xfbe\xc3\xd1sb\xed&\xf2\xb2\xe5|C\xd5`\xe3\x82\xdd\xf7U\x05\\\nz%IX|\x12\x87,\x02\xcd\xa2\x1c\x0f\x1c!\xfa\xc9\x05`\x89\xb2^\x92?\xddZ_\xd6\x18\x9a\xc5\xac\"\xe3\x82%\x87\xd3\xa9\x1b\x99\\\x1a\xdf\xea\xb3 \x97\xbe\xa8\xb1a\xe0\x1e\xcfOO\xe7e\xeb\x84}2\x96cZy\xf9\xcb\x0c\x123\xe2\xfc\xab-\x0d\x93\xf8\xd6\xc6tu\xe2\xaa\xdc\xf3\xf1\xbf\xc2=\x1f\xaf\xc2\xc8)\x9b\xde\xe3\xc7s)a`G\xc7\x1e?\xcbO\xe4\x9dh\xe2\xd8\x7f\xb8`\xce\x16\x88\x93\x94\xfd\xc6\xeaU\x8f\x87\xfb\xb5\xa5{\x88\xff\xe2\xf8#\xc8\x0fx\xf8\xf7f\xbb\xca\xf1\xf2\x92\xedx,\xe8;\x90M\x12<FR\xf8\x90\x03v\x90\xb0hq\x8f\x91\x02l\x98f\x8a\xdcB\xc7\xb0\xb8\x01\x1b\xc6L\xaa\xd8\x92\xb8\xb60\xecH~\xc7\x83y\x1d\xd9\xf7\xf0\xe1v\xd6\x91\xfd\xc0\x11\xf4\xb6\x94\x9c\x87H;\x86u\x19\xf0\x91\x92\x0cI<\xcd.g\xed2\xbdh{\xd4_\x05\xab+\xc0\xe6\x0cl\n\x90\xb5=\xd2\xe4\xda\xdf\xcf\x97\x04\x0c\xacy\xc6\xdd7(\xf5m\x136r6\x837\xfa\xb9\x05\xceO\x08\xd0\x91\xddM6mr\xc6N\x03N'4M\x0e\xab\x0d~+\xbb\x97\xee@\xb1s\xb2)\xa58N\xdd\xc7\x1eh\x9e\xdd\x11\xcd\x16\xdd7:.\xb5\xde\x81\xfd\xb0>\xb6\xfcF\xcb(\xea\xfb\x89r\xb8J`v1,\xb3\xf14\xb1\xd6\xa8\xfe\x0e\x00PK\x07\x08\x07\xda\xcc\xa3\x17\x02\x00\x00\x8f\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/core/tasks/defaults.ymlUT\x05\x00\x01\xf0b\xe7[\xccWAo\xdb\xb8\x12\xbe\xfbW\xccs\x1f\xa0\xe4`;\x0d\xfaz\x10\x90C\xfazY`\xd1\xc3vom@\xd0\xe4\xc8fC\x93Zr\xe4\xd4\xeb\xf8\xbf/\x86\xa2l\xc5\x89\x13\xbb\xc8v\xd7'K$\x87\xdf|\xf3\xf1\x1bj\x04S\xeb\xd5m9\x18\x00\x8c \"\x89J**\x07\x90~s\x19\x85\xc2@B:-nqU\xc2p\xbd\x86373\xee\xbb0n\x160F\xa1\xbc\xa3\xe0\xad\xc5 \xc8\xe6\xf9\xd6+iE-i~\xaf\xb1\x92\x8d\xa5\xb3\xa28\x87\xff\\AQ\x80t\x1a\x9e\x0dq\x8b\xabg#\x9c\xc3=L\xbd\xb7\xb0\xd9\x0c[\xe0\xbb$8\x0d'\x17X\xc2TF|\xff\x0e\xd0)\xaf\x11~\xff\xf53\xdc\xe2\n*\x1f\xda\xcd!\xe3\x87\xdd\xe69\xebvk\xa9\xc8xW\xc20\xce\xd1ZP\x92`\xbd>\x057l6\x8c\xb3Eq\x0f\x14\xa0\xf8\x1a\n(\xbe\xba\xa2}\x1c\xe9\xf40\xcc\xdbNQy\xc6\xed|~\x11pf\"a(\x0f\xef:}\xffn\xcb\xd8\xf5K\xe9s%Me\x94$\xfc[i\xd8S\xc0\xcf\xe4!m\x9d\x89\xb8\x9b\xa3+\x1fI\xf8\xb0^f\xe8007\xa7\x0b\x07L\x05\xce;\x84:\xf8\xa5\xd1\xa8s*\x89\xb3\x12\x86Y<	\x9d6\x016\x9b\xc9z\xdd	\xb0\x05\xcd\xda\xa9\x8cE\xd6\xee\xa9\x8c\x9d(\x94\x0fG\xe7}\xacb^!\x7f\x15\xe8g\xe4\x9f d\x02Z\x818O\x07D\xb2\xef\x86\xc7\x9c\xc2\x97\x1c\xb2_\x87\xebq$\xed\x1b\x12\xd68\x8c\xcc\xe1>\x0e@\x1b\xf1\xa8}\xc5\x87\x87\xc1\xee\xa1\xe7\xbb\xec\x97\xdf\xbcq\xc9\x83\xd93\x8f\xcc\x87\xc9:6!\x9e\xfbz\x19\xa5h'\xa54xZ\xd0F\x81lh\x0es\xaae\x8cw\xfae\xe3\xcbg\xb6\x0e\xc6Q\x95\x92?\x8cVF\xa3\x04\xc7\x17M\xc4\x90\xa5[\xfe\xf7\xcc\xd7\xe8b\xb4\x90\xf7\x1c\xc9:\xbc\x85\xe2\xc8Pi\x91\x0f\x1a6\x9b\xe2|\xb8m!\x83c\x95\xbe\x8b\xd4%\xfd\x03r~\"Jy4\x19\xdd\x8a\\\xbf]\x9bf\x86J\xb8\x93\x86\xa0qd,\xdc6S\x04Y\x9b\x88a\xc96\x1aA.\xa5\xb1rj1\xa1l\x82\xe9\xe06\x81\x9dt\xbdNk\x84\xac\x8dh\x17\xb5\x1d\x97-u\x8e\xd2\xd2\xfc\xcfN\xde\x91$5L\xb2\xc6\x12./.\xf2ke\x0d:\xeai{\xcf\x95\xf91\x8e\xa5^\x187V\x81z\xe7%\xaf\xdc\xde\x83\x9eY\xc8\x0d\xa3[\x18\x90\x82\xc1X\xc2\xdb\xcb\xf4\xac\xd1\xcaU	\xff\xdb+\xe8\x96\x05\x91)0\xd6\xd0*MJ\\\x95pV\xb4)\x15`\xdc\x81\xe9\xe7\xe9ru\xf6\xf4\xe0\xb8]\x0eWWL\xc7y\n\xfd\x06\xda\x06\xcf\x07\x15\x9c\x87:\xe0\xd2\xf8&&\xf6\x10\xc8\x83\xf2\x8bZ\x06\x1cC\x9c\xfb\xc6j\x98\"\xb0B\xa1\x92\xc6\x8eS\x0c5\x97n\x86Zt~\xda?\x8b2F\x0c\x04\x93\x18\x96\x13.\\pH\x18'\x0b\xe9L\x85\x91\"\xe0w\x13)\xa68l\xff]\xb5\xf9\xeaX\x1e^\x96g%\x90%h\x13P\x91\x0f\xb9\xb9G\xdb\x84\xba\x0b\x14\x83z\xa6\xccHJ\xef\x15\xabk%<$n\xf1\x07c\xf6\x95\xb3\xabq\x8a\xc9;?\x13t/fZ\x12\xbc'\xa1\xe4\x83&y\x00\xb1\x92\x8fo8\xbbr\x0c+\xe34\x90\x02.)\xf9\xb16\x91\x12\xef\xdd\xa1\xe1*8\xdda\xea*\x11\xfb/8\xda\xa4\x89a\x12\xe72\xe0\x84\xd4Sc\xd6L\x0f\x8f\xbc\x7f\xf7\xc4\xd8\xa3\x15\x0cK\xd0\xaa\xc62\xfd\xed\xedRK\"\x0c\xee\x11\xaa\xa2\x97U\xb1\x1d\xdb\x11\xd4\x0e\x0bNzG\xcc~\x97\xe7\x1f)\xd1\x9b+*o5\xafg\xf7\xe9\xbd\x1f3\xac\xf8\xe5\xe2f\xccj\xe5\x96k\xba\x1e0\xec\xdf0\xb4\x8bBJ)E:\xf9\x02\x1d\xdb[6e\x9e_B@\xa71\x80\xf2\x01\xe1\xa1\xc6	\x17\xb5\x95\xb4=\x17\xad\x9c\x0f\x1d\x8bt\xab$\\\x8c3\x8c\xf1J.\xec\xf8\xdbeW]\x8d\x91\x1b\xfa\xe4\xa4\xf5\xed\xe2;Cs\xc1\xb1c\x96\xbe\x0f(\xb6X\xb7f\xd7f\xcc\xf3\xc6\xbd<\xdf\x00:\xdd~\xa4\x0d\xba{\xd7\xb0XH\xd6mr\xb3Y\xf0M-x\xd78\x1c\x0c\x1e~\x94\xf2\xdb\x12\x86\xb2\xae\xed*\xdd\xa2\x9d\x19\xe7{6\x1f<\xf8\xff\xa7_\xb6\xa4\x0d\xfb-\xbck\x16\x8a\xacP\x0b\xee\xa70\x1a\xb1\x9b(\xef*3\xbb\x9a,e\x92c\xa2\xc2\"Mvc\xb0\xdd\xad\xf2Aq\xbb\xa9\xedJTV\xce`\xb3\x19U\x87\x8di\xf2\x18`*\xc2\xf0\x89\\>~\xfa\x0c\xd7\xd7\xd7\xd7\xf0\x91u\xf1/\xcaA\xbb8b\xc1\x8e\x92`3\xfeS\xf4\x9c]_\xd9\x86+\x0c&B@\xa9W\xafU\x9c\x19\x128\xaf\xd3\x9dp\x16\xb0\x86\xdf8z\xf70Z\xc2'O\xdd\xab;\x05\xa3,\xe1\x9d\x11$4B\xf9\xc6\xd1\xc3\x16\xcd\xa8\x92\x16\xe3\x97\x82\xc1\x88\x9cBq\x03\xf7`\xd1\xcd\xd2g\xfd\xb0\xdf\xc7\xdf^^\xf4\x9b\xf4\xb0\x17\xbb\xbb\xff\xdc\x83q\xc4m\xf7\xa5\xd0Y%\xc6)\xdbh\x14$\xe3mL|\xa7\x1a<`\x98\x05\xd9}\x81Am\xa5C\xe0\x96o\x14\xc6\xd7\xa2\xf9\x87\xcf@\x865J\xb0F\x1d\xac\xc7\xe7\xa0K\xa2\xe7z\xff8\xf6\xa7\x1c\xf4t\x07\xcc~;JN\xf8\xa5\xc8VX\xdcl\xdf\xf3Wg;V\x07\x0c\xf8Gc\xa2!,n\x06[\x7f4n\x89\x8e/5b\xee#1a\xac\xa0l\x9a\x17\x17\x17\xc5\xe0\xaf\x01\x00PK\x07\x08\x01\x92\x14\x88I\x05\x00\x00\xbc\x13\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/kubernetes/core/tasks/dns.yamlUT\x05\x00\x01\xf0b\xe7[\xbcT=o\xdb0\x10\xdd\xf5+\x1e\xdc\xc1Ke\xc5\x08\xba\x08H\x816\xd9\x8a\x16E2t\x14h\xea$\x11\xa1H\x81wrj\xb8\xfa\xef\x05\x15Ynl'S\xd0M:\xbe\xfbx\xc7\xc7\x97\xc2\xa9\x96r\xa8\xae\xb3;\xdc\xfa@w?\x1e\xd0*g*bI\x00n\xc8\xda\x1c\x8b\xfd\x1e\x8f\xfd\x86\xb4\xd8B\xb7%\x86\x01i:\x06\xbc\xabL}\x93mU\xc8\xac\xd9d1fI\xb2\xe3\xd9T{\xbfG\xe5\x83\xa6b\xfc-*\xabj\x0cCZ!\xe3\xb0\x1d\xe1\xc1\x91\x10g\x87\xe6\x9ci\x1f\xa8t\xbc\xda\xa9\xd6.\x92d\x9e\x95\x99\x82\xcc\xc3\xfe\xf4%C\x05B U\xee\xdeg\xe4\x9a\x04]\xac\x9b\xba\x91v\xca;\x16j\xf1\x07u\xa0\x0e\xd3`\x8b\x04\x08T\x1b\x16\n\xf9!X\x1c\xc6\x08$\xc1\x10\xe7X_%@IV\xedr\\\xc7\xef\xde\x89\xb19\x12\x00H\xb1X\xae\xb3\xf5\x12\xc6\xbd\xac\xb0b)}/\x8b\x19u\xdf;g\\\xfd\x06r\xde\xd0mC\xfa1\xae\x1b\xf4\xdb\xb0\x11\xe3j|\xeb7\xe3\xdd\xaa \xa6RZ\xf8}6u\xb2\xa0\xb8\xb8\xfd\x1eF\xa8]\xc9\xae\xa3(\x94\xc3\x7f\x9c\x0d\xc3\x10\x19Y\xef\xbb<\x89\xbc\xf6\x88\xb0\x1c\xcb\x07\n[\xa3\xe9\x8b\xd6\xbew\xb2\xfc8QY\xc6\xea\xa5\xe3\x94\xd5\x12\xc3\xcb\x94{o\xe9\x1c\x18b\xf4\x14zk\xfbxK\x973tx\x0b\xff\xd5\xb82\xee\xfd4\xed,\xe7-p\x1a6\xe7=\xc6\xed~W\xddKtz\xa9\xf6\x1du\xd6\xefZr\xf2\x1a\xf8(\xc4\xd8\xb2\x88\xea\x88[\x8f\xd7\\)c\xa9,\x9e\x1ar\xa7\xa7\xab\xa0\xf1\x19\xeb\xa3t\xee\xa9\xf5[\xfaOj)\xe39\xcd\x02\xb9\xa8\x9a\x8b\xd2\x99\xfd\xe8\xc8s\x15\x88{+<)\xec\x99\xec\x98\x1c4nnpuf \x07\x8e\x07\xc3\x81\xda0\xb9hz\x95\xb1\x14\xe5	tJ\x9a\x1c\x8b\xd7=j\xd2\xd0\xe4Q\xf1\xad\xb2(\xa1\xfcX\x0c\x98\x1b\xffRF\xc6g\x19\x1f\xa2xh\xdf\x12\xfa.\x01\x9e\x94\x91\xa2\xf2a\xea\xea\x83\xe4\xf8t=\xfe4\x9e\xe5\x1f\xc2\xfaY\x99\x91\xf8Du6\x97\xd1h>@i1\xde\xa1Q\x0c\xe7\xd1\x05\xda\x1a\xdf\xf3\xf3\\S\xd7N\x05Z\x81\x1b\xdf\xdb\x12\x1bB\xa7\x98G\x99\xac\x12@7\xca\xd5T\x16O\x0d\xb9\x1c\xce\xff\x1d\x00PK\x07\x085A\x18\x0dJ\x02\x00\x00$\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/kubernetes/core/tasks/ebs.yamlUT\x05\x00\x01\xf0b\xe7[\x94S\xcbn\xdb0\x10\xbc\xeb+\x06\xce!\xc9\xc1R\x1a\xa0\x17\x019\xf5C\x88\x15\xb5\xb6\xb6^\x91\x02\x97r\xea\x06\xfe\xf7\x82\x92\xed\xa4@\x83\"7\xbefv8;\xbbE\xa0\x91[\xfc\x18\xd8\x1f@\xaa\x18\xa2e\x03%\x86eQ\x05\x1dI\x94:\xe5\n\x90\xe0u\xee\xd9\xa5\xa8\xdcV\x00.\xe8)\xb1/\x04\xcbQ&;\x98\xdb\xa58\xb6\x85\xd0-\x84\x8eT\x8e\x85\xa2\xbaV$3N\x19\xbd$\xf69&a\x03\xff\x12\xcbV\x01;\xb9\xf2O\x94\x87\x16\x9b\xb77H\xe6\x11\xe7\xf3f9\xb6L\x99\xdb\x1b\xf8T\x01\xaf\x92\x07W\x1e\xd9\xaal\x8b\xc6\xd2\xb19\xcc\x1d\xa7\xc0\x99\xad\x19)\xc8\x8eK\x85j\x8bN\xa3?\xb4U\x05\\\x05\xbd\x92d\xcc!\x8b\xa2\x80@\x93\x18\xa7#'\x88\xfd\xe5\x020'Y\x8b\x94\xa5\xae\xfa\n\xc6\xd1$n\x059\x8d\x9e\x14\xe7s30i\x1e~o.\x80\"}6\xe7c\xcf-\x9e\x9f\x9e.\xc7^\x85Cv\x9eS^\xf9\xca\xca\xf5\x92\n\xc5ek5\xf5\xa3\x84\xda\xa7|s\xe2\x86<\xf0\xe9\xbf\xc0\x03\x9fn\xc0\xc4\xb9\x98\xde\xe2\xdb\xf3\"\xa1g\xa5S\x8b\xef\xcb&\xf1^,sj\xdf]p\x17\x0bD%\x17\xbf\xb1z\xd5\xe2\xe1~\xfd\xd2=$|\xf2\xfc\x11\x14z<\xfc\xfb\xb2^\xe1xy)v<.\xd4w \x9f%\x06\x0cd\x08\xb1\x04\xec(q\xb6\xc5=F\x8e\xf0q\x9c(q\x0d\x1b\xe2\xac=:\xc6Df\xd8\x91h\xbdp\xf8\x81\xc2\x9e{\xf7:ph\x11\xe2\xc7^'\x0e='pgxOE\x01e\x1e'-\xd9\xbav+\xf9\x16\x9b\xcf\x82\xd4pg\xdb%I\x96c\xe2\xfaD\xa3\xd6?\x9f\xaf\xad\xee\xd9J3\x9b\xaf\xc07\x1fe\xfa\xc4\xe5\xbbEf)@{\x86\xd728eH\x00\x1bX?\xa4\xcfgu~\xecq>c\xbb-q\xf41\xecd\xff\xd2\x1c)5*\xdd\xa2A97\xefw\xa0i\xd2\x13\xb6;4_\xf9\xe3\"\xf2\x0e\x1c\xfau\x8e\xca\xf8-.o\xeeG*\xb9Y\xb2\xb0Oq\x9e\\\xa0\x91m\x83\xea\xcf\x00PK\x07\x08w~\xa7\xab\x10\x02\x00\x00j\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/kubernetes/core/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x91\xc1j\xeb0\x10E\xf7\xfe\x8aK\xf6I\xe0-M\xf26Yf\xd3?\x10c{\xec\x08\x8f4f$\xb9\xa4\xf4\xe3\x8b\xed\x96R\x1a(\xd9\x8e\xe6\x9c{\xd1\xec\x11)p\x8d\xd3X\x1a\xb6\xc8\x99\xd3\xb1U\xe3\xff\x15\xd0qS\x86\xba\x02\x80\x90\x86\x1a/\xe6cF\xa6\xe1\xf76z5\\K\xc3W\x9fq\xd1\xd8\xfb\xa1\x18e5Ld\x89\xad\xaa\xf6\xf0aR\xcb.S\x1aS\x8d\x8e{*\x92\xd3\xe1\x1edy\xddj\\n\xdc\x8e \x11\xdc4\xe5\x042F\xca^\x044\x93\x17j\x84+\xc0\xc7VJ\xc7\xceTx\xeb\xb7\xd1\x93q\xbb\x08\xd6\xd1\x9a\xe3z\xd3P/B\xb7\n\x1d\x89\x9fy\xc9\xfbr|\xd61\xd5\xf1p\xa7 \x15\xf0z\xe3\xb8M\x1c\xc7%\xb2\xc3;\x1aUy\xc0q\x93~b\xadh\xe9\xdcd:\xfb\x8e\x0d\xe73v\xdc\xfe\xdb=@\xe9\xad\x18\xff	\xaf[\xbb\xef\x1f:\x1d\x9f\xbb\xd4\xf1\xe9S}\x0c\x00PK\x07\x08\x8a]\xfc\x8e\xf4\x00\x00\x00\x13\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/core/tasks/rook.yamlUT\x05\x00\x01\xf0b\xe7[\xecX\xcd\x92\xe3\xb8\x0d\xbe\xfb)P\xee\x83gjW\xb6gz3U\xeb\xaa\xce%\xd7\x9c\x92\x1c\xb7JE\x91\x90\xc55Ej	\xd2\xdd\xcaN\xbf{\n$\xe5\xdf\xfe\xcd\xcc&\x97=\xd9\x12\x01\x10\xfc\x08|\x00T\x81\x15=n\xe0o\x1d\xca\x1d\x08c\xa0s\x14\x08\x84G\xa0\xa0\x8d\x01\xb1\x17\xda\x88\xc6\xe0\x0c@[i\xa2\xc2\xda;\x83\x9b\x19\x00\x14\xed\xc1\xa3d\x03\xe9U\x10\xb4\xa3\xba\xf5\xae\xdf\xb0\xc1:\x19\xac\x85\xd1{\x9c\xcd\xa6\xfd\x04\x11\xfa\x00J{\x94\xc1y\x8d\x04\xf8\xa0)\xd0\x0c\xa0\xd5\x93\xf5A\x84n\x03\xf3\xdf\x7f\x07\x1d\xb0\x87\xc7\xc7yzMA\x04\xdc\x1c\x94\xc7\x19\xc0\xbd\x0e]\xcdB\x94\xe5\xbds\xbbZ\xe2\xd0\xd5\x14\x9c\x17[\xac'i\xde*\x1b\xfa_8\x934+Xa\x90\xab]l\xd0[\x0cH+v\xafj\xb4\x9d\x96\xc9\xefO\x97{au\x8b\x14\xb2\xe0J\x9aH\x01\xfd\xdb\x84\xcby\xdf&\x1c\x9c3\x8d{\x98\x84\xf7\xc2\xaf\x8cn\x92+\x06\xc3j\xefL\xecq0q\xab-\x15\xa1#\xba\xce\xb6z[+\x11\x04\x83\x9b@\x9d\xdd\xa4\xb5\x84\x1aA'\xf6\x08\x0d\xa2\x85\xde\xedQA\xb4\n=\xe8@\xe0\xee-PlZg\x14\xfa\xc3=H\x83\xc2\xc6\x01\x84\x1d\xc1\x19ub\xea\x1dQ!\x1aB\x1b\x9e\xbe\x85\x17\xc1\xa8\xdc\x80^\x04\xe7\x97\xa3\xe8\xcd\xdbT\xca\xcd\xbcC\x83\x8fA#\x05\xec\xdf\xa1\xe4\x9a_Q\x86\x8a\xaf\x16\xdf\xaf\x16\xe9]\x1e\x0e\xce\x99w\x88\x97x\xab\xa4\x11DYoVAc\x9c\xdcmf3\xc60_\xee\xbd\xd0\x01\xa2\x0d\xda\x00\xe3	b\xd0\x84~\xcf\x01Ag$\x03\x10\xbd\xce\x89\xc3\x7fM\xbej\xd6\xa9\xc5\xa0\xeb\xacT\x1b'\x85\x81\xc7\xc7U\x87\xc2\x84\xee\xdf\xf3\xa2\xc0Q\x10\xa9\x96N\xe1\x06>\xaf\xd7\xe5\xb54\x1am\xa8%\xfa\x90\xed\xf1\xbf\x12\xb6\xab\xf2HK\xa1zm\x97\xd2\x87\x03\xd5\x1c4w8\xbe\xaa\xb8\xc3\xf1\xa0\xe810\xd5l\xe0\xd3\xe7\xe4\x82B#\xc6\x0d\xfc%=x\xdcj\x8e\x9b\xcd\x11\x85\xba@\xa0\x8d\x0eLh\x90\xb1\xda\xc0\x87E>\xd2\x02\xb4}F\xfc#\x08\xab\xe0\xc3\xd3\x8b\xcb\xac\x0eww\x0c\xc7\xc7d\xfa\x06\x84\x0c\xdaY\xe8\x04\x81u\xcc\xdf{\xed\"%\xf4\x10\x82\x03\xe9\xfaAx\\\x02u.\x1a\x05\x0d\xc2 \x88\xa0\x15\xda,\x93\x0d\xd9	\xbbEU\xdfwh7`\xdd\xe9]o\xd1r*!\x0c\xd8'a\xea\xd0\xf0EJ\x11\xe0\x1a|m\xb7\x1e\x89\xd2\xed\xd4\xd2\x87\x9a\x93\x84-\xc1\xe3\xe3k\xe2;\x1c\xcf\xc4\xffz\xa9P\x8c/\x07\xec\xe7O\xfa\xd8\x08\xc2/?\x01Z\x8e\x19\xf8\xd7\xdf\xff\x99\xd4u\xab%c\xd1:\x0f\x01)\x00\xdd2\xfa\xaf\x9d\xe6d7\xf8:\x99\xfe\n\xc1\xc3\xe2\x17\xbf\x80\xc5/v\x91\x1f+\x95\x1e\xe6\x17\xf1@\xb7u\xf3\xe5\xa7:\x98\x8c\xc6\x7f\xe9\xf0-\xb1\xaf \x9d\x0d\xde\x19\x83\x1e\x08\xa5\xc7\xf0\x9a\xf7'w\xe1\x9d\x0b\xb5\x14W\xd7\xf1\xad\x87\x12W\xe7\x92n\x183\xd5\xc7\xdeE\x1b\x80\xa4\xd7C\xf6\x95\xd7&* /70\x7f\xa6\x90\xae\xb2\xee\x92\xba\x89\x08\x14\x12\xa7\xfa\xea\x0d\n' {L5\xea\xcc\x1d\xf4{-\x11\xa2\xd5!\x15\xb6\xb4A\xc0~0\\\xf9\xcbv\xd9\xbbH\xb9\x80f\x86W\xe57\xf3\xea\xe4a6\xb7\xfc\xf5\xf3\xa5\xa7oW\xbe\x8c\x1a\xb6_g\xfbu\x11I\x12\xd6\x05\xdd\x8e|*\xe3\x84J,\xe1\x91\x82\xf0\x01<\x8dd\xdc6\x9f\xbd\xc7 6\xd0\x9aH]\xdd	\xab\x0cz.\xb9\x009\xb9\x9f0\xbf,\xe9\xff\x1cv\x87:\xf9\x02X/\x96\xd6U)\xef\xcb\xdc#LL\xc1u\xb3\xa4z\xaa5O\xc0\xf8=\xccf\x9bO6\x95\x87\x93\x1d\xa8>\x83\x94l\xa0\xe5\"\xa6\x92\xb6qn\xa8K\nNQbD\x83\xa5\xa0\x9d\x1e\xe5\x8c\x98\xa4G&\x1e\x06\x1d\xa6\x96\x84\xce2\xb7\x94C\x19L-{\xc5\xacWU\xe9E\xea\xc8\xee\xae\xda\xb8\xe3\x1a\x88a0#Sd\xeb\xbc\xe4\x82:\x98\xb1n\x8d\xd8\xc2\xe3c\xd5\xbeR\xeb\xff8\xf0\xd2\xf1\xa0\x02m\xf7h\xb9\xa5OSC2zw\x07\x8b^0;\xae\xd7\xeb\xc5A\xf2\xc3\x19\xe0\xf0\x15\x1a\xe7\x0c|\xbcX\x9e\xf0;\xae\x9f\x00]f\x903\xa0A\x13x\x14j\xfc^\x80o1\xc0\xe0\x14Ae\xd3N\x15\x0f%U\xe6\x05\xf8\n[\x8f\xc3\xc9\xfb\xc9\x8d\xcb\x04\x9f\xde\xd7G\xdf\x8e\x0d\xc6\xfa\xb4\xc1\xb8]\x9f6\x0f%\xec*\x98/>\xad>\xa5\x16\xe2\xdc\xd4\x92\x82r1LT\xc4\x82\xff\x88\xd6j\xbb}I\xf8\x99hU8\x187\xf6h\xc3\x9f\xf1\xfa\xcex\xb5.L!\xfdL\xcc\x96\x8e\x9a\xcd\xdd\xa4\xea\xe3\xbc\xf0#\xdc;\xbf\x13\xdeE\xab`@\xab\xb4\xddNc\xd3\x03\xf7qi\x04\xcb\xe3\x03p\xa7\x8e\xc0\xd3\x00\xfc\x161\"\xe8\xf6|\xa9\x18({\x0c\xb11Z\x82&\x8a\x98J\xc7\xe0\xc1\xc6\xbeA\xcf\x86[g\x8c\xbb\x9f\x15YA\x14{\xde;t\"\xb0\xdd\xab\xb1\x85\x13\xab\x1c\xfe\xc7\xb3\xd5\xe4\xcf}j2\x85!\xc7\x9df\x91\x9b\x95\x01\xe4\x89lM\x86\x0fv\x8fI\xf1\xc7\xa5\xecu\xae\xfa\xed}\x95\x96\xe9\xf6l@\x9br\xe9\xd8\xd4\x9d\xb8{\x92\xc0g)\x0c7\xfcq\xc5\xa3\xa0\x84\xa2\x83O\xeb\x87\x9f\xd7\xc0_Q\xf8\xef\x975\xa8\x98\x1a\xf3\xd6\xe3o\x11m\x80\xa0{t\x91gi\x0b\xc8Q\x18\x851#\xe4qh\x84\xcc0T|)\xe4\xf03\xefS:z\xa1\x14\x88\xbc\x90\n\x1d\x88\xd4\xd9\xe59\xed\x07\xe8\x9d\xd2\xadF\x0f\xceN\xb7\xeeZp\xa4\xe0\x07\xc0\x87\xe0\x05\xdf2wC\xe9X\x87++S[b\x9f\xf2pA?\x97X\\0\xd0\x13\x1c\xf4\xa2F	\xc0\xab\x88\xd2\x04\x84\x81\x11+\xaeA+\x0c%\x08y\xdaa\x04u\x80\x06\x19n.\x8a\xbaT\xed\x1bh\xb0u\x1e!t\x17\x89\xa3	\xe2\xf0#\x90c\xc5<8\xb1\x01\xb4\xe9On~P\xc1\x88\x01\xce\x02\xf7\xb4\xa5<9KN\xc4\x03\xc1\x94\xe3_vJSc\xf9r].3\xf8\xea\x12\x85\xcb\x16\xe9\xd8\x15\x7f\x83\xbd\xf9\x94\xf4\xffw\xcc\xcb\xbd]C\xfeRQ\xfa.\x14\xf1mm\xd4[\xe0\xcd}\xd1\x07\x96\xaf\xa7\x08\xce\x05\xa1L\xfbi%\xc7S\xfa\xc6\x89Wbl\xe8\x06\xd0\xaa\\;fS;?/\xf5)\xe5\xe3\xd6\xbb8\xd4\x0c\x1b\xcd\xe1\xf8}\x96s\xa7\x11\xd4\x810ZP\xfa\x00g\xb4Em\xaf>\xcer\xc4\x84\xd5\x92\x85\xbd\xcc\xdc\xc7\x92\x1bX$U\x98z\xc6\xfcq\xe4n\xfa~\xba\xdc\x0b\x13\xb9e\x9c/\x92N\xbe\xb2\x0d\x8ci/\xe0/\x84L\x9dl<\xad'?\x0f\xcfLX\xb5\xd22d\x92\xd9\xa5\x1c)=\x06T\xf6\xee\xc0\xd2\xf3i\x9d\x9e\x11\xa8h\xa4\x80\xfd\xfc?\x03\x00PK\x07\x08\x1c\x82\xc4&\xae\x06\x00\x00	\x17\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00>\x00	\x00roles/kubernetes/core/templates/etc/pki/ingress_openssl.cnf.j2UT\x05\x00\x01\xf0b\xe7[\xc4SOk\x1bO\x0c\xbd\xef\xa7\xd0\xc5\xf8\xf7#\xc6\x18z+\x18\x1a\xd2\x1cz	%iN\xcb2\x8c=Z[\xf5Z3\x1ei\xb71\xb6\xbf{\x19\xff[\x07\xff	\xed\xa5\xa7\x1d\xa4\xa7\xa7'\xe9m\x1eqQd\x11\x17\x06\xdf\x14Y\xc8\xb3\xc0\x10\x9aO&\xe2\"s$J<\xa9I\xa6\xe8\x0c\xdb9\xc2\x10\x12\xf8<\x91\xe5\x97\xe3E\x96\xef\xc8\x8ald\x85\xc6\x0f\x9eE\xa3%\xd6\xd4\xe7\xe1\xfe\xf3\x8f\xe7\xd7\xc7l\x86\xcbW\xb1\x93D\xcf\x9e\x9f1\xd4\x8e\xac\x92\xe7\x1e8\x9a\x90\xda\xea\x85&l\xb5\x8e\xd8\x83\x19.\x1fyLa\x8aq\x8e\xac\x99\xd4\xa3\x9f8\xd6\xfbJ\x9fv\n\xbf\xd8J\xb7j%\xcb\x8f\xcf\"[u@P\xe1\x181\xa5\x8f\xa6\xa4(j\xe6V\x14\xa3\x99zQ\x18B\x9e\xbe\x8d\x8d\x92ww\x89\xc1`\xd0-\xfa\xe5\xc21\xac\xa1\xf2\xbf0B\x0f.\x83R4\xb5\xfe(od\xea\xa3\xf6\xe0\n\x8c\xb8AV\x1f\x97\xe6@X@gs6\x02\x0c\xe1?\xe2ID\x11\xe3\xb0\xb4u\xa5\xc6\xb1\x98\x16p\xd7\x02\xacs\x94vj\xab\xf7\x98\xb5\xf8\xa8\xff\xc3\xdd\x87\x9bY\xc3\x0e\xb9\x86\x9aiQ\xe3^Q\xe9\xe3\xb1\x14\x88[\x9a\x94\xff\xfa\xf4\xd2_\xad\xa0\xf2>\xf4\x89\x1d\xbe\xc1f\x03CX\xad\xda\x92\xcdv.d\x97\x88v\x94T\xb6{\xb9\xb0\x8a~\x88\xd4XECa_p8,\x85?=k\xdem\xc9\xba\xc5\xb5\xb3Y\x16\x1aU\xb8\xa5\xba\x86iy\x0e\xb7\xc2J\xf0\x1f\x08<\xb6gG\xe5\xfeM%\xcc\xea\x11\x9a\x86\xa2\xd6\xb62\x14\x8c\x0dt\xb6\xbd\xad\xb6\x86B\xfa\x0b.\xe0o\x0evZ{I\xc2\xc9\x8dn\xf9\x96\x82\xec\xbdxBy\xdb\xc6\x14\xde\x9b\x98\xc2_Z\x98\xc2\xc1\xc0IEg\x93}\xfb~\xc3\xbd\x14\xce\xbc\xfb{\x00PK\x07\x08{fW\x08\xcd\x01\x00\x00P\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/azure-storage-classes.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xd4Wok\x1b\xb9\x13~\xefO1\x90\x17\xf9\xfd k\xa7\x7f\xa0e\xa1/\x9c\x7f\xbd\xd0\xf4l\xec\xb4\x07WJ\x91\xa5Y[g\xad\xb47#9\xb8\x9f\xfe\x90V\xbbv\xd24\x17\xaew\xa55\x04v\xa5\xd13\x9ag\xe6\x99\x9d\x14E18\x80\xe2\xcb\xdf\xe0\x00\xc6\xbf\xbf\x9b\x9d\xc3\xc9\xd5\xe4\xf4\x0d\xcc\xaf'\xb3\xf1\xeb\xf3\xaf\xd8\x8aF\xbfGb\xedl	\x9b'\x83\xb5\xb6\xaa\x84+\xcd~\xa0=\xd6\\\x0e\x06\x00\x070\x8d&\xec\xd1z\xd88\x13j\x04i\x84\xae\x19\x04!p\x83RW\x1a\x15h\x0b\xaf\xf5	,\x82\x87\xf1\xe7@\x08\xb5\xb0b\x89\n\x94\xe6u2Nh\x0bm\x0c*Xla\xfe\xe6\x1dT\x8e@t \x12X\x7f\xc6!\\\xaf\x901n3\x90\xb0K\x84\x8a\\\x0d\xcf\x9eF\xf8x`\xfe\x1c\x1cAB\x9b>\xcf\xf0\xde\xc1\xb3\xa7\xd7\x9d\xc1\xcb\xe3h1}y\xdc\xee\x0e\x93q\xfc\x8b\xd8\xe0W\xe4\xc2r\xd5\x04\x0f\xc2*\xb8\x9cL\xe7\xd0 U\x8eja%\x82\xab@\xc0\x94\xb0\xd6\xa1\xbe\x15\x05(l\xd0*\x06g\xc1\xafr<\xce\xaf\xe2K\xbco\x82\x8b\xcf\xda\xb2OP1\xa0\x88\x17\x17\xadS\xc8\x91\xa6\xf82~3\x07i\x02{\xa4\xbd\xdb]8\x82\xda\x11\x82\xb6\xe96^;{\x04\x8c\x08S\xd2R\xdbe\xf20\xbd}\xd7\xb7\xf9\x86g\x89\xe7\x84\xf3\xbf\x95\xf7\x0d\x97\xa3\x91\x88\x99\x18\xd6Z\x92cW\xf9\xa1t\xf5\xa8i\xa1F\n\xbd\xd0\x86G9\xc2\"F\xc8\xa3\xff\xb7I?;\xbf\x1e_^\xcd\xa1\x03RN\xf2\x1d\x1c\xb4E\xe0\xd6\xc3H\xac\xf3S\x8bR\xa8\xad\x15\xb5\x96E\xb3i\xe1\xf6\xaa\xee\x9e_\x9f\x19\x85\x95\x08\xc6\x03{Gb\x99\n\x8d\x19\x1ar\x1b\x1d\xab\x94c\xadxa\x95 \x95\x8b,\xba\x1b\xa6\xf3\xf3n\xa3;\xac\x19\x16B\xae\xdbb\xfb\xe5\xec\x8c\x8f\x12{\n\x8d\xde 1H\xc7\xbe\xc0\xaaB\xe9\xf5\xa6Mfw\xf4f\xa5\x0d\x02{m\x0c,0\xf2\xde\xd7\x87\x1f\xee<\xf5\x95\x0dZ\xa10\xa98\x13\x8eH\xe0\xd0\x83\x83\xc2Mr\xee\x91=\xdc8Z\x1b'\xd40rS@+\xbby\xeb\xfa4F<\x00\x00\xd8\x17g\xbe\xd7p\xfd\x92\x87\xda\x8d6O\x92E\x8d^(\xe1E\x99\xde\x00\xac\xa8\xb1\x84\x94\x90\xa2\xa3\xa9X\x18'\xd7\x85B\x83>\xc6\x08;6\x91JX\x87\x05\x92E\x8f	w\x97\xc1\xd6R\x90\xa8\xd1#q\xe7A\n\xb9\xd2vY;\x85%\xccP\xa8\x895\xdb\xbc\xd7\x86\x91\x8b1\xaf\xe5{\x0b)]\xb0\xdeo\x1b,{\xf6>]\xcd\xe6\xc9\x8c0\xf5\x93\xa93ZnK8\xdb]\xf5 7\x9c\x13m\x95\xb6\xcb\xb7\xc9\xede]\xa3\xd2\xc2#\x8c\xe07\xa1\xfd\x85\xa3\x0bM\xecO\x9d\xe5P#}?R)\n\xc8\xfe\x14\xa4\xcevW\xfd\xc7\xa4\x1e\xc0\x03\x12\xdeWq\xd7P\x9a\xdcB\x1fPsgrW\xcc]\xf7\xdd)l'\xe5\xf9\xfc\xacX\x08F\x05+\xbd\\\x15{\x9d\xfb\x08\x8c\xbb)\x8c\xf0h\xe56\x9d\x1d\xe6\xcf\x04R\x94y\x12\xe8\xfb\xb7\x0c\x14\xacM\xaa&\xa7\x82\x8c]v'J\xb8\xac\xfa\x0e\xdd\xb7\xec\xad\x0b\x94\x90r\xcf\x86\xc0\x08w\xe2\x8b\x8d\xdaD/\xfe\x1e\x0eR\xec\xff\xa5\xe0\xb3\xa3\x1fO\xef9\x95?\xa1\xdcoS\xfa\x03\xa9\xfdo(\xfdv\xb1\x0f\xbe6_\xf6\xe3\xe5\xc5\xe5\xd5\xf9\x83\xd3\xe5#\x87\xcbV\xf8\x17\xda \x83\x0cDh\xbd\xd9\x82\xb3f\x9b\x14	7\xda\xafv\x1f\xdcLFV\xf5e\x15u\x99\xa4\x98\x19\xe9\x0c\x8e\x92\x06\xf3\xb4Z\xc5)'\x0e\x88}\xda\xbe\xcb\x87\xb7\xd2\xe6\x91\x1f\xdc\xde\xb2\x8e)\x9e4\xb1\x1f\xf5\xf5R\x80\xd2\xf4)\x96\xcb\xab\xe3\x17/^\xf4\xab\xf1\xd0=\xcbA\xabWO\x8e\x8f\x8f{\xbb\xe5\xfe\xc2\x97\xe5\xc8\xeb\xf0k\xba}\xc7q\xd2iju\xb3\x93\xf1)\xcc\\\xcc\x0c\xe1\x9f!\xce\xf8\xb1\x7f\xee2\x96\xa8C\x984HiR\x8d\xc7\x8a[\xf4\xd1B\xc8\xa1\x08~\xe5H\x7fN6wF\x98\xb6\xe2O\xdb\xa6\x1a}=\xc0/o\xd9c]\xb6\x9cI\xe3B\xec\xaen\xa3\x15\xc6\xd6\x0c@\xc1\xe0\x1em\xa2\xd1\xaf\xc9\x85\x86K\xf8px\xf81\xaf\xc7q\x83] \x89i\x9dQ\x12z\xde\xdb\xde -\xb8L\x8f\x1f\x0e\x97\xe8\x0f\x8f\x0e%\xa1\xf0x\xf8\xf1\xdf\x88/\x7fu\xbf%Lgp\x86U\x17\xe8\xfd\x0cB\x1f\xfe\x03I\xc8\x96\x8fs\xcba\xf1\x07J\xbfGp\x9e[\x916Z\xe2\xb8\x1d\xf1\xf2f\x07\xda\xf4\xff:\x16\xad\x18\x8b\x85\xb6]\xbe:3n\x84\xc4v\x14-x\xcb\x1e\xeb\xc1_\x03\x00PK\x07\x08\xa9i)\x89\x8a\x04\x00\x00\xdf\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/calico.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec}mo\x1b9\x92\xf0w\xfd\x8a\x82\x84A\x9ega\xc9\xc9\xbc]F\x87\xfb\xe0\xc8\x8a#\x8c-\x0b\x96<\xb3\x83\xc1B\xa0\xbaK\x12\xcf-\xb2\x97d+\xd1\xce\xe6\xbf\x1f\x8ad\xbf\xaa[\x92\x93\xd8\x99\xbd[\x01w\x137\xc9b\xb1XU\xac7r\xbb\xddn\xab\x03S\x99\xa8\x00\xfb\x10\xb0\x88\x07\xf2\xdc\xe0&\x8e\x98A}\xee>t\x03)\x96|\xd5\xdb\xb1M\xd4\xea\xc0l\xcd5\x0c\xec\xa7\x1b\x16\x03\xd7\x90h\x0c\xc1Hp\xfd\x12\x85\xc0@c\xb4\xec\xae\xa56\x18\xc2\xc0\x82\x01.\xb4aQ\xc4\x0c\x97\xa2\xd7z\xe0\"\xec\xe7pZ,\xe6\xbf\xa0\xd2\\\x8a>l_\xb56hX\xc8\x0c\xeb\xb7\x00\x04\xdbd\xd8yd\xfcW\x1d3B\xfc!Y`W\xef\xb4\xc1M+\x1d\xe4\xba\xcf\xf9\xd2\xf6hsaP\xd1\xbf\xff\xeb\x8f? \x10\xdc5\xc0\xc7\x8f\xedV\x0b\xa0\x03\xbf\xc9\x046\x896\xa0\xd1\x00\x03!E\xf7\x1f\xa8$lY\x94 ,\xa5\x82\xd9.^3P\x18G<`\x1a\x16\x18\xc9\xf7\xbd\x16\x80\xd9\xc5k6\xd7\xa8\xb6<\xc09a\xd5\x87\xb6\x90\x02=\xe4AF\x15\xb3\xc6\x94\x16\x0b\x16<\xa0\xb0TK4\xf6r|}C\x1f\xda\x0b\xae\xc2Z\x107\xb3{?\xae\x05\xb0E\xb3\x9eoL\xd2\x87\xf6\x1f\x7f@(\x83\x07T\xf4\xf7?\xb90\xd0\x85\x1f_\xa6K$\x1a\xcd\x83(\xd1\x06\xd5<\xe0\xa1r#\xf6>\xe7$\x99\x11\xba\xe3\x11\x084\xef\xa5z\xc8\xb6\xd7n \x98lCA\n@\x16\xacA\xc8\x10{`\xc7\xe9\x18\x03\xce\"KZKB\x0d\\\x80!\xd6q`\xe0=\x8f\"X \xb0\xc4\xc8\x0d3<`Q\xb4\x83X\xc6	\xf1^hI\"\xf8\xdcO>w\xa3\xfa\xf0\xcfn\x0b\x00\xe0\x0f\xfb\xff\x01\xdaD\xefv\x1f\xda\x0f\xafu7\x96a\xd7\xf7o\x9f\xa5\x1d\x02\x91\xf2\x15u{\xd9\xfb\xae\xf7*o\x8c\xa3d\xc5\x85n\xf7\xe1w\xff)\x07M\xbf\xb6\xd9\xc5\x16\xbc\xdb\x9dl \xfd\xda\x91\\\xcd#\xdcbD\x1d\xb8XV\x9a\x89\x11\xb5\x91\n\xe7)\x10\xa2\xb5\x12hP\x97{\x12\xdd\xd2u\xcc\xe7?\xdf\xbf\x19\xde\x8d\x87\xb3\xe1t>\xbe\xbd\x1c\xce\xc7\x177\xc3\xf9\xbc<bc\x92v\x1f\xe6\xf3\xc1x4\xbf\x99\xdd\xcf\xe7\xa5V\x1e\xb3M\xbb_Z\xc8\xdeR\xba\xb6S\xa1\xc7\xc7\x12\x88XF<\xd8\xed\x01)\x80yx\xad\x9b\x87\x17VZ\x03\x82Z\xdd~\xe6+\x1e\xdc\x8e\xdf\x8e\xae\xe6oG\xd7\xc3\xc9\xc5\xec\xdd|^\x02\xde\xaa\xc1\xf2\x8fV\x0dV\xb1Tf\xc3\xe22\xb5\xb4`\xa6\xdd\x07\xa3\x12,}\x0fX\xcc\x16<\xe2\x86;<\xed\xe8\x1b\x16\xc7\\\xac\xb4\x1fp\xfa\xd4\x0b&\xc2\xf7<4\xeb\xf6\xe1I\xf2~{3\xf8q\x7f\xb3\xff\xfd\xd8j\x1dQ\xcf\x0fa\xd8\x0dT\xa8\x9df.*P\x16s\xfc`P\xd0_\xba\xf7\xf0Z\xf7\xb8<\xdf\xbeZ\xa0a\xafR\xcd\x9bh#7w\xa8\xad\xf2\xbf\xc4%\x17\x9c\x04\xbb\xa4y\xbd\xea]b\xc4?\x94\xc4_\xf7\x02\x15\xf6b%\xff\x1b\x03\xe3\x84\xa3'\xd5\xaaErO\x03u c\xec\xc3\xc0)\x96\x16\xc0J\xc9$\xeeC\xfd(Rc\xb9\xea\xf7\xaa\x9d\xc0\x008d\xdf\x12\x02\xa9\"\xb5\xfa\xc76\xc6Q\xa2XT\x8b\x9fm\xd7\\\xac\x92\x88\xa9\xba\x1e\x96\xb8OB4G3\x92\xafE$\x83\x87g&\xd5hrq\xf3\x86\xe6-Q(\xc7\xa6B\x98\xac\xa1\xf5\xd4\x04\xb1\xc4`K\xd7\x03\x9f\x99*\x96\"\x17n\xf2]\x892\x15\xb4*\xe4)\xb6\xee\x9e\x9cD\xb4\x19k&\xc2\xe8\xb9\xc9CL\xf3\xceN\xbc\xc75\x1e\x9f\x1a\xb6q-\xcfB\x14\xa7{\xbe\x02Q\x06\xa9\xb5Y&\x8a\xc7\xa7\x86(\xde<}j\xa2,Vq\x8c\xa8\x9e\x99\"o\xae&\x13DU\"G\x8aI\x85\x16\xfe\xf3s\x10\xe2k\x1eLo\xae&\xcd\xc7\xd2\x1en\xfb4*\xe1\xfe\xe4\xc4\xe2q,e\xf4\xecb4\x912\xaa\x88\x90\xc5\xa3B\x0e\x87\xdd\x93\x13\xc1:\xa5\"\x8c%\x17\xe6\x99I\xf1Nj3\xf4S\x97\x08R\xc2\xa9B\x96b\xdb\x93\x13\xc7{\x82\xe4\xcd\xa8\xcd\xd7\x10(\x0fg\x94#P\"T\x0d~\x15r\xed\xf7xr\xa2\xad\"\xb9`\x91\xf7<\xad\xe7\xf4\xec\xf6\xcd\x95Ea\xecP\x98\x10\ne+\xa7\x16\xc5\n\xe5\xf6\xfb<\xbd\xc5S\x9aS\xe3s\x0bd\x89lS,\x0b\xe5\x1en\x87\xe8\xa5\xf1\xe9\x85\xf3\x938l\x9c\x86\xc9\xc2\xcf\xa6V3{U0\xab\x10\xeayY\xea\xd1\xcc\xf4\xe5)T\xe5\xa4\x02J\xf5\xa4!\xee9\xe2\xec\xab\x05\x0b\x9c\xa3\xdf\xea\xc0H\x04Q\x12R\x9c\xd5\xab;%#\x17\xa5\xa4\x18#\xc5W(Nj\x94\x8c\"T\x1a\x02\xb9\x89\xa5@a\xceZ\x1d`\"\x84\x05\x17!pCA<\x1a\xe0CB{\xe3|P\x93\x05\x81L\x84\xc9b\xb6n\xca;\x19a\xc9\xc2\xb5\x18\xb2\xc4\xac\xa5\xe2\xff\xb0gG\xaeYkL\xd8\x86I[*\x89\x1c\xdbu`,C\xd4\xc0\x14\xc2{f\x82\xb5\x8b2o\xa4\xe0F*\xbb\xda\x10#\xa4\x89t\xaf\x05\xd0%\xb5~E\xfa@\xf7\xe1\xf7v\xdb\x85Q\x94\xe7\x19\xbfO\xd4\x8d\xe2mn#\xb6\xa8\x16\x85\x06;K\xf6W\xc4\xb5\xc9\xfeX\xd9-\xed\xc0D\x86\x0e\xa3\xbf'\xa8\xb8\x8f{\xaf1x\xb0\xf8\xe0\x07\xae\x0d\x8a\x00\x1f\x85O,\xc3Zt\xd29\xc9\x11\xc9\xd7ag\xdf0\xc1}\xa4\x14\xde\xafQ\xb85\xd9&K\x13\x0ck(R\xcb\xd4\xcdd\xf2\xd6b\x1df\x9e4\x9f\x07\xbf\xce\xf3\xa6\xed\xa9\xc4*\xd2OEG\xb4\x82\xce\nM\xfd\xae\x05\n\x99\xc1l\xc6$\x0e\x8b\x7f:JY\x12\x8f\x11i_\x83\x00\xb5&\xa9p=k\x8c	\xfd\xf9t\xad\x01\xda\xbc\xfb\x87\xd6A\x1acO$\xdfp\x11r\xb1z\x12\xc9\x94\x11\xde\xe1\x92\xe4%\xdd\xf7\x03\xb0[\x00\xfb\xfa\xe2\x98\xf0\xebdA:W\xf7[]?z\xeat\xd0\x85\xd3AG\x014\xe6\x85\x88X\xc7\x14\xa7\xc7\x8ad	.\x19n\xa4\x98\xe2\x11\x9di\xfb>\x87\x9e\xa4\x89\x8a\xba1\xcd\xce\xb8\x14\x06\x08\xcb\xc0F\x92,X\x85r\xe6T\xc2\x99\xd5\xf7\x19M\x1e\xa7(3\xc5T\xd6\x9a\xf6\xaf\x0cb3\xef\x9e<O\xe6\x8ee_<Ek\x80w\xe0\xde\xa7\x1bC\xae\x03\xb9E\x95v\x86\xd1D[-\xcc\xc2-*\xc35n\x90v\xe3\xa8v\xaf\x01i\x93}\xba\xf7\xa9\x0b\xb2\xa4?\xd7\x86\x99\xa4v	\xa4n0\xb4\xc8\x06\x112\xc5\xc5\xca\x1ev\xde\xc2\xba\x17l\xcbx\xc4\x16\xc4\x9b\x11[\xe5x\xc4\x85\x13\xaa\x93&\x13m\x96I\x83\x96\x1b\xac$\xe9\n\x8a\x8b\xf2o\x84\x160!\xa4\xc9u\x19\xfd2\x8dB@\x7f\xa5)\x1cjk&Vh\xb9\xea\xe7,\xa1S\xb2\x02y-Cys\x87\x8b\x95W\x05\xcd\x1cVq\x9a\xf6i\xd5\xb0m~\xcb\x16\xbb\x94\x06\x84\xafu\xbdv\xc5E\x7f\x06\xb7\x97\xf9\xbb\xc0\x93\xde\x1a:t.\x96\x11\xdf\x93U\xbb\x89\xa8\xadxy&y4\xa2\x8d\xcc\x95\xf3H\xc6\x1f\xdeb\xd2\xb0e\x8a\xcbD\xc3\xe0\xee\xd2I\x8a\xcf\xec\x7f\xb6\xa9\xe0\\\xa1B\x92%\xa7\xda~\xe6%o+\xc5\x0es8Y\xdc\xac\xd4\xb3\x01F\xd1Ji\xb0\x1ej\xfd\xf3\xfa\xd6\xccDob\xce\xc2\xf7R\xdf\xa6C\x9d\xda\xf6\xa3;M\xe6O\xad\x1a=\xc8Z\xe9\x16S\xe9\x82\xb3\x12\xac\xc2\xf7\xf6\x8bU	v\xb7\xa5\x00m\x982I\xfc\xf9\xbb]%\xf9\xa1->\xd1\xd8i2\xd4\x1e\xa9\xe3\xa8.a\x8dV\xcf\xd5,\xf3\x80\x1aj\xf2\x08\x1a-\xcbT+Y\xe1\xd6\x081\xaa\x0d\xd7\x14kpV\xb8\x14\xd1\x0e\x14\xfe=\xb1N\x02i\xa7$^)\x16\",\x95\xdc\xc0\xf6\xdb\xde\x8f\xeeh\x0e\x18E\xb8:T\n\xa1p#\xb7\x18\x02[\x1a\xcc\xfbKE\xcbZ*\xd4\xebR	\x8d\xfe|\x0b\xbf>@\xed[r\xc9<y\xaf\xeaIAT\xe0\xcaS\xc1o'iC#\x89j\xc4\x17\xce\xc3aQ$\x83/\xb6\xb4?\x8ds\xf1\xb9NX\x9aS:\xe8\"\xda\xfc&\xe4\xda\xc4)\x04\x16iI\x8ce\x99\xd5Z\x13\x8b\x9d\xd5\xf9n/\x94L\x0c\x02[\xad\x14\xae|U\xd6S\x90\xbd\xc2>%\xc9I\xf9\xc12\xc0\x86\xaf\xbc\xddR\xb2fCk\x89\x93\xb6\xed\xd5pX\xc0\x04,\x9c\x13\x97\xca\x0f_\x82\x90\xc6\x0b\x10\x99VV\xe4\x98(\x89\x0f$T\x1d`us\x97\xf8.\xb28\xd4P\x80\xc5\xb1n^p\x8e\\\xd3\xf6\x90\xe3q\xb23\xd6\xe8\xce5\xc6OHu=\x81gf\xc1>\xd6\x1b\xb3\x83\x1a=\xb0c\x11.\x0f\xc4\xd6\xd3\x95\x8a\x0d)\xd8\xb1\xc4\xec\x80\xd3\xc0R<\xce\xe0\xfd\x9a\x07\xeb\xac\x98\xccV\xcf\x15\xcc\xc2\x17\xda\x97\xed\xb9m\xeaQ\xf9\"\x81\x07\xcd\x8d&\x8bx\x81\xe6=\xa2p\x05&V\x1f\xd3\xf9q1\x19Yk\x0f\xd5\x19(\x0c\x93\x808%\x83\x18I\x16\xa6'M\xde\xb3W\x8eif{\xe91m\xde@\xbb\xdef\xaa\x01Dl\x81\x91\x971*scq\\\x19\x9c\x86|\xa9~\xc9w\xec\xda?\xfa\xf0\xc3\xf7\xff\xf1\x9dg\xc7XI#\x03\x19\xf5a6\x98\xf8o\x86\xa9\x15\x9a\x89T\xa6\x02\x92\x1a\x1b\x10\xd5\x18a`\xa4:\x84\x91\xdd\xeb\x03\xfbw\x89q$w\xe4\x9f\x81\\\xfa=1\xae,\xd2\xd2\x95-\xe46\xf3\xab+\xa4%\x81<\xcf\xe8\x9bCz\x16\x12w`\x9cl\x16\xa8r\xb4\xd3\x8aP\xaa|\x94\x80\xc2\xeaY\xbb\xa23[Lj\x8b\x1e\x8d\xdc/*\xfd\x0b\x13\xe1_|\x17\x12\x9b\x0e\xd8\xa9\xe6~\xd5sb\x08k\xb2[\x88\xb6|2\x0bQ\xf8\"\xca\xac|\xd6\x11\x8c\xb4W\x87\xfe\x0f~EP\x18\xc8\xcd\x86\x8aL\x9d\xa6\xb3(\x91n\xdc\xc9\x04\xd6l\x8b\xb0\x91\xb6(\x95	\xf8\xe1\xa5\xb5\x99h\x0d\x17\x96\xf2\xaf^\xfa/\xc0\x0d\x95\xf8\xa2\xd6(LZ\xd1\xf9\xffl\xc8\xd1\x81%\xa4\n\xdeaV\xf5\xf8\xff{@>\x1aH\x81)\x89\xec\xa1\x83[T;\x82\xdf\xfd6\x9d\xa3\x070rFP\xacd\x98\x04\xa4\xaa\xce\xe0}q	*\x11\x82\x84\x90\x19\x88\x90i\x03\xdfet'\xdaZ!\xa5\xb5 \xf0M\xcc\x02\xcbU\x14S\xa21\xde\x90\"\xe2\xa4c\xfa@\xe1{\x85[N,\xf5\x8e\x93u\xb9\xbb\xe6\x1bn\xfa\xf0\xed\x1e\x8bo\xe8\xc4\xba.Ha\x93\x1c\x02\xa4\xb5\xd3~`\x81!\x01\xcal\xd6,;\xd4\x02E7=\x1f\xe0\x04\xea\x0cX$\x05\x95\xd0\x9a\xb5]\xf3@q[@{\x11\x86R\xe8[2=\x8d\x8c\xd0\x1f\xa6\xb6R\xf9\x0c6L=h\xdb=\x96!0\xd2\xa2\x81\x1fW\x80\xcf\xc2\xb0+\xc5\x19\xa0\xd0\x89\x0dKpC\x16\x90\x86Xq\xa9\xb8\xd9\x81\xa6@|b)\xeb\xf4%3@\xda43\xc2\xbc\xd1Gll\x93Ni\xb4\x82/	\x18\xed\xbf\x83\x88[\x1e\xf8@\xb5\xfby\xc8\xa8z,\x8a\xd7\xac\x97W\x91Rz6E\x96\xca|\xfb\xf0\xe2E\x06\xd9\xbb\x18]\xaa$\xd6\x01\xa3\xf1\xe5\x91\x9a-\xb1kd\xd7N\xd8\x87\x17T\xd7\xe9\x86\xa7\x8a\x93~\xc4\x8a\xd3\x92j\xa3\x1f\xa5\x84+\xe0\xa4\xeeC\xc4E\xf2\xc1w\"\x13\xc2\x07E\\E\xa7\xff\x9eoAi\x07o\x98z\xa8\xdf\x06O|+&\ns:\xa7\xe1\x1f2\xb0\x1ep\xd7\xaf\xd9\xef\xac\x07\x80\x8ciV\xa9\xfa0\xa4lDj\xe7v`\xcaE\x90\x19\\\x01\x13/L\x1a~\x01fy\"\x11\x86G\xa9\x9e\xd0\x90\xc4V\x08\xc9\x16\xb32\x96\x88\xb4\xcdPu\x7f\x06\xd7\xae\x81\x88\x90\x16_cH\xe0R\xacu)\x80;\xae\xb1\x17\xe8\x97\xb2\xd7 bZ\xbbN\xcel\xe8\xa6\xdb\x9bR\xc9\x83\xed\xc0R[C\xc7\xfa\x0d\xef\xb5WG\xdeE\xc0\xb0\x12\xae\x01#\x1f\x90\x1c\x12\x0d!\xea@\xf1\x05\x86\xb0F\x85\x90o\xeey\xfe\xcf\xce\xebo\x7f\xc8\x0eN\x8dAB\xac?\x90\xc2\xe0\x07\x93o\xa6\xc7\xa0\x0f?\xfe\xf0\xc3w\xdf\xfb\xcf\x14\x8df\\\xa0\xcav\xbd\x0b|\xc3V\xd8\x07\xbaz@\x05\xe1\nc9WR\x1a\xf8\xf8\x91\xbe%J\xa10\xf3\xf4\x9c\xfc\xfdE\x881\x8a\x10\x05\x85\x1d^\xfc\xed\xf7\x174\xca\xfe\xb7\xa0'\xe8o\xad\x82\x17\x7f\x83\x8fy\x91r\xed\x81\xed)\x9c\x1b\x07\xde\x7fI\x11\x9dT-\x85\xc6\xa3\x1f\xa0\xd9\x94\x00@\xb1M\x97\xec\xddj\x8b\xcd\xd5\xed\xcd\xc5_'w\xb7\x83i\xa1\x11\xdc\xb5\x80\xb7Jn\x8ac\x8an\xc5[\x8eQ\xe8\xad\xda\xf2/U5$\x87\x1bNy\xde8)\x00\xe9\xc0\xd0\x9d\xc2\xae*\x1f\"\xb9Zq\xb1\"\xb30\xc4%K\"\xd3\x03\x18X\xbf\xc1\x1d\xbf\x12\xda!.\x92U\x9b\xf8\x9c\x0b235Z\xbfEjnv\xbd\x9aE\xcd~\x9b\xbc\xbb\x98_\xdf^M\x87\xbf\x0c\xefF\xb3\xdf\xa6\x83\xbb\xe1p\xbc\xbf\xc2\xf4n@\xa1\xa5\x03\x97\\[\x04S\xcc\x8c\x84%\x8f\\\xe8F\xeft$WT\xbblO4\xa9\x11BI\xc2\xbaa\x0f\x84\xaf\xd0\xd6\x18\xc8\xcf\xdc\x83\xe8\xa5\x05\xf5\xb5\x88\xb9[*\xa7,\xee\xb7\x9a\xbd\xcb\xae\xb9\xe4-\x1d\xb8\xf1\xd9\xd9\x8aY@v\xb2]\xa4\xb7\xb0EfD\xa5'\xbb\xf5\xcd\x84=>\xe8\xbc\xc7\x05\x8b\xe8\xcf\x12\xf0@\n\x81A\x16!\xa8\xdf\x93\xc1\xedx<\x1c\xccF\xb7\xe3\xbb\xe1\x9b\x8b\xeb\x8b\xf1`4\xbe\xba\xb9\xbd\x1c\xd6\x92 \x17\xf9v#\xc4\xcb\x8b\xd9\xc5tv{7\x9c\xfd6\xf9t(\xef\x86\x17\xd7\xb3w\xc3\xf1\xc5\x9b\xeb\xe1e-\x14:\x97\x8a\xe3;p/\x9c\x0dgMD\x8dt\xec\xb8x\xbc73c%7h\xd6\x98h\xd8\xa0Q< [\xca\xe9y\xaf\xaauE3\x9f\x95\xe0[\xdbT\xc6V1Z\x87!\xf5khP\xea\\m\xd8.;\x04\xac\xc4\x04\x89*\x1e\xdbU\xbe\x99\xdc\xdd\xde\x0cg\xef\x86\xf7\xd3\x9b\xe1\xecn4\x98>f\xcdG@Mn\xeff\xb5\xb4\xfb\xe9\xe5O\xdf\xe5p\"\xbeE\x81ZO\x94\\x[\xcc\x9f\xd5\xc6\xc4WXP\xe1\xf4\x8b\x99Y\xf7\xe1<\x1dTn\xb3\xba\xf1\xa7\x97?\xbd.}&\xfa\xf4\xc1\xc6\x0d\xe8\x9f\x85\xb6\x18\x15\x97\xe1\x94.\xb8\x84\xba\x0f\xdf\xbd,\xb4\xd9\xa8\x08\x8b.1b\xbb\xba\x1e\x8d\x07\x0d\x90\x98\\\xe8\xb1\x14wR\x9a\x92\x95A?{\x02N\x14\xdf\xf2\x08W8$#\xc8\x9a~}X\xb2H\xe7=\x15\xb2\x90?\x9a.\xd9\xa8/J\x98W/[Uu^0\x93\xe8\xe3\xdf\x13\xd4\xc5\x13\x8b~A\x9c\xb8c\xd4\x1e\x84t\xb3j\xcd\xe6i\xd7y\x10'\xc5\xc3\x90~\x1b\xdcH\xb5;0\xc6u\xa0a\x7f|C&j\xa9\x97;^,\\\xa9j\x9b\xfc\xf0o\x8a\xb3\xbaQ\xfd#\x00\xbf\xf9x|e\x85\xee\x0eA\x14!_\xd2\xd0f\xd8u\x185\xd3\xa1<h\x7f\x92l\xc2c\x1e\xfdD\x86t\xac\xa9$&\xce\x837IH\xb1\xbb\xfc\xe2$y\xc0\xc4\xa6\xf0\xf3k\x9d\x06\xb8\xe0\"3\xd9\xa9\xdd\xda\xe7e_\xdf\xa5\xf4*u\xf2\x13\x19\xe63\xb9\x89\x9e\xc5\xf7\xdf\xb0\x0f\x85\xcc\xac\xf3!?\xd1S<1\x16F\x06r}(\xcc\x872u1(@\xbds\xab\xee\x8c\x0c\xf3\xf7\x18\xd1\x9d]\xe6\xfa\xe5\xf5\x02\xee\x94\xf5\xf6\xbaO\xa8\x80\x14\xad\x8e\xbb\xc9\xb9adr\xdb>\xd4\x01\x95\xf5\x8e\xc8\xec`Eg\xdf\x9b\xe6i\xc1CV2Q\x1b\xaei\xdc\xa1\xc3Q\xc3\xc3\xf1/;6\xf5\xe2\x1e\xbd\x17~f\x97\xc1\x98\x1a\xc5\x0c\xaev\xc4A\xf6V/\xf6\xe1\xce\x85\x10\xee\xf3\xba!\x1fTp_R\xddT\xc7\x18\x9f\x17	\xf0\x98=E \xc0c@\xc7\xff\x81\x90@c \xa00\xfc\xcf\x19\x12\xf8:>=\x99\xe6t\xf3\xbd(\x8aD\xael\x056dL\xb7\xa5	\xa7\x82\xcd\xde\x05\\.\x91\xe2\x12c9\xf5}\x8f{\xf3_1\x90PFy\xf8\x01\x83$\xab\xa9;\x80\xb1.\xd5q5\x85\x01:p\xc3\x05\xdf\xf0\x7f\x90\xd3\xf3^\x18\xbeA\x08\x1d\xff\xb1j@\x0f\n\x05\xa0\xff	\x06\xa3\xa8\xa8\x9b\x8c\x84\x90\x82\xae\xed\xa5T\x99\x1b\xd1\xc9\x06\xb4\xfb\xd6\xe6\xd1\xfd\xf3\x82\xd7Oa\xa6P\x06\xfa<\x90\"\xc0\xd8\xe8s\xd2~\x14\xef\xd7\xe7\xb6\xe8\"\x96\xe1y\xc7P\xaaWX\x91\xea\xca%\x85\xa3\xb2\xed,4])\x16\xe0\xa4l\x0c\xa6&Os\xb0\x83\x98\xa3\x1a\xe9 \xabq\x90j\xf5=\x1d\x90+\xfc4\x9b\xaa3\x02\xd9\xbcW%\xc3E\x84\xf1LJ\x89\xcf\x14s\x0272>\x9b\x96\xd6\x90\x92\xf9b]\x04N\xdc\xb5\x9f\x82>\xa3-(F\x94YD\xc6b\x1e\x8d\xea\xa4\xa8\xa4/\x1d\xd4O\x9d\x1a\xfd\xbe\xb3E,\x83\x01_:z\x12\x08^\x1b;\x01\xaa\x93\xde0*\xdd\xfb\xbd}.cs\x1e\x08~\xbe\xe0\"\xcdL\x11Z\xed3hw=\x9e>+X\x1b\xfc\xc8\x17Uw\x9f\xbf0\xee`(\x04`\xd9\x18\x04\xf1m\x13\xeb\xbc\x90\xd2\xeb\x11\xf3\x90`\xd5\xa21\xb8\xb8\x1e\x0dn\xe7\xe3\xe1\xec\xd7\xdb\xbb\x9fG\xe3\xab\xf9\x9b\x8b\xc1\xcf\xc3\xf1\xe5\xe9\xb8\x04i\xb6\xe1g\xdc\xd5\xc6e*\xa6W\xf6TG\xf5guO\xf9\xc1\x8b\x02\x16\xb5\xee@\x93C\x90\x1b\xce\xde\"\x9a\xd3\xab\x11\x87<\x82\x92-\\;\xa8\xea\x12\x14;\x15,r\xa9\xeaZ\xea\xcd\xef\xb2K\xd0\x00\xf0\x9b\x8f',\xad\x80@\xadKp2J\x8dt(/\xe4\x80K\x90\x83\xda\xca(\xd9\xe0\x0di\xf6\xca\xaeuaC_\x1d\x97\x9eo\x99:\x8f\xf8\xc2\n\x967>u\xab\x8e\x83r\xa5EA\xe5n\xc8\xd5\x01\xa8\xd4\xb9(\xaf\xb5\x10\x03\xc1\xbb\x0b.J\xa0\xf64\xa8'^n-/\xb8`*\xaf\x1f!-IF\xf1\xfe\xb3(.~Wz	\xa5U\xd5\x04\x1e:)\xa0\xaf\xad\xdd\n\xa8\xf4\xf4\xfa\xb0\"\xeb\xd8\x1b2\x94QK\xa9\xe2\xfd\x05\xbbd:NlZ7_o1\x10I/\x94\xd0\xdb\x1e\x8dj\xaf\x0f\xedW/\xbb\xbe\x14\x86\x00S\x99U1\"T,v\xae8,Mo\xd0\x94F\xa7\xd4'L\xbc\xf6\xb3\x18\x8d\xaeN\xd7\xc2_X\xf3\xed\xbdkS\xc2\xa4\x03S\xb4\x01?+\x05DFX0\xaav\xf5\xb1\xb9\x87\xd7\xda.\xd3\xae\xab\xf7g<s:Dl\xfbN\x91KPgY\xec\xc6\x8d\xb9\x99\xdd\x9f\x8e\xdf\x97\xdd\x8d\xf4\x05\xa5\xd2\xfc\x1d\x98(\xdc\xa20\xde\xc1\xce4\x845\xa9t\x84H\x8f\xc4Px\x83\x9c\x97\xfaM\x98^\x0f\x87i\x9dE\x95\xe5m\\\xae\xfd\xefC\xef\xff\xc2\xa1\xf7\xc9\xc7\xd3\xfe\xf9iA\xa1	\xd2\x03\xb4\x176\x9eu\xd5c\xb3\x03\x17!]q\x82\xb7\x11~\x80_,\xeep\xa98\xa5\xe3\xad\xc3\x9e\x17\xe7\xc4\xa8\xc8\xab\x81{\xc1?\xc0\xa5\xdc0.`J/\x8b\x99<\x8aw\xc9\x1f(FT<\x18\xe9$\x90\x9bM\"x@\xb5\xc76(\xf1\x96J\x83\xc1\xde\xd2 \xd5\xe5n&\xc3t'\x02J	\xf5ZU\x99YF\xf8a+\xa3nh\x11{:\x1f \x96\xe1\xb7\xae&\xac\xeb\xa7lp	\xfe-\x97\xffk\xe4\xb2\x9e\xc7\xba\x954\x05\xec\x0b\\\x89\x19\xb3\xa3\xa0\x00\xbb\x03w\x89\xd0\xde\xa39/\x87a3\x9b\xb0\x10\x9b\xc8\x1e\xca\xe3E\xf9\xc9\x87\xc4J\xae\x14\xdb\xe8\xcc\xde\xf17[\xc8\xf4\xb4E\xbb:\x85Z\x18N\xeb\xd8\x17\xa8\xfdx\xe2\x13H\x13\xad\xa7A~*5\x01D\xab\xac\xb87O\xbf\xbf\"F\xa4c+\xb2\xf7/\xa9$\xedU\x01\xdd\xa3\xb5\x04\x07]\xe8\xe3\xf5\x04G+\n\xfc\xb5\xb3\xe2\x1eRF\xdb\x87\xd5\xa9\xea\x94\xac\x81\xac\xf6-\xdf\x86\"\xdfei\xe4\xf9^\x1e\xd9\xe3_~#\xb0\x04$+\\Mb\x97\x92\xccnM\xdarWB#+\x06\xac\x9f\xfe\xed\xf0z\xf4W\x9a\xfa\xdd\xc5\xcf\xaf\xa7\xd3\xe1\xdd/\xa3\xc1\xb0\xc9*\x7f\x0e\xc3k\xbf\xda\xb1\x04\xaf\x03\xbf2n\xb2\xbb\x99G\x88\xfb\xeb\xc5h6\x7f{{\x97'\xeb\x1b\x08\\M\xb2\xa7\xf6\xf6cMlz\x9b\xf1q\xd4\xfb\x12f\xf5ZJ\x8d\x19\xd3\x95_\x10\xdd'\xca\xbfN\xcc\xc7.\xce'\x05\xe9\x85IZ\x16\x0f\xa9\xe4t\xe9\x98;\xcc\xca|m{-\x0f\x0c\xae\xef\xa7\xb3\xe1\xddA\xf1z\xad\xcf\x16\xab\xb8*[\x94\x87\xec\x86h0p\x8e\xd7\x9b\xab	\x8c&\x14FW\xa8u\xbd8\x8f\x9al{\xaaCt\xb0\xaa\xd3\xf8\xb2\xa4\xd1d4\xa9\x05\xe9\xf7k4\xf9\xe5\xfb\xc9\xed\xed\xf5|4i\x9c$}\xc56\x9e\xa3\x08X\xac\xe9-\x00\xca\xbb\xd2\xeb\xad\xc5\xfe\xce\x99$W\xcc\nR\"\x04F\x10R\xa6\x15\x89ol\x88\x97\xc7\xdc\xbe\xe3\xeb\x8aJ\xc2\xda\xd5:\xed1\x9a\x8c\xc6\xa3\xc9\x9f\xcfY+\xdd$\xd9~\x0f\xb1\x94\x91\x8f8SY6\x05y\xf4Z&Q\xe8jy\xa1\xdd\xcd\xcb\ny\xa8\xda\xb5\x1b\\\xdd\x8d\xc1\xe8\xf2\xaen\xcb\x9fc\xdd{\xcf\xf4\x96\xc0\xe5\x05eK^\xa8*\xd3\xd2\xbf\xb5j\"\xaa4\xd3m\x9b\xcc\xd5\xbdC\xab\xbd\x1cM\xa9@\xc9>\xc4J\xe5mW\xa3\xf1\xd5#5\xa9\xb3\xf9\xd3;\xdd\xb4\x0dd\x9a\xa4\xa5w\xc0\x82\xf4\xfd\xe0\x8b\xc1`8\x99\xd5\xa3\xe3\xf8\xedr\xf8\xf6\xe2\xfez6\x1c_NnG\xe3\xd9\xec\xf6\xdd\xedtva\x8b\xbb\x1a\x84\xcf\xc1l7\x90g4\xd9\xfeH\xa6S~\x8e\x1f\x9a}4\xf9\xe5\xc7\xe9\xfdd\xaf\xe6\xe8\x80\x1b\x9f\xd3s4\x99_\xdc\xcfn/\x873W\x8c6\xa7\x9a\xa8\xdb\xaf\x1dm\xb7\xaf^7\xe0\xfb\xe3\xbf\x06\xc2E&+\xd4O\xeeUY\xe6Ks\xbct\xb8V3\xdf\xd3cp\x9a\xeb\xf8\x0e\x89F\x19\x97\x93J\xe4>\x03\xdc\x01\x96\xfd\xe9\xe5O\xaf\x8a\xb0\x0e\xd4\x9c\xd9D\xa5\xab)\x0b\xf7j\xceR\x8b\xb9\xe0\x06\x9d\xe2\x18\xfb\xac\x0fy\x0c\xa7\x07\xacj\x07U\x03V\xc5Ne\xc7\xb8\xa6\xc5\x0f>\xe8\x187\x00\xfc\xe6\xe3	K+ P\x1b\xb0:\x19\xa5F:\x94\x17r\x92c\xdcX\x15	\x80\x1f\xf2\xa2\x89j\xbe\xa0\xd4\x91\xa4\xaa\x98\x13-\x14\xaa\xa4\xbf.t\x97t\x04ti\xbe\xd2\xe0\xe6\x12\xc0\xc6\xea\xc8B\x99 \xfd\x96\x8cG\x89\xc2\xd9\x9ar\xd12\n\xfb\xf0c\xa1\xbd\xb9\xbe\xf1\x89VX\xcevg\xcb_p\x15\xd6\xb4\x1dZ\xfe\xa9\xc1\xc4\x88/\xce7\x92\x8aDt\xabN\xb7F|\xd1\xado'|\xe8\xaa\xc8\x9e$W\x83\x8c*\x11\xe7\x1f\x0c\x9d\x97\xba\x97=$\x9d\xff\x9c\xe2\xf1\x1d\xba5\x1d\xf2\x89\xca\xd5\xa7\xf5\xe9@\x9a\xcdIC\xedz\xb6LuU\"\xba\xb5]\x1e7\x13\x91\xee\xc8LD\xbd/4\x13Yl\x11\x9a\xf3\xfc\xb1\xfb\xda\xf556\x9f\xba]\xa4\x1eQ\x99y\xc8U\xb5\xf65\x8b-\xa3*\xd4m\x9d\xc0\x0d\x0e5\x17t\xd2;\x11T\xc6\x16\x03d\xb4\\\xdaB\xd2\x03l\x85\xfe]\xd3\x94\x9f\x0b\xac\x9c\xbf\xd8R\x08\x93\xf5Z\xd5\xf3\xb1\x9e\x7f\xc9\x80\xb4\xdcY_\xb7\xbd/\x12\xddc\xecs\x10b\xba\xa8\x1aV \xe7\xb3\x0f\x97\\\xd9J\xc3\xdd\xad\x1a\x14_\xae\xc8\x0f\xe6Fn::q\x03\x9b\x9e:q\x83`\x1e\x9c\xf6\xa0\xc4\xbb\x89\xdf\xf2\x08\x1b\x17[\xcb\xc4'-\xf4\x88\x94\xe4s\xef\xcdYe\xeaC\xd35\xc8\x88g\xc9B\x16y0\xae\xc9I\xd4U\x0e\x1c\xd9\xc7\xa6l\xcf\xa9\x9bX\x97\xc092esV\xe8\xd8\xa4ti\x87n\xc8\xf9\x1b\xc6a\xca\xdb6bP\xad\x1e+>\xd2a\x03\xd8P\x8aa\xdb\x90\x82\xbb\x1d\\~}\xa1\x00\xa7\\\x80\x96\xbd\x82Rx\x01\xa5\x000}\xcb!\xbbk\\\xac\x11\xb3&C\x1e7\xdf\xdf\xba|\xd6\xc7\x92\xf3`\xa5J\xce8\xfe\xc9\x9d\xe6\x8c\x99\xde\xdb\xdbZ\xb5Z\xbf\xb5\xc7v\xaeFeU\xf5p\xf1\xc14\x9f\xef\xac\xc9\x00\xb6\x1e\x97#\xf9\"\xd8\x16\xc5\xdfY?\xfe\x7f\x8d\xc7_5(\xd6\x90W_SH_\x03\xfc\xc4\x9a\xf2\x13\xab\xef\xab\xef\xa1f\xcf\xafN\x11\xb3\xea\xd1\x157\xebd\xd1\x0b\xe4\xe6\xbc\xf4<\xcdyutmQ\xbc/\x9c?\xe1\x0d\x83=p\x9fX1\xbf\x07'\xad\x97vQ\xb4B\x8b-\x06\xb5\xef\x18\xd9\xd7\x02\xe8\xd1\x0c\xb1\xa2K\x8a\x81\xe1[\xcc.\xec\xed\xdf\xab\x7ft\x1d~\xcd\xdat]5>fO\xee\x1c\xae\xae?F8\x80\x03\xd7\x0dN)\xcf\xafA\xb8\xb1T?-\x00\x7f\xc4\xad\xf6/R\xc2\xfe\xe7\xba~\x9e\x95\x9c\xd3z\xba\xf4^M\x05}w\xe5$\x9b\n\x9a\xcb\xe2\x0fT\x927\xecKs\xbdu\x16\x05.\x97\\gG\x8a\xee\xef\xa9\xc6\xc3S=Av\xb5\xba\xa8\xd33\xad>]\xe4\xde\xc8)@\xf0\xd7\xf9\xf3\xf3\xb2\xb8>\x1f\x8a\x9a\x0fn\xc7\xb3\xbb\xdb\xeb\xeb\xe1\xdd\xb4>\x94\xb4\xe7\x18\xa7\x87G\x96\x82;\x90\x89\xc97\xffI\xfc\xf7D+\x17\xa5\xa0\xa7\x99\xbb\x85\xb7\x12\xf3_\x17\xba\xeaK\x1c55<\xd7\xa0[Z\xff3\x00PK\x07\x08\xf2\x19\x16\x061\x18\x00\x00oq\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/cilium.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec;\xefs\xdb\xb6\x92\xdf\xf5W\xec8\xd7s\xfb\xc6\x94\x924\xed\xe54\xd3\x0f\x8e\xed\xa4\x9ag\xcb\x9eHy\xbd7\x9d\x8e\x1e\x04.%\x9cA\x00\x05@\xd9z=\xff\xef7\x0b\x82\x12)\x89\xb2\xdcsr\xed\xcd\xf1\x8beb\xb1\xbf\xb0\xd8]\xec\x82I\x92t^\xc0H\x17\x96c\x1f\xe6\xde\x1b\xd7\xef\xf5f\xc2\xcf\x8bi\x97\xeb\xbc\xc7\x85\x14\xc5\xea\xcfT\xeaio\xf1\xaa\xfb}\xf7\xbb\x9eP\xce3){\xb7\xc5\x14\xadB\x8f\xae\xf7k!\xf8m\x12\x07\xbaK\x96\xcb\xce\x0b\x18\xcf\x11D\x8a\xca\x0b/\xd0\x01\xb3\x08\xcek\x8b)0\x07g\x1f\xcf\xdd	\xf89Z\x04\xe1@i\xb8]\x84Q\xf8\x1a=O\xbf\x81L[8\x0b\xc4;\xcc\x88\xbf\xa1uB\xab>,^un\x85J\xfbp\xa6U&fW\xcctr\xf4,e\x9e\xf5;\x00\x8a\xe5\xd8\x87\x92\xf7\x84\x07\x90\xf8\xd6\x19F\x92\x12\xd3\x89[:\x8fy\xa7\x9a\x14\x99\\&LJ\xcd\x99\x17Z%\xb9N	\x91M;\x1d\x80\x170\xc8`\xa9\x0b\xb8c\xca\x83\xd7`\x0b\x15\x89\x80P\x90\xe2\xb4\x98\x01\xcd\x00>gj\x86\xe0\xe7\xc2\xc1\x82\xc9\x02	\xda\xdb\x02;P\x82\xf5\xe1(c\xd2\xe1\x11\xe1E\xc5\xa6\x12\x13a\x16o\xfapD`G\x8d\xb7\xdf7\xa0K\x8d\x1a-\x05_\x02\xaaL[\x8e9*\x1f(\xf7!\xc5\x8c\x15\xd2\x9f\x00\x93wl\xe9@[P\xb8@\xbb\xc6XN\xed\xc3\xd1o\xbf\x01W\xa2[J\xd0-_O\xea\x18\x1f\x1e\x8e\xb6\xe5\x8e\x02\xe7Z	\xaf-	\xc6f3\x8b3\xe6\x11\xbce\\\xa8YX5\xc3\xf8-zw\x02\x0e}\xa9	\x89\x0b\x94\x01\x9f\xd7p$\xf5\xdd\xd1	\x1c\xe5\x98\x8a\"?:!F\x8frv/\xf2\"?\xea\x06\x19\xe7b6GK\xd6QN=\x89?\x9d\xab\x90\x97\xc8\xe6\xcc\xc3\x9d\x90\x12\xa6\x08\x0eQ\xd1bT\xec\xe9\xc2\x9b\xc2w;P\xbdI*n\x83\x1d\x95\xd4K!\x89d\x04Z\x89$4a\xf3h\x17L\xc2L/\xd0*\x17\xb8\xf0K#8\x93\xe0E\x8e0E\x7fGt\xe3\xec\x80Mi/2Q\x9a\x11\xe0\x02\x95wA-\xc8\xf8\x1c\xc8\xc2\xee0\x05\xae\x95BNTZ8L*\xe2}\xf8\xce\xedg3\x93l\xe6 E\x8f6\x17\n\xe1n.\xf8\x1c\xc6g7q$\xfc\x7f\x02\x85\xd1\x8a$\x08\xb82a\x9d\x07=uD\x83\xd88\x01\xce\n\xb7&P\x97\xc2\xd1\xb2M\x11f\xa8\xd02\x8fi\x1b\xcf\x81^\x9f\x84,9\xe6>\x99I=e2\xc9\xd9}\x82\xca[\x81.\xf9\x0b8\x83\\d\xe4\x14H\xa3q\xedA\x15\xf9\x14-\xe8\xac\xa6\x9dr\xa1]a\x8c\xb6\x9e<\x07\xb7\xda9\xa2\x00\xa8R\xa3\x85\n\x86f\xa4\xf00]\x82\xb1\xdak\xaee\x1f<7dX\x9a<\x0c\xb1;5Y\xb2f\xc7sC,\xf5\xe1\xe8\xbb\xd7o^\xbf}{\xb4\x05\xc1\xd42B\xbc\xfe\xfe\xf5\xab7o\xe2~\xb8\xb1X\xf3\x12\xc4k\xce\x0cD\xc9\x88-}\xe7\xc0\xa0M\xcaM\x00\x92yT|\x19\x15h1-8\xa6'\xc0|4`\x04\xbc7\xa8\x1c\x12\xaa\xc2$\x99\xd5\xb4\xa11\xd7v	5Bd@\xa4\xab\x8a\x92PQu\xc6\x91t\xc6b\x04\xc6\x84\xe4\xc8\x99q\x1b\xbe\xe3Bqf\\!K\xc6\xc9e\x04\xa4\\\xe7y\xa1*s\xad\xecY\xe9\x14]\x1f\x16\xf7\x92\xa9\x93\xb0\xee\x0b\x84h\xdf\xa9p\xe4\xb5\xd2.\xfc4\xa7-\xe7\x8f\xdd\xea]`*L\x869[\x04\xe7guA\xfe!x\xae\xd4\xc1\xd9\xe0\xfcc\x07\xc0\x17J\xa1\xecC\xd3\x13\x95o\xe1\xe1\xa1\xf3\xdbW \xb2\x1dC?\xfc\x00\xc7\x15\xb1c\xf8\xea\xa1\x03\xc0\n\xaf\x93TX\xe4>!\xd2I\xa0\xe8j\x0eU1/\x16\xe5{\xa1f	\x17\xa9-\x9d \x05\x83	\x97\x85\xf3h'\xf4:8\xbe\xdf\xbe\"\xdb\x12\x19\xa1\x0f\xcb4dyX\x1f\x12\"Bw\xe1Z\xc9%X\x94\xb8\xa0\x98pG\xaa\x98\x16B\xa6\xe4\x05\x19\xe4\xe8\xe64%\x82\x87E\x8a\xbf\x13\x8aF\xd1	G\xd2\xf4f\xeds\xc7u]Qp\xac\x966%\x13\x0fLh\xe5\xad\x96\x12-\xe4L\xb1\x19\xf9JM\xbe\xc6.\x83\xf6;\x00\xc2\xb0\xbc\x0f\xeb\x10]Jr\xc5\xdc\xaf\x05Z\x96\x96+\xe2-\xcb2\xc1A\"[\x10\xdf\x9b\xab\x94\xaf\xc0k\xda\x8cQ>\x11\xc6\xd32\xb8\xc4\x16\xb2\xa6\xee@\xe7'&\xfc\xcaf\xdf\xdd\xbc\x87LH,\xa3\xeeIi1\xb9.TM\"\xa1\x84\x0fb1\xa1B\xc8\xbac\xc2\x97\xa6L\x80\xbb\xa2\xe6\xbdO\x9c\xa6M\x96dL\xca)\xe3\xb75&cd\xad|D2G&\xfd<\xe1s\xe4\xb7B\xcd\xb6!\xefP\xca\xe4V\xe9;\x95\xacs\x965\xd9\x15U\x8b\xb9\xf6X\x1aZ\x957\xac\xb0\x05\xd9\xcf59O @\xda\x01s\xcaz(D\xea,\xe63\xa5\xb1\xd0\x00\x9bQ\xf8v\x9e\xd9\x10\xd1\xb8D\xa6\x92\x98\xbc\x84Iu\x06\x1a\xa3\xa4\x99\x0d\x88\x95\xed\x9c\x0d\x07\xa4\xcaL\xcc\n[\xeev\xe1\xe0\xce\n\xef\xc9F\x97\x81r\xe4$0@\xb8\x0b\xe7u\x9ep%B\xd2\xb4\x03i\x8a\x0b\xc1\x11\n\xb7^\xb30\xb9\xb9\xc5+\x83\xa2\xa4g\x8e\xa5\xf7\x0d\x16I\xe2\x95(V\xe9\xc7Dd\x8cG\xb3\xa7ltw\x9a7BK\x84O9';h\xcd\xf5\xda\x93\xbc\xff9\xeeD\x1b\n{\xc1\xfd\xb5d\x92\xdbD\xec\x94\xf1.+\xfc\\[\xf1\xcf\xb0\n\xdd\xdb\xb7\xae+to\x9d\xc1\x96\xbb\xff\xa3\x96\xd8.W\xb9\xb9:	0#>X]\x18G 	(\xf4w\xda\x92-G\xbc\x1d\x00\x8b.\xe4\xf3M\x90\x90\xde\x89\xb0\x02\x0b\xb4\xd388CZ\xf7\x04\xa4p\xe5\x8f;\xe6\xf9|\x9bN*\x1c\xa7\xecg\xd9Jf\x15\x87\xa5\xe0\xbf\x97\xca\xd1\xd1\x0e\xf6+U\x13\xce\x04(S\x89\x04\x92\x95I\xd5\xa8\x87\xff\xb8\xce\x8dV\x94ry\xe6\x0b\xf7\x9c\xec\x90Gn\xd0~\x1c/\xfd*L\xca<\x1e(\xf1J\xaa\xf0\xabW\n\xd1\x14\xc1\xec^&\xbc\xf7\xa8\\L\x986\xd0\n5\xb3\xe8\xb6\x94\xc1-\x12g{\xf8\xdf\xa2\xc2\x8cX\x13j5\x88\xd2\x99TL\xa4\x98\x91s\x8f\xac=\x89\xfe>\xfd\xc5\xb4`'\xfd0\xb4m\xfd-#k5W\x001,\xdf\x91\x7fo\xc5\xd2\x0e\xb4\x8dp\xc3H\x9b\xef\xb6\xc1\xd7v\x10\x19nZC\xf5z\x1d\xa7v\xbe\\\xe3\xad)\xfd\xf8/\xc7\x9f\xcfY\xad\x1de\x9b\xd7\xda\xdeX\xe1HH)\\\xce<\x9d\xab\xe4\x12R\x94\xe8\x11~\xe6\xda\xe2\x7fQ\x06\xf3K\xaa\\\xd8\x7f\xe0(\xae0\x1fk	\xccR>G\x08\xa6(\x14\x1d\xf4_\xc4|(\xc4\xa8X=\xa8m\xde\xc3\xb6lI\xff\x8f\xe3\nw\xa8\xc8\"K!\xb3:\x87\xdb\xb7\x0e\x98JA\xe4tBZ\xe5\xdf\xc7Aa!\xdb\xa6\xd9\xe5\xfa\x1c\x931\xbc\x00\xaa\xaf\x90&\xe9\x10\x15L\x0b(\xef\x81\xb9\xbe#P[\x9eSU\x19\xbb\x8dN\xa9\xd8\xa1H\xcf\x82N\xc4\xc0 \x15Y\x86\xb6L\x1c\xe8\xb8\x9bb\xb7\xe1\x18\x03\xbf\x06m\xa6m^\xa5\x99\xca\xc5\x83\x87\xce\x80\xc1\xd9\xf0\xa6\\\xc7\x98\xf19\xf8\xc7X\x07%\xfc\x83\xe6\n\xef6\xfcz\xc3\xf9\xd7\x87\x02\xad\x90\xd5\x91\x83 0\xb4\xab\xe3\xe3B\xf8\xe5v(y\xdc\x08\xfe\xdf\xd9\xfcq\x9c\xcd;\xa1\xe8H\xb5'A\xd2\x12?bF\xef\xabU\xdb\xe3\xd0:\x00[$61\xbab\xfa\x9f\xc8\xbd\xebw\x92\x08\xbc\x91(\xfe\xfe\xd4\xf3ye\xaf\xf9\xdb\xe7V\xc2\x1a\xf5\x13\xb5\xb1\x9e\xf8\x84\x8c\xfc\x99\xd5b\x9cIH#\xc94B>\xdb\x8a\x1ed\x10\x07\xa8\xe0\x19\xd7\xcbX\xb1\x10\x12g\x98\x06\xb9\x0b\x87vG\x84g\xc6\xb8u0?g\x98k5\xc2\xdfs\x9e\x02\x90l\x8a2lq\xa0\xf0\x930c*\xd1;T\xd9\xa3\x11\x87\x12\xb9\xd7\x96~\x03\xe4\x94\xb4^\xd6\xa6mO\x84\x98)\x8f<U\x18g\xcbr\xa2_\x1a\xec\xc3G-\xa5P\xb3Oe*M\xb3m\xfdM\x852g\xf7\x9f\x14[0!\xa9>\xd0\x87\xd7Tg\xc2\xdcP\x0d.\xf2Qs\"\x00MIv3\x05PID\x0f\xf9\xcaQC2z\xa6\xe8Yw]i!O\xa6]\x1f\xa4P\xc5}\x04\x9ak\xe7\x87e\x8c\xe8W\xed\x00z\xe8\xfd\xcd\xe0\xbc\x0f\xe1\xb8\x1d_z-\xc9~(c^\xb3\x96@eU}\xb8\xb8\x17.\x1cx\xe8q\x8d\x03\xf2\xb0f\x87\x11\xa0,\x0b\x87\xfd\xff\xc12\x8e7h\x85NG\xc8\xb5J]\x1f^E0c\x85\xb6\xc2/\xcf$s\xaeDS\xaexY\xeb\xe0V\x84\xfc,BSJ\x7fV\x95k\x1alF+\xaa\x17+(\x11\xad\x84\xa3G\xe4l\x86e\xedO[\x9cX4zb\xb5\xf6\xf0\xf0@\xa5\x81\xc2Rr1\xc9\x99\x12\x19:\xff\xf3q\x8a\x06U\x8a\x8a\xe2\xdd\xf1/?\x1fs%\xc2\x9f\x80\x9d~9\xcb\x8f\x7f\xa1\xb2\xe1\x06\x8d\x9bB\xca\x9b\xd8\xf4\x18dC\xedo,\xba2q\xa9\x1e\xaa\x7f2\x95\xae%\xa0'\x81\x1e	\x98\xc4\xf4\x04m\xd7\xcdk\x00\xa8\x16\x9b\xf0\xa5\xd4g\x83\xcb\xc1\xa7\xab\xc9\xe9\xe5\xe5d4>\x1d_4\x80\xa0l\x08\xbd\xb7:o\xce\xa6\x87W\xdd\xac\xbf\xe22F\xb3\xcd\xe7\x16\x97\x8f\xe8\xb5zv7\xc16\x1fm\xc8$\x98l\x18\xe4Ny\xde\xdd\xbc\xff\"\xf2\xac\x8aZ_@\xa6\x9fN\x07\xe3 \xd8\xd5\xf5\xa7\xe1\xf8\xf9\x17\xaaY\xbf\xdc\xc0\xff\x0c\x8b\xe4\x90\x17a\xbbj\xe5\xf1\xde7-\x8a3\xc3\xa6B\x86\xbe\xeb\xa6\xad\xb1t\xc3\xd8\xe9I`x1\x9e\x9c\x9e_\x0d\x86\x8d\xb1up\xd9b`\xa1e\x91\xe3\x159\x1d\xb7{7T\x8d\x88\xc6 \xb5\xe3\n\xe5o\x98\x9f\xf7\xa1\xe7\x96\xae\x97\xb9\xde\xd4d;1D;\xb7\x85\xda\x83c\xc1l\xcf\x16*v\xaa#\xe0j\xe7\xd6x\xdb\xc0Z\xd5>\xffD^\xa9\x85sfg[K\x90\xc4\xee7uF~\xe8\xf9\xdcT\x9d\xfc\xf8:g\xe6\x10\x8f\xf6\xd7\xb7\xa3\xc9\xf0\xfa\xfcb2<\xbdz\xc2\xf6\xcf\x04\xca\xb4\xc5\x8fm\x94y\xebC\xb5\xc9\xa5\x81P\xf0\xedR\xfc\xa1\x80\xd4\x00\xdd\xd8\xce\x81\xcf\xd3\xab\x8b\xd1\xcd\xe9\xd9\xff\x02\x9fUb\xd1]%M\xfb\x98}\x7fy:\x1c^\\N\xaeNG\xe3\x8b\x8f\x93\xf3\x8b\xbf\x0d\x9e\xc2\xf4\xa1\xb1\"\x93\x8c\x9alI\xce(\xc9O\xca\xf2\xfb\xf3{\xa2V\xf1>\x0d\x07\xc3\xd1\xf8\xf4\xf2rr=\x9c\\\xfc\xc7\xe03x\xd9J\xc4BU])\xad\x12\xbc\x17\x9f\xc1\xe1n\x89yv\xf9\x89\x96\xef\xeab\xf4\xe3\xe4\xecz\xf8~\xf0a\x83h\x88\"\xd1AI1]m\xc0\xf2$C\x1d\xc2\xde^\xfc\xc3\xc1\xe4\xec\xc7\xd3\xc1p0\xfc0\xb9\xba>\xff\x0c\x16\x12\xfa=s&\xa8\xbc\x13n\xbc|	\xb5}\x1a\x8d\xaf\xa3t\xd7\xc3\xf7\xcfo\xf6\x1b\xbd\xac\xe7\x0f\xbdRd\xc8\x97\\\xae\x0e\x1e1\x81\xd6\xce\x8f\xa8&\xd9|\x0d\x80\xf7\xc87\xdf\xb5\xb8\xf8*P\xf5he\xa2I7sO\x00cq\xe4\xb5y.*\x85\xdaIG\x8a\x05*t\xee\xc6\xea\xe9\x86\xa0\xbb\xe4i\xa5S\x95\x95v\x0c\xacJF\xcd\x87b\xd7\xd4\nl\xae\\\xc6\x84,,\x8e\xe7\x16\xdd\\\xcb\xb4\x0f\xaf^6\x00\xe2}3j50I5\\\xb6\\u\xa0+a\xe8~\xc84\xdc7\xa3\xdb5\xaa\xdc\xe9r	\x92Y\xba\xb4\xa57\x10\xb2\x85\x16)0E\x15\xc7p\xf9\xe8\x96\xee\x1a\xfd+U}i\x9d!\x18\x01\xddS\x88\xb71\xc2e\x9f\xb2\xb0Y\xf5\xb4\xc5\xea\xc4T!\x9dj\xed\x9d\xb7\xcc\x18\xaa\xa9zv\x8b\x0e\xa4V\xa1\x87?'Z\xf7\x06y\xbcc\xb3~\xa2\\\xe7(\xd9r}t{\xddT\x81i\x9e\xec\xbem\x8e\xba\x82st\xae\xae\xc1\xc6\xb8\x179\xea\xc2\xaf\xa6\x7fW\x1b\xa5z\xb3\xf8c\x9b\xc3\xb7\x8f\xeb\xab.\xd1\xe7\xd5V\xad\xff\xb6~,\xfeZ\xa0\xdb\xcc\x96\x01\xb8)\xca\xc3p\xd8(\x93\x90\x97N*\xe0	7E\xf3dKOyAh\xcf\xacx\x83h}\x93\xa6\x8e[\x8a\\D\xcc\xda\xee\x1c\x8a\xd3\xbfj\xd2-\xe7\xf5\x1fA\xf9\xd5\xc3!\xf2\xd5&<<4\xae\xdc\xb4c\xdf\xcdU\xbb6\x9a\xd3\xb6\xc9\xacn\xf9|\xe9c\x15=	\x8c\xfe>\xa2\xb8\xfe\xe9\xf2\xe2\xff\xc6\x99k\x03\x8f\x12\x89a~\xbe\x87\x13\xaa~\xf5\xb4\xf1\x14\xedzS\xa1vf[\xe8y\x88\xe5\n}\xfa\x18.\xf4<\xe0R\xe8\xbb\xe9\xce\xdc\xad\x96|%\x0e\xb9\xc5U\x1dm\xd7\xf1\xb4=s\xdb\x98D\xfe\x91.\x84m\xadX\x8dr=\xddxL3\x8f\x9d\xd7\x1e\xa1\xf9\x02\x86\x88)\xa6\xf1\xda!\x95C\xe9\xa7\xd4,\x85[*SJ\xba\x00H=\xda\x9d\xacJ1Mv\x8d7\xed\x88R\xda\xddP\x07h\xe3>\xde\x1d\x93\x9a\xdf\xee\xa1A\xe7\xfa\x08\xda\xad\x81\x96\xb5\x87\xda\x1e|\x01c\x0d\xb7\x88&\xde\xb5\xaa\xee1\xc6H\xed\xa0\x07\x85\x99\xd1\xbd7\xd7iY\x98f\x91\x81\xcc)\xec\x97\x158=fO\xc5!\x16[C\xc1\xfa<\xdcF\xd4vym\xcf\xaa[\x16Of4d/S\x13n\x98\xba\xce\x01\x1b}/\xcf-\x95\x96\x83\xf8\x8d\x89h\xf4\xc9tj\x00#\x8b\x99PU\xe2C\x94;\x078\x80\xbd\x1c\xb69\x82\xdf\xcb\xe2\xc6\x15\xb8=\x9c\xb6\xb8\x98\xbd\xdc\xb6\xbb\x9a\x83\xf8=pW\xee\xdf\x91{\x19\xdc\xb5=\x83\xf5\xb1\x90\x06Bu}\x93\xf4\x14\x8b[r	w\xc2\xcf\xe3\xb5=c5\x01\xa2\x83\xaf\xb1;\xeb\x96M c\xf5\xfd\xf2\x9b-\xfeZ\xb6\xf3^\x06[\xf6v]\x89\xef\x85\xc4\xdd[\x88\\L\xfd>.\x9d\xa6\x9b+\xbe\xc5\xe3~\xd7_\xbei2\x1a\xbf\xa8\xb8\n\x9f\x82\xbc\xd9H\xb6\xf7\x94\xcfK\\\xf5VLR#\xde&H\xc3\\\xc3\xdd\x8ex\xd57\x133\xa8\x97\xeb\x0e\x8a(\xab\"QS\xa4\x1d3\x1f\xef\x16\xa2\x91zI_\x9a\xb4\xb6\x0b\x0fhy6\xfb\x86B\xc7\xafOz\xa1	X\x9b\xde\x86\xb8\xea\xc3Y4Rp\x16;W\x8f\xf6\x19\xf7\x11j\x97\xc15\xba\x90m\xdd\xc6Qag\xb8>\x18l\xf6\x1f_\xedia>\xad/\xb9_\x88v1\x9a\xdd\xcb\xb6&dK\xbf1T\x88\xceb\xdb\xef4M\xb5r\x94\xe2\xac\x88B[G\x92B\x00f\x19r\xdf\x87\xa1\x1e\xf19\x92\x17z|^{'\xb3)\xd3\xbe^e\xdcg\x9b\xed\xca\x98\x07T\xbd\xc0\xd3\xf0\x85\xd4\xe1\xad\x82\x0d\xf2\xcf\xde\xc3\\\x11\xf8<\xcd\xccv)vw\x0e\xc2\x87j?\xfc\xcb\xd7\xb1bw~\xf1\xee\xd3\x87o\xb6\xa0\xda\xbe\x9a[O\x1c\x9c_\x0c\xc7\x83\xf1\xdf\xa9!z}v:\x1e\\\x0f\xe9\xc0s\xf1\xcd!\x9d\x87?Ua\xff\xcf\xd2&	Ky\xb8\x16\x0f\xed4\x04\x83\xd9@\xfb\x0c=\xce\xb6\x92\xfb\x13\xb5|\xa8\x14\x95\xefP\x9bJl\x0f\x9d\xd5\xd8AiA\x8b0\x83\xf3\xcf'\x8aH\xbf\x80 \x83\x9b\xd3\xab\xe7_\x0d\xfa\x80\xe9\x0b\xac\xc2\xf9`t\xfa\xee\xf2br1<\xbf\xb9\x1e\x0c\xc7\x93\xb3\x8f\x9fa=\xe2'k\xeb\xef\x91\xe8\xc3\xe25L\xf5<\xb7pm\x0e\xf8\xf9\xf7N[0x~!\xf74&\xe8+\xf6\x0f\x9bI|\x99\xf7\xf4\xe1\xd5\xeb\x7f\xeb\xbe\xec\xbe\xec6\xcb\xb8\xab\xd3H\xf9\x81\xd8?7\x07\xb5\xf5}\xf8\xf7\xd7\xdf\xbe\xd9\x18p|\x8e$\xc6\x8f\xe3\xf1\xcd\xe3\x85\xfa\xef_\xee\xab<\xbfz\xb9\xb7\x0e\xff\xed\xb3U\x96\xab,\xe3\xc9\xc5\xe5\xed\x89-\xf5\xe5\x15\xe0\xce\x12\xf3\xe6\xe8\x13\xaa\xcc\xbb\x10?Vh\xde5g_\xad\xf90\xf6\xf6\xea\xe7\xe0\x8a\xf3\x7f\x0f\x00PK\x07\x08\xcf\x16-\xa6:\x0f\x00\x00\x7fA\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/control-plane-services.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xac\x94K\x8f\xd30\x14\x85\xf7\xfe\x15G\xed\x82U\x80\x94\"\xd1\xec\xd0\xac\xd8\xa0J \xf6\xaes;\xb5\xc6\xf1\xb5\xec\x9b\x81\xfc{\xe4<\x98i\xc9\xa8\xf3\xc8\xb2\xd7\xf7q\xce\xf9\xda\xae!'\x9b`\xb4\x7f'8\x10L$-T\xc3z\xc8\x89\x90D\x8b5\x08\\\xa3\xd1\xde\x1e)	\xb4\xaf\xf3[\x07\x1d	\x9e\x05\x9e\xa8\xa6\x1a\x87.\x97a\xd8Kd\x87\xe0\xb4'\xb5Fb\xfc\x9e\xf6\xf6\x0d\x89\xe2\xbd54\x9d0\x1c	\x91\x1d!Yo(7\xb3w\xdd\xa3\x89\x06\xc2Y[2Q\x87\xe1P\x88\xdc\x90\x9c\xa8Mj\x0d\xe3\xda$\x14\xbf\xedS/)\x91\xe4\x81\xef\xec	\x072\xbaM\xfd\x96\x0e5\xfbA\xec\xb8/D\xfec\xa9V\xea\xce\xfa\xba\xc2\x8fA\x97\xd2\xc1\xfe\xa2\x98,\xfb\n\xf7\xa5jHt\xadEW\n\xf0\xba\xa1\n$\xa6\x1e?\xa4\xa0\x0dU\xb8k\x0fT\xa4.	5\np\xfa@.\xe5~\xc0p\x13\xd8\x93\x97\x7fc\x80X\x8a\xd5\x94S1\xe4\x04h\xef9\xa7\xcd~\x1c}\xf0\xf8\xde\xf2\x87\xc1|\x85\x95\xc4\x96V3\x0d\x81\xa3TXm>})W*\x052yK\"GF8\xbeTL^\xd6\xcb(F\xcf\x0dI\xb4&Mw\x85\x0d\xbb\n?o\xf6C\xa5\xbf\x9dO+@\xba@\x15n&(\n\x0f\x80\xaa\x9eJ\xaf+\xe5\x80\xbf\x1e\x8f\xd6[\xe9\xc6\xba*\x8a\xe22\xfes6\x970f\xf3\xcf\x0fcm\xf4\xe5(\x16\x8d\xf6\xfa\x96\xe2\xd3|\x9e\x1ex%\xb2\x91H\xf9q\xf3y\xb3\xba\x8e\xf4*\xb3\x97\x0b|\x0e\x8a\xffP\x9fDBq\xc6\xbb\xf7\xd1\xdb\xe8]\x88\x8e\xb7$\xfb\x8b\xea\xf9\xb7by\x94\xc9\x9c\xa8n\xddU\x82\x8f\xfb\xde\x0e\xae\\\x0c\xdcst-\xcd\xab\x9c\xe5U\xce\xfc\x8a\x97\xe7\x95\xff]\xbb+\xac\xa6\x9e7s\xda\xee\x16\xe3tM\xd3\xc2\x8c\xb6\xbb9F\xdb\xdd\x0c\xa3\xbf\x03\x00PK\x07\x08\xf1R\xb0\xb0\xe0\x01\x00\x00\xac\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00S\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns-autoscaler.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xccW[o\x1b\xbb\x11~\xdf_1X!\xf09\x80v\x15\x1b\xa8Ql\xe1\x07\x1d\xc7N\x85\xa4V 9M\x83\xc00(r\xb4b\xcd%\xd9!)Y5\xfc\xdf\x0b\xeeE\x17[\xbe\xb4O\xe5\x8b\x8d\xe5\xcc7\xdf|3\x1cR=87vM\xb2\\x8\xf9x|\n\xd7\x0b\x84/a\x86\xa4\xd1\xa3\x83a\xf0\x0bC.OzI\x0f\xbeJ\x8e\xda\xa1\x80\xa0\x05\x12\xf8\x05\xc2\xd02\xbe\xc0n\xa7\x0f\x7fGr\xd2h8\xc9?\xc2o\xd1 m\xb7\xd2\xdf\xff\x92\xf4`m\x02Tl\x0d\xdax\x08\x0e\xc1/\xa4\x83\xb9T\x08x\xcf\xd1z\x90\x1a\xb8\xa9\xac\x92Ls\x84\x95\xf4\x8b:L\x0b\x92'=\xf8\xd9B\x98\x99gR\x03\x03n\xec\x1a\xcc|\xd7\x0e\x98\xaf	\xc7\xb5\xf0\xde\x16\x83\xc1j\xb5\xcaYM67T\x0eTc\xe8\x06_G\xe7\x17W\xd3\x8b\xec$\xffX\xbb|\xd7\n\x9d\x03\xc2\x7f\x05I(`\xb6\x06f\xad\x92\x9c\xcd\x14\x82b+0\x04\xac$D\x01\xdeD\xbe+\x92^\xea\xb2\x0f\xce\xcc\xfd\x8a\x11&=\x10\xd2y\x92\xb3\xe0\xf7\xc4\xea\xd8I\xb7g`40\x0d\xe9p\n\xa3i\n\x7f\x0c\xa7\xa3i?\xe9\xc1\x8f\xd1\xf5_\xc7\xdf\xaf\xe1\xc7p2\x19^]\x8f.\xa60\x9e\xc0\xf9\xf8\xea\xd3\xe8z4\xbe\x9a\xc2\xf8\x12\x86W?\xe1\xcb\xe8\xeaS\x1fP\xfa\x05\x12\xe0\xbd\xa5\xc8\xdf\x10\xc8(#\x8a\xa8\xd9\x14q\x8f\xc0\xdc4\xd5s\x16\xb9\x9cK\x0e\x8a\xe92\xb0\x12\xa14K$-u	\x16\xa9\x92.\x16\xd3\x01\xd3\"\xe9\x81\x92\x95\xf4\xcc\xd7_\xb6\x1d\xd0b\xe6Ir'\xb5(`\x8a\xb4\x94\x1c\x87\x9c\x9b\xa0}\xc2\xacl{\xa2\x80\xe5qR\xa1g\x82yV$\x00\x9aUX\x007\x84B\xbb\x8c\x05o\x1cg\n\xa9\xddr\x96q,\xe0.\xcc0sk\xe7\xb1J\x00\x14\x9b\xa1r\xd1\x1b\x80	at\xc54+\x91\xf2\xbbM\xcf\xe6\xd2\x0c*#\xb0\x80	r\xa3\xb9T\x98dY\xd6\xd2;W\xc1y\xa4\x89Q\xb8\xc7\x8df\x8c\xe7\xac\xeev\xf9\xef:\xc9\xfc\xee\xcf5\xd6A\xd6\x0d\xa1\xe2 \xf9\xff\x91#\x05\x85uf\x190+?\x93	\xd6\x15\xf0+Mo\xeal	\x9d	\xc4\xb1\xfe\xa6\x8d@\xd7n,\x91f\xf5G%\x9dO\xfb\x90\xae\x98\xe7\x8b\xf4\xe6}H\x84us\xc7\x84\xb9\xd1\x9e\x8cRHnP'\xf34@\x895~\xb0\x82y<\x14\x00\xef=\xeaXkw\x88\xb4@\xab\xcc\xbaB\xed;\xfc>t\xf1\x1d\xfaw\x06\xed\xc1\x04+\xb3l\x1a\x9a\x1b=\x97e\xc5\xac\x83(\x1f\x9885f\xa8\xcc\n\xa4s\xa1>jsy\x8f\xa2Hz\xb0m\x91Lj\x1ef\xcc\x1b\x1a\xf0\xa6\x1f2K\xc6\x1a\x8a20\xb5\xd3\x8b\xbd\xe3\xd3\xf7Ud\xcb\xe4\x05\xd58a\x93\xc0\xc1^\xfcCj!u\xf9\x7f\xd6\x92.\xcc\xfe\x89\xdc\xb7]y\xf0|\x03\xbcz\x90_<\xcad\x14Np\x1e\x91\x9f\x1f\xccn6\xbcv\xca\xba\x8a\xbc\xa2SRk\xbd+)\xb3\xd6\xc5\x03\xdd\x84\xfc\xb4i\xc8\x03r\x1eL\xe7\x85d\x9e\x08l\xed\x0b\xee\xb0\xd3\x83Q\xef\xae\xf9\\33\x0bH=\x05L\xff\xeb2Y\xe4\x91\xb8C\x85\xdc\x1b\x8a\xff\x03Tq\x0c|\xdd)\xfck\xcc<VV1\x8f\xad\xeb\x8e\x1a\x00\xfb\xd9\xbd\x8e\x13\x17\xd3\xda\xb4\xf7\xc4\xd6\xc5\xf1\x05\x8a\xa0\x90r\xa6\xec\x82=\xc9\x88\xc7;\x943\x95Y#\n8:\xda\xba!\x8f\xef\x81\xdc!\x0f$\xfd\xfa\xa0w\xe3$\x0c\xbfC\x1a\x08\x9c\xb3\xa0|\x03\xd1)\x13\x97\xdb\xbb\x98\xae^.r\\\x96\xa4\x89\xf1\xce\x15s\xae\xb1m\x9a1\xebj\xd6Qn\x1d\xbcQH\xfbIgp\x87\xeb\x02\xd2\xf3\xd6r\x18+\xea\xc6Z\xad\xd3\xd6	\xc0\xd8\xe8e\xa8\x80\xf4\xe2^:\xef\xba\xad8\x8c\x99\xd4H;p\xb1\xfb\nx\xa67\x80\xacX\x89\x05<<\xd4\xc5\xbd%\xb4\xe6\x96\x8c\xf1\xf0\xf8\x18\xbf\x05\"\xd4\xfe\xb6bZ\xce\xd1\xf9_G\x02-j\x81\x9aKtG7\xbf\x8e\xa2W\xfd\xf7\xf5a\x18M\x1c\xf1\xa3\x1bx|lI\x01\xa0^v\x0c\xb7\x1c?\x8f\xff6\xfc\xc7\xb7\xc9\xf8|\xba\xd9\x02X2\x15\xf0\x92L\xb5\xb5\xdf\xbd\xd8.%*\xd1\x8e\x84\xdd\xd5]|E\xf3\x00q9\xb7!y\xba\xb9Q)\xae\xf8xC\xe7\xf7\xbe\x01p\x1b6\x12	\xedn\xb7y\xddv\x0e\xb7\xdc\x86\xdd\xdc\xe2\xaa\xb02\xb4~\xd3\xb31\x8b\xce\x0f\x1f@\xce\x0fEi\xe8\xd71\x0c\xbdb\xd0B}\xd8\xe5\xd1\xf8\x16\xef\x02\xff\xf0\xf8\xde\xbcw\x9c\x1a\xe2\xa8\x85\x9cG\x80\xb7\xe2\x1c\xe2\xf8\x96V\xfb\xae\xcf\x03n\x82wp\xdcT\x15\xd3b\xb7\x8c\x19\xbcuc\xef\x19g\xd9fb\x9f\xed\x0f\xecne\x90e\x9b\xbb\xfb\xec\xe0l\xecV\x0f\xa6\x0b\x13\x94\x80;D\x0b\x9eQ\x89\xf5\x0f\x16\xb7\xd6\xbc\xf9\xa9\xd2R\x1b\xd4\xaf>7\x10\xda\x0d\xea\xa8B\xbb|\xcd*\x95\xcf\x98\xc3'\xc1\x1b\x9c\xb3\xed\xe3h\xd0\x92\xd8\xb1\xeb\xc1\x8f\x05\xea\x0e>>j\x82\x8bot\x15}\xa1~\x0c\xfeV\x13\xa8\x0ca-\xbd\xfb\xbd\x0fi\xfd\xcf7\xa4I\xf3\xc6K\xc15\xfc\x85\xa9\xa4f\x1e\xf3\xbd\x10\xa3y\x8b\xea*\xa6T\x83\xda\x87\xe6\xa9\xf9N\x90(f;\x7f3\xcb\x88U\xee\xec!UR#\xa3\xb4xH+\xa9\xd3\xe2\xb8\xff\x8cWq\xf2\xa7\xd3\xfe\xb3@\xc5\xf1i?\xb5\x84K\xd4~*u\xa9\xf0\x9b\x91\xda_2\xa9\x02aZ\xc4\xabr\xef\xac\xc6\xe8\xca\x94\xde8/\x90\xe8,\x1a<\xd9^\x9e\x9d$\xff\x19\x00PK\x07\x08\xb4\xf0\xa3\xd5w\x05\x00\x00\xf3\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00H\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xacW_o\x1b\xb1\x0d\x7f\xf7\xa7 \x0e(\xf2\xb2s\x9a\x05)R\xbd\xa5N\xda\x05XS#I\x8b\x01Ea\xc8\x12\xcf\xd6\xa2\x13UI\xe7\xc6\xcb\xfc\xdd\x07\xdd\xff\xb3/M\xbaM/\xb6D\xf1'R\"\x7f\xe4q\xab\xbe\xa1\xf3\x8a\x0c\x83\xcd\xc9\xe4A\x19\xc9\xe0\x0e\xddF	\xbc\x10\x82\n\x13&9\x06.y\xe0l\x02`x\x8e\x0c\x049\x94\xc6\xd7so\xb9@\x06\x0f\xc5\x12S\xbf\xf5\x01\xf3I\x9a\xa6\x93>\xb4[r1\xe5EX\x93S\xff\xe2A\x91\x99>\x9c\xfb\xa9\xa2\xe3\xf6\xd0\x99.|@wK\x1a\x07'j\xbeD\xed\xe3?(\x8fp\x06\x03\x96\xaaK\xa2\xe0\x83\xe3\xd6*\xb3\xaa\xceH%f\xbc\xd0\xa11\x8dAe\x10k,v\x85F\xcf&)p\xab>9*l\x89\x9cB\x92L\x00\x1cz*\x9c\xc0z\x0d\x8d\xb4\xa4L	\x96\x82\xaf.\xa5\x9aX\x92\xd5\x9f\xd6\xff8\xdd\xa0[\xd6\xbaZ\xf9Pn\xf8\xc5\x83X\xbf\xee<Cr\x1ff\x85\xe1\x7f\xbc\xcb\x0f\xcaHeV\x83+\xe5\xc6P(5\xeb{\x1d\x83\x1c\\5/\x02\x15V\xf2\x80\x0c\x92\xe0\nL\xfe\xff/C\x1ao1\x8bp\xcd\xdb\xb0Q\xc3\xca\xb8\x99\x00\x1c\x86\xcd3o\xee\x8b\xe5?Q\x84\xf2\xd9G\x03\xbc\xd1\xfb\xe3\xb0\xee\x82\x97L\xa6V\x9f\xb9\x1d\xdc\xf3+Q\x9b\xed3r\x98)\x8d\x0c\xfe]F\xfb\x94\x9d\x9d\xc2S\xf97\x0et\x8e\x9co\xa7k\xe4:\xac\xdb\xa9C.\xb7\xed\xac{=\x10U^M5	\xaeA\x99\x94K\xe9\xa6\xdcY\x0e\xca\xbe\xab\xfet\x87@\x19\xda\xa0\x8cGQ8\xec\xadg\\\xeb\xb0vT\xac\xd6\xe3(\xed\xde]\xfb\xcf:\xca1\xac\xb1\xf0\xc0\xde\x9f\x9c\x9d\xb6\x82\x8c\xdc/\xee$L\xe1\x18\x838\x8e\x89\xa77SA&k\xb7\x08.\xd6\x08\xa7o\xdb\x05Md\xdb\x89CM\\\xf6d\\.\xb9\xe6FT&\xef\x0e\x9e\x8a[\xeb;\xb2\xb9D\xabi\x9b\xe3\x7f\xc7n\xc3\xd0\xe7\xd6\xd6\xe4WQ\xe2>MU\xa0I|\xdd\xcb\x9b\xbbd\xe2-\x8a\x18\x1d\x0e\xadV\x82{\x06\x7f\x9d\x00\xc4t	\xb8\xdaF	@\xd8ZdpKZ+\xb3\xfaZ&^\xb9\xee\xfa+\xacv?\xe7\x8f_\x0d\xdfp\xa5\xf92F\xcfI\x84C\x8d\"\x90\xab\xf6\xe4\x91\x84\xfe\xde#\xd2C\xab\x03\xe6V\xb7\xa0\xfd(\x06\x18\xfa;\xa6\x0d\xd0x\x15\x07\xcf2eT\xa8}\x89#R\xdb\xc5\xc1jd\xc0\x9f\x85r(/\x0b\xa7\xcc\xeaN\xacQ\x16\xd1\xe5\xeb\x95\xa1v\xf9\xea\x11E\x11s\xbf\xc3k0\xefj7\xef\xd1\xe55\x135#\xad\xbc\xbez\xb4\x0e\xbd\xef\xb8\xae\x1b)<\xe0\x96\x958id\x9f=\xce\xcby$\x96=\x1d\x00\xb2\xe8x\xbcY\xb8zT>4	i\x9d\"\xa7\xc2v\xa6\xb9\xf77\xbd\xd2\x93\xd6	\x98\n\xa7\x82\x12\\\xd7\n~Pdo\xf6\xa8\"\x8e@:\x1e54\xbd6:\x99\xd5h\x17R\x92\xf1_\x8c\xde&\x931#\x93\xca\xcaN\x98\x02f\x19\x8a\xc0\xe0\x86\xea\x0b\xc7\xc9\x8b\xee	2\x81+\x83\xae\xbd\xe5\xf4 [\xaa\xa1r\xbeB\x06OO\xa5d\xe1\xd0\xd2\xc2\x11\x05\xd8\xed\xe2Z\xe1\x1c\x9a\xb0\xc8\xb9Q\x19\xfa\xf0\xfdH\xa2E#\xd1\x08\x85\xfe\xe8\xc7\xf7\xa3\xa8\xd5\xfcJS.y'\x8e~\xc0\xae\xe3\x95\xf2\x8cy\xa1\xf5\x9c\xb4\x12[\x06\xd7\xd9\x0d\x85\xb9C\x1f3\xba\xd9\x85f\xd3\x18\xdb\x94j\x06\x9f\xbe|\xbe\xf8\xc7\xfc\xf6\xcb\xec\xae\x15\x01l\xb8.\xf0\xa3\xa3\xbc\xdb\x0f\xd0\xd5\xe7\x8f\n\xb5\xack\xd3\x98\x9c\x81V\xb9\n~*l1\xd9W\x1e\x84\xa5\xc3\x9f\x05\xfa0X\x03\x10\xb6h/L\x1a\xbfhv-\x84-\xfan\xc7\x91cNn;\xbe\xbd\x92E\x8d\xa77\xa0\xb2\x86\xc8\x16\x95u%\x1a\xb9\xfd\xd5Z\xe9M\xff\x98J\xc4\x9e\x87y\xb3\xfb\xad\x03\xbd\x03+c\xd0H\x95E\xadQ\xc41\x13F=\x1d\xee?\x84n\x8fi0\xb8[y\x06\xdf!IcmI\xfe\x02IYo\xea+8n*n\x02?Z\x95\x0d\xe9\"\xc7\xcf1)\xfda\xf8D\x18\xb5J\xabM\xad\x14 \x8f\xfb\xe7<\xac\x19\xf4O\xe8\xed\x88\x05:\xa6)\x83\xd8?\xb5\x02KnxN\x9bisr\x81A\xafh6\x0dE?\xdb\xca\x1a\x1bH\x90f\xf0\xf5r\xde\xee}%N\x1a\x84\x1d\xc5\xba\x9f\xfd\x06\xeb\xfd\xc9\x88U9\x06\xa7\x84\x7f\x11\xadl,\"Q\x92	\xf8\x18:\xd7\x01\xb8\xd6\xf4k\xee\xd4Fi\\\xe1\x95\x17\\\x97]*\x8b\xcd\x87\xef_\xb7\xe0\x96/\x95VA\x0d\xf3\x0b\x80K9\\H\xe1\xe6\xea~\xf1\xe1\xfa\xe6rqwu\xfb\xedzv5\x10KGv_\x81\xeb\x86\xa4\x9b\xce*>\xdc-Q\xf8\xa84\xd6\xdd\xe5\xf0\x19\xb5\xda\xa0A\xef\xe7\x8e\x96ma\x8ec\x1d\x82\xfd\x84\x037\x01l\x15({-\\\x13\x0e\x0c\xce\xdf\x9ew}O\x1c^\xac1^\xf2\xdf\xee\xef\xbb\x9b\x04\x88UVq}\x89\x9ao\xefP\x90\x91\x9e\xc1\xbb\xbejP9R\x11Z\xe1YO\xe6\x0b!\xd0\xfb\xfb\xb5C\xbf&-\xab\xce\xa1\x19\x19W\xbap\xd8\x93v\xba1\x96\xd5\x1f\xbb;lQ{\xde\x9e\x9c\x9f\xbc\xe4\xad4\xbe\xe1\xfa\xcb\xea\x13\xaf\xd6\xa82\xd1\x1f\x12\xfds\x99*\x9aV}\xf8$\xe3\xc5,\x0e\x15\xf0\xb0\xb7(;\x87\x86>\x06@\xcd\xeb\xb6\xc2\xfdNt\xff3{\xa4\x03\xedu\x93\xed\x07\xe6~\x0bz\xf0\x11\xd75\xdb\xb1\xeb\x8c\xbc\xc2 y\x7frv\x9a\x8c\x88\xbdp\xdc>\xf71\xf7RG\xdbt4u\x0b\xd3Cym\xef;lO\xf7\xcf\xab\xf1\xaf\xe7\x0c\x92\xa7\xa7\x12rQ\xaf-\xa4\xf1\xb0\xdb%\x93\x1es\xa6{\xbch\xfb\xc4\xd9\x91PE\x8f\xe9\x08\xf9=\xa3p?\xeb+\xf4\xf9\xcd\x0eip@t\xff\x19\x00PK\x07\x08+\x88?\x85\xb2\x05\x00\x00R\x11\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00P\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/default-backend.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xb4V\xddk\x1bG\x10\x7f\xd7_1H\x18?\x9dl\xa5i1\xf7\xe6:\xc4\x0d\xa4\x89\xb0M)\x84 V{#\xdd\xd6{\xbb\xdb\xd9\xb9\x03\xc5\xe8\x7f/{_\xba\x0fK\xb6)\xd9\x97\x83\x9d\xdf|\xff\xe6f\x85S\x7f!yeM\x0c\xc5b\xf2\xa8L\x12\xc3g\xe5y\xa2\x183\x1fO\x00\"\xe8\x82\x84s\xfe\xa2XL\x00\x00*\xf4\x07t\xda\xee24\\^f\xc8\"\x11,\x82j8Fd\x18C\x82\x1b\x91k\x8eRf\x17\xad\x85|D\x93D\xc5b~\xd9Ay'$\xc6\xf0\x98\xaf1\xf2;\xcf\x98\xd5B-\xd6\xa8}c\x10B\x0c\xcf[l\x11E\x93\xd3\xf4\xe9	dN\x84\x86W\x990j\x83\x9e\xbf\x9d'\xe8\xd0$h\xa4B\x7f\xfe\xfd\xdb\xb9\xb4\x84\xe1\xdb\xd8\xac\x03\x0cW\xb5\xa5\xf3\xef\xb0\xdfO\xa1u\x10\x82$\x83\x8c~\xae\xec\x85\xd4\xb9g\xa4\xc8#\x15*\xe40e\xcaq\xda\xa2\xc9j\x8c\xa1\xb6Z\xdez\x87\xb2I\x88\xd0i%\x85\x8faQ\xdfx\xd4(\xd9\xd2!\xe5L\xb0L?\x0f\xea\xf0r%\x183\xa7\x05c\xc7\xd0\xa0=\xcf\xd5\xf75\x96\xab\xf3\x93\xea\xdc\xfa\xe8\x96)\x9c\xba\xc0\xd7R\xda\xdc\xf0\x97\xe3\xd4\xf2\xa2\xa3\xe5HYR\xbc\xbb\xd1\xc2\xfbJ\xa9C\xb2(U\xdb\xb4\x83f\xa4L\x19\xc1\xca\x9a[\x12\x12\x97H\xca&\xf7(\xadI|\x0c\xbf5\x94\x0dGZ\xc3B\x19\xa4\x0e;\xc3\xc4\x1c'}\x07\x06\x80\xa6\xe8\xea\x1d4o\xbf\xfey\xfd\xf7\xf2\xee\xeb\xcd}O\x0cP\x08\x9d\xe3G\xb2Y_/\x1cBos\x92\xf8Q\xa1N\xeep3F\x1c01h\x95)\xf6s\xe9\xf2\x1el\x06\xd7f\x07*\x13[\x04\xe5\xc1\x85Zx/\xd6\x1aAx\xd0\xd6lA\xf4r\x05\x98\xc1b\x0e\x9f\xb8\xec\x0dz\x10\xf0\xfe\xf2=\xb8`@0\\\x0c\xa0\xef\xba\xd0w\x97\x97`\x0d\x08\xb8HQhN\x7f\x00\x9a\xc4Ye\xb8\xa7UF\x13C\x98dK\xb8\"tvE\xd62\xec\xf7\xffw\xba=\xc9\x92q\xbd(\xb5*\xd0\xa0\xf7K\xb2\xeb\xce\xe4T'\x0c\xd9-\xf2\xf0\x1a\xc0	N\xe36\x93\xb1\xd8\x12\xc7puy\xd5\xa5O=\xed2\xc5\xd0\xf4?\x1e\x1e\x96\x03\xa12\x8a\x95\xd0\x1fP\x8b]\xcb\xc0_\x86&Xehsn\x01\xbf\xf6\xe4\xc1\xf3\xa0e\xd1\x81\xb8\xcb\xe7\xe3j\x882P\x0c\x0c\xfa7G?4\x18\x8ety\xd9\xa4z\x1eWu\x99W\x8d\xc6J\xba|X\xeap2\xcc,\xedN\xabV\x98\xa0\xfdt\x06j3\xf2Q\xb1\xb9\xf4`\xe9\x98\xb46r6\x0c\xa1R\x8e_6}\xb6\x7fu\xd2\x1d\xad*h4\x89\xda\x04\x0b'\xbd\x1c\x0b\xf1d\x95\xfa\xbacw\xad\xeb\xd12\xef\xed\xf1\xfb\xea\xdf\xfa\xc6%\xfes\xf6\xf7\xeb\xd7kwC\xf4\x98\x1e\xb5#\xd7Zud\xd9J\xabcx\xb89L\x1a\x0b\xda\"\x8f\xe6`\xbc\x83_Z\xb6\x1e}X\x86\xd7\x9bM\x18\xdb]\x0c_\xac\xc1\xda\x0d\xef\x1c\xc6pS%\xf2i\xf9\xbaV\xd4k\xee\xad\xcf\xaav\xf7\x1di\xcad6tNk!\xe7\"\xe7\xd4\x92\xfaQn\xbe\xf9\xe3UY\xfab1\x99\x1d\xe2\xaa\xe3\xbf\xb3\x1a\x7fW&Qf;\x99\xf5\x83\x9b\xb5l8\xbcK\xfa\x8f>ZW\xa0\xf0&*\xb7\xd4\xacy\xca\xa8[\xb2\xb9;\x11M\x03\x1d\x05\xd3\x08\x8e\xaf\xdd\x88Z\x98\xcf\xd7\xff\xa0\x0cD)\xb5\xa2>\xfd\x9b\x9a\xbfl\xd1\x8b\xc9\xec$\xf9G=>Y\xe6g\xab\xfc\xd6\xd6\x97Y\x06\x14\xe5\xba\xf9wGmi\xeb\x8bp5\x9dN\x8e\xfc\xe8;\xb2\x02i\xdd\xbf\xffo\x00PK\x07\x08\xe2\xb1\xe5J\x88\x03\x00\x00/\x0c\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/dns-aaaa-delay.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x94T\xcfo\xf36\x0c\xbd\xfb\xaf >\xa0\xe8\xc9	v\x1b|\xfb\xd6\x0d\xc3\x80\xad+\xbe\x02\xbb\x14\x85\xa1\xc8t\xa3E\x165\x92\xca\x9a\x05\xf9\xdf\x07\xc5Nj\xa7\xf9\xb1\xf1\x92\x80\xe2{|\x8f\x12]\x96eQ\x98\xe8\xfe@\x16G\xa1\x82\xf5w\xc5\xca\x85\xa6\x82g\xe4\xb5\xb3\xf8\xd5ZJA\x8b\x0e\xd54FMU\x00\x04\xd3a\x05M\x90\xd2\x18c\xca\x06\xbd\xd9\x0ci\x89\xc6b\x05\xab\xb4\xc0R6\xa2\xd8\x15\xc5\xa7\x1e\xbc0vf\x92.\x89\xdd?F\x1d\x85\xd9\xea{\x999\x9a\x1f\xbb?\xf8$\x8a\xfc\x8d<\xfe\xe0B\xe3\xc2\xdbM\x05%\x93\xc7r1TKZ\xfc\x89V\xa5*J8k\xe8\xff\xda\xc8\xec\xdf\xb0\xcd\xddMt?3\xa5x\xc5I\x01\xf0\xc9\xc8\xb1ed\xb7v\x1e\xdf\xb0)\xa3\xc42	\xf20\xa5\x1e\xf3\xa3\xc1\x8e\xc23\xea\xe4fL\x8c\x92\x07tk\x0e\x97-\x00x\xb3@/\xd9\x03d\xbeO\x97(\x11m>\x15\xf4h\x958\xff\x07\xe8\x8c\xda\xe5\xaf#\xe8y0\x80b\x17\xbdQ\x1c`#\xa1\x00\xd3\xe6\x97\x04\xe4\xfcAD\x8e%\x89>\xa2\xfeM\xbc\xaa@9\xe1\x90W\xf2\xc8\xfb\x81\x0fnr\x94\xb0\xc2M\x05\x0f\xec\xd4Y\xe3\xbf6\x0d\x05\xf9=\xf8\xcd\xb1\x02\x80b\xc6\x11W\xf0\xd3\xbb\x13\x95\x11\x18\xdb\x16\xadV\xf0H\xcfv\x89M\xf2\x87fWp2\xd9\x92\xc7\xf3\x8b\x91#\xb2#v\xbay\xf0F\xa4\xaf\xeb\xf7\xa3\xb4\xfdS/\xed {`\xb6\x14\xd4\xb8\x80<1x\xe1\xcd\x1e\xc2u\xe6\x0d+\xd8n\xc1\x12c\xcd\x18\xa9f\"\x85\xdd.\xe7\x123\x06\xad;\x13\\\x8b\xa2/\xf7\x0dF\x0c\x0d\x06\xebP\xee__\xee3*\xffN-\xe4\x8c\xb0\xbd\x7f\x85\xdd\xee\xb4\xd9S\xf2\xfe\x89\xbc\xb3\x9b\n~i\x1fI\x9f\x18\x05\x83\x8e\xea\x04m\xda[\xa7\xa0\xf8\xae\x1fv\x86\xb9\x0c\xbb0\xb9\xdf\x1c\xde\xb5h7\xd6\x0f\xcf\xe9\x10\x91\xf1Y)N\x93\x00\xf8\x8e\xf64\x07`\xa9\xebL\xfe\x9c\xbd|\x993v\xb4\xc6\x99,\xbf\xbc\x8e\xea\x18\x85\x12[\x1c\x8d9\x07\xe3_	EO\xb2\x006\xa6\xfd|\x9b u\xbe\xe4z?\xa1\xfaP^\xdb\x98\xa6S\xca\xd1aG\xbc\xb9\x8a\xebK2t{\x07\xae=\xe5\xf7\xaes\x03;\xf1\x85\xc3\x81\xe2n\xda\xbdGV7i\xefv\xff\xcd\xe9HI/\x16C\xe3\xda\x0c\xbf\xd6\xe1\xbc\xb6k\x93\x99\x02?\xb7:\xb6\xfd [\x93O\x1d\xfe\x967qro\x87\xb5yW\xb3\xf0(\xa5'\xbb\x1a\x1d\x03t\x19\xf2dtY\xc1\x9cS\x98\x0f\x85\xb3\x93\xc2\x03\x8fw\x8bR\xedE\x06\xef\x16\xf3\xe3q\xafi$\xe7\x86\x98\xfc\xc1\xdb\xf3\x8cr\x00\xf1\x86\xb6\x8b\xca\xae\xd1m\xb7\xa0\xb6\x8e\x86Q\xa9n\x9ch\xdd\x92o\x90a\xb7+\xfe\x1d\x00PK\x07\x08B\x8c\x03z\xc3\x02\x00\x00\x18\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00O\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/ebs-blockstore.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xcc\x95Ok\xdb@\x10\xc5\xef\xfa\x14Cz\xad\xfe\x99(\x14\xdd\xe2?u\x03	6^%=\xaf\xa5\xa9\xbdX\xd2.;#'\xf6\xa7/Z\xa5!\xb4\xa9qZ\x89V\xb7\xd97\xcc\xf2\xde\xfcX\xf9\xbe\xefI\xa3\x1e\xd0\x92\xd2u\n\xfb\xd8\xdb\xa9\xbaH\xe1V\x11{\x8a\xb1\xa2\xd4\xf3\x00>\xc0\xbd\x98\x01\xae\xc9\xdf\x98\x91_`\x89\x8c\xf0y\xb1\x82\xbb\x85\xc8\x9c6\xb9\x163\xe1:\xa7\xb3\xec\xfa\xe6V\xc0\x96\xd9P\x1a\x86\x85\xce)\x90\x8f\x14\xc8J\x1eu\x1d\xe4\xba\n\xaf\xbf\x8a\xd9d\x14\x96\x92\x918\xbc'\xb4\xf3F\x15\x18\xce\xc6\xe2A\x97M\x85\xd9\xc1 \x05[\xaeJ\xcf\x0d\xf5O~\xaeecF\xe0\xc3\x1ck\xb4\xb2\x84ec\x8d&\x04!\xa6\xe0\xe4n.\x08uD\x82\x18\xe6j\x0c>\xc4W\x90\xa9\xb1\xd3\xef\xe4\x13\xdc,\x96\x02\xe2\xab\x8fQ\x14\xbd\x9ce[\xab\x9b\xcd\xd64\x0c\xa3$\x82;5\x0e\xa9\x8d\xc4\x87.)\xc1\xda\xca\x0dNJI\xe4\x01\x00\xbc\xce\x93:1\xd8}\xa2@\xe9p\x1f\xbb\x8e\nY\x16\x92e\xea*\x80ZV\x98\xfe\x94\xae\x93\x8c\xd5{\xd5NB\x9b\xc2\xaeY\xa3\xad\x91\xd1\x8d\x92\x8f\xe4\xe3\xba\xbb\xd1H++d\xb4\xf4c\"\x1f\x0c\xa6m\"\xae\xb6\x98\x97RUK]\xaa\xfc\x90\xc2\xd4\xadoP\x0f\x16Y\xaaz@\x0f\xab\xee\x82s\xd9P:\x06\x1f\x96/q\x16\xdd\xaa\x85\x98\xfe\xca\xc6\xe5	6\xae.\x7f\xc7F\xdc\n\x1d\x1d0X\xb4J\xc7=\xe2\xa1t\xfc\\+mh\x89v>N\xe1\"\x89.\x9eO\xbfQ\xe6V\x80O|\xf9\x0f8j\xcd\xf6\xc7\xd1\xdf\x9a}'p\xc4-p\xaf\xde\x8e\x85aU\xa9#\x16\xf0e\xfa\x06tI\x14\x9d\xc0.y\x9b\xb9dx\xe2\x88\xfb$\x8e8>3\xee\x81\xd9\"\xee\x93\xad\xf3m\xbd\x97\xa2\xbc\xa5h\xa2\xcb?\xa2f\x94\x9c\xfe\x8b\x0dHM\xde+5\xf9\xffBM\xde\xe7\x8bt\xbe\xad\x15\xb2T\xf5\xf7\x01\x00PK\x07\x08\xb9\xbd\\i\xf5\x01\x00\x00\xac	\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00H\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/flannel.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xcc\x18Mo\xdb8\xf6\xee_\xf1\xa0\xa2\xc8\xa5\x92\x12\xecb\xb1\x10\xb0\x874\xc9\x16\x056i\xd1\xb4{	\x02\x83\xa2\x9ed6\x14\xc9\x92On<\x19\xff\xf7\x01e\xca\x96,\xd9I\x07\x19`x\x92\xdf\xf7\xf7#\x1d\xc7\xf1\xec\x0d\xdc\xea\xc6r\xcc`Ad\\\x96\xa6\x95\xa0E\x93'\\\xd7)\xd7\x16\xb5KK\xc9\x94B\x99\xe6R\xe7\xe9\xf249;KN\xd3K\xcd\x9b\x1a\x151\x12Z\xa5\x0fM\x8eq\xa0KV\xb5\x9c=\x08Udp!\x1bGh\xbfh\x893f\xc4\xff\xd1:\xa1U\x066g<a\x0d-\xb4\x15\xbf\xb5\x12\x92\x87\x7f\xbbD\xe8ty6\xab\x91X\xc1\x88e3\x00\xc5j\xcc \x08\x9e\xd9F\xa2\xf3\xe0\x18\x98\x11\x1f\xacnL\xfb\xd3\x9f\x18\xa2h\xe6?,\xba\xd6\xa3\x1e\xc6\xe8\xc2\xb5\xb8%\xda\xbc\x07\xaf\x90~]\x98\xd2\x05NJ\x93\xc2\xd1\x96\xea'#\xbe\xf8\x93\xc2SG\x8c\x9aI\x1d\xa6\x15;\xf3\x99\x1b\x87\xf8\xbdP\x85P\xd5\xabDZK\xfc\x82\xa5\x0fUg\xff\x11Y3\x80\x911\xa3\xe4\xb9&\xff\x8e\x9c\\6\x8b\x03\xf5-\xda\xa5\xe0x\xce\xb9n\x14\x8d\x186\xbf\x9da\xbe:\xdb\x02s+GX\x07\xf7_\xec\xe5\xc18\x1d,\xb4\xd88\x13\xfb\x10\xc4y\x08\xe9\xeb\x19\xff\x8a\x915V,\x85\xc4\n\x8b\xd6\xe0\xc6\xa1\x9d\x88\xcd6\x02{\xe1>\xdcf\xcf\x04>\xc4S\xabRT\xd7\xcc\x0c\x121\xd9\xbe\xfd\xe1\x10\xf3\xb2:\xac\x00@\xb2\x1cehQ\x12h\xb3\xb6\xdf\xda\xfag\xc6\xecFA7 \xb8\x121\xd7\xaaL\xbe;\xaf\xff\xf7\x96\xf2)\xf4S\xe4\xd5D\x19D<\xb7\xa7\xd1\xbb\x0e\xcaUW9\x1ew\x9a\xfc#9\xdb!\x8dl*\xa1\\\x94\xc1]\x00\xed\xe4\xf9\x13\xd1\xca\xb42\x83%[N\x7f\xa2\x02%V\x8c<A\x9f	 Z0a\x8dP\xd7\xba\xf0H\xb2\x0d\xf6\x19\x01\"\xe1.\xb1d\x8d\xa4\x0f\x8c\xf0'[\x05\xaa\x1e\xd1z\xfb\xbd\xde\xf1N\xdaf\xb4\xa5\x9a\x99\xa1m\x9c\x19\x96\x0b)H\xa0\x1b\xdb\xe7Y\xae\x991BU\xee\xa8\xea \xf2~\xd6\xc1\x15\xd2\x91\x14\xdc \xfd\xd4\xf6\xc1G\xec\xe9\xa9-\x859\xdfl\x859\x17\x85\x85\xf5zke\xf4\x9e\xf1\x07T\xc5\xc0\xb8\xe8k\xf0\xe9\xe9\xc9';	aO\xf2\x0d\xad\xe7\x0f\xec\xeb`\xd1\xb8\x05\x981.\xdd\xf6\xc1%\xc3Z\xab[\xa4\xe7J\xb5p\x87[\xe1\xe5\x95\xea\x0cr\xaf\xc0\xa1DN\xda\xfao\x80\xda\x0f\xf2\xff\xf5\x8a}\x8f\x0b\xa01\x05#\xbc%\xcb\x08\xabU\xd0\xb32\x98\xc1\x17-\xa5P\xd5\xb7\x96\xa0\x85\xdb>\xa4\x93W\xb3\xc7o\x8a-\x99\x90,\x97\x98\xc1\xd9\x0c\x80\xb06rK\xd3\xf7\x1f`\xe8\xd2\x84[\xa3&\xf4\x80\xce=\x7f|\xab\xde\x0e\xdc\xf4'Gb\x89O\xbcUH\xe8\xa7Z\xaa]\x06R\xa8\xe6\xf1\x18\x11\xb3|\x91\x01\xab\x8b\x7f\xfd3\x90-\xb4\xa3PO\x83\n%-\xd1\xb6\x0bi\xbb\xdc\xfd\xe6\xc5\xb2DN\x19\xdc\xe8[\xbe\xc0\xa2\x91\x1d\xbd?\xdax\x16m3\xb8z\x14\x8e\xdc\x16\x15\xc3\x03\xae2\xb8\xb0\x82\x04g\xf2\xbc(\xb4r\x9f\x94\\\xbd\x8cy\xa7\xf4\xea\x11yC/\xd0\xe9\x06\x83\xf9fp\xe5i	\xfc\xa4\xd7V\xd0\xeaB2\xe76\x04\x9b\x91\x1c\xfb\x90\xc7<\xd8\x1a\xa8\x85\x12t\xa1\x151\xa1\xd0\xf6\"\xf2\x06h\x81]\xf2\xe0\xe2\xe6#l\x86\x1dtS\xcb\x01\xe9\x96&\xb7\xa2\xa8\xf0]\x1b\xf0Xj\xce$0U\xf4\x04I\xad\x8d\xef\xc0 \xc1\xbd\xf3l\x0e\x81Y\x04\xae\x8d\xc0\x02J\xabk\x0f\x85\xc5\xca\xa0\xf5\xf9\x07Q\xb3j\x17\x8f8\xac\x07\xa1\x1c1)c\xaeD\x1c\xc4mi`\xc3\x93\x81\xef~mqn\xd1\xe8\xb9\xd5\x9a`\xbd\xf6\xb0\xc6ZT4\xaf\x99\x12%:\xba;)\xd0\xa0*Pq\x81\xee\xe4\xfe\xee\x84kEV\xcb\xd8H\xa6\xd0\x03\xbc%\x12\xc9\x7f:\xcbO\xeea\xbd\x9b\xab\x00\\\xd75S\xc5.h\xfe\xc4\x90\xe6B\xa5n\xb1\x07\x8d\xf9\x1e\x80\x1b\x88KH\xb5\xa1\x94+\xd12mB9\x84\xf5\xc2:\x80o\xa3:\x80\x86a\x0e-\xdb\x00\xd3\xd3\xbe\xd4\xb2\xa9\xf1\xda\x17P/\xe3\xe1R\xd9\x16\x8c\x0fo.\xd4\x00\x07P{\x8e\xcf\x8c\x16\xd9X\xfe\xb1L\xbdf\x86\x94\xf0\xc9\x08u\xf9ky\xe1\xa6g\x08\xb3\xd5\xc8\xf7\xb8\xdc\x03\xa4H|\xf0\\I\x07W\x88)j\x9f6\x85\x94\x14\xe9\xd9i\xc7\x95\xf8\x8d\xd7\xbb\xf1\xbf8\x07G\xe2?\xd05\xc9?\xbcD\x1d\xca\xe3\xc8\xc3@\xcb'fB\xd7\x84\xfd\x88\xfcMr\xdb\x96\xa2/\xf3`V\xf1\\\xaaca\xe2\x9a\xb9\x1f=\xba\x0d\xbcu\xce5\xb9\xbf\xa9\xd4\x95\x1d\xe1E\xc98\xfe\xc7;\xa9\xc4\xbc\xfd14p\xf4\xa4\xec\xc0?\x1at\xfb\xb9\x06\xe0\xa6i\x07V\xb0{\xde\xd1\xcd\xb9i\x86\x82\xfd\xa9\xb1\xd6v5\xcd\xb0\xc1y\x9e\xa7\xb7 \xca\xadD)j\x11\xe4i\xbb\x0f\x0dLo\x87\x8a6\xc8\xec\xb0\xa0\xb7\xebg\xdc\xe8)\xdd\x18\x84\xaa\x10\xa5\xe7\x9b\x949m\xc6\xa4\xbfC\x8e\xb1\xf0\xad\xa2\x9d\x14\x87\xbci7\xa2V\x84\x8f4L\xc1\xeea\x94A\xc9\xa4\xeb\xef`\x80\xfe=x?u\xac(2\xb8\x8bn\xae\xbe\xce\xcf/\xaf?\xdeD\xf7=\x02T\xcb!}\xd7<\x9f?]\xceo\xce\xaf\xaf\x06H\x80%\x93\x0d\xfe\xd7\xeaz_\x0b@)P\x16\xe1\x81\xbd\x7fZ\xdcf$w\x17\xb4\xc4\xb7\xffQ\xd5\xb7\x9f\xcf/\xfeb\xfd\xed\x83\xf0\x97V\x8em\x8e\xad\x1b\xdbl[{2\xaa\xc3\x17\xe3\xa1\xa5uh\xd8m\x16b/\xc1\xd3F\xf9\x9d\xda\xda\xd3\x83\x81\xff\x9b\xe5\x90\x85\xd3\xa3\xfc\xa8\x98\xe9\xb9~x/\x1f\x15\xd6\xdb\xff#Q\xd3\x11\xe3\xddK}(o<\xf7c^V\xb3?\x06\x00PK\x07\x08l\xad\x1c\x1d\xab\x05\x00\x00\x15\x14\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00I\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/heapster.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xecZ\xddo\x1b\xb9\x11\x7f\xf7_1\xb0\x91(\x01\xb2\xb2}\xc6\x15\xc1\x02B\xe1\xfa\xbe\x1e.\x17#)\x8a\x16\x81!P\xdc\x91\xc4\x9aK\xb2\xe4\xac\xcf:\xc7\xff{\xc1\xfd\xd2~p\xe5\xb5\xdcKR\xc0\xfb\"-93\x9c\x19\xce\xf07\xe42\x8a\xa2\x03f\xc4?\xd0:\xa1U\x0c7\xa7\x07\xd7B%1\xfc*\x1c\x1d\x08\xc2\xd4\xc5\x07\x07\x00\x11t\xa8\x00\x00\n\xca\x8fho\x04\xc7s\xceu\xa6(\xefH\x91X\xc2\x88\xc5\xf9\x1b\x80b)\xc6\xb0Ff\x1c\xa1\x8d\x1ck\xb4;\xc38\xc6p\x9d-0r\x1bG\x98\xf6\xc7\xb3\x0b\xc6\xa7,\xa3\xb5\xb6\xe2\x0fFB\xab\xe9\xf5[7\x15\xfa\xb8\xa5\xc9\x85\xcc\xbc\xfc\x0fZ\xe2\xdf\x84J\x84Z\x8d\xd1\x86\xdbE\xd9!\xd9\x02\xa5\xab\x94\x86\\'\xab\x900\x1f\x8a\x17\xd2#W\xd8\x1b\xc3!\xd9\x0c\x0fkj\x96$Z\xa5L\xb1\x15\xdai\x9b5\xd5	\xc6\xf0\x01\xb9V\\H\xccY\xac\x96\xf8\x01\x97\xd5h\xcc\x88\x9f\xad\xce\xcc\x0ecK\xca\x9e\xada's\x9b\xb7\xbbl\xf1o\xe4TZ\x15\x0d\xcf\xd9\xd7\x98\xa5q\xd3\x93S\xd9LbmD\xe5\xac\xb2\xc1\x87\xe7a5\x11\x16\x9d\xce,\xaf\x88}_m\x80+i\"P:i\xbc\x19\x9dT/7h\x17\x0d\xd6\x15V\xde\x89@\n\xb7}\xf9\x9d\x11_\xef\xa9\x8e\x1f\xfb\xd8\x11\xa3\x81A\xb9EF\xd5\xb4\x16:\xe4/G\xf0K9\xbb\x13\xe7u\x9e+\xa6\xd4\x06R\xad\x04i\xeb\x80\xd6X\xfb\x0d\x124RoRT\x04/AP\xce\xf0\xca\xbd~\x03L%\xe08\x93\xa5\xfdG9[\xad&\xe8e\xde\xd0`\x17KP\xc8\xd19f7\xd3}s\xf3\x81\xe9.b*6:\x89r\xa3\"[\xd1\x0f\xae\x12_#g\x9f\x10\x84\x0f\xc7X8\x90\xf0\x96P\xf9\x04s;\x84ogkD\x1cg&\xf1\xe1\xf5\x94\x99|\xc4\xf2j\x17\xdf\xde4>m\xe9\xadC\xf9\xe1\xe0\xfd\x82\xab/3\xc6\xb5\xe1\xf0\x87:(F\xac\xb3\xbbG\x0b\xe7\x1a3f\xabqIS\x00[\x0cK\xab\x15\xa1J\xea\xe6\xc7\x81\xe9MU\x90\x1c\xde\xdd\x01\xcf\xacEE\xf3\x94)\xb1DG\x9f&	\x1aT	*.\xd0M\xae>M\xb8\xb6\xe8\x7f+e\xfc\xffR\xc4\xe4\n\xee\xef\x0b\xc1\xce \xaf\xa2\xd4\xa2\x91\x823\x17\xc3i\xd9\xe2P\"'m+\n\x80\xd4\xaf\xf1\xbfv\x8a\x82\xb0\xd9\x84\xa9\x91\x8c\xb0\xc1\xdc\xa9\x81B\x0e\x1c\x92V\xaa\x18v\xa4\x7f\xfe\x14\xf7\x94\xd6)\xa5)\xcf\xfe\xc6L\xfb\xc7\xf15&\x99D;e\xd2\xacY'\xcf\xb8\x15$8\x93\x91\xd1I\x0c\x93I\xcd\xdat\xba\x7f\\+\xf8\x7f\x1b\x88z\xff\x18+\xb4\x15\xb4\xb9\x90\xcc\xb9\x82\xb0Q%Fk\xb1Z7\xe4\x92\x96hCz\x1f\xc1;f\xafsP3:\x01\xe6\x80A\xa5\xad/\xda\"\xad`\xa9\xad_\xb3\x0b\x0b\x85ZM\x1b\x82\xf3\xbc\xc5M\x0c\x87\x17%\xd7\xb9_n\xdc{%7\xd5\x92\\=\xdax\x1d\xb4\x8d\xe1\xf0\xc7[\xe1\xa8^\xb3\xfd\xc3\xb5\"&\x14\xda\x8e\x82\x11\x88\x94\xad0\x06\x1f\xea\xda\xe2\xdc\xa2\xd1s\xab5\xc1\xfd\xfd\xde\xe1\xef,\xcfC\xbf5Tw\x99\xe9t\xa2\xbai\xabVUO1\xfc\xfc\xfe\xdd\xf9?/?\xbc\xbf\xf8\xd8!\x00\xb8a2\xc3\x9f\xacN\xbb\xbcM\xb0\xfaI\xa0L\x1a\xf5n\xfb\xa9 -\x06)RAn\xcaMv\x10&\xe98\xcf?Gp\x8dh\xc0\xe2\x7f2t\x04\xb3B\x08\x90.\xdai-\xdc\xd6\xf9 \x14\xac2f\x99\"\xc4\x04\xb8\x0f\xae\x80\xc4\xcaC\xa5B`\xac\xbe\x11	&\xb0\xd8\xc4\xb0&2.>>^	Zg\x8b)\xd7\xe9\xf16\x1b\x9a\x7f\x85s\x19\xba\xe3\xd3\x93\xef\xbe\xffKo\x90R\xdf\x80A\x00\xdcdy<Tj\xcc+\xe297Y\x7fV\xfd\x93b\xaa\xedf\x80\xab\xe8\xf4\x8cw/|AWS\x14\xe6\xe5R\xb5\xed5\x97l/\xfa\xe3\x15|\xf1\x0eq/\xee\xc7\xd8\xd5\x18\xbfP\x0eU\"\x96\x9e9,yX\xa3\xb0\x07\xdal\xfd!\xea\xe1\x1ar\xbc\xffu\x9a2\x95\xf4\xa7&\x82\xe3\xcaI\x81\xbe(*\xa2}\xb6\x8d\x81\xa9\xcb\xd2\x94\xd9\xcd\x9c\x19\x11W\x81\xd3\xe8Np\xc92I\x7f\xcd\x1c\xb6w\xd23_\xe3\xbc\xf4\x94\x12\xe9\x17\xcf\xd8j\xb9\xd4\x96f>\xb0N^\n\xe5\x90g\x16\xf3\xfe\xa0V\x95\xca\x91\xf1\\oO\xde~\x17$K\x91\xac\xe0\x91\xcfF\x99\xf9\xa5t\xd6\x0c\xa6\xa2w\xbe\xed\xed\xc7\xa1\x17\xdf\x8b\xe7h\x9b|^\xe9\x18\x82\xe3\x1b\xabIs-c\xf8\xfb\xc5eO\xbb\xb2\xd0*\\\xd9\xea=\x02\x85\xe8\x13\x934\xf8-\x9e\x83D\xb8k\x10\xda#\xb0\x15\xdc\x81\x13\x8a#\x08\x82\xcca\xb1I\x92\xb8b|\x03\xc5\\u\xa4U\xae\x02\xad\xe4\x06\\fr\x9b@+,\xe9\x81\x110 \x91\xe2\x1bp\x1a~\xc7\\\x01?|\xb1o\x03\xa64\xad\xd1n\xad\xfeVV\xfb\xa8\xb0\xbb\x97Yyk'u\xef^\x80C\xf2\xf9Zt\xcc\x1dY\x98\xc1\xabJV\x9f\xeb\xb3#+\xd4\xeau\xc9-\x96m\xe6O\xd1\xe9\x15\xccf0I'A\xf9B\xf9\x95\xfbU\x9b'\x8eN\xaf>\x0bE\x95P\x94\x0e\x83\xda\x85\xb8[\x8c\xe14\x0f\x00^\x11O\xce\xcf\xe7\x02\xf3QH\xc3ii\x0eHt>\x84\x98\x82\xd3\x0e\xdfX\xa0\xf4%\xedC\xde\xb9\xbb\x83Op\xfa\xa6i\x8f7\xf0\x18NONN^\xc3\x15|\x86\x94\xdd\xfax\xf1.\x11K\x98L'\x1e\xd7Z\"\x1br\xdab:\xec\xb9G\xef\xee:D\xf7\xf7\x0d\xaf\x1d\xb6\\\xf8\x0c\xccM`.\xf3`?|\xee2\x0f\xc3t/G\xb5\x1d\xea}<h\xf7\x84\x8f\xc4\xee\x1e\xdf\x03\x10>R\xcd\x9d\xbej\x1b\xf9\x15\x00\xfd\xdb\x01\xf1\xb3\xaf\x0c\xe2g{\x81x	B\x7f\xce\x0e(?\x84\xf7\xb6\x8b?\x1e\x03\x8c\xf9\x99c\x19\xae\xf9\xff\x91h\xd8\xa5}\xc6\xc0g\x0c\xec`\xe0\x180)\xc2\xe8Q\x10\xd2a\xe9\x02G/\x88\xb5m\xb7\x8d\x05\x89P64\xa9C\x864r'\x08\x08#\x15	\xd8\xdbV~\xf4\xe2\x1f(\xf3\xb6'\x1b\xef\xfe5\xbf|\xff\xc3\xfc\xb7\xf3w?\x06,\xdby\xb8\x01\xb0\xdcy\xacQ\xf6_2Z\xc7\xf59\xf9\xd4\xefe\xc6h\xf3\xf1\xf2\xfc\xe2\x0b\xaa\x94\x7fby\x04j\xd6\x1f\xa0\x02\x9dQ\xc4M6\xab\x03\xa2\xae\x18\x16\xcc\xe1Px{\x88\xc3[\xb2l\x807\xef\xf3\xccs\x83v\xee?\xa2\xf5\x97\xf5b\xec\"\xb6\x87\x86\xdf\xa6\xca\xb0\x06\x83\x12\n%\x8a\xee\x07\xf5\xa0\xb5E\xb7\xd62\x99}\x1f\x1ck\xfb\xd5fVY\x19\xa4\xab7\x92\xbb\xc9\x8c\x9622h\x85Nfg'\xfe	JCG\"e\xa4\xed\x0co\x8dV\xa8H0\xd9^\xd0\x8e\xf2\x03\xd1\xe1]\xf2\xff\xee\xc4\xf2)x\xdd\xde\xce\x96\x8bJ\xabH\x1c\x87\xde]\x96g\x10\x7f\x06\xf1\xbdA\xbc\x8c\xa6=\xb0\xbc\xcb\xb9]\xa7v\x07\xf8\x16\xd9[\x81<\x8c\xab\xed\xa3\xdb\x1dy\xf3 \xcew3g'\xdc\x8f\xd4n\xd83\xcf\xe0\xff\x7f	\xfe\xe5\xc4?\xa5\x06\xe8\x8a(\xdf\xf7\xaf\x08\xba\x02\xf7.\x0c\xba\x82\xca\xf7o\xa4L\xa8\x102Tj=\xb5Z\xe8\\f\xc8\x99\x9b\xf7\x11\xca\xab\x08U\x94\xc5\x07\xa1\x8d\xf7>\xb7\x0e\x1a\xdfNF\\$hS\x17\xa3\x1fV\xd7\xa8\xfa\xd7\x02:g\x1f\xfe\xdaNq\xd8Q\x0b\x04 fWH\xc1O\x19\xe1\xcf\x14[)o\xcf\x86\xe5\x9c\x1d\xec<)\xe9_Oh\xddC\xf8\xef\x00PK\x07\x08\x93*B\x9cW\x07\x00\x00\xc9)\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00S\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kube-state-metrics.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xccXKo\x1b7\x10\xbe\xfbW\x0cT\x04\xbed\xed\xf8P\xc0X\xc0\x07\xd7ir\xc9\xc3\x88\x8b\xa2E`\x08\x14w$1\xe1r\x18r\xe8\xc6	\xfc\xdf\x0b\xeej\xb5O\xedJ\x8e\x91\x96\x17a\xc9y\xf3\x9b\x07\x95$\xc9\x91\xb0\xeaOt^\x91I\xe1\xee\xec\xe8\xb32Y\no\x94\xe7#\xc5\x98\xfb\xf4\xe8\x08 \x81\x0e\x15\x00@Iy\x83\xeeNI\xbc\x94\x92\x82\xe1\xe2 G\x16\x99`\x91\x16_\x00F\xe4\x98\xc2\xe7\xb0\xc0\xc4\xb3`Lrd\xa7\xa4o\x1c{+\xe4\x96\xe6\xde3\xe6}\xb5n!\xe4\x89\x08\xbc&\xa7\xbe	VdN>\x9f\xfb\x13E\xa7-\x83\xaet\xf0\x8c\xee\x03i<\xdc\x1a\x174\xfa\xd2\xee\xc2\xe7\xd7\x8e\x82\xf5)|\x9c\xcdn7\xfc\x0e=\x05'+\xb2\x18\x1cIf\xa9V\xb9\xb0\x95K	x\x94\x0e\xb9\xfe6\x94a\xfde)\xab?|\x19\xc1z\xa3\xd2\xf0%\x10\x8b\xe6\xb6\xd5J\x16\x8eK2\xecHkt\xf5\xb1V\xb9b'\xcc\xaa\xa9'^\xacg4|G:\xe4(\xb5P\xf9\xee\xe3\xfad{'\xf5\x16\x9a\xcc\x922[\x9f\xee\xd0-\x8a\xc8h\xe5y\xf6\x1cf\xff\x08\x96\xeb\xd9\xedP\xf0\xf0+\xa3\x89\xb7\xe8\xc7\xc2\x98	\xcc\xc9\xf8f\xd82\xb4\x9a\xees4\xdc\x8b\x83\xc7G\x99\"\xac\x1d5\xa2@\xe82\xe8\xff\xa7\xf8EC\xf30\x14\x1d\x99O\xb4\xa8d'\xd0\xf88\xc8\x8f\xc0\xe4\xa5\xd0\xca\xac\xc6\xdc)r\x91\x0c\x0bm)\xabx\xd0=F\xa3%\xad\xe4\xfd\x982KY\xa6\xbc\x0b6\xa6\xc0\"d\xab=\xae\xe8\xf0*\xf2KQ\xa9\x9cAF\x1f\xe5\x16\xb0\x85\x05.\xc9!\x9c\x9d\x9c\x9f\xbc\x00\xbf\xa6\xa03\x08\x1e\xf7-J\xa3\xd5h\xa0\xf8\xd5\x95q\xa8p&\x0e\xbd\xfa\x86\xee\x07JV\xa3\x02m/i\x85<\x0c\x86=\xd3\xb7\x97\xab\x15>\xdf\xc5j\x12\x05\xf5]\x99\xdd\x0eY\xf1\x1cf\xc1f\x82\xf1Q7X\x87\xfc7e2eVO\xd8\x95\xe2\x91#\x8d\x1fpY]a\x95\xa0#\x96m(k\xc3&:c\xeb\x82}X|B\xc9\x9bDHv\xf7\xdd\xff\xb0\xd3>:\xd0?\x18\xcbn\xb3\x9f\xd0\xf6\xf3C\x19\xdbM;j/\xb7i\xf2\xc4\xb0\x04\xd0b\x81z[0#2\xed\x88\x0bPU\xb7\x14f\xdf\xbf\x83\x0c\xce\xa1\xe1y.\x8cZ\xa2\xe7\x8f\xc7\x19Z4\x19\x1a\xa9\xd0\x1f\xdf~<\x96\xe40\xfe\xf6%\xc6\xdd\x8d\xb0\xe3[xx\x98mU\xc4\xfbMa\xe9\xc80\x9a\xac\xd8\xf6\x16eec\xd5\xcbS8\xdb\xecx\xd4(\x99\\\xedE\x1e\xbb\xd4\x9b\x8ekS\xce1\xe6V\x0b\xc6\x86\x98\xceT:\x14\xb0i\xb9?-pq\xd5\x8d(V7Yb=\xd9\xcc\x8b)\xcc\xd8\x05ls\x0c\x84\xbb\x1b\xf2\xb8|\x0b\xf1\xef\xc6\xa1\x1e\x97u\x8a\x9c\xe2\xfb+-\xbco\xd2\x17\xf0K\xd6j\xb5nP\xc7\xf1T(\x83\xae\x81\xc5\x98n\x13\xe5\xa9\\*\x17+L!\"\x92\x1c\xce\x1dZ\x9a;\"\x86\x87\x87'@\xa9w\xb2\x08t\xc3.\x004wMCkS_\xbf\x7f{\xf9\xd7\xf5\x87\xf7W7\xadc\x80;\xa1\x03\xber\x94\xb7\xf9\x9a\x83\xcb+\x85:k\xb4\x89\xe6\xaa\x1acZN\xed\xfeD\xda\xd0\x12$)\xcf\x85\xc9\xbaV\x9d\x8e^R\xa4H\x12K\x8e/\xce_\x9c\xbf\xe8\xf0&	\xa3\xc6\x88\xbb\xfb-\xcdYG~\x92hZ1y\xce\xd0\xb9\xd6Y\xe4\xf0\xc3AZ3\xdbN\xf6\xf5\x80pM\x8eS\x18\xb0\xaa\x84\xc4\xd6\xb2I\xfe\xb6\xc5\x0eE\xa6\x0cz\x7f\xedh\xd1\xc8\xf5rE\xc3^#w\xb7\x01\xac\xe0u\n\xa7k\x14\x9a\xd7\xdf\xfa\xc7\xc3\xc6\x02(\xa3X	\xfd\x12\xb5\xb8\xbfAI&\xf3)\xfc\xda\xe1g\x95#\x05\xdeq^\xdd|'\x96\xc5\xd8\x89h\xc1\xe1\x97\x80\x9e\xe1\xa2D\x060\x95\xfb\xbcV\xbe\x0e\x07(\x03\xab \x9c0\x8c\x98\x81\x8cY\xd9\x91\xb7\x11\xd4\xd3\x03 m(\xd2+bi^T\xa3\xf9\xe6\xf6\xe6\x15\xd3\\\xda\xd0M\x92\xb8r\xcc\xc9\xddOr\x97dQ\xc0\xf7g\xa0\x96C\x9a\n\xefJ=\xe4F\x086\xa2\x9eum)\xf9\xd3\xbd\x14<{8$\x06\x0d\xc6\xd2\x014\x99ZF!S\xbav\xd9:\x15\xb76{_\xe9\xd6\x80~\xe2\x88,#\xd3\x9a\x16\x9f\xba\x8c\xb64\xec\xac\xa0;a\xfdH\x18\x16\xfe\xfc0\x1c;R\xf6\x80e\xa5y\x12\x9e\x1d\xc2G\xc3t@\xe1ap\x1d\x10\xb0/l\xf7\xf4a:\xed\x87\xc4\xec\x05\xe3\x81\xd6[c\xfb\xed\xdf\xf3\xeb\xf7/\xe7\xef.\xdf\xfe\xde\x0b\xc8H\xff\x05X\x8et\xde\xcd\xe9u\xd1\x01\xaa\x91\xf0$&\xd3\xb4\x157\xd7\x97W?\xc5\x94\xe2a1=\x0f\xc4P\x9dZ\xca\xe6F\x18\xd3m\x9d	$\xc9\xb6_\\L\xcc\x0d\x1br\x1b.\xc6S\xc9#\xb32\xab\xe1\xf6\x105\xe2Wv\xe2\x00A\x05\xfdnq%$\xf74\xaaN\xef\xbek\x85\x9e\xc3\xc4\x15,\xa3By\xed\xd0\xafIg{\x1a\xb8\xa5\xef\xb7\xd6\x18\xbb\xfa\xff\x93\xa1\xeb\xea=\xd2\x87\xfe\x85\x7f\xf2\x17\xa50\x86\xb8\xf8'\xa3Q\xd9\xad\xa3\x1cy\x8d\xa1x\x96x\xe9\x84\xed\xbeF\xba/\xd1\xc3^2cO\xbb\xe6\xeb\xb15\x94\x8e\x8e\xa3\x03#\x1d\x0b\xb7B.\x07\xcba\x1eGL\x92t\n\x7f\\]\xef\xcb\xb6{\xa2\xb5\xfd\x11vZCWL\xffu\xbc+V\xff\x0e\x00PK\x07\x08\xf2\xb8\xe9 B\x05\x00\x00\xdb\x19\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kubernetes-dashboard.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xccX_o\xdb\xba\x0e\x7f\xcf\xa7 2\x0c}\xa9\xd3\x0d\xdb\xc3`\xe0>\xf4\xb6\xd8\x1f\xdc\xad-\xd6\xdd\x8b\x0b\x0cE\xa1\xc8t\xacSI\xd4$:\xadO\xd1\xef~ ;Nm\xc7M\xd23\x9cs\xa6\x97\xad\x12\xf9\xe3\x8f\xa4D\xd2I\x92d\"\x9c\xfa\x1f\xfa\xa0\xc8\xa6\xb0|=\xb9Q6K\xe1\xb3\n<Q\x8c&\xa4\x93	@\x02\xcd\xf6%J\x8f<\x01\x00\x18\xa8\xc5-\x83,2\xc1\"\xad\xff\x02\xd0b\x8e:\xb4\x7fE\x15\x97\xc2M9Go\x911$\x99\x08\xc5\x9c\x84\xcfV\x12V\x18\x1c\x17H$z\x0e\x1d\xb1\xe0\x84\\\xc9&\xa1\n\x8c\xa6>\xe4\xcaa\n\xe7N\xfc(\xb1\xcf\xdb/\x95\xc4c)\xa9\xb4\xff\x04\xff \xb6\x93\xefp\xfdJ\x1a7\x18\xfa\xb9\x903QrA^\xfd.X\x91\x9d\xdd\xbc\x0b3EGOp\xdf\xc2\xc5(\xab\x8c\xd0\xbb\xa3\xe9K\x8d\x9d\xf4\xbd\x80c\xad\xe9\x16N[\xaf\x80	\xa4G\xc1\x08\x07\xa3^\xdf`\x95\x14\xa43\xf4\x07\x10\xea\xab3[\xa1%\xf1\xfe|\xf0T\xba\x90\xc2\xf7\xe9\xf4jm\xc5c\xa0\xd2K\xac\xf7\x1b\xa5\xd09^\xa2\x9f\xd7G\x8d\xe1\xe9\xd5O\xd0\x0b\xc8\xac\xec\"\x1c\x80$\x9b\xab\x05\x18\xe1\x9eI\xb0Q4\xc2\xfd\x04\xc7\x05\xf2!\x94.\x8bD\x85\xcd C\x8d\x8c\x9d0\xe3\x9d\xd4ePK\\\x051\xfct\x14\xdb \x9f	\xd3\x04zG\xfa\xa6\x870.R\xbf\xcb1\xd7\x17\xc8Q\xa9q+\xfe\xaf\xf1j\x8fX\xd41X\x85\xe3\xef\xcd\xdb~Qio\xcdn\xaf\xf7\xf0\xd5 {%\x03\xe4\x9e\x0c\x14(\\`\xf4\xcfNo]\xdc\xb6z\xd2B\x8f\x91v\x9e\xee\xaa\xe9\xd5\x9f3z\xd4\xd7\xdef\xfa\x10\xa6\x05\xb3K[.i\xbb\x13:[#\xfc\xe2U\xba\x1aV\xc8\x7f+\x9b)\xbb\xf8E\n%i\xfc\x8ay[*\xdb\xd2\xb6\x85\xcdJrP\xf1\xf7.\xdb\xa1\x9c\xff\x86\x92\xd7\xc5yK\x9f\xdb\xe1\xe3\xba1\xed\xd3\x9aN\xd1i\xaa\x0c\x8e\xb4P\xe1\\8\xfa\xcb\xfb\xe8d+\xd3x\x16\x1c\xca\xd6\x92G\xa7\x95\x14!\x85\xd7\xeb\x9d\xa5\x8a-\xff\xa3\nL\xbe\xfa\xac\x8c\xe2\x14^\xbfZ\x1d\x07\xd4(\x99\xfc#U#X\x16\x9f\x07s\xcc\xeeI\x86\xd18-\x18;@\x83\x98\x8c\xc5\xa5\xbdJi,\x07\x96\xd1\xb6p\xfb\x19\xed;\x1f\x97$\xcbBY\xf4\x9d.\x1eW\xb2\xe5\x9e\xf5\x04c\x95\x8e\xbe@\xae\xee\xe0V\xa8X\xf8 '_k\x9e_\xc2\xad\xe2\x02,\xde\xc2\xfa\xd1\x802b\x81\x03\x90z/\x85\xe9\xfd=H\xf2x\xed\xd1\xd1\xb5'bxx\x88{\xa5\xf7h\xf9\xda\x08\xabr\x0c\xfc\xfd C\x876C+\x15\x86\x83\xab\xef\x07Q+\xfe;\xe6}\xdc\x0f^\x1e\\\xc1\xc3\xc3t`\x18\xed\xb2\xef\xf9\xa3\xef\x1f\xce\xbf\x1c\xff\xff\xe2\xeb\xf9\xc9\xe5@\x00`)t\x89\xef=\x99\xa1n\xb7\n\xbeW\xa8\xb3\xce\xa3\xef\xaf\xb6\n\xa6\xa0\xe3\x1d\x0b3\xe9\xca\xc9\xb8\xc8 7qy\xfcQbx|\xde\xdd%]\x99\xc2\xfd}\xa7b]\xafCq\xdd*^KW\xc2\xc3\xc3\x06p,\x7f\x86|\xb5\x07B#\x18A\xee_\x82\xca\xc7\xed5\xce\xd5\xd6\xc8o\x15Y\xc1\xbd\xdc\xe4\xd4`\xa4{\x9a\x19\x01\xd8\x11\x93\x0e\xc7\xc6\x19\xb4\x99\xca#\xd0n\x8bO\xb3\xde\x1d\xc9>\xc4\xa6\xe95\x8d\x0e&\x008\xf2c\x89O\x1e\x1f\xf3\x05yN\xe1\xdd\xdb\xb7oFB\xe1<1I\xd2)|;\xb9\x18\x9c\x0b\xbf\x18EN\x12Q2%\x0b\xb4\xe8\x05c=\xd4\xa9\\I\xc1\x18&}\xe1X\x10\xfek%\x99\xd8\x04\x80\x0b\x84\x9c\xe2\xd4\x1d\xeb\x82V\x16\xe3\\c\x84-\x85\xd6U]\x8eT^\xc1\x7f\xd6\xe1\x81\xe3\x8bO\x10\xd0/\xd1\xc3G\n<\x82\xfe)\x07K\xbc\xd2U\x98\x1dv\x86\xa6[\xa55\x08\x8e\x15\x89\xa3\xa5H\x1b2\x15$E\xc0\xc8\xa6\x83\x1f\xc7HI\xd6\xa2\x1c3\xc3\x04\x8ag\x1d_\xc8\xea*^\xc0\x88\x92a.J\xcd\x90\x11\x86\x9a\xcd-\xf9\x9b\xd9\xa4\x07Q\xa3\xd4\xb1s\xaa\xf1()(\xf0\xbf\xea\x11\xe7\xe8\xc8T\x89\xc82\x8f!\xa41\xa3\x1b\xbaQ\xb3\x9dzz\x8a\xed\xe6\xac\xd3\xd9fa)g\xf1\x1b \x0e\x87\x9a\xe4z&i\xd7\x92ti\xf0Kl\xfa\xa3\x19\xde\xf3\xcb\xba\xbbL\x04\xbb\x10\\\xa4p\xd4\xfd\xfa\xee\xae\x17pR\x7f\x85\x01\xd9$S\xe1f\xc5#\x067\xf6W\x04\xbcC	\x9a\x16\x9b\xd7(\xe9\x19`\xe36$\xda\xb1\x85\x8dK\x1a\xdc\x81\x88VK\xb4\x18\xc2\x85\xa7y\xa7\xd3\xb6+\xc6\xf3\x03\xf2\xe6\x01@\x90\x05\xc6\x1e\xf0\xf1\xdb\xb7\x8b\xcd\xf2\x0f\xe0\x1a\xb7\xc7N\x9e~{\xca*VB\x9f\xa2\x16\xd5%J\xb2YH\xe1\xcd\xab\xc9@\x0cX\x19\xa4\x92\xc7E\x1aG\x079|v\xfe\x9a\xcf\xc4>H\\\xcd\xfe\xd9s.C\xb2+\x0b\xf1-V\xa7\xca\xa7p\xdf\xad\x92\xa1\xf7\x8b\xcb\xd9^\x03(\x80\xf3\x8a\xbc\xe2\xeaD\x8b\x10\x1e\x95V\xa3hR\xa8E\xd1\xb1\xf1\x02NF\x0b\x11\x93\x8e\x85L\x91\x0d\xf1E?\xd6\x0fS\x06\xae\xdf\xf3\x1c!\xabgY\xcc\x80,\x18\x11_V\x07\xb9\x830L\xc5\x0dV)X\xca0\x89S\xff\xec\xd1\xa7\xf8\x8d\xb1\x01\x14\x17\xe69JN\xe1\x8c.e\x81Y\xa9\xc7~\x9a\xda\x18\xa8\x7f\xb1Yz\xd0\x96\x92z#\x85~\x13b\xe1\x17\xc8\x1b\xfdis\xac~z~\xfec\x00PK\x07\x08=\xf86\x0fM\x05\x00\x00\x92\x14\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00N\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/nginx-ingress.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xecZ{o\"9\x12\xff\x7f>\x85\x95\xb9Uv\xa5\xeb\x00\x93d\xb4\x83vWJ\x08\x90d\x02I\x80&\x1bF\xa3\x96q\x17\xdd\x0en\xbb\xd7v\x13\xc8\xdc|\xf7\x93\xfb\x01\x0d4\x81y\x9eN\x8a\xff\x89h\xd7\xe3\xe7rU\xb9\\\xb1eY\xafpH\xfb \x15\x15\xbc\x8a&\x95Wc\xca\xdd*\xba\xa2J\xbf\xa2\x1a\x02U}\xf5\n!\x0b\xadP!\x84PB\xd9\x059\xa1\x04N\x08\x11\x11\xd7\xf1D\x00\x1a\xbbX\xe3j\xfc\x0b!\x8e\x03\xa8\"\xeeQ>\xb5(\xf7$(e)\x9c\x9bT!&PE\xe3h\x08\x96\x9a)\x0d\xc1\xbaR9\xc4\xe4\x00G\xda\x17\x92>aM\x05?\x18\xff\xae\x0e\xa8(-\xc1\xa9\xb1Hi\x90\x1d\xc1`g,$\xe1\x91\x19\x8f\x8c\x18\xa8\x0c|\xbc\xf4\xa6\x14Q8\xffd\x86\x85\xf6\xf6\xe6?%(\x11I\xb2`2\xc3BD\xf0\x11\xf5\x02\x1c\xaa%F\xe0n((\xd7\xcb_\xb9pa\xf9K(\xdc\xe5\x0f\n\x88\x84\x1c\xdb\x04\xe4pE%3\xfb\x96\xfd4\xca\x1e\xb1&\xfe\xb7/e\x19]\x81b\x0f\xf4\xb7\x1bL%\xbe\xb4\xa3\"3\xbez\xc50\xd5\xc0\x8dg\xa9\xad\xdb\x98\xba\xecO\x81\xb5\x05\x8c\xa1\x81	p\xfd\x0c\x16CC$`\x0ds\x9a\xd4\x9f~\x98aJJc\x1d=k\x9f(t\x0d\xa2\xaf\x0d\xeb/\x8a\xe7y o\xcc.?!\xca\xb7F\xaf\xe1\x9d\xc3\xdb\xd1\xe5\xbf/\xcclAm\x83\"/\xef5:\x83\x11\x8e\x98VH\x0b\xb4\xf7\x070 &\xe3Z\xd4\xfd\xcb\xfa#\xddv\x8b0\xac\xd4_\x0b\xc5\x08\xbdF\xe7 \xa1\x8a\xf6\x164\x82k)\x18\x03i1\xc0.\xc8\xbf\xac?\xe2\xe4\xbb\xc2\xd7\xf3\xa9B>\x8e\xf5\x0d\x01a\x17\x87\x1a\\DGh&\"D|\xcc=@@\xb5\x0f\x12\x85X\xe2\x004\xc8%	\x8f>p\xc4p\xc4\x89O\xb9\x87\xb4\x0f\xabY~\x0e\xe5 \xc7h\xa1\xbd\x8dX\xadX\xc0\xde\x97\x84}\xea\xe6?b\xb7\n\x92\xceR\x98\x7f\x83o\xac\x9fH\x1b2\xdc\xb7\x84\xef)\xe5.\xe5\xde\xce\xa7\xb2\x89b\x8bS\x85\xada\x8e\xf3\xf9\x90\x16\x0c:0\xcapg\xf6x\x06fJ\xb9\x92d\xb6\xa4\x15\x15\x0d\x1f\x80\xe8\xb9\x11\xad\xcd\xa5\xd0\xd6\xf2g\xe3z\xbe\xd6\xd4\xb9\x02\xe8K-\x9e\xab\x83\xd6\x0d\xff\x8d\xb6]\xad\xcb\xb6\"\xf8\x1fZz\xb9\xa0\x8c#\xb1\x85\xc3\xdd\xcb\xc9E\xceK*@+\x15\xf8\xac\xef\xe6\x0b\xe6\xd7i\xe9\x88\x14hM\xb9\xa7\xd0H\x8a\xa0\x8a|\xadCU-\x95<\xaa\xfdhx@DP25\xb3\xe4\xa0A\x952#\xc6hJC&\x86\xa5\x00\x1b\xa3\x97\\AT)R -/\xa2.\x94b\x8a\x14\\$c_*\xcd3\xceA\xe0\xceap\xa1\xd3\x8cl\xa6#\x05.z\xa4\xda/D\x12\x0b\xa5\x9c\xe4 e&)i	\x90\x81\x81)\x0eB\x06\xaaD\"\xa5E\x90\x16\xf3\xa9\xcaP\x8a\xe9\xcc\x1a\nwf)\xfad\x8e\x92O\x9f\x92\x0duRY\xce\xc2\xbcNL\xed\x18j\xc7P\xa3\xcf\x9f\xb3Tg|HZLx\x16\x83	\xb0-rbj\x87	\xcf\x89\xa9sr\x94bV(\x85\x16D0\xb5E\x8aR\xcc\x99\xd3\xe6d\xc4(-	\xd8\xb54\x0d@Dz\x8b\xa0\x98\xc11\x0cN\xca\xb0&M\x01\x8f\xa5\x89\x08v\x93f\x18\n\xa4\xbdF\xc0\xf1\x90\x81\xb9\xb3IJ\xb2\x03 \xf9hM\xb4\xb2\x92\xd2\xae\x8a\xf6\xb4\x8c c3\x85:H\xcbx\xbf\xe5c\xe5[\xc3\x88\x8cA\xef\xb2g	\xabcX\x1d\xc3\xea$\xac\xab\x1bh\x0c/\xc1\xa5\x12\x88\x9e+_\xcb\x89K\x91\xda\x8d\x0b\xacg\xc2\xf49X\x9a)'\xb9_\xc5\xd0\xd0\xe7\xcf9\xd6\x82\xfb\xa9\x99B\x88\xe1!\xb0\xdca\x8a\xc3p5\xff\xb0\xe1|v\x92]\xb3\xcd~\x91HJ\xe0\xda	0\xa7#P\xfa\xc3\xbe\x0b!p\x178\xa1\xa0\xf6?~\xd8'B\x82\xf9\x9bIZ\xa05_Sa\xfb\x1f\xe7F\xd3\xb3\x10\xaa\xe8:\xc4\xffD\xb0\x96R\\?.\x9b\x0eB\x08\xaa\xe8\xaa[\xd6W\xddJ\xadc\xbb\x97=zZ\xef\xd6Noo\xfb\x97\xa7=\xbbow\xfa\x97\xbdd\xbe\xfc\xbeg\xb3\xcb\xdbq\xfb\xb4[n\x9f\xdav\xff\xf4}\xd9\x93\xa4\x12\x96\xc9\xa1\x7fqW\xbf=\"\xcd\xe9\xf8\xbe\xd7o\xb5\xd9\xe5\x98\xbci\xcfHp1\x1b\x8c\xa7}\\\xaeto\xcb,\xc2\xbc\xa1\x86\xec\xfei`\xb3\xab\xbb\xa0\xd2\xb2+\x9d\xa3\xeecX\xbe\x7f\xe8\\@M\xbfm\x1d\x86\x15\xb7\xecV:\xf5w\xc2\xad\xb7\xfdn\xcf\x0fzw\xad\xe3\xbbs\xef\xa8_n\x0f\\\xbb=\xe86\x1b\x83\xf6Y;\xec6\xc7\x93\xd69\xbbj7\x1b\xac[\x9f\xea\xf7\xe5\xcb\x0e\x04\x97\x03\xd2\xd4\xf7\xeey\x87\xd7\x82\xcb\xc9}9\xac\xf5\xeb\x97\xf60\xe8W\xc0~<\xbe}j\xc9\xbb\xb1z\xd3z\xb8x\xb2\x99\xef\xbbg\xee!\xa9\xa9r\xef\xf0\xf4|p~z\xec\x96\xdf\x05\xd7\xf6\xf1[Ri\xbc\x19\xd8\xfa\xb4\xdd\x1f\xd8\xf6\xec\xdd\xa0\xdbc\x7f\xdfs7\x18\xf4[\xefqY<]\x9f\xf9\xc7\xf6C\xe3ph\xb7\x1b\xb7v\xfb\xc9>?\x0d\xb1=\xc5\xad\x07\xaf\xec>th\xab\xd9\x0e\xdc7\x95\x7fZ5\xdd\xee\x8d\xfb\x7f\x13\xd6\x97\xad\x9a\xbe\xe9\xcct\xef6\xe8\xd0Vs\xfa\xf6~|1s\xef\xf49\x1e7\xda\xf6I\xd8\xbd\xbe\xf3k\x9d\xa0\xd2\xec\x06\xf6SwL\x0e\x07\xcdA8l6\xc6W\xe5i\x994X\xa7S\xd7\x13;\xd0\xa2_	/\xaf\x9b\xd3[\xd2|\xf7\xa6Si\xb7:\xc1\xe5\xe3uo\xd0\xbdk\xea\xa7\xfb\xbfO\x1f\x08\xf7\x8e\xed\xe6`\xd2;c\xd7\xb5\xf1\xf1u\xff\xc1\xbd\xbb\xb3\xfbM\xf2\xd4n\x0f\xef\x8e/HY\x0fn{}=l\xb6\xc5Uy\xf0\xb6u\xee\x9fw\x9f|\xda:w/o\xc7\x0d~\xd3+\xbfO\xfd\xa1\xd1\x1b\xdfz\x9d\xba\xe7\xd9\xf5F\xf7\xd6\xae4\xfa\xf5~\xd7\x9e\x99\xb9\xb2\xaey\x7f\xfe\x99z\xb2f\xea\x80\xc84\xef\xf8B\xe9	\x96\xea\x03\xe5\xe6b*\xe4\xcc1\x9fL\x10}\xfc\xb0\xbf1\x0d\x0c\xdf\x1e\xc51G@\xea\x9c\x0f'\xc2\xc70\xfb.\xc2\xc70Ke\xff\xc0\xcc1\xc4\x8a\x12\xc7\x14@?4\x81\xe4\x83\xd9(\xfbf\x03\xe5p\xfb:\xc4J=\xba\x9b\x92\xc9\x9a\xf5p\x18\xaa\xe5\xbe\xdb\x19\x86@\xf0\xee\xb3V\\\xa9\xf5\xe6\xe9l{q\xa4B Y\x96U\xf1mT\xc8E\xd6\x0dLc\xe3j%\x15oK\xc7\x1a\x82\x90a\x0d91+\xa0\x8b\xf6g\x9b\xd4\x9f\x92\xe8\xb3\xb1(\xb4\xcc- \xad\xde\xad\xb4\x89\xb6rb\xa7-\x0c\xc1\xa0j\x8aI\xae\x81ge\x9e\x19\x98s\xa1\xe3B0\xe7\x8cf(\xe2\x83\x1b\x99K3f\xa1\x8f\x0fVtJ\xaa)\xc1\xcc\n\x85[E\xfb\xfbK\xac\xa1\x14\x01h\x1f\xa2\x984\x14q\xc6\xa8\x94\xdf\x1c\x1f\xed=C\xa7\x88\xc4\xe1:\xfa\xbc\x07\x98a\x1c\xbf\x0d\xfaQ\xc8q\x15\x99\x85\xe6\xe6\xd4\xd2=\xac\xfd\xfc\xa5\xc0TTTH\xaag5\xd3\xcfH\xa8s\x9dh\xcb\xa7^\xd6N4C\x0b\x06\xb2\xc8R\xafQ\x0b\xcbq\xdcx\x08\x85\x8b\xb0B\x18e\xf6A\xd8u-\xc1\xd1HH\xd3uIlJ\xb9\x97\xefD\x98\x18K\x12_-\xe5:q]\xc1\xd55g\xb3e\x83!$B\x83A\xc8*\xda\xabO\xa9\xd2\xb9&\xa6\x19\xaf\xd1	c\xe2q\x8eE\x0b$#\x8e\x04\x8f\xbf$u\xf8\x01J\x9a.T!	\xffDT\x82k\xe0\xad\xc8Y\xd0\x1b!D\x04A\xc4)\xc1\x1a\xe2{\x80Y\xa8*\\\x83\xe9\x17\xc7\x17\xe6\x15\x87It/q \x04\xa3Q\\\xe5\xb5E7\xf5\xb6W\x9f~1\xbd\x9f\x8d\xa7\x87\x11\xef\xe0\xd1\x88r\xaag\x0e\xe6\x8a\x0e\x198\x9ei~\xfc\xc7MZW\xbf\xee\xef\xff\x16\xdf_(G\x1f\xf6\xf7\xff\x8d\xf61c\xe6\x0f\x17\x1c\xf6?\xa2_\xb2\xfclF&j\xd9\xf7\x8d\x96\x93\xc2\x194\xb7\xd9Y$)\xf7R\xdc\x94{\x17\x1e\x17\xf3\xcf\xf5)\x90\xc8\xb8\xca\xb2\xdcLv7\xcdd=\x90\xc1\x8a3\x99a%\x89\xad>\x0d\xcd\xf2\xd7\x1dn'\x83\x7f\xfa\xf4UV\\\xd4\xbf\x9b\x1c/\xf1;\xb3Q\xc0]:Z\xb6\xa7Q\x82)\x07\xb9\xb2,\x0b\xf1\x82h\\;	\xb2A\x03\xec%\xa7\xaf\xa9\x85\x1d	\xa1p\xa4\x10\xe6:\xf4\x1d*h%I\x9cTW\x8cZ\xd8\xe6]l:\xa8E;\"?H\x18=_'d\xcc\x0e	\xa3b\xf3\x06\x10\x089\xdbQJBl\x04m\x89\x15F\x03\x9aj\x15r+Y*vi7\xd3\xbbNLP\xfd\x02u\x05Bv\xb0SN\xc0\xe7\xcfK\xfe\xb5\x9b\xe6\xcd+\xd8\xcd\xc2\xcbb\xd6!\x14\xb8\xbb\x19Xz\x05~a\xa1\xb4\xd9\xb2\xd5\xd7M\xea\xb4\xac4yYCL\xc6\xc0\xdd\xec0\xff\xf3_\xbf\xde\\\x9f9\xed\x93V\xbd{sR\xab\xffV\xca\x08MK(\xa3.\xd0n\xa5]\x9e\x00\x87\xeb26![k[\x15c4WtS\xbe\xd3Q|\"\xac\xcb\xff\xaa\xfbv6\x80O\x8a\xcci\x88\xab(3\xc6\x1a\x01B\x13\xcc\"h\x98vY\xc1$B#\n\xcc\xcd\xf5\x88WG<\x7f\x83M\x8f+\xab\x07\x0f\x8c\xce5a\xabP\xe2}\xf9yx\xe2\x96\xc4\n\x8f)\xb1\n}\xd00TWZ<\x08\x15\xa4\xeb\x9b\xb8H\x8bk\xb44\xda\xa8r|\xec\xa4\xb5eQDgv0\x8e\xb8]\xf4\xef\xe5g\x05,\xfe\x01Qp\x96$\xe0\x8e\x8e\x0e\x0d4`\n~\x08\x9c\xa4\xb4\xfc\x9eh\x17\x93\xeb\x1a\xb2\xd5\x14\xe7\x94\x89`Q\x00-S\xc5\x16\xeek`f\x12\xe7(\x81&i@+\xc5Ji\xbb\xa7@w\x02\x7f}>\xd1\xb5\xa2\xc6\xdaHnFr\xdd]\xe6X|O*\xe9/\xcc\x01\x9b\xbdn\xfb\x13\x94\x9do\x9el\xb8\xbd\x1d\xbf~\xf5+\xb8;\xe5/%I\xf7\xad-\\0;\xfb\xaa \"\x0b]3\\u4\x8d\xa5\x07\xeb\xfe\xc7S\xc1UtX.\xe7\xc8\xb3\x86s\x15\xf5j7\x05\x8a\x16\x11\x15\xce\xc3\xa7HU\xfe{^W\xfe{\x91\xae\xf5\xdbxq\x0f4\x7f\x8e\xef\xb4\x9b/\x0f\x8a^\x1e\x14\xbd<(zyP\xf4\xf2\xa0\xe8\xe5A\xd1\xcb\x83\xa2\x97\x07E/\x0f\x8a^\x1e\x14\xfd\x9f<(\xfa\xef\x00PK\x07\x08\xd7P\x8a\x8d\x0c\n\x00\x00V/\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Z\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-cluster.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xcc:]o\xdb\xb8\x96\xef\xfe\x15\x076\x06n\x01[I:\xd3;{\x0d\xecC'\x99{\xb7\xc0\xa6	\x9av\xe7a0kP\xe2\xb1\xc5k\x8a\xd4\x92\x94]\xdf\xde\xfc\xf7\xc59$%9\x1fm\xb7\x0f\x83\xedC\x93H\x87\xe7\xfb\x9b\x9a\xfd\xd9\xff&3\xb8\xc2\x8d2\x08\xa1F\xf0\x18\x822[\x0f\x1b\xeb\xf8\x81\xb3v\xb7\xac\xb0\xad\xa1\xd2\x9d\x0f\xe8\xe0\xa0B\x0d\x95m\x1akN\xc1\x05\xb4\xce\xca\xae\n\xca\x9a\x0c]Lf\xf0Fk0V\xa2\x8fG\xc5^(-J\x8d\xe0\xc4\x01$\xeeU\xc5\xaf\xb4\x86\x12\xa1\xf3({\xe2\x97#\xba\x05\xbc	\xa0Q\xf8\x00\xa1v\x88	\xa5p\x08\x0e\xff\xa7S\x0e\xe5d\x06\xca@\xa8\x95\x07\xfc$\x9aVc\x01w\x18\x05\x93\xb6\xea\x1a4A0wD\xa0\xb1\x0eAb\x10J{ Y\x82ub;RA\xcfh1\x99\xcc\xe0o\xd6e\xac\x0b\x08\x16*\x87\"D\xdcI\xd6\xd5d\x06\x00\xbb\xae\xc4*\xe8\xfc~\xb9I*d\x85\x15G\xd1\xe8/\x81\xd9\x16\x9d\x08\xd6}\x150\xeb7\xc2\xfd\xd9\xff&\x13\xd1\xaa\xffB\xe7\x955+ \xf7(H\xcaB\xd9\xb3\xfd\xc5d\xa7\x8c\\\xb1\xf1.#\x97\x93\x06\x83\x90\"\x88\xd5\x04\xc0\x88\x06W\x83_\xa5'\xbe\x15\xd5\xc9c\xdfbE\xe0\x04\x93)M\x00\x00f\xf0\x81tnM\x10\xca\xa0\x03\xd5\x90\xd5\xd8o\x82\x05-:S\xd5\x83\xf7H\x81\xe4\xa8\xad\x95\x1e^4\xd6,\xa0\xd9\xba\x05X/\x17\xd0H\xbf\x00\xb7=\xbc,\x12\xe2\xfd\xc5\x8f\xa0<4\xaaQ\xd5\x02\xf6\x17?\xd1_FtA\xe9\xce/@\x18	\xfb\x8b\xd7\xf4\xd0V\xc1\xb6\x9d\xcf\x07\xdf\xffzys}\xfd\xeb\xbb\xab7\x1f\xde\xde\xbc[\xc1[3\x8a\x85\x051\x07\x02H\"\xb5Q\x15\xec\xa38\x10\xc4\x16\x94\xf1\x01\x85\x04\xbba\x9e\xb7h\xd0	\xcd\xb47Zl\x17p\xa8UUC\xdbi\xed\x19B\x8b\x80>\x80C\x8a\x05d\x9e*\xdbi	\x0e}\xa7\x03E\x80T\x9b\x0d:4!q\x97\xe8yp\x9d1\xcal9\x0e9R\xb0\x8f\xd3\x18(b\xec\xf6 \x02\xd4!\xb4~uvVwe!m\xb5CWT\xb69sgd\x96\xf8\x1f\x1d:\xcb\x8ax\xbb\x81\xa3\xed\xe0 L k\x94\x18\xc3\xacuX)\x8f\x0b~Y	\x03B\x1f\xc4\xd1'\xc5\x04\xd5\xa0\x0f\xa2iY#\xbe\xabj\xe8\xd1\xaf\xf6\x17?\x15\xaf\x8a\xd7\xcbW\xe7\x17\x7f=\xff\xeb\xc5\xcf\x89\xd0\x07\x8ar\x02o\xd4\xb6\x0e`l\xc8.\x01\x02\x0c\x1e\xd8\xfd\xb2\xe4\x0b\xf8G\xe7\x03x\xac:\xa7\xc2\x116\xea\x13z\xd88\xdb\xb0J;#\xd1\xe9#i&\xc6\x1f\xfd\xe6\x8f>`\x93\xf5\xcf\xe9\xc9\xa1\xec*\x84}\xa7\xc9H\xa5\xd2*(\xf4\xcc\x10;\xe1\n>\x7f\x86\xca:\\;l\xed\xdaY\x1b\xe0\xfe\x9e\x9eu\x8e\xcc\xb1n\x84Q\x1b\xf4\xe1\xf7\xb9\xc4\x16\x8dDS)\xf4\xf3?~\x9f\xd3)\xfe\x89mM?\xbd\xab\xe6\x7f\xc0\xfd}\x92\xf6\xb7\x1aC\x8d\x8eT*\xb4\xb6\x07\xe8\x8c\xef\xda\xd6\xba\x80r0\xaf\xdd\xb0\xd4\x05\\Fz\xfa\x18]\x99\x9d$\xbb1g\xcc\xfe\xf0\x82}\x81\xb5\xe0\x90r\x14\x1a\x19Sd\xb0\xd0\xb5['$\xd2\xaf\xf9t\xb6\xf3M\xf4\x7f\n\x05:\x9bX\x88\xcc\xa1\x84C\x8d)\x11+O	\x95P\x04\xd7a>~e\xd9b\xa37\xa0\xc6!\x13\xe1\x18\xdb\xc7A\xd2\x15l\x84\xf68\xc99\xa0\x15\xa1\xa6\xccM\x1c\xd4\xd6\x07\"\xeb85l\xd4\xb6s)\xd5+=*/-1\xea\x03\xca\x02\xae\xc9%J\xcca\x89\x92\x88\xce\xe0mC\x9a\x11&\xac@EgvHA*\xb4\x1e\x07\xcc\x02\x1a\xb1#E:d \x89\x1a\xb9\x1c(\x0fR9\xac\x82u\xc7\xe8b(\xaa:\xf2G%D{\x82\xa2\xb80\x89\xad\x8dP\x9a\xd4\xe0\x83p!\xcbC.\x9cc3\xf2e\xe0Z\x19E\xe5e\xc1\x10\xf33\xca\xa8\xf3\x115\xe5{\xd9c2L\xd2\x82\xa8\x9c\xf5\x1e\x1c\x96\xd6\x06_\xc0G\x8f0\xe5\xe3g\x94p\xa7\xa0\x06\xe4\x80f\xaf\x9c5T,\x892A])\xf7\x1f\xd6\x87[\x11j\xf6q:\xb4\x8e\xa4\xd6\xf4~-\x95\x8b\xce:\xb8\xaaul\xe2\xecC\xbe\xe6$E1\xaaL\x87\x80{4\xa4`\x01U\x8d\xd5\x8e\x95\xe0\x93e)\x05\xa30\x9e\xbdy\xeeI1\xa1\xf3)\xcd\x95T\xb4\xd9/%\xfb\xf5\x01AZ3\x0f\x83\xff&\x9a\x14\xc4e\x17\xd8:1IH\xac\x94D\xb0\x14J\x07\x95\x1c\x89t!\x18\xca\xc1\xcdo\xef\xc0)\xbf\x8b|\xd8\x98\x18| 2\xef\xad\xdd\xcd}/N\xebl\x85\xbe\x0f\xb9\x058J\xe29[\xe6Z(m\xe5\xf9\x8f\xb3F\x90%9a.\x13\x8e\xa2\x0e\x8d\x9e\xf1\x93\x14>\xf9\x0d\xe9\xc1\xefT\xfb1\x02^\x92\x82\xfc\xd8\xf99nj\x04\xd1\xd8\xce\x04b\x82\xbd)&\\v#\x94\x13\xa0\x87\xb1dV\x046\xb2\x1c\xb6\xf5\xba\xb1F\x05\xeb\xd6\xfc.g\x1a\x8e\xb8\xebN\x07\xd5j\xbcE\xf7\xceJ|\xe6\x1c\x83\xae\x9b\x04\xbbn\xd1\xad\xa9#\x83\x7fAi\xad\x8e\x08\x9b\xad\x8b\x0c4Vv\x1a}.\xe0w\xb8\xe7*\x97\x1eg\xe7 \x7f1\x18]\xb7\xa4\x8cP\xe9\x8e\xcc\x9c\x9b:\xad|(8\xf2\xa7R\xf8\xba\xb4\xc2\xc9);\xc14I\xa3\xccv\x9a\xb1&Z\x94\xee\x84&\xf3\x1c\x01\x0d\xf5\x9d\x12\xcact\x82\xa1\xd7;\xad\x86p\xf9\x9e\xd1\xd2\xa3)\xd9/#\xa5\x10K\xa5+\xe1\xa2\x10\x01X\xa6\xae\xa6\xdd\xaeE\x17\xac\xaf\x84F\xc7o \x03\xae8\xcd\xb1g\xc5'\x8c\x9dL\x01\xbd0\xdc\x97\xee\x15\x1e\xc8wS\xf4'\xef\x9f\xc0\x00\xb6\x9a\x9c\xe0\xcd\xe6\xe9\xdf\xaf\x13\xc9\x13[\x10a\x8fn\x9f\xda\xe1\x0c\x1b}\x1c\x04\xf8\xae\xe4|\xfa\xa2\xf3\xb8\xe9tL\xe1\x14;\xac\xc0\x8a\xbc\x9d\xb8:=\xbcW\x02\x0482\xa7\xe7\xa0\xf8t|\x99\xf4\xde9}\xebp\xa3>\xad \xba}O\xf2\x0b\xcc\x08j\xee\x11\xb6\x8a\xb2\x03\xe5\xe1\xa8^\xfa\xed)9\xe99\xfc\x0b$nD\xa7\xc3\x8b\x9f\xff\xf2\x97\xd7/\xbf\"-\x0bqw\xf7\x9f\x99	\xaf\x1f\x1b\xa6u\xb6\xa1zK\xc5R\xa3\xe3v\x80,\x93,B\x8e\xdd\xbb[v\xe94\x83x\xb8\x1d\x0eG7n\x1d.S\x01A\xf9\xb4\xe5\x06t\xcf\x9a\x8e\xfc\x8b{d\xc2*\xb1\xd5\xf68\xe2\xf3=\xfb\xa6)\xe0\xed\x06\xb0i\xc3q1:\x90Z\xcb\xecO\xe3I+\xd7\xe3\xf79wbr\xae\xbe\x93\xab\xc5\x9eZ4R\x1bMl\xb9k\xcf\xd8\x16}.r\x14\xc9\xef\xc6\\\x12Q/\x1a\x1c\xb1\"\xfc	+\xd6\xc1\x0e\xb1\x05\x15\"\xd7\xc5\x13\xb4s\x86yL\xbb\x8fZ&\xb2\xfb7?0U\xd5\xd6z|\x8a\x83\x17J\xa2\xd0\xfaDA\xdc\x02\x0d\xcaLLD%\xa3|I\x1a')\x1fHH\x1e\xf1\xa0)\xf0\x05\xdc\xe4\xe2\x12{]V\xf6\x16\x03\xc8\xae\xd5\xaa\xa2	\x8e]*\xcd\xc2\xbdp\xfc\x90\x1cY\x19EmKj\xb4NI\x8e\xc7#\x00\x83\xe1`\xdd.\xdb+\xd8-Y\x88\xda6\x8f\xdcn\xbc\x8b\x00\xfc~\xf4\xf7\x10H\xa4\xccu\xe7qMo\xd7	\xdf\x89\xe7\xb9R^+\xe7N<\x9d\xf2\xaf\xe9\x9a\x12\x1dyV\x9c\xaf\xc8\xac\"Di[t\x1b\xebbg\xedJ	MF\x90\xad{\xf2\x10\x9a\xd4\x86\x8d:\x17\xb6\xc7t\x00\x9b\x0e\xad:9\x01\x04kui?E|\xc43:\xbf\x82\xf3\\\xb5\xa9\xc7pV\xa7np/\x9c\xb2\x1du\xa1\xeet\xd3\xe0\xab\x1a\xa9Pp9\xa0\xce\xc7\x19\x0c\xe8\xe3\xb4F\xb4Z-*\xa46\xa8\xe7-v\x94\x1ey\xac\xf3P\xa2\xb6\x87\"5-\x98\xb7\x03)\xa3\xce\x85\xd6s8pa\x8bA\xa4\xf5\xc0\xc4@\xdc\x9a\x11\xf1\xb4\xd4\xd0\xa2D\x9d\x151wV\xe3\xbf\xa7\xed\xc4\x92\x00\xe6T\x9c\x98l\xb0\x9a\xf6\x054\xb9)\x93\x9dJ\xc0\x0e\x8fd\x9b\xf9\xc9!b\xb4\x17i5\xf9<#!],iPv4\x8aF\xf5RW\xa65=\xe7\xa0<#yhA$\xb9\x9d\x12&\x96\xce\x8dB-	\xc8X\xb3L9G\x05h\x85QUb\xc4(\x0d\xadU\x86B\x1d?U\xd8\x92\xda`v?\xf9\xfc\x03\x11\x19|\xb0gkm\x19\xc6SwA\xd9\xe1K 4\xd6\x7f\x05\xc4z\xf9U,[\x07?\xf4\xcd\xcf\x83\xd8x\x9a\xafT`\xa8\xbb\xfa\x1a8\xf1\x98\xc0\xad\x1f%\xfc/\xf0\x9b\xb1o\xddW\xc1\x89\xf7{\xd6&\x1a\xa96Q\x0ea\x8c\x8d[.\xff\xff\xcb\xc8\x03c\xcf[\xf9	\x98Gf~\x02\xe6\x91\x9d\x9f\xc2\xf3%C?\x01O\xbc=k\xe9\xa7\xf0\x7f\xc9\xd4O\xc0\x8fl\xcd\xc5\xd6X\xb2\xf9\xd8|\xdcyy\x0c\x8bQm\x02_9\xd1\xe2	X.\xe8T\xa3\xcac\xee\x86\x8ag\xdc\xe8	3<\xe9G\xadS\x96\x96%\x97Z\xf8X\xd7W\x83\xf2\x08\xd32nH\x96\x95SAUB\x0f\xaaz\xf6-\xc7@|\x9b2\xd3\xe9\xe1\xad{\xf6\xb0Co;W\x0d3\x04\x95!j\xba\xd0\x07O\xf9\x10\xb4jT\xa0<\x1f\x80\\~\x916%\x94\xc9I\xc0\xd6\xca\xbe8\n\xbd\xa1\xfch\x0d\xc2\xe5\xedG^\xd90\x8a\x0b\xd8\xaa\xad(\x8f\x81\x9b\xa6\x06\x1b\xeb\x8e=g\xa9\x9d\x8fd\xf2_\x00U\xdb\xad`z\x12\xa9\xcd\xd6\xad3\xbf\xeb\x08\xbf\xae\xda\x0e\xee\xef\xa7\xfd\xb1\x88\xfc[N6\xd8\x8cNf\x91\xbf\x9d\x81|\xe2{X\xe8\xcf\x8e\x99\xe8\xc7\xcaoS\x865\xdf\xab\x8c'N~\x8f2\xc6hz\x81\xbe\x83\x85\xfe\xec\x98	r\xe9oW\x86\xf5\xf2\x91}\xbf\x8d\x93\xa7N~\x872N\xd0\xf4\x02}\x07\x0b\xfd\xd9\x81\x89\xd8\x05\xc5\xdcN\xa1F\x93p#8\xbc\xf5\x11\x1c6v\x8fpsw\x95\x9aE\xcan\x96\x1b\n\xc9\xbf{\xb1I\xc3\x8d\x0f\xce\xf2,\x10\xcf\xd0\x91\xb7\x9b\x9b.\xbc1\xf2Nl\xf0\x83}\xcf\xcf\x87\x8dH\xca&+\x98\xe5\x11\x004\xeeQ\xf7w:\xa7\x0d\x1cQ\xf4\xa8c\x1b\xc7\xc6\xeb<\xbe\xd1\x9a\xb6\x1d\xfeA\xbaL\x18\xb8M\x16Z\xaf\xa9\xff\xf2\x0f\xa6\xb3x\xfa*\xded}\xe5|\xbe\xef:\xc5\x10\x9f\xfeM\xe9\x80\xee\xa1\xc92\x07\x11f\xbda\xa0>|\xb4\xad\xb8\x0c<w*\xbf\xef\x0fDUd\x9f\x8d6K%\x83\x15\xd3\xaf\xd1P\xb2\xfa\xf0\xc3\xb1\xe5\xfeA\x1e\x8dh\x921\xa9\xd4\xd0t\xab\xc9\x07\xe8\x1e\x8d\x86\xa1,\x1aa\xe1\xad\xeb\xf0&\xad(\x15\xa6\xb9\x86\x08\xdf\xa5\xc1q \x82\x9fhFR\xb4\xb7\xb6F\x1f\xa9GQ\x81H\xe7\x0b>\xde\x10\xa5$\x1e\x06\xbe\x07\x9c=\xae\xd5\xc0[\xff2_BEC\xad`\xda\xc8\xf3)mE\xf8^\xe6H\xf7\x06\xd6,]\xea\x9e\xc4\xe0=\xde\x02\x05\xc0rou\xd7`\xac\xb7\xc4\x82\n4\xd0\x96\xdaV;\x90e\x92\x9f*GO{\xe0\x8c\xd6\xa4\xa5\xf0x\xa7\xfe\x89\xd7\xbf\xac`zq\xfe\xea'\xa2\xde\x998z\x07\x12\x97\x85R~\x97j\x7f#\xb4\xa6\xad\x7f-\x0c\\\x9c\x9f\xc3\xdf\x7f\xe9\xf1\xfd\xc3v\xce\x08\xfd\x00\xdd\x97\xf1\xbd\"\x0c\xd4(%\xc4	\x99\xf5\xd2\xdf\xa2\xeb\xd5rAl\xf1\xc2m/t\x87|_S\"\xd8=:\xa7\xa4D\x93\xd74\xbc\xef\xeb\x0d\x1fC\xaeg\x10M\xe5\x8em@\xd9\xe3\xa5\xfdWD\xdd[.Q \xefa\x82)y(\x0fS^\xf5O'3\xb8<\x89h\xda\x01\x92\x8aG.\x95=\x82\xb0\xf4~\xb7$eKJ7\xd9\x8c\xbc\x1c\xe9\xb5\x8drqrS\x9c&\xb5tk+\x0c\x9f\xa4\xc9\xc7\xa5\x95\xd7\xc9\x16<\xdau\xc4\xc3\x8a\xba\xa7\xe4\xeay\x90:M\x00c\x86\xd3\xc0A{\xc36\xef\xd4\xd3\n=6\xf3\x84\xe9\x87\xfb\xc9\xec\x11\x95\xd9\xf8\xd4\xd9^\xb83\xadJ^1O\xe8\x8a@\xaa\xbd\x92\x9d\xc8\xf7\xed\x14\x89\xa1F\xe5R\xd4gS\xf6W\x1e\xe4\xbf\x07\xd4z\xc1\xa3\xdd|\x94\x06\xe7 J\xca\xd7y<O\x11\xcfV)(i\x98E\x0cR\xd2\x0e\xadR\xe8\xf6\x9d\x1c\"\x8d\xc5}W\xca\x17\xb3\xc2g+\x0c\x8d\\\x01\xf0+\xdd\x8c\xd0\xa1\xb9\x879!\x99\xa7\x89#-\x84\x1b\x11\xe2]\xaer0\x1f\xa6d\xbaf\xa6\x95E<\xc1\xc32}g\x00\xc0\xb8\x06-\xd1{\xf2\xe7\x9f_\x15\x17?\x17?\x15\x17\xe7\x17\xe4P\xf0P\xab}\x0eP\xd5s~\x95\xb9\x7f\xa4@\xb2\x14f1&\xb3S\xb3N\xd92g\xe9\xf0R*\xd7\xd3\xef\xd5\x90\xb8\x1dwS\xfd\x83\xdc\xdc\xbd>?o\xfa\x93'\x0d\x13\x85\xfd\xb5\x1a\xbd\xcbu\xf9\xfb\xb1<\xd6\xdb\xab\xb1\xder\x95\x1b\xeb,%\xfeQ\x1c\xfe_\xf5\x95HzY\xf6\x94z>\xcc\xbe\xc1sNJ\xfd\xba\x8cRV\xb6E\x0cY^\xa4\xd4j[\xe7\xfd\x930\x15\xa6\xcc\xe43\xca\xa1\xfa\xf5\x0f\x1e\xa7\xbf\xd7=\x07	\x18f\x0f\xfa\x87Dx\x14E\xa3|\xc8Y0\xdd\x1a\xa7\x94\x89\xa7[\xcf\x08\x11QfR0.]}\x12{\xce\x91\x7f|d\x90\xbeu\xf8o/\x8b/\xbf\xcb\x8dZZc\xb1E\xb2|\xb4\xe9n\x84\x11[\xdeK\x0c\xfb=*J\xae\xe3\xe4\xecAF\xb8tYE\x05`C\x17\xd9q\xc17\x00^\xf7x\xf2\xc8\xf6v\xc3\xabvJ\xbc\x98n\xdam\xdaG\xf7yW&\xfapk\xe5U\x8f\xeb\x97Nn1\xc4\xaf\x92n\xee\xae\x16pM\x97\xfa\xef\xff\xfe[\xfc(\xe3\xfa\xea./\"\x0bz\x0f\xb7W\xbf\xc4\x92\x17\x91\xc9q\xf3\x92\x98\xa1\xab\x0b\xca]>\xd0\x02m{\xa4^T\xf3\x96+m\x95\x7f\x97\xe8\xd5\xd6\xfc\xf1\"\xdf\xeamU\xa0\xcf \xf8\xfb\x07kw\xf1\xbfR\xdb2\xdf\xee\xc5\x03\xf1\xb6#\x11^\x0e\xfa(\xa3\x0cE#_\x16\xa9S\x1ei q\x15\x9b	2)\x1d!\x03P\xf7;\x1a\xebY\xde\xceD\xb8Pc\xc3}\xb3>\xc6\xfb\x1a\xe9\x842Qr\x89\x01\xab\x90/\xa8\";\xb7V&M\x0e\xbd3Q}C6\x8d\xbe\xad\x0c4\xcat\x01S\x97Nh\\\xa3\x0cz\xa8\xed\x01\xb45[\xaa\x8eh\x82\xa2\xb6O(\xdd9\xbc\xb2\x8dP\x06\xb4\xda!L\x1dn\x955g\xff\xb4\x069KO\xfbRPSjW\x06\xa6\xc6\xda.L\xe1\x05}\xac!\xa5\xca\x03C\xa83C\xb9=\xb8\xba\xf9\xed\xdd\xd9\xcd\xc7\x0f\xc0\xfb\xa5\xbd\xd0/\xa3\x9c\xb1-di\xc9\xef\xe2\xd7 \xf4Y\x0e\xb5\x8d\xf4i\xcc\x9e>@aP\x98>\x94}J\x90\xb1\x1b)N\xda\xdf\xd8\x8c\xd0\xcb\x1f\xcf\xa7Y\x0dE\xde\\\\\xd3\x8a\x15\x0d\xe5\x95\x0f\xaaA\xdb\x85\x15\xfcx\xfe=\xae}-\xaaZ\x19|\xec\xde\xc1\x02\x1a\xfe\xa4\x80\xadNS\x11KD\xf15|U1\\\xe5(\x0f5\n\x1d\xea\xfe\xe6\xe4\x86\xc0\xfb\x0f\xd7('\xde\xb4h\xeej\xb5I]\xf2,\xf2\xf0\x1c\x0b\xa7~1\\v(\x93RZ\xb0p\xe0\xaa\x9c\xbf\xd1{\x0eSO\xee\xc9\xd7\xa3;\x0d\xdb\xa2\xf1\xc4\xe0\xb2\x11U\xad\x0c.E\xab&\xff;\x00PK\x07\x08\xad\xa5\xban\x9e\x0e\x00\x00\x08)\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Y\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-common.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec=]s\xe3\xb6v\xef\xfa\x15\x18\xab3\x9b\xdc\xae\xb4\xc9\xcd\xbd\xc9F\xed\x8b-y7\xbe\xb1-\x8d\xe4\xdd\x99L\x9av \x12\x92\x10\x93\x04\x0b\x80\xf2*\xbd\xfd\xef\x9d\x03\x80$H\x82\x1f\x92%\xad\x93\x86/\x96	\xf2\xe0\xe0|\xe3\xe0\x00\xec\x9f\xff\xea\xf5\xd1\x98\x13,	\x92\x1b\x82<\x16\x86,B\x9c\x08\x96p\x8f\x08$7X\"\xcc	\x8a\x88G\x84\xc0|\x87$CBb.\xd5\x0b,&\x1cK\xc6\x11\x8e|u\xc3#\xf1\x06yA\"$\xe1\xc3^\x1f=l\x88 \x16\xc0\xbf\x84\x89\x90\x7fAK\x82<\xd5\xad\x8f\x96d\xc58)@\x1b\xeep\x18(\x90)$u\x83qx\x8ar\xb4\xc5\x9c\xe2H\n\xd3\x01\x128\x8c\x03\"\x10\x0e\x02\x84\x85HBb\x10G\x82F\xeb \x07\x8c\x9eh\x10\xa0\x10GxM\xf2V\xd3	\xf2\xb8\x8fh\x04}\x00D\x82.8c\x8f\x03\x18\xd1\x05\x8apHD\x8c=2\xec\x01\xcdnV\xc5\xe1G\x84\xf8\x02Hc`\x87I i\x9c\xc3\x16\xe8\x0b\x1a!\x9f\xaeV\x84\x93H\xe6\xe0\xc4\x97\xaf\x91 \x80/A\x82x\x92\xb2\x08-I\xc0\x9ez}\xb4b\x1c]\x18\xe4\x06\"&\x1e]Q/\xa7\xe5\xc5P\x0d>\xfb_\xbf\xa7G\x9e\x82R\xe3\x05\xe4\x007\x8b\xe8\x00\x9a`o\x93#\xd2\xeb\xa3\xa7\x0d\xe1\xa4y\\\xd0h0*\xf7\x8e\x97lK\x8a\xbd\xfb\x0cEL\xda\xfd\xe25\xa6\xd1\xb0\x97\xcb\xdf\xd9\xae^\xaf\x8f\xee\xd3\xc1\xba\x86\n\xe2\xc6\xe4\x86p\x04l\xb7\x07\xc63\xfc{8\xa6\x1f	\x17\x94E#\xb4\xfd\xba\xf7H#\x7f\x94\x83\xed\x85Db\x1fK<\xea!E\xd9\x11\xcaDh vB\x92\x10\xa1>\n\xd9\x96\xf8h\xc5Y\x987\x17\x181\xbd\xbd\x1b\xa1\xab\xeb\xf77\xf7h|=\xfb\x01\x8d\xe7\x13#\xe9\xe3\xf9\x04\xf9\xc4\x0b0\xc7@a\xd1\x1b\x0c\x06\x05\xa4pL\xc9'I\"@Q\x0c\x1f\xdf\x8a!eo\xb6_/\x89\xc4)\xba\xe3DH\x16\xce\xcd\xf8&dE#\n\xc0\x1c\xd8\x03f\x86\xddb\x08\xff\x0c\x01\xe1!e=\x10Gxn\xcdY\x12\x8fP\xa1M\x8f]@3B\xa6O\x12o\xc6\x1a\x90\xba\x1bP!\x7f,\xb7\xdcR!Uk\x1c$\x1c\x07\xc5\xeeU\x03(l\x12`^h\xea!$<\x16\x13\x8b\x11~\x0f\xa1\xad\xc5'\x84\xb68\xa0\xbe\xa2\x99F\x8b\xc5$\xba\x9c\xdd|\xfcf\xe1mH\xa8\x18\x06W\xcc\xc1\xa2I\x9ab\x0fW:T\x84\xea\x9f\x81\x0bG\x11\x93\xaa\x0b1B\xff\xf3\xbf\x856\xa0O*8\x85\x06w\x97\xe9\x85\x83\x80=}\x88D\x12\xc7\x8cK\xe2\x97\xdf\x85K\xee`\xe8K\xc6\x02\x82\xa3J;\x0d\xf1\x9a\xd4\xbf&$\xa7\xd1\xba\xd0\xecc\xb1Y2\xcc\xfd}\x10%\x11^\x06\x87\xe0\x97\xf0`\xc6\xc9\x8a~\xda\x0bG\xb8\x80\"\xf5/\xd1H\x925\xe1\x8e\xf6\x90F4L\xc2\x11\xfa\xca\xd5\x88?\xe9\xc6o\xff\xfe\xf7o\xfe^y@\x88`?\x16\x801\x98P\xfe\x03\x13r\x86\xe5\xa6\xfcr\x8c\xa5$<\x1a\xa1\xff|\xf3\xc5\x7f,\xfe\xf5\xcb^\xc7\xf1\xfbT\xf0$\x06Q\xbbSv9$\x91\xdcG\xaeB\xecmhD&\x19\x98\xab\xc4_\x13\x99\xe9O=\x1fk\x98\xa1\xdd\xde\x8c\xf9\x1a\x90\xd8_\x10\x98\xf0\xef0p-\xc2\x91G\x1ehHXr\x00\x7f5\"w\xee\xf1\xed\x89\x96x\xa4\xf1\x87x\xcd\xb1O\xc6\x1b\xe2=V^\xaf\x1fQx\x80\x9e\xdf\x99\x98aF\xf8=\xf3\xc9\xfe$\xf4XR\x15\x83\x82T\x7f\xbf\xaf>4+\xd3\x96\x05IH\xc6\x01\xa6\xe1\x03	\xe3\x00KR\xb1|\xe1\x9a\xefc\xf1B\xe6'\x81\x8bD\x08QIBgC3D}\x81{u\xbf\xdb*\xdb\xad\x16\xae\x8d7\x11\x91O\x8c?\x8ez\xdd\x91\xde0!\xef\xdd\xaf5\xf7e$mK}\xc2\xeb\xdf\xac\xd1bA\x02\xe2I\xc6\xab\xfeKH\xc6\x1d\x8e\xa4i\x08]lT\x9b^\x1cj\xabZG\xda\xd5f\xb5\x13{/\xdb\xd5\xc5?\x1db\xc3\xda\xd1L\x04\xb9\x0c\x02\xb0+b\x7f\xc3\x121\xff%\xaa\xa4\xc7\xa2\x15]\xd7\x81\xef\x82\x02\\i\xd4;![\xea5 \xd4\x11\xa9T[\xc8\xc3.>\x0e4;L\xf8bE\x03\x02\xcaH\xfe\xb9\x0c\x12\xfd\xeb\xcb\x7fi\xe8\x05\x02\x90%\x16dA\x7f#wWG\xc1\xe7	\x07G\x84\xf6+KxtT\x88L\xf8bF\xf8\x11\xf9I\"\x8f\xefbI\xfc#\xc2,pU\xf2\x84\xfcs\x85\x03Q\xcfL\xad\xc0\x1a\x81\x06ynVd}\xf9\n\xc8;\x1aH\xb7\x8f\xe8Lm\x0d\x08\x02\xdbc\x00\xa3\\9\x9f\x06\x8b\x91\xaa\x0c\xe6\x1c\xefjI\xd0\x10'\xecc\x17\x14\x83*\x11\xfb!\x03\xeb\xc6\xb33\x0d\xaa\xd9\xeav\xe6V\x1aq*\x0b\\\x8e\x16\xf2+\xcb\xa2\xd4<\xd34\xf4V\x89o\x96\xf56)o\x14\xc9.\x92\xdd\x0c\xa0Y\x9a\x9bY\xfe\xacH\xb7Yj\x1b\x916\x0e\xd5\xc5*\x13\x00\x8e\x03,\x84f\xc9\x82\xc8*WC\x16Q\xc9@\x19F\xbd\xee\xce\xf8\xe0\xec\x01\x87\xb9\xc2a\xb3V\xbe\xf4\xef(\xe7{\xe3\n\x91<\xe1b\xb4\xd7d\x89\x13\xc8\xf9M\x17\x13q\xb3\x9a&\xf22\xf2\x17xE\x1e\xd8\\\xdd\x1f\xf5\xba\xca5\xa4\xf6\xc0[\xeeO\xdcz|]\xfd\xc4\x01\xf6t\xc0^fpY\xa1\xb1\xef\xab\xe4!\x0ef\x1c\"`>\x869ad\xe830Y\xd0I1\x0d\xd2\xab\xe5\x8dO\x84\xc7\xa9\xcal\x8c\xd0\xc4\xe8\xd0\x0eL\x81\x8f\x98N\xcd\xff\xf8V\xe8\x80\xd4@\xf9\xc7bz\x0f\x8a:BC\xc8\xd4\x0dK)\x97\x02\x1ew,\x1a\xb3$\x92\xbdZ\xe1( p\x9f\x84K\xc2\x11[\xa1\xbb\xe9}]\x7f!\x8b\x86^\x064\xed\xe9rM\xcc\xf3\xba\x13\x1fKR\x05\x90\xc6\x9eC\x95\x97\xa7,\x82\xb9\x83\x908\x8c\x0bh/d\xfev\x1b\xce\xe3\x84\xab\x15\x06\xfb\x1d\x1be\x89e\"\x86\"kM\xfb\xf8\x81\xe0\xa03g \x9d[|\xa3\xda\x05\xa4<\x87\x1b\x0d\xd5\xa4\xb4\xaf\xef'vB\xbb\x92\xe6\xbe\xbd\xb9\xbe\x7f\x80\x1c\xf7Y\xd2\xda\x94\xc0\x12\xd2\xf3\xb3\xda\x00\xc7\x99\xd4\x86\x86\x9a\x9c64\xb9S\xda\x06\xda\x8b\xc8h{8.\xddI\xe5\x8f-\x7f%\x9e\xac\xf25\xe7`\x95\xbd\xef\x16gb\xad\x9a#\xa9\xd5\x96\xe7\xb3\xf7]\x06\xcb\xc1\xe2\xbc\xd1\xc9f\x0b\x0f\x07\xab\xf3\xd6\x97\xb2\x80\x91*\xca\x82\xf0-\xe1\xfb8p\xecI\xba%\xe3\xda\xfcc\x9ab\xfc\xba)9\xf9\xf5\xfe	H\xdd\xefB\xe2\xc8_\xee\xaah5\xb9\xb8\xb6\x15\x9bf7\xe8r\x85UB\xce\x18\xdb\xcbU\xaf0\x0d\x12N&,\xc4\xb4\x92?\xae\xb1\xcb\xf9\xc5I\x1cP\x0f\xd7,\x135\xf5\x0b\x97\xa0\xbf9\x83\x84V\xfe\xb5\xf3\xb0\x9d\x8f\x84c\x91p2f\xfea\xc8\x83y\x1fo\x92\xa8\x9a\xa2\xef&Gpy\xcc\xa7\xd1\xfaP()\xbb+\xaf\xd6G\xfa5Q~3\xa3ZE\xa4EH\xda\xc4\xa4\x9d\xd6\xcd\xa2\xd2AX\xba\x88K\x9b\xc0\xb4\x8bL\x97\x81\xb4\x8bM;\x1e]E\xa7	R\xcc\x89\x00\x9b\x0b\xf6BL\xa3		\x88\xacP\xb8l\xca\xbaE\xde\x97\xcaB\xdeM\x16\xbdZ\xe1\xa8	y}\"(\x87\x92\x0d\x05\x01\xddM\x16\xc8\xc7$dQm \\p\x1fC\xcb'\x1c72.\x07\x1d&\xae\xa8\x06\x1c\xf7g\x8b8\xa2\x95 \xcf\x8f5\xee\xdf-\x1cA\xc6\xfd\xbb\x853\xbaP}:\xe2\x8ahe\xeen\x18\xd7+\xba\xd9\\l%^Jd\xc9\xb1\xcfJ\xb7\xea\x1f\x86+v\xb8\xd2\x1ay\xce\xaf\xac\xa6f\xaf7\xc5\x81\xe1\xcf\xa8\xb7\x8f\xce\x1f;\xfe(kE*\xfcU\xb5\x98^\xfd\xe3z\xfc\x80\x16\x0f\xd3\xf9\xf5\x99\xf4CO\x17 \x87t\x045\x99*`\x0b\x00\xe6P\x17\xab\xd5\xa966*\x0e\xed\xb1\x9a_JX\xbe\xc6\x92<\xe1\xdd>\xda\"w\xf1~2\x0f\x97\x10\xc1\x18\xd4o\xa5\xa2\x839YU\x92?\x87\x97\xdd\x08\xe2%\x9c\xcc\xa0d\xc7\x05\x94FBB\xd9\x87\xd8\x1f\xf21u\xc8n\xf9\xa3\xc5\xf0\xcdT\xfc\xe3\x04\xe0\xa3^w\xcc\xff\xe4\xd5\xe7\xe1\xd5\x01\xf1n\xd9\xbdU\x9cX\x8b\x9f\xfb\xb0\xb8\x9e\x9f+\x1a\xb4|H\"\x8eQ9k\xf9\xb4\x0f\x82\xf0f\xaf\x07O\xb4y>\x85V\xb3\xf7K\xd2\x8e\xaaq$\xf7Xb~j\x98\xe6\xd9\xb6\x9ce#\x0fs\x06U9yu;\x1d\xff\x88f\xd3\xe9\xed\x998\xb8\x0c\x98\xf7\x08!\xe7\xf3yw\x05\xa0\xc009b\x95\xac\xcd\xc9\xaf\x1c	\x07\xa7\xb2\xc6\x0eQJ\x99\xee%rV\xe9\xfdqz\xfb\xe1\xee\xfa\\\x04\xd7U\x84\xa2NA\x1a\xa2\xc1\x8f\xea\xcd\x12a\xf5\xcd\nEM/%Rns\x08\x0e9\xdf\xb6\x13\x17\x07\xf1\x06\xff\xb5B\xe22\x05M\xbb\xae\xe9\xd7\x86\xe9\xea\xc3\xf8\xc7\xebs\xacvh\x1d]&\xde#\x91bh\xff\xe7\xa0v\xb99\xab\xa3\x17\xa3\xc2,\xde\x8c<\xcd\xf1(s\xee\x8f\x10\x14\xb2\xf4\x8a%\x83\xe9\xbd\x8ae\xd3V\xedJ\xe1U\xe2\xa1\xddT\xe1\xa4\x8db\x99\x9fv\x9b\x93\xab0\x02\xb64(\x0e\x10[ZS\xe2|\x83\x82H\x96yd\xa8\x1e\x86\x05\xb3\xa42\xd3\xaap\xb2\x96\xcf\xe3\xdb\xcb\x9b\xbb33\xdb\x83\xc2\xdcz\x8e\x1f\x8b\xb1FO\xcb\xdd8\xf4\xd5f\xab\xaa\x1an`\xbbjo\xe4\xbd\x1e^\x83\x00xY\x17n)\xf0\xec\xdfu\xa9\x91CD!gv\xad<\xa0\xf9\xf4\xf6\xfa\xea\xe6~rs\xff^\xd9W\xc3i-\x82s\x16\x90+\x1aAV\xb1 +|\x89\xbd!N\xe4\x86q\xfa\x9b\x9au\xe6\xd6\xc1!\x0c\xd9\xee\xa2\x81&\xcb@\xd3\xa5\xc7Y\xa0\xe6w=\x04\xa6\xe6\xbd\xe6_-\xec\x1eB\x15\xe4Z\xbb\x10\x89\xeaQ\xd1{`\xde\x87u%\xea\x91K/_.wo\x92\xca\x9a\x14\x17\x9e\xb3\x89\xaa\xcc\x99\x02\xd9\x8b\xcc\x99]\xcf/\x1f\xa6s\xf5\x84\xe2\x88\xdeqe\xb6?!\xa0\x99\xda6\xa7v\x18\xd0h\x0dE\xfb\xf6~8\xc7\x0e=\xd8M\x88\xad\x9d]\x9dYY\x11\x86F\xe6\xa6\x08\x84\xeb\x10\xb4%\xc0K\x92\xaeC\xa4\xbb\xdb4\x0d\x8d%S\xa5\xdc\x83%\xf6\x1e	\xb0\x05`\xf4\xf0z\xcd\xc9Z\x89\xd4<\xd1\xe5\"\x06,\xc8\xe2\"+\x0cW\xec\x0c\xb1\xf46\xb7V7HK\x8f\x1d+\xbdI!\x92\x81d\x83\x9c\x836\xb2#t\x01v\xe5\xa2\xa7\xeaxF\xe8\xe7_*\xae\xf0Dd\x1a\xa8\x1e\x0f%\xd6\xb1\x06\xdc\x1bd\xfag\x14\xe5\xe2\xa2g/,*j\x0b\xe2q\xed\xe9\x06(f~\xfe\xe3M\xc0\xd6\xe6	\xa5X\xbaE\x17q\x858\x86\x7f\xb7\x84/\x0d\xe8\xb5\xf2\xb2\x03\xb5\xf5N=\xf8\x04\\T\xbf\xe2\xec\x97\xca\xb6\x83v\x0fP\x12\x9b\xcc\xfc\x00\xf9jA\xa2\x8a-\x8eU'\x03\x94G\x83U\xf4}\x12\x07l\x17\x9a\x82\x87A\xba\x88@dw\xfc\x9a\xb1\xcaT5S\xd1\xc2\xbe\xce|\xf3jFW\xd0K*\x05bO\xd1\xe1\xda\xd9*o\x99-\xcb\xba8\xc8\x92\x1d(\xa3\xfbHX&U\x05\xd9)\xc8\xd5K\x10$[p\x06*\"#\xab$\xc8n\x14\x05\xad\x1b\xc2\x1d%\xcb\x18-\x87\x13\x00Q\x9b\xc3~\xe1\xf1|\"Ne\xe0\xd7\x01[\xe2\xe0PI8\x9bi\xd7h\x9e\xd7\xa8\xeb>\xcfh\xce\x9d\x83\xecb\xc8\xfbh\xc6`5\x15\xceQ@T\xa8]\xf9f'\xfe\x8aD\x1e\x84z\xa9aWO\xc3^\x1d\xf7\xe3>\x91\x84CMM\xb4\xd6\x95\x90f;;\xac\xcdB\xa8\x9b\x04>\xe2	\xac\x11\x0f\xb2JI\xf3\xebM\xcc\xd9\xa7]'\xf5\xe80\xa4\x01\"\xdb\xac\x90\xad\x8ff\x1f\x85\xdaE?\xfb8\xd6\xbb\xe6\xb5\xdd\xf5\xd1r\x97\xebI\xcc\xd9\x96\x82\x86\xab\xf4\xd2\x00\xc5 \x14B\x92H\xe63\xf4\xea\xdd,\xd6\x1f \x12\xf91\xa3\xfb(\xf9\xa1V\xc9DJF6\xab\x06\xc9\xb4{P\x13\xdd\xd1HV\x88\xba4\xb8\x95l\xdd\xafl\xd9\x0d\xe0\xfe\xb6\xd6\x16\xf2\xea\x98.\xferQ\xec\x17nT\xb0~\xe6\xeb1\x0b\xa8\xb7\xebj\xf9\xc1\x04S\xa5\x03\xa9o7\xe68\xdfa\x08\x8eKr\x16\x04\xa9P1?o\\\xea-\xc9\x15HK&\xb3\xa3J,P \xc1\xa05>\xc7\xd4\x06\xecr2\x83\xb4\x9a\xc68\xa16\xba\xe9rX\x0f6\x13\xd3h=\x84C\x08\xc4\x86\xae\xcc|\xb9$\x03f\x93\xb6k \xed\x82\xb6\xbf\\\x98\xee\xba!\xd5M\xda\xf7G\xa2M\xe5<A}N!sQD\xc0\xf8q\x1d\x0b^\xc2LL\n\xa8\xdb\x06a\x1f\x84k88\x06K\xc4\xc9\x7f'\x94g'\x98\x0c\x9ehfb\x1d\x1e\xb9\xb3\xc7j\n\x02\xc35Og\x00/\xde\x7f[\xb8\xd68\xf1\xd3\x13\xe9\x8c\x9e\xbc~\xb8\xdd|_)X>\x97\xa7\xadJ}q\n\x97\xe9`\xda\x9b\xf1<\xa7c_1\xb9t\xa0\x94\x9f\x96u\x16\xd1\xc0#5\xce\xb1-\xbe\x96\xe4\xa0=(h\x0e\x0br\xaeX\xa1ACppQ\xca\xa9v\x19	\xdc\xb4\x93_\x85\xb4V!\xe3\xb5\xb8\x9e\x7f\xbc\x19_\xa3\xcb\xf1x\xfa\xe1\xfe\x01-~Z<\\\xdfY\xa9/\xe0\x182\xc7\x16A\x82\x9fz\xcaZB\xf2N\xef\xc81q]\xca\xe3\xd7\x08\xafI$_+\x17\xeaS\xe1\xb1-\xe1;p\xc6\xc5\x99Q\x96\xb9)%\x04_\xeed\xba\xaf\xcf\xd1\x99%A\xb0\xd0\x922\xea\xf5\xb3\x1a\xcbp7\xe0dM\x85\xe4\xbb\x81N\xd6\xf4RJC\xfe\xb1\x86\xce\x05^\x14\xd8d\xa7\x85\xfb\xe8=\xc7\x91\xecBguS\xa4S\x06\xc9r\xc9HO5\xcb\xe9\xa0\xe9\x7f\xf44\xf3gNy\x1c\x96\xd6\xae\xc9g\x9b\xb1\xe4\x89\xec\xda4\xf6\x89\xa8Pf\xbf\xad\x8e&\x17\xe2\x8ad\xac\x84W6\xe9\x82\xe4\xc4k5/\xd3\xcai\xe8\x87R\x1bu\xda\xc5\x87,}\x91\x8d\xed\xa0s\xd1\xce+\x15vV\xb9vH\x9fO8,\xfb\xe2\xb4\x1d\xf9\xc1wg\xbaz}tE\xd64RY	\xb6\xca$\xb3\xba*\xa2\x0f\x0e$\x9f\xd4\xa1\x8d\xfa\x84\xc2\xec\xd0\xc6,6\xd7\xf7\xad\x93\x03\x8d	\xb3\xced\xec\xf5s\x1a\x0e\xd1\xcd\n\xedX\x82\x9e\x94\xb1d\xe65{}\x06 `\xd7\x11\x8c\xaf\xf5\x8b\xf6I\x89!\xf3\xe9J978\xbc\x92AD\xdaW\x8a\xb3\xd4z\xa1\xac,\xe3\xb0>\x17\xec>\xc3q\x86\xc66d\x0b\x95\xd6y\x86\xe9h\x1d\xabQ\xc79\xc8\xd0\xf4\xbd(\x05\x04\xe9\xdc\x1c\xeaZ\x10\xect\x1e\xa2\xbbDHD>Q!\x15\xed<U\xaa	<\xe5\x04\x18\xe7\x0fS)v\xc7#\xd3\xc5\xc4\x8df\xc9\x0079$&\xfc\x1a;|\\\x9f\x0e\xb86\x0e\xe6\xee\xfd\xbc\x0b\xe1\xee\xd6\xbc\x0b\xdd\x9eK\x96p\xcd\xcfB\x16\x18u\x81,\xe3\xbb	\x9a_\xcf\xa6\xf3\x87\xeby\x99\xe1\x95\xb5\xbfC\xe2D/\xf4\x07\x9c\xc0\xd9\x80\xa4a\x88\x19\x1f\x1b\xf1)b~\xfba\xf1pm-\x0f\xe7!T\x81\x1d\x8d\xb9\xed\x03E\xd51\xb5A?_\\\xfcR\x98\xc7\xa0\x9f/\xf2\x99\x8bj\xd3\xf3\x0e\xf43\xbaX\x13y\xf1\x1a]\xc0\xd4\x10\xfe\xaa\x19!\xfc\xd0V\x12~\xe9\xdc\x0c\xfc\xd2\x8b}\x17\xe8\x97\xc2\xec\x07\xa0[sjG\xdf\xd6Q\xa1\x00\xc6\xfe\xff\xcd\x8aF8\xa0\xbf\x11\xde\x82X\x0b>'\x9c\xc2\xeeE\xfc\xda\xa5\xda4\x07`M7\x0bs\xfe\x8e\xf9\xa9<\x90\x03\xc3`B\xbe\x0c\xb9S\xd1\x00&\xd5\x8d\x01\xfc\xf9V\x92rTjJ\x04NN\x82,\x0f\xe5\x94\x89b(\xbag\xe2\xc29\xb0n\xf2e\xa5&\xea\x85\xccJ(4\x8b\x9b\x9e\xb7C\x08&7&\xbc2\xec|%\xf2q\x1bZ\x1fU\xce\x8e\xa1k\xd9\xa2\xb5IM4\xe9\xdd\xef}\x01\xc6x\"p\xb1\x05?T\xf4P\xb6/;\x99\x9b\xea\xe2`\xf71\x99\xee\xda\x83v\xd9n][\xb0iV\xa1L\x91p\x16I\x8b\xb9\x97K8\x7f\xb9\x90{\xb1\xe6\x16\xd9@`f!aa\xa9Y{\x8e:\xa7.\xd5|e\xdaj=s\x9a2\xbfB\xc7\x9f;9b\xf1G\xf8J\x942^d\x14\x01\x8e\xc1\xa1M\xca\xcc\xd9Rv\"\xce4\x85\x12\x871\xa4&A\x05\x1d\x1d\xc0\x80\x06\xfc\xcaD\x85n\xe0\x00_ a\x1a\x93lH\xc3\xd4\xde\xfaLD:\xad\x81\xd7\xcd\xa1\xbe\xa7\xa2x\x93C9*\xc5\xa1\xa3\x03(\xde\x80_G\x8a\xdb\xa9\xbf\xcfO\xecf\x1d>\x94\xe6\xcdf\xc7\nN\xcf\xce\x81T\xdeU\x92u?\xf2[\x83::\x17\x0cZ\xa7\xa3w\xda\xc1q	\xee\xa68\xd8\xef\xdc\xcat\xa2\xb8]\x06\xa5\xbf\x0b#Y\xcc\x02\xb6\xde\xa1\x80y\x8a\x9a\xbd\xcaP\x8f\xca\x050\xa5'\xa1\xfe\xf1M{!\x1cr\xc4;\xc5\xd3\xd8\xca\xf1\x92\x1d\x15\x9d\xc8\x88w\x8a+\x0f\xa3u\x0d\x91\x0b=\x1e \xe3]0\xb6\xa9^GT+\xaf{\x9e\xab\x9c\xaa\x8f\x99\x0fE\xe6	\xa7rgj\xb3\x8e\x93\xad\xef\xf5\xed|\xfd\x89\xb3\xf5\xbd\xbeY%S_\x82zA\xb9\xfa\xa2j\x19\xdd\x9bM!\x89<\xfe0\xbfy\xf8	\xcd\xa6\xb77\xe3\x9f*\xf9O\xcd\x8a\xd2\xc6\xbf\x19\xf3\x17\x86W3\xd5^\xe7\xadcN\xb74 k\xe2g;\xbe\xf2[\xd9\xae<\xf5\x85\x19\xe2\x8fq\x8c\x974\xa0\xf9~\xf5~Z-\xa5\xca7\xc7\x8b\x1bS\xaa\xb0\xf8i\xf1_\x97\x93\xbb\x9b{UO\xb7\x12\xca\xdf\xa0\x81\xa2\xfd* \x9f\xf4\xf6K\xbd\x18\x8d6Xd\x8fxV\x17\x8a?\x9e*T\x8d\x19\xd4~R\x1c\x04;X\x1a\xc0\xd1No(\xeb\xa1\xf4M\x18\x97>\xeau\x84\xe6It).#\xa8\x1d\xecC\x8d\xeb\xa5\x80\xed\xce\xaf\x11|!'P\xc7<\xe0@\xbd$\xd0@/}f_\xb0\xe2I\x84\x04\x0b	\x88\xbb@X\x00\x95 Y\xd2\xd7\x99wuW\xa3\x04\x99\xf6$\x82G\xb2\x05\x0d\xd8\xe1\xfc\x1a-\x13X\x88\xc7\xaa\xfa\x81#*\xa2W\x12\xe1\xe0	\xef\x04z\x8c`3\x01\xde\x10\xec\x83JI\xaav\x95f\x18\xd6\x8c\xa1\x8av\xed`\x05\xb9\xa5Q\xf2I\xe5;\xf4/\xa8y$\x9f$\x943&\x91\xa3\xff\x7fC\x82HD\xe1Ke\xba|\xf2\x89\x04\xc1@=\x08\xfb\xda4\x94\x9a\xfeL-n.\n\xf0e8\x12A\xc1\xb29\xdf\x0b\x80\x1b\x91\xd0s\xaa;\x9c\x1eZ\xea\xb3\xa7\xe8	s\xffr\x96\n\x0d	c\xb9\x9bPn\xfe\xcd\xab{?\xe6_\x101m\xa6\x80\xc2<\xc8\x19Xe\xe2\x97E\x12\xfc\xff<\xad\x05\x1b\xa0M\xf1\xb0\xd7\\\x0e\x15\x7f\x8d\x8c\xa7\x1f\xe1\x11\xb0\x12\x05L\x06\xf2H\xa6\xe0\xbcJ9\x08\x90\xcc\xe8Q\xa8*]t\xb11z\xda\x10\x95\"\xdb\xa9e\xb4U\x12\x04\xbb\x8c\x96\xfd\xec\xf0\xf2\xdd\x00\x0e\xdb\xf7a=H\xa0\x10?\xc2\n$\x15h\x83\xb9\xb2T\x11\xa6\x81\"\x8f\x13/\xa0v\x1f\x99j\xae\x8d\xf9,\x12\xbax\xc3\x93\xe8M\xe2\x93\xed\x05\xbc\x04#\x9f.&p\x86Bl\x9e\x87\x9c\n\xf6\xa7Q\xb0\x1b!u\x88|\x0d\x9c#\x80\xd8b\xfe&\xa0\xcb7\x10X(X\x8c\xa3\xa7\x0d\x96dK \x87M\xca\x1f<\x82\xf3q\x12\x02\"\xaa\x89\xdd\xd8\x9d\xd2Ccu\x84\xe6\xc4\xcdl\xacF,\x88\x94\xe0\xad\x928?\x8c\xdf\x1c\xd2-zH={3\x1bgV\xcd\xe8\xb4\xe2B\xfa\xf5;\xb1\xc1\xe6\xebv\xea\xe3~\xb3\x9bI\xee\x90\x0c\x88\xd9\xcd\xc4\x02a}\x83\xa5$1\xaf\x14\x89^\x81n\xc1C\xe9\x07^\x00Am\x14\xa0:\xaaW\xfc\x8aK\n\x17^\x80sp2\xddR\xa3\x0e\x89\x10$Z\x13\x0e\xf5\xf1\x92y,\x80\xd5/x`\x00\n7B\xdf~\xf76\xfdxO\x88?\xc1\xff\xdf\x7f\x85\xfa\xe8\xdf\x07\xc8|\x1c\x0c\xb1\x00(\xb2\xc2I\x002\xcbe\x1b\xf8\xbf\xda\xe0\xbf\xf9\xe6\xab\xf4\xe4F\x05>\xfb\xdf\x00\x98_N\xa6\x0b\x05V\xa4\x12(^\xc3\x99pD\xd8`\xbe}[\x04\xf3]\x0e\xc6\x00\xca\xbe.\xa6\x80\xa1\x1f\x1e\x1ef\xe8\x0b\xf8H\xa0eb\xbe4\xa8\x1b\xa0\xdf}\x95\x011\xd8Yw\xea\x80.\xec\xd1\xbd\xfd\xdb\xdf\xbe\xb1\xd1\xca\xfe7H\xc1to\xc6YH\xe4\x86$\x02\xdd\x11\xc9\xa9W\x18\xd8\xf7\x7f}[\x80\xa0\xfe\xb7\x83\xbb\x06\xb7^\xf0\xff\x0e\xbf\x8fLt\xbd\xa8\x04\x00\x8d\x13\x93\xea,\xae\x1a\x08\xbc\x8aE<\x02]}\x95\xe6d\x81&\x85\x85,\x84\n\x15\xf9\xa5%\xad\xb4\xd5O\xe3C\x15\x93P\"\n\x8f\x96\xf6\xd7\x97\xc3\x0f\x84\xac\x84.\\\x03P\x90g\x8e6\x9d\xc8\xd5D?V\xa9\xd5 \x16\xf11\xa7i\x16Q\xf3Y\xc3\xe7\xd9\xe5|\x00	\xbb\xd2\xce\x98\x12E\xbc\xd3'zR\x92v\x9f\x87\x19\xfc\xeap;!e\x98\xf0_,U:\xa7y\xf7T\xbc\xaeR\x03i\xba\x97O\x9b\x96\x84\xec\x89hc\xe7\x0b~\x07D\xda7\xbdQ\xef\x01s\x07Wp\x85\xe3\xc5\x8d:n\xe8\xdd\xe2\xe8\x95@\x82*\xfcVb\x10\x07\xc9\x9aF\x03\x81\xeb\xa8}\xc4\xae\xf2\x9d\x8d\x0d\xfd\x19\x12\xa8\xa5\xdcz\x02\xd4\x11\xea\xa8\xcb\xba\x15\xc9KGfh\x97~m\xa402o\xb5v\xc7\x12iE\x92\x1dA\xa0\x9f/\xb2\xed\x9a\xa61-JJk\x92\xb2Z\xa4\xb4\x06\xc8\xd4\xfb\x14j\x92L]\xd0/\xdd\xba\xcc\xd7\xf8j\xfa\xac\x96\x1b\x99N\x1d\x1dxL\xe5\x8el\x95s\x0d3 X\x90\xe3\x8c\xd1-!\x8e\xf5\xfe\xa2X\x18%\xb3\xaa\xa4l\xc3t\xb0\x90d\xd2\x00\x92n\x0b\x02\xd8r%\x0d5\xc2t@ld\xa9\xad\xd5\x91\xd2\xa5\xf6\x10$\x1fsg9\xee\x16\xb7\xd4\xf3#%y\x1d[R\x9bX\xd2Z\xdbR\x1f\x87/Pw\xa6-\xdd\xa9k\xb4\x9c\x9d\x9e\xa3H\xcb\xd9\xb1s\xb7\xe0\x11FP5\x03.\xd3\x06\xaf\xb7\x99\x18SW\xd9\xd1re2\xde\x08\xb6#\xb0\xf2\x1e\xfa6T3K\\\x8f\xb3\xd97\xd2`\x07u\xea\x0cK\x89\xbd\x0d\xe45\x8f\xd0\xa9\xab\x9bn&\xfetb\xe8t\x8d<\x89\xe0T\x83\xf3h`\x03\x06gT\xc7\x06,\x9e\xa1\x9b\xdd\xc7\xd6M\x13\xcc\xee\xce\xcf\xa9S\x95Pco\x91/\xab\xb3>\x12\xc3<\xf9\x0c\xf5j\xd7i\xf3\x84\xd9,\xd6\xa9\xc7\x8e&J\x1f\x1fR\x86\xd8@<c%^\xa3\x8b\xb8\xae\x9f\xf6\xf1\x9c\xc9Fuq\x0fOf\x14\xee\x18\xa3\x10?t	2\xec\x18\xb0\xb3r\xef\x9dZs\xcc\xae^V\x8e\xcd\x81`}\x08y6Z\x15B\xda\x17K\xb0\x02\x96\xcdT\xab\x15\x9c\xe7\xfb\x95bt\xf6\xcc\xb9D\xbb\x0cX\x8c\xa8\x0c\xaa\x05\xb5n\xac;\x07\xbdl\xd6\xc1\x80>\xd3\x0c\xcc\x0e0J\xa4k\xf0\xeb\xcf\x9a\x8b\xb9L`\xc5\\\xce\xaf*\xfb\xd3\x8e\x91\xfc\xe1K\xff,I&\xd5\x8f\xc5\xe1\xce\x19&\xd7\xb8\x9d\xc49On	\xc6\xf1\x87O,9\"\x97\x17\x9ca\xca\x98_+\x16v\\q\xd4\xdc\x12H\xc3y\x12KN\xfd\xe9\xea\x0e\x8a\x15\x84M\x12|\x90\x19+\x93\xd9\xc9\x87B0\x983\xc26\xb7\x07kk\xce\x08\xcb\xb3\x9dx\x1e[\xed\xf1\x1c\xf3\xd6j\xaf\x87\xccS[q\xeffO\x8e7/\xfd3\x15\xf5\xff/\x15Uk\x87\xce\x93\x87j\xe9\xfe\\\xca\xdc\x10S\x1e\xaa\xd9\xddG\xd5MB\xfeL?=+\xfd\xf4\x02\xd35\x07X\xa3\xdfY\x12-\xc2\xb1\xd809l\x1f\x98I\x84\x9a\x17\xbaQ\xb01\xf9yp\xdf\xaa \xda!\x1f\xb9\xf0\xb7 \x93\xaf\xcc\x1e\x93$\xcf\xcc\x9b\xba>\xb6\xe4\xa2\x85\xa7>\x12\x93J\x86\x9f}$\xa6\x81\x1c\x155\xcd\xd7\xa5\x0dvf\xa6tLr\x887pr{RA+\xeb\xca\x11\x1c\x17b\xdf\xd6\xe8\xd8\x9e\xadt\xf6.\xb5\xc9,\x87\xe3u\xce\xfd_\\F\xaf\x9c\x99\xa8\x99\xec\x9c\x87J/;\xf9\xb9\xcf\xe4\xd0\x19\xc7\xa5dxf0s\x94\xb4gG\xbeW&\xb9vP\x961\xb12\xd1\xe9\xc8\xaeSS\xe9H\xc9\xcege\x05\xea\x08\xd6\x10?v\xa4^\x8b	\xbc\xba\xb9\x9f\xdc\xdc\xbf\xef\xfd\xdf\x00PK\x07\x08\xe1Z\xf1\xc4D\x17\x00\x000\xa2\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00[\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-operator.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xec[os\xdb6\x93\x7f\xefO\xb1cM\xa7\xc9\x9c\xfe\xc4i\xd26\xba\xb67\xb2D;\x9a\xc8\x92N\x94\xddf:\x1d\x0dD.%\x8cI\x80\x05@;\x9a>\xf9\xee7\x0b\x82\x7f$\xd3\x8a\xd3'\xed=/\xaa7\xb6D`\xb1\xbbX\xec\xfev\x17l\xfd\xdd\x9f\x93\x16,\xb7\x08!\xa6\xb1\xdc%(\x0cDR\x81\xd9\"()oA\xa6\xa8\x98\x91\xea\xa4\x05C)\x0c\xe3B\xdb\x87\x81L\x12)@\xa31\\l\xb4\x9d\x94Hm\xe0]\xb6F%\xd0\xa0\xae\xd1\xd4\xdd\x93\x16\\H\x05\xf8\x81%i\x8cm0\x12\x02\x85\xcc`\xb9T'\xc0t\x0bA\x9ci\x83\xaa\x7f\xd2\x02\x80\xdbl\x8d\x81\x89\x8b\x91\x9d\xc8\xf2\xd4\xc9\xd7\xee\xeeX\x12\x1f\x1bV\xb0\xfe\xc9\x81nM7\xee\xa4\x05\x83XK\xd0\x88 \xcd\x16U\xa9\x03\xd0\x96y\x88x\x8c\xb9\xc4wLqf\xb8\x14\x1adT\x8e\xb3\x84H\x82N\xf9\x131#\xf4\x96G&\x7f\x08\xc3\x06\xfd\xa9L\x08.6\xc0\x05\xccR\x14>\x0d\xdf\xa7r\xcf\xcd\xb6\x13h\xee\x88x\x82\xad\xe3\\\x83\xa4<z\x02\xa1\xe2w\xa8Nj;\xfc\xf7|NZ0\x9b\\\xf5\xe1\xdc\xbb\x1cOa6\xf7\x16\x83\xe5l\x01#o>\x99\xbd\xbf\xf2\xa6\xcb\x13\x96\xf2\x1bT\x9aK\xd1\x07\x96\xa6\xbawwvr\xcbE\xd8\x87Qi('	\x1a\x162\xc3\xfa'\x00\x82%\xd8w[N\xe2\x15jp\x8ft\xca\x82\xbd\xe7z\xa7\x0d&\x00-H\xe4\x1d\x86\x10)\x99T\x8f\xab9'\x001[c\xaci\x11(\xf7('e\x7f\xd2F*\xb6\xc1\xce\x9a\x05\xb7H\x1c\x12\x81\x13\x9db@S4\xc6\x18\xd0\x04;6a&\xd8Nj\xf4\x80\xa4\xab\xf3U,p\x02\xa00\x8dy\xc0t\x1f\xceN\x00\x0c&i\xcc\x0c:B5\xd1\x01\xf6y<N\x15\xa0`\x8d>\xa9\xe2Rq\xb3\x1b\xc6L\xeb\xa9\xd5aM=\x9d@q\xc3\x03\x16\xbb\xd1\x1a\xd5\x1d\x0fp\x10\x042\x13fz\xa0\xf2\\\xa5nh\x90;\x00TNq\x00\x9dc{\x94\x7fx\xc26\xd8\x87?\xfe\x80@*\\)L\xe5JIi\xe0\xe3G\xfa-S\n\x85Y%L\xf0\x08\xb5\xf9\xf5\xeb\x10S\x14!\x8a\x80\xa3\xfe\xfa\xb7_\xbf\xa6Y\xf4\xb7\xe4\x89\xbeh\x15|\xfd\x1b|\xfc\xe8\xd8\x00`j\xa3\xfb\xf0\xeb)\xf1p\xda\x86\xd3\x82\x8d\xd3\xdf\xca!w2\xce\x12\xbc\")K\xfeI\x82\x84~\x993\xb3\xedC\xef\x8e\xa9^\xcc\xd7\xbd\xd2\x10\xf2O]H)\"\xbeyd:\x9a\xa0G\x1c\x94\x8f\x0b\x1b\x0e1bYl\xdc\xecN\xc8+\xfd\xa0\xb8\xabs\x93/u9\xbb\x1a\xfc2_\xcc\x86~\xf9\x08\xe0\x8e\xc5\x19^(\x99T\xe3\xe9\xa3P\xcbL\x05x\xc11\x0e\x17\x18\xed?\xad\x9e\xf7!\xe6	7\xba\x1b\xa4Y9\xa4eM\x83v6\xe2\x9bLY_f\xfd\x1b\xa4\xccTr\x14\xfb<\xf4\xe6oW\xc3\xd9\xf4\xe2\x90\xad\x03\xdd\xf5\xca\xdd\xaa\xfe\xeb:\xd5\x95S[0\x8e\xac\xef*6\x0b\xf4Vfq\x08R\xc4;\xb8\xa7ce\x9d\xacs\xcf0\\\x8c4\xf9F\x9a\xa2Y\x82\xd5\x81nS\x1c\x02\xb3\xe5\x9a\xe2\xca\xa9Q\x19\x9ev\x0f\xd7\xe1\x1a\xb8\x06!M>X\x02\x0dk\xef3p\xcf\xe3\xf8\xc8\xca,\x8e\xabEu\xf7\x81z\x16\xb3\xd9\xbb\xd5\xf0z\xb1\xf0\xa6\xcb\xd5tp\xe5\xf9\xf3\xc1\xd0[\xcd\xa6\x93\xf7\x87\xfb\xd8\x87\xd3\x88\xc5\x1aO\xcb\x07-XJ\x08\xb9\xb6.}q>\x18\xb6!\x13\x14\xea(&\x13\x97\x91\x8ccy\xcf\xc5\xa6\xff`_h\xf8\xca\x9b\x0e\xce'\xde\xe8\xd0b\xfaN!\xe5\xef-XPd\x1fl,a\x19\x93\x1b\xe1Rt\xe1g\x92\xde\xfd\x80VX\x8a\xf7F\x03\x85\x1d\xfb\xfd\x16w5\xb1[0\xdcJ\xa9\x11\xd6h\xee\x11\x05L\xa5\x1fl1\xcc(\xc0\xcf\x15F\xa8\xaa_\x80\x89\x10\xa6\xd2\xfb\x80Af\xf0\xa1\x08\x83KR\xdar6\xa1\xc01\x9eM\x0f\x0d\xbf\x0f\xa7\x15\xb1\xba0\xcff)\xb1\xcf\xe2\xe7\xcdr\x11\xd3]\xf0k\x06B\xca\xbc\xc5\x1d\x85l\xfa\xd7\n	;\x99\xc1=\xb33K\x1d\xd4Vy\x8c\xcd\xd5;\xaf\xda\xdbV\x8d\xd9\x1f\xde\xe1n\x16-\xb7\xb8$\xf2K\xb9t4\x7f\xfa\x1c\xde5\xc4\\\x9b.\xcc3\x03[Th\xbfZ\xbe\x89\xa8nd\x9a\x8e\xc8\xfb\xc1\xd5\x84,8a\xa6\xfb\x04!\xfc&	\xfe\xb5\xf7#yJ\x8c\"\x0cL\xbf\xb6\xcd\x07C\x80\xd4\xda\x07!C\xec(\x19c\xf7\xb6\x04\x83].{\x14?\x94\x8c\xd3\x98\x89\x873\x8b3\xd8\x07\xef\x03\xd7F\x1fY\xdd\xd9\xd0\xe7-\x8e&\x08?g\xd1f\xb3*b+\x04\x14\\\xad:I\xf7\xe4Q\xa4\xb0\xd6\x94\xca\xf0\x99~^\xae\xb4\xaf\xf2\xf9b<[\x8c\x97\xefW\xc3\xc9\xc0\xf7\xad\x8b(GV\xa6\xd3\x14\xad?m5S\x19\xe2 \x8a\xb8\xe0f\xf7\xf8\xa6Og#o5\xb8\xb8\x18O\xc7\xcbf\xc3%\xdd\xfdX\x00 \xda\xca\xff.\xe0\xd0\x8f\xe4\xcb\xdb\xc4\xdd\xa7\xb9\xb1Q\x154\x06\x19A\x11Hd\x88]\x182\x01\xeb\x1d\x9c\x0e\xc4\xee\x14\xa4\x82\xd3\x05j\xa3x`0\xdc\xf3\xd7\xf9\x80L\xa3\x86!%\x05,L\xb8 x\x1f\xa20\x9c\xc5\x9a\xa8\xb8\x98\xda\x8bX\x1c\x13N\xab\x13\xa0T#\xd3\x84\xa5\xebK\xd8\xe3\x92d\xda\xc0\x96\xdd!\xb0\x9c\xb8\xc6@\xa1\xa1S\x83,\xa8\xa1\xc4B\xea\"*\xad)\xe9\x11:K\nd\xc9D\xdd\xa0\xc8\x04N\xad\xd4\xd7\x1a\xd5i\xe1g\xec\x12\x99F\xd5vO}\xbb\\\xf9\xbc\x96.9F\xc8\xa6\xea\xb2\xb8\x81\x15_\\\xc0\xfd\x96\x07[K\x7f\x9fh35+N\x9d\xe4\xbeQ^\xcd\xae\xa7\xcb\x95\xef\x0d\xaf\xadi^\xcdF^\xa3G\xa3M\xa9=\xc8=j\x0e\x14\xe0\xdez'b\xc8\xfa`fcK\xc0\x08K\x88\xd0\xfe\x1e\xc5\xf8\xc1a\xb0\xea\x98\x15\x8c\\L\xbc_nf\x93\xeb+o5\x1a/V\xf3\xc1\xf2m9\xa6b\xa0\x84\x18\xe4Vb4\xbd\x9c\\\x1ag\x1b.\xf4'x\xbb%\xc5\xc4d\x88\x19eo\xc4\xdb\x1a!\x92\xd9\xde.\x16\x0cM\xc6\xe7\xa4\x88\xeb\x89\xe7?\xe4\xa8U\xb1\xf4\x03\x01\xc7\xa5\x9c\xf0\xf5UNx\xcf\xbd[\xb0	L\xec\x00?\x18\xc5 \xe4\xca&\x0e\x1c	\xc8\xb8\x8du\xba*\xb0\xf5\xf1m\xf2\x9b\x98\xd02Ak\x06?\xf6\xb6R\x9b\x1eA\xb7~\xaf\xa4h\xbf\xb7i\x90Mg\x0fG\xbe<\x1c\xfa\xb2.\x82\xdd\xce\x11\xd7\x81\xbcC\xf5\xff\x8f\x16\xaa\x1d\x1a\x8d\xfd\xe1\xec\xc6[4A\x86z\x1c\xae\x82\xd5\xe9\x11\xef\xde \xe1\x97\xc5\x0d\x0d\xec~Y\xe8\xd0 \xc1_\x84\x1e\x1aD\xf9\x07@|\x1a@\x94\x1b\xf4\xa71D\xa9\xf8/\x0e#J\xde\x9e\x06%JF\xbe\x14\xa6\x80CP1\xa0T\xc7\x96\x15j\x95\xc2$\x8b\x0d/\np\x90\x97&t\x17\xa6\xd2`\x1f\x96.\xc1\x0b\xa4\xd0<D\x85u\xb7\xce\x04\xe0\x87\x14\x15\xa7d\x8a\xc5\x10!3\x99\xb2\xc6n\x034\xa3b\xa5\x0e\x14_c\x08\xcc\xd4fn\x8dI\xfb\xbd^(\x03\xdd%&\xbb\x81L\xec\xb7^\xc2(+\xb5\xf9~\xa4{u\xf2\x1dG^\xf7Z\x05\xcb\x1d\xca\xa9\x1d\xc7\xb6\x8a\xc7E\x87\xe5%\x16\x97d\xd6\xd6\xcc\xa3{\xc27[\x8a\xa1\x99FH(\x15\xb0\x8a`\xdar\xab)\xdd\xe2\xc2\xb2\xa7\xfb\xbd\xde\x86\x9bm\xb6\xb6\xcc\x95\xf9w\x8fk\x9d\xa1\xee\x9d\xbdx\xf9]I\xbdp\x9f6U\x1dL&\xb3\x9fWW\xd7\x93\xe5x>\xf1V\x17\xe3\x89\xe7\xbf\xf7\x97\xdeUu\x9e\x1f\xa4\xab\xe5\x93\xbcn\x1c\xcb\xcd\x86pV\x8cw\x18\x97\xa5\xe3\nW\x8f\xa7\x173\xf8\x17\x8c\xbc\xf3\xeb\xcbf&&\xb3\xcb\xd5\xc4\xbb\xf1&\x0dk\xd2\xec\x07KraP\xdd\xb1\xd8*d\x8b\xc1\xad\x0d\xf5[d\xb1\xd9\x169]\xbd\x96L@\x0d\xb24,\x8a\xcd\xda0\x93\x95\x95\x84 \xd3F&e\x15\xa5\xdb\xac)[\xf8\xf0\x97\x83\xe5\xb5\xbf\x1a\xbe\xf5\x86\xefV\xe3\xe9\xd2[\xdc\x0c\x9a\xb8\xfe\xf6\x85~\x02\xd3<\x02\xbcCE\xd8X\x00/\xf9\xf9=\x93*K\x1ea\xe3j6]\xbd\xf5\x06\x93\xe5\xdbO2\xf1\xea\xf5C&\xc2\xa2\xc2c$\xdc3n`\x8d\x91T\x08F\xedh\x0f\x8d\x84\x88\xf1\xd8Fz\xa9@!\x95Q{T\xb2$\xd8i\xb6uo\xed\nw\x96y\xb2g` \xf0\xde~}\x96i\x8c\xb2\xdc\x18\x02\x99\xa4(4\xa3\"7D1KS\xfaG\xa0\xb9\x97\xea\xf6\xf9\x11)g\xd7\xcb\xd5r|\xe5\xcd\xae\x97\x0d\xc2}\xfb\xe2\x88pE5\"tN\x8dV\x0c\x91\x8a\x9c\xe5\xa6\xd3\x19\xe9\x14\xcf!dH\x07\x0cM\xb7\xf9\x98\x94\xeen\xe4\xdd\x8c\x87\x9e\x7fL\xed\xaf\x93=\xc6~\xde\"a.R\xad6L\x19r\xed\x9a\x0ep\xaa\xf8\x1d\x8fq\x83\x84\x8eY\x91/1 \x00g!u\xdb\xb9\x01.\x828\x0b1\xef\xbaXgE:&\x93\x96:\xb4\xe4*\xae[\xa5\x17\x14\x18\xa0\xd6L\xedhe\xd25S\x84t-\x11&v\x19\x0f!\xf7\x0e\x84\x8eE\xd9y\x90\xb5\xceC\x9d,\xe5S	YJ\x88\x86\xf1\xd8\xfa\x9f\xa7\xf9\x9eo\xce^\xb5\xec\xff\xae\x92\xd5\xf9\xe6\xf5\xeb\xef\xde\xbc\xf9\xf6\xd5Y\xb3Kz;\xf3\x97\x04\xffW\x0b\xef\x7f\xaf\xc7\x0b\xcf\xa7`w3\x9ex\x97G*[\xe5\x83\x16\x8c\x05\x10\xd2\x05\xcdM\xe6@\x90\xefM\xb8\xc8>\x80B[\xf5\x8f\xc9\x1e\xd6\n\xd9\xad\x86g\x86'\xa8Af\xe69\xc9\x1e3\xb5q\xed\x9d\xdcU\xb7\xad\xa2C\x89Z|m\xac\x1esc'\xf7\x12iX \x0b\x7fV\xdc\xe0\x15a|\x97\xdc\xc0\xb3\x98iS\xac\x06\xf7\\\xe8\x9a\xa1\xb7`\xe4J|\xdc\x95txd\xf3R\x9b\x92j\x9e\xf0\x98)\xb77_d\x07^\xbe:{\xc4\xfb\xe7\xf5\xc2\x95\xefM\xc6\xd3\xeb_V\x0bo28\xa7\xff/\x9f\xa8\xe7\\[\x85\xd4\xdc\xe4\xd5S\xc3n1\xdf\x02\xd2-\x99_\xb0\x95\xf7\xc2&\x04d}6\x10v\x8fj!EekW\"\xc0/\xaa\x89\x97\xaf_5\xc7A\xa7\x89\x0b\xffr1\xbb\x9e?\xc5\xcc\n\xf6Yfd\xc2\x0c\x0f@\xaa`K\xd5\x0ckt\xf9\xa9\"\x87X8\x1eF[\xe7\x1c\x0e\x86\xcd\xe1x4\xf6\xa9\x82\xeb\xdc\xcc\xea\xedl9\x9f\\_6X\xbd\xab\x1c\x97\x8c\xb6`\xae\xe4\x1d\x0f\x8bh\xc65\x86\xa0p\x83\x1f\xc8\xd5\x90\xd6mh\xd2\xae&BG\x91u\xad\"q\xd3u\x03)t\xabu\x08kF\x93\xf3]m\xe7\xf3\xf2\x8d]S\xfep\x8bp\xfa\xec\x7f\xf8s\xb5\x0e\x7f}\xd1y\xf3\xdb\x7f\xed\xd7\xd9\x05\x04L#\x05a\xeb-\xcc\x96	\x90\x02\xf3%\xdaT\x07\xc9\x17'\xb3\xd0hAB\x95\x0f\x9a-\xd6\xe2^\x0bFy\x81\xc7\xf1W\xf0`\x97\x0f\x93N\xbe|{\x8f\x19\xfbM\x94\xac\xd5h\x0d\xc2B!\xf8!U\xa8\xa9\x0b	,\"t\x90f\xb6\x85\x0d\xacbm\x1d\xb3\xe0\xd6\x96Z)g\xd7\xb75B\xe3\xc8\xe9\x84k\xc0$5\xbb\xbc\x83\xe0\x8aQ\x07\xbcf\x1a\xc3\xee\x03\xe8U\x85\x93\x81G\x01\xfdz\xe4\xdd\xac\xce'\x83\xe1\xbb\xc9\xd8o\x8av\x9f!qs\xe8\xc1\xaaOl\xcb0y\x8f\xb8\x0b\xe7e\x15\x8d\x8e/Id\x07\x86\xd6\xebq\x0dQ\x16\xc7;\xd0Y\x9aJe0l\xc3:s\xa7|mo\x0c(\x0c\x98\xc1\x90\xa2\xaa=\xf1QF\xb0\x97\\\x1f2]G\x0b\\@\xc4\xee\xa4*\xe0\xd9\xd0\x1f\x17L4#\xd3\xe2DN\xbc_V\xa3\xc5\xf8\xc6[<\xc5\xf9?\x88\xb6f[\x9d\xba\x9d\x8b\xf3\xa4\x90\xaa\xc5\xa3\xd8}Y\xe8+\xce\xaa\x146\x03-\xd1\x82\xc3\x91\x0f\xe2\xac\xa3\x17J\xcc\xfbJ\x02)\x9aK\x8a\xa6\x85W\xa7co\xdbY\x1b\xe9\x10\x96\xbb\xcb\xb0\x93\x99\x82\x99?\xd2\xee\xc0I\x01~\x9e\x11\xd9\xb6\xed\xc8\xb2\xe2cQN\x99\xdf\x0c\xeb\xe1\xa0IW\x85Y\xbdwvU\xe3\xb6\xb4\xa4\x07\xe1\xb2v\x83\xa0\xb0\x84\xbb\xbcG_\xdf*\x82\x1d\x17~i6\xcb\x02\xcc0a+JMS\xf2\xb1m\x0b\x14l\x0b\x18REY\x81\xa1\xda\xd7\x1acy\xff\x988C\x7f\\l?\xa1\xee\x0b\xff\x0b\n\xb28\x1f\xfd\xfdR,\xceGM\xa5\xc3\x83\xa6\xdcc:\xb8\\\xcc\x87\xab+o\xb9\x18\x0f\xfd\xa6\n\xe4ao\xcf\xedhu\xf7\x86\xe4\xd1\x82\xa5z+\x0d\xf9\xbb\xb2\xd8G\xe6]^\x1bI)\x80\x90W\xdc\xb3\xf3\x82\xa9\xda\x9e\xf8\xd3\xc1\xdc\x7f;[.\xbdESA\xf4\x11v\xc8\x82\xe0]^\x82\x0db\x8e\xd4U\x94\xa2(\xca\xfe\x00\xaf\xbag\xdf9\xc0\xeb\xdc\x0d%B\x86\xe5\xd7lhvTU\x8d\xad\x17&\xccTtK\xc9\xe8\x1c\xa9\x9cx\x9b\x1e+\xba1A\xb7-lDN\xd8\xcez-\xaeU\x96\x92\xd3\n3\x9b\x19d\xe9F\xb1p\xaf<\xeecN\xd2=\x82M\xc6C\xec\x97h\x8b\xd0\x15U\xa3l\x15\x80\xbe\xf4\xee\xce\xba/m!\xa0SP\xdb\x9a$nV\xe3\xc5l1,,{\xf5\xce[L\xbd\xc9j8\x19\xd3\x05\x99G\x0f\xac\xfb\x9dJ\xa8\xd5i\xcc\xcb\xde\xce	Q\xf7\xc1\xa5\xb8\x16\x83\xe0f\xd7\xae\x1cw\x11\xfe\xc9\x1b\xcd\xc4\x88j\xe7\x94\x06\x84\xb0\x90\x16\x0e_\xdb\x99M\xb1\xb7\x8cu\x8f\x8d\xac[\x88\x13\x8a@\xcbx\xba\xba\x9e\x8f\x06Ko\xe5/\x17\x83\xa5w\xf9\xbeI\xba\x82\x97C	\x17\xeb\xf0?P\xbc\xc5\xf9\xe8\xdf\x94m\xd9\xecfI\xe4J\x98\xf5./\xe8\x16q\xd6:)\x0c\xad\xdb\x0d\xb6Llr\xe3t\xf3kK\xd7\x1cv\xee\xb7(\xdcP\\\xa6j\xd3\xc6\xdde\xb3\x90\xec\x9e2NNw\xd6\"\x1epv\x10\xe4\xf3Ej\x84\xcd\x16\xb5\xf3\xe2e\xff<D\xcd\x95E\x996\xd6?\\\xbc\xc1FJ\xd7F\x86\xb2\x1a_\x0d.\xbd&\xcd\xfd\x9e\xb1\x1d\x9d/:P\x81\xe6\xc5\xdf>\x9d\xb2\xee\xcb\xd3#t\x17\xde\xe5\x98\xf6d\xf1i\xe2\xb7\xdfkK[s\xdb\xe3\xec\xe4Lw\x14n8\x1d E\xab\x9du_\x1c[m\xbe\x98\xdd\x8c\xfd\xf1l\xea}\xdez5WK\xab\xbc:\xbeJ\xcd\xdf~\xd6*5\x97\xff\x04\xcd\x0d\x96\xcb\xc1\xf0\xedg\n\xc2\x8ca\xc1\xb6\xa0_\x97\x02\\\x87\xae\xecx\xed\\M\x83G\xe5\xa3\xe2\xceQ\x8e\x99(5\xa8Y\xe8a\xab\xcfN?fR\xef\xae\xcf\xbd\x89\xb7<\xda\xac;$\xfaH;\x85\xfck\x9eP\xd1\x01C\xf5\xc9r\xf8\xa11\xfc\xfb=\xf6\x07\xf5\xf0Z\xb7\x87\x0e\x0f-Y\x0f\xd9\x7f]\xd3\xe7\xc1J\xf7E3|\x8d\xdaTu-w\xfb!\xbf\x97e\xc13\xd3nCI\x96\"L\xd5\xb6\xf0Q\xf5U\x0d\xbd=\xc0\xf3\xcf\xbd\x94\xc3{)\xd6P\xed\xfe\xe4P\xe0Iv\x9a\x07\xe7\xbf\xcbDs\xc6\xfe2\xeb\xac\x84\xd7e\xf6U\x85L\x90U\xd9\x89\xf6^\xd7\xae)\x14 \xb4\x98e\xbb\xe2\xf6\x89\x93\xf2QK\xcd\x15\xf8\x8f\x91\x1e3\xd2a\xe1\xdb\xed\x0e\xb9j\xe9F\xa5\x81E\x9d1\xbfC\x81ZC\x82t\xfbG\x03!\x9fjv\x03\xa4\xac'A\xab\xf9l\xd1\x88\x95\xdf\xbcxsvz\x94\xccd|\xe3M=\xdf\x7f\x02\xa9\xef\xcfN\x1f\x95\x87\xcad\x7fF\x18\x02\x90O\x95\xe4\xc5\xe9\xe3 \xf4s\xc4x\xb1W Y\xba\x92\xacm\xfed\xc2\xf0\xeap\x803\xb0\x18\xdd5Xj\xfe\xe4H\x94\x9a\x0c4\xcb\xba\xf3\x1a5\xe7\xe7m\x15-D\x83A^G\x13\x90	Ew\xa9lnF\x83\xeagiN\xc4\x98\xf5\x999\xd85\xd4\xb8u\xef\x91\x10\x84\xafn\xb7\xb6 \xd9\xa86\xa9\xbb\x0dI\xa8\xdb\xa06\xf7m\x10\x91nS=\xc4\x15N\xa8sS\xb4C\\\xbd\x9e\xec\x0d\x8c\x94\xf1Z~\xa8\x11[\x16uP\x02\x1b\xb6he\x97\xb6\xefp\x10\xa7\xae\xd1\xa5\xf7\nz\xf9\x04\x19\xc17/^\xd0\x0d\xad\xba+fa\x88aU\x02\xb68z\xbd\x83\xdb\xefm\xf0s\xb76\x08\xe3G\xb5\xfb\xf0-\xf8\xc1\xaad\xff\x80\xd54\xf6Sm(\xb1l$u\xacY\xee\xa0(\x8e\xbb=T\xa8\xdd%\x8a\xda.QK\xd86BwV\xf1un]\xaf\xaf\xb6Q\xc4	\x95,Y\x98\x8f\xfe\x89l \xe4\x96\xe5\"\xfb ]\xe8,\x01Y\xbbW\xde\x02\xe8t~\xb2S:\x89\x14\x9c\xdeo\xd9(\x16`\x87Z\xec2\xec\xc3+\xab+)B\x0d\xcfH\x1d$l\xa72\xb0N\xc2\x04\xdb\xa0\xa2\x8e\xe0\xe6\xf9!]\x0b}\xaf\xa7\x0bo0|k\xab\x806XU\x0e\x97\xae\xaa\xcd\xa6#\xbf\x0f\xaf\x8bU\x1e:\x8f\xa7\x12i:~\xaf\xf7\xcf\x0c\x1d\x10\x96\x94)\x0e\x89M\xfaO\x99v\xc59\xd2Q(\xef\xc5=S!\x0c\xe6\xe3rr\xe1\xc9\xac\x00M\x97=\x1f\xde\xe6\x8f\x1e\xb9\xc5o\x7f\xa7\xcbf}\xfb\xaeG\x97\xb8\xa0\x975\xcaa\xb9\xb1\xa42,/\x94|\x06\x83\xf3\xd9\xe8\xcb\xf1W\xbc\xc7\xd2\x15\x8f\xf3g\xaf$\xfeI&\xfd\xf9`\xf8\x17pZ\xbc\x1a\xb4\xffVE\xedU\x11z\x95\xe2\xf7\x0cu\xfd\xf5\x11\xfa\x04if\xdfo\xa1R\xd0\xaa@n\xabb\xec*H\xb3\xfa\x9b*\xf4\xa1\xebzjwlR>\x82\xe6\xfd\xf1\x15%M\xfb\xc3\xf2\x179,e\xa9\x9a\x9f9\x02_\xd5\x17\xce\x1f\xf5?E\xf2\xab\x8fO\x10\xaf\xc6B\xce$\x8a\x90G4\xf7\x08\xf5&\xa6\x8ehc\x7f\xd6\xc3e\xca%][\xc0\xf6\xac\xca\xcd)l\xbb\xe9\xb5\x1d\xdb\xb9\x19q\xd5\x87?>\x1e\x0c?\xf6\xaaN}\x96{\xdd\xcd\x9b\x8e\x1a_v\xfb\xbf\x01\x00PK\x07\x08\xb7\xb5m\xb2\x17\x11\x00\x00\xc89\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-filesystem.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcU\xcd\x8e#5\x10\xbe\xf7S|\x9a\xb9\x80\xb4i\x84\xb8\xe5\x862B\xe2\xb0\xecJAplU\xec\xeatil\x97\xb1\xdd\x19\xc2*\xef\x8e\x9c\xfeIX\x98]\x01\xd2\xf6%q\xaa\xea\xfb\xa9jW\x1e\xbf\xf4\xd3<b\x97\x98\n\x83\xd0\x8b\xe3|\xce\x85=^\xa4\x0c\xc8\\\x8a\x84c\x9eN\x89\xa3\x13CE4\x80\x03\x1d\x1c[\xf4\x9a@\x88I\xedh\xe6\xc0I\x92\x06\xcf\xa1\xb4\xcd#\xbe\x87\x97 ~\xf4\xd0\x1e\xdf\xe1\xdd\xfe)C\x03\xac\xf4='\x0e\x05A-gPb$\xfem\x94\xc4\x16\x12P\x06\xc9\xe0\xdf\xc9G\xc7\x15\x06\xcf\xe3\x81Mq0\x93\xd4M\x8f\xa4\xfa\xbc\xb9	n\xcf\xe4]s\xe7\xeb\xcb<MCQ~\xe1\x94E\xc3\x16\x86\xe3\xd0Va\xad\xe87\xa7o\x9bg	v\x8b\x1d\xc7\xe1\x87Uh\xe3\xb9\x90\xa5B\xdb\x06\x08\xe4y;Y9:=\x90\x9b\x1c\x15M<Gs$\xb3\xa4T\xf8&G6\xb5\xf4\x11?\x0f\x8c\x05\x0cQ\xd5\xa1\xc6Z\xbc\x1ds\xc1\x98\xf9~`m\x835\xf7\xbd\xaa\xab\x08X\x13\xd8Ng \xcb\x1f\xbc\xc5\x87\x0fWM]%\xecn=\xee*BW\x99\xba\xa5R4tF\xc7Pp\xb9\xac\xa2\x9c\xe4R\xe7\xfdWa\xb9\xc5\x8e\xc2\xc7\xc2\xa0	\x9c(\x8f\x89a\xd4J8\xb6\x0d\xb0\xc8\xcc\x93\xae\x0dz\x127&~RO\x12\xb6\xd0lg\xc1\x7f\xb7\xf09\x13\x96>k\xe2\xd7\x81\xcb\xc0	E\x11\x13gN\xa7\xbbNS\xb07g\xd7\xb7\xf9\xd6!Xv\\\xf1\x1a\xac\x95\xefU]~\x17\x9ej\x84\xb7(i\xe4\xb5S+h\xe5\x10\xc3\xf8\xca\xdb\xfc5\x8c\x86^\x8ec\xa2\x19jI\xdbW%i\xea\xc9\x04\x10F\x7f\xe0T\x9bM\xa6\xc8\x89\xf1\xf6i\x0f	\xb9P0\x9c\xaf\x89S`W\xfd}b\xb26\xdf\xcf\xf1\xbe	Lf\xf8't\xbc\x88s\x18\xe8\xc4\xa0\xb0$Tb{8O\x0b\x83\xf0B\xc9\xdfL\x1a2\x03_WFO\xb9p\xfd\x10\xa7'N\xed\xec\xe8\xc7\x1e=\xb9\xcco\x16\x9c\xbay\x9c\xc3\x81A'\x12Ww\xce\x1b\x1c\xc62\xfd\x1c\xb4\xcc\xfc\x13\xd1\x15\xbf\xbd3\xbd\x9f\xd4\xbcn{R\xdd\xcdl7\xef\xf5jQ\xdfK\x90rF\x1a\x1d\xe7\xfa.P\x8c\xee\\\xbf\x94::\x9ba9:=\xd7]w\xad\x8b\x8e\x0c\xd7\xd3\xeb\x84kJ\xa7\xb1\x0e7/\x9c\x14\x82\x96\xeb\xbc\xf3'\xe4\xaeI\x1f\x97\xc7$\x9a\xa4\x9cw\x8er\xfe\xe9\xbaU\xea\xe5\xdd\xd4UBG\xde\x98$E\x0c\xb9\xf9\xdag\x1d\x93\xe1\xf9v\x01N\xbc\x94\xf5\x04\x988n\xf1\xf0\x9a\x8a\xa5\xbc\x9b\xca:\x13G\\.\x0fk\xb5g\xaf\xe9\xfc/\x00<\xfb;\x80\xfa?\xc0\xf9?\xc8Y\n\xff\x87\xa0\x15\xc2\xb3\xc7\xe5\xf2\xf0\xe7\x00PK\x07\x08\xf4K\xbb\x13\xba\x02\x00\x00\x8e\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00_\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-store.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcT\xc1n\xdbF\x10\xbd\xf3+\x1e\xac\x8bs0\xeb\xd6\x97\x82@\x0f\x86\x0d\xf4\xd4\xd8H\x8a\xe6H\x8c\x97Cq\xeb\xe5\xeevgiU\x0d\xf4\xef\xc5pEI1R\xb5I\x81\xe8&\xee\xcc\x9b\xf7\xe6\xcd\xcc\xea[\xff\xaa\x15\xee\x12Sf\x90Gx\xfa\x9dM\x86\xe4\x90\x18\x1b\x9b\x07\x08\xe7l\xfdZ\xd0\x87\x84\xc4\xd1YC\xd9\x06\x0f\xebA\x88)t\x93\x99\xff\xb3\x7f\xb1)\xf8\x91}\xae\xab\x15n1Zo\xc7iD\xe8q\x83!H\x96\x82\xf8\xf0\xfe^@\x89\x91\xf8\x8f\xc9&\xee\x14*\x0fV\xc0\x7f\xd2\x18\x1dk:\x9e\xa7'6\xd9\xc1\x14nW=R\x08\xcfW\x85\xe0\xd5L\xb0\xde\xd2\xe8\xaa\x13)\xdf\xe6WU\x14\xedo\x9c\xc4\x06\xdf\xc0p\x1cj\xa5V\xdb\xf0\xdd\xcb\xf7\xd5\xb3\xf5]\x83;\x8e\xc3\xc3L\xf5\xbd2\xadF\xce\xd4Q\xa6\xa6\x02<\x8d\xdc\x145r\xf3\x89\xa0\xfd\xa3D2K\x84\xc2W\x12\xd9h\xe6\n\xbf\x0e\x8c\x18\x82\x83~\xc2$\xdc!\x87\xa5Gy`,\x85\xe6(\xa9\xf1\xcb$Y\xe3N\xad\xab+\x1c\xe2\x1eCp\n\x0d\xf4d\xdd\x94\xf8>\x8cd}3\x1b6\x7f_\x12\xb9+q\x80\xd8\xbf\xb8\xc1\xc7\x8f\xb3\x86V\x19\xb6EE;\xdb\xd2*v\xab\xf5\xdb\x93\xa2\xad	\x93\xcf\xd8\xed\xfe\x9b\x8e\x83\x86\x1aw\xe4_+@H\xe0D2%\x86	\x9d\xf5\xeb\xba\x02\xce\xea	\xd2}\xa5\x9c\x8e\xfeU\xce\x87\x81\xf3\xc0I\xbd\x88\x89\x85\xd3\xcb\x89\x13\xe4\xbb\xa3\x1cAx\xb5e\x1d;V\xc4\n\x87\xdc\xc7\x10\x9c<\xf8{}\xe1\x06=9\xe1C\xdb\xd6\xc4\x997\xb4\x85\x96\xb1F\x1b\xe0{\xbb\x9e\x12\xedQ\xd64\xbf\x17y+\xe4md\xddA\x9d\x8e\xfd\x13.\xe5\xe6\xcd\xfc\xac\x8f\xe7\xac\xdc'\xb4\x1aW\xbcS\x1a\xb7H\xdcsboX%+\xb2\xb0I\x9c\xcb&\xf3<\x18\xc7Q\xc6f\xe0T\xc6S\xc4\xc1p\xca\xb6\xd7>2\xac\x94[S\xbc\x11qw\xc7\xc7w\xdc7X}vQ\xae\"\x8f{ye#RF\x1e(\xe3\xdd\xcf\x1f\x10C\xa7\x97\xc698+\x99\xbdv\xfcr\xc89\x16\xc91\xa4\xdc\xe0\x87\xeb\xeb\x1f\xaf\xbf\x1cB\xde\xd4\xb8\xf5\x9f\x93\xb1\x1c\xb3z\x06\x156S\xe2G-uR\xc4O\xe3\x13'5c\xe6\xb84k\xbdA\xc7\xd1\x85\xad\xdeN\\\xda\xb5\xd7\x8e\xc0\xf6 \xe7\xde\x86\x8e\xe5\xa7\x9c&.\xf4\xad\x97L\xde\xb0\x9c\xb3m	:]\xbaO\xe6T=Zo\n\x0d\x19\xc2\xe4:<\xf1\x9e\x05w\xda1r\x0e^k\x83\x04\x84\x8ex\x0c^\xb8\xdc\x84\x85\xd79\x0e\xe4\\[\x00\x0es\xa3^Q\xdf[o\xf3\x16ir,:>\x14\xa3\xdb.s\xf4\xaa\x19!\x1d+\x97\xceFG\x86\xb5Q\xe7j\x1f\x82\x96\xda\xe4}\xc8\xf3\x82\x9c\xe7|\x0c[2c\xb2!\xd9\xbc\xbds$\xf2v>\xddz\xf1\xe69\xa45_\x99d\xb35\xe4\xf6\xc7E\xc2\x94\xd4\x9c\xfd\xa9tv\xb4\xf9\xf0\x0f0qjp\xf1\xcf\xc4\x17\x80\xb6$\xb6&N\xd8\xed.\x0e\xf9#\x8f!m\xbf\x08b\xe4\xf1\x04B\xc7\x94\xe5\xab(-\xa9\xff\x8b\xd4\x01d\xe4\x11\xbb\xdd\xc5\xdf\x03\x00PK\x07\x08\x92\xb3\x14\xae\x1d\x03\x00\x00\xef\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbc\x90\xb1n\x840\x10D\xfb\xfd\x8a\xd1QC\x14]G{}RDI\xbfg\x06\xe1\x00\xb6\xe55\x91\xee\xef#\x03\x91\xf2\x057\xe5X~;3\xcd\xb3%\x0dn\x99Z\x08\x0d\x88\xf7o\xba\x02+1\x13\x9b1c\x8c\x19\xea\x1c\xcdP\"\xcaD\xd8\x15\x0cC\x8a>\x94N\x1a`\xde\xeete\x81;(\xed\x88\x1c\xe3\xdc\x1e\xa8\xb6B\xba\x87\xae\x8b\xfc\xbb\xf9\x1c\x89h\xf2_\xcc\xe6c\xe8\xe1\x98\xa6\xae&\xeb||\xf9y\x95\xd9\x87\xa1\xc7\x8diz\xdf\x93~\xd4\xce\x9f\xc6,+\x8b\x0eZ\xb4\x17 \xe8\xca\x1e\x03G\xdd\x96\xa3\xcciZR\xc7\xfe\xa8Z\xd1b\x89\xae\xfe\xd8\xb7;\x1f\xec\xfa7\xc3\xee\n0xK\x8b>\xdev\xec\xe5\xe4b3\xe6\xcb\xef\x00PK\x07\x08\xe2\x85\x10u\xc3\x00\x00\x00\xfa\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-pool.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xbcR=o\xdb0\x10\xdd\xf9+\x1e\xe0\xb5Q\x10t\xd3\xe6&\xe8\xda\x02-\xba\x1ag\xf2T\x1dL\xdd\xa9<\xca\xae\x13\xf8\xbf\x17\x94c\xc0S\xc7p{\xfcx_\xbc\xcdG\xaf\xb0\xc1sa\xaa\x0c\xc23\xcf#f\xb3\x8c\x93\xd4\x11\xce\xb5\x8a\xfev\x0cVPx\xce\x12\xa9\x8a)D1\x17KK\\\x11\xebQ\x8a\xe9\xc4Z\xbd\x0b\x1bl1\x89\xca\xb4L\xb0\x01\x9f\xf1\xed\xc7\x8b\xc3\x14I\x86\x81\x0bk\xc5h^\x1dT\x18\x85\xff,R8A\x14u\x14\x07\xff\xa5i\xce\xdc\x85\x0dpX\xf6\x1ckF\xbc\xda{\x18P\xcc\x0e\x0f\xcd_w\xa6)\x87\xbb\x14\x1f\xb3B\xa0Y~qq1\xed\x11y\x1e\xbbf\xa9\x13{<>\x85\x83h\xea\xd7\x0e\xbfd\x8b\x87\xeff9L\\)Q\xa5>\x00J\x13\xf7\xb7\x1e[\x8a\xf7=\x9f)\xb6\x83\x16\xaeq\x06\x9f9\xb6\x07\x1b\xfc\x1c\x19\x03I^\n#\xd9D\xa28I\xce\xf0\xb90%\xd4\x91o|\xde\xcan\xb8\xa9\x81b1\xf7\xbb\xcao$\xaf\xa6\xec\x017\xfc\xb2r\xf60O\xab\xdeW+\xa0\xeb\x04\xec\xc99\xb5\x7f+tB\xb4Y\xd8?\xa19\x93\xe1\xbc\n\xe92\xed\xb94\xd9\xebi\x87-\\^\xb9\xed<A4\xb5ia\x87\x1a\n\xa7E\x13i<w\x017\xc7\x95S\x0b\x89\xf5Q\x8f\xb7\xb7\xf5{w\xad\x81\xdd\xfb\x95]s\xb2\x8b\xb6h\xc5\xe5\xb2:\xdc\xe2\xc0\xe7\xc7#\xe5\x85\x91\xc5kS#U\xab\xebd\xb6hw\xe8\x7f\xacw\xd7p\xb9\xfc\x1b\x00PK\x07\x08\x12\xe0\x1b?\x82\x01\x00\x00w\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00`\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-storage-class.yaml.j2UT\x05\x00\x01\xf0b\xe7[\xecTMo\xe4D\x10\xbd\xf7\xafx\xcah\xa5]\x81\x8d\"\xf6\x80,\xed\x01\x12\x0e\x08\x89]\x11X\x8eI\xa7]^\x17\xd3\xee6]\xed\xcc\x0cQ\xfe;\xaa\xb6\x9dL\xc2J !V{\x88o\xd3\xd3\xfd>\xaa^\xd5\xe6S\x7ff\x83\xb3D6\x13,$\xc7d?\x10\x9c\xb7\"\xd8q\xeea1\xc6\xe8\x91{\x9b!\x94\x05\x89F\xcf\xcef\x8e\x01]Lz!\xc5vr\xe5\x80\xc2\x0d\xa7\x18\x06\n\xb96\x1b|\x8b\x81\x03\x0f\xd3\x80\xd8\xe1k\x84\xd8\xd2\x02\xfb\xf6\xe2\\`\x13!\xd1\x1f\x13'j\xc1\x01\xb9g\x01\xed\xed0z\x82pp\x84\xdc\x13Z\xea\xec\xe43:\xcb~Jt\x1e\x07\xcb\x01,\xe8\xa3\x14\x1al\xa7kr\xd9\xc3\xcdF\xaa\x0e)\xc6m\xb5\xd8\xa9\x8a\x9d\xfa`\x07o\x8e\x8c\x7f\x9a\xcf\x18;\xf2{J\xc214p4\xf6\xb5j\xab9~usj\xb6\x1c\xda\x06g4\xf6\xdf\xf9\xe8\xb6\xefb\xf4f\xa0l[\x9bmc\x80`\x07j\xd6\x92k#\x963\x19\xad\xd3?\xd4\xa5b\x1a\x19\xc9\xe9\x83\xe5j\xa6V\x7f\x01\xc2\x7fR\x83\xdb\xdbR\x90K\xbdz\xb9T\xe5\xb2T\xe5r}\xc01\\\xba8\x85\x8c\xbb;SU\xd5#\xd9\xcb\x93z\xfb\x8d<\x12~1\x9f\x9f)\xd2\xc7t\xaf\xf2\xaakuW%\xca\x96\x83\x01l\x081\x17Ni\xcc\xed\x0bp\xb76yUW\xc4\xe1\xcd\x1b\x9c|\x1c\xe4\x04/\xeef\x83G\xf7k\xcdA\n\x94\xa9\xc8d\xa9\x16\xd49\x01\x0dNr\x9a\xe8D\x19)\xb4\xdc)\xc6\x98\xe2\x0dks(=iO\xd1l6\xf8-\xa6\xad\x94\xa8\xffx\x0f\x8f\xd3\xfa\xf4\xf5\x17\xc6z\x1fw\xef\xa3\x9f\x06\xfa~?\xda\xa08\x0d\x94\xc4\x8c6\xd9\x812%\xd1z\\\xaf\xdd}\xda\xcc\x0d.Fr\xdc\x1dJ\xd2\xef[\xab\xf3\xa2\x07j\x1e\xceO\x92)\xa1Kq\xc0\xaeg\xd7#\xc75\xeb7\x85]j\x03l\xf0C\x87\x1034\x0c\xdc1\xb5_\x823v\xec=&!\\)\xda\x15\xac<\x9a\xaa\xbfq.t3\xe2/:\x92,\xb0^\xe2\x13\x89\xbb\x9e\x12\x1d\xbf\x98\x99\xae\xc9`\xc5\xf8i\xc5>\x8e\xeaS\xd3\x1d{\x92\x83d\x1a\x90\x0f\xe3\xbd\x8c\xd9X\xfdO\x9eh\x9f__\xa9\xd6N\xf4u\x83}'\x85\xe2\xe5\xdbQ\x03f\xfd\xab{6\x1b@{\x96\xcc\xe1C\x999\xadJ\x9aW\xdb\"]O\xda\xd2\xebAgA/.\x01\x9b\xd7V\xd9P\xc7\xa9W\xe6M\xb9\xfb\xabh\x82\x14\xf1\xf4\xdf\xf0\x1feI\xc8%\x9a\x1b\x01\x17\x83\xc6[\x89\x7f\x9f$#\x06\xc2\x96\x0e\xe8\xa3o\xf5P\x0b\xf6 }~\xb9v\x8aV\xa4A_\x16&\xdd\xaad]\xff\xd0\xe5\x97\xf2\xea\xa8s\x0f\xe6J\xe7\x94^\xa6\x81\xda\x07[\x17\x85b\x1e\x8d\xaa\xb8\xabf\x16\x93tHyx\x17=\xbbC\x83\x9f\xe7\xe1\xfe\x9fVGK\x9e2\xfd\xc7\xd51\x83<\xaf\x8e\xe7\xd5\xf1\xbc:>\xa7\xd5qN\x9e2\xfd5\x00PK\x07\x08\x96%\xe0 \xea\x02\x00\x00\xfa\n\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Z\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/toolbox/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x8cTMo\xe38\x0c\xbd\xe7W\x10\xd9C.u\xbd\xedQ\xb7E?\xb0E\xb7m\xd0\x1d\xcc\x1c\x82\"\x90e&\x16\"\x89\x82Hg\x1a\x14\xf9\xef\x03\xe5\x0bv\x93t\x86\x07\x07!\xf9\x9e\x9e\xf9(\xebh\xbfcbKA\x81\x8e\x91\xcb\xe5\xd5`aC\xad\xe0\x16\xa3\xa3\x95\xc7 \x03\x8f\xa2k-Z\x0d\x00\x82\xf6\xa8 \x11-\n\x83\xb1)\x84\xc8\xf1.\xcfQ\x9bnq\x00\xe0t\x85\x8e3\x102\xff1\x92#\x9a\\N\x18\x9d5\x9a\x15\\\x0d\x00\x18\x1d\x1a\xa1\x94+\x00^\x8bi\xfe\xeb0\x9d\xe1\x02\x10\xf4\xd1i\xc1\x1d\xae\xa3\x1b\xa0/\xe6\xac\xa0\\\xd8\x8b\xcaQ\x07\x1e\x93\xb3f\xa5\xe0\xc6\xb5,\x98\xeemb\xf9a\xa5\xf9\x97X\x9eQv\x8d\x86\x82h\x1b0\xed^\x17\xa08;\xadmX\xaf\xe7\xa8\xe0\xe3\x03\x0c%\x9c&\x8c4MD\x02\xebu\xce\xb5)a\x90\xa9\xd7\xc1\xce\x90e2\xaa1b\xa81\x18\x8b<z\x9b\x8c2*\xff\x1ef\x9a\xffp2\xa37X\xafw\x1a\x00\x0cy\xaf\xb3\xa3\x93a)6\xd8\xe1\xdb\xa1\xa4\xd3\x9c\x15L\x86\xc5|x\x01\xc3\xa2\xc8\xcf\xb2\xe5T:2\xda\x95\x95\x0dev\xa9\xa2\xf7Kn:\xb8\x8d\xf0q\xeb\xdc~2\x0f\xb3g\x92qB\xce\xeb\xb2g\xc7\xb0\xdcO\xa2;\x8d\xd7\x97\x97\xc7\xe9?\xb7O\x0f\xcf\xd3\xff\xefn^\xef\xbeuz\x00\x96\xda\xb5x\x9f\xc8w\xa19\x18MBy\xc4\xd5+\xce>\xd7\x8e\xd7\xd2S8\xeaY\xe0J\x81\xae\xbd\x0d\xc5\x96\xec\xd0\xc1h\xdadeuCA\xf0]\xba\xf41\xd9\xa5u8\xc7Z\x81\xa4\x16\x0f\xa5%\xb9\xd6\xe3\x13\xb5A\x0e\x86\xe7(\xc0\xe7\xdcXK\xa3\xa0\xacq98\xd6\xd9\xcf\xf6\x11\xbc\xe2\xb2j\xf9\x04\x8aW\xdc/\xf4\x81\xceV\xa5\xa7\xbaux\n\xeclu\\\xdc/\xa8\xa7P`\xa8#\xd9 \xc5\xf6\xc5z\x14\xddcPL\x99\x17n\xd7\xf0\x17\xd8\x194\xdb\x8b\xf0\x93\xd2B\xc1L;\xc6\x0b\x90\x06a\x98\xaa\x1a\xbc\x8e\xc3\xfd\x12B\xa3\xc3\x9c/\x80\x11\xa1\x11\x89\xac\xcarn\xa5i\xabKC~\xc3\xbb}X\xe6\x16\xb9\xbc\xfe\xfb\xfajwP\xef\x8c\x8e\x13[\xb9\x1d\x0b\x8a\x93C\xce\xf0\x8d'\x87\xbe\x1c\xf1\xd8\xa5\xe2\xdc\xb4\xbfd\xf8\xecZ\xf1\xd5\xd8\xbfd:e\xe3\x9f\xf9d(\xcc\xec\xfcIG5\xf8\xcd\xd58\x90\xf47\xc5\n\xfa\xde.\xe7\x937\xb7&\x7fE{\xf9\xbd\xdc\xae\"\xfe5\x00PK\x07\x087\x01\xd2\x91m\x02\x00\x00M\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-http.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x9c\x91\xc1j\xc30\x10D\xef\xfe\x8a\xf9\x01\x81{*\xe8Vz-\xc1\xd0\xd2k\xd9\xc8\x9bXD\xd6\x8a\xd564\x7f_\x9c\xd8\xae\xf1\xb1G\xcf\xbey\x1e\x10\x95\xf8\xc9Z\xa3d\x8f\xebSs\x89\xb9\xf7x\x8b\xd5\x9ah<V\x0f\xa0\x01\x1cv\x1c\x00<\xd8w\xd6k\x0c|OF6\xea\xc9\xc8\xdf\xbf\x80L#{\xa8\xc8\xc5\x05.\x83\x1b\xcf\xeaz\xaa\xc3QH{\xc7?\xc6\x9a)\xb9\xc1\xacl\x1a\xb5P\xd8\xd6\xe6S\xa2#\xa7\xba\xa8\x01*e\x03M\xee\xf54U\xbfB\xfa\xae\xc6\xba\x17\xd5\xc2a\x91\x14Q[\x8dn\x9e\xbb\x0e\x9c\xf3\x07\xe6\xf1\xdc\xb6\xed_\xa4b\x12$y|\xbcvkj\xa4g\xb6n\x8fWN\x1cLt\xf9\xd3\xbf\xb7\x03\x95\xeb\xf4\x06/\xa7S\xcc\xd1n\x1e\x07\xc9<w\xedV\xd8\xe3 =w\xa2\xd6\xfc\x0e\x00PK\x07\x08+o\x971\xda\x00\x00\x00\xda\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-https.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x9c\x90\xc1j\x031\x0cD\xef\xfe\n\xfd\x80\x0f\xa59\x14\xdfJ\xefa\xa1\xa5\xd7\xa2\xd8J\xd6\xc4k	I\x0d\xcd\xdf\x17o\x97\xb2\xe7\x1c53o\x18\x84R?I\xadrOp{\n\xd7\xdaK\x82w\xd2[\xcd\x14\x16r,\xe8\x98\x02@\xc7\x85\x12(\xf35f\x929.\x17\x8d\x05m>1j\x89\xf4\xe3\xa4\x1d[\x9c\xdd\xc5\xb6\xb8	\xe6=\x13\x00\x1a\x9e\xa8\xd9\xe8\x03@\x91\x9d9\nWy\xc4\xbfr\xfb6'\xdd\xc3&\x94\x07(\xac\xbe6\xc4m\xd3\xff\x8a\x15\x1fv\x82\x97\xc3\xe1\xf9\xefTv\xce\xdc\x12|\xbcM\xab\xe2\xa8\x17\xf2i\x1f3j\x94\x9d\xf5\xa1]\x00F6\x1e\xf8z>\xd7^\xfd\x9e\xe0\xc8\x9d\x02\x80\xdf\x85\x12\x1c\xb9\xd0\xc4\xea\xe1w\x00PK\x07\x08\x8c$\x8d\xf9\xc3\x00\x00\x00k\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00`\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus-service.yamlUT\x05\x00\x01\xf0b\xe7[4\x8e\xc1j\xc40\x0cD\xef\xfe\n\xfd\xc0\x82KO\xd1\xb5\xf7\xb2\xd0\xd2\xbb\xeb\x0c\x8d\xd9\xb5%d%\xa5\x7f_\x928G=\xcd\x1b&i\xf9\x82\xf5\"\x8di{	\x8f\xd2f\xa6\x0f\xd8V2B\x85\xa79y\xe2@\xd4R\x05\x93\x89<njR\xe1\x0b\xd6>x\xd7\x94\xafg\x86.\xa1+\xf2.\xf9\x9f\x82\xe9]f\xdc\xc5<\x10\xa9\x98\xf7\xfds\x1b\x85\xbf\xf8\x0eDDmd\x98^\xe3\x14\xe3\xc1\xf60\xd3\x14\xa7q\x9a\xb8dy2}\xbe\xdd\x0f\xe2\xc9~\xe0\xa7v\x16u<\x91]\x8c/c\x0c\x1d\xe3\xd4\xa4\xc2\x17\xac=\xfc\x0f\x00PK\x07\x08	\xbd\xf9B\xa0\x00\x00\x00\xf9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00X\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus.yamlUT\x05\x00\x01\xf0b\xe7[\xa4\x93Ao\x131\x10\x85\xef\xfe\x15\xa3=\xb3\xdb\"q@{\x03\x0e\\Z\x84R\xc1\x05\xe5\xe0x\xa7\xc9\x90\xb5\xc7\xcc\x8c\x83\xe0\xd7#\xef.$$\x15T\xed)\x19\xafg\xbe\xe7\xe7g\x9f\xe93\x8a\x12\xa7\x1e\x0e/\xdd\x9e\xd2\xd0\xc3\x1d\xca\x81\x02\xbe	\x81K2\x17\xd1\xfc\xe0\xcd\xf7\x0e \xf9\x88=d\xe1\x88\xb6\xc3\xa2\xcb\x92f\x1f\xb0\x07a\xde\xb7\x01\xf3\xce\xb5m\xebNg\xcb\xc6\x87\xce\x17\xdb\xb1\xd0Oo\xc4\xa9\xdb\xbf\xd6\x8e\xf8\xea\x0f\xf5\xddX\xd4PV<\xe2\x93\x90RF\xd4\xde\xb5\xe03\xbd\x17.Y{\xf8\xd24k\x07 \xa8\\$\xd4\xcf\x00-$\x1e\xb0*oA\xe7\x93\xce\x05\xa6!3%\x9b\xab\xccC\xfds@\xd9L\x83\xb6h\xcd\x0bhF\xd2\xe9\xf7\xbb\xb7\xb0k\xd6\x8f\xc1\x05N\xf7\xb4\x8d>_\xcc\xab\xed\x89\xd3j\x91\xf7iu3\xa1\xae\"\x9aP\xd0f}.`\xfdLc\xdfR\x1a(m\x9f\xe6/\x8f\xb8\xc2\xfb\xda\xf2\xdb\xe1\x7f\xe0\x1d\xc0\xe5\xb5>\x00\xd3\xb2\xf9\x8a\xc1\xa6\x8b{0}\x8f\x17xnM\xe4D\xc6Bi\xdb\x05\x16d\xed\x02\xc7c\xde>\x1eO|i\xc7\x94\xe4\xff#\x01F\xbf\xc1q\xca\x15\x9c<\x8b\xc5\xb7\xe3\x82\xd3\x8c\xa1\xee\xd2\xbf\xde\xd6\x87K\xef\x97\x0d\xb7\xb3\xf8;\x1c1\x18Km\x05\x885t7'D\x00C\x1fg\xday\xcek\xf9\xad\xa0\xdaR\x01D\x8c,?zxu}}K\xee\xd7\x00PK\x07\x08\x917\xd9qs\x01\x00\x00\xfb\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/service-monitor.yamlUT\x05\x00\x01\xf0b\xe7[d\x8eAn\xeb0\x0cD\xf7:\xc5\\\xc0	\xfe\xe2ot\x86\xb6\x9b\x00\xdd\x16,M\xc4B$\x91\xa0X\x9f\xbf\xb0\x93\x02\x0e\xc2\xe5p\xe6\xcd\x90\x95O\xf1Q\xb4g4\xed%\xd4K\xbf\x9eX]t\x9cX\xdby\xfd\x97n\xa5\xcf\x19\x17\xf1\xb5\xb0\xbc\xdf]\xa9I\xd0LA9\x01\x9d\x9ad\xb8\xeamb\xb1ejW\x7f\xa8\xc3\x88\x8f\xaf\x04T\xfa\x96:\xb6\x18\x10B\xed\xfeM\xc3\x84\xffX{\xea\"U8\xd47\x11h\x14\xbc|P\x93G\x12\x98\x9e\xa8\xe3\xd5\xfdv(\x02\xc8\xecu\xe2v\x1b\xe5\x8b\xeb\xcf\x08\xf1\xe7\xa5\xd2g\xd3\xd2c\xaf\x9c`\xea\x91\xb1D\xd8\xd4$\xbc\xf0\xd8	F\xb1d\x9c\x8fR\xe9!\xbeR\xcd\xf8?\xd2\xef\x00PK\x07\x08\xfa,\xfc\x87\xbf\x00\x00\x00c\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Q\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[,\xcc\xbb\xae\xc20\x10\x84\xe1\xdeO\xb1J\xef\x1c\x1d\xd1\xb9\xa5\x87\x02A\xbf\xd8\x83br\xf1j\xd7A\xe2\xed\x91#\xb7\xffh>\x96\xfc\x80Z.[\xa0\x08\x99F-e\x1es\xf9\xfb\xfc\xbb9o)\xd0\x192]\x9fo\xc4z\xabEq7\xa8[Q9q\xe5\xe0\x886^\x11(\xe1\xc5\xfbR\xfdn\xd0\x1eM8\"P\x03}\xa3\x9d	b{Xs\xfa`'_\x0e\xdc\x1f\xd5\x11\xa5l\xb2\xf0\xf7r\xb0Cwi7\xe8\xe0~\x03\x00PK\x07\x08\xb9\xff\xa0\xe3\x82\x00\x00\x00\xae\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00M\x00	\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[\x8cTMo\xe38\x0c\xbd\xfbW\x10\xd9C.u\xbd\xed\xd1\xb7E?\xb0E\xb7m\xd0\x1d\xcc\x1c\x82\"\x90e&\x16\"\x89\x82Hg\x1a\x14\xf9\xef\x03\xe5\xc3\xb0\x9b\xa43<8\x08\xc9\xf7\xf4\xccGY\x05\xf3\x1d#\x1b\xf2%\xa8\x10\xb8X]eK\xe3\xeb\x12n1XZ;\xf4\x929\x14U+Qe\x06\xe0\x95\xc3\x12\"\xd12\xd7\x18\x9a\\\x88,\xef\xf3\x1c\x94\xee\x173\x00\xab*\xb4\x9c\x80\x90\xf8\x8f\x91\x1cP\xa7r\xc4`\x8dV\\\xc2U\x06\xc0hQ\x0b\xc5T\x01pJt\xf3_\x8f\xe9\x0c\x17\x80\xa0\x0bV	\xeeq=\xdd\x00C1g\x05\xa5\xc2AT\x8a\xda\xf3\x84\xac\xd1\xeb\x12nl\xcb\x82\xf1\xdeD\x96\x1fF\x9a\x7f\x89\xe5\x19e\xdf\xa8\xc9\x8b2\x1e\xe3\xfeu\x01\xf2\xb3\xd3\xda\x85qj\x81%||\x80\xa6\x88\xb3\x88\x81f\x91H`\xb3I\xb96F\xf42s\xca\x9b9\xb2L\xc75\x06\xf45zm\x90\xc7o\xd3qB\xa5\xdfn\xa6\xe9\x0fG=~\x83\xcdf\xaf\x01@\x93s*9:\x1d\x15b\xbc\x19\xbdu%\x15\x17\\\xc2t\x94/F\x170\xca\xf3\xf4,Z\x8e\x85%\xadlQ\x19_$\x97*z\xbf\xe4\xa6\x87\xdb\n\x9f\xb4\xd6\x1e&\xf30\x7f&\x99D\xe4\xb4.\x07v\xf4\xab\xc3$\xfa\xd3x}yy\x9c\xfds\xfb\xf4\xf0<\xfb\xff\xee\xe6\xf5\xee[\xaf\x07`\xa5l\x8b\xf7\x91\\\x1f\x9a\x82QG\x94G\\\xbf\xe2\xfcs\xedx-\x1d\xf9\xa3\x9e%\xaeKP\xb53>\xdf\x91u\x1d\x8c\xba\x8dF\xd67\xe4\x05\xdf\xa5O\x1f\xa2Y\x19\x8b\x0b\xacK\x90\xd8bWZ\x91m\x1d>Q\xeb\xa53<E\x0e.\xe5&J\x9a\x12\x8a\x1aW\xd9\xb1\xceav\x88\xe05\x17U\xcb'P\xbc\xe6aa\x08\xb4\xa6*\x1c\xd5\xad\xc5S`k\xaa\xe3\xe2aA\x1d\xf9\x1c}\x1d\xc8x\xc9w/6\xa0\xe8\x1f\x83\xa2\x8b\xb4p\xfb\x86\xbf\xc0\xcc\xa1\xd9]\x84\x9f\x14\x97%\xcc\x95e\xbc\x00i\x10F\xb1\xaa\xc1\xa90:,!4\xca/\xf8\x02\x18\x11\x1a\x91\xc0eQ,\x8c4mu\xa9\xc9myw\x0f\xc3\xdc\"\x17\xd7\x7f__\xed\x0f\x1a\x9c\xd1sb'\xb7gA~r\xc8	\xbe\xf5\xa4\xebK\x11\x8e]\xca\xcfM\xfbK\x86\xcf\xae\xe5_\x8d\xfdK\xa6S6\xfe\x99O\x9a\xfc\xdc,\x9eT(\xb3\xdf\\\x8d\x8ed\xb8)F\xd0\x0dv9\x9d\xbc\xbd5\xe9+:\xc8\x1f\xe4\xf6\x15q\xf6k\x00PK\x07\x08\x9dq\xfb\x8an\x02\x00\x00N\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00M\x00	\x00roles/kubernetes/core/templates/usr/lib/systemd/system/rook-umount.service.j2UT\x05\x00\x01\xf0b\xe7[\x84\xce1n\x83@\x10\x85\xe1~N\xc1\x05\x80\x13l\x11+.\xd2\xda\x89R d\xad7\xcff\x04\xcc\x90\xd9\xd9\xc4\xc8\xf2\xdd#\x99\x03\xa4\x7fz\xff\xd7}\x08{O\xaf\xc8\xc9xqV	\xa6:\xd6	\xcbP\x95Y\x8bx\xa6\x03\xbe\x0b\x1br\xb8\xdf\xab\xa4\xe2\x91\x05v\xb2\"\xce3N\x19\xf6\xc3	\xd5\xe3A;\\\xd4\xf0\xef\xec\xe5\xe2\xb0 \xf0_\xb5\xb1V\x99X\xd0x\xb4+\x9c\xa8;n\x7f=\xedoHG\xd7%\xb4\xf0\xd4\x8e\xe5\x0c\x138r\xfb\x14\x9eY\xda\x0d\xd8\xe4\x81\xde\xd7\x05A\x05yP\xa7\x03\xe6\xc8\xf2\xac\xeco\xecaE&\xea\xde${\x9c\xa6\x9e>\xa38\xbevk\x98\xcb\xe4\\\x97\x0ck<\xda\x15N\x7f\x03\x00PK\x07\x08\x1e\xb8\xbe\xe8\xbe\x00\x00\x00\x0f\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/ha/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[|Tm\x8b\xdb8\x10\xfe\xae_1xY\xd2\xa58\xcd\xcb\xa5\xf8\x0c=\xc8%\xa1)\xb4wK}_\x8ee\x11\x8a5I\x86(\x92\x91\xc6\xde\x86\xed\xfe\xf7C\x897\xc9\xa6\xbb\xf7\xc9\xd2\xf36\x8f%\xec4M\xc5\x15(c\xdcC\x80\xa5\xf3%\xd9\x15\xf0\x1a\xa1\x0ej\x85\xe0\x96\xf0\xe56\x00\xd9\xc0\xa8t\xdcN\xff*\x00-{\xc2\x00\xb4\x04\x8b\xa8Q\x0b\xa5\xb5\xc7\x10$\xd9\x06-;\xbf\x93KB\xa3sH\x1e\xaf\xa3\xac4\xae\xd6\xb2\xf2\xae!\x8d\x1e>}\x82\x0e\x96\x83\x0e\\?U\x9e\x1a\xc5(\xa9z\xbc\x064\x01\xcf0mC\x04\xad\xa6%\\?%B\\\xc1\x14\x97\xaa6\x0c\x0dy\xae\x95\x01\xaa\x80\xc2\xbe\xf0\x97\xdbX/\xae\x96\xe4\x03\xc3;e58kv7\xb0U\x81\xd1w\xc5\xa6^\xa0l\x9d\x92*\xa9*\x8a\x0d\x1fa\xed\x027\xca\x87\xbb\x95wu\x15\xee:\x07G\xe7\xfe\xaew\x7f\x7f\xd79u\xec\xdc\xc3S,BA\xae\x95,M\x1d\x83\x0f\x19\x97V\xf8	\x06\xed\x8a\xd7\xf0\x07\xf4!\x96yg\x1d\x83\xa6\xa0\x16\x06\xe5a\x82\\+\xf8	\x0b\xe7\xcc\xcd!x\xad*\xef~\xecdi\x08-K\xa6-\xba\x9asH\x86\xbdmrd\x03\xfa\x06\xfd[\xac\xad\xb7\xb2\xac\xea\x90\xc3\xe0\xe80n%\x0d6hrH\x1e\x94\xb7dWgq\xc1\xc8\x05Y-]\xc5\xe4l\xc8!\xb1.\x0d\xc14C\xb0.e\x13\x9a~\xef\xb8\xea\xb7\xab\x94\xa9\xdc \x87\x04\xe0\n\xd8\x04h\xfa\xdd\xc1{\x80w\x0f\xae6\x1a\xd6\xaaA`\x07J\xeb=\xdb\xef\x0e\xa1\xa4j\x8d>\xdc\xfc:\xb9erHf\x93\xe9|\x96~/\xc6\xe9xV\xf4\x07Y\xfay\xf2--\xe6\xe3\xc1\xe8c~\xe0f\x93\xe9\x89}\xc1|\x7f\x03\xbft\xbc*\xcf/\xe6\x16\xf3\xf1\xa5}0\xfa\xf8\\g\x98\xfd\xf62\xe5U\xee88\xb2o\xb9\xfe\x07\x7f#&o+\xb6]\xe2\x01\x9cJ\xbf\xdc\xe5m\xd8Y\xedS|+}\x1e\x16GM\x8b\xe2\xfc\xa0\xa6\xb3\"\x9d\xfc9\x19F>\x11W\x977\x99\x03\xfc\xf3\xb5\xe8\x0f\xa3%\xbd8\x81\x13qq\x8b\x07b2\x1fO\xe6\xe3A/\xbd\xfd\xfb\xeb\xbf\xfdaot\xbc\xca\xf8\xce\xef\xc7\xb3\xe2\xf3\xe4[\xbby\xd6\nQ\xa2g\xa9\xc9\xe7\xf0\x01\xb9\xfcPmHx\xe7X\x96J\x96\x9e\xe5\x92\x0cZ\xb5\xc5\x1cZ\xb4[z\x16\xd6i\x94{\xa7\xb2Znpw\xa6\x8b\\\xb7\xc2\xed!:\xe4\x02\xf6P|\x02\x94\x9e[E\x8c\x01\x00\xd8\xe0\xaeE6\xb8\x13\xc2\xe3\x8a\x02\xfb\x9d\xac\\\x94\x8ez\xbd\xde	3\x8b\x13<\x12\x02\xed\xfe\xc3G.\xb54\xaeT&\xfe\x0f\x7f\xec\xe3\xc4\x1e\x0c\xac<\x93]\x1dp\xa9t\x83\x9e)`\x9b\x92eY\xf6\xaa\x90,1)\xf3\x8b\xe1\xf7,\xcb\xc4\x7f\x03\x00PK\x07\x08\x8a\xe3\xa3a\xc8\x02\x00\x00\xe1\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x007\x00	\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-haproxy.confUT\x05\x00\x01\xf0b\xe7[4\xce1N+1\x10\x80\xe1\xde\xa7\x18%\xab\xd7\xed\xce&\x8a\"\xbdt\x08\x89\n*\xe0\x00\x8e=\xb1G\xb1=+{\x88\x13\n\xce\x8e@\xe1\x00\xff\xa7\x7f\xfd\x16\xb9A\xb7\x0d\\%\xab\xe4\xe1T%\x1f\xcc:\xaa.\xed\x80\xd8{\x9f\x1a\xe7\x8f\x94\xc9\xb3\x9d\x9cd<&	\xb8\x9d7{\x9c\xb7\xb8\xf9\x8f\x8e\x8aV\x9b\xf8\x93\xfc\xe8\xc5\x9d\xa9\x8eIB\xe0\x12\xc6\xce\x1a\xc7\xdan\xed'1\xc3\x83\xf7\xef\x85\xaf\xcf\xdc\x94\xca\xab\xb83)\xe0\xc5VL|\xc4h\x97*\xd7\x1bz\xba`\x92`\xcc\xf0\xc4\x89\x1e\x7f\xb7^\xc4\x13\xcc\xfb\xdd\xce\xf0	\x86\xa5J\xa86\x17\x9b	\x9c\x14\xb5\\\x1a\xac\xee\xfd\n4R\xb9\xb3\x12\xfe\xd8)I0\xff\xe0\xcb|\x0f\x00PK\x07\x08\x99\x11\xdc\xba\xc3\x00\x00\x00\xf2\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00:\x00	\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-keepalived.confUT\x05\x00\x01\xf0b\xe7[<\x8d\xb1N\x031\x0c@\xf7|\x85\xd5Vlw\xbeVU%\xba\"\xb1 6~\xc0$nb5\x89ONh\x04\x03\xdf\x8e\xca\xd0\xf9\xe9\xbd\xb7\xfdH\xd2`P\x03oL\x9d\x03\\L\xcb\xd9mS\xefk;#\x8e1\xe6&\xe5+\x17\x0eB\xb3\xd7\x82\x9fY#\x1e\x96\xfd	\x97\x03\xee\x9f\xd1s\xedFY~8LA\xfd\x95m\xca\x1a\xa3\xd48\x0d\xe9i\xb2\xf6\xdd\xee\x8a\xdb\xbdJ\xe6\x97\xff\xcf\xbb\x06\x86\xe5t<:\xb9\xc0n5\x8dF\xa5Ra\xf0Z;Im\xb0yc^)\xcb\x8d\xc3\x06z\xe2\nx#\xc3{\xe9\xfa s\xd6\xe8\x9e\xe0\xd7\xfd\x0d\x00PK\x07\x08\x18H<R\xa8\x00\x00\x00\xc9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x003\x00	\x00roles/kubernetes/ha/files/etc/rsyslog.d/remote.confUT\x05\x00\x01\xf0b\xe7[\x00j\x00\x95\xff$ModLoad imudp.so\n$UDPServerRun 514\n$UDPServerAddress 127.0.0.1\n\n$FileCreateMode 0644\n$DirCreateMode 0755\n\x03\x00PK\x07\x08\xd5\x9e\x80<q\x00\x00\x00j\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x002\x00	\x00roles/kubernetes/ha/files/etc/sysconfig/keepalivedUT\x05\x00\x01\xf0b\xe7[l\x91Oo\xd40\x10\xc5\xef\xf9\x14O\x8d\x04\xbb\x07\xaf\xb8 \x10R\x0f\x15Y\xd1\x88\xd2D\x9b\x92k1\xc9\xe4\x8f\xeax,{\xb2(\xdf\x1ey\x03\xec\xd2\xd6\x87\x19i<\xbf7\x9e\xe7\x14\x85\x93\x91m@\xc7\x1eODN\x9b\xf1H\xed\x0e\x15\x11~\x9c\x0bPj \xe3\xde\x82gq\xb3@\xdb\xf6\xa2}\xf3q\x1b+Iz)\xd1\xb0\xed6\xef\xb7\x98\xb4\x85\xd3=\xad34\xcc\x18\x04\xdcA\x1b\x03^\xa7\xefpK\x9e\xa0=A\x06\xc2\xc4A\x92\x14\x0dO\x13[\xb0\xa5\x80OI\x9a\xa4P\xea\xe8\xbd\xc3\xffG\x951\x15\xd6,\xf0\xb3\xc5\xafQ\x06\xd4\x87C\x890\xff\x0cK\x10\x9av'\xb4\x19\xa8y\xba\x04\x01\xf5\xf9%zK\xda\xc8\xb0v\x93\x7f.\xd2\xb2\x15\xe5\xc9\x90\x0e\xf4\xe71\xaa\x8e\"\x19[\x81\xa7\x89\x8f\xb4N\xaf\xf32\xe0\x0d\xeaC\xf1\xfda\x1f\xc0\x16\xad\xa6\xb8O\x10v\xaf\x88\x8d\xee\x18\x00\x95?\x17\xcb\xcb\xba\x82\xb0c\xc3\xfd\xf2\xba\xcc<9\x15\xdd>\xaf\xa6\xda\x18\xb3yr'C\xe3\xe5\xd8\xcf^G\xb7\xd1j\xd1+i\xb8W-\x89\x1e\xcd\x99\xccb\xceNEja\xb8\xc7D!\xc4\xff;3\x9dnF3\xca\xf2\x97\xa9b|\xa7>\xa0\"\x81\xe1F\x1b\x84%D\xf6_\xe7\xa6\xa5N\xcfF\xae\xef\x8a/\x8f\xd9\xcd\xfe[q\xbfM\xd2$\xf9\xba\xdf\x977wy\xbd\xcf\x1e\x8b\xf2!/\xee\xab\xeb+\x95A\x95W\xc9\xef\x01\x00PK\x07\x08\xa9\xea\xad\x9cs\x01\x00\x00\x9d\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/ha/files/etc/systemd/system/bynet.service.d/bynet.confUT\x05\x00\x01\xf0b\xe7[\x00 \x00\xdf\xff[Unit]\nWants=keepalived.service\n\x03\x00PK\x07\x08\xf8+\xff\xa2'\x00\x00\x00 \x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Q\x00	\x00roles/kubernetes/ha/files/etc/systemd/system/keepalived.service.d/keepalived.confUT\x05\x00\x01\xf0b\xe7[\x00f\x00\x99\xff[Unit]\nAfter=bynet.service\nWants=bynet.service\nPartOf=bynet.service\n\n[Install]\nWantedBy=bynet.service\n\x03\x00PK\x07\x08\x9a\x97F\xfem\x00\x00\x00f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/ha/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[\xc4\xceM\xaa\x840\x10\x04\xe0}NQ\xb8q\x95\x0b\xe42\xd2\x92B\xe5\xe5G\xd2y?\xb9\xfdc\x1cE\x06dv\x83\xdb.\xaa\xeb\xb3\xd6\x1a\x8b$\x91\x0e\x85!\x8b\x87$\x8fB\xadR*\xbe\xc8U\xc2\xf2Co\x00mZ\x19\xbd3\x00\xf6\xcaK\x0eh\x95\xba=\xda\xda\xfb\x91I\xc6@\xef\xd0\xa8[\xd5\x0bcN\xc3s\xee8\xff\xceL\x0e]\x1fE+K\x8f%a*\xf9{\x1d\x1eC\xda\x99w\xcaY\xd6\x92\xff\xda5\xf1\x0co\xf3\x8d-\xb1^\xeb\x8e\xe86[\xd1\xa6!O\xd7\xba3\xfc\xa8\xef\x7f\x00PK\x07\x08\x89B~\x8a\x97\x00\x00\x00\x85\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/kubernetes/ha/tasks/haproxy.ymlUT\x05\x00\x01\xf0b\xe7[\x9cT\xcd\x8e\xd40\x0c\xbe\xcfSXE\xa8\x17:\x05\xb4\xe2\xd0\x1b\x07$\xb8\xed\x1bTi\xe2N\xc3\xa6N\x15\xbb3[\xad\xf6\xddQ\x9at\xb6\x03\x1a	8\xb5\xb1\xbf\xcf?\x9f\x9dT\xd09\xaf\x9f\x9a\xc3\x01\xa0\x02R#6\xf0\x8dx\x0e\x08\x83\x9a\x82\x7f^\xe0\x14\xfc<1\xe0\xb3e9\x00@24\xeb/dJ\xf1\xf2\x02Vp\x84\xd7\xd7\";X\x94`\x03S@FJ\xc4\x8b\x95\xa1\x8d0N\xecj\xcb\x91OOs\x87\xfbB\x94103\x06(3\xae\x04\xf1PFX\x99\xaaX\x89\x11r[\xce>l\xae\x97\xaf\xd6\x0f)\xcf\xea\x025MH\xa6\x81\x05y\x9f\x99Q\xe0\xfb\xd7\xc7\x18\x04xa\xc1\xd1\xc0L6\xb5!8N.6\x97cp\xd0\x0d\xcc\x1cjg\xbb:\xa3\xf3\xb7\xce9\x8f\x8c\xe1l5\x1e\x7f~\xce$\x83,\x0d\x14\xf5\xdf\xf16QGo\xb0\x81\x8f_\x1e\x1e\xb2\xc1_\x08C\x03\xc1{\xc9\x96U\x97l\xd9u\xa4\x9c\xf3\x97kO\xe2\xa1\xb3d\xe2W\x01y\xaa\x9c\xd7\xca\xd5\xf1o\x9d3\x92\xc0\x8f\xc75\"/\xac\xc5\xdd\xeaK(G;\x9d\x1f\x8evj\xc9\xd3Jnc\xc0\x8c:+7c\x03\x9f\xf21\x85h\x19%	\x1dm\x01O\x96%\xd6\x9e[m	E{\xeaW/y\xb1\xfd\xb2%\xad \xa0\xf3\xca\x80\"\x03\x01YT\x90\x8d\xb6\xef\xb1\xd0~Z\xa0\xacQ\xf4&\xfcu\x00\xba?\x95\xc5\xfd\xf1\xdd\xa1\xfc9\xaf;\xc0\x7f\x1fP.,\xf3\xde\x9d\x95\xb3&.\x15\xac+\xd1Y\xaa\x8d\xd7O\x18\x00\x9fQoh\xa8\xaa\xe4\xe7\x08\xb8\x1a5T=\xbc\xbf'\xec\x7f\xab:\xa2\xa8\x06z7\xf3\xd0\x0e\x8a\x8c\xc3\x90r\\\x06\xa4\xdb\xf8G=(:\xa1\x01\x1f~\x1f\xe8\xe6\xdaO\n\xa9\xf7A\xbf\xbd1a&\xb2t\xda\x16.\xde\xa0\xdb\x8d\xcb\xc0\xdb\xb7e]\x04\xdc\x96\x0eIu\x0e\xdf\xeer*\xb2(G\x15\xf7\xac\x04KI\xfa\x96\xd4\x88\\\x1c~\x0d\x00PK\x07\x08n\xbe2\x84\xf4\x01\x00\x00\xfb\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/kubernetes/ha/tasks/keepalived.ymlUT\x05\x00\x01\xf0b\xe7[\xecVOo\xa3>\x10\xbd\xf3)F\xbe\xa4\xfdI$U\x7f\xed\xae\xc4u\x8f{Y\xf5\xb0Wd\xf0\x00n\x8c\x8dl\xd3,\xaa\xfa\xddWvLphB\x93U\x8f=\xb5\x19\xcf\x1f\xcf{o\xc6\xa4i\x9a$)\x14B\x95\xdb,I\x00R\x90\xb4\xc5\x0c~h\xa4\x16a\x83\xb6\xdc\x98\xc1XlY\xf8\xbby}\x05n\xb1\x85\xb77`\\ci\x95\xe6h\x12\x00\x80\x8a\x0b\xcc\xfc\x7f\x00\x1d\xb5M\x06d9\x03	\xce\xc6R\x8b\xd9!\xdf\x10\xcc\xadb\x98\xc1\xdd\xf7\xc7\xc7`P;\x89:\x03\xad\x94\x0d\x96Z\xab\xbe\x8b,;n\x9b\xdc\xdd\xcf\x8c\x17I\x81\x14\x83D\xbb6\xa8_x\x89k6VM\x81l\x11;*\xf8\x0b\xb2\xf8\xd8\x03a\xd0\xe6\x15-\xed\x98\xc7'\xc9Q\xd2B \xcb\xa0\x14\xbd\xb1\xa8s^Q\x17\x84.5p\x03-\xb5es\xe3J\xae\xff#\xb71\xa6\x06-\xfc<\xd4\x83\x00\n\xf4\x92\xef\x9b\xb1\xd8v\xc2\xe1\x10\n\x1a]f\xd0\x1b\xbd\x11\xbc\x98\x93p\xe2\xde\xcf\xf7!\x8e\xa1\xb1\x0e\xf9\x8bC\xc91\xdc\xdf\x1e\x1e.\x82{\xd6\xda\x94\x17J%+^\xf7\x9aZ\xae\xa4W\xc5\xf9\x06\x89\x13\xc8\x14\x1bw\xe6\xd2\xac\x9f\xef\xc9\xac\xaf\xe5\x00\xf2\x91T\xa6\x02\xc7*\x1b\xdb\xd6Xs\xc7l\xec\x99\xbb\xab\xf8S\xa9,\xaf\x86\x91\xa2\x144\nE\x19P\xc9@\xa3\xb1T\xdb(,\x86\xa8\xa5[\x8c\x8e@I1\x80\xee\xa5W,\xfc~z\xfa\x05\xa6/\xf6\xf4\xfa\xec\xa5\xea\x0eu&\xa4\xcc`\xdc]x\x1d\xf5}\n\xa0%\xbf\x8f\xa7\xe8\x84\x16*\xa5K\xcc`@s\x1e\xa5P\xf4\x13\x80\xf2\xc3\x06[\xa9v@\x0b\xd5\x1f\xf9.\x82\x13+}6\xf6\xe1w,\x92\x19b\xd7\x05\x7f\x06\x8c\xbb\x06\xe5\xa5\xbb\x04\x94\x86\x9b\xae/\x04/\xf3\x17\xde\xed\xbd=\xa0s\xe3\xd9ut\xfb\x0f\xd4,(\xddc\xb4$r\x86\x1dJ\x86\xd2\x82\x92\xc1\xfbb\xf6\xa64\x11\x0b\x91\xf1B\x1e\xafI\xf3\xc5\xe8;F\x89[C\xb0\xf2;E\x9b\xc1\x08U\xaf\xd9\xe6\xff\xbb\xb4\xa1\x9dV\x7f\x06O\xc3\x8a\x9c\xa6\xf5]\xd0\x8c\xbe\x13S\xb8\xe4N\xce\xed\xa6\x8fy\xbb\x00\xa0P\xf9kg_\xb5\xb3[\xb44\x83J\xf4\xa6\xc9\x1b*\x99@\x1d\xcf\xc1\xe4\xee\x9f\xd0u\xd9PY\xbb\xd7O\xc7G\x01\xa0\xf14\xa6\x00\xa5o\"\xf2v\x8f\xa6\xe4\xb2\xf6U\xc2\xce\x181\xdf\xc7L\xbe\xc7\x9f\x97^\xe2\x07\xe3\xe1K\xceMn2\x0e.Y\xb5\xd4\xbdk+\xe0r\x0fq.i\x8b\x86$\x7f\x07\x00PK\x07\x08_\xc3\xc3\xcd\x82\x02\x00\x00,\x0b\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00roles/kubernetes/ha/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x91\xc1n\xc20\x0c\x86\xefy\x8a_\x1c\x06\x1c6\xee\x15\xda\x85#\x97\xbdA\xe4\xb6\xa6\x8d\xea&\x91\x93\xb0!\xed\xe1\xa7\x14\xd0\xa6i\xd2\xc45\xb6\xbf\xcf\xf1\xff\x0cO37\xd8O\xa5e\xf5\x9c9\xedFz5@\xcfm\x19\x1a\x03\x00s\x1a\x1a\xbc\xa9\xf3\x19\x99\x86\xdf\xbd8\x05\xc5\xb1\xb4|t\x19\x87\xe0On(J9(\"ib5\xe6.9\x8c\xdcM \x11\x8c!\xe5\x04RF\xcaN\x04t&'\xd4\n\x1b\xc0\xf9NJ\xcfV\x83\xf0\xd5\x7f]1*w\x15\xb0<eJS\xb2'\x0dsS\x81v\x01Z\x12w\xe6\xeak%tS\x1d6\xc0\xdd\xee|\xca\xd5\xfd\x84\xee\xb6$cb\x8e\xcbP\xbfP\xdd\x1c\x83f\xbb\xc0\x9b\x1f\xc5\x97\xcb,\xe6\x1f\xd4HQ\xc3\xc7\xe5\x0f\xce\xadr\x87\xbc\x8f\xec\x1b\xac6X\xcf\x942\xeb\x1a\xcec\xd0P\xa2\xad\x1fM\xd8\x82|\x8f\x8dKv$\xdbI\xa9M\xf8D\x1b\x82lW\xdf\xd7\xdc\xef\x1e\xc9l\xf7`h_\x03\x00PK\x07\x08\x92rq\xa5\x02\x01\x00\x00\x19\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x008\x00	\x00roles/kubernetes/ha/templates/etc/haproxy/haproxy.cfg.j2UT\x05\x00\x01\xf0b\xe7[\xecW[o\xdb6\x14~\xd7\xaf8C\x11\xb8E+\xdf\xd2\xa4m\x80>\x0c[\xb6=\x0cX\x80f\xc0\x80\xae (\xf2H\"B\x91\xea!\xe5$u\xf4\xdf\x07\xeabKr\x9a\xa6\xc8\xb0\xa7\xf9\xc9\xe6\xb9\xf2;\x1f?\xd2\xcfr\xefKw\xb6X\x88\xc4\x1a\x8f\xf3L\xf9\xbcJ\xe6\xca.r^\x92\xbd\xb9\x8d\xa5\xb0f\xb3X\xcdO\x16\xc2\x9aTe\x15q\xaf\xac\x99\xe7\xbe\xd0\xcf\x8e\xe7\xc7Q\xa6m\xc2u\x04`\x92\x92\xac\x80\xed\x16\xbaXf\xaa\x82\x89\xb2rP\xd7\xd1\xf6\x08RK\xa0@\x19 n2|>\xf5\xbaS\xc6\xbf\x80\xa3:\x02\x10e\x15\x17\xbc\x0c\xb9\x14\xbc\x84\x15\xd4u\xfb\xbdM\x84F\x86\\\xad+\x8f\x13\xee\x10\xb6[\x81\xe4\x99TT\x87em3X\xad\xdf\xcc\x97\xf3\xe5|\x05\xda\n\xae\x97\xc3\xd6\xb4\xcd\x98\xc6\x0dj\xe8\xddc\x87F\xc6\xb9u\xde\xf0\x02#\x80\x82\xdf\x08k\x0c\xbc]\xbd[\x87\x9er\xb2\xd6\xc3b\xc3i\xa1U\xd2\x03\x14\x01T\x0e	\xf6?3\xb2U9\xf8-9\x16\xd6D\x00\xbe28O\xaa\xd4\xa9/\x08\xc7\xeb7\xa7o\xfbE\xe7\xf4\\b\xca+\xedc\x99\xc7%'^\xc0z\xf9z\xe4 \xb8\xc8\xb1\x89]-\xc3gh\xd3*E\xaf\n\x84\xd3\xf1z\xc1o\x08\x85%	\xab\xd7\xa7\xc1\xe2\x9c\x8e\xfbJ\x8922\xb6e\x18\xa7\x1bb\xe3\x9cf\xc1\xc6z[]\xdf\x17)T\x99#\xdd\x1f\xd9\xdb\x9a\xc8gMU\x87\xb4A\x8a7H*\xbd\x05c\x0dFQ\xd7\x89k\x9c\x12\xae\xb9\x11\x08\x1a\xb9\xf3\x01\xf9n\x8c\xe1\xb3#Ya%\x86\x85\xc0\xdb\x08\xa0\xed\xb0\xf1\x8bs\xe4\xda\xe7\xb1\xc8Q\\\xb9\x89\xcda\xc0\xd4c\x8cD\x96\x86Vi\x8d\xd76\x8b\x8d\xa5\x82\xebC\x83\xa9\xf4p5\xd4\xd56\x9b\xac\xf4\x9b\x13\xda\xba@\x1dBO\n\x1d\x1c\x0f\xfc\x08\xa5r%\xf7\"\x1fpk\xdd\xcdQ\x15h+\x0fa\x0d\x85\x0f\x1b<\x99X\xb4B\xd3\x18\x86x\xb7\xab\xac\x0f\xbf\x83\x0e\xd0\xdd\xd9j\xfb\xea\x1d^\x81\xa7\n_\x0c\xfcf\xc7\xcbb\xd6/7\xc3\xea\\\xa1\x8d\x9c\x16\x1c\xe7\xbb\xa7\xe0\xb8\xa3o\x17\x8cR\n\xc2c$\xf0R\xb5\xd9\x1b2\x10~\xe6R\xc2_\xf1/\x96\xae9I\x94\xf1\x05Yo\xcf\xfenF\x10&\x18H\x18\xc0\xb8\xaa\x12d\x1bE\xbe\xe2\x9a\xa9\x92\xf1RA]\x9f\xed-\xcdR\xc3\xcd\xd2\x92\xef4D\xa5PV\x89Vb\x10\n\xdc\xc8\xc3\xd5}\xe4Q=({\xe0\xd7\x15} ~\xa7^*\x1d\xe4\xda\xc9\xd4\x83-w\xd4\xf7b@{/:.vcg	\x17Wc,\xf7\xf8\x12f\xcay\xba}\x14r\xbd3\xd3I\xdfA8\xc4 \xc8\xc3\xa2r\xb4h\x14u\x81^\xf4:\xb80V\xe2\xbc\xc4\xe2\xab\xe0>\n\xbd'\x16~\x10\xdb\xa7\xe4\xee\xe0\x1f\xcbN\xdar3\xb5\x81\xb3\\h\x90V\\!\xb1kR\x1e\x1d\x14\xe8s+\xe1\xe2\x8f\x0f\x97p\xf1\xe7%\xfc|\xfe\xfb\xf9\xe59\\\xfcx\xf9\xd3oQ'\x1c\x84\x9f+t\xe1\xb8\xf9 `\x12\xe9\x90\xf3\x8d\xa7\x0b\xa0n\xc3\x10X*\xa0\x9e\xc6K4\xb7\xc1c\xd4A\xa3DRQ\x90\x14'r,p\x9f\xea\x87Q\xae)\x81z\xa8\xa2\xe8\x90R\x0f2\xb1\x13\xe4PF\xe4W\xf0\xeb\xf9%,Za\xfe\xd2\xb7\xdc\x084\xe0M\xd9\xb4\xe5I\x99\x0c\xecU\x04\xbb\x0b\x80le$\xd9D\x99>\x84\xd0\x95\xd68\x1c\xc3\xf4\xa1\xe9\x07\x8e\xdc~\x03\x9d\x10\x832\x1e	VK\x07\xd2^\x9b\xf6\xd7\x89\x03R\x0ea\x0d)\xd7\x1a\xd6\xe0\xb4\xbdv\x9e\x93\x87\xd3\xa5\xdbK\xf2\xc92|\xff\\a\x85\xb0>9\x85kTY\xee\xc3\xa5\x1b\x01t\xcf\x98\xf0L\x08/\x99\xe6\xaew\x1fg\x05w\x1ei\xf6	\xe2\x86{\xed\x11Pf\x83\xc6[\xbae\xfd\xab\x02\xde\xbfoC{/\x87\x1e:\x84YW\xe7=\xacvf\xd4\x0e\x1f\xf6}>\xed\xe0N\xa3\xc9|\xde=\xa4\xc6G\xa2\x03g7\xca\xed\x16\xb4\xb5\xe5\\\x19\x897\xdd\x1b+\xb4\xb7\xe1\xe4>\x86/\x9f>r)	\x9dc\xfb\xbd\xa4\n\xb5\xfc4T\xd8\x89\xba\xf6\x80m\xb7\xd3\xad\xd55\x08\x1e\xa7J\xe3\xd7Dd\xbb\x0d\x8f,&8\x13\xe4Y\xf0\x0c\x8f\xb1&0\xb0&\x0e\x1a4|A\x00\x8c\x9e\x83\xd1\x01\x7f\x1f}\x02\xbeu\xbc;b\x8fn\xf1\xfb\xc8\xbeY/\xfe\xa7\xf2\x7fF\xe5~\xceOgr\x9fiw3<\xc4\xe2	\x0d[r\x1e\x90\xb1U\x014<\xd1\xc8\xd0\x0b\xc9\x1a\xc2\xb3\x86\xea\xc1\xe31\x13\xd0\xcay4\x10\xc2\xe3\xef\xd8\xd4\x1d\x10\x96\x9a\x0b|>\x9b\xcf^\xc1,\x9e\x85\xf7]\xac\x8c\xf2\x8a\xeb\x98\xcb\x0d\x92W\x0e\xe3\x12\xa7\x92~xq\x86\xe2\xacQJe\xb2\xb6}\xd6eb\xbbL\x0dp\xe1?\x1c\xbc\x1c\xccb\xd9\xfdyh\xc7\x15\x12MG\xf5\x95\x9e\xe0;6[\xd7g\xeb\xe3\xb7\xcb\x7f\x19\xab\xa7c\xf4tl\x9e\x88\xc9\x9bw\xd1\xf8\xff\xf2\xf0>\xf8g\x00PK\x07\x08z\x9cl\xa0\xe7\x04\x00\x00\xfc\x0f\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00?\x00	\x00roles/kubernetes/ha/templates/etc/keepalived/keepalived.conf.j2UT\x05\x00\x01\xf0b\xe7[\xdcTQk\xe3F\x10~\xd7\xaf\x98\xbb`\xfc\xd2s$][\xdaB\xa0n\x9aB\xe9\x1d\x1cu/}\x08aY\xafF\xf6\xe0\xd5\xee2\xbbR\xce\x18\xfd\xf7\xb2\xf2\xcav\xe2\xe4!\xa5\xd0\xd27\xed\xcc~3\xb3\xdf|\x9f\xde\xc0\xb555\xadZ\x96\x81\xac\x81_H#\xd4\x96a\x83\xe8\xa4\xa6\x0e\xab,\xbb\x80\x95\xb6K\xa9E\x85\xb5\x87]v\x01\x00\xc6\x06\xaaI\x0d(\x81\x8d$\x9d\x12\x00RI\xef\xd1\xfcX\x13\xe3\x83\xd4z\xa6\xadJ\xa9Z\x92\xb6\x1d\xf2s9\xbf\xf5\xb2j\xe8\x19\\\xffBGQ\xb3m`\xae\xf1\x8b4\x15\xe3\xec\xfa\x85\xbe\xbe	Nx\xe4\x0e\x19\x8a\xef\xcbY\xf1\xedw\xb32\xcfg\xc51\xab\xac1\xa8\x82\x08\xd4\xa0m\x03\xbc\xcf\x87\x1c\xdb6 \x0b\xaa\xe0\xc3\xedB\xfc|s{\xf3!\xbb\x80>{\xcc\x07\x00\x1a\xb9\xd4(\xbcbrAxT-S\xd8f}\x96u\xcc.\xc5a\xad\xd6\x1b\xb1\x96\x8e\xed\x97\xed\x00\x03H\x99\xb7\x97\xad\xe7\xcb%\x99\xcbq\x80\x12\x0e\xa1\x0di-\xb5\x86w9$\xf0\xdb\x01K& wRC9\x1c\x1f\x90V\xeb\x90\x0eu\x04\xbc\x1f\xe2L\x1e\xa1<\xccB\xc6\x07i\x14\xc2\xed\xaf\xa2\x18\xa7\x082 \xec&@5\x90\xe9\xd0\x04\xcb[\xb1\xb6>\x18\xd9 \\]\xc1\xb4\x91> \xe7y>\x85I\x0f\x1f\xe7\x8b?n~\x8f\x08\xd4\x1ec\xe4\xa7\xf9\xf5o\x9f?\x0d\x11SQ\x0d\x93~hn\xacc\xc4\xc6\x85\xec8r-\x15\xc2n\x07J\xb7\xb1\xa6\xa0\x18\x98U\xd8\x91B\xe8\xf7\xb8\x0b\xf8\xd8\xea@J\xfa\xf0C\xd4\x1a<X\xde\x90Y\x8150\xffs\xf1\x15,\x15P\x98zXj\xab6X%P\x13\x01\xc2\xb3\x12\xe4b\x8bM\xbbD!\xab\x0e9\x90\x8f_\x15\xa3\xf7\xc7&\x9fMj\x11\xcb\xfbT|\x1c\x0c\xa4\xa9\x00;\xe4\xed\xc3\x1a\x19\x07Hk\xe8U-\xc6\xfb\x0e\x91\x13\xd9\xbb\xc9`\xb0\xc8.\x90\x81\x15\xdb\xd6\xf9\xbbD\xf0\xf4\x1e\xde%\xea -$^\xec$\xfb\xbb\xf8q\x7f7uL\x9d\x0c(\xc8M\xef\xe1\xcd\xd5K\x03\x1c\xaa@$\xe2I\x91D\x848.\xbb&\xd4\xd5\xfd\xc8\x0c\x9c-r\x7f\x8e\x83\xa7\xc2\xfd~\xa3\x1dqh\xa5\x16G\xa7|S\x0c	\xc7d\xa3	^#\xab\"/N5U\xe4\xf9\xa39\x86\xba\xfb\x97\n2\xa3\xd4e\x1b\xd6hB\xfa\x17%\x92\xc7\x84\x08[\x87\xf0i\xbeX<\x0e;\xe9=\x14eY<\xf7\x16r\xa3P\x8e\xc5F1\x8d\xef%'\xa4\xa3\x91\xafT \xb0T\x9b\xd1\xedG\xec\xa9\xefS\xbf>\xcb\xf6\xbc\xb8v\xa9I\x9d\x94\x1dTw\x88\xba\xbd;\x86\xe0a\x87'\xacE=\xec\xef\x0er\x98\xf4\xe7&/\xffU\x93?}\xc9\x13\x9f\x9f\xfb\xe9\x0c@\xae\xfbz6.\xe4\xbfm\xabG\xbb\xf8'\xacT\xfeO\xadt\xae\xfa\xc4\xd6\xdf0\xd2\xe9\x1f\xe2\xaf\x01\x00PK\x07\x08\x0f\xd9\x11u\xdb\x02\x00\x00\xcf\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00G\x00	\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/haproxy.service.j2UT\x05\x00\x01\xf0b\xe7[\xacUMo\xda@\x10\xbd\xfbW\xcc\xa1\x11\xad\x94\x85\xaaR/H{H\x14\x9a U\n\x82FmE\x91\xb5Y\x8f\xc9\n{\xd7\x9d\x1d\xa7A\x96\xff{\xb5`\x08\x1f\x81$mN\xe0\xf1\xcc{\xe3\xd9\xf7f\xc77\xd6\xf0$\xba@\xaf\xc9\x14l\x9c\x95Wg\x03r\x0fs\xf8\xeaT\x02\xe7*SV#Eg)#I\x8b\xfc\xc7\xd1\xac\xcd\x8a\xa6\xc8PU\xa0\x9dee,RL\xa5e\x93c\xec\x91\xee\x8dF\xa8\xebh\x88\xbfKC\xe8\xe5s\x89\xd1x\xb4\xac\x9aD\xdfL\x8e\xae\xe4\x11+\xe2\x11j\xf91\x1a\xa2\x0f\x0f\xd2Y\x91*\x93\x95\x84\xabPx\xff9\xaaN\xc0\xa4\xfb\xf8 %\xb4\xd6\xd1\xa4\x05'u\xd4{@\xbd@\x1e\x10J\x11\xdag\x8au\x9e@]\x03+?\xf3\x90`\x86\x8c D\xeaH#\xdc\xa9\"\x0c\xe3h\xe1\x9ac]\xbd\xaa\xaaN\x003\x8f\xfb\xcc\x9d\xd2S\xe7\xd6\xd8N\xe2\xf4\x0c	f&\xcb\x0ep\xed\xa6R\xbe\x05o\x13\x93\xee\xe1/\xa0\xfd\x1d\x08\x0d\xadu\xbd\x9f{\xc6\\s\x06\x1eY\xa0\xbd7\xe4l\x8e\x96\xe1\xeal0\xbc\xfe\xf13\xbe\x19\xf5\x86q\xffB\xbe{\xbf.2	\x88rE\xf8\xa1\xf5&4\x97\xc3\xeb\x9b\xc1\x13<\xd3\x0d\x9e\x7f:T\xb9}\xa0TZ\x10\x82r\x10\xc2\"\x8b;\xe7\x19~E\x00\x00\"\xf4\xb5\xff\xd9\xd5N\xa4~2\xfd\xb1\xfdj7\xf4X\x90\xbb\xd22\xf0\xbc@yklr\xeaI\xcb\x0e\xb2\xee4\x9f\xb8\xfam\xebtz\x9ax\x96\x8bIdN\xab\xec`\x9a[\xf8\xd3K\n\x88]rG\xc9\xc2(\x908N\x0cA]w\xaa\n\xc89\x8e\xb5\x8a5q\x9c\x9a\x0c\xad\xca\x83\xf7\x8e\xb1\x1f\xa9\xfa\xcff\xc2\xa3o[\x97`[\x13?\xd3\xc6*\xed\x0dIg8\x7f	\xe9\x0c\xe7\x87H\x1b\xad\x11\x16.\x0eC\x82\xba\x0e\xa1\x92\x08-\xc7\xb9\xb2&E\xcf\xe3V\x82\x05\xda\x04\xad6\xe8[\x93\xf1b#\x91\xcbD\x91)\x8b!\xd0\x10\x86\xbf\x9etk\x12v\xd1\x92\xa2y\x135\xaes\xc5\x8e\xc0\x97\x1bkkw<\xb5q\xe4\xee\xb6\xd96FX\xe8r\xd3\x1c\xf8\ng \xecz\xe0\x98-\xee\xe1\x90\xb8\xbb\x07N\xa1I\x0d6y\x04y\xb1\xb6\xbb\xaf\xd6\xf51\x92=\xcdv\x9f\xd1\xeb\x0b\xc1\x96Z<\n\x16R\x1a0\xb1\xe8\xb4\x99K\x13\xe4Dw\xdeL|\x1br\xdb\x15\x8egW\x80`\xf8\xb4\x96\xe6\xe6\x15\xd4\\\xca_\xc2\xc5\xd9{0\xe1\n\xe7\xd2\xcbQ\xffr\xd0\x1f\xf4\xa2h\xdc\xb7\x9eU\x96M\xa2\xef\xca2&\xe7s\x99\x97\x19\x1bQz\xa46+\x9a\"G\x7f\x07\x00PK\x07\x08>)\x8b\xdf\x98\x02\x00\x00\x91\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00J\x00	\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/keepalived.service.j2UT\x05\x00\x01\xf0b\xe7[\xccU\xd1\x8b\x1a?\x10~\xdf\xbfb^\xc4\x97\x8b\xfe\xf8A)\x08yh\xe9\xb5\x1c\xa5T\xce\x96>X\x91\x98\xcc\xda`6\xd9Nf\xed\x89\xdd\xff\xbd\xc4\xdb\xea\xbazZ\x0e\n\xdd\xa7\x9d\xc9\xcc\xf7e&_2\xd3\xcf\xde\xf2,{\x83Q\x93-\xd9\x06/\xdf#\x96\xca\xd95\x1a\xc8\x03\xc1\x07\x15\x19	|0\x18\xb3W9#\xc9\xed\x16t\xf0\xac\xacG\x9aS\xe5\xd9\x168\x8fHk\xab\x11\xea\x1a\xb6=\xb09,6\x1ey\x8e^-\x1c\x9a\x9f\x8b\x10\x1c\xf4\xea\xc5\xc6#\x0f\x9a\xd8m\x0f\xd0\x1b\x9bC\xaf\xce\xee\xf1{e	\xe35\xf0\xec\x02x\xf6Ey\x8e\xf2\x88#\x1b+\xe2\x8fy\xc7\xd9f\xce\xa6\x93\xc7\xed\xcc\xb2O\xb6\xc0P\xf1\x84\x15\xf1\x04\xb5\xfc/\xbb\xc7\x98\x0c\x19\xbc\xc8\x95u\x15\xe1oWZ\x7f\xd1\xec\xe6\xa4\x1b %\xf4\xf7^\xd3O\x9b\xbb}@\xbdC\x1e\x13J\x91\xcad\x9a\xeb\xc2@]\x03\xab\xb8\x8a`\xd0!#\x08\x91\x07\xd2\x08\xab\xfdA\\\xcc\xdd\xd3\xec\x01\xce%\xcacB\xaa<\x08A\x05\x08\xe1\x91\xc5\xb7\x10\x19\x84(\xc9\xae\xad\xc3%\x1a\xf8\x9a\x01\x00\x08Q\x84\xca3\xf0\xa6D\xb9\xb0\xde\xdcD\xd2r\x88\xac\x87\x07\x92\x1b\x13\xf9\xc4\x17vb\x8a\x92R\xd6\x88\xc2u\xc0\xb8\x89:\xf8\xdc.\xcfB\x9f]}\x16	ca\x12\x1cc1<R\xc5\xa0U\xc9\x95\xb8'\x88\x9b\x1e\x13\x96aN!0\xd4urUD\xe8y^(os\x8c<\xed\x1b,\xd1\x1b\xf4\xdab\xec\xcf\xa6;\xa5Pp\xa2t\xcacr\x1c:\x90\xacH\xba?K2y\xec\xe1a\xb1q\xa4oXE\x1a\xc6\x85\xf5\xad\xee\x81\x10&xNrZ\xa5\xff\xaa(Ej\"\x08\xe1\xc22\xfd\xc6\xe0\xb0\xb1\x0c\xb2\xb2\xae1r\xa5\xad\xb3\xbc\x81\x97 \xc4\x9a\xa8\x04\x91C\xe7\x88[D\x83\x84\x9a5*\x0deGk\x8f\xe2^Y\xe7\xda\xcaLW\xd0E<\xbd\x19\xbbJR!&\xe8\x15\xd2I\xe2\xe5h*\xce\xc6\xca\x93\xb8\xa3\x0b\xf0#\xd0J>y	\xd6\xdd\xd2G]\x9bB'\xf6\x9c\\GO\xca\xfcl\xfe\x05\x9d\x8e\xfe$\xe6\x80)\xbc*\xda\xcfI\xe3g\xa3\x87\x7fA\x9e\xff\x9c\x1a\xbbG\x1f9\x94 \x18\xfeo\xb5\xe4h\"4/\xfc\xdb\xf4\n\xdf>\xd84\x0f\xb8\x8arr\xf7n|7\xbe\xcd\xb2\xe9\x9d\x8f\xac\x9c\x9b\xed\x06\x0e\x9a\xd7\x1bYT\x8e\xad\xa8\"\xd2\x80\x15-\x91\x9f5\x04\x7f\x0d\x00PK\x07\x08\xc9\x08\x8ebm\x02\x00\x00\x8e\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x004\x00	\x00roles/kubernetes/security-policies/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x91\xc1\x8a\xe30\x0c\x86\xef~\n\x93=\xbb9uY\xf22\xc6\x95\xd5F\xc4\xb1\xbd\x92R\x08\xa5\xef>\xc4\xedL\xa7-\x0c\xcc\xcd\xfa\xf4\xff\xd2\x8f<-\x07\xf4\xa1\x92\x17\xe43\xb2O\x05B\x1al7\xaaV\x19\xfa\xbe\xd5c\x11\x1d.\x17\xfb\x10K\xf2\xb5\xb0\xda\xeb\xb53ot\xb0\xdd\xdf\xfd\xfe_gLF\xf5\xb5$\x82\xd5\xcf!\xd3\x11Ee0\xd6:{\x080a\x8e\xee\xd6m(\xe21,I]\xc4|\x07Y\xbe\xf7\x8f\\\xb2\xbexF\x0cU\x14\xf9\xa1\xfb\xe3ZL'\x1a\x14\xdd\x8c\xca\x04_cL\x95\xfa\x9a\xa42\x9d)\xe1	c[\xca(\x9bE1\x1a\xc3\x87\x00\xbf\x90W\xa6\xc2\xa4\xab\x87\x14D^\x8d\xb7T\xab(\xce\x0e\x98\x94 \xa4\xb7\xc6H\xa7\xb1A\xc0:>\xcb\xee\xe71\x86Q\xca\xc2\x80\xfe\xffR4\xfc\xb0\xa6\xd9\xb8\x94\xc9m\xd3\x9e\xabO\x89\x01d\xf5\x91x\xb0=*\xf4u\xa2\x1bk\xe3B\x9c)o\x0fk'\\\x87;\xd8M\xb8]\xdaZ\xd8\xfe\xda\x868S\xde\x01\xab\xf9\x18\x00PK\x07\x08Sd\xb5!\x0f\x01\x00\x00M\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/backend-policy.yamlUT\x05\x00\x01\xf0b\xe7[l\x8dAJD1\x10D\xf79E] #\xee$g\x10\x11\x04\xf7\xfd\x93\x9e1$\xe9\x0e\xe9\xa8\xcc\xede\x88\xff\xbbpjW\xfd\xe8W%K\nx\xe1\xf9\xad\xa3\xbcj\xcd\xf1\xea\xa8\xe7w\x1e\x96U\x02d\x91,\x97Sy\xb2S\xd6\x87\xafG\xd7xR\xa2I\xc1\x01B\x8d\xadS\xe4\x80\xf2\xb9\xb1\xb7\xabMn\xbf `\xa3XX\x92\xefKm\x9d\xe3\xed\xabkz\xe3\xcaq\xea\xb8U\xa0\xd1\x8c\x1f\xcf\xb4q\xb5u\x00\x86\xd6?\x81\x03\xb2\\\x06\xdb\x81=\xceC\xdb\xde\x00\xff_\xba\xe7\x8e|\xcf\x1a9\x0f\x95\xc9\x92\x0e\x19\xe0\x00\x00\x00\xdc\xcf\x00PK\x07\x08\xac\xaf\x17\xec\xa4\x00\x00\x00#\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00[\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/default-deny.yamlUT\x05\x00\x01\xf0b\xe7[,\xcbA\n\xc2@\x0cF\xe1\xfd\x9c\"\x17h\xc5\x9d\xcc!D\x10\xdc\xc7\x99_	\xd3&C\x93*E\xbc\xbb\x88n\xdf\xe3k\xa25\xd3\x11\xf1\xb4\xa5\x9dl\x92\xb2%\xeer\xc1\xe2b\x9aI\x7fG\xf4>\xb6\x83\x8fb\xbb\xc7>\xcd\x08\xae\x1c\x9c\x13\x91\xf2\x8cL\x157^\xa7\x18*t\xfbG\xef\\\x90\xa9\xadW\x0c\xbey`N\xdeQ\xbe\xa6[=cB	[2\xbd\xde\xe93\x00PK\x07\x08\xa9\x91\x81xr\x00\x00\x00\x85\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00Y\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/dns-policy.yamlUT\x05\x00\x01\xf0b\xe7[,\xcbA\x8a\x021\x10\x85\xe1}N\xf1.\xd0=\xccn\xc8\x19\x06\x11\x04\xf7\xd5I\xd1\x86t*E**\x8dxwi\xe2\xf2\xfd\x8f/'\x89\x1e'\xee\xcf\xda\xf2\xb9n)\xec\x8e4]\xb9Y\xaa\xe2!\xe3I\xb2\xce\xf9\xcf\xe6T\x7f\x1e\xbf\xaep\xa7H\x9d\xbc\x03\x84\n{D\xb1I\x87\x1e\xc9\x94\x02{\xe4\xfb\xc2\x93\xed\xd6\xb98S\x0e\x87\xd0\x1a/\xbcq\xe8\xb5\x1d\x13(\xd4\xc3\xed\x9f\x16\xdel\x04\x80T\xbf8\x8a9 \xc9\xda\xd8\xcc;`\xc2\xeb\xed>\x03\x00PK\x07\x08&\xe1_0\x8b\x00\x00\x00\xb7\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/frontend-policy.yamlUT\x05\x00\x01\xf0b\xe7[l\x8f1k\xc30\x10\x85w\xff\x8a\x83\xceN\xe5:)\xb2\xb6\xee\xa5\x14\n]J\x07E>'\xc2\x92NH\xd7\x86\xfc\xfb\x12l\xd2 e\xd4\xf7\xbe\xf7\xd0\xcd6\x8c\n\xde\x90O\x94\xe6wr\xd6\x9c\x1b\x1d\xed'\xa6l)(\x08Kb\xc3a3\xcb\xbc\xb1\xf4\xf8\xdb5\x1eY\x8f\x9a\xb5j\x00\x82\xf6\x98\xa36\xa8`\xfe\xd9c\x9b\xcf\x99\xd1\xaf\x81\x82)Q`\x0cc\x1b\x97\xed\x1c\xd1\\j\x91\xc6\x0fth\x98\xd2\xe5	\xe05\x9b\xe3\xab\xde\xa3\xcb\x0b\x00H\xe4n\x16\xa0\x01\xb0\xe1\x900\xafB\x0b\x91\x12_\xed\x07xq\x8eNpd\x8e\xc0IO\x935\xc0\xf4\xdf\x8f4\xe6\xd5]\xaa\n\xa4(\xc0v\xdb\x17\xa4\x17\xa2\xb2zQ{;!j\xadB\xbbg\xd1\x15H\xd6\xfbR\xc8;\xd6S\x8d\xcaO\x0cB\x0e\x15\x1a\xca\xf9A\x0ceQ\xde9\xa8\xbbZS\"\xaf\xe0\xeb\xbb\xf9\x1b\x00PK\x07\x08\x93v\x94\xe0\xea\x00\x00\x00.\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00^\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/heapster-policy.yamlUT\x05\x00\x01\xf0b\xe7[\x9c\x90\xcdJC1\x10\x85\xf7y\x8a\xa1]\xa7V\xdd\\\x02\xbe\x81\x88 \xb8\x9f\x9bL\xdbpo~\x98\x99*}{\x89i+\xb6(hVg\xe6p\xbe\x1cf\x8a98x\"}/<=\x979\xfa\x83\xc1\x1a_\x89%\x96\xec w'\xe6\xedj\x1ad\x15\xcb\xcd\xdb\xadI\xa4\x18P\xd1\x19\x80\x8c\x89\xa4\xa2'\x07\xd3~$+\x07QJG\xc3\xc1\x8e\xb0\x8a\x12\xdb\xda\xd9R\xc9\xb7X-\xe1\x85f\xf2Z\xb8\x8d\x00	\xd5\xef\x1eq\xa4Y\xfa\x02\x00k\xfd\x02\x18\x80\x98\xb7L\xf2i[\xd8pIM5}\xee\xf0\x1d\xf9\x03\xb6\xbd\x13\xd5\x9d\xa0G\xd2U\xad_\x18\x97\xf5\xfe\x9a?Em\"\xe5\xe8\xc5\x01e\x1cg\n\xff\xed\xd2\xee\xcf\x99\x94\xc4\x06\x94\xddX\x90\x83Yv\x14\xab\xb8\xa6\x01\x96\xb0\x17\n\xb0)\x0c\x8b\x96\xf0:\x83\x96\xda\xbe\x13\xb0\xf6\xdc\xaa\x16\xd6\x87a\xbd\xe8\xa9\xcep0\xac/\xe7\xe1\xeejso>\x06\x00PK\x07\x08\x1d\xcf\xca\xf8\xf6\x00\x00\x00W\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00h\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/kube-state-metrics-policy.yamlUT\x05\x00\x01\xf0b\xe7[\x94\xce\xdfJC1\x0c\x06\xf0\xfb>E^\xe0L\xbc\x93>\x83\x88 x\x9f\xd3~\xce\xd0\xbf4Q\xd9\xdbK=\x9b \xdb\x84\xf5*M\xc2/_\x92\x1a==\xc1\xbe\xdaH\xcf-K88\xee\xf2\x8a\xa1\xd2\xaa\xa7\xbaM\xa4\xeew\xe9Aw\xd2\xee>\xef]\x81qdc\xef\x88*\x17h\xe7\x00O\xe9c\xc5\xa2\x075\x94\xe3\xe0\xd436,\x056$\xe8\xd2\xb7+\xda\x11&\xd0[|AF\xb06\xe6\x97\xa8\xb0\x85\xf7G^\x91uk\x10q\xef\x97(G$u?\xa0?\x8b\x0b\xbd\x8dVf5\xeb\xdf\\\x7f\xf1+\x07\xe6;\xf7\xfd\x89?\x9agQ\xff\xd1\xaeG\xbeU\xba\x94\x0b\x95\xd7\x8c\xe8\xbe\x07\x00PK\x07\x08\x83\xa9\xf3\xad\xb4\x00\x00\x00\xbf\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00c\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/ceph-critical.yamlUT\x05\x00\x01\xf0b\xe7[\xcc\x92\xb1n\xf30\x0c\x84w=\xc5\xbd\x80\xfd\xe7\x1f\n\xb4^\xd3\x07\xe8Ptg$\xc6&BK\x82H\xa7\x08\xd0\x87/\xe2$C\xd1\xad\xc8\x10\x08\xd0\"\xf1\x8e\xf7\xe1\xa8\xca\x077\x93\x92\x07X\x9c8-*y\xec\x0f\xcf\xd6K\xf9w\xfc\x1f\x0e\x92\xd3\x80\xb7&\xa5\x89\x9f\xb6Jfaf\xa7DNC\x002\xcd< r\x9d\xba\xd8\xc4%\x92\x86#\xe9\xc2\x03^6\x9b\xcb	\xa3\x96\x1d\xe9+\xefiQ\x1f\xb0'5\x0e\x89-6\xa9\xbez\x7f\x05`{\x9dG-\xc9\xe0\x139\xa81rq\xc4\x92M\x127N\xb0\x939\xcfP>\xb2\x82rB\xe3\xf3\x13$\xc3'F+\xe5\xd0\xad\xeb\\?f\x9a\xd9*E\xee\x03\xf0>\x89\xe1STa~\xbeGvx[\xe6\xca	\xbb\xd3*\xf0C\xff\x16	\xf1\x1c\x9c\xad\x0f]\xd7\x85{B\xbb\xd8=(\xbb\x15\xef\x0d\xe8\xc3\x93\xf4\xd2h\xe4\xdf(\x9f\xfeX\xc3\xab\xe0}\xeaxoz\xdf\x03\x00PK\x07\x08\xb9d\xfd\xfa\xf9\x00\x00\x00\xb9\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00]\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/default.yamlUT\x05\x00\x01\xf0b\xe7[T\x8eAN\xc3@\x0cE\xf7s\x8a\x7f\x82PX\xa1\xd9\xc2\x01\xbab\xefdLb\xd5\x1d\x8f\xc6N#$\x0e\x8f\x9a\x82\"\xf4\x97_z\xefQ\x93\x0f\xee.V3|Z\xb8\xac*u\x1e.\xaf>\x88=\xdd\x9e\xd3Ej\xc98w\xb1.\xf1\xf5\xa6\xe4\x9e\xae\x1cT(('\x00\xa8t\xe5\x8c\xc2\x9f\xb4j\xa4\x1b\xe9\xca\x19/\xa7\xd3ciV\x1bI\xdf\x1fwF\xf4\x95Sa\x9f\xba\xb4\xd8\xb5\xdf;\xe5l\xc5Q-@\xee2W.\xa0\xffVl\xa2\x8a\x91Q\xba\xb5\xc6\x05R\xc3\x10\x0b\xff\xa91\xdd\xe3\xb0I,\xd8+\x8e\x88\xe1P\xec\xb7\xda\xc6\x1d\xed\x17\x0f\xea\x0c\x8f;\xbe\x99\xbb\x8c\xca\xe9g\x00PK\x07\x08\xc6Wi\xeb\xb3\x00\x00\x00\x18\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00j\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-critical.yamlUT\x05\x00\x01\xf0b\xe7[T\xceAj\xc3@\x0c\x05\xd0\xfd\x9c\xe2_ n\xb2+\xb3M\x0f\xd0E\xe9^\x9eQlay\xc6\x8cd\x17C\x0f_\xe2\xa6\xd0 \xd0F\xe8\xffG\x8b|r3\xa9%\xc2\xd2\xc8yU)C7\xbdZ'\xf5e\xbb\x84IJ\x8exoR\x9b\xf8~U2\x0b3;er\x8a\x01(4s\xc4\xb4\xf6|\xb2\xdd\x9c\xe7Sj\xe2\x92H\xc3F\xbar\xc4\xe5|~L\x18\xb4\xf6\xa4o|\xa3U=\xe2Fj\x1c2[j\xb2\xf8a\xf8\x0e\xc0\xf5\x11\x80\xa5f\x83\x8f\xe4\xa0\xc6(\xd5\x91j1\xc9\xdc8\xe3\xb7\x0d\xca\x1b+\xa8d4\xbe\x9f \x05>\xf2\x7f\xd1a\xb4\x85\x12w\x01\xf8\x18\xc5\xf0%\xaa0\xbf\xef\x81\x1d\xde\xd6y\xe1\x8c~?~\x9f\xa2\xd3\x9f&)\x99\xb1u\xe1g\x00PK\x07\x08y\x84\x11Z\xcb\x00\x00\x004\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00f\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-high.yamlUT\x05\x00\x01\xf0b\xe7[d\x8e1n\xf30\x0cFw\x9d\xe2\xbb@\xfc\xe7\xdf\x02\xad\xed\xd0\xb1C\xd1\x9d\x91\x18\x8b\x08-	\"\x9d\xc2@\x0f_\xc4\xe9\x10\xa0 \xc0\x85\xe0{\x8f\xba|\xf20i5\xc2R\xe1\xbc\xaa\xd4y\xba\x9el\x92\xf6\xef\xf6?\\\xa5\xe6\x88\xf7!m\x88o/Jfaa\xa7LN1\x00\x95\x16\x8e\xb8\xaeg>\xd8f\xce\xcb\xa1\xc8\\\xc2\x8dt\xe5\x88\xd3\xf1\xf8\x980k;\x93\xbe\xf2\x85V\xf5\x88\x0b\xa9q\xc8liH\xf7]\xff\x1d\x807\x99\x0b\xfa\xaf\x0c\xbde\x83\x17r\xd0`\xd4\xe6H\xad\x9ad\x1e\x9c\xf1\xb0A\xf9\xc6\n\xaa\x19\x83\xef'H\x85\x17~.\xda\x1b\xadS\xe2)\x00\x1fE\x0c_\xa2\n\xf3\xfb\x9e\xd9\xe1c]:g\x9c\xb7\xfd\xf7\x0f\xfa\x19\x96\x86\xb8$R$%3\xb6)\xfc\x0c\x00PK\x07\x08\x10\xae\x8f\xb7\xd2\x00\x00\x00D\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/privileged.yamlUT\x05\x00\x01\xf0b\xe7[t\x8eMk\"A\x10\x86\xef\xf3+\xea&,\xec\xb8\xb2\xb8\x87\xbe\x89\x1b\x82\x10dHH\xee\xe5L\x19\x0b\xbb\xbb\x9a\xaej?\xfe}\xe8\x891\x18\xf0X\xef\xc7\xf3\x16&~\xa3\xac,\xd1A\x12\xcf\xfdyz\x98m\xc8p\xd6\xec9\x0e\x0e:\x19^\xa8/\x99\xed\xdc\x8d~\x13\xc8p@C\xd7\x00D\x0c\xe4 e>\xb0\xa7w\x1a\x1a\x00\x8cQ\x0c\x8d%jM\x00(\xf5\xbd\x84\xd4\xea\x05\xd3\xa2O;l\xf7eC9\x92\x91\xb6,S\xf4^\x8e4tY\xb6\xeci\x8d\x81\xd4\xc1\xe4\xd7\xa4\xd1D}\xe5|o8\xb0\\\xa8.\xd5N\xf7\xa5?h\x8f~\xdc\xbd\x0d\xd0\xb0\xc4\x84\x1b\xf6lL\x97\x97~\x8fh\x80\x83\xf8\x12~\x8a;Q[\x93\x1d%\xef\xaf\xa4\xaau\x92\xed\x1a\x0d\x1c\x1d\xfc\x19{\x00\x01O\x0e\xfe\xcd\xe7\x7f\xe7\x97\xe8\xaa[\xdeVW\xff\xafw.q\xa1\xafJ\xf9\x13\x95\x8b'\x07\x93\xe7\xaa.\xe2\xb9>\xa0\xf4\xc4\xb1\x9c\xee\xfb%%O\x81\xa2\xa1\x7f\xccR\x92\xdeEmu\x0c\xdc\xf1?\x06\x00PK\x07\x08\x94\x901G\x0e\x01\x00\x00\xfd\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00U\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/restricted.yamlUT\x05\x00\x01\xf0b\xe7[\xbc\x94Oo\x1b7\x10\xc5\xef\xfa\x14\x03\xe4\xa0Ck\x19F\xe1\x1e\xf6&\xd8Ia\xc0q\x05\xa7\xcd}\xb4\x9c\xd5N\xcd\x1d\xb23C)\xfa\xf6\x05\xa9\x7fv\x9b\x83\x81\x029\xee\x13\xf9{o\xfeP\x98\xf9+\xa9q\x92\x0er\x8a\xdc\xef\xaf\xb77kr\xbc\x99\xbd\xb0\x84\x0eV)|\xa1\xbe(\xfb~\xd5~\x9fM\xe4\x18\xd0\xb1\x9b\x01\x08N\xd4\x81\x92\xb9r\xef\x14f\x00(\x92\x1c\x9d\x93X=\x01`\xd4\xf7i\xca\x0b;b\x16\x18\xf3\x88\x8b\x97\xb2&\x15r\xb2\x05\xa7k\x8c1\xed(\xac4\x0d\x1c\xe9	'\xb2\x0e\xe6!\xf5/\xa4\xd7\x81\x06,\xd1\xe7\x0d\x879\xa3NI/\xbc\x1a\xf7]8-\xe2<\xd1[\xde\xbb\xe2\x1do\xbc\x8a\xd7\xc1\xff\x88\xf7}\xdc\x7f\xe2Y\xa6\xbe\xf60+o9\xd2\x86B\x07\x03F\xa3\x19\xc0\x07x\xa6\xbf\x0b+\x05\xf0\x04YiK\xe2@\xd6c<4\xbf\xca\x9a\x92/\xeaLjwW'\xca\xc7\xf3\xa1\xd7\xb8?F6`\x03\xa5P$\xa08\xec\xd8G\x90$W\x15\x03?A`k\xa0K\x9eW~?\xb7L\xeb\xe2\xb0#\xe8Q k\xdar `\x87!)\x04\x1aH\x8c\x80\x05\x02e\x1fk,=Vp\xaf)\xdfa\xc65Gv\xa6\xe3\xde\\\xc1\xf2\xf1\xb1Q\x97\xcd\xb5OJ\xb0M\xb1L\x04\xbe\xcfd\x15q\xf8>\xdf\x98\xf7I\x06\xde|\xc6<?)4e\xdf\xdf\xb3\x9e\x85\xac\xe9/\xaa\xcbzV\x8cz\xa5\xe3z]\xd5\xb1\xeed\x87\x1a\x96\xab\x87\x83\xf6\x01\x96f\xcdvD\x87\\\xdf\x8b9\x89\x7f=\x98\x83\x91C\xc9\xb0\xde\x83\x8f\x04},\xe6\xa4\x80ab\x01T\x02\xc3\x81\xea8\x8a\xd1\xe2d\xf2o\xca]D\x9e\xaa\xdd\x98\xcc\x9f\xc8wI_.\xe3\xa9\xe2\xc3\xea\xee\xad\xb0z\xb8\xbf\x08Zdi\x7f\x1aiw\x8c|\\\x8fC\xa4$\x8e,\xa45\x85\x16i\xa3M\xc5\xdb\x82\\\xc6\xd9:\n\xa0%R\x07\xf3\xcf\xc5\xfc\xb9R\x9f\x92<\xa7\xd4\xfac\xf4\xc8R\xbe\x9d<\xda\xce\x1c\xfe6\x00[\x8b\xac\xf9I\nd\xad\xf4b,\x1bX\xe6\xbc\xaco\x16\x14}\xac)F\x14\xf8\xf2\xb1\xb1\xdex6\xbf\xa5\xec\x9bW\xc99\xd2D\xe2\x18\x7f\xd3T\xb2u\xdf\x8dW\xcf\x02(\xca\xe6\xb4\x07\xb5\xfcOI\xd7\x1c\x00C\xa8\x01j\xa8V\xeb\xa6\x82\x0e\x96u\x0c\x13K\x077\xc7O\x80	\xbfu\xf0\xeb\xed\xed/\xb73\x80\xc1\x9a\xeb\x8f5U\xc2\xf0\xbb\xc4}m\xf8'\x8ed{s\x9a:\x180\x1a\xcd\xfe\x19\x00PK\x07\x08\xc5_\x81\xb6|\x02\x00\x00\xae\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/privileged.yamlUT\x05\x00\x01\xf0b\xe7[\xacRAN\xc30\x10\xbc\xfb\x15\xfe@\x8azC\xb9\x01\x07.\x88C\x11\xdc\x1d{Z\x96:\xb6\xb5kG*\xafGM\x1c\xd4\xa0*\x02\x89\xdc23;;\x1e\xadI\xf4\x06\x16\x8a\xa1\xd5\xc3V\x1d)\xb8V?\x91dE\x19\xbd\xb4J\xebF_\x8a\xb83vcJ~\x8fL\x9f&S\x0c\x9b\xe3\xadl(\xde\x0c[\xa5\xb5\xd6\x93\xc5\x83/\x92\xc1\xbb\xe81\xa2=\xb2q&\x9b\xb3\xe1\xf9\x0b\xa6G\xab\x13\xd3@\x1e\x07\xb8&Ij\x8a\x80G\x9e\x8b\xc7\xb8\xbbn\x7f\xe4XR\x05\xceP\x8a\x9e\xec\xa9\xfe2$\x16\xb6\xf3\xc0\xc4;\x81-L\xf94J	\xf2C\xfcl\xfa\xc5\xc0w\x90\xaa\x1b\xc0\xdd\x05_\x04\xea\x1f\xaa\xb8\xa7\xe0(\x1c\xae6\xb2\xd2\xc9\x14^J\xf7\x01\x9bk\xaa\xa6\x16=VS-\xe6\xa6V\xa2\xa9_\xad\xbaf>\x8d\x18\xd7SXh^\xc0\x03Y\xdcY\x1bK\xc8\x0b\x7f\x87\xbd)\xfe\x12\x93d,Z},\x1d\x1a9IF\xbf\xf0z\x9d\x0f`v\x18\x85&\x91\x80\x87Jq\xf4\xd8a\xdf\xfe\xf9\xcd\xd7\x0fs\xa5v\xf55\x00PK\x07\x08\xb8\x81\xa92\x18\x01\x00\x00\x1d\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00V\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/restricted.yamlUT\x05\x00\x01\xf0b\xe7[\xacR\xbbn\xc30\x0c\xdc\xf5\x15\xfc\x81\xb8\xc8Vxl\x87.E\x87\x0c\xdde\x89M\xd9\xc8\x92@R\x06\xd2\xaf/,+\xa9\xd1G\x80\x00\xf1\xe6\xbb#\xefp\xa2\xcd\xf4\x8a,\x94b\x0f\xd3\xd6\x1c(\xfa\x1e\x9eI\xd4\x90\xe2(\xbd\x01\xd8\xc0Z\xc4\x83u\x9d-\xfa\x9e\x98>\xadR\x8a\xdd\xe1^:Jw\xd3\xd6\x00\x00,+\x1eC\x11E\xde\xa5\x80\x15\x1dQ\xad\xb7j\xe7\x85\xf3\x17\xed\x88=0\x8a29E\xbf\xc9\x927E\x90+\xcf%`\xf5n\xeeO\x9cJn\xc0\x0c\xe5\x14\xc8\x1d\xdb/\xa3\xa4\xc2\xee4\xb0\xf0^\xd0\x15&=V)\xa1\xfc\x10\xbf\xd8q=\xf0\x1d\xa4\xe9&\xe4a\xc5\x17As\x83*\x1e(z\x8a\xfb\xeb\x1aY\xa2K\x19>\xd0\xe9*\xd3Rt\xad\xa6ap\xee\xeaB\xb8\xb3\xf6\xdf'8uu+\x8b\xcc4Q\xc0\xfd/\x0bN\x01w\xf8\xd6\x9bk\xc3\xff}c\x97\xae\xeak\x00PK\x07\x08n\x8d\x7fZ\xef\x00\x00\x00\xe8\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00a\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/kube-system.yamlUT\x05\x00\x01\xf0b\xe7[\x84\x8f\xbdj\x041\x0c\x84{?\x85\xd8\xdeEZ\xb7!E\x9a\x90\x1fH\xafh\x87\xac9\xafm$y\xc9\xbd}\xd8\xbb\x0d\x04\x12\xb8v>\x8d>\x86{~\x87Zn5\xd1v\x17N\xb9\xce\x89^am\xa8\xe0e4\xe7\xb0\xc2yf\xe7\x14\x88*\xafH$e\x98C\xa3A\xb7,\xb0\x03XgA\xa2\xd3\xf8@\xb4\xb39\xd6`\x1d\xb2\xf7LZ\xc7\x1b\n\xc4\x9b\xee\x01\xd1\xca.\xcb\xc3WW\xd8\xae\xb7k\x1a\xa9u({SJ\xf4X/\xd9Q\x7f\xba\xb8\x9f57\xcd~\xbe/lv\xe0\x8d\xcb\xc0\xf1\x80(\xd2t\xb5\xc7\xdafD\xd1\xecY\xb8L\x7f\xf0\xcf\x8c\x7f.~m\xb8\x85\x97\xfc\xb9L\xe1{\x00PK\x07\x08\x9a2\x8aI\xb5\x00\x00\x00H\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00f\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph-system.yamlUT\x05\x00\x01\xf0b\xe7[l\x90\xbdn\xc40\x0c\x83w?\x85p\xbb\x87\xae^\x8b\x0e]\x8a\xfe\x00\xdd\x05\x85\xc0\x19\xe7X\x86\xa4\x04\xbd\xb7/\x92K\xa7f%M~\xa6x\xd4o\x98W\xed\x85\xd6\xa7t\xab}*\xf4\xc63|\xb0 \xcd\x08\x9e8\xb8$\xa2\xce3\n\x99\xea-\x0b\xc65\xfb\xdd\x03s\xca9\xa7\xd3\x96O\xb8.&\xf8X4\xf8\xa4I\xda\xe2\x01\xcb\x0e[\xab\xc0\x0f\xc4\x0e>\xe1\xf8\x80la\x17\x1d\xf8B\x83\x84\xda&\x10\xcd\x1cr}\xf9\x19\x06\xdf\xfe\xe0\x0f5\x93\x0e\x18\x87\x1a\x15z\xed\xbbv\xc4\xb7\x81\x85\xde\xad\xaa\xd5\xb8?7v?\xec\x95\xdb\x82\xa3\x80(\xd3\xe5A\xcf]'d\xb1\x1aU\xb8]\xfe\xd9\x7f[N^\xec\xb7\x12\xabQ\x85\xdb%\xa5\xdf\x01\x00PK\x07\x08\xa1\xaa,}\xc3\x00\x00\x00r\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00_\x00	\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph.yamlUT\x05\x00\x01\xf0b\xe7[l\x90\xbdj\xc4@\x0c\x84\xfb}\n\xe1~\x8b\xb4\xdb\x86\x14iB~ \xbd\x90\x07n\xb9\xb5\xb5H\xb2\xc9\xbd}\xd8\x8b\x03\x81\xb8\x9d\xd1\xcc\xc7\x88{\xfd\x84y\xd5\xb5\xd0\xfe\x90\xaeu\x9d\x0b\xbd\xf0\x02\xef,H\x0b\x82g\x0e.\x89h\xe5\x05\x85L\xf5\x9a\x05\xfd\x92r\xce\xe94\xfe\x0e\xd7\xcd\x04o\x9b\x06\x9fTH\xdb<`\xd9a{\x15\xf8\xd1}'\xfe\x05x\x87\x8c\x94\x8bv|\xa0ABm\x08D\x0b\x87\\\x9e\xbe\xba\xc1\x07\xdc\x7f\xd4L\xdaa\x1cjT\xe8y\xbdkG|L*\xf4jU\xad\xc6\xed\xb1\xb1\xfba\xef\xdc6\x1c\x05D\x99&\xbfy`\xc9\xab\xce\xc8b5\xaap\x9b\xfe\xd9\xbf#N.\xc6w\xb2X\x8d*\xdc\xa6\xf4=\x00PK\x07\x08\"\xf4\x0d\xcc\xc0\x00\x00\x00c\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x001\x00	\x00roles/kubernetes/security-policies/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xc4XM\x8f\xdb6\x13\xbe\xfbW\xcc\xeb`\xe1\xb7\x07[\x1b \xcdA\xc8\x16H\x16iQ\x04H\x8a\xa2=\xa5\x01A\x93#\x8b5E\xb2\x1c\xca\x8e\xeb\xe8\xbf\x17\xa4d\xcb\xde\xc8\xf6~\x1c\xf6\xb2XS|F\xf3<\xf3Ej\n\x86W\x98\xc3\x9be=Go0 e\x84\xa2\xf6*l\xa6\xcej%\x14\xd2O#\x00\x89\xf3z\x91\x8f\x00\x00*Z\xe4\xf0\x9bW&@\xe0\x8b\x0bP(\xac\x87\x0f\xf5\x1c?\xa8\x00\xb7\xd6\x14jQ{\x1e\xac\x07\xc7=\xa1\x1f\x8dv.\xdc\x96(\x96\xc0\xb5\x86\xd2R \xe0\x1e\x81\x82\xd2\x1a\xf8\x8a+\xcd\xe7\x1aG\x00\xca\x08]Kd\xdejl\xddi	8\x8f\"\x1aHK\x81\xd3\x92X\xe1m\x95G\x83,\x19d\\\xab\x15\xc6\xf7\xcd\xb5\x15\xcb|4\x02x\x01\x1f?\xfd\xf1>\x87P\"\xd4^Aee\xad\x11\xa4E2\x93\x00\x1a\x03\xd4\x04\x8e\x13\xa5-\xb7oA\xa0\x0f\xaaP\x82\x07\x84\xb5\n\xa5\xad\x03\xfc]WN\x99\x05\x84\xd2\xdbzQBe=Bi\xad\xa3\x11\xc0\x8e\xde\x9a\xab\x00\xb5	JC\x14\x0c\xb8S\x84~\x85\x1e\x14\x1d1\x04\xa0\x12\xb5\xce\xe1[\xe2\x02 j\xafaJ\xb0\xbd\x02U\xc0\xc2\xdb\xda\xd1\xe7I\xc5)\xa0\x9f|\xf9\xa6\xd1,B	77\xf0\x12\xb8\x91\xb0{\x02\xca\xb4\x9bYT\x88\xe0\xaa)Cp\x94g\x99\xb6\x82\xeb\xa8I\xbe\xdd&g\x18w\x8a\x11i\xe6\xac\x0f\xd04\xdb+@\xad\n\xf8\xbf\"Vr&t\x1d-\xc27\x98[\xab\x7f\x80\xabf\x87[)\xd7b[&=Rh[K\xe6\xbc])\x89\x1e\xfew\x03\x13\xbe\xa6\xc9\x81\x13\xdbm\x8a\xf3\x8a\xfb=\x99\xeb\xeb\xeb\xc9\x97\xcf\\J\x8fDL\x99\x15\x9a`\xfd\x86\x15\n\xb5\xfc\x02Ms\xd6_\xc2\xfbX\x9f8\xafV< Snr\xc9\xa4\x91\xaa\x80\xab&+\x91\xebP\xfe\x0b\x7fu\x01\x01\x98N\x05\x8f\x99\x00\xdbm\xca\x08&\x95\x87\xa6\x89\xa4\xbc\xb5\x81	\xce\x84\x0f\xacP\x1a\xa3\xf8\xd04\xc7\xe0ah\\\xa6\x19\x97\x9523\xe1\xc3]\xd4\x127\x17@qG\xd3$/=\x06\xaf\x90rx}\x1d\xd3<F+f\xf3:\xd6S\xe0K\x04\xb2\x15BP\xf1\x8f\x05m\xb9L\x19N\x81\x07%\xc0Y	\x157\xaa@\n1\x89c\xfdk\xbe\xc9\xe1\xe5ug}\xa1bJ\xe4}\x1a\xb3.\x87\x95Va\x936\xa5d\xcfa<\xb1\xcb\x94\x8c\xc3[g\x14\xa4\xad\xc38A^\x00\x17AY\x03%'06V\xf5J\xd9\x9a\x92_\xc9Sa+\xc7=\xce\x80J[k	sl\xcb\xb3\xe0J\xcf\x92\x0dQr\xb3@\xc9\xd6%\x9a\x1c\x8c\x1d\x1d\x14!'\x8a\xd2g\xe4W\xd9A\xdb\xdaS\xddnA\x05\xacb\xbc\xf0\xab\xdaq\x8fa\xcc\xbb@8\x1e\xca\xfc\xb4\x81\xac\xb7\xd0\x01\x92\xe79H\xe5Q\xc4lN\xcb\xb1s\xb0\xb8\x8fvv\xa7\xe0\xe7\\\xec\x7f\x18\x0ck\xeb\x97\xfb\xdf\x8e\\\xff\xbfW6\xf5g\xa19\xd1~\xd9#\xd9\xda\x0b\x9c\xfeS\xdb\xc0\x0fY\x0b\xeb6\xc9|\x1f\xd3(\xe5i\x12{W\"r\xe7!y\x91\xc3\xf8,\xe6\x80\xfdl\xc3+=\xee\xa0\x12)\xe40>\xff\xc2a\xf0\x81R0\x8e\xe55\xe7\x82\xf54\x9af\xfc\x1dSG.\x85\xec\x02\xc9\x9d\xa4\x0f\xe0\xe8\xc8\x9d\xa7\x98=\x04:\xc8\xcf\x91\xbb@\xafK\x0dH\xd3ys\x1f\xa6\x87\xc9\xf4\x00\xb6\x1d\xec\xb1\x8cO\xc0\x07Y\x1b\x0c\xac\xe5s)\xb6]\xeeC\xca\xfd{\x85\xf9\xfbjyH\xc4\x8f\xd0\x8f\x0e\xfeY+\xc3y\xd0AX\"zA\x95]\xe9C*\xfd\xfb\xa8r\xa7Y<P\x95c\xf4cU9oeP\x95\x1d\x84%\xc8\x90*\xdd\xd9.\x82_\xc4	\x82E\xa1\x84B\x13`\xcd7Q\x93tJ\x8c\xb3\x8e0\x9dM'\xdd\xbc\x98\xb4\x9dz\x16\xdbI\xdfP\xb8sz\x03\xbf\xbf{{{g\x1e\xeeOi\xe3\xee\x00!\x82f\xa2\x92qtL\xa7i!\x1dvo\xb2\x15\xf7\x99V\xf3\xac\x1b\xc1Y\xff\xac\xb3>- {T[\xbdGoL\xfb\x8e\xc6\xb5\xd3\x1bv0j\xba!\xdd\xaf\xcf\xbc\x88g\xc9\xeb=\xb4;Gt\x93\x7f\x7f\x12\xf8qH\xa8\xd8z\x9fG\xa73\xad\xf9r\x8b\x1dV\xa9\x9f\xb9G\"9rO\xd2\xe8N\xff~\x1e\xb9N4\xe7S\x92\x9d\xec\xcf\xc3\xca\x19\x0c\xce\xea!\xf1\xda'O\xcb\xb1\xe3\x11\xf0<\xfa\xed\x9c\x18\xee\xe8\xa7d<\xdb\xd4O$\xa1\x18\xcc\xc1\xa7\x95\xe9\x9dy\xf1<\x12\x9eo\xff\xa7$<;\x01\x86%<\x86\x0c\x95\xf4\xf1\x8e\x07J\xdb\xde3\xfa\xdbj\xbc\xd5\xc6\xd3h\xb4qp\xf5\x8cqh\xb7\x8eO\xdc\xd0\xc7\xfd\xb7\x90\xb7R\xc2\x87?\xdf\xbd\xbf\xfd\xf4\xf1\xe7_\x7f\x89c+\xc3 2\xe7m\x9c\xed\xb3t\x81\x1f\x01heP\x99\xfez\xd2]N\x86\xb6\xb6\x9bs\x98\xe0\xd7t\xc3\xed\xad\x9f\x9bP\x93\xd1\xc1\x15\xc6y$4!-	\x8fim\x83\xed8\xac\xac\xc4\x1c\xae_\xbfz\xd5\x93\xe0RBM\xe8\xa3\xfb\xd1d\xfb5b\x04i\xf1\xf0\xdbMl\xca\xdc\x90\x9akd\xf1\x19\xec[\x0bw\x0e\x8d\xec_\x93,P\x9e\xcc\xf5/z\x93=\xe1\xeb\xd5y\xec\xc5\xcfW\xff\x0d\x00PK\x07\x08'\x87\xd9\x1d\x1e\x05\x00\x00A\x13\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00roles/kubernetes/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00=\x00\xc2\xffkube_version: \"{{ current_manifest['kubernetes-version'] }}\"\n\x03\x00PK\x07\x08,F\xb9}D\x00\x00\x00=\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00roles/kubernetes/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[d\xcdA\n\x021\x0c\x85\xe1}O\xf1.\x90\x0b\xf42\x12\xa6O\x1chSI\x820\xb7\x17\xa7\xe3B\xdc\xe6\xf1\x7f\x11\x98\x0eVlNMB\xad\xc19\xe6\x8b\x88#\x92\xa3I\x8e\xe7}\xef\x8c\x02\xc4\x83\xbd\xd7\xbf\x05\"W.\xb2\xe2R\xbe\xae\xb3Om\x97\x1b\xa9\x9e\xd8\xa6\xa5\xeeFo\x1fr}\xa9\x05\xc0\x95\xfc\xec@\xa4\xe6	\x9d5\xd7\xb1)\xc7\xb4\xdb\xd2+\x0eFy\x0f\x00PK\x07\x08\x1bv\xd3\x9ar\x00\x00\x00\xca\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/kubernetes/systemd/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00$\x00\xdb\xff---\ndependencies:\n - role: manifest\n\x03\x00PK\x07\x08\xccd6\xd0+\x00\x00\x00$\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00'\x00	\x00roles/kubernetes/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xacV\xc1\xb2\x9b8\x10\xbc\xf3\x15S\xef\xe2\xd3\xc2V\xca\xb5\x07*\xc9%\x87=\xe4\xb2\x7f@\xc9\xd2\x00\x8a\x85\x86\x9a\x19\x9c\xf5\xdfoI\x80\xe1=\xbf\xd4\xdaIN6\x92\xa6\xbb\xa5n4\xfc\x01\xd1\x0cX\xc3\xc7\xf3tB\x8e\xa8(\x95\\Eqp\x9f\x0b\x00\x87\xa7\xa9\xab\x0b\x00\x80A\xba\x1a\xfea\x1f\x15\xd4t\xef\x16@K\x0c_\xa7\x13~\xf5\n_(\xb6\xbe\x9b\xd8(1\x8c\x86\x05\xb9(V\xba/=\xda3\x98\x10\xa0'Q\x01\xc3\x08\xa2>\x040\x17\xe3\x839\x05,\x00|\xb4ar\xd80\x05\x9cE\xccbGF\x9b\x00\xf2\x90\x1a9K\xd32\x0du\x02l2`c\x82\xbf\xe0\xc6gD\x90\x15F\xe4\xc1\x8bx\x8a\x02\x14!	\xafl0\"\x95\x1b|\xe5]52\xb9\xc9j#\xc8\xde\x84\x02\xa0\xf5+\xf5h\xb4\xaf\x1f)\x01\x18\xc8a\x0d\x7f\x1e\x8f\xc7MA\x16\x0c\xbe\x05KQ\x8d\x8f\xc8\xae\x14\xe4\x8b\xb7\x08\xf8\xaf\x17\x95\x02@\xd4h\x0d{\xbaI\xb8\n\xfe\xb4z\xb2\xfcV\xf7 \x05\x00c\xe7E\x91k\x10M\xc4\xa7@\xf6\x9c\xcem\xd5\xd0\x12[\x84\x05\n\x94\x80\x91\xd1\xb8$\xa9\xf5\x9d\x80\x89\x0e\x18E\x0d\xebNf\x96\xb3\x14%\xb4\xcd\x877kf\xfdX\xaf\x18\xb8\x0e;\x83\x03\xc5\x861\x90q5\\Q\x8a\x9d\xaa\xc5\x9b\n\xd5\xfe\xff6KW\xdd6\xd0Rp\xc8\xdb\xe9\xed\xddZ\x0f\xf0\xe5a\xd8\x97\xd7{p\x9e\xd1*\xf1u/\x951&\xc6\xad\xfe\xa6\x85.\xc8\xec\x1df	\x19Iq\x18C\x82Zq\xd9\xd6\xf0\xf2\xa8\x9a\x15\xafL\xde\x94\xdf>\xac\xea\x1c\x8a>\xb3\xabW83\xc8\x16\x93\xad\xa2Y\xd7\xe5\x15\x91\xd4\xb7\xd7\xe4c2\xecG\xb1\xc8\xe72\xa0\x9a\x1a\xda0I\xdf\xf4&\xba\x80<[\xf1\xbd\xc7X\xc3\xe7E\xf6;L\xa5\xedM\xec0\xe3\xcc\x8bE\xcb\xf4\x02\x94\xb3\xa1\xf0\xe9\x13(O\xf8\xcbIvd\xcf\xc8?N\xf1n\xfe\xf7'x\x06\xff\xad\xe9\xbd\x83|\"\xb9s\xedM\xc3/\xa4\xf6N\xc5O$\xf6\x0ec\x19x/\xab\xf3\xd4\xc39]L}&\xa3o\x18\x1e\xc9gk\x82`~?n\x01ox\x8a\xea\x07L\xf1=\xcc\x90\x87\xad\x0b\xfc\x8d\x9a\xff\x01\xb5\xa0=\xe6\xee\x07<\xc5\xe8c\x07&\x8a\x9f\x1b_ kBc\xacz\x8a5X\x1a\x86\xc4\x91\x16\xa7\xe2WW}\x1al\x16\x84fC8\xa1\xa5D\x98\x15\xee\xba\x10\x8dW8\xe4T\x0d\xa4\xeeP\xbc\xbd\xa7\xf2-\xb5N\x97\xdf>\x14\x9b\x85\xb7\xaa}\x8f\xfb\xebx\xcc\x8f\xf4=&5L\xa4\xf9\xb9c\x9a\xc6\xe5\xf9\xc6\xfe\xb1z\xfa+\xa3\xfa\x99\xcf\x8c\xff\x06\x00PK\x07\x08\x8b\xe5\x0e\xb8\x9e\x02\x00\x00\xd5\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00roles/kubernetes/systemd/templates/etc/motd.j2UT\x05\x00\x01\xf0b\xe7[T\xcd\xb1J\x041\x10\xc6\xf1>O\xf15[\xea\x03\\'\x1c\xe2!\n\x82\xd8\x86\x9c\x99\xbd\x1dnoFg\xb2Z\xacyw\xc9FP\xcb\xfc\xf9\xe5\x9b\x87\xf4:\xb1\x10\x0e\xfb\x1d\xd6\x15I\x9c\x8f3\xc5K\xcf\x913j\x0d\x07)d\x92f\xdc>\xed\x1f\xff\xb9\xf1=\x0b\xbe0\xeb'Y\x93\xf7\xcb\x91\xce\\\xf0B\xe6\xac\xb2\xd9so\xf1\xa3\xb7\xc6n\xfaw\xdc\xa9\x97\xcdL\xea%\xda\"\xc2r\x8a?\xe3\xd7^\xb2.\xa5\xf9\x10\x9e'\x82\xe9L\x0e\x1dQ&v\x88fB2\xda\x85u\xc0\xa8\x86\x93\xe9\xf2\x16%]\x08,\x7f^\x8e\xa1\x06\x00\xb8j\x97~{\x1b^\x07\x90\xe4Q\x0dC\x0d\xdf\x03\x00PK\x07\x08b\x86\x86m\xb3\x00\x00\x00\x0c\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00[\x00	\x00roles/kubernetes/systemd/templates/etc/systemd/system/containerd.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[t\xce\xc1J\xc3@\x10\x80\xe1{\x9eb!\x97V4\x93\x18\xa4V\xc8AD<H\xa9\xd8\x8bPJ\xc9\xee\x0e\xedBv'\xcc\xcc\x16\xa5\xcd\xbb\x8b\x9e\xbcx\xfb\xf8O\xffv\x83|\n\x0ewEi\x8e\xaa\xa3<\x00\x1c\x82\x1e\xb3\xad\x1cEp\xc4H\x026\x1f\x04\x82HF\x81fY/\xcb_;\x8a\x11\x93\xde\xb4m{\xbbh\xea\xf6\xbexN\xa7\xc0\x94~j\xf7\xb2^=~\xbc\xbd\xaf\x9f6\xdd\xf9l\xb6\xb3Y\x9f$\xd8\x01\xf7#\x93C\x11\xe2\xbd\xa3\x9c\xd4\\\x99\xbaZ\xdc\xcd/!\xe9\xfc\xda4;s1\xb1\xff4\xd3\xf4\xdfR\xd2>$d\xff\x97v \x0b\xb1\x17E\x06ON\x80F\xa9\xa2/\xe5K\x14\xa3/^\xc30\xac\xc8c729\x14)\xbe\x07\x00PK\x07\x08m\xea\x009\xc6\x00\x00\x00\xf9\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00W\x00	\x00roles/kubernetes/systemd/templates/etc/systemd/system/docker.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[t\x8f\xcdj\xc2@\x14\x85\xf7\xf3\x14\x03\xd9h\xa9\x99\xa4\xa1U\x0bY\x94bE\x8a(\x86\xd2\x82\x88L&\xb7:437\xcc\xbd\x11E}\xf7\xd2\x1f\xa1\x9b\xee>\xbe\xb38\xe7,_\xbc\xe5\x95x\xd5\x9e)\x0f\xb0\xb1\xc4\xe1\x10\x13\x84\x9d5 \xc4\xb2\xf8\xa1\x95\x88\xa4A\xcf\xdaz\x08\xd5%\x97\x95%\xdd4\xa0\x03I\xebe\x85\xe6\x03\x82L\x07qr'\"\xb9en\xe8^\xa9\x8d\xe5m[\xc6\x06\x9d2\x18\x00I\x95\xed\x86\x94%j\x81T:L\x86\xd17\x1bt\x0e<\xf7\xb2,\xbb\xe9\xa7I6\x10#\xbf\xb3\x01\xfd\x97\xcd\xc7\xb3\xe9\xc3\xdb|1{,\xf2\xe3Q.;\x1d\xed\xc9\x965\xac\x9b\x80\x06\x880\xac\x0d\xb6\x9e\xe5\x95L\xe2\xfem\xf7d=w\xafe\xba\x92'\xe9\xf4^\x9e\xcf\xffM\xba\xbc\xfa\x8be\x8d\xa5r\x9a\x18\x82\xaa\xd0\x90\xc2\x86bWEt \x06W\x89g[\xd7S\xac \xffm\x17b\x01\xc4:p\x8e\xbe\xf7\xaem\xdd\x06\xb8\xa8'\x0c\x06F{\xcb\x05kn)/&\xe3\xf9d>\x12\x9f\x03\x00PK\x07\x08\xbc\xc0\xa9+\x13\x01\x00\x00{\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/manifest/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[*K-*\xce\xcc\xcf\x8bO\xcb\xccI\x8d/H,\xc9\xb0RP\xaa\xaeV(\xca\x87r\x15jk\xf5\xf5\xf4@(\xcc5(\xd8\xd3\xdfO\x89\xab(5'5\xb18\x95\x08-A\xae>\xae\x8e\xc1\xaeJ\\\xb9\x89y\x99i\xa9\xc5%D\xe8\xf1u\xf4\xf3ts\x0d\x0eQ\xe2\x829-7\xb1$9\xc3JA=NCOKS\x1d.\\\x94Z\x90\x93\x98\x9cj\xa5\xa0\x1ec\xa8\xce\x05\x18\x00PK\x07\x08l|\xb3(u\x00\x00\x00\xc8\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/manifest/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\x95Oo\xdb0\x0c\xc5\xef\xfe\x14D.M\x80-\xbd\x1b\xdd\x80a\xd8\xa9\x97\xdd\x87A`\x14\xda\x16bK\x06Eu-\xda~\xf7A\xfe\xd3\xba\xf6\xe4\xa4\xab\xafz\xa4~\xa4\xdf\xb3?\x83\xc5\x86r\xb8i\xd0\x9a\x82\xbc|\xcd\x00\x8et\x08e\x9e\x01\x004\xbe\xcc\xe1'\x1b+ XNdP8\x86\xdbp\xa0[#\xf0\xdd\xd9\xc2\x94\x81Q\x1cC\x8b\xec\x89\xb3ll\xbd\xd1\x15\xe9S\xa7\x7f|\x84;bo\x9cU\x85\xa9I\xb5(\x15<?o2\x80\xdai\xac\x15j1\xce\xe6\xe0\x05\x05\xe2\xe9\x97\xcdJ\xcd\x81\xb4\x8b\xf0\xd6e\x00L\xa5\xf1B\x9c\xbf\xc8[&OVS\n\x85\xa9&\xf4\xf4.\x94TM\x02e\x94OQ<\x89*PK\xbf\xe0S8\xd0\xc9\x88\x1a\xa0s\x88\x03\xd7\xce\x9dB\xbb\xbd\x8adW\x9f\x96\xf3\xef\xe0)^B\xf7\x8a\xa9\xadQ\xd3v\x944(\xbaz\xad\x18\x8ew\x03\xe5\x9f\x8a\xecr?\xfb\xb8\xed=\xdd\x1b/\xfe\xff\x00\x17[\xf9\x18\xa0u\xb2\n	h\x8f\x8b\xd5\xce\xa7\x18\xcc\xf7\xcd{b\x01\xa9P\xe6\xa3\x80\xf1p\xa4\xc2X:\xc6\xe5`\xa7\xec\x87\x8e\xf2|E\x1f	:a\xffl\xd7q\x1d\xaf\xd2\xc2n\xd6.\xce\x7f\xa6\xe5\xb9\x0d\xc0\xee5\xbeO\x13\xd2\x1fF*J\x061\x92&L\x1e\x8f\x9a\xe0\x05:\x80}\xdc\x97}P\xc4\xec\xd8\xab\x02\x05\xeb\x1c\x84C2l\xe3\x87\xe3]iK\x16%\xe2\xf6\xa2\x9f\xe6\xcdX]\x87#\xa9;d\xdf\xbb7\xd9\xb6\xcf\xc7\xa2\xcbz@t`&+j,\xeb\xef\x18^\xb8\xff53\xd1\xef7Q\x1cU\x13+&\xbc;\xbf\xe5\xacyW\n\xfem\x8d\xd4b&\xaf\xfd\xadz\x1e\x8fQ\xda}\x83\x00-\x90\x15~\x00c\x17,\x17\xb8\xe7\xe6\xfa\xc2?\xd2\xf5\xe5\xbf\xa4\xbf\x03\x00PK\x07\x08]\xc9\xcb\xca\xcc\x01\x00\x00\xed\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x00roles/precheck/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\xecV\xddn\xdb8\x13\xbd\xf7S\x0c\\\x04\xbd\x92,\xc9\xb2,\x07\xdf\xd7\x17)\n\x82\xa2F\x12a\x8a\x148#;F\x9aw_P\xb6S'\xae\x13/\x16{\xb1@s\x13\x83s8s\xe6\x87s4xT\x1d\xaa-	\xb4\xb22X?\xc2\x01i6\xa3\x0e\x8d\x11Xk\xd6\xb6\x15\xbd\xab\xf1\x11\xe6\xf3\xd9\xac\xd6\x14`\xa2\x97\xc4\xe8E'\x1fa\xfe\xfc\x0c\xadw\xe3@\xdf\xbf\x1e\x8f\xbf\xfe\x80\x9f`\xd0\xb6\xdc\xc1\xff \x83\x97\x97\xf9l\xf6\x05:\xb9C`\x07\x84\x0c\xa7\xfb\xac{\x14\x13\x01X&@\xa8\xa0\xc2N\xdb\x1a$\xc1\x1ea\x8f\x1e\xa1\x91\xdah\xdbB\xe3<\xa4\x01\xe3l\x0d\xe1\"\xd4\xbai\xd0\xa3U8\xbb\xf2w\xe4\xd59\xe2\x9d\xf4\xf4\xfd=\xc1\x9f\x8d\xf6\xc4?biI\x87|j\xc98\xdd\x8eqp\xaa\xfb\xa9-C\x148M\xdc\xe9@\x8a\x8d \xe4P\x0e:\xfa>\x1d\xd6\xd8\xc8\xd10\xbdA\x9e\x0f\x1fg\x00\xf3]\x1f\xf7\xf2I\xf4r\x10\xca\x8d\x96\xe7\x8f0\xcf\x8a,\xcd\xf39\xc0\x17h\xf4\x13\x12Tc\x0b\xfb.\xe4\x8bF\x12kE(\xbd\xea@yI\x1d!A=N\xc5\xeb\xb1\x07\x8f\xc4^+\xd6\xce\x06\xff\x169\xd6\xc3.\x8f\xf5 \x1a\xe7\xf7\xd2\xd7!D:?\x1b+\xaf\xeb\x16O\xff\"\xdbDJ\x1a\x13\xe9\x81C+\xe9\x15\xfb\x05\xa2\xf0\x07\xe74\xa1\xf1\xae\x87\xc1#dq\x12\xaf\xdf\x872NI#\x06\xe7Yxi[\x0c~\x96\xd9\xba(\xa1H6\x9b\xcdk\xf4\x89\x1a\xabA\xf0^x\x1ci\x02&\xd7\xe6F\xdb\xa9\x03n\x9c*T\xfc\x82(\xe71&\xd7\xcb'\xe5\xac\x0d\xc64+\xdfZ-r\x8d;\x11\xea\\I\xb55\xae\x9d`I\xf2\xce\x8b\xef\xb1\x0f\xa8`-\x97eY$\xef\x1c\xed?\x00\xbcf\x12\xc2\xd0\xc1^\x86\xcaV\xc5\xefq\xbc\x17\xd5\xa8\xb6\xc8\xf4Z\xa0k\xa0u\xa2\xc7\xd0S\x12$w\xb7*\x14\xc8\x07S\x9el\n(\xd7\xcb2I\xe0&\xc7\xc0\xcf\x07\x9f\xc7\x0e\xff\x86\x1d\x1d\xacT\xdbK\xd0\xea\x1a\xb4\xbf\x8c\x99\x16\xcb2\xbf\x8ei\x91\x1bm\x18}l\x1b\x11Z\xc4>8>\x95\xf94\xea\x9f\x80[\xb4\xe8\xb5z;\x01\xc9g\xb7B\x1a\xa7\x1b\x02)\xcc\xb3\xa6\x0e\xa7\xf1\xcf\x97\xd9e\xf7\xa7\x84,\xea\xb6\x8bO\x8f3n\x95\xe0\xce#ui\xc0\x97\xc9\xbd\xf0,\xc07w\xc3\x97\xe7IL\x92\xcbW\xe6\x9d\xdb.\x14\x0e\x1dx4\x92\xb1>\xaf\nmE\xe3\x11\xc5\xb6:\xf0\xb1-\xcf\x0f\xa0\x1b8\xaf\xaa\x1e{\xe7\x0f\xa2\xafb\x8f\xd2\xc4\xecX\x1a\xf8\xf6\x7f8\xd6\x19\x1e^\x96i\xbeZg\xe5\xf3\x03\xa0\xd1\x0d\xa4\xcb4Yg\xf0\xedS\x07\xc5j\xb5\\\xc3\xc3K\x96l\xd6\xe9*\x9b\xee\x13\xc2\xc3K\x9a\xe4\xe5j]\x84\x03[\xeb\x06\x1e^\xa6:m\xd1[4\xf1\xa0\xebs\xab\xf3t\x93/\x93\xe5\xe52\xd9;\xbf\x95\xde\x8d\xb6\x9e\x16\xf8\x94\xf5v\xac\xd0 \x03{\xb9COa\xb9\x0ffl\xb5%\xd0D#>B\xc7<\xd0\xe3b\xd1j\xee\xc6*V\xae\x9f.y\x8b\x8ct\xf9s\xc2\xd3b\x9d\x17\xc5&pj(\xd6\xd6\xb1n\x0e\xd3\xce\x1d	\xbd\xd0\x96XZu\xace\x99n\xb2\xf9-\xe4^\xb2\xea\x8e\xb8U\x96gey\x99\xc9\x99\xd3\xaf\xe8\xb1v\x8b\xca\xb8v\x91%\xe9f\x91,\x17\xd9f\xb2F\x83wO\x87\x88\xc6\x8a\x0d\xb2F\x8aj\xac\xc6\xb6\xd5\xb6\x8d\xa4\x8d\xb4e\xf4\xbdfF\xcbQx\x018\xad\xf2\xc8#!/\xee\x98\xf8\n\x85\xd1\x15ziN\xbb\xfb,;<Z\xac\xff\x93\x9a\xe3Q\xb9\xbeG[\xcb\x10\x81\xa6Y\xe1\x0e\xa1q\xc6\xb8}\x98\x10\xe5z\x9c\x14\xe9\xd7t\x84\xda\xc7\xca\xd5H\x9d\x1e\xa6!\xf1\xa3\xb5\xa1\xcaa\xf1\x87\xd2\xb2\xd4\x16=E\xdaF\xb5S[\xf4\x11\xed\xa5\xef\x17\x7fC\xcbV\xe1\xdd\x06\xa2\xe3\x10>\x12\xeaP\x10|Rf\xac\x11\xa4=p\x17\xc8Uh\xdc\x1e\xac\xab\x11\x82 \xc2\xe4\xe4C\x01L\xe7W\xe6w\x02\x98\xae>\x10\xc0 \x01\xf7(\xe05\xeeR\x01\xd3b\xbd^giq[\x02\xaf\x10\x1fj`\x92\x97\xc9\xef\x91oU0O\xae\xb7\xe7\x0d\x19L\xef\x91A\xb8M\xf3\x9d\x0cf\xf7\xc8`\xf6\x89\x0c\x86EY\\\xc7\xbc\xf1b\xff\xa9\x0c\xa6Y\xf2Y\x88\x0fd\xb0,\xf2;u\xea\xa4\x82\x9b\xe2.\xf4$\x82iv\xda\x91w\x8a`\xf8|\xf8\xa3\x81\x7f4\xf0\xdf\xd0\xc0\xbf\x06\x00PK\x07\x08D\x0f\xbb\x8ce\x04\x00\x00\xca\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x009\x00	\x00roles/precheck/files/etc/modules-load.d/br_netfilter.confUT\x05\x00\x01\xf0b\xe7[\x00\x0d\x00\xf2\xffbr_netfilter\n\x03\x00PK\x07\x087\x01\xee\x7f\x14\x00\x00\x00\x0d\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x009\x00	\x00roles/precheck/files/etc/modules-load.d/ip_conntrack.confUT\x05\x00\x01\xf0b\xe7[\x00\x0d\x00\xf2\xffip_conntrack\n\x03\x00PK\x07\x08\x00\x08\x8aR\x14\x00\x00\x00\x0d\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/precheck/tasks/all_hosts_alive.ymlUT\x05\x00\x01\xf0b\xe7[\x00M\x00\xb2\xff---\n- assert:\n    that:\n      - ansible_play_hosts == ansible_play_hosts_all\n\x03\x00PK\x07\x08\x80\x03e\x85T\x00\x00\x00M\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00(\x00	\x00roles/precheck/tasks/ansible_version.ymlUT\x05\x00\x01\xf0b\xe7[t\x91O\x8b\xdb0\x10\xc5\xef\xf9\x14\x8f\\\x92@\x1c\xba\xdb^\xea\xd2\x85R\xe8\xad\x14\xfa\x0fz2\x13{\xb2\x16+Kaf\x94\xc5]\xfc\xdd\x8b\x15\xc7M\xa1\xd1\xcd\x1a\xcf\xcf\xbf\xf7\\ P\xc7%\x1e\x16\x00\xf0\x93\xc5\x1dzX\xcb\xf8\x10\xd4\xed=\xe3\xc4\xa2.\x06\xbc\xbc\x80\xceW\xd5t\xb5;$\xef1\x0cy\xd3)4\x1d\x8fQ\x8c\x1b\xec{<\xa5=?9[\x00\xa4\xcabe~\xcbZ\xb2\x12\xcb[,\xa7\x97\xcfUu\xec\x8e$\xbc^\xdd\xef\xde\xac\xb6X=\xbc_m0\x0c\xcb\x8c\xe9\xf4\xb1\xc4\xf2WL\xe8\x92\x1a\x92\xfe\xd5\xb5\x98\x1f\xc7\x04\x93\x02\xea\x18\x0e\xee1	Y\x94\xdd\x08\x90\x14\xaa\x18j.\xd1\xb3\x8e\x86\xa1\xafX$\x8aV\x072\xf2%L\x12/\x16\x97n\xa6V\\8q\xb0(=\xda\xa86\xd6\x06\x12\xc6\xd7O\x1f\x8b\xbb\xbb\xfb\xd7\x18\x95\xbd\xa3p;\xf4L\xa8f\x82\xd3\x99V\x9d\xc8\xbb\x86\x8c\xff\xcdy\xfe3\xe7\x933\x7f\xfe\xf1\xed;8h\x12\x9ewK\xfc\x1f?\x0c\xd9\xf1Z\xedr\x9e\x9d\xb5\xb3\xfc\x0e_\x82\xef\xb1\xa6\xe2\xf7\xab\xe2\xed\x06\x14\x1a4\xa4\xed\xba\xd8\x80\xbc\x8f\xcf\xdc\xbc\x83\x1a\x89\xe9\x95NFP\x00\xf9cK\xa8[\x12\xaa\x8de\x0b\x0e\x8dN\xd3q\x14R\xc7\xe2\xeam\xc6:\xc5\xc8\x13\xd4\xa4\xbc\xbc]\xff\x9f\x01\x00PK\x07\x08oO\xe7$[\x01\x00\x00\x9a\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/cloud-init.ymlUT\x05\x00\x01\xf0b\xe7[\x8c\xcdAj\xc40\x10D\xd1\xbdOQ\x170r\xc8N\x17\xc92\xb4\xedV\xdc w\x1b\xa9\x94an?`\x98\xfdl\x1fE\xfd\x19.\xa7f\xfc\x88\x11\xc3i\x15<\x14\xc5\xaa\"\xfdKK\xd5\xd6\xb4\xd5\x18{2\xef\x14\xdf4\xad\x11\x9c\x8b\xb9\xf5CwX\xc7\xd5\xb4\xab\x13\xab\x96h\x8a-\x9c\xe6\xc3\xfco\x02\x1eb\xfc-\xd1\xf2\x04\x00\x97\xf0\xc8\x9f\x1d\xdf\xfbN\xa1\xe6w\xe0&\xda\xa91\x98\xf1\xbd,7\xecZ\xe5\x99\xf1\xb5L\xaf\x01\x00PK\x07\x08\xeax\xb7\xad\x84\x00\x00\x00\xcd\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/fix_stacki.ymlUT\x05\x00\x01\xf0b\xe7[\x94Q\xcbj\xe3@\x10\xbc\xcfW\x14\xec\x82v\x97\x95\xb4\xde\xe3\x80o\xb9\xe5\x98\x1cM\xccHnY\x8dF3b\xa6\xe5\x07\xc2\xff\x1e\xf4\xb0\x83\xe3C\xc8\xad\x1f\xd5U\xdd\xd5),;bW\xb1%\xad\x00\xa03Rk\xe4$e\x1e(z{\xc8J\xef\xaa\xa9\x15hO\xa7N#y\x8bdBY\xe3W\xf6\xe7\xf70\x80\x85Z\\.c\xf63\x99\x90#\xa9F\xb2\xc06\xab\xcd\xff\xb9^\x98\xb2	TE\x8d3\xc5[\xa5\xef\xae\xf9\x91\xa5\xde\x8etq^&Eqv\xff>\xc2\xd5\x12r\xd7\xb2R?^\xc4\x94\x0d\xa3\x08\xbe!X\xbf\x0f^\x8c\xd0_<\xf7\x055,\xa8\xf8D;\xb0d*\x853-i<y\x8a\xf3q7x\xb6\xcb\xe3\xc8\x03:q\x14\x05D1\xf2\xe0\xc5\x03\\M~p\x14\nz\x1a\xd9\x06\x8a\xbd\x15\xa5R\x14\xd6\x97\x8dVj\\5PgM\xb9\x98\xfb%\xe5\x9d\xcdC\xb2\x0c]9\x90\x0c\x1b\x87\x96cd\xb7\xf7M2+|\xfe\xe0\xf7D\xf2\x83	#*o\x0d\xdb\xab\xe0\xf2\xbf\xbb^6\x9d\xcd\x18&\xd9cM\xee\xee\xee\xb1-\xd9\xe4a\xc4z\x8d\xd7\xd0\x93z\x1f\x00PK\x07\x08\x05~\x94\x8c(\x01\x00\x00_\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/precheck/tasks/format_check.ymlUT\x05\x00\x01\xf0b\xe7[\xac\x8eAj\xc30\x10E\xf7:\xc5'P\xb4r\xbd\x17\xe4\x16\xdd\x9b\x895r\x86J#\x18\x8dSB\xc8\xddKS\x9a^ \xfb\xf7\xff{\x13\x94\x1a'\\\xd8\xa4\\E7\xdcn\x88\xf3\x85l\xaer\x9as_?\xd9\"\xa4`\xed\xea$\xca\xb6\xd8\xae.\x8dq<\"\xfe\x01\\\x07\xff\xef\"\xeew\xc8\x80v\xc7\xc9\xad\x8c\x00\xac\xbd5\xd2\x9c0\x9c\x1cS\xc1\xb4\xe2\xed\xe3e\xba\x00\x18o2\x9c-\xe1\xb7\xea\xa1-$\x95\xf3\xf2ufM8\xc4GM\x84\xe8\x93y\x1f\x9e\xfb\xee\x87\x00\x90^\x176\xeb6\x96BN5\xc1m\xe7\x9f\xf63\xe9\xf6|\xd1\x1e\x80\xf0=\x00PK\x07\x08L0\x1f\x97\xaa\x00\x00\x00:\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/precheck/tasks/ha_host_check.ymlUT\x05\x00\x01\xf0b\xe7[\x84\x91\xc1N\x031\x0cD\xef\xfd\x8a\xa1\x1cz*\x07\x8e+\xf8\x96\xc8\xcb\xba\x8d\xd5\xac\xb3\x8a\x9d\"\xa4~<\xca.B\xad\xd8\x96\\3~\x1e\xcf\xec\xd1\xa7\xfcq\xea6\xc03\x061\xea\x13\x87\x91\xcc\xb9\x84H\x18\xab9z\x86\x97\xca\x90\x03\x96\x1f\xc3\x1b^7\xc0\x1ed\xc6\xc5\xdbt{\xa3\x1d;l\x8d\xfd/\xa9[\x10\x9f\x91\xf5\x1a\xb2\xfd\x99\xf4H\xde\xad\xec\xbf\xa0\xcf9\xcd\xa26\xda\x01\xc7\x92\xebd/\x8b\x04\x17$\xd6\xa3\xc7\xd9\xd0]G\xa7\xdas8K\xf1J)\xc8\x14h\x92\xe5\xb2Hg\x06)d\x02\x0dCa\xb3y\xcd\x8a\x111\x1c(\x19\xdf\x1a\xd6\xecX\x83\x8b\xa1\xea\xc0\x07Q\x1e@:\xac\x89.^d\xc4\xd3;v\xbbY\xf2\x80\xa5Y\xf9:\x83&\xbd\x9b\xd5\x7f)\xcc\xd4`\x96\xc2\x94\x8b\xff6\xdcZ\xbb\x7f{+\xef\xf6\xf4U\xda\xb5\xc9G\x06\xdb\xa2\x0e\xa2gV\xcf\xe5+\xc4l\xae42Do\x0b\xde|\x0f\x00PK\x07\x08\xd1\x98\xbf\xe4\xf9\x00\x00\x00\xa0\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/precheck/tasks/ip_precheck.ymlUT\x05\x00\x01\xf0b\xe7[\x94\xd0Aj\xf30\x10\x05\xe0\xbdO\xf1~m\xbc\xf2\x05\x04\xf97=E	\xc1L\xa3Q%jKaf\x1c0!w/V\xdd\x92R\xba\xa8VBz\xf3\xc1\xbca\x18\xba\x0e\x18Phf\x0f\xf7\x94\xf8\xfc\x06Kd\xb0\xc4\xb8\xdd\x90\xcb\x95\x8bUY\xc7T\xd5\xb6\x18\xeew$RP\xd1\xfc2q{G\xe0\x98\x0b\x07\xd7\x01\x00\xa9\xb2\x98ow4\xcdcK]I\xf4\xf8\x13<\x1d\xfbG\xab?!\xeb'\x08*aw>\xce\x9f\x9c\x7f\x078\xb7\x8f\xcf\xfa\xea\xf1\xff\xd1r\xcfu\xc1\xbc\xa8A/|\xceq\xc5\xb7\xf9C\x8f|\x01\x85 \xac\xca\x8aX\xa5\x95\xb2\xfd\xfd\xdeL.-\xf4\xb5%b\x9ex\xaf\xa5\xac#\x8bT\xd11\x92\xd1\xe4a\xb2p\xf7>\x00PK\x07\x08~\xa40\xf1\xc4\x00\x00\x00\x84\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00roles/precheck/tasks/ip_validate.ymlUT\x05\x00\x01\xf0b\xe7[\xbcSOk\xdbN\x10=\xff\xf4)\xde/\x17A\xc1\x8a\x15L\x02\x0b=\x94\x9ezI/\xbd\x94$\x15#il-\x96w\xc5\xceJ\xa9q\xfd\xdd\xcb\xaeh\x90+\xd1\xd2R\"t\xd9\xd9\xa7\xf7\x8f\xd1\n\x86\x0e\xacP5\\\xed\xe1\x1b\xf2\xd0\xdd\xb0Ak+j\x1b+\x1eZP\xf3V\x1b\xae\xa1\x0d\xae\xd9W\xd7a.	\xd0j\xc3\xdalu\xcb*\x01\x80\x8e|\xa3.!\x80x\xf2\xac\xd09\x166>\xe2\x1c\xef\xf8k\xa7\x90~\xc9o\xee\x1e\xb3u|\xf3Gy\xf3\xa2\x9aF\\\xe0WH\xf3\x9b\xbbl\x9d\xad\xb3\xfc\xbf\x9f\xee\xed\xb3a\xa7\xe0\xac\x1dyw\xce\xf6\xdd\xe4|\xb05+\xaco7\x9b\x04c\xc2b\x1c\x1dY\x92d9\xfa\xedkEW\xea\"q\xd4^M\xb4\xc7\xa3\xedJ\xaa\xf6\x17u(\x95c\xfa\xfc	\xc5\xbfil`\xa7\xb7\xc7q[\xf6}\xc9\xc5\xa0\x9d\xef\xa9-tWP\xa7\xc3\xca\x18\xeb\xd1\x0b\xd7(\x8f\x18\xe3\x19h3\xb0\xf1\xd6\x1d\x13\x80D\xd8\xf9\xb1\xbb\xc0\xa3\"j '\x0f/\xb0\"\x8c\xc2z>=\xa4dD\x97-\xc7Q\xfa\x84\xff\xdf.	G\xb6\x83\xec\x14\xae>\xdb\x1e\xef\xdf\xdd\xdf\x7f\xfc\x14\xa4\xf4\xce\xe0tZ\xf4z>\x83\x04\xb4xGu\xedX$\x03>\xc4e\xf0\xb4g\x13\x12\x9dN\x98\xbb\xc4\xf9|\x95\x00\xcf\x0d\x1b\x15\xf3\xd7Z(x>\x90xvEC\xf8\x86\xd2\xdav\xb9\xc8\xae/[]M,\xbcN\x8d3\xd9\xdf\x958\xf7\xf9\xa3\xc2\xf9\xcd\xdf\x17\x98\x00\xab\x05\xc2\xc9\x1fI\xa6\x9e\x03\xe2g\xbf\xec\xfe\xfb\x00PK\x07\x08S\x8a\x05\xea\x94\x01\x00\x00\xf1\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x00roles/precheck/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x8c\x92\xc1n\xac0\x0cE\xf7\xf9\nk\xf6\xbc\x91\xde\x12u\xba\xe9r6\xfd\x83\xc8\x04\x03\x16!A\xb6a:\x7f_\x95v\xaaVm\xe8l\xe1\xe4\xc4\xd7\xb9\x15$\x9c\xa8\x86\x87Y(\x0c\x14\xc6G\x07\xd0R\xb3\xf4\xb5\x03\x00\x98\xb4\xaf\xe1Y8\x19\x18\xf6_0\xe8\xb2\xc0yi\xe8\xcc\x06O9u\xdc/\x82\x96\x05f\x14%q\xae\x82&\xe60\xbey*\xe0\x14\xe2\xd2\x927\xd4Qk\xc0\x18\xfd\x90\xd5\xd4c\xe4\x95\xfe]\xa7\xf8+\x96\x94\x9bH~%Q\xce\xa9\x84M4e\xb9\xfam\xfe\x12\xa3\x17\x9c\xf7\x89EI\xbc\x92\x19\xa7^K\x9a.\xcb\x84\xb6/\x1ap\xcb\xb6\x0f\xf1\xeco\xbb,\x8e\x1c|\x88yiCN\xdd\x9f\xb2\x15#\xb7h\xc5U\xeaU\x83\xc5\xd2\xdf<SR\x8d\xfb\xb7t\xfc\xe2\xd50\x8c\\\xd2\x18O\xf4\xcdq\x19(\xd5p\x0b\xaa\x9e\x126\x91Z\xe7~<\xf5\x96\xb4\xe2\xc4\xf6!\x7f?\xba}\xf6\xb3\xe4\x95[\x128\x9d\xe0@\xe1\xff\xc1\xb9\xcf\xe2\x1e\xefl\xee\xf1\xfe\xea\xbe\x0e\x00PK\x07\x08\xdd\xc60\x0c\x0d\x01\x00\x00\x15\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00%\x00	\x00roles/precheck/tasks/memory_check.ymlUT\x05\x00\x01\xf0b\xe7[\xa4\x91Ok\x021\x10\xc5\xef~\x8a\x87g\xddb[\xc4.m\xa1z\x90\x1e\xea\xa1\x08\xa5'\x99]\xc7M0\x7f$\x93X\x16\xf1\xbb\x97\xb8\xd2\xf6 ^\x9aS\x0e\xf3\xf2~\xf3\xcb\x10\x8e,\x97\xe8\xcf\x14\xd7[DE\x11Q1\xb4\x93H\xae\xe6\x1bi%\xb2\x85\"\xc1\xf3\x13&\xf3)\x02\xd9~\x0f \x11\x0e\xb1\xec\x018\xc5\xba[>C\x90\x13]\x19^Y\xb6>\xb4+[\x15\x81\xc9\x14\xd1G2\xa7gF\x0f\xb7\xa7\xf1\x0di\xb3\xb2\xd2\x94\xe8\x7f\xfa\x84\xb5\x87\xf3\x11\x8a\xf6\x0cv>5*\xb7A\xbbKL\x05\x9036\xc99q\xe6\xf3\x9b\x1c*\x80\xa5\xe2\xc0\xd0\x82\xc3\xe1:\xd1\xf1\xf86\x05\xedI\x1b\xaa\x0c\xe7\xe5\x00Iu\xcd\"g\xb8\x99wM\xa0\x98\x0cE\xed\x9d\x0c\xd0\xfa\x14\xf0\xc7\xcd/\xed\x7f\x8a{C\xac\xb9JM\xe7\xb2\xab\xfexy_\xbc.\xe6%\x96\x17\xbeE\x94Of\xdd\xadO\x11\x86I\"F\xe3\x1f\x0b\x83,.0,\xb5\xa8\x18\xbb\xe0+\xc3VP{\xb7\xd1M\n\xda5\xd8Q\x88\x92\xe7\xb7\xa9\xe2\xe08\xb2d\x05_\x8a]y\x1d\xff\x11\xa3\xf1\xdd\xe4\xfe{\x00PK\x07\x08\xe3\xcf\xec\x1e'\x01\x00\x00D\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/precheck/tasks/openssl_check.ymlUT\x05\x00\x01\xf0b\xe7[d\xca1\x0e\xc2@\x0c\x04\xc0\xde\xafX\x1e\x00\xa2\x8eD\xc9;\xa2\x13Y\x88%\xe3 \xef\x01\xdf\xa7A\xd7\xa4\x9e9\"\xdb\x93\x13\xae\xa9w\x11\xdb\x8b)\x05\\\xf0To\x11\\\x0c\xd0\xca\x88i\xe8\x87%\xdf\xd2\x80{\xf3\xe02\x7fW\xe6\xe0\xf9\xcf\xa7\xba\xe1p\xc1\xd9\x80\xe2\xc3\xd5Y\xbbc\xbf\x01\x00PK\x07\x08/\x0e\xc9\xe3Z\x00\x00\x00\x80\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00+\x00	\x00roles/precheck/tasks/sc_cloudconf_check.ymlUT\x05\x00\x01\xf0b\xe7[D\x8d\xb1n\xc4 \x10D\xfb\xfd\x8a\x91{|=\xd2}\x0bZ\xe1\xe5\x8cB\xe0\xb4\xbb\x0e\xc9\xdfG\xc4E\x8aif\xf4\xde\x84\x10\x88\x02:\x7fJD>%\x7f\xd4\xfeB-\xc8m\\\x07\xf2\xe8\x05\xa56\x81|Ws\xc3\xe8\xe0i\x04\x98\xb3G\x02\x807\xfb\x19\xb1=\xc4\xf3\x83\xa7\xad\xec\x8b\xdb\x08PyUs\xd1x\xfbVM@\xe1\xda\xe4H\xf3\x94\x1e\xd1\x87\xff\x8f\xfb\xd2\xee\xf7\x17\x01\xdc\x7f\x92\xa8\x0e\xb5T\xd8\xb9E\xb8^B\xc0\x8d\xfea\xe9\xad\xe3\xab\x1e\xa2x>\xb1\xf1\xb4\x8d~\x07\x00PK\x07\x08\xb9\x18\xec\xc8\x94\x00\x00\x00\xd5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/swap_check.ymlUT\x05\x00\x01\xf0b\xe7[T\xcb1\x8e\x830\x10F\xe1\xde\xa7\xf8\x8b\x95\xa86\x90\x96\x82#\xa4\xc8\x05\xd0\xc4\x1e\xf0\x08c\x90g\x12\x8a8w\x8f(S\xbf\xf7\xfd#\xd3\xca=|d\xbf@&\xe8A;D\x11D\xe9\x9188@#\xa7\xd4\xc3\x93\xa1\xdd\xcb\xe6\xdb\xf3QT\xd0\xb1\xa0\xb9\xdd1\xe0\x8a\xf7^$\x1b\xfe\xbaO\xe3\x80\xc2\xb3\xa8q\xe9A\xde\xe4\xc5\xe3I\x1c0\x91$\x0e\xe3\x119\xff\xa4\x8bZ\xd8\x9eV\xad\xc8Z\x13\xe7\xd9\"\x06t\xee;\x00PK\x07\x08F\xc2\xac\xba\x81\x00\x00\x00\x9f\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1f\x00	\x00roles/precheck/tasks/sysctl.ymlUT\x05\x00\x01\xf0b\xe7[\x94\x92An\x830\x10E\xf7>\xc5\x17{`\xef\xcb \xc7\x9e\xa4V\x8c\x8d<\x93T(\xe2\xeeUL\x93\xbajC\xe9r\x98\xff\xe7\xa1'\xb7\x88f$\x0d\xc3LY0&7\xe5t \x1c\xf2\x10I\x8e>\x08e\xf8\xc8bB \xa7\xf0Lh\x05\xe0\xb3\\\x87\xcbg\x16#\xa41eb\x8a\xa2\xd4\x83\xc2\xe6J/\x186\xc5\xa3?A\x12z\x12\xdb\x8f\xc9]\x02q\x1b\x92q\x9d\xebkBw\x8f*\xc0\xa6i^\xff\x82\xb3\xd5\xd8\xd9\x02\x1c\xb1\xe8\x9d\x14\xf5\xca\x8f\x9f\x06\x9bb\x94l\xec\xf9O?u\xf8\x1f~\xbe1\xb6\xfd\xd4\xd1\xfd~~km\xf9\xf9\x99W-xf+\xa1~\x0e\xcd\xed\x06/4vg\x9a\xb1,MY]M\xb8\xd4\xbb2?\xb7\xeb\x91\x81I4f\xe2\xd2\xc8t\xc7>\xe6w/o\x83\xf3V\xd6\x1b_\x05\xf1\xf1\xc4X\x96F}\x0c\x00PK\x07\x08\xe5\xb5\xe9\xfd\xe2\x00\x00\x00\xcc\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x00roles/precheck/tasks/time_check.ymlUT\x05\x00\x01\xf0b\xe7[\xb4R\xcdn\xd3@\x10\xbe\xfb)>\xdc\x0b=4B\xe2fQ\x9e\x81\x037\x84\xac\xc9z\x1c\xaf\xb2\xde\x8df\xc6\xa9B\xf1\xbb\xa3\xb5M\xea\xb4\x0dH\x15\xec)\x19\xcf|\x7f37\xb8C\xa4\x9e+\x90*\x8b\xc1|\xcf\xf0\n\x97D\xd8\x19R\x04\x85\x80.\xa9)\xda$p,\xe6[\xef\xc8X\x8b\x1b`\x19\xac\xa6\xdf\x80ud\x15zRc\xa93X\xed:v{|\xba\x9f0\x8e$\xfa\xcd\xc7#GKr\xaas)\xd3\x7f\xdfPT\xbf\x0d\\7d<\xcdm\xf8\x90\\\xb7\xa0\xf6\xba\xabP~\xed\x18SuV\x99\"\x1e\x1f\xf1\x12\x0c\xe3\x88*\x7fz\x0b!\xc6q\xe1\x9c\xdf\x03)\x02\xabfc\x11\xd6\xf1\x99{\xf6\x98yv\x92\x86\x83n\xe6\xc2\xcf\xd6\x8b\xdaY\xc3\xcb$\xc6\x11\xdb\x13\xfa$<c~\xfc\x00e\x97b\xa3\x9b\x0b\xe6/\x81I\x19=\xed\x19:\x08\xbf\xb6\x9a\xac'\xbb\xd4\xb2(n`\x9dWD\xe6FAh|\xdb\xb2p4h\n\x83\xf9\x14s`\xbb\x84\xfd\xb0\xe5\xbd\xb7\xe2\x0e\xdb\x90\xdc\xbe*p\xbe\x81\x1dY\xc7\x82#\x05\xdfL>\x7f\xa4\xc8Zd5.\xf5=\xc5\xa6\x9a\xcayI\xce\x02\x82W\xbb\xbb\xec\x13\xde\xf9\xbc\xfbj5\xbfb8\xb2\xf8\xf6\xb40d\xe5\xe7\xb6\x02\xab[\xc2\xea\x96rW\xfd\xbb\x0b>>\x01o\xd4\x9a4\xd8\xd2=\xdd\xc8\xe7\xe5\xcf\xf4\xca\xcb\xd1~P\xc3\x96A\xcf\x0c\"\xaf8\x8bnp\xf4\xb46XNh\x14O5\x8b$\xd1\xba%\xa3P\xc1d\x98\xf5>t\x1c+\xbc\x7f\xaeQ\xd1p\xeb#7\xb8\xbdj\xde\xa5h\x92\xc2!P\xe4\xbf\x87\xb0\xee~c\x18\xafC\xfc\xc7P\xaeh~\n\x87bs\xc5\xd8\xbb{\x94%nQ\x14\xff\x14.\xc9\xf9<\xfe\xb8\xb3_\x03\x00PK\x07\x08\x8bw\xff^\xc3\x01\x00\x00\x16\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00roles/precheck/tasks/user_settings.ymlUT\x05\x00\x01\xf0b\xe7[l\x90\xbdn*A\x0c\x85\xfby\x8as\xf7\"m\xb5\\\xddv$\xda\x94)\xd3D\x112\x8caG,\x9e\x95m\x82\x10\x90g\x8ff\xf9\xa9(gt|\xbe\xcf\xee \xb4\xe7\x88-;\xd6\x07U\x16\x07\xa7\xecY\xb6\xd8\x97\xc4\x01\xb0\x9e\x87!b\x95%\xa1\xfb\xc0\x05t\xdc\xa1\x9d\xfd\xc7b\x81\xe6\x9e\xedj\xb6\xc1y\xd4,\x8e\xd9\xfb\xdb\xb5\xc5\x05\xae\xe8\xd6	\xedg\xa4a\xec)~\xb5\x01P\xdefs\xd68q\x96u0\x84\x87\x86\xf1\x0d?\xb1\x917\x0f\x97\xa9\x1f\xd9p0V\x8cZ\xbes\xe2\x04\x92\x04)\x0e\x1a\x94)\x9d\xeax\x00\x86,\x9ce\x93\x07\x8e\x01\xb8\xbd#\x9a\xda\xdd\x15\x9c\xcf\xb7\x8d\x96\xf7\xea\xc9\x00\xd7k\x03\xfc}\xf2\x9f\xc8T\xd8\xa4\xad\x84#\x9d\x0cN;\xc6\xa6(\xac\xec\x19\xcadE&\xc6H\xdeG4?\xff\xe6+\xb2^\xd7\xcd\xf4kN\xce\x11\xa3\xb2\xb1T\xb5c\xcf\x12_\xf1\xeb&\xcf{\xcc\xcdS98\xfe,^D\xc3\xef\x00PK\x07\x08\xe4\x07\xf9\x1f\x00\x01\x00\x00\xb3\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x002\x00	\x00roles/precheck/test_plugins/hostname_validation.pyUT\x05\x00\x01\xf0b\xe7[lS?o\xdc>\x0c\xdd\xfd)\xde\xf0\x03d\xe7w9\x04\xddz\x80\x87\x0e-\xd0![\xb6&\xb5u6m+\x95%C\xa4\x93\\\x9b~\xf7\xc2\xf2\x9f\xdc!\xf1 Q\xd4#E>>\x9b~\xf0A\x10(i\x82\xef\xa1\x1d\x9b\xa3%,n\n\xc1\x07N\x12\xa5Tr\xd7\x19F\xa0\x96^0\x8c\xd6R\x8d\x18\xf1e\x89\xa8|M\x11\x97\xd4\xd4\xcc\xb8\xf4I\xdb\x91r\xa5v\x18\xb4\x08\x05\x17m\xd3:\x1f\xa8\xd2L\xf97m\x99v\xe8G+\xc6\x1a\xf7\xe6\xd0Ru\x85\x9c\x06\xca\x15\x93\x0eU\xa7\xb2C\x02\x00J)|}\x19<\x13\xca@%4C\xe3\xe8\xbd%\xed\xd0\x18+\x140\xb2q-\xa4#\x94sp\x89\x9e\xa4\xf35\x8e'\xd4\xd4\xe8\xd1\xca>f\x9b\xbe\xd8\x97aX\xf3\x8b\xec	\xde\xd9\x13F\xa6f\xb4h|xK\xa1]\x8d2\x16V\xe2\xb93U\x07m\x03\xe9\xfa\xb4e\xea\xf4\x13M\xcf\x9a\x00\xff\xbcV\xc3\xfb\xb5\xee\xb87V\xb7\x8c\x1c7\xf1d\x9a36\x0e[\xa2\x19\xf4\x9a#\xd0\xfe\xfb\n\xdcH\xfa\x18w\x1b\xbdE \xc4\xb0\xca\xf7\x83\xb1\x94.\xc4\xeffl\x1e\xd7l\x86N\xac!GQ\x1cGc\xc58.\x8a}K\x92\xaa\xe9B\xcd\xa0@2\x067c\xd3\x96D\x8b\x84\xb4\x08\x17#\xdaa\x9b\xd1<\xf1,\x9bE\xd0y\x16\xa7{*\x9e\xb45\xb5\x16J\x11\xef\xcf\xe4\xf03M\x7f\xe8\xeb\xdf\x0f\xd3rs\xfd\xf9\xfe\xfa\xe1j1\x1f\xb2\xfb\xfdU\xf6\x7f\xba\x1e_W\xe3#\xe8\x7f\xab<\x96z\xcf\xe4\xb7=\xb6\xc3\"\xaee\xdb\x8aN\x92\xa4\xb2\x9a\x19w\xc4r\xeb\xeb\xd1R\xea\x8f\x8fTIv\xb8\x98]5\xb2\xf8\x1e\x8f\xc6=\xeaO\x10b\x89\nY\xba\x9b4\xb7v\xcc\x173\x9f~\x88	\xcd)\x93m\x96\xa4g\xec\xfe\xd9\x1c\xd3\xa7\xde\xd1\xa6\x0e\xef\xa9L\x00\x00\x00\xfe&\xff\x06\x00PK\x07\x089$\xe2\xbc\xde\x01\x00\x00\xc3\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00roles/root-cert/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00\x13\x00\xec\xffcert_dir: /etc/pki\n\x03\x00PK\x07\x08vJ\x11J\x1a\x00\x00\x00\x13\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x00roles/root-cert/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\x94SAk\xdcL\x0c\xbd\xfbW\xbco/\x9b@\x9c\xcdG\x1b\n&-\x94\xd0S.\xbd\x97b\xb4\xb6l\x8b\xcc\xce\x18I\xb3i\x08\xf9\xef\xc5\xde$N7\xb4\xb4\xc7\x91\xde\xbc\xf7\xe6iT\"\xd2\x8e+\\iJ^6\xac\xfe\xa9\x00Z\xde\xe6\xbe*\x00`g}\x85\xaf*\xd1\xe1\xd4\xbf\xc6\xa1K\x8a\x9b\xbc\xe5\x1bq\\\xa7\xd8I\x9f\x95<)FRc-\x8ag\xf2\xeb\x81\x9b[P\x08\x18\x92\xb9\x81\x94a.!\x80\xf6$\x81\xb6\x81\x0b@b\x13r\xcb\xb5\xa6\xc0\x07\xed\x83\xb5Q\xb9\x99\x08\xe6\x92\x93\xddZ\xddi\xdaU\x13a=\x13\xd6\x14d\xcf\x8b\xde\x97hY\x19i\xe4h\x16 \x06\x89\xe6\x14\x02\xb7\x05`\x03\x87P\xbdt\xf7\xac&)\x16@G\x12\xb8\xad\xef\x06\x8e/\xed\xfa\xa9}\xae\x0d\xfe\xfb\x88\x8b\x02P\xee\xc5\x9c\xf5\x0df1\xc0\x07\x03\xeb\x87\x07L\x91\xd6\xad(\x1e\x1f7\xae\xd9|C\xb1\x19\x92\xdaf\x0d\xfe!\xe66)\xcb\xf3\x93G\xf2\xa1\xc2\xea\x8f\x17W3\xd2\x9c\x9c+\xb4\xa2\xdcx\xd2\xfb\xb9\x98\xee\xe2dl\x1a\xe6|\xee5\xe5\xf1\xd5y\x97Z\xaep\xf1\xe1\xf2r1\x9b\xc7\x96\x9cq\xfdy\xf6*\x9d4\xe4lKP\xab\x03\xa0l\xa8|\x0dX-\x0c\xce\xe6\x90\x0e\xb7y\xcb%\x8db\xac{\xd6)w\xcd1J\xec\x172\xbb7\xe7]\xe3a\xf6\x9f\xed\xe8\xce/\xf1\xbe0\xd5\xca\x96\x83\x1f\x8f\xe8\xb8?\xcd(&\x87D|\xbb8{w\xf6\xfe{\x81\xa9 \xdd}\x05\xe5\x90\xa8\x05\xc5\x16\xca\xe6\xa4\xfeV\xba\x19(\xf6\xbf\xa77oS\xf6\xf3Nb{\xb2>yz\xda\xe9\xfat\xfa\x18\xe5\xffK\x1cW\x9b\xbf]\xa6\xcd?l\xd3\xcf\x01\x00PK\x07\x08\x07V\xc9\x17\xb4\x01\x00\x00\xaa\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00!\x00	\x00roles/timesyncd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[\x00Z\x00\xa5\xfftime_servers:\n- \"0.pool.ntp.org\"\n- \"1.pool.ntp.org\"\n- \"2.pool.ntp.org\"\n- \"3.pool.ntp.org\"\n\x03\x00PK\x07\x08\xf8V\x85<a\x00\x00\x00Z\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x00roles/timesyncd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[\xdcVOo\xdc\xb6\x13\xbd\xef\xa7x?\xf9\x07\xac]t\xd7\xe8U\xa8\x03\x14n\x81\x02)Z\x03NOE+P\xe4h\xc5\x9a\"\x05\xceh\x13w\xed\xef^\x90\xa2\xec\x8dc7\xc9\xa9\x7fn6g8\xefq\xde\xcc[m\xe0\xd5@5\xbe\x16;\x10\xdfzm^\xad\x00C\xed\xb4\xabW\x000\xf0\xae\xc6U\xb4^ jw\x9c\x87.D\xbc\x9eZzm\x05\x97\xc1wv7E%!bT\x91)\xaeV\x1b\xb4.\xe8\x9bz\xb5\x02\x16\xa0\xcb\x9e\xf4\x0d\x94s\xe8\x03\x0bCE\x02\x8bu\x0ej\xaf\xacS\xad\xa3\x8ck\xbdv\x93\xa1&\x06G3\x13\x94\nc$\x9d\x8a\x94CQ|\xc3M\x17\xc3P\xa7\xb2M.\xdb(g\xf7t\x8c{M\x82\xf4\xc6?\x82\x9f\x01\x96\x7f\xde/^\x1d\x0e\x99X\xb3\x84q\x7f_\xe5\x8c\xb7=\xf9\xfaI\xcc2\x0cu\xd6\x93IP'\xd0\xc19\xd2\x82N\xe9\xf44o\xc0\xb7,4\x80)\xee\xad&\x86\x04d\xf2\xf02\x9a\xe5\x18,J&>b\xfb\xd1B\x99R\xb9\xded\xb8\xf9\x1d\x91v\x96\x85b\xbd\x04\xb9I\xc5s+N\xc0$b\xfd\x0e\xd3\x98x\xab\xc9\xcd=\xc9z\xe6\x07&Z\xe9U\xd6+-\xa9\x83\x8f\x94\xb84pNN\x7fe\x08\x8a3\x17g=Y\xdf\xd9G\xb5F%}\x8ds\x12}>\x937\xe7\x0f`[\x1d|W\x1a\x1fiG\xef\xc6\x1a\xeb\xdf~|su\xb1.\xa7\xa9^\x8d*\x1d\x1d\x0e\x19\xae)p\xb8\xc3\xef\xc1\xfa\xd35\xd6gO\xd49}\xff\xd1[\xe5\xd9\xb6\xae4h\xbb\x04\x7f\xa9R\xf3\xab_\x8f\xe4\x9b\xb5\xfa\xac\xcb\x17\x17X\xb3\x84q$\xb3>\xcb\xf7O\x97\x1b\xc6\xb2D\xdbNb\x83Oy\xd5\xf5\x0f\xdf]W\x08\x11/f\xfc\xdcN^\xa6\xea\xecE\x9dt\x1f\x83\xbf\xfd\x04\x91\xa6q\xc9-\xef\xc9{\x1aF\x8a*\xd7\x9c\xb5`H\xaf\x04\x13S\xc9.\xd33:\xa5\x9fSpNz^\xb6\x84C\x11\xdb/\xfe\xbf\x88\xb7\xd4AUb\x87\x03l\x1a\xdf\xfb{\xd8v\x8a,e\xa7\xac\xf4M\n\x94\xe9\xdd\xa0z*\xf6?I\xe0\x17\xd5\xbb$/?]W\xb3x\x8ay\x1a\x88\xb1\x1f\xde\xaaH\x1b	\xc1\xb5\xe1\xddF\x0f&\xf1\xb1\x1eW\xdf\xbc\xf9\xfex\xd7\xb3\x1f\xd8\xae\\\xc0~HW8%\xb7\x94\x14\x9b\x98L2\x8e\xb4yy\x13rC\xb8'\xe7\xea\xe7P\x96-+\xb6\x82\xbb;\x90\xee\x03\xd6\xdfZN\xfej\xd6O\xac\xa2 f\xcfk\x12J\x8e\x9f \x8dW\xf0\xe8\x15\xc3\x87\xe4\xbb{\x1b&\xcee)\x11\xd2a\x18U\xa4-\xb8\x0f\x933h	\xa3bF\xa7\xac\xdb\xe6\x1a\xbaW~G\xa6Ic[\xc3\x87cG&\x9f\xc8\x1cy\xca\x07-\xf82\xd9Q\xee\x9a\x0f\xf2\xd7\xcd\xc8Cm\x96\xc1\xcd\x0ckDbQQ\xc8\x94c\xa3h\x08\xbe\x89\xe4\x8225n\x89K\xa0,\xcf\\d\xf3\xc0\xe8\xc8\xf7?h\xd1\x96\xc5\x84I\xb6\x9d\xf5\xe6\xf4\xb1\xb3gx\x85\xcdWyZ\xfe\x1bf\xf4D\xad\xd9\x07\xfe~\xad\n\x8f\x7f\xb3B\x9f\xe2&\xf3\xdeh\x17&\xd3\x8c1\xec\xad\xa1\x88\xff]\xa0bQ\xfa\xc6V\xab\xd5\xc3\x07\xdc\xe3\x8f\xebG\xbe\xe0\xce?\xe3\x13\xee\xcf\x01\x00PK\x07\x089\x0e)\xf8+\x03\x00\x00\x1f\n\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92\x9bC8n\x01\x00\x00\x88\x03\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00roles/containerd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM1\x0c*hH\x01\x00\x00\xe2\x02\x00\x00)\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc7\x01\x00\x00roles/containerd/filter_plugins/filter.pyUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM}\x1e\xbb_X\x00\x00\x00\x83\x00\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81o\x03\x00\x00roles/containerd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0+\x00\x00\x00$\x00\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81 \x04\x00\x00roles/containerd/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x89qK\xb7\xef\x01\x00\x00\x0f\x07\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa0\x04\x00\x00roles/containerd/tasks/binaries.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMXx\xdc{\x80\x02\x00\x00\xc0\x06\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe9\x06\x00\x00roles/containerd/tasks/images.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMy$\xc9]{\x03\x00\x00i	\x00\x00\x1f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1	\x00\x00roles/containerd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x85\x04\x7fXB\x01\x00\x00]\x02\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x92\x0d\x00\x00roles/containerd/tasks/manifest_preload.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0b\xcd!\xd0\xe2\x00\x00\x00\xcb\x01\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x816\x0f\x00\x00roles/containerd/templates/etc/containerd/config.toml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM&\\g\xd3s\x00\x00\x00l\x00\x00\x00-\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x87\x10\x00\x00roles/containerd/templates/etc/crictl.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x17\xcf\xca\xfd&\x01\x00\x00\xb4\x01\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81^\x11\x00\x00roles/containerd/templates/usr/lib/systemd/system/containerd.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd0@\x0f5\x84\x00\x00\x00}\x00\x00\x00\x1b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x02\x13\x00\x00roles/dns/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x83\xeb\xc5{\xac\x01\x00\x00\xaf\x04\x00\x00\x18\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd8\x13\x00\x00roles/dns/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xaa\xc2\x8c\xc6\x99\x01\x00\x00\xe3\x03\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd3\x15\x00\x00roles/docker/registry/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMm\xa3$\xadq\x00\x00\x00j\x00\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xca\x17\x00\x00roles/docker/registry/files/etc/systemd/system/registry.service.d/registry.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM:\x1cM\xc4d\x00\x00\x00\xfb\x00\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1\x18\x00\x00roles/docker/registry/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0+\x00\x00\x00$\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x83\x19\x00\x00roles/docker/registry/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc2u\xca\x98\xdf\x00\x00\x00\xeb\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x08\x1a\x00\x00roles/docker/registry/tasks/certs.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xdcp\x8c\xd6F\x03\x00\x00\xff\x08\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81C\x1b\x00\x00roles/docker/registry/tasks/images.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMcwF\xff\x8e\x05\x00\x00j\x10\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe6\x1e\x00\x00roles/docker/registry/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMu@\x04\xf8-\x01\x00\x009\x02\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcf$\x00\x00roles/docker/registry/tasks/manifest_preload.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xba\xa3}\x8d\xde\x01\x00\x00q\x04\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81c&\x00\x00roles/docker/registry/tasks/registry_image.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xbc\xc0\xa9+\x13\x01\x00\x00{\x01\x00\x00R\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa6(\x00\x00roles/docker/registry/templates/etc/systemd/system/docker.service.d/docker.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM@\x8fM\xad;\x01\x00\x00\xbc\x02\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81B*\x00\x00roles/docker/registry/templates/etc/systemd/system/registry.service.d/registry.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x93\xb1\xda%\x8e\x02\x00\x00c\x06\x00\x00J\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\n,\x00\x00roles/docker/registry/templates/usr/lib/systemd/system/registry.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\"\xd5\x9aI\x17\x02\x00\x00\x95\x04\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x19/\x00\x00roles/docker/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM<\x00\x98w\x85\x00\x00\x00\xb8\x00\x00\x001\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8d1\x00\x00roles/docker/systemd/files/etc/logrotate.d/dockerUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM*\xab`\x88\xa2\x00\x00\x00\xc1\x00\x00\x007\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81z2\x00\x00roles/docker/systemd/files/etc/rsyslog.d/30-docker.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMt\xae\xdf)\x9a\x00\x00\x00\xc0\x00\x00\x00A\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8a3\x00\x00roles/docker/systemd/files/usr/lib/systemd/system/logrotate.timerUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x91\xcbw0\x9d\x00\x00\x00\xc3\x01\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9c4\x00\x00roles/docker/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x05Ds\x7f\xe9\x01\x00\x00G\x05\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x965\x00\x00roles/docker/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96\x1e\x11\xca\x8b\x00\x00\x00\x06\x01\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd97\x00\x00roles/docker/systemd/templates/etc/docker/daemon.json.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMpE\xdb\xc5\xc2\x00\x00\x00g\x01\x00\x00\x1c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd38\x00\x00roles/etcd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa6\xf2\x7f\x92\xcc\x00\x00\x00\x1b\x01\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe89\x00\x00roles/etcd/files/etc/rsyslog.d/30-etcd.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x00\x00\x00\x02\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x16;\x00\x00roles/etcd/files/var/lib/etcd/.pidUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2_=\xd8\x84\x00\x00\x00}\x00\x00\x00\x1c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81q;\x00\x00roles/etcd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM!\xc3\xb0\x19\x18\x06\x00\x00|\x14\x00\x00\x19\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81H<\x00\x00roles/etcd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM!\x7f\xb7\xa1\x7f\x01\x00\x00\xdf\x02\x00\x002\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb0B\x00\x00roles/etcd/templates/var/lib/etcd/update_ionice.shUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMC\xe9 }\x19\x00\x00\x00\x12\x00\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x98D\x00\x00roles/ipsec/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe4\xf6GL\x85\x00\x00\x00\xe9\x00\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x05E\x00\x00roles/ipsec/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x13j{%\xe1\x01\x00\x00\x1a\x05\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdbE\x00\x00roles/ipsec/tasks/strongswan.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8b\xf7\xa5\xf7\x08\x02\x00\x00\xd5\x03\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x13H\x00\x00roles/ipsec/templates/etc/ipsec.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0bj\xe4\x154\x00\x00\x00-\x00\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81yJ\x00\x00roles/ipsec/templates/etc/ipsec.secrets.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfd\xc5\x8c/\xab\x00\x00\x00\xe5\x00\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0eK\x00\x00roles/ipsec/templates/etc/strongswan.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8c3\xa50\xbb\x00\x00\x00\x15\x01\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1cL\x00\x00roles/journald/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM(\xf1B1|\x00\x00\x00\xcf\x00\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81.M\x00\x00roles/journald/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc4Kuq-\x02\x00\x00\xbe\x08\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x01N\x00\x00roles/journald/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xean\xb6\xe4\xa0\x0e\x00\x00\xf6,\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x82P\x00\x00roles/kubernetes/control-plane/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfb\xe7\xc1S\xb5\x00\x00\x00\x87\x01\x00\x00?\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x89_\x00\x00roles/kubernetes/control-plane/files/etc/logrotate.d/kubernetesUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM>\xd4	\xb7\xa7\x00\x00\x00\xc6\x00\x00\x00E\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb4`\x00\x00roles/kubernetes/control-plane/files/etc/rsyslog.d/30-kubernetes.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb3iZ\xa8y\x00\x00\x00r\x00\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd7a\x00\x00roles/kubernetes/control-plane/files/srv/kubernetes/manifests/audit/audit-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMp\xad\x17%P\x00\x00\x00I\x00\x00\x00:\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdcb\x00\x00roles/kubernetes/control-plane/files/usr/local/bin/etcdctlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xff\xe6\x92\x06`\x00\x00\x00\xca\x00\x00\x00:\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9dc\x00\x00roles/kubernetes/control-plane/files/usr/local/bin/kubectlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf5K9{]\x01\x00\x00\x81\x03\x00\x007\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81nd\x00\x00roles/kubernetes/control-plane/filter_plugins/filter.pyUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x14\x7f\xe8\xf6f\x00\x00\x00\xfb\x00\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x819f\x00\x00roles/kubernetes/control-plane/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd2\xa6LL\xdd\x10\x00\x00\xb4@\x00\x00-\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x06g\x00\x00roles/kubernetes/control-plane/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xaaI\xceHf\x01\x00\x00$\x04\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Gx\x00\x00roles/kubernetes/control-plane/tasks/taint-and-label.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMU@N\xe1\x83\x00\x00\x00|\x00\x00\x00<\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1cz\x00\x00roles/kubernetes/control-plane/templates/etc/aws/aws.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xff\x95\xe8Q\x0b\x01\x00\x00\n\x02\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x12{\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/admissioncontrol.cfg.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xef\xdd\xfa9\x89\x00\x00\x00\xd4\x00\x00\x00Y\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xab|\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/encryption-config.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0d\xc77:\x85\x00\x00\x00\xaf\x00\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc4}\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/eventratelimit.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x17\x0fGf\x87\x02\x00\x00\xbe\x05\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd6~\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/kubelet.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa4o\xdc \x03\x02\x00\x00\xa2\x03\x00\x00Q\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe3\x81\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/scheduler.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf16\x8d\xa6%\x01\x00\x00\xb3\x02\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81n\x84\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/configs/vsphere.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\x9b\x0d\xd0b\x08\x00\x00\xbb\x1b\x00\x00N\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x19\x86\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/etcd.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMO\x00\x1b\x868\x06\x00\x00\xa0\x16\x00\x00X\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x8f\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-apiserver.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x99\x91\xfd\x8c\xb6\x04\x00\x00\xd2\x11\x00\x00a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc7\x95\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-controller-manager.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM,c\x93Q\x9b\x03\x00\x00\x84\n\x00\x00X\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x15\x9b\x00\x00roles/kubernetes/control-plane/templates/etc/kubernetes/manifests/kube-scheduler.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9a\x97F\xfem\x00\x00\x00f\x00\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81?\x9f\x00\x00roles/kubernetes/control-plane/templates/etc/systemd/system/kubelet.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\\\xef\x8fe7\x00\x00\x000\x00\x00\x00N\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81A\xa0\x00\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-serial.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x94b\x82\x065\x00\x00\x00.\x00\x00\x00L\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xfd\xa0\x00\x00roles/kubernetes/control-plane/templates/etc/tmpfiles.d/systemd-uuid.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x80\x81\xd3n\xb4\x04\x00\x00\xc8\x0e\x00\x00b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb5\xa1\x00\x00roles/kubernetes/control-plane/templates/srv/kubernetes/manifests/control-plane/kube-proxy.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfc\x0e\x1d\x87\xae\x05\x00\x00]\x11\x00\x00R\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x02\xa7\x00\x00roles/kubernetes/control-plane/templates/usr/lib/systemd/system/kubelet.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x81g*k|\x01\x00\x00`\x03\x00\x00F\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x819\xad\x00\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe9\xc2\xafj\xab\x01\x00\x00\xa7\x04\x00\x00M\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x812\xaf\x00\x00roles/kubernetes/control-plane/templates/var/lib/kubelet/remote-kubeconfig.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMz\x9bV\xf3\xf2\x0d\x00\x00\xfb8\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81a\xb1\x00\x00roles/kubernetes/core/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc3\xf1\xcb\xbc\xa8\x02\x00\x00\x02\n\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb1\xbf\x00\x00roles/kubernetes/core/examples/blocks.mdUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96r\x91\x9c\xd6\x02\x00\x00\xd2\x06\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb8\xc2\x00\x00roles/kubernetes/core/examples/filesystem.mdUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\n\x87\xb84\x00\x00\x00-\x00\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf1\xc5\x00\x00roles/kubernetes/core/examples/object.mdUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x15\xdd1\x9b\xac\x00\x00\x00$\x01\x00\x00=\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x84\xc6\x00\x00roles/kubernetes/core/files/etc/kubernetes/rook-bin/umount.shUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92L2\xc6Z\x00\x00\x00\x85\x00\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa4\xc7\x00\x00roles/kubernetes/core/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0+\x00\x00\x00$\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\\\xc8\x00\x00roles/kubernetes/core/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x07\xda\xcc\xa3\x17\x02\x00\x00\x8f\x04\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe1\xc8\x00\x00roles/kubernetes/core/tasks/azure.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x01\x92\x14\x88I\x05\x00\x00\xbc\x13\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81U\xcb\x00\x00roles/kubernetes/core/tasks/defaults.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM5A\x18\x0dJ\x02\x00\x00$\x06\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xfd\xd0\x00\x00roles/kubernetes/core/tasks/dns.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMw~\xa7\xab\x10\x02\x00\x00j\x04\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa2\xd3\x00\x00roles/kubernetes/core/tasks/ebs.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8a]\xfc\x8e\xf4\x00\x00\x00\x13\x02\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0d\xd6\x00\x00roles/kubernetes/core/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x1c\x82\xc4&\xae\x06\x00\x00	\x17\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\\\xd7\x00\x00roles/kubernetes/core/tasks/rook.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM{fW\x08\xcd\x01\x00\x00P\x05\x00\x00>\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81f\xde\x00\x00roles/kubernetes/core/templates/etc/pki/ingress_openssl.cnf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa9i)\x89\x8a\x04\x00\x00\xdf\x0e\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa8\xe0\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/azure-storage-classes.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf2\x19\x16\x061\x18\x00\x00oq\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbf\xe5\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/calico.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xcf\x16-\xa6:\x0f\x00\x00\x7fA\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81n\xfe\x00\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/cilium.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf1R\xb0\xb0\xe0\x01\x00\x00\xac\x07\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81&\x0e\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/control-plane-services.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb4\xf0\xa3\xd5w\x05\x00\x00\xf3\x0e\x00\x00S\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x94\x10\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns-autoscaler.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM+\x88?\x85\xb2\x05\x00\x00R\x11\x00\x00H\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x95\x16\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/coredns.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\xb1\xe5J\x88\x03\x00\x00/\x0c\x00\x00P\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc6\x1c\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/default-backend.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMB\x8c\x03z\xc3\x02\x00\x00\x18\x08\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd5 \x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/dns-aaaa-delay.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9\xbd\\i\xf5\x01\x00\x00\xac	\x00\x00O\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1e$\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/ebs-blockstore.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMl\xad\x1c\x1d\xab\x05\x00\x00\x15\x14\x00\x00H\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x99&\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/flannel.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x93*B\x9cW\x07\x00\x00\xc9)\x00\x00I\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc3,\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/heapster.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf2\xb8\xe9 B\x05\x00\x00\xdb\x19\x00\x00S\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9a4\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kube-state-metrics.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM=\xf86\x0fM\x05\x00\x00\x92\x14\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81f:\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/kubernetes-dashboard.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd7P\x8a\x8d\x0c\n\x00\x00V/\x00\x00N\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81?@\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/nginx-ingress.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xad\xa5\xban\x9e\x0e\x00\x00\x08)\x00\x00Z\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd0J\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-cluster.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe1Z\xf1\xc4D\x17\x00\x000\xa2\x00\x00Y\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xffY\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-common.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb7\xb5m\xb2\x17\x11\x00\x00\xc89\x00\x00[\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd3q\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/cluster/rook-operator.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf4K\xbb\x13\xba\x02\x00\x00\x8e\x07\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81|\x83\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-filesystem.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92\xb3\x14\xae\x1d\x03\x00\x00\xef\x08\x00\x00_\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xca\x86\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-store.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe2\x85\x10u\xc3\x00\x00\x00\xfa\x01\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81}\x8a\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x12\xe0\x1b?\x82\x01\x00\x00w\x03\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd5\x8b\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-pool.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x96%\xe0 \xea\x02\x00\x00\xfa\n\x00\x00`\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe5\x8d\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/storage/rook-storage-class.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM7\x01\xd2\x91m\x02\x00\x00M\x06\x00\x00Z\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81f\x91\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook/toolbox/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM+o\x971\xda\x00\x00\x00\xda\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81d\x94\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-http.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8c$\x8d\xf9\xc3\x00\x00\x00k\x01\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd2\x95\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-dashboard-external-https.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM	\xbd\xf9B\xa0\x00\x00\x00\xf9\x00\x00\x00`\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81*\x97\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus-service.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x917\xd9qs\x01\x00\x00\xfb\x03\x00\x00X\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81a\x98\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/prometheus.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xfa,\xfc\x87\xbf\x00\x00\x00c\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81c\x9a\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-monitoring/service-monitor.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9\xff\xa0\xe3\x82\x00\x00\x00\xae\x00\x00\x00Q\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb6\x9b\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-object-user.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9dq\xfb\x8an\x02\x00\x00N\x06\x00\x00M\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc0\x9c\x01\x00roles/kubernetes/core/templates/srv/kubernetes/manifests/rook-toolbox.yaml.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x1e\xb8\xbe\xe8\xbe\x00\x00\x00\x0f\x01\x00\x00M\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb2\x9f\x01\x00roles/kubernetes/core/templates/usr/lib/systemd/system/rook-umount.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8a\xe3\xa3a\xc8\x02\x00\x00\xe1\x05\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf4\xa0\x01\x00roles/kubernetes/ha/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x99\x11\xdc\xba\xc3\x00\x00\x00\xf2\x00\x00\x007\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x18\xa4\x01\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-haproxy.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x18H<R\xa8\x00\x00\x00\xc9\x00\x00\x00:\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81I\xa5\x01\x00roles/kubernetes/ha/files/etc/rsyslog.d/30-keepalived.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd5\x9e\x80<q\x00\x00\x00j\x00\x00\x003\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81b\xa6\x01\x00roles/kubernetes/ha/files/etc/rsyslog.d/remote.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa9\xea\xad\x9cs\x01\x00\x00\x9d\x02\x00\x002\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81=\xa7\x01\x00roles/kubernetes/ha/files/etc/sysconfig/keepalivedUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf8+\xff\xa2'\x00\x00\x00 \x00\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x19\xa9\x01\x00roles/kubernetes/ha/files/etc/systemd/system/bynet.service.d/bynet.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9a\x97F\xfem\x00\x00\x00f\x00\x00\x00Q\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbe\xa9\x01\x00roles/kubernetes/ha/files/etc/systemd/system/keepalived.service.d/keepalived.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x89B~\x8a\x97\x00\x00\x00\x85\x02\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb3\xaa\x01\x00roles/kubernetes/ha/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMn\xbe2\x84\xf4\x01\x00\x00\xfb\x04\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa6\xab\x01\x00roles/kubernetes/ha/tasks/haproxy.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM_\xc3\xc3\xcd\x82\x02\x00\x00,\x0b\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf6\xad\x01\x00roles/kubernetes/ha/tasks/keepalived.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x92rq\xa5\x02\x01\x00\x00\x19\x02\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd7\xb0\x01\x00roles/kubernetes/ha/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMz\x9cl\xa0\xe7\x04\x00\x00\xfc\x0f\x00\x008\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x812\xb2\x01\x00roles/kubernetes/ha/templates/etc/haproxy/haproxy.cfg.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x0f\xd9\x11u\xdb\x02\x00\x00\xcf\x08\x00\x00?\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x88\xb7\x01\x00roles/kubernetes/ha/templates/etc/keepalived/keepalived.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM>)\x8b\xdf\x98\x02\x00\x00\x91\x08\x00\x00G\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd9\xba\x01\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/haproxy.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc9\x08\x8ebm\x02\x00\x00\x8e\x07\x00\x00J\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xef\xbd\x01\x00roles/kubernetes/ha/templates/usr/lib/systemd/system/keepalived.service.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMSd\xb5!\x0f\x01\x00\x00M\x02\x00\x004\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xdd\xc0\x01\x00roles/kubernetes/security-policies/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xac\xaf\x17\xec\xa4\x00\x00\x00#\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81W\xc2\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/backend-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa9\x91\x81xr\x00\x00\x00\x85\x00\x00\x00[\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8f\xc3\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/default-deny.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM&\xe1_0\x8b\x00\x00\x00\xb7\x00\x00\x00Y\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x93\xc4\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/dns-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x93v\x94\xe0\xea\x00\x00\x00.\x02\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xae\xc5\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/frontend-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x1d\xcf\xca\xf8\xf6\x00\x00\x00W\x02\x00\x00^\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81-\xc7\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/heapster-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x83\xa9\xf3\xad\xb4\x00\x00\x00\xbf\x01\x00\x00h\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb8\xc8\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/network/kube-state-metrics-policy.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9d\xfd\xfa\xf9\x00\x00\x00\xb9\x03\x00\x00c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0b\xca\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/ceph-critical.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc6Wi\xeb\xb3\x00\x00\x00\x18\x01\x00\x00]\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9e\xcb\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/default.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMy\x84\x11Z\xcb\x00\x00\x004\x01\x00\x00j\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe5\xcc\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-critical.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x10\xae\x8f\xb7\xd2\x00\x00\x00D\x01\x00\x00f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Q\xce\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/priority-class/kube-system-high.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x94\x901G\x0e\x01\x00\x00\xfd\x01\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc0\xcf\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/privileged.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xc5_\x81\xb6|\x02\x00\x00\xae\x05\x00\x00U\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81Z\xd1\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/psp/restricted.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb8\x81\xa92\x18\x01\x00\x00\x1d\x03\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81b\xd4\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/privileged.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMn\x8d\x7fZ\xef\x00\x00\x00\xe8\x02\x00\x00V\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x07\xd6\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/rbac/restricted.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x9a2\x8aI\xb5\x00\x00\x00H\x01\x00\x00a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x83\xd7\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/kube-system.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xa1\xaa,}\xc3\x00\x00\x00r\x01\x00\x00f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd0\xd8\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph-system.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\"\xf4\x0d\xcc\xc0\x00\x00\x00c\x01\x00\x00_\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x810\xda\x01\x00roles/kubernetes/security-policies/files/srv/kubernetes/manifests/resource-quota/rook-ceph.yamlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM'\x87\xd9\x1d\x1e\x05\x00\x00A\x13\x00\x001\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x86\xdb\x01\x00roles/kubernetes/security-policies/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM,F\xb9}D\x00\x00\x00=\x00\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0c\xe1\x01\x00roles/kubernetes/systemd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x1bv\xd3\x9ar\x00\x00\x00\xca\x00\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb1\xe1\x01\x00roles/kubernetes/systemd/handlers/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xccd6\xd0+\x00\x00\x00$\x00\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x84\xe2\x01\x00roles/kubernetes/systemd/meta/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8b\xe5\x0e\xb8\x9e\x02\x00\x00\xd5\x08\x00\x00'\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0c\xe3\x01\x00roles/kubernetes/systemd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMb\x86\x86m\xb3\x00\x00\x00\x0c\x01\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x08\xe6\x01\x00roles/kubernetes/systemd/templates/etc/motd.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMm\xea\x009\xc6\x00\x00\x00\xf9\x00\x00\x00[\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81 \xe7\x01\x00roles/kubernetes/systemd/templates/etc/systemd/system/containerd.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xbc\xc0\xa9+\x13\x01\x00\x00{\x01\x00\x00W\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81x\xe8\x01\x00roles/kubernetes/systemd/templates/etc/systemd/system/docker.service.d/override.conf.j2UT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMl|\xb3(u\x00\x00\x00\xc8\x00\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x19\xea\x01\x00roles/manifest/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM]\xc9\xcb\xca\xcc\x01\x00\x00\xed\x06\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe5\xea\x01\x00roles/manifest/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMD\x0f\xbb\x8ce\x04\x00\x00\xca\x0e\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x05\xed\x01\x00roles/precheck/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM7\x01\xee\x7f\x14\x00\x00\x00\x0d\x00\x00\x009\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1\xf1\x01\x00roles/precheck/files/etc/modules-load.d/br_netfilter.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x00\x08\x8aR\x14\x00\x00\x00\x0d\x00\x00\x009\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81E\xf2\x01\x00roles/precheck/files/etc/modules-load.d/ip_conntrack.confUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x80\x03e\x85T\x00\x00\x00M\x00\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc9\xf2\x01\x00roles/precheck/tasks/all_hosts_alive.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMoO\xe7$[\x01\x00\x00\x9a\x02\x00\x00(\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81|\xf3\x01\x00roles/precheck/tasks/ansible_version.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xeax\xb7\xad\x84\x00\x00\x00\xcd\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x816\xf5\x01\x00roles/precheck/tasks/cloud-init.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x05~\x94\x8c(\x01\x00\x00_\x02\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x14\xf6\x01\x00roles/precheck/tasks/fix_stacki.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jML0\x1f\x97\xaa\x00\x00\x00:\x01\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x96\xf7\x01\x00roles/precheck/tasks/format_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xd1\x98\xbf\xe4\xf9\x00\x00\x00\xa0\x02\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9c\xf8\x01\x00roles/precheck/tasks/ha_host_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM~\xa40\xf1\xc4\x00\x00\x00\x84\x01\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf2\xf9\x01\x00roles/precheck/tasks/ip_precheck.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMS\x8a\x05\xea\x94\x01\x00\x00\xf1\x04\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x11\xfb\x01\x00roles/precheck/tasks/ip_validate.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xdd\xc60\x0c\x0d\x01\x00\x00\x15\x03\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\xfd\x01\x00roles/precheck/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe3\xcf\xec\x1e'\x01\x00\x00D\x02\x00\x00%\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81a\xfe\x01\x00roles/precheck/tasks/memory_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM/\x0e\xc9\xe3Z\x00\x00\x00\x80\x00\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe4\xff\x01\x00roles/precheck/tasks/openssl_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xb9\x18\xec\xc8\x94\x00\x00\x00\xd5\x00\x00\x00+\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9b\x00\x02\x00roles/precheck/tasks/sc_cloudconf_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMF\xc2\xac\xba\x81\x00\x00\x00\x9f\x00\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x91\x01\x02\x00roles/precheck/tasks/swap_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe5\xb5\xe9\xfd\xe2\x00\x00\x00\xcc\x02\x00\x00\x1f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81l\x02\x02\x00roles/precheck/tasks/sysctl.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x8bw\xff^\xc3\x01\x00\x00\x16\x05\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa4\x03\x02\x00roles/precheck/tasks/time_check.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xe4\x07\xf9\x1f\x00\x01\x00\x00\xb3\x01\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc1\x05\x02\x00roles/precheck/tasks/user_settings.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM9$\xe2\xbc\xde\x01\x00\x00\xc3\x03\x00\x002\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1e\x07\x02\x00roles/precheck/test_plugins/hostname_validation.pyUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jMvJ\x11J\x1a\x00\x00\x00\x13\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81e	\x02\x00roles/root-cert/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\x07V\xc9\x17\xb4\x01\x00\x00\xaa\x03\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd7	\x02\x00roles/root-cert/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM\xf8V\x85<a\x00\x00\x00Z\x00\x00\x00!\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe0\x0b\x02\x00roles/timesyncd/defaults/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\xb8jM9\x0e)\xf8+\x03\x00\x00\x1f\n\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x99\x0c\x02\x00roles/timesyncd/tasks/main.ymlUT\x05\x00\x01\xf0b\xe7[PK\x05\x06\x00\x00\x00\x00\xbb\x00\xbb\x00\xafS\x00\x00\x19\x10\x02\x00\x00\x00"
 }
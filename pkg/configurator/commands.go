@@ -2,6 +2,7 @@ package configurator
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -21,6 +22,7 @@ import (
 type Command struct {
 	Hosts Hosts
 	ui    *ui.UI
+	ctx   context.Context
 }
 
 // NewCommand returns a new command
@@ -58,6 +60,18 @@ func NewCommand(hosts Hosts, platformConfig interface{}, ui *ui.UI) (*Command, e
 	}, nil
 }
 
+// WithContext sets the context to kill the commands in execution on the hosts
+// and to prevent new commands to be executed once the context is done
+func (c *Command) WithContext(ctx context.Context) *Command {
+	c.ctx = ctx
+	for _, host := range c.Hosts {
+		if host.ssh != nil {
+			host.ssh.SetContext(ctx)
+		}
+	}
+	return c
+}
+
 func (c *Command) executeFnInHosts(hosts Hosts, wg *sync.WaitGroup, fn func(Host)) {
 	wg.Add(len(hosts))
 
@@ -280,28 +294,33 @@ func (c *Command) CopyTo(from, to string, forceFiles, backupFiles, sudoFiles boo
 	return nil
 }
 
+// GetFile copies the remote file `from` of the first command host to the local
+// file `to`. Returns the number of bytes copied
+func (c *Command) GetFile(from, to string, perm os.FileMode) (int64, error) {
+	if len(c.Hosts) == 0 {
+		return 0, fmt.Errorf("there are no hosts to get the file %q from", from)
+	}
+	host := c.Hosts[0]
+	defer host.ssh.Close()
+
+	length, err := host.ssh.GetFile(to, from, perm)
+	if err != nil {
+		return length, fmt.Errorf("failed to get the file %q from host %s. %s", from, host.PublicIP, err)
+	}
+	return length, nil
+}
+
 // Exec executes a script file or command line on every command host
 func (c *Command) Exec(command, script string, sudoExec bool) (*ssh.CommandResult, error) {
 	var result ssh.CommandResult
 	result.Hosts = ssh.NewHostCommandResultMap()
 
-	var sudoCmd string
-	if sudoExec {
-		sudoCmd = "sudo "
+	execCmd, content, targetFile, err := execCommandLine(command, script, sudoExec)
+	if err != nil {
+		return &result, err
 	}
-
-	var content []byte
-	var targetFile string
 	if len(script) != 0 {
-		filename := filepath.Base(script)
-		targetFile = filepath.Join("/tmp", filename)
-
-		var err error
-		content, err = ioutil.ReadFile(script)
-		if err != nil {
-			return &result, fmt.Errorf("failed to read the script file %q", script)
-		}
-		command = filepath.Join("/tmp", filename)
+		command = targetFile
 	}
 
 	errMsg := []string{}
@@ -321,11 +340,6 @@ func (c *Command) Exec(command, script string, sudoExec bool) (*ssh.CommandResul
 			}
 		}
 
-		execCmd := strings.TrimSpace(command)
-		if sudoCmd == "sudo " && !strings.HasPrefix(execCmd, "sudo ") {
-			execCmd = sudoCmd + execCmd
-		}
-
 		outCmdMsg, errCmdMsg, exitStat, err := host.ssh.ExecAndWait(execCmd)
 		if err != nil {
 			handleError(err, "failed to execute command %q at host %s", execCmd, host.PublicIP)
@@ -347,13 +361,32 @@ func (c *Command) Exec(command, script string, sudoExec bool) (*ssh.CommandResul
 		}
 		// fmt.Printf(outputMsg+"\n", command, host.PublicIP)
 	})
-	var err error
 	if len(errMsg) != 0 {
 		err = fmt.Errorf("failed to execute the command %q at the following hosts: %s", command, strings.Join(errMsg, ", "))
 	}
 	return &result, err
 }
 
+// execCommandLine returns the command line to execute on every host, with sudo
+// if requested. If a script file is given, it also returns the script content
+// and the remote file to copy it before execute it
+func execCommandLine(command, script string, sudoExec bool) (execCmd string, content []byte, targetFile string, err error) {
+	if len(script) != 0 {
+		targetFile = filepath.Join("/tmp", filepath.Base(script))
+		content, err = ioutil.ReadFile(script)
+		if err != nil {
+			return "", nil, "", fmt.Errorf("failed to read the script file %q", script)
+		}
+		command = targetFile
+	}
+
+	execCmd = strings.TrimSpace(command)
+	if sudoExec && !strings.HasPrefix(execCmd, "sudo ") {
+		execCmd = "sudo " + execCmd
+	}
+	return execCmd, content, targetFile, nil
+}
+
 // TODO: Do benchmark test with previous code and the following:
 // func (c *Command) generateHosts(done <-chan interface{}) <-chan *Host {
 // 	hostStream := make(chan *Host)
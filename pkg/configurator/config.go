@@ -21,6 +21,7 @@ type Config struct { //  aws
 	KubeAdvertiseAddress                    string      `json:"kube_advertise_address" yaml:"kube_advertise_address" mapstructure:"kube_advertise_address"`
 	MasterSchedulableEnabled                bool        `json:"master_schedulable_enabled" yaml:"master_schedulable_enabled" mapstructure:"master_schedulable_enabled"`
 	EtcdLocalProxyEnabled                   bool        `json:"enable_etcd_local_proxy" yaml:"enable_etcd_local_proxy" mapstructure:"enable_etcd_local_proxy"`
+	ContainerRuntime                        string      `json:"container_runtime" yaml:"container_runtime" mapstructure:"container_runtime"`
 	DockerMaxConcurrentUploads              int         `json:"docker_max_concurrent_uploads,omitempty" yaml:"docker_max_concurrent_uploads,omitempty" mapstructure:"docker_max_concurrent_uploads,omitempty"`
 	DockerMaxConcurrentDownloads            int         `json:"docker_max_concurrent_downloads,omitempty" yaml:"docker_max_concurrent_downloads,omitempty" mapstructure:"docker_max_concurrent_downloads,omitempty"`
 	DockerLogMaxFiles                       string      `json:"docker_log_max_files,omitempty" yaml:"docker_log_max_files,omitempty" mapstructure:"docker_log_max_files"` // docker config takes it as a string
@@ -44,6 +45,7 @@ type Config struct { //  aws
 	ClusterIface                            string      `json:"cluster_iface" yaml:"cluster_iface" mapstructure:"cluster_iface"`
 	CniIface                                string      `json:"cni_iface" yaml:"cni_iface" mapstructure:"cni_iface"`
 	CniIPEncapsulation                      string      `json:"cni_ip_encapsulation" yaml:"cni_ip_encapsulation" mapstructure:"cni_ip_encapsulation"`
+	CNI                                     CNI         `json:"cni" yaml:"cni" mapstructure:"cni"`
 	PublicVipIfaceName                      string      `json:"public_vip_iface_name" yaml:"public_vip_iface_name" mapstructure:"public_vip_iface_name"`
 	PublicVipIface                          string      `json:"public_vip_iface" yaml:"public_vip_iface" mapstructure:"public_vip_iface"`
 	KubeAuditLogMaxAge                      int         `json:"kube_audit_log_max_age" yaml:"kube_audit_log_max_age" mapstructure:"kube_audit_log_max_age"`
@@ -77,6 +79,7 @@ type Config struct { //  aws
 	TerminatedPodGCThreshold                int         `json:"terminated_pod_gc_threshold" yaml:"terminated_pod_gc_threshold" mapstructure:"terminated_pod_gc_threshold"`
 	AdditionalRSharedMountPoints            []string    `json:"additional_rshared_mount_points,omitempty" yaml:"additional_rshared_mount_points,omitempty" mapstructure:"additional_rshared_mount_points"`
 	WaitForReady                            int         `json:"wait_for_ready" yaml:"wait_for_ready" mapstructure:"wait_for_ready"`
+	PruneResources                          bool        `json:"prune_resources,omitempty" yaml:"prune_resources,omitempty" mapstructure:"prune_resources"`
 	SysctlSettings                          interface{} `json:"sysctl_settings,omitempty" yaml:"sysctl_settings,omitempty" mapstructure:"sysctl_settings"`
 }
 
@@ -1,6 +1,7 @@
 package configurator
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -65,6 +66,8 @@ type Configurator struct {
 	address        string
 	port           int
 	Hosts          Hosts
+	targets        Hosts
+	etcdJoining    Hosts
 	stateData      map[string]interface{}
 	platformConfig map[string]interface{}
 	platform       string
@@ -73,6 +76,8 @@ type Configurator struct {
 	config         *Config
 	resources      *resources.Resources
 	ui             *ui.UI
+	ctx            context.Context
+	release        string
 }
 
 // PodsPhaseCount tracks the count of the phases of the pods
@@ -85,6 +90,23 @@ type PodsPhaseCount struct {
 	Unknown   uint32
 }
 
+// Add counts a pod in the given phase
+func (pc *PodsPhaseCount) Add(phase corev1.PodPhase) {
+	pc.Total++
+	switch phase {
+	case corev1.PodPending:
+		pc.Pending++
+	case corev1.PodRunning:
+		pc.Running++
+	case corev1.PodSucceeded:
+		pc.Succeeded++
+	case corev1.PodFailed:
+		pc.Failed++
+	case corev1.PodUnknown:
+		pc.Unknown++
+	}
+}
+
 // AnsibleStatsMap is a type safe map wrapped with mutex locks around get/set calls
 // where the keys are the role name and values are references to AnsibleStats
 type AnsibleStatsMap struct {
@@ -114,7 +136,7 @@ func (asm *AnsibleStatsMap) GetSnapshot() map[string]*AnsibleStats {
 }
 
 // New creates a configurator
-func New(clusterName, platform, address string, port int, hosts Hosts, stateData map[string]interface{}, platformConfig interface{}, config *Config, res []string, basePath string, ui *ui.UI) (*Configurator, error) {
+func New(clusterName, platform, address string, port int, hosts Hosts, stateData map[string]interface{}, platformConfig interface{}, config *Config, res []string, charts map[string]*resources.Chart, basePath string, ui *ui.UI) (*Configurator, error) {
 	pConfig := make(map[string]interface{})
 
 	// DEBUG:
@@ -141,6 +163,7 @@ func New(clusterName, platform, address string, port int, hosts Hosts, stateData
 
 	conf := Configurator{
 		clusterName:    clusterName,
+		release:        manifest.Version,
 		address:        address,
 		port:           port,
 		Hosts:          hosts,
@@ -157,6 +180,7 @@ func New(clusterName, platform, address string, port int, hosts Hosts, stateData
 	if err != nil {
 		return nil, err
 	}
+	r.AddCharts(charts)
 	r.AddResources(res)
 	conf.resources = r
 
@@ -195,6 +219,46 @@ func New(clusterName, platform, address string, port int, hosts Hosts, stateData
 	return &conf, nil
 }
 
+// WithEtcdJoining sets the master hosts that join the running etcd cluster as
+// new members, instead of bootstrapping a new etcd cluster. The members have to
+// be added to the etcd cluster before configure the hosts
+func (c *Configurator) WithEtcdJoining(ipOrDNS ...string) *Configurator {
+	c.etcdJoining = c.Hosts.FilterByNode(ipOrDNS...)
+	return c
+}
+
+// WithRelease sets the KubeKit release to install, by default the release of
+// this KubeKit. The release has to be in the KubeKit manifest and its package
+// has to be installed on the hosts
+func (c *Configurator) WithRelease(release string) *Configurator {
+	c.release = release
+	return c
+}
+
+// WithContext sets the context to cancel the configuration. Once the context is
+// done the commands in execution on the hosts are killed and no new commands
+// are executed
+func (c *Configurator) WithContext(ctx context.Context) *Configurator {
+	c.ctx = ctx
+	if c.resources != nil {
+		c.resources.WithContext(ctx)
+	}
+	for _, host := range c.Hosts {
+		if host.ssh != nil {
+			host.ssh.SetContext(ctx)
+		}
+	}
+	return c
+}
+
+// canceled returns the context error if the context is done
+func (c *Configurator) canceled() error {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
 // GetPrivateKey return the private key from the cluster platform configuration
 func GetPrivateKey(platformConfig map[string]interface{}) (string, error) {
 	if privateKey, ok := platformConfig["private_key"]; ok {
@@ -233,8 +297,12 @@ func (c *Configurator) Configure() error {
 	default:
 		errConfig = c.configureWithAnsible()
 	}
+	if err := c.canceled(); err != nil {
+		return err
+	}
 
-	if errResources := c.ApplyResources(false); errResources != nil {
+	prune := c.config != nil && c.config.PruneResources
+	if errResources := c.ApplyResources(false, prune); errResources != nil {
 		return errResources
 	}
 
@@ -264,6 +332,36 @@ func (c *Configurator) Configure() error {
 	return nil
 }
 
+// ConfigureHosts configures only the hosts with the given IP's or DNS's, for
+// example, the new nodes of a scaled cluster. The inventory still contains all
+// the cluster hosts so the new nodes join the existing cluster
+func (c *Configurator) ConfigureHosts(ipOrDNS ...string) error {
+	switch c.platform {
+	case "eks", "aks":
+		return c.waitClusterReady()
+	}
+
+	c.targets = c.Hosts.FilterByNode(ipOrDNS...)
+	defer func() { c.targets = nil }()
+
+	if len(c.targets) == 0 {
+		return fmt.Errorf("not found hosts %v in the cluster", ipOrDNS)
+	}
+
+	if err := c.configureWithAnsible(); err != nil {
+		return err
+	}
+	if err := c.canceled(); err != nil {
+		return err
+	}
+
+	if err := c.waitClusterReady(); err != nil {
+		return err
+	}
+
+	return c.validateCluster()
+}
+
 func (c *Configurator) addDataToResources() {
 	switch c.platform {
 	case "ec2":
@@ -322,22 +420,31 @@ func (c *Configurator) addDataToResources() {
 		}
 	}
 
-	c.resources.AddData("kubekitVersion", manifest.Version)
+	c.resources.AddData("kubekitVersion", c.release)
 	c.resources.AddData("clusterName", c.clusterName)
 	c.resources.AddData("platform", c.platform)
 	c.resources.AddData("certsPath", c.certPath)
 }
 
 // ApplyResources applies the Kubernetes manifests after rendering the templates.
-// It may only export the rendered manifests if `export` is true.
-func (c *Configurator) ApplyResources(export bool) error {
+// It may only export the rendered manifests if `export` is true. If `prune` is
+// true, the objects previously applied that are no longer rendered are deleted.
+func (c *Configurator) ApplyResources(export, prune bool) error {
 	c.addDataToResources()
 
 	if export {
 		return c.resources.Export(filepath.Join(c.certPath, "..", "kubernetes"))
 	}
 
-	return c.resources.ApplyAll()
+	return c.resources.ApplyAll(prune)
+}
+
+// DiffResources compares the rendered Kubernetes manifests with the live
+// objects in the cluster
+func (c *Configurator) DiffResources() ([]resources.ResourceDrift, error) {
+	c.addDataToResources()
+
+	return c.resources.Diff()
 }
 
 func (c *Configurator) waitClusterReady() error {
@@ -409,22 +516,9 @@ func (c *Configurator) getPodStatusInNS(namespace string) (*PodsPhaseCount, erro
 		c.ui.Log.Infof("No pods found in namespace %s", namespace)
 		return nil, nil
 	}
-	counts := PodsPhaseCount{
-		Total: uint32(len(pods.Items)),
-	}
+	counts := PodsPhaseCount{}
 	for _, p := range pods.Items {
-		switch p.Status.Phase {
-		case corev1.PodPending:
-			counts.Pending++
-		case corev1.PodRunning:
-			counts.Running++
-		case corev1.PodSucceeded:
-			counts.Succeeded++
-		case corev1.PodFailed:
-			counts.Failed++
-		case corev1.PodUnknown:
-			counts.Unknown++
-		}
+		counts.Add(p.Status.Phase)
 		// Check at the bottom of this file, an example of the information available from a Pod
 		c.ui.Log.Infof("Namespace: %s, Pod name: %q, Status: %s, On node: %s", namespace, p.Name, p.Status.Phase, p.Spec.NodeName)
 	}
@@ -590,7 +684,9 @@ func (c *Configurator) configureWithAnsible() error {
 	if err := c.Setup(); err != nil {
 		return err
 	}
-	c.UploadCerts()
+	if err := c.UploadCerts(); err != nil {
+		return err
+	}
 	return c.RunPlaybook()
 }
 
@@ -614,8 +710,14 @@ func (c *Configurator) executeInHosts(hosts Hosts, wg *sync.WaitGroup, f func(ho
 	wg.Wait()
 }
 
+// executeInAllHosts executes the given function in every host to configure, if
+// there are targets hosts those are the only hosts to configure
 func (c *Configurator) executeInAllHosts(wg *sync.WaitGroup, f func(host Host, logger *log.Logger)) {
-	c.executeInHosts(c.Hosts, wg, f)
+	hosts := c.Hosts
+	if len(c.targets) != 0 {
+		hosts = c.targets
+	}
+	c.executeInHosts(hosts, wg, f)
 }
 
 // Setup installs and configures Ansible and upload the Ansible roles and inventory
@@ -646,7 +748,7 @@ func (c *Configurator) Setup() error {
 		// This call should be deprecated once the KubeOS is packaged with Ansible
 		configureAnsible(host, logger, username)
 
-		uploadRoles(host, logger)
+		uploadRoles(host, logger, c.release)
 
 		uploadInventory(host, logger, string(inventoryYaml))
 	})
@@ -778,8 +880,8 @@ func configureAnsible(host Host, logger *log.Logger, name string) {
 }
 
 // uploadRoles uploads the Ansible playbook, roles and other required files to
-// all the nodes
-func uploadRoles(host Host, logger *log.Logger) {
+// all the nodes, the VERSION file has the release to install
+func uploadRoles(host Host, logger *log.Logger, release string) {
 	// Backup the existing playbook, if any
 	rolesPath := filepath.Join(ConfiguratorBaseDir, "roles")
 
@@ -862,7 +964,7 @@ func uploadRoles(host Host, logger *log.Logger) {
 	// Upload the VERSION
 	versionFile := filepath.Join(ConfiguratorBaseDir, "VERSION")
 
-	if err := host.ssh.CreateFile(versionFile, manifest.Version, 0644); err != nil {
+	if err := host.ssh.CreateFile(versionFile, release, 0644); err != nil {
 		logger.Errorf("[%s] failed to create the file %q: %s", host.RoleName, versionFile, err)
 		return
 	}
@@ -882,9 +984,25 @@ func uploadInventory(host Host, logger *log.Logger, inventoryYaml string) {
 
 // UploadCerts uploads the certificate files located in the 'certificates/'
 // directory in the cluster configuration dir
-func (c *Configurator) UploadCerts() {
+func (c *Configurator) UploadCerts() error {
+	return c.UploadCertsFrom(filepath.Join(c.certPath, c.platform))
+}
+
+// UploadCertsFrom uploads the certificate files located in the given directory,
+// with the same files and per host directories of the platform certificates
+// directory. It returns an error if the certificates were not uploaded to any
+// of the hosts
+func (c *Configurator) UploadCertsFrom(certsDir string) error {
 	var wg sync.WaitGroup
-	certsDir := filepath.Join(c.certPath, c.platform)
+	var mu sync.Mutex
+	errMsg := []string{}
+	fail := func(logger *log.Logger, format string, a ...interface{}) {
+		msg := fmt.Sprintf(format, a...)
+		logger.Error(msg)
+		mu.Lock()
+		defer mu.Unlock()
+		errMsg = append(errMsg, msg)
+	}
 	c.executeInAllHosts(&wg, func(host Host, logger *log.Logger) {
 		defer wg.Done()
 		defer host.ssh.Close()
@@ -892,7 +1010,7 @@ func (c *Configurator) UploadCerts() {
 		targetDir := filepath.Join(ConfiguratorBaseDir, "certificates")
 
 		if err := host.ssh.MkDir(targetDir); err != nil {
-			logger.Errorf("[%s] failed to create the certificates directory %q: %s", host.RoleName, targetDir, err)
+			fail(logger, "[%s] failed to create the certificates directory %q: %s", host.RoleName, targetDir, err)
 			return
 		}
 		c.ui.Notify(host.RoleName, "certificates", "<certificates>", "", ui.Upload)
@@ -932,11 +1050,11 @@ func (c *Configurator) UploadCerts() {
 
 			content, err := ioutil.ReadFile(filePath)
 			if err != nil {
-				logger.Errorf("failed to read the certificate file %q: %s", filePath, err)
+				fail(logger, "failed to read the certificate file %q: %s", filePath, err)
 				return
 			}
 			if err := host.ssh.CreateFile(targetFilePath, string(content), 0644); err != nil {
-				logger.Errorf("[%s] failed to upload the certificate file %q: %s", host.RoleName, targetFilePath, err)
+				fail(logger, "[%s] failed to upload the certificate file %q: %s", host.RoleName, targetFilePath, err)
 				return
 			}
 			logger.Debugf("[%s] uploaded certificate file %s", host.RoleName, fileName)
@@ -953,11 +1071,11 @@ func (c *Configurator) UploadCerts() {
 
 				content, err := ioutil.ReadFile(filePath)
 				if err != nil {
-					logger.Errorf("failed to read the certificate file %q: %s", filePath, err)
+					fail(logger, "failed to read the certificate file %q: %s", filePath, err)
 					return
 				}
 				if err := host.ssh.CreateFile(targetFilePath, string(content), 0644); err != nil {
-					logger.Errorf("[%s] failed to upload the certificate file %q: %s", host.RoleName, targetFilePath, err)
+					fail(logger, "[%s] failed to upload the certificate file %q: %s", host.RoleName, targetFilePath, err)
 					return
 				}
 				logger.Debugf("[%s] uploaded the %s certificate file %s", host.RoleName, pubHostname, fileName)
@@ -968,14 +1086,15 @@ func (c *Configurator) UploadCerts() {
 
 		err := host.ssh.Start(cmdMvCerts)
 		if err != nil {
-			logger.Errorf("[%s] failed to move the certificates to %s: %s", host.RoleName, TLSDirectory, err)
+			fail(logger, "[%s] failed to move the certificates to %s: %s", host.RoleName, TLSDirectory, err)
 			return
 		}
 		mvOutput := strings.TrimRight(cmdMvCerts.Stdout.String(), "\n")
 		if mvOutput == "OK" {
 			logger.Debugf("[%s] certificates moved to %s", host.RoleName, TLSDirectory)
 		} else {
-			logger.Errorf("[%s] failed to move the certificates to %s", host.RoleName, TLSDirectory)
+			fail(logger, "[%s] failed to move the certificates to %s", host.RoleName, TLSDirectory)
+			return
 		}
 
 		TLSTrustDirectory := filepath.Join(TLSDirectory, "trust", "anchors")
@@ -983,14 +1102,14 @@ func (c *Configurator) UploadCerts() {
 
 		err = host.ssh.Start(cmdMvRoot2Trust)
 		if err != nil {
-			logger.Errorf("[%s] failed to move the certificates to %s: %s", host.RoleName, TLSDirectory, err)
+			fail(logger, "[%s] failed to move the certificates to %s: %s", host.RoleName, TLSDirectory, err)
 			return
 		}
 		mvOutput = strings.TrimRight(cmdMvRoot2Trust.Stdout.String(), "\n")
 		if mvOutput == "OK" {
 			logger.Debugf("[%s] root certificates copied to %s", host.RoleName, TLSTrustDirectory)
 		} else {
-			logger.Errorf("[%s] failed to copy the root certificates to %s", host.RoleName, TLSDirectory)
+			fail(logger, "[%s] failed to copy the root certificates to %s", host.RoleName, TLSDirectory)
 		}
 	})
 
@@ -1008,11 +1127,11 @@ func (c *Configurator) UploadCerts() {
 
 		content, err := ioutil.ReadFile(filePath)
 		if err != nil {
-			logger.Errorf("failed to read the kubeconfig file %q: %s", filePath, err)
+			fail(logger, "failed to read the kubeconfig file %q: %s", filePath, err)
 			return
 		}
 		if err := host.ssh.CreateFile(targetFilePath, string(content), 0644); err != nil {
-			logger.Errorf("[%s] failed to upload the kubeconfig file %q: %s", host.RoleName, targetFilePath, err)
+			fail(logger, "[%s] failed to upload the kubeconfig file %q: %s", host.RoleName, targetFilePath, err)
 			return
 		}
 		logger.Infof("[%s] uploaded kubeconfig file", host.RoleName)
@@ -1021,7 +1140,7 @@ func (c *Configurator) UploadCerts() {
 
 		err = host.ssh.Start(cmdMvKConf)
 		if err != nil {
-			logger.Errorf("[%s] failed to move the kubeconfig to /var/lib/kubelet/remote-kubeconfig: %s", host.RoleName, err)
+			fail(logger, "[%s] failed to move the kubeconfig to /var/lib/kubelet/remote-kubeconfig: %s", host.RoleName, err)
 			return
 		}
 		mvOutput := strings.TrimRight(cmdMvKConf.Stdout.String(), "\n")
@@ -1029,9 +1148,14 @@ func (c *Configurator) UploadCerts() {
 			logger.Debugf("[%s] kubeconfig moved to /var/lib/kubelet/remote-kubeconfig", host.RoleName)
 			c.ui.Notify(host.RoleName, "certificates", "remote kubeconfig generated and uploaded", "")
 		} else {
-			logger.Errorf("[%s] failed to move the kubeconfig to /var/lib/kubelet/remote-kubeconfig", host.RoleName)
+			fail(logger, "[%s] failed to move the kubeconfig to /var/lib/kubelet/remote-kubeconfig", host.RoleName)
 		}
 	})
+
+	if len(errMsg) != 0 {
+		return fmt.Errorf("failed to upload the certificates. %s", strings.Join(errMsg, ", "))
+	}
+	return nil
 }
 
 // RunPlaybook will execute ansible remotely to configure the host to have
@@ -1095,6 +1219,15 @@ func (c *Configurator) RunPlaybook() error {
 
 		err = host.ssh.Start(executePlaybook)
 		doneAnsibleCh <- true
+		if c.canceled() != nil {
+			// the SSH session was killed but the playbook may still be running
+			killPlaybook := &ssh.Command{Command: "sudo pkill -9 -f ansible-playbook; true"}
+			if errK := host.ssh.WithoutContext().Start(killPlaybook); errK != nil {
+				logger.Errorf("[%s] failed to stop the Ansible playbook: %s", host.RoleName, errK)
+			}
+			logger.Warnf("[%s] configuration canceled", host.RoleName)
+			return
+		}
 		if err != nil {
 			logger.Errorf("[%s] failed to run the Ansible playbook: %s", host.RoleName, err)
 			return
@@ -1120,6 +1253,10 @@ func (c *Configurator) RunPlaybook() error {
 		c.ui.Notify(host.RoleName, task, configMsg, "")
 	})
 
+	if err := c.canceled(); err != nil {
+		return err
+	}
+
 	var ok bool
 	for role, stat := range globalStats.GetSnapshot() {
 		if stat != nil && stat.Ok() {
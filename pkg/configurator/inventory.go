@@ -55,6 +55,8 @@ type InventoryHost struct {
 	HostnameShort string   `json:"hostname_short,omitempty" yaml:"hostname_short" mapstructure:"hostname_short"`
 	KubeletTaints []string `json:"kubelet_taints,omitempty" yaml:"kubelet_taints" mapstructure:"kubelet_taints"`
 	KubeletLabels []string `json:"kubelet_labels,omitempty" yaml:"kubelet_labels" mapstructure:"kubelet_labels"`
+
+	EtcdInitialClusterState string `json:"etcd_initial_cluster_state,omitempty" yaml:"etcd_initial_cluster_state,omitempty" mapstructure:"etcd_initial_cluster_state"`
 }
 
 // InventoryVariables contain all the variables required for the Ansible inventory
@@ -90,6 +92,7 @@ type InventoryVariables struct {
 	KubeServiceIP                           string      `json:"kube_service_ip,omitempty" yaml:"kube_service_ip" mapstructure:"kube_service_ip"`
 	KubeAdvertiseAddress                    string      `json:"kube_advertise_address,omitempty" yaml:"kube_advertise_address" mapstructure:"kube_advertise_address"`
 	MasterSchedulableEnabled                bool        `json:"master_schedulable_enabled,omitempty" yaml:"master_schedulable_enabled" mapstructure:"master_schedulable_enabled"`
+	ContainerRuntime                        string      `json:"container_runtime" yaml:"container_runtime" mapstructure:"container_runtime"`
 	DockerMaxConcurrentUploads              int         `json:"docker_max_concurrent_uploads,omitempty" yaml:"docker_max_concurrent_uploads,omitempty" mapstructure:"docker_max_concurrent_uploads,omitempty"`
 	DockerMaxConcurrentDownloads            int         `json:"docker_max_concurrent_downloads,omitempty" yaml:"docker_max_concurrent_downloads,omitempty" mapstructure:"docker_max_concurrent_downloads,omitempty"`
 	DockerLogMaxFiles                       string      `json:"docker_log_max_files,omitempty" yaml:"docker_log_max_files,omitempty" mapstructure:"docker_log_max_files"` // docker config takes it as a string
@@ -117,6 +120,7 @@ type InventoryVariables struct {
 	PublicVipIfaceName                      string      `json:"public_vip_iface_name,omitempty" yaml:"public_vip_iface_name" mapstructure:"public_vip_iface_name"`
 	PublicVipIface                          string      `json:"public_vip_iface,omitempty" yaml:"public_vip_iface" mapstructure:"public_vip_iface"`
 	CniIPEncapsulation                      string      `json:"cni_ip_encapsulation,omitempty" yaml:"cni_ip_encapsulation" mapstructure:"cni_ip_encapsulation"`
+	CNI                                     CNI         `json:"cni" yaml:"cni" mapstructure:"cni"`
 	TimeServers                             []string    `json:"time_servers,omitempty" yaml:"time_servers" mapstructure:"time_servers"`
 	KubeAuditLogMaxAge                      int         `json:"kube_audit_log_max_age,omitempty" yaml:"kube_audit_log_max_age" mapstructure:"kube_audit_log_max_age"`
 	KubeAuditLogMaxBackup                   int         `json:"kube_audit_log_max_backup,omitempty" yaml:"kube_audit_log_max_backup" mapstructure:"kube_audit_log_max_backup"`
@@ -191,6 +195,7 @@ var defaultInventoryVariables = InventoryVariables{
 	KubeVirtualIPApi:                   "",
 	PublicVirtualIP:                    "",
 	MasterSchedulableEnabled:           false,
+	ContainerRuntime:                   "docker",
 	DockerMaxConcurrentUploads:         10,
 	DockerMaxConcurrentDownloads:       10,
 	DockerLogMaxFiles:                  "5", // docker config takes it as a string
@@ -212,6 +217,7 @@ var defaultInventoryVariables = InventoryVariables{
 	PublicVipIface:                     "{{ hostvars[inventory_hostname][public_vip_iface_name] }}",
 	CniIface:                           "{{ cluster_iface.device }}",
 	CniIPEncapsulation:                 "Always",
+	CNI:                                defaultCNI,
 	KubeAuditLogMaxAge:                 30,
 	KubeAuditLogMaxBackup:              10,
 	KubeAuditLogMaxSize:                128,
@@ -245,6 +251,16 @@ func (c *Config) LoadNilDefault() {
 	}
 }
 
+// InventoryGroup returns the Ansible inventory group of the host with the given
+// role name, that is the role without the number. i.e. master001 is in master
+func InventoryGroup(roleName string) string {
+	group := roleName
+	if len(group) > ZeroPadLen {
+		group = group[:len(group)-ZeroPadLen]
+	}
+	return strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(strings.ToLower(group))
+}
+
 // Inventory creates an Ansible inventory from the Configurator information
 func (c *Configurator) Inventory() (*Inventory, error) {
 	var vars InventoryVariables
@@ -269,7 +285,7 @@ func (c *Configurator) Inventory() (*Inventory, error) {
 
 	for _, host := range c.Hosts {
 
-		roleNameGroup := strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(strings.ToLower(host.RoleName[:len(host.RoleName)-ZeroPadLen]))
+		roleNameGroup := InventoryGroup(host.RoleName)
 
 		tempLabels, err := getListFromNodePool(c.platformConfig, "kubelet_node_labels", roleNameGroup)
 		if err != nil {
@@ -293,6 +309,9 @@ func (c *Configurator) Inventory() (*Inventory, error) {
 			KubeletLabels: tempLabels,
 			KubeletTaints: tempTaints,
 		}
+		if len(c.etcdJoining.FilterByNode(host.PublicIP)) != 0 {
+			ih.EtcdInitialClusterState = "existing"
+		}
 
 		hr, ok := hostsRoles[roleNameGroup]
 		if !ok {
@@ -344,6 +363,24 @@ func (c *Configurator) Inventory() (*Inventory, error) {
 		}
 	}
 
+	switch vars.ContainerRuntime {
+	case "":
+		vars.ContainerRuntime = defaultInventoryVariables.ContainerRuntime
+	case "docker", "containerd":
+	default:
+		return nil, fmt.Errorf("unknown container runtime %q, the supported container runtimes are 'docker' and 'containerd'", vars.ContainerRuntime)
+	}
+
+	if err := vars.CNI.validate(c.platform); err != nil {
+		return nil, err
+	}
+	if vars.CNI.Provider == "calico" && len(vars.CNI.Calico.IPEncapsulation) != 0 {
+		vars.CniIPEncapsulation = vars.CNI.Calico.IPEncapsulation
+	}
+	if vars.CNI.Provider == "flannel" {
+		c.ui.Log.Warn("the CNI provider Flannel does not enforce the Kubernetes network policies")
+	}
+
 	if _, ok := ValidAddressInventoryFields[vars.AddressInventoryField]; !ok {
 		vars.AddressInventoryField = "private_dns"
 	} else if c.platform == "ec2" {
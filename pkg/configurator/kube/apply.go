@@ -14,7 +14,7 @@ func (c *Client) Apply(name string, content []byte) error {
 
 	c.ui.Log.Debugf("applying resource template: %q", name)
 	r := c.ResultForContent(name, content, true)
-	return c.ApplyResource(r)
+	return c.ApplyResource(r, nil)
 }
 
 // ApplyFile creates a resource in the given local filename or HTTP URL
@@ -22,11 +22,12 @@ func (c *Client) ApplyFile(filename string) error {
 	c.ui.Log.Debugf("applying resource from file: %q", filename)
 	filenames := []string{filename}
 	r := c.ResultForFilenameParam(filenames, true)
-	return c.ApplyResource(r)
+	return c.ApplyResource(r, nil)
 }
 
-// ApplyResource creates a resource with the resource.Result
-func (c *Client) ApplyResource(r *resource.Result) error {
+// ApplyResource creates a resource with the resource.Result. The given labels,
+// if any, are added to every object before create or patch it
+func (c *Client) ApplyResource(r *resource.Result, labels map[string]string) error {
 	return r.Visit(func(info *resource.Info, err error) error {
 		var resKind string
 		if info.Mapping != nil {
@@ -38,6 +39,10 @@ func (c *Client) ApplyResource(r *resource.Result) error {
 		}
 		c.ui.Log.Debugf("applying object %s%q on namespace %s", resKind, info.Name, info.Namespace)
 
+		if err := addLabels(info.Object, labels); err != nil {
+			return fmt.Errorf("labeling %s. %s", info.String(), err)
+		}
+
 		// if err := info.Get(); err != nil {
 		originalObj, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
 		if err != nil {
@@ -0,0 +1,213 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// DriftStatus is the state of a live object compared with the rendered object
+type DriftStatus string
+
+// The possible states of a live object compared with the rendered object
+const (
+	DriftInSync  DriftStatus = "in-sync"
+	DriftChanged DriftStatus = "drifted"
+	DriftMissing DriftStatus = "missing"
+	DriftOrphan  DriftStatus = "orphan"
+)
+
+// Drift is the difference between a rendered object and the live object in the
+// cluster. The changes are the patch to apply to the live object to get the
+// rendered object, without the fields that are only in the live object, such
+// as the status or the defaults set by the API server
+type Drift struct {
+	Object  `json:",inline" yaml:",inline"`
+	Status  DriftStatus            `json:"status" yaml:"status"`
+	Changes map[string]interface{} `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// Diff compares every object in the resource.Result with the live object in
+// the cluster. The given labels, if any, are added to every object as they are
+// when the object is applied
+func (c *Client) Diff(r *resource.Result, labels map[string]string) ([]Drift, error) {
+	drifts := []Drift{}
+	err := r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := addLabels(info.Object, labels); err != nil {
+			return fmt.Errorf("labeling %s. %s", info.String(), err)
+		}
+
+		drift := Drift{
+			Object: objectFor(info),
+			Status: DriftInSync,
+		}
+
+		current, err := resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("retrieving current configuration of resource %s. %s", info.String(), err)
+			}
+			drift.Status = DriftMissing
+			drifts = append(drifts, drift)
+			return nil
+		}
+
+		changes, err := liveChanges(info, current)
+		if err != nil {
+			return fmt.Errorf("comparing resource %s. %s", info.String(), err)
+		}
+		if changes != nil {
+			drift.Status = DriftChanged
+			drift.Changes = changes
+		}
+		drifts = append(drifts, drift)
+		return nil
+	})
+	return drifts, err
+}
+
+// liveChanges returns the changes to apply to the live object to get the
+// rendered object, the same patch used to update it. Returns nil if every field
+// of the rendered object has the same value in the live object
+func liveChanges(info *resource.Info, current runtime.Object) (map[string]interface{}, error) {
+	patch, _, err := createPatch(info, current)
+	if err != nil || patch == nil {
+		return nil, err
+	}
+
+	var changes map[string]interface{}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+	clean, _ := withoutDeletions(changes)
+	changes, _ = clean.(map[string]interface{})
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	// a list in a merge patch is replaced entirely, and with a strategic patch
+	// the list elements may have only the merge key, so the rendered object is
+	// compared field by field with the live object
+	rendered, err := toJSONValue(info.Object)
+	if err != nil {
+		return nil, err
+	}
+	live, err := toJSONValue(current)
+	if err != nil {
+		return nil, err
+	}
+	if isSubset(rendered, live) {
+		return nil, nil
+	}
+
+	return changes, nil
+}
+
+func toJSONValue(obj runtime.Object) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+// withoutDeletions removes from the patch the fields to delete (null) and the
+// patch directives (i.e. `$setElementOrder`), these are the fields that are in
+// the live object but not in the rendered object. The list elements of a
+// strategic patch left with the merge key only are removed as well. Returns
+// true if something was removed
+func withoutDeletions(patch interface{}) (interface{}, bool) {
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		removed := false
+		clean := make(map[string]interface{}, len(p))
+		for k, v := range p {
+			if v == nil || strings.HasPrefix(k, "$") {
+				removed = true
+				continue
+			}
+			v, r := withoutDeletions(v)
+			removed = removed || r
+			if isEmpty(v, r) {
+				continue
+			}
+			clean[k] = v
+		}
+		return clean, removed
+	case []interface{}:
+		removed := false
+		clean := make([]interface{}, 0, len(p))
+		for _, v := range p {
+			v, r := withoutDeletions(v)
+			if m, ok := v.(map[string]interface{}); ok && r && len(m) <= 1 {
+				removed = true
+				continue
+			}
+			clean = append(clean, v)
+		}
+		return clean, removed
+	default:
+		return patch, false
+	}
+}
+
+// isEmpty returns true if the patch value is an empty map or a list that is
+// empty because its elements were removed
+func isEmpty(v interface{}, removed bool) bool {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return removed && len(value) == 0
+	default:
+		return false
+	}
+}
+
+// isSubset returns true if every field in a has the same value in b. The lists
+// should have the same length and every element of a is a subset of the element
+// in b with the same index. An empty map or list is a subset of a missing field
+func isSubset(a, b interface{}) bool {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if len(av) == 0 && b == nil {
+			return true
+		}
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range av {
+			if !isSubset(v, bv[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		if len(av) == 0 && b == nil {
+			return true
+		}
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !isSubset(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
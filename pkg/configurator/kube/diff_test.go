@@ -0,0 +1,92 @@
+package kube
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func deployment(replicas int64, container map[string]interface{}, status bool) *unstructured.Unstructured {
+	obj := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "app",
+			"namespace": "default",
+			"labels":    map[string]interface{}{"app": "app"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{container},
+				},
+			},
+		},
+	}
+	if status {
+		obj["metadata"].(map[string]interface{})["uid"] = "3c1f0a52"
+		obj["status"] = map[string]interface{}{"readyReplicas": replicas}
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func Test_liveChanges(t *testing.T) {
+	rendered := map[string]interface{}{"name": "app", "image": "app:1.0"}
+	live := map[string]interface{}{"name": "app", "image": "app:1.0", "terminationMessagePath": "/dev/termination-log"}
+	changed := map[string]interface{}{"name": "app", "image": "app:0.9", "terminationMessagePath": "/dev/termination-log"}
+
+	tests := []struct {
+		name    string
+		current *unstructured.Unstructured
+		want    map[string]interface{}
+	}{
+		{"in sync with defaults and status", deployment(2, live, true), nil},
+		{"replicas drifted", deployment(3, live, true), map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": float64(2)},
+		}},
+		{"image drifted", deployment(2, changed, false), map[string]interface{}{
+			"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{"name": "app", "image": "app:1.0"}},
+			}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &resource.Info{Object: deployment(2, rendered, false), Name: "app", Namespace: "default"}
+			got, err := liveChanges(info, tt.current)
+			if err != nil {
+				t.Fatalf("liveChanges() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("liveChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isSubset(t *testing.T) {
+	tests := []struct {
+		name string
+		a    interface{}
+		b    interface{}
+		want bool
+	}{
+		{"equal", map[string]interface{}{"a": "1"}, map[string]interface{}{"a": "1"}, true},
+		{"extra field", map[string]interface{}{"a": "1"}, map[string]interface{}{"a": "1", "b": "2"}, true},
+		{"different value", map[string]interface{}{"a": "1"}, map[string]interface{}{"a": "2"}, false},
+		{"missing field", map[string]interface{}{"a": "1", "b": "2"}, map[string]interface{}{"a": "1"}, false},
+		{"empty map", map[string]interface{}{"a": map[string]interface{}{}}, map[string]interface{}{}, true},
+		{"list length", []interface{}{"a"}, []interface{}{"a", "b"}, false},
+		{"list elements", []interface{}{map[string]interface{}{"a": "1"}}, []interface{}{map[string]interface{}{"a": "1", "b": "2"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSubset(tt.a, tt.b); got != tt.want {
+				t.Errorf("isSubset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
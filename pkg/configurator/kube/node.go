@@ -1,8 +1,16 @@
 package kube
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // Nodes returns the list of nodes
@@ -31,3 +39,131 @@ func (c *Client) NodesReady() (int, int, error) {
 
 	return readyCount, len(nodes.Items), nil
 }
+
+// NodeByAddress returns the name of the node with any of the given addresses
+// (IP or DNS) or name
+func (c *Client) NodeByAddress(addresses ...string) (string, error) {
+	nodes, err := c.Nodes()
+	if err != nil {
+		return "", err
+	}
+	for _, n := range nodes.Items {
+		for _, addr := range addresses {
+			if len(addr) == 0 {
+				continue
+			}
+			if n.Name == addr || strings.Split(addr, ".")[0] == n.Name {
+				return n.Name, nil
+			}
+			for _, a := range n.Status.Addresses {
+				if a.Address == addr {
+					return n.Name, nil
+				}
+			}
+		}
+	}
+
+	return "", fmt.Errorf("not found a node with any of the addresses %v", addresses)
+}
+
+// CordonNode marks the given node as unschedulable
+func (c *Client) CordonNode(name string) error {
+	node, err := c.clientset.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable {
+		return nil
+	}
+	node.Spec.Unschedulable = true
+	_, err = c.clientset.CoreV1().Nodes().Update(node)
+	return err
+}
+
+// UncordonNode marks the given node as schedulable
+func (c *Client) UncordonNode(name string) error {
+	node, err := c.clientset.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !node.Spec.Unschedulable {
+		return nil
+	}
+	node.Spec.Unschedulable = false
+	_, err = c.clientset.CoreV1().Nodes().Update(node)
+	return err
+}
+
+// DrainNode cordons the given node and evicts all the pods running on it,
+// except the pods managed by a DaemonSet and the mirror pods. It waits until
+// the evicted pods are gone or the timeout is reached
+func (c *Client) DrainNode(name string, timeout time.Duration) error {
+	if err := c.CordonNode(name); err != nil {
+		return err
+	}
+
+	listOpts := metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": name}).String(),
+	}
+	pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(listOpts)
+	if err != nil {
+		return err
+	}
+
+	evicted := 0
+	for _, pod := range pods.Items {
+		if !evictable(pod) {
+			continue
+		}
+		eviction := &policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		}
+		if err := c.clientset.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to evict pod %s/%s. %s", pod.Namespace, pod.Name, err)
+		}
+		evicted++
+	}
+	if evicted == 0 {
+		return nil
+	}
+
+	return wait.PollImmediate(5*time.Second, timeout, func() (bool, error) {
+		pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(listOpts)
+		if err != nil {
+			return false, err
+		}
+		for _, pod := range pods.Items {
+			if evictable(pod) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// DeleteNode removes the given node from the cluster
+func (c *Client) DeleteNode(name string) error {
+	err := c.clientset.CoreV1().Nodes().Delete(name, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// evictable returns true if the pod is not a mirror pod, it's not managed by a
+// DaemonSet and it's not completed
+func evictable(pod v1.Pod) bool {
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+	if ref := metav1.GetControllerOf(&pod); ref != nil && ref.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package kube
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// Object is a reference to an object in the cluster
+type Object struct {
+	APIVersion string `json:"apiVersion" yaml:"apiVersion"`
+	Kind       string `json:"kind" yaml:"kind"`
+	Namespace  string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name       string `json:"name" yaml:"name"`
+}
+
+func (o Object) String() string {
+	if len(o.Namespace) == 0 {
+		return fmt.Sprintf("%s %q", o.Kind, o.Name)
+	}
+	return fmt.Sprintf("%s %q on namespace %s", o.Kind, o.Name, o.Namespace)
+}
+
+func objectFor(info *resource.Info) Object {
+	gvk := info.Object.GetObjectKind().GroupVersionKind()
+	if info.Mapping != nil {
+		gvk = info.Mapping.GroupVersionKind
+	}
+	return Object{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  info.Namespace,
+		Name:       info.Name,
+	}
+}
+
+// Objects returns the reference to every object in the resource.Result
+func (c *Client) Objects(r *resource.Result) ([]Object, error) {
+	objects := []Object{}
+	err := r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objectFor(info))
+		return nil
+	})
+	return objects, err
+}
+
+// DeleteObject deletes the given object only if it has all the given labels.
+// Returns false if the object was not deleted because it does not exists or it
+// does not have the labels, so it's not owned by whom is deleting it
+func (c *Client) DeleteObject(obj Object, labels map[string]string) (bool, error) {
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		return false, err
+	}
+	mapper, err := c.Config.ToRESTMapper()
+	if err != nil {
+		return false, err
+	}
+	mapping, err := mapper.RESTMapping(gv.WithKind(obj.Kind).GroupKind(), gv.Version)
+	if err != nil {
+		// the kind is not served anymore, so the object cannot exists
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	client, err := c.Config.DynamicClient()
+	if err != nil {
+		return false, err
+	}
+	objClient := client.Resource(mapping.Resource).Namespace(obj.Namespace)
+
+	current, err := objClient.Get(obj.Name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	currentLabels := current.GetLabels()
+	for k, v := range labels {
+		if currentLabels[k] != v {
+			c.ui.Log.Debugf("the object %s does not have the label %s=%s, it won't be deleted", obj, k, v)
+			return false, nil
+		}
+	}
+
+	c.ui.Log.Debugf("deleting object %s", obj)
+	propagation := metav1.DeletePropagationBackground
+	if err := objClient.Delete(obj.Name, &metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// addLabels adds the given labels to the object, overwriting the existing
+// labels with the same key
+func addLabels(obj runtime.Object, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	objLabels := accessor.GetLabels()
+	if objLabels == nil {
+		objLabels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		objLabels[k] = v
+	}
+	accessor.SetLabels(objLabels)
+	return nil
+}
@@ -42,23 +42,51 @@ func (ae applyErrors) Error() string {
 	return fmt.Sprintf("creating the following resources: %s\n", ae.String())
 }
 
-// ApplyAll mimic the `kubectl apply` command to create or update all the resources in the list
-func (r *Resources) ApplyAll() error {
+// ApplyAll mimic the `kubectl apply` command to create or update all the resources in the list.
+// The applied objects are saved in the cluster inventory, and if `prune` is true
+// the objects previously applied that are no longer in the resources are deleted.
+// If there are charts to apply, the Helm binary is required before applying
+// any resource
+func (r *Resources) ApplyAll(prune bool) error {
+	if r.hasCharts() {
+		if _, err := lookHelm(); err != nil {
+			return err
+		}
+	}
+
 	errors := applyErrors{}
+	failed := map[string]bool{}
 
 	// DEBUG:
 	// r.ui.Log.Debugf("the following resources will be applied: %v", r.content)
 	for _, res := range r.Names() {
 		if _, ok := r.content[res]; !ok {
+			// it's not applied, so its objects are kept like a failed resource
 			r.ui.Log.Warnf("resource content for %q not found", res)
+			failed[res] = true
 			continue
 		}
-		if err := r.Apply(res); err != nil {
+		apply := r.Apply
+		if isChart(res) {
+			apply = r.ApplyChart
+		}
+		if err := apply(res); err != nil {
 			r.ui.Log.Errorf("failed applying resource %s. %v", res, err)
 			errors.Add(res, err)
+			failed[res] = true
 		}
 	}
 
+	if err := r.PruneCharts(); err != nil {
+		r.ui.Log.Errorf("failed uninstalling the removed charts. %v", err)
+		errors.Add("charts", err)
+	}
+
+	if err := r.updateInventory(failed, prune); err != nil {
+		r.ui.Log.Errorf("failed updating the inventory of the applied resources. %v", err)
+		errors.Add("inventory", err)
+	}
+
 	if errors.Empty() {
 		return nil
 	}
@@ -74,23 +102,12 @@ func (r *Resources) Apply(name string) (err error) {
 	var result *resource.Result
 	for l := 0; l < 6; time.Sleep(10 * time.Second) {
 		l++
-		switch {
-		case isFile(name):
-			if !isURL(name) {
-				name = strings.TrimPrefix(name, "file://")
-			}
-			filenames := []string{name}
-			result = r.kubeClient.ResultForFilenameParam(filenames, true)
-
-		default:
-			resContent, err := r.Render(name, "")
-			if err != nil {
-				return fmt.Errorf("failed rendering resource. %v", err)
-			}
-			result = r.kubeClient.ResultForContent(name, resContent, true)
+		result, err = r.result(name)
+		if err != nil {
+			return err
 		}
 
-		err = r.kubeClient.ApplyResource(result)
+		err = r.kubeClient.ApplyResource(result, r.ownerLabels())
 		if err != nil {
 			r.ui.Log.Debugf("received error during validation of apply, attempting retry: %s,", err)
 			continue
@@ -99,6 +116,11 @@ func (r *Resources) Apply(name string) (err error) {
 		exists, err := r.kubeClient.ExistsResource(result)
 		if exists {
 			// Resources applied and successfully found
+			objects, err := r.kubeClient.Objects(result)
+			if err != nil {
+				return fmt.Errorf("failed to get the applied objects. %v", err)
+			}
+			r.applied[name] = objects
 			return nil
 		}
 		if err != nil {
@@ -109,3 +131,21 @@ func (r *Resources) Apply(name string) (err error) {
 	}
 	return err
 }
+
+// result returns the builder results of the given resource, from the file or
+// URL, or rendering the template
+func (r *Resources) result(name string) (*resource.Result, error) {
+	if isFile(name) {
+		if !isURL(name) {
+			name = strings.TrimPrefix(name, "file://")
+		}
+		filenames := []string{name}
+		return r.kubeClient.ResultForFilenameParam(filenames, true), nil
+	}
+
+	resContent, err := r.Render(name, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed rendering resource. %v", err)
+	}
+	return r.kubeClient.ResultForContent(name, resContent, true), nil
+}
@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+)
+
+// ResourceDrift is the drift of the objects of a resource
+type ResourceDrift struct {
+	Resource string       `json:"resource" yaml:"resource"`
+	Objects  []kube.Drift `json:"objects" yaml:"objects"`
+}
+
+// Diff compares the objects of every resource in the list with the live objects
+// in the cluster. The objects in the cluster inventory that are no longer in
+// the resources are reported as orphans, these are deleted when the resources
+// are applied with prune. The charts are not compared
+func (r *Resources) Diff() ([]ResourceDrift, error) {
+	drifts := []ResourceDrift{}
+	rendered := inventory{}
+
+	for _, name := range r.Names() {
+		if _, ok := r.content[name]; !ok {
+			r.ui.Log.Warnf("resource content for %q not found", name)
+			continue
+		}
+		if isChart(name) {
+			r.ui.Log.Debugf("resource %s is a chart, it's not compared", name)
+			continue
+		}
+
+		result, err := r.result(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the objects of resource %s. %s", name, err)
+		}
+		objects, err := r.kubeClient.Diff(result, r.ownerLabels())
+		if err != nil {
+			return nil, fmt.Errorf("failed comparing resource %s. %s", name, err)
+		}
+		drifts = append(drifts, ResourceDrift{Resource: name, Objects: objects})
+		for _, obj := range objects {
+			rendered[name] = append(rendered[name], obj.Object)
+		}
+	}
+
+	_, previous, err := r.loadInventory()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the inventory of the applied resources. %s", err)
+	}
+	orphans := previous.orphans(rendered)
+	for _, name := range orphans.names() {
+		i := indexOfDrift(drifts, name)
+		if i < 0 {
+			drifts = append(drifts, ResourceDrift{Resource: name})
+			i = len(drifts) - 1
+		}
+		for _, obj := range orphans[name] {
+			drifts[i].Objects = append(drifts[i].Objects, kube.Drift{Object: obj, Status: kube.DriftOrphan})
+		}
+	}
+
+	return drifts, nil
+}
+
+func indexOfDrift(drifts []ResourceDrift, name string) int {
+	for i, d := range drifts {
+		if d.Resource == name {
+			return i
+		}
+	}
+	return -1
+}
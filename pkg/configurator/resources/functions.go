@@ -30,6 +30,19 @@ func init() {
 	}
 }
 
+// funcs returns the template functions for the resources, the images are looked
+// up in the manifest of the release in the `kubekitVersion` data, by default
+// the release of this KubeKit
+func (r *Resources) funcs() template.FuncMap {
+	release, ok := r.data["kubekitVersion"]
+	if !ok || len(release) == 0 {
+		release = manifest.Version
+	}
+	return template.FuncMap{
+		"manifestImg": manifestImgOf(release),
+	}
+}
+
 // publicKey read the given public key located in the given certificates
 // directory and platform
 func publicKey(certsPath, platform, certName string) (string, error) {
@@ -93,11 +106,19 @@ func unmarshallEFS(marshalled string) []config.ElasticFileshareData {
 
 // manifestImg looks up an image source in the release manifest
 func manifestImg(dependencyType, name string) string {
-	switch strings.ToLower(dependencyType) {
-	case "controlplane", "control_plane":
-		return manifest.KubeManifest.Releases[manifest.Version].Dependencies.ControlPlane[name].Src
-	case "core":
-		return manifest.KubeManifest.Releases[manifest.Version].Dependencies.Core[name].Src
+	return manifestImgOf(manifest.Version)(dependencyType, name)
+}
+
+// manifestImgOf returns the function to look up an image source in the
+// manifest of the given release
+func manifestImgOf(release string) func(dependencyType, name string) string {
+	return func(dependencyType, name string) string {
+		switch strings.ToLower(dependencyType) {
+		case "controlplane", "control_plane":
+			return manifest.KubeManifest.Releases[release].Dependencies.ControlPlane[name].Src
+		case "core":
+			return manifest.KubeManifest.Releases[release].Dependencies.Core[name].Src
+		}
+		return ""
 	}
-	return ""
 }
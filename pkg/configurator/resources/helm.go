@@ -0,0 +1,282 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HelmBinary is the Helm 3 binary used to install, upgrade and uninstall the
+// charts. The Helm SDK requires a newer version of the Kubernetes client than
+// the one used by KubeKit, so the charts are applied with the binary. It's
+// looked up in the PATH if it's not an absolute path
+var HelmBinary = "helm"
+
+// chartPrefix is the prefix of the resources that are a reference to a chart
+// in the list of charts of the cluster config
+const chartPrefix = "helm://"
+
+// chartsConfigMap is the name of the ConfigMap, in the kube-system namespace,
+// with the charts installed by KubeKit. It's used to uninstall the charts
+// removed from the cluster config
+const chartsConfigMap = "kubekit-charts"
+
+// Chart is a Helm chart to install as a cluster resource. The chart is the
+// name of the chart in the repository, an OCI reference (`oci://`) or a local
+// chart directory or archive, for offline use. The values are in YAML format
+// and they are a template rendered with the same data and functions of the
+// resource templates
+type Chart struct {
+	Chart     string `json:"chart" yaml:"chart" mapstructure:"chart"`
+	Repo      string `json:"repo,omitempty" yaml:"repo,omitempty" mapstructure:"repo"`
+	Version   string `json:"version,omitempty" yaml:"version,omitempty" mapstructure:"version"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty" mapstructure:"namespace"`
+	Values    string `json:"values,omitempty" yaml:"values,omitempty" mapstructure:"values"`
+}
+
+// ChartResource returns the resource name to reference the chart with the
+// given release name
+func ChartResource(release string) string {
+	return chartPrefix + release
+}
+
+func isChart(name string) bool {
+	return strings.HasPrefix(name, chartPrefix)
+}
+
+func chartRelease(name string) string {
+	return strings.TrimPrefix(name, chartPrefix)
+}
+
+func (c *Chart) namespace() string {
+	if len(c.Namespace) == 0 {
+		return "default"
+	}
+	return c.Namespace
+}
+
+func (c *Chart) isOCI() bool {
+	return strings.HasPrefix(c.Chart, "oci://")
+}
+
+func (c *Chart) isLocal() bool {
+	return len(c.Repo) == 0 && !c.isOCI() && (strings.HasPrefix(c.Chart, "/") || strings.HasPrefix(c.Chart, ".") || strings.HasPrefix(c.Chart, "file://"))
+}
+
+func (c *Chart) validate() error {
+	if len(c.Chart) == 0 {
+		return fmt.Errorf("the chart is required")
+	}
+	if c.isOCI() && len(c.Repo) != 0 {
+		return fmt.Errorf("the repository cannot be set for the OCI chart %q", c.Chart)
+	}
+	if c.isLocal() && len(c.Version) != 0 {
+		return fmt.Errorf("the version cannot be set for the local chart %q", c.Chart)
+	}
+	return nil
+}
+
+// AddCharts adds the given charts to the list of charts. The charts are
+// installed only if they are referenced in the list of resources
+func (r *Resources) AddCharts(charts map[string]*Chart) {
+	for release, chart := range charts {
+		r.charts[release] = chart
+	}
+}
+
+// renderValues renders the values template of the given chart release
+func (r *Resources) renderValues(release string, chart *Chart) ([]byte, error) {
+	var values bytes.Buffer
+
+	valuesTpl, err := template.
+		New(release).
+		Option("missingkey=error").
+		Funcs(tmplFuncMap).
+		Funcs(r.funcs()).
+		Parse(chart.Values)
+	if err != nil {
+		return nil, err
+	}
+	if err := valuesTpl.Execute(&values, r.data); err != nil {
+		return nil, err
+	}
+
+	return values.Bytes(), nil
+}
+
+// helmArgs returns the arguments for `helm upgrade --install` of the chart
+func (r *Resources) helmArgs(release string, chart *Chart, valuesFile string) []string {
+	chartRef := chart.Chart
+	if chart.isLocal() {
+		chartRef = strings.TrimPrefix(chartRef, "file://")
+	}
+
+	args := []string{"upgrade", release, chartRef, "--install", "--wait",
+		"--namespace", chart.namespace(), "--create-namespace",
+		"--kubeconfig", r.kubeClient.Config.KubeConfig,
+	}
+	if len(chart.Repo) != 0 {
+		args = append(args, "--repo", chart.Repo)
+	}
+	if len(chart.Version) != 0 {
+		args = append(args, "--version", chart.Version)
+	}
+	if len(valuesFile) != 0 {
+		args = append(args, "--values", valuesFile)
+	}
+
+	return args
+}
+
+// hasCharts returns true if any resource in the list is a chart
+func (r *Resources) hasCharts() bool {
+	for _, name := range r.order {
+		if isChart(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookHelm returns the path to the Helm binary or an error if it's not found
+func lookHelm() (string, error) {
+	path, err := exec.LookPath(HelmBinary)
+	if err != nil {
+		return "", fmt.Errorf("the Helm binary %q is required to apply the charts. %s", HelmBinary, err)
+	}
+	return path, nil
+}
+
+// helm executes the Helm binary with the given arguments. The execution is
+// killed when the context is done
+func (r *Resources) helm(args ...string) error {
+	helmPath, err := lookHelm()
+	if err != nil {
+		return err
+	}
+
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.ui.Log.Debugf("executing %s %s", helmPath, strings.Join(args, " "))
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, helmPath, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("helm %s was canceled. %s", args[0], ctx.Err())
+		}
+		return fmt.Errorf("failed to execute helm %s. %s. %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ApplyChart installs or upgrades the chart referenced by the given resource
+func (r *Resources) ApplyChart(name string) error {
+	release := chartRelease(name)
+	chart, ok := r.charts[release]
+	if !ok {
+		return fmt.Errorf("not found the chart %q in the list of charts", release)
+	}
+	if err := chart.validate(); err != nil {
+		return fmt.Errorf("invalid chart %q. %s", release, err)
+	}
+
+	var valuesFile string
+	if len(chart.Values) != 0 {
+		values, err := r.renderValues(release, chart)
+		if err != nil {
+			return fmt.Errorf("failed rendering the values of the chart %q. %s", release, err)
+		}
+		f, err := ioutil.TempFile("", "kubekit-"+release+"-values-*.yaml")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		_, err = f.Write(values)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		valuesFile = f.Name()
+	}
+
+	r.ui.Log.Infof("installing or upgrading the chart %s as release %s in the namespace %s", chart.Chart, release, chart.namespace())
+	return r.helm(r.helmArgs(release, chart, valuesFile)...)
+}
+
+// PruneCharts uninstalls the charts installed by KubeKit that are no longer in
+// the list of resources, then it saves the installed charts in the cluster
+func (r *Resources) PruneCharts() error {
+	clientset, err := r.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	configMaps := clientset.CoreV1().ConfigMaps("kube-system")
+
+	installed := map[string]string{}
+	for _, name := range r.order {
+		if !isChart(name) {
+			continue
+		}
+		release := chartRelease(name)
+		if chart, ok := r.charts[release]; ok {
+			installed[release] = chart.namespace()
+		}
+	}
+
+	cm, err := configMaps.Get(chartsConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		// nothing to prune or to save if KubeKit never installed a chart
+		if len(installed) == 0 {
+			return nil
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      chartsConfigMap,
+				Namespace: "kube-system",
+			},
+		}
+		if cm, err = configMaps.Create(cm); err != nil {
+			return err
+		}
+	}
+
+	removed := []string{}
+	for release := range cm.Data {
+		if _, ok := installed[release]; !ok {
+			removed = append(removed, release)
+		}
+	}
+	sort.Strings(removed)
+
+	for _, release := range removed {
+		namespace := cm.Data[release]
+		r.ui.Log.Infof("uninstalling the release %s from the namespace %s, the chart was removed from the resources", release, namespace)
+		if err := r.helm("uninstall", release, "--namespace", namespace, "--kubeconfig", r.kubeClient.Config.KubeConfig); err != nil {
+			// keep it to retry the next time
+			r.ui.Log.Errorf("failed to uninstall the release %s. %s", release, err)
+			installed[release] = namespace
+		}
+	}
+
+	cm.Data = installed
+	_, err = configMaps.Update(cm)
+	return err
+}
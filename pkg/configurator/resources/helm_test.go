@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+)
+
+func TestChart_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		chart   Chart
+		wantErr bool
+	}{
+		{"repo chart", Chart{Chart: "ingress-nginx", Repo: "https://kubernetes.github.io/ingress-nginx", Version: "2.0.0"}, false},
+		{"oci chart", Chart{Chart: "oci://registry.example.com/charts/app", Version: "1.0.0"}, false},
+		{"local chart", Chart{Chart: "/opt/charts/app"}, false},
+		{"no chart", Chart{Repo: "https://charts.example.com"}, true},
+		{"oci chart with repo", Chart{Chart: "oci://registry.example.com/charts/app", Repo: "https://charts.example.com"}, true},
+		{"local chart with version", Chart{Chart: "file:///opt/charts/app", Version: "1.0.0"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.chart.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Chart.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResources_helmArgs(t *testing.T) {
+	r := &Resources{
+		kubeClient: &kube.Client{Config: kube.NewConfig("", "/tmp/kubeconfig")},
+	}
+	tests := []struct {
+		name       string
+		chart      Chart
+		valuesFile string
+		want       []string
+	}{
+		{
+			"repo chart",
+			Chart{Chart: "ingress-nginx", Repo: "https://kubernetes.github.io/ingress-nginx", Version: "2.0.0", Namespace: "ingress"},
+			"/tmp/values.yaml",
+			[]string{"upgrade", "app", "ingress-nginx", "--install", "--wait", "--namespace", "ingress", "--create-namespace", "--kubeconfig", "/tmp/kubeconfig", "--repo", "https://kubernetes.github.io/ingress-nginx", "--version", "2.0.0", "--values", "/tmp/values.yaml"},
+		},
+		{
+			"local chart",
+			Chart{Chart: "file:///opt/charts/app"},
+			"",
+			[]string{"upgrade", "app", "/opt/charts/app", "--install", "--wait", "--namespace", "default", "--create-namespace", "--kubeconfig", "/tmp/kubeconfig"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.helmArgs("app", &tt.chart, tt.valuesFile); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resources.helmArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResources_renderValues(t *testing.T) {
+	r := &Resources{
+		data: map[string]string{"clusterName": "kubedemo"},
+	}
+	chart := &Chart{Chart: "app", Values: "cluster: {{ .clusterName }}\nsecret: {{ base64Encode \"s3cr3t\" }}\n"}
+
+	got, err := r.renderValues("app", chart)
+	if err != nil {
+		t.Fatalf("Resources.renderValues() error = %v", err)
+	}
+	want := "cluster: kubedemo\nsecret: czNjcjN0\n"
+	if string(got) != want {
+		t.Errorf("Resources.renderValues() = %q, want %q", got, want)
+	}
+
+	chart.Values = "region: {{ .region }}"
+	if _, err := r.renderValues("app", chart); err == nil {
+		t.Errorf("Resources.renderValues() expected an error with a missing key")
+	}
+}
+
+func TestResources_ApplyAllWithoutHelm(t *testing.T) {
+	defer func(helmBinary string) { HelmBinary = helmBinary }(HelmBinary)
+	HelmBinary = "kubekit-helm-not-found"
+
+	// without kubernetes client, it fails if anything is applied
+	r := &Resources{
+		order:   []string{"open-policy-agent", "helm://app"},
+		content: map[string]string{"open-policy-agent": "", "helm://app": ""},
+	}
+	if err := r.ApplyAll(false); err == nil {
+		t.Errorf("Resources.ApplyAll() expected an error without the Helm binary")
+	}
+}
@@ -0,0 +1,188 @@
+package resources
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Labels added to every object applied by KubeKit. An object is owned by the
+// cluster, and can be pruned, only if it has these labels
+const (
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ClusterLabel   = "kubekit.io/cluster"
+)
+
+// inventoryConfigMap is the name of the ConfigMap, in the kube-system
+// namespace, with the objects applied by KubeKit per resource. It's used to
+// find the objects that are no longer rendered by the resources
+const inventoryConfigMap = "kubekit-resources"
+
+// inventoryKey is the key in the inventory ConfigMap with the inventory in
+// JSON format. The resource names cannot be used as keys because they may be
+// URLs or file paths
+const inventoryKey = "inventory.json"
+
+// inventory is the list of objects applied per resource
+type inventory map[string][]kube.Object
+
+// objectKey identifies an object no matter the API version used to apply it,
+// an object may be served by more than one API group or version
+func objectKey(obj kube.Object) string {
+	return obj.Kind + "/" + obj.Namespace + "/" + obj.Name
+}
+
+// orphans returns the objects per resource in the inventory that are not in
+// the given inventory
+func (inv inventory) orphans(current inventory) inventory {
+	objects := map[string]struct{}{}
+	for _, objs := range current {
+		for _, obj := range objs {
+			objects[objectKey(obj)] = struct{}{}
+		}
+	}
+
+	orphans := inventory{}
+	for res, objs := range inv {
+		for _, obj := range objs {
+			if _, ok := objects[objectKey(obj)]; !ok {
+				orphans[res] = append(orphans[res], obj)
+			}
+		}
+	}
+	return orphans
+}
+
+// names returns the sorted resource names in the inventory
+func (inv inventory) names() []string {
+	names := make([]string, 0, len(inv))
+	for res := range inv {
+		names = append(names, res)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ownerLabels returns the labels added to every object applied to the cluster
+func (r *Resources) ownerLabels() map[string]string {
+	return map[string]string{
+		ManagedByLabel: "kubekit",
+		ClusterLabel:   r.data["clusterName"],
+	}
+}
+
+// loadInventory returns the inventory ConfigMap and the inventory in it. The
+// ConfigMap is nil if KubeKit never saved the inventory in the cluster
+func (r *Resources) loadInventory() (*corev1.ConfigMap, inventory, error) {
+	clientset, err := r.KubernetesClientSet()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inv := inventory{}
+	cm, err := clientset.CoreV1().ConfigMaps("kube-system").Get(inventoryConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, inv, nil
+		}
+		return nil, nil, err
+	}
+	if data, ok := cm.Data[inventoryKey]; ok {
+		if err := json.Unmarshal([]byte(data), &inv); err != nil {
+			return nil, nil, err
+		}
+	}
+	return cm, inv, nil
+}
+
+// saveInventory creates or updates the inventory ConfigMap with the given
+// inventory
+func (r *Resources) saveInventory(cm *corev1.ConfigMap, inv inventory) error {
+	clientset, err := r.KubernetesClientSet()
+	if err != nil {
+		return err
+	}
+	configMaps := clientset.CoreV1().ConfigMaps("kube-system")
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+
+	if cm == nil {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventoryConfigMap,
+				Namespace: "kube-system",
+				Labels:    r.ownerLabels(),
+			},
+			Data: map[string]string{inventoryKey: string(data)},
+		}
+		_, err = configMaps.Create(cm)
+		return err
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[inventoryKey] = string(data)
+	_, err = configMaps.Update(cm)
+	return err
+}
+
+// updateInventory saves in the cluster the objects applied per resource, the
+// objects of the resources that failed to apply are kept from the previous
+// inventory. The objects in the previous inventory that are no longer applied
+// are orphans, if `prune` is true the orphans owned by the cluster are deleted,
+// otherwise they are kept in the inventory to be pruned later
+func (r *Resources) updateInventory(failed map[string]bool, prune bool) error {
+	cm, previous, err := r.loadInventory()
+	if err != nil {
+		return err
+	}
+
+	current := inventory{}
+	for res, objs := range r.applied {
+		current[res] = objs
+	}
+	for res := range failed {
+		if objs, ok := previous[res]; ok {
+			if _, ok := current[res]; !ok {
+				current[res] = objs
+			}
+		}
+	}
+
+	orphans := previous.orphans(current)
+	for _, res := range orphans.names() {
+		for _, obj := range orphans[res] {
+			if !prune {
+				r.ui.Log.Warnf("the object %s of the resource %s is no longer applied, set 'prune_resources' to delete it", obj, res)
+				current[res] = append(current[res], obj)
+				continue
+			}
+			deleted, err := r.kubeClient.DeleteObject(obj, r.ownerLabels())
+			if err != nil {
+				// keep it to retry the next time
+				r.ui.Log.Errorf("failed to delete the object %s of the resource %s. %s", obj, res, err)
+				current[res] = append(current[res], obj)
+				continue
+			}
+			if deleted {
+				r.ui.Log.Infof("deleted the object %s, it's no longer applied by the resource %s", obj, res)
+			} else {
+				r.ui.Log.Debugf("the object %s of the resource %s was not found or is not owned by the cluster, it's removed from the inventory", obj, res)
+			}
+		}
+	}
+
+	// nothing to save if KubeKit never applied a resource
+	if cm == nil && len(current) == 0 {
+		return nil
+	}
+	return r.saveInventory(cm, current)
+}
@@ -0,0 +1,39 @@
+package resources
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+)
+
+func Test_inventory_orphans(t *testing.T) {
+	pspExtensions := kube.Object{APIVersion: "extensions/v1beta1", Kind: "PodSecurityPolicy", Name: "privileged"}
+	pspPolicy := kube.Object{APIVersion: "policy/v1beta1", Kind: "PodSecurityPolicy", Name: "privileged"}
+	restricted := kube.Object{APIVersion: "policy/v1beta1", Kind: "PodSecurityPolicy", Name: "restricted"}
+	quota := kube.Object{APIVersion: "v1", Kind: "ResourceQuota", Namespace: "default", Name: "quota"}
+	role := kube.Object{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role", Namespace: "default", Name: "app"}
+
+	previous := inventory{
+		"pod-security-policies": {pspExtensions, restricted},
+		"resource-quotas":       {quota},
+		"app":                   {role},
+	}
+	current := inventory{
+		"pod-security-policies": {pspPolicy},
+		// the role moved to another resource
+		"app-rbac": {role},
+	}
+
+	want := inventory{
+		"pod-security-policies": {restricted},
+		"resource-quotas":       {quota},
+	}
+	got := previous.orphans(current)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inventory.orphans() = %v, want %v", got, want)
+	}
+	if names := got.names(); !reflect.DeepEqual(names, []string{"pod-security-policies", "resource-quotas"}) {
+		t.Errorf("inventory.names() = %v", names)
+	}
+}
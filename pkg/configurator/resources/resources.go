@@ -2,6 +2,7 @@ package resources
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -16,12 +17,21 @@ import (
 
 // DefaultResourcesPerPlatform store the resources to create in a K8s cluster after
 // it's up and running. Some resources are applied on any platform, those are
-// under the name of "default", and some platforms require specific resources
+// under the name of "default", and some platforms require specific resources.
+// The CNI providers (`cni://`) are installed by the configurator, not applied
+// as resources, the providers of a platform replace the "default" providers and
+// the first one is the default provider of the platform
 var DefaultResourcesPerPlatform = map[string][]string{
 	"default": []string{
 		"open-policy-agent",
+		"cni://calico",
+		"cni://cilium",
+		"cni://flannel",
 	},
 	"aks": []string{
+		// the pods network of AKS is managed by the cloud provider, Calico is
+		// used only for the network policies
+		"cni://calico",
 		"azure-storage-classes",
 		"pod-security-policies",
 		"priority-classes",
@@ -32,6 +42,9 @@ var DefaultResourcesPerPlatform = map[string][]string{
 		"aks-acr-docker-secret",
 	},
 	"eks": []string{
+		// the pods network of EKS is managed by the cloud provider, Calico is
+		// used only for the network policies
+		"cni://calico",
 		"aws-auth",
 		"priority-classes",
 		"eks-calico",
@@ -64,6 +77,11 @@ var DefaultResourcesPerPlatform = map[string][]string{
 	"vra":       []string{},
 	"stacki":    []string{},
 	"openstack": []string{},
+	"azure": []string{
+		// the Azure network drops the IP-in-IP packets used by Calico
+		"cni://cilium",
+		"cni://flannel",
+	},
 }
 
 // // DefaultDataKeyMapping is a default map of state keys and data template
@@ -82,7 +100,10 @@ type Resources struct {
 	order      []string
 	content    map[string]string
 	data       map[string]string
+	charts     map[string]*Chart
+	applied    inventory
 	kubeClient *kube.Client
+	ctx        context.Context
 	ui         *ui.UI
 }
 
@@ -99,6 +120,8 @@ func New(kubeconfigPath string, ui *ui.UI) (*Resources, error) {
 		content:    make(map[string]string, 0),
 		kubeClient: kubeClient,
 		data:       data,
+		charts:     make(map[string]*Chart, 0),
+		applied:    inventory{},
 		ui:         ui,
 	}, nil
 }
@@ -116,21 +139,62 @@ func DefaultResourcesFor(platforms ...string) []string {
 	var resources = []string{}
 	platforms = append(platforms, "default")
 	for _, p := range platforms {
-		resources = append(resources, DefaultResourcesPerPlatform[p]...)
+		for _, res := range DefaultResourcesPerPlatform[p] {
+			if !isCNIProvider(res) {
+				resources = append(resources, res)
+			}
+		}
 	}
 	return resources
 }
 
+// CNIProvidersFor return the CNI providers supported on the given platform. The
+// first provider is the default one on the platform
+func CNIProvidersFor(platform string) []string {
+	providers := cniProviders(DefaultResourcesPerPlatform[platform])
+	if len(providers) == 0 {
+		providers = cniProviders(DefaultResourcesPerPlatform["default"])
+	}
+	return providers
+}
+
+func cniProviders(resources []string) []string {
+	providers := []string{}
+	for _, res := range resources {
+		if isCNIProvider(res) {
+			providers = append(providers, strings.TrimPrefix(res, cniPrefix))
+		}
+	}
+	return providers
+}
+
+const cniPrefix = "cni://"
+
+func isCNIProvider(res string) bool {
+	return strings.HasPrefix(res, cniPrefix)
+}
+
+// WithContext sets the context to cancel the execution of Helm
+func (r *Resources) WithContext(ctx context.Context) *Resources {
+	r.ctx = ctx
+	return r
+}
+
 // Names return the names of the resources loaded
 func (r *Resources) Names() []string {
 	return r.order
 }
 
-// AddResources adds the given list of resources to the list of resources.
+// AddResources adds the given list of resources to the list of resources. A
+// resource could be a template, a file, a URL or a chart reference (`helm://`).
 // Returns an error if it's a template resource that does not exists
 func (r *Resources) AddResources(resources []string) error {
 	for _, res := range resources {
 		switch {
+		case isChart(res):
+			r.ui.Log.Debugf("loaded chart %q", chartRelease(res))
+			r.content[res] = ""
+			r.order = append(r.order, res)
 		case isFile(res):
 			r.ui.Log.Debugf("loaded resource in file %q", res)
 			r.content[res] = ""
@@ -211,6 +275,7 @@ func (r *Resources) Render(name string, filename string) ([]byte, error) {
 		New(name).
 		Option("missingkey=error").
 		Funcs(tmplFuncMap).
+		Funcs(r.funcs()).
 		Parse(codeTemplate)
 	if err != nil {
 		return nil, err
@@ -262,6 +327,10 @@ func (r *Resources) Export(exportDir string) error {
 			r.ui.Log.Debugf("resource %s is a file (local or remote)", name)
 			continue
 		}
+		if isChart(name) {
+			r.ui.Log.Debugf("resource %s is a chart", name)
+			continue
+		}
 
 		filename := filepath.Join(exportDir, name+".yaml")
 
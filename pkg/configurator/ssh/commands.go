@@ -86,6 +86,65 @@ func (c *Config) Shell(inReader io.Reader, outWriter, errWriter io.Writer) error
 	// }
 }
 
+// ShellSession starts a remote shell reading the input from the given reader
+// instead of a terminal, like when the same input is sent to many hosts. The
+// pseudo terminal doesn't echo the input, so it's not repeated by every host.
+// It waits until the remote shell exits, the reader may not be consumed after
+// it returns
+func (c *Config) ShellSession(inReader io.Reader, outWriter, errWriter io.Writer) error {
+	err := c.setClient()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	session, err := c.client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	go keepAlive(session)
+
+	session.Stdout = outWriter
+	session.Stderr = errWriter
+	// the input is copied in a goroutine not waited by the session, so it exits
+	// when the remote shell does even if there is no more input
+	in, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	go func() {
+		io.Copy(in, inReader)
+		in.Close()
+	}()
+
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          0, // Don't print what I type, every host would print it
+		ssh.TTY_OP_ISPEED: 115200,
+		ssh.TTY_OP_OSPEED: 115200,
+	}
+	if err := session.RequestPty("dumb", 40, 200, modes); err != nil {
+		return fmt.Errorf("request for pseudo terminal failed: %s", err)
+	}
+
+	if err := session.Shell(); err != nil {
+		return fmt.Errorf("failed to start shell: %s", err)
+	}
+
+	defer c.killOnDone(session)()
+
+	err = session.Wait()
+	if errC := c.canceled(); errC != nil {
+		return errC
+	}
+	if _, ok := err.(*ssh.ExitError); ok {
+		// the exit status of the last command executed, not a session failure
+		return nil
+	}
+	return err
+}
+
 func isTerminal(r io.Reader) (int, bool) {
 	switch v := r.(type) {
 	case *os.File:
@@ -145,15 +204,30 @@ func (c *Config) Start(cmd *Command) error {
 
 // StartAndWait initiates the connection, runs command on remote host and waits for output
 func (c *Config) StartAndWait(cmd *Command) error {
+	exitStatus, err := c.run(cmd.Command, &cmd.Stdout, &cmd.Stderr)
+	cmd.ExitStatus = exitStatus
+	return err
+}
+
+// StreamAndWait initiates the connection, runs command on remote host writing
+// the output to the given writers as it's received, and waits for it to exit
+func (c *Config) StreamAndWait(command string, stdout, stderr io.Writer) (exitStatus int, err error) {
+	defer c.Close()
+	return c.run(command, stdout, stderr)
+}
+
+// run runs the command on the remote host with the given output writers and
+// returns the command exit status
+func (c *Config) run(command string, stdout, stderr io.Writer) (int, error) {
 	err := c.setClient()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// Testing:
 	session, err := c.client.NewSession()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer session.Close()
 
@@ -161,24 +235,48 @@ func (c *Config) StartAndWait(cmd *Command) error {
 	// a session timeout set.
 	go keepAlive(session)
 
-	session.Stdout = &cmd.Stdout
-	session.Stderr = &cmd.Stderr
+	session.Stdout = stdout
+	session.Stderr = stderr
 
-	if err = session.Start(cmd.Command + "\n"); err != nil {
-		return err
+	if err = session.Start(command + "\n"); err != nil {
+		return 0, err
 	}
 
+	// kill the remote command if the context is done before it finish
+	defer c.killOnDone(session)()
+
 	err = session.Wait()
+	if errC := c.canceled(); errC != nil {
+		return 0, errC
+	}
 	if err != nil {
 		switch err.(type) {
 		case *ssh.ExitError:
-			cmd.ExitStatus = err.(*ssh.ExitError).ExitStatus()
+			return err.(*ssh.ExitError).ExitStatus(), nil
 		default:
-			return err
+			return 0, err
 		}
 	}
 
-	return nil
+	return 0, nil
+}
+
+// killOnDone kills the session if the context is done before the returned
+// function is called
+func (c *Config) killOnDone(session *ssh.Session) func() {
+	if c.ctx == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			session.Signal(ssh.SIGKILL)
+			session.Close()
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
 }
 
 // Exec executes a command in the remote host
@@ -0,0 +1,90 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Mux multiplexes the output of the commands executed in many hosts at same
+// time, every line is written prefixed with the host it comes from and the
+// lines of different hosts are never mixed
+type Mux struct {
+	mu    sync.Mutex
+	width int
+}
+
+// NewMux returns a multiplexer for the output of the given hosts, used to align
+// the prefix of every line
+func NewMux(hosts ...string) *Mux {
+	m := &Mux{}
+	for _, host := range hosts {
+		if len(host) > m.width {
+			m.width = len(host)
+		}
+	}
+	return m
+}
+
+// Writer returns a writer to send to w the output of the given host
+func (m *Mux) Writer(w io.Writer, host string) *PrefixWriter {
+	return &PrefixWriter{
+		mux:    m,
+		w:      w,
+		prefix: []byte(fmt.Sprintf("%-*s | ", m.width, host)),
+	}
+}
+
+// Printf writes to w a line with the prefix of the given host
+func (m *Mux) Printf(w io.Writer, host string, format string, a ...interface{}) {
+	pw := m.Writer(w, host)
+	fmt.Fprintf(pw, format, a...)
+	pw.Flush()
+}
+
+// PrefixWriter writes complete lines prefixed with the host they come from.
+// The carriage returns sent by a pseudo terminal are removed
+type PrefixWriter struct {
+	mux    *Mux
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+// Write buffers the data and writes every complete line. It never fails, the
+// errors writing to the underlying writer are ignored to not kill the remote
+// command
+func (pw *PrefixWriter) Write(p []byte) (int, error) {
+	pw.buf = append(pw.buf, p...)
+	for {
+		i := bytes.IndexByte(pw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		pw.writeLine(pw.buf[:i])
+		pw.buf = pw.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line if it doesn't end with a new line
+func (pw *PrefixWriter) Flush() {
+	if len(pw.buf) == 0 {
+		return
+	}
+	pw.writeLine(pw.buf)
+	pw.buf = nil
+}
+
+func (pw *PrefixWriter) writeLine(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	out := make([]byte, 0, len(pw.prefix)+len(line)+1)
+	out = append(out, pw.prefix...)
+	out = append(out, line...)
+	out = append(out, '\n')
+
+	pw.mux.mu.Lock()
+	defer pw.mux.mu.Unlock()
+	pw.w.Write(out)
+}
@@ -0,0 +1,59 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestMux_Writer(t *testing.T) {
+	var out bytes.Buffer
+	mux := NewMux("10.0.0.1", "10.0.0.10")
+
+	w1 := mux.Writer(&out, "10.0.0.1")
+	w2 := mux.Writer(&out, "10.0.0.10")
+
+	w1.Write([]byte("hello "))
+	w2.Write([]byte("first\r\nsec"))
+	w1.Write([]byte("world\n"))
+	w2.Write([]byte("ond"))
+	w2.Flush()
+	w1.Flush()
+
+	want := "10.0.0.10 | first\n" +
+		"10.0.0.1  | hello world\n" +
+		"10.0.0.10 | second\n"
+	if got := out.String(); got != want {
+		t.Errorf("Mux.Writer() output = %q, want %q", got, want)
+	}
+}
+
+func TestMux_Concurrent(t *testing.T) {
+	var out bytes.Buffer
+	hosts := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	mux := NewMux(hosts...)
+
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			w := mux.Writer(&out, host)
+			for i := 0; i < 100; i++ {
+				fmt.Fprintf(w, "line %03d\n", i)
+			}
+		}(host)
+	}
+	wg.Wait()
+
+	lines := bytes.Split(bytes.TrimSuffix(out.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 300 {
+		t.Fatalf("Mux.Writer() wrote %d lines, want 300", len(lines))
+	}
+	for _, line := range lines {
+		if len(line) != len("10.0.0.1 | line 000") {
+			t.Errorf("Mux.Writer() mixed the lines of the hosts: %q", line)
+		}
+	}
+}
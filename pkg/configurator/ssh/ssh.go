@@ -1,6 +1,7 @@
 package ssh
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -12,6 +13,7 @@ type Config struct {
 	Address string
 	config  *ssh.ClientConfig
 	client  *ssh.Client
+	ctx     context.Context
 }
 
 // New returns an instance of the SSH configuration
@@ -61,6 +63,29 @@ func New(username, address, privateKey, password string) (*Config, error) {
 	}, nil
 }
 
+// SetContext sets the context to cancel the commands in execution and to
+// prevent new commands to be executed once the context is done
+func (c *Config) SetContext(ctx context.Context) {
+	c.ctx = ctx
+}
+
+// WithoutContext returns a copy of this SSH configuration without context. It's
+// used to execute commands, such as a cleanup, after the context is done
+func (c *Config) WithoutContext() *Config {
+	return &Config{
+		Address: c.Address,
+		config:  c.config,
+	}
+}
+
+// canceled returns the context error if the context is done
+func (c *Config) canceled() error {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
 func publicKey(privateKey string) (ssh.AuthMethod, error) {
 	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
 	if err != nil {
@@ -70,6 +95,11 @@ func publicKey(privateKey string) (ssh.AuthMethod, error) {
 }
 
 func (c *Config) setClient() error {
+	// do not open new connections once the context is done
+	if err := c.canceled(); err != nil {
+		return err
+	}
+
 	if c.client != nil {
 		c.client.Close()
 		// return nil
@@ -0,0 +1,259 @@
+package configurator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator/ssh"
+)
+
+// StreamOptions are the options to execute a command on many hosts streaming
+// the output of every host
+type StreamOptions struct {
+	// Parallel is the maximum number of hosts executing the command at same
+	// time. Zero or negative is no limit
+	Parallel int
+	// Timeout is the maximum time the command can run on every host. Zero is no
+	// timeout
+	Timeout time.Duration
+	// FailFast stops the execution on every host once the command fails on one
+	// of them, the hosts waiting to execute it are skipped
+	FailFast bool
+}
+
+// context returns the context of the command or the background context if the
+// command doesn't have one
+func (c *Command) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// hostNames returns the public IP of every command host, used to prefix the
+// output of the host
+func (c *Command) hostNames() []string {
+	names := make([]string, 0, len(c.Hosts))
+	for _, host := range c.Hosts {
+		names = append(names, host.PublicIP)
+	}
+	return names
+}
+
+// ExecStream executes a script file or command line on every command host, like
+// Exec, but the output is written to out and errOut as it's received, every
+// line prefixed with the host it comes from. The returned result has the exit
+// status of every host but not the output
+func (c *Command) ExecStream(command, script string, sudoExec bool, out, errOut io.Writer, opts StreamOptions) (*ssh.CommandResult, error) {
+	var result ssh.CommandResult
+	result.Hosts = ssh.NewHostCommandResultMap()
+
+	execCmd, content, targetFile, err := execCommandLine(command, script, sudoExec)
+	if err != nil {
+		return &result, err
+	}
+	if len(script) != 0 {
+		command = targetFile
+	}
+
+	parallel := opts.Parallel
+	if parallel <= 0 || parallel > len(c.Hosts) {
+		parallel = len(c.Hosts)
+	}
+
+	ctx, cancel := context.WithCancel(c.context())
+	defer cancel()
+
+	mux := ssh.NewMux(c.hostNames()...)
+	sem := make(chan struct{}, parallel)
+
+	var mu sync.Mutex
+	errMsg := []string{}
+	skipped := []string{}
+	handleError := func(host Host, err error, msg string, a ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		errMsg = append(errMsg, fmt.Sprintf("%s (%s) %s", host.PublicIP, err, fmt.Sprintf(msg, a...)))
+	}
+
+	var wg sync.WaitGroup
+	for i, host := range c.Hosts {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// the context may be done while waiting for a free slot, or after
+		// getting it, if so, the remaining hosts are skipped
+		if ctx.Err() != nil {
+			for _, h := range c.Hosts[i:] {
+				skipped = append(skipped, h.PublicIP)
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(host Host) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			hostCtx := ctx
+			if opts.Timeout > 0 {
+				var cancelHost context.CancelFunc
+				hostCtx, cancelHost = context.WithTimeout(ctx, opts.Timeout)
+				defer cancelHost()
+			}
+			sshConf := host.ssh.WithoutContext()
+			sshConf.SetContext(hostCtx)
+			defer sshConf.Close()
+
+			stdout := mux.Writer(out, host.PublicIP)
+			stderr := mux.Writer(errOut, host.PublicIP)
+			start := time.Now()
+
+			exitStat, err := c.streamIn(sshConf, execCmd, content, targetFile, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+
+			switch {
+			case err == context.DeadlineExceeded && ctx.Err() == nil:
+				// only this host context is done, the command timed out
+				err = fmt.Errorf("timed out after %s", opts.Timeout)
+				handleError(host, err, "failed to execute command %q at host %s", execCmd, host.PublicIP)
+				mux.Printf(errOut, host.PublicIP, "%s", err)
+			case err == context.Canceled:
+				handleError(host, err, "command %q canceled at host %s", execCmd, host.PublicIP)
+				mux.Printf(errOut, host.PublicIP, "canceled")
+			case err != nil:
+				handleError(host, err, "failed to execute command %q at host %s", execCmd, host.PublicIP)
+				mux.Printf(errOut, host.PublicIP, "failed to execute the command. %s", err)
+			default:
+				mux.Printf(errOut, host.PublicIP, "exit status %d (%s)", exitStat, time.Since(start).Round(time.Millisecond))
+			}
+
+			result.Hosts.Store(host.PublicIP, &ssh.HostCommandResult{
+				ExitStatus: exitStat,
+			})
+
+			if err == nil && exitStat == 0 {
+				atomic.AddUint32(&result.Success, 1)
+				return
+			}
+			atomic.AddUint32(&result.Failures, 1)
+			if opts.FailFast {
+				cancel()
+			}
+		}(host)
+	}
+	wg.Wait()
+
+	msgs := []string{}
+	if len(errMsg) != 0 {
+		msgs = append(msgs, fmt.Sprintf("failed to execute the command %q at the following hosts: %s", command, strings.Join(errMsg, ", ")))
+	}
+	if len(skipped) != 0 {
+		msgs = append(msgs, fmt.Sprintf("the command %q was not executed at the following hosts: %s", command, strings.Join(skipped, ", ")))
+	}
+	if len(msgs) != 0 {
+		err = fmt.Errorf("%s", strings.Join(msgs, ". "))
+	}
+	return &result, err
+}
+
+// streamIn copies the script, if any, and executes the command in the host of
+// the given SSH configuration
+func (c *Command) streamIn(sshConf *ssh.Config, execCmd string, content []byte, targetFile string, stdout, stderr io.Writer) (int, error) {
+	if len(content) != 0 {
+		if err := sshConf.CreateFile(targetFile, string(content), 0700); err != nil {
+			return 0, fmt.Errorf("failed to copy the script %q. %s", targetFile, err)
+		}
+	}
+	return sshConf.StreamAndWait(execCmd, stdout, stderr)
+}
+
+// Console opens a shell on every command host and sends to all of them every
+// line read from in, like synchronized terminals. The output of every host is
+// written to out and errOut prefixed with the host it comes from. It returns
+// when every remote shell exits, which happens when in is closed or when the
+// `exit` command is sent
+func (c *Command) Console(in io.Reader, out, errOut io.Writer) error {
+	if len(c.Hosts) == 0 {
+		return fmt.Errorf("there are no hosts to open a console")
+	}
+
+	mux := ssh.NewMux(c.hostNames()...)
+
+	inputs := make([]*io.PipeWriter, len(c.Hosts))
+	var mu sync.Mutex
+	errMsg := []string{}
+
+	var wg sync.WaitGroup
+	wg.Add(len(c.Hosts))
+	for i, host := range c.Hosts {
+		pr, pw := io.Pipe()
+		inputs[i] = pw
+
+		go func(host Host, pr *io.PipeReader) {
+			defer wg.Done()
+			// once the shell exits the input is not read anymore, closing it
+			// prevents the broadcast to block
+			defer pr.Close()
+
+			stdout := mux.Writer(out, host.PublicIP)
+			stderr := mux.Writer(errOut, host.PublicIP)
+			err := host.ssh.ShellSession(pr, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+
+			if err != nil {
+				mux.Printf(errOut, host.PublicIP, "console closed. %s", err)
+				mu.Lock()
+				errMsg = append(errMsg, fmt.Sprintf("%s (%s)", host.PublicIP, err))
+				mu.Unlock()
+				return
+			}
+			mux.Printf(errOut, host.PublicIP, "console closed")
+		}(host, pr)
+	}
+
+	// broadcast every line to the hosts with an open console, the input is
+	// closed when there is no more input or all the consoles are closed
+	go func() {
+		defer func() {
+			for _, pw := range inputs {
+				pw.Close()
+			}
+		}()
+
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) != 0 {
+				open := 0
+				for _, pw := range inputs {
+					if _, errW := pw.Write(line); errW == nil {
+						open++
+					}
+				}
+				if open == 0 {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if len(errMsg) != 0 {
+		return fmt.Errorf("failed to open or keep the console on the following hosts: %s", strings.Join(errMsg, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+#!/usr/bin/python
+
+class FilterModule(object):
+
+    def filters(self):
+        return {
+            'normalized_image': self.normalized_image
+        }
+
+    # normalized_image returns the full name of the image as it's stored by
+    # containerd, i.e. 'registry:2' is 'docker.io/library/registry:2'
+    def normalized_image(self, name):
+        parts = name.split('/', 1)
+        if len(parts) == 1 or ('.' not in parts[0] and ':' not in parts[0] and parts[0] != 'localhost'):
+            domain, remainder = 'docker.io', name
+        else:
+            domain, remainder = parts[0], parts[1]
+        if domain == 'docker.io' and '/' not in remainder:
+            remainder = 'library/' + remainder
+        return domain + '/' + remainder
@@ -101,6 +101,17 @@ func New(key []byte) (*Crypto, error) {
 	}, nil
 }
 
+// EnvKey returns the key in the environment variable defined on 'EnvKeyName'
+// constant. Unlike New(nil), it does not fall back to the default passphrase, use
+// it to encrypt data that must not be decrypted with a well known key
+func EnvKey() ([]byte, error) {
+	keyStr := os.Getenv(EnvKeyName)
+	if len(keyStr) == 0 {
+		return nil, fmt.Errorf("the KubeKit key is not set in the environment variable %s", EnvKeyName)
+	}
+	return []byte(keyStr), nil
+}
+
 // getKey get the passphrase or key from the environment variables defined on
 // 'EnvKeyName' constant
 func getKey() ([]byte, error) {
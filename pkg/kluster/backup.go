@@ -0,0 +1,357 @@
+package kluster
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	"github.com/liferaft/kubekit/pkg/manifest"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// BackupsDirname is the name of the directory in the cluster directory where
+// the etcd backups are stored
+const BackupsDirname = "backups"
+
+// DefaultRestoreTimeout is the default time to wait for the cluster to be ready
+// after an etcd snapshot is restored
+const DefaultRestoreTimeout = 10 * time.Minute
+
+// Backup is the metadata of an etcd snapshot stored in the cluster directory
+type Backup struct {
+	Name        string    `json:"name" yaml:"name" mapstructure:"name"`
+	ClusterName string    `json:"cluster_name" yaml:"cluster_name" mapstructure:"cluster_name"`
+	Node        string    `json:"node" yaml:"node" mapstructure:"node"`
+	Release     string    `json:"release" yaml:"release" mapstructure:"release"`
+	EtcdVersion string    `json:"etcd_version" yaml:"etcd_version" mapstructure:"etcd_version"`
+	Size        int64     `json:"size" yaml:"size" mapstructure:"size"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at" mapstructure:"created_at"`
+	Path        string    `json:"path" yaml:"path" mapstructure:"path"`
+}
+
+// Scripts executed on the master nodes to restore an etcd snapshot. The etcd
+// and API server static pods are stopped moving their manifests to another
+// directory, then every etcd member is rebuilt from the snapshot with the name,
+// peers and token of its manifest, finally the static pods are started again
+const (
+	restoreManifestsDir = "/etc/kubernetes/manifests-restore"
+
+	restoreStopScript = `set -e
+mkdir -p ` + restoreManifestsDir + `
+for m in etcd kube-apiserver; do
+  if [ -f /etc/kubernetes/manifests/$m.yaml ]; then
+    mv /etc/kubernetes/manifests/$m.yaml ` + restoreManifestsDir + `/
+  fi
+done
+for i in $(seq 60); do
+  pgrep -x etcd >/dev/null || exit 0
+  sleep 5
+done
+echo "etcd is still running after 5 minutes" >&2
+exit 1
+`
+
+	restoreSnapshotScript = `set -e
+snapshot=%s
+manifest=` + restoreManifestsDir + `/etcd.yaml
+HOSTNAME=$(hostname)
+name=$(sed -n 's/^ *--name=//p' $manifest | head -1)
+name=$(eval "echo $name")
+peers=$(grep -A1 'name: CLUSTER_PEERS' $manifest | sed -n 's/^ *value: "\(.*\)"/\1/p')
+token=$(sed -n 's/^ *--initial-cluster-token=//p' $manifest | head -1)
+data_dir=$(sed -n 's/^ *--data-dir=//p' $manifest | head -1)
+peer_url=$(echo "$peers" | tr ',' '\n' | sed -n "s|^$name=||p")
+if [ -z "$name" ] || [ -z "$peer_url" ] || [ -z "$data_dir" ]; then
+  echo "not found the etcd member of this node in $manifest" >&2
+  exit 1
+fi
+rm -rf $data_dir.restore
+ETCDCTL_API=3 /etc/kubernetes/bin/etcdctl snapshot restore $snapshot --name $name --initial-cluster $peers --initial-cluster-token $token --initial-advertise-peer-urls $peer_url --data-dir $data_dir.restore
+rm -rf $data_dir/member.bkp
+if [ -d $data_dir/member ]; then
+  mv $data_dir/member $data_dir/member.bkp
+fi
+mv $data_dir.restore/member $data_dir/member
+rm -rf $data_dir.restore $snapshot
+`
+
+	restoreStartScript = `set -e
+if [ -d ` + restoreManifestsDir + ` ]; then
+  mv ` + restoreManifestsDir + `/*.yaml /etc/kubernetes/manifests/
+  rmdir ` + restoreManifestsDir + `
+fi
+`
+)
+
+// BackupsDir returns the directory where the etcd backups of the cluster are
+func (k *Kluster) BackupsDir() string {
+	return filepath.Join(k.Dir(), BackupsDirname)
+}
+
+// Backup takes a snapshot of etcd on a master node and stores it, with its
+// metadata, in the backups directory of the cluster
+func (k *Kluster) Backup() (*Backup, error) {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	if err := k.LoadState(); err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now().UTC()
+	name := fmt.Sprintf("snapshot-%s", createdAt.Format("2006-01-02T15-04-05Z"))
+
+	master, remoteFile, err := k.SnapshotEtcd(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the snapshots directory is only accessible by root, the snapshot is copied
+	// to a temporal location readable by the user to get it
+	tmpFile := filepath.Join("/tmp", filepath.Base(remoteFile))
+	cmdCopy := fmt.Sprintf("sh -c 'cp %s %s && chmod 0644 %s'", remoteFile, tmpFile, tmpFile)
+	if err := k.execOnHost(master, cmdCopy, true); err != nil {
+		return nil, fmt.Errorf("failed to prepare the etcd snapshot to download. %s", err)
+	}
+	defer k.execOnHost(master, "rm -f "+tmpFile, true)
+
+	backupsDir := k.BackupsDir()
+	if err := os.MkdirAll(backupsDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the backups directory %q. %s", backupsDir, err)
+	}
+	localFile := filepath.Join(backupsDir, name+".db")
+
+	c, err := k.newCommandFor([]string{master.PublicIP}, nil)
+	if err != nil {
+		return nil, err
+	}
+	size, err := c.GetFile(tmpFile, localFile, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := k.Release()
+	if err != nil {
+		k.ui.Log.Warnf("the release of the cluster is unknown. %s", err)
+	}
+	var etcdVersion string
+	if r, ok := manifest.KubeManifest.Releases[release]; ok {
+		etcdVersion = r.EtcdVersion
+	}
+
+	backup := &Backup{
+		Name:        name,
+		ClusterName: k.Name,
+		Node:        master.PublicIP,
+		Release:     release,
+		EtcdVersion: etcdVersion,
+		Size:        size,
+		CreatedAt:   createdAt,
+		Path:        localFile,
+	}
+
+	backupB, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(backupsDir, name+".json"), backupB, 0600); err != nil {
+		return nil, fmt.Errorf("failed to save the metadata of the backup %s. %s", name, err)
+	}
+
+	k.ui.Log.Infof("etcd backup %s of %d bytes stored at %s", name, size, localFile)
+
+	return backup, nil
+}
+
+// Backups returns the etcd backups of the cluster, the newest first
+func (k *Kluster) Backups() ([]*Backup, error) {
+	files, err := filepath.Glob(filepath.Join(k.BackupsDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []*Backup{}
+	for _, file := range files {
+		backupB, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		backup := Backup{}
+		if err := json.Unmarshal(backupB, &backup); err != nil {
+			return nil, fmt.Errorf("failed to read the backup metadata %q. %s", file, err)
+		}
+		backups = append(backups, &backup)
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// GetBackup returns the etcd backup of the cluster with the given name. If the
+// name is "latest" returns the newest backup
+func (k *Kluster) GetBackup(name string) (*Backup, error) {
+	backups, err := k.Backups()
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("the cluster %q does not have backups", k.Name)
+	}
+	if name == "latest" {
+		return backups[0], nil
+	}
+	for _, backup := range backups {
+		if backup.Name == name || backup.Name+".db" == name {
+			return backup, nil
+		}
+	}
+
+	return nil, fmt.Errorf("not found the backup %q of the cluster %q", name, k.Name)
+}
+
+// Restore restores the etcd snapshot `from` on every master node, rebuilding
+// the etcd members. The snapshot is the name of a backup of the cluster or the
+// path to a snapshot file. All the etcd members and API servers are stopped
+// during the restore, then it waits for the cluster to be ready
+func (k *Kluster) Restore(from string, timeout time.Duration) error {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	switch platformName {
+	case "eks", "aks":
+		return fmt.Errorf("the %s platform does not support to restore etcd, it's managed by the platform", platformName)
+	}
+
+	snapshotFile := from
+	if _, err := os.Stat(snapshotFile); err != nil {
+		backup, err := k.GetBackup(from)
+		if err != nil {
+			return err
+		}
+		snapshotFile = backup.Path
+	}
+	if _, err := os.Stat(snapshotFile); err != nil {
+		return fmt.Errorf("not found the etcd snapshot file %q. %s", snapshotFile, err)
+	}
+
+	if err := k.LoadState(); err != nil {
+		return err
+	}
+	masters := k.HostsFilterBy(nil, []string{"master"})
+	if len(masters) == 0 {
+		return fmt.Errorf("not found master nodes in the cluster %q", k.Name)
+	}
+	mastersIP := []string{}
+	for _, master := range masters {
+		mastersIP = append(mastersIP, master.PublicIP)
+	}
+
+	k.ui.Log.Infof("uploading the etcd snapshot %s to the master nodes", snapshotFile)
+	if err := k.CopyFile(snapshotFile, ":/tmp", mastersIP, nil, true, false, false, "", "", "0644"); err != nil {
+		return err
+	}
+	remoteFile := filepath.Join("/tmp", filepath.Base(snapshotFile))
+
+	k.ui.Log.Infof("stopping etcd and the API server on the master nodes")
+	if err := k.execScript(mastersIP, restoreStopScript); err != nil {
+		k.execScript(mastersIP, restoreStartScript)
+		return fmt.Errorf("failed to stop etcd. %s", err)
+	}
+
+	k.ui.Log.Infof("restoring the etcd members from the snapshot")
+	errRestore := k.execScript(mastersIP, fmt.Sprintf(restoreSnapshotScript, remoteFile))
+
+	// etcd is started again even if the restore failed, the members that were
+	// not restored keep their previous data
+	k.ui.Log.Infof("starting etcd and the API server on the master nodes")
+	if err := k.execScript(mastersIP, restoreStartScript); err != nil {
+		k.State[platformName].Status = FailedConfigurationStatus.String()
+		if errRestore != nil {
+			return fmt.Errorf("failed to restore the etcd snapshot and to start etcd. %s. %s", errRestore, err)
+		}
+		return fmt.Errorf("failed to start etcd. %s", err)
+	}
+	if errRestore != nil {
+		k.State[platformName].Status = FailedConfigurationStatus.String()
+		return fmt.Errorf("failed to restore the etcd snapshot. %s", errRestore)
+	}
+
+	k.ui.Log.Infof("waiting for the cluster to be ready")
+	if err := k.waitNodesReady(timeout); err != nil {
+		k.State[platformName].Status = FailedConfigurationStatus.String()
+		return err
+	}
+
+	k.State[platformName].Status = RunningStatus.String()
+	k.ui.Log.Infof("the etcd snapshot %s was restored on the cluster %q", filepath.Base(snapshotFile), k.Name)
+
+	return nil
+}
+
+// waitNodesReady waits until all the Kubernetes nodes are ready or the timeout
+// is reached. The API server may not be available at the beginning
+func (k *Kluster) waitNodesReady(timeout time.Duration) error {
+	client, err := kube.NewClientE("", filepath.Join(k.CertsDir(), "kubeconfig"), k.ui)
+	if err != nil {
+		return fmt.Errorf("cannot connect to the Kubernetes cluster. %s", err)
+	}
+
+	var ready, total int
+	err = wait.PollImmediate(10*time.Second, timeout, func() (bool, error) {
+		if ready, total, err = client.NodesReady(); err != nil {
+			k.ui.Log.Debugf("the Kubernetes API is not available yet. %s", err)
+			return false, nil
+		}
+		k.ui.Log.Debugf("%d/%d nodes ready", ready, total)
+		return total != 0 && ready == total, nil
+	})
+	if err != nil {
+		return fmt.Errorf("not all the nodes are ready after %s (%d/%d)", timeout, ready, total)
+	}
+
+	return nil
+}
+
+// execOnHost executes the given command on the given host, returns an error if
+// the command failed
+func (k *Kluster) execOnHost(host configurator.Host, command string, sudoExec bool) error {
+	return k.execOn([]string{host.PublicIP}, command, sudoExec)
+}
+
+// execScript executes the given shell script as root on the given nodes
+func (k *Kluster) execScript(nodes []string, script string) error {
+	command := fmt.Sprintf("echo %s | base64 -d | sudo sh -s", base64.StdEncoding.EncodeToString([]byte(script)))
+	return k.execOn(nodes, command, false)
+}
+
+func (k *Kluster) execOn(nodes []string, command string, sudoExec bool) error {
+	result, err := k.Exec(command, "", nodes, nil, sudoExec)
+	if err != nil {
+		return err
+	}
+	if result.Failures == 0 {
+		return nil
+	}
+
+	errMsgs := []string{}
+	for host, res := range result.Hosts.GetSnapshot() {
+		if res.ExitStatus != 0 {
+			errMsgs = append(errMsgs, fmt.Sprintf("%s (%s)", host, strings.TrimSpace(res.Stderr)))
+		}
+	}
+	sort.Strings(errMsgs)
+
+	return fmt.Errorf("failed on the nodes: %s", strings.Join(errMsgs, ", "))
+}
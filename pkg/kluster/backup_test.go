@@ -0,0 +1,65 @@
+package kluster
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestKluster_GetBackup(t *testing.T) {
+	path, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	k := &Kluster{Name: "kkdemo", path: filepath.Join(path, "cluster.yaml")}
+
+	if _, err := k.GetBackup("latest"); err == nil {
+		t.Errorf("GetBackup() expected an error for a cluster without backups")
+	}
+
+	if err := os.MkdirAll(k.BackupsDir(), 0700); err != nil {
+		t.Fatalf("failed to create the backups directory. %v", err)
+	}
+	now := time.Now().UTC()
+	for i, name := range []string{"snapshot-old", "snapshot-new", "snapshot-middle"} {
+		createdAt := now
+		switch name {
+		case "snapshot-old":
+			createdAt = now.Add(-2 * time.Hour)
+		case "snapshot-middle":
+			createdAt = now.Add(-1 * time.Hour)
+		}
+		backupB, _ := json.Marshal(Backup{Name: name, ClusterName: k.Name, Size: int64(i), CreatedAt: createdAt})
+		if err := ioutil.WriteFile(filepath.Join(k.BackupsDir(), name+".json"), backupB, 0600); err != nil {
+			t.Fatalf("failed to write the backup metadata. %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		backup   string
+		wantName string
+		wantErr  bool
+	}{
+		{"latest", "latest", "snapshot-new", false},
+		{"by name", "snapshot-old", "snapshot-old", false},
+		{"by file name", "snapshot-middle.db", "snapshot-middle", false},
+		{"not found", "snapshot-none", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := k.GetBackup(tt.backup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBackup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name != tt.wantName {
+				t.Errorf("GetBackup() = %v, want %v", got.Name, tt.wantName)
+			}
+		})
+	}
+}
@@ -267,8 +267,11 @@ func (k *Kluster) GenerateCerts(userCACertsFiles tls.KeyPairs, forceGenerateCA b
 		return err
 	}
 	// ... the Certificates
-	_, err = k.genCertificates(baseCertsDir, platformName)
-	return err
+	if _, err = k.genCertificates(baseCertsDir, platformName); err != nil {
+		return err
+	}
+
+	return k.pushDir(k.CertsDir())
 
 	// return k.certificates.Save(overwrite)
 }
@@ -777,6 +780,12 @@ func (k *Kluster) GenerateKubeConfig() ([]byte, error) {
 	return content.Bytes(), err
 }
 
+// DeleteCerts deletes the certificates and the kubeconfig file of the cluster,
+// locally and in the shared storage
+func (k *Kluster) DeleteCerts() error {
+	return k.deleteDir(k.CertsDir())
+}
+
 // WriteKubeConfig saves the kubeconfig content in a file into the cluster directory
 func (k *Kluster) WriteKubeConfig(kubeconfigContent []byte) (string, error) {
 	baseCertsDir, err := k.makeCertDir()
@@ -791,7 +800,10 @@ func (k *Kluster) WriteKubeConfig(kubeconfigContent []byte) (string, error) {
 	}
 	defer kubeconfigFile.Close()
 
-	return kubeconfigFilename, ioutil.WriteFile(kubeconfigFilename, kubeconfigContent, 0644)
+	if err := ioutil.WriteFile(kubeconfigFilename, kubeconfigContent, 0644); err != nil {
+		return kubeconfigFilename, err
+	}
+	return kubeconfigFilename, k.push(kubeconfigFilename)
 }
 
 // CreateKubeConfigFile creates the kubeconfig file for this cluster
@@ -0,0 +1,195 @@
+package kluster
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+)
+
+// certificatesDumpCMD prints every certificate on a node preceded by a line
+// with the certificate file name
+const certificatesDumpCMD = "sh -c 'for f in " + configurator.TLSDirectory + "/*.crt; do echo \"" + certificateFileMark + "$f\"; cat $f; done'"
+
+const certificateFileMark = "### "
+
+// CertificateInfo is the information of a certificate to know who it identifies
+// and when it expires. The node is empty for the certificates in the cluster
+// directory
+type CertificateInfo struct {
+	Name      string    `json:"name" yaml:"name" toml:"name" mapstructure:"name"`
+	Node      string    `json:"node,omitempty" yaml:"node,omitempty" toml:"node,omitempty" mapstructure:"node"`
+	File      string    `json:"file" yaml:"file" toml:"file" mapstructure:"file"`
+	CN        string    `json:"cn" yaml:"cn" toml:"cn" mapstructure:"cn"`
+	SANs      []string  `json:"sans,omitempty" yaml:"sans,omitempty" toml:"sans,omitempty" mapstructure:"sans"`
+	Issuer    string    `json:"issuer" yaml:"issuer" toml:"issuer" mapstructure:"issuer"`
+	IsCA      bool      `json:"is_ca" yaml:"is_ca" toml:"is_ca" mapstructure:"is_ca"`
+	NotBefore time.Time `json:"not_before" yaml:"not_before" toml:"not_before" mapstructure:"not_before"`
+	NotAfter  time.Time `json:"not_after" yaml:"not_after" toml:"not_after" mapstructure:"not_after"`
+}
+
+// NewCertificateInfo returns the information of the given certificate
+func NewCertificateInfo(name, node, file string, cert *x509.Certificate) *CertificateInfo {
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return &CertificateInfo{
+		Name:      name,
+		Node:      node,
+		File:      file,
+		CN:        cert.Subject.CommonName,
+		SANs:      sans,
+		Issuer:    cert.Issuer.CommonName,
+		IsCA:      cert.IsCA,
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+	}
+}
+
+// ExpiresIn returns the time left until the certificate expires, it's negative
+// if the certificate is expired
+func (ci *CertificateInfo) ExpiresIn() time.Duration {
+	return time.Until(ci.NotAfter)
+}
+
+// CertificatesInfo returns the information of the certificates in the cluster
+// directory, including the certificates generated for every node
+func (k *Kluster) CertificatesInfo() ([]*CertificateInfo, error) {
+	certsDir := filepath.Join(k.CertsDir(), k.Platform())
+	if _, err := os.Stat(certsDir); err != nil {
+		return nil, fmt.Errorf("not found the certificates of the cluster %q, generate them with 'kubekit init certificates %s'", k.Name, k.Name)
+	}
+
+	files, err := filepath.Glob(filepath.Join(certsDir, "*.crt"))
+	if err != nil {
+		return nil, err
+	}
+	hostFiles, err := filepath.Glob(filepath.Join(certsDir, "*", "*.crt"))
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, hostFiles...)
+
+	certsInfo := []*CertificateInfo{}
+	for _, file := range files {
+		certPEM, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		certs, err := certificatesFromPEM(certPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the certificate %q. %s", file, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), ".crt")
+		if dir := filepath.Dir(file); dir != certsDir {
+			name = name + "@" + filepath.Base(dir)
+		}
+		for _, cert := range certs {
+			certsInfo = append(certsInfo, NewCertificateInfo(name, "", file, cert))
+		}
+	}
+
+	return certsInfo, nil
+}
+
+// NodesCertificatesInfo returns the information of the certificates installed
+// on the cluster nodes, or the nodes filtered by name, IP, DNS or pool
+func (k *Kluster) NodesCertificatesInfo(nodes, pools []string) ([]*CertificateInfo, error) {
+	if err := k.LoadState(); err != nil {
+		return nil, err
+	}
+
+	result, err := k.Exec(certificatesDumpCMD, "", nodes, pools, true)
+	if err != nil {
+		return nil, err
+	}
+
+	certsInfo := []*CertificateInfo{}
+	for host, res := range result.Hosts.GetSnapshot() {
+		if res.ExitStatus != 0 {
+			return nil, fmt.Errorf("failed to read the certificates on %s. %s", host, strings.TrimSpace(res.Stderr))
+		}
+		hostCertsInfo, err := parseCertificatesDump(host, res.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		certsInfo = append(certsInfo, hostCertsInfo...)
+	}
+	sort.SliceStable(certsInfo, func(i, j int) bool {
+		return certsInfo[i].Node < certsInfo[j].Node
+	})
+
+	return certsInfo, nil
+}
+
+// parseCertificatesDump parses the output of the certificates dump command
+// executed on a node
+func parseCertificatesDump(node, dump string) ([]*CertificateInfo, error) {
+	certsInfo := []*CertificateInfo{}
+
+	var file string
+	var content strings.Builder
+	flush := func() error {
+		if len(file) == 0 {
+			return nil
+		}
+		certs, err := certificatesFromPEM([]byte(content.String()))
+		if err != nil {
+			return fmt.Errorf("failed to read the certificate %q on %s. %s", file, node, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ".crt")
+		for _, cert := range certs {
+			certsInfo = append(certsInfo, NewCertificateInfo(name, node, file, cert))
+		}
+		content.Reset()
+		return nil
+	}
+
+	for _, line := range strings.Split(dump, "\n") {
+		if strings.HasPrefix(line, certificateFileMark) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			file = strings.TrimPrefix(line, certificateFileMark)
+			continue
+		}
+		content.WriteString(line + "\n")
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return certsInfo, nil
+}
+
+// certificatesFromPEM returns all the certificates in the given PEM data
+func certificatesFromPEM(data []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, nil
+}
@@ -0,0 +1,46 @@
+package kluster
+
+import (
+	"crypto/x509"
+	"reflect"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/crypto/tls"
+)
+
+func Test_parseCertificatesDump(t *testing.T) {
+	caKP, err := tls.NewCAKeyPair(&tls.KeyPair{}, "", "root_ca", "kubernetes-ca")
+	if err != nil {
+		t.Fatalf("failed to generate the CA certificate. %s", err)
+	}
+	apiKP, err := tls.NewKeyPair("", "kube_api_server", "kube-apiserver", "", []string{"kubernetes"}, []string{"10.0.0.1"}, caKP, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+	if err != nil {
+		t.Fatalf("failed to generate the API server certificate. %s", err)
+	}
+
+	dump := certificateFileMark + "/etc/pki/root_ca.crt\n" + string(caKP.PEMEncodeCert()) +
+		certificateFileMark + "/etc/pki/kube_api_server.crt\n" + string(apiKP.PEMEncodeCert()) +
+		certificateFileMark + "/etc/pki/empty.crt\n"
+
+	got, err := parseCertificatesDump("10.0.0.1", dump)
+	if err != nil {
+		t.Fatalf("parseCertificatesDump() error = %s", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("parseCertificatesDump() returned %d certificates, want 2", len(got))
+	}
+
+	ca, api := got[0], got[1]
+	if ca.Name != "root_ca" || ca.Node != "10.0.0.1" || !ca.IsCA || ca.CN != "kubernetes-ca" {
+		t.Errorf("parseCertificatesDump() CA certificate = %+v", ca)
+	}
+	if api.Name != "kube_api_server" || api.File != "/etc/pki/kube_api_server.crt" || api.IsCA || api.Issuer != "kubernetes-ca" {
+		t.Errorf("parseCertificatesDump() API server certificate = %+v", api)
+	}
+	if want := []string{"kubernetes", "10.0.0.1"}; !reflect.DeepEqual(api.SANs, want) {
+		t.Errorf("parseCertificatesDump() API server SANs = %v, want %v", api.SANs, want)
+	}
+	if api.ExpiresIn() <= 0 {
+		t.Errorf("parseCertificatesDump() API server certificate expired at %s", api.NotAfter)
+	}
+}
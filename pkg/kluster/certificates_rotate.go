@@ -0,0 +1,231 @@
+package kluster
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/crypto/tls"
+)
+
+// Kubernetes components restarted after the certificates are rotated, so they
+// load the new certificates. The control plane static pods are not restarted by
+// the kubelet, their containers are restarted
+var (
+	restartControlPlaneContainers = []string{"etcd", "kube-apiserver", "kube-controller-manager", "kube-scheduler", "kube-proxy"}
+	restartNodeContainers         = []string{"kube-proxy"}
+)
+
+// RotateCerts regenerates the certificates of the cluster signed by the
+// existing CA certificates, or it regenerates also the CA certificates if
+// `rotateCA` is true. The current certificates are backed up, the new ones are
+// uploaded to the nodes and the control plane is restarted one master node at
+// a time, then the rest of the nodes. The kubeconfig file is regenerated. When
+// the CA is rotated, the nodes first trust a bundle of the old and new CA, then
+// switch to the new certificates and finally trust only the new CA
+func (k *Kluster) RotateCerts(userCACertsFiles tls.KeyPairs, rotateCA bool, timeout time.Duration) error {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+
+	switch platformName {
+	case "eks", "aks":
+		return fmt.Errorf("the %s platform does not support to rotate certificates, they are managed by the platform", platformName)
+	}
+
+	if err := k.LoadState(); err != nil {
+		return err
+	}
+	if len(k.State[platformName].Nodes) == 0 {
+		return fmt.Errorf("the cluster %q does not have nodes", k.Name)
+	}
+
+	certsDir := filepath.Join(k.CertsDir(), platformName)
+	backupDir := fmt.Sprintf("%s.%d.bkp", certsDir, time.Now().Unix())
+	if err := copyDir(certsDir, backupDir); err != nil {
+		return fmt.Errorf("failed to backup the certificates to %s. %s", backupDir, err)
+	}
+	k.ui.SetLogPrefix(logPrefix)
+	k.ui.Log.Infof("certificates backed up to %s", backupDir)
+
+	if rotateCA {
+		k.ui.Log.Warnf("the CA certificates and the service account key are regenerated, the pods using service accounts have to be restarted after the rotation")
+	}
+	if err := k.GenerateCerts(userCACertsFiles, rotateCA); err != nil {
+		return err
+	}
+	k.ui.SetLogPrefix(logPrefix)
+
+	pConf := k.provisioner[platformName].Config()
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, k.Dir(), k.ui)
+	if err != nil {
+		return err
+	}
+	conf = conf.WithContext(k.ctx)
+
+	if rotateCA {
+		// the nodes trust the old and the new CA before they switch to the new
+		// certificates, so the nodes and clients with the old certificates and
+		// the ones with the new certificates trust each other during the rotation
+		stageDir, err := ioutil.TempDir("", "kubekit-certs")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(stageDir)
+
+		k.ui.Log.Infof("uploading the bundle of the old and new CA certificates to the nodes")
+		if err := stageCertsDir(backupDir, certsDir, stageDir); err != nil {
+			return fmt.Errorf("failed to create the CA certificates bundle. %s", err)
+		}
+		// the API server still has the old certificate, the old kubeconfig is used
+		if err := k.rolloutCerts(conf, stageDir, timeout); err != nil {
+			return err
+		}
+
+		k.ui.Log.Infof("uploading the new certificates with the bundle of the old and new CA certificates to the nodes")
+		if err := stageCertsDir(certsDir, backupDir, stageDir); err != nil {
+			return fmt.Errorf("failed to create the CA certificates bundle. %s", err)
+		}
+		if err := k.CreateKubeConfigFile(); err != nil {
+			return err
+		}
+		if err := k.rolloutCerts(conf, stageDir, timeout); err != nil {
+			return err
+		}
+	} else if err := k.CreateKubeConfigFile(); err != nil {
+		return err
+	}
+
+	k.ui.Log.Infof("uploading the certificates to the nodes")
+	if err := k.rolloutCerts(conf, certsDir, timeout); err != nil {
+		return err
+	}
+
+	k.State[platformName].Status = RunningStatus.String()
+	k.ui.Log.Infof("the certificates of the cluster %q were rotated", k.Name)
+
+	return nil
+}
+
+// rolloutCerts uploads the certificates in the given directory to the nodes and
+// restarts the Kubernetes components to load them, the control plane one master
+// node at a time, then the rest of the nodes
+func (k *Kluster) rolloutCerts(conf *configurator.Configurator, certsDir string, timeout time.Duration) error {
+	platformName := k.Platform()
+
+	if err := conf.UploadCertsFrom(certsDir); err != nil {
+		k.State[platformName].Status = FailedConfigurationStatus.String()
+		return err
+	}
+
+	runtime := k.containerRuntime()
+	restartControlPlaneCMD := restartContainersCMD(runtime, restartControlPlaneContainers...)
+	restartNodeCMD := restartContainersCMD(runtime, restartNodeContainers...)
+
+	hosts := k.State[platformName].Nodes
+	for _, master := range hosts.FilterByRole("master") {
+		k.ui.Log.Infof("restarting the control plane on master node %s", master.PublicIP)
+		if err := k.execOnHost(master, restartControlPlaneCMD, true); err != nil {
+			k.State[platformName].Status = FailedConfigurationStatus.String()
+			return fmt.Errorf("failed to restart the control plane on %s. %s", master.PublicIP, err)
+		}
+		if err := k.waitNodesReady(timeout); err != nil {
+			k.State[platformName].Status = FailedConfigurationStatus.String()
+			return err
+		}
+	}
+
+	workersIP := []string{}
+	for _, host := range hosts {
+		if host.RoleName != "master" {
+			workersIP = append(workersIP, host.PublicIP)
+		}
+	}
+	if len(workersIP) != 0 {
+		k.ui.Log.Infof("restarting the Kubernetes services on the worker nodes")
+		if err := k.execOn(workersIP, restartNodeCMD, true); err != nil {
+			k.State[platformName].Status = FailedConfigurationStatus.String()
+			return fmt.Errorf("failed to restart the Kubernetes services. %s", err)
+		}
+		if err := k.waitNodesReady(timeout); err != nil {
+			k.State[platformName].Status = FailedConfigurationStatus.String()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// caBundleFiles are the CA certificates uploaded to the nodes. While the CA is
+// rotated they are a bundle of the old and new CA certificates
+var caBundleFiles = []string{"root_ca.crt", "etcd_root_ca.crt"}
+
+// stageCertsDir copies the certificates in the directory `from` to the directory
+// `to`, replacing the CA certificates with the bundle of the CA certificates in
+// `from` and in `other`
+func stageCertsDir(from, other, to string) error {
+	if err := os.RemoveAll(to); err != nil {
+		return err
+	}
+	if err := copyDir(from, to); err != nil {
+		return err
+	}
+
+	for _, name := range caBundleFiles {
+		ca, err := ioutil.ReadFile(filepath.Join(from, name))
+		if err != nil {
+			return err
+		}
+		otherCA, err := ioutil.ReadFile(filepath.Join(other, name))
+		if err != nil {
+			return err
+		}
+
+		bundle := append(bytes.TrimSpace(ca), '\n')
+		if !bytes.Equal(bytes.TrimSpace(ca), bytes.TrimSpace(otherCA)) {
+			bundle = append(bundle, bytes.TrimSpace(otherCA)...)
+			bundle = append(bundle, '\n')
+		}
+		if err := ioutil.WriteFile(filepath.Join(to, name), bundle, 0600); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyDir copies the content of the directory `from` to the new directory `to`
+func copyDir(from, to string) error {
+	return filepath.Walk(from, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer dst.Close()
+
+		_, err = io.Copy(dst, src)
+		return err
+	})
+}
@@ -0,0 +1,60 @@
+package kluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStageCertsDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]map[string]string{
+		"old": {"root_ca.crt": "OLD ROOT CA\n", "etcd_root_ca.crt": "ETCD CA\n", "node.crt": "OLD NODE", "node01/kubelet.crt": "OLD KUBELET"},
+		"new": {"root_ca.crt": "NEW ROOT CA\n", "etcd_root_ca.crt": "ETCD CA\n", "node.crt": "NEW NODE", "node01/kubelet.crt": "NEW KUBELET"},
+	}
+	for certsDir, certs := range files {
+		for name, content := range certs {
+			filename := filepath.Join(dir, certsDir, name)
+			os.MkdirAll(filepath.Dir(filename), 0700)
+			if err := ioutil.WriteFile(filename, []byte(content), 0600); err != nil {
+				t.Fatalf("failed to write the certificate %s. %v", filename, err)
+			}
+		}
+	}
+
+	stageDir := filepath.Join(dir, "stage")
+	tests := []struct {
+		name string
+		from string
+		want map[string]string
+	}{
+		{"old certificates", "old", map[string]string{"root_ca.crt": "OLD ROOT CA\nNEW ROOT CA\n", "etcd_root_ca.crt": "ETCD CA\n", "node.crt": "OLD NODE", "node01/kubelet.crt": "OLD KUBELET"}},
+		{"new certificates", "new", map[string]string{"root_ca.crt": "NEW ROOT CA\nOLD ROOT CA\n", "etcd_root_ca.crt": "ETCD CA\n", "node.crt": "NEW NODE", "node01/kubelet.crt": "NEW KUBELET"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := "new"
+			if tt.from == "new" {
+				other = "old"
+			}
+			if err := stageCertsDir(filepath.Join(dir, tt.from), filepath.Join(dir, other), stageDir); err != nil {
+				t.Fatalf("stageCertsDir() error = %v", err)
+			}
+			for name, want := range tt.want {
+				got, err := ioutil.ReadFile(filepath.Join(stageDir, name))
+				if err != nil {
+					t.Fatalf("stageCertsDir() the certificate %s was not staged. %v", name, err)
+				}
+				if string(got) != want {
+					t.Errorf("stageCertsDir() %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
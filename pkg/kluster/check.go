@@ -0,0 +1,452 @@
+package kluster
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CheckStatus is the result of a cluster diagnostic
+type CheckStatus string
+
+// The possible results of a cluster diagnostic, from the best to the worst
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+)
+
+// Thresholds of the cluster diagnostics to warn or fail
+const (
+	clockSkewWarn       = 30 * time.Second
+	clockSkewFail       = 180 * time.Second // same offset allowed by the configurator
+	diskUsageWarn       = 80
+	diskUsageFail       = 90
+	etcdDBSizeWarn      = 80
+	etcdDBSizeFail      = 95
+	certExpiryWarn      = 30 * 24 * time.Hour
+	defaultEtcdQuotaMiB = 2048 // etcd default quota when etcd_quota_backend_bytes is not set
+)
+
+// controlPlaneContainers are the containers that should be running on every
+// master node
+var controlPlaneContainers = []string{"etcd", "kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+
+// Scripts executed on the nodes to collect the diagnostics data, every line is
+// a `key=value` pair
+const (
+	checkNodeScript = `echo "epoch=$(date +%s)"
+echo "disk=$(df -P / | awk 'NR==2 {print $5}' | tr -d %)"
+`
+	checkMasterScriptFmt = `echo "etcd=$(` + etcdctlCMD + ` endpoint status --write-out=json 2>&1 | tr -d '\n')"
+for c in %s; do
+  echo "container.$c=$(%s | wc -l)"
+done
+`
+)
+
+// checkMasterScript returns the script to collect the diagnostics data on the
+// master nodes, for the given container runtime
+func checkMasterScript(runtime string) string {
+	return fmt.Sprintf(checkMasterScriptFmt, strings.Join(controlPlaneContainers, " "), containersCMD(runtime, "$c"))
+}
+
+// CheckResult is the result of a cluster diagnostic, on a node or in the
+// entire cluster if the node is empty
+type CheckResult struct {
+	Check   string      `json:"check" yaml:"check" toml:"check" mapstructure:"check"`
+	Node    string      `json:"node,omitempty" yaml:"node,omitempty" toml:"node,omitempty" mapstructure:"node"`
+	Status  CheckStatus `json:"status" yaml:"status" toml:"status" mapstructure:"status"`
+	Message string      `json:"message" yaml:"message" toml:"message" mapstructure:"message"`
+}
+
+// CheckReport is the result of all the diagnostics of a cluster. The status is
+// the worst status of all the diagnostics
+type CheckReport struct {
+	ClusterName string         `json:"cluster_name" yaml:"cluster_name" toml:"cluster_name" mapstructure:"cluster_name"`
+	Status      CheckStatus    `json:"status" yaml:"status" toml:"status" mapstructure:"status"`
+	Pass        int            `json:"pass" yaml:"pass" toml:"pass" mapstructure:"pass"`
+	Warn        int            `json:"warn" yaml:"warn" toml:"warn" mapstructure:"warn"`
+	Fail        int            `json:"fail" yaml:"fail" toml:"fail" mapstructure:"fail"`
+	Results     []*CheckResult `json:"results" yaml:"results" toml:"results" mapstructure:"results"`
+}
+
+func (r *CheckReport) add(check, node string, status CheckStatus, format string, a ...interface{}) {
+	r.Results = append(r.Results, &CheckResult{
+		Check:   check,
+		Node:    node,
+		Status:  status,
+		Message: fmt.Sprintf(format, a...),
+	})
+	switch status {
+	case CheckPass:
+		r.Pass++
+	case CheckWarn:
+		r.Warn++
+	case CheckFail:
+		r.Fail++
+	}
+	if worseStatus(status, r.Status) {
+		r.Status = status
+	}
+}
+
+func worseStatus(a, b CheckStatus) bool {
+	rank := map[CheckStatus]int{CheckPass: 0, CheckWarn: 1, CheckFail: 2}
+	return rank[a] > rank[b]
+}
+
+// Check runs the diagnostics of the cluster over SSH and the Kubernetes API:
+// node readiness and pressure, etcd health and database size, clock skew,
+// certificates expiration, disk usage, pods phase per namespace and the
+// control plane containers status
+func (k *Kluster) Check() (*CheckReport, error) {
+	if err := k.LoadState(); err != nil {
+		return nil, err
+	}
+
+	hosts := k.HostsFilterBy(nil, nil)
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("not found nodes in the cluster %q, it may not be provisioned", k.Name)
+	}
+
+	report := &CheckReport{
+		ClusterName: k.Name,
+		Status:      CheckPass,
+		Results:     []*CheckResult{},
+	}
+
+	k.checkKubernetes(report)
+	k.checkNodes(report, hosts)
+	k.checkMasters(report, hosts.FilterByRole("master"))
+	k.checkCertificates(report)
+
+	return report, nil
+}
+
+// checkKubernetes runs the diagnostics using the Kubernetes API
+func (k *Kluster) checkKubernetes(report *CheckReport) {
+	client, err := kube.NewClientE("", filepath.Join(k.CertsDir(), "kubeconfig"), k.ui)
+	if err != nil {
+		report.add("kubernetes-api", "", CheckFail, "cannot connect to the Kubernetes API. %s", err)
+		return
+	}
+
+	nodes, err := client.ListNodes()
+	if err != nil {
+		report.add("kubernetes-api", "", CheckFail, "cannot get the nodes from the Kubernetes API. %s", err)
+		return
+	}
+	report.add("kubernetes-api", "", CheckPass, "the Kubernetes API is available")
+
+	for _, node := range nodes.Items {
+		status, msg := nodeConditionsStatus(node.Status.Conditions)
+		report.add("node-ready", node.Name, status, msg)
+	}
+
+	pods, err := client.ListPods("")
+	if err != nil {
+		report.add("pods", "", CheckFail, "cannot get the pods from the Kubernetes API. %s", err)
+		return
+	}
+	counts := map[string]*configurator.PodsPhaseCount{}
+	for _, pod := range pods.Items {
+		if _, ok := counts[pod.Namespace]; !ok {
+			counts[pod.Namespace] = &configurator.PodsPhaseCount{}
+		}
+		counts[pod.Namespace].Add(pod.Status.Phase)
+	}
+	namespaces := make([]string, 0, len(counts))
+	for ns := range counts {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		status, msg := podsPhaseStatus(counts[ns])
+		report.add("pods/"+ns, "", status, msg)
+	}
+}
+
+// checkNodes runs the diagnostics executed on every node
+func (k *Kluster) checkNodes(report *CheckReport, hosts configurator.Hosts) {
+	before := time.Now()
+	outputs, errs := k.collect(hosts, checkNodeScript)
+	after := time.Now()
+
+	for _, host := range hosts {
+		name := hostName(host)
+		if err, ok := errs[host.PublicIP]; ok {
+			report.add("ssh", name, CheckFail, "cannot execute the diagnostics. %s", err)
+			continue
+		}
+		values := outputs[host.PublicIP]
+
+		epoch, err := strconv.ParseInt(values["epoch"], 10, 64)
+		if err != nil {
+			report.add("clock-skew", name, CheckFail, "cannot get the node time. %s", err)
+		} else {
+			skew := clockSkew(time.Unix(epoch, 0), before, after)
+			status, msg := clockSkewStatus(skew)
+			report.add("clock-skew", name, status, msg)
+		}
+
+		usage, err := strconv.Atoi(values["disk"])
+		if err != nil {
+			report.add("disk-usage", name, CheckFail, "cannot get the disk usage. %s", err)
+		} else {
+			status, msg := diskUsageStatus(usage)
+			report.add("disk-usage", name, status, msg)
+		}
+	}
+}
+
+// checkMasters runs the diagnostics executed on the master nodes
+func (k *Kluster) checkMasters(report *CheckReport, masters configurator.Hosts) {
+	if len(masters) == 0 {
+		return
+	}
+
+	quota := int64(defaultEtcdQuotaMiB) * 1024 * 1024
+	if k.Config != nil && k.Config.EtcdQuotaBackendBytes > 0 {
+		quota = int64(k.Config.EtcdQuotaBackendBytes)
+	}
+
+	outputs, errs := k.collect(masters, checkMasterScript(k.containerRuntime()))
+
+	for _, host := range masters {
+		name := hostName(host)
+		if _, ok := errs[host.PublicIP]; ok {
+			// the SSH failure is reported by the nodes diagnostics
+			continue
+		}
+		values := outputs[host.PublicIP]
+
+		dbSize, version, err := parseEtcdStatus(values["etcd"])
+		if err != nil {
+			report.add("etcd-health", name, CheckFail, "the etcd member is not healthy. %s", err)
+		} else {
+			report.add("etcd-health", name, CheckPass, "the etcd member %s is healthy", version)
+			status, msg := etcdDBSizeStatus(dbSize, quota)
+			report.add("etcd-db-size", name, status, msg)
+		}
+
+		for _, c := range controlPlaneContainers {
+			if n, _ := strconv.Atoi(values["container."+c]); n > 0 {
+				report.add("container/"+c, name, CheckPass, "%s is running", c)
+			} else {
+				report.add("container/"+c, name, CheckFail, "%s is not running", c)
+			}
+		}
+	}
+}
+
+// checkCertificates checks the expiration of the certificates on every node
+func (k *Kluster) checkCertificates(report *CheckReport) {
+	certsInfo, err := k.NodesCertificatesInfo(nil, nil)
+	if err != nil {
+		report.add("certificates", "", CheckFail, "cannot get the certificates from the nodes. %s", err)
+		return
+	}
+
+	// only the certificate that expires first on every node is reported
+	first := map[string]*CertificateInfo{}
+	nodes := []string{}
+	for _, ci := range certsInfo {
+		if f, ok := first[ci.Node]; !ok || ci.NotAfter.Before(f.NotAfter) {
+			if !ok {
+				nodes = append(nodes, ci.Node)
+			}
+			first[ci.Node] = ci
+		}
+	}
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		status, msg := certExpiryStatus(first[node])
+		report.add("certificates", node, status, msg)
+	}
+}
+
+// collect executes the given diagnostics script on the given hosts and returns
+// the `key=value` pairs printed by every host, or the error if the script
+// failed, indexed by the host public IP
+func (k *Kluster) collect(hosts configurator.Hosts, script string) (map[string]map[string]string, map[string]error) {
+	outputs := map[string]map[string]string{}
+	errs := map[string]error{}
+
+	ips := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		ips = append(ips, host.PublicIP)
+	}
+
+	command := fmt.Sprintf("echo %s | base64 -d | sudo sh -s", base64.StdEncoding.EncodeToString([]byte(script)))
+	result, err := k.Exec(command, "", ips, nil, false)
+	if err != nil {
+		for _, ip := range ips {
+			errs[ip] = err
+		}
+		return outputs, errs
+	}
+
+	results := result.Hosts.GetSnapshot()
+	for _, ip := range ips {
+		res, ok := results[ip]
+		switch {
+		case !ok:
+			errs[ip] = fmt.Errorf("no result from the node")
+		case res.ExitStatus != 0:
+			errs[ip] = fmt.Errorf("%s", strings.TrimSpace(res.Stderr))
+		default:
+			outputs[ip] = parseKeyValues(res.Stdout)
+		}
+	}
+
+	return outputs, errs
+}
+
+func hostName(host configurator.Host) string {
+	if len(host.PrivateDNS) != 0 {
+		return host.PrivateDNS
+	}
+	return host.PublicIP
+}
+
+// parseKeyValues returns the `key=value` pairs, one per line, in the output
+func parseKeyValues(output string) map[string]string {
+	values := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		kv := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(kv) == 2 {
+			values[kv[0]] = kv[1]
+		}
+	}
+	return values
+}
+
+// parseEtcdStatus returns the database size and version from the output of
+// `etcdctl endpoint status --write-out=json`
+func parseEtcdStatus(output string) (int64, string, error) {
+	status := []struct {
+		Endpoint string `json:"Endpoint"`
+		Status   struct {
+			Version string `json:"version"`
+			DBSize  int64  `json:"dbSize"`
+		} `json:"Status"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &status); err != nil || len(status) == 0 {
+		return 0, "", fmt.Errorf("%s", strings.TrimSpace(output))
+	}
+	return status[0].Status.DBSize, status[0].Status.Version, nil
+}
+
+// clockSkew returns the difference between the node time and the local time
+// when the node time was taken, between before and after
+func clockSkew(remote, before, after time.Time) time.Duration {
+	// the node time has a resolution of seconds
+	before = before.Truncate(time.Second)
+	switch {
+	case remote.Before(before):
+		return before.Sub(remote)
+	case remote.After(after):
+		return remote.Sub(after)
+	default:
+		return 0
+	}
+}
+
+func clockSkewStatus(skew time.Duration) (CheckStatus, string) {
+	switch {
+	case skew >= clockSkewFail:
+		return CheckFail, fmt.Sprintf("the node time differs %s from the local time, the certificates may be invalid", skew.Round(time.Second))
+	case skew >= clockSkewWarn:
+		return CheckWarn, fmt.Sprintf("the node time differs %s from the local time", skew.Round(time.Second))
+	default:
+		return CheckPass, fmt.Sprintf("the node time differs %s from the local time", skew.Round(time.Second))
+	}
+}
+
+func diskUsageStatus(usage int) (CheckStatus, string) {
+	msg := fmt.Sprintf("%d%% of the root filesystem is used", usage)
+	switch {
+	case usage >= diskUsageFail:
+		return CheckFail, msg
+	case usage >= diskUsageWarn:
+		return CheckWarn, msg
+	default:
+		return CheckPass, msg
+	}
+}
+
+func etcdDBSizeStatus(size, quota int64) (CheckStatus, string) {
+	usage := int(size * 100 / quota)
+	msg := fmt.Sprintf("the etcd database size is %d MiB, %d%% of the %d MiB quota", size/1024/1024, usage, quota/1024/1024)
+	switch {
+	case usage >= etcdDBSizeFail:
+		return CheckFail, msg
+	case usage >= etcdDBSizeWarn:
+		return CheckWarn, msg
+	default:
+		return CheckPass, msg
+	}
+}
+
+func certExpiryStatus(ci *CertificateInfo) (CheckStatus, string) {
+	expiresIn := ci.ExpiresIn()
+	switch {
+	case expiresIn <= 0:
+		return CheckFail, fmt.Sprintf("the certificate %s expired on %s", ci.Name, ci.NotAfter.Format(time.RFC3339))
+	case expiresIn < certExpiryWarn:
+		return CheckWarn, fmt.Sprintf("the certificate %s expires in %d days", ci.Name, int(expiresIn.Hours()/24))
+	default:
+		return CheckPass, fmt.Sprintf("the first certificate to expire, %s, expires in %d days", ci.Name, int(expiresIn.Hours()/24))
+	}
+}
+
+// nodeConditionsStatus returns the status of a node from its conditions, it
+// fails if it's not ready or has disk pressure and warns with other pressures
+func nodeConditionsStatus(conditions []corev1.NodeCondition) (CheckStatus, string) {
+	ready := false
+	pressures := []string{}
+	diskPressure := false
+	for _, c := range conditions {
+		switch {
+		case c.Type == corev1.NodeReady:
+			ready = c.Status == corev1.ConditionTrue
+		case c.Status == corev1.ConditionTrue && strings.HasSuffix(string(c.Type), "Pressure"):
+			pressures = append(pressures, string(c.Type))
+			if c.Type == corev1.NodeDiskPressure {
+				diskPressure = true
+			}
+		case c.Status == corev1.ConditionTrue && c.Type == corev1.NodeNetworkUnavailable:
+			pressures = append(pressures, string(c.Type))
+		}
+	}
+
+	switch {
+	case !ready:
+		return CheckFail, "the node is not ready"
+	case diskPressure:
+		return CheckFail, fmt.Sprintf("the node is ready with %s", strings.Join(pressures, ", "))
+	case len(pressures) != 0:
+		return CheckWarn, fmt.Sprintf("the node is ready with %s", strings.Join(pressures, ", "))
+	default:
+		return CheckPass, "the node is ready"
+	}
+}
+
+func podsPhaseStatus(counts *configurator.PodsPhaseCount) (CheckStatus, string) {
+	msg := fmt.Sprintf("%d pods: %d running, %d succeeded, %d pending, %d failed, %d unknown", counts.Total, counts.Running, counts.Succeeded, counts.Pending, counts.Failed, counts.Unknown)
+	if counts.Pending+counts.Failed+counts.Unknown != 0 {
+		return CheckWarn, msg
+	}
+	return CheckPass, msg
+}
@@ -0,0 +1,137 @@
+package kluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_parseEtcdStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		wantSize    int64
+		wantVersion string
+		wantErr     bool
+	}{
+		{"healthy", `[{"Endpoint":"https://127.0.0.1:2379","Status":{"header":{"cluster_id":1},"version":"3.3.10","dbSize":20480,"leader":1}}]`, 20480, "3.3.10", false},
+		{"unhealthy", `Error: context deadline exceeded`, 0, "", true},
+		{"empty", `[]`, 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, version, err := parseEtcdStatus(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseEtcdStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if size != tt.wantSize || version != tt.wantVersion {
+				t.Errorf("parseEtcdStatus() = (%d, %q), want (%d, %q)", size, version, tt.wantSize, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func Test_clockSkew(t *testing.T) {
+	before := time.Unix(1000, 500)
+	after := time.Unix(1002, 0)
+	tests := []struct {
+		name   string
+		remote int64
+		want   time.Duration
+	}{
+		{"in time", 1001, 0},
+		{"same second of before", 1000, 0},
+		{"behind", 940, 60 * time.Second},
+		{"ahead", 1302, 300 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := clockSkew(time.Unix(tt.remote, 0), before, after); got != tt.want {
+				t.Errorf("clockSkew() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checkStatuses(t *testing.T) {
+	mib := int64(1024 * 1024)
+	tests := []struct {
+		name   string
+		status func() (CheckStatus, string)
+		want   CheckStatus
+	}{
+		{"clock in time", func() (CheckStatus, string) { return clockSkewStatus(2 * time.Second) }, CheckPass},
+		{"clock skew", func() (CheckStatus, string) { return clockSkewStatus(time.Minute) }, CheckWarn},
+		{"clock skew too large", func() (CheckStatus, string) { return clockSkewStatus(5 * time.Minute) }, CheckFail},
+		{"disk usage", func() (CheckStatus, string) { return diskUsageStatus(40) }, CheckPass},
+		{"disk almost full", func() (CheckStatus, string) { return diskUsageStatus(85) }, CheckWarn},
+		{"disk full", func() (CheckStatus, string) { return diskUsageStatus(99) }, CheckFail},
+		{"etcd db size", func() (CheckStatus, string) { return etcdDBSizeStatus(100*mib, 2048*mib) }, CheckPass},
+		{"etcd db size near quota", func() (CheckStatus, string) { return etcdDBSizeStatus(1700*mib, 2048*mib) }, CheckWarn},
+		{"etcd db size over quota", func() (CheckStatus, string) { return etcdDBSizeStatus(2000*mib, 2048*mib) }, CheckFail},
+		{"certificate", func() (CheckStatus, string) {
+			return certExpiryStatus(&CertificateInfo{Name: "node", NotAfter: time.Now().Add(365 * 24 * time.Hour)})
+		}, CheckPass},
+		{"certificate expires soon", func() (CheckStatus, string) {
+			return certExpiryStatus(&CertificateInfo{Name: "node", NotAfter: time.Now().Add(10 * 24 * time.Hour)})
+		}, CheckWarn},
+		{"certificate expired", func() (CheckStatus, string) {
+			return certExpiryStatus(&CertificateInfo{Name: "node", NotAfter: time.Now().Add(-time.Hour)})
+		}, CheckFail},
+		{"pods running", func() (CheckStatus, string) {
+			return podsPhaseStatus(&configurator.PodsPhaseCount{Total: 2, Running: 1, Succeeded: 1})
+		}, CheckPass},
+		{"pods pending", func() (CheckStatus, string) {
+			return podsPhaseStatus(&configurator.PodsPhaseCount{Total: 2, Running: 1, Pending: 1})
+		}, CheckWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, msg := tt.status(); got != tt.want {
+				t.Errorf("status = %v (%s), want %v", got, msg, tt.want)
+			}
+		})
+	}
+}
+
+func Test_nodeConditionsStatus(t *testing.T) {
+	cond := func(t corev1.NodeConditionType, s corev1.ConditionStatus) corev1.NodeCondition {
+		return corev1.NodeCondition{Type: t, Status: s}
+	}
+	tests := []struct {
+		name       string
+		conditions []corev1.NodeCondition
+		want       CheckStatus
+	}{
+		{"ready", []corev1.NodeCondition{cond(corev1.NodeReady, corev1.ConditionTrue), cond(corev1.NodeDiskPressure, corev1.ConditionFalse)}, CheckPass},
+		{"not ready", []corev1.NodeCondition{cond(corev1.NodeReady, corev1.ConditionFalse)}, CheckFail},
+		{"disk pressure", []corev1.NodeCondition{cond(corev1.NodeReady, corev1.ConditionTrue), cond(corev1.NodeDiskPressure, corev1.ConditionTrue)}, CheckFail},
+		{"memory pressure", []corev1.NodeCondition{cond(corev1.NodeReady, corev1.ConditionTrue), cond(corev1.NodeMemoryPressure, corev1.ConditionTrue)}, CheckWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, msg := nodeConditionsStatus(tt.conditions); got != tt.want {
+				t.Errorf("nodeConditionsStatus() = %v (%s), want %v", got, msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckReport_add(t *testing.T) {
+	report := &CheckReport{Status: CheckPass}
+	report.add("a", "", CheckPass, "ok")
+	report.add("b", "node1", CheckFail, "failed %d", 1)
+	report.add("c", "node2", CheckWarn, "warning")
+
+	if report.Status != CheckFail {
+		t.Errorf("add() status = %v, want %v", report.Status, CheckFail)
+	}
+	if report.Pass != 1 || report.Warn != 1 || report.Fail != 1 {
+		t.Errorf("add() counts = %d/%d/%d, want 1/1/1", report.Pass, report.Warn, report.Fail)
+	}
+	if report.Results[1].Message != "failed 1" {
+		t.Errorf("add() message = %q, want %q", report.Results[1].Message, "failed 1")
+	}
+}
@@ -21,12 +21,22 @@ func List(path string, clustersName ...string) ([]*Kluster, error) {
 
 	listAll := len(clustersName) == 0
 
+	// with a shared storage, the clusters path is a copy of the storage and only
+	// the clusters in the storage are listed
+	storageDirs, err := pullClusters(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the clusters from %s. %s", remoteStorage.Name(), err)
+	}
+
 	uuidDirs, err := ioutil.ReadDir(path)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, uuidDir := range uuidDirs {
+		if storageDirs != nil && !storageDirs[uuidDir.Name()] {
+			continue
+		}
 		clusterDir, err := ioutil.ReadDir(filepath.Join(path, uuidDir.Name()))
 		if err != nil {
 			continue
@@ -33,7 +33,11 @@ func (k *Kluster) newCommandFor(nodes []string, pools []string) (*configurator.C
 	hosts := k.HostsFilterBy(nodes, pools)
 	platformConfig := k.provisioner[platform].Config()
 
-	return configurator.NewCommand(hosts, platformConfig, k.ui)
+	c, err := configurator.NewCommand(hosts, platformConfig, k.ui)
+	if err != nil || k.ctx == nil {
+		return c, err
+	}
+	return c.WithContext(k.ctx), nil
 }
 
 // CopyFile is to copy files to/form cluster nodes
@@ -57,6 +61,31 @@ func (k *Kluster) Exec(command, script string, nodes []string, pools []string, s
 	return c.Exec(command, script, sudoExec)
 }
 
+// ExecStream execute a script file or command line on every node of the
+// cluster or the selected nodes, like Exec, writing the output of every node as
+// it's received with the options to limit the parallel executions, timeout or
+// stop on the first failure
+func (k *Kluster) ExecStream(command, script string, nodes []string, pools []string, sudoExec bool, out, errOut io.Writer, opts configurator.StreamOptions) (*ssh.CommandResult, error) {
+	c, err := k.newCommandFor(nodes, pools)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ExecStream(command, script, sudoExec, out, errOut, opts)
+}
+
+// Console opens a shell on every node of the cluster or the selected nodes and
+// sends every line read from in to all of them. The output of every node is
+// prefixed with the node IP address
+func (k *Kluster) Console(nodes []string, pools []string, in io.Reader, out, errOut io.Writer) error {
+	c, err := k.newCommandFor(nodes, pools)
+	if err != nil {
+		return err
+	}
+
+	return c.Console(in, out, errOut)
+}
+
 // StartShellTo opens an interactive shell to the given host name
 func (k *Kluster) StartShellTo(nodeName string, in io.Reader, out, e io.Writer) error {
 	nodes := k.HostsFilterBy([]string{nodeName}, nil)
@@ -1,6 +1,7 @@
 package kluster
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -17,6 +18,7 @@ import (
 	"github.com/liferaft/kubekit/pkg/configurator"
 	"github.com/liferaft/kubekit/pkg/crypto/tls"
 	"github.com/liferaft/kubekit/pkg/provisioner"
+	"github.com/liferaft/kubekit/pkg/storage"
 	"github.com/nightlyone/lockfile"
 	"github.com/pelletier/go-toml"
 	"gopkg.in/yaml.v2"
@@ -85,10 +87,13 @@ type Kluster struct {
 	State        map[string]*State                  `json:"state" yaml:"state" mapstructure:"state"`                        // State of the cluster for each platform
 	Config       *configurator.Config               `json:"config,omitempty" yaml:"config,omitempty" mapstructure:"config"` // Kubernetes configuration, no matter what platform
 	Resources    []string                           `json:"resources" yaml:"resources" mapstructure:"resources"`
+	Charts       map[string]*resources.Chart        `json:"charts,omitempty" yaml:"charts,omitempty" mapstructure:"charts"` // Helm charts referenced in the resources as helm://RELEASE
 	path         string                             // Path is where the cluster configuration file is
 	provisioner  map[string]provisioner.Provisioner // List of provisioners. It's a platform that can be provisioned
 	certificates tls.KeyPairs                       // List of TLS key pairs
 	ui           *ui.UI                             // UI to print out to console
+	ctx          context.Context                    // Context to cancel the provisioning and configuration
+	savedStatus  string                             // Status in the configuration file, to notify when it changes
 }
 
 // New creates a new Kluster or load it if the file already exists
@@ -148,9 +153,12 @@ func New(name, platformName, path, format string, parentUI *ui.UI, envConfig map
 		return &cluster, nil
 	}
 
-	cluster.Config, err = configurator.DefaultConfig(envConfig)
+	if cluster.Config, err = configurator.DefaultConfig(envConfig); err != nil {
+		return &cluster, err
+	}
+	cluster.Config.CNI.DefaultFor(platformName)
 
-	return &cluster, err
+	return &cluster, nil
 }
 
 // Update updates the cluster with the given configuration
@@ -243,6 +251,7 @@ func (k *Kluster) Copy(name, platformName, path, format string, parentUI *ui.UI,
 		Name:      name,
 		Config:    k.Config,
 		Resources: k.Resources,
+		Charts:    k.Charts,
 		path:      path,
 		ui:        newUI,
 	}
@@ -506,6 +515,7 @@ func (k *Kluster) Load() error {
 	if err := k.LoadSummary(); err != nil {
 		return err
 	}
+	k.savedStatus = k.status()
 
 	// DEBUG:
 	// fmt.Printf("DEBUG: cluster %s config version: %s\tMin: %s\tMax: %s\n", k.Name, k.Version, MinSemVersion, SemVersion)
@@ -670,14 +680,22 @@ func (k *Kluster) Save() error {
 		err = fmt.Errorf("can't stringify the Kluster, unknown format %q", format)
 	}
 
-	lock, err := lockFile(k.path)
+	lock, err := k.lock(k.path)
 	if err != nil {
 		return err
 	}
 	defer lock.Unlock()
 
 	k.ui.Log.Debugf("updating cluster configuration file %s", k.path)
-	return ioutil.WriteFile(k.path, data, 0644)
+	if err := ioutil.WriteFile(k.path, data, 0644); err != nil {
+		return err
+	}
+	if err := k.push(k.path); err != nil {
+		return err
+	}
+	k.notifyStatusChange()
+
+	return nil
 }
 
 // UpdateState creates a new State structure from the given provisioner TF state
@@ -713,13 +731,37 @@ func (k *Kluster) UpdateState(platform string) {
 	k.State[platform].Nodes = hosts
 }
 
-// Lock locks the cluster so no action can be done until it's unlocked with lock.Unlock()
-func (k *Kluster) Lock(name string) (lockfile.Lockfile, error) {
+// Subscribe registers a function to receive the status of every task reported
+// by the cluster, the provisioner and the configurator. The returned function
+// cancels the subscription
+func (k *Kluster) Subscribe(fn func(ui.Notification)) (unsubscribe func()) {
+	return k.ui.Subscribe(fn)
+}
+
+// WithContext sets the context to cancel the actions on the cluster. When the
+// context is done, Terraform halts and the commands in execution on the nodes
+// are killed. The user set with notifier.WithActor is reported in the events
+func (k *Kluster) WithContext(ctx context.Context) *Kluster {
+	k.ctx = ctx
+	return k
+}
+
+// canceled returns the context error if the context is done
+func (k *Kluster) canceled() error {
+	if k.ctx == nil {
+		return nil
+	}
+	return k.ctx.Err()
+}
+
+// Lock locks the cluster so no action can be done until it's unlocked with
+// lock.Unlock(). If there is a shared storage, the cluster is also locked there
+func (k *Kluster) Lock(name string) (storage.Unlocker, error) {
 	if name == "" {
 		name = k.Name
 	}
 	f := filepath.Join(k.Dir(), "."+name)
-	return lockFile(f)
+	return k.lock(f)
 }
 
 func lockFile(filename string) (lockfile.Lockfile, error) {
@@ -2,6 +2,7 @@ package kluster
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/liferaft/kubekit/pkg/configurator"
 )
@@ -18,12 +19,15 @@ func (k *Kluster) Configure() error {
 	pConf := k.provisioner[platformName].Config()
 	clusterDir := k.Dir()
 
-	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, clusterDir, k.ui)
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, clusterDir, k.ui)
 	if err != nil {
 		return err
 	}
 
-	if err := conf.Configure(); err != nil {
+	start := time.Now()
+	err = conf.WithContext(k.ctx).Configure()
+	observeConfiguration(platformName, start, err)
+	if err != nil {
 		k.State[platformName].Status = FailedConfigurationStatus.String()
 		return err
 	}
@@ -46,12 +50,10 @@ func (k *Kluster) ApplyClientCertificates(restartServices bool) error {
 	pConf := k.provisioner[platformName].Config()
 	clusterDir := k.Dir()
 
-	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, clusterDir, k.ui)
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, clusterDir, k.ui)
 	if err != nil {
 		return err
 	}
 
-	conf.UploadCerts()
-
-	return nil
+	return conf.UploadCerts()
 }
@@ -0,0 +1,44 @@
+package kluster
+
+import (
+	"fmt"
+	"strings"
+)
+
+// containerRuntime returns the container runtime of the cluster nodes. It's
+// Docker unless the configuration sets containerd
+func (k *Kluster) containerRuntime() string {
+	if k.Config != nil && k.Config.ContainerRuntime == "containerd" {
+		return "containerd"
+	}
+	return "docker"
+}
+
+// containersCMD returns the command to list the IDs of the running containers
+// of the Kubernetes component with the given name
+func containersCMD(runtime, name string) string {
+	if runtime == "containerd" {
+		return fmt.Sprintf("/usr/local/bin/crictl ps -q --label io.kubernetes.container.name=%s", name)
+	}
+	return fmt.Sprintf("docker ps -q --filter label=io.kubernetes.container.name=%s", name)
+}
+
+// restartContainersCMD returns the command to restart the containers of the
+// given Kubernetes components and then the kubelet. With containerd the
+// containers are stopped, so the kubelet starts them again
+func restartContainersCMD(runtime string, names ...string) string {
+	restart := "docker restart"
+	if runtime == "containerd" {
+		restart = "/usr/local/bin/crictl stop"
+	}
+	return fmt.Sprintf("sh -c 'for c in %s; do %s | xargs -r %s; done; systemctl restart kubelet'", strings.Join(names, " "), containersCMD(runtime, "$c"), restart)
+}
+
+// powerServicesCMD returns the command to stop or start the Kubernetes services.
+// Systemd orders the services by their dependencies
+func powerServicesCMD(runtime string, on bool) string {
+	if on {
+		return fmt.Sprintf("systemctl start %s kubelet", runtime)
+	}
+	return fmt.Sprintf("systemctl stop kubelet %s", runtime)
+}
@@ -0,0 +1,69 @@
+package kluster
+
+import (
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+)
+
+func TestKluster_containerRuntime(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *configurator.Config
+		want   string
+	}{
+		{"no config", nil, "docker"},
+		{"not set", &configurator.Config{}, "docker"},
+		{"docker", &configurator.Config{ContainerRuntime: "docker"}, "docker"},
+		{"containerd", &configurator.Config{ContainerRuntime: "containerd"}, "containerd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &Kluster{Config: tt.config}
+			if got := k.containerRuntime(); got != tt.want {
+				t.Errorf("Kluster.containerRuntime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_restartContainersCMD(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		names   []string
+		want    string
+	}{
+		{"docker control plane", "docker", []string{"etcd", "kube-apiserver"}, "sh -c 'for c in etcd kube-apiserver; do docker ps -q --filter label=io.kubernetes.container.name=$c | xargs -r docker restart; done; systemctl restart kubelet'"},
+		{"docker node", "docker", []string{"kube-proxy"}, "sh -c 'for c in kube-proxy; do docker ps -q --filter label=io.kubernetes.container.name=$c | xargs -r docker restart; done; systemctl restart kubelet'"},
+		{"containerd node", "containerd", []string{"kube-proxy"}, "sh -c 'for c in kube-proxy; do /usr/local/bin/crictl ps -q --label io.kubernetes.container.name=$c | xargs -r /usr/local/bin/crictl stop; done; systemctl restart kubelet'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restartContainersCMD(tt.runtime, tt.names...); got != tt.want {
+				t.Errorf("restartContainersCMD() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_powerServicesCMD(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		on      bool
+		want    string
+	}{
+		{"start docker", "docker", true, "systemctl start docker kubelet"},
+		{"stop docker", "docker", false, "systemctl stop kubelet docker"},
+		{"start containerd", "containerd", true, "systemctl start containerd kubelet"},
+		{"stop containerd", "containerd", false, "systemctl stop kubelet containerd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := powerServicesCMD(tt.runtime, tt.on); got != tt.want {
+				t.Errorf("powerServicesCMD() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
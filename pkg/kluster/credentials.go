@@ -9,8 +9,6 @@ import (
 	"reflect"
 	"strings"
 	"text/tabwriter"
-
-	yaml "gopkg.in/yaml.v2"
 )
 
 const (
@@ -65,7 +63,7 @@ func NewCredentials(clustername, platform, path string) CredentialHandler {
 	switch platform {
 	case "ec2", "eks":
 		return NewAWSCredentials(clustername, path)
-	case "aks":
+	case "aks", "azure":
 		return NewAzureCredentials(clustername, path)
 	default:
 		return NewPlatformCredentials(clustername, platform, path)
@@ -197,13 +195,10 @@ func (c *PlatformCredentials) Ask() error {
 	)
 }
 
-// Read reads the platform credentials from the cluster credentials file
+// Read reads the platform credentials from the credentials backend, by default
+// the cluster credentials file
 func (c *PlatformCredentials) Read() error {
-	credentialsBytes, err := read(c.path)
-	if err != nil || credentialsBytes == nil {
-		return err
-	}
-	return yaml.Unmarshal(credentialsBytes, &c)
+	return credentialsBackend.Read(c)
 }
 
 func read(path string) ([]byte, error) {
@@ -213,13 +208,10 @@ func read(path string) ([]byte, error) {
 	return ioutil.ReadFile(path)
 }
 
-// Write writes the platform credentials to the cluster credentials file
+// Write writes the platform credentials to the credentials backend, by default
+// the cluster credentials file encrypted
 func (c *PlatformCredentials) Write() error {
-	credentialsBytes, err := yaml.Marshal(c)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(c.path, credentialsBytes, 0600)
+	return credentialsBackend.Write(c)
 }
 
 func printCredentials(header, row string) {
@@ -15,7 +15,6 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/go-ini/ini"
 	homedir "github.com/mitchellh/go-homedir"
-	yaml "gopkg.in/yaml.v2"
 )
 
 // AwsCredentials represents the credentials just for AWS
@@ -180,22 +179,16 @@ func (c *AwsCredentials) Ask() error {
 	)
 }
 
-// Read reads the AWS credentials from the cluster credentials file
+// Read reads the AWS credentials from the credentials backend, by default
+// the cluster credentials file
 func (c *AwsCredentials) Read() error {
-	credentialsBytes, err := read(c.path)
-	if err != nil || credentialsBytes == nil {
-		return err
-	}
-	return yaml.Unmarshal(credentialsBytes, &c)
+	return credentialsBackend.Read(c)
 }
 
-// Write writes the AWS credentials to the cluster credentials file
+// Write writes the AWS credentials to the credentials backend, by default
+// the cluster credentials file encrypted
 func (c *AwsCredentials) Write() error {
-	credentialsBytes, err := yaml.Marshal(c)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(c.path, credentialsBytes, 0600)
+	return credentialsBackend.Write(c)
 }
 
 // LoadSharedCredentialsFromProfile loads the AWS credentials from the AWS shared credentials file for the given profile
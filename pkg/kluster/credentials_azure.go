@@ -2,11 +2,8 @@ package kluster
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"strings"
-
-	yaml "gopkg.in/yaml.v2"
 )
 
 // AzureCredentials represents the credentials just for AWS
@@ -157,20 +154,14 @@ func (c *AzureCredentials) Ask() error {
 	)
 }
 
-// Read reads the Azure credentials from the cluster credentials file
+// Read reads the Azure credentials from the credentials backend, by default
+// the cluster credentials file
 func (c *AzureCredentials) Read() error {
-	credentialsBytes, err := read(c.path)
-	if err != nil || credentialsBytes == nil {
-		return err
-	}
-	return yaml.Unmarshal(credentialsBytes, &c)
+	return credentialsBackend.Read(c)
 }
 
-// Write writes the Azure credentials to the cluster credentials file
+// Write writes the Azure credentials to the credentials backend, by default
+// the cluster credentials file encrypted
 func (c *AzureCredentials) Write() error {
-	credentialsBytes, err := yaml.Marshal(c)
-	if err != nil {
-		return err
-	}
-	return ioutil.WriteFile(c.path, credentialsBytes, 0600)
+	return credentialsBackend.Write(c)
 }
@@ -0,0 +1,171 @@
+package kluster
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/crypto"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// CredentialsBackend is where the platform credentials of the clusters are
+// kept. Every CredentialHandler reads and writes the credentials with the
+// backend in use
+type CredentialsBackend interface {
+	// Name returns a human readable location of the backend
+	Name() string
+	// Read reads the credentials of the cluster into the given handler. It's
+	// not an error if there are no credentials for the cluster
+	Read(CredentialHandler) error
+	// Write writes the credentials of the given handler
+	Write(CredentialHandler) error
+}
+
+// credentialsBackend is the backend where the credentials are read and
+// written, by default the credentials file in the cluster directory
+var credentialsBackend CredentialsBackend = &FileCredentials{}
+
+// SetCredentialsBackend sets the backend for the platform credentials, or the
+// default encrypted credentials file if it's nil
+func SetCredentialsBackend(b CredentialsBackend) {
+	if b == nil {
+		b = &FileCredentials{}
+	}
+	credentialsBackend = b
+}
+
+// NewCredentialsBackend returns the credentials backend for the given URL. The
+// supported URLs are:
+//
+//   - empty or `file://`, the credentials file in the cluster directory
+//   - `vault://host:8200/mount/prefix?tls=false&kv=1`, the Vault KV secrets
+//     engine. TLS is used unless `tls=false` and the KV version is 2 unless
+//     `kv=1`. The token is taken from the VAULT_TOKEN environment variable
+func NewCredentialsBackend(backendURL string) (CredentialsBackend, error) {
+	if len(backendURL) == 0 {
+		return &FileCredentials{}, nil
+	}
+
+	u, err := url.Parse(backendURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials backend URL %q. %s", backendURL, err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		return &FileCredentials{}, nil
+	case "vault":
+		if len(u.Host) == 0 {
+			return nil, fmt.Errorf("the Vault address is required in the credentials backend URL %q", backendURL)
+		}
+		path := strings.Trim(u.Path, "/")
+		if len(path) == 0 {
+			return nil, fmt.Errorf("the KV secrets engine mount is required in the credentials backend URL %q", backendURL)
+		}
+		mount, prefix := path, ""
+		if i := strings.Index(path, "/"); i != -1 {
+			mount, prefix = path[:i], path[i+1:]
+		}
+
+		q := u.Query()
+		scheme := "https"
+		if q.Get("tls") == "false" {
+			scheme = "http"
+		}
+		version := 2
+		if q.Get("kv") == "1" {
+			version = 1
+		}
+
+		return NewVaultCredentials(scheme+"://"+u.Host, os.Getenv(vaultTokenEnv), mount, prefix, version)
+	default:
+		return nil, fmt.Errorf("unknown credentials backend %q, the supported backends are 'file' and 'vault'", u.Scheme)
+	}
+}
+
+// FileCredentials keeps the credentials in the credentials file of the cluster
+// directory, encrypted with the KubeKit key (KUBEKIT_KEY). The key is required,
+// the credentials are never encrypted with the default passphrase. If there is
+// a shared storage the file is also kept there
+type FileCredentials struct{}
+
+// Name returns a human readable location of the backend
+func (f *FileCredentials) Name() string {
+	return "credentials file"
+}
+
+// Read reads and decrypts the credentials file. The files in plain text, from
+// previous versions, are also read and encrypted the next time they are written
+func (f *FileCredentials) Read(c CredentialHandler) error {
+	if err := pullClusterFile(c.clusterPath()); err != nil {
+		return fmt.Errorf("failed to get the credentials file from %s. %s", remoteStorage.Name(), err)
+	}
+
+	credentialsBytes, err := read(c.clusterPath())
+	if err != nil || credentialsBytes == nil {
+		return err
+	}
+
+	// an encrypted file is just one DEC() value, a YAML file may have a DEC()
+	// inside a credential value
+	text := strings.TrimSpace(string(credentialsBytes))
+	if strings.HasPrefix(text, crypto.ActionDec.String()+"(") && strings.HasSuffix(text, ")") && !strings.Contains(text, "\n") {
+		cr, err := credentialsCrypto()
+		if err != nil {
+			return fmt.Errorf("cannot decrypt the credentials file %s. %s", c.clusterPath(), err)
+		}
+		if credentialsBytes, err = cr.DecryptValue(text); err != nil {
+			return fmt.Errorf("failed to decrypt the credentials file %s. %s", c.clusterPath(), err)
+		}
+	}
+
+	if err := yaml.Unmarshal(credentialsBytes, c); err != nil {
+		return fmt.Errorf("failed to read the credentials file %s, if it's encrypted verify the KubeKit key in %s. %s", c.clusterPath(), crypto.EnvKeyName, err)
+	}
+	return nil
+}
+
+// Write encrypts and writes the credentials file. The empty credentials, like
+// the ones of the platforms without credentials, are not encrypted
+func (f *FileCredentials) Write(c CredentialHandler) error {
+	credentialsBytes, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Empty() {
+		if err := ioutil.WriteFile(c.clusterPath(), credentialsBytes, 0600); err != nil {
+			return err
+		}
+		return pushClusterFile(c.clusterPath())
+	}
+
+	cr, err := credentialsCrypto()
+	if err != nil {
+		return fmt.Errorf("refusing to write the credentials file %s. %s", c.clusterPath(), err)
+	}
+	// the YAML is encrypted as is, EncryptValue would remove any ENC() in it
+	encCredentials, err := cr.Encrypt(credentialsBytes)
+	if err != nil {
+		return err
+	}
+	encCredentials = fmt.Sprintf("%s(%s)\n", crypto.ActionDec, encCredentials)
+
+	if err := ioutil.WriteFile(c.clusterPath(), []byte(encCredentials), 0600); err != nil {
+		return err
+	}
+	return pushClusterFile(c.clusterPath())
+}
+
+// credentialsCrypto returns the crypto to encrypt and decrypt the credentials
+// file with the KubeKit key, it fails if the key is not set
+func credentialsCrypto() (*crypto.Crypto, error) {
+	key, err := crypto.EnvKey()
+	if err != nil {
+		return nil, fmt.Errorf("%s. Export %s with a key of 16, 24 or 32 characters to encrypt the credentials, or use the Vault credentials backend", err, crypto.EnvKeyName)
+	}
+	return crypto.New(key)
+}
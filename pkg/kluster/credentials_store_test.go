@@ -0,0 +1,135 @@
+package kluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/crypto"
+)
+
+func TestFileCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, CredentialsFileName)
+
+	backend := &FileCredentials{}
+	creds := NewPlatformCredentials("testCluster", "vsphere", path)
+	creds.SetParameters("vcenter.example.com", "admin", "5uperSecure!Pa55w0rd")
+
+	// the credentials are not encrypted with the default key
+	os.Unsetenv(crypto.EnvKeyName)
+	if err := backend.Write(creds); err == nil {
+		t.Fatalf("Write() expected an error without the KubeKit key")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Write() wrote the credentials file without the KubeKit key")
+	}
+
+	os.Setenv(crypto.EnvKeyName, "Cred3ntialsKey16")
+	defer os.Unsetenv(crypto.EnvKeyName)
+	if err := backend.Write(creds); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"vcenter.example.com", "admin", "5uperSecure!Pa55w0rd"} {
+		if strings.Contains(string(content), value) {
+			t.Errorf("Write() the credentials file has %q in plain text", value)
+		}
+	}
+
+	got := NewPlatformCredentials("testCluster", "vsphere", path)
+	if err := backend.Read(got); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.Server != creds.Server || got.Username != creds.Username || got.Password != creds.Password {
+		t.Errorf("Read() = %+v, want %+v", got, creds)
+	}
+
+	os.Unsetenv(crypto.EnvKeyName)
+	if err := backend.Read(NewPlatformCredentials("testCluster", "vsphere", path)); err == nil {
+		t.Errorf("Read() expected an error decrypting without the KubeKit key")
+	}
+}
+
+func TestFileCredentials_WriteEmpty(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, CredentialsFileName)
+
+	os.Unsetenv(crypto.EnvKeyName)
+	backend := &FileCredentials{}
+	if err := backend.Write(NewPlatformCredentials("testCluster", "raw", path)); err != nil {
+		t.Fatalf("Write() empty credentials error = %v", err)
+	}
+	got := NewPlatformCredentials("testCluster", "raw", path)
+	if err := backend.Read(got); err != nil {
+		t.Fatalf("Read() empty credentials error = %v", err)
+	}
+	if !got.Empty() {
+		t.Errorf("Read() = %+v, want empty credentials", got)
+	}
+}
+
+func TestFileCredentials_ReadPlainText(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, CredentialsFileName)
+
+	plain := "platform: AWS\naccess_key: AKIAEXAMPLE\nsecret_key: DEC(secret)\nsession_token: \"\"\nregion: us-west-2\n"
+	if err := ioutil.WriteFile(path, []byte(plain), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got := NewAWSCredentials("testCluster", path)
+	if err := (&FileCredentials{}).Read(got); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if got.AccessKey != "AKIAEXAMPLE" || got.SecretKey != "DEC(secret)" || got.Region != "us-west-2" {
+		t.Errorf("Read() = %+v, want the credentials in the plain text file", got)
+	}
+}
+
+func TestNewCredentialsBackend(t *testing.T) {
+	os.Setenv(vaultTokenEnv, "token")
+	defer os.Unsetenv(vaultTokenEnv)
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"default", "", "credentials file", false},
+		{"file", "file://", "credentials file", false},
+		{"vault", "vault://127.0.0.1:8200/secret/kubekit?tls=false", "Vault http://127.0.0.1:8200/secret/kubekit", false},
+		{"vault without mount", "vault://127.0.0.1:8200", "", true},
+		{"unknown", "s3://bucket", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCredentialsBackend(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCredentialsBackend() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name() != tt.want {
+				t.Errorf("NewCredentialsBackend() = %q, want %q", got.Name(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package kluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// vaultTokenEnv is the environment variable with the Vault token, the same
+// used by the Vault CLI
+const vaultTokenEnv = "VAULT_TOKEN"
+
+// VaultCredentials keeps the credentials in the KV secrets engine of HashiCorp
+// Vault, version 1 or 2. The credentials of every cluster are a secret named
+// as the cluster, under the given prefix
+type VaultCredentials struct {
+	address string
+	token   string
+	mount   string
+	prefix  string
+	version int
+	http    *http.Client
+}
+
+// NewVaultCredentials creates a Vault credentials backend for the KV secrets
+// engine mounted in the given path
+func NewVaultCredentials(address, token, mount, prefix string, version int) (*VaultCredentials, error) {
+	if len(token) == 0 {
+		return nil, fmt.Errorf("the Vault token is required, set it in the environment variable %s", vaultTokenEnv)
+	}
+	if version != 1 && version != 2 {
+		return nil, fmt.Errorf("unknown version %d of the Vault KV secrets engine", version)
+	}
+
+	return &VaultCredentials{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		prefix:  strings.Trim(prefix, "/"),
+		version: version,
+		http: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// Name returns a human readable location of the backend
+func (v *VaultCredentials) Name() string {
+	return fmt.Sprintf("Vault %s/%s", v.address, path.Join(v.mount, v.prefix))
+}
+
+// secretURL returns the URL to read or write the secret of the given cluster
+func (v *VaultCredentials) secretURL(clusterName string) string {
+	if v.version == 1 {
+		return v.address + "/v1/" + path.Join(v.mount, v.prefix, clusterName)
+	}
+	return v.address + "/v1/" + path.Join(v.mount, "data", v.prefix, clusterName)
+}
+
+// Read reads the secret with the cluster credentials. It's not an error if
+// the secret does not exists
+func (v *VaultCredentials) Read(c CredentialHandler) error {
+	req, err := http.NewRequest("GET", v.secretURL(c.clusterName()), nil)
+	if err != nil {
+		return err
+	}
+	body, status, err := v.do(req)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return nil
+	}
+
+	secret := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return fmt.Errorf("failed to decode the credentials of cluster %q from Vault. %s", c.clusterName(), err)
+	}
+
+	data := secret.Data
+	// the KV version 2 has the secret data and metadata inside the data
+	if v.version == 2 {
+		versioned := struct {
+			Data json.RawMessage `json:"data"`
+		}{}
+		if err := json.Unmarshal(secret.Data, &versioned); err != nil {
+			return fmt.Errorf("failed to decode the credentials of cluster %q from Vault. %s", c.clusterName(), err)
+		}
+		data = versioned.Data
+	}
+
+	params := map[string]string{}
+	if len(data) != 0 && string(data) != "null" {
+		if err := json.Unmarshal(data, &params); err != nil {
+			return fmt.Errorf("failed to decode the credentials of cluster %q from Vault. %s", c.clusterName(), err)
+		}
+	}
+
+	return c.AssignFromMap(params)
+}
+
+// Write writes the cluster credentials to the secret, replacing the existing
+// credentials
+func (v *VaultCredentials) Write(c CredentialHandler) error {
+	var payload interface{} = c.asMap()
+	if v.version == 2 {
+		payload = map[string]interface{}{
+			"data": c.asMap(),
+		}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", v.secretURL(c.clusterName()), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, status, err := v.do(req)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("not found the KV secrets engine %q in Vault %s", v.mount, v.address)
+	}
+	return nil
+}
+
+// do sends the request to Vault and returns the response body and status. A
+// not found status is not an error
+func (v *VaultCredentials) do(req *http.Request) ([]byte, int, error) {
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.http.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to connect to Vault %s. %s", v.address, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return body, resp.StatusCode, nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, resp.StatusCode, fmt.Errorf("the Vault request %s %s failed. %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, resp.StatusCode, nil
+}
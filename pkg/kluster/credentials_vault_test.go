@@ -0,0 +1,108 @@
+package kluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeVault is a Vault server with a KV secrets engine mounted in `secret`
+type fakeVault struct {
+	mu      sync.Mutex
+	version int
+	secrets map[string]map[string]string
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != "token" {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/v1/secret/")
+	if f.version == 2 {
+		if !strings.HasPrefix(key, "data/") {
+			http.NotFound(w, r)
+			return
+		}
+		key = strings.TrimPrefix(key, "data/")
+	}
+
+	switch r.Method {
+	case "GET":
+		data, ok := f.secrets[key]
+		if !ok {
+			http.Error(w, `{"errors":[]}`, http.StatusNotFound)
+			return
+		}
+		if f.version == 2 {
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": data}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	case "POST":
+		data := map[string]string{}
+		if f.version == 2 {
+			payload := struct {
+				Data map[string]string `json:"data"`
+			}{}
+			json.NewDecoder(r.Body).Decode(&payload)
+			data = payload.Data
+		} else {
+			json.NewDecoder(r.Body).Decode(&data)
+		}
+		f.secrets[key] = data
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestVaultCredentials(t *testing.T) {
+	for _, version := range []int{1, 2} {
+		fake := &fakeVault{version: version, secrets: map[string]map[string]string{}}
+		server := httptest.NewServer(fake)
+
+		backend, err := NewVaultCredentials(server.URL, "token", "secret", "kubekit", version)
+		if err != nil {
+			t.Fatalf("NewVaultCredentials() error = %v", err)
+		}
+
+		// reading the credentials of a cluster without them is not an error
+		empty := NewAWSCredentials("testCluster", "")
+		if err := backend.Read(empty); err != nil {
+			t.Errorf("Read() KV v%d without secret error = %v", version, err)
+		}
+		if !empty.Empty() {
+			t.Errorf("Read() KV v%d without secret = %+v, want empty credentials", version, empty)
+		}
+
+		creds := NewAWSCredentials("testCluster", "")
+		creds.SetParameters("AKIAEXAMPLE", "secretKey", "", "us-west-2")
+		if err := backend.Write(creds); err != nil {
+			t.Fatalf("Write() KV v%d error = %v", version, err)
+		}
+		if got := fake.secrets["kubekit/testCluster"]["secret_key"]; got != "secretKey" {
+			t.Errorf("Write() KV v%d secret_key = %q, want %q", version, got, "secretKey")
+		}
+
+		got := NewAWSCredentials("testCluster", "")
+		if err := backend.Read(got); err != nil {
+			t.Fatalf("Read() KV v%d error = %v", version, err)
+		}
+		if got.AccessKey != creds.AccessKey || got.SecretKey != creds.SecretKey || got.Region != creds.Region {
+			t.Errorf("Read() KV v%d = %+v, want %+v", version, got, creds)
+		}
+
+		denied, _ := NewVaultCredentials(server.URL, "wrong", "secret", "kubekit", version)
+		if err := denied.Read(got); err == nil {
+			t.Errorf("Read() KV v%d expected an error with an invalid token", version)
+		}
+
+		server.Close()
+	}
+}
@@ -0,0 +1,30 @@
+package kluster
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/configurator/resources"
+)
+
+// DiffResources compares the Kubernetes resources of the cluster, rendered with
+// the current cluster configuration, with the live objects in the cluster
+func (k *Kluster) DiffResources() ([]resources.ResourceDrift, error) {
+	if err := k.LoadState(); err != nil {
+		return nil, err
+	}
+
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("Diff [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	pConf := k.provisioner[platformName].Config()
+	clusterDir := k.Dir()
+
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, clusterDir, k.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	return conf.DiffResources()
+}
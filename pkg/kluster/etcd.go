@@ -0,0 +1,44 @@
+package kluster
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+)
+
+// EtcdSnapshotsDir is the directory on the master nodes where the etcd
+// snapshots are stored. It's the same directory used by the etcd snapshots
+// cron job created by the configurator
+const EtcdSnapshotsDir = "/data/etcd-snapshots"
+
+// etcdctlCMD is the etcdctl command executed on the master nodes with the
+// certificates to access the local etcd member
+const etcdctlCMD = "ETCDCTL_API=3 /etc/kubernetes/bin/etcdctl --endpoints=https://127.0.0.1:2379 --cert=/etc/pki/etcd_node.crt --key=/etc/pki/etcd_node.key"
+
+// SnapshotEtcd takes a snapshot of the etcd database on the first available
+// master node. The snapshot is stored in the etcd snapshots directory of that
+// node with the given name. Returns the master node and the snapshot file path
+func (k *Kluster) SnapshotEtcd(name string) (configurator.Host, string, error) {
+	masters := k.HostsFilterBy(nil, []string{"master"})
+	if len(masters) == 0 {
+		return configurator.Host{}, "", fmt.Errorf("not found master nodes in the cluster %q", k.Name)
+	}
+
+	snapshotFile := filepath.Join(EtcdSnapshotsDir, name+".db")
+	command := fmt.Sprintf("mkdir -p %s && %s snapshot save %s", EtcdSnapshotsDir, etcdctlCMD, snapshotFile)
+
+	errMsgs := []string{}
+	for _, master := range masters {
+		err := k.execOnHost(master, fmt.Sprintf("sh -c '%s'", command), true)
+		if err == nil {
+			k.ui.Log.Infof("etcd snapshot saved on %s at %s", master.PublicIP, snapshotFile)
+			return master, snapshotFile, nil
+		}
+		k.ui.Log.Warnf("failed to take the etcd snapshot on %s. %s", master.PublicIP, err)
+		errMsgs = append(errMsgs, err.Error())
+	}
+
+	return configurator.Host{}, "", fmt.Errorf("failed to take the etcd snapshot on the master nodes: %s", strings.Join(errMsgs, ", "))
+}
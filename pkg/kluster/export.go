@@ -70,7 +70,7 @@ func (k *Kluster) ExportK8s() error {
 	pConf := k.provisioner[platformName].Config()
 	clusterDir := k.Dir()
 
-	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, clusterDir, k.ui)
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, clusterDir, k.ui)
 	if err != nil {
 		return err
 	}
@@ -78,5 +78,5 @@ func (k *Kluster) ExportK8s() error {
 	logPrefix = fmt.Sprintf("Export [ %s@%s ]", k.Name, platformName)
 	k.ui.SetLogPrefix(logPrefix)
 
-	return conf.ApplyResources(true)
+	return conf.ApplyResources(true, false)
 }
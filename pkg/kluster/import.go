@@ -0,0 +1,61 @@
+package kluster
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Import brings existing nodes, grouped by node pool, under the management of
+// KubeKit. The number of nodes of every node pool is set to the number of nodes
+// to import, then the nodes are imported into the Terraform state so the next
+// apply or delete manage them. The resources that are not imported, such as
+// load balancers or security rules, are created in the next apply
+func (k *Kluster) Import(ids map[string][]string) error {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	if len(ids) == 0 {
+		return fmt.Errorf("there are no nodes to import into the cluster %q", k.Name)
+	}
+
+	poolNames := make([]string, 0, len(ids))
+	for poolName := range ids {
+		poolNames = append(poolNames, poolName)
+	}
+	sort.Strings(poolNames)
+
+	for _, poolName := range poolNames {
+		if err := k.SetNodePoolCount(poolName, len(ids[poolName])); err != nil {
+			return err
+		}
+	}
+	if err := k.Save(); err != nil {
+		return err
+	}
+
+	if err := k.LoadState(); err != nil {
+		return err
+	}
+	p := k.provisioner[platformName]
+
+	k.ui.Log.Infof("importing the nodes of the node pools %v into the cluster %q", poolNames, k.Name)
+
+	logPrefix = fmt.Sprintf("Provisioner [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	err := p.Import(ids)
+	defer k.SaveState()
+	defer k.ui.TerminateAllNotifications("")
+
+	logPrefix = fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	if err != nil {
+		k.State[platformName].Status = FailedProvisioningStatus.String()
+		return err
+	}
+	k.State[platformName].Status = ProvisionedStatus.String()
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package kluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	homedir "github.com/mitchellh/go-homedir"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Inventory formats
+const (
+	InventoryAnsible      = "ansible"
+	InventorySSHConfig    = "ssh-config"
+	InventoryPrometheusSD = "prometheus-sd"
+	InventoryHosts        = "hosts"
+)
+
+// InventoryFormats are all the inventory formats, sorted as they are exported
+var InventoryFormats = []string{InventoryAnsible, InventorySSHConfig, InventoryPrometheusSD, InventoryHosts}
+
+// inventoryFilenames are the files where every inventory format is exported
+var inventoryFilenames = map[string]string{
+	InventoryAnsible:      "inventory.yaml",
+	InventorySSHConfig:    "ssh_config",
+	InventoryPrometheusSD: "prometheus-sd.json",
+	InventoryHosts:        "hosts",
+}
+
+// nodeExporterPort is the port of the Prometheus node-exporter on every node
+const nodeExporterPort = 9100
+
+// inventoryPath is the directory where the inventory of every cluster is kept
+// updated after every apply or delete. If it's empty, the inventory is only
+// exported on request
+var inventoryPath string
+
+// SetInventoryPath sets the directory to keep the clusters inventory updated,
+// or unset it if it's empty
+func SetInventoryPath(path string) {
+	inventoryPath = path
+}
+
+// ValidInventoryFormat returns true if the given inventory format is supported
+func ValidInventoryFormat(format string) bool {
+	_, ok := inventoryFilenames[format]
+	return ok
+}
+
+// inventoryNode is a cluster node with the addresses used in the inventory
+type inventoryNode struct {
+	configurator.Host
+	name  string // name of the node in the cluster, like master000
+	group string // inventory group, like master
+}
+
+// sshAddress returns the address KubeKit uses to access the node with SSH
+func (n inventoryNode) sshAddress() string {
+	if len(n.PublicIP) != 0 {
+		return n.PublicIP
+	}
+	return n.PrivateIP
+}
+
+// privateAddress returns the address to access the node from the cluster network
+func (n inventoryNode) privateAddress() string {
+	if len(n.PrivateIP) != 0 {
+		return n.PrivateIP
+	}
+	return n.PublicIP
+}
+
+// inventoryNodes returns the cluster nodes sorted by name
+func (k *Kluster) inventoryNodes() []inventoryNode {
+	state, ok := k.State[k.Platform()]
+	if !ok || state == nil {
+		return nil
+	}
+
+	// the nodes are named as the configurator does, the role name followed by
+	// the zero padded index of the node in the role, like master000
+	roleNameFormat := fmt.Sprintf("%%s%%0%dd", configurator.ZeroPadLen)
+	counter := map[string]int{}
+	nodes := make([]inventoryNode, 0, len(state.Nodes))
+	for _, host := range state.Nodes {
+		name := fmt.Sprintf(roleNameFormat, host.RoleName, counter[host.RoleName])
+		counter[host.RoleName]++
+		nodes = append(nodes, inventoryNode{
+			Host:  host,
+			name:  name,
+			group: configurator.InventoryGroup(name),
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].name < nodes[j].name
+	})
+
+	return nodes
+}
+
+// sshUserAndKey returns the user and private key file to access the nodes with
+// SSH. If the private key is only in the cluster configuration, it's saved in
+// the cluster certificates directory
+func (k *Kluster) sshUserAndKey() (string, string, error) {
+	platform, ok := k.provisioner[k.Platform()]
+	if !ok {
+		return "", "", fmt.Errorf("not found platform named %s", k.Platform())
+	}
+
+	platformConfig := make(map[string]interface{})
+	pConfigB, err := json.Marshal(platform.Config())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal the platform configuration. %s", err)
+	}
+	json.Unmarshal(pConfigB, &platformConfig)
+
+	var username string
+	if u, ok := platformConfig["username"].(string); ok {
+		username = u
+	}
+
+	privKeyFile, privKey, _ := platform.GetPrivateKey()
+	if len(privKeyFile) != 0 {
+		if privKeyFile, err = homedir.Expand(privKeyFile); err != nil {
+			return "", "", err
+		}
+		if privKeyFile, err = filepath.Abs(privKeyFile); err != nil {
+			return "", "", err
+		}
+		if _, err := os.Stat(privKeyFile); err == nil {
+			return username, privKeyFile, nil
+		}
+	}
+	if len(privKey) == 0 {
+		return username, "", nil
+	}
+
+	key, err := configurator.GetPrivateKey(platformConfig)
+	if err != nil {
+		return "", "", err
+	}
+	if privKeyFile, err = k.certFilePath(privateKeyFileName); err != nil {
+		return "", "", err
+	}
+	if err := writeKeyFile(privKeyFile, []byte(key)); err != nil {
+		return "", "", err
+	}
+
+	return username, privKeyFile, nil
+}
+
+// Inventory returns the inventory of the cluster nodes in the given format:
+// an Ansible inventory (ansible), an SSH config file (ssh-config), Prometheus
+// file_sd targets for node-exporter (prometheus-sd) or /etc/hosts lines (hosts)
+func (k *Kluster) Inventory(format string) ([]byte, error) {
+	switch format {
+	case InventoryAnsible:
+		return k.ansibleInventory()
+	case InventorySSHConfig:
+		return k.sshConfigInventory()
+	case InventoryPrometheusSD:
+		return k.prometheusSDInventory()
+	case InventoryHosts:
+		return k.hostsInventory()
+	default:
+		return nil, fmt.Errorf("unknown inventory format %q, the supported formats are: %s", format, strings.Join(InventoryFormats, ", "))
+	}
+}
+
+// ansibleInventory returns a standalone Ansible inventory, with the same groups
+// as the inventory used to configure the cluster, to run playbooks on the nodes
+func (k *Kluster) ansibleInventory() ([]byte, error) {
+	username, keyFile, err := k.sshUserAndKey()
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{
+		"cluster_name":   k.Name,
+		"cloud_provider": k.Platform(),
+	}
+	if len(username) != 0 {
+		vars["ansible_user"] = username
+	}
+	if len(keyFile) != 0 {
+		vars["ansible_ssh_private_key_file"] = keyFile
+	}
+
+	groups := map[string]interface{}{}
+	for _, node := range k.inventoryNodes() {
+		host := map[string]interface{}{
+			"ansible_host": node.sshAddress(),
+		}
+		for name, value := range map[string]string{
+			"private_ip":  node.PrivateIP,
+			"public_ip":   node.PublicIP,
+			"private_dns": node.PrivateDNS,
+			"public_dns":  node.PublicDNS,
+			"pool":        node.Pool,
+		} {
+			if len(value) != 0 {
+				host[name] = value
+			}
+		}
+
+		group, ok := groups[node.group].(map[string]interface{})
+		if !ok {
+			group = map[string]interface{}{"hosts": map[string]interface{}{}}
+			groups[node.group] = group
+		}
+		group["hosts"].(map[string]interface{})[node.name] = host
+	}
+
+	inventory := map[string]interface{}{
+		"all": map[string]interface{}{
+			"vars": vars,
+			"children": map[string]interface{}{
+				"kube_cluster": map[string]interface{}{
+					"children": groups,
+				},
+			},
+		},
+	}
+
+	return yaml.Marshal(inventory)
+}
+
+// sshConfigInventory returns the ~/.ssh/config entries to access every node as
+// `ssh CLUSTER-NODE`, for example `ssh kubedemo-master001`
+func (k *Kluster) sshConfigInventory() ([]byte, error) {
+	username, keyFile, err := k.sshUserAndKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# KubeKit cluster %s on %s\n", k.Name, k.Platform())
+	for _, node := range k.inventoryNodes() {
+		fmt.Fprintf(&b, "\nHost %s-%s\n", k.Name, node.name)
+		fmt.Fprintf(&b, "  HostName %s\n", node.sshAddress())
+		if len(username) != 0 {
+			fmt.Fprintf(&b, "  User %s\n", username)
+		}
+		if len(keyFile) != 0 {
+			fmt.Fprintf(&b, "  IdentityFile %q\n", keyFile)
+			fmt.Fprintf(&b, "  IdentitiesOnly yes\n")
+		}
+	}
+
+	return b.Bytes(), nil
+}
+
+// prometheusTargetGroup is a group of targets of the Prometheus file-based
+// service discovery
+type prometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// prometheusSDInventory returns the node-exporter targets of every node for the
+// Prometheus file-based service discovery (file_sd_configs)
+func (k *Kluster) prometheusSDInventory() ([]byte, error) {
+	groups := []prometheusTargetGroup{}
+	for _, node := range k.inventoryNodes() {
+		labels := map[string]string{
+			"cluster":  k.Name,
+			"platform": k.Platform(),
+			"node":     node.name,
+			"group":    node.group,
+		}
+		if len(node.Pool) != 0 {
+			labels["pool"] = node.Pool
+		}
+		groups = append(groups, prometheusTargetGroup{
+			Targets: []string{node.privateAddress() + ":" + strconv.Itoa(nodeExporterPort)},
+			Labels:  labels,
+		})
+	}
+
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// hostsInventory returns the /etc/hosts lines to resolve the nodes by the same
+// name used in the SSH config and by their private DNS name
+func (k *Kluster) hostsInventory() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# KubeKit cluster %s on %s\n", k.Name, k.Platform())
+	for _, node := range k.inventoryNodes() {
+		names := []string{k.Name + "-" + node.name}
+		if len(node.PrivateDNS) != 0 {
+			names = append(names, node.PrivateDNS)
+			if short := strings.Split(node.PrivateDNS, ".")[0]; short != node.PrivateDNS {
+				names = append(names, short)
+			}
+		}
+		fmt.Fprintf(&b, "%s\t%s\n", node.sshAddress(), strings.Join(names, " "))
+	}
+
+	return b.Bytes(), nil
+}
+
+// ExportInventory writes the inventory of the cluster nodes in every format to
+// the given directory
+func (k *Kluster) ExportInventory(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, format := range InventoryFormats {
+		data, err := k.Inventory(format)
+		if err != nil {
+			return err
+		}
+		filename := filepath.Join(dir, inventoryFilenames[format])
+		if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateInventory updates the inventory of the cluster in the inventory path,
+// if it's set, to be called after every apply or delete. The inventory of a
+// terminated cluster or a cluster without nodes is removed
+func (k *Kluster) UpdateInventory() error {
+	if len(inventoryPath) == 0 {
+		return nil
+	}
+
+	dir := filepath.Join(inventoryPath, k.Name)
+	if k.status() == TerminatedStatus.String() || len(k.inventoryNodes()) == 0 {
+		return os.RemoveAll(dir)
+	}
+
+	k.ui.Log.Debugf("updating the inventory of cluster %s in %s", k.Name, dir)
+	return k.ExportInventory(dir)
+}
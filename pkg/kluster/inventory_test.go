@@ -0,0 +1,104 @@
+package kluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestKluster_Inventory(t *testing.T) {
+	path, err := ioutil.TempDir("", "inventory")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	cluster, err := CreateCluster("kkinventory", "ec2", path, "yaml", map[string]string{}, parentUI)
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	cluster.State["ec2"].Nodes = configurator.Hosts{
+		{PublicIP: "54.0.0.2", PrivateIP: "10.0.0.2", PrivateDNS: "ip-10-0-0-2.ec2.internal", RoleName: "worker", Pool: "worker"},
+		{PublicIP: "54.0.0.1", PrivateIP: "10.0.0.1", PrivateDNS: "ip-10-0-0-1.ec2.internal", RoleName: "master", Pool: "master"},
+		{PublicIP: "54.0.0.3", PrivateIP: "10.0.0.3", PrivateDNS: "ip-10-0-0-3.ec2.internal", RoleName: "worker", Pool: "worker"},
+	}
+
+	hosts, err := cluster.Inventory(InventoryHosts)
+	if err != nil {
+		t.Fatalf("Inventory(hosts) error = %v", err)
+	}
+	assert.Equal(t, `# KubeKit cluster kkinventory on ec2
+54.0.0.1	kkinventory-master000 ip-10-0-0-1.ec2.internal ip-10-0-0-1
+54.0.0.2	kkinventory-worker000 ip-10-0-0-2.ec2.internal ip-10-0-0-2
+54.0.0.3	kkinventory-worker001 ip-10-0-0-3.ec2.internal ip-10-0-0-3
+`, string(hosts))
+
+	sshConfig, err := cluster.Inventory(InventorySSHConfig)
+	if err != nil {
+		t.Fatalf("Inventory(ssh-config) error = %v", err)
+	}
+	assert.Contains(t, string(sshConfig), "Host kkinventory-master000\n  HostName 54.0.0.1\n  User ec2-user\n")
+
+	sd, err := cluster.Inventory(InventoryPrometheusSD)
+	if err != nil {
+		t.Fatalf("Inventory(prometheus-sd) error = %v", err)
+	}
+	assert.Contains(t, string(sd), `"10.0.0.1:9100"`)
+	assert.Contains(t, string(sd), `"pool": "worker"`)
+
+	ansible, err := cluster.Inventory(InventoryAnsible)
+	if err != nil {
+		t.Fatalf("Inventory(ansible) error = %v", err)
+	}
+	var inventory struct {
+		All struct {
+			Vars     map[string]string `yaml:"vars"`
+			Children struct {
+				KubeCluster struct {
+					Children map[string]struct {
+						Hosts map[string]map[string]string `yaml:"hosts"`
+					} `yaml:"children"`
+				} `yaml:"kube_cluster"`
+			} `yaml:"children"`
+		} `yaml:"all"`
+	}
+	if err := yaml.Unmarshal(ansible, &inventory); err != nil {
+		t.Fatalf("invalid Ansible inventory. %v\n%s", err, ansible)
+	}
+	assert.Equal(t, "kkinventory", inventory.All.Vars["cluster_name"])
+	groups := inventory.All.Children.KubeCluster.Children
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "54.0.0.2", groups["worker"].Hosts["worker000"]["ansible_host"])
+	assert.Equal(t, "10.0.0.1", groups["master"].Hosts["master000"]["private_ip"])
+
+	if _, err := cluster.Inventory("csv"); err == nil {
+		t.Errorf("Inventory() expected an error for an unknown format")
+	}
+
+	// the inventory is kept updated in the inventory path, and removed when the
+	// cluster is terminated
+	invPath := filepath.Join(path, "inventory")
+	SetInventoryPath(invPath)
+	defer SetInventoryPath("")
+
+	if err := cluster.UpdateInventory(); err != nil {
+		t.Fatalf("UpdateInventory() error = %v", err)
+	}
+	for _, format := range InventoryFormats {
+		if _, err := os.Stat(filepath.Join(invPath, "kkinventory", inventoryFilenames[format])); err != nil {
+			t.Errorf("UpdateInventory() the %s inventory was not exported. %v", format, err)
+		}
+	}
+	cluster.State["ec2"].Status = TerminatedStatus.String()
+	if err := cluster.UpdateInventory(); err != nil {
+		t.Fatalf("UpdateInventory() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(invPath, "kkinventory")); !os.IsNotExist(err) {
+		t.Errorf("UpdateInventory() the inventory of the terminated cluster was not removed")
+	}
+}
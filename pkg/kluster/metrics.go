@@ -0,0 +1,49 @@
+package kluster
+
+import (
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/metrics"
+)
+
+// durationBuckets are the buckets, in seconds, of the provisioning and
+// configuration durations, from 30 seconds to 2 hours
+var durationBuckets = []float64{30, 60, 120, 300, 600, 900, 1200, 1800, 2700, 3600, 5400, 7200}
+
+var (
+	provisioningDuration = metrics.NewHistogramVec(
+		"kubekit_provisioning_duration_seconds",
+		"Duration of the cluster provisioning (create) or termination (terminate) with Terraform.",
+		durationBuckets,
+		"platform", "action", "result",
+	)
+	configurationDuration = metrics.NewHistogramVec(
+		"kubekit_configuration_duration_seconds",
+		"Duration of the cluster configuration with Ansible.",
+		durationBuckets,
+		"platform", "result",
+	)
+)
+
+func init() {
+	metrics.Register(provisioningDuration, configurationDuration)
+}
+
+func metricsResult(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+func observeProvisioning(platform string, destroy bool, start time.Time, err error) {
+	action := "create"
+	if destroy {
+		action = "terminate"
+	}
+	provisioningDuration.Observe(time.Since(start).Seconds(), platform, action, metricsResult(err))
+}
+
+func observeConfiguration(platform string, start time.Time, err error) {
+	configurationDuration.Observe(time.Since(start).Seconds(), platform, metricsResult(err))
+}
@@ -0,0 +1,108 @@
+package kluster
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/notifier"
+)
+
+// eventNotifier sends the cluster lifecycle events to the configured webhooks.
+// If it's nil, no event is sent
+var eventNotifier *notifier.Notifier
+
+// SetNotifier sets the notifier of the cluster lifecycle events, or unset it if
+// it's nil
+func SetNotifier(n *notifier.Notifier) {
+	eventNotifier = n
+}
+
+// NotificationsEnabled returns true if the cluster lifecycle events are sent
+func NotificationsEnabled() bool {
+	return eventNotifier.Enabled()
+}
+
+// WaitNotifications blocks until all the events sent are delivered, to not lose
+// them when KubeKit exits
+func WaitNotifications() {
+	eventNotifier.Wait()
+}
+
+// status returns the current status of the cluster or empty if there is no
+// state for the platform
+func (k *Kluster) status() string {
+	if state, ok := k.State[k.Platform()]; ok && state != nil {
+		return state.Status
+	}
+	return ""
+}
+
+// newEvent creates an event of the given type for this cluster, the actor is
+// taken from the cluster context
+func (k *Kluster) newEvent(eventType string) *notifier.Event {
+	e := notifier.NewEvent(eventType, k.Name, k.Platform(), notifier.ActorFromContext(k.ctx))
+	e.Status = k.status()
+	return e
+}
+
+// Notify sends an event of the given type for this cluster, with the error if
+// the action failed. Use it to notify when an apply or delete starts or finish
+func (k *Kluster) Notify(eventType string, err error) {
+	if !eventNotifier.Enabled() {
+		return
+	}
+	eventNotifier.Notify(k.newEvent(eventType).WithError(err))
+}
+
+// notifyStatusChange sends the cluster.status.changed event if the status is
+// not the same as the one in the last loaded or saved configuration file
+func (k *Kluster) notifyStatusChange() {
+	status := k.status()
+	previous := k.savedStatus
+	k.savedStatus = status
+	if len(previous) == 0 || previous == status || !eventNotifier.Enabled() {
+		return
+	}
+
+	e := k.newEvent(notifier.StatusChanged)
+	e.PreviousStatus = previous
+	eventNotifier.Notify(e)
+}
+
+// NotifyExpiringCertificates sends the cluster.certificate.expiring event if
+// any certificate of the cluster expires before the time configured in the
+// notifications. It returns the number of expiring certificates
+func (k *Kluster) NotifyExpiringCertificates() (int, error) {
+	if !eventNotifier.Enabled() {
+		return 0, nil
+	}
+
+	certsInfo, err := k.CertificatesInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	expiring := []map[string]interface{}{}
+	for _, ci := range certsInfo {
+		if ci.ExpiresIn() < eventNotifier.CertificateExpiryWarning() {
+			expiring = append(expiring, map[string]interface{}{
+				"name":      ci.Name,
+				"cn":        ci.CN,
+				"not_after": ci.NotAfter,
+			})
+		}
+	}
+	if len(expiring) == 0 {
+		return 0, nil
+	}
+
+	e := k.newEvent(notifier.CertificateExpiring)
+	e.Actor = ""
+	days := int(eventNotifier.CertificateExpiryWarning().Hours() / 24)
+	e.Message = fmt.Sprintf("%d certificates of cluster %s@%s expire in less than %d days, rotate them with 'kubekit rotate certificates %s'", len(expiring), k.Name, k.Platform(), days, k.Name)
+	e.Data = map[string]interface{}{
+		"certificates": expiring,
+	}
+	eventNotifier.Notify(e)
+
+	return len(expiring), nil
+}
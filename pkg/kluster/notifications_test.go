@@ -0,0 +1,67 @@
+package kluster
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/notifier"
+)
+
+func TestKluster_notifyStatusChange(t *testing.T) {
+	var mu sync.Mutex
+	events := []*notifier.Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e notifier.Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("invalid event. %s", err)
+		}
+		mu.Lock()
+		events = append(events, &e)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	n, err := notifier.New(&notifier.Config{Webhooks: []*notifier.WebhookConfig{{URL: server.URL}}}, parentUI)
+	if err != nil {
+		t.Fatalf("notifier.New() error = %v", err)
+	}
+	SetNotifier(n)
+	defer SetNotifier(nil)
+
+	path, err := ioutil.TempDir("", "notifications")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	if _, err := CreateCluster("kknotify", "ec2", path, "yaml", map[string]string{}, parentUI); err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	cluster, err := LoadCluster("kknotify", path, parentUI)
+	if err != nil {
+		t.Fatalf("LoadCluster() error = %v", err)
+	}
+
+	// saving the same status is not a change
+	if err := cluster.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cluster.State["ec2"].Status = CreatingStatus.String()
+	if err := cluster.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	WaitNotifications()
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	e := events[0]
+	if e.Type != notifier.StatusChanged || e.Cluster != "kknotify" || e.Status != CreatingStatus.String() || e.PreviousStatus != AbsentStatus.String() {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
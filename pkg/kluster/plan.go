@@ -0,0 +1,150 @@
+package kluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kraken/terraformer"
+)
+
+// ClusterResourcesGroup is the name of the group of the planned changes of the
+// resources that do not belong to a node pool, such as load balancers or
+// security groups
+const ClusterResourcesGroup = "cluster"
+
+// PlanReport is the report of the changes to apply to the cluster
+// infrastructure, grouped by node pool
+type PlanReport struct {
+	ClusterName string      `json:"cluster_name" yaml:"cluster_name"`
+	Platform    string      `json:"platform" yaml:"platform"`
+	Destroy     bool        `json:"destroy" yaml:"destroy"`
+	ToAdd       int         `json:"to_add" yaml:"to_add"`
+	ToChange    int         `json:"to_change" yaml:"to_change"`
+	ToDestroy   int         `json:"to_destroy" yaml:"to_destroy"`
+	Destructive bool        `json:"destructive" yaml:"destructive"`
+	NodePools   []*PoolPlan `json:"node_pools" yaml:"node_pools"`
+}
+
+// PoolPlan is the list of planned changes of the resources of a node pool
+type PoolPlan struct {
+	Name      string                        `json:"name" yaml:"name"`
+	Resources []*terraformer.ResourceChange `json:"resources" yaml:"resources"`
+}
+
+// PlanReport returns the report of the changes to apply to create or update
+// the cluster, or to destroy it if `destroy` is true. The changes are not
+// applied
+func (k *Kluster) PlanReport(destroy bool) (*PlanReport, error) {
+	platformName := k.Platform()
+
+	k.LoadState()
+	k.ui.Log.Debug("state(s) loaded")
+
+	p, ok := k.provisioner[platformName]
+	if !ok {
+		return nil, fmt.Errorf("platform %q not initialized", platformName)
+	}
+
+	logPrefix := fmt.Sprintf("Provisioner [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+	defer k.ui.SetLogPrefix(fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName))
+
+	plan, err := p.Plan(destroy)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := p.Changes()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := terraformer.NewStats(plan)
+	report := &PlanReport{
+		ClusterName: k.Name,
+		Platform:    platformName,
+		Destroy:     destroy,
+		ToAdd:       stats.Add,
+		ToChange:    stats.Change,
+		ToDestroy:   stats.Destroy,
+	}
+
+	// the node pools are used only to group the changes, without them all the
+	// changes are in the cluster group
+	var poolNames []string
+	if pConfig, err := k.platformConfigMap(); err == nil {
+		if nodePools, ok := pConfig["node_pools"].(map[string]interface{}); ok {
+			for name := range nodePools {
+				poolNames = append(poolNames, name)
+			}
+			sort.Strings(poolNames)
+		}
+	}
+
+	report.NodePools = groupChangesByPool(changes, poolNames)
+	for _, pool := range report.NodePools {
+		for _, rc := range pool.Resources {
+			if rc.IsDestructive() {
+				report.Destructive = true
+			}
+		}
+	}
+
+	if report.Destructive {
+		k.ui.Log.Warnf("the plan deletes or replaces %d resources", report.ToDestroy)
+	}
+
+	return report, nil
+}
+
+// groupChangesByPool groups the resource changes by the node pool they belong
+// to. The node pools are sorted by name, with the cluster group at the end
+func groupChangesByPool(changes []*terraformer.ResourceChange, poolNames []string) []*PoolPlan {
+	groups := map[string]*PoolPlan{}
+	for _, rc := range changes {
+		name := poolOfResource(rc.Name, poolNames)
+		if _, ok := groups[name]; !ok {
+			groups[name] = &PoolPlan{
+				Name:      name,
+				Resources: []*terraformer.ResourceChange{},
+			}
+		}
+		groups[name].Resources = append(groups[name].Resources, rc)
+	}
+
+	pools := make([]*PoolPlan, 0, len(groups))
+	for _, pool := range groups {
+		pools = append(pools, pool)
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		if pools[i].Name == ClusterResourcesGroup || pools[j].Name == ClusterResourcesGroup {
+			return pools[j].Name == ClusterResourcesGroup && pools[i].Name != ClusterResourcesGroup
+		}
+		return pools[i].Name < pools[j].Name
+	})
+
+	return pools
+}
+
+// poolOfResource returns the node pool of a resource. The resource names in
+// the Terraform templates contain the node pool name in lowercase with dashes.
+// If the name contains more than one node pool name, the longest is used
+func poolOfResource(resourceName string, poolNames []string) string {
+	dash := strings.NewReplacer("_", "-", ".", "-")
+	resourceName = dash.Replace(strings.ToLower(resourceName))
+
+	poolOf, match := ClusterResourcesGroup, ""
+	for _, poolName := range poolNames {
+		name := dash.Replace(strings.ToLower(poolName))
+		if resourceName == name ||
+			strings.HasPrefix(resourceName, name+"-") ||
+			strings.HasSuffix(resourceName, "-"+name) ||
+			strings.Contains(resourceName, "-"+name+"-") {
+			if len(name) > len(match) {
+				poolOf, match = poolName, name
+			}
+		}
+	}
+
+	return poolOf
+}
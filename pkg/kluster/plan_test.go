@@ -0,0 +1,63 @@
+package kluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kraken/terraformer"
+)
+
+func Test_poolOfResource(t *testing.T) {
+	poolNames := []string{"master", "worker", "gpu_worker"}
+
+	tests := []struct {
+		name         string
+		resourceName string
+		want         string
+	}{
+		{"vsphere node", "worker", "worker"},
+		{"ec2 autoscaling group", "kkdemo-node-master", "master"},
+		{"ec2 iam role", "kube-worker-role", "worker"},
+		{"ec2 wait", "wait-worker", "worker"},
+		{"longest pool name", "kkdemo-node-gpu-worker", "gpu_worker"},
+		{"cluster resource", "alb", ClusterResourcesGroup},
+		{"partial pool name", "kkdemo-workers", ClusterResourcesGroup},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := poolOfResource(tt.resourceName, poolNames); got != tt.want {
+				t.Errorf("poolOfResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_groupChangesByPool(t *testing.T) {
+	changes := []*terraformer.ResourceChange{
+		{Address: "aws_alb.alb", Name: "alb", Action: "update"},
+		{Address: "aws_autoscaling_group.kkdemo-node-worker", Name: "kkdemo-node-worker", Action: "update"},
+		{Address: "aws_autoscaling_group.kkdemo-node-master", Name: "kkdemo-node-master", Action: "create"},
+		{Address: "null_resource.wait-worker", Name: "wait-worker", Action: "replace"},
+	}
+
+	got := map[string][]string{}
+	order := []string{}
+	for _, pool := range groupChangesByPool(changes, []string{"master", "worker"}) {
+		order = append(order, pool.Name)
+		for _, rc := range pool.Resources {
+			got[pool.Name] = append(got[pool.Name], rc.Address)
+		}
+	}
+
+	if want := []string{"master", "worker", ClusterResourcesGroup}; !reflect.DeepEqual(order, want) {
+		t.Errorf("groupChangesByPool() pools = %v, want %v", order, want)
+	}
+	want := map[string][]string{
+		"master":              {"aws_autoscaling_group.kkdemo-node-master"},
+		"worker":              {"aws_autoscaling_group.kkdemo-node-worker", "null_resource.wait-worker"},
+		ClusterResourcesGroup: {"aws_alb.alb"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupChangesByPool() = %v, want %v", got, want)
+	}
+}
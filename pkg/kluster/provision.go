@@ -2,6 +2,7 @@ package kluster
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kraken/terraformer"
 )
@@ -17,12 +18,24 @@ func (k *Kluster) provision(destroy bool) error {
 	// LoadState makes the platforms provisioners
 	p := k.provisioner[platformName]
 
+	if k.ctx != nil {
+		if err := p.AddHook(terraformer.NewHaltHook(k.ctx)); err != nil {
+			return err
+		}
+	}
+
 	k.ui.Log.Debugf("starting process to provisioning/terminating the cluster %q", platformName)
 
 	logPrefix = fmt.Sprintf("Provisioner [ %s@%s ]", k.Name, platformName)
 	k.ui.SetLogPrefix(logPrefix)
 
+	start := time.Now()
 	err := p.Apply(destroy)
+	// Terraform do not fail when it's halted
+	if errC := k.canceled(); errC != nil && err == nil {
+		err = errC
+	}
+	observeProvisioning(platformName, destroy, start, err)
 	defer k.SaveState()
 	defer k.ui.TerminateAllNotifications("")
 
@@ -0,0 +1,332 @@
+package kluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kraken/terraformer"
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// replaceSSHTimeout is the time to wait for the new node to be accessible
+// with SSH after it's provisioned
+const replaceSSHTimeout = 10 * time.Minute
+
+// etcdMember is a member of the etcd cluster from `etcdctl member list`
+type etcdMember struct {
+	ID       uint64   `json:"ID"`
+	Name     string   `json:"name"`
+	PeerURLs []string `json:"peerURLs"`
+}
+
+// Replace replaces the given node, by IP, DNS or name, with a new node. The
+// node is drained and removed from Kubernetes, if it's a master node it's also
+// removed from the etcd cluster. Then only the instance of the node is
+// provisioned again. Returns the new host, it has to be configured with
+// ConfigureReplacement to join the cluster
+func (k *Kluster) Replace(node string, drainTimeout time.Duration) (configurator.Host, error) {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	switch platformName {
+	case "ec2", "vsphere", "openstack", "azure":
+	default:
+		return configurator.Host{}, fmt.Errorf("the %s platform does not support replacing nodes, the supported platforms are ec2, vsphere, openstack and azure", platformName)
+	}
+
+	if err := k.LoadState(); err != nil {
+		return configurator.Host{}, err
+	}
+	k.UpdateState(platformName)
+	prevHosts := k.State[platformName].Nodes
+
+	host, err := findHost(prevHosts, node)
+	if err != nil {
+		return configurator.Host{}, err
+	}
+	isMaster := host.RoleName == "master"
+
+	var etcdPeer configurator.Host
+	if isMaster {
+		if k.Config != nil && k.Config.EtcdLocalProxyEnabled {
+			return configurator.Host{}, fmt.Errorf("cannot replace a master node when the etcd local proxy is enabled")
+		}
+		// a master with a healthy etcd member is required to modify the etcd cluster
+		if etcdPeer, err = k.etcdPeerFor(host); err != nil {
+			return configurator.Host{}, err
+		}
+	}
+
+	client, err := kube.NewClientE("", filepath.Join(k.CertsDir(), "kubeconfig"), k.ui)
+	if err != nil {
+		k.ui.Log.Warnf("cannot connect to the Kubernetes cluster to drain or remove the node. %s", err)
+		client = nil
+	}
+
+	if client != nil {
+		nodeName, err := client.NodeByAddress(host.PrivateDNS, host.PublicDNS, host.PrivateIP, host.PublicIP)
+		if err != nil {
+			k.ui.Log.Warnf("cannot find the Kubernetes node of host %s. %s", host.PublicIP, err)
+		} else {
+			// the node may be dead, so the pods that cannot be evicted are deleted with the node
+			k.ui.Log.Infof("draining node %s", nodeName)
+			if err := client.DrainNode(nodeName, drainTimeout); err != nil {
+				k.ui.Log.Warnf("failed to drain node %s, it's replaced anyway. %s", nodeName, err)
+			}
+			if err := client.DeleteNode(nodeName); err != nil {
+				return configurator.Host{}, fmt.Errorf("failed to delete node %s from Kubernetes. %s", nodeName, err)
+			}
+		}
+	}
+
+	if isMaster {
+		if err := k.removeEtcdMember(etcdPeer, host); err != nil {
+			return configurator.Host{}, err
+		}
+	}
+
+	p := k.provisioner[platformName]
+	if k.ctx != nil {
+		if err := p.AddHook(terraformer.NewHaltHook(k.ctx)); err != nil {
+			return configurator.Host{}, err
+		}
+	}
+
+	k.ui.Log.Infof("replacing the %s node %s", host.RoleName, host.PublicIP)
+	err = p.Replace(&state.Node{
+		PublicIP:   host.PublicIP,
+		PrivateIP:  host.PrivateIP,
+		PublicDNS:  host.PublicDNS,
+		PrivateDNS: host.PrivateDNS,
+		RoleName:   host.RoleName,
+		Pool:       host.Pool,
+	})
+	// Terraform do not fail when it's halted
+	if errC := k.canceled(); errC != nil && err == nil {
+		err = errC
+	}
+	defer k.SaveState()
+	k.ui.TerminateAllNotifications("")
+
+	if err != nil {
+		k.State[platformName].Status = FailedProvisioningStatus.String()
+		return configurator.Host{}, err
+	}
+
+	k.UpdateState(platformName)
+	k.State[platformName].Status = ProvisionedStatus.String()
+	currHosts := k.State[platformName].Nodes
+
+	newHost, err := replacementHost(prevHosts, currHosts, host)
+	if err != nil {
+		return configurator.Host{}, err
+	}
+	k.ui.Log.Infof("the node %s has been replaced by %s", host.PublicIP, newHost.PublicIP)
+
+	return newHost, nil
+}
+
+// ConfigureReplacement configures the new host that replaced a node to join
+// the existing Kubernetes cluster. If it's a master node it's added to the
+// etcd cluster before the configuration
+func (k *Kluster) ConfigureReplacement(host configurator.Host) error {
+	k.ui.Log.Infof("waiting for the node %s to be accessible", host.PublicIP)
+	err := wait.PollImmediate(10*time.Second, replaceSSHTimeout, func() (bool, error) {
+		return k.execOnHost(host, "true", false) == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("the node %s is not accessible with SSH after %s", host.PublicIP, replaceSSHTimeout)
+	}
+
+	if host.RoleName != "master" {
+		return k.ConfigureNodes(configurator.Hosts{host})
+	}
+
+	etcdPeer, err := k.etcdPeerFor(host)
+	if err != nil {
+		return err
+	}
+	if err := k.addEtcdMember(etcdPeer, host); err != nil {
+		return err
+	}
+
+	return k.configureNodes(configurator.Hosts{host}, true)
+}
+
+// findHost returns the host with the given IP, DNS or hostname
+func findHost(hosts configurator.Hosts, node string) (configurator.Host, error) {
+	found := hosts.FilterByNode(node)
+	if len(found) == 0 {
+		for _, h := range hosts {
+			if strings.Split(h.PrivateDNS, ".")[0] == node || strings.Split(h.PublicDNS, ".")[0] == node {
+				found = append(found, h)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return configurator.Host{}, fmt.Errorf("not found the node %q in the cluster", node)
+	case 1:
+		return found[0], nil
+	default:
+		return configurator.Host{}, fmt.Errorf("found %d nodes with the address or name %q, use the IP address of the node", len(found), node)
+	}
+}
+
+// replacementHost returns the host that replaced the given host. It's the new
+// host, or the same host if the platform kept the address of the node
+func replacementHost(prevHosts, currHosts configurator.Hosts, replaced configurator.Host) (configurator.Host, error) {
+	newHosts := configurator.Hosts{}
+	for _, h := range currHosts {
+		if len(prevHosts.FilterByNode(hostIPs(h)...)) == 0 {
+			newHosts = append(newHosts, h)
+		}
+	}
+
+	switch len(newHosts) {
+	case 1:
+		return newHosts[0], nil
+	case 0:
+		if same := currHosts.FilterByNode(hostIPs(replaced)...); len(same) != 0 {
+			return same[0], nil
+		}
+		return configurator.Host{}, fmt.Errorf("not found the node that replaced %s", replaced.PublicIP)
+	default:
+		return configurator.Host{}, fmt.Errorf("found %d new nodes replacing %s, it was expected only one", len(newHosts), replaced.PublicIP)
+	}
+}
+
+// hostIPs returns the IP addresses of the host, an empty address would match
+// any host without that address
+func hostIPs(h configurator.Host) []string {
+	ips := []string{}
+	for _, ip := range []string{h.PublicIP, h.PrivateIP} {
+		if len(ip) != 0 {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// etcdPeerFor returns a master node, other than the given host, with a healthy
+// etcd member
+func (k *Kluster) etcdPeerFor(host configurator.Host) (configurator.Host, error) {
+	for _, master := range k.HostsFilterBy(nil, []string{"master"}) {
+		if master.PublicIP == host.PublicIP {
+			continue
+		}
+		if err := k.execOnHost(master, fmt.Sprintf("sh -c '%s endpoint health'", etcdctlCMD), true); err != nil {
+			k.ui.Log.Debugf("the etcd member on %s is not healthy. %s", master.PublicIP, err)
+			continue
+		}
+		return master, nil
+	}
+
+	return configurator.Host{}, fmt.Errorf("not found another master node with a healthy etcd member to replace the master node %s. If it's the only master node restore the cluster from a backup", host.PublicIP)
+}
+
+// etcdMembers returns the members of the etcd cluster, listed from the given
+// master node
+func (k *Kluster) etcdMembers(peer configurator.Host) ([]etcdMember, error) {
+	command := fmt.Sprintf("sh -c '%s member list --write-out=json'", etcdctlCMD)
+	result, err := k.Exec(command, "", []string{peer.PublicIP}, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := result.Hosts.GetSnapshot()[peer.PublicIP]
+	if !ok || res.ExitStatus != 0 {
+		var stderr string
+		if ok {
+			stderr = strings.TrimSpace(res.Stderr)
+		}
+		return nil, fmt.Errorf("failed to list the etcd members on %s. %s", peer.PublicIP, stderr)
+	}
+
+	return parseEtcdMembers(res.Stdout)
+}
+
+// removeEtcdMember removes the etcd member of the given host from the etcd
+// cluster, using the etcd member of the given peer
+func (k *Kluster) removeEtcdMember(peer, host configurator.Host) error {
+	members, err := k.etcdMembers(peer)
+	if err != nil {
+		return err
+	}
+	member, ok := etcdMemberOf(members, host)
+	if !ok {
+		k.ui.Log.Warnf("not found the etcd member of the node %s, it may be removed already", host.PublicIP)
+		return nil
+	}
+
+	k.ui.Log.Infof("removing the etcd member %s (%x) of the node %s", member.Name, member.ID, host.PublicIP)
+	command := fmt.Sprintf("sh -c '%s member remove %x'", etcdctlCMD, member.ID)
+	if err := k.execOnHost(peer, command, true); err != nil {
+		return fmt.Errorf("failed to remove the etcd member %s. %s", member.Name, err)
+	}
+
+	return nil
+}
+
+// addEtcdMember adds the given host as a new member of the etcd cluster, using
+// the etcd member of the given peer. The member has to be added before it's
+// started with the existing cluster state
+func (k *Kluster) addEtcdMember(peer, host configurator.Host) error {
+	members, err := k.etcdMembers(peer)
+	if err != nil {
+		return err
+	}
+	if _, ok := etcdMemberOf(members, host); ok {
+		k.ui.Log.Debugf("the node %s is already an etcd member", host.PublicIP)
+		return nil
+	}
+
+	// the member name is set by the member itself when it starts
+	name := "etcd-" + strings.ToLower(strings.Split(host.PrivateDNS, ".")[0])
+	k.ui.Log.Infof("adding the node %s as the etcd member %s", host.PublicIP, name)
+	command := fmt.Sprintf("sh -c '%s member add %s --peer-urls=https://%s:2380'", etcdctlCMD, name, host.PrivateIP)
+	if err := k.execOnHost(peer, command, true); err != nil {
+		return fmt.Errorf("failed to add the etcd member of the node %s. %s", host.PublicIP, err)
+	}
+
+	return nil
+}
+
+// parseEtcdMembers returns the members from the output of `etcdctl member list
+// --write-out=json`
+func parseEtcdMembers(output string) ([]etcdMember, error) {
+	list := struct {
+		Members []etcdMember `json:"members"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, fmt.Errorf("cannot parse the etcd members list. %s", err)
+	}
+	return list.Members, nil
+}
+
+// etcdMemberOf returns the member with a peer URL to any of the host addresses
+func etcdMemberOf(members []etcdMember, host configurator.Host) (etcdMember, bool) {
+	for _, m := range members {
+		for _, peerURL := range m.PeerURLs {
+			u, err := url.Parse(peerURL)
+			if err != nil {
+				continue
+			}
+			switch u.Hostname() {
+			case "":
+				continue
+			case host.PrivateIP, host.PublicIP, host.PrivateDNS, host.PublicDNS:
+				return m, true
+			}
+		}
+	}
+	return etcdMember{}, false
+}
@@ -0,0 +1,91 @@
+package kluster
+
+import (
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+)
+
+var testReplaceHosts = configurator.Hosts{
+	{PublicIP: "54.0.0.1", PrivateIP: "10.0.0.1", PrivateDNS: "master-1.example.com", RoleName: "master"},
+	{PublicIP: "54.0.0.2", PrivateIP: "10.0.0.2", PrivateDNS: "master-2.example.com", RoleName: "master"},
+	{PublicIP: "54.0.0.3", PrivateIP: "10.0.0.3", PrivateDNS: "worker-1.example.com", RoleName: "worker"},
+}
+
+func Test_findHost(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    string
+		want    string
+		wantErr bool
+	}{
+		{"by public IP", "54.0.0.2", "54.0.0.2", false},
+		{"by private IP", "10.0.0.3", "54.0.0.3", false},
+		{"by DNS", "master-1.example.com", "54.0.0.1", false},
+		{"by hostname", "worker-1", "54.0.0.3", false},
+		{"unknown", "worker-9", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findHost(testReplaceHosts, tt.node)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.PublicIP != tt.want {
+				t.Errorf("findHost() = %v, want %v", got.PublicIP, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replacementHost(t *testing.T) {
+	replaced := testReplaceHosts[2]
+	newHost := configurator.Host{PublicIP: "54.0.0.4", PrivateIP: "10.0.0.4", RoleName: "worker"}
+
+	tests := []struct {
+		name      string
+		currHosts configurator.Hosts
+		want      string
+		wantErr   bool
+	}{
+		{"new address", configurator.Hosts{testReplaceHosts[0], testReplaceHosts[1], newHost}, "54.0.0.4", false},
+		{"same address", testReplaceHosts, "54.0.0.3", false},
+		{"not replaced", testReplaceHosts[:2], "", true},
+		{"too many new hosts", configurator.Hosts{testReplaceHosts[0], newHost, {PublicIP: "54.0.0.5"}}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replacementHost(testReplaceHosts, tt.currHosts, replaced)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replacementHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.PublicIP != tt.want {
+				t.Errorf("replacementHost() = %v, want %v", got.PublicIP, tt.want)
+			}
+		})
+	}
+}
+
+func Test_etcdMemberOf(t *testing.T) {
+	output := `{"header":{"cluster_id":1,"member_id":2,"raft_term":3},"members":[{"ID":12345678901234567890,"name":"etcd-master-1","peerURLs":["https://10.0.0.1:2380"],"clientURLs":["https://10.0.0.1:2379"]},{"ID":42,"name":"etcd-master-2","peerURLs":["https://10.0.0.2:2380"]}]}`
+
+	members, err := parseEtcdMembers(output)
+	if err != nil {
+		t.Fatalf("parseEtcdMembers() error = %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("parseEtcdMembers() returned %d members, want 2", len(members))
+	}
+
+	m, ok := etcdMemberOf(members, testReplaceHosts[0])
+	if !ok || m.ID != 12345678901234567890 || m.Name != "etcd-master-1" {
+		t.Errorf("etcdMemberOf() = %+v, %v, want member etcd-master-1", m, ok)
+	}
+	if _, ok := etcdMemberOf(members, testReplaceHosts[2]); ok {
+		t.Errorf("etcdMemberOf() found a member for a worker node")
+	}
+
+	if _, err := parseEtcdMembers("Error: context deadline exceeded"); err == nil {
+		t.Errorf("parseEtcdMembers() expected an error with an invalid output")
+	}
+}
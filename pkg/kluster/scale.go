@@ -0,0 +1,379 @@
+package kluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// DefaultDrainTimeout is the default time to wait for the pods of a node to be
+// evicted before the node is destroyed
+const DefaultDrainTimeout = 5 * time.Minute
+
+// autoScaledPlatforms are the platforms where the nodes of a node pool are
+// created by an auto scaling group or an agent pool, decrementing the count of
+// the node pool does not destroy specific nodes, the group chooses them. On
+// these platforms KubeKit chooses the nodes to remove, drain them and remove
+// them with the provisioner before the count is decremented
+var autoScaledPlatforms = map[string]bool{
+	"ec2": true,
+	"eks": true,
+	"aks": true,
+}
+
+// nodeAddressAttributes are the Terraform resource attributes that may contain
+// the IP or DNS of a node, it depends of the platform
+var nodeAddressAttributes = []string{
+	"private_ip",
+	"public_ip",
+	"private_dns",
+	"public_dns",
+	"access_ip_v4",
+	"default_ip_address",
+	"ipv4_address",
+}
+
+// NodePoolCount returns the number of nodes of the given node pool
+func (k *Kluster) NodePoolCount(poolName string) (int, error) {
+	pConfig, err := k.platformConfigMap()
+	if err != nil {
+		return 0, err
+	}
+	pool, err := nodePoolFromConfig(pConfig, k.Platform(), poolName)
+	if err != nil {
+		return 0, err
+	}
+
+	count, ok := pool["count"]
+	if !ok {
+		return 0, nil
+	}
+	countF, ok := count.(float64)
+	if !ok {
+		return 0, fmt.Errorf("the count of node pool %q is not a number", poolName)
+	}
+
+	return int(countF), nil
+}
+
+// SetNodePoolCount sets the number of nodes of the given node pool in the
+// platform configuration. It's not persisted until the cluster is saved
+func (k *Kluster) SetNodePoolCount(poolName string, count int) error {
+	if count < 0 {
+		return fmt.Errorf("the number of nodes of the node pool %q cannot be negative", poolName)
+	}
+
+	platformName := k.Platform()
+	pConfig, err := k.platformConfigMap()
+	if err != nil {
+		return err
+	}
+	pool, err := nodePoolFromConfig(pConfig, platformName, poolName)
+	if err != nil {
+		return err
+	}
+	pool["count"] = count
+
+	pConfigB, err := json.Marshal(pConfig)
+	if err != nil {
+		return err
+	}
+
+	// The platform configuration is a pointer, unmarshal the updated configuration into it
+	config := k.provisioner[platformName].Config()
+	if err := json.Unmarshal(pConfigB, config); err != nil {
+		return fmt.Errorf("failed to update the %s platform configuration. %s", platformName, err)
+	}
+	k.Platforms[platformName] = config
+
+	return nil
+}
+
+// Scale changes the number of nodes of the given node pools and provision the
+// cluster with the new number of nodes. The nodes to remove are drained before
+// destroy them, if the Kubernetes cluster is not reachable to drain them the
+// scale fails unless force is true. On the platforms with auto scaling groups
+// the last nodes of the pool are removed. Returns the new hosts to be configured
+func (k *Kluster) Scale(counts map[string]int, drainTimeout time.Duration, force bool) (configurator.Hosts, error) {
+	platformName := k.Platform()
+
+	switch platformName {
+	case "raw", "stacki", "vra":
+		return nil, fmt.Errorf("the %s platform does not support scaling, modify the list of nodes in the configuration and apply the changes", platformName)
+	}
+
+	scaleIn := false
+	removeCounts := map[string]int{}
+	for poolName, count := range counts {
+		current, err := k.NodePoolCount(poolName)
+		if err != nil {
+			return nil, err
+		}
+		if count < current {
+			scaleIn = true
+			removeCounts[poolName] = current - count
+		}
+	}
+
+	autoScaled := autoScaledPlatforms[platformName]
+	if scaleIn && platformName == "aks" {
+		return nil, fmt.Errorf("the %s platform does not support scaling in node pools, the agent pool chooses the nodes to remove and they cannot be drained before. Only more nodes can be added", platformName)
+	}
+
+	// the nodes to remove have to be drained, it's not possible without access
+	// to the Kubernetes cluster
+	var client *kube.Client
+	if scaleIn {
+		kubeconfigPath := filepath.Join(k.CertsDir(), "kubeconfig")
+		var err error
+		if client, err = kube.NewClientE("", kubeconfigPath, k.ui); err != nil {
+			if !force {
+				return nil, fmt.Errorf("cannot connect to the Kubernetes cluster to drain the nodes to remove, use force to remove them without draining. %s", err)
+			}
+			k.ui.Log.Warnf("cannot connect to the Kubernetes cluster, the nodes will be removed without draining them. %s", err)
+			client = nil
+		}
+	}
+
+	if err := k.LoadState(); err != nil {
+		return nil, err
+	}
+	k.UpdateState(platformName)
+	prevHosts := k.State[platformName].Nodes
+
+	for poolName, count := range counts {
+		if err := k.SetNodePoolCount(poolName, count); err != nil {
+			return nil, err
+		}
+	}
+
+	var removedHosts configurator.Hosts
+	if autoScaled {
+		var err error
+		if removedHosts, err = nodesToRemove(prevHosts, removeCounts); err != nil {
+			return nil, err
+		}
+	} else {
+		removedAddresses, err := k.plannedNodesToDestroy()
+		if err != nil {
+			return nil, err
+		}
+		removedHosts = prevHosts.FilterByNode(removedAddresses...)
+	}
+
+	if client != nil {
+		for _, host := range removedHosts {
+			nodeName, err := client.NodeByAddress(host.PrivateDNS, host.PublicDNS, host.PrivateIP, host.PublicIP)
+			if err != nil {
+				k.ui.Log.Warnf("cannot find the Kubernetes node of host %s. %s", host.PublicIP, err)
+				continue
+			}
+			k.ui.Log.Infof("draining node %s", nodeName)
+			if err := client.DrainNode(nodeName, drainTimeout); err != nil {
+				return nil, fmt.Errorf("failed to drain node %s. %s", nodeName, err)
+			}
+		}
+	}
+
+	// the auto scaling groups would choose the nodes to remove, so the drained
+	// nodes are removed before decrementing the count of their node pools
+	if autoScaled && len(removedHosts) != 0 {
+		nodes := make([]*state.Node, 0, len(removedHosts))
+		for _, host := range removedHosts {
+			k.ui.Log.Infof("removing the %s node %s", host.Pool, host.PublicIP)
+			nodes = append(nodes, &state.Node{
+				PublicIP:   host.PublicIP,
+				PrivateIP:  host.PrivateIP,
+				PublicDNS:  host.PublicDNS,
+				PrivateDNS: host.PrivateDNS,
+				RoleName:   host.RoleName,
+				Pool:       host.Pool,
+			})
+		}
+		if err := k.provisioner[platformName].Remove(nodes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := k.Create(); err != nil {
+		return nil, err
+	}
+
+	k.UpdateState(platformName)
+	currHosts := k.State[platformName].Nodes
+
+	// the removed nodes are also removed from Kubernetes
+	if client != nil {
+		for _, host := range prevHosts {
+			if len(currHosts.FilterByNode(host.PublicIP, host.PrivateIP)) != 0 {
+				continue
+			}
+			nodeName, err := client.NodeByAddress(host.PrivateDNS, host.PublicDNS, host.PrivateIP, host.PublicIP)
+			if err != nil {
+				continue
+			}
+			if err := client.DeleteNode(nodeName); err != nil {
+				k.ui.Log.Warnf("failed to delete node %s from Kubernetes. %s", nodeName, err)
+			}
+		}
+	}
+
+	newHosts := configurator.Hosts{}
+	for _, host := range currHosts {
+		if len(prevHosts.FilterByNode(host.PublicIP, host.PrivateIP)) == 0 {
+			newHosts = append(newHosts, host)
+		}
+	}
+
+	return newHosts, nil
+}
+
+// nodesToRemove returns the last hosts of every node pool, as many as the number
+// of nodes to remove from the pool
+func nodesToRemove(hosts configurator.Hosts, removeCounts map[string]int) (configurator.Hosts, error) {
+	poolNames := make([]string, 0, len(removeCounts))
+	for poolName := range removeCounts {
+		poolNames = append(poolNames, poolName)
+	}
+	sort.Strings(poolNames)
+
+	removed := configurator.Hosts{}
+	for _, poolName := range poolNames {
+		poolHosts := configurator.Hosts{}
+		for _, host := range hosts {
+			if host.Pool == poolName {
+				poolHosts = append(poolHosts, host)
+			}
+		}
+		n := removeCounts[poolName]
+		if n > len(poolHosts) {
+			return nil, fmt.Errorf("cannot remove %d nodes from the node pool %q, it has %d nodes", n, poolName, len(poolHosts))
+		}
+		removed = append(removed, poolHosts[len(poolHosts)-n:]...)
+	}
+
+	return removed, nil
+}
+
+// ConfigureNodes configures only the given hosts to join them to the existing
+// Kubernetes cluster
+func (k *Kluster) ConfigureNodes(hosts configurator.Hosts) error {
+	return k.configureNodes(hosts, false)
+}
+
+// configureNodes configures only the given hosts. If joinEtcd is true, the
+// master hosts join the running etcd cluster as new members
+func (k *Kluster) configureNodes(hosts configurator.Hosts, joinEtcd bool) error {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	k.ui.Log.Debugf("starting the configuration of %d nodes of cluster %q on %s", len(hosts), k.Name, platformName)
+
+	pConf := k.provisioner[platformName].Config()
+	clusterDir := k.Dir()
+
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, clusterDir, k.ui)
+	if err != nil {
+		return err
+	}
+
+	addresses := []string{}
+	for _, host := range hosts {
+		addresses = append(addresses, host.PublicIP)
+	}
+
+	if joinEtcd {
+		conf.WithEtcdJoining(addresses...)
+	}
+
+	if err := conf.WithContext(k.ctx).ConfigureHosts(addresses...); err != nil {
+		k.State[platformName].Status = FailedConfigurationStatus.String()
+		return err
+	}
+
+	k.State[platformName].Status = RunningStatus.String()
+
+	return nil
+}
+
+// plannedNodesToDestroy returns the IP's and DNS's of the resources that the
+// provisioner plans to destroy
+func (k *Kluster) plannedNodesToDestroy() ([]string, error) {
+	p := k.provisioner[k.Platform()]
+
+	plan, err := p.Plan(false)
+	if err != nil {
+		return nil, err
+	}
+
+	state := p.State()
+	addresses := []string{}
+	if plan == nil || plan.Changes == nil || state == nil {
+		return addresses, nil
+	}
+
+	for _, r := range plan.Changes.Resources {
+		if r.Action != plans.Delete && r.Action != plans.DeleteThenCreate && r.Action != plans.CreateThenDelete {
+			continue
+		}
+		if r.Addr.Resource.Resource.Mode != addrs.ManagedResourceMode {
+			continue
+		}
+		instance := state.ResourceInstance(r.Addr)
+		if instance == nil || instance.Current == nil {
+			continue
+		}
+		attrs := map[string]interface{}{}
+		if err := json.Unmarshal(instance.Current.AttrsJSON, &attrs); err != nil {
+			continue
+		}
+		for _, attrName := range nodeAddressAttributes {
+			if v, ok := attrs[attrName].(string); ok && len(v) != 0 {
+				addresses = append(addresses, v)
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
+func (k *Kluster) platformConfigMap() (map[string]interface{}, error) {
+	platformName := k.Platform()
+	p, ok := k.provisioner[platformName]
+	if !ok {
+		return nil, fmt.Errorf("platform %q not initialized", platformName)
+	}
+
+	pConfigB, err := json.Marshal(p.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the %s platform configuration. %s", platformName, err)
+	}
+	pConfig := map[string]interface{}{}
+	if err := json.Unmarshal(pConfigB, &pConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the %s platform configuration. %s", platformName, err)
+	}
+
+	return pConfig, nil
+}
+
+func nodePoolFromConfig(pConfig map[string]interface{}, platformName, poolName string) (map[string]interface{}, error) {
+	nodePools, ok := pConfig["node_pools"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not found node pools in the %s platform configuration", platformName)
+	}
+	pool, ok := nodePools[poolName].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not found node pool %q in the %s platform configuration", poolName, platformName)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,122 @@
+package kluster
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+)
+
+func TestKluster_SetNodePoolCount(t *testing.T) {
+	path, err := ioutil.TempDir("", "scale")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	cluster, err := CreateCluster("kkscale", "ec2", path, "yaml", map[string]string{}, parentUI)
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+
+	if err := cluster.SetNodePoolCount("worker", 5); err != nil {
+		t.Fatalf("SetNodePoolCount() error = %v", err)
+	}
+	got, err := cluster.NodePoolCount("worker")
+	if err != nil {
+		t.Fatalf("NodePoolCount() error = %v", err)
+	}
+	if got != 5 {
+		t.Errorf("NodePoolCount() = %d, want 5", got)
+	}
+
+	if err := cluster.SetNodePoolCount("worker", -1); err == nil {
+		t.Errorf("SetNodePoolCount() with a negative count expected an error")
+	}
+	if err := cluster.SetNodePoolCount("unknown", 1); err == nil {
+		t.Errorf("SetNodePoolCount() of an unknown pool expected an error")
+	}
+	if _, err := cluster.NodePoolCount("unknown"); err == nil {
+		t.Errorf("NodePoolCount() of an unknown pool expected an error")
+	}
+}
+
+func TestKluster_Scale(t *testing.T) {
+	path, err := ioutil.TempDir("", "scale")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path)
+
+	raw, err := CreateCluster("kkscaleraw", "raw", path, "yaml", map[string]string{}, parentUI)
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	if _, err := raw.Scale(map[string]int{"worker": 2}, time.Minute, false); err == nil || !strings.Contains(err.Error(), "does not support scaling") {
+		t.Errorf("Scale() on raw error = %v, want not supported error", err)
+	}
+
+	cluster, err := CreateCluster("kkscale", "ec2", path, "yaml", map[string]string{}, parentUI)
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	if err := cluster.SetNodePoolCount("worker", 3); err != nil {
+		t.Fatalf("SetNodePoolCount() error = %v", err)
+	}
+
+	// there is no kubeconfig to drain the nodes, without force it's not removed
+	_, err = cluster.Scale(map[string]int{"worker": 1}, time.Minute, false)
+	if err == nil || !strings.Contains(err.Error(), "use force") {
+		t.Errorf("Scale() in without access to Kubernetes error = %v, want drain error", err)
+	}
+	if got, _ := cluster.NodePoolCount("worker"); got != 3 {
+		t.Errorf("Scale() failed but changed the node pool count to %d", got)
+	}
+
+	if _, err := cluster.Scale(map[string]int{"unknown": 1}, time.Minute, false); err == nil {
+		t.Errorf("Scale() of an unknown pool expected an error")
+	}
+}
+
+func Test_nodesToRemove(t *testing.T) {
+	hosts := configurator.Hosts{
+		{PublicIP: "10.0.0.1", Pool: "master"},
+		{PublicIP: "10.0.0.2", Pool: "worker"},
+		{PublicIP: "10.0.0.3", Pool: "worker"},
+		{PublicIP: "10.0.0.4", Pool: "worker"},
+		{PublicIP: "10.0.0.5", Pool: "gpu"},
+	}
+
+	tests := []struct {
+		name         string
+		removeCounts map[string]int
+		want         []string
+		wantErr      bool
+	}{
+		{"nothing to remove", map[string]int{}, []string{}, false},
+		{"last workers", map[string]int{"worker": 2}, []string{"10.0.0.3", "10.0.0.4"}, false},
+		{"several pools", map[string]int{"worker": 1, "gpu": 1}, []string{"10.0.0.5", "10.0.0.4"}, false},
+		{"more than the pool nodes", map[string]int{"master": 2}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nodesToRemove(hosts, tt.removeCounts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nodesToRemove() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotIPs := []string{}
+			for _, host := range got {
+				gotIPs = append(gotIPs, host.PublicIP)
+			}
+			if strings.Join(gotIPs, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("nodesToRemove() = %v, want %v", gotIPs, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package kluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// Start starts the cluster or the nodes filtered by name, IP, DNS or pool
+func (k *Kluster) Start(nodes []string, pools []string) error {
+	return k.power(nodes, pools, true)
+}
+
+// Stop stops the cluster or the nodes filtered by name, IP, DNS or pool
+func (k *Kluster) Stop(nodes []string, pools []string) error {
+	return k.power(nodes, pools, false)
+}
+
+// Restart stops and starts the cluster or the nodes filtered by name, IP, DNS
+// or pool
+func (k *Kluster) Restart(nodes []string, pools []string) error {
+	if err := k.Stop(nodes, pools); err != nil {
+		return err
+	}
+	return k.Start(nodes, pools)
+}
+
+func (k *Kluster) power(nodes []string, pools []string, on bool) error {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	if err := k.LoadState(); err != nil {
+		return err
+	}
+
+	hosts := k.HostsFilterBy(nodes, pools)
+	if len(hosts) == 0 {
+		return fmt.Errorf("not found nodes in cluster %q matching the nodes %v or pools %v", k.Name, nodes, pools)
+	}
+	allNodes := len(nodes) == 0 && len(pools) == 0
+
+	action := "stopping"
+	if on {
+		action = "starting"
+	}
+	k.ui.Log.Infof("%s %d nodes of cluster %q", action, len(hosts), k.Name)
+
+	var err error
+	switch platformName {
+	case "raw", "stacki", "vra":
+		err = k.powerServices(nodes, pools, on)
+	default:
+		err = k.powerNodes(hosts, allNodes, on)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The cluster status only changes when all the nodes are stopped or started
+	if allNodes {
+		if on {
+			k.State[platformName].Status = RunningStatus.String()
+		} else {
+			k.State[platformName].Status = StoppedStatus.String()
+		}
+	}
+
+	return nil
+}
+
+// powerNodes powers on or off the given hosts using the provisioner
+func (k *Kluster) powerNodes(hosts configurator.Hosts, allNodes bool, on bool) error {
+	platformName := k.Platform()
+	p := k.provisioner[platformName]
+
+	// if all the nodes are selected, do not send them so the provisioner handles
+	// the entire cluster
+	var nodes []*state.Node
+	if !allNodes {
+		nodes = make([]*state.Node, 0, len(hosts))
+		for _, host := range hosts {
+			nodes = append(nodes, &state.Node{
+				PublicIP:   host.PublicIP,
+				PrivateIP:  host.PrivateIP,
+				PublicDNS:  host.PublicDNS,
+				PrivateDNS: host.PrivateDNS,
+				RoleName:   host.RoleName,
+				Pool:       host.Pool,
+			})
+		}
+	}
+
+	if !on {
+		return p.Stop(nodes)
+	}
+
+	if err := p.Start(nodes); err != nil {
+		return err
+	}
+
+	// the provisioner refreshes the state if the nodes addresses change when
+	// they are started, like on EC2 and EKS
+	k.UpdateState(platformName)
+
+	return nil
+}
+
+// powerServices stops or starts the Kubernetes services on the given nodes, it's
+// used on the platforms where the nodes are not managed by KubeKit, so they
+// cannot be powered off or on
+func (k *Kluster) powerServices(nodes []string, pools []string, on bool) error {
+	command := powerServicesCMD(k.containerRuntime(), on)
+
+	result, err := k.Exec(command, "", nodes, pools, true)
+	if err != nil {
+		return err
+	}
+	if result.Failures == 0 {
+		return nil
+	}
+
+	failedNodes := []string{}
+	for host, res := range result.Hosts.GetSnapshot() {
+		if res.ExitStatus != 0 {
+			failedNodes = append(failedNodes, host)
+		}
+	}
+	sort.Strings(failedNodes)
+
+	return fmt.Errorf("failed to execute %q on the nodes: %s", command, strings.Join(failedNodes, ", "))
+}
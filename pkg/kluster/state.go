@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform/states"
 	"github.com/kraken/terraformer"
 	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
 )
 
 // State represent the final state of one platform. It's basically the
@@ -31,6 +32,18 @@ func (k *Kluster) LoadState() error {
 	}
 	stateFilename := k.StateFile()
 
+	if err := k.pull(stateFilename); err != nil {
+		return fmt.Errorf("can't get the state from %s. %s", remoteStorage.Name(), err)
+	}
+	if err := k.pull(state.PlatformStateFile(stateFilename)); err != nil {
+		return fmt.Errorf("can't get the platform state from %s. %s", remoteStorage.Name(), err)
+	}
+	// the certificates are generated by the first apply, the next ones, maybe
+	// from other working copy, reuse them
+	if err := k.pullDir(k.CertsDir()); err != nil {
+		return fmt.Errorf("can't get the certificates from %s. %s", remoteStorage.Name(), err)
+	}
+
 	var state *states.State
 
 	if _, err := os.Stat(stateFilename); !os.IsNotExist(err) {
@@ -128,19 +141,27 @@ func (k *Kluster) SaveState() error {
 
 	stateFilename := filepath.Join(stateDirname, platform+".tfstate")
 
+	// the state of the platforms without Terraform, like vRA, is saved by the
+	// provisioner, it only needs to be copied to the shared storage
+	if err := k.push(state.PlatformStateFile(stateFilename)); err != nil {
+		return err
+	}
+
 	p := k.provisioner[platform]
 	state := p.State()
 
 	if state == nil || state.Empty() {
 		k.ui.Log.Debugf("the state of cluster %q is empty, the tfstate file won't be saved", k.Name)
-		return nil
+		// the state file persisted by the provisioner, maybe empty after a
+		// termination, replaces the state in the shared storage
+		return k.push(stateFilename)
 	}
 
-	lock, err := lockFile(stateFilename)
-	defer lock.Unlock()
+	lock, err := k.lock(stateFilename)
 	if err != nil {
 		return err
 	}
+	defer lock.Unlock()
 
 	if _, err := os.Stat(stateFilename); os.IsExist(err) {
 		os.Rename(stateFilename, stateFilename+".bkp")
@@ -157,5 +178,11 @@ func (k *Kluster) SaveState() error {
 	}
 
 	k.ui.Log.Debugf("saved state of cluster %q to %s", k.Name, stateFilename)
-	return nil
+	return k.push(stateFilename)
+}
+
+// DeleteState deletes the Terraform state files of the cluster, locally and in
+// the shared storage
+func (k *Kluster) DeleteState() error {
+	return k.deleteDir(k.StateDir())
 }
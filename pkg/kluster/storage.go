@@ -0,0 +1,278 @@
+package kluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liferaft/kubekit/pkg/storage"
+)
+
+// remoteStorage is the shared storage where the clusters configuration and
+// Terraform state are kept. The clusters path is used as the local working
+// copy, the files are pulled from the storage before they are read and pushed
+// to the storage after they are written. If it's nil, only the local clusters
+// path is used
+var remoteStorage storage.Storage
+
+// SetStorage sets the shared storage for the clusters configuration, the
+// Terraform state, the certificates and the credentials file, or unset it if
+// it's nil. The credentials file is encrypted with the KubeKit key but the
+// certificates, including the CA private keys, are shared as they are, so the
+// access to the storage should be restricted to the cluster operators
+func SetStorage(s storage.Storage) {
+	remoteStorage = s
+}
+
+// Storage returns the storage where the clusters of the given path are kept,
+// the shared storage if it's set, otherwise the local clusters path
+func Storage(clustersPath string) storage.Storage {
+	if remoteStorage != nil {
+		return remoteStorage
+	}
+	return storage.NewLocal(clustersPath)
+}
+
+// storageKey returns the storage key of a file in the clusters path
+func storageKey(clustersPath, filename string) string {
+	rel, err := filepath.Rel(clustersPath, filename)
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// isConfigKey returns true if the key is of a cluster configuration file, that
+// is a file named as the default configuration filename in a cluster directory
+func isConfigKey(key string) bool {
+	parts := strings.Split(key, "/")
+	return len(parts) == 2 && strings.HasPrefix(parts[1], DefaultConfigFilename+".")
+}
+
+// pullClusters copies the configuration file of all the clusters in the shared
+// storage to the clusters path. It returns the cluster directories found in the
+// storage, or nil if there is no shared storage
+func pullClusters(clustersPath string) (map[string]bool, error) {
+	if remoteStorage == nil {
+		return nil, nil
+	}
+
+	keys, err := remoteStorage.List("")
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := map[string]bool{}
+	for _, key := range keys {
+		if !isConfigKey(key) {
+			continue
+		}
+		if err := pull(clustersPath, key); err != nil {
+			return nil, err
+		}
+		dirs[strings.Split(key, "/")[0]] = true
+	}
+
+	return dirs, nil
+}
+
+// pull copies the file with the given key from the shared storage to the
+// clusters path. It's not an error if the file is not in the storage
+func pull(clustersPath, key string) error {
+	if remoteStorage == nil {
+		return nil
+	}
+
+	data, err := remoteStorage.Get(key)
+	if err == storage.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Join(clustersPath, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, fileMode(key))
+}
+
+// fileMode returns the permissions of the file with the given key, only the
+// owner can read the certificates and credentials
+func fileMode(key string) os.FileMode {
+	parts := strings.Split(key, "/")
+	if len(parts) < 2 {
+		return 0644
+	}
+	if parts[1] == CertificatesDirname || parts[1] == CredentialsFileName {
+		return 0600
+	}
+	return 0644
+}
+
+// push copies the file with the given key from the clusters path to the shared
+// storage. It's not an error if the file does not exists
+func push(clustersPath, key string) error {
+	if remoteStorage == nil {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(clustersPath, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return remoteStorage.Put(key, data)
+}
+
+// clustersPath returns the directory with all the clusters, including this one
+func (k *Kluster) clustersPath() string {
+	return filepath.Dir(k.Dir())
+}
+
+// pull copies the given cluster file from the shared storage
+func (k *Kluster) pull(filename string) error {
+	return pull(k.clustersPath(), storageKey(k.clustersPath(), filename))
+}
+
+// push copies the given cluster file to the shared storage
+func (k *Kluster) push(filename string) error {
+	return push(k.clustersPath(), storageKey(k.clustersPath(), filename))
+}
+
+// pullDir copies all the files in the given cluster directory from the shared
+// storage
+func (k *Kluster) pullDir(dir string) error {
+	if remoteStorage == nil {
+		return nil
+	}
+
+	keys, err := remoteStorage.List(storageKey(k.clustersPath(), dir) + "/")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := pull(k.clustersPath(), key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushDir copies all the files in the given cluster directory to the shared
+// storage. It's not an error if the directory does not exists
+func (k *Kluster) pushDir(dir string) error {
+	if remoteStorage == nil {
+		return nil
+	}
+
+	return filepath.Walk(dir, func(filename string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(filename, storage.LockSuffix) {
+			return nil
+		}
+		return k.push(filename)
+	})
+}
+
+// deleteDir removes all the files in the given cluster directory, locally and
+// in the shared storage
+func (k *Kluster) deleteDir(dir string) error {
+	if remoteStorage != nil {
+		keys, err := remoteStorage.List(storageKey(k.clustersPath(), dir) + "/")
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := remoteStorage.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// pullClusterFile copies the given file of a cluster directory from the shared
+// storage
+func pullClusterFile(filename string) error {
+	clustersPath := filepath.Dir(filepath.Dir(filename))
+	return pull(clustersPath, storageKey(clustersPath, filename))
+}
+
+// pushClusterFile copies the given file of a cluster directory to the shared
+// storage
+func pushClusterFile(filename string) error {
+	clustersPath := filepath.Dir(filepath.Dir(filename))
+	return push(clustersPath, storageKey(clustersPath, filename))
+}
+
+// lock locks the given cluster file in the clusters path and, if set, in the
+// shared storage
+func (k *Kluster) lock(filename string) (storage.Unlocker, error) {
+	localLock, err := lockFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if remoteStorage == nil {
+		return localLock, nil
+	}
+
+	remoteLock, err := remoteStorage.Lock(storageKey(k.clustersPath(), filename))
+	if err != nil {
+		localLock.Unlock()
+		return nil, err
+	}
+
+	return locks{localLock, remoteLock}, nil
+}
+
+// locks is a group of locks released in reverse order
+type locks []storage.Unlocker
+
+func (l locks) Unlock() error {
+	var err error
+	for i := len(l) - 1; i >= 0; i-- {
+		if errU := l[i].Unlock(); errU != nil && err == nil {
+			err = errU
+		}
+	}
+	return err
+}
+
+// deleteFromStorage removes all the files of the cluster in the given directory
+// from the shared storage
+func deleteFromStorage(clusterDir string) error {
+	if remoteStorage == nil {
+		return nil
+	}
+
+	keys, err := remoteStorage.List(filepath.Base(clusterDir) + "/")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := remoteStorage.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteConfig deletes the configuration and all the files of the cluster in
+// the given directory, locally and in the shared storage
+func DeleteConfig(clusterDir string) error {
+	if err := deleteFromStorage(clusterDir); err != nil {
+		return err
+	}
+	return os.RemoveAll(clusterDir)
+}
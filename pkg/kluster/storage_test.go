@@ -0,0 +1,116 @@
+package kluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/crypto"
+	"github.com/liferaft/kubekit/pkg/storage"
+)
+
+func TestSharedStorage(t *testing.T) {
+	sharedPath, err := ioutil.TempDir("", "shared")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(sharedPath)
+	// the working copies of two engineers
+	path1, err := ioutil.TempDir("", "clusters1")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path1)
+	path2, err := ioutil.TempDir("", "clusters2")
+	if err != nil {
+		t.Fatalf("failed to create a temporal directory. %v", err)
+	}
+	defer os.RemoveAll(path2)
+
+	SetStorage(storage.NewLocal(sharedPath))
+	defer SetStorage(nil)
+
+	cluster, err := CreateCluster("kkshared", "ec2", path1, "yaml", map[string]string{}, parentUI)
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+
+	key := storageKey(path1, cluster.Path())
+	if _, err := Storage(path1).Get(key); err != nil {
+		t.Errorf("the cluster configuration %q was not pushed to the shared storage. %v", key, err)
+	}
+
+	// the cluster is in the working copy of the second engineer
+	names, err := ListNames(path2)
+	if err != nil {
+		t.Fatalf("ListNames() error = %v", err)
+	}
+	if len(names) != 1 || names[0] != "kkshared" {
+		t.Fatalf("ListNames() = %v, want [kkshared]", names)
+	}
+
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(sharedPath, filepath.Base(cluster.Dir()), ".apply.lock")); err != nil {
+		t.Errorf("Lock() the cluster is not locked in the shared storage. %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Errorf("Unlock() error = %v", err)
+	}
+
+	// the certificates and credentials of the first engineer are used by the
+	// second one
+	certFile := filepath.Join(cluster.CertsDir(), "ec2", "root_ca.key")
+	os.MkdirAll(filepath.Dir(certFile), 0700)
+	if err := ioutil.WriteFile(certFile, []byte("CA key"), 0600); err != nil {
+		t.Fatalf("failed to write the certificate. %v", err)
+	}
+	if err := cluster.pushDir(cluster.CertsDir()); err != nil {
+		t.Fatalf("pushDir() error = %v", err)
+	}
+	os.Setenv(crypto.EnvKeyName, "Shared4KubeKitKy")
+	defer os.Unsetenv(crypto.EnvKeyName)
+	credentials := NewPlatformCredentials("kkshared", "vsphere", filepath.Join(cluster.Dir(), CredentialsFileName))
+	credentials.SetParameters("vcenter.example.com", "admin", "secret")
+	if err := credentials.Write(); err != nil {
+		t.Fatalf("Write() credentials error = %v", err)
+	}
+
+	cluster2 := &Kluster{path: filepath.Join(path2, filepath.Base(cluster.Dir()), filepath.Base(cluster.Path()))}
+	if err := cluster2.pullDir(cluster2.CertsDir()); err != nil {
+		t.Fatalf("pullDir() error = %v", err)
+	}
+	certFile2 := filepath.Join(cluster2.CertsDir(), "ec2", "root_ca.key")
+	if info, err := os.Stat(certFile2); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("the certificate was not pulled from the shared storage with mode 0600. %v", err)
+	}
+	credentials2 := NewPlatformCredentials("kkshared", "vsphere", filepath.Join(cluster2.Dir(), CredentialsFileName))
+	if err := credentials2.Read(); err != nil {
+		t.Fatalf("Read() credentials error = %v", err)
+	}
+	if credentials2.Password != "secret" {
+		t.Errorf("Read() credentials from the shared storage, password = %q, want %q", credentials2.Password, "secret")
+	}
+
+	if err := cluster.DeleteCerts(); err != nil {
+		t.Fatalf("DeleteCerts() error = %v", err)
+	}
+	if _, err := Storage(path1).Get(storageKey(path1, certFile)); err != storage.ErrNotFound {
+		t.Errorf("the certificate was not deleted from the shared storage. %v", err)
+	}
+
+	// deleted by the first engineer, it's not listed by the second one
+	if err := DeleteConfig(cluster.Dir()); err != nil {
+		t.Fatalf("DeleteConfig() error = %v", err)
+	}
+	names, err = ListNames(path2)
+	if err != nil {
+		t.Fatalf("ListNames() error = %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("ListNames() = %v, want no clusters after delete", names)
+	}
+}
@@ -0,0 +1,210 @@
+package kluster
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+	"github.com/liferaft/kubekit/pkg/configurator/kube"
+	"github.com/liferaft/kubekit/pkg/manifest"
+)
+
+// Release returns the KubeKit release installed on the cluster. It's the
+// release in the VERSION file uploaded by the configurator to the master nodes
+func (k *Kluster) Release() (string, error) {
+	versionFile := filepath.Join(configurator.ConfiguratorBaseDir, "VERSION")
+	masters := k.HostsFilterBy(nil, []string{"master"})
+	if len(masters) == 0 {
+		return "", fmt.Errorf("not found master nodes in the cluster %q", k.Name)
+	}
+
+	for _, master := range masters {
+		result, err := k.Exec("cat "+versionFile, "", []string{master.PublicIP}, nil, false)
+		if err != nil || result.Failures != 0 {
+			k.ui.Log.Debugf("failed to read the file %s on %s. %v", versionFile, master.PublicIP, err)
+			continue
+		}
+		res, ok := result.Hosts.GetSnapshot()[master.PublicIP]
+		if !ok {
+			continue
+		}
+		if release := strings.TrimSpace(res.Stdout); len(release) != 0 {
+			return release, nil
+		}
+	}
+
+	return "", fmt.Errorf("cannot get the release of the cluster %q from the file %s on the master nodes", k.Name, versionFile)
+}
+
+// Upgrade upgrades the Kubernetes cluster to the given KubeKit release. The
+// upgrade path from the current release is verified and a snapshot of etcd is
+// taken before start. The package of the release is copied and installed on
+// every node, then the master nodes are upgraded one at a time and the worker
+// nodes pool by pool. Every node is drained before the upgrade and the cluster
+// health is verified before continue with the next step
+func (k *Kluster) Upgrade(to, pkgFilename string, forcePkg bool, drainTimeout time.Duration) error {
+	platformName := k.Platform()
+	logPrefix := fmt.Sprintf("KubeKit [ %s@%s ]", k.Name, platformName)
+	k.ui.SetLogPrefix(logPrefix)
+
+	switch platformName {
+	case "eks", "aks":
+		return fmt.Errorf("the %s platform does not support upgrades, the Kubernetes version is managed by the platform", platformName)
+	}
+
+	if err := k.LoadState(); err != nil {
+		return err
+	}
+	if len(k.State[platformName].Nodes) == 0 {
+		return fmt.Errorf("the cluster %q does not have nodes to upgrade", k.Name)
+	}
+
+	from, err := k.Release()
+	if err != nil {
+		return err
+	}
+	if err := manifest.CanUpgrade(from, to); err != nil {
+		return fmt.Errorf("cannot upgrade the cluster %q from release %s to %s. %s", k.Name, from, to, err)
+	}
+	if len(pkgFilename) == 0 {
+		return fmt.Errorf("the package of the release %s is required to upgrade the cluster %q", to, k.Name)
+	}
+	release := manifest.KubeManifest.Releases[to]
+	k.ui.Log.Infof("upgrading the cluster %q from release %s to %s (Kubernetes %s, etcd %s)", k.Name, from, to, release.KubernetesVersion, release.EtcdVersion)
+
+	snapshotName := fmt.Sprintf("snapshot-upgrade-%s-%s", from, time.Now().UTC().Format("2006-01-02T15-04-05Z"))
+	if _, _, err := k.SnapshotEtcd(snapshotName); err != nil {
+		return fmt.Errorf("the upgrade was not started. %s", err)
+	}
+
+	if err := k.installUpgradePackage(pkgFilename, forcePkg); err != nil {
+		return fmt.Errorf("the upgrade was not started. %s", err)
+	}
+
+	pConf := k.provisioner[platformName].Config()
+	conf, err := configurator.New(k.Name, platformName, k.State[platformName].Address, k.State[platformName].Port, k.State[platformName].Nodes, k.State[platformName].Data, pConf, k.Config, k.Resources, k.Charts, k.Dir(), k.ui)
+	if err != nil {
+		return err
+	}
+	conf = conf.WithContext(k.ctx).WithRelease(to)
+
+	client, err := kube.NewClientE("", filepath.Join(k.CertsDir(), "kubeconfig"), k.ui)
+	if err != nil {
+		return fmt.Errorf("cannot connect to the Kubernetes cluster to drain the nodes. %s", err)
+	}
+
+	for _, step := range upgradeSteps(k.State[platformName].Nodes) {
+		k.ui.Log.Infof("upgrading %s", step.name)
+		if err := k.upgradeHosts(conf, client, step.hosts, drainTimeout); err != nil {
+			k.State[platformName].Status = FailedConfigurationStatus.String()
+			return fmt.Errorf("failed to upgrade %s. %s", step.name, err)
+		}
+	}
+
+	k.State[platformName].Status = RunningStatus.String()
+	k.ui.Log.Infof("the cluster %q was upgraded to release %s", k.Name, to)
+
+	return nil
+}
+
+// installUpgradePackage copies the package of the target release to every node
+// and installs it, the nodes are configured with the installed release
+func (k *Kluster) installUpgradePackage(pkgFilename string, forcePkg bool) error {
+	k.ui.Log.Infof("installing the package %s on every node", filepath.Base(pkgFilename))
+	if err := k.CopyPackage(pkgFilename, "/tmp/", true); err != nil {
+		return fmt.Errorf("failed to copy the package %s to the nodes. %s", pkgFilename, err)
+	}
+
+	pkgFilepath := filepath.Join("/tmp", filepath.Base(pkgFilename))
+	result, failedNodes, err := k.InstallPackage(pkgFilepath, forcePkg)
+	if err != nil {
+		return fmt.Errorf("failed to install the package %s. %s", pkgFilepath, err)
+	}
+	if result.Failures != 0 {
+		return fmt.Errorf("failed to install the package %s in %d/%d nodes: %s", pkgFilepath, result.Failures, result.Success+result.Failures, strings.Join(failedNodes, ", "))
+	}
+
+	return nil
+}
+
+type upgradeStep struct {
+	name  string
+	hosts configurator.Hosts
+}
+
+// upgradeSteps returns the group of hosts to upgrade at the same time, in
+// order. Every master node is a step, then every pool of worker nodes
+func upgradeSteps(hosts configurator.Hosts) []upgradeStep {
+	steps := []upgradeStep{}
+	for _, master := range hosts.FilterByRole("master") {
+		steps = append(steps, upgradeStep{
+			name:  fmt.Sprintf("master node %s", master.PublicIP),
+			hosts: configurator.Hosts{master},
+		})
+	}
+
+	pools := map[string]configurator.Hosts{}
+	for _, host := range hosts {
+		if host.RoleName == "master" {
+			continue
+		}
+		pool := host.Pool
+		if len(pool) == 0 {
+			pool = host.RoleName
+		}
+		pools[pool] = append(pools[pool], host)
+	}
+	poolNames := make([]string, 0, len(pools))
+	for poolName := range pools {
+		poolNames = append(poolNames, poolName)
+	}
+	sort.Strings(poolNames)
+
+	for _, poolName := range poolNames {
+		steps = append(steps, upgradeStep{
+			name:  fmt.Sprintf("node pool %s", poolName),
+			hosts: pools[poolName],
+		})
+	}
+
+	return steps
+}
+
+// upgradeHosts drains the given hosts, configures them with the new release
+// and makes them schedulable again. The configuration of the hosts waits for
+// the cluster to be ready and validates it
+func (k *Kluster) upgradeHosts(conf *configurator.Configurator, client *kube.Client, hosts configurator.Hosts, drainTimeout time.Duration) error {
+	nodeNames := []string{}
+	for _, host := range hosts {
+		nodeName, err := client.NodeByAddress(host.PrivateDNS, host.PublicDNS, host.PrivateIP, host.PublicIP)
+		if err != nil {
+			k.ui.Log.Warnf("cannot find the Kubernetes node of host %s, it won't be drained. %s", host.PublicIP, err)
+			continue
+		}
+		k.ui.Log.Infof("draining node %s", nodeName)
+		if err := client.DrainNode(nodeName, drainTimeout); err != nil {
+			return fmt.Errorf("failed to drain node %s. %s", nodeName, err)
+		}
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	addresses := []string{}
+	for _, host := range hosts {
+		addresses = append(addresses, host.PublicIP)
+	}
+	if err := conf.ConfigureHosts(addresses...); err != nil {
+		return err
+	}
+
+	for _, nodeName := range nodeNames {
+		k.ui.Log.Debugf("uncordoning node %s", nodeName)
+		if err := client.UncordonNode(nodeName); err != nil {
+			return fmt.Errorf("failed to uncordon node %s. %s", nodeName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package kluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/liferaft/kubekit/pkg/configurator"
+)
+
+func Test_upgradeSteps(t *testing.T) {
+	hosts := configurator.Hosts{
+		configurator.Host{PublicIP: "10.0.0.4", RoleName: "worker", Pool: "worker"},
+		configurator.Host{PublicIP: "10.0.0.1", RoleName: "master", Pool: "master"},
+		configurator.Host{PublicIP: "10.0.0.5", RoleName: "gpu", Pool: "gpu"},
+		configurator.Host{PublicIP: "10.0.0.2", RoleName: "master", Pool: "master"},
+		configurator.Host{PublicIP: "10.0.0.3", RoleName: "worker", Pool: "worker"},
+	}
+
+	got := []string{}
+	for _, step := range upgradeSteps(hosts) {
+		ips := ""
+		for _, host := range step.hosts {
+			ips += " " + host.PublicIP
+		}
+		got = append(got, step.name+":"+ips)
+	}
+
+	want := []string{
+		"master node 10.0.0.1: 10.0.0.1",
+		"master node 10.0.0.2: 10.0.0.2",
+		"node pool gpu: 10.0.0.5",
+		"node pool worker: 10.0.0.4 10.0.0.3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("upgradeSteps() = %v, want %v", got, want)
+	}
+}
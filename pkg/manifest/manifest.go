@@ -31,14 +31,20 @@ type Release struct {
 	PreviousVersion   string       `json:"previous-version" yaml:"previous-version" mapstructure:"previous-version"`
 	KubernetesVersion string       `json:"kubernetes-version" yaml:"kubernetes-version" mapstructure:"kubernetes-version"`
 	DockerVersion     string       `json:"docker-version" yaml:"docker-version" mapstructure:"docker-version"`
+	ContainerdVersion string       `json:"containerd-version,omitempty" yaml:"containerd-version,omitempty" mapstructure:"containerd-version"`
 	EtcdVersion       string       `json:"etcd-version" yaml:"etcd-version" mapstructure:"etcd-version"`
 	Dependencies      Dependencies `json:"dependencies" yaml:"dependencies" mapstructure:"dependencies"`
 }
 
-// Dependencies has 1 kind of dependencies list: core
+// Dependencies has 4 kinds of dependencies list: control-plane, core and cni,
+// with the container images, and container-runtime, with the binaries of the
+// container runtime installed when it's not Docker. The cni images are used
+// only by the CNI provider with the same name prefix
 type Dependencies struct {
-	ControlPlane map[string]Dependency `json:"control-plane,omitempty" yaml:"control-plane,omitempty" mapstructure:"control-plane"`
-	Core         map[string]Dependency `json:"core,omitempty" yaml:"core,omitempty" mapstructure:"core"`
+	ControlPlane     map[string]Dependency `json:"control-plane,omitempty" yaml:"control-plane,omitempty" mapstructure:"control-plane"`
+	Core             map[string]Dependency `json:"core,omitempty" yaml:"core,omitempty" mapstructure:"core"`
+	CNI              map[string]Dependency `json:"cni,omitempty" yaml:"cni,omitempty" mapstructure:"cni"`
+	ContainerRuntime map[string]Dependency `json:"container-runtime,omitempty" yaml:"container-runtime,omitempty" mapstructure:"container-runtime"`
 }
 
 // Dependency defines a software, package or application required on KubeOS
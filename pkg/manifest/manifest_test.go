@@ -68,6 +68,63 @@ func TestManifestWriteFile(t *testing.T) {
 	}
 }
 
+func TestManifestUpgradePath(t *testing.T) {
+	m := manifest.Manifest{
+		Releases: map[string]manifest.Release{
+			"1.2.0": manifest.Release{PreviousVersion: "1.1.0"},
+			"1.1.0": manifest.Release{PreviousVersion: "1.0.0"},
+			"1.0.0": manifest.Release{},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    []string
+		wantErr bool
+	}{
+		{"previous release", "1.1.0", "1.2.0", []string{"1.2.0"}, false},
+		{"intermediate release", "1.0.0", "1.2.0", []string{"1.1.0", "1.2.0"}, false},
+		{"same release", "1.2.0", "1.2.0", nil, true},
+		{"downgrade", "1.2.0", "1.0.0", nil, true},
+		{"unknown target", "1.2.0", "1.3.0", nil, true},
+		{"unknown source", "0.9.0", "1.2.0", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.UpgradePath(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpgradePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("UpgradePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanUpgrade(t *testing.T) {
+	previous := manifest.KubeManifest.Releases[manifest.Version].PreviousVersion
+	if err := manifest.CanUpgrade(previous, manifest.Version); err != nil {
+		t.Errorf("cannot upgrade from the previous release %s to %s. %s", previous, manifest.Version, err)
+	}
+	if err := manifest.CanUpgrade(manifest.Version, manifest.Version); err == nil {
+		t.Errorf("expected an error upgrading to the same release %s", manifest.Version)
+	}
+
+	// any release in the manifest can be the target, not only this version
+	kubeManifest := manifest.KubeManifest
+	defer func() { manifest.KubeManifest = kubeManifest }()
+	manifest.KubeManifest = manifestDataTest
+	if err := manifest.CanUpgrade("1.1.0", "1.1.1"); err != nil {
+		t.Errorf("cannot upgrade from the release 1.1.0 to 1.1.1. %s", err)
+	}
+	if err := manifest.CanUpgrade("1.1.0", "1.2.0"); err == nil {
+		t.Errorf("expected an error upgrading to a release not in the manifest")
+	}
+}
+
 var manifestDataTest = manifest.Manifest{
 	Releases: map[string]manifest.Release{
 		"1.1.1": manifest.Release{
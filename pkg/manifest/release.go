@@ -4,10 +4,13 @@ var release = Release{
 	PreviousVersion:   "2.0.16",
 	KubernetesVersion: controlplane["kube-apiserver"].Version,
 	DockerVersion:     "19.03.1",
+	ContainerdVersion: containerruntime["containerd"].Version,
 	EtcdVersion:       controlplane["etcd"].Version,
 	Dependencies: Dependencies{
-		ControlPlane: controlplane,
-		Core:         core,
+		ControlPlane:     controlplane,
+		Core:             core,
+		CNI:              cni,
+		ContainerRuntime: containerruntime,
 	},
 }
 
@@ -212,3 +215,71 @@ var core = map[string]Dependency{
 		ChecksumType: "sha256",
 	},
 }
+
+// cni are the images of the CNI providers other than Calico, which images are
+// in core. Every image name starts with the name of the provider using it. The
+// images are pulled by digest, an image without checksum has to be prebaked
+// TODO: set the digests of the images before releasing
+var cni = map[string]Dependency{
+	"cilium": Dependency{
+		Version:      "v1.6.5",
+		Name:         "cilium",
+		Src:          "docker.io/cilium/cilium:v1.6.5",
+		PrebakePath:  "/opt/kubekit/kubekit-core/docker.io/cilium/cilium-v1.6.5.tar.xz",
+		Checksum:     "",
+		ChecksumType: "sha256",
+		LicenseURL:   "https://github.com/cilium/cilium/blob/master/LICENSE",
+	},
+	"cilium-operator": Dependency{
+		Version:      "v1.6.5",
+		Name:         "cilium-operator",
+		Src:          "docker.io/cilium/operator:v1.6.5",
+		PrebakePath:  "/opt/kubekit/kubekit-core/docker.io/cilium/operator-v1.6.5.tar.xz",
+		Checksum:     "",
+		ChecksumType: "sha256",
+		LicenseURL:   "https://github.com/cilium/cilium/blob/master/LICENSE",
+	},
+	"flannel": Dependency{
+		Version:      "v0.11.0",
+		Name:         "flannel",
+		Src:          "quay.io/coreos/flannel:v0.11.0-amd64",
+		PrebakePath:  "/opt/kubekit/kubekit-core/quay.io/coreos/flannel-v0.11.0-amd64.tar.xz",
+		Checksum:     "",
+		ChecksumType: "sha256",
+		LicenseURL:   "https://github.com/coreos/flannel/blob/master/LICENSE",
+	},
+}
+
+// containerruntime are the binaries installed when the container runtime is
+// containerd. The checksums are verified when the archives are downloaded, the
+// archives without checksum are not downloaded, they have to be prebaked
+// TODO: set the checksums of the release archives before releasing
+var containerruntime = map[string]Dependency{
+	"containerd": Dependency{
+		Version:      "1.3.3",
+		Name:         "containerd",
+		Src:          "https://github.com/containerd/containerd/releases/download/v1.3.3/containerd-1.3.3.linux-amd64.tar.gz",
+		PrebakePath:  "/opt/kubekit/kubekit-container-runtime/containerd-1.3.3.linux-amd64.tar.gz",
+		Checksum:     "",
+		ChecksumType: "sha256",
+		LicenseURL:   "https://github.com/containerd/containerd/blob/master/LICENSE",
+	},
+	"runc": Dependency{
+		Version:      "v1.0.0-rc10",
+		Name:         "runc",
+		Src:          "https://github.com/opencontainers/runc/releases/download/v1.0.0-rc10/runc.amd64",
+		PrebakePath:  "/opt/kubekit/kubekit-container-runtime/runc-v1.0.0-rc10.amd64",
+		Checksum:     "",
+		ChecksumType: "sha256",
+		LicenseURL:   "https://github.com/opencontainers/runc/blob/master/LICENSE",
+	},
+	"crictl": Dependency{
+		Version:      "v1.15.0",
+		Name:         "crictl",
+		Src:          "https://github.com/kubernetes-sigs/cri-tools/releases/download/v1.15.0/crictl-v1.15.0-linux-amd64.tar.gz",
+		PrebakePath:  "/opt/kubekit/kubekit-container-runtime/crictl-v1.15.0-linux-amd64.tar.gz",
+		Checksum:     "",
+		ChecksumType: "sha256",
+		LicenseURL:   "https://github.com/kubernetes-sigs/cri-tools/blob/master/LICENSE",
+	},
+}
@@ -0,0 +1,54 @@
+package manifest
+
+import "fmt"
+
+// UpgradePath returns the releases to install, in order, to upgrade a cluster
+// from the release `from` to the release `to`. Every release can only be
+// upgraded from its previous version, so the path is built following the
+// previous version of every release, starting from the target release
+func (m *Manifest) UpgradePath(from, to string) ([]string, error) {
+	if from == to {
+		return nil, fmt.Errorf("the cluster is already in the release %s", to)
+	}
+	if _, ok := m.Releases[to]; !ok {
+		return nil, fmt.Errorf("the release %s is not in the manifest", to)
+	}
+
+	path := []string{}
+	visited := map[string]struct{}{}
+	for version := to; version != from; {
+		release, ok := m.Releases[version]
+		if !ok {
+			return nil, fmt.Errorf("there is no upgrade path from release %s to %s, the release %s is not in the manifest", from, to, version)
+		}
+		if _, ok := visited[version]; ok {
+			return nil, fmt.Errorf("there is no upgrade path from release %s to %s, found a loop in the release %s", from, to, version)
+		}
+		visited[version] = struct{}{}
+
+		path = append([]string{version}, path...)
+
+		if len(release.PreviousVersion) == 0 {
+			return nil, fmt.Errorf("there is no upgrade path from release %s to %s", from, to)
+		}
+		version = release.PreviousVersion
+	}
+
+	return path, nil
+}
+
+// CanUpgrade returns an error if a cluster in the release `from` cannot be
+// upgraded to the release `to` by this KubeKit. The target release has to be in
+// the manifest of this KubeKit and the cluster has to be in the previous version
+// of it
+func CanUpgrade(from, to string) error {
+	path, err := KubeManifest.UpgradePath(from, to)
+	if err != nil {
+		return err
+	}
+	if len(path) > 1 {
+		return fmt.Errorf("the cluster has to be upgraded to the release %s first", path[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+// Package metrics is a minimal implementation of Prometheus metrics (counters,
+// gauges and histograms with labels) exposed in the Prometheus text format.
+//
+// The Prometheus client library (github.com/prometheus/client_golang) is not
+// used on purpose. The Kubernetes 1.15 client used by KubeKit selects the
+// v0.9.3 release, and the releases with the current API upgrade modules shared
+// with the Kubernetes client, such as json-iterator and gofuzz. The server only
+// needs a few counters, gauges and histograms, so this package exposes them in
+// the text format version 0.0.4, which any Prometheus server scrapes, without
+// new dependencies. Once the Kubernetes client is upgraded, this package can be
+// replaced by the client library keeping the same metric names
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefBuckets are the default histogram buckets, in seconds, for the latency of
+// a request
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Collector is a metric, or a family of metrics with labels, able to write
+// itself in the Prometheus text format
+type Collector interface {
+	Name() string
+	Write(w io.Writer) error
+}
+
+// Registry is a set of collectors exposed together
+type Registry struct {
+	mu         sync.RWMutex
+	collectors map[string]Collector
+}
+
+// DefaultRegistry is the registry used by the package functions
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		collectors: make(map[string]Collector),
+	}
+}
+
+// Register adds the collectors to the registry. A collector replaces the one
+// already registered with the same name
+func (r *Registry) Register(collectors ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, c := range collectors {
+		r.collectors[c.Name()] = c
+	}
+}
+
+// Write writes all the metrics in the registry in the Prometheus text format,
+// sorted by name
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.collectors))
+	for name := range r.collectors {
+		names = append(names, name)
+	}
+	collectors := make([]Collector, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		collectors = append(collectors, r.collectors[name])
+	}
+	r.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		if err := c.Write(bw); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Handler returns the HTTP handler to expose the metrics in the registry
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		if err := r.Write(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// Register adds the collectors to the default registry
+func Register(collectors ...Collector) {
+	DefaultRegistry.Register(collectors...)
+}
+
+// Handler returns the HTTP handler to expose the metrics in the default registry
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+// desc is the description of a family of metrics
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d desc) Name() string {
+	return d.name
+}
+
+func (d desc) writeHeader(w io.Writer, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, metricType)
+}
+
+func (d desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metric %s requires %d label values, got %d", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelPairs returns the labels in the format `{name="value",...}`, with the
+// extra pair, if any, at the end
+func (d desc) labelPairs(values []string, extra ...string) string {
+	if len(d.labels) == 0 && len(extra) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(d.labels)+1)
+	for i, label := range d.labels {
+		pairs = append(pairs, label+`="`+escaper.Replace(values[i])+`"`)
+	}
+	if len(extra) == 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[0], extra[1]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CounterVec is a family of counters partitioned by labels
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	labels map[string][]string
+	values map[string]float64
+}
+
+// NewCounterVec creates a family of counters with the given label names
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{
+		desc:   desc{name: name, help: help, labels: labels},
+		labels: make(map[string][]string),
+		values: make(map[string]float64),
+	}
+}
+
+// Inc increments by 1 the counter with the given label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the given value, which should be positive, to the counter with the
+// given label values
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	key := c.key(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.labels[key]; !ok {
+		c.labels[key] = append([]string{}, labelValues...)
+	}
+	c.values[key] += v
+}
+
+// Write implements the Collector interface
+func (c *CounterVec) Write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeHeader(w, "counter")
+	for _, key := range sortedKeys(c.labels) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(c.labels[key]), formatFloat(c.values[key]))
+	}
+	return nil
+}
+
+// Sample is the value of a gauge with the given label values
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// GaugeFunc is a family of gauges partitioned by labels, its values are
+// obtained from a function every time the metrics are collected
+type GaugeFunc struct {
+	desc
+	fn func() []Sample
+}
+
+// NewGaugeFunc creates a family of gauges with the given label names. The
+// function returns the value of every gauge in the family
+func NewGaugeFunc(name, help string, fn func() []Sample, labels ...string) *GaugeFunc {
+	return &GaugeFunc{
+		desc: desc{name: name, help: help, labels: labels},
+		fn:   fn,
+	}
+}
+
+// Write implements the Collector interface
+func (g *GaugeFunc) Write(w io.Writer) error {
+	samples := g.fn()
+	sort.Slice(samples, func(i, j int) bool {
+		return strings.Join(samples[i].LabelValues, "\xff") < strings.Join(samples[j].LabelValues, "\xff")
+	})
+
+	g.writeHeader(w, "gauge")
+	for _, s := range samples {
+		g.key(s.LabelValues)
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.labelPairs(s.LabelValues), formatFloat(s.Value))
+	}
+	return nil
+}
+
+// HistogramVec is a family of histograms partitioned by labels
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	labels  map[string][]string
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec creates a family of histograms with the given buckets upper
+// bounds, sorted in increasing order, and label names. The "+Inf" bucket is
+// added implicitly
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			panic(fmt.Sprintf("histogram %s buckets are not in increasing order", name))
+		}
+	}
+	return &HistogramVec{
+		desc:    desc{name: name, help: help, labels: labels},
+		buckets: buckets,
+		labels:  make(map[string][]string),
+		values:  make(map[string]*histogram),
+	}
+}
+
+// Observe adds a single observation to the histogram with the given label values
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist, ok := h.values[key]
+	if !ok {
+		h.labels[key] = append([]string{}, labelValues...)
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+	for i, upperBound := range h.buckets {
+		if v <= upperBound {
+			hist.counts[i]++
+		}
+	}
+	hist.count++
+	hist.sum += v
+}
+
+// Write implements the Collector interface
+func (h *HistogramVec) Write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(w, "histogram")
+	for _, key := range sortedKeys(h.labels) {
+		values, hist := h.labels[key], h.values[key]
+		for i, upperBound := range h.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(values, "le", formatFloat(upperBound)), hist.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(values, "le", "+Inf"), hist.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(values), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(values), hist.count)
+	}
+	return nil
+}
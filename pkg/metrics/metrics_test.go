@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	requests := NewCounterVec("test_requests_total", "Total number of requests.", "method", "code")
+	requests.Inc("Apply", "OK")
+	requests.Inc("Apply", "OK")
+	requests.Inc("Delete", `Permission "Denied"`)
+
+	clusters := NewGaugeFunc("test_clusters", "Number of clusters.", func() []Sample {
+		return []Sample{
+			{LabelValues: []string{"ec2"}, Value: 2},
+			{LabelValues: []string{"aks"}, Value: 1},
+		}
+	}, "platform")
+
+	duration := NewHistogramVec("test_duration_seconds", "Duration.", []float64{1, 10}, "platform")
+	duration.Observe(0.5, "ec2")
+	duration.Observe(5, "ec2")
+	duration.Observe(50, "ec2")
+
+	r := NewRegistry()
+	r.Register(requests, duration, clusters)
+
+	var b bytes.Buffer
+	if err := r.Write(&b); err != nil {
+		t.Fatalf("Registry.Write() error = %s", err)
+	}
+
+	want := `# HELP test_clusters Number of clusters.
+# TYPE test_clusters gauge
+test_clusters{platform="aks"} 1
+test_clusters{platform="ec2"} 2
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{platform="ec2",le="1"} 1
+test_duration_seconds_bucket{platform="ec2",le="10"} 2
+test_duration_seconds_bucket{platform="ec2",le="+Inf"} 3
+test_duration_seconds_sum{platform="ec2"} 55.5
+test_duration_seconds_count{platform="ec2"} 3
+# HELP test_requests_total Total number of requests.
+# TYPE test_requests_total counter
+test_requests_total{method="Apply",code="OK"} 2
+test_requests_total{method="Delete",code="Permission \"Denied\""} 1
+`
+	assert.Equal(t, want, b.String())
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	r := NewRegistry()
+	counter := NewCounterVec("test_total", "Total.")
+	counter.Inc()
+	r.Register(counter)
+	// registering a collector with the same name replaces it
+	r.Register(NewCounterVec("test_total", "Total."))
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "# HELP test_total Total.\n# TYPE test_total counter\n", rec.Body.String())
+}
@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Webhook payload formats
+const (
+	FormatJSON        = "json"
+	FormatSlack       = "slack"
+	FormatCloudEvents = "cloudevents"
+)
+
+const (
+	defRetries                  = 3
+	defTimeout                  = 10 * time.Second
+	defCertificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// Config is the notifications configuration, from the `notifications` section
+// of the KubeKit configuration file
+type Config struct {
+	Webhooks []*WebhookConfig `json:"webhooks" yaml:"webhooks" toml:"webhooks" mapstructure:"webhooks"`
+	// CertificateExpiryWarning is how long before a certificate expires to send
+	// the cluster.certificate.expiring event. Default: 720h (30 days)
+	CertificateExpiryWarning string `json:"certificate_expiry_warning,omitempty" yaml:"certificate_expiry_warning,omitempty" toml:"certificate_expiry_warning,omitempty" mapstructure:"certificate_expiry_warning"`
+}
+
+// WebhookConfig is the configuration of a webhook. The events and clusters are
+// glob patterns, if they are empty every event or cluster is sent
+type WebhookConfig struct {
+	Name     string   `json:"name,omitempty" yaml:"name,omitempty" toml:"name,omitempty" mapstructure:"name"`
+	URL      string   `json:"url" yaml:"url" toml:"url" mapstructure:"url"`
+	Format   string   `json:"format,omitempty" yaml:"format,omitempty" toml:"format,omitempty" mapstructure:"format"`
+	Secret   string   `json:"secret,omitempty" yaml:"secret,omitempty" toml:"secret,omitempty" mapstructure:"secret"`
+	Events   []string `json:"events,omitempty" yaml:"events,omitempty" toml:"events,omitempty" mapstructure:"events"`
+	Clusters []string `json:"clusters,omitempty" yaml:"clusters,omitempty" toml:"clusters,omitempty" mapstructure:"clusters"`
+	Retries  *int     `json:"retries,omitempty" yaml:"retries,omitempty" toml:"retries,omitempty" mapstructure:"retries"`
+	Timeout  string   `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty" mapstructure:"timeout"`
+
+	timeout time.Duration
+}
+
+// validate checks the webhook configuration and set the default values
+func (c *WebhookConfig) validate() error {
+	if len(c.Name) == 0 {
+		c.Name = c.URL
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("the webhook %q URL must be an http or https URL", c.Name)
+	}
+
+	switch c.Format {
+	case "":
+		c.Format = FormatJSON
+	case FormatJSON, FormatSlack, FormatCloudEvents:
+	default:
+		return fmt.Errorf("unknown format %q for the webhook %q, the supported formats are: %s, %s and %s", c.Format, c.Name, FormatJSON, FormatSlack, FormatCloudEvents)
+	}
+
+	for _, pattern := range append(append([]string{}, c.Events...), c.Clusters...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q for the webhook %q. %s", pattern, c.Name, err)
+		}
+	}
+
+	if c.Retries == nil {
+		retries := defRetries
+		c.Retries = &retries
+	} else if *c.Retries < 0 {
+		return fmt.Errorf("the retries of the webhook %q cannot be negative", c.Name)
+	}
+
+	c.timeout = defTimeout
+	if len(c.Timeout) != 0 {
+		if c.timeout, err = time.ParseDuration(c.Timeout); err != nil || c.timeout <= 0 {
+			return fmt.Errorf("invalid timeout %q for the webhook %q", c.Timeout, c.Name)
+		}
+	}
+
+	return nil
+}
+
+// accepts returns true if the event should be sent to this webhook
+func (c *WebhookConfig) accepts(e *Event) bool {
+	return matchAny(c.Events, e.Type) && matchAny(c.Clusters, e.Cluster)
+}
+
+// matchAny returns true if the value match any of the patterns, or there are
+// no patterns
+func matchAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// Event types
+const (
+	StatusChanged       = "cluster.status.changed"
+	ApplyStarted        = "cluster.apply.started"
+	ApplyFinished       = "cluster.apply.finished"
+	DeleteStarted       = "cluster.delete.started"
+	DeleteFinished      = "cluster.delete.finished"
+	CertificateExpiring = "cluster.certificate.expiring"
+)
+
+// Event is a cluster lifecycle event
+type Event struct {
+	ID             string                 `json:"id"`
+	Type           string                 `json:"type"`
+	Time           time.Time              `json:"time"`
+	Cluster        string                 `json:"cluster"`
+	Platform       string                 `json:"platform,omitempty"`
+	Actor          string                 `json:"actor,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	PreviousStatus string                 `json:"previous_status,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+	Message        string                 `json:"message"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+}
+
+// NewEvent creates an event of the given type for the cluster. The message is
+// generated from the other fields if it's not set when the event is sent
+func NewEvent(eventType, cluster, platform, actor string) *Event {
+	var id string
+	if u, err := uuid.NewV4(); err == nil {
+		id = u.String()
+	}
+	return &Event{
+		ID:       id,
+		Type:     eventType,
+		Time:     time.Now().UTC(),
+		Cluster:  cluster,
+		Platform: platform,
+		Actor:    actor,
+	}
+}
+
+// WithError sets the error of the event, if any
+func (e *Event) WithError(err error) *Event {
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// failed returns true if the event reports a failure
+func (e *Event) failed() bool {
+	return len(e.Error) != 0
+}
+
+// message returns a human readable description of the event
+func (e *Event) message() string {
+	if len(e.Message) != 0 {
+		return e.Message
+	}
+
+	by := ""
+	if len(e.Actor) != 0 {
+		by = " by " + e.Actor
+	}
+	on := e.Cluster
+	if len(e.Platform) != 0 {
+		on = e.Cluster + "@" + e.Platform
+	}
+
+	switch e.Type {
+	case StatusChanged:
+		if len(e.PreviousStatus) == 0 {
+			return fmt.Sprintf("cluster %s is %s", on, e.Status)
+		}
+		return fmt.Sprintf("cluster %s changed from %s to %s", on, e.PreviousStatus, e.Status)
+	case ApplyStarted:
+		return fmt.Sprintf("apply of cluster %s started%s", on, by)
+	case DeleteStarted:
+		return fmt.Sprintf("delete of cluster %s started%s", on, by)
+	case ApplyFinished, DeleteFinished:
+		action := "apply"
+		if e.Type == DeleteFinished {
+			action = "delete"
+		}
+		if e.failed() {
+			return fmt.Sprintf("%s of cluster %s%s failed. %s", action, on, by, e.Error)
+		}
+		return fmt.Sprintf("%s of cluster %s%s finished successfully", action, on, by)
+	case CertificateExpiring:
+		return fmt.Sprintf("certificates of cluster %s are about to expire", on)
+	default:
+		return fmt.Sprintf("%s on cluster %s%s", e.Type, on, by)
+	}
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of the context with the user performing the action
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the user performing the action from the context, or
+// the local user if there is none
+func ActorFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if actor, ok := ctx.Value(actorKey{}).(string); ok && len(actor) != 0 {
+			return actor
+		}
+	}
+	return LocalActor()
+}
+
+// LocalActor returns the user running KubeKit as `user@hostname`
+func LocalActor() string {
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		return name + "@" + host
+	}
+	return name
+}
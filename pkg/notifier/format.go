@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const cloudEventsSource = "kubekit"
+
+// cloudEvent is a CloudEvents v1.0 event in the structured content mode
+type cloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            *Event `json:"data"`
+}
+
+// slackMessage is the payload of a Slack incoming webhook, also accepted by
+// Mattermost and Rocket.Chat
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// payload returns the body and content type of the webhook request for the
+// event in the given format
+func payload(format string, e *Event) ([]byte, string, error) {
+	switch format {
+	case FormatJSON, "":
+		data, err := json.Marshal(e)
+		return data, "application/json", err
+	case FormatSlack:
+		data, err := json.Marshal(slackMessage{Text: slackText(e)})
+		return data, "application/json", err
+	case FormatCloudEvents:
+		data, err := json.Marshal(cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              e.ID,
+			Source:          cloudEventsSource,
+			Type:            "io.kubekit." + e.Type,
+			Subject:         e.Cluster,
+			Time:            e.Time.Format(time.RFC3339Nano),
+			DataContentType: "application/json",
+			Data:            e,
+		})
+		return data, "application/cloudevents+json", err
+	default:
+		return nil, "", fmt.Errorf("unknown webhook format %q", format)
+	}
+}
+
+// slackText returns the event message for a chat, with an emoji to identify
+// the kind of event
+func slackText(e *Event) string {
+	icon := ":information_source:"
+	switch {
+	case e.failed():
+		icon = ":x:"
+	case e.Type == CertificateExpiring:
+		icon = ":warning:"
+	case e.Type == DeleteStarted || e.Type == DeleteFinished:
+		icon = ":wastebasket:"
+	case e.Type == ApplyStarted || e.Type == ApplyFinished:
+		icon = ":rocket:"
+	}
+	return fmt.Sprintf("%s *KubeKit*: %s", icon, e.message())
+}
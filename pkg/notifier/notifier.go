@@ -0,0 +1,213 @@
+// Package notifier sends the cluster lifecycle events, such as the status
+// changes, the apply and delete of a cluster or the certificates about to
+// expire, to HTTP webhooks. The payloads are signed with HMAC-SHA256, together
+// with the request timestamp, when the webhook has a secret and the failed
+// deliveries are retried
+package notifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kraken/ui"
+)
+
+// Headers sent with every webhook request
+const (
+	SignatureHeader = "X-KubeKit-Signature"
+	TimestampHeader = "X-KubeKit-Timestamp"
+	EventHeader     = "X-KubeKit-Event"
+	DeliveryHeader  = "X-KubeKit-Delivery"
+)
+
+// SignatureTolerance is the maximum difference between the timestamp of a
+// signed request and the time it's verified. The receivers should reject the
+// requests out of this window, so a captured request can't be replayed later
+const SignatureTolerance = 5 * time.Minute
+
+// retryWait is the time to wait before the first retry, it's doubled on every
+// retry
+var retryWait = time.Second
+
+// Notifier sends the events to the configured webhooks
+type Notifier struct {
+	webhooks   []*WebhookConfig
+	certExpiry time.Duration
+	client     *http.Client
+	ui         *ui.UI
+	wg         sync.WaitGroup
+}
+
+// New creates a notifier from the given configuration
+func New(config *Config, parentUI *ui.UI) (*Notifier, error) {
+	n := &Notifier{
+		certExpiry: defCertificateExpiryWarning,
+		client:     &http.Client{},
+		ui:         parentUI,
+	}
+	if config == nil {
+		return n, nil
+	}
+
+	if len(config.CertificateExpiryWarning) != 0 {
+		d, err := time.ParseDuration(config.CertificateExpiryWarning)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid certificate expiry warning %q", config.CertificateExpiryWarning)
+		}
+		n.certExpiry = d
+	}
+
+	for _, w := range config.Webhooks {
+		if w == nil {
+			continue
+		}
+		if err := w.validate(); err != nil {
+			return nil, err
+		}
+		n.webhooks = append(n.webhooks, w)
+	}
+
+	return n, nil
+}
+
+// Enabled returns true if there is at least one webhook to notify
+func (n *Notifier) Enabled() bool {
+	return n != nil && len(n.webhooks) != 0
+}
+
+// CertificateExpiryWarning returns how long before a certificate expires the
+// cluster.certificate.expiring event is sent
+func (n *Notifier) CertificateExpiryWarning() time.Duration {
+	if n == nil {
+		return defCertificateExpiryWarning
+	}
+	return n.certExpiry
+}
+
+// Notify sends the event to every webhook accepting it. The delivery is
+// asynchronous, use Wait to block until all the events are delivered
+func (n *Notifier) Notify(e *Event) {
+	if !n.Enabled() {
+		return
+	}
+	e.Message = e.message()
+
+	for _, w := range n.webhooks {
+		if !w.accepts(e) {
+			continue
+		}
+		n.wg.Add(1)
+		go func(w *WebhookConfig) {
+			defer n.wg.Done()
+			if err := n.deliver(w, e); err != nil {
+				n.logf("failed to send the event %s of cluster %s to the webhook %q. %s", e.Type, e.Cluster, w.Name, err)
+			}
+		}(w)
+	}
+}
+
+// Wait blocks until all the events sent are delivered or failed
+func (n *Notifier) Wait() {
+	if n == nil {
+		return
+	}
+	n.wg.Wait()
+}
+
+func (n *Notifier) logf(format string, args ...interface{}) {
+	if n.ui == nil || n.ui.Log == nil {
+		return
+	}
+	n.ui.Log.Warnf(format, args...)
+}
+
+// deliver sends the event to the webhook, retrying with an exponential backoff
+// when the request fails, or the response is a server error or 429
+func (n *Notifier) deliver(w *WebhookConfig, e *Event) error {
+	body, contentType, err := payload(w.Format, e)
+	if err != nil {
+		return err
+	}
+
+	wait := retryWait
+	for attempt := 0; ; attempt++ {
+		retry, err := n.post(w, e, body, contentType)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= *w.Retries {
+			return err
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// post sends a single request to the webhook. It returns true if the request
+// can be retried after a failure
+func (n *Notifier) post(w *WebhookConfig, e *Event, body []byte, contentType string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "KubeKit")
+	req.Header.Set(EventHeader, e.Type)
+	req.Header.Set(DeliveryHeader, e.ID)
+	if len(w.Secret) != 0 {
+		// every attempt has its own timestamp, so a retry is not rejected
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(w.Secret, timestamp, body))
+	}
+
+	client := *n.client
+	client.Timeout = w.timeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected response %s", resp.Status)
+}
+
+// Sign returns the signature of the timestamp and the payload with the given
+// secret, in the format `sha256=<hex HMAC-SHA256>`, to verify the webhook
+// requests. The signed content is `<timestamp>.<payload>`, where the timestamp
+// is the value of the X-KubeKit-Timestamp header, in seconds since the epoch
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature of the timestamp and the payload is
+// valid for the secret and the timestamp is within SignatureTolerance from now
+func Verify(secret, timestamp string, payload []byte, signature string) bool {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := time.Since(time.Unix(sec, 0))
+	if age > SignatureTolerance || age < -SignatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature))
+}
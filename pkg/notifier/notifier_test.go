@@ -0,0 +1,189 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// webhook is a test webhook server that records the requests received and
+// fails the first ones with the given status code
+type webhook struct {
+	mu       sync.Mutex
+	failures int
+	code     int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (w *webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.requests = append(w.requests, r)
+	w.bodies = append(w.bodies, body)
+	if len(w.requests) <= w.failures {
+		rw.WriteHeader(w.code)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+func newTestNotifier(t *testing.T, webhooks ...*WebhookConfig) *Notifier {
+	n, err := New(&Config{Webhooks: webhooks}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %s", err)
+	}
+	return n
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	defer func(wait time.Duration) { retryWait = wait }(retryWait)
+	retryWait = time.Millisecond
+
+	tests := []struct {
+		name         string
+		format       string
+		failures     int
+		code         int
+		wantRequests int
+		wantType     string
+		check        func(t *testing.T, body map[string]interface{})
+	}{
+		{"json", FormatJSON, 0, 0, 1, "application/json", func(t *testing.T, body map[string]interface{}) {
+			assert.Equal(t, DeleteFinished, body["type"])
+			assert.Equal(t, "shared-prod", body["cluster"])
+			assert.Equal(t, "alice@laptop", body["actor"])
+			assert.Equal(t, "delete of cluster shared-prod@ec2 by alice@laptop finished successfully", body["message"])
+		}},
+		{"slack", FormatSlack, 0, 0, 1, "application/json", func(t *testing.T, body map[string]interface{}) {
+			assert.Equal(t, ":wastebasket: *KubeKit*: delete of cluster shared-prod@ec2 by alice@laptop finished successfully", body["text"])
+		}},
+		{"cloudevents", FormatCloudEvents, 0, 0, 1, "application/cloudevents+json", func(t *testing.T, body map[string]interface{}) {
+			assert.Equal(t, "1.0", body["specversion"])
+			assert.Equal(t, "io.kubekit."+DeleteFinished, body["type"])
+			assert.Equal(t, "shared-prod", body["subject"])
+			assert.Equal(t, "shared-prod", body["data"].(map[string]interface{})["cluster"])
+		}},
+		{"retry server errors", FormatJSON, 2, http.StatusServiceUnavailable, 3, "application/json", nil},
+		{"retry too many requests", FormatJSON, 1, http.StatusTooManyRequests, 2, "application/json", nil},
+		{"give up after retries", FormatJSON, 10, http.StatusInternalServerError, 4, "application/json", nil},
+		{"no retry client errors", FormatJSON, 1, http.StatusBadRequest, 1, "application/json", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &webhook{failures: tt.failures, code: tt.code}
+			server := httptest.NewServer(w)
+			defer server.Close()
+
+			n := newTestNotifier(t, &WebhookConfig{URL: server.URL, Format: tt.format, Secret: "s3cr3t"})
+			n.Notify(NewEvent(DeleteFinished, "shared-prod", "ec2", "alice@laptop"))
+			n.Wait()
+
+			if !assert.Len(t, w.requests, tt.wantRequests) {
+				return
+			}
+			req, body := w.requests[0], w.bodies[0]
+			assert.Equal(t, tt.wantType, req.Header.Get("Content-Type"))
+			assert.Equal(t, DeleteFinished, req.Header.Get(EventHeader))
+			assert.NotEmpty(t, req.Header.Get(DeliveryHeader))
+			assert.True(t, Verify("s3cr3t", req.Header.Get(TimestampHeader), body, req.Header.Get(SignatureHeader)), "invalid signature %q", req.Header.Get(SignatureHeader))
+
+			if tt.check != nil {
+				var payload map[string]interface{}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("invalid payload %s. %s", body, err)
+				}
+				tt.check(t, payload)
+			}
+		})
+	}
+}
+
+func TestNotifier_filters(t *testing.T) {
+	w := &webhook{}
+	server := httptest.NewServer(w)
+	defer server.Close()
+
+	n := newTestNotifier(t, &WebhookConfig{
+		URL:      server.URL,
+		Events:   []string{"cluster.delete.*"},
+		Clusters: []string{"shared-*"},
+	})
+	n.Notify(NewEvent(DeleteStarted, "shared-prod", "ec2", "alice"))
+	n.Notify(NewEvent(DeleteStarted, "alice-dev", "ec2", "alice"))
+	n.Notify(NewEvent(ApplyStarted, "shared-prod", "ec2", "alice"))
+	n.Wait()
+
+	if assert.Len(t, w.requests, 1) {
+		assert.Empty(t, w.requests[0].Header.Get(SignatureHeader))
+		assert.Empty(t, w.requests[0].Header.Get(TimestampHeader))
+		var e Event
+		if err := json.Unmarshal(w.bodies[0], &e); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, DeleteStarted, e.Type)
+		assert.Equal(t, "shared-prod", e.Cluster)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"type":"cluster.delete.finished"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-2*SignatureTolerance).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signed    string
+		payload   []byte
+		want      bool
+	}{
+		{"valid", now, now, payload, true},
+		{"expired", old, old, payload, false},
+		{"timestamp changed", now, old, payload, false},
+		{"payload changed", now, now, []byte(`{}`), false},
+		{"invalid timestamp", "now", "now", payload, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := Sign("s3cr3t", tt.signed, payload)
+			assert.Equal(t, tt.want, Verify("s3cr3t", tt.timestamp, tt.payload, signature))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook *WebhookConfig
+		wantErr bool
+	}{
+		{"defaults", &WebhookConfig{URL: "https://hooks.example.com/kubekit"}, false},
+		{"invalid URL", &WebhookConfig{URL: "hooks.example.com"}, true},
+		{"unknown format", &WebhookConfig{URL: "https://hooks.example.com", Format: "xml"}, true},
+		{"invalid timeout", &WebhookConfig{URL: "https://hooks.example.com", Timeout: "soon"}, true},
+		{"invalid pattern", &WebhookConfig{URL: "https://hooks.example.com", Clusters: []string{"["}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&Config{Webhooks: []*WebhookConfig{tt.webhook}}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				assert.Equal(t, FormatJSON, tt.webhook.Format)
+				assert.Equal(t, defRetries, *tt.webhook.Retries)
+				assert.Equal(t, defTimeout, tt.webhook.timeout)
+			}
+		})
+	}
+}
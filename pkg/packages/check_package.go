@@ -37,11 +37,20 @@ func GetBaseImages(cluster *kluster.Kluster, forcePkg bool, pkgFilename string)
 //CheckRpmPackage opens the contents of the RPM package and validates against
 //the manifest
 func CheckRpmPackage(pkgFilename string, forcePkg bool) error {
+	return CheckReleaseRpmPackage(manifest.Version, pkgFilename, forcePkg)
+}
+
+//CheckReleaseRpmPackage opens the contents of the RPM package and validates
+//against the given release of the manifest
+func CheckReleaseRpmPackage(release, pkgFilename string, forcePkg bool) error {
 
 	if len(pkgFilename) == 0 || forcePkg {
 		return nil
 	}
-	check := getPackages()
+	if _, ok := manifest.KubeManifest.Releases[release]; !ok {
+		return fmt.Errorf("the release %s is not in the manifest", release)
+	}
+	check := getReleasePackages(release)
 
 	p, err := rpm.OpenPackageFile(pkgFilename)
 	if err != nil {
@@ -66,12 +75,22 @@ func CheckRpmPackage(pkgFilename string, forcePkg bool) error {
 }
 
 func getPackages() map[string]string {
+	return getReleasePackages(manifest.Version)
+}
+
+func getReleasePackages(release string) map[string]string {
 	check := make(map[string]string)
-	for k := range manifest.KubeManifest.Releases[manifest.Version].Dependencies.ControlPlane {
-		check[manifest.KubeManifest.Releases[manifest.Version].Dependencies.ControlPlane[k].PrebakePath] = "NOT FOUND"
+	for k := range manifest.KubeManifest.Releases[release].Dependencies.ControlPlane {
+		check[manifest.KubeManifest.Releases[release].Dependencies.ControlPlane[k].PrebakePath] = "NOT FOUND"
+	}
+	for k := range manifest.KubeManifest.Releases[release].Dependencies.Core {
+		check[manifest.KubeManifest.Releases[release].Dependencies.Core[k].PrebakePath] = "NOT FOUND"
+	}
+	for k := range manifest.KubeManifest.Releases[release].Dependencies.CNI {
+		check[manifest.KubeManifest.Releases[release].Dependencies.CNI[k].PrebakePath] = "NOT FOUND"
 	}
-	for k := range manifest.KubeManifest.Releases[manifest.Version].Dependencies.Core {
-		check[manifest.KubeManifest.Releases[manifest.Version].Dependencies.Core[k].PrebakePath] = "NOT FOUND"
+	for k := range manifest.KubeManifest.Releases[release].Dependencies.ContainerRuntime {
+		check[manifest.KubeManifest.Releases[release].Dependencies.ContainerRuntime[k].PrebakePath] = "NOT FOUND"
 	}
 
 	return check
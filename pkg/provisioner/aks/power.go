@@ -0,0 +1,139 @@
+package aks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/liferaft/azure"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// Start starts the given nodes, or all the cluster nodes if none is given. The
+// nodes in a scale set are started all together with the entire scale set
+func (p *Platform) Start(nodes []*state.Node) error {
+	return p.power(nodes, true)
+}
+
+// Stop stops and deallocates the given nodes, or all the cluster nodes if none
+// is given. The nodes in a scale set are stopped with the entire scale set
+func (p *Platform) Stop(nodes []*state.Node) error {
+	return p.power(nodes, false)
+}
+
+func (p *Platform) power(nodes []*state.Node, on bool) error {
+	if p.t == nil || p.t.State == nil || p.t.State.Empty() {
+		return fmt.Errorf("cannot change the power state of the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	output := p.t.State.RootModule().OutputValues
+	defaultNodeResourceGroup := "MC_" + p.config.ClusterName + "_" + p.config.ClusterName + "_" + reformatRGLocation(p.config.ResourceGroupLocation)
+	nodeResourceGroup := state.OutputKeysValueAsStringDefault(output, "node_resource_group", defaultNodeResourceGroup)
+
+	clientID, clientSecret := p.config.ClusterClientID, p.config.ClusterClientSecret
+	if len(clientID) == 0 {
+		clientID, clientSecret = p.config.ClientID, p.config.ClientSecret
+	}
+	authInfo := &azure.AuthInfo{
+		SubscriptionID: p.config.SubscriptionID,
+		TenantID:       p.config.TenantID,
+		ClientID:       clientID,
+		ClientSecret:   clientSecret,
+	}
+	session, err := azure.NewSession(authInfo, false)
+	if err != nil {
+		return fmt.Errorf("issues connecting to Azure: %s", err)
+	}
+	vmssClient, err := azure.VMSSClientByEnvStr(p.config.Environment, session)
+	if err != nil {
+		return fmt.Errorf("issues connecting to Azure via VMSS Client: %s", err)
+	}
+	vmsClient, err := azure.VirtualMachinesClientByEnvStr(p.config.Environment, session)
+	if err != nil {
+		return fmt.Errorf("issues connecting to Azure via Virtual Machines Client: %s", err)
+	}
+
+	ctx := context.Background()
+
+	// workers can be from availability sets or scale sets, so we check for both
+	vmssNames, err := azure.ListVMSSNames(vmssClient, nodeResourceGroup)
+	if err != nil {
+		return fmt.Errorf("issues retrieving virtual machine scale set names from Azure via VMSS Client: %s", err)
+	}
+	for _, vmssName := range vmssNames {
+		if !inPool(nodes, vmssName) {
+			continue
+		}
+		if on {
+			p.ui.Log.Debugf("starting scale set %s", vmssName)
+			err = azure.StartVMSSWithContext(vmssClient, ctx, nodeResourceGroup, vmssName)
+		} else {
+			p.ui.Log.Debugf("deallocating scale set %s", vmssName)
+			err = azure.DeallocateVMSSWithContext(vmssClient, ctx, nodeResourceGroup, vmssName)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	vms, err := azure.GetVMs(vmsClient, nodeResourceGroup, nil)
+	if err != nil {
+		return fmt.Errorf("issues retrieving virtual machines from Azure via Virtual Machines Client: %s", err)
+	}
+	for _, vm := range vms {
+		if vm.Name == nil || !isNode(nodes, *vm.Name) {
+			continue
+		}
+		if on {
+			p.ui.Log.Debugf("starting virtual machine %s", *vm.Name)
+			err = azure.StartVM(vmsClient, nodeResourceGroup, *vm.Name)
+		} else {
+			p.ui.Log.Debugf("deallocating virtual machine %s", *vm.Name)
+			err = azure.DeallocateVM(vmsClient, nodeResourceGroup, *vm.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inPool returns true if there are no nodes or any of them is in the given pool
+func inPool(nodes []*state.Node, pool string) bool {
+	if len(nodes) == 0 {
+		return true
+	}
+	for _, n := range nodes {
+		if n.Pool == pool {
+			return true
+		}
+	}
+	return false
+}
+
+// isNode returns true if there are no nodes or any of them has the given VM
+// name as hostname
+func isNode(nodes []*state.Node, vmName string) bool {
+	if len(nodes) == 0 {
+		return true
+	}
+	for _, n := range nodes {
+		if len(n.Pool) == 0 && strings.Split(n.PrivateDNS, ".")[0] == vmName {
+			return true
+		}
+	}
+	return false
+}
+
+// Replace is not supported on this platform, the nodes are managed by the
+// platform
+func (p *Platform) Replace(node *state.Node) error {
+	return fmt.Errorf("the %s platform does not support replacing nodes", p.name)
+}
+
+// Remove is not supported on this platform, the agent pools choose the nodes to
+// remove
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform does not support removing nodes", p.name)
+}
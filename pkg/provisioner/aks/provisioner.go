@@ -74,6 +74,15 @@ func (p *Platform) PreApply(destroy bool) error {
 	return p.setupPreviewFeatures()
 }
 
+// Changes returns the changes of the resources in the last plan
+func (p *Platform) Changes() ([]*terraformer.ResourceChange, error) {
+	if p.t == nil {
+		return nil, fmt.Errorf("cannot get the changes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	return p.t.Changes()
+}
+
 // Apply apply the changes either to create or destroy the cluster on this platform
 func (p *Platform) Apply(destroy bool) error {
 	if p.t == nil {
@@ -154,6 +163,24 @@ func (p *Platform) Terminate() error {
 	return p.t.Apply(true)
 }
 
+// AddHook adds a Terraform hook to the provisioner, such as a hook to halt the
+// changes. The platform has to be a provisioner already
+func (p *Platform) AddHook(hook terraformer.Hook) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot add the hook, the %s plaftorm is not a provisioner yet", p.name)
+	}
+	p.t.Hooks = append(p.t.Hooks, hook)
+
+	return nil
+}
+
+// Import brings existing infrastructure under the management of KubeKit. The
+// nodes of this platform are managed by the cloud provider, so it's not
+// supported
+func (p *Platform) Import(ids map[string][]string) error {
+	return fmt.Errorf("the %s platform does not support to import existing infrastructure", p.name)
+}
+
 // Code returns the Terraform code to execute
 func (p *Platform) Code() []byte {
 	var templateContent bytes.Buffer
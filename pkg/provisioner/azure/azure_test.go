@@ -0,0 +1,111 @@
+package azure
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/johandry/log"
+	"github.com/kraken/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tUI         = ui.New(false, log.NewDefault())
+	version     = "1.0"
+	credentials = []string{"my_subscription_id", "my_tenant_id", "my_client_id", "my_client_secret"}
+)
+
+func TestCreateFrom(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[interface{}]interface{}
+		want   *Config
+	}{
+		{
+			name:   "create Azure from nil config",
+			config: nil,
+			want:   defaultAzure().config,
+		},
+		{
+			name: "create Azure from config",
+			config: map[interface{}]interface{}{
+				"resource_group_location": "West US 2",
+				"default_node_pool": map[interface{}]interface{}{
+					"vm_size": "Standard_D8s_v3",
+				},
+				"node_pools": map[interface{}]interface{}{
+					"master": map[interface{}]interface{}{
+						"count": 3,
+					},
+				},
+			},
+			want: &Config{
+				ClusterName:           "testCluster",
+				ResourceGroupLocation: "West US 2",
+				DefaultNodePool:       NodePool{VMSize: "Standard_D8s_v3"},
+				NodePools:             map[string]NodePool{"master": NodePool{Count: 3}},
+				SubscriptionID:        "my_subscription_id",
+				TenantID:              "my_tenant_id",
+				ClientID:              "my_client_id",
+				ClientSecret:          "my_client_secret",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CreateFrom("testCluster", tt.config, credentials, tUI, version)
+			assert.Equal(t, tt.want, got.Config(), tt.name)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	got, err := New("testCluster", nil, tUI, version)
+	if err != nil {
+		t.Fatalf("New() error = %s", err)
+	}
+	want := defaultConfig
+	want.ClusterName = "testCluster"
+	assert.Equal(t, &want, got.Config())
+}
+
+func TestCode(t *testing.T) {
+	p := defaultAzure()
+	p.config.ResourceGroupLocation = "West US"
+	p.config.NodePools = map[string]NodePool{
+		"master": defaultMasterNodePool,
+		"worker": NodePool{Count: 2, ImageID: "/subscriptions/id/images/kubeos"},
+	}
+
+	code := string(p.Code())
+	if strings.HasPrefix(code, "failed at") {
+		t.Fatalf("Code() %s", code)
+	}
+
+	for _, want := range []string{
+		`resource "azurerm_virtual_machine" "master"`,
+		`resource "azurerm_virtual_machine" "worker"`,
+		`resource "azurerm_network_interface_backend_address_pool_association" "master"`,
+		`id        = "/subscriptions/id/images/kubeos"`,
+		`offer     = "CentOS"`,
+		`frontend_port                  = "8443"`,
+		`backend_port                   = "6443"`,
+	} {
+		assert.Contains(t, code, want)
+	}
+	assert.NotContains(t, code, `resource "azurerm_network_interface_backend_address_pool_association" "worker"`)
+	assert.Equal(t, 3, strings.Count(code, `\"pool\": \"`), "number of nodes in the output")
+}
+
+func defaultAzure() *Platform {
+	config := defaultConfig
+	p := &Platform{
+		name:    "azure",
+		config:  &config,
+		ui:      tUI,
+		version: version,
+	}
+	p.config.ClusterName = "testCluster"
+	p.Credentials(credentials...)
+	return p
+}
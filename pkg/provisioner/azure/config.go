@@ -0,0 +1,230 @@
+package azure
+
+import (
+	"encoding/json"
+
+	"github.com/johandry/merger"
+	"github.com/liferaft/kubekit/pkg/provisioner/config"
+	"github.com/liferaft/kubekit/pkg/provisioner/utils"
+)
+
+// defaultConfig is the default configuration for the Azure platform
+var defaultConfig = Config{
+	Username:              "kubekit",
+	KubeAPISSLPort:        6443,
+	DisableMasterHA:       true,
+	KubeVIPAPISSLPort:     8443,
+	DefaultNodePool:       defaultNodePool,
+	Environment:           "public",
+	ResourceGroupLocation: requiredValue + "West US",
+	VnetAddressSpace:      "10.240.0.0/16",
+	SubnetAddressPrefix:   "10.240.0.0/20",
+	TimeServers:           []string{"0.us.pool.ntp.org", "1.us.pool.ntp.org", "2.us.pool.ntp.org"},
+	NodePools: map[string]NodePool{
+		"master": defaultMasterNodePool,
+		"worker": defaultWorkerNodePool,
+	},
+}
+
+var defaultNodePool = NodePool{
+	Count:          1,
+	VMSize:         "Standard_D4s_v3",
+	ImagePublisher: "OpenLogic",
+	ImageOffer:     "CentOS",
+	ImageSku:       "7.7",
+	ImageVersion:   "latest",
+	RootVolumeSize: 200,
+	RootVolumeType: "Premium_LRS",
+	KubeletNodeLabels: []string{
+		`node-role.kubernetes.io/compute=""`,
+		`node.kubernetes.io/compute=""`,
+	},
+}
+
+var defaultMasterNodePool = NodePool{
+	Name:  "master",
+	Count: 1,
+	KubeletNodeLabels: []string{
+		`node-role.kubernetes.io/master=""`,
+		`node.kubernetes.io/master=""`,
+	},
+	KubeletNodeTaints: []string{
+		`node-role.kubernetes.io/master="":NoSchedule`,
+		`node.kubernetes.io/master="":NoSchedule`,
+	},
+}
+
+var defaultWorkerNodePool = NodePool{
+	Name:  "worker",
+	Count: 1,
+	KubeletNodeLabels: []string{
+		`node-role.kubernetes.io/worker=""`,
+		`node.kubernetes.io/worker=""`,
+	},
+}
+
+const requiredValue = "# Required value. Example: "
+
+// Config defines the Azure configuration parameters in the Cluster config file
+type Config struct {
+	// Following fields are platform generic
+	ClusterName             string   `json:"-" yaml:"-" mapstructure:"clustername"`
+	KubeAPISSLPort          int      `json:"kube_api_ssl_port" yaml:"kube_api_ssl_port" mapstructure:"kube_api_ssl_port"`
+	DisableMasterHA         bool     `json:"disable_master_ha" yaml:"disable_master_ha" mapstructure:"disable_master_ha"`
+	KubeVirtualIPShortname  string   `json:"kube_virtual_ip_shortname" yaml:"kube_virtual_ip_shortname" mapstructure:"kube_virtual_ip_shortname"`
+	KubeVirtualIPApi        string   `json:"kube_virtual_ip_api" yaml:"kube_virtual_ip_api" mapstructure:"kube_virtual_ip_api"`
+	KubeVIPAPISSLPort       int      `json:"kube_vip_api_ssl_port" yaml:"kube_vip_api_ssl_port" mapstructure:"kube_vip_api_ssl_port"`
+	PublicAPIServerDNSName  string   `json:"public_apiserver_dns_name" yaml:"public_apiserver_dns_name" mapstructure:"public_apiserver_dns_name"`
+	PrivateAPIServerDNSName string   `json:"private_apiserver_dns_name" yaml:"private_apiserver_dns_name" mapstructure:"private_apiserver_dns_name"`
+	Username                string   `json:"username" yaml:"username" mapstructure:"username"`
+	PrivateKey              string   `json:"private_key,omitempty" yaml:"private_key,omitempty" mapstructure:"private_key"`
+	PrivateKeyFile          string   `json:"private_key_file" yaml:"private_key_file" mapstructure:"private_key_file"`
+	PublicKey               string   `json:"public_key,omitempty" yaml:"public_key,omitempty" mapstructure:"public_key"`
+	PublicKeyFile           string   `json:"public_key_file" yaml:"public_key_file" mapstructure:"public_key_file"`
+	DNSServers              []string `json:"dns_servers" yaml:"dns_servers" mapstructure:"dns_servers"`
+	DNSSearch               []string `json:"dns_search" yaml:"dns_search" mapstructure:"dns_search"`
+	TimeServers             []string `json:"time_servers" yaml:"time_servers" mapstructure:"time_servers"`
+
+	// Following are Azure specific fields
+	SubscriptionID        string              `json:"-" yaml:"-" mapstructure:"-"`
+	TenantID              string              `json:"-" yaml:"-" mapstructure:"-"`
+	ClientID              string              `json:"-" yaml:"-" mapstructure:"-"`
+	ClientSecret          string              `json:"-" yaml:"-" mapstructure:"-"`
+	Environment           string              `json:"environment" yaml:"environment" mapstructure:"environment"`
+	ResourceGroupLocation string              `json:"resource_group_location" yaml:"resource_group_location" mapstructure:"resource_group_location"`
+	VnetAddressSpace      string              `json:"vnet_address_space" yaml:"vnet_address_space" mapstructure:"vnet_address_space"`
+	SubnetAddressPrefix   string              `json:"subnet_address_prefix" yaml:"subnet_address_prefix" mapstructure:"subnet_address_prefix"`
+	DefaultNodePool       NodePool            `json:"default_node_pool" yaml:"default_node_pool" mapstructure:"default_node_pool"`
+	NodePools             map[string]NodePool `json:"node_pools" yaml:"node_pools" mapstructure:"node_pools"`
+}
+
+// NodePool defines the settings for group of virtual machines on Azure. The
+// virtual machines are created from the custom image ImageID, or from the
+// marketplace image ImagePublisher, ImageOffer, ImageSku and ImageVersion if
+// there is no custom image
+type NodePool struct {
+	Name              string   `json:"-" yaml:"-" mapstructure:"name"`
+	Count             int      `json:"count" yaml:"count" mapstructure:"count"`
+	VMSize            string   `json:"vm_size,omitempty" yaml:"vm_size,omitempty" mapstructure:"vm_size"`
+	ImageID           string   `json:"image_id,omitempty" yaml:"image_id,omitempty" mapstructure:"image_id"`
+	ImagePublisher    string   `json:"image_publisher,omitempty" yaml:"image_publisher,omitempty" mapstructure:"image_publisher"`
+	ImageOffer        string   `json:"image_offer,omitempty" yaml:"image_offer,omitempty" mapstructure:"image_offer"`
+	ImageSku          string   `json:"image_sku,omitempty" yaml:"image_sku,omitempty" mapstructure:"image_sku"`
+	ImageVersion      string   `json:"image_version,omitempty" yaml:"image_version,omitempty" mapstructure:"image_version"`
+	RootVolumeSize    int      `json:"root_volume_size,omitempty" yaml:"root_volume_size,omitempty" mapstructure:"root_volume_size"`
+	RootVolumeType    string   `json:"root_volume_type,omitempty" yaml:"root_volume_type,omitempty" mapstructure:"root_volume_type"`
+	KubeletNodeLabels []string `json:"kubelet_node_labels,omitempty" yaml:"kubelet_node_labels,omitempty" mapstructure:"kubelet_node_labels"`
+	KubeletNodeTaints []string `json:"kubelet_node_taints,omitempty" yaml:"kubelet_node_taints,omitempty" mapstructure:"kubelet_node_taints"`
+}
+
+// MergeNodePools merges the node pools in this configuration with the given
+// environment configuration for node pools
+func (c *Config) MergeNodePools(nodePoolsEnvConf map[string]string) {
+	if len(nodePoolsEnvConf) == 0 {
+		return
+	}
+	nodePoolsMap := merger.TransformMap(nodePoolsEnvConf)
+	nodePools, ok := nodePoolsMap["node_pools"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for nodePool := range nodePools {
+		n := NodePool{}
+		nodePoolEnv := utils.TrimLeft(nodePoolsEnvConf, "node_pools__"+nodePool+"__")
+		merger.Merge(&n, nodePoolEnv, c.NodePools[nodePool])
+		c.NodePools[nodePool] = n
+	}
+}
+
+// NewConfigFrom returns a new Azure configuration from a map, usually from a
+// cluster config file
+func NewConfigFrom(m map[interface{}]interface{}) *Config {
+	c := &Config{}
+	c.MergeWithMapConfig(m)
+	return c
+}
+
+// MergeWithEnv merges this configuration with the given configuration in
+// a map[string]string, usually from environment variables
+func (c *Config) MergeWithEnv(envConf map[string]string, conf ...Config) error {
+	partialEnvConf, nodePoolsEnvConf := utils.RemoveEnv(envConf, "node_pools")
+	var err error
+	if len(conf) == 0 {
+		err = merger.Merge(c, partialEnvConf)
+	} else {
+		err = merger.Merge(c, partialEnvConf, conf[0])
+	}
+	if err != nil {
+		return err
+	}
+	c.MergeNodePools(nodePoolsEnvConf)
+	return nil
+}
+
+// MergeWithMapConfig merges this configuration with the given configuration in
+// a map[string], usually from a cluster config file
+func (c *Config) MergeWithMapConfig(m map[interface{}]interface{}) {
+	for k, v := range m {
+		name := k.(string)
+		switch name {
+		case "default_node_pool":
+			m1 := v.(map[interface{}]interface{})
+			c.DefaultNodePool = getNodePool(m1)
+		case "node_pools":
+			m1 := v.(map[interface{}]interface{})
+			c.NodePools = getNodePools(m1)
+		case "dns_servers":
+			c.DNSServers = config.GetListFromInterface(v)
+		case "dns_search":
+			c.DNSSearch = config.GetListFromInterface(v)
+		case "time_servers":
+			c.TimeServers = config.GetListFromInterface(v)
+		default:
+			config.SetField(c, name, v)
+		}
+	}
+}
+
+func getNodePool(m map[interface{}]interface{}) NodePool {
+	n := NodePool{}
+	for k, v := range m {
+		name := k.(string)
+		switch name {
+		case "kubelet_node_labels":
+			n.KubeletNodeLabels = config.GetListFromInterface(v)
+		case "kubelet_node_taints":
+			n.KubeletNodeTaints = config.GetListFromInterface(v)
+		default:
+			config.SetField(&n, name, v)
+		}
+	}
+	return n
+}
+
+func getNodePools(m map[interface{}]interface{}) map[string]NodePool {
+	nPools := make(map[string]NodePool, len(m))
+	for k, v := range m {
+		m1 := v.(map[interface{}]interface{})
+		nPool := getNodePool(m1)
+		nPools[k.(string)] = nPool
+	}
+	return nPools
+}
+
+func (c *Config) copyWithDefaults() Config {
+	cfg := *c
+	marshalled, _ := json.Marshal(c.DefaultNodePool)
+	nodePools := make(map[string]NodePool, len(cfg.NodePools))
+	for k, v := range cfg.NodePools {
+		n := NodePool{}
+		json.Unmarshal(marshalled, &n)
+
+		a, _ := json.Marshal(v)
+		json.Unmarshal(a, &n)
+
+		n.Name = k
+		nodePools[k] = n
+	}
+	cfg.NodePools = nodePools
+	return cfg
+}
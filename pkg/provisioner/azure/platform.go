@@ -0,0 +1,11 @@
+package azure
+
+// Name returns the platform name
+func (p *Platform) Name() string {
+	return p.name
+}
+
+// Config returns the default configuration for Azure
+func (p *Platform) Config() interface{} {
+	return p.config
+}
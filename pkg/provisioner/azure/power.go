@@ -0,0 +1,85 @@
+package azure
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/liferaft/azure"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// Start starts the given nodes, or all the cluster nodes if none is given
+func (p *Platform) Start(nodes []*state.Node) error {
+	return p.power(nodes, true)
+}
+
+// Stop stops and deallocates the given nodes, or all the cluster nodes if none
+// is given. The public IP addresses are static, so the nodes keep them when
+// they are started again
+func (p *Platform) Stop(nodes []*state.Node) error {
+	return p.power(nodes, false)
+}
+
+func (p *Platform) power(nodes []*state.Node, on bool) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot change the power state of the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	authInfo := &azure.AuthInfo{
+		SubscriptionID: p.config.SubscriptionID,
+		TenantID:       p.config.TenantID,
+		ClientID:       p.config.ClientID,
+		ClientSecret:   p.config.ClientSecret,
+	}
+	session, err := azure.NewSession(authInfo, false)
+	if err != nil {
+		return fmt.Errorf("issues connecting to Azure: %s", err)
+	}
+	client, err := azure.VirtualMachinesClientByEnvStr(p.config.Environment, session)
+	if err != nil {
+		return fmt.Errorf("issues connecting to Azure via Virtual Machines Client: %s", err)
+	}
+
+	// the resource group of the cluster is named as the cluster
+	resourceGroup := p.config.ClusterName
+
+	for _, vmName := range vmNames(p.t.State, nodes) {
+		if on {
+			p.ui.Log.Debugf("starting virtual machine %s", vmName)
+			err = azure.StartVM(client, resourceGroup, vmName)
+		} else {
+			p.ui.Log.Debugf("deallocating virtual machine %s", vmName)
+			err = azure.DeallocateVM(client, resourceGroup, vmName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to change the power state of the virtual machine %s. %s", vmName, err)
+		}
+	}
+
+	return nil
+}
+
+// vmNames returns the name of the virtual machines of the given nodes, or of
+// all the cluster virtual machines if no node is given. The name of the virtual
+// machine is the node hostname
+func vmNames(s *states.State, nodes []*state.Node) []string {
+	names := []string{}
+	for _, attr := range state.ResourceInstancesAttributes(s, "azurerm_virtual_machine") {
+		name, ok := attr["name"].(string)
+		if !ok || len(name) == 0 {
+			continue
+		}
+		if len(nodes) == 0 {
+			names = append(names, name)
+			continue
+		}
+		for _, n := range nodes {
+			if n.HasAddress(name) {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+
+	return names
+}
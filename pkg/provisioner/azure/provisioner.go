@@ -0,0 +1,198 @@
+package azure
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/terraform/builtin/provisioners/file"
+	"github.com/kraken/terraformer"
+	"github.com/liferaft/kubekit/pkg/crypto"
+	"github.com/liferaft/kubekit/pkg/provisioner/utils"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm"
+)
+
+// ResourceTemplates maps resource names to content of resources
+// implementation specified in code.go
+var ResourceTemplates map[string]string
+
+// BeProvisioner setup the Plaftorm to be a Provisioner
+func (p *Platform) BeProvisioner(state *terraformer.State) error {
+	// If I'm already a provisioner, return
+	if p.t != nil {
+		return nil
+	}
+
+	variables := p.Variables()
+	rendered := p.Code()
+
+	t, err := utils.NewTerraformer(rendered, variables, state, p.config.ClusterName, "Azure", p.ui)
+	if err != nil {
+		return err
+	}
+
+	t.AddProvider("azurerm", azurerm.Provider())
+	t.AddProvisioner("file", file.Provisioner())
+
+	p.t = t
+
+	return nil
+}
+
+// Plan do the planning of the changes either to create or destroy the cluster on this platform.
+func (p *Platform) Plan(destroy bool) (plan *terraformer.Plan, err error) {
+	if p.t == nil {
+		return nil, fmt.Errorf("cannot get the plan, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	p.ui.Log.Debug("getting the cluster plan before apply it")
+	return p.t.Plan(destroy)
+}
+
+// Changes returns the changes of the resources in the last plan
+func (p *Platform) Changes() ([]*terraformer.ResourceChange, error) {
+	if p.t == nil {
+		return nil, fmt.Errorf("cannot get the changes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	return p.t.Changes()
+}
+
+// Apply apply the changes either to create or destroy the cluster on this platform
+func (p *Platform) Apply(destroy bool) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot apply the changes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	if !destroy {
+		p.ui.Log.Debug("starting to provision the cluster")
+	} else {
+		p.ui.Log.Debug("starting to terminate the cluster")
+	}
+	return p.t.Apply(destroy)
+}
+
+// Provision provisions or creates a cluster on this platform
+func (p *Platform) Provision() error {
+	if p.t == nil {
+		return fmt.Errorf("cannot provision the cluster, the %s plaftorm is not a provisioner yet", p.name)
+	}
+	return p.t.Apply(false)
+}
+
+// Terminate terminates or destroys a cluster on this platform
+func (p *Platform) Terminate() error {
+	if p.t == nil {
+		return fmt.Errorf("cannot terminate the cluster, the %s plaftorm is not a provisioner yet", p.name)
+	}
+	return p.t.Apply(true)
+}
+
+// AddHook adds a Terraform hook to the provisioner, such as a hook to halt the
+// changes. The platform has to be a provisioner already
+func (p *Platform) AddHook(hook terraformer.Hook) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot add the hook, the %s plaftorm is not a provisioner yet", p.name)
+	}
+	p.t.Hooks = append(p.t.Hooks, hook)
+
+	return nil
+}
+
+// Import is not supported on this platform. Every virtual machine depends on a
+// network interface and public IP created by KubeKit in the cluster resource
+// group, so existing virtual machines cannot be brought under its management
+func (p *Platform) Import(ids map[string][]string) error {
+	return fmt.Errorf("the %s platform does not support to import existing infrastructure", p.name)
+}
+
+// Code returns the Terraform code to execute
+func (p *Platform) Code() []byte {
+	var templateContent bytes.Buffer
+	var renderedContent bytes.Buffer
+
+	for k, v := range ResourceTemplates {
+		templateContent.WriteString(fmt.Sprintf("# section created from template %s\n\n%s\n", k, v))
+	}
+	tmplFuncMap := template.FuncMap{
+		"Dash":  func(s string) string { return strings.NewReplacer("_", "-", ".", "-").Replace(s) },
+		"Lower": func(s string) string { return strings.ToLower(s) },
+		"Trim":  strings.TrimSpace,
+		"MasterPool": func(pools map[string]NodePool) NodePool {
+			// master lookup by label
+			for _, pool := range pools {
+				for _, label := range pool.KubeletNodeLabels {
+					if label == `node-role.kubernetes.io/master=""` {
+						return pool
+					}
+				}
+			}
+
+			// fall back, check for named "master" even if label is incorrect
+			if master, ok := pools["master"]; ok {
+				return master
+			}
+
+			// return a default master pool, as it will likely be used just for the name
+			return NodePool{
+				Name:  "master",
+				Count: 1,
+			}
+		},
+		"Count": func(count int) []int {
+			var counter []int
+			for i := 0; i < count; i++ {
+				counter = append(counter, i)
+			}
+			return counter
+		},
+	}
+
+	resourceTpl, err := template.
+		New("azure").
+		Option("missingkey=error").
+		Funcs(tmplFuncMap).
+		Parse(templateContent.String())
+
+	if err != nil {
+		return []byte(fmt.Sprintf("failed at resourceTpl.New() with %s", err))
+	}
+
+	copied := p.config.copyWithDefaults()
+
+	err = resourceTpl.Execute(&renderedContent, copied)
+	if err != nil {
+		return []byte(fmt.Sprintf("failed at resourceTpl.Execute() with %s\nmap contained: %v", err, p.config))
+	}
+
+	if p.t != nil {
+		p.t.Code = renderedContent.Bytes()
+	}
+
+	return renderedContent.Bytes()
+}
+
+// Variables returns the variables as a map where the key is the variable name.
+// Only the sensitive data such as credentials and private keys are variables,
+// all other values are rendered directly from Config
+func (p *Platform) Variables() map[string]interface{} {
+	return map[string]interface{}{
+		"subscription_id": p.config.SubscriptionID,
+		"tenant_id":       p.config.TenantID,
+		"client_id":       p.config.ClientID,
+		"client_secret":   p.config.ClientSecret,
+		"private_key":     cryptoKey(p.config.PrivateKey),
+	}
+}
+
+func cryptoKey(key string) string {
+	if crypto.IsEncrypted(key) {
+		if c, err := crypto.New(nil); err == nil {
+			if decrypted, err := c.DecryptValue(key); err == nil {
+				return string(decrypted)
+			}
+		}
+	}
+	return key
+}
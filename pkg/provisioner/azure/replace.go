@@ -0,0 +1,35 @@
+package azure
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// Replace destroys the virtual machine of the given node and creates it again.
+// The virtual machine is tainted and the changes are applied only to that
+// virtual machine, so only the virtual machine and its OS disk are recreated,
+// the network interface, the public IP and the rest of the infrastructure are
+// not modified
+func (p *Platform) Replace(node *state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot replace the node, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	addresses := state.ResourceInstancesAddressFor(p.t.State, "azurerm_virtual_machine", []*state.Node{node}, "name")
+	if len(addresses) == 0 {
+		return fmt.Errorf("not found the virtual machine of node %s in the %s state", node.PublicIP, p.name)
+	}
+	if err := p.t.Taint(addresses...); err != nil {
+		return err
+	}
+
+	p.ui.Log.Debugf("replacing the virtual machine %v", addresses)
+	return p.t.ApplyTargets(false, addresses...)
+}
+
+// Remove is not required on this platform, the nodes are destroyed when the
+// count of their node pool is decremented and the changes are applied
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform removes the nodes decrementing the count of their node pool", p.name)
+}
@@ -0,0 +1,107 @@
+// network.tf creates the resource group, network and API load balancer shared by all the node pools
+
+resource "azurerm_resource_group" "kubekit" {
+  name     = "{{ $.ClusterName }}"
+  location = "{{ $.ResourceGroupLocation }}"
+
+  tags = {
+    ClusterName = "{{ $.ClusterName }}"
+  }
+}
+
+resource "azurerm_virtual_network" "kubekit" {
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-vnet"
+  address_space       = ["{{ $.VnetAddressSpace }}"]
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+}
+
+resource "azurerm_network_security_group" "kubekit" {
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-nsg"
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+
+  // the traffic inside the virtual network and from the load balancer is allowed by the default rules
+  security_rule {
+    name                       = "ssh"
+    priority                   = 100
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "22"
+    source_address_prefix      = "*"
+    destination_address_prefix = "*"
+  }
+
+  security_rule {
+    name                       = "kube-api-ssl-port"
+    priority                   = 110
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_range     = "{{ $.KubeAPISSLPort }}"
+    source_address_prefix      = "*"
+    destination_address_prefix = "*"
+  }
+}
+
+resource "azurerm_subnet" "kubekit" {
+  name                      = "{{ Dash ( Lower $.ClusterName ) }}-subnet"
+  resource_group_name       = azurerm_resource_group.kubekit.name
+  virtual_network_name      = azurerm_virtual_network.kubekit.name
+  address_prefix            = "{{ $.SubnetAddressPrefix }}"
+  network_security_group_id = azurerm_network_security_group.kubekit.id
+}
+
+resource "azurerm_subnet_network_security_group_association" "kubekit" {
+  subnet_id                 = azurerm_subnet.kubekit.id
+  network_security_group_id = azurerm_network_security_group.kubekit.id
+}
+
+// the load balancer is the entry point to the Kubernetes API, it forwards the
+// VIP port to the API port of the master nodes
+resource "azurerm_public_ip" "api" {
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-api"
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+  allocation_method   = "Static"
+}
+
+resource "azurerm_lb" "api" {
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-api"
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+
+  frontend_ip_configuration {
+    name                 = "api"
+    public_ip_address_id = azurerm_public_ip.api.id
+  }
+}
+
+resource "azurerm_lb_backend_address_pool" "api" {
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-masters"
+  resource_group_name = azurerm_resource_group.kubekit.name
+  loadbalancer_id     = azurerm_lb.api.id
+}
+
+resource "azurerm_lb_probe" "api" {
+  name                = "kube-api-ssl-port"
+  resource_group_name = azurerm_resource_group.kubekit.name
+  loadbalancer_id     = azurerm_lb.api.id
+  protocol            = "Tcp"
+  port                = "{{ $.KubeAPISSLPort }}"
+}
+
+resource "azurerm_lb_rule" "api" {
+  name                           = "kube-vip-api-ssl-port"
+  resource_group_name            = azurerm_resource_group.kubekit.name
+  loadbalancer_id                = azurerm_lb.api.id
+  protocol                       = "Tcp"
+  frontend_port                  = "{{ $.KubeVIPAPISSLPort }}"
+  backend_port                   = "{{ $.KubeAPISSLPort }}"
+  frontend_ip_configuration_name = "api"
+  backend_address_pool_id        = azurerm_lb_backend_address_pool.api.id
+  probe_id                       = azurerm_lb_probe.api.id
+}
@@ -0,0 +1,17 @@
+output "service_ip" {
+  value = azurerm_public_ip.api.ip_address
+}
+
+output "service_port" {
+  value = azurerm_lb_rule.api.frontend_port
+}
+
+output "nodes" {
+  value = [ {{- range $k, $v := $.NodePools -}} {{- range $i := Count $v.Count  }}
+    "{\"private_ip\": \"${azurerm_network_interface.
+    {{- Dash ( Lower $k ) }}.{{ $i }}.private_ip_address}\",\"public_ip\": \"${azurerm_public_ip.
+    {{- Dash ( Lower $k ) }}.{{ $i }}.ip_address}\",\"public_dns\": \"${azurerm_virtual_machine.
+    {{- Dash ( Lower $k ) }}.{{ $i }}.name}\",\"private_dns\": \"${azurerm_virtual_machine.
+    {{- Dash ( Lower $k ) }}.{{ $i }}.name}\",\"pool\": \"{{ $v.Name }}\",\"role\": \"{{ Dash ( Lower $k ) }}\"}",{{ end }}{{ end }}
+  ]
+}
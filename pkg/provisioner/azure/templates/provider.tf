@@ -0,0 +1,7 @@
+provider "azurerm" {
+  subscription_id = var.subscription_id
+  tenant_id       = var.tenant_id
+  client_id       = var.client_id
+  client_secret   = var.client_secret
+  environment     = "{{ .Environment }}"
+}
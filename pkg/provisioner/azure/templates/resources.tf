@@ -0,0 +1,111 @@
+// resources.tf creates the virtual machines of every node pool, with a static public IP to access them
+
+{{ $masterNodePool := MasterPool $.NodePools }}
+{{ range $k, $v := .NodePools }}
+
+resource "azurerm_availability_set" "{{ Dash ( Lower $k ) }}" {
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-{{ Dash ( Lower $k ) }}"
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+  managed             = true
+}
+
+resource "azurerm_public_ip" "{{ Dash ( Lower $k ) }}" {
+  count               = "{{ $v.Count }}"
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-{{ Dash ( Lower $k ) }}-${format("%02d", count.index+1)}"
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+  allocation_method   = "Static"
+}
+
+resource "azurerm_network_interface" "{{ Dash ( Lower $k ) }}" {
+  count               = "{{ $v.Count }}"
+  name                = "{{ Dash ( Lower $.ClusterName ) }}-{{ Dash ( Lower $k ) }}-${format("%02d", count.index+1)}"
+  location            = azurerm_resource_group.kubekit.location
+  resource_group_name = azurerm_resource_group.kubekit.name
+
+  ip_configuration {
+    name                          = "primary"
+    subnet_id                     = azurerm_subnet.kubekit.id
+    private_ip_address_allocation = "Dynamic"
+    public_ip_address_id          = element(azurerm_public_ip.{{ Dash ( Lower $k ) }}.*.id, count.index)
+  }
+}
+{{ if eq $k $masterNodePool.Name }}
+resource "azurerm_network_interface_backend_address_pool_association" "{{ Dash ( Lower $k ) }}" {
+  count                   = "{{ $v.Count }}"
+  network_interface_id    = element(azurerm_network_interface.{{ Dash ( Lower $k ) }}.*.id, count.index)
+  ip_configuration_name   = "primary"
+  backend_address_pool_id = azurerm_lb_backend_address_pool.api.id
+}
+{{ end }}
+resource "azurerm_virtual_machine" "{{ Dash ( Lower $k ) }}" {
+  count                         = "{{ $v.Count }}"
+  name                          = "{{ Dash ( Lower $.ClusterName ) }}-{{ Dash ( Lower $k ) }}-${format("%02d", count.index+1)}"
+  location                      = azurerm_resource_group.kubekit.location
+  resource_group_name           = azurerm_resource_group.kubekit.name
+  availability_set_id           = azurerm_availability_set.{{ Dash ( Lower $k ) }}.id
+  network_interface_ids         = [element(azurerm_network_interface.{{ Dash ( Lower $k ) }}.*.id, count.index)]
+  vm_size                       = "{{ $v.VMSize }}"
+  delete_os_disk_on_termination = true
+
+  storage_image_reference {
+{{- if $v.ImageID }}
+    id        = "{{ $v.ImageID }}"
+{{- else }}
+    publisher = "{{ $v.ImagePublisher }}"
+    offer     = "{{ $v.ImageOffer }}"
+    sku       = "{{ $v.ImageSku }}"
+    version   = "{{ $v.ImageVersion }}"
+{{- end }}
+  }
+
+  storage_os_disk {
+    name              = "{{ Dash ( Lower $.ClusterName ) }}-{{ Dash ( Lower $k ) }}-${format("%02d", count.index+1)}-osdisk"
+    caching           = "ReadWrite"
+    create_option     = "FromImage"
+    managed_disk_type = "{{ $v.RootVolumeType }}"
+    disk_size_gb      = "{{ $v.RootVolumeSize }}"
+  }
+
+  os_profile {
+    computer_name  = "{{ Dash ( Lower $.ClusterName ) }}-{{ Dash ( Lower $k ) }}-${format("%02d", count.index+1)}"
+    admin_username = "{{ $.Username }}"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = true
+
+    ssh_keys {
+      key_data = "{{ Trim $.PublicKey }}"
+      path     = "/home/{{ $.Username }}/.ssh/authorized_keys"
+    }
+  }
+
+  tags = {
+    ClusterName = "{{ $.ClusterName }}"
+    Pool        = "{{ $k }}"
+  }
+}
+
+resource "null_resource" "wait-{{ Dash ( Lower $k ) }}" {
+  count = "{{ $v.Count }}"
+
+  // wait again when the virtual machine is replaced
+  triggers = {
+    vm_id = element(azurerm_virtual_machine.{{ Dash ( Lower $k ) }}.*.id, count.index)
+  }
+
+  connection {
+    user        = "{{ $.Username }}"
+    host        = element(azurerm_public_ip.{{ Dash ( Lower $k ) }}.*.ip_address, count.index)
+    private_key = var.private_key
+    timeout     = "5m"
+  }
+
+  provisioner "file" {
+    content     = "terraform was able to ssh to the instance"
+    destination = "/tmp/terraform.up"
+  }
+}
+{{ end }}
@@ -0,0 +1,147 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/liferaft/kubekit/pkg/provisioner/utils"
+)
+
+// Import brings the existing instances under the management of KubeKit. The
+// nodes of this platform are created by auto scaling groups, so the instances
+// of every node pool have to be in one auto scaling group, this group and its
+// launch configuration are the imported resources. The number of nodes of every
+// node pool in the configuration has to be the same as the number of instances.
+// The layout of all the instances is verified before importing anything, the
+// standalone instances can't be imported
+func (p *Platform) Import(ids map[string][]string) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot import the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	copied := p.config.copyWithDefaults()
+	p.reconcileVersion(&copied)
+	clusterName := utils.ResourceName(p.config.ClusterName)
+
+	poolNames := make([]string, 0, len(ids))
+	counts := make(map[string]int, len(ids))
+	instanceIDs := []string{}
+	for poolName := range ids {
+		pool, ok := copied.NodePools[poolName]
+		if !ok {
+			return fmt.Errorf("not found node pool %q in the %s platform configuration", poolName, p.name)
+		}
+		poolNames = append(poolNames, poolName)
+		counts[poolName] = pool.Count
+		instanceIDs = append(instanceIDs, ids[poolName]...)
+	}
+	sort.Strings(poolNames)
+
+	sess, err := utils.NewAWSSession(p.config.AwsAccessKey, p.config.AwsSecretKey, p.config.AwsSessionToken, p.config.AwsRegion)
+	if err != nil {
+		return err
+	}
+
+	groupOf, groups, err := utils.AWSAutoScalingGroupsOf(sess, instanceIDs)
+	if err != nil {
+		return err
+	}
+
+	if errs := importLayoutErrors(poolNames, ids, counts, groupOf, groups); len(errs) != 0 {
+		return fmt.Errorf("cannot import the instances: %s. The %s platform creates the nodes of every node pool with an auto scaling group and a launch configuration, only the instances in such a group, one group per node pool, can be imported. Attach the standalone instances to an auto scaling group (i.e. with 'aws autoscaling attach-instances') or use the 'raw' platform to configure them without provisioning", strings.Join(errs, "; "), p.name)
+	}
+
+	targets := map[string]string{}
+	for _, poolName := range poolNames {
+		pool := copied.NodePools[poolName]
+		group := groups[groupOf[ids[poolName][0]]]
+
+		if capacity := int(aws.Int64Value(group.DesiredCapacity)); capacity != pool.Count {
+			p.ui.Log.Warnf("the auto scaling group %s has a desired capacity of %d, it will be changed to %d nodes", aws.StringValue(group.AutoScalingGroupName), capacity, pool.Count)
+		}
+
+		resourceName := fmt.Sprintf("%s-node-%s", clusterName, utils.ResourceName(pool.Name))
+		targets["aws_autoscaling_group."+resourceName] = aws.StringValue(group.AutoScalingGroupName)
+		targets["aws_launch_configuration."+resourceName] = aws.StringValue(group.LaunchConfigurationName)
+	}
+
+	p.ui.Log.Debugf("importing resources %v", targets)
+	return p.t.Import(targets)
+}
+
+// importLayoutErrors returns the reasons the instances of the given node pools
+// can't be imported. The instances of every node pool have to be all the
+// instances of one auto scaling group with a launch configuration, not shared
+// with other node pool
+func importLayoutErrors(poolNames []string, ids map[string][]string, counts map[string]int, groupOf map[string]string, groups map[string]*autoscaling.Group) []string {
+	errs := []string{}
+	poolOf := map[string]string{}
+
+	for _, poolName := range poolNames {
+		poolIDs := ids[poolName]
+		if counts[poolName] != len(poolIDs) {
+			errs = append(errs, fmt.Sprintf("the node pool %q has %d nodes but %d instances are imported", poolName, counts[poolName], len(poolIDs)))
+		}
+
+		standalone := []string{}
+		names := []string{}
+		for _, id := range poolIDs {
+			name, ok := groupOf[id]
+			if !ok {
+				standalone = append(standalone, id)
+				continue
+			}
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		if len(standalone) != 0 {
+			errs = append(errs, fmt.Sprintf("the instances %s of the node pool %q do not exists or are not in an auto scaling group", strings.Join(standalone, ", "), poolName))
+		}
+		if len(names) > 1 {
+			errs = append(errs, fmt.Sprintf("the instances of the node pool %q are in different auto scaling groups (%s)", poolName, strings.Join(names, ", ")))
+		}
+		if len(names) != 1 {
+			continue
+		}
+
+		name := names[0]
+		if other, ok := poolOf[name]; ok {
+			errs = append(errs, fmt.Sprintf("the instances of the node pools %q and %q are in the same auto scaling group %s", other, poolName, name))
+			continue
+		}
+		poolOf[name] = poolName
+
+		group, ok := groups[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("not found the auto scaling group %s of the node pool %q", name, poolName))
+			continue
+		}
+		if len(aws.StringValue(group.LaunchConfigurationName)) == 0 {
+			errs = append(errs, fmt.Sprintf("the auto scaling group %s of the node pool %q does not use a launch configuration, launch templates are not supported", name, poolName))
+		}
+		others := []string{}
+		for _, instance := range group.Instances {
+			if id := aws.StringValue(instance.InstanceId); !contains(poolIDs, id) {
+				others = append(others, id)
+			}
+		}
+		if len(others) != 0 {
+			errs = append(errs, fmt.Sprintf("the auto scaling group %s of the node pool %q also has the instances %s, all its instances have to be imported", name, poolName, strings.Join(others, ", ")))
+		}
+	}
+
+	return errs
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
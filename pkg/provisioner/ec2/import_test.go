@@ -0,0 +1,97 @@
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+func testGroup(name, launchConfiguration string, instanceIDs ...string) *autoscaling.Group {
+	group := &autoscaling.Group{
+		AutoScalingGroupName: aws.String(name),
+	}
+	if len(launchConfiguration) != 0 {
+		group.LaunchConfigurationName = aws.String(launchConfiguration)
+	}
+	for _, id := range instanceIDs {
+		group.Instances = append(group.Instances, &autoscaling.Instance{InstanceId: aws.String(id)})
+	}
+	return group
+}
+
+func TestImportLayoutErrors(t *testing.T) {
+	poolNames := []string{"master", "worker"}
+	ids := map[string][]string{
+		"master": {"i-m1"},
+		"worker": {"i-w1", "i-w2"},
+	}
+	counts := map[string]int{"master": 1, "worker": 2}
+
+	tests := []struct {
+		name    string
+		counts  map[string]int
+		groupOf map[string]string
+		groups  map[string]*autoscaling.Group
+		want    []string
+	}{
+		{
+			name:    "one group per pool",
+			counts:  counts,
+			groupOf: map[string]string{"i-m1": "asg-m", "i-w1": "asg-w", "i-w2": "asg-w"},
+			groups: map[string]*autoscaling.Group{
+				"asg-m": testGroup("asg-m", "lc-m", "i-m1"),
+				"asg-w": testGroup("asg-w", "lc-w", "i-w1", "i-w2"),
+			},
+			want: []string{},
+		},
+		{
+			name:    "standalone instances",
+			counts:  counts,
+			groupOf: map[string]string{"i-w1": "asg-w"},
+			groups: map[string]*autoscaling.Group{
+				"asg-w": testGroup("asg-w", "lc-w", "i-w1"),
+			},
+			want: []string{
+				`the instances i-m1 of the node pool "master" do not exists or are not in an auto scaling group`,
+				`the instances i-w2 of the node pool "worker" do not exists or are not in an auto scaling group`,
+			},
+		},
+		{
+			name:    "different groups, shared group and count",
+			counts:  map[string]int{"master": 3, "worker": 2},
+			groupOf: map[string]string{"i-m1": "asg-w", "i-w1": "asg-w", "i-w2": "asg-x"},
+			groups: map[string]*autoscaling.Group{
+				"asg-w": testGroup("asg-w", "lc-w", "i-m1", "i-w1"),
+				"asg-x": testGroup("asg-x", "lc-x", "i-w2"),
+			},
+			want: []string{
+				`the node pool "master" has 3 nodes but 1 instances are imported`,
+				`the auto scaling group asg-w of the node pool "master" also has the instances i-w1, all its instances have to be imported`,
+				`the instances of the node pool "worker" are in different auto scaling groups (asg-w, asg-x)`,
+			},
+		},
+		{
+			name:    "launch template and other instances",
+			counts:  counts,
+			groupOf: map[string]string{"i-m1": "asg-m", "i-w1": "asg-w", "i-w2": "asg-w"},
+			groups: map[string]*autoscaling.Group{
+				"asg-m": testGroup("asg-m", "", "i-m1"),
+				"asg-w": testGroup("asg-w", "lc-w", "i-w1", "i-w2", "i-w3"),
+			},
+			want: []string{
+				`the auto scaling group asg-m of the node pool "master" does not use a launch configuration, launch templates are not supported`,
+				`the auto scaling group asg-w of the node pool "worker" also has the instances i-w3, all its instances have to be imported`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := importLayoutErrors(poolNames, ids, tt.counts, tt.groupOf, tt.groups)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("importLayoutErrors() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+	"github.com/liferaft/kubekit/pkg/provisioner/utils"
+)
+
+// Start powers on the given nodes, or all the cluster nodes if none is given
+func (p *Platform) Start(nodes []*state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot start the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	sess, err := utils.NewAWSSession(p.config.AwsAccessKey, p.config.AwsSecretKey, p.config.AwsSessionToken, p.config.AwsRegion)
+	if err != nil {
+		return err
+	}
+
+	instanceIDs := state.ResourceInstancesIDFor(p.t.State, "aws_instance", nodes, "private_ip", "public_ip")
+	p.ui.Log.Debugf("starting instances %v", instanceIDs)
+
+	// the auto scaling processes are resumed on the groups with all their nodes
+	// started, even if only some of the nodes or pools are started
+	if err := utils.StartAWSInstances(sess, instanceIDs, p.autoScalingGroups()); err != nil {
+		return err
+	}
+
+	// the instances may get new public IP's and DNS's when started, refresh the
+	// state to get them without applying any other change
+	p.ui.Log.Debugf("refreshing the state to get the nodes addresses")
+	if err := p.t.Refresh(false); err != nil {
+		return fmt.Errorf("failed to refresh the nodes addresses. %s", err)
+	}
+
+	return nil
+}
+
+// Stop powers off the given nodes, or all the cluster nodes if none is given
+func (p *Platform) Stop(nodes []*state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot stop the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	sess, err := utils.NewAWSSession(p.config.AwsAccessKey, p.config.AwsSecretKey, p.config.AwsSessionToken, p.config.AwsRegion)
+	if err != nil {
+		return err
+	}
+
+	instanceIDs := state.ResourceInstancesIDFor(p.t.State, "aws_instance", nodes, "private_ip", "public_ip")
+	p.ui.Log.Debugf("stopping instances %v", instanceIDs)
+
+	return utils.StopAWSInstances(sess, instanceIDs, p.autoScalingGroups())
+}
+
+func (p *Platform) autoScalingGroups() []string {
+	names := []string{}
+	for _, attr := range state.ResourceInstancesAttributes(p.t.State, "aws_autoscaling_group") {
+		if name, ok := attr["name"].(string); ok && len(name) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Remove terminates the instances of the given nodes decrementing the desired
+// capacity of their auto scaling groups, so they are not replaced. The count of
+// the node pools has to be decremented in the configuration before applying any
+// other change. Then the state is refreshed, no change is applied
+func (p *Platform) Remove(nodes []*state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot remove the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	instanceIDs := state.ResourceInstancesIDFor(p.t.State, "aws_instance", nodes, "private_ip", "public_ip")
+	if len(instanceIDs) != len(nodes) {
+		return fmt.Errorf("found %d instances of the %d nodes to remove in the %s state", len(instanceIDs), len(nodes), p.name)
+	}
+
+	sess, err := utils.NewAWSSession(p.config.AwsAccessKey, p.config.AwsSecretKey, p.config.AwsSessionToken, p.config.AwsRegion)
+	if err != nil {
+		return err
+	}
+
+	p.ui.Log.Debugf("removing instances %v", instanceIDs)
+	if err := utils.RemoveAWSInstances(sess, instanceIDs); err != nil {
+		return err
+	}
+
+	return p.t.Refresh(false)
+}
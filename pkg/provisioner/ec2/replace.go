@@ -0,0 +1,34 @@
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+	"github.com/liferaft/kubekit/pkg/provisioner/utils"
+)
+
+// Replace terminates the instance of the given node and waits for its auto
+// scaling group to launch a new one. Then the state is refreshed to get the new
+// instance, no change is applied to the infrastructure
+func (p *Platform) Replace(node *state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot replace the node, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	instanceIDs := state.ResourceInstancesIDFor(p.t.State, "aws_instance", []*state.Node{node}, "private_ip", "public_ip")
+	if len(instanceIDs) == 0 {
+		return fmt.Errorf("not found the instance of node %s in the %s state", node.PublicIP, p.name)
+	}
+
+	sess, err := utils.NewAWSSession(p.config.AwsAccessKey, p.config.AwsSecretKey, p.config.AwsSessionToken, p.config.AwsRegion)
+	if err != nil {
+		return err
+	}
+
+	p.ui.Log.Debugf("replacing the instance %s", instanceIDs[0])
+	if err := utils.ReplaceAWSInstance(sess, instanceIDs[0]); err != nil {
+		return err
+	}
+
+	return p.t.Refresh(false)
+}
@@ -0,0 +1,52 @@
+package openstack
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/liferaft/kubekit/pkg/provisioner/utils"
+)
+
+// Import brings the existing compute instances under the management of KubeKit.
+// The IDs are the UUID of the compute instances, grouped by node pool. The
+// floating IPs are not imported, they are created and associated to the
+// instances in the next apply. The number of nodes of every node pool in the
+// configuration has to be the same as the number of instances to import
+func (p *Platform) Import(ids map[string][]string) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot import the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	targets, err := p.importTargets(ids)
+	if err != nil {
+		return err
+	}
+
+	p.ui.Log.Debugf("importing resources %v", targets)
+	return p.t.Import(targets)
+}
+
+func (p *Platform) importTargets(ids map[string][]string) (map[string]string, error) {
+	copied := p.config.copyWithDefaults()
+	p.reconcileVersion(&copied)
+
+	poolNames := make([]string, 0, len(ids))
+	for poolName := range ids {
+		poolNames = append(poolNames, poolName)
+	}
+	sort.Strings(poolNames)
+
+	targets := map[string]string{}
+	for _, poolName := range poolNames {
+		pool, ok := copied.NodePools[poolName]
+		if !ok {
+			return nil, fmt.Errorf("not found node pool %q in the %s platform configuration", poolName, p.name)
+		}
+		if pool.Count != len(ids[poolName]) {
+			return nil, fmt.Errorf("the node pool %q has %d nodes but %d instances are imported", poolName, pool.Count, len(ids[poolName]))
+		}
+		utils.AddImportTargets(targets, "openstack_compute_instance_v2", utils.ResourceName(pool.Name), ids[poolName])
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,103 @@
+package openstack
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// powerTimeout is the number of seconds to wait for a server to be active or shutoff
+const powerTimeout = 300
+
+// Start powers on the given nodes, or all the cluster nodes if none is given
+func (p *Platform) Start(nodes []*state.Node) error {
+	return p.power(nodes, true)
+}
+
+// Stop powers off the given nodes, or all the cluster nodes if none is given
+func (p *Platform) Stop(nodes []*state.Node) error {
+	return p.power(nodes, false)
+}
+
+func (p *Platform) power(nodes []*state.Node, on bool) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot change the power state of the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	client, err := p.computeClient()
+	if err != nil {
+		return err
+	}
+
+	serverIDs := state.ResourceInstancesIDFor(p.t.State, "openstack_compute_instance_v2", nodes, "access_ip_v4", "name")
+
+	for _, id := range serverIDs {
+		server, err := servers.Get(client, id).Extract()
+		if err != nil {
+			return fmt.Errorf("failed to get the server %s. %s", id, err)
+		}
+
+		if on {
+			if server.Status == "ACTIVE" {
+				continue
+			}
+			p.ui.Log.Debugf("starting server %s", id)
+			if err := startstop.Start(client, id).ExtractErr(); err != nil {
+				return fmt.Errorf("failed to start the server %s. %s", id, err)
+			}
+			if err := servers.WaitForStatus(client, id, "ACTIVE", powerTimeout); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if server.Status == "SHUTOFF" {
+			continue
+		}
+		p.ui.Log.Debugf("stopping server %s", id)
+		if err := startstop.Stop(client, id).ExtractErr(); err != nil {
+			return fmt.Errorf("failed to stop the server %s. %s", id, err)
+		}
+		if err := servers.WaitForStatus(client, id, "SHUTOFF", powerTimeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Platform) computeClient() (*gophercloud.ServiceClient, error) {
+	provider, err := openstack.NewClient(p.config.OpenstackAuthURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OpenStack client. %s", err)
+	}
+
+	// same as the Terraform provider, do not verify the server certificate
+	provider.HTTPClient = http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	opts := gophercloud.AuthOptions{
+		IdentityEndpoint: p.config.OpenstackAuthURL,
+		Username:         p.config.OpenstackUserName,
+		Password:         p.config.OpenstackPassword,
+		TenantName:       p.config.OpenstackTenantName,
+		DomainName:       p.config.OpenstackDomainName,
+	}
+	if err := openstack.Authenticate(provider, opts); err != nil {
+		return nil, fmt.Errorf("failed to authenticate with OpenStack. %s", err)
+	}
+
+	return openstack.NewComputeV2(provider, gophercloud.EndpointOpts{
+		Region: p.config.OpenstackRegion,
+	})
+}
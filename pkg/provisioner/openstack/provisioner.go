@@ -50,6 +50,15 @@ func (p *Platform) Plan(destroy bool) (plan *terraformer.Plan, err error) {
 	return p.t.Plan(destroy)
 }
 
+// Changes returns the changes of the resources in the last plan
+func (p *Platform) Changes() ([]*terraformer.ResourceChange, error) {
+	if p.t == nil {
+		return nil, fmt.Errorf("cannot get the changes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	return p.t.Changes()
+}
+
 // Apply apply the changes either to create or destroy the cluster on this platform
 func (p *Platform) Apply(destroy bool) error {
 	if p.t == nil {
@@ -80,6 +89,17 @@ func (p *Platform) Terminate() error {
 	return p.t.Apply(true)
 }
 
+// AddHook adds a Terraform hook to the provisioner, such as a hook to halt the
+// changes. The platform has to be a provisioner already
+func (p *Platform) AddHook(hook terraformer.Hook) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot add the hook, the %s plaftorm is not a provisioner yet", p.name)
+	}
+	p.t.Hooks = append(p.t.Hooks, hook)
+
+	return nil
+}
+
 // Code returns the Terraform code to execute
 func (p *Platform) Code() []byte {
 
@@ -0,0 +1,62 @@
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// Replace destroys the instance of the given node and creates it again. The
+// instance is tainted and the changes are applied only to that instance, its
+// floating IP association and the resource waiting for it, the rest of the
+// infrastructure is not modified
+func (p *Platform) Replace(node *state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot replace the node, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	addresses := state.ResourceInstancesAddressFor(p.t.State, "openstack_compute_instance_v2", []*state.Node{node}, "access_ip_v4", "name")
+	if len(addresses) == 0 {
+		return fmt.Errorf("not found the instance of node %s in the %s state", node.PublicIP, p.name)
+	}
+	if err := p.t.Taint(addresses...); err != nil {
+		return err
+	}
+
+	targets := []string{}
+	for _, address := range addresses {
+		instanceTargets, err := replaceTargets(address)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, instanceTargets...)
+	}
+
+	p.ui.Log.Debugf("replacing the instance %v", addresses)
+	return p.t.ApplyTargets(false, targets...)
+}
+
+// replaceTargets returns the address of the given instance and the addresses
+// of the resources of the same node depending on it: the floating IP
+// association and the resource waiting for the instance to be accessible
+func replaceTargets(address string) ([]string, error) {
+	addr, diags := addrs.ParseAbsResourceInstanceStr(address)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid resource address %q. %s", address, diags.Err())
+	}
+	name := addr.Resource.Resource.Name
+	key := addr.Resource.Key.String()
+
+	return []string{
+		address,
+		fmt.Sprintf("openstack_compute_floatingip_associate_v2.float_assoc-%s%s", name, key),
+		fmt.Sprintf("null_resource.wait-%s%s", name, key),
+	}, nil
+}
+
+// Remove is not required on this platform, the nodes are destroyed when the
+// count of their node pool is decremented and the changes are applied
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform removes the nodes decrementing the count of their node pool", p.name)
+}
@@ -0,0 +1,33 @@
+package openstack
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_replaceTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    []string
+		wantErr bool
+	}{
+		{"instance", "openstack_compute_instance_v2.worker[1]", []string{
+			"openstack_compute_instance_v2.worker[1]",
+			"openstack_compute_floatingip_associate_v2.float_assoc-worker[1]",
+			"null_resource.wait-worker[1]",
+		}, false},
+		{"invalid address", "openstack_compute_instance_v2.", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceTargets(tt.address)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replaceTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replaceTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/kraken/terraformer"
 	"github.com/kraken/ui"
 	"github.com/liferaft/kubekit/pkg/provisioner/aks"
+	"github.com/liferaft/kubekit/pkg/provisioner/azure"
 	"github.com/liferaft/kubekit/pkg/provisioner/ec2"
 	"github.com/liferaft/kubekit/pkg/provisioner/eks"
 	"github.com/liferaft/kubekit/pkg/provisioner/openstack"
@@ -28,9 +29,16 @@ type Provisioner interface {
 	GetPrivateKey() (string, []byte, bool)
 	PrivateKey(string, []byte, []byte)
 	Plan(bool) (*terraformer.Plan, error)
+	Changes() ([]*terraformer.ResourceChange, error)
 	Apply(bool) error
 	Provision() error
 	Terminate() error
+	AddHook(terraformer.Hook) error
+	Import(map[string][]string) error
+	Start([]*state.Node) error
+	Stop([]*state.Node) error
+	Replace(*state.Node) error
+	Remove([]*state.Node) error
 	Code() []byte
 	State() *terraformer.State
 	LoadState(*bytes.Buffer) error
@@ -45,6 +53,7 @@ type Provisioner interface {
 
 var allPlatforms = []string{
 	"aks",
+	"azure",
 	"ec2",
 	"eks",
 	"vsphere",
@@ -89,6 +98,8 @@ func New(clusterName, platformName string, envConfig map[string]string, ui *ui.U
 	switch platformName {
 	case "aks":
 		p, err = aks.New(clusterName, envConfig, ui, version)
+	case "azure":
+		p, err = azure.New(clusterName, envConfig, ui, version)
 	case "ec2":
 		p, err = ec2.New(clusterName, envConfig, ui, version)
 	case "eks":
@@ -121,6 +132,8 @@ func NewPlatform(name, clusterName string, config interface{}, credentials []str
 	switch name {
 	case "aks":
 		return aks.CreateFrom(clusterName, c, credentials, ui, version), nil
+	case "azure":
+		return azure.CreateFrom(clusterName, c, credentials, ui, version), nil
 	case "ec2":
 		return ec2.CreateFrom(clusterName, c, credentials, ui, version), nil
 	case "eks":
@@ -140,6 +140,7 @@ func TestSupportedPlatformsName(t *testing.T) {
 			name: "all platform",
 			want: []string{
 				"aks",
+				"azure",
 				"ec2",
 				"eks",
 				"vsphere",
@@ -1,7 +1,10 @@
 package raw
 
 import (
+	"fmt"
+
 	"github.com/kraken/terraformer"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
 )
 
 // BeProvisioner setup the Plaftorm to be a Provisioner
@@ -16,6 +19,12 @@ func (p *Platform) Plan(destroy bool) (plan *terraformer.Plan, err error) {
 	return nil, nil
 }
 
+// Changes returns the changes of the resources in the last plan
+func (p *Platform) Changes() ([]*terraformer.ResourceChange, error) {
+	p.ui.Log.Debugf("%s platform do not implements Changes()", p.name)
+	return nil, nil
+}
+
 // Apply apply the changes either to create or destroy the cluster on this platform
 func (p *Platform) Apply(destroy bool) error {
 	p.ui.Log.Debugf("%s platform do not implements Apply()", p.name)
@@ -34,6 +43,46 @@ func (p *Platform) Terminate() error {
 	return nil
 }
 
+// AddHook adds a Terraform hook to the provisioner. This platform do not use
+// Terraform, so the hook is ignored
+func (p *Platform) AddHook(hook terraformer.Hook) error {
+	p.ui.Log.Debugf("%s platform do not implements AddHook()", p.name)
+	return nil
+}
+
+// Import brings existing infrastructure under the management of KubeKit. The
+// nodes of this platform are not created by KubeKit, to use existing nodes add
+// them to the cluster configuration
+func (p *Platform) Import(ids map[string][]string) error {
+	return fmt.Errorf("the %s platform does not import nodes, add the existing nodes to the cluster configuration", p.name)
+}
+
+// Start powers on the given nodes. The nodes are not managed by KubeKit, so the
+// Kubernetes services are started by the cluster instead
+func (p *Platform) Start(nodes []*state.Node) error {
+	p.ui.Log.Debugf("%s platform do not implements Start()", p.name)
+	return nil
+}
+
+// Stop powers off the given nodes. The nodes are not managed by KubeKit, so the
+// Kubernetes services are stopped by the cluster instead
+func (p *Platform) Stop(nodes []*state.Node) error {
+	p.ui.Log.Debugf("%s platform do not implements Stop()", p.name)
+	return nil
+}
+
+// Replace is not supported on this platform, the nodes are not managed by
+// KubeKit
+func (p *Platform) Replace(node *state.Node) error {
+	return fmt.Errorf("the %s platform does not support replacing nodes", p.name)
+}
+
+// Remove is not supported on this platform, the nodes are not managed by
+// KubeKit
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform does not support removing nodes", p.name)
+}
+
 // Code returns the Terraform code to execute
 func (p *Platform) Code() []byte {
 	p.ui.Log.Debugf("%s platform do not implements Code()", p.name)
@@ -0,0 +1,227 @@
+package stacki
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Host attributes set on the Stacki frontend to know the hosts allocated to a
+// cluster, the node pool they belong to and the index of their address in the
+// node pool address pool
+const (
+	clusterAttr = "kubekit.cluster"
+	poolAttr    = "kubekit.pool"
+	addressAttr = "kubekit.address"
+)
+
+// Client is a client of the Stacki frontend web service API. Every Stacki
+// command, like `list host`, is sent to the `/stack` endpoint after login
+type Client struct {
+	url      string
+	username string
+	password string
+	http     *http.Client
+	csrf     string
+}
+
+// Host is a host registered in the Stacki frontend
+type Host struct {
+	Name        string `json:"host"`
+	Appliance   string `json:"appliance"`
+	Box         string `json:"box"`
+	OSAction    string `json:"osaction"`
+	Environment string `json:"environment"`
+}
+
+// HostAttr is an attribute of a host registered in the Stacki frontend
+type HostAttr struct {
+	Host  string `json:"host"`
+	Attr  string `json:"attr"`
+	Value string `json:"value"`
+}
+
+// NewClient creates a client of the Stacki frontend API in the given URL, i.e.
+// https://stacki.example.com, and login with the given credentials
+func NewClient(apiURL, username, password string, insecure bool) (*Client, error) {
+	if _, err := url.Parse(apiURL); err != nil {
+		return nil, fmt.Errorf("invalid Stacki URL %q. %s", apiURL, err)
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{
+		Jar:     jar,
+		Timeout: 2 * time.Minute,
+	}
+	if insecure {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	c := &Client{
+		url:      strings.TrimSuffix(apiURL, "/"),
+		username: username,
+		password: password,
+		http:     httpClient,
+	}
+
+	if err := c.login(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// login gets the CSRF token and the session from the Stacki frontend
+func (c *Client) login() error {
+	resp, err := c.http.Get(c.url + "/login")
+	if err != nil {
+		return fmt.Errorf("failed to connect to the Stacki frontend %s. %s", c.url, err)
+	}
+	resp.Body.Close()
+	c.csrf = c.cookie("csrftoken")
+
+	form := url.Values{}
+	form.Set("USERNAME", c.username)
+	form.Set("PASSWORD", c.password)
+	req, err := http.NewRequest("POST", c.url+"/login", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-CSRFToken", c.csrf)
+	req.Header.Set("Referer", c.url)
+
+	resp, err = c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to login to the Stacki frontend %s. %s", c.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to login to the Stacki frontend %s as %q. %s", c.url, c.username, resp.Status)
+	}
+	// the CSRF token is rotated after login
+	if csrf := c.cookie("csrftoken"); len(csrf) != 0 {
+		c.csrf = csrf
+	}
+
+	return nil
+}
+
+func (c *Client) cookie(name string) string {
+	u, err := url.Parse(c.url)
+	if err != nil {
+		return ""
+	}
+	for _, cookie := range c.http.Jar.Cookies(u) {
+		if cookie.Name == name {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+// Run executes the given Stacki command and stores the JSON output in `out`,
+// if it's not nil
+func (c *Client) Run(cmd string, out interface{}) error {
+	body, err := json.Marshal(map[string]string{"cmd": cmd})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.url+"/stack", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CSRFToken", c.csrf)
+	req.Header.Set("Referer", c.url)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute the Stacki command %q. %s", cmd, err)
+	}
+	defer resp.Body.Close()
+
+	output, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to execute the Stacki command %q. %s: %s", cmd, resp.Status, strings.TrimSpace(string(output)))
+	}
+
+	if out == nil || len(bytes.TrimSpace(output)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(output, out); err != nil {
+		return fmt.Errorf("failed to decode the output of the Stacki command %q. %s", cmd, err)
+	}
+
+	return nil
+}
+
+// Hosts returns the hosts registered in the Stacki frontend
+func (c *Client) Hosts() ([]Host, error) {
+	hosts := []Host{}
+	err := c.Run("list host", &hosts)
+	return hosts, err
+}
+
+// HostsAttr returns the value of the given attribute for every host that has it
+func (c *Client) HostsAttr(attr string) (map[string]string, error) {
+	attrs := []HostAttr{}
+	if err := c.Run(fmt.Sprintf("list host attr attr=%s", attr), &attrs); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		if a.Attr == attr {
+			values[a.Host] = a.Value
+		}
+	}
+
+	return values, nil
+}
+
+// SetHostAttr sets the attribute of a host
+func (c *Client) SetHostAttr(host, attr, value string) error {
+	return c.Run(fmt.Sprintf("set host attr %s attr=%s value=%s", host, attr, value), nil)
+}
+
+// RemoveHostAttr removes the attribute of a host
+func (c *Client) RemoveHostAttr(host, attr string) error {
+	return c.Run(fmt.Sprintf("remove host attr %s attr=%s", host, attr), nil)
+}
+
+// SetHostName renames a host
+func (c *Client) SetHostName(host, name string) error {
+	return c.Run(fmt.Sprintf("set host name %s name=%s", host, name), nil)
+}
+
+// SetHostIP sets the IP address of a host network interface
+func (c *Client) SetHostIP(host, iface, ip string) error {
+	return c.Run(fmt.Sprintf("set host interface ip %s interface=%s ip=%s", host, iface, ip), nil)
+}
+
+// SetHostBoot sets the action of a host in the next boot, `install` to install
+// the OS or `os` to boot from the installed OS
+func (c *Client) SetHostBoot(host, action string) error {
+	return c.Run(fmt.Sprintf("set host boot %s action=%s", host, action), nil)
+}
+
+// SetHostPower powers on, off or resets a host
+func (c *Client) SetHostPower(host, command string) error {
+	return c.Run(fmt.Sprintf("set host power %s command=%s", host, command), nil)
+}
@@ -13,6 +13,9 @@ var defaultConfig = Config{
 	PrivateKeyFile:         "/root/.ssh/id_rsa",
 	PublicKeyFile:          "/root/.ssh/id_rsa.pub",
 	APIAddress:             "",
+	StackiURL:              "",
+	StackiUsername:         "admin",
+	InstallTimeout:         "60m",
 	KubeAPISSLPort:         6558,
 	KubeVIPAPISSLPort:      8444,
 	KubeVirtualIPApi:       requiredValue + "39.80.0.100",
@@ -29,8 +32,11 @@ var defaultConfig = Config{
 }
 
 var defaultNodePool = NodePool{
-	Name:  "default",
-	Count: 0,
+	Name:      "default",
+	Count:     0,
+	Appliance: "backend",
+	Box:       "default",
+	Interface: "eth0",
 	KubeletNodeLabels: []string{
 		`node-role.kubernetes.io/compute=""`,
 		`node.kubernetes.io/compute=""`,
@@ -93,6 +99,11 @@ const requiredValue = "# Required value. Example: "
 type Config struct {
 	clusterName            string
 	APIAddress             string              `json:"api_address" yaml:"api_address" mapstructure:"api_address"`
+	StackiURL              string              `json:"stacki_url" yaml:"stacki_url" mapstructure:"stacki_url"`
+	StackiUsername         string              `json:"stacki_username" yaml:"stacki_username" mapstructure:"stacki_username"`
+	StackiPassword         string              `json:"-" yaml:"-" mapstructure:"-"`
+	StackiInsecure         bool                `json:"stacki_insecure,omitempty" yaml:"stacki_insecure,omitempty" mapstructure:"stacki_insecure"`
+	InstallTimeout         string              `json:"install_timeout" yaml:"install_timeout" mapstructure:"install_timeout"`
 	KubeAPISSLPort         int                 `json:"kube_api_ssl_port" yaml:"kube_api_ssl_port" mapstructure:"kube_api_ssl_port"`
 	DisableMasterHA        bool                `json:"disable_master_ha" yaml:"disable_master_ha" mapstructure:"disable_master_ha"`
 	KubeVirtualIPShortname string              `json:"kube_virtual_ip_shortname" yaml:"kube_virtual_ip_shortname" mapstructure:"kube_virtual_ip_shortname"`
@@ -117,6 +128,9 @@ type Config struct {
 type NodePool struct {
 	Name              string   `json:"-" yaml:"-" mapstructure:"name"`
 	Count             int      `json:"count" yaml:"count" mapstructure:"count"`
+	Appliance         string   `json:"appliance,omitempty" yaml:"appliance,omitempty" mapstructure:"appliance"`
+	Box               string   `json:"box,omitempty" yaml:"box,omitempty" mapstructure:"box"`
+	Interface         string   `json:"interface,omitempty" yaml:"interface,omitempty" mapstructure:"interface"`
 	KubeletNodeLabels []string `json:"kubelet_node_labels,omitempty" yaml:"kubelet_node_labels,omitempty" mapstructure:"kubelet_node_labels"`
 	KubeletNodeTaints []string `json:"kubelet_node_taints,omitempty" yaml:"kubelet_node_taints,omitempty" mapstructure:"kubelet_node_taints"`
 	Nodes             []Node   `json:"address_pool" yaml:"address_pool" mapstructure:"address_pool"`
@@ -0,0 +1,301 @@
+package stacki
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultSSHPort        = 22
+	defaultInstallTimeout = 60 * time.Minute
+	sshPollInterval       = 15 * time.Second
+)
+
+// allocation is a host of the Stacki frontend allocated to a node pool, with
+// the address assigned from the node pool address pool
+type allocation struct {
+	host    string
+	pool    string
+	address int
+	node    Node
+}
+
+// managed returns true if the hosts are allocated from a Stacki frontend,
+// otherwise the nodes are the ones listed in the address pools
+func (c *Config) managed() bool {
+	return len(c.StackiURL) != 0
+}
+
+// poolSetting returns the value of a node pool setting, or the value in the
+// default node pool if the node pool does not have it
+func (c *Config) poolSetting(pool NodePool, get func(NodePool) string, defValue string) string {
+	if v := get(pool); len(v) != 0 {
+		return v
+	}
+	if v := get(c.DefaultNodePool); len(v) != 0 {
+		return v
+	}
+	return defValue
+}
+
+// poolNames returns the sorted list of node pool names
+func (c *Config) poolNames() []string {
+	names := make([]string, 0, len(c.NodePools))
+	for name := range c.NodePools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// poolNodes returns the nodes of the node pool. When the hosts are allocated
+// from Stacki only the first `count` addresses of the address pool are used
+func (c *Config) poolNodes(pool NodePool) []Node {
+	if !c.managed() || pool.Count >= len(pool.Nodes) {
+		return pool.Nodes
+	}
+	return pool.Nodes[:pool.Count]
+}
+
+func (c *Config) installTimeout() (time.Duration, error) {
+	if len(c.InstallTimeout) == 0 {
+		return defaultInstallTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.InstallTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid install timeout %q. %s", c.InstallTimeout, err)
+	}
+	return timeout, nil
+}
+
+// allocate finds the free hosts required by every node pool, the hosts already
+// allocated to the cluster are not allocated again. It fails if there are not
+// enough free hosts or addresses, before allocating any host. It also returns
+// the hosts to release from the node pools with more hosts than required, the
+// ones with the last addresses of the address pool
+func (p *Platform) allocate() ([]allocation, []string, error) {
+	hosts, err := p.client.Hosts()
+	if err != nil {
+		return nil, nil, err
+	}
+	clusters, err := p.client.HostsAttr(clusterAttr)
+	if err != nil {
+		return nil, nil, err
+	}
+	pools, err := p.client.HostsAttr(poolAttr)
+	if err != nil {
+		return nil, nil, err
+	}
+	addresses, err := p.client.HostsAttr(addressAttr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allocated := map[string][]string{}
+	for host, cluster := range clusters {
+		if cluster == p.config.clusterName {
+			allocated[pools[host]] = append(allocated[pools[host]], host)
+		}
+	}
+
+	taken := map[string]bool{}
+	allocations := []allocation{}
+	releases := []string{}
+	for _, poolName := range p.config.poolNames() {
+		pool := p.config.NodePools[poolName]
+		if pool.Count > len(pool.Nodes) {
+			return nil, nil, fmt.Errorf("the node pool %q requires %d nodes but there are %d addresses in the address pool", poolName, pool.Count, len(pool.Nodes))
+		}
+
+		poolHosts := sortByAddress(allocated[poolName], addresses)
+		if len(poolHosts) > pool.Count {
+			releases = append(releases, poolHosts[pool.Count:]...)
+			continue
+		}
+
+		used := map[int]bool{}
+		for _, host := range poolHosts {
+			if i, err := strconv.Atoi(addresses[host]); err == nil {
+				used[i] = true
+			}
+		}
+
+		appliance := p.config.poolSetting(pool, func(np NodePool) string { return np.Appliance }, "backend")
+		box := p.config.poolSetting(pool, func(np NodePool) string { return np.Box }, "default")
+
+		missing := pool.Count - len(poolHosts)
+		for i := 0; i < pool.Count && missing > 0; i++ {
+			if used[i] {
+				continue
+			}
+			host := ""
+			for _, h := range hosts {
+				if _, owned := clusters[h.Name]; owned || taken[h.Name] {
+					continue
+				}
+				if h.Appliance == appliance && h.Box == box {
+					host = h.Name
+					break
+				}
+			}
+			if len(host) == 0 {
+				return nil, nil, fmt.Errorf("not enough free hosts with appliance %q and box %q for the node pool %q, %d hosts are required", appliance, box, poolName, pool.Count-len(poolHosts))
+			}
+			taken[host] = true
+			allocations = append(allocations, allocation{
+				host:    host,
+				pool:    poolName,
+				address: i,
+				node:    pool.Nodes[i],
+			})
+			missing--
+		}
+	}
+
+	return allocations, releases, nil
+}
+
+// sortByAddress sorts the hosts by the index of their address in the address
+// pool, the hosts without address index go to the end
+func sortByAddress(hosts []string, addresses map[string]string) []string {
+	index := func(host string) int {
+		i, err := strconv.Atoi(addresses[host])
+		if err != nil {
+			return math.MaxInt32
+		}
+		return i
+	}
+	sorted := append([]string{}, hosts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if index(sorted[i]) == index(sorted[j]) {
+			return sorted[i] < sorted[j]
+		}
+		return index(sorted[i]) < index(sorted[j])
+	})
+	return sorted
+}
+
+// install claims the allocated host for the cluster, sets the host name and IP
+// address from the address pool and reboots the host to install the OS. If the
+// installation fails the host is released, so it's allocated again next time
+func (p *Platform) install(a allocation) (_ string, err error) {
+	host := a.host
+	defer func() {
+		if err == nil {
+			return
+		}
+		if errR := p.releaseHost(host); errR != nil {
+			p.ui.Log.Errorf("failed to release the host %s after a failed installation, release it manually. %s", host, errR)
+		}
+	}()
+
+	if err := p.client.SetHostAttr(host, clusterAttr, p.config.clusterName); err != nil {
+		return "", err
+	}
+	if err := p.client.SetHostAttr(host, poolAttr, a.pool); err != nil {
+		return "", err
+	}
+	if err := p.client.SetHostAttr(host, addressAttr, strconv.Itoa(a.address)); err != nil {
+		return "", err
+	}
+
+	if len(a.node.PrivateDNS) != 0 && a.node.PrivateDNS != host {
+		if err := p.client.SetHostName(host, a.node.PrivateDNS); err != nil {
+			return "", err
+		}
+		host = a.node.PrivateDNS
+	}
+
+	iface := p.config.poolSetting(p.config.NodePools[a.pool], func(np NodePool) string { return np.Interface }, "eth0")
+	if len(a.node.PrivateIP) != 0 {
+		if err := p.client.SetHostIP(host, iface, a.node.PrivateIP); err != nil {
+			return "", err
+		}
+	}
+
+	if err := p.client.SetHostBoot(host, "install"); err != nil {
+		return "", err
+	}
+	if err := p.client.SetHostPower(host, "reset"); err != nil {
+		return "", err
+	}
+
+	return host, nil
+}
+
+// release returns the hosts allocated to the cluster to the pool of free hosts
+// and powers them off
+func (p *Platform) release() error {
+	clusters, err := p.client.HostsAttr(clusterAttr)
+	if err != nil {
+		return err
+	}
+
+	hosts := []string{}
+	for host, cluster := range clusters {
+		if cluster == p.config.clusterName {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		if err := p.releaseHost(host); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseHost powers off the host and removes the attributes that allocate it
+// to the cluster
+func (p *Platform) releaseHost(host string) error {
+	p.ui.Log.Infof("releasing host %s", host)
+	if err := p.client.SetHostBoot(host, "os"); err != nil {
+		return err
+	}
+	if err := p.client.SetHostPower(host, "off"); err != nil {
+		return err
+	}
+	for _, attr := range []string{addressAttr, poolAttr, clusterAttr} {
+		if err := p.client.RemoveHostAttr(host, attr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitSSH waits until the SSH port of every node is open, which means the OS
+// installation is done
+func (p *Platform) waitSSH(nodes []Node, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	port := strconv.Itoa(p.sshPort)
+
+	for _, node := range nodes {
+		address := node.PublicIP
+		if len(address) == 0 {
+			address = node.PrivateIP
+		}
+		address = net.JoinHostPort(address, port)
+
+		for {
+			conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+			if err == nil {
+				conn.Close()
+				p.ui.Log.Debugf("SSH is ready on %s", address)
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("timeout waiting for SSH on %s after the OS installation. %s", address, err)
+			}
+			time.Sleep(sshPollInterval)
+		}
+	}
+
+	return nil
+}
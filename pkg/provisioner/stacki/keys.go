@@ -26,7 +26,20 @@ func (p *Platform) PrivateKey(file string, encKey, key []byte) {
 	p.config.PrivateKey = string(encKey)
 }
 
-// Credentials is to assign the credentials to the configuration
+// Credentials is to assign the credentials of the Stacki frontend to the
+// configuration. The parameters are the Stacki URL, username and password. The
+// URL and username are optional, if empty the ones in the configuration are used
 func (p *Platform) Credentials(params ...string) {
-	p.ui.Log.Debugf("%s platform does not implements Credentials()", p.name)
+	if len(params) != 3 {
+		p.ui.Log.Debugf("received %d credential parameters for the %s platform, expected 3", len(params), p.name)
+		return
+	}
+	p.ui.Log.Debug("getting Stacki credentials")
+	if len(params[0]) != 0 {
+		p.config.StackiURL = params[0]
+	}
+	if len(params[1]) != 0 {
+		p.config.StackiUsername = params[1]
+	}
+	p.config.StackiPassword = params[2]
 }
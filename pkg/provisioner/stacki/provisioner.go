@@ -1,12 +1,36 @@
 package stacki
 
 import (
+	"fmt"
+
 	"github.com/kraken/terraformer"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
 )
 
-// BeProvisioner setup the Plaftorm to be a Provisioner
+// BeProvisioner setup the Plaftorm to be a Provisioner. If the Stacki URL is
+// set, it connects to the Stacki frontend to allocate the hosts, otherwise the
+// nodes are the ones in the address pools
 func (p *Platform) BeProvisioner(state *terraformer.State) error {
-	p.ui.Log.Debugf("%s platform do not implements BeProvisioner()", p.name)
+	// If I'm already a provisioner, return
+	if p.client != nil {
+		return nil
+	}
+
+	if !p.config.managed() {
+		p.ui.Log.Debugf("the Stacki URL is not set, the nodes of the %s platform are the ones in the address pools", p.name)
+		return nil
+	}
+
+	if len(p.config.StackiPassword) == 0 {
+		return fmt.Errorf("the Stacki password is not set, login to the cluster with the Stacki credentials or set the environment variable STACKI_PASSWORD")
+	}
+
+	client, err := NewClient(p.config.StackiURL, p.config.StackiUsername, p.config.StackiPassword, p.config.StackiInsecure)
+	if err != nil {
+		return err
+	}
+	p.client = client
+
 	return nil
 }
 
@@ -16,25 +40,116 @@ func (p *Platform) Plan(destroy bool) (plan *terraformer.Plan, err error) {
 	return nil, nil
 }
 
-// Apply apply the changes either to create or destroy the cluster on this platform
+// Changes returns the changes of the resources in the last plan
+func (p *Platform) Changes() ([]*terraformer.ResourceChange, error) {
+	p.ui.Log.Debugf("%s platform do not implements Changes()", p.name)
+	return nil, nil
+}
+
+// Apply apply the changes either to create or destroy the cluster on this
+// platform. To create the cluster, the free hosts required by every node pool
+// are allocated from the Stacki frontend, the OS is installed on them and it
+// waits until they are accessible with SSH. The hosts of the node pools with
+// more hosts than required are released. To destroy the cluster, the hosts
+// are released
 func (p *Platform) Apply(destroy bool) error {
-	p.ui.Log.Debugf("%s platform do not implements Apply()", p.name)
-	return nil
+	if !p.config.managed() {
+		p.ui.Log.Debugf("the Stacki URL is not set, the nodes of the %s platform are not provisioned", p.name)
+		return nil
+	}
+	if p.client == nil {
+		return fmt.Errorf("cannot apply the changes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	if destroy {
+		p.ui.Log.Debug("starting to terminate the cluster")
+		return p.release()
+	}
+
+	p.ui.Log.Debug("starting to provision the cluster")
+
+	timeout, err := p.config.installTimeout()
+	if err != nil {
+		return err
+	}
+
+	allocations, releases, err := p.allocate()
+	if err != nil {
+		return err
+	}
+	for _, host := range releases {
+		if err := p.releaseHost(host); err != nil {
+			return fmt.Errorf("failed to release the host %s. %s", host, err)
+		}
+	}
+	if len(allocations) == 0 {
+		p.ui.Log.Infof("all the hosts are allocated, nothing to install")
+		return nil
+	}
+
+	nodes := make([]Node, 0, len(allocations))
+	for _, a := range allocations {
+		host, err := p.install(a)
+		if err != nil {
+			return fmt.Errorf("failed to install the host %s for the node pool %q. %s", a.host, a.pool, err)
+		}
+		p.ui.Log.Infof("installing host %s for the node pool %q", host, a.pool)
+		nodes = append(nodes, a.node)
+	}
+
+	return p.waitSSH(nodes, timeout)
 }
 
 // Provision provisions or creates a cluster on this platform
 func (p *Platform) Provision() error {
-	p.ui.Log.Debugf("%s platform do not implements Provision()", p.name)
-	return nil
+	return p.Apply(false)
 }
 
 // Terminate terminates or destroys a cluster on this platform
 func (p *Platform) Terminate() error {
-	p.ui.Log.Debugf("%s platform do not implements Terminate()", p.name)
+	return p.Apply(true)
+}
+
+// AddHook adds a Terraform hook to the provisioner. This platform do not use
+// Terraform, so the hook is ignored
+func (p *Platform) AddHook(hook terraformer.Hook) error {
+	p.ui.Log.Debugf("%s platform do not implements AddHook()", p.name)
+	return nil
+}
+
+// Import brings existing infrastructure under the management of KubeKit. The
+// nodes of this platform are not created by KubeKit, to use existing nodes add
+// them to the cluster configuration
+func (p *Platform) Import(ids map[string][]string) error {
+	return fmt.Errorf("the %s platform does not import nodes, add the existing nodes to the cluster configuration", p.name)
+}
+
+// Start powers on the given nodes. The nodes are not managed by KubeKit, so the
+// Kubernetes services are started by the cluster instead
+func (p *Platform) Start(nodes []*state.Node) error {
+	p.ui.Log.Debugf("%s platform do not implements Start()", p.name)
 	return nil
 }
 
-// Code returns the Terraform code to execute
+// Stop powers off the given nodes. The nodes are not managed by KubeKit, so the
+// Kubernetes services are stopped by the cluster instead
+func (p *Platform) Stop(nodes []*state.Node) error {
+	p.ui.Log.Debugf("%s platform do not implements Stop()", p.name)
+	return nil
+}
+
+// Replace is not supported on this platform yet
+func (p *Platform) Replace(node *state.Node) error {
+	return fmt.Errorf("the %s platform does not support replacing nodes", p.name)
+}
+
+// Remove is not supported on this platform yet
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform does not support removing nodes", p.name)
+}
+
+// Code returns the Terraform code to execute. This platform do not use
+// Terraform, the hosts are allocated with the Stacki API
 func (p *Platform) Code() []byte {
 	p.ui.Log.Debugf("%s platform do not implements Code()", p.name)
 	return []byte{}
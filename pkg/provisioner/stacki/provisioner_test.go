@@ -0,0 +1,355 @@
+package stacki
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var testCredentials = []string{"", "admin", "$up3r5ecret"}
+
+// fakeStacki is a Stacki frontend that keeps the hosts and their attributes in
+// memory and understands the commands used by the provisioner
+type fakeStacki struct {
+	mu    sync.Mutex
+	hosts []*Host
+	attrs map[string]map[string]string
+	ips   map[string]string
+	boot  map[string]string
+	power map[string]string
+	// fail is the prefix of the commands to fail
+	fail string
+}
+
+func newFakeStacki(hosts ...*Host) *fakeStacki {
+	return &fakeStacki{
+		hosts: hosts,
+		attrs: map[string]map[string]string{},
+		ips:   map[string]string{},
+		boot:  map[string]string{},
+		power: map[string]string{},
+	}
+}
+
+func (f *fakeStacki) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/login":
+		http.SetCookie(w, &http.Cookie{Name: "csrftoken", Value: "token", Path: "/"})
+		return
+	case "/stack":
+		if r.Header.Get("X-CSRFToken") != "token" {
+			http.Error(w, "missing CSRF token", http.StatusForbidden)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	var body map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	args := strings.Fields(body["cmd"])
+	params := map[string]string{}
+	var host string
+	for _, arg := range args {
+		if kv := strings.SplitN(arg, "=", 2); len(kv) == 2 {
+			params[kv[0]] = kv[1]
+		} else {
+			host = arg
+		}
+	}
+
+	if len(f.fail) != 0 && strings.HasPrefix(body["cmd"], f.fail) {
+		http.Error(w, "failed command "+body["cmd"], http.StatusInternalServerError)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(body["cmd"], "list host attr"):
+		out := []HostAttr{}
+		for _, h := range f.hosts {
+			if v, ok := f.attrs[h.Name][params["attr"]]; ok {
+				out = append(out, HostAttr{Host: h.Name, Attr: params["attr"], Value: v})
+			}
+		}
+		json.NewEncoder(w).Encode(out)
+	case strings.HasPrefix(body["cmd"], "list host"):
+		json.NewEncoder(w).Encode(f.hosts)
+	case strings.HasPrefix(body["cmd"], "set host attr"):
+		if f.attrs[host] == nil {
+			f.attrs[host] = map[string]string{}
+		}
+		f.attrs[host][params["attr"]] = params["value"]
+	case strings.HasPrefix(body["cmd"], "remove host attr"):
+		delete(f.attrs[host], params["attr"])
+	case strings.HasPrefix(body["cmd"], "set host name"):
+		for _, h := range f.hosts {
+			if h.Name == host {
+				h.Name = params["name"]
+			}
+		}
+		f.attrs[params["name"]] = f.attrs[host]
+		delete(f.attrs, host)
+	case strings.HasPrefix(body["cmd"], "set host interface ip"):
+		f.ips[host] = params["ip"]
+	case strings.HasPrefix(body["cmd"], "set host boot"):
+		f.boot[host] = params["action"]
+	case strings.HasPrefix(body["cmd"], "set host power"):
+		f.power[host] = params["command"]
+	default:
+		http.Error(w, "unknown command "+body["cmd"], http.StatusBadRequest)
+	}
+}
+
+func TestPlatform_ProvisionTerminate(t *testing.T) {
+	fake := newFakeStacki(
+		&Host{Name: "backend-0-0", Appliance: "backend", Box: "default"},
+		&Host{Name: "backend-0-1", Appliance: "backend", Box: "default"},
+		&Host{Name: "backend-0-2", Appliance: "backend", Box: "default"},
+		&Host{Name: "frontend-0-0", Appliance: "frontend", Box: "default"},
+	)
+	// a host of another cluster is not allocated
+	fake.attrs["backend-0-0"] = map[string]string{clusterAttr: "otherCluster"}
+
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	// the SSH port of the installed nodes is a local listener
+	ssh, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ssh.Close()
+
+	config := defaultConfig
+	config.clusterName = "testCluster"
+	config.StackiURL = server.URL
+	config.NodePools = map[string]NodePool{
+		"master": {
+			Count: 1,
+			Nodes: []Node{
+				{PrivateIP: "10.0.0.1", PublicIP: "127.0.0.1", PrivateDNS: "master-1"},
+				{PrivateIP: "10.0.0.2", PublicIP: "127.0.0.1", PrivateDNS: "master-2"},
+			},
+		},
+		"worker": {
+			Count: 1,
+			Nodes: []Node{
+				{PrivateIP: "10.0.0.3", PublicIP: "127.0.0.1", PrivateDNS: "worker-1"},
+			},
+		},
+	}
+
+	p := newPlatform(&config, testCredentials, tUI, version)
+	p.sshPort = ssh.Addr().(*net.TCPAddr).Port
+
+	if err := p.BeProvisioner(nil); err != nil {
+		t.Fatalf("BeProvisioner() error = %v", err)
+	}
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+
+	wantPools := map[string]string{"master-1": "master", "worker-1": "worker"}
+	for host, pool := range wantPools {
+		if got := fake.attrs[host][clusterAttr]; got != "testCluster" {
+			t.Errorf("Provision() host %s cluster = %q, want %q", host, got, "testCluster")
+		}
+		if got := fake.attrs[host][poolAttr]; got != pool {
+			t.Errorf("Provision() host %s pool = %q, want %q", host, got, pool)
+		}
+		if got := fake.boot[host]; got != "install" {
+			t.Errorf("Provision() host %s boot action = %q, want %q", host, got, "install")
+		}
+	}
+	if got := fake.ips["master-1"]; got != "10.0.0.1" {
+		t.Errorf("Provision() host master-1 IP = %q, want %q", got, "10.0.0.1")
+	}
+	if _, ok := fake.attrs["frontend-0-0"]; ok {
+		t.Errorf("Provision() allocated the frontend host")
+	}
+
+	nodes := p.Nodes()
+	if len(nodes) != 2 {
+		t.Errorf("Nodes() returned %d nodes, want 2", len(nodes))
+	}
+
+	// provision again does not allocate more hosts
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() again error = %v", err)
+	}
+	if got := fake.attrs["backend-0-2"][clusterAttr]; len(got) != 0 {
+		t.Errorf("Provision() again allocated host backend-0-2 to %q", got)
+	}
+
+	if err := p.Terminate(); err != nil {
+		t.Fatalf("Terminate() error = %v", err)
+	}
+	for host := range wantPools {
+		if _, ok := fake.attrs[host][clusterAttr]; ok {
+			t.Errorf("Terminate() did not release host %s", host)
+		}
+		if got := fake.power[host]; got != "off" {
+			t.Errorf("Terminate() host %s power = %q, want %q", host, got, "off")
+		}
+	}
+	if got := fake.attrs["backend-0-0"][clusterAttr]; got != "otherCluster" {
+		t.Errorf("Terminate() released host backend-0-0 of another cluster")
+	}
+}
+
+func TestPlatform_ProvisionNotEnoughHosts(t *testing.T) {
+	fake := newFakeStacki(
+		&Host{Name: "backend-0-0", Appliance: "backend", Box: "default"},
+	)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := defaultConfig
+	config.clusterName = "testCluster"
+	config.StackiURL = server.URL
+	config.NodePools = map[string]NodePool{
+		"worker": {
+			Count: 2,
+			Nodes: []Node{
+				{PrivateIP: "10.0.0.1"},
+				{PrivateIP: "10.0.0.2"},
+			},
+		},
+	}
+
+	p := newPlatform(&config, testCredentials, tUI, version)
+	if err := p.BeProvisioner(nil); err != nil {
+		t.Fatalf("BeProvisioner() error = %v", err)
+	}
+	if err := p.Provision(); err == nil {
+		t.Errorf("Provision() expected an error with not enough free hosts")
+	}
+	if len(fake.attrs) != 0 {
+		t.Errorf("Provision() allocated hosts when there are not enough free hosts")
+	}
+}
+
+func TestPlatform_ProvisionScaleDown(t *testing.T) {
+	fake := newFakeStacki(
+		&Host{Name: "backend-0-0", Appliance: "backend", Box: "default"},
+		&Host{Name: "backend-0-1", Appliance: "backend", Box: "default"},
+		&Host{Name: "backend-0-2", Appliance: "backend", Box: "default"},
+	)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	ssh, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ssh.Close()
+
+	config := defaultConfig
+	config.clusterName = "testCluster"
+	config.StackiURL = server.URL
+	config.NodePools = map[string]NodePool{
+		"worker": {
+			Count: 3,
+			Nodes: []Node{
+				{PrivateIP: "10.0.0.1", PublicIP: "127.0.0.1", PrivateDNS: "worker-1"},
+				{PrivateIP: "10.0.0.2", PublicIP: "127.0.0.1", PrivateDNS: "worker-2"},
+				{PrivateIP: "10.0.0.3", PublicIP: "127.0.0.1", PrivateDNS: "worker-3"},
+			},
+		},
+	}
+
+	p := newPlatform(&config, testCredentials, tUI, version)
+	p.sshPort = ssh.Addr().(*net.TCPAddr).Port
+	if err := p.BeProvisioner(nil); err != nil {
+		t.Fatalf("BeProvisioner() error = %v", err)
+	}
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+
+	pool := config.NodePools["worker"]
+	pool.Count = 1
+	config.NodePools["worker"] = pool
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() scaling down error = %v", err)
+	}
+
+	if got := fake.attrs["worker-1"][clusterAttr]; got != "testCluster" {
+		t.Errorf("Provision() scaling down released the host worker-1")
+	}
+	for _, host := range []string{"worker-2", "worker-3"} {
+		if _, ok := fake.attrs[host][clusterAttr]; ok {
+			t.Errorf("Provision() scaling down did not release the host %s", host)
+		}
+		if got := fake.power[host]; got != "off" {
+			t.Errorf("Provision() scaling down host %s power = %q, want %q", host, got, "off")
+		}
+	}
+
+	// scaling up again allocates the released addresses
+	pool.Count = 2
+	config.NodePools["worker"] = pool
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() scaling up error = %v", err)
+	}
+	if got := fake.attrs["worker-2"][addressAttr]; got != "1" {
+		t.Errorf("Provision() scaling up host worker-2 address = %q, want %q", got, "1")
+	}
+}
+
+func TestPlatform_ProvisionFailedInstall(t *testing.T) {
+	fake := newFakeStacki(
+		&Host{Name: "backend-0-0", Appliance: "backend", Box: "default"},
+	)
+	fake.fail = "set host interface ip"
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	config := defaultConfig
+	config.clusterName = "testCluster"
+	config.StackiURL = server.URL
+	config.NodePools = map[string]NodePool{
+		"worker": {
+			Count: 1,
+			Nodes: []Node{
+				{PrivateIP: "10.0.0.1", PrivateDNS: "worker-1"},
+			},
+		},
+	}
+
+	p := newPlatform(&config, testCredentials, tUI, version)
+	if err := p.BeProvisioner(nil); err != nil {
+		t.Fatalf("BeProvisioner() error = %v", err)
+	}
+	if err := p.Provision(); err == nil {
+		t.Fatalf("Provision() expected an error when the installation fails")
+	}
+	for host, attrs := range fake.attrs {
+		if _, ok := attrs[clusterAttr]; ok {
+			t.Errorf("Provision() did not release the host %s after a failed installation", host)
+		}
+	}
+}
+
+func TestPlatform_BeProvisionerWithoutPassword(t *testing.T) {
+	config := defaultConfig
+	config.clusterName = "testCluster"
+	config.StackiURL = "https://stacki.example.com"
+
+	p := newPlatform(&config, nil, tUI, version)
+	if err := p.BeProvisioner(nil); err == nil {
+		t.Errorf("BeProvisioner() expected an error without the Stacki password")
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"github.com/kraken/ui"
 )
 
-// Platform implements the Provisioner interface for vSphere
+// Platform implements the Provisioner interface for Stacki
 type Platform struct {
 	name    string
 	config  *Config
 	ui      *ui.UI
 	version string
+	client  *Client
+	sshPort int
 }
 
 // New creates a new Plaform with the given environment configuration
@@ -26,27 +28,30 @@ func New(clusterName string, envConfig map[string]string, ui *ui.UI, version str
 		config:  config,
 		ui:      ui,
 		version: version,
+		sshPort: defaultSSHPort,
 	}, nil
 }
 
 // CreateFrom creates a new Plaftorm with the given configuration for Stacki
 func CreateFrom(clusterName string, config map[interface{}]interface{}, credentials []string, ui *ui.UI, version string) *Platform {
 	if config == nil {
-		return newPlatform(&defaultConfig, ui, version)
+		return newPlatform(&defaultConfig, credentials, ui, version)
 	}
 	c := NewConfigFrom(config)
 	c.clusterName = clusterName
 
-	return newPlatform(c, ui, version)
+	return newPlatform(c, credentials, ui, version)
 }
 
-func newPlatform(c *Config, ui *ui.UI, version string) *Platform {
+func newPlatform(c *Config, credentials []string, ui *ui.UI, version string) *Platform {
 	p := &Platform{
 		name:    "stacki",
 		config:  c,
 		ui:      ui,
 		version: version,
+		sshPort: defaultSSHPort,
 	}
+	p.Credentials(credentials...)
 
 	return p
 }
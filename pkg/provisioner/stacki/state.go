@@ -52,11 +52,13 @@ func (p *Platform) Output(name string) string {
 }
 
 // Nodes return the list of nodes provisioned. It took the value from the
+// address pools, only the addresses assigned to the allocated hosts if the hosts
+// are allocated from the Stacki frontend
 func (p *Platform) Nodes() []*state.Node {
 
 	stateNodes := make([]*state.Node, 0)
 	for roleName, nodePool := range p.config.NodePools {
-		for _, node := range nodePool.Nodes {
+		for _, node := range p.config.poolNodes(nodePool) {
 			stateNode := &state.Node{
 				PublicIP:   node.PublicIP,
 				PrivateIP:  node.PrivateIP,
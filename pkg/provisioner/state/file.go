@@ -0,0 +1,13 @@
+package state
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// PlatformStateFile returns the file where the platforms without a Terraform
+// state (i.e. vRA) persist their state, for the given Terraform state file. It
+// has the same name with the extension `.json`, in the same directory
+func PlatformStateFile(tfStateFile string) string {
+	return strings.TrimSuffix(tfStateFile, filepath.Ext(tfStateFile)) + ".json"
+}
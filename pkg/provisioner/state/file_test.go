@@ -0,0 +1,18 @@
+package state
+
+import "testing"
+
+func TestPlatformStateFile(t *testing.T) {
+	tests := []struct {
+		tfStateFile string
+		want        string
+	}{
+		{"/kubekit/clusters/kkdemo/.tfstate/vra.tfstate", "/kubekit/clusters/kkdemo/.tfstate/vra.json"},
+		{"vra", "vra.json"},
+	}
+	for _, tt := range tests {
+		if got := PlatformStateFile(tt.tfStateFile); got != tt.want {
+			t.Errorf("PlatformStateFile(%q) = %q, want %q", tt.tfStateFile, got, tt.want)
+		}
+	}
+}
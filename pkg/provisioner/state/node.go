@@ -34,3 +34,16 @@ func NewNodeFromAttr(attr map[string]string) *Node {
 	}
 	return &node
 }
+
+// HasAddress returns true if the node has any of the given IP's or DNS's
+func (n *Node) HasAddress(ipOrDNS ...string) bool {
+	for _, addr := range ipOrDNS {
+		if len(addr) == 0 {
+			continue
+		}
+		if n.PublicIP == addr || n.PrivateIP == addr || n.PublicDNS == addr || n.PrivateDNS == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package state
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+// ResourceInstancesAttributes returns the attributes of every instance of the
+// resources of the given type found in the TF state. It includes managed
+// resources and data sources
+func ResourceInstancesAttributes(s *states.State, resourceType string) []map[string]interface{} {
+	attrs := []map[string]interface{}{}
+	if s == nil || s.Empty() {
+		return attrs
+	}
+
+	for _, module := range s.Modules {
+		for _, resource := range module.Resources {
+			if resource.Addr.Type != resourceType {
+				continue
+			}
+			for _, instance := range resource.Instances {
+				if instance.Current == nil {
+					continue
+				}
+				attr := map[string]interface{}{}
+				if err := json.Unmarshal(instance.Current.AttrsJSON, &attr); err != nil {
+					continue
+				}
+				attrs = append(attrs, attr)
+			}
+		}
+	}
+
+	return attrs
+}
+
+// ResourceInstancesIDFor returns the ID of the instances of the given resource
+// type that belong to the given nodes. An instance belongs to a node if any of
+// the given address attributes has one of the node IP or DNS. If no nodes are
+// given, returns the ID of all the instances
+func ResourceInstancesIDFor(s *states.State, resourceType string, nodes []*Node, addressAttrs ...string) []string {
+	ids := []string{}
+	for _, attr := range ResourceInstancesAttributes(s, resourceType) {
+		id, ok := attr["id"].(string)
+		if !ok || len(id) == 0 {
+			continue
+		}
+		if len(nodes) == 0 {
+			ids = append(ids, id)
+			continue
+		}
+		for _, addrAttr := range addressAttrs {
+			addr, ok := attr[addrAttr].(string)
+			if !ok || len(addr) == 0 {
+				continue
+			}
+			if hasAddress(nodes, addr) {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+
+	return ids
+}
+
+// ResourceInstancesAddressFor returns the address, like `aws_instance.web[0]`,
+// of the managed instances of the given resource type that belong to the given
+// nodes. An instance belongs to a node if any of the given address attributes
+// has one of the node IP or DNS
+func ResourceInstancesAddressFor(s *states.State, resourceType string, nodes []*Node, addressAttrs ...string) []string {
+	addresses := []string{}
+	if s == nil || s.Empty() {
+		return addresses
+	}
+
+	for _, module := range s.Modules {
+		for _, resource := range module.Resources {
+			if resource.Addr.Type != resourceType || resource.Addr.Mode != addrs.ManagedResourceMode {
+				continue
+			}
+			for key, instance := range resource.Instances {
+				if instance.Current == nil {
+					continue
+				}
+				attr := map[string]interface{}{}
+				if err := json.Unmarshal(instance.Current.AttrsJSON, &attr); err != nil {
+					continue
+				}
+				for _, addrAttr := range addressAttrs {
+					addr, ok := attr[addrAttr].(string)
+					if ok && len(addr) != 0 && hasAddress(nodes, addr) {
+						addresses = append(addresses, resource.Addr.Instance(key).Absolute(module.Addr).String())
+						break
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+func hasAddress(nodes []*Node, addr string) bool {
+	for _, n := range nodes {
+		if n.HasAddress(addr) {
+			return true
+		}
+	}
+	return false
+}
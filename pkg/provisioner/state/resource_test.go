@@ -0,0 +1,87 @@
+package state
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/states"
+)
+
+func testState(resourceType string, mode addrs.ResourceMode, instancesAttrs ...string) *states.State {
+	s := states.NewState()
+	provider := addrs.ProviderConfig{Type: addrs.NewLegacyProvider("test")}.Absolute(addrs.RootModuleInstance)
+	for i, attrs := range instancesAttrs {
+		addr := addrs.Resource{
+			Mode: mode,
+			Type: resourceType,
+			Name: "node",
+		}.Instance(addrs.IntKey(i))
+		s.RootModule().SetResourceInstanceCurrent(addr, &states.ResourceInstanceObjectSrc{
+			Status:    states.ObjectReady,
+			AttrsJSON: []byte(attrs),
+		}, provider)
+	}
+	return s
+}
+
+func TestResourceInstancesIDFor(t *testing.T) {
+	instances := []string{
+		`{"id": "i-0001", "private_ip": "10.0.0.1", "public_ip": "54.0.0.1"}`,
+		`{"id": "i-0002", "private_ip": "10.0.0.2", "public_ip": "54.0.0.2"}`,
+		`{"id": "i-0003", "private_ip": "10.0.0.3", "public_ip": ""}`,
+	}
+
+	tests := []struct {
+		name         string
+		state        *states.State
+		resourceType string
+		nodes        []*Node
+		want         []string
+	}{
+		{"all instances", testState("aws_instance", addrs.DataResourceMode, instances...), "aws_instance", nil, []string{"i-0001", "i-0002", "i-0003"}},
+		{"by private IP", testState("aws_instance", addrs.DataResourceMode, instances...), "aws_instance", []*Node{{PrivateIP: "10.0.0.2"}}, []string{"i-0002"}},
+		{"by public IP", testState("aws_instance", addrs.ManagedResourceMode, instances...), "aws_instance", []*Node{{PublicIP: "54.0.0.1"}, {PrivateIP: "10.0.0.3"}}, []string{"i-0001", "i-0003"}},
+		{"unknown node", testState("aws_instance", addrs.DataResourceMode, instances...), "aws_instance", []*Node{{PrivateIP: "10.0.0.9"}}, []string{}},
+		{"other resource type", testState("aws_instance", addrs.DataResourceMode, instances...), "vsphere_virtual_machine", nil, []string{}},
+		{"nil state", nil, "aws_instance", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResourceInstancesIDFor(tt.state, tt.resourceType, tt.nodes, "private_ip", "public_ip")
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceInstancesIDFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceInstancesAddressFor(t *testing.T) {
+	instances := []string{
+		`{"id": "vm-1", "default_ip_address": "10.0.0.1"}`,
+		`{"id": "vm-2", "default_ip_address": "10.0.0.2"}`,
+	}
+
+	tests := []struct {
+		name  string
+		state *states.State
+		nodes []*Node
+		want  []string
+	}{
+		{"by IP", testState("vsphere_virtual_machine", addrs.ManagedResourceMode, instances...), []*Node{{PublicIP: "10.0.0.2"}}, []string{"vsphere_virtual_machine.node[1]"}},
+		{"all nodes", testState("vsphere_virtual_machine", addrs.ManagedResourceMode, instances...), []*Node{{PublicIP: "10.0.0.1"}, {PrivateIP: "10.0.0.2"}}, []string{"vsphere_virtual_machine.node[0]", "vsphere_virtual_machine.node[1]"}},
+		{"unknown node", testState("vsphere_virtual_machine", addrs.ManagedResourceMode, instances...), []*Node{{PublicIP: "10.0.0.9"}}, []string{}},
+		{"data sources", testState("vsphere_virtual_machine", addrs.DataResourceMode, instances...), []*Node{{PublicIP: "10.0.0.1"}}, []string{}},
+		{"nil state", nil, []*Node{{PublicIP: "10.0.0.1"}}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResourceInstancesAddressFor(tt.state, "vsphere_virtual_machine", tt.nodes, "default_ip_address")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceInstancesAddressFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awscredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// asgSuspendedProcesses are the auto scaling processes to suspend while the
+// instances are stopped, otherwise the stopped instances are replaced
+var asgSuspendedProcesses = []string{
+	"HealthCheck",
+	"ReplaceUnhealthy",
+	"AZRebalance",
+}
+
+// NewAWSSession creates an AWS session with the given credentials
+func NewAWSSession(accessKey, secretKey, sessionToken, region string) (*session.Session, error) {
+	return session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: awscredentials.NewStaticCredentials(accessKey, secretKey, sessionToken),
+	})
+}
+
+// StopAWSInstances suspends the health checks of the given auto scaling groups
+// and stops the given instances, waiting until they are stopped
+func StopAWSInstances(sess *session.Session, instanceIDs []string, asgNames []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	asgSvc := autoscaling.New(sess)
+	for _, name := range asgNames {
+		if _, err := asgSvc.SuspendProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(name),
+			ScalingProcesses:     aws.StringSlice(asgSuspendedProcesses),
+		}); err != nil {
+			return fmt.Errorf("failed to suspend the processes of the auto scaling group %s. %s", name, err)
+		}
+	}
+
+	ec2Svc := ec2.New(sess)
+	ids := aws.StringSlice(instanceIDs)
+	if _, err := ec2Svc.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids}); err != nil {
+		return fmt.Errorf("failed to stop the instances %v. %s", instanceIDs, err)
+	}
+
+	return ec2Svc.WaitUntilInstanceStopped(&ec2.DescribeInstancesInput{InstanceIds: ids})
+}
+
+// StartAWSInstances starts the given instances, waiting until they are running,
+// and resumes the processes of the given auto scaling groups that have all their
+// instances running. The groups with stopped instances keep the processes
+// suspended, so they are not replaced, until the rest of the instances are
+// started
+func StartAWSInstances(sess *session.Session, instanceIDs []string, asgNames []string) error {
+	if len(instanceIDs) != 0 {
+		ec2Svc := ec2.New(sess)
+		ids := aws.StringSlice(instanceIDs)
+		if _, err := ec2Svc.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids}); err != nil {
+			return fmt.Errorf("failed to start the instances %v. %s", instanceIDs, err)
+		}
+		if err := ec2Svc.WaitUntilInstanceRunning(&ec2.DescribeInstancesInput{InstanceIds: ids}); err != nil {
+			return err
+		}
+	}
+
+	asgNames, err := awsAutoScalingGroupsRunning(sess, asgNames)
+	if err != nil {
+		return err
+	}
+
+	asgSvc := autoscaling.New(sess)
+	for _, name := range asgNames {
+		if _, err := asgSvc.ResumeProcesses(&autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: aws.String(name),
+			ScalingProcesses:     aws.StringSlice(asgSuspendedProcesses),
+		}); err != nil {
+			return fmt.Errorf("failed to resume the processes of the auto scaling group %s. %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// awsAutoScalingGroupsRunning returns the given auto scaling groups that have
+// all their instances running
+func awsAutoScalingGroupsRunning(sess *session.Session, asgNames []string) ([]string, error) {
+	if len(asgNames) == 0 {
+		return nil, nil
+	}
+
+	asgSvc := autoscaling.New(sess)
+	groups, err := asgSvc.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice(asgNames),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the auto scaling groups %v. %s", asgNames, err)
+	}
+
+	groupInstances := map[string][]string{}
+	instanceIDs := []string{}
+	for _, group := range groups.AutoScalingGroups {
+		name := aws.StringValue(group.AutoScalingGroupName)
+		groupInstances[name] = []string{}
+		for _, instance := range group.Instances {
+			id := aws.StringValue(instance.InstanceId)
+			groupInstances[name] = append(groupInstances[name], id)
+			instanceIDs = append(instanceIDs, id)
+		}
+	}
+
+	running := map[string]bool{}
+	if len(instanceIDs) != 0 {
+		ec2Svc := ec2.New(sess)
+		err := ec2Svc.DescribeInstancesPages(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)}, func(out *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range out.Reservations {
+				for _, instance := range reservation.Instances {
+					if instance.State != nil && aws.StringValue(instance.State.Name) == ec2.InstanceStateNameRunning {
+						running[aws.StringValue(instance.InstanceId)] = true
+					}
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the state of the instances of the auto scaling groups %v. %s", asgNames, err)
+		}
+	}
+
+	names := []string{}
+	for _, name := range asgNames {
+		ids, ok := groupInstances[name]
+		if !ok {
+			continue
+		}
+		allRunning := true
+		for _, id := range ids {
+			if !running[id] {
+				allRunning = false
+				break
+			}
+		}
+		if allRunning {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// AWSAutoScalingGroupOf returns the auto scaling group all the given instances
+// belong to. It's an error if an instance is not in an auto scaling group or if
+// they are in different groups
+func AWSAutoScalingGroupOf(sess *session.Session, instanceIDs []string) (*autoscaling.Group, error) {
+	if len(instanceIDs) == 0 {
+		return nil, fmt.Errorf("no instances to find the auto scaling group")
+	}
+
+	groupOf, groups, err := AWSAutoScalingGroupsOf(sess, instanceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var name string
+	for _, id := range instanceIDs {
+		group, ok := groupOf[id]
+		if !ok {
+			return nil, fmt.Errorf("the instance %s is not in an auto scaling group", id)
+		}
+		if len(name) != 0 && group != name {
+			return nil, fmt.Errorf("the instances %v are in different auto scaling groups (%s and %s)", instanceIDs, name, group)
+		}
+		name = group
+	}
+
+	group, ok := groups[name]
+	if !ok {
+		return nil, fmt.Errorf("not found the auto scaling group %s", name)
+	}
+	return group, nil
+}
+
+// AWSAutoScalingGroupsOf returns the name of the auto scaling group of every
+// given instance and the groups by name. The instances that are not in an auto
+// scaling group, or do not exists, are not in the returned map
+func AWSAutoScalingGroupsOf(sess *session.Session, instanceIDs []string) (map[string]string, map[string]*autoscaling.Group, error) {
+	groupOf := make(map[string]string, len(instanceIDs))
+	groups := map[string]*autoscaling.Group{}
+	if len(instanceIDs) == 0 {
+		return groupOf, groups, nil
+	}
+
+	asgSvc := autoscaling.New(sess)
+	err := asgSvc.DescribeAutoScalingInstancesPages(&autoscaling.DescribeAutoScalingInstancesInput{
+		InstanceIds: aws.StringSlice(instanceIDs),
+	}, func(page *autoscaling.DescribeAutoScalingInstancesOutput, lastPage bool) bool {
+		for _, instance := range page.AutoScalingInstances {
+			groupOf[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.AutoScalingGroupName)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the auto scaling group of the instances %v. %s", instanceIDs, err)
+	}
+
+	names := []string{}
+	for _, name := range groupOf {
+		if _, ok := groups[name]; !ok {
+			groups[name] = nil
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return groupOf, groups, nil
+	}
+
+	err = asgSvc.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice(names),
+	}, func(page *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		for _, group := range page.AutoScalingGroups {
+			groups[aws.StringValue(group.AutoScalingGroupName)] = group
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the auto scaling groups %v. %s", names, err)
+	}
+	for name, group := range groups {
+		if group == nil {
+			delete(groups, name)
+		}
+	}
+
+	return groupOf, groups, nil
+}
+
+// ReplaceAWSInstance terminates the given instance of an auto scaling group
+// without decrement its desired capacity, so the group launches a new instance
+// to replace it. It waits until the instance is terminated and the group has
+// all its instances in service again
+func ReplaceAWSInstance(sess *session.Session, instanceID string) error {
+	group, err := AWSAutoScalingGroupOf(sess, []string{instanceID})
+	if err != nil {
+		return err
+	}
+	groupName := aws.StringValue(group.AutoScalingGroupName)
+
+	asgSvc := autoscaling.New(sess)
+	if _, err := asgSvc.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(false),
+	}); err != nil {
+		return fmt.Errorf("failed to terminate the instance %s. %s", instanceID, err)
+	}
+
+	ec2Svc := ec2.New(sess)
+	if err := ec2Svc.WaitUntilInstanceTerminated(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice([]string{instanceID})}); err != nil {
+		return fmt.Errorf("failed waiting for the instance %s to be terminated. %s", instanceID, err)
+	}
+
+	return asgSvc.WaitUntilGroupInService(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: aws.StringSlice([]string{groupName}),
+	})
+}
+
+// RemoveAWSInstances terminates the given instances of auto scaling groups
+// decrementing the desired capacity of their groups, so the groups do not
+// launch new instances to replace them. It waits until the instances are
+// terminated
+func RemoveAWSInstances(sess *session.Session, instanceIDs []string) error {
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	asgSvc := autoscaling.New(sess)
+	for _, id := range instanceIDs {
+		if _, err := asgSvc.TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+			InstanceId:                     aws.String(id),
+			ShouldDecrementDesiredCapacity: aws.Bool(true),
+		}); err != nil {
+			return fmt.Errorf("failed to terminate the instance %s. %s", id, err)
+		}
+	}
+
+	ec2Svc := ec2.New(sess)
+	if err := ec2Svc.WaitUntilInstanceTerminated(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(instanceIDs)}); err != nil {
+		return fmt.Errorf("failed waiting for the instances %v to be terminated. %s", instanceIDs, err)
+	}
+
+	return nil
+}
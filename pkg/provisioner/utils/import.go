@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceName returns the given name as it's used in the Terraform templates
+// to name the resources, in lower case and with dashes
+func ResourceName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+// AddImportTargets adds to the targets the address of every instance of the
+// given resource mapped to the ID to import, in the same order of the IDs. The
+// resource is expected to be created with `count` in the Terraform templates
+func AddImportTargets(targets map[string]string, resourceType, resourceName string, ids []string) {
+	for i, id := range ids {
+		address := fmt.Sprintf("%s.%s[%d]", resourceType, resourceName, i)
+		targets[address] = id
+	}
+}
@@ -0,0 +1,308 @@
+package vra
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Resource types of the resources created by a vRA request
+const (
+	machineResourceType    = "Infrastructure.Virtual"
+	deploymentResourceType = "composition.resource.type.deployment"
+)
+
+// States of a vRA request
+const (
+	requestSuccessful = "SUCCESSFUL"
+	requestFailed     = "FAILED"
+	requestProvFailed = "PROVIDER_FAILED"
+	requestRejected   = "REJECTED"
+)
+
+// Client is a client of the vRealize Automation catalog REST API. It logins
+// with the identity service the first time a request is sent, and again when
+// the token expires
+type Client struct {
+	url      string
+	username string
+	password string
+	tenant   string
+	http     *http.Client
+	token    string
+}
+
+// Request is a vRA catalog request, to provision a catalog item or to execute
+// an action on a resource
+type Request struct {
+	ID                string `json:"id"`
+	State             string `json:"state"`
+	Phase             string `json:"phase"`
+	RequestCompletion *struct {
+		CompletionDetails string `json:"completionDetails"`
+	} `json:"requestCompletion"`
+}
+
+// Resource is a resource created by a vRA request, like a deployment or a
+// virtual machine
+type Resource struct {
+	ID   string                 `json:"resourceId"`
+	Name string                 `json:"name"`
+	Type string                 `json:"resourceType"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// NewClient creates a client of the vRA API in the given URL, i.e.
+// https://vra.example.com
+func NewClient(apiURL, username, password, tenant string, insecure bool) (*Client, error) {
+	if _, err := url.Parse(apiURL); err != nil {
+		return nil, fmt.Errorf("invalid vRA URL %q. %s", apiURL, err)
+	}
+
+	httpClient := &http.Client{
+		Timeout: 2 * time.Minute,
+	}
+	if insecure {
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	return &Client{
+		url:      strings.TrimSuffix(apiURL, "/"),
+		username: username,
+		password: password,
+		tenant:   tenant,
+		http:     httpClient,
+	}, nil
+}
+
+// login gets a token from the vRA identity service
+func (c *Client) login() error {
+	credentials := map[string]string{
+		"username": c.username,
+		"password": c.password,
+		"tenant":   c.tenant,
+	}
+	token := struct {
+		ID string `json:"id"`
+	}{}
+
+	c.token = ""
+	if _, err := c.send("POST", "/identity/api/tokens", credentials, &token); err != nil {
+		return fmt.Errorf("failed to login to vRA %s as %q. %s", c.url, c.username, err)
+	}
+	if len(token.ID) == 0 {
+		return fmt.Errorf("failed to login to vRA %s as %q, no token received", c.url, c.username)
+	}
+	c.token = token.ID
+
+	return nil
+}
+
+// do sends a request to the vRA API, login if there is no token or the token
+// expired. It returns the response headers
+func (c *Client) do(method, uri string, in, out interface{}) (http.Header, error) {
+	if len(c.token) == 0 {
+		if err := c.login(); err != nil {
+			return nil, err
+		}
+	}
+
+	header, err := c.send(method, uri, in, out)
+	if err != errUnauthorized {
+		return header, err
+	}
+	if err := c.login(); err != nil {
+		return nil, err
+	}
+	return c.send(method, uri, in, out)
+}
+
+var errUnauthorized = fmt.Errorf("unauthorized")
+
+func (c *Client) send(method, uri string, in, out interface{}) (http.Header, error) {
+	body := bytes.NewReader(nil)
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.url+uri, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(c.token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send the request %s %s. %s", method, uri, err)
+	}
+	defer resp.Body.Close()
+
+	output, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && len(c.token) != 0 {
+		return nil, errUnauthorized
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("the request %s %s failed. %s: %s", method, uri, resp.Status, strings.TrimSpace(string(output)))
+	}
+
+	if out == nil || len(bytes.TrimSpace(output)) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(output, out); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of %s %s. %s", method, uri, err)
+	}
+
+	return resp.Header, nil
+}
+
+// CatalogItemID returns the ID of the entitled catalog item with the given name
+func (c *Client) CatalogItemID(name string) (string, error) {
+	items := struct {
+		Content []struct {
+			ID   string `json:"catalogItemId"`
+			Name string `json:"name"`
+		} `json:"content"`
+	}{}
+
+	filter := url.Values{}
+	filter.Set("$filter", fmt.Sprintf("name eq '%s'", name))
+	if _, err := c.do("GET", "/catalog-service/api/consumer/entitledCatalogItemViews?"+filter.Encode(), nil, &items); err != nil {
+		return "", err
+	}
+
+	for _, item := range items.Content {
+		if item.Name == name {
+			return item.ID, nil
+		}
+	}
+	return "", fmt.Errorf("not found the catalog item %q or the user %q is not entitled to request it", name, c.username)
+}
+
+// RequestTemplate returns the template of the request to provision the given
+// catalog item
+func (c *Client) RequestTemplate(itemID string) (map[string]interface{}, error) {
+	template := map[string]interface{}{}
+	_, err := c.do("GET", "/catalog-service/api/consumer/entitledCatalogItems/"+itemID+"/requests/template", nil, &template)
+	return template, err
+}
+
+// SubmitRequest submits a request to provision the given catalog item and
+// returns the request ID
+func (c *Client) SubmitRequest(itemID string, template map[string]interface{}) (string, error) {
+	req := Request{}
+	if _, err := c.do("POST", "/catalog-service/api/consumer/entitledCatalogItems/"+itemID+"/requests", template, &req); err != nil {
+		return "", err
+	}
+	if len(req.ID) == 0 {
+		return "", fmt.Errorf("the request to provision the catalog item %s was not accepted", itemID)
+	}
+	return req.ID, nil
+}
+
+// Request returns the request with the given ID
+func (c *Client) Request(id string) (*Request, error) {
+	req := &Request{}
+	_, err := c.do("GET", "/catalog-service/api/consumer/requests/"+id, nil, req)
+	return req, err
+}
+
+// RequestResources returns the resources created by the given request
+func (c *Client) RequestResources(id string) ([]Resource, error) {
+	resources := struct {
+		Content []Resource `json:"content"`
+	}{}
+	_, err := c.do("GET", "/catalog-service/api/consumer/requests/"+id+"/resourceViews", nil, &resources)
+	return resources.Content, err
+}
+
+// DestroyResource requests the execution of the Destroy action on the given
+// resource, usually a deployment, and returns the request ID
+func (c *Client) DestroyResource(resourceID string) (string, error) {
+	actions := struct {
+		Content []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"content"`
+	}{}
+	actionsURI := "/catalog-service/api/consumer/resources/" + resourceID + "/actions"
+	if _, err := c.do("GET", actionsURI, nil, &actions); err != nil {
+		return "", err
+	}
+
+	var actionID string
+	for _, action := range actions.Content {
+		if action.Name == "Destroy" {
+			actionID = action.ID
+			break
+		}
+	}
+	if len(actionID) == 0 {
+		return "", fmt.Errorf("the resource %s cannot be destroyed or the user %q is not entitled to destroy it", resourceID, c.username)
+	}
+
+	template := map[string]interface{}{}
+	if _, err := c.do("GET", actionsURI+"/"+actionID+"/requests/template", nil, &template); err != nil {
+		return "", err
+	}
+	header, err := c.do("POST", actionsURI+"/"+actionID+"/requests", template, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// the ID of the request is at the end of the location of the new request
+	location := header.Get("Location")
+	if len(location) == 0 {
+		return "", fmt.Errorf("the request to destroy the resource %s was not accepted", resourceID)
+	}
+	return path.Base(location), nil
+}
+
+// WaitRequest waits until the request is done, it fails if the request fails
+// or the timeout is reached
+func (c *Client) WaitRequest(id string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		req, err := c.Request(id)
+		if err != nil {
+			return err
+		}
+
+		switch req.State {
+		case requestSuccessful:
+			return nil
+		case requestFailed, requestProvFailed, requestRejected:
+			details := ""
+			if req.RequestCompletion != nil {
+				details = req.RequestCompletion.CompletionDetails
+			}
+			return fmt.Errorf("the vRA request %s finished with state %s. %s", id, req.State, details)
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for the vRA request %s, the last state was %s", id, req.State)
+		}
+		time.Sleep(requestPollInterval)
+	}
+}
@@ -13,6 +13,9 @@ var defaultConfig = Config{
 	PrivateKeyFile:    requiredValue + "/home/username/.ssh/id_rsa",
 	PublicKeyFile:     requiredValue + "/home/username/.ssh/id_rsa.pub",
 	APIAddress:        requiredValue + "39.80.0.50",
+	VRAURL:            "",
+	VRATenant:         "vsphere.local",
+	RequestTimeout:    "60m",
 	KubeAPISSLPort:    6443,
 	KubeVIPAPISSLPort: 8443,
 	KubeVirtualIPApi:  "",
@@ -91,6 +94,12 @@ const requiredValue = "# Required value. Example: "
 type Config struct {
 	clusterName            string
 	APIAddress             string              `json:"api_address" yaml:"api_address" mapstructure:"api_address"`
+	VRAURL                 string              `json:"vra_url" yaml:"vra_url" mapstructure:"vra_url"`
+	VRAUsername            string              `json:"vra_username,omitempty" yaml:"vra_username,omitempty" mapstructure:"vra_username"`
+	VRAPassword            string              `json:"-" yaml:"-" mapstructure:"-"`
+	VRATenant              string              `json:"vra_tenant" yaml:"vra_tenant" mapstructure:"vra_tenant"`
+	VRAInsecure            bool                `json:"vra_insecure,omitempty" yaml:"vra_insecure,omitempty" mapstructure:"vra_insecure"`
+	RequestTimeout         string              `json:"request_timeout" yaml:"request_timeout" mapstructure:"request_timeout"`
 	KubeAPISSLPort         int                 `json:"kube_api_ssl_port" yaml:"kube_api_ssl_port" mapstructure:"kube_api_ssl_port"`
 	DisableMasterHA        bool                `json:"disable_master_ha" yaml:"disable_master_ha" mapstructure:"disable_master_ha"`
 	KubeVirtualIPShortname string              `json:"kube_virtual_ip_shortname" yaml:"kube_virtual_ip_shortname" mapstructure:"kube_virtual_ip_shortname"`
@@ -113,6 +122,8 @@ type Config struct {
 type NodePool struct {
 	Name              string   `json:"-" yaml:"-" mapstructure:"name"`
 	Count             int      `json:"count" yaml:"count" mapstructure:"count"`
+	Blueprint         string   `json:"blueprint,omitempty" yaml:"blueprint,omitempty" mapstructure:"blueprint"`
+	Component         string   `json:"component,omitempty" yaml:"component,omitempty" mapstructure:"component"`
 	KubeletNodeLabels []string `json:"kubelet_node_labels,omitempty" yaml:"kubelet_node_labels,omitempty" mapstructure:"kubelet_node_labels"`
 	KubeletNodeTaints []string `json:"kubelet_node_taints,omitempty" yaml:"kubelet_node_taints,omitempty" mapstructure:"kubelet_node_taints"`
 	Nodes             []Node   `json:"address_pool" yaml:"address_pool" mapstructure:"address_pool"`
@@ -0,0 +1,307 @@
+package vra
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultRequestTimeout = 60 * time.Minute
+
+// requestPollInterval is the time to wait between checks of a vRA request state
+var requestPollInterval = 30 * time.Second
+
+// deployment is the vRA deployment of a node pool, created by a request of the
+// node pool blueprint
+type deployment struct {
+	RequestID  string `json:"request_id"`
+	ResourceID string `json:"resource_id,omitempty"`
+	Nodes      []Node `json:"nodes,omitempty"`
+}
+
+// deploymentsState is the state of the cluster on vRA, it's persisted in the
+// cluster state directory to destroy the deployments later
+type deploymentsState struct {
+	Deployments map[string]*deployment `json:"deployments"`
+}
+
+// managed returns true if the VMs are requested to vRA, otherwise the nodes
+// are the ones listed in the address pools
+func (c *Config) managed() bool {
+	return len(c.VRAURL) != 0
+}
+
+// poolNames returns the sorted list of node pool names
+func (c *Config) poolNames() []string {
+	names := make([]string, 0, len(c.NodePools))
+	for name := range c.NodePools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *Config) requestTimeout() (time.Duration, error) {
+	if len(c.RequestTimeout) == 0 {
+		return defaultRequestTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.RequestTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid request timeout %q. %s", c.RequestTimeout, err)
+	}
+	return timeout, nil
+}
+
+// loadState reads the deployments state from the state file, if it exists
+func (p *Platform) loadState() error {
+	p.state = &deploymentsState{
+		Deployments: map[string]*deployment{},
+	}
+	if len(p.stateFile) == 0 {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(p.stateFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, p.state); err != nil {
+		return fmt.Errorf("can't load the vRA state from %q. %s", p.stateFile, err)
+	}
+	if p.state.Deployments == nil {
+		p.state.Deployments = map[string]*deployment{}
+	}
+
+	return nil
+}
+
+// saveState writes the deployments state to the state file
+func (p *Platform) saveState() error {
+	if len(p.stateFile) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(p.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.stateFile, data, 0644)
+}
+
+// deploy requests the deployment of every node pool that is not deployed yet
+// and waits until all of them are done. The request is saved in the state as
+// soon as it's submitted, so a failed or interrupted deployment can be resumed
+// or destroyed. The deployments are not scaled, it fails before requesting
+// anything if the number of nodes of a deployed node pool has changed
+func (p *Platform) deploy(timeout time.Duration) error {
+	if errs := p.countChanges(); len(errs) != 0 {
+		return fmt.Errorf("cannot change the number of nodes of a vRA deployment, %s. Restore the count of the node pools or delete the cluster to deploy it again", strings.Join(errs, "; "))
+	}
+
+	for _, poolName := range p.config.poolNames() {
+		pool := p.config.NodePools[poolName]
+		if pool.Count == 0 {
+			continue
+		}
+
+		d, ok := p.state.Deployments[poolName]
+		if ok && len(d.Nodes) != 0 {
+			continue
+		}
+
+		if !ok {
+			requestID, err := p.requestDeployment(poolName, pool)
+			if err != nil {
+				return err
+			}
+			d = &deployment{RequestID: requestID}
+			p.state.Deployments[poolName] = d
+			if err := p.saveState(); err != nil {
+				return err
+			}
+			p.ui.Log.Infof("requested the deployment of the node pool %q, request %s", poolName, requestID)
+		}
+
+		if err := p.client.WaitRequest(d.RequestID, timeout); err != nil {
+			return fmt.Errorf("failed to deploy the node pool %q. %s", poolName, err)
+		}
+		if err := p.collectResources(d); err != nil {
+			return err
+		}
+		if err := p.saveState(); err != nil {
+			return err
+		}
+		p.ui.Log.Infof("deployed %d nodes for the node pool %q", len(d.Nodes), poolName)
+	}
+
+	return nil
+}
+
+// countChanges returns the node pools deployed with a number of nodes different
+// to the required by the configuration, including the removed node pools
+func (p *Platform) countChanges() []string {
+	poolNames := make([]string, 0, len(p.state.Deployments))
+	for name := range p.state.Deployments {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+
+	errs := []string{}
+	for _, poolName := range poolNames {
+		d := p.state.Deployments[poolName]
+		if len(d.Nodes) == 0 {
+			continue
+		}
+		if count := p.config.NodePools[poolName].Count; len(d.Nodes) != count {
+			errs = append(errs, fmt.Sprintf("the node pool %q has %d nodes but %d are required", poolName, len(d.Nodes), count))
+		}
+	}
+	return errs
+}
+
+// requestDeployment submits the request of the node pool blueprint with the
+// number of machines in the node pool
+func (p *Platform) requestDeployment(poolName string, pool NodePool) (string, error) {
+	blueprint := pool.Blueprint
+	if len(blueprint) == 0 {
+		blueprint = p.config.DefaultNodePool.Blueprint
+	}
+	if len(blueprint) == 0 {
+		return "", fmt.Errorf("the node pool %q does not have a blueprint to request", poolName)
+	}
+	component := pool.Component
+	if len(component) == 0 {
+		component = p.config.DefaultNodePool.Component
+	}
+
+	itemID, err := p.client.CatalogItemID(blueprint)
+	if err != nil {
+		return "", err
+	}
+	template, err := p.client.RequestTemplate(itemID)
+	if err != nil {
+		return "", err
+	}
+	if err := setMachinesCount(template, component, pool.Count); err != nil {
+		return "", fmt.Errorf("cannot request the blueprint %q for the node pool %q. %s", blueprint, poolName, err)
+	}
+	template["description"] = fmt.Sprintf("KubeKit cluster %s, node pool %s", p.config.clusterName, poolName)
+
+	return p.client.SubmitRequest(itemID, template)
+}
+
+// setMachinesCount sets the number of machines to create by the given
+// component of the request template. If the component is not set, it's set on
+// every machine component of the blueprint
+func setMachinesCount(template map[string]interface{}, component string, count int) error {
+	data, ok := template["data"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("the request template does not have data")
+	}
+
+	found := false
+	for name, value := range data {
+		if len(component) != 0 && name != component {
+			continue
+		}
+		compValue, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		compData, ok := compValue["data"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := compData["_cluster"]; !ok {
+			continue
+		}
+		compData["_cluster"] = count
+		found = true
+	}
+
+	if !found {
+		if len(component) != 0 {
+			return fmt.Errorf("not found the machine component %q", component)
+		}
+		return fmt.Errorf("not found a machine component")
+	}
+	return nil
+}
+
+// collectResources gets the deployment and the machines created by the
+// deployment request
+func (p *Platform) collectResources(d *deployment) error {
+	resources, err := p.client.RequestResources(d.RequestID)
+	if err != nil {
+		return err
+	}
+
+	d.Nodes = []Node{}
+	for _, r := range resources {
+		switch r.Type {
+		case deploymentResourceType:
+			d.ResourceID = r.ID
+		case machineResourceType:
+			ip, _ := r.Data["ip_address"].(string)
+			d.Nodes = append(d.Nodes, Node{
+				PublicIP:   ip,
+				PrivateIP:  ip,
+				PublicDNS:  r.Name,
+				PrivateDNS: r.Name,
+			})
+		}
+	}
+	sort.Slice(d.Nodes, func(i, j int) bool {
+		return d.Nodes[i].PrivateDNS < d.Nodes[j].PrivateDNS
+	})
+
+	return nil
+}
+
+// destroy requests the destruction of every deployment in the state and waits
+// until all of them are done. The deployment is removed from the state when
+// it's destroyed
+func (p *Platform) destroy(timeout time.Duration) error {
+	poolNames := make([]string, 0, len(p.state.Deployments))
+	for name := range p.state.Deployments {
+		poolNames = append(poolNames, name)
+	}
+	sort.Strings(poolNames)
+
+	for _, poolName := range poolNames {
+		d := p.state.Deployments[poolName]
+
+		// the deployment may not be collected if the deploy request failed
+		if len(d.ResourceID) == 0 {
+			if err := p.collectResources(d); err != nil {
+				return err
+			}
+		}
+		if len(d.ResourceID) != 0 {
+			requestID, err := p.client.DestroyResource(d.ResourceID)
+			if err != nil {
+				return fmt.Errorf("failed to destroy the node pool %q. %s", poolName, err)
+			}
+			p.ui.Log.Infof("requested to destroy the deployment of the node pool %q, request %s", poolName, requestID)
+			if err := p.client.WaitRequest(requestID, timeout); err != nil {
+				return fmt.Errorf("failed to destroy the node pool %q. %s", poolName, err)
+			}
+		} else {
+			p.ui.Log.Warnf("the request %s of the node pool %q did not create a deployment, nothing to destroy", d.RequestID, poolName)
+		}
+
+		delete(p.state.Deployments, poolName)
+		if err := p.saveState(); err != nil {
+			return err
+		}
+		p.ui.Log.Infof("destroyed the node pool %q", poolName)
+	}
+
+	return nil
+}
@@ -1,12 +1,36 @@
 package vra
 
 import (
+	"fmt"
+
 	"github.com/kraken/terraformer"
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
 )
 
-// BeProvisioner setup the Plaftorm to be a Provisioner
+// BeProvisioner setup the Plaftorm to be a Provisioner. If the vRA URL is set,
+// it creates the client to request the VMs to vRA, otherwise the nodes are the
+// ones in the address pools
 func (p *Platform) BeProvisioner(state *terraformer.State) error {
-	p.ui.Log.Debugf("%s platform do not implements BeProvisioner()", p.name)
+	// If I'm already a provisioner, return
+	if p.client != nil {
+		return nil
+	}
+
+	if !p.config.managed() {
+		p.ui.Log.Debugf("the vRA URL is not set, the nodes of the %s platform are the ones in the address pools", p.name)
+		return nil
+	}
+
+	if len(p.config.VRAPassword) == 0 {
+		return fmt.Errorf("the vRA password is not set, login to the cluster with the vRA credentials or set the environment variable VRA_PASSWORD")
+	}
+
+	client, err := NewClient(p.config.VRAURL, p.config.VRAUsername, p.config.VRAPassword, p.config.VRATenant, p.config.VRAInsecure)
+	if err != nil {
+		return err
+	}
+	p.client = client
+
 	return nil
 }
 
@@ -16,25 +40,94 @@ func (p *Platform) Plan(destroy bool) (plan *terraformer.Plan, err error) {
 	return nil, nil
 }
 
-// Apply apply the changes either to create or destroy the cluster on this platform
+// Changes returns the changes of the resources in the last plan
+func (p *Platform) Changes() ([]*terraformer.ResourceChange, error) {
+	p.ui.Log.Debugf("%s platform do not implements Changes()", p.name)
+	return nil, nil
+}
+
+// Apply apply the changes either to create or destroy the cluster on this
+// platform. To create the cluster, the blueprint of every node pool is
+// requested to vRA with the number of machines in the node pool. To destroy
+// the cluster, the deployments of the node pools are destroyed
 func (p *Platform) Apply(destroy bool) error {
-	p.ui.Log.Debugf("%s platform do not implements Apply()", p.name)
-	return nil
+	if !p.config.managed() {
+		p.ui.Log.Debugf("the vRA URL is not set, the nodes of the %s platform are not provisioned", p.name)
+		return nil
+	}
+	if p.client == nil {
+		return fmt.Errorf("cannot apply the changes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+	if p.state == nil {
+		if err := p.loadState(); err != nil {
+			return err
+		}
+	}
+
+	timeout, err := p.config.requestTimeout()
+	if err != nil {
+		return err
+	}
+
+	if destroy {
+		p.ui.Log.Debug("starting to terminate the cluster")
+		return p.destroy(timeout)
+	}
+
+	p.ui.Log.Debug("starting to provision the cluster")
+	return p.deploy(timeout)
 }
 
 // Provision provisions or creates a cluster on this platform
 func (p *Platform) Provision() error {
-	p.ui.Log.Debugf("%s platform do not implements Provision()", p.name)
-	return nil
+	return p.Apply(false)
 }
 
 // Terminate terminates or destroys a cluster on this platform
 func (p *Platform) Terminate() error {
-	p.ui.Log.Debugf("%s platform do not implements Terminate()", p.name)
+	return p.Apply(true)
+}
+
+// AddHook adds a Terraform hook to the provisioner. This platform do not use
+// Terraform, so the hook is ignored
+func (p *Platform) AddHook(hook terraformer.Hook) error {
+	p.ui.Log.Debugf("%s platform do not implements AddHook()", p.name)
 	return nil
 }
 
-// Code returns the Terraform code to execute
+// Import brings existing infrastructure under the management of KubeKit. The
+// nodes of this platform are not created by KubeKit, to use existing nodes add
+// them to the cluster configuration
+func (p *Platform) Import(ids map[string][]string) error {
+	return fmt.Errorf("the %s platform does not import nodes, add the existing nodes to the cluster configuration", p.name)
+}
+
+// Start powers on the given nodes. The nodes are not managed by KubeKit, so the
+// Kubernetes services are started by the cluster instead
+func (p *Platform) Start(nodes []*state.Node) error {
+	p.ui.Log.Debugf("%s platform do not implements Start()", p.name)
+	return nil
+}
+
+// Stop powers off the given nodes. The nodes are not managed by KubeKit, so the
+// Kubernetes services are stopped by the cluster instead
+func (p *Platform) Stop(nodes []*state.Node) error {
+	p.ui.Log.Debugf("%s platform do not implements Stop()", p.name)
+	return nil
+}
+
+// Replace is not supported on this platform yet
+func (p *Platform) Replace(node *state.Node) error {
+	return fmt.Errorf("the %s platform does not support replacing nodes", p.name)
+}
+
+// Remove is not supported on this platform yet
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform does not support removing nodes", p.name)
+}
+
+// Code returns the Terraform code to execute. This platform do not use
+// Terraform, the VMs are requested with the vRA API
 func (p *Platform) Code() []byte {
 	p.ui.Log.Debugf("%s platform do not implements Code()", p.name)
 	return []byte{}
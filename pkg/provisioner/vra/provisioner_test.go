@@ -0,0 +1,237 @@
+package vra
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVRA is a vRA catalog API that completes every request on the second
+// time it's checked, creating a deployment with the requested machines
+type fakeVRA struct {
+	mu          sync.Mutex
+	requests    map[string]*fakeRequest
+	deployments map[string]bool
+	counter     int
+}
+
+type fakeRequest struct {
+	checks    int
+	state     string
+	resources []Resource
+}
+
+func newFakeVRA() *fakeVRA {
+	return &fakeVRA{
+		requests:    map[string]*fakeRequest{},
+		deployments: map[string]bool{},
+	}
+}
+
+func (f *fakeVRA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/identity/api/tokens" {
+		json.NewEncoder(w).Encode(map[string]string{"id": "token"})
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/catalog-service/api/consumer/"), "/")
+	switch {
+	case parts[0] == "entitledCatalogItemViews":
+		name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Query().Get("$filter"), "name eq '"), "'")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"content": []map[string]string{{"catalogItemId": "item-" + name, "name": name}},
+		})
+	case parts[0] == "entitledCatalogItems" && r.Method == "GET":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"catalogItemId": parts[1],
+			"data": map[string]interface{}{
+				"vSphere_Machine_1": map[string]interface{}{
+					"data": map[string]interface{}{"_cluster": 1},
+				},
+			},
+		})
+	case parts[0] == "entitledCatalogItems" && r.Method == "POST":
+		var template map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&template)
+		count := int(template["data"].(map[string]interface{})["vSphere_Machine_1"].(map[string]interface{})["data"].(map[string]interface{})["_cluster"].(float64))
+
+		f.counter++
+		id := fmt.Sprintf("request-%d", f.counter)
+		deploymentID := fmt.Sprintf("deployment-%d", f.counter)
+		req := &fakeRequest{
+			state:     "IN_PROGRESS",
+			resources: []Resource{{ID: deploymentID, Name: parts[1], Type: deploymentResourceType}},
+		}
+		for i := 0; i < count; i++ {
+			req.resources = append(req.resources, Resource{
+				ID:   fmt.Sprintf("%s-vm-%d", deploymentID, i),
+				Name: fmt.Sprintf("%s-vm-%d", parts[1], i),
+				Type: machineResourceType,
+				Data: map[string]interface{}{"ip_address": fmt.Sprintf("10.0.%d.%d", f.counter, i)},
+			})
+		}
+		f.requests[id] = req
+		f.deployments[deploymentID] = true
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": id})
+	case parts[0] == "requests" && len(parts) == 3:
+		json.NewEncoder(w).Encode(map[string]interface{}{"content": f.requests[parts[1]].resources})
+	case parts[0] == "requests":
+		req, ok := f.requests[parts[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if req.checks++; req.checks > 1 {
+			req.state = requestSuccessful
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": parts[1], "state": req.state})
+	case parts[0] == "resources" && len(parts) == 3:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"content": []map[string]string{{"id": "action-destroy", "name": "Destroy"}},
+		})
+	case parts[0] == "resources" && r.Method == "GET":
+		json.NewEncoder(w).Encode(map[string]interface{}{"resourceId": parts[1]})
+	case parts[0] == "resources" && r.Method == "POST":
+		f.counter++
+		id := fmt.Sprintf("request-%d", f.counter)
+		f.requests[id] = &fakeRequest{state: requestSuccessful}
+		delete(f.deployments, parts[1])
+		w.Header().Set("Location", "/catalog-service/api/consumer/requests/"+id)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testPlatform(t *testing.T, url, stateDir string) *Platform {
+	config := defaultConfig
+	config.clusterName = "testCluster"
+	config.APIAddress = ""
+	config.VRAURL = url
+	config.DefaultNodePool = NodePool{Blueprint: "CentOS"}
+	config.NodePools = map[string]NodePool{
+		"master": {Count: 1},
+		"worker": {Count: 2, Blueprint: "CentOS-Large"},
+	}
+
+	p := newPlatform(&config, []string{"", "user", "secret"}, tUI, version)
+	if err := p.BeProvisioner(nil); err != nil {
+		t.Fatalf("BeProvisioner() error = %v", err)
+	}
+	if err := p.PersistStateToFile(filepath.Join(stateDir, "vra.tfstate")); err != nil {
+		t.Fatalf("PersistStateToFile() error = %v", err)
+	}
+	return p
+}
+
+func TestPlatform_ProvisionTerminate(t *testing.T) {
+	defer func(d time.Duration) { requestPollInterval = d }(requestPollInterval)
+	requestPollInterval = time.Millisecond
+
+	fake := newFakeVRA()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	stateDir, err := ioutil.TempDir("", "vra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(stateDir)
+
+	p := testPlatform(t, server.URL, stateDir)
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+
+	nodes := p.Nodes()
+	if len(nodes) != 3 {
+		t.Fatalf("Nodes() returned %d nodes, want 3", len(nodes))
+	}
+	pools := map[string]int{}
+	for _, n := range nodes {
+		pools[n.RoleName]++
+		if len(n.PublicIP) == 0 || len(n.PrivateDNS) == 0 {
+			t.Errorf("Nodes() node without IP or DNS: %+v", n)
+		}
+	}
+	if pools["master"] != 1 || pools["worker"] != 2 {
+		t.Errorf("Nodes() nodes per pool = %v, want 1 master and 2 workers", pools)
+	}
+	if got := p.Address(); got != "10.0.1.0" {
+		t.Errorf("Address() = %q, want %q", got, "10.0.1.0")
+	}
+	if len(fake.deployments) != 2 {
+		t.Errorf("Provision() created %d deployments, want 2", len(fake.deployments))
+	}
+
+	// provision again does not request the deployments again
+	if err := p.Provision(); err != nil {
+		t.Fatalf("Provision() again error = %v", err)
+	}
+	if len(fake.deployments) != 2 {
+		t.Errorf("Provision() again created %d deployments, want 2", len(fake.deployments))
+	}
+
+	// the deployments are not scaled
+	p.config.NodePools["worker"] = NodePool{Count: 3, Blueprint: "CentOS-Large"}
+	if err := p.Provision(); err == nil {
+		t.Errorf("Provision() expected an error changing the number of nodes")
+	}
+	if len(fake.deployments) != 2 {
+		t.Errorf("Provision() changing the number of nodes created %d deployments, want 2", len(fake.deployments))
+	}
+
+	// a new platform loads the deployments from the state file to terminate them
+	p = testPlatform(t, server.URL, stateDir)
+	if got := len(p.Nodes()); got != 3 {
+		t.Errorf("Nodes() from the state file returned %d nodes, want 3", got)
+	}
+	if err := p.Terminate(); err != nil {
+		t.Fatalf("Terminate() error = %v", err)
+	}
+	if len(fake.deployments) != 0 {
+		t.Errorf("Terminate() left %d deployments", len(fake.deployments))
+	}
+	if got := len(p.Nodes()); got != 0 {
+		t.Errorf("Nodes() after Terminate() returned %d nodes, want 0", got)
+	}
+}
+
+func Test_setMachinesCount(t *testing.T) {
+	template := map[string]interface{}{
+		"data": map[string]interface{}{
+			"_leaseDays": nil,
+			"vSphere_Machine_1": map[string]interface{}{
+				"data": map[string]interface{}{"_cluster": 1.0},
+			},
+		},
+	}
+
+	if err := setMachinesCount(template, "", 3); err != nil {
+		t.Fatalf("setMachinesCount() error = %v", err)
+	}
+	got := template["data"].(map[string]interface{})["vSphere_Machine_1"].(map[string]interface{})["data"].(map[string]interface{})["_cluster"]
+	if got != 3 {
+		t.Errorf("setMachinesCount() _cluster = %v, want 3", got)
+	}
+
+	if err := setMachinesCount(template, "Other_Machine", 3); err == nil {
+		t.Errorf("setMachinesCount() expected an error with an unknown component")
+	}
+}
@@ -14,10 +14,16 @@ func (p *Platform) State() *terraformer.State {
 }
 
 // PersistStateToFile makes the state to persist in a file and be up to date all
-// the time. Every time the state changes Terraformer will update the file
+// the time. This platform do not have a Terraform state, the vRA deployments
+// are saved in a JSON file with the same name and the extension `.json`, in
+// the same directory, every time they change
 func (p *Platform) PersistStateToFile(filename string) error {
-	p.ui.Log.Debugf("%s platform do not implements PersistStateToFile()", p.name)
-	return nil
+	if !p.config.managed() {
+		p.ui.Log.Debugf("the vRA URL is not set, the %s platform do not have a state", p.name)
+		return nil
+	}
+	p.stateFile = state.PlatformStateFile(filename)
+	return p.loadState()
 }
 
 // LoadState loads the given Terraform state in a buffer into the terraformer state
@@ -34,7 +40,12 @@ func (p *Platform) Address() string {
 	if p.config.APIAddress != "" {
 		return p.config.APIAddress
 	}
-	return p.config.NodePools["master"].Nodes[0].PublicIP
+	for _, node := range p.Nodes() {
+		if node.RoleName == "master" {
+			return node.PublicIP
+		}
+	}
+	return ""
 }
 
 // Port returns the port to access the Kubernetes cluster
@@ -52,11 +63,19 @@ func (p *Platform) Output(name string) string {
 }
 
 // Nodes return the list of nodes provisioned. It took the value from the
+// address pools, or from the vRA deployments if the VMs are requested to vRA
 func (p *Platform) Nodes() []*state.Node {
 
 	stateNodes := make([]*state.Node, 0)
 	for roleName, nodePool := range p.config.NodePools {
-		for _, node := range nodePool.Nodes {
+		nodes := nodePool.Nodes
+		if p.config.managed() {
+			nodes = nil
+			if p.state != nil && p.state.Deployments[roleName] != nil {
+				nodes = p.state.Deployments[roleName].Nodes
+			}
+		}
+		for _, node := range nodes {
 			stateNode := &state.Node{
 				PublicIP:   node.PublicIP,
 				PrivateIP:  node.PrivateIP,
@@ -4,12 +4,15 @@ import (
 	"github.com/kraken/ui"
 )
 
-// Platform implements the Provisioner interface for vSphere
+// Platform implements the Provisioner interface for vRA
 type Platform struct {
-	name    string
-	config  *Config
-	ui      *ui.UI
-	version string
+	name      string
+	config    *Config
+	ui        *ui.UI
+	version   string
+	client    *Client
+	state     *deploymentsState
+	stateFile string
 }
 
 // New creates a new Plaform with the given environment configuration
@@ -29,24 +32,25 @@ func New(clusterName string, envConfig map[string]string, ui *ui.UI, version str
 	}, nil
 }
 
-// CreateFrom creates a new Plaftorm with the given configuration for vSphere
+// CreateFrom creates a new Plaftorm with the given configuration for vRA
 func CreateFrom(clusterName string, config map[interface{}]interface{}, credentials []string, ui *ui.UI, version string) *Platform {
 	if config == nil {
-		return newPlatform(&defaultConfig, ui, version)
+		return newPlatform(&defaultConfig, credentials, ui, version)
 	}
 	c := NewConfigFrom(config)
 	c.clusterName = clusterName
 
-	return newPlatform(c, ui, version)
+	return newPlatform(c, credentials, ui, version)
 }
 
-func newPlatform(c *Config, ui *ui.UI, version string) *Platform {
+func newPlatform(c *Config, credentials []string, ui *ui.UI, version string) *Platform {
 	p := &Platform{
 		name:    "vra",
 		config:  c,
 		ui:      ui,
 		version: version,
 	}
+	p.Credentials(credentials...)
 
 	return p
 }
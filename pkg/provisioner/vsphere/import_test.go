@@ -0,0 +1,64 @@
+package vsphere
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlatform_importTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     map[string][]string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			"master and worker",
+			map[string][]string{
+				"master": {"/dc1/vm/kube/master-01"},
+				"worker": {"/dc1/vm/kube/worker-01", "/dc1/vm/kube/worker-02"},
+			},
+			map[string]string{
+				"vsphere_virtual_machine.dumb-master[0]": "/dc1/vm/kube/master-01",
+				"vsphere_virtual_machine.dumb-worker[0]": "/dc1/vm/kube/worker-01",
+				"vsphere_virtual_machine.dumb-worker[1]": "/dc1/vm/kube/worker-02",
+			},
+			false,
+		},
+		{
+			"unknown pool",
+			map[string][]string{"storage": {"/dc1/vm/kube/storage-01"}},
+			nil,
+			true,
+		},
+		{
+			"count mismatch",
+			map[string][]string{"master": {"/dc1/vm/kube/master-01", "/dc1/vm/kube/master-02"}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig.copyWithDefaults()
+			cfg.ClusterName = "testCluster"
+			worker := cfg.NodePools["worker"]
+			worker.Count = 2
+			cfg.NodePools["worker"] = worker
+			p := &Platform{
+				name:    "vsphere",
+				config:  &cfg,
+				ui:      tUI,
+				version: version,
+			}
+
+			got, err := p.importTargets(tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("importTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importTargets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
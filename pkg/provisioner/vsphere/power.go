@@ -0,0 +1,118 @@
+package vsphere
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Start powers on the given nodes, or all the cluster nodes if none is given
+func (p *Platform) Start(nodes []*state.Node) error {
+	return p.power(nodes, true)
+}
+
+// Stop shutdowns the guest OS of the given nodes, or all the cluster nodes if
+// none is given. If the guest cannot be shutdown, the VM is powered off
+func (p *Platform) Stop(nodes []*state.Node) error {
+	return p.power(nodes, false)
+}
+
+func (p *Platform) power(nodes []*state.Node, on bool) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot change the power state of the nodes, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	ctx := context.Background()
+	client, err := p.vSphereClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Logout(ctx)
+
+	uuids := state.ResourceInstancesIDFor(p.t.State, "vsphere_virtual_machine", nodes, "default_ip_address")
+	searchIndex := object.NewSearchIndex(client.Client)
+
+	for _, uuid := range uuids {
+		ref, err := searchIndex.FindByUuid(ctx, nil, uuid, true, nil)
+		if err != nil {
+			return fmt.Errorf("failed to find the VM with UUID %s. %s", uuid, err)
+		}
+		vm, ok := ref.(*object.VirtualMachine)
+		if !ok {
+			return fmt.Errorf("not found the VM with UUID %s", uuid)
+		}
+
+		if on {
+			p.ui.Log.Debugf("powering on VM %s", uuid)
+			if err := powerOn(ctx, vm); err != nil {
+				return fmt.Errorf("failed to power on the VM with UUID %s. %s", uuid, err)
+			}
+			continue
+		}
+
+		p.ui.Log.Debugf("powering off VM %s", uuid)
+		if err := powerOff(ctx, vm); err != nil {
+			return fmt.Errorf("failed to power off the VM with UUID %s. %s", uuid, err)
+		}
+	}
+
+	return nil
+}
+
+func powerOn(ctx context.Context, vm *object.VirtualMachine) error {
+	powerState, err := vm.PowerState(ctx)
+	if err != nil {
+		return err
+	}
+	if powerState == types.VirtualMachinePowerStatePoweredOn {
+		return nil
+	}
+
+	task, err := vm.PowerOn(ctx)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+func powerOff(ctx context.Context, vm *object.VirtualMachine) error {
+	powerState, err := vm.PowerState(ctx)
+	if err != nil {
+		return err
+	}
+	if powerState == types.VirtualMachinePowerStatePoweredOff {
+		return nil
+	}
+
+	// Shutdown the guest OS requires the VMware tools, if fail power off the VM
+	if err := vm.ShutdownGuest(ctx); err == nil {
+		return vm.WaitForPowerState(ctx, types.VirtualMachinePowerStatePoweredOff)
+	}
+
+	task, err := vm.PowerOff(ctx)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+func (p *Platform) vSphereClient(ctx context.Context) (*govmomi.Client, error) {
+	u, err := url.Parse(fmt.Sprintf("https://%s/sdk", p.config.VsphereServer))
+	if err != nil {
+		return nil, fmt.Errorf("invalid vSphere server %q. %s", p.config.VsphereServer, err)
+	}
+	u.User = url.UserPassword(p.config.VsphereUsername, p.config.VspherePassword)
+
+	// same as the Terraform provider, allow unverified SSL
+	client, err := govmomi.NewClient(ctx, u, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to vSphere server %s. %s", p.config.VsphereServer, err)
+	}
+
+	return client, nil
+}
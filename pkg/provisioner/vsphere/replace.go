@@ -0,0 +1,33 @@
+package vsphere
+
+import (
+	"fmt"
+
+	"github.com/liferaft/kubekit/pkg/provisioner/state"
+)
+
+// Replace destroys the VM of the given node and creates it again. The VM is
+// tainted and the changes are applied only to that VM, the rest of the
+// infrastructure is not modified
+func (p *Platform) Replace(node *state.Node) error {
+	if p.t == nil {
+		return fmt.Errorf("cannot replace the node, the %s plaftorm is not a provisioner yet", p.name)
+	}
+
+	addresses := state.ResourceInstancesAddressFor(p.t.State, "vsphere_virtual_machine", []*state.Node{node}, "default_ip_address")
+	if len(addresses) == 0 {
+		return fmt.Errorf("not found the VM of node %s in the %s state", node.PublicIP, p.name)
+	}
+	if err := p.t.Taint(addresses...); err != nil {
+		return err
+	}
+
+	p.ui.Log.Debugf("replacing the VM %v", addresses)
+	return p.t.ApplyTargets(false, addresses...)
+}
+
+// Remove is not required on this platform, the nodes are destroyed when the
+// count of their node pool is decremented and the changes are applied
+func (p *Platform) Remove(nodes []*state.Node) error {
+	return fmt.Errorf("the %s platform removes the nodes decrementing the count of their node pool", p.name)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry is the record of a call modifying a cluster, allowed or not
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user"`
+	Groups   []string  `json:"groups,omitempty"`
+	Auth     string    `json:"auth,omitempty"`
+	Method   string    `json:"method"`
+	Verb     string    `json:"verb"`
+	Clusters []string  `json:"clusters,omitempty"`
+	Allowed  bool      `json:"allowed"`
+	Code     string    `json:"code"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// AuditLog writes every AuditEntry as a JSON line
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAuditLog creates an AuditLog appending the entries to the given file. If
+// the filename is "-" the entries are printed to the standard output
+func NewAuditLog(filename string) (*AuditLog, error) {
+	if filename == "-" {
+		return &AuditLog{w: os.Stdout}, nil
+	}
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log file %s. %s", filename, err)
+	}
+	return &AuditLog{w: f}, nil
+}
+
+// Log writes the entry to the audit log
+func (l *AuditLog) Log(entry AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(b)
+	return err
+}
+
+// Close closes the audit log file
+func (l *AuditLog) Close() error {
+	if c, ok := l.w.(io.Closer); ok && l.w != os.Stdout {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Authentication methods of an Identity
+const (
+	AuthMethodCertificate = "x509"
+	AuthMethodOIDC        = "oidc"
+	AuthMethodToken       = "token"
+)
+
+// Metadata keys used by the HTTP/REST gateway to forward to the gRPC server the
+// identity of a client authenticated with a certificate
+const (
+	mdGatewaySecret = "x-kubekit-gateway-secret"
+	mdGatewayUser   = "x-kubekit-gateway-user"
+	mdGatewayGroups = "x-kubekit-gateway-groups"
+)
+
+// Identity is the authenticated caller of the API
+type Identity struct {
+	User   string
+	Groups []string
+	Method string
+}
+
+func (id *Identity) String() string {
+	if id == nil {
+		return "anonymous"
+	}
+	return fmt.Sprintf("%s (%s)", id.User, id.Method)
+}
+
+// Authenticator identifies the caller of a gRPC call. It returns a nil Identity
+// and no error when the call does not have the credentials it knows about, so
+// the next authenticator is used
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*Identity, error)
+}
+
+// bearerToken returns the token from the authorization metadata. The
+// HTTP/REST gateway forwards the Authorization header with this key
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if len(value) > 7 && strings.EqualFold(value[:7], "bearer ") {
+			return strings.TrimSpace(value[7:])
+		}
+	}
+	return ""
+}
+
+// CertificateAuthenticator identifies the clients by the subject of their
+// verified certificate. The user is the Common Name and the groups are the
+// Organizations
+type CertificateAuthenticator struct{}
+
+// Authenticate implements the Authenticator interface
+func (a *CertificateAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil, nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	return certificateIdentity(tlsInfo.State.VerifiedChains[0][0]), nil
+}
+
+func certificateIdentity(cert *x509.Certificate) *Identity {
+	if len(cert.Subject.CommonName) == 0 {
+		return nil
+	}
+	return &Identity{
+		User:   cert.Subject.CommonName,
+		Groups: cert.Subject.Organization,
+		Method: AuthMethodCertificate,
+	}
+}
+
+// TokenAuthenticator identifies the clients by a static bearer token
+type TokenAuthenticator struct {
+	tokens map[string]*Identity
+}
+
+// NewTokenAuthenticator creates a TokenAuthenticator with the tokens in the
+// given CSV file. Every line has the format `token,user,"group1,group2"`, the
+// groups column is optional
+func NewTokenAuthenticator(filename string) (*TokenAuthenticator, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the tokens file %s. %s", filename, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	a := &TokenAuthenticator{
+		tokens: make(map[string]*Identity),
+	}
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the tokens file %s. %s", filename, err)
+		}
+		if len(record) < 2 || len(record[0]) == 0 || len(record[1]) == 0 {
+			return nil, fmt.Errorf("the line %d of the tokens file %s requires at least a token and a user", line, filename)
+		}
+		id := &Identity{
+			User:   record[1],
+			Method: AuthMethodToken,
+		}
+		if len(record) > 2 && len(record[2]) != 0 {
+			for _, group := range strings.Split(record[2], ",") {
+				id.Groups = append(id.Groups, strings.TrimSpace(group))
+			}
+		}
+		a.tokens[record[0]] = id
+	}
+
+	return a, nil
+}
+
+// Authenticate implements the Authenticator interface
+func (a *TokenAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	token := bearerToken(ctx)
+	if len(token) == 0 {
+		return nil, nil
+	}
+	for t, id := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return id, nil
+		}
+	}
+	// The token may be a JWT for the OIDC authenticator
+	return nil, nil
+}
+
+// gatewayAuthenticator trusts the identity forwarded by the HTTP/REST gateway
+// of this server, it is the only one knowing the secret
+type gatewayAuthenticator struct {
+	secret string
+}
+
+// Authenticate implements the Authenticator interface
+func (a *gatewayAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	secret := md.Get(mdGatewaySecret)
+	if len(secret) == 0 {
+		return nil, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(secret[0]), []byte(a.secret)) != 1 {
+		return nil, fmt.Errorf("invalid gateway credentials")
+	}
+	user := md.Get(mdGatewayUser)
+	if len(user) == 0 || len(user[0]) == 0 {
+		return nil, nil
+	}
+	return &Identity{
+		User:   user[0],
+		Groups: md.Get(mdGatewayGroups),
+		Method: AuthMethodCertificate,
+	}, nil
+}
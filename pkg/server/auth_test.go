@@ -0,0 +1,287 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testPolicy = `
+rules:
+- groups: ["admins"]
+  verbs: ["*"]
+  clusters: ["*"]
+- users: ["alice"]
+  groups: ["team-a"]
+  verbs: ["get", "apply"]
+  clusters: ["team-a-*"]
+- users: ["*"]
+  verbs: ["get"]
+  clusters: ["shared"]
+`
+
+type testRequest struct {
+	clusterName string
+}
+
+func (r *testRequest) GetClusterName() string { return r.clusterName }
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write %s. %s", filename, err)
+	}
+	return filename
+}
+
+func TestPolicy_Allowed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p, err := LoadPolicy(writeTestFile(t, dir, "policy.yaml", testPolicy))
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %s", err)
+	}
+
+	alice := &Identity{User: "alice"}
+	bob := &Identity{User: "bob", Groups: []string{"team-a"}}
+	root := &Identity{User: "root", Groups: []string{"admins"}}
+
+	tests := []struct {
+		name     string
+		id       *Identity
+		verb     string
+		clusters []string
+		want     bool
+	}{
+		{"user allowed by name", alice, VerbApply, []string{"team-a-dev"}, true},
+		{"user allowed by group", bob, VerbGet, []string{"team-a-prod"}, true},
+		{"verb not allowed", alice, VerbDelete, []string{"team-a-dev"}, false},
+		{"cluster not allowed", alice, VerbGet, []string{"team-b-dev"}, false},
+		{"one of the clusters not allowed", bob, VerbGet, []string{"team-a-dev", "team-b-dev"}, false},
+		{"all the clusters not allowed", alice, VerbGet, nil, false},
+		{"all the clusters allowed", root, VerbDelete, nil, true},
+		{"any user", &Identity{User: "carol"}, VerbGet, []string{"shared"}, true},
+		{"anonymous", nil, VerbGet, []string{"shared"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.Allowed(tt.id, tt.verb, tt.clusters...))
+		})
+	}
+
+	if _, err := LoadPolicy(writeTestFile(t, dir, "invalid.yaml", "rules:\n- users: [alice]\n  verbs: [get]\n")); err == nil {
+		t.Errorf("LoadPolicy() expected an error for a rule without clusters")
+	}
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a, err := NewTokenAuthenticator(writeTestFile(t, dir, "tokens.csv", "# token,user,groups\ns3cr3t,alice,\"team-a,dev\"\nt0k3n,bob\n"))
+	if err != nil {
+		t.Fatalf("NewTokenAuthenticator() error = %s", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *Identity
+	}{
+		{"with groups", "s3cr3t", &Identity{User: "alice", Groups: []string{"team-a", "dev"}, Method: AuthMethodToken}},
+		{"without groups", "t0k3n", &Identity{User: "bob", Method: AuthMethodToken}},
+		{"unknown token", "unknown", nil},
+		{"no token", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if len(tt.token) != 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+			got, err := a.Authenticate(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "oidc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "key-1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+
+	a, err := NewOIDCAuthenticator("https://issuer.example.com", "kubekit", writeTestFile(t, dir, "jwks.json", string(jwks)), "email", "")
+	if err != nil {
+		t.Fatalf("NewOIDCAuthenticator() error = %s", err)
+	}
+
+	sign := func(claims jwt.MapClaims, kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    "https://issuer.example.com",
+			"aud":    []string{"other", "kubekit"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  "alice@example.com",
+			"groups": []string{"team-a"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		claims  func(jwt.MapClaims)
+		kid     string
+		want    *Identity
+		wantErr bool
+	}{
+		{"valid", nil, "key-1", &Identity{User: "alice@example.com", Groups: []string{"team-a"}, Method: AuthMethodOIDC}, false},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, "key-1", nil, true},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, "key-1", nil, true},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "other" }, "key-1", nil, true},
+		{"unknown key", nil, "key-2", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+sign(claims, tt.kid)))
+			got, err := a.Authenticate(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// A static token is not an OIDC token, it's ignored
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer s3cr3t"))
+	got, err := a.Authenticate(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestAuthInterceptor_unary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	policy, err := LoadPolicy(writeTestFile(t, dir, "policy.yaml", testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := NewTokenAuthenticator(writeTestFile(t, dir, "tokens.csv", "s3cr3t,alice\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var audit bytes.Buffer
+
+	a := &authInterceptor{
+		authenticators: []Authenticator{tokens},
+		policy:         policy,
+		auditLog:       &AuditLog{w: &audit},
+		verbs: map[string]string{
+			"/test/Apply":    VerbApply,
+			"/test/Describe": VerbGet,
+			"/test/Version":  VerbNone,
+		},
+		enabled: true,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		method    string
+		cluster   string
+		wantCode  codes.Code
+		wantAudit bool
+	}{
+		{"allowed", "s3cr3t", "/test/Apply", "team-a-dev", codes.OK, true},
+		{"denied", "s3cr3t", "/test/Apply", "team-b-dev", codes.PermissionDenied, true},
+		{"not mutating", "s3cr3t", "/test/Describe", "team-a-dev", codes.OK, false},
+		{"authenticated only", "s3cr3t", "/test/Version", "", codes.OK, false},
+		{"without verb", "s3cr3t", "/test/Unknown", "team-a-dev", codes.PermissionDenied, false},
+		{"unauthenticated", "", "/test/Describe", "team-a-dev", codes.Unauthenticated, false},
+		{"health check", "", "/grpc.health.v1.Health/Check", "", codes.OK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit.Reset()
+			ctx := context.Background()
+			if len(tt.token) != 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+tt.token))
+			}
+			_, err := a.unary(ctx, &testRequest{clusterName: tt.cluster}, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+
+			if !tt.wantAudit {
+				assert.Empty(t, audit.String())
+				return
+			}
+			var entry AuditEntry
+			if err := json.Unmarshal(audit.Bytes(), &entry); err != nil {
+				t.Fatalf("invalid audit entry %q. %s", audit.String(), err)
+			}
+			assert.Equal(t, "alice", entry.User)
+			assert.Equal(t, tt.method, entry.Method)
+			assert.Equal(t, []string{tt.cluster}, entry.Clusters)
+			assert.Equal(t, tt.wantCode == codes.OK, entry.Allowed)
+			assert.Equal(t, tt.wantCode.String(), entry.Code)
+			assert.True(t, strings.HasSuffix(audit.String(), "\n"))
+		})
+	}
+}
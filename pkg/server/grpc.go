@@ -68,6 +68,7 @@ func (s *Server) StartGRPC(port string) *Server {
 		opts = append(opts, grpc.Creds(creds))
 		secureMsg = "secure "
 	}
+	opts = append(opts, s.interceptorOptions()...)
 	s.grpcServer = grpc.NewServer(opts...)
 
 	for name, serv := range s.services {
@@ -75,10 +75,10 @@ func (s *Server) StartHTTP(port string) *Server {
 		secureMsg = "secure "
 	}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(
+	gwmux := runtime.NewServeMux(append(s.gatewayMuxOptions(), runtime.WithMarshalerOption(
 		runtime.MIMEWildcard,
 		&runtime.JSONPb{OrigName: true, EmitDefaults: true},
-	))
+	))...)
 	// Or:?
 	// gwmux := runtime.NewServeMux()
 
@@ -99,17 +99,26 @@ func (s *Server) StartHTTP(port string) *Server {
 	if s.healthServer != nil {
 		s.setHealthCheck(mux)
 	}
+	if s.withMetrics {
+		s.setMetrics(mux)
+	}
 	mux.Handle("/", gwmux)
 
+	var httpMux http.Handler = mux
+	if s.withMetrics {
+		httpMux = metricsHandler(mux)
+	}
+
 	s.httpServer = &http.Server{
 		Addr:    httpAddress,
-		Handler: mux,
+		Handler: httpMux,
 	}
 
 	if !s.insecure {
 		s.httpServer.TLSConfig = &tls.Config{
 			Certificates: []tls.Certificate{s.certificate},
 		}
+		s.setClientAuth(s.httpServer.TLSConfig)
 	}
 
 	s.makeSignalCh()
@@ -0,0 +1,424 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicMethodPrefixes are the methods that do not require authentication
+var publicMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the authenticated caller of the API, if any
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// WithAuthenticator adds an authenticator to identify the callers of the API.
+// Once there is an authenticator, or an authorization policy, every call
+// requires an authenticated caller. When TLS is enabled the clients are also
+// identified by the subject of their certificate
+func (s *Server) WithAuthenticator(a Authenticator) *Server {
+	s.authenticators = append(s.authenticators, a)
+	return s
+}
+
+// WithTokenAuth identifies the callers by the static bearer tokens in the given
+// file. Nothing is done if the filename is empty
+func (s *Server) WithTokenAuth(filename string) *Server {
+	if len(filename) == 0 {
+		return s
+	}
+	a, err := NewTokenAuthenticator(filename)
+	if err != nil {
+		return s.addErr(err)
+	}
+	return s.WithAuthenticator(a)
+}
+
+// WithOIDCAuth identifies the callers by the OpenID Connect ID Token in the
+// bearer token. Nothing is done if the issuer is empty
+func (s *Server) WithOIDCAuth(issuer, clientID, jwks, usernameClaim, groupsClaim string) *Server {
+	if len(issuer) == 0 {
+		return s
+	}
+	a, err := NewOIDCAuthenticator(issuer, clientID, jwks, usernameClaim, groupsClaim)
+	if err != nil {
+		return s.addErr(err)
+	}
+	return s.WithAuthenticator(a)
+}
+
+// WithPolicy authorizes every call with the policy in the given file. Nothing
+// is done if the filename is empty
+func (s *Server) WithPolicy(filename string) *Server {
+	if len(filename) == 0 {
+		return s
+	}
+	p, err := LoadPolicy(filename)
+	if err != nil {
+		return s.addErr(err)
+	}
+	s.policy = p
+	return s
+}
+
+// WithAuditLog records every call modifying a cluster in the given file.
+// Nothing is done if the filename is empty
+func (s *Server) WithAuditLog(filename string) *Server {
+	if len(filename) == 0 {
+		return s
+	}
+	l, err := NewAuditLog(filename)
+	if err != nil {
+		return s.addErr(err)
+	}
+	s.auditLog = l
+	return s
+}
+
+func (s *Server) authEnabled() bool {
+	return len(s.authenticators) != 0 || s.policy != nil
+}
+
+// interceptorOptions returns the gRPC server options with the metrics,
+// authentication, authorization and audit interceptors, if they are enabled
+func (s *Server) interceptorOptions() []grpc.ServerOption {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+
+	if s.withMetrics {
+		unary = append(unary, metricsUnaryInterceptor)
+		stream = append(stream, metricsStreamInterceptor)
+	}
+
+	if s.authEnabled() || s.auditLog != nil {
+		a := &authInterceptor{
+			policy:   s.policy,
+			auditLog: s.auditLog,
+			verbs:    make(map[string]string),
+			enabled:  s.authEnabled(),
+		}
+		for _, serv := range s.services {
+			for method, verb := range serv.Verbs {
+				a.verbs[method] = verb
+			}
+		}
+		if !s.insecure {
+			a.authenticators = append(a.authenticators, &gatewayAuthenticator{secret: s.gatewaySecret()}, &CertificateAuthenticator{})
+		}
+		a.authenticators = append(a.authenticators, s.authenticators...)
+
+		unary = append(unary, a.unary)
+		stream = append(stream, a.stream)
+	}
+
+	if len(unary) == 0 {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(chainUnaryInterceptors(unary...)),
+		grpc.StreamInterceptor(chainStreamInterceptors(stream...)),
+	}
+}
+
+// chainUnaryInterceptors returns an interceptor calling the given interceptors
+// in order, the first one is the outermost. The gRPC server accepts only one
+func chainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, h)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// chainStreamInterceptors returns an interceptor calling the given interceptors
+// in order, the first one is the outermost. The gRPC server accepts only one
+func chainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, h)
+			}
+		}
+		return next(srv, ss)
+	}
+}
+
+// gatewayMuxOptions returns the HTTP/REST gateway options to forward to the
+// gRPC server the identity of the clients authenticated with a certificate
+func (s *Server) gatewayMuxOptions() []runtime.ServeMuxOption {
+	if !s.authEnabled() || s.insecure {
+		return nil
+	}
+
+	secret := s.gatewaySecret()
+	return []runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			// Do not let a client impersonate the gateway
+			if strings.HasPrefix(strings.ToLower(key), "grpc-metadata-x-kubekit-gateway-") {
+				return "", false
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+		runtime.WithMetadata(func(ctx context.Context, r *http.Request) metadata.MD {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+				return nil
+			}
+			id := certificateIdentity(r.TLS.VerifiedChains[0][0])
+			if id == nil {
+				return nil
+			}
+			md := metadata.Pairs(mdGatewaySecret, secret, mdGatewayUser, id.User)
+			md.Append(mdGatewayGroups, id.Groups...)
+			return md
+		}),
+	}
+}
+
+// setClientAuth makes the TLS server to verify the client certificates, if
+// given, when authentication is enabled
+func (s *Server) setClientAuth(config *tls.Config) {
+	if !s.authEnabled() {
+		return
+	}
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	config.ClientCAs = s.certPool
+}
+
+// gatewaySecret returns the random secret shared by the HTTP/REST gateway and
+// the gRPC server of this process
+func (s *Server) gatewaySecret() string {
+	if len(s.gatewaySecretKey) != 0 {
+		return s.gatewaySecretKey
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		s.addErr(err)
+		return ""
+	}
+	s.gatewaySecretKey = hex.EncodeToString(b)
+	return s.gatewaySecretKey
+}
+
+type authInterceptor struct {
+	authenticators []Authenticator
+	policy         *Policy
+	auditLog       *AuditLog
+	verbs          map[string]string
+	enabled        bool
+}
+
+func (a *authInterceptor) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if isPublicMethod(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	id, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id != nil {
+		ctx = context.WithValue(ctx, identityKey{}, id)
+	}
+
+	verb := a.verbs[info.FullMethod]
+	clusters := requestClusters(req)
+	if err := a.authorize(id, verb, clusters); err != nil {
+		a.audit(id, info.FullMethod, verb, clusters, false, err)
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	a.audit(id, info.FullMethod, verb, clusters, true, err)
+	return resp, err
+}
+
+func (a *authInterceptor) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isPublicMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ctx := ss.Context()
+	id, err := a.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if id != nil {
+		ctx = context.WithValue(ctx, identityKey{}, id)
+	}
+
+	// The clusters are in the request, so the call is authorized when the
+	// handler receives the first message
+	as := &authServerStream{
+		ServerStream: ss,
+		ctx:          ctx,
+		auth:         a,
+		id:           id,
+		verb:         a.verbs[info.FullMethod],
+	}
+	err = handler(srv, as)
+	if as.authorized {
+		a.audit(id, info.FullMethod, as.verb, as.clusters, true, err)
+	} else if as.denied != nil {
+		a.audit(id, info.FullMethod, as.verb, as.clusters, false, as.denied)
+	}
+	return err
+}
+
+// authenticate returns the identity of the caller. It's an error if there is
+// no identity and authentication is enabled
+func (a *authInterceptor) authenticate(ctx context.Context) (*Identity, error) {
+	for _, authenticator := range a.authenticators {
+		id, err := authenticator.Authenticate(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if id != nil {
+			return id, nil
+		}
+	}
+	if a.enabled {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return nil, nil
+}
+
+// authorize returns a PermissionDenied error if the method has no verb or the
+// policy does not allow the identity to use the verb on the clusters
+func (a *authInterceptor) authorize(id *Identity, verb string, clusters []string) error {
+	if len(verb) == 0 {
+		return status.Error(codes.PermissionDenied, "the method is not allowed, it has no verb to authorize it")
+	}
+	if a.policy == nil || verb == VerbNone {
+		return nil
+	}
+	if a.policy.Allowed(id, verb, clusters...) {
+		return nil
+	}
+	target := "all the clusters"
+	if len(clusters) != 0 {
+		target = "cluster " + strings.Join(clusters, ", ")
+	}
+	return status.Errorf(codes.PermissionDenied, "%s is not allowed to %s %s", id, verb, target)
+}
+
+func (a *authInterceptor) audit(id *Identity, method, verb string, clusters []string, allowed bool, err error) {
+	if a.auditLog == nil || len(verb) == 0 || verb == VerbGet || verb == VerbNone {
+		return
+	}
+
+	entry := AuditEntry{
+		Time:     time.Now().UTC(),
+		User:     id.String(),
+		Method:   method,
+		Verb:     verb,
+		Clusters: clusters,
+		Allowed:  allowed,
+		Code:     status.Code(err).String(),
+	}
+	if id != nil {
+		entry.User = id.User
+		entry.Groups = id.Groups
+		entry.Auth = id.Method
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+
+	// A failure to audit should not fail the call, it's already done
+	a.auditLog.Log(entry)
+}
+
+// authServerStream authorizes a stream call with the first received message
+type authServerStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	auth       *authInterceptor
+	id         *Identity
+	verb       string
+	clusters   []string
+	authorized bool
+	denied     error
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized {
+		return nil
+	}
+	s.clusters = requestClusters(m)
+	return s.authorizeStream()
+}
+
+func (s *authServerStream) SendMsg(m interface{}) error {
+	// Nothing is sent before the authorization, even if no message was received
+	if !s.authorized {
+		if err := s.authorizeStream(); err != nil {
+			return err
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *authServerStream) authorizeStream() error {
+	if s.denied != nil {
+		return s.denied
+	}
+	if err := s.auth.authorize(s.id, s.verb, s.clusters); err != nil {
+		s.denied = err
+		return err
+	}
+	s.authorized = true
+	return nil
+}
+
+func isPublicMethod(method string) bool {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestClusters returns the clusters in the request message
+func requestClusters(req interface{}) []string {
+	switch r := req.(type) {
+	case interface{ GetClusterName() string }:
+		if name := r.GetClusterName(); len(name) != 0 {
+			return []string{name}
+		}
+	case interface{ GetNames() []string }:
+		return r.GetNames()
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsPath = "/metrics"
+
+// requestBuckets are the buckets, in seconds, of the requests latency. The
+// stream calls, such as ApplyStream, last as long as the cluster provisioning
+var requestBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300, 900, 1800, 3600}
+
+var (
+	grpcRequests = metrics.NewCounterVec(
+		"kubekit_grpc_requests_total",
+		"Total number of gRPC requests by method and status code.",
+		"method", "code",
+	)
+	grpcRequestDuration = metrics.NewHistogramVec(
+		"kubekit_grpc_request_duration_seconds",
+		"Latency of the gRPC requests by method.",
+		requestBuckets,
+		"method",
+	)
+	httpRequests = metrics.NewCounterVec(
+		"kubekit_http_requests_total",
+		"Total number of HTTP requests by handler, HTTP method and status code.",
+		"handler", "method", "code",
+	)
+	httpRequestDuration = metrics.NewHistogramVec(
+		"kubekit_http_request_duration_seconds",
+		"Latency of the HTTP requests by handler and HTTP method.",
+		requestBuckets,
+		"handler", "method",
+	)
+)
+
+func init() {
+	metrics.Register(grpcRequests, grpcRequestDuration, httpRequests, httpRequestDuration)
+}
+
+// WithMetrics enable the HTTP server to expose the Prometheus metrics on
+// /metrics, and to collect the gRPC and HTTP requests metrics
+func (s *Server) WithMetrics() *Server {
+	s.withMetrics = true
+	return s
+}
+
+func (s *Server) setMetrics(mux *http.ServeMux) {
+	s.ui.Log.Debugf("registering metrics on HTTP/REST (%s)", metricsPath)
+	mux.Handle(metricsPath, metrics.Handler())
+}
+
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeGRPCRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+func metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeGRPCRequest(info.FullMethod, start, err)
+	return err
+}
+
+func observeGRPCRequest(method string, start time.Time, err error) {
+	grpcRequests.Inc(method, status.Code(err).String())
+	grpcRequestDuration.Observe(time.Since(start).Seconds(), method)
+}
+
+// statusRecorder records the status code of the HTTP response
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// metricsHandler collects the metrics of the requests to the given HTTP handler
+func metricsHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		h.ServeHTTP(rec, r)
+
+		handler := metricsHandlerName(r.URL.Path)
+		httpRequests.Inc(handler, r.Method, strconv.Itoa(rec.code))
+		httpRequestDuration.Observe(time.Since(start).Seconds(), handler, r.Method)
+	})
+}
+
+// metricsHandlerName returns the name of the handler serving the given path. The
+// path is not used as label because it contains the cluster names. The REST API
+// calls are also collected by gRPC method, the gateway calls the gRPC API
+func metricsHandlerName(path string) string {
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	switch parts[0] {
+	case "api":
+		if len(parts) > 1 {
+			return "/api/" + parts[1]
+		}
+		return "/api"
+	case "swagger", "healthz", "metrics":
+		return "/" + parts[0]
+	default:
+		return "other"
+	}
+}
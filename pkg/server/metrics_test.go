@@ -0,0 +1,52 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+" before")
+			resp, err := handler(ctx, req)
+			calls = append(calls, name+" after")
+			return resp, err
+		}
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	chain := chainUnaryInterceptors(metricsUnaryInterceptor, interceptor("first"), interceptor("second"))
+	resp, err := chain(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "/test/Method"}, handler)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "request", resp)
+	assert.Equal(t, []string{"first before", "second before", "handler", "second after", "first after"}, calls)
+
+	var b bytes.Buffer
+	grpcRequests.Write(&b)
+	assert.Contains(t, b.String(), `kubekit_grpc_requests_total{method="/test/Method",code="OK"} 1`)
+}
+
+func TestMetricsHandlerName(t *testing.T) {
+	tests := map[string]string{
+		"/api/v1/cluster/kkdemo": "/api/v1",
+		"/api/v1/version":        "/api/v1",
+		"/healthz/v1/Kubekit":    "/healthz",
+		"/swagger/kubekit.json":  "/swagger",
+		"/metrics":               "/metrics",
+		"/":                      "other",
+		"/favicon.ico":           "other",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, metricsHandlerName(path), path)
+	}
+}
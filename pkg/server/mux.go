@@ -48,6 +48,7 @@ func (s *Server) StartMux(port string) *Server {
 	if !s.insecure {
 		opts = append(opts, grpc.Creds(credentials.NewClientTLSFromCert(s.certPool, s.host)))
 	}
+	opts = append(opts, s.interceptorOptions()...)
 	s.grpcServer = grpc.NewServer(opts...)
 
 	for name, serv := range s.services {
@@ -88,10 +89,10 @@ func (s *Server) StartMux(port string) *Server {
 		gwopts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(
+	gwmux := runtime.NewServeMux(append(s.gatewayMuxOptions(), runtime.WithMarshalerOption(
 		runtime.MIMEWildcard,
 		&runtime.JSONPb{OrigName: true, EmitDefaults: true},
-	))
+	))...)
 	// Or:?
 	// gwmux := runtime.NewServeMux()
 
@@ -112,6 +113,9 @@ func (s *Server) StartMux(port string) *Server {
 	if s.healthServer != nil {
 		s.setHealthCheck(mux)
 	}
+	if s.withMetrics {
+		s.setMetrics(mux)
+	}
 	mux.Handle("/", gwmux)
 
 	var httpMux http.Handler
@@ -121,6 +125,9 @@ func (s *Server) StartMux(port string) *Server {
 	} else {
 		httpMux = mux
 	}
+	if s.withMetrics {
+		httpMux = metricsHandler(httpMux)
+	}
 
 	// Accepting connections over the network with `:port` (better) instead of `host:port`/`localhost:port`.
 	httpAddress := fmt.Sprintf(":%s", port) // Or?: serveAddress
@@ -157,6 +164,7 @@ func (s *Server) StartMux(port string) *Server {
 			CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256},
 			MinVersion:       tls.VersionTLS12,
 		}
+		s.setClientAuth(httpServer.TLSConfig)
 		conn = tls.NewListener(conn, httpServer.TLSConfig)
 		secureMsg = "secure "
 	}
@@ -0,0 +1,312 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+const (
+	defOIDCUsernameClaim = "sub"
+	defOIDCGroupsClaim   = "groups"
+
+	// jwksRefreshInterval is the minimum time between two downloads of the JWKS
+	// when a token is signed with an unknown key
+	jwksRefreshInterval = time.Minute
+)
+
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// OIDCAuthenticator identifies the clients by an OpenID Connect ID Token (JWT)
+// issued by Issuer for ClientID and signed with one of the keys in the JWKS
+type OIDCAuthenticator struct {
+	Issuer        string
+	ClientID      string
+	UsernameClaim string
+	GroupsClaim   string
+
+	jwks        string
+	mu          sync.RWMutex
+	keys        map[string]interface{}
+	lastRefresh time.Time
+}
+
+// NewOIDCAuthenticator creates an OIDCAuthenticator with the JSON Web Key Set
+// located in jwks, either a file or a http(s) URL. The username and groups
+// claims are "sub" and "groups" if they are empty
+func NewOIDCAuthenticator(issuer, clientID, jwks, usernameClaim, groupsClaim string) (*OIDCAuthenticator, error) {
+	if len(issuer) == 0 || len(clientID) == 0 {
+		return nil, fmt.Errorf("the OIDC issuer and client ID are required")
+	}
+	if len(jwks) == 0 {
+		return nil, fmt.Errorf("the OIDC JSON Web Key Set file or URL is required")
+	}
+	if len(usernameClaim) == 0 {
+		usernameClaim = defOIDCUsernameClaim
+	}
+	if len(groupsClaim) == 0 {
+		groupsClaim = defOIDCGroupsClaim
+	}
+
+	a := &OIDCAuthenticator{
+		Issuer:        issuer,
+		ClientID:      clientID,
+		UsernameClaim: usernameClaim,
+		GroupsClaim:   groupsClaim,
+		jwks:          jwks,
+	}
+	if err := a.refresh(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Authenticate implements the Authenticator interface
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	token := bearerToken(ctx)
+	if len(token) == 0 || strings.Count(token, ".") != 2 {
+		return nil, nil
+	}
+
+	parser := &jwt.Parser{ValidMethods: oidcSigningMethods}
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(token, claims, a.keyFunc); err != nil {
+		return nil, fmt.Errorf("invalid OIDC token. %s", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != a.Issuer {
+		return nil, fmt.Errorf("invalid OIDC token. Unexpected issuer %q", iss)
+	}
+	if !a.validAudience(claims["aud"]) {
+		return nil, fmt.Errorf("invalid OIDC token. It was not issued for the client %q", a.ClientID)
+	}
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("invalid OIDC token. It has no expiration time")
+	}
+
+	user, _ := claims[a.UsernameClaim].(string)
+	if len(user) == 0 {
+		return nil, fmt.Errorf("invalid OIDC token. The claim %q was not found", a.UsernameClaim)
+	}
+	id := &Identity{
+		User:   user,
+		Method: AuthMethodOIDC,
+	}
+	switch groups := claims[a.GroupsClaim].(type) {
+	case string:
+		id.Groups = []string{groups}
+	case []interface{}:
+		for _, g := range groups {
+			if group, ok := g.(string); ok {
+				id.Groups = append(id.Groups, group)
+			}
+		}
+	}
+
+	return id, nil
+}
+
+// validAudience returns true if the audience claim, a string or a list of
+// strings, contains the client ID
+func (a *OIDCAuthenticator) validAudience(aud interface{}) bool {
+	switch audience := aud.(type) {
+	case string:
+		return audience == a.ClientID
+	case []interface{}:
+		for _, v := range audience {
+			if s, ok := v.(string); ok && s == a.ClientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *OIDCAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if key := a.key(kid); key != nil {
+		return key, nil
+	}
+
+	// The issuer may have rotated the keys, if so, get the new ones
+	a.mu.RLock()
+	canRefresh := isURL(a.jwks) && time.Since(a.lastRefresh) > jwksRefreshInterval
+	a.mu.RUnlock()
+	if canRefresh {
+		if err := a.refresh(); err != nil {
+			return nil, err
+		}
+		if key := a.key(kid); key != nil {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+func (a *OIDCAuthenticator) key(kid string) interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	// A token without key ID can be verified only if there is a single key
+	if len(kid) == 0 && len(a.keys) == 1 {
+		for _, key := range a.keys {
+			return key
+		}
+	}
+	return a.keys[kid]
+}
+
+func (a *OIDCAuthenticator) refresh() error {
+	var content []byte
+	var err error
+	if isURL(a.jwks) {
+		content, err = downloadJWKS(a.jwks)
+	} else {
+		content, err = ioutil.ReadFile(a.jwks)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the OIDC JSON Web Key Set from %s. %s", a.jwks, err)
+	}
+
+	keys, err := parseJWKS(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse the OIDC JSON Web Key Set from %s. %s", a.jwks, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = keys
+	a.lastRefresh = time.Now()
+
+	return nil
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
+
+func downloadJWKS(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns the RSA and EC public signing keys of a JSON Web Key Set
+// (RFC 7517) indexed by key ID
+func parseJWKS(content []byte) (map[string]interface{}, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if len(k.Use) != 0 && k.Use != "sig" {
+			continue
+		}
+		var key interface{}
+		var err error
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsaPublicKey()
+		case "EC":
+			key, err = k.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q. %s", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no RSA or EC signing keys found")
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid RSA exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jsonWebKey) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+	}
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("the point is not on the curve %s", k.Crv)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Verbs checked by the authorization policy. Every verb but VerbGet modifies a
+// cluster or gives access to it, so the calls using them are recorded in the
+// audit log. The methods with VerbNone only require an authenticated caller,
+// they are not checked by the policy nor recorded. The methods without a verb
+// are denied
+const (
+	VerbGet    = "get"
+	VerbApply  = "apply"
+	VerbDelete = "delete"
+	VerbToken  = "token"
+	VerbNone   = "none"
+)
+
+// anyCluster is the cluster name used to authorize the calls that are not
+// for a specific cluster, such as listing all the clusters. Only the rules
+// with a cluster pattern matching every name (i.e. "*") allow them
+const anyCluster = "*"
+
+// Policy is the authorization policy of the server. A call is allowed if at
+// least one rule allows it, otherwise it's denied
+type Policy struct {
+	Rules []PolicyRule `json:"rules" yaml:"rules"`
+}
+
+// PolicyRule allows the users or groups to use the verbs on the clusters. All
+// the fields accept glob patterns, so "*" matches any user, group, verb or
+// cluster and "team-a-*" matches every cluster with the "team-a-" prefix
+type PolicyRule struct {
+	Users    []string `json:"users,omitempty" yaml:"users,omitempty"`
+	Groups   []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Verbs    []string `json:"verbs" yaml:"verbs"`
+	Clusters []string `json:"clusters" yaml:"clusters"`
+}
+
+// LoadPolicy reads the authorization policy from a YAML or JSON file
+func LoadPolicy(filename string) (*Policy, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the authorization policy file %s. %s", filename, err)
+	}
+
+	p := &Policy{}
+	if err := yaml.UnmarshalStrict(content, p); err != nil {
+		return nil, fmt.Errorf("failed to parse the authorization policy file %s. %s", filename, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid authorization policy file %s. %s", filename, err)
+	}
+
+	return p, nil
+}
+
+func (p *Policy) validate() error {
+	for i, rule := range p.Rules {
+		if len(rule.Users)+len(rule.Groups) == 0 {
+			return fmt.Errorf("rule #%d has no users or groups", i+1)
+		}
+		if len(rule.Verbs) == 0 {
+			return fmt.Errorf("rule #%d has no verbs", i+1)
+		}
+		if len(rule.Clusters) == 0 {
+			return fmt.Errorf("rule #%d has no clusters", i+1)
+		}
+		for _, patterns := range [][]string{rule.Users, rule.Groups, rule.Verbs, rule.Clusters} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("rule #%d has an invalid pattern %q", i+1, pattern)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Allowed returns true if the given identity can use the verb on every one of
+// the given clusters. An empty list of clusters means all the clusters
+func (p *Policy) Allowed(id *Identity, verb string, clusters ...string) bool {
+	if id == nil {
+		return false
+	}
+	if len(clusters) == 0 {
+		clusters = []string{anyCluster}
+	}
+
+	for _, cluster := range clusters {
+		if !p.allowed(id, verb, cluster) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Policy) allowed(id *Identity, verb, cluster string) bool {
+	for _, rule := range p.Rules {
+		if rule.appliesTo(id) && match(rule.Verbs, verb) && match(rule.Clusters, cluster) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r PolicyRule) appliesTo(id *Identity) bool {
+	if match(r.Users, id.User) {
+		return true
+	}
+	for _, group := range id.Groups {
+		if match(r.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func match(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
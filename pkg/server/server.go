@@ -42,6 +42,12 @@ type Server struct {
 	certificate tls.Certificate
 	certPool    *x509.CertPool
 
+	// Authentication, authorization & audit
+	authenticators   []Authenticator
+	policy           *Policy
+	auditLog         *AuditLog
+	gatewaySecretKey string
+
 	// Servers
 	grpcServer       *grpc.Server
 	grpcPort         string
@@ -54,6 +60,7 @@ type Server struct {
 	healthPort       string
 	withSwagger      bool
 	withHealthCheck  bool
+	withMetrics      bool
 	allowCORS        bool
 }
 
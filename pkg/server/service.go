@@ -27,6 +27,10 @@ type Service struct {
 	ServiceRegister             ServiceRegisterable
 	RegisterHandlerFromEndpoint RegisterHandlerFromEndpoint
 	SwaggerBytes                []byte
+	// Verbs maps the full gRPC method name (i.e. "/pkg.Service/Method") to the
+	// verb checked by the authorization policy. Methods not in the map only
+	// require an authenticated caller
+	Verbs map[string]string
 }
 
 // Services is a collection of services
@@ -26,6 +26,7 @@ func ServicesForVersion(version string, dry bool, a ...interface{}) (server.Serv
 				ServiceRegister:             servicev1.NewKubeKitService(clustersPath, parentUI, dry),
 				RegisterHandlerFromEndpoint: apiv1.RegisterKubekitHandlerFromEndpoint,
 				SwaggerBytes:                apiv1.Swagger,
+				Verbs:                       servicev1.Verbs,
 			},
 		}, nil
 	default:
@@ -4,14 +4,16 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
-	"github.com/nightlyone/lockfile"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
 	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
 	"github.com/liferaft/kubekit/pkg/crypto/tls"
 	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/notifier"
+	"github.com/liferaft/kubekit/pkg/storage"
 	context "golang.org/x/net/context"
 )
 
@@ -29,42 +31,102 @@ func (s *KubeKitService) Apply(ctx context.Context, in *apiv1.ApplyRequest) (*ap
 	}
 
 	// only apply if not dry
-	if !s.dry {
-		go s.doApply(ctx, cluster, in)
+	if s.dry {
+		return &apiv1.ApplyResponse{
+			Api:    apiVersion,
+			Status: kluster.AbsentStatus.String(),
+		}, nil
 	}
 
+	op, err := s.operations.start(in.ClusterName, "apply")
+	if err != nil {
+		return nil, err
+	}
+	go s.doApply(withActor(op.ctx, ctx), cluster, in, newEventer(cluster, op))
+
 	return &apiv1.ApplyResponse{
-		Api:    apiVersion,
-		Status: kluster.AbsentStatus.String(),
+		Api:         apiVersion,
+		Status:      kluster.AbsentStatus.String(),
+		OperationId: op.info.Id,
 	}, nil
 }
 
-func (s *KubeKitService) doApply(ctx context.Context, cluster *kluster.Kluster, in *apiv1.ApplyRequest) {
+// ApplyStream creates or modifies the given cluster like Apply but instead of
+// returning immediately, it streams the events of every step until the cluster
+// is provisioned and configured
+func (s *KubeKitService) ApplyStream(in *apiv1.ApplyRequest, stream apiv1.Kubekit_ApplyStreamServer) error {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return err
+	}
+
+	cluster, err := kluster.LoadCluster(in.ClusterName, s.clustersPath, s.ui)
+	if err != nil {
+		return err
+	}
+
+	// only apply if not dry
+	if s.dry {
+		return stream.Send(&apiv1.Event{
+			Api:         apiVersion,
+			ClusterName: in.ClusterName,
+			Type:        apiv1.EventType_STATUS,
+			Timestamp:   time.Now().Unix(),
+			Status:      kluster.AbsentStatus.String(),
+		})
+	}
+
+	op, err := s.operations.start(in.ClusterName, "apply")
+	if err != nil {
+		return err
+	}
+
+	// the apply is not canceled if the client is gone, only with CancelOperation
+	return streamEvents(stream, cluster, op, func(ev *eventer) {
+		s.doApply(withActor(op.ctx, stream.Context()), cluster, in, ev)
+	})
+}
+
+func (s *KubeKitService) doApply(ctx context.Context, cluster *kluster.Kluster, in *apiv1.ApplyRequest, ev *eventer) {
 	var err error
 	var status string
 
-	var lock lockfile.Lockfile
+	var lock storage.Unlocker
 	if lock, err = cluster.Lock("apply"); err != nil {
+		ev.status("", err)
 		return
 	}
 	defer lock.Unlock()
 
 	platform := cluster.Platform()
+	cluster.WithContext(ctx)
+	cluster.Notify(notifier.ApplyStarted, nil)
 
 	defer func() {
 		if err != nil {
 			s.ui.Log.Errorf("failed to apply the cluster %s. %s", cluster.Name, err)
 		}
 		cluster.State[platform].Status = status
-		if errS := cluster.Save(); errS != nil {
+		errS := cluster.Save()
+		if errS != nil {
 			s.ui.Log.Errorf("failed to save the cluster configuration file for %s. %s", cluster.Name, errS)
+			if err == nil {
+				err = errS
+			}
+		}
+		if errI := cluster.UpdateInventory(); errI != nil {
+			s.ui.Log.Warnf("failed to update the inventory of the cluster %s. %s", cluster.Name, errI)
 		}
+		cluster.Notify(notifier.ApplyFinished, err)
+		ev.status(status, err)
 	}()
 
 	// 1. Generate the SSH keys:
 	// required for the terraform templates and provisioner
 	s.ui.Log.Infof("generating cluster %q ssh keys", in.ClusterName)
-	if err = cluster.HandleKeys(); err != nil {
+	ev.stepStarted("keys")
+	err = cluster.HandleKeys()
+	ev.stepFinished("keys", err)
+	if err != nil {
 		status = kluster.FailedProvisioningStatus.String()
 		return
 	}
@@ -72,13 +134,25 @@ func (s *KubeKitService) doApply(ctx context.Context, cluster *kluster.Kluster,
 	// 2. Provisioning, Upload & Install the KubeKit package:
 	if in.Action == apiv1.ApplyAction_ALL || in.Action == apiv1.ApplyAction_PROVISION {
 		status = kluster.FailedProvisioningStatus.String()
+		if err = ctx.Err(); err != nil {
+			return
+		}
 
 		s.ui.Log.Infof("provisioning cluster %q on %s", in.ClusterName, platform)
-		if err = doProvisioning(cluster, platform); err != nil {
+		ev.stepStarted("provisioning")
+		err = doProvisioning(cluster, platform)
+		ev.stepFinished("provisioning", err)
+		if err != nil {
+			return
+		}
+		if err = ctx.Err(); err != nil {
 			return
 		}
 		s.ui.Log.Infof("uploading and installing the package to cluster %q on %s", in.ClusterName, platform)
-		if err = installPackage(cluster, s.clustersPath, platform, in.ForcePackage); err != nil {
+		ev.stepStarted("package")
+		err = installPackage(cluster, s.clustersPath, platform, in.ForcePackage)
+		ev.stepFinished("package", err)
+		if err != nil {
 			return
 		}
 
@@ -89,25 +163,39 @@ func (s *KubeKitService) doApply(ctx context.Context, cluster *kluster.Kluster,
 	// 3. Generate certificates, Create Kubeconfig file & Configure Kubernetes
 	if in.Action == apiv1.ApplyAction_ALL || in.Action == apiv1.ApplyAction_CONFIGURE {
 		status = kluster.FailedConfigurationStatus.String()
+		if err = ctx.Err(); err != nil {
+			return
+		}
 
+		ev.stepStarted("certificates")
 		var caCertsFiles tls.KeyPairs
 		caCertsFiles, err = getCACerts(cluster, in.CaCerts)
+		if err == nil {
+			s.ui.Log.Infof("generating CA certificates for cluster %q on %s", in.ClusterName, platform)
+			err = cluster.GenerateCerts(caCertsFiles, true)
+		}
+		ev.stepFinished("certificates", err)
 		if err != nil {
 			return
 		}
-		s.ui.Log.Infof("generating CA certificates for cluster %q on %s", in.ClusterName, platform)
-		if err = cluster.GenerateCerts(caCertsFiles, true); err != nil {
+		if err = cluster.LoadState(); err != nil {
 			return
 		}
-		if err = cluster.LoadState(); err != nil {
+		if err = ctx.Err(); err != nil {
 			return
 		}
 		s.ui.Log.Infof("creating the Kubeconfig file for cluster %q on %s", in.ClusterName, platform)
-		if err = cluster.CreateKubeConfigFile(); err != nil {
+		ev.stepStarted("kubeconfig")
+		err = cluster.CreateKubeConfigFile()
+		ev.stepFinished("kubeconfig", err)
+		if err != nil {
 			return
 		}
 		s.ui.Log.Infof("configuring Kubernetes cluster %q on %s", in.ClusterName, platform)
-		if err = doConfiguration(cluster); err != nil {
+		ev.stepStarted("configuration")
+		err = doConfiguration(cluster)
+		ev.stepFinished("configuration", err)
+		if err != nil {
 			return
 		}
 
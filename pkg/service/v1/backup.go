@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"fmt"
+
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/storage"
+	context "golang.org/x/net/context"
+)
+
+// Backup takes a snapshot of the etcd database of the cluster and stores it in
+// the backups directory of the cluster
+func (s *KubeKitService) Backup(ctx context.Context, in *apiv1.BackupRequest) (*apiv1.BackupResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	cluster, err := kluster.LoadCluster(in.ClusterName, s.clustersPath, s.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't backup if dry
+	if s.dry {
+		return &apiv1.BackupResponse{
+			Api: apiVersion,
+		}, nil
+	}
+
+	// the cluster can't be applied, restored or upgraded while it's backed up
+	lock, err := cluster.Lock("apply")
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	cluster.WithContext(ctx)
+
+	if err := cluster.HandleKeys(); err != nil {
+		return nil, err
+	}
+
+	backup, err := cluster.Backup()
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.BackupResponse{
+		Api: apiVersion,
+		Backup: &apiv1.Backup{
+			Name:        backup.Name,
+			ClusterName: backup.ClusterName,
+			Node:        backup.Node,
+			Release:     backup.Release,
+			EtcdVersion: backup.EtcdVersion,
+			Size:        backup.Size,
+			CreatedAt:   backup.CreatedAt.Unix(),
+			Path:        backup.Path,
+		},
+	}, nil
+}
+
+// Restore restores the etcd database of the cluster from a backup. It returns
+// immediately with the operation ID, the restore is done in the background
+func (s *KubeKitService) Restore(ctx context.Context, in *apiv1.RestoreRequest) (*apiv1.RestoreResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	if len(in.From) == 0 {
+		return nil, fmt.Errorf("the backup to restore is required")
+	}
+
+	cluster, err := kluster.LoadCluster(in.ClusterName, s.clustersPath, s.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the backups of the cluster are restored, not any file of the server
+	backup, err := cluster.GetBackup(in.From)
+	if err != nil {
+		return nil, err
+	}
+
+	platform := cluster.Platform()
+	status := cluster.State[platform].Status
+
+	// don't restore if dry
+	if s.dry {
+		return &apiv1.RestoreResponse{
+			Api:    apiVersion,
+			Status: status,
+		}, nil
+	}
+
+	op, err := s.operations.start(in.ClusterName, "restore")
+	if err != nil {
+		return nil, err
+	}
+	go s.doRestore(op.ctx, cluster, backup.Name, newEventer(cluster, op))
+
+	return &apiv1.RestoreResponse{
+		Api:         apiVersion,
+		Status:      status,
+		OperationId: op.info.Id,
+	}, nil
+}
+
+func (s *KubeKitService) doRestore(ctx context.Context, cluster *kluster.Kluster, from string, ev *eventer) {
+	var err error
+
+	var lock storage.Unlocker
+	if lock, err = cluster.Lock("apply"); err != nil {
+		ev.status("", err)
+		return
+	}
+	defer lock.Unlock()
+
+	platform := cluster.Platform()
+	cluster.WithContext(ctx)
+
+	defer func() {
+		if err != nil {
+			s.ui.Log.Errorf("failed to restore the cluster %s. %s", cluster.Name, err)
+		}
+		errS := cluster.Save()
+		if errS != nil {
+			s.ui.Log.Errorf("failed to save the cluster configuration file for %s. %s", cluster.Name, errS)
+			if err == nil {
+				err = errS
+			}
+		}
+		ev.status(cluster.State[platform].Status, err)
+	}()
+
+	ev.stepStarted("keys")
+	err = cluster.HandleKeys()
+	ev.stepFinished("keys", err)
+	if err != nil {
+		return
+	}
+
+	ev.stepStarted("restore")
+	err = cluster.Restore(from, kluster.DefaultRestoreTimeout)
+	ev.stepFinished("restore", err)
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	context "golang.org/x/net/context"
+)
+
+// CheckCluster runs the diagnostics of the cluster and returns the result of
+// every diagnostic
+func (s *KubeKitService) CheckCluster(ctx context.Context, in *apiv1.CheckClusterRequest) (*apiv1.CheckClusterResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	cluster, err := kluster.LoadCluster(in.ClusterName, s.clustersPath, s.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't check if dry
+	if s.dry {
+		return &apiv1.CheckClusterResponse{
+			Api:         apiVersion,
+			ClusterName: in.ClusterName,
+		}, nil
+	}
+
+	cluster.WithContext(ctx)
+
+	// the SSH keys are required to access the nodes
+	if err := cluster.HandleKeys(); err != nil {
+		return nil, err
+	}
+
+	report, err := cluster.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	return checkClusterResponse(report), nil
+}
+
+func checkClusterResponse(report *kluster.CheckReport) *apiv1.CheckClusterResponse {
+	results := make([]*apiv1.CheckResult, 0, len(report.Results))
+	for _, r := range report.Results {
+		results = append(results, &apiv1.CheckResult{
+			Check:   r.Check,
+			Node:    r.Node,
+			Status:  string(r.Status),
+			Message: r.Message,
+		})
+	}
+
+	return &apiv1.CheckClusterResponse{
+		Api:         apiVersion,
+		ClusterName: report.ClusterName,
+		Status:      string(report.Status),
+		Pass:        int32(report.Pass),
+		Warn:        int32(report.Warn),
+		Fail:        int32(report.Fail),
+		Results:     results,
+	}
+}
@@ -3,10 +3,12 @@ package v1
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
 	"github.com/liferaft/kubekit/pkg/kluster"
-	"github.com/nightlyone/lockfile"
+	"github.com/liferaft/kubekit/pkg/notifier"
+	"github.com/liferaft/kubekit/pkg/storage"
 	context "golang.org/x/net/context"
 )
 
@@ -21,34 +23,92 @@ func (s *KubeKitService) Delete(ctx context.Context, in *apiv1.DeleteRequest) (*
 		return nil, err
 	}
 
+	platform := cluster.Platform()
+
 	// don't delete if dry
-	if !s.dry {
-		go s.doDelete(ctx, cluster, in.DestroyAll)
+	if s.dry {
+		return &apiv1.DeleteResponse{
+			Api:    apiVersion,
+			Status: cluster.State[platform].Status,
+		}, nil
 	}
 
-	platform := cluster.Platform()
+	op, err := s.operations.start(in.ClusterName, "delete")
+	if err != nil {
+		return nil, err
+	}
+	status := cluster.State[platform].Status
+	go s.doDelete(withActor(op.ctx, ctx), cluster, in.DestroyAll, newEventer(cluster, op))
+
 	return &apiv1.DeleteResponse{
-		Api:    apiVersion,
-		Status: cluster.State[platform].Status,
+		Api:         apiVersion,
+		Status:      status,
+		OperationId: op.info.Id,
 	}, nil
 }
 
-func (s *KubeKitService) doDelete(ctx context.Context, cluster *kluster.Kluster, destroyAll bool) {
+// DeleteStream deletes or terminate an existing cluster like Delete but instead
+// of returning immediately, it streams the events of every step until the
+// cluster is terminated
+func (s *KubeKitService) DeleteStream(in *apiv1.DeleteRequest, stream apiv1.Kubekit_DeleteStreamServer) error {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return err
+	}
+
+	cluster, err := kluster.LoadCluster(in.ClusterName, s.clustersPath, s.ui)
+	if err != nil {
+		return err
+	}
+
+	// don't delete if dry
+	if s.dry {
+		platform := cluster.Platform()
+		return stream.Send(&apiv1.Event{
+			Api:         apiVersion,
+			ClusterName: in.ClusterName,
+			Type:        apiv1.EventType_STATUS,
+			Timestamp:   time.Now().Unix(),
+			Status:      cluster.State[platform].Status,
+		})
+	}
+
+	op, err := s.operations.start(in.ClusterName, "delete")
+	if err != nil {
+		return err
+	}
+
+	// the delete is not canceled if the client is gone, only with CancelOperation
+	return streamEvents(stream, cluster, op, func(ev *eventer) {
+		s.doDelete(withActor(op.ctx, stream.Context()), cluster, in.DestroyAll, ev)
+	})
+}
+
+func (s *KubeKitService) doDelete(ctx context.Context, cluster *kluster.Kluster, destroyAll bool, ev *eventer) {
 	var err error
 	var status string
 
-	var lock lockfile.Lockfile
+	var lock storage.Unlocker
 	if lock, err = cluster.Lock("delete"); err != nil {
+		ev.status("", err)
 		return
 	}
 	defer lock.Unlock()
 
 	platform := cluster.Platform()
+	cluster.WithContext(ctx)
+	cluster.Notify(notifier.DeleteStarted, nil)
 
 	defer func() {
 		if err != nil {
 			s.ui.Log.Errorf("failed to destroy the cluster %s. %s", cluster.Name, err)
 		}
+		defer func() {
+			if errI := cluster.UpdateInventory(); errI != nil {
+				s.ui.Log.Warnf("failed to update the inventory of the cluster %s. %s", cluster.Name, errI)
+			}
+			cluster.Notify(notifier.DeleteFinished, err)
+			ev.status(status, err)
+		}()
 		if destroyAll {
 			return
 		}
@@ -56,10 +116,16 @@ func (s *KubeKitService) doDelete(ctx context.Context, cluster *kluster.Kluster,
 		cluster.State[platform].Status = status
 		if errS := cluster.Save(); errS != nil {
 			s.ui.Log.Errorf("failed to save the cluster configuration file for %s. %s", cluster.Name, errS)
+			if err == nil {
+				err = errS
+			}
 		}
 	}()
 
-	if err = cluster.Terminate(); err != nil {
+	ev.stepStarted("termination")
+	err = cluster.Terminate()
+	ev.stepFinished("termination", err)
+	if err != nil {
 		status = kluster.FailedTerminationStatus.String()
 		s.ui.Log.Errorf("failed to delete the cluster %s. %s", cluster.Name, err)
 		return
@@ -1,7 +1,6 @@
 package v1
 
 import (
-	"os"
 	"path/filepath"
 
 	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
@@ -39,5 +38,5 @@ func (s *KubeKitService) DeleteClusterConfig(ctx context.Context, in *apiv1.Dele
 
 func (s *KubeKitService) doDeleteClusterConfig(path string) error {
 	baseDir := filepath.Dir(path)
-	return os.RemoveAll(baseDir)
+	return kluster.DeleteConfig(baseDir)
 }
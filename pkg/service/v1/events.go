@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kraken/ui"
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	"github.com/liferaft/kubekit/pkg/kluster"
+)
+
+// ansiColors matches the ANSI color codes the UI adds to the task names
+var ansiColors = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// eventer sends the events of a long running action, such as apply or delete,
+// to be streamed to the client and records the steps and result in the action
+// operation. Without events channel the events are not streamed, and a nil
+// eventer discards all the events
+type eventer struct {
+	clusterName string
+	platform    string
+	op          *operation
+	events      chan *apiv1.Event
+}
+
+// newEventer returns an eventer that only records the events in the given
+// operation, used by the non streaming actions
+func newEventer(cluster *kluster.Kluster, op *operation) *eventer {
+	return &eventer{
+		clusterName: cluster.Name,
+		platform:    cluster.Platform(),
+		op:          op,
+	}
+}
+
+type eventSender interface {
+	Send(*apiv1.Event) error
+}
+
+// streamEvents executes the given action sending to the stream all the events
+// emitted by the action and by the cluster UI. It returns when the action is
+// done, after all the events are sent
+func streamEvents(stream eventSender, cluster *kluster.Kluster, op *operation, action func(*eventer)) error {
+	ev := newEventer(cluster, op)
+	ev.events = make(chan *apiv1.Event, 100)
+
+	go func() {
+		defer close(ev.events)
+		unsubscribe := cluster.Subscribe(ev.notification)
+		defer unsubscribe()
+
+		action(ev)
+	}()
+
+	// if the client is gone, keep receiving the events until the action is done,
+	// so the action is never blocked sending them
+	var err error
+	for e := range ev.events {
+		if err != nil {
+			continue
+		}
+		err = stream.Send(e)
+	}
+
+	return err
+}
+
+func (ev *eventer) send(e *apiv1.Event) {
+	if ev == nil || ev.events == nil {
+		return
+	}
+	e.Api = apiVersion
+	e.ClusterName = ev.clusterName
+	e.Timestamp = time.Now().Unix()
+	if ev.op != nil {
+		e.OperationId = ev.op.info.Id
+	}
+	ev.events <- e
+}
+
+// stepStarted emits the event of a starting step
+func (ev *eventer) stepStarted(step string) {
+	if ev != nil && ev.op != nil {
+		ev.op.stepStarted(step)
+	}
+	ev.send(&apiv1.Event{
+		Type: apiv1.EventType_STEP_STARTED,
+		Step: step,
+	})
+}
+
+// stepFinished emits the event of a finished step, with the error if it failed
+func (ev *eventer) stepFinished(step string, err error) {
+	if ev != nil && ev.op != nil {
+		ev.op.stepFinished(step, err)
+	}
+	e := &apiv1.Event{
+		Type: apiv1.EventType_STEP_FINISHED,
+		Step: step,
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	ev.send(e)
+}
+
+// status emits the final event with the cluster status and finish the operation
+func (ev *eventer) status(status string, err error) {
+	if ev != nil && ev.op != nil {
+		ev.op.finish(status, err)
+	}
+	e := &apiv1.Event{
+		Type:   apiv1.EventType_STATUS,
+		Status: status,
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	ev.send(e)
+}
+
+// notification emits the event of a task reported to the cluster UI. The tasks
+// reported by the platform are Terraform resources, the others are Ansible or
+// KubeKit tasks on the nodes
+func (ev *eventer) notification(n ui.Notification) {
+	e := &apiv1.Event{
+		Type:     apiv1.EventType_TASK,
+		Location: n.Location,
+		Resource: ansiColors.ReplaceAllString(n.Resource, ""),
+		Message:  ansiColors.ReplaceAllString(n.Status, ""),
+	}
+	if n.Location == ev.platform {
+		e.Type = apiv1.EventType_RESOURCE
+	}
+	// the UI reports the failed tasks in red
+	if strings.HasPrefix(n.Resource, ui.Red) {
+		e.Error = "task failed"
+	}
+	ev.send(e)
+}
@@ -0,0 +1,58 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/kraken/ui"
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+)
+
+func TestEventer_notification(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification ui.Notification
+		wantType     apiv1.EventType
+		wantResource string
+		wantMessage  string
+		wantErr      bool
+	}{
+		{"terraform resource", ui.Notification{Location: "ec2", Resource: "aws_instance.master[0]", Status: "Creating..."}, apiv1.EventType_RESOURCE, "aws_instance.master[0]", "Creating...", false},
+		{"ansible task", ui.Notification{Location: "master", Resource: ui.Green + "etcd", Status: "Configuration complete after 1m0s"}, apiv1.EventType_TASK, "etcd", "Configuration complete after 1m0s", false},
+		{"failed ansible task", ui.Notification{Location: "worker", Resource: ui.Red + "kubelet", Status: "Configuration complete after 5s"}, apiv1.EventType_TASK, "kubelet", "Configuration complete after 5s", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev := &eventer{
+				clusterName: "kkdemo",
+				platform:    "ec2",
+				events:      make(chan *apiv1.Event, 1),
+			}
+			ev.notification(tt.notification)
+			got := <-ev.events
+
+			if got.ClusterName != "kkdemo" || got.Api != apiVersion {
+				t.Errorf("eventer.notification() cluster = %q, api = %q, want %q and %q", got.ClusterName, got.Api, "kkdemo", apiVersion)
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("eventer.notification() type = %v, want %v", got.Type, tt.wantType)
+			}
+			if got.Resource != tt.wantResource {
+				t.Errorf("eventer.notification() resource = %q, want %q", got.Resource, tt.wantResource)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("eventer.notification() message = %q, want %q", got.Message, tt.wantMessage)
+			}
+			if (len(got.Error) != 0) != tt.wantErr {
+				t.Errorf("eventer.notification() error = %q, wantErr %v", got.Error, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventer_nil(t *testing.T) {
+	var ev *eventer
+	// a nil eventer, used by the non streaming actions, discards the events
+	ev.stepStarted("provisioning")
+	ev.stepFinished("provisioning", nil)
+	ev.status("running", nil)
+}
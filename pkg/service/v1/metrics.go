@@ -0,0 +1,63 @@
+package v1
+
+import (
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/metrics"
+)
+
+// registerMetrics registers the metrics of the clusters and the operations of
+// this service. They are collected every time the metrics are requested
+func (s *KubeKitService) registerMetrics() {
+	metrics.Register(
+		metrics.NewGaugeFunc(
+			"kubekit_clusters",
+			"Number of clusters by platform and status.",
+			s.clustersMetrics,
+			"platform", "status",
+		),
+		metrics.NewGaugeFunc(
+			"kubekit_operations_in_flight",
+			"Number of running operations by action, such as apply or delete.",
+			s.operations.runningMetrics,
+			"action",
+		),
+	)
+}
+
+func (s *KubeKitService) clustersMetrics() []metrics.Sample {
+	ci, err := kluster.GetClustersInfo(s.clustersPath, nil)
+	if err != nil {
+		s.ui.Log.Warnf("failed to get the clusters for the metrics. %s", err)
+		return nil
+	}
+
+	type key struct{ platform, status string }
+	count := map[key]float64{}
+	for _, i := range ci {
+		count[key{i.Platform, i.Status}]++
+	}
+
+	samples := make([]metrics.Sample, 0, len(count))
+	for k, v := range count {
+		samples = append(samples, metrics.Sample{LabelValues: []string{k.platform, k.status}, Value: v})
+	}
+	return samples
+}
+
+// runningMetrics returns the number of running operations by action. The
+// actions always reported are apply and delete, even if there are none
+func (ops *operations) runningMetrics() []metrics.Sample {
+	count := map[string]float64{"apply": 0, "delete": 0}
+	for _, op := range ops.all("") {
+		if op.State == apiv1.OperationState_OPERATION_RUNNING {
+			count[op.Action]++
+		}
+	}
+
+	samples := make([]metrics.Sample, 0, len(count))
+	for action, v := range count {
+		samples = append(samples, metrics.Sample{LabelValues: []string{action}, Value: v})
+	}
+	return samples
+}
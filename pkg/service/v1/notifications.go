@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/liferaft/kubekit/pkg/kluster"
+	"github.com/liferaft/kubekit/pkg/notifier"
+	"github.com/liferaft/kubekit/pkg/server"
+	context "golang.org/x/net/context"
+)
+
+// certificatesCheckInterval is how often the certificates of every cluster are
+// checked to notify the ones about to expire
+const certificatesCheckInterval = 24 * time.Hour
+
+// withActor returns the operation context with the user of the request, to be
+// reported in the cluster lifecycle events
+func withActor(opCtx, reqCtx context.Context) context.Context {
+	actor := "anonymous"
+	if id, ok := server.IdentityFromContext(reqCtx); ok && id != nil {
+		actor = id.User
+	}
+	return notifier.WithActor(opCtx, actor)
+}
+
+// watchCertificates notifies periodically the clusters with certificates about
+// to expire, only if there are webhooks to notify
+func (s *KubeKitService) watchCertificates() {
+	if s.dry || !kluster.NotificationsEnabled() {
+		return
+	}
+
+	go func() {
+		for {
+			s.notifyExpiringCertificates()
+			time.Sleep(certificatesCheckInterval)
+		}
+	}()
+}
+
+func (s *KubeKitService) notifyExpiringCertificates() {
+	clusters, err := kluster.List(s.clustersPath)
+	if err != nil {
+		s.ui.Log.Warnf("failed to list the clusters to check the certificates. %s", err)
+		return
+	}
+	for _, cluster := range clusters {
+		// the clusters not configured yet don't have certificates
+		if _, err := cluster.NotifyExpiringCertificates(); err != nil {
+			s.ui.Log.Debugf("not checked the certificates of cluster %s. %s", cluster.Name, err)
+		}
+	}
+}
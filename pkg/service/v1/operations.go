@@ -0,0 +1,251 @@
+package v1
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	uuid "github.com/nu7hatch/gouuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	context "golang.org/x/net/context"
+)
+
+// maxOperations is the maximum number of operations kept in the history. When
+// the limit is reached the oldest finished operations are removed
+const maxOperations = 100
+
+// operation is a long running action on a cluster, such as apply or delete.
+// It records the steps and the result of the action and it can be canceled
+// with the context cancel function
+type operation struct {
+	mu     sync.Mutex
+	info   *apiv1.Operation
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// operations is the history of the operations executed by the service
+type operations struct {
+	mu   sync.Mutex
+	list []*operation
+	max  int
+}
+
+func newOperations(max int) *operations {
+	return &operations{
+		max: max,
+	}
+}
+
+// start creates and registers a new running operation. The operation context
+// is not the request context, so the operation continues after the request is
+// done until it finish or it's canceled
+func (ops *operations) start(clusterName, action string) (*operation, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the operation ID. %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	op := &operation{
+		info: &apiv1.Operation{
+			Id:          id.String(),
+			ClusterName: clusterName,
+			Action:      action,
+			State:       apiv1.OperationState_OPERATION_RUNNING,
+			StartTime:   time.Now().Unix(),
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+
+	ops.list = append(ops.list, op)
+	ops.prune()
+
+	return op, nil
+}
+
+// prune removes the oldest finished operations until the history is within the
+// limit. The running operations are never removed
+func (ops *operations) prune() {
+	excess := len(ops.list) - ops.max
+	if excess <= 0 {
+		return
+	}
+	list := make([]*operation, 0, len(ops.list))
+	for _, op := range ops.list {
+		if excess > 0 && op.done() {
+			excess--
+			continue
+		}
+		list = append(list, op)
+	}
+	ops.list = list
+}
+
+// get returns the operation with the given ID or nil if it's not found
+func (ops *operations) get(id string) *operation {
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+
+	for _, op := range ops.list {
+		if op.info.Id == id {
+			return op
+		}
+	}
+	return nil
+}
+
+// all returns a copy of all the operations, or only the operations of the given
+// cluster, sorted from the oldest to the newest
+func (ops *operations) all(clusterName string) []*apiv1.Operation {
+	ops.mu.Lock()
+	defer ops.mu.Unlock()
+
+	list := []*apiv1.Operation{}
+	for _, op := range ops.list {
+		if len(clusterName) != 0 && op.info.ClusterName != clusterName {
+			continue
+		}
+		list = append(list, op.snapshot())
+	}
+	return list
+}
+
+// snapshot returns a copy of the operation information
+func (op *operation) snapshot() *apiv1.Operation {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	return proto.Clone(op.info).(*apiv1.Operation)
+}
+
+func (op *operation) done() bool {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	return op.info.State != apiv1.OperationState_OPERATION_RUNNING
+}
+
+// stepStarted records the start of a step
+func (op *operation) stepStarted(name string) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	op.info.Steps = append(op.info.Steps, &apiv1.OperationStep{
+		Name:      name,
+		StartTime: time.Now().Unix(),
+	})
+}
+
+// stepFinished records the end of the last started step with the given name
+func (op *operation) stepFinished(name string, err error) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	for i := len(op.info.Steps) - 1; i >= 0; i-- {
+		step := op.info.Steps[i]
+		if step.Name != name || step.EndTime != 0 {
+			continue
+		}
+		step.EndTime = time.Now().Unix()
+		if err != nil {
+			step.Error = err.Error()
+		}
+		return
+	}
+}
+
+// finish records the end of the operation with the final cluster status and
+// the error, if any. An operation with its context done is canceled even if it
+// finished without errors, as some of the steps may not be executed
+func (op *operation) finish(clusterStatus string, err error) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	if op.info.State != apiv1.OperationState_OPERATION_RUNNING {
+		return
+	}
+
+	op.info.EndTime = time.Now().Unix()
+	op.info.Status = clusterStatus
+
+	switch {
+	case op.ctx.Err() != nil:
+		op.info.State = apiv1.OperationState_OPERATION_CANCELED
+	case err != nil:
+		op.info.State = apiv1.OperationState_OPERATION_FAILED
+	default:
+		op.info.State = apiv1.OperationState_OPERATION_SUCCEEDED
+	}
+	if err != nil {
+		op.info.Error = err.Error()
+	}
+
+	// release the context resources
+	op.cancel()
+}
+
+// ListOperations returns the operations in the service history, optionally
+// filtered by cluster name
+func (s *KubeKitService) ListOperations(ctx context.Context, in *apiv1.ListOperationsRequest) (*apiv1.ListOperationsResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	return &apiv1.ListOperationsResponse{
+		Api:        apiVersion,
+		Operations: s.operations.all(in.ClusterName),
+	}, nil
+}
+
+// GetOperation returns the operation with the given ID, with the steps done so
+// far if it's still running
+func (s *KubeKitService) GetOperation(ctx context.Context, in *apiv1.GetOperationRequest) (*apiv1.GetOperationResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	op := s.operations.get(in.Id)
+	if op == nil {
+		return nil, status.Errorf(codes.NotFound, "operation %q not found", in.Id)
+	}
+
+	return &apiv1.GetOperationResponse{
+		Api:       apiVersion,
+		Operation: op.snapshot(),
+	}, nil
+}
+
+// CancelOperation cancels a running operation. Terraform is halted after the
+// resources in progress are done and the commands in execution on the nodes
+// are killed. The operation is canceled once the action is stopped, use
+// GetOperation to know when it's done
+func (s *KubeKitService) CancelOperation(ctx context.Context, in *apiv1.CancelOperationRequest) (*apiv1.CancelOperationResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	op := s.operations.get(in.Id)
+	if op == nil {
+		return nil, status.Errorf(codes.NotFound, "operation %q not found", in.Id)
+	}
+	if op.done() {
+		return nil, status.Errorf(codes.FailedPrecondition, "operation %q is not running", in.Id)
+	}
+
+	s.ui.Log.Infof("canceling the operation %s of cluster %q", in.Id, op.info.ClusterName)
+	op.cancel()
+
+	return &apiv1.CancelOperationResponse{
+		Api:       apiVersion,
+		Operation: op.snapshot(),
+	}, nil
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/johandry/log"
+	"github.com/kraken/ui"
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	context "golang.org/x/net/context"
+)
+
+func TestOperation_finish(t *testing.T) {
+	tests := []struct {
+		name      string
+		cancel    bool
+		err       error
+		wantState apiv1.OperationState
+	}{
+		{"succeeded", false, nil, apiv1.OperationState_OPERATION_SUCCEEDED},
+		{"failed", false, fmt.Errorf("failed to provision"), apiv1.OperationState_OPERATION_FAILED},
+		{"canceled", true, context.Canceled, apiv1.OperationState_OPERATION_CANCELED},
+		{"canceled without error", true, nil, apiv1.OperationState_OPERATION_CANCELED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := newOperations(maxOperations)
+			op, err := ops.start("kkdemo", "apply")
+			if err != nil {
+				t.Fatalf("operations.start() error = %v", err)
+			}
+
+			op.stepStarted("provisioning")
+			if tt.cancel {
+				op.cancel()
+			}
+			op.stepFinished("provisioning", tt.err)
+			op.finish("running", tt.err)
+
+			got := op.snapshot()
+			if got.State != tt.wantState {
+				t.Errorf("operation.finish() state = %v, want %v", got.State, tt.wantState)
+			}
+			if got.EndTime == 0 {
+				t.Errorf("operation.finish() end time not set")
+			}
+			if len(got.Steps) != 1 || got.Steps[0].Name != "provisioning" || got.Steps[0].EndTime == 0 {
+				t.Errorf("operation.finish() steps = %v, want one finished step 'provisioning'", got.Steps)
+			}
+			if (len(got.Error) != 0) != (tt.err != nil) {
+				t.Errorf("operation.finish() error = %q, want error %v", got.Error, tt.err)
+			}
+			if op.ctx.Err() == nil {
+				t.Errorf("operation.finish() the operation context is not done")
+			}
+		})
+	}
+}
+
+func TestOperations_prune(t *testing.T) {
+	ops := newOperations(2)
+
+	running, _ := ops.start("kkdemo", "apply")
+	finished, _ := ops.start("kkdemo", "delete")
+	finished.finish("terminated", nil)
+	ops.start("kkdemo2", "update")
+
+	if got := len(ops.all("")); got != 2 {
+		t.Fatalf("operations.all() returned %d operations, want 2", got)
+	}
+	if ops.get(finished.info.Id) != nil {
+		t.Errorf("operations.prune() the finished operation %s was not removed", finished.info.Id)
+	}
+	if ops.get(running.info.Id) == nil {
+		t.Errorf("operations.prune() the running operation %s was removed", running.info.Id)
+	}
+	if got := len(ops.all("kkdemo2")); got != 1 {
+		t.Errorf("operations.all(\"kkdemo2\") returned %d operations, want 1", got)
+	}
+}
+
+func TestKubeKitService_CancelOperation(t *testing.T) {
+	s := &KubeKitService{
+		ui:         ui.New(false, log.StdLogger()),
+		operations: newOperations(maxOperations),
+	}
+	op, _ := s.operations.start("kkdemo", "apply")
+
+	if _, err := s.CancelOperation(context.Background(), &apiv1.CancelOperationRequest{Id: "unknown"}); err == nil {
+		t.Errorf("KubeKitService.CancelOperation() expected an error canceling an unknown operation")
+	}
+
+	if _, err := s.CancelOperation(context.Background(), &apiv1.CancelOperationRequest{Id: op.info.Id}); err != nil {
+		t.Fatalf("KubeKitService.CancelOperation() error = %v", err)
+	}
+	if op.ctx.Err() == nil {
+		t.Errorf("KubeKitService.CancelOperation() the operation context is not done")
+	}
+
+	// the action finish when it sees the context is done
+	op.finish("failed provisioning", op.ctx.Err())
+	res, err := s.GetOperation(context.Background(), &apiv1.GetOperationRequest{Id: op.info.Id})
+	if err != nil {
+		t.Fatalf("KubeKitService.GetOperation() error = %v", err)
+	}
+	if res.Operation.State != apiv1.OperationState_OPERATION_CANCELED {
+		t.Errorf("KubeKitService.GetOperation() state = %v, want %v", res.Operation.State, apiv1.OperationState_OPERATION_CANCELED)
+	}
+
+	if _, err := s.CancelOperation(context.Background(), &apiv1.CancelOperationRequest{Id: op.info.Id}); err == nil {
+		t.Errorf("KubeKitService.CancelOperation() expected an error canceling a finished operation")
+	}
+}
+
+func TestOperations_runningMetrics(t *testing.T) {
+	ops := newOperations(maxOperations)
+	ops.start("kkdemo", "apply")
+	ops.start("kkdemo2", "apply")
+	finished, _ := ops.start("kkdemo3", "delete")
+	finished.finish("terminated", nil)
+	ops.start("kkdemo4", "update")
+
+	got := map[string]float64{}
+	for _, sample := range ops.runningMetrics() {
+		got[sample.LabelValues[0]] = sample.Value
+	}
+	want := map[string]float64{"apply": 2, "delete": 0, "update": 1}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("operations.runningMetrics() = %v, want %v", got, want)
+	}
+}
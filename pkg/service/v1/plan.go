@@ -0,0 +1,87 @@
+package v1
+
+import (
+	apiv1 "github.com/liferaft/kubekit/api/kubekit/v1"
+	"github.com/liferaft/kubekit/pkg/kluster"
+	context "golang.org/x/net/context"
+)
+
+// PlanCluster returns the changes to apply to the cluster infrastructure,
+// grouped by node pool, without applying them
+func (s *KubeKitService) PlanCluster(ctx context.Context, in *apiv1.PlanClusterRequest) (*apiv1.PlanClusterResponse, error) {
+	if err := s.checkAPIVersion(in.Api); err != nil {
+		return nil, err
+	}
+
+	cluster, err := kluster.LoadCluster(in.ClusterName, s.clustersPath, s.ui)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't plan if dry
+	if s.dry {
+		return &apiv1.PlanClusterResponse{
+			Api:         apiVersion,
+			ClusterName: in.ClusterName,
+			Destroy:     in.Destroy,
+		}, nil
+	}
+
+	cluster.WithContext(ctx)
+
+	// the SSH keys are required for the terraform templates and provisioner
+	if err := cluster.HandleKeys(); err != nil {
+		return nil, err
+	}
+
+	report, err := cluster.PlanReport(in.Destroy)
+	if err != nil {
+		return nil, err
+	}
+
+	return planClusterResponse(report), nil
+}
+
+func planClusterResponse(report *kluster.PlanReport) *apiv1.PlanClusterResponse {
+	pools := make([]*apiv1.PlanNodePool, 0, len(report.NodePools))
+	for _, pool := range report.NodePools {
+		resources := make([]*apiv1.PlanResourceChange, 0, len(pool.Resources))
+		for _, rc := range pool.Resources {
+			attrs := make([]*apiv1.PlanAttributeChange, 0, len(rc.Attributes))
+			for _, attr := range rc.Attributes {
+				attrs = append(attrs, &apiv1.PlanAttributeChange{
+					Name:              attr.Name,
+					Before:            attr.Before,
+					After:             attr.After,
+					Computed:          attr.Computed,
+					Sensitive:         attr.Sensitive,
+					ForcesReplacement: attr.ForcesReplacement,
+				})
+			}
+			resources = append(resources, &apiv1.PlanResourceChange{
+				Address:    rc.Address,
+				Mode:       rc.Mode,
+				Type:       rc.Type,
+				Name:       rc.Name,
+				Action:     rc.Action,
+				Attributes: attrs,
+			})
+		}
+		pools = append(pools, &apiv1.PlanNodePool{
+			Name:      pool.Name,
+			Resources: resources,
+		})
+	}
+
+	return &apiv1.PlanClusterResponse{
+		Api:         apiVersion,
+		ClusterName: report.ClusterName,
+		Platform:    report.Platform,
+		Destroy:     report.Destroy,
+		ToAdd:       int32(report.ToAdd),
+		ToChange:    int32(report.ToChange),
+		ToDestroy:   int32(report.ToDestroy),
+		Destructive: report.Destructive,
+		NodePools:   pools,
+	}
+}
@@ -16,6 +16,7 @@ type KubeKitService struct {
 	clustersPath string
 	ui           *ui.UI
 	dry          bool
+	operations   *operations
 }
 
 // NewKubeKitService creates a new KubeKit service
@@ -24,11 +25,16 @@ func NewKubeKitService(clustersPath string, parentUI *ui.UI, dry bool) *KubeKitS
 		parentUI.Log.Warn("Starting the KubeKit service in dry mode. API calls will be inert.")
 	}
 
-	return &KubeKitService{
+	s := &KubeKitService{
 		clustersPath: clustersPath,
 		ui:           parentUI,
 		dry:          dry,
+		operations:   newOperations(maxOperations),
 	}
+	s.registerMetrics()
+	s.watchCertificates()
+
+	return s
 }
 
 // Register registers this service to the given gRPC server
@@ -30,9 +30,39 @@ func (s *KubeKitService) UpdateCluster(ctx context.Context, in *apiv1.UpdateClus
 		}, nil
 	}
 
+	op, err := s.operations.start(in.ClusterName, "update")
+	if err != nil {
+		return nil, err
+	}
+	ev := newEventer(cluster, op)
+
+	ev.stepStarted("configuration")
+	err = updateClusterConfig(cluster, in)
+	ev.stepFinished("configuration", err)
+
+	if err == nil && len(in.Credentials) != 0 {
+		ev.stepStarted("credentials")
+		err = s.updateClusterCredentials(cluster, in.Credentials)
+		ev.stepFinished("credentials", err)
+	}
+
+	ev.status(cluster.State[cluster.Platform()].Status, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiv1.UpdateClusterResponse{
+		Api:         apiVersion,
+		OperationId: op.info.Id,
+	}, nil
+}
+
+// updateClusterConfig updates the cluster configuration with the received
+// variables and resources
+func updateClusterConfig(cluster *kluster.Kluster, in *apiv1.UpdateClusterRequest) error {
 	if len(in.Variables) != 0 {
 		if err := cluster.Update(in.Variables); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
@@ -40,33 +70,27 @@ func (s *KubeKitService) UpdateCluster(ctx context.Context, in *apiv1.UpdateClus
 		cluster.Resources = in.Resources
 	}
 
-	if err := cluster.Save(); err != nil {
-		return nil, err
-	}
-
-	if len(in.Credentials) != 0 {
-		platform := cluster.Platform()
-		path := filepath.Join(filepath.Dir(cluster.Path()), ".credentials")
+	return cluster.Save()
+}
 
-		credentials := kluster.NewCredentials(in.ClusterName, platform, path)
+// updateClusterCredentials updates the cluster credentials file with the
+// received credentials
+func (s *KubeKitService) updateClusterCredentials(cluster *kluster.Kluster, newCredentials map[string]string) error {
+	platform := cluster.Platform()
+	path := filepath.Join(filepath.Dir(cluster.Path()), ".credentials")
 
-		// take the parameters from the file. ignore err, the file may not be there
-		s.ui.Log.Debugf("reading credentials from file")
-		if rerr := credentials.Read(); rerr != nil {
-			s.ui.Log.Warnf("cannot read the credentials file. %s", rerr)
-		}
+	credentials := kluster.NewCredentials(cluster.Name, platform, path)
 
-		s.ui.Log.Debugf("assinging new credentials")
-		if err := credentials.AssignFromMap(in.Credentials); err != nil {
-			return nil, err
-		}
+	// take the parameters from the file. ignore err, the file may not be there
+	s.ui.Log.Debugf("reading credentials from file")
+	if rerr := credentials.Read(); rerr != nil {
+		s.ui.Log.Warnf("cannot read the credentials file. %s", rerr)
+	}
 
-		if err := credentials.Write(); err != nil {
-			return nil, err
-		}
+	s.ui.Log.Debugf("assinging new credentials")
+	if err := credentials.AssignFromMap(newCredentials); err != nil {
+		return err
 	}
 
-	return &apiv1.UpdateClusterResponse{
-		Api: apiVersion,
-	}, nil
+	return credentials.Write()
 }
@@ -0,0 +1,30 @@
+package v1
+
+import "github.com/liferaft/kubekit/pkg/server"
+
+const methodPrefix = "/kubekit.v1.Kubekit/"
+
+// Verbs maps every KubeKit gRPC method to the verb checked by the server
+// authorization policy. The methods not in the map are denied. The Version
+// method only requires an authenticated caller and Token has its own verb, it
+// gives access to the cluster
+var Verbs = map[string]string{
+	methodPrefix + "Version":             server.VerbNone,
+	methodPrefix + "Token":               server.VerbToken,
+	methodPrefix + "GetClusters":         server.VerbGet,
+	methodPrefix + "Describe":            server.VerbGet,
+	methodPrefix + "ListOperations":      server.VerbGet,
+	methodPrefix + "GetOperation":        server.VerbGet,
+	methodPrefix + "PlanCluster":         server.VerbGet,
+	methodPrefix + "CheckCluster":        server.VerbGet,
+	methodPrefix + "Init":                server.VerbApply,
+	methodPrefix + "Apply":               server.VerbApply,
+	methodPrefix + "ApplyStream":         server.VerbApply,
+	methodPrefix + "UpdateCluster":       server.VerbApply,
+	methodPrefix + "CancelOperation":     server.VerbApply,
+	methodPrefix + "Backup":              server.VerbApply,
+	methodPrefix + "Restore":             server.VerbApply,
+	methodPrefix + "Delete":              server.VerbDelete,
+	methodPrefix + "DeleteStream":        server.VerbDelete,
+	methodPrefix + "DeleteClusterConfig": server.VerbDelete,
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nightlyone/lockfile"
+)
+
+// Local is a storage in a local directory, the objects are files and the keys
+// are the files path relative to the directory
+type Local struct {
+	root string
+}
+
+// Won't compile if Local can't be realized by a Storage
+var _ Storage = (*Local)(nil)
+
+// NewLocal returns the local storage in the given directory
+func NewLocal(root string) *Local {
+	return &Local{
+		root: root,
+	}
+}
+
+// Name returns the local directory of the storage
+func (l *Local) Name() string {
+	return l.root
+}
+
+// Filename returns the file of the object with the given key
+func (l *Local) Filename(key string) string {
+	return filepath.Join(l.root, filepath.FromSlash(key))
+}
+
+// List returns the keys of the files with the given prefix
+func (l *Local) List(prefix string) ([]string, error) {
+	keys := []string{}
+	if _, err := os.Stat(l.root); os.IsNotExist(err) {
+		return nil, fmt.Errorf("storage directory not found (%s)", l.root)
+	}
+
+	err := filepath.Walk(l.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, path)
+		if err != nil {
+			return nil
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) && !isLockKey(key) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+
+	return keys, err
+}
+
+// Get returns the content of the file
+func (l *Local) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(l.Filename(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Put creates or replaces the file, creating the directories if needed
+func (l *Local) Put(key string, data []byte) error {
+	filename := l.Filename(key)
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
+
+// Delete removes the file
+func (l *Local) Delete(key string) error {
+	err := os.Remove(l.Filename(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Lock locks the file using a lock file with the process PID
+func (l *Local) Lock(key string) (Unlocker, error) {
+	filename := l.Filename(key)
+	// Lockfile cannot work with relative paths, so make it absolute
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, err
+	}
+
+	lock, err := lockfile.New(filename + LockSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("cannot init the lock for file %q: %v", filename, err)
+	}
+	if err := lock.TryLock(); err != nil {
+		return nil, fmt.Errorf("cannot lock the file %q: %v", filename, err)
+	}
+	return lock, nil
+}
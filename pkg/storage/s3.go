@@ -0,0 +1,311 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	uuid "github.com/nu7hatch/gouuid"
+)
+
+// DefaultLockTTL is the time a lock object is valid. A lock older than this is
+// considered abandoned, for example by a killed process, and can be taken
+const DefaultLockTTL = 3 * time.Hour
+
+// S3 is a storage in an S3 bucket or S3-compatible object store like MinIO.
+// The locks are objects with the key of the locked object and the suffix
+// `.lock`, containing who and when the lock was taken. The locks require an
+// object store with conditional writes (If-None-Match and If-Match), like AWS
+// S3 or MinIO
+type S3 struct {
+	bucket  string
+	prefix  string
+	client  s3iface.S3API
+	LockTTL time.Duration
+}
+
+// Won't compile if S3 can't be realized by a Storage
+var _ Storage = (*S3)(nil)
+
+// s3Lock is the content of a lock object
+type s3Lock struct {
+	ID      string    `json:"id"`
+	Owner   string    `json:"owner"`
+	Created time.Time `json:"created"`
+}
+
+// NewS3 returns the storage in the given bucket, all the objects are under the
+// given prefix. The credentials are taken from the environment or the AWS
+// shared configuration. Set the endpoint to use an S3-compatible object store
+func NewS3(bucket, prefix, region, endpoint string) (*S3, error) {
+	cfg := aws.NewConfig()
+	if len(region) != 0 {
+		cfg = cfg.WithRegion(region)
+	} else if len(os.Getenv("AWS_REGION")) == 0 {
+		cfg = cfg.WithRegion("us-east-1")
+	}
+	if len(endpoint) != 0 {
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the session to the S3 storage. %s", err)
+	}
+
+	return &S3{
+		bucket:  bucket,
+		prefix:  strings.Trim(prefix, "/"),
+		client:  s3.New(sess),
+		LockTTL: DefaultLockTTL,
+	}, nil
+}
+
+// Name returns the S3 URL of the storage
+func (s *S3) Name() string {
+	return "s3://" + path.Join(s.bucket, s.prefix)
+}
+
+func (s *S3) objectKey(key string) string {
+	if len(s.prefix) == 0 {
+		return key
+	}
+	return s.prefix + "/" + key
+}
+
+// List returns the keys of the objects with the given prefix
+func (s *S3) List(prefix string) ([]string, error) {
+	keys := []string{}
+	base := s.objectKey("")
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.objectKey(prefix)),
+	}
+	err := s.client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			key := strings.TrimPrefix(aws.StringValue(obj.Key), base)
+			if !isLockKey(key) {
+				keys = append(keys, key)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the objects in %s. %s", s.Name(), err)
+	}
+
+	return keys, nil
+}
+
+// Get returns the content of the object
+func (s *S3) Get(key string) ([]byte, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get %q from %s. %s", key, s.Name(), err)
+	}
+	defer out.Body.Close()
+
+	return ioutil.ReadAll(out.Body)
+}
+
+// Put creates or replaces the object
+func (s *S3) Put(key string, data []byte) error {
+	_, err := s.client.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put %q to %s. %s", key, s.Name(), err)
+	}
+	return nil
+}
+
+// Delete removes the object
+func (s *S3) Delete(key string) error {
+	_, err := s.client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete %q from %s. %s", key, s.Name(), err)
+	}
+	return nil
+}
+
+// Lock locks the object creating the lock object. The lock object is created
+// with a conditional write (If-None-Match), and an expired lock is replaced
+// only if it's the same lock that was read (If-Match), so when many processes
+// take the lock at same time only one of them gets it
+func (s *S3) Lock(key string) (Unlocker, error) {
+	lockKey := key + LockSuffix
+
+	current, etag, err := s.getLock(lockKey)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && time.Since(current.Created) < s.LockTTL {
+		return nil, fmt.Errorf("cannot lock %q in %s, it's locked by %s since %s", key, s.Name(), current.Owner, current.Created.Format(time.RFC3339))
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the lock ID. %s", err)
+	}
+	lock := &s3Lock{
+		ID:      id.String(),
+		Owner:   lockOwner(),
+		Created: time.Now(),
+	}
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return nil, err
+	}
+
+	condition := ifNoneMatch("*")
+	if current != nil {
+		condition = ifMatch(etag)
+	}
+	if err := s.putIf(lockKey, data, condition); err != nil {
+		if isPreconditionFailed(err) {
+			return nil, fmt.Errorf("cannot lock %q in %s, it was locked by other process at the same time", key, s.Name())
+		}
+		return nil, err
+	}
+
+	return &s3Unlocker{storage: s, key: lockKey, id: lock.ID}, nil
+}
+
+// putIf creates or replaces the object only if the given condition is true
+func (s *S3) putIf(key string, data []byte, condition request.Option) error {
+	_, err := s.client.PutObjectWithContext(aws.BackgroundContext(), &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(key)),
+		Body:   bytes.NewReader(data),
+	}, condition)
+	if err != nil {
+		if isPreconditionFailed(err) {
+			return err
+		}
+		return fmt.Errorf("failed to put %q to %s. %s", key, s.Name(), err)
+	}
+	return nil
+}
+
+// getLock returns the lock object and its ETag, or nil if there is no lock
+func (s *S3) getLock(lockKey string) (*s3Lock, string, error) {
+	out, err := s.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.objectKey(lockKey)),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to get %q from %s. %s", lockKey, s.Name(), err)
+	}
+	defer out.Body.Close()
+
+	data, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var lock s3Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, "", fmt.Errorf("the lock %q in %s is corrupted. %s", lockKey, s.Name(), err)
+	}
+	return &lock, aws.StringValue(out.ETag), nil
+}
+
+// s3Unlocker removes the lock object if it's still owned by this lock
+type s3Unlocker struct {
+	storage *S3
+	key     string
+	id      string
+}
+
+func (u *s3Unlocker) Unlock() error {
+	current, etag, err := u.storage.getLock(u.key)
+	if err != nil {
+		return err
+	}
+	if current == nil || current.ID != u.id {
+		return fmt.Errorf("the lock %q in %s is not owned by this process", u.key, u.storage.Name())
+	}
+
+	// the lock is deleted only if it wasn't replaced after it was read
+	_, err = u.storage.client.DeleteObjectWithContext(aws.BackgroundContext(), &s3.DeleteObjectInput{
+		Bucket: aws.String(u.storage.bucket),
+		Key:    aws.String(u.storage.objectKey(u.key)),
+	}, ifMatch(etag))
+	if err != nil && !isNotFound(err) {
+		if isPreconditionFailed(err) {
+			return fmt.Errorf("the lock %q in %s is not owned by this process", u.key, u.storage.Name())
+		}
+		return fmt.Errorf("failed to delete %q from %s. %s", u.key, u.storage.Name(), err)
+	}
+	return nil
+}
+
+// ifNoneMatch is the condition to write an object only if its ETag doesn't
+// match, with "*" the object is written only if it doesn't exists
+func ifNoneMatch(etag string) request.Option {
+	return func(r *request.Request) {
+		r.HTTPRequest.Header.Set("If-None-Match", etag)
+	}
+}
+
+// ifMatch is the condition to write or delete an object only if its ETag match
+func ifMatch(etag string) request.Option {
+	return func(r *request.Request) {
+		r.HTTPRequest.Header.Set("If-Match", etag)
+	}
+}
+
+// isPreconditionFailed returns true if the error is because the condition of a
+// conditional request failed, or there was a concurrent conditional request
+func isPreconditionFailed(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		switch reqErr.StatusCode() {
+		case http.StatusPreconditionFailed, http.StatusConflict:
+			return true
+		}
+	}
+	return false
+}
+
+func lockOwner() string {
+	hostname, _ := os.Hostname()
+	user := os.Getenv("USER")
+	return fmt.Sprintf("%s@%s (pid %d)", user, hostname, os.Getpid())
+}
+
+func isNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
+}
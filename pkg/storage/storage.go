@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrNotFound is returned by Get when the object does not exists
+var ErrNotFound = errors.New("object not found")
+
+// Storage is where the clusters configuration and Terraform state are stored.
+// Every object is identified by a key, a slash separated path relative to the
+// storage root, such as `<uuid>/cluster.yaml` or `<uuid>/.tfstate/ec2.tfstate`
+type Storage interface {
+	// Name returns a human readable location of the storage
+	Name() string
+	// List returns the keys of all the objects with the given prefix, the lock
+	// objects are not included
+	List(prefix string) ([]string, error)
+	// Get returns the content of the object, or ErrNotFound if doesn't exists
+	Get(key string) ([]byte, error)
+	// Put creates or replaces the object with the given content
+	Put(key string, data []byte) error
+	// Delete removes the object, it's not an error if doesn't exists
+	Delete(key string) error
+	// Lock locks the object so no other process, local or remote, can lock it
+	// until it's unlocked. It fails if the object is already locked
+	Lock(key string) (Unlocker, error)
+}
+
+// Unlocker releases a lock
+type Unlocker interface {
+	Unlock() error
+}
+
+// LockSuffix is appended to the key of an object to identify its lock
+const LockSuffix = ".lock"
+
+// New returns the storage for the given URL. The supported URLs are:
+//
+//   - a local directory path or `file:///path/to/clusters`
+//   - `s3://bucket/prefix?region=us-west-2&endpoint=http://localhost:9000`, the
+//     endpoint is optional and used for S3-compatible object stores like MinIO
+func New(storageURL string) (Storage, error) {
+	if !strings.Contains(storageURL, "://") {
+		return NewLocal(storageURL), nil
+	}
+
+	u, err := url.Parse(storageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage URL %q. %s", storageURL, err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		return NewLocal(u.Path), nil
+	case "s3":
+		if len(u.Host) == 0 {
+			return nil, fmt.Errorf("the bucket is required in the S3 storage URL %q", storageURL)
+		}
+		q := u.Query()
+		return NewS3(u.Host, strings.Trim(u.Path, "/"), q.Get("region"), q.Get("endpoint"))
+	default:
+		return nil, fmt.Errorf("unknown storage type %q, the supported storages are 'file' and 's3'", u.Scheme)
+	}
+}
+
+func isLockKey(key string) bool {
+	return strings.HasSuffix(key, LockSuffix)
+}
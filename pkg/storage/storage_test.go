@@ -0,0 +1,296 @@
+package storage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal S3-compatible object store, with path-style requests and
+// conditional writes, to test the S3 storage without MinIO or AWS
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	etags   map[string]string
+	version int
+}
+
+type fakeS3List struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string   `xml:"Name"`
+	Prefix      string   `xml:"Prefix"`
+	KeyCount    int      `xml:"KeyCount"`
+	IsTruncated bool     `xml:"IsTruncated"`
+	Contents    []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// path: /bucket/key
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	bucket, key := parts[0], ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+
+	switch {
+	case r.Method == http.MethodGet && len(key) == 0:
+		prefix := r.URL.Query().Get("prefix")
+		list := fakeS3List{Name: bucket, Prefix: prefix}
+		keys := []string{}
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			list.Contents = append(list.Contents, struct {
+				Key string `xml:"Key"`
+			}{k})
+		}
+		list.KeyCount = len(keys)
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(list)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			return
+		}
+		w.Header().Set("ETag", f.etags[key])
+		w.Write(data)
+	case r.Method == http.MethodPut:
+		if !f.preconditions(r, key) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`))
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		f.version++
+		f.objects[key] = data
+		f.etags[key] = fmt.Sprintf(`"%d"`, f.version)
+		w.Header().Set("ETag", f.etags[key])
+	case r.Method == http.MethodDelete:
+		if !f.preconditions(r, key) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`<Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`))
+			return
+		}
+		delete(f.objects, key)
+		delete(f.etags, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// preconditions returns true if the If-Match and If-None-Match conditions of
+// the request are true for the given object
+func (f *fakeS3) preconditions(r *http.Request, key string) bool {
+	etag, exists := f.etags[key]
+	if m := r.Header.Get("If-Match"); len(m) != 0 && m != etag {
+		return false
+	}
+	if m := r.Header.Get("If-None-Match"); len(m) != 0 && exists && (m == "*" || m == etag) {
+		return false
+	}
+	return true
+}
+
+func newTestS3(t *testing.T) (*S3, func()) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "AKIAFAKE")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "fake")
+
+	server := httptest.NewServer(&fakeS3{objects: map[string][]byte{}, etags: map[string]string{}})
+	s, err := New("s3://kubekit/team/clusters?region=us-west-2&endpoint=" + server.URL)
+	if err != nil {
+		server.Close()
+		t.Fatalf("New() error = %v", err)
+	}
+	return s.(*S3), server.Close
+}
+
+func newTestLocal(t *testing.T) (*Local, func()) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatalf("failed to create the temporal directory. %s", err)
+	}
+	s, err := New("file://" + dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("New() error = %v", err)
+	}
+	return s.(*Local), func() { os.RemoveAll(dir) }
+}
+
+func TestStorage(t *testing.T) {
+	tests := []struct {
+		name       string
+		newStorage func(t *testing.T) (Storage, func())
+		reentrant  bool // the local locks are owned by the process, not by the lock
+	}{
+		{"local", func(t *testing.T) (Storage, func()) { return newTestLocal(t) }, true},
+		{"s3", func(t *testing.T) (Storage, func()) { return newTestS3(t) }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, cleanup := tt.newStorage(t)
+			defer cleanup()
+
+			if _, err := s.Get("uuid/cluster.yaml"); err != ErrNotFound {
+				t.Errorf("Get() of a missing object error = %v, want %v", err, ErrNotFound)
+			}
+
+			objects := map[string]string{
+				"uuid/cluster.yaml":          "name: kkdemo",
+				"uuid/.tfstate/ec2.tfstate":  "{}",
+				"other/cluster.yaml":         "name: kkother",
+				"other/.tfstate/aks.tfstate": "{}",
+			}
+			for key, content := range objects {
+				if err := s.Put(key, []byte(content)); err != nil {
+					t.Fatalf("Put(%q) error = %v", key, err)
+				}
+			}
+
+			data, err := s.Get("uuid/cluster.yaml")
+			if err != nil || string(data) != "name: kkdemo" {
+				t.Errorf("Get() = %q, %v, want %q", data, err, "name: kkdemo")
+			}
+
+			lock, err := s.Lock("uuid/cluster.yaml")
+			if err != nil {
+				t.Fatalf("Lock() error = %v", err)
+			}
+
+			keys, err := s.List("uuid/")
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			sort.Strings(keys)
+			if strings.Join(keys, ",") != "uuid/.tfstate/ec2.tfstate,uuid/cluster.yaml" {
+				t.Errorf("List() = %v, want the 2 objects of the cluster without locks", keys)
+			}
+
+			if _, err := s.Lock("uuid/cluster.yaml"); err == nil && !tt.reentrant {
+				t.Errorf("Lock() of a locked object expected an error")
+			}
+			if err := lock.Unlock(); err != nil {
+				t.Errorf("Unlock() error = %v", err)
+			}
+			lock, err = s.Lock("uuid/cluster.yaml")
+			if err != nil {
+				t.Fatalf("Lock() after unlock error = %v", err)
+			}
+			lock.Unlock()
+
+			if err := s.Delete("uuid/cluster.yaml"); err != nil {
+				t.Errorf("Delete() error = %v", err)
+			}
+			if err := s.Delete("uuid/cluster.yaml"); err != nil {
+				t.Errorf("Delete() of a missing object error = %v", err)
+			}
+			if _, err := s.Get("uuid/cluster.yaml"); err != ErrNotFound {
+				t.Errorf("Get() of a deleted object error = %v, want %v", err, ErrNotFound)
+			}
+		})
+	}
+}
+
+func TestS3_LockExpired(t *testing.T) {
+	s, cleanup := newTestS3(t)
+	defer cleanup()
+
+	if _, err := s.Lock("uuid/.apply"); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+
+	// the lock was abandoned, so it can be taken once it expires
+	s.LockTTL = time.Nanosecond
+	lock, err := s.Lock("uuid/.apply")
+	if err != nil {
+		t.Fatalf("Lock() of an expired lock error = %v", err)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Errorf("Unlock() error = %v", err)
+	}
+}
+
+func TestS3_LockConcurrent(t *testing.T) {
+	s, cleanup := newTestS3(t)
+	defer cleanup()
+
+	// many processes take the same lock, or the same expired lock, at same time
+	for _, expired := range []bool{false, true} {
+		if expired {
+			abandoned := fmt.Sprintf(`{"id":"abandoned","owner":"killed","created":%q}`, time.Now().Add(-2*DefaultLockTTL).Format(time.RFC3339))
+			if err := s.Put("uuid/.apply"+LockSuffix, []byte(abandoned)); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+		}
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		locks := []Unlocker{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if lock, err := s.Lock("uuid/.apply"); err == nil {
+					mu.Lock()
+					locks = append(locks, lock)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(locks) != 1 {
+			t.Fatalf("Lock() at same time, expired = %v, got %d locks, want 1", expired, len(locks))
+		}
+		if err := locks[0].Unlock(); err != nil {
+			t.Errorf("Unlock() error = %v", err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantName string
+		wantErr  bool
+	}{
+		{"local path", "/tmp/clusters", "/tmp/clusters", false},
+		{"file URL", "file:///tmp/clusters", "/tmp/clusters", false},
+		{"s3 URL", "s3://kubekit/team/clusters", "s3://kubekit/team/clusters", false},
+		{"s3 without bucket", "s3:///clusters", "", true},
+		{"unknown", "ftp://kubekit/clusters", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Name() != tt.wantName {
+				t.Errorf("New().Name() = %q, want %q", got.Name(), tt.wantName)
+			}
+		})
+	}
+}
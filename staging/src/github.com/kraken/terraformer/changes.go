@@ -0,0 +1,229 @@
+package terraformer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Values printed for the attributes which value is unknown until the changes
+// are applied or which value is sensitive
+const (
+	ComputedValue  = "(known after apply)"
+	SensitiveValue = "(sensitive)"
+)
+
+// ResourceChange is a planned change of a resource, with the changes of every
+// attribute
+type ResourceChange struct {
+	Address    string             `json:"address" yaml:"address"`
+	Mode       string             `json:"mode" yaml:"mode"`
+	Type       string             `json:"type" yaml:"type"`
+	Name       string             `json:"name" yaml:"name"`
+	Action     string             `json:"action" yaml:"action"`
+	Attributes []*AttributeChange `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// AttributeChange is a planned change of a resource attribute. The name is the
+// path to the attribute, like `tags.Name` or `ebs_block_device.0.volume_size`
+type AttributeChange struct {
+	Name              string `json:"name" yaml:"name"`
+	Before            string `json:"before,omitempty" yaml:"before,omitempty"`
+	After             string `json:"after,omitempty" yaml:"after,omitempty"`
+	Computed          bool   `json:"computed,omitempty" yaml:"computed,omitempty"`
+	Sensitive         bool   `json:"sensitive,omitempty" yaml:"sensitive,omitempty"`
+	ForcesReplacement bool   `json:"forces_replacement,omitempty" yaml:"forces_replacement,omitempty"`
+}
+
+// ActionName returns a name for the given plan action
+func ActionName(action plans.Action) string {
+	switch action {
+	case plans.Create:
+		return "create"
+	case plans.Update:
+		return "update"
+	case plans.DeleteThenCreate, plans.CreateThenDelete:
+		return "replace"
+	case plans.Delete:
+		return "delete"
+	case plans.Read:
+		return "read"
+	default:
+		return "no-op"
+	}
+}
+
+// IsDestructive returns true if the resource is going to be deleted or replaced
+func (rc *ResourceChange) IsDestructive() bool {
+	return rc.Mode == "managed" && (rc.Action == "delete" || rc.Action == "replace")
+}
+
+// Changes returns the changes of the resources in the last plan, with the
+// values of the attributes to change. Plan has to be called before. The
+// resources without changes are not included
+func (t *Terraformer) Changes() ([]*ResourceChange, error) {
+	if t.plan == nil || t.plan.Changes == nil {
+		return nil, fmt.Errorf("there is no plan, get the plan before the changes")
+	}
+
+	schemas := t.context.Schemas()
+
+	changes := []*ResourceChange{}
+	for _, rcs := range t.plan.Changes.Resources {
+		if rcs.Action == plans.NoOp {
+			continue
+		}
+
+		addr := rcs.Addr.Resource.Resource
+		rc := &ResourceChange{
+			Address: rcs.Addr.String(),
+			Mode:    "managed",
+			Type:    addr.Type,
+			Name:    addr.Name,
+			Action:  ActionName(rcs.Action),
+		}
+		if addr.Mode == addrs.DataResourceMode {
+			rc.Mode = "data"
+		}
+		changes = append(changes, rc)
+
+		// The attributes of the resources to delete are not printed
+		if rcs.Action == plans.Delete {
+			continue
+		}
+
+		schema, _ := schemas.ResourceTypeConfig(rcs.ProviderAddr.ProviderConfig.Type.Type, addr.Mode, addr.Type)
+		if schema == nil {
+			return nil, fmt.Errorf("not found the schema of the resource %s", rc.Address)
+		}
+		change, err := rcs.Decode(schema.ImpliedType())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the changes of the resource %s. %s", rc.Address, err)
+		}
+
+		rc.Attributes = attributesChanges(schema, change.Before, change.After, rcs.RequiredReplace)
+	}
+
+	return changes, nil
+}
+
+// attributesChanges returns the attributes with a different value before and
+// after the change
+func attributesChanges(schema *configschema.Block, before, after cty.Value, requiredReplace cty.PathSet) []*AttributeChange {
+	beforeValues := map[string]string{}
+	afterValues := map[string]string{}
+	computed := map[string]bool{}
+	flattenValue("", before, beforeValues, nil)
+	flattenValue("", after, afterValues, computed)
+
+	set := map[string]struct{}{}
+	for _, m := range []map[string]string{beforeValues, afterValues} {
+		for name := range m {
+			set[name] = struct{}{}
+		}
+	}
+	for name := range computed {
+		set[name] = struct{}{}
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attrs := []*AttributeChange{}
+	for _, name := range names {
+		beforeValue, afterValue := beforeValues[name], afterValues[name]
+		if beforeValue == afterValue && !computed[name] {
+			continue
+		}
+
+		attr := &AttributeChange{
+			Name:     name,
+			Before:   beforeValue,
+			After:    afterValue,
+			Computed: computed[name],
+		}
+		if computed[name] {
+			attr.After = ComputedValue
+		}
+
+		topName := topAttributeName(name)
+		if a, ok := schema.Attributes[topName]; ok && a.Sensitive {
+			attr.Sensitive = true
+			if len(attr.Before) != 0 {
+				attr.Before = SensitiveValue
+			}
+			if !attr.Computed && len(attr.After) != 0 {
+				attr.After = SensitiveValue
+			}
+		}
+		// the path set of the changes without replacements is a zero value
+		if !requiredReplace.Empty() {
+			attr.ForcesReplacement = requiredReplace.Has(cty.GetAttrPath(topName))
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	return attrs
+}
+
+// flattenValue stores in `values` the string value of every primitive value in
+// the given value, using the path to the value as key. If `computed` is not nil
+// the unknown values are stored in it
+func flattenValue(prefix string, v cty.Value, values map[string]string, computed map[string]bool) {
+	if v.IsNull() {
+		return
+	}
+	if !v.IsKnown() {
+		if computed != nil && len(prefix) != 0 {
+			computed[prefix] = true
+		}
+		return
+	}
+
+	join := func(key string) string {
+		if len(prefix) == 0 {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	ty := v.Type()
+	switch {
+	case ty.IsObjectType() || ty.IsMapType():
+		for it := v.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			flattenValue(join(k.AsString()), ev, values, computed)
+		}
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		i := 0
+		for it := v.ElementIterator(); it.Next(); i++ {
+			_, ev := it.Element()
+			flattenValue(join(strconv.Itoa(i)), ev, values, computed)
+		}
+	case ty == cty.String:
+		values[prefix] = v.AsString()
+	case ty == cty.Number:
+		values[prefix] = v.AsBigFloat().Text('f', -1)
+	case ty == cty.Bool:
+		values[prefix] = strconv.FormatBool(v.True())
+	default:
+		values[prefix] = fmt.Sprintf("%#v", v)
+	}
+}
+
+func topAttributeName(name string) string {
+	for i, c := range name {
+		if c == '.' {
+			return name[:i]
+		}
+	}
+	return name
+}
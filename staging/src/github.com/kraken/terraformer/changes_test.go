@@ -0,0 +1,145 @@
+package terraformer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/plans"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestActionName(t *testing.T) {
+	tests := []struct {
+		action plans.Action
+		want   string
+	}{
+		{plans.Create, "create"},
+		{plans.Update, "update"},
+		{plans.DeleteThenCreate, "replace"},
+		{plans.CreateThenDelete, "replace"},
+		{plans.Delete, "delete"},
+		{plans.Read, "read"},
+		{plans.NoOp, "no-op"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := ActionName(tt.action); got != tt.want {
+				t.Errorf("ActionName(%v) = %q, want %q", tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceChange_IsDestructive(t *testing.T) {
+	tests := []struct {
+		name string
+		rc   ResourceChange
+		want bool
+	}{
+		{"create", ResourceChange{Mode: "managed", Action: "create"}, false},
+		{"update", ResourceChange{Mode: "managed", Action: "update"}, false},
+		{"replace", ResourceChange{Mode: "managed", Action: "replace"}, true},
+		{"delete", ResourceChange{Mode: "managed", Action: "delete"}, true},
+		{"data source", ResourceChange{Mode: "data", Action: "delete"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rc.IsDestructive(); got != tt.want {
+				t.Errorf("IsDestructive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerraformer_ChangesWithoutPlan(t *testing.T) {
+	tf := &Terraformer{}
+	if _, err := tf.Changes(); err == nil {
+		t.Errorf("Changes() without a plan expected an error")
+	}
+}
+
+func Test_attributesChanges(t *testing.T) {
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id":            {Type: cty.String, Computed: true},
+			"ami":           {Type: cty.String, Required: true},
+			"instance_type": {Type: cty.String, Optional: true},
+			"password":      {Type: cty.String, Optional: true, Sensitive: true},
+			"count":         {Type: cty.Number, Optional: true},
+			"monitoring":    {Type: cty.Bool, Optional: true},
+			"tags":          {Type: cty.Map(cty.String), Optional: true},
+			"volumes":       {Type: cty.List(cty.String), Optional: true},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		before          cty.Value
+		after           cty.Value
+		requiredReplace cty.PathSet
+		want            []*AttributeChange
+	}{
+		{
+			name:   "no changes",
+			before: cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("i-1"), "ami": cty.StringVal("ami-1")}),
+			after:  cty.ObjectVal(map[string]cty.Value{"id": cty.StringVal("i-1"), "ami": cty.StringVal("ami-1")}),
+			want:   []*AttributeChange{},
+		},
+		{
+			name:   "create",
+			before: cty.NullVal(cty.Object(map[string]cty.Type{"id": cty.String, "ami": cty.String})),
+			after:  cty.ObjectVal(map[string]cty.Value{"id": cty.UnknownVal(cty.String), "ami": cty.StringVal("ami-1")}),
+			want: []*AttributeChange{
+				{Name: "ami", After: "ami-1"},
+				{Name: "id", After: ComputedValue, Computed: true},
+			},
+		},
+		{
+			name: "update primitives and collections",
+			before: cty.ObjectVal(map[string]cty.Value{
+				"count":      cty.NumberIntVal(2),
+				"monitoring": cty.False,
+				"tags":       cty.MapVal(map[string]cty.Value{"Name": cty.StringVal("kkdemo"), "Owner": cty.StringVal("ops")}),
+				"volumes":    cty.ListVal([]cty.Value{cty.StringVal("vol-1")}),
+			}),
+			after: cty.ObjectVal(map[string]cty.Value{
+				"count":      cty.NumberIntVal(3),
+				"monitoring": cty.True,
+				"tags":       cty.MapVal(map[string]cty.Value{"Name": cty.StringVal("kkdemo")}),
+				"volumes":    cty.ListVal([]cty.Value{cty.StringVal("vol-1"), cty.StringVal("vol-2")}),
+			}),
+			want: []*AttributeChange{
+				{Name: "count", Before: "2", After: "3"},
+				{Name: "monitoring", Before: "false", After: "true"},
+				{Name: "tags.Owner", Before: "ops"},
+				{Name: "volumes.1", After: "vol-2"},
+			},
+		},
+		{
+			name:            "sensitive and forces replacement",
+			before:          cty.ObjectVal(map[string]cty.Value{"ami": cty.StringVal("ami-1"), "password": cty.StringVal("old")}),
+			after:           cty.ObjectVal(map[string]cty.Value{"ami": cty.StringVal("ami-2"), "password": cty.StringVal("new")}),
+			requiredReplace: cty.NewPathSet(cty.GetAttrPath("ami")),
+			want: []*AttributeChange{
+				{Name: "ami", Before: "ami-1", After: "ami-2", ForcesReplacement: true},
+				{Name: "password", Before: SensitiveValue, After: SensitiveValue, Sensitive: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := attributesChanges(schema, tt.before, tt.after, tt.requiredReplace)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("attributesChanges() =")
+				for _, attr := range got {
+					t.Errorf("  %+v", *attr)
+				}
+				t.Errorf("want")
+				for _, attr := range tt.want {
+					t.Errorf("  %+v", *attr)
+				}
+			}
+		})
+	}
+}
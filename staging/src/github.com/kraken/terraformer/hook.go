@@ -3,6 +3,7 @@ package terraformer
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -388,3 +389,50 @@ func dropCR(data []byte) []byte {
 	}
 	return data
 }
+
+// Won't compile if HaltHook can't be realized by a Hook
+var _ Hook = (*HaltHook)(nil)
+
+// HaltHook makes Terraform halt when the given context is done. The resources
+// in progress are completed but no other resource is refreshed, planned or
+// applied. Terraform do not return an error when it's halted, so the context
+// error has to be checked after Apply or Plan
+type HaltHook struct {
+	terraform.NilHook
+
+	ctx context.Context
+}
+
+// NewHaltHook returns a new HaltHook for the given context
+func NewHaltHook(ctx context.Context) *HaltHook {
+	return &HaltHook{
+		ctx: ctx,
+	}
+}
+
+func (h *HaltHook) action() (terraform.HookAction, error) {
+	if h.ctx.Err() != nil {
+		return terraform.HookActionHalt, nil
+	}
+	return terraform.HookActionContinue, nil
+}
+
+// PreApply is called before a single resource is applied.
+func (h *HaltHook) PreApply(addr addrs.AbsResourceInstance, gen states.Generation, action plans.Action, priorState, plannedNewState cty.Value) (terraform.HookAction, error) {
+	return h.action()
+}
+
+// PreDiff is called before a single resource is diffed.
+func (h *HaltHook) PreDiff(addr addrs.AbsResourceInstance, gen states.Generation, priorState, proposedNewState cty.Value) (terraform.HookAction, error) {
+	return h.action()
+}
+
+// PreProvisionInstanceStep is called before a provisioner is executed.
+func (h *HaltHook) PreProvisionInstanceStep(addr addrs.AbsResourceInstance, typeName string) (terraform.HookAction, error) {
+	return h.action()
+}
+
+// PreRefresh is called before a single resource state is refreshed.
+func (h *HaltHook) PreRefresh(addr addrs.AbsResourceInstance, gen states.Generation, priorState cty.Value) (terraform.HookAction, error) {
+	return h.action()
+}
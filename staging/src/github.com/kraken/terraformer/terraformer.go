@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/addrs"
@@ -47,6 +48,7 @@ type Terraformer struct {
 	provisioners map[string]provisioners.Factory
 	context      *terraform.Context
 	stateMgr     statemgr.Writer
+	targets      []addrs.Targetable
 }
 
 // State is an alias for terraform.State
@@ -193,24 +195,150 @@ func (t *Terraformer) Apply(destroy bool) (err error) {
 	return nil
 }
 
-// Refresh refreshes state of the existig (or not) infrastructure.
+// ApplyTargets do apply the changes only to the given resources, like
+// `aws_instance.web[0]`, and the resources they depend on. It's the same as the
+// command `terraform apply -target=ADDRESS`. If destroy is 'true' will destroy
+// only the given resources and the resources depending on them.
+func (t *Terraformer) ApplyTargets(destroy bool, addresses ...string) error {
+	if len(addresses) == 0 {
+		return fmt.Errorf("at least one resource address is required to apply the changes to")
+	}
+
+	targets := make([]addrs.Targetable, 0, len(addresses))
+	for _, address := range addresses {
+		target, diags := addrs.ParseTargetStr(address)
+		if diags.HasErrors() {
+			return fmt.Errorf("invalid resource address %q. %s", address, diags.Err())
+		}
+		targets = append(targets, target.Subject)
+	}
+
+	t.targets = targets
+	defer func() { t.targets = nil }()
+	t.lw.Logger.Debugf("applying the changes to the resources %s", strings.Join(addresses, ", "))
+
+	return t.Apply(destroy)
+}
+
+// Import brings existing infrastructure under Terraform management. The
+// targets map the resource instance address, like `aws_instance.web[0]`, to the
+// ID of the existing resource. The imported resources are added to the State,
+// if the import fails the resources successfully imported are kept.
+func (t *Terraformer) Import(targets map[string]string) (err error) {
+	t.lw.SetLogOut()
+	defer t.lw.RestoreLogOut()
+
+	addresses := make([]string, 0, len(targets))
+	for address := range targets {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	importTargets := make([]*terraform.ImportTarget, 0, len(targets))
+	for _, address := range addresses {
+		addr, diags := addrs.ParseAbsResourceInstanceStr(address)
+		if diags.HasErrors() {
+			return fmt.Errorf("invalid resource address %q. %s", address, diags.Err())
+		}
+		importTargets = append(importTargets, &terraform.ImportTarget{
+			Addr:         addr,
+			ID:           targets[address],
+			ProviderAddr: addr.Resource.Resource.DefaultProviderConfig().Absolute(addrs.RootModuleInstance),
+		})
+	}
+
+	ctx, err := t.NewContext(false)
+	if err != nil {
+		return err
+	}
+	t.lw.Logger.Debugf("new context created and assigned")
+	t.context = ctx
+
+	t.lw.Logger.Infof("importing %d resources", len(importTargets))
+	state, diags := ctx.Import(&terraform.ImportOpts{
+		Targets: importTargets,
+	})
+	if state != nil {
+		t.State = state
+		if t.stateMgr != nil {
+			if errW := t.stateMgr.WriteState(state); errW != nil {
+				t.lw.Logger.Warnf("failed to persist the imported state. %s", errW)
+			}
+		}
+	}
+	if diags.HasErrors() {
+		return fmt.Errorf("error importing resources. The state has been partially updated with successfully imported resources. %s", diags.Err())
+	}
+
+	t.lw.Logger.Infof("import complete, %d resources imported", len(importTargets))
+	return nil
+}
+
+// Taint marks the given resource instances, like `aws_instance.web[0]`, as
+// tainted in the State, so they are destroyed and created again the next time
+// the changes are applied. It's the same as the command `terraform taint`.
+func (t *Terraformer) Taint(addresses ...string) error {
+	if t.State == nil || t.State.Empty() {
+		return fmt.Errorf("cannot taint resources of an empty state")
+	}
+
+	ss := t.State.SyncWrapper()
+	for _, address := range addresses {
+		addr, diags := addrs.ParseAbsResourceInstanceStr(address)
+		if diags.HasErrors() {
+			return fmt.Errorf("invalid resource address %q. %s", address, diags.Err())
+		}
+
+		rs := ss.Resource(addr.ContainingResource())
+		is := ss.ResourceInstance(addr)
+		if rs == nil || is == nil || is.Current == nil {
+			return fmt.Errorf("not found the resource instance %s in the state", address)
+		}
+
+		obj := is.Current
+		obj.Status = states.ObjectTainted
+		ss.SetResourceInstanceCurrent(addr, obj, rs.ProviderConfig)
+		t.lw.Logger.Infof("resource instance %s has been marked as tainted", address)
+	}
+
+	// the sync wrapper modifies the State in place
+	if t.stateMgr != nil {
+		if err := t.stateMgr.WriteState(t.State); err != nil {
+			return fmt.Errorf("failed to persist the tainted state. %s", err)
+		}
+	}
+
+	return nil
+}
+
+// Refresh refreshes state of the existig (or not) infrastructure. The State is
+// updated with the attributes of the existing resources, like new IP addresses,
+// but no change is applied to the infrastructure
 func (t *Terraformer) Refresh(destroy bool) (err error) {
 	// Do not set the log out before planning bc Plan also set it out and then
 	// restore it. So, this will cause the following lines to log as TF does.
 	t.lw.SetLogOut()
 	defer t.lw.RestoreLogOut()
 
-	ctx := t.context
-	if ctx != nil {
-		ctx, err = t.NewContext(destroy)
-		if err != nil {
-			return err
+	ctx, err := t.NewContext(destroy)
+	if err != nil {
+		return err
+	}
+	t.lw.Logger.Debugf("new context created and assigned")
+	t.context = ctx
+
+	if err := t.refresh(); err != nil {
+		return fmt.Errorf("error refreshing state. %s", err)
+	}
+
+	t.State = ctx.State()
+	if t.stateMgr != nil {
+		if err := t.stateMgr.WriteState(t.State); err != nil {
+			return fmt.Errorf("failed to persist the refreshed state. %s", err)
 		}
-		t.lw.Logger.Debugf("new context created and assigned")
-		t.context = ctx
 	}
 
-	return t.refresh()
+	return nil
 }
 
 func (t *Terraformer) refresh() error {
@@ -402,6 +530,7 @@ func (t *Terraformer) NewContext(destroy bool) (*terraform.Context, error) {
 		Hooks:            t.Hooks,
 		ProviderResolver: providers.ResolverFixed(t.providers),
 		Provisioners:     t.provisioners,
+		Targets:          t.targets,
 	}
 
 	ctx, diags := terraform.NewContext(&ctxOpts)
@@ -0,0 +1,66 @@
+package terraformer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/hashicorp/terraform/addrs"
+)
+
+const testCode = `
+resource "null_resource" "a" {}
+resource "null_resource" "b" {}
+`
+
+func resourceID(t *testing.T, tf *Terraformer, address string) string {
+	addr, diags := addrs.ParseAbsResourceInstanceStr(address)
+	if diags.HasErrors() {
+		t.Fatalf("invalid address %s. %s", address, diags.Err())
+	}
+	is := tf.State.ResourceInstance(addr)
+	if is == nil || is.Current == nil {
+		t.Fatalf("not found the resource %s in the state", address)
+	}
+	var attrs map[string]interface{}
+	if err := json.Unmarshal(is.Current.AttrsJSON, &attrs); err != nil {
+		t.Fatalf("failed to decode the attributes of %s. %s", address, err)
+	}
+	return attrs["id"].(string)
+}
+
+func TestTerraformer_ApplyTargets(t *testing.T) {
+	tf, err := New(NewLogger(ioutil.Discard, "TEST", DefLogLevel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tf.Code = []byte(testCode)
+
+	if err := tf.Apply(false); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	idA, idB := resourceID(t, tf, "null_resource.a"), resourceID(t, tf, "null_resource.b")
+
+	if err := tf.Taint("null_resource.a", "null_resource.b"); err != nil {
+		t.Fatalf("Taint() error = %v", err)
+	}
+	if err := tf.ApplyTargets(false, "null_resource.a"); err != nil {
+		t.Fatalf("ApplyTargets() error = %v", err)
+	}
+	if tf.Stats.Add != 1 || tf.Stats.Destroy != 1 {
+		t.Errorf("ApplyTargets() planned %d to add and %d to destroy, want 1 and 1", tf.Stats.Add, tf.Stats.Destroy)
+	}
+	if got := resourceID(t, tf, "null_resource.a"); got == idA {
+		t.Errorf("ApplyTargets() did not replace the target null_resource.a")
+	}
+	if got := resourceID(t, tf, "null_resource.b"); got != idB {
+		t.Errorf("ApplyTargets() replaced null_resource.b, not a target")
+	}
+
+	if err := tf.ApplyTargets(false); err == nil {
+		t.Errorf("ApplyTargets() expected an error without targets")
+	}
+	if err := tf.ApplyTargets(false, "null_resource."); err == nil {
+		t.Errorf("ApplyTargets() expected an error with an invalid address")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/johandry/log"
 )
@@ -24,13 +26,26 @@ type action struct {
 	state    string
 }
 
+// Notification is a task status reported by the UI to the subscribers
+type Notification struct {
+	Location string
+	Resource string
+	Action   TaskAction
+	Status   string
+	Done     bool
+	Time     time.Time
+}
+
 // UI handles the interactivity with the user
 type UI struct {
-	Tasks  *Tasks
-	Log    *log.Logger
-	scroll bool
-	lines  int
-	order  []string
+	Tasks       *Tasks
+	Log         *log.Logger
+	scroll      bool
+	lines       int
+	order       []string
+	mu          sync.Mutex
+	subscribers map[int]func(Notification)
+	nextSubID   int
 	// Out *Output
 	// cluster  string
 }
@@ -96,6 +111,7 @@ func (ui *UI) Print(task *Task, stdOut, logOut string) {
 		return
 	}
 	task.LastStatus = stdOut
+	ui.publish(task, stdOut)
 	if !isRegular(ui.Log.Out) {
 		return
 	}
@@ -109,6 +125,48 @@ func (ui *UI) Print(task *Task, stdOut, logOut string) {
 	// ui.output.Update(location, resource, strings.ToUpper(stdOut[:1])+stdOut[1:])
 }
 
+// Subscribe registers a function to receive every task status printed by this
+// UI. The returned function cancels the subscription
+func (ui *UI) Subscribe(fn func(Notification)) (unsubscribe func()) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	if ui.subscribers == nil {
+		ui.subscribers = make(map[int]func(Notification))
+	}
+	id := ui.nextSubID
+	ui.nextSubID++
+	ui.subscribers[id] = fn
+
+	return func() {
+		ui.mu.Lock()
+		defer ui.mu.Unlock()
+		delete(ui.subscribers, id)
+	}
+}
+
+// publish sends the task status to all the subscribers
+func (ui *UI) publish(task *Task, status string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	if len(ui.subscribers) == 0 {
+		return
+	}
+
+	n := Notification{
+		Location: task.Node,
+		Resource: task.Name,
+		Action:   task.Action,
+		Status:   status,
+		Done:     !task.EndTime.IsZero(),
+		Time:     time.Now(),
+	}
+	for _, fn := range ui.subscribers {
+		fn(n)
+	}
+}
+
 func isRegular(out io.Writer) bool {
 	file := out.(*os.File)
 	stat, err := file.Stat()
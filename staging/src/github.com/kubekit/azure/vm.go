@@ -2,6 +2,7 @@ package azure
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-03-01/compute"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -70,3 +71,27 @@ func UpdateVM(vmClient *compute.VirtualMachinesClient, resourceGroupName, vmName
 
 	return nil
 }
+
+// DeallocateVM stops and deallocates the specified VM, so it doesn't incur compute charges
+func DeallocateVM(vmClient *compute.VirtualMachinesClient, resourceGroupName, vmName string) error {
+	ctx := context.Background()
+
+	future, err := vmClient.Deallocate(ctx, resourceGroupName, vmName)
+	if err != nil {
+		return fmt.Errorf("cannot deallocate vm: %v", err)
+	}
+
+	return future.WaitForCompletionRef(ctx, vmClient.Client)
+}
+
+// StartVM starts the specified VM
+func StartVM(vmClient *compute.VirtualMachinesClient, resourceGroupName, vmName string) error {
+	ctx := context.Background()
+
+	future, err := vmClient.Start(ctx, resourceGroupName, vmName)
+	if err != nil {
+		return fmt.Errorf("cannot start vm: %v", err)
+	}
+
+	return future.WaitForCompletionRef(ctx, vmClient.Client)
+}
@@ -162,3 +162,29 @@ func ListVMSSNames(vmssClient *compute.VirtualMachineScaleSetsClient, resourceGr
 
 	return names, nil
 }
+
+// DeallocateVMSSWithContext stops and deallocates all the VMSS instances, so they don't incur compute charges
+func DeallocateVMSSWithContext(vmssClient *compute.VirtualMachineScaleSetsClient, ctx context.Context, resourceGroupName, vmssName string) error {
+	future, err := vmssClient.Deallocate(ctx, resourceGroupName, vmssName, nil)
+	if err != nil {
+		return fmt.Errorf("cannot deallocate vmss: %v", err)
+	}
+	if err := future.WaitForCompletionRef(ctx, vmssClient.Client); err != nil {
+		return fmt.Errorf("cannot get the vmss deallocate future response: %v", err)
+	}
+
+	return nil
+}
+
+// StartVMSSWithContext starts all the VMSS instances
+func StartVMSSWithContext(vmssClient *compute.VirtualMachineScaleSetsClient, ctx context.Context, resourceGroupName, vmssName string) error {
+	future, err := vmssClient.Start(ctx, resourceGroupName, vmssName, nil)
+	if err != nil {
+		return fmt.Errorf("cannot start vmss: %v", err)
+	}
+	if err := future.WaitForCompletionRef(ctx, vmssClient.Client); err != nil {
+		return fmt.Errorf("cannot get the vmss start future response: %v", err)
+	}
+
+	return nil
+}
@@ -84,6 +84,9 @@ func LongString() string {
 	if len(release.DockerVersion) != 0 {
 		fmt.Fprintf(&version, "\nDocker version: %s", release.DockerVersion)
 	}
+	if len(release.ContainerdVersion) != 0 {
+		fmt.Fprintf(&version, "\ncontainerd version: %s", release.ContainerdVersion)
+	}
 	if len(release.EtcdVersion) != 0 {
 		fmt.Fprintf(&version, "\netcd version: %s", release.EtcdVersion)
 	}